@@ -121,6 +121,16 @@ func (c *Client) requireWritableCatalogStore() error {
 	return nil
 }
 
+func (c *Client) requireOnline() error {
+	if c != nil && c.options != nil && c.options.offline {
+		return &errors.ConfigError{
+			Component: "catalog updates",
+			Message:   "client is offline; catalog updates are disabled",
+		}
+	}
+	return nil
+}
+
 func snapshotBuilder(builder *catalogs.Builder) (*catalogs.Catalog, error) {
 	if builder == nil {
 		return nil, &errors.ValidationError{
@@ -5,12 +5,10 @@ package app
 
 import (
 	"context"
-	"os"
-	"path/filepath"
-	"strings"
 	"sync"
 
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 
 	"github.com/agentstation/starmap"
 	"github.com/agentstation/starmap/internal/application"
@@ -107,6 +105,17 @@ func (a *App) Config() *Config {
 	return a.config
 }
 
+// EffectiveConfig returns the config file in use, the selected profile, and
+// every setting after merging the profile, environment, and defaults.
+func (a *App) EffectiveConfig() (file, profile string, settings map[string]any) {
+	return a.config.ConfigFile, a.config.Profile, viper.AllSettings()
+}
+
+// LogOutput returns where logs are written: stderr, stdout, or a file path.
+func (a *App) LogOutput() string {
+	return a.config.LogOutput
+}
+
 // Logger returns the application logger.
 func (a *App) Logger() *zerolog.Logger {
 	return a.logger
@@ -246,6 +255,15 @@ func (a *App) buildStarmapOptions(storeOption starmap.Option) ([]starmap.Option,
 	if a.config.UseEmbeddedCatalog {
 		opts = append(opts, starmap.WithEmbeddedCatalog())
 	}
+	if a.config.ReviewNewModels {
+		opts = append(opts, starmap.WithModelReview())
+	}
+	if a.config.ReconciliationStrategy != "" {
+		opts = append(opts, starmap.WithReconciliationStrategy(a.config.ReconciliationStrategy))
+	}
+	if !a.config.DiffRules.IsZero() {
+		opts = append(opts, starmap.WithDiffRules(a.config.DiffRules))
+	}
 	if a.config.EmbeddedBootstrapMaxAge > 0 {
 		opts = append(opts, starmap.WithEmbeddedBootstrapMaxAge(a.config.EmbeddedBootstrapMaxAge))
 	}
@@ -286,20 +304,6 @@ func (a *App) catalogStoreOption() (starmap.Option, error) {
 	return starmap.WithCatalogStore(store), nil
 }
 
-func expandHomePath(path string) (string, error) {
-	if path != "~" && !strings.HasPrefix(path, "~/") {
-		return path, nil
-	}
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", errors.WrapResource("resolve", "home directory", path, err)
-	}
-	if path == "~" {
-		return home, nil
-	}
-	return filepath.Join(home, strings.TrimPrefix(path, "~/")), nil
-}
-
 // Option is a functional option for configuring the App.
 type Option func(*App) error
 
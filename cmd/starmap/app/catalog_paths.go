@@ -1,13 +1,16 @@
 package app
 
-import "github.com/agentstation/starmap/pkg/constants"
+import (
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/constants"
+)
 
 func (a *App) catalogDatabasePath() (string, error) {
 	path := a.config.CatalogPath
 	if path == "" {
 		path = constants.DefaultCatalogDatabasePath
 	}
-	return expandHomePath(path)
+	return paths.ResolveHome(path)
 }
 
 // CatalogExportPath returns the configured editable catalog export path, or the
@@ -17,12 +20,12 @@ func (a *App) CatalogExportPath() (string, error) {
 	if path == "" {
 		path = constants.DefaultCatalogExportPath
 	}
-	return expandHomePath(path)
+	return paths.ResolveHome(path)
 }
 
 func (a *App) configuredCatalogExportPath() (string, error) {
 	if a.config.CatalogExportPath == "" {
 		return "", nil
 	}
-	return expandHomePath(a.config.CatalogExportPath)
+	return paths.ResolveHome(a.config.CatalogExportPath)
 }
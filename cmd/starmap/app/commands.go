@@ -5,16 +5,43 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/agentstation/starmap/cmd/starmap/cmd/agreement"
 	"github.com/agentstation/starmap/cmd/starmap/cmd/auth"
 	"github.com/agentstation/starmap/cmd/starmap/cmd/authors"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/bugreport"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/catalogfmt"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/compare"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/compareproviders"
 	"github.com/agentstation/starmap/cmd/starmap/cmd/completion"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/contribute"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/cost"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/coverage"
 	"github.com/agentstation/starmap/cmd/starmap/cmd/deps"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/diff"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/docs"
 	"github.com/agentstation/starmap/cmd/starmap/cmd/embed"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/export"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/federate"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/initialize"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/migrate"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/mirror"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/mock"
 	"github.com/agentstation/starmap/cmd/starmap/cmd/models"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/naming"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/pricing"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/provenance"
 	"github.com/agentstation/starmap/cmd/starmap/cmd/providers"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/quota"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/review"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/scrape"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/selfupdate"
 	"github.com/agentstation/starmap/cmd/starmap/cmd/serve"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/simulate"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/tag"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/telemetry"
 	"github.com/agentstation/starmap/cmd/starmap/cmd/update"
 	"github.com/agentstation/starmap/cmd/starmap/cmd/validate"
+	"github.com/agentstation/starmap/cmd/starmap/cmd/views"
 )
 
 // NewProvidersCommand returns a new providers command with app dependencies.
@@ -27,21 +54,151 @@ func (a *App) NewModelsCommand() *cobra.Command {
 	return models.NewCommand(a)
 }
 
+// NewProvenanceCommand returns a new provenance command with app dependencies.
+func (a *App) NewProvenanceCommand() *cobra.Command {
+	return provenance.NewCommand(a)
+}
+
+// NewPricingCommand returns a new pricing command with app dependencies.
+func (a *App) NewPricingCommand() *cobra.Command {
+	return pricing.NewCommand(a)
+}
+
+// NewCostCommand returns a new cost command with app dependencies.
+func (a *App) NewCostCommand() *cobra.Command {
+	return cost.NewCommand(a)
+}
+
+// NewExportCommand returns a new export command with app dependencies.
+func (a *App) NewExportCommand() *cobra.Command {
+	return export.NewCommand(a)
+}
+
+// NewAgreementCommand returns a new agreement command with app dependencies.
+func (a *App) NewAgreementCommand() *cobra.Command {
+	return agreement.NewCommand(a)
+}
+
+// NewCoverageCommand returns a new coverage command with app dependencies.
+func (a *App) NewCoverageCommand() *cobra.Command {
+	return coverage.NewCommand(a)
+}
+
+// NewNamingCommand returns a new naming command with app dependencies.
+func (a *App) NewNamingCommand() *cobra.Command {
+	return naming.NewCommand(a)
+}
+
+// NewContributeCommand returns a new contribute command with app dependencies.
+func (a *App) NewContributeCommand() *cobra.Command {
+	return contribute.NewCommand(a)
+}
+
+// NewDocsCommand returns a new docs command with app dependencies.
+func (a *App) NewDocsCommand() *cobra.Command {
+	return docs.NewCommand(a)
+}
+
+// NewFmtCommand returns a new fmt command with app dependencies.
+func (a *App) NewFmtCommand() *cobra.Command {
+	return catalogfmt.NewCommand(a)
+}
+
+// NewScrapeCommand returns a new scrape command with app dependencies.
+func (a *App) NewScrapeCommand() *cobra.Command {
+	return scrape.NewCommand(a)
+}
+
 // NewAuthorsCommand returns a new authors command with app dependencies.
 func (a *App) NewAuthorsCommand() *cobra.Command {
 	return authors.NewCommand(a)
 }
 
+// NewCompareCommand returns a new compare command with app dependencies.
+func (a *App) NewCompareCommand() *cobra.Command {
+	return compare.NewCommand(a)
+}
+
+// NewCompareProvidersCommand returns a new compare-providers command with app dependencies.
+func (a *App) NewCompareProvidersCommand() *cobra.Command {
+	return compareproviders.NewCommand(a)
+}
+
+// NewDiffCommand returns a new diff command with app dependencies.
+func (a *App) NewDiffCommand() *cobra.Command {
+	return diff.NewCommand(a)
+}
+
 // NewUpdateCommand returns a new update command with app dependencies.
 func (a *App) NewUpdateCommand() *cobra.Command {
 	return update.NewCommand(a)
 }
 
+// NewFederateCommand returns a new federate command with app dependencies.
+func (a *App) NewFederateCommand() *cobra.Command {
+	return federate.NewCommand(a)
+}
+
+// NewMigrateCommand returns a new migrate command with app dependencies.
+func (a *App) NewMigrateCommand() *cobra.Command {
+	return migrate.NewCommand(a)
+}
+
+// NewMirrorCommand returns a new mirror command with app dependencies.
+func (a *App) NewMirrorCommand() *cobra.Command {
+	return mirror.NewCommand(a)
+}
+
+// NewTagCommand returns a new tag command with app dependencies.
+func (a *App) NewTagCommand() *cobra.Command {
+	return tag.NewCommand(a)
+}
+
+// NewReviewCommand returns a new review command with app dependencies.
+func (a *App) NewReviewCommand() *cobra.Command {
+	return review.NewCommand(a)
+}
+
+// NewViewsCommand returns a new views command with app dependencies.
+func (a *App) NewViewsCommand() *cobra.Command {
+	return views.NewCommand(a)
+}
+
+// NewQuotaCommand returns a new quota command with app dependencies.
+func (a *App) NewQuotaCommand() *cobra.Command {
+	return quota.NewCommand(a)
+}
+
+// NewSimulateCommand returns a new simulate command with app dependencies.
+func (a *App) NewSimulateCommand() *cobra.Command {
+	return simulate.NewCommand(a)
+}
+
+// NewSelfUpdateCommand returns a new self-update command with app dependencies.
+func (a *App) NewSelfUpdateCommand() *cobra.Command {
+	return selfupdate.NewCommand(a)
+}
+
+// NewTelemetryCommand returns a new telemetry command with app dependencies.
+func (a *App) NewTelemetryCommand() *cobra.Command {
+	return telemetry.NewCommand(a)
+}
+
+// NewBugReportCommand returns a new bugreport command with app dependencies.
+func (a *App) NewBugReportCommand() *cobra.Command {
+	return bugreport.NewCommand(a)
+}
+
 // NewServeCommand returns a new serve command with app dependencies.
 func (a *App) NewServeCommand() *cobra.Command {
 	return serve.NewCommand(a)
 }
 
+// NewMockCommand returns a new mock command with app dependencies.
+func (a *App) NewMockCommand() *cobra.Command {
+	return mock.NewCommand(a)
+}
+
 // NewValidateCommand returns a new validate command with app dependencies.
 func (a *App) NewValidateCommand() *cobra.Command {
 	return validate.NewCommand(a)
@@ -76,6 +233,11 @@ func (a *App) NewDepsCommand() *cobra.Command {
 	return deps.NewCommand()
 }
 
+// NewInitCommand returns a new init command with app dependencies.
+func (a *App) NewInitCommand() *cobra.Command {
+	return initialize.NewCommand(a)
+}
+
 // NewAuthCommand returns a new auth command with app dependencies.
 func (a *App) NewAuthCommand() *cobra.Command {
 	return auth.NewCommand()
@@ -9,6 +9,9 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
+
+	"github.com/agentstation/starmap/internal/cli/locale"
+	"github.com/agentstation/starmap/pkg/differ"
 )
 
 // Config holds the application configuration loaded from various sources
@@ -18,10 +21,16 @@ type Config struct {
 	Verbose bool
 	Quiet   bool
 	NoColor bool
+	NoEmoji bool
 	Output  string
+	// Locale formats numbers, prices, and dates in table output, such as
+	// de-DE. Empty uses the LC_ALL, LC_NUMERIC, or LANG environment variable.
+	Locale string
 
 	// Config file
 	ConfigFile string
+	// Profile names the config file profile merged over the top-level settings.
+	Profile string
 
 	// Starmap configuration
 	// CatalogExportPath is an optional editable YAML import/export tree.
@@ -34,6 +43,18 @@ type Config struct {
 	RemoteServerURL               string
 	RemoteServerAPIKey            string
 	RemoteServerOnly              bool
+	// ReviewNewModels holds models first discovered by sync in pending-review.
+	ReviewNewModels bool
+	// ReconciliationStrategy names the registered conflict resolution strategy.
+	ReconciliationStrategy string
+	// DiffRules are the change detection tolerance and ignore rules.
+	DiffRules differ.Rules
+	// Credentials are environment variables, such as provider API keys, set
+	// for the process when the environment does not already define them.
+	Credentials map[string]string
+	// Commands holds flag defaults keyed by command path (e.g., "update",
+	// "serve"). Flags given on the command line take precedence.
+	Commands map[string]map[string]any
 
 	// Logging configuration
 	LogLevel  string
@@ -45,9 +66,17 @@ type Config struct {
 // 1. Command-line flags (handled by cobra)
 // 2. Environment variables
 // 3. .env files
-// 4. Config file (~/.starmap/config.yaml)
-// 5. Defaults.
+// 4. Config file profile (--profile, STARMAP_PROFILE, or profile)
+// 5. Config file (~/.starmap/config.yaml)
+// 6. Defaults.
 func LoadConfig() (*Config, error) {
+	return loadConfig("", "")
+}
+
+// loadConfig loads configuration from configFile and merges the named
+// profile over it. Empty arguments fall back to the environment and the
+// config file itself.
+func loadConfig(configFile, profile string) (*Config, error) {
 	// Load .env files first (before Viper env binding)
 	loadEnvFiles()
 	viper.Reset()
@@ -61,9 +90,12 @@ func LoadConfig() (*Config, error) {
 
 	// Bind common API keys
 	bindAPIKeys()
+	_ = viper.BindEnv("profile", "STARMAP_PROFILE")
 
 	// Try to read config file if it exists
-	configFile := viper.GetString("config")
+	if configFile == "" {
+		configFile = viper.GetString("config")
+	}
 	if configFile != "" {
 		viper.SetConfigFile(configFile)
 	} else {
@@ -79,6 +111,14 @@ func LoadConfig() (*Config, error) {
 	if err := viper.ReadInConfig(); err == nil {
 		configFileUsed = viper.ConfigFileUsed()
 	}
+	if profile == "" {
+		profile = viper.GetString("profile")
+	}
+	if profile != "" {
+		if err := mergeProfile(profile, configFileUsed); err != nil {
+			return nil, err
+		}
+	}
 
 	// Build config from viper
 	config := &Config{
@@ -86,10 +126,13 @@ func LoadConfig() (*Config, error) {
 		Verbose: viper.GetBool("verbose"),
 		Quiet:   viper.GetBool("quiet"),
 		NoColor: viper.GetBool("no-color"),
+		NoEmoji: viper.GetBool("no-emoji"),
 		Output:  viper.GetString("output"),
+		Locale:  viper.GetString("locale"),
 
 		// Config file
 		ConfigFile: configFileUsed,
+		Profile:    profile,
 
 		// Starmap configuration
 		CatalogExportPath:             viper.GetString("catalog_export_path"),
@@ -100,6 +143,15 @@ func LoadConfig() (*Config, error) {
 		RemoteServerURL:               viper.GetString("remote_server_url"),
 		RemoteServerAPIKey:            viper.GetString("remote_server_api_key"),
 		RemoteServerOnly:              viper.GetBool("remote_server_only"),
+		ReviewNewModels:               viper.GetBool("review_new_models"),
+		ReconciliationStrategy:        viper.GetString("reconciliation_strategy"),
+		DiffRules: differ.Rules{
+			PriceTolerance:      viper.GetFloat64("diff_price_tolerance"),
+			IgnoreTimestampOnly: viper.GetBool("diff_ignore_timestamp_only"),
+			IgnoreFields:        viper.GetStringSlice("diff_ignore_fields"),
+		},
+		Credentials: credentials(),
+		Commands:    commandDefaults(),
 
 		// Logging configuration
 		// LogLevel: empty string means "use precedence logic" (see logger.go)
@@ -127,6 +179,21 @@ func (c *Config) UpdateFromFlags(verbose, quiet, noColor bool, output, logLevel
 	}
 }
 
+// applyLocale sets the locale of table output from Locale, falling back to
+// the locale environment variables when it is empty.
+func (c *Config) applyLocale() error {
+	if c.Locale == "" {
+		locale.Set(locale.Detect())
+		return nil
+	}
+	tag, err := locale.Parse(c.Locale)
+	if err != nil {
+		return err
+	}
+	locale.Set(tag)
+	return nil
+}
+
 // loadEnvFiles loads environment variables from .env files.
 func loadEnvFiles() {
 	// Try to load .env files in order of precedence
@@ -3,8 +3,11 @@ package app
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/cli/emoji"
 )
 
 // Execute runs the starmap CLI application with the given arguments.
@@ -17,7 +20,10 @@ func (a *App) Execute(ctx context.Context, args []string) error {
 	rootCmd.SetArgs(args)
 
 	// Execute with context
-	return rootCmd.ExecuteContext(ctx)
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	a.reportTelemetry(ctx, cmd, time.Since(start), err)
+	return err
 }
 
 // createRootCommand creates the root cobra command with all subcommands.
@@ -60,11 +66,14 @@ when API keys are configured.`,
 
 	// Add global flags
 	rootCmd.PersistentFlags().StringVar(&a.config.ConfigFile, "config", "", "config file (default is $HOME/.starmap/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&a.config.Profile, "profile", a.config.Profile, "config file profile to use (or set STARMAP_PROFILE)")
 	rootCmd.PersistentFlags().BoolVarP(&a.config.Verbose, "verbose", "v", false, "verbose output (shortcut for --log-level=debug)")
 	rootCmd.PersistentFlags().BoolVarP(&a.config.Quiet, "quiet", "q", false, "minimal output (shortcut for --log-level=warn)")
 	rootCmd.PersistentFlags().BoolVar(&a.config.NoColor, "no-color", false, "disable colored output")
+	rootCmd.PersistentFlags().BoolVar(&a.config.NoEmoji, "no-emoji", a.config.NoEmoji, "replace status symbols with text labels (or set STARMAP_NO_EMOJI)")
 	// Use -o for output (not -f) to avoid conflict with embed cat --filename
 	rootCmd.PersistentFlags().StringVarP(&a.config.Output, "output", "o", "", "output format: table, json, yaml, wide")
+	rootCmd.PersistentFlags().StringVar(&a.config.Locale, "locale", a.config.Locale, "locale for numbers, prices, and dates in tables, e.g. de-DE (default from LANG)")
 	rootCmd.PersistentFlags().StringVar(&a.config.LogLevel, "log-level", "", "log level: trace, debug, info, warn, error (overrides -v/-q)")
 
 	// Add --format and --fmt as aliases for --output
@@ -82,6 +91,16 @@ when API keys are configured.`,
 
 // setupCommand is called before any command runs.
 func (a *App) setupCommand(cmd *cobra.Command, _ []string) error {
+	// The config was loaded before flags were parsed, so reload it when the
+	// flags select a different file or profile.
+	if cmd.Flags().Changed("config") || cmd.Flags().Changed("profile") {
+		config, err := loadConfig(mustGetString(cmd, "config"), mustGetString(cmd, "profile"))
+		if err != nil {
+			return err
+		}
+		*a.config = *config
+	}
+
 	// Update config from parsed flags
 	// These flags are defined as persistent flags in createRootCommand, so errors indicate programming errors
 	verbose := mustGetBool(cmd, "verbose")
@@ -91,6 +110,22 @@ func (a *App) setupCommand(cmd *cobra.Command, _ []string) error {
 	logLevel := mustGetString(cmd, "log-level")
 
 	a.config.UpdateFromFlags(verbose, quiet, noColor, output, logLevel)
+	if cmd.Flags().Changed("locale") {
+		a.config.Locale = mustGetString(cmd, "locale")
+	}
+	if err := a.config.applyLocale(); err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("no-emoji") {
+		a.config.NoEmoji = mustGetBool(cmd, "no-emoji")
+	}
+	emoji.SetPlain(a.config.NoEmoji || emoji.PlainRequested())
+	if err := a.config.applyCommandDefaults(cmd); err != nil {
+		return err
+	}
+	if err := a.config.applyCredentials(); err != nil {
+		return err
+	}
 
 	// Reinitialize logger with updated config
 	logger := NewLogger(a.config)
@@ -103,20 +138,47 @@ func (a *App) setupCommand(cmd *cobra.Command, _ []string) error {
 // This is where we wire up all the command handlers.
 func (a *App) registerCommands(rootCmd *cobra.Command) {
 	// Setup commands (getting started)
+	rootCmd.AddCommand(a.NewInitCommand())
 	rootCmd.AddCommand(a.NewDepsCommand())
 	rootCmd.AddCommand(a.NewAuthCommand())
+	rootCmd.AddCommand(a.NewSelfUpdateCommand())
+	rootCmd.AddCommand(a.NewTelemetryCommand())
+	rootCmd.AddCommand(a.NewBugReportCommand())
 
 	// Catalog commands (working with models/providers)
 	rootCmd.AddCommand(a.NewProvidersCommand())
 	rootCmd.AddCommand(a.NewModelsCommand())
+	rootCmd.AddCommand(a.NewProvenanceCommand())
+	rootCmd.AddCommand(a.NewPricingCommand())
+	rootCmd.AddCommand(a.NewCostCommand())
+	rootCmd.AddCommand(a.NewExportCommand())
+	rootCmd.AddCommand(a.NewScrapeCommand())
+	rootCmd.AddCommand(a.NewAgreementCommand())
+	rootCmd.AddCommand(a.NewCoverageCommand())
+	rootCmd.AddCommand(a.NewNamingCommand())
+	rootCmd.AddCommand(a.NewContributeCommand())
 	rootCmd.AddCommand(a.NewAuthorsCommand())
+	rootCmd.AddCommand(a.NewCompareCommand())
+	rootCmd.AddCommand(a.NewCompareProvidersCommand())
 	rootCmd.AddCommand(a.NewUpdateCommand())
+	rootCmd.AddCommand(a.NewDiffCommand())
+	rootCmd.AddCommand(a.NewFederateCommand())
+	rootCmd.AddCommand(a.NewMigrateCommand())
+	rootCmd.AddCommand(a.NewMirrorCommand())
+	rootCmd.AddCommand(a.NewTagCommand())
+	rootCmd.AddCommand(a.NewReviewCommand())
+	rootCmd.AddCommand(a.NewViewsCommand())
+	rootCmd.AddCommand(a.NewQuotaCommand())
+	rootCmd.AddCommand(a.NewSimulateCommand())
 
 	// Server commands (running the API)
 	rootCmd.AddCommand(a.NewServeCommand())
+	rootCmd.AddCommand(a.NewMockCommand())
 
 	// Development commands (debugging and exploration)
 	rootCmd.AddCommand(a.NewValidateCommand())
+	rootCmd.AddCommand(a.NewFmtCommand())
+	rootCmd.AddCommand(a.NewDocsCommand())
 	rootCmd.AddCommand(a.NewEmbedCommand())
 
 	// Additional commands (no group)
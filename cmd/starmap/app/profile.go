@@ -0,0 +1,133 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// mergeProfile merges the settings of the named profile over the top-level
+// config file settings. Environment variables and flags still take
+// precedence over both.
+func mergeProfile(name, configFile string) error {
+	key := "profiles." + name
+	if !viper.IsSet(key) {
+		source := configFile
+		if source == "" {
+			source = "the config file"
+		}
+		return &errors.ConfigError{
+			Component: "profile",
+			Message:   fmt.Sprintf("profile %q is not defined in %s", name, source),
+		}
+	}
+	if err := viper.MergeConfigMap(viper.GetStringMap(key)); err != nil {
+		return &errors.ConfigError{
+			Component: "profile",
+			Message:   fmt.Sprintf("merge profile %q", name),
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+// credentials returns the configured credentials keyed by environment
+// variable name. Config keys are case-insensitive, so names are upper-cased.
+func credentials() map[string]string {
+	configured := viper.GetStringMapString("credentials")
+	if len(configured) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(configured))
+	for name, value := range configured {
+		result[strings.ToUpper(name)] = value
+	}
+	return result
+}
+
+// commandDefaults returns the configured flag defaults keyed by command path.
+func commandDefaults() map[string]map[string]any {
+	configured := viper.GetStringMap("commands")
+	if len(configured) == 0 {
+		return nil
+	}
+	result := make(map[string]map[string]any, len(configured))
+	for path := range configured {
+		result[path] = viper.GetStringMap("commands." + path)
+	}
+	return result
+}
+
+// applyCredentials exports the configured credentials that the environment
+// does not already define.
+func (c *Config) applyCredentials() error {
+	for name, value := range c.Credentials {
+		if _, ok := os.LookupEnv(name); ok {
+			continue
+		}
+		if err := os.Setenv(name, value); err != nil {
+			return &errors.ConfigError{
+				Component: "credentials",
+				Message:   fmt.Sprintf("set %s", name),
+				Err:       err,
+			}
+		}
+	}
+	return nil
+}
+
+// applyCommandDefaults sets the configured defaults for the flags of cmd
+// that were not given on the command line.
+func (c *Config) applyCommandDefaults(cmd *cobra.Command) error {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	defaults := c.Commands[path]
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			return &errors.ConfigError{
+				Component: "commands." + path,
+				Message:   fmt.Sprintf("unknown flag --%s", name),
+			}
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := setFlag(flag, defaults[name]); err != nil {
+			return &errors.ConfigError{
+				Component: "commands." + path,
+				Message:   fmt.Sprintf("invalid value for --%s", name),
+				Err:       err,
+			}
+		}
+	}
+	return nil
+}
+
+// setFlag sets flag from a config value. Lists replace slice flag values.
+func setFlag(flag *pflag.Flag, value any) error {
+	values, ok := value.([]any)
+	if !ok {
+		return flag.Value.Set(fmt.Sprint(value))
+	}
+	items := make([]string, len(values))
+	for i, item := range values {
+		items[i] = fmt.Sprint(item)
+	}
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		return slice.Replace(items)
+	}
+	return flag.Value.Set(strings.Join(items, ","))
+}
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const profileConfig = `catalog_path: /srv/starmap/default
+credentials:
+  STARMAP_TEST_SHARED_KEY: shared
+commands:
+  serve:
+    host: 0.0.0.0
+profiles:
+  prod:
+    catalog_path: /srv/starmap/prod
+    catalog_export_path: /srv/starmap/prod-export
+    credentials:
+      STARMAP_TEST_PROVIDER_KEY: prod-key
+    commands:
+      update:
+        source: provider-api
+      serve:
+        port: 9090
+        cors-origins: [https://a.example.com, https://b.example.com]
+  staging:
+    catalog_path: /srv/starmap/staging
+`
+
+func writeProfileConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(profileConfig), constants.FilePermissions); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigMergesProfile(t *testing.T) {
+	path := writeProfileConfig(t)
+
+	config, err := loadConfig(path, "prod")
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if config.Profile != "prod" || config.CatalogPath != "/srv/starmap/prod" || config.CatalogExportPath != "/srv/starmap/prod-export" {
+		t.Fatalf("config = profile %q catalog %q export %q, want prod paths", config.Profile, config.CatalogPath, config.CatalogExportPath)
+	}
+	if config.Credentials["STARMAP_TEST_SHARED_KEY"] != "shared" || config.Credentials["STARMAP_TEST_PROVIDER_KEY"] != "prod-key" {
+		t.Fatalf("credentials = %#v, want shared and prod keys", config.Credentials)
+	}
+	if config.Commands["serve"]["host"] != "0.0.0.0" || config.Commands["serve"]["port"] != 9090 {
+		t.Fatalf("serve defaults = %#v, want top-level host and profile port", config.Commands["serve"])
+	}
+
+	staging, err := loadConfig(path, "staging")
+	if err != nil {
+		t.Fatalf("loadConfig staging: %v", err)
+	}
+	if staging.CatalogPath != "/srv/starmap/staging" || staging.Credentials["STARMAP_TEST_PROVIDER_KEY"] != "" {
+		t.Fatalf("staging = catalog %q credentials %#v, want staging without prod credentials", staging.CatalogPath, staging.Credentials)
+	}
+}
+
+func TestLoadConfigSelectsProfileFromEnvironment(t *testing.T) {
+	path := writeProfileConfig(t)
+	t.Setenv("STARMAP_PROFILE", "staging")
+
+	config, err := loadConfig(path, "")
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if config.Profile != "staging" || config.CatalogPath != "/srv/starmap/staging" {
+		t.Fatalf("config = profile %q catalog %q, want staging", config.Profile, config.CatalogPath)
+	}
+}
+
+func TestLoadConfigRejectsUnknownProfile(t *testing.T) {
+	_, err := loadConfig(writeProfileConfig(t), "qa")
+	var configErr *errors.ConfigError
+	if !stderrors.As(err, &configErr) || configErr.Component != "profile" {
+		t.Fatalf("error = %v, want profile ConfigError", err)
+	}
+}
+
+func TestApplyCommandDefaultsKeepsExplicitFlags(t *testing.T) {
+	root := &cobra.Command{Use: "starmap"}
+	serve := &cobra.Command{Use: "serve", RunE: func(*cobra.Command, []string) error { return nil }}
+	serve.Flags().Int("port", 8080, "")
+	serve.Flags().String("host", "localhost", "")
+	serve.Flags().StringSlice("cors-origins", nil, "")
+	root.AddCommand(serve)
+	if err := serve.ParseFlags([]string{"--host", "127.0.0.1"}); err != nil {
+		t.Fatalf("ParseFlags: %v", err)
+	}
+
+	config := &Config{Commands: map[string]map[string]any{
+		"serve": {"port": 9090, "host": "0.0.0.0", "cors-origins": []any{"https://a.example.com", "https://b.example.com"}},
+	}}
+	if err := config.applyCommandDefaults(serve); err != nil {
+		t.Fatalf("applyCommandDefaults: %v", err)
+	}
+	if port, _ := serve.Flags().GetInt("port"); port != 9090 {
+		t.Errorf("port = %d, want configured 9090", port)
+	}
+	if host, _ := serve.Flags().GetString("host"); host != "127.0.0.1" {
+		t.Errorf("host = %q, want explicit 127.0.0.1", host)
+	}
+	if origins, _ := serve.Flags().GetStringSlice("cors-origins"); len(origins) != 2 {
+		t.Errorf("cors-origins = %v, want two configured origins", origins)
+	}
+
+	config.Commands["serve"]["prot"] = 1
+	var configErr *errors.ConfigError
+	if err := config.applyCommandDefaults(serve); !stderrors.As(err, &configErr) {
+		t.Fatalf("error = %v, want ConfigError for unknown flag", err)
+	}
+}
+
+func TestExecuteReloadsConfigForProfileFlag(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CONFIG", "")
+	path := writeProfileConfig(t)
+	app, err := New("test", "none", "unknown", "test")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Unsetenv("STARMAP_TEST_SHARED_KEY")
+		_ = os.Unsetenv("STARMAP_TEST_PROVIDER_KEY")
+	})
+	t.Setenv("STARMAP_TEST_SHARED_KEY", "from-environment")
+
+	if err := app.Execute(context.Background(), []string{"--config", path, "--profile", "prod", "version", "-o", "json"}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if app.Config().CatalogPath != "/srv/starmap/prod" {
+		t.Fatalf("CatalogPath = %q, want prod profile path", app.Config().CatalogPath)
+	}
+	if got := os.Getenv("STARMAP_TEST_PROVIDER_KEY"); got != "prod-key" {
+		t.Fatalf("STARMAP_TEST_PROVIDER_KEY = %q, want profile credential", got)
+	}
+	if got := os.Getenv("STARMAP_TEST_SHARED_KEY"); got != "from-environment" {
+		t.Fatalf("STARMAP_TEST_SHARED_KEY = %q, want environment to take precedence", got)
+	}
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/agentstation/starmap/internal/telemetry"
+)
+
+// reportTelemetry sends the usage event of cmd when the user has opted in.
+// Failures are logged at debug level and never change the command result.
+func (a *App) reportTelemetry(ctx context.Context, cmd *cobra.Command, duration time.Duration, err error) {
+	if cmd == nil || !cmd.Runnable() {
+		return
+	}
+	path, pathErr := telemetry.DefaultPath()
+	if pathErr != nil {
+		return
+	}
+	status, statusErr := telemetry.CurrentStatus(path)
+	if statusErr != nil || !status.Enabled {
+		return
+	}
+
+	var flags []string
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		flags = append(flags, flag.Name)
+	})
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	event := telemetry.NewEvent(status.InstallID, a.version, command, flags, duration, err)
+	if sendErr := telemetry.NewClient(status.Endpoint).Send(context.WithoutCancel(ctx), event); sendErr != nil {
+		a.logger.Debug().Err(sendErr).Msg("Telemetry event not sent")
+	}
+}
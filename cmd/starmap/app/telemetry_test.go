@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agentstation/starmap/internal/telemetry"
+)
+
+func TestExecuteReportsTelemetryOnlyWhenEnabled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CONFIG", "")
+	t.Setenv(telemetry.EnvTelemetry, "")
+	t.Setenv(telemetry.EnvDoNotTrack, "")
+	events := make(chan telemetry.Event, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event telemetry.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Decode: %v", err)
+		}
+		events <- event
+	}))
+	defer server.Close()
+	t.Setenv(telemetry.EnvEndpoint, server.URL)
+
+	app, err := New("v1.2.3", "none", "unknown", "test")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := app.Execute(context.Background(), []string{"version", "-o", "json"}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatal("event sent before opting in")
+	}
+
+	if err := app.Execute(context.Background(), []string{"telemetry", "on", "-o", "json"}); err != nil {
+		t.Fatalf("telemetry on: %v", err)
+	}
+	event := <-events
+	if event.Command != "telemetry on" || event.Outcome != telemetry.OutcomeSuccess || event.Version != "v1.2.3" ||
+		len(event.Flags) != 1 || event.Flags[0] != "output" || event.InstallID == "" {
+		t.Fatalf("event = %+v, want telemetry on with the output flag name", event)
+	}
+
+	if err := app.Execute(context.Background(), []string{"telemetry", "off"}); err != nil {
+		t.Fatalf("telemetry off: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatal("event sent after opting out")
+	}
+}
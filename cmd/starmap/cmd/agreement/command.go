@@ -0,0 +1,194 @@
+// Package agreement provides the agreement command, which compares a model
+// field across the sources starmap update reconciles.
+package agreement
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/agreement"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/table"
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/internal/sources/modelsdev"
+	"github.com/agentstation/starmap/internal/sources/providers"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// NewCommand creates the agreement command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var (
+		field         string
+		providerID    string
+		disagreements bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "agreement --field <path>",
+		GroupID: "catalog",
+		Short:   "Compare a model field across sources",
+		Long: `Show, for each provider offering, the value every source reports for a model
+field and whether the sources agree.
+
+The field is a dotted path of model JSON names, such as pricing.tokens.input or
+limits.context_window. Token prices compare by their per-1M price. The
+provider APIs and models.dev are observed as starmap update would, reusing a
+provider fetch from the last few minutes; providers without credentials are
+skipped.
+
+After the table, providers where two sources disagree on at least two models
+and at least half of the models both report are listed as systematic
+discrepancies. When every disagreement has the same ratio, as a unit error
+would, the ratio is shown.`,
+		Args: cobra.NoArgs,
+		Example: `  starmap agreement --field pricing.tokens.input
+  starmap agreement --field limits.context_window -p openai
+  starmap agreement --field pricing.tokens.output --disagreements -o json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := agreement.ValidateField(field); err != nil {
+				return err
+			}
+			cat, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			var opts []sources.Option
+			if providerID != "" {
+				opts = append(opts, sources.WithProviderFilter(catalogs.ProviderID(providerID)))
+			}
+			observed := observe(cmd, app, []sources.Source{
+				providers.New(cat.Providers(), providers.WithFetchCacheDir(paths.ExpandHome(constants.DefaultProviderFetchCachePath))),
+				modelsdev.NewHTTPSource(),
+			}, opts)
+
+			report, err := agreement.Build(field, observed)
+			if err != nil {
+				return err
+			}
+			if disagreements {
+				rows := report.Rows[:0]
+				for _, row := range report.Rows {
+					if row.Status == agreement.StatusDisagree {
+						rows = append(rows, row)
+					}
+				}
+				report.Rows = rows
+			}
+			return printReport(cmd.OutOrStdout(), app.OutputFormat(), report)
+		},
+	}
+
+	cmd.Flags().StringVar(&field, "field", "", "Model field to compare, e.g. pricing.tokens.input")
+	cmd.Flags().StringVarP(&providerID, "provider", "p", "", "Only compare this provider's models")
+	cmd.Flags().BoolVar(&disagreements, "disagreements", false, "Only list models the sources disagree on")
+	_ = cmd.MarkFlagRequired("field")
+
+	return cmd
+}
+
+// observe observes each source, keeping the catalog of sources that return
+// one even with errors, as starmap update does.
+func observe(cmd *cobra.Command, app application.Application, srcs []sources.Source, opts []sources.Option) []agreement.Source {
+	logger := app.Logger()
+	observed := make([]agreement.Source, 0, len(srcs))
+	for _, src := range srcs {
+		observation, err := src.Observe(cmd.Context(), opts...)
+		if err != nil {
+			logger.Warn().Err(err).Str("source", src.ID().String()).Msg("Source observation had errors")
+		}
+		if cleanupErr := src.Cleanup(); cleanupErr != nil {
+			logger.Warn().Err(cleanupErr).Str("source", src.ID().String()).Msg("Source cleanup failed")
+		}
+		result := agreement.Source{ID: src.ID()}
+		if observation.Catalog != nil {
+			result.Catalog = observation.Catalog
+		}
+		observed = append(observed, result)
+	}
+	return observed
+}
+
+func printReport(w io.Writer, outputFormat string, report *agreement.Report) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, report)
+	}
+
+	headers := []string{"Provider", "Model"}
+	alignment := []table.Align{table.AlignLeft, table.AlignLeft}
+	for _, source := range report.Sources {
+		headers = append(headers, source.String())
+		alignment = append(alignment, table.AlignRight)
+	}
+	headers = append(headers, "Status")
+	alignment = append(alignment, table.AlignLeft)
+
+	rows := make([][]string, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		cells := []string{string(row.Provider), row.Model}
+		for _, source := range report.Sources {
+			value, ok := row.Value(source)
+			cells = append(cells, formatValue(value, ok))
+		}
+		rows = append(rows, append(cells, statusLabel(row.Status)))
+	}
+	if err := format.NewFormatter(detected).Format(w, format.Data{
+		Headers:         headers,
+		Rows:            rows,
+		ColumnAlignment: alignment,
+	}); err != nil {
+		return err
+	}
+
+	for _, discrepancy := range report.Discrepancies {
+		if !discrepancy.Systematic {
+			continue
+		}
+		line := fmt.Sprintf("%s %s: %s and %s disagree on %d of %d models",
+			emoji.Warning, discrepancy.Provider, discrepancy.Left, discrepancy.Right, discrepancy.Disagreed, discrepancy.Compared)
+		if discrepancy.Ratio != 0 {
+			line += fmt.Sprintf(" (%s = %s × %s)", discrepancy.Right, strconv.FormatFloat(discrepancy.Ratio, 'g', 4, 64), discrepancy.Left)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatValue(value any, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+func statusLabel(status agreement.Status) string {
+	switch status {
+	case agreement.StatusAgree:
+		return emoji.Success + " agree"
+	case agreement.StatusDisagree:
+		return emoji.Error + " disagree"
+	default:
+		return string(status)
+	}
+}
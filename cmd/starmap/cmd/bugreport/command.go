@@ -0,0 +1,199 @@
+// Package bugreport provides the bugreport command.
+package bugreport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/bugreport"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// issuesURL is where bug reports are filed.
+const issuesURL = "https://github.com/agentstation/starmap/issues/new"
+
+// configProvider exposes the effective CLI configuration.
+type configProvider interface {
+	EffectiveConfig() (file, profile string, settings map[string]any)
+	LogOutput() string
+}
+
+// Result describes a written bug report.
+type Result struct {
+	Path  string   `json:"path" yaml:"path"`
+	Files []string `json:"files" yaml:"files"`
+	Notes []string `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// NewCommand creates the bugreport command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var (
+		file     string
+		logLines int
+	)
+
+	cmd := &cobra.Command{
+		Use:     "bugreport",
+		GroupID: "setup",
+		Short:   "Bundle sanitized diagnostics for a GitHub issue",
+		Long: `Bugreport writes a tarball to attach to a GitHub issue. It contains:
+
+  environment.json   starmap version, build, OS, and starmap-related variables
+  config.yaml        the effective configuration, including the active profile
+  catalog.json       the catalog generation, its counts, and readiness
+  last-update.json   the change counts of the last applied update
+  logs/              the last lines of the log output file and ~/.starmap/logs
+
+Values of API keys, tokens, passwords, and other secret settings are replaced
+with [REDACTED], as are those values and URL passwords wherever they appear in
+the logs, and the home directory is shortened to ~. Catalog contents are not
+included. Review the bundle before attaching it.`,
+		Example: `  starmap bugreport
+  starmap bugreport -f /tmp/report.tar.gz --log-lines 500`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if logLines < 0 {
+				return &errors.ValidationError{Field: "log-lines", Value: logLines, Message: "must not be negative"}
+			}
+			now := time.Now()
+			if file == "" {
+				file = bugreport.FileName(now)
+			}
+			redactor := bugreport.NewRedactor(os.Environ())
+			report := collect(cmd.Context(), app, redactor, now, logLines)
+			result, err := write(file, report, redactor)
+			if err != nil {
+				return err
+			}
+			return printResult(cmd.OutOrStdout(), app.OutputFormat(), result)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "",
+		"Bundle path (default starmap-bugreport-<time>.tar.gz in the current directory)")
+	cmd.Flags().IntVar(&logLines, "log-lines", bugreport.DefaultLogLines,
+		"Trailing lines to include from each log file")
+
+	return cmd
+}
+
+// collect gathers the report. Failures are recorded as notes so a broken
+// catalog or config still produces a report.
+func collect(ctx context.Context, app application.Application, redactor *bugreport.Redactor, now time.Time, logLines int) bugreport.Report {
+	report := bugreport.Report{
+		CreatedAt:   now.UTC().Truncate(time.Second),
+		Environment: bugreport.NewEnvironment(app.Version(), app.Commit(), app.Date(), app.BuiltBy(), os.Environ(), redactor),
+	}
+	note := func(format string, args ...any) {
+		report.Notes = append(report.Notes, fmt.Sprintf(format, args...))
+	}
+
+	logOutput := ""
+	if provider, ok := app.(configProvider); ok {
+		file, profile, settings := provider.EffectiveConfig()
+		report.Config = bugreport.NewConfig(file, profile, settings, redactor)
+		logOutput = provider.LogOutput()
+	} else {
+		note("Configuration is not available to this command.")
+	}
+
+	report.Catalog = catalogInfo(ctx, app, note)
+
+	if path, err := bugreport.DefaultLastUpdatePath(); err != nil {
+		note("Last update: %v", err)
+	} else if report.LastUpdate, err = bugreport.LoadLastUpdate(path); err != nil {
+		note("Last update: %v", err)
+	}
+
+	if logLines > 0 {
+		for _, path := range bugreport.LogPaths(logOutput, paths.ExpandHome(constants.DefaultLogsPath)) {
+			lines, err := bugreport.TailLog(path, logLines)
+			if err != nil {
+				note("Log %s: %v", path, err)
+				continue
+			}
+			report.Logs = append(report.Logs, bugreport.Log{Path: path, Lines: lines})
+		}
+	}
+	return report
+}
+
+func catalogInfo(ctx context.Context, app application.Application, note func(string, ...any)) bugreport.Catalog {
+	var info bugreport.Catalog
+	state, err := app.CatalogState()
+	if err != nil {
+		note("Catalog: %v", err)
+		return info
+	}
+	info.GenerationID = state.GenerationID
+	info.Sequence = state.Sequence
+	if state.Catalog != nil {
+		info.Providers = state.Catalog.Providers().Len()
+		info.Models = len(state.Catalog.Models().List())
+	}
+	if readiness, err := app.Readiness(); err != nil {
+		note("Catalog readiness: %v", err)
+	} else {
+		info.Readiness = &readiness
+	}
+
+	sm, err := app.Starmap()
+	if err != nil {
+		note("Catalog generation: %v", err)
+		return info
+	}
+	generation, err := sm.CurrentGeneration(ctx)
+	if err != nil {
+		note("Catalog generation: %v", err)
+		return info
+	}
+	manifest := generation.Manifest
+	info.SchemaVersion = manifest.SchemaVersion
+	info.GeneratedAt = &manifest.GeneratedAt
+	info.Degraded = manifest.Degraded
+	info.DegradationReasons = manifest.DegradationReasons
+	return info
+}
+
+func write(file string, report bugreport.Report, redactor *bugreport.Redactor) (Result, error) {
+	out, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, constants.FilePermissions) //nolint:gosec // path is given by the user
+	if err != nil {
+		return Result{}, errors.WrapIO("create", file, err)
+	}
+	files, err := bugreport.Write(out, file, report, redactor)
+	if closeErr := out.Close(); err == nil && closeErr != nil {
+		err = errors.WrapIO("close", file, closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(file)
+		return Result{}, err
+	}
+	return Result{Path: file, Files: files, Notes: report.Notes}, nil
+}
+
+func printResult(w io.Writer, outputFormat string, result Result) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, result)
+	}
+
+	fmt.Fprintf(w, "%s Wrote bug report %s\n", emoji.Success, result.Path)
+	for _, name := range result.Files {
+		fmt.Fprintf(w, "   %s\n", name)
+	}
+	for _, note := range result.Notes {
+		fmt.Fprintf(w, "%s %s\n", emoji.Warning, note)
+	}
+	_, err := fmt.Fprintf(w, "\nReview the files, then attach the bundle to an issue at %s\n", issuesURL)
+	return err
+}
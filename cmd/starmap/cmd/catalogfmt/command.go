@@ -0,0 +1,139 @@
+// Package catalogfmt provides the fmt command, which rewrites catalog YAML
+// files in the canonical form starmap writes them in.
+package catalogfmt
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// NewCommand creates the fmt command using app context.
+func NewCommand(_ application.Application) *cobra.Command {
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:     "fmt [catalog-dir]",
+		GroupID: "development",
+		Short:   "Rewrite catalog YAML in canonical form",
+		Long: `Rewrite providers.yaml, authors.yaml, and every model file in a catalog
+directory in the canonical form starmap update writes: sorted providers and
+authors, canonical key order, block-style lists, and section comments. Hand
+edits then produce the same bytes as generated writes, so later updates only
+diff the values that changed.
+
+Files that are already canonical are left untouched; the paths of rewritten
+files are printed. Files with fields the catalog does not know are reported
+and skipped rather than rewritten without them. Model file comments other than
+# starmap:pin annotations are dropped, as starmap update drops them.
+
+The catalog directory defaults to internal/embedded/catalog. With --check,
+nothing is written and the command fails if any file is not canonical.`,
+		Args: cobra.MaximumNArgs(1),
+		Example: `  starmap fmt
+  starmap fmt --check
+  starmap fmt ~/.starmap/exports/catalog`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "internal/embedded/catalog"
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			return run(cmd, dir, check)
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "List files that are not canonical without rewriting them, and fail if there are any")
+
+	return cmd
+}
+
+func run(cmd *cobra.Command, dir string, check bool) error {
+	files, err := catalogFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	var changed, failed int
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		data, err := os.ReadFile(path) //nolint:gosec // Paths come from walking the catalog directory.
+		if err != nil {
+			return errors.WrapIO("read", path, err)
+		}
+		formatted, err := catalogs.FormatYAMLFile(file, data)
+		if err != nil {
+			failed++
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s %s: %v\n", emoji.Error, file, err)
+			continue
+		}
+		if bytes.Equal(data, formatted) {
+			continue
+		}
+		changed++
+		if !check {
+			info, err := os.Stat(path)
+			if err != nil {
+				return errors.WrapIO("stat", path, err)
+			}
+			if err := os.WriteFile(path, formatted, info.Mode().Perm()); err != nil {
+				return errors.WrapIO("write", path, err)
+			}
+		}
+		if _, err := fmt.Fprintln(w, file); err != nil {
+			return err
+		}
+	}
+
+	cmd.SilenceUsage = true
+	switch {
+	case failed > 0:
+		return fmt.Errorf("%d files could not be formatted", failed)
+	case check && changed > 0:
+		return fmt.Errorf("%d files are not canonical; run starmap fmt", changed)
+	}
+	return nil
+}
+
+// catalogFiles returns the slash-separated paths of the files fmt formats,
+// in walk order.
+func catalogFiles(dir string) ([]string, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, errors.WrapIO("stat", dir, err)
+	}
+	fsys := os.DirFS(dir)
+	var files []string
+	for _, name := range []string{"providers.yaml", "authors.yaml"} {
+		if _, err := fs.Stat(fsys, name); err == nil {
+			files = append(files, name)
+		}
+	}
+	for _, root := range []string{"providers", "authors"} {
+		err := fs.WalkDir(fsys, root, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if !d.IsDir() && strings.HasSuffix(file, ".yaml") && catalogs.IsModelFile(file) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.WrapIO("walk", filepath.Join(dir, root), err)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,118 @@
+package compare
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// CachingRow is one provider offering in the prompt caching matrix.
+type CachingRow struct {
+	Provider        string          `json:"provider" yaml:"provider"`
+	Model           string          `json:"model" yaml:"model"`
+	Modes           []string        `json:"modes,omitempty" yaml:"modes,omitempty"`
+	MinTokens       int64           `json:"min_tokens,omitempty" yaml:"min_tokens,omitempty"`
+	TTLs            []time.Duration `json:"ttls,omitempty" yaml:"ttls,omitempty"`
+	DefaultTTL      *time.Duration  `json:"default_ttl,omitempty" yaml:"default_ttl,omitempty"`
+	CacheReadPer1M  *float64        `json:"cache_read_per_1m,omitempty" yaml:"cache_read_per_1m,omitempty"`
+	CacheWritePer1M *float64        `json:"cache_write_per_1m,omitempty" yaml:"cache_write_per_1m,omitempty"`
+	ModelOverride   bool            `json:"model_override" yaml:"model_override"`
+}
+
+// BuildCachingMatrix collects the resolved prompt caching support of every
+// provider offering matching opts. Model-level caching data overrides the
+// provider default; ModelOverride marks rows where it did.
+func BuildCachingMatrix(catalog catalogs.Reader, opts MatrixOptions) ([]CachingRow, error) {
+	rows := []CachingRow{}
+	err := selectOfferings(catalog, opts, func(provider *catalogs.Provider, model *catalogs.Model) {
+		rows = append(rows, newCachingRow(provider, model))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return limitRows(rows, opts.Limit), nil
+}
+
+func newCachingRow(provider *catalogs.Provider, model *catalogs.Model) CachingRow {
+	row := CachingRow{
+		Provider:      string(provider.ID),
+		Model:         model.ID,
+		ModelOverride: model.Caching != nil,
+	}
+	if caching := model.PromptCachingFor(provider); caching != nil {
+		for _, mode := range caching.Modes {
+			row.Modes = append(row.Modes, mode.String())
+		}
+		row.MinTokens = caching.MinTokens
+		row.TTLs = caching.TTLs
+		row.DefaultTTL = caching.DefaultTTL
+	}
+	if model.Pricing != nil && model.Pricing.Tokens != nil {
+		tokens := model.Pricing.Tokens
+		row.CacheReadPer1M = per1M(tokens.CacheRead)
+		row.CacheWritePer1M = per1M(tokens.CacheWrite)
+		if tokens.Cache != nil {
+			if row.CacheReadPer1M == nil {
+				row.CacheReadPer1M = per1M(tokens.Cache.Read)
+			}
+			if row.CacheWritePer1M == nil {
+				row.CacheWritePer1M = per1M(tokens.Cache.Write)
+			}
+		}
+	}
+	return row
+}
+
+// cachingHeaders returns the column headers of the caching matrix.
+func cachingHeaders() []string {
+	return []string{"Provider", "Model", "Caching", "Min Tokens", "TTLs", "Default TTL", "Cache Read Price", "Cache Write Price"}
+}
+
+// cachingCells renders a caching row's cells. Machine formats leave unknown
+// values empty; the terminal table and markdown show "-".
+func cachingCells(row CachingRow, machine bool) []string {
+	modes := strings.Join(row.Modes, ", ")
+	if modes == "" && !machine {
+		modes = "-"
+	}
+	ttls := make([]string, len(row.TTLs))
+	for i, ttl := range row.TTLs {
+		ttls[i] = formatTTL(ttl)
+	}
+	defaultTTL := ""
+	if row.DefaultTTL != nil {
+		defaultTTL = formatTTL(*row.DefaultTTL)
+	}
+	return []string{
+		row.Provider,
+		row.Model,
+		modes,
+		formatCount(row.MinTokens, machine),
+		orDash(strings.Join(ttls, ", "), machine),
+		orDash(defaultTTL, machine),
+		formatCost(row.CacheReadPer1M, machine),
+		formatCost(row.CacheWritePer1M, machine),
+	}
+}
+
+// formatTTL renders whole hours and minutes compactly (1h, 5m) instead of
+// the time.Duration form (1h0m0s).
+func formatTTL(ttl time.Duration) string {
+	switch {
+	case ttl >= time.Hour && ttl%time.Hour == 0:
+		return strconv.FormatInt(int64(ttl/time.Hour), 10) + "h"
+	case ttl >= time.Minute && ttl%time.Minute == 0:
+		return strconv.FormatInt(int64(ttl/time.Minute), 10) + "m"
+	default:
+		return ttl.String()
+	}
+}
+
+func orDash(value string, machine bool) string {
+	if value == "" && !machine {
+		return "-"
+	}
+	return value
+}
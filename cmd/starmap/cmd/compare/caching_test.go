@@ -0,0 +1,64 @@
+package compare
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func TestBuildCachingMatrixResolvesProviderDefaults(t *testing.T) {
+	fiveMinutes, oneHour := 5*time.Minute, time.Hour
+	builder := catalogs.NewEmpty()
+	provider := catalogs.Provider{
+		ID:   "alpha",
+		Name: "Alpha",
+		PromptCaching: &catalogs.PromptCaching{
+			Modes:      []catalogs.PromptCachingMode{catalogs.PromptCachingModeExplicit},
+			MinTokens:  1024,
+			TTLs:       []time.Duration{fiveMinutes, oneHour},
+			DefaultTTL: &fiveMinutes,
+		},
+		Models: map[string]*catalogs.Model{
+			"alpha-default": {ID: "alpha-default", Name: "Alpha Default", Pricing: &catalogs.ModelPricing{
+				Tokens: &catalogs.ModelTokenPricing{CacheRead: &catalogs.ModelTokenCost{Per1M: 0.3}},
+			}},
+			"alpha-large": {ID: "alpha-large", Name: "Alpha Large", Caching: &catalogs.PromptCaching{
+				Modes:     []catalogs.PromptCachingMode{catalogs.PromptCachingModeExplicit},
+				MinTokens: 4096,
+			}},
+		},
+	}
+	if err := builder.SetProvider(provider); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	rows, err := BuildCachingMatrix(catalog, MatrixOptions{})
+	if err != nil {
+		t.Fatalf("BuildCachingMatrix: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %#v", rows)
+	}
+	base, large := rows[0], rows[1]
+	if base.ModelOverride || base.MinTokens != 1024 || len(base.TTLs) != 2 || base.CacheReadPer1M == nil {
+		t.Fatalf("default row = %#v", base)
+	}
+	if !large.ModelOverride || large.MinTokens != 4096 || len(large.TTLs) != 0 {
+		t.Fatalf("override row = %#v", large)
+	}
+
+	var out bytes.Buffer
+	if err := render(&out, rows, cachingHeaders(), cachingCells, &Flags{Matrix: true, Format: "markdown"}, ""); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if want := "| alpha | alpha-default | explicit | 1,024 | 5m, 1h | 5m | $0.3 | - |"; !strings.Contains(out.String(), want) {
+		t.Fatalf("markdown missing %q:\n%s", want, out.String())
+	}
+}
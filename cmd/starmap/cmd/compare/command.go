@@ -0,0 +1,163 @@
+// Package compare provides the model comparison command.
+package compare
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/constants"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/globals"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Flags holds flags for the compare command.
+type Flags struct {
+	Providers    []string
+	Capabilities []string
+	Search       string
+	Limit        int
+	Matrix       bool
+	Caching      bool
+	Documents    bool
+	Format       string
+}
+
+// NewCommand creates the compare command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	flags := &Flags{}
+
+	cmd := &cobra.Command{
+		Use:     "compare [model-id...]",
+		GroupID: "catalog",
+		Short:   "Compare features and pricing across models",
+		Long: `Compare capabilities, limits, and token pricing across many models at once.
+
+Models are selected by provider, capability, and search filters, or by
+listing model IDs. By default models are shown side by side (one column per
+model); --matrix renders one row per provider offering instead, which scales
+to dozens of models. Use --format to render the result as a terminal table,
+CSV, or markdown, or the global --output json|yaml for structured rows.
+Prices are USD per 1M tokens.
+
+--caching compares prompt caching support instead: activation mode
+(automatic or explicit cache_control), minimum cacheable tokens, cache
+lifetimes, and cache read/write prices.
+
+--documents compares native PDF input instead: page and size limits, how
+scanned pages are read (OCR), approximate tokens per page, and per-page
+prices.`,
+		Example: `  starmap compare gpt-4o claude-sonnet-4-5
+  starmap compare --provider openai --provider anthropic --capability tools --matrix
+  starmap compare -p openai --capability vision --matrix --format csv > vision.csv
+  starmap compare -p groq --matrix --format markdown
+  starmap compare -p anthropic -p openai --caching --matrix
+  starmap compare --documents --matrix --format markdown`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalog, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			globalFlags, err := globals.Parse(cmd)
+			if err != nil {
+				return err
+			}
+			opts := MatrixOptions{
+				Providers:    flags.Providers,
+				Capabilities: flags.Capabilities,
+				Models:       args,
+				Search:       flags.Search,
+				Limit:        flags.Limit,
+			}
+			if flags.Caching && flags.Documents {
+				return &errors.ValidationError{
+					Field:   "documents",
+					Value:   true,
+					Message: "cannot be combined with --caching",
+				}
+			}
+			if flags.Documents {
+				rows, err := BuildDocumentMatrix(catalog, opts)
+				if err != nil {
+					return err
+				}
+				return render(cmd.OutOrStdout(), rows, documentHeaders(), documentCells, flags, globalFlags.Output)
+			}
+			if flags.Caching {
+				rows, err := BuildCachingMatrix(catalog, opts)
+				if err != nil {
+					return err
+				}
+				return render(cmd.OutOrStdout(), rows, cachingHeaders(), cachingCells, flags, globalFlags.Output)
+			}
+			rows, err := BuildMatrix(catalog, opts)
+			if err != nil {
+				return err
+			}
+			return render(cmd.OutOrStdout(), rows, matrixHeaders(), matrixCells, flags, globalFlags.Output)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&flags.Providers, "provider", "p", nil,
+		"Providers to include (repeatable; default: all)")
+	cmd.Flags().StringSliceVar(&flags.Capabilities, "capability", nil,
+		"Required capabilities (repeatable): tools, reasoning, streaming, vision, structured_outputs, web_search")
+	cmd.Flags().StringVar(&flags.Search, "search", "",
+		"Search term to filter models")
+	cmd.Flags().IntVarP(&flags.Limit, "limit", "l", 0,
+		"Limit number of models")
+	cmd.Flags().BoolVar(&flags.Matrix, "matrix", false,
+		"Render one row per model instead of one column per model")
+	cmd.Flags().BoolVar(&flags.Caching, "caching", false,
+		"Compare prompt caching support instead of features and pricing")
+	cmd.Flags().BoolVar(&flags.Documents, "documents", false,
+		"Compare PDF/document input support instead of features and pricing")
+	cmd.Flags().StringVar(&flags.Format, "format", "",
+		"Render format: table, csv, markdown (default: table)")
+
+	return cmd
+}
+
+// render writes rows in the requested layout and format. cells renders one
+// row; machine formats keep raw values.
+func render[T any](w io.Writer, rows []T, labels []string, cells func(T, bool) []string, flags *Flags, output string) error {
+	if len(rows) == 0 {
+		return &errors.NotFoundError{Resource: "models", ID: "matching comparison filters"}
+	}
+	renderFormat := flags.Format
+	switch output {
+	case constants.FormatJSON, constants.FormatYAML:
+		return format.NewFormatter(format.Format(output)).Format(w, rows)
+	case constants.FormatCSV, constants.FormatMarkdown:
+		if renderFormat == "" {
+			renderFormat = output
+		}
+	}
+
+	machine := renderFormat == constants.FormatCSV
+	body := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		body = append(body, cells(row, machine))
+	}
+	headers := labels
+	if !flags.Matrix {
+		headers, body = sideBySide(labels, body)
+	}
+
+	switch renderFormat {
+	case constants.FormatTable, constants.FormatWide, "":
+		return format.NewFormatter(format.FormatTable).Format(w, format.Data{Headers: headers, Rows: body})
+	case constants.FormatCSV:
+		return writeCSV(w, headers, body)
+	case constants.FormatMarkdown:
+		return writeMarkdown(w, headers, body)
+	default:
+		return &errors.ValidationError{
+			Field:   "format",
+			Value:   renderFormat,
+			Message: "unsupported format (use table, csv, or markdown)",
+		}
+	}
+}
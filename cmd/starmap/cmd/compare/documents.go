@@ -0,0 +1,81 @@
+package compare
+
+import (
+	"strconv"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// DocumentRow is one provider offering in the document input matrix.
+type DocumentRow struct {
+	Provider      string   `json:"provider" yaml:"provider"`
+	Model         string   `json:"model" yaml:"model"`
+	Supported     bool     `json:"supported" yaml:"supported"`
+	MaxPages      *int     `json:"max_pages,omitempty" yaml:"max_pages,omitempty"`
+	MaxFileSize   *int64   `json:"max_file_size,omitempty" yaml:"max_file_size,omitempty"`
+	OCR           string   `json:"ocr,omitempty" yaml:"ocr,omitempty"`
+	PageTokens    int64    `json:"page_tokens,omitempty" yaml:"page_tokens,omitempty"`
+	PagePrice     *float64 `json:"page_price,omitempty" yaml:"page_price,omitempty"`
+	ModelOverride bool     `json:"model_override" yaml:"model_override"`
+}
+
+// BuildDocumentMatrix collects the resolved document input support of every
+// provider offering matching opts. Model-level document data overrides the
+// provider default; ModelOverride marks rows where it did.
+func BuildDocumentMatrix(catalog catalogs.Reader, opts MatrixOptions) ([]DocumentRow, error) {
+	rows := []DocumentRow{}
+	err := selectOfferings(catalog, opts, func(provider *catalogs.Provider, model *catalogs.Model) {
+		rows = append(rows, newDocumentRow(provider, model))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return limitRows(rows, opts.Limit), nil
+}
+
+func newDocumentRow(provider *catalogs.Provider, model *catalogs.Model) DocumentRow {
+	row := DocumentRow{
+		Provider:      string(provider.ID),
+		Model:         model.ID,
+		ModelOverride: model.Documents != nil,
+	}
+	if documents := model.DocumentsFor(provider); documents != nil {
+		row.Supported = true
+		row.MaxPages = documents.MaxPages
+		row.MaxFileSize = documents.MaxFileSize
+		row.OCR = documents.OCR.String()
+		row.PageTokens = documents.PageTokens
+	}
+	if model.Pricing != nil && model.Pricing.Operations != nil {
+		row.PagePrice = model.Pricing.Operations.DocumentPage
+	}
+	return row
+}
+
+// documentHeaders returns the column headers of the document input matrix.
+func documentHeaders() []string {
+	return []string{"Provider", "Model", "PDF Input", "Max Pages", "Max Size (MB)", "OCR", "Tokens/Page", "Page Price"}
+}
+
+// documentCells renders a document row's cells. Machine formats leave
+// unknown values empty; the terminal table and markdown show "-".
+func documentCells(row DocumentRow, machine bool) []string {
+	maxPages := ""
+	if row.MaxPages != nil {
+		maxPages = strconv.Itoa(*row.MaxPages)
+	}
+	maxSize := ""
+	if row.MaxFileSize != nil {
+		maxSize = strconv.FormatInt(*row.MaxFileSize/(1<<20), 10)
+	}
+	return []string{
+		row.Provider,
+		row.Model,
+		formatCapability(row.Supported, machine),
+		orDash(maxPages, machine),
+		orDash(maxSize, machine),
+		orDash(row.OCR, machine),
+		formatCount(row.PageTokens, machine),
+		formatCost(row.PagePrice, machine),
+	}
+}
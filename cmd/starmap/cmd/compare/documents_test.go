@@ -0,0 +1,70 @@
+package compare
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func TestBuildDocumentMatrixResolvesProviderDefaults(t *testing.T) {
+	maxPages, maxSize, pagePrice := 100, int64(32<<20), 0.001
+	pdfInput := &catalogs.ModelFeatures{Modalities: catalogs.ModelModalities{
+		Input: []catalogs.ModelModality{catalogs.ModelModalityText, catalogs.ModelModalityPDF},
+	}}
+	builder := catalogs.NewEmpty()
+	provider := catalogs.Provider{
+		ID:   "alpha",
+		Name: "Alpha",
+		Documents: &catalogs.ModelDocumentInput{
+			MaxPages:    &maxPages,
+			MaxFileSize: &maxSize,
+			OCR:         catalogs.DocumentOCRVision,
+			PageTokens:  1500,
+		},
+		Models: map[string]*catalogs.Model{
+			"alpha-pdf": {ID: "alpha-pdf", Name: "Alpha PDF", Features: pdfInput},
+			"alpha-ocr": {ID: "alpha-ocr", Name: "Alpha OCR",
+				Documents: &catalogs.ModelDocumentInput{OCR: catalogs.DocumentOCRDedicated},
+				Pricing: &catalogs.ModelPricing{
+					Operations: &catalogs.ModelOperationPricing{DocumentPage: &pagePrice},
+				},
+			},
+			"alpha-text": {ID: "alpha-text", Name: "Alpha Text"},
+		},
+	}
+	if err := builder.SetProvider(provider); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	rows, err := BuildDocumentMatrix(catalog, MatrixOptions{})
+	if err != nil {
+		t.Fatalf("BuildDocumentMatrix: %v", err)
+	}
+	byModel := map[string]DocumentRow{}
+	for _, row := range rows {
+		byModel[row.Model] = row
+	}
+	if row := byModel["alpha-pdf"]; !row.Supported || row.ModelOverride || row.MaxPages == nil || *row.MaxPages != 100 {
+		t.Fatalf("default row = %#v", row)
+	}
+	if row := byModel["alpha-ocr"]; !row.ModelOverride || row.OCR != "dedicated" || row.PagePrice == nil {
+		t.Fatalf("override row = %#v", row)
+	}
+	if row := byModel["alpha-text"]; row.Supported {
+		t.Fatalf("text-only row = %#v, want unsupported", row)
+	}
+
+	var out bytes.Buffer
+	if err := render(&out, rows, documentHeaders(), documentCells, &Flags{Matrix: true, Format: "markdown"}, ""); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if want := "| alpha | alpha-pdf | ✓ | 100 | 32 | vision | 1,500 | - |"; !strings.Contains(out.String(), want) {
+		t.Fatalf("markdown missing %q:\n%s", want, out.String())
+	}
+}
@@ -0,0 +1,280 @@
+package compare
+
+import (
+	"encoding/csv"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agentstation/starmap/internal/catalog/query"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/locale"
+	"github.com/agentstation/starmap/internal/cli/table"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// capabilityColumns lists the capability columns of the matrix in order.
+var capabilityColumns = []struct {
+	Key    string
+	Header string
+	Has    func(*catalogs.ModelFeatures) bool
+}{
+	{"tools", "Tools", func(f *catalogs.ModelFeatures) bool { return f.ToolCalls || f.Tools }},
+	{"reasoning", "Reasoning", func(f *catalogs.ModelFeatures) bool { return f.Reasoning }},
+	{"streaming", "Streaming", func(f *catalogs.ModelFeatures) bool { return f.Streaming }},
+	{"vision", "Vision", func(f *catalogs.ModelFeatures) bool {
+		return slices.Contains(f.Modalities.Input, catalogs.ModelModalityImage)
+	}},
+	{"structured_outputs", "Structured", func(f *catalogs.ModelFeatures) bool { return f.StructuredOutputs }},
+	{"web_search", "Web Search", func(f *catalogs.ModelFeatures) bool { return f.WebSearch }},
+}
+
+// MatrixRow is one provider offering in the comparison matrix.
+type MatrixRow struct {
+	Provider       string          `json:"provider" yaml:"provider"`
+	Model          string          `json:"model" yaml:"model"`
+	Name           string          `json:"name" yaml:"name"`
+	ContextWindow  int64           `json:"context_window,omitempty" yaml:"context_window,omitempty"`
+	OutputTokens   int64           `json:"output_tokens,omitempty" yaml:"output_tokens,omitempty"`
+	InputPer1M     *float64        `json:"input_per_1m,omitempty" yaml:"input_per_1m,omitempty"`
+	OutputPer1M    *float64        `json:"output_per_1m,omitempty" yaml:"output_per_1m,omitempty"`
+	CacheReadPer1M *float64        `json:"cache_read_per_1m,omitempty" yaml:"cache_read_per_1m,omitempty"`
+	Capabilities   map[string]bool `json:"capabilities" yaml:"capabilities"`
+}
+
+// MatrixOptions selects the offerings included in a matrix.
+type MatrixOptions struct {
+	Providers    []string
+	Capabilities []string
+	Models       []string
+	Search       string
+	Limit        int
+}
+
+// BuildMatrix collects one row per provider offering matching opts, ordered
+// by provider and model ID. Every requested capability must be present.
+func BuildMatrix(catalog catalogs.Reader, opts MatrixOptions) ([]MatrixRow, error) {
+	rows := []MatrixRow{}
+	err := selectOfferings(catalog, opts, func(provider *catalogs.Provider, model *catalogs.Model) {
+		rows = append(rows, newMatrixRow(string(provider.ID), model))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return limitRows(rows, opts.Limit), nil
+}
+
+// selectOfferings visits every provider offering matching opts, ordered by
+// provider and model ID.
+func selectOfferings(catalog catalogs.Reader, opts MatrixOptions, visit func(*catalogs.Provider, *catalogs.Model)) error {
+	for _, capability := range opts.Capabilities {
+		if !knownCapability(capability) {
+			return &errors.ValidationError{
+				Field:   "capability",
+				Value:   capability,
+				Message: "unsupported capability (use tools, reasoning, streaming, vision, structured_outputs, or web_search)",
+			}
+		}
+	}
+
+	providerIDs := slices.Clone(opts.Providers)
+	if len(providerIDs) == 0 {
+		for _, provider := range catalog.Providers().List() {
+			providerIDs = append(providerIDs, string(provider.ID))
+		}
+	}
+	slices.Sort(providerIDs)
+	providerIDs = slices.Compact(providerIDs)
+
+	for _, providerID := range providerIDs {
+		provider, ok := catalog.Providers().Get(catalogs.ProviderID(providerID))
+		if !ok {
+			return &errors.NotFoundError{Resource: "provider", ID: providerID}
+		}
+		models, err := query.CatalogModels(catalog, providerID)
+		if err != nil {
+			return err
+		}
+		models = query.Models(models, query.ModelOptions{Search: opts.Search})
+		for _, capability := range opts.Capabilities {
+			models = query.Models(models, query.ModelOptions{Capability: capability})
+		}
+		for i := range models {
+			if len(opts.Models) > 0 && !slices.Contains(opts.Models, models[i].ID) {
+				continue
+			}
+			visit(provider, &models[i])
+		}
+	}
+	return nil
+}
+
+func limitRows[T any](rows []T, limit int) []T {
+	if limit > 0 && len(rows) > limit {
+		return rows[:limit]
+	}
+	return rows
+}
+
+func knownCapability(capability string) bool {
+	switch strings.ToLower(capability) {
+	case "tools", "tool_calls", "reasoning", "streaming", "vision", "image", "structured_outputs", "web_search":
+		return true
+	default:
+		return false
+	}
+}
+
+func newMatrixRow(providerID string, model *catalogs.Model) MatrixRow {
+	row := MatrixRow{
+		Provider:     providerID,
+		Model:        model.ID,
+		Name:         model.Name,
+		Capabilities: make(map[string]bool, len(capabilityColumns)),
+	}
+	if model.Limits != nil {
+		row.ContextWindow = model.Limits.ContextWindow
+		row.OutputTokens = model.Limits.OutputTokens
+	}
+	if model.Pricing != nil && model.Pricing.Tokens != nil {
+		tokens := model.Pricing.Tokens
+		row.InputPer1M = per1M(tokens.Input)
+		row.OutputPer1M = per1M(tokens.Output)
+		row.CacheReadPer1M = per1M(tokens.CacheRead)
+		if row.CacheReadPer1M == nil && tokens.Cache != nil {
+			row.CacheReadPer1M = per1M(tokens.Cache.Read)
+		}
+	}
+	for _, column := range capabilityColumns {
+		row.Capabilities[column.Key] = model.Features != nil && column.Has(model.Features)
+	}
+	return row
+}
+
+func per1M(cost *catalogs.ModelTokenCost) *float64 {
+	if cost == nil {
+		return nil
+	}
+	value := cost.Per1M
+	return &value
+}
+
+// matrixHeaders returns the column headers of the wide matrix.
+func matrixHeaders() []string {
+	headers := []string{"Provider", "Model", "Context", "Output", "Input Price", "Output Price", "Cache Read Price"}
+	for _, column := range capabilityColumns {
+		headers = append(headers, column.Header)
+	}
+	return headers
+}
+
+// matrixCells renders a row's cells. Machine formats keep raw numbers and
+// booleans; the terminal table and markdown use human-readable values.
+func matrixCells(row MatrixRow, machine bool) []string {
+	cells := []string{
+		row.Provider,
+		row.Model,
+		formatCount(row.ContextWindow, machine),
+		formatCount(row.OutputTokens, machine),
+		formatCost(row.InputPer1M, machine),
+		formatCost(row.OutputPer1M, machine),
+		formatCost(row.CacheReadPer1M, machine),
+	}
+	for _, column := range capabilityColumns {
+		cells = append(cells, formatCapability(row.Capabilities[column.Key], machine))
+	}
+	return cells
+}
+
+// sideBySide transposes rows so each offering is a column, which reads
+// better than the wide matrix when comparing a handful of models. The first
+// cell of every row becomes the column header.
+func sideBySide(labels []string, rows [][]string) ([]string, [][]string) {
+	headers := []string{"Attribute"}
+	body := make([][]string, len(labels)-1)
+	for i, label := range labels[1:] {
+		body[i] = []string{label}
+	}
+	for _, cells := range rows {
+		headers = append(headers, cells[0])
+		for i, cell := range cells[1:] {
+			body[i] = append(body[i], cell)
+		}
+	}
+	return headers, body
+}
+
+func writeCSV(w io.Writer, headers []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(headers); err != nil {
+		return errors.WrapIO("write", "csv", err)
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return errors.WrapIO("write", "csv", err)
+	}
+	return nil
+}
+
+func writeMarkdown(w io.Writer, headers []string, rows [][]string) error {
+	var b strings.Builder
+	writeMarkdownRow(&b, headers)
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeMarkdownRow(&b, separators)
+	for _, row := range rows {
+		writeMarkdownRow(&b, row)
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return errors.WrapIO("write", "markdown", err)
+	}
+	return nil
+}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		b.WriteString(" ")
+		b.WriteString(strings.ReplaceAll(cell, "|", `\|`))
+		b.WriteString(" |")
+	}
+	b.WriteString("\n")
+}
+
+func formatCount(value int64, machine bool) string {
+	switch {
+	case machine && value == 0:
+		return ""
+	case machine:
+		return strconv.FormatInt(value, 10)
+	case value == 0:
+		return "-"
+	default:
+		return table.FormatNumber(value)
+	}
+}
+
+func formatCost(value *float64, machine bool) string {
+	switch {
+	case value == nil && machine:
+		return ""
+	case value == nil:
+		return "-"
+	case machine:
+		return strconv.FormatFloat(*value, 'f', -1, 64)
+	default:
+		return locale.Money(*value, "USD", -1)
+	}
+}
+
+func formatCapability(has, machine bool) string {
+	switch {
+	case machine:
+		return strconv.FormatBool(has)
+	default:
+		return emoji.Check(has)
+	}
+}
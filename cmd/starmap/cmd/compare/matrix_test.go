@@ -0,0 +1,150 @@
+package compare
+
+import (
+	"bytes"
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func compareTestCatalog(t *testing.T) *catalogs.Catalog {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	for _, provider := range []catalogs.Provider{
+		{ID: "alpha", Name: "Alpha", Models: map[string]*catalogs.Model{
+			"alpha-tools": {
+				ID: "alpha-tools", Name: "Alpha Tools",
+				Features: &catalogs.ModelFeatures{ToolCalls: true, Streaming: true},
+				Limits:   &catalogs.ModelLimits{ContextWindow: 128000, OutputTokens: 4096},
+				Pricing: &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{
+					Input:  &catalogs.ModelTokenCost{Per1M: 2.5},
+					Output: &catalogs.ModelTokenCost{Per1M: 10},
+				}},
+			},
+			"alpha-text": {ID: "alpha-text", Name: "Alpha Text", Features: &catalogs.ModelFeatures{Streaming: true}},
+		}},
+		{ID: "beta", Name: "Beta", Models: map[string]*catalogs.Model{
+			"beta-tools": {ID: "beta-tools", Name: "Beta Tools", Features: &catalogs.ModelFeatures{
+				Tools: true, Reasoning: true, StructuredOutputs: true, WebSearch: true,
+			}},
+		}},
+	} {
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider(%s): %v", provider.ID, err)
+		}
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return catalog
+}
+
+func TestBuildMatrixFiltersAcrossProviders(t *testing.T) {
+	rows, err := BuildMatrix(compareTestCatalog(t), MatrixOptions{
+		Providers:    []string{"beta", "alpha"},
+		Capabilities: []string{"tools"},
+	})
+	if err != nil {
+		t.Fatalf("BuildMatrix: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Model != "alpha-tools" || rows[1].Model != "beta-tools" {
+		t.Fatalf("rows = %#v", rows)
+	}
+	if rows[0].InputPer1M == nil || *rows[0].InputPer1M != 2.5 || !rows[0].Capabilities["streaming"] {
+		t.Fatalf("alpha-tools row = %#v", rows[0])
+	}
+	if !rows[1].Capabilities["reasoning"] || rows[1].InputPer1M != nil {
+		t.Fatalf("beta-tools row = %#v", rows[1])
+	}
+}
+
+func TestBuildMatrixFiltersOnEveryRenderedCapability(t *testing.T) {
+	for _, column := range capabilityColumns {
+		rows, err := BuildMatrix(compareTestCatalog(t), MatrixOptions{Capabilities: []string{column.Key}})
+		if err != nil {
+			t.Fatalf("BuildMatrix(--capability %s): %v", column.Key, err)
+		}
+		for _, row := range rows {
+			if !row.Capabilities[column.Key] {
+				t.Errorf("--capability %s kept %s/%s without it", column.Key, row.Provider, row.Model)
+			}
+		}
+	}
+
+	rows, err := BuildMatrix(compareTestCatalog(t), MatrixOptions{Capabilities: []string{"structured_outputs", "web_search"}})
+	if err != nil {
+		t.Fatalf("BuildMatrix: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Model != "beta-tools" {
+		t.Fatalf("rows = %#v, want beta-tools", rows)
+	}
+}
+
+func TestBuildMatrixRejectsUnknownInputs(t *testing.T) {
+	catalog := compareTestCatalog(t)
+
+	var validationErr *pkgerrors.ValidationError
+	if _, err := BuildMatrix(catalog, MatrixOptions{Capabilities: []string{"telepathy"}}); !stderrors.As(err, &validationErr) {
+		t.Fatalf("unknown capability error = %T: %v", err, err)
+	}
+	var notFound *pkgerrors.NotFoundError
+	if _, err := BuildMatrix(catalog, MatrixOptions{Providers: []string{"missing"}}); !stderrors.As(err, &notFound) {
+		t.Fatalf("unknown provider error = %T: %v", err, err)
+	}
+}
+
+func TestRenderMatrixFormats(t *testing.T) {
+	rows, err := BuildMatrix(compareTestCatalog(t), MatrixOptions{Models: []string{"alpha-tools"}})
+	if err != nil {
+		t.Fatalf("BuildMatrix: %v", err)
+	}
+
+	for _, test := range []struct {
+		name   string
+		flags  Flags
+		output string
+		want   []string
+	}{
+		{
+			name:  "csv matrix keeps raw values",
+			flags: Flags{Matrix: true, Format: "csv"},
+			want:  []string{"Provider,Model,Context", "alpha,alpha-tools,128000,4096,2.5,10,,true,false,true"},
+		},
+		{
+			name:  "markdown matrix",
+			flags: Flags{Matrix: true, Format: "markdown"},
+			want:  []string{"| Provider | Model |", "| --- |", "| alpha | alpha-tools | 128,000 |"},
+		},
+		{
+			name:   "global csv output is honored",
+			output: "csv",
+			want:   []string{"Attribute,alpha", "Model,alpha-tools", "Input Price,2.5"},
+		},
+		{
+			name:   "json rows",
+			output: "json",
+			want:   []string{`"model": "alpha-tools"`, `"input_per_1m": 2.5`},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := render(&out, rows, matrixHeaders(), matrixCells, &test.flags, test.output); err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(out.String(), want) {
+					t.Fatalf("output missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+
+	var validationErr *pkgerrors.ValidationError
+	if err := render(&bytes.Buffer{}, rows, matrixHeaders(), matrixCells, &Flags{Format: "pdf"}, ""); !stderrors.As(err, &validationErr) {
+		t.Fatalf("unsupported format error = %T: %v", err, err)
+	}
+}
@@ -0,0 +1,97 @@
+// Package compareproviders provides the compare-providers command.
+package compareproviders
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+)
+
+// NewCommand creates the compare-providers command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var providers []string
+
+	cmd := &cobra.Command{
+		Use:     "compare-providers <model-id>",
+		GroupID: "catalog",
+		Short:   "Compare how providers serve the same model",
+		Long: `Compare the offerings of one underlying model across the providers that
+serve it: context window, output limit, token prices, quantization, serving
+speed, availability, and lifecycle.
+
+Offerings are linked through model definitions, so provider model IDs that
+name the same weights differently (for example a "-versatile" or "-turbo"
+suffix) are compared together when their lineage root is the model. Columns
+marked with * differ between providers. Prices are USD per 1M tokens.`,
+		Example: `  starmap compare-providers llama-3.1-70b
+  starmap compare-providers llama-3.1-70b --providers groq,together,vertex
+  starmap compare-providers deepseek-r1 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cat, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			comparison, err := Compare(cat, args[0], providers)
+			if err != nil {
+				return err
+			}
+			return printComparison(cmd.OutOrStdout(), app.OutputFormat(), comparison)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&providers, "providers", "p", nil,
+		"Only compare these providers (comma-separated)")
+
+	return cmd
+}
+
+func printComparison(w io.Writer, outputFormat string, comparison *Comparison) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, comparison)
+	}
+
+	differs := make(map[string]bool, len(comparison.Differences))
+	for _, key := range comparison.Differences {
+		differs[key] = true
+	}
+	headers := []string{"Provider", "Model ID"}
+	for _, field := range fields {
+		header := field.Header
+		if differs[field.Key] {
+			header += " *"
+		}
+		headers = append(headers, header)
+	}
+	rows := make([][]string, 0, len(comparison.Offerings))
+	for _, offering := range comparison.Offerings {
+		row := []string{offering.Provider, offering.Model}
+		for _, field := range fields {
+			row = append(row, field.Value(offering))
+		}
+		rows = append(rows, row)
+	}
+	if err := format.NewFormatter(detected).Format(w, format.Data{Headers: headers, Rows: rows}); err != nil {
+		return err
+	}
+
+	if len(comparison.Offerings) > 1 {
+		if len(comparison.Differences) == 0 {
+			_, _ = fmt.Fprintf(w, "\n%s All %d offerings of %s match\n", emoji.Success, len(comparison.Offerings), comparison.Model)
+		} else {
+			_, _ = fmt.Fprintf(w, "\n* differs across providers: %s\n", strings.Join(comparison.Differences, ", "))
+		}
+	}
+	if len(comparison.Missing) > 0 {
+		_, err := fmt.Fprintf(w, "%s Not served by: %s\n", emoji.Info, strings.Join(comparison.Missing, ", "))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package compareproviders
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Offering is one provider's service of a model, with the facts that vary
+// between providers.
+type Offering struct {
+	Provider              string   `json:"provider" yaml:"provider"`
+	Model                 string   `json:"model" yaml:"model"` // Provider model ID
+	DefinitionID          string   `json:"definition_id" yaml:"definition_id"`
+	ContextWindow         int64    `json:"context_window,omitempty" yaml:"context_window,omitempty"`
+	OutputTokens          int64    `json:"output_tokens,omitempty" yaml:"output_tokens,omitempty"`
+	InputPer1M            *float64 `json:"input_per_1m,omitempty" yaml:"input_per_1m,omitempty"`
+	OutputPer1M           *float64 `json:"output_per_1m,omitempty" yaml:"output_per_1m,omitempty"`
+	Quantization          string   `json:"quantization,omitempty" yaml:"quantization,omitempty"`
+	OutputTokensPerSecond float64  `json:"output_tokens_per_second,omitempty" yaml:"output_tokens_per_second,omitempty"`
+	TimeToFirstTokenMS    int64    `json:"time_to_first_token_ms,omitempty" yaml:"time_to_first_token_ms,omitempty"` // Median
+	Availability          string   `json:"availability" yaml:"availability"`
+	Lifecycle             string   `json:"lifecycle" yaml:"lifecycle"`
+}
+
+// Comparison lists the offerings of one underlying model and the fields
+// whose values differ between them.
+type Comparison struct {
+	Model       string     `json:"model" yaml:"model"`             // Canonical definition the offerings share
+	Definitions []string   `json:"definitions" yaml:"definitions"` // Linked definitions, including Model
+	Offerings   []Offering `json:"offerings" yaml:"offerings"`
+	Differences []string   `json:"differences" yaml:"differences"`
+	Missing     []string   `json:"missing,omitempty" yaml:"missing,omitempty"` // Requested providers without an offering
+}
+
+// fields lists the compared offering fields in display order.
+var fields = []struct {
+	Key    string
+	Header string
+	Value  func(Offering) string
+}{
+	{"context_window", "Context", func(o Offering) string { return count(o.ContextWindow) }},
+	{"output_tokens", "Max Output", func(o Offering) string { return count(o.OutputTokens) }},
+	{"input_price", "Input $/1M", func(o Offering) string { return price(o.InputPer1M) }},
+	{"output_price", "Output $/1M", func(o Offering) string { return price(o.OutputPer1M) }},
+	{"quantization", "Quantization", func(o Offering) string { return dash(o.Quantization) }},
+	{"output_tokens_per_second", "Tok/s", func(o Offering) string {
+		if o.OutputTokensPerSecond == 0 {
+			return "-"
+		}
+		return strconv.FormatFloat(o.OutputTokensPerSecond, 'f', 0, 64)
+	}},
+	{"time_to_first_token", "TTFT p50", func(o Offering) string {
+		if o.TimeToFirstTokenMS == 0 {
+			return "-"
+		}
+		return strconv.FormatInt(o.TimeToFirstTokenMS, 10) + "ms"
+	}},
+	{"availability", "Availability", func(o Offering) string { return o.Availability }},
+	{"lifecycle", "Lifecycle", func(o Offering) string { return o.Lifecycle }},
+}
+
+// Compare collects every offering of modelID's underlying model. Offerings
+// are linked through the definition layer: an offering belongs to the model
+// when its definition is the model's definition or shares its lineage root,
+// so differently named provider IDs of the same weights are compared
+// together. modelID may also be a provider model ID that model matching
+// linked to a shared definition. providers, when set, limits the comparison
+// to those providers.
+func Compare(catalog *catalogs.Catalog, modelID string, providers []string) (*Comparison, error) {
+	definition, err := catalog.FindModel(modelID)
+	if err != nil {
+		return nil, err
+	}
+	identity := definition.ID
+	if definition.Lineage.Root != nil {
+		identity = *definition.Lineage.Root
+	}
+
+	wanted := make(map[catalogs.ProviderID]bool, len(providers))
+	for _, id := range providers {
+		provider, ok := catalog.Providers().Resolve(catalogs.ProviderID(strings.TrimSpace(id)))
+		if !ok {
+			return nil, &errors.NotFoundError{Resource: "provider", ID: id}
+		}
+		wanted[provider.ID] = true
+	}
+
+	comparison := &Comparison{Model: string(identity), Offerings: []Offering{}, Differences: []string{}}
+	served := make(map[catalogs.ProviderID]bool)
+	for _, linked := range catalog.Definitions() {
+		if linked.ID != identity && (linked.Lineage.Root == nil || *linked.Lineage.Root != identity) {
+			continue
+		}
+		comparison.Definitions = append(comparison.Definitions, string(linked.ID))
+		for _, offering := range catalog.DefinitionOfferings(linked.ID) {
+			if len(wanted) > 0 && !wanted[offering.ProviderID] {
+				continue
+			}
+			served[offering.ProviderID] = true
+			comparison.Offerings = append(comparison.Offerings, newOffering(catalog, offering))
+		}
+	}
+	if len(comparison.Offerings) == 0 {
+		return nil, &errors.NotFoundError{Resource: "provider offering", ID: modelID}
+	}
+	slices.SortFunc(comparison.Offerings, func(a, b Offering) int {
+		if c := strings.Compare(a.Provider, b.Provider); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Model, b.Model)
+	})
+
+	for id := range wanted {
+		if !served[id] {
+			comparison.Missing = append(comparison.Missing, string(id))
+		}
+	}
+	slices.Sort(comparison.Missing)
+
+	for _, field := range fields {
+		for _, offering := range comparison.Offerings[1:] {
+			if field.Value(offering) != field.Value(comparison.Offerings[0]) {
+				comparison.Differences = append(comparison.Differences, field.Key)
+				break
+			}
+		}
+	}
+	return comparison, nil
+}
+
+func newOffering(catalog *catalogs.Catalog, offering catalogs.ProviderOffering) Offering {
+	row := Offering{
+		Provider:     string(offering.ProviderID),
+		Model:        string(offering.ProviderModelID),
+		DefinitionID: string(offering.DefinitionID),
+		Availability: string(offering.Availability),
+		Lifecycle:    string(offering.Lifecycle),
+	}
+	if offering.Limits != nil {
+		row.ContextWindow = offering.Limits.ContextWindow
+		row.OutputTokens = offering.Limits.OutputTokens
+	}
+	if offering.Pricing != nil && offering.Pricing.Tokens != nil {
+		row.InputPer1M = per1M(offering.Pricing.Tokens.Input)
+		row.OutputPer1M = per1M(offering.Pricing.Tokens.Output)
+	}
+
+	// Serving quantization and speed are not yet part of the offering schema,
+	// so they are read from the provider's legacy model record.
+	legacy, err := catalog.LegacyV0().ProviderModel(offering.ProviderID, string(offering.ProviderModelID))
+	if err != nil {
+		return row
+	}
+	if legacy.Metadata != nil && legacy.Metadata.Architecture != nil {
+		row.Quantization = string(legacy.Metadata.Architecture.Quantization)
+	}
+	if legacy.Performance != nil {
+		row.OutputTokensPerSecond = legacy.Performance.OutputTokensPerSecond
+		row.TimeToFirstTokenMS = legacy.Performance.MedianTimeToFirstToken().Milliseconds()
+	}
+	return row
+}
+
+func per1M(cost *catalogs.ModelTokenCost) *float64 {
+	if cost == nil {
+		return nil
+	}
+	value := cost.Per1M
+	return &value
+}
+
+func price(value *float64) string {
+	if value == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+func count(value int64) string {
+	if value == 0 {
+		return "-"
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
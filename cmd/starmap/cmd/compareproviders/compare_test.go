@@ -0,0 +1,122 @@
+package compareproviders
+
+import (
+	"bytes"
+	stderrors "errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func compareProvidersTestCatalog(t *testing.T) *catalogs.Catalog {
+	t.Helper()
+	root := "llama-3.1-70b"
+	ttft := 200 * time.Millisecond
+	pricing := func(input, output float64) *catalogs.ModelPricing {
+		return &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{
+			Input:  &catalogs.ModelTokenCost{Per1M: input},
+			Output: &catalogs.ModelTokenCost{Per1M: output},
+		}}
+	}
+	builder := catalogs.NewEmpty()
+	for _, provider := range []catalogs.Provider{
+		{ID: "groq", Name: "Groq", Models: map[string]*catalogs.Model{
+			"llama-3.1-70b-versatile": {
+				ID: "llama-3.1-70b-versatile", Name: "Llama 3.1 70B Versatile",
+				Lineage:     &catalogs.ModelLineage{Root: &root},
+				Limits:      &catalogs.ModelLimits{ContextWindow: 131072, OutputTokens: 8192},
+				Pricing:     pricing(0.59, 0.79),
+				Metadata:    &catalogs.ModelMetadata{Architecture: &catalogs.ModelArchitecture{Quantization: catalogs.QuantizationFP8}},
+				Performance: &catalogs.ModelPerformance{OutputTokensPerSecond: 250, TimeToFirstToken: &catalogs.LatencyPercentiles{P50: &ttft}},
+			},
+		}},
+		{ID: "together", Name: "Together", Models: map[string]*catalogs.Model{
+			"llama-3.1-70b": {
+				ID: "llama-3.1-70b", Name: "Llama 3.1 70B",
+				Limits:   &catalogs.ModelLimits{ContextWindow: 131072, OutputTokens: 4096},
+				Pricing:  pricing(0.88, 0.88),
+				Metadata: &catalogs.ModelMetadata{Architecture: &catalogs.ModelArchitecture{Quantization: catalogs.QuantizationFP16}},
+			},
+		}},
+		{ID: "other", Name: "Other", Models: map[string]*catalogs.Model{
+			"llama-3.1-8b": {ID: "llama-3.1-8b", Name: "Llama 3.1 8B"},
+		}},
+	} {
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider(%s): %v", provider.ID, err)
+		}
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return catalog
+}
+
+func TestCompareLinksOfferingsThroughLineageRoot(t *testing.T) {
+	catalog := compareProvidersTestCatalog(t)
+	for _, modelID := range []string{"llama-3.1-70b", "llama-3.1-70b-versatile"} {
+		comparison, err := Compare(catalog, modelID, nil)
+		if err != nil {
+			t.Fatalf("Compare(%s): %v", modelID, err)
+		}
+		if comparison.Model != "llama-3.1-70b" || len(comparison.Offerings) != 2 {
+			t.Fatalf("Compare(%s) = %#v", modelID, comparison)
+		}
+		groq := comparison.Offerings[0]
+		if groq.Provider != "groq" || groq.Quantization != "fp8" || groq.OutputTokensPerSecond != 250 || groq.TimeToFirstTokenMS != 200 {
+			t.Fatalf("groq offering = %#v", groq)
+		}
+		want := []string{"output_tokens", "input_price", "output_price", "quantization", "output_tokens_per_second", "time_to_first_token"}
+		if !slices.Equal(comparison.Differences, want) {
+			t.Fatalf("differences = %v, want %v", comparison.Differences, want)
+		}
+	}
+}
+
+func TestCompareFiltersProviders(t *testing.T) {
+	catalog := compareProvidersTestCatalog(t)
+	comparison, err := Compare(catalog, "llama-3.1-70b", []string{"together", "other"})
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+	if len(comparison.Offerings) != 1 || comparison.Offerings[0].Provider != "together" {
+		t.Fatalf("offerings = %#v", comparison.Offerings)
+	}
+	if !slices.Equal(comparison.Missing, []string{"other"}) || len(comparison.Differences) != 0 {
+		t.Fatalf("comparison = %#v", comparison)
+	}
+
+	_, err = Compare(catalog, "llama-3.1-70b", []string{"nowhere"})
+	var notFound *pkgerrors.NotFoundError
+	if !stderrors.As(err, &notFound) || notFound.Resource != "provider" {
+		t.Fatalf("unknown provider err = %v", err)
+	}
+	if _, err := Compare(catalog, "gpt-4o", nil); !stderrors.As(err, &notFound) {
+		t.Fatalf("unknown model err = %v", err)
+	}
+}
+
+func TestPrintComparisonMarksDifferingColumns(t *testing.T) {
+	comparison, err := Compare(compareProvidersTestCatalog(t), "llama-3.1-70b", nil)
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+	var out bytes.Buffer
+	if err := printComparison(&out, "table", comparison); err != nil {
+		t.Fatalf("printComparison: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{"llama-3.1-70b-versatile", "fp16", "* differs across providers: output_tokens"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("output missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "CONTEXT *") || strings.Contains(text, "Context *") {
+		t.Fatalf("matching context column marked as different:\n%s", text)
+	}
+}
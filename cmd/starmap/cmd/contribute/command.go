@@ -0,0 +1,124 @@
+// Package contribute provides the contribute command, which helps community
+// contributors check catalog edits before opening a pull request.
+package contribute
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/contribute"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/table"
+)
+
+// NewCommand creates the contribute command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "contribute",
+		GroupID: "catalog",
+		Short:   "Check catalog contributions before opening a pull request",
+		Long: `Tools for contributors editing the embedded catalog under
+internal/embedded/catalog.`,
+	}
+
+	cmd.AddCommand(newCheckCommand(app))
+
+	return cmd
+}
+
+func newCheckCommand(app application.Application) *cobra.Command {
+	var (
+		catalogDir string
+		base       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check catalog edits the way CI does",
+		Long: `Check a catalog directory the way CI checks the embedded catalog and list
+every complaint, so a pull request passes on the first push.
+
+Errors fail CI:
+  schema     files decode into the catalog types; unknown fields and
+             malformed YAML are errors
+  lint       required fields, author references, value ranges, and model
+             files saved where the loader looks for them
+
+Warnings are reported but do not fail CI:
+  authority  with --base, a changed model field that a source outranks the
+             local catalog for and that is not pinned; starmap update will
+             overwrite it
+  logo       a provider or author without a logo.svg
+  sorted     providers.yaml or authors.yaml out of id order, or a file that
+             differs from the layout starmap update writes; starmap fmt
+             fixes both
+
+The command fails when any error is found.`,
+		Args: cobra.NoArgs,
+		Example: `  starmap contribute check
+  starmap contribute check --base origin/main
+  starmap contribute check --catalog-dir ./my-catalog -o json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var opts contribute.Options
+			if base != "" {
+				changes, err := contribute.GitChanges(cmd.Context(), catalogDir, base)
+				if err != nil {
+					return err
+				}
+				opts.Changes = changes
+			}
+			report, err := contribute.Check(catalogDir, opts)
+			if err != nil {
+				return err
+			}
+			if err := printReport(cmd.OutOrStdout(), app.OutputFormat(), report); err != nil {
+				return err
+			}
+			if errs := report.Errors(); errs > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d catalog errors would fail CI", errs)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&catalogDir, "catalog-dir", "internal/embedded/catalog", "Catalog directory to check")
+	cmd.Flags().StringVar(&base, "base", "", "Git revision to compare against for the authority check, e.g. origin/main")
+
+	return cmd
+}
+
+func printReport(w io.Writer, outputFormat string, report *contribute.Report) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, report)
+	}
+
+	if len(report.Findings) == 0 {
+		_, err := fmt.Fprintf(w, "%s Checked %d files in %s: no findings\n", emoji.Success, report.Files, report.Dir)
+		return err
+	}
+
+	rows := make([][]string, 0, len(report.Findings))
+	for _, finding := range report.Findings {
+		severity := emoji.Warning + " warning"
+		if finding.Severity == contribute.SeverityError {
+			severity = emoji.Error + " error"
+		}
+		rows = append(rows, []string{severity, string(finding.Rule), finding.File, finding.Message})
+	}
+	if err := format.NewFormatter(detected).Format(w, format.Data{
+		Headers:         []string{"Severity", "Rule", "File", "Message"},
+		Rows:            rows,
+		ColumnAlignment: []table.Align{table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignLeft},
+	}); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Checked %d files in %s: %d errors, %d warnings\n",
+		report.Files, report.Dir, report.Errors(), len(report.Findings)-report.Errors())
+	return err
+}
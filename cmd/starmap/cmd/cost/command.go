@@ -0,0 +1,108 @@
+// Package cost provides the cost command for estimating the price of model
+// usage.
+package cost
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/locale"
+	"github.com/agentstation/starmap/internal/cli/table"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/costs"
+)
+
+// NewCommand creates the cost command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cost",
+		GroupID: "catalog",
+		Short:   "Estimate the cost of model usage",
+		Long: `Estimate the cost of model usage from the catalog's token and operation
+prices.`,
+	}
+
+	cmd.AddCommand(newEstimateCommand(app))
+
+	return cmd
+}
+
+func newEstimateCommand(app application.Application) *cobra.Command {
+	var (
+		modelID  string
+		provider string
+		usage    costs.Usage
+	)
+
+	cmd := &cobra.Command{
+		Use:   "estimate --model <model-id>",
+		Short: "Estimate the cost of a usage on a model",
+		Long: `Estimate the cost of a usage on a model, itemized by billing component.
+
+Token counts are totals; the token flags carry a -tokens suffix because
+--output selects the output format. Cached input is billed at the cache read or write
+price when one is recorded and at the input price otherwise; reasoning tokens
+without a reasoning price are billed as output. When the prompt exceeds a
+context pricing tier, the tier's prices apply. Images are priced with the
+model's image token formula. Without --provider, the first provider by ID
+that prices the model is used.`,
+		Args: cobra.NoArgs,
+		Example: `  starmap cost estimate --model gpt-4o --input-tokens 10000 --output-tokens 2000
+  starmap cost estimate --model claude-sonnet-4-5 -p anthropic --input-tokens 2000 --cache-read-tokens 50000 --output-tokens 800
+  starmap cost estimate --model gpt-4o --input-tokens 500 --images 3 --image-width 1024 --image-height 1024 -o json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cat, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			breakdown, err := costs.NewEstimator(cat).Estimate(modelID, catalogs.ProviderID(provider), usage)
+			if err != nil {
+				return err
+			}
+			return printBreakdown(cmd.OutOrStdout(), app.OutputFormat(), breakdown)
+		},
+	}
+
+	cmd.Flags().StringVarP(&modelID, "model", "m", "", "Model to price")
+	cmd.Flags().StringVarP(&provider, "provider", "p", "",
+		"Price this provider's offering of the model (default: first priced offering)")
+	cmd.Flags().Int64Var(&usage.InputTokens, "input-tokens", 0, "Uncached input tokens")
+	cmd.Flags().Int64Var(&usage.OutputTokens, "output-tokens", 0, "Output tokens, excluding reasoning")
+	cmd.Flags().Int64Var(&usage.CacheReadTokens, "cache-read-tokens", 0, "Input tokens read from the prompt cache")
+	cmd.Flags().Int64Var(&usage.CacheWriteTokens, "cache-write-tokens", 0, "Input tokens written to the prompt cache")
+	cmd.Flags().Int64Var(&usage.ReasoningTokens, "reasoning-tokens", 0, "Internal reasoning tokens")
+	cmd.Flags().IntVar(&usage.Images, "images", 0, "Input images")
+	cmd.Flags().IntVar(&usage.ImageWidth, "image-width", 0, "Width of each input image in pixels")
+	cmd.Flags().IntVar(&usage.ImageHeight, "image-height", 0, "Height of each input image in pixels")
+	cmd.Flags().Int64Var(&usage.Requests, "requests", 0, "Requests billed a per-request fee (default 1)")
+	_ = cmd.MarkFlagRequired("model")
+
+	return cmd
+}
+
+func printBreakdown(w io.Writer, outputFormat string, breakdown *costs.Breakdown) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, breakdown)
+	}
+
+	currency := string(breakdown.Currency)
+	rows := make([][]string, 0, len(breakdown.Items)+1)
+	for _, item := range breakdown.Items {
+		rows = append(rows, []string{
+			string(item.Component),
+			locale.Number(item.Quantity),
+			locale.Decimal(item.UnitPrice, -1),
+			locale.Decimal(item.Cost, 6),
+		})
+	}
+	rows = append(rows, []string{"total", "", "", locale.Decimal(breakdown.Total, 6)})
+	return format.NewFormatter(detected).Format(w, format.Data{
+		Headers:         []string{"Component", "Quantity", "Unit Price (" + currency + ")", "Cost (" + currency + ")"},
+		Rows:            rows,
+		ColumnAlignment: []table.Align{table.AlignLeft, table.AlignRight, table.AlignRight, table.AlignRight},
+	})
+}
@@ -0,0 +1,140 @@
+// Package coverage provides the coverage command, which reports the models
+// of each author that the providers serving the author do not list.
+package coverage
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/coverage"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/table"
+	"github.com/agentstation/starmap/internal/sources/modelsdev"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// missingPreview is how many missing models the table lists per row before
+// summarizing the rest; wide output lists them all.
+const missingPreview = 3
+
+// NewCommand creates the coverage command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var (
+		authorID   string
+		providerID string
+		gapsOnly   bool
+		offline    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "coverage",
+		GroupID: "catalog",
+		Short:   "Report author models missing from providers",
+		Long: `Show, for each model author, which of the author's known models are missing
+from each provider that serves the author.
+
+An author's known models are its models in the catalog plus the models.dev
+models its attribution rules in authors.yaml match. A provider serves an
+author when it lists at least one of them. Model IDs are compared ignoring
+case and any organization prefix. Use --offline to skip models.dev and
+compare against the catalog alone.`,
+		Args: cobra.NoArgs,
+		Example: `  starmap coverage
+  starmap coverage --author meta --gaps
+  starmap coverage -p groq -o wide
+  starmap coverage --offline -o json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cat, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+
+			var reference catalogs.Reader
+			if !offline {
+				src := modelsdev.NewHTTPSource()
+				observation, err := src.Observe(cmd.Context())
+				if err != nil {
+					app.Logger().Warn().Err(err).Str("source", src.ID().String()).Msg("Source observation had errors")
+				}
+				if cleanupErr := src.Cleanup(); cleanupErr != nil {
+					app.Logger().Warn().Err(cleanupErr).Str("source", src.ID().String()).Msg("Source cleanup failed")
+				}
+				if observation.Catalog != nil {
+					reference = observation.Catalog
+				}
+			}
+
+			report, err := coverage.Build(cat, reference)
+			if err != nil {
+				return err
+			}
+			gaps := report.Gaps[:0]
+			for _, gap := range report.Gaps {
+				if authorID != "" && gap.Author != catalogs.AuthorID(authorID) {
+					continue
+				}
+				if providerID != "" && gap.Provider != catalogs.ProviderID(providerID) {
+					continue
+				}
+				if gapsOnly && len(gap.Missing) == 0 {
+					continue
+				}
+				gaps = append(gaps, gap)
+			}
+			report.Gaps = gaps
+			return printReport(cmd.OutOrStdout(), app.OutputFormat(), report)
+		},
+	}
+
+	cmd.Flags().StringVarP(&authorID, "author", "a", "", "Only report this author")
+	cmd.Flags().StringVarP(&providerID, "provider", "p", "", "Only report this provider")
+	cmd.Flags().BoolVar(&gapsOnly, "gaps", false, "Only list providers missing at least one model")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Compare against the catalog only, without models.dev")
+
+	return cmd
+}
+
+func printReport(w io.Writer, outputFormat string, report *coverage.Report) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, report)
+	}
+	if len(report.Gaps) == 0 {
+		_, err := fmt.Fprintln(w, "No providers serve the selected authors.")
+		return err
+	}
+
+	rows := make([][]string, 0, len(report.Gaps))
+	for _, gap := range report.Gaps {
+		rows = append(rows, []string{
+			string(gap.Author),
+			string(gap.Provider),
+			strconv.Itoa(gap.Listed),
+			strconv.Itoa(gap.Known),
+			strconv.FormatFloat(gap.Coverage()*100, 'f', 0, 64) + "%",
+			missingList(gap.Missing, detected == format.FormatWide),
+		})
+	}
+	return format.NewFormatter(detected).Format(w, format.Data{
+		Headers: []string{"Author", "Provider", "Listed", "Known", "Coverage", "Missing"},
+		Rows:    rows,
+		ColumnAlignment: []table.Align{
+			table.AlignLeft, table.AlignLeft, table.AlignRight, table.AlignRight, table.AlignRight, table.AlignLeft,
+		},
+	})
+}
+
+func missingList(missing []string, all bool) string {
+	if len(missing) == 0 {
+		return "-"
+	}
+	if all || len(missing) <= missingPreview {
+		return strings.Join(missing, ", ")
+	}
+	return fmt.Sprintf("%s, +%d more", strings.Join(missing[:missingPreview], ", "), len(missing)-missingPreview)
+}
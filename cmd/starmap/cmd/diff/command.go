@@ -0,0 +1,120 @@
+// Package diff provides the diff command for comparing two catalogs.
+package diff
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/differ"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// FormatHTML renders the diff as a standalone HTML report.
+const FormatHTML = "html"
+
+const (
+	embeddedLabel = "embedded"
+	activeLabel   = "active"
+)
+
+// NewCommand creates the diff command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var from, to string
+
+	cmd := &cobra.Command{
+		Use:     "diff [report-file]",
+		GroupID: "catalog",
+		Short:   "Show changes between two catalogs",
+		Long: `Show the model, provider, and author changes between two catalogs.
+
+By default the embedded catalog shipped with this binary is compared with the
+active catalog, which shows what updates have changed locally. Use --from and
+--to to compare editable YAML catalog directories instead.
+
+With -o html the diff is rendered as a standalone HTML report with summary
+charts, color-coded field changes, and a collapsible section per model, for
+readers who won't read terminal diffs. The report is written to report-file,
+or to stdout when no file is given. Other formats (table, json, yaml) are
+written the same way.`,
+		Example: `  starmap diff                                   # Embedded vs active catalog
+  starmap diff --output html report.html         # Standalone HTML report
+  starmap diff --from ./old --to ./new -o json   # Compare two catalog directories`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			base, baseLabel, err := loadCatalog(app, from, embeddedLabel)
+			if err != nil {
+				return err
+			}
+			target, targetLabel, err := loadCatalog(app, to, activeLabel)
+			if err != nil {
+				return err
+			}
+			report := NewReport(baseLabel, targetLabel, differ.New().Catalogs(base, target))
+
+			if len(args) == 0 {
+				return printReport(cmd.OutOrStdout(), app.OutputFormat(), report)
+			}
+			var buf bytes.Buffer
+			if err := printReport(&buf, app.OutputFormat(), report); err != nil {
+				return err
+			}
+			if err := os.WriteFile(args[0], buf.Bytes(), constants.FilePermissions); err != nil {
+				return errors.WrapIO("write", args[0], err)
+			}
+			cmd.PrintErrf("Wrote diff report to %s (%d changes)\n", args[0], report.Summary.Total)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "",
+		"Base catalog directory (default: embedded catalog)")
+	cmd.Flags().StringVar(&to, "to", "",
+		"Target catalog directory (default: active catalog)")
+
+	return cmd
+}
+
+// loadCatalog loads the catalog at dir, or the default catalog named by
+// label when dir is empty.
+func loadCatalog(app application.Application, dir, label string) (catalogs.Reader, string, error) {
+	if dir != "" {
+		builder, err := catalogs.NewFromPath(dir)
+		if err != nil {
+			return nil, "", errors.WrapResource("load", "catalog", dir, err)
+		}
+		return builder, dir, nil
+	}
+	if label == activeLabel {
+		catalog, err := app.Catalog()
+		return catalog, label, err
+	}
+	builder, err := catalogs.NewEmbedded()
+	if err != nil {
+		return nil, "", errors.WrapResource("load", "catalog", label, err)
+	}
+	return builder, label, nil
+}
+
+func printReport(w io.Writer, outputFormat string, report *Report) error {
+	switch detected := format.DetectFormat(outputFormat); detected {
+	case FormatHTML:
+		return WriteHTML(w, report)
+	case format.FormatJSON, format.FormatYAML:
+		return format.NewFormatter(detected).Format(w, report)
+	case format.FormatTable, format.FormatWide:
+		return WriteText(w, report, false)
+	default:
+		return &errors.ValidationError{
+			Field:   "output",
+			Value:   outputFormat,
+			Message: "unsupported format (use table, json, yaml, or html)",
+		}
+	}
+}
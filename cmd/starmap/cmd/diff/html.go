@@ -0,0 +1,68 @@
+package diff
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"math"
+
+	"github.com/agentstation/starmap/pkg/differ"
+)
+
+//go:embed report.html
+var reportTemplateText string
+
+// reportTemplate renders a self-contained page: styles are inline and charts
+// are plain HTML bars, so the report opens offline and can be attached or
+// mailed as a single file.
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": percent,
+	"label": func(changeType differ.ChangeType) string {
+		return changeLabels[changeType]
+	},
+}).Parse(reportTemplateText))
+
+// changeLabels name change types in the report.
+var changeLabels = map[differ.ChangeType]string{
+	differ.ChangeTypeAdd:    "added",
+	differ.ChangeTypeUpdate: "updated",
+	differ.ChangeTypeRemove: "removed",
+}
+
+// chartRow is one bar of a summary chart.
+type chartRow struct {
+	Label  string
+	Counts Counts
+}
+
+// WriteHTML renders report as a standalone HTML document.
+func WriteHTML(w io.Writer, report *Report) error {
+	rows := []chartRow{
+		{Label: "Models", Counts: report.Summary.Models},
+		{Label: "Providers", Counts: report.Summary.Providers},
+		{Label: "Authors", Counts: report.Summary.Authors},
+	}
+	scale := 0
+	for _, row := range rows {
+		scale = max(scale, row.Counts.Total())
+	}
+	fieldScale := 0
+	if len(report.Fields) > 0 {
+		fieldScale = report.Fields[0].Count
+	}
+	return reportTemplate.Execute(w, struct {
+		*Report
+		Chart      []chartRow
+		Scale      int
+		FieldScale int
+	}{report, rows, scale, fieldScale})
+}
+
+// percent returns n as a percentage of total, to one decimal place, for chart
+// bar widths.
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)*1000/float64(total)) / 10
+}
@@ -0,0 +1,251 @@
+package diff
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/differ"
+)
+
+// maxFieldCounts bounds the most-changed fields listed in a report.
+const maxFieldCounts = 10
+
+// Report is a catalog changeset prepared for rendering.
+type Report struct {
+	From        string       `json:"from" yaml:"from"`
+	To          string       `json:"to" yaml:"to"`
+	GeneratedAt time.Time    `json:"generated_at" yaml:"generated_at"`
+	Summary     Summary      `json:"summary" yaml:"summary"`
+	Models      []Entry      `json:"models,omitempty" yaml:"models,omitempty"`
+	Providers   []Entry      `json:"providers,omitempty" yaml:"providers,omitempty"`
+	Authors     []Entry      `json:"authors,omitempty" yaml:"authors,omitempty"`
+	Fields      []FieldCount `json:"fields,omitempty" yaml:"fields,omitempty"` // Most changed fields, most first
+}
+
+// Summary counts the changes in a report.
+type Summary struct {
+	Models    Counts `json:"models" yaml:"models"`
+	Providers Counts `json:"providers" yaml:"providers"`
+	Authors   Counts `json:"authors" yaml:"authors"`
+	Total     int    `json:"total" yaml:"total"`
+}
+
+// Counts counts added, updated, and removed resources of one kind.
+type Counts struct {
+	Added   int `json:"added" yaml:"added"`
+	Updated int `json:"updated" yaml:"updated"`
+	Removed int `json:"removed" yaml:"removed"`
+}
+
+// Total returns the number of changed resources.
+func (c Counts) Total() int {
+	return c.Added + c.Updated + c.Removed
+}
+
+// Entry is one added, updated, or removed resource.
+type Entry struct {
+	ID       string            `json:"id" yaml:"id"`
+	Provider string            `json:"provider,omitempty" yaml:"provider,omitempty"` // Provider scope of a model, when known
+	Type     differ.ChangeType `json:"type" yaml:"type"`
+	Changes  []Change          `json:"changes,omitempty" yaml:"changes,omitempty"` // Field changes of an update
+}
+
+// Name returns the entry ID qualified by its provider, when known.
+func (e Entry) Name() string {
+	if e.Provider == "" {
+		return e.ID
+	}
+	return e.Provider + "/" + e.ID
+}
+
+// Change is one field change of an updated resource.
+type Change struct {
+	Field    string            `json:"field" yaml:"field"`
+	Type     differ.ChangeType `json:"type" yaml:"type"`
+	OldValue string            `json:"old_value,omitempty" yaml:"old_value,omitempty"`
+	NewValue string            `json:"new_value,omitempty" yaml:"new_value,omitempty"`
+}
+
+// FieldCount counts the changes to one top-level field across resources.
+type FieldCount struct {
+	Field string `json:"field" yaml:"field"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+// NewReport prepares changeset for rendering; from and to label the compared
+// catalogs.
+func NewReport(from, to string, changeset *differ.Changeset) *Report {
+	report := &Report{From: from, To: to, GeneratedAt: time.Now().UTC()}
+	fields := map[string]int{}
+
+	if models := changeset.Models; models != nil {
+		report.Models = append(report.Models, modelEntries(models.AddedScoped, models.Added, differ.ChangeTypeAdd)...)
+		for _, update := range models.Updated {
+			report.Models = append(report.Models, updateEntry(update.ID, string(update.ProviderID), update.Changes, fields))
+		}
+		report.Models = append(report.Models, modelEntries(models.RemovedScoped, models.Removed, differ.ChangeTypeRemove)...)
+	}
+	if providers := changeset.Providers; providers != nil {
+		for _, provider := range providers.Added {
+			report.Providers = append(report.Providers, Entry{ID: string(provider.ID), Type: differ.ChangeTypeAdd})
+		}
+		for _, update := range providers.Updated {
+			report.Providers = append(report.Providers, updateEntry(string(update.ID), "", update.Changes, fields))
+		}
+		for _, provider := range providers.Removed {
+			report.Providers = append(report.Providers, Entry{ID: string(provider.ID), Type: differ.ChangeTypeRemove})
+		}
+	}
+	if authors := changeset.Authors; authors != nil {
+		for _, author := range authors.Added {
+			report.Authors = append(report.Authors, Entry{ID: string(author.ID), Type: differ.ChangeTypeAdd})
+		}
+		for _, update := range authors.Updated {
+			report.Authors = append(report.Authors, updateEntry(string(update.ID), "", update.Changes, fields))
+		}
+		for _, author := range authors.Removed {
+			report.Authors = append(report.Authors, Entry{ID: string(author.ID), Type: differ.ChangeTypeRemove})
+		}
+	}
+
+	for _, entries := range [][]Entry{report.Models, report.Providers, report.Authors} {
+		slices.SortStableFunc(entries, func(a, b Entry) int {
+			return cmp.Compare(a.Name(), b.Name())
+		})
+	}
+	report.Summary = Summary{
+		Models:    countEntries(report.Models),
+		Providers: countEntries(report.Providers),
+		Authors:   countEntries(report.Authors),
+	}
+	report.Summary.Total = report.Summary.Models.Total() + report.Summary.Providers.Total() + report.Summary.Authors.Total()
+	report.Fields = topFields(fields)
+	return report
+}
+
+// modelEntries lists added or removed models, preferring the provider-scoped
+// form when the changeset carries it.
+func modelEntries(scoped []differ.ModelChange, models []catalogs.Model, changeType differ.ChangeType) []Entry {
+	entries := make([]Entry, 0, max(len(scoped), len(models)))
+	if len(scoped) > 0 {
+		for _, change := range scoped {
+			entries = append(entries, Entry{ID: change.Model.ID, Provider: string(change.ProviderID), Type: changeType})
+		}
+		return entries
+	}
+	for _, model := range models {
+		entries = append(entries, Entry{ID: model.ID, Type: changeType})
+	}
+	return entries
+}
+
+func updateEntry(id, provider string, changes []differ.FieldChange, fields map[string]int) Entry {
+	entry := Entry{ID: id, Provider: provider, Type: differ.ChangeTypeUpdate}
+	for _, change := range changes {
+		entry.Changes = append(entry.Changes, Change{
+			Field:    change.Field(),
+			Type:     change.Type,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+		field, _, _ := strings.Cut(change.Path, ".")
+		fields[field]++
+	}
+	return entry
+}
+
+func countEntries(entries []Entry) Counts {
+	var counts Counts
+	for _, entry := range entries {
+		switch entry.Type {
+		case differ.ChangeTypeAdd:
+			counts.Added++
+		case differ.ChangeTypeUpdate:
+			counts.Updated++
+		case differ.ChangeTypeRemove:
+			counts.Removed++
+		}
+	}
+	return counts
+}
+
+func topFields(fields map[string]int) []FieldCount {
+	counts := make([]FieldCount, 0, len(fields))
+	for field, count := range fields {
+		counts = append(counts, FieldCount{Field: field, Count: count})
+	}
+	slices.SortFunc(counts, func(a, b FieldCount) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		return cmp.Compare(a.Field, b.Field)
+	})
+	if len(counts) > maxFieldCounts {
+		counts = counts[:maxFieldCounts]
+	}
+	return counts
+}
+
+// changeSymbols mark entries and field changes in text output.
+var changeSymbols = map[differ.ChangeType]string{
+	differ.ChangeTypeAdd:    "+",
+	differ.ChangeTypeUpdate: "~",
+	differ.ChangeTypeRemove: "-",
+}
+
+// changeColors are the ANSI colors of entries and field changes in colored
+// text output.
+var changeColors = map[differ.ChangeType]string{
+	differ.ChangeTypeAdd:    "\033[32m", // Green
+	differ.ChangeTypeUpdate: "\033[33m", // Yellow
+	differ.ChangeTypeRemove: "\033[31m", // Red
+}
+
+const colorReset = "\033[0m"
+
+// WriteText writes report as plain text, one line per changed resource and
+// field. With color, lines are colored by change type for terminals.
+func WriteText(w io.Writer, report *Report, color bool) error {
+	paint := func(changeType differ.ChangeType, line string) string {
+		if !color {
+			return line
+		}
+		return changeColors[changeType] + line + colorReset
+	}
+	if _, err := fmt.Fprintf(w, "Comparing %s → %s\n", report.From, report.To); err != nil {
+		return err
+	}
+	if report.Summary.Total == 0 {
+		_, err := fmt.Fprintln(w, "No changes")
+		return err
+	}
+	sections := []struct {
+		title   string
+		counts  Counts
+		entries []Entry
+	}{
+		{"Models", report.Summary.Models, report.Models},
+		{"Providers", report.Summary.Providers, report.Providers},
+		{"Authors", report.Summary.Authors, report.Authors},
+	}
+	for _, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "\n%s: %d added, %d updated, %d removed\n",
+			section.title, section.counts.Added, section.counts.Updated, section.counts.Removed)
+		for _, entry := range section.entries {
+			_, _ = fmt.Fprintf(w, "  %s\n", paint(entry.Type, changeSymbols[entry.Type]+" "+entry.Name()))
+			for _, change := range entry.Changes {
+				_, _ = fmt.Fprintf(w, "      %s\n", paint(change.Type,
+					fmt.Sprintf("%s %s: %s → %s", changeSymbols[change.Type], change.Field, change.OldValue, change.NewValue)))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package diff
+
+import (
+	"bytes"
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/differ"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func diffTestReport(t *testing.T) *Report {
+	t.Helper()
+	build := func(providers ...catalogs.Provider) *catalogs.Catalog {
+		builder := catalogs.NewEmpty()
+		for _, provider := range providers {
+			if err := builder.SetProvider(provider); err != nil {
+				t.Fatalf("SetProvider: %v", err)
+			}
+		}
+		catalog, err := builder.Build()
+		if err != nil {
+			t.Fatalf("Build: %v", err)
+		}
+		return catalog
+	}
+	base := build(catalogs.Provider{ID: "openai", Name: "OpenAI", Models: map[string]*catalogs.Model{
+		"gpt-4o": {ID: "gpt-4o", Name: "GPT-4o", Features: &catalogs.ModelFeatures{Modalities: catalogs.ModelModalities{
+			Input: []catalogs.ModelModality{catalogs.ModelModalityText},
+		}}},
+		"gpt-3.5": {ID: "gpt-3.5", Name: "GPT-3.5"},
+	}})
+	target := build(catalogs.Provider{ID: "openai", Name: "OpenAI", Models: map[string]*catalogs.Model{
+		"gpt-4o": {ID: "gpt-4o", Name: "GPT-4o <omni>", Features: &catalogs.ModelFeatures{Modalities: catalogs.ModelModalities{
+			Input: []catalogs.ModelModality{catalogs.ModelModalityText, catalogs.ModelModalityImage},
+		}}},
+		"gpt-5": {ID: "gpt-5", Name: "GPT-5"},
+	}})
+	return NewReport("embedded", "active", differ.New().Catalogs(base, target))
+}
+
+func TestNewReport(t *testing.T) {
+	report := diffTestReport(t)
+
+	if report.Summary.Models != (Counts{Added: 1, Updated: 1, Removed: 1}) || report.Summary.Total != 3 {
+		t.Fatalf("summary = %+v", report.Summary)
+	}
+	var names []string
+	for _, entry := range report.Models {
+		names = append(names, entry.Name())
+	}
+	if got := strings.Join(names, ","); got != "openai/gpt-3.5,openai/gpt-4o,openai/gpt-5" {
+		t.Fatalf("models = %s", got)
+	}
+	updated := report.Models[1]
+	if updated.Type != differ.ChangeTypeUpdate || len(updated.Changes) != 2 {
+		t.Fatalf("updated entry = %+v", updated)
+	}
+	if field := updated.Changes[1].Field; field != "features.modalities.input[image]" {
+		t.Fatalf("element change field = %q", field)
+	}
+	if len(report.Fields) != 2 || report.Fields[0].Field != "features" {
+		t.Fatalf("fields = %+v", report.Fields)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printReport(&buf, FormatHTML, diffTestReport(t)); err != nil {
+		t.Fatalf("printReport: %v", err)
+	}
+	html := buf.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		`<span class="badge add">added</span>openai/gpt-5`,
+		`<span class="badge remove">removed</span>openai/gpt-3.5`,
+		`<tr class="add"><td class="field">features.modalities.input[image]</td>`,
+		"GPT-4o &lt;omni&gt;",
+		`class="add" style="width: 33.3%"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	if strings.Contains(html, "<script") || strings.Contains(html, "http") {
+		t.Error("report should be standalone without scripts or external resources")
+	}
+}
+
+func TestPrintReportFormats(t *testing.T) {
+	report := diffTestReport(t)
+
+	var text bytes.Buffer
+	if err := printReport(&text, "table", report); err != nil {
+		t.Fatalf("printReport(table): %v", err)
+	}
+	if !strings.Contains(text.String(), "+ openai/gpt-5") || !strings.Contains(text.String(), "~ name: GPT-4o → GPT-4o <omni>") {
+		t.Errorf("text report = %s", text.String())
+	}
+	if strings.Contains(text.String(), "\033[") {
+		t.Error("table report should not be colored")
+	}
+
+	var colored bytes.Buffer
+	if err := WriteText(&colored, report, true); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	if !strings.Contains(colored.String(), changeColors[differ.ChangeTypeAdd]+"+ openai/gpt-5"+colorReset) ||
+		!strings.Contains(colored.String(), changeColors[differ.ChangeTypeRemove]+"- openai/gpt-3.5"+colorReset) {
+		t.Errorf("colored report = %q", colored.String())
+	}
+
+	var validationErr *pkgerrors.ValidationError
+	if err := printReport(&bytes.Buffer{}, "csv", report); !stderrors.As(err, &validationErr) {
+		t.Fatalf("printReport(csv) = %v, want *errors.ValidationError", err)
+	}
+}
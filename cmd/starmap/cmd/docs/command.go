@@ -0,0 +1,157 @@
+// Package docs provides the docs command, which checks the repository's
+// markdown documentation before it ships.
+package docs
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/table"
+	"github.com/agentstation/starmap/internal/docscheck"
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/constants"
+)
+
+// NewCommand creates the docs command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "docs",
+		GroupID: "development",
+		Short:   "Check the repository's documentation and generate catalog references",
+		Long: `Tools for the markdown documentation shipped with starmap: checking the
+guides under docs/ and the package READMEs gomarkdoc generates, and
+generating a single-page reference of the catalog.`,
+	}
+
+	cmd.AddCommand(newVerifyCommand(app))
+	cmd.AddCommand(newReferenceCommand(app))
+
+	return cmd
+}
+
+func newVerifyCommand(app application.Application) *cobra.Command {
+	var (
+		external bool
+		noCache  bool
+		cacheTTL time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify [path...]",
+		Short: "Check markdown for broken links and missing images",
+		Long: `Check every markdown file under each path (default: the current directory)
+and list each broken link, so broken documentation fails CI instead of
+shipping. Hidden, vendor, node_modules, and testdata directories are skipped.
+
+Errors fail the command:
+  link      a relative link names a file that does not exist
+  anchor    a link fragment names no heading or <a name> anchor in its file
+  asset     an image, such as a provider logo, does not exist
+
+With --external, every http and https URL is also requested:
+  external  a URL responds 4xx; network errors, 429, and 5xx responses are
+            warnings, since they often pass on their own
+
+External results are cached in ~/.starmap/cache/docs-links.json. Only URLs
+that responded successfully are cached, for --cache-ttl, so a broken URL is
+requested again on every run until it is fixed.`,
+		Example: `  starmap docs verify
+  starmap docs verify docs README.md
+  starmap docs verify --external --cache-ttl 72h -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"."}
+			}
+			opts := docscheck.Options{External: external}
+			if external {
+				checker := &docscheck.HTTPChecker{CacheTTL: cacheTTL}
+				if !noCache {
+					checker.CachePath = filepath.Join(paths.ExpandHome(constants.DefaultCachePath), "docs-links.json")
+				}
+				opts.Checker = checker
+			}
+
+			reports := make([]*docscheck.Report, 0, len(args))
+			errs := 0
+			for _, path := range args {
+				report, err := docscheck.Check(cmd.Context(), path, opts)
+				if err != nil {
+					return err
+				}
+				reports = append(reports, report)
+				errs += report.Errors()
+			}
+			if err := printReports(cmd.OutOrStdout(), app.OutputFormat(), reports); err != nil {
+				return err
+			}
+			if errs > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d broken documentation links", errs)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&external, "external", false, "Also request external http and https URLs")
+	cmd.Flags().DurationVar(&cacheTTL, "cache-ttl", docscheck.DefaultCacheTTL, "How long a successful external check is reused")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Request every external URL, ignoring cached results")
+
+	return cmd
+}
+
+func printReports(w io.Writer, outputFormat string, reports []*docscheck.Report) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		if len(reports) == 1 {
+			return format.NewFormatter(detected).Format(w, reports[0])
+		}
+		return format.NewFormatter(detected).Format(w, reports)
+	}
+
+	rows := [][]string{}
+	for _, report := range reports {
+		for _, finding := range report.Findings {
+			severity := emoji.Warning + " warning"
+			if finding.Severity == docscheck.SeverityError {
+				severity = emoji.Error + " error"
+			}
+			location := finding.File + ":" + strconv.Itoa(finding.Line)
+			rows = append(rows, []string{severity, string(finding.Rule), location, finding.Target, finding.Message})
+		}
+	}
+	if len(rows) > 0 {
+		if err := format.NewFormatter(detected).Format(w, format.Data{
+			Headers:         []string{"Severity", "Rule", "Location", "Target", "Message"},
+			Rows:            rows,
+			ColumnAlignment: []table.Align{table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignLeft},
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, report := range reports {
+		summary := fmt.Sprintf("Checked %d links in %d files under %s", report.Links, report.Files, report.Root)
+		if report.CacheHits > 0 {
+			summary += fmt.Sprintf(" (%d external from cache)", report.CacheHits)
+		}
+		if len(report.Findings) == 0 {
+			if _, err := fmt.Fprintf(w, "%s %s: no findings\n", emoji.Success, summary); err != nil {
+				return err
+			}
+			continue
+		}
+		errs := report.Errors()
+		if _, err := fmt.Fprintf(w, "%s: %d errors, %d warnings\n", summary, errs, len(report.Findings)-errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
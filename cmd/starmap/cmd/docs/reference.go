@@ -0,0 +1,343 @@
+package docs
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/query"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/docscheck"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+func newReferenceCommand(app application.Application) *cobra.Command {
+	var (
+		outputFile     string
+		pdfFile        string
+		includePrivate bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "reference",
+		Short: "Generate a single-page reference of every provider and model",
+		Long: `Write one markdown page listing every provider in the catalog and, for
+each, every model it serves with its context window, prices, and main
+capabilities. The page is meant for offline review and procurement
+discussions, where browsing the CLI or API is not an option.
+
+The page has no timestamp, so regenerating it from the same catalog gives the
+same bytes. With --pdf the page is also converted with pandoc, which must be
+installed separately. Model fields marked private in the catalog overlay are
+left out unless --include-private is set.`,
+		Example: `  starmap docs reference
+  starmap docs reference -f REFERENCE.md
+  starmap docs reference -f REFERENCE.md --pdf REFERENCE.pdf`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if pdfFile != "" && outputFile == "" {
+				return &errors.ValidationError{
+					Field:   "pdf",
+					Value:   pdfFile,
+					Message: "--pdf converts the markdown file, so --file is required",
+				}
+			}
+			catalog, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			if !includePrivate {
+				if catalog, err = catalog.Public(); err != nil {
+					return err
+				}
+			}
+
+			if outputFile == "" {
+				return writeReference(cmd.OutOrStdout(), catalog)
+			}
+			if err := writeReferenceFile(outputFile, catalog); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s Wrote %s\n", emoji.Success, outputFile)
+			if pdfFile == "" {
+				return nil
+			}
+			if err := convertToPDF(cmd, outputFile, pdfFile); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s Wrote %s\n", emoji.Success, pdfFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFile, "file", "f", "", "Write the reference to a file instead of stdout")
+	cmd.Flags().StringVar(&pdfFile, "pdf", "", "Also convert the reference to a PDF with pandoc")
+	cmd.Flags().BoolVar(&includePrivate, "include-private", false, "Include model fields marked private, for internal use")
+
+	return cmd
+}
+
+func writeReferenceFile(path string, catalog catalogs.Reader) error {
+	var b strings.Builder
+	if err := writeReference(&b, catalog); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(b.String()), constants.FilePermissions); err != nil {
+		return errors.WrapIO("write", path, err)
+	}
+	return nil
+}
+
+// convertToPDF runs pandoc on the generated markdown. Pandoc is optional, so
+// its absence is reported as a configuration problem rather than a failure
+// of the reference itself.
+func convertToPDF(cmd *cobra.Command, markdownPath, pdfPath string) error {
+	if _, err := exec.LookPath("pandoc"); err != nil {
+		return &errors.ConfigError{
+			Component: "docs reference",
+			Message:   "pandoc is required for --pdf; install it from https://pandoc.org/installing.html or omit --pdf",
+		}
+	}
+	pandoc := exec.CommandContext(cmd.Context(), "pandoc", markdownPath, "--from", "gfm", "--output", pdfPath) //nolint:gosec // pandoc executable is fixed; paths are passed as arguments.
+	if output, err := pandoc.CombinedOutput(); err != nil {
+		return &errors.ProcessError{Operation: "pandoc", Command: "pandoc", Output: string(output), Err: err}
+	}
+	return nil
+}
+
+// referenceProvider is one provider section of the reference.
+type referenceProvider struct {
+	provider *catalogs.Provider
+	models   []catalogs.Model
+}
+
+// writeReference renders the reference page: a contents table with one row
+// per provider, then one section per provider with a row per model.
+// Providers and models are ordered by ID. Benchmark score columns are added
+// only when some model has evaluations, so catalogs without them render as
+// before.
+func writeReference(w io.Writer, catalog catalogs.Reader) error {
+	providers := catalog.Providers().List()
+	slices.SortFunc(providers, func(a, b catalogs.Provider) int { return cmp.Compare(a.ID, b.ID) })
+
+	sections := make([]referenceProvider, 0, len(providers))
+	total := 0
+	evaluations := false
+	for i := range providers {
+		provider := &providers[i]
+		models, err := query.CatalogModels(catalog, string(provider.ID))
+		if err != nil {
+			return err
+		}
+		slices.SortFunc(models, func(a, b catalogs.Model) int { return cmp.Compare(a.ID, b.ID) })
+		sections = append(sections, referenceProvider{provider: provider, models: models})
+		evaluations = evaluations || slices.ContainsFunc(models, func(model catalogs.Model) bool {
+			return !model.Evaluations.IsEmpty()
+		})
+		total += len(models)
+	}
+
+	var b strings.Builder
+	b.WriteString("# Starmap Catalog Reference\n\n")
+	fmt.Fprintf(&b, "%d providers serving %d models. Prices are USD per 1M tokens; `-` means the catalog has no value.\n\n", len(sections), total)
+
+	b.WriteString("## Providers\n\n")
+	rows := make([][]string, 0, len(sections))
+	for _, section := range sections {
+		rows = append(rows, []string{
+			fmt.Sprintf("[%s](#%s)", section.provider.Name, docscheck.Slug(sectionHeading(section.provider))),
+			"`" + string(section.provider.ID) + "`",
+			strconv.Itoa(len(section.models)),
+			providerAPIKey(section.provider),
+		})
+	}
+	writeMarkdownTable(&b, []string{"Provider", "ID", "Models", "API key"}, rows)
+
+	headers := []string{"Model", "Name", "Context", "Output", "Input $", "Output $", "Capabilities"}
+	if evaluations {
+		headers = append(headers, "MMLU", "Arena Elo", "HumanEval")
+	}
+	for _, section := range sections {
+		fmt.Fprintf(&b, "\n## %s\n\n", sectionHeading(section.provider))
+		if details := providerDetails(section.provider); details != "" {
+			b.WriteString(details + "\n\n")
+		}
+		if len(section.models) == 0 {
+			b.WriteString("No models in the catalog.\n")
+			continue
+		}
+		rows := make([][]string, 0, len(section.models))
+		for _, model := range section.models {
+			row := referenceModelRow(model)
+			if evaluations {
+				row = append(row, evaluationColumns(model.Evaluations)...)
+			}
+			rows = append(rows, row)
+		}
+		writeMarkdownTable(&b, headers, rows)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return errors.WrapIO("write", "reference", err)
+	}
+	return nil
+}
+
+func sectionHeading(provider *catalogs.Provider) string {
+	return fmt.Sprintf("%s (%s)", provider.Name, provider.ID)
+}
+
+func providerAPIKey(provider *catalogs.Provider) string {
+	if provider.APIKey == nil || provider.APIKey.Name == "" {
+		return "-"
+	}
+	return "`" + provider.APIKey.Name + "`"
+}
+
+// providerDetails is the one-line summary under a provider heading.
+func providerDetails(provider *catalogs.Provider) string {
+	var parts []string
+	if provider.Headquarters != nil && *provider.Headquarters != "" {
+		parts = append(parts, *provider.Headquarters)
+	}
+	if provider.Catalog != nil && provider.Catalog.Docs != nil && *provider.Catalog.Docs != "" {
+		parts = append(parts, fmt.Sprintf("[docs](%s)", *provider.Catalog.Docs))
+	}
+	if provider.StatusPageURL != nil && *provider.StatusPageURL != "" {
+		parts = append(parts, fmt.Sprintf("[status](%s)", *provider.StatusPageURL))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func referenceModelRow(model catalogs.Model) []string {
+	var contextWindow, outputTokens int64
+	if model.Limits != nil {
+		contextWindow = model.Limits.ContextWindow
+		outputTokens = model.Limits.OutputTokens
+	}
+	var input, output *float64
+	if model.Pricing != nil && model.Pricing.Tokens != nil {
+		if model.Pricing.Tokens.Input != nil {
+			input = &model.Pricing.Tokens.Input.Per1M
+		}
+		if model.Pricing.Tokens.Output != nil {
+			output = &model.Pricing.Tokens.Output.Per1M
+		}
+	}
+	name := model.Name
+	if name == model.ID {
+		name = ""
+	}
+	return []string{
+		"`" + model.ID + "`",
+		orDash(name),
+		orDash(compactCount(contextWindow)),
+		orDash(compactCount(outputTokens)),
+		formatPrice(input),
+		formatPrice(output),
+		orDash(strings.Join(modelCapabilities(model), ", ")),
+	}
+}
+
+// modelCapabilities lists the capabilities buyers compare first, plus the
+// model class when the model is not a chat model.
+func modelCapabilities(model catalogs.Model) []string {
+	var capabilities []string
+	if class := model.ClassOrDefault(); class != catalogs.ModelClassChat {
+		capabilities = append(capabilities, string(class))
+	}
+	features := model.Features
+	if features == nil {
+		return capabilities
+	}
+	if features.Tools || features.ToolCalls {
+		capabilities = append(capabilities, "tools")
+	}
+	if slices.Contains(features.Modalities.Input, catalogs.ModelModalityImage) {
+		capabilities = append(capabilities, "vision")
+	}
+	if features.Reasoning {
+		capabilities = append(capabilities, "reasoning")
+	}
+	if features.WebSearch {
+		capabilities = append(capabilities, "web search")
+	}
+	if features.StructuredOutputs {
+		capabilities = append(capabilities, "structured output")
+	}
+	return capabilities
+}
+
+// evaluationColumns returns the MMLU, Arena Elo, and HumanEval cells.
+func evaluationColumns(evaluations *catalogs.ModelEvaluations) []string {
+	if evaluations == nil {
+		return []string{"-", "-", "-"}
+	}
+	return []string{formatScore(evaluations.MMLU), formatScore(evaluations.ArenaElo), formatScore(evaluations.HumanEval)}
+}
+
+func formatScore(value *float64) string {
+	if value == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+// compactCount writes token counts the way model cards do: 128K, 1M.
+func compactCount(n int64) string {
+	switch {
+	case n <= 0:
+		return ""
+	case n >= 1_000_000 && n%100_000 == 0:
+		return strconv.FormatFloat(float64(n)/1_000_000, 'f', -1, 64) + "M"
+	case n >= 1_000 && n%1_000 == 0:
+		return strconv.FormatInt(n/1_000, 10) + "K"
+	default:
+		return strconv.FormatInt(n, 10)
+	}
+}
+
+func formatPrice(value *float64) string {
+	if value == nil {
+		return "-"
+	}
+	return "$" + strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func writeMarkdownTable(b *strings.Builder, headers []string, rows [][]string) {
+	writeMarkdownRow(b, headers)
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeMarkdownRow(b, separators)
+	for _, row := range rows {
+		writeMarkdownRow(b, row)
+	}
+}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	b.WriteString("|")
+	for _, cell := range cells {
+		b.WriteString(" ")
+		b.WriteString(strings.ReplaceAll(cell, "|", `\|`))
+		b.WriteString(" |")
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,109 @@
+package docs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/internal/docscheck"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func referenceTestCatalog(t *testing.T) *catalogs.Catalog {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	for _, provider := range []catalogs.Provider{
+		{ID: "beta", Name: "Beta"},
+		{ID: "alpha", Name: "Alpha", APIKey: &catalogs.ProviderAPIKey{Name: "ALPHA_API_KEY"}, Models: map[string]*catalogs.Model{
+			"alpha-large": {
+				ID: "alpha-large", Name: "Alpha Large",
+				Features: &catalogs.ModelFeatures{Tools: true, WebSearch: true},
+				Limits:   &catalogs.ModelLimits{ContextWindow: 128000, OutputTokens: 1_000_000},
+				Pricing: &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{
+					Input:  &catalogs.ModelTokenCost{Per1M: 2.5},
+					Output: &catalogs.ModelTokenCost{Per1M: 10},
+				}},
+			},
+			"alpha-embed": {ID: "alpha-embed", Name: "alpha-embed", Class: catalogs.ModelClassEmbedding},
+		}},
+	} {
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider(%s): %v", provider.ID, err)
+		}
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return catalog
+}
+
+func TestWriteReference(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeReference(&out, referenceTestCatalog(t)); err != nil {
+		t.Fatalf("writeReference: %v", err)
+	}
+	page := out.String()
+	for _, want := range []string{
+		"2 providers serving 2 models.",
+		"| [Alpha](#alpha-alpha) | `alpha` | 2 | `ALPHA_API_KEY` |",
+		"| [Beta](#beta-beta) | `beta` | 0 | - |",
+		"| `alpha-embed` | - | - | - | - | - | embedding |",
+		"| `alpha-large` | Alpha Large | 128K | 1M | $2.5 | $10 | tools, web search |",
+		"No models in the catalog.",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("reference missing %q:\n%s", want, page)
+		}
+	}
+	if strings.Index(page, "## Alpha (alpha)") > strings.Index(page, "## Beta (beta)") {
+		t.Error("providers are not ordered by ID")
+	}
+
+	if strings.Contains(page, "MMLU") {
+		t.Error("benchmark columns rendered for a catalog without evaluations")
+	}
+
+	anchors := docscheck.Anchors(out.Bytes())
+	for _, anchor := range []string{"alpha-alpha", "beta-beta"} {
+		if !anchors[anchor] {
+			t.Errorf("contents link #%s has no heading", anchor)
+		}
+	}
+}
+
+func TestWriteReferenceEvaluations(t *testing.T) {
+	catalog := referenceTestCatalog(t)
+	model, err := catalog.ProviderModel("alpha", "alpha-large")
+	if err != nil {
+		t.Fatalf("ProviderModel: %v", err)
+	}
+	mmlu, elo := 88.7, 1287.0
+	model.Evaluations = &catalogs.ModelEvaluations{MMLU: &mmlu, ArenaElo: &elo}
+	builder := catalogs.NewEmpty()
+	if err := builder.MergeWith(catalog); err != nil {
+		t.Fatalf("MergeWith: %v", err)
+	}
+	if err := builder.SetProviderModel("alpha", model); err != nil {
+		t.Fatalf("SetProviderModel: %v", err)
+	}
+	catalog, err = builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := writeReference(&out, catalog); err != nil {
+		t.Fatalf("writeReference: %v", err)
+	}
+	page := out.String()
+	for _, want := range []string{
+		"| Model | Name | Context | Output | Input $ | Output $ | Capabilities | MMLU | Arena Elo | HumanEval |",
+		"| `alpha-embed` | - | - | - | - | - | embedding | - | - | - |",
+		"| `alpha-large` | Alpha Large | 128K | 1M | $2.5 | $10 | tools, web search | 88.7 | 1287 | - |",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("reference missing %q:\n%s", want, page)
+		}
+	}
+}
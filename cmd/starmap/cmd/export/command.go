@@ -0,0 +1,66 @@
+// Package export provides the export command for loading catalog data into
+// data warehouses and infrastructure pipelines.
+package export
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/export"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// NewCommand creates the export command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var (
+		exportFormat   string
+		provider       string
+		includePrivate bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		GroupID: "catalog",
+		Short:   "Export flat model and pricing records",
+		Long: `Export one flat record per provider offering of a model, with identity,
+capabilities, limits, and token pricing per 1M tokens.
+
+Records are sorted by provider and model ID and keep a fixed field order in
+every format, so exports diff cleanly and load into data warehouses without a
+schema per release. Each record carries schema_version; the schema is
+documented in docs/EXPORT.md.
+
+  json     A single JSON array of records
+  ndjson   One JSON record per line, for warehouse bulk loaders
+  csv      A header row and one row per record; lists are joined with ';'
+
+Model fields marked private in the catalog overlay are left out unless
+--include-private is set.`,
+		Args: cobra.NoArgs,
+		Example: `  starmap export > models.json
+  starmap export --format ndjson > models.ndjson
+  starmap export --format csv --provider openai > openai.csv`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cat, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			if !includePrivate {
+				if cat, err = cat.Public(); err != nil {
+					return err
+				}
+			}
+			records := export.Records(cat, catalogs.ProviderID(provider))
+			return export.Write(cmd.OutOrStdout(), export.Format(exportFormat), records)
+		},
+	}
+
+	cmd.Flags().StringVar(&exportFormat, "format", string(export.FormatJSON),
+		"Export format: json, ndjson, csv")
+	cmd.Flags().StringVarP(&provider, "provider", "p", "",
+		"Export only this provider's offerings (default: all)")
+	cmd.Flags().BoolVar(&includePrivate, "include-private", false,
+		"Include model fields marked private, for internal use")
+
+	return cmd
+}
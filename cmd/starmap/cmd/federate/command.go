@@ -0,0 +1,60 @@
+// Package federate provides the federate command implementation.
+package federate
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/cmd/starmap/cmd/update"
+	"github.com/agentstation/starmap/internal/application"
+)
+
+// NewCommand creates the federate command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	flags := &update.Flags{}
+
+	cmd := &cobra.Command{
+		Use:     "federate",
+		GroupID: "catalog",
+		Short:   "Merge another Starmap server's catalog into the local catalog",
+		Args:    cobra.NoArgs,
+		Long: `Federate pulls the current catalog generation from another Starmap server
+and reconciles it with your local catalog as an additional source. This lets
+an organization layer an internal catalog over a shared community catalog.
+
+Local authority rules decide every conflicting field:
+• Providers and models that exist only on the remote are added
+• Fields your local catalog sets always win
+• Remote values only fill fields no local source provides
+
+The remote generation is verified against its manifest checksum and schema
+compatibility before it is merged. Changes are previewed and confirmed like
+starmap update.`,
+		Example: `  starmap federate --remote https://starmap.example.com/api/v1 --dry
+  starmap federate --remote https://starmap.example.com/api/v1 -y
+  STARMAP_REMOTE_API_KEY=... starmap federate --remote https://internal.example.com/api/v1`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if flags.RemoteAPIKey == "" {
+				flags.RemoteAPIKey = os.Getenv("STARMAP_REMOTE_API_KEY")
+			}
+			return update.ExecuteUpdate(cmd.Context(), app, flags, app.Logger())
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Remote, "remote", "",
+		"Versioned API root of the Starmap server to federate, e.g. https://starmap.example.com/api/v1")
+	_ = cmd.MarkFlagRequired("remote")
+	cmd.Flags().StringVar(&flags.RemoteAPIKey, "remote-api-key", "",
+		"Bearer token for the remote server (default: $STARMAP_REMOTE_API_KEY)")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry", false,
+		"Preview changes without applying them")
+	cmd.Flags().BoolVarP(&flags.AutoApprove, "yes", "y", false,
+		"Auto-approve changes without confirmation")
+	cmd.Flags().StringVar(&flags.OutputDir, "output-dir", "",
+		"Save federated catalog to directory")
+	cmd.Flags().StringVar(&flags.InputDir, "input-dir", "",
+		"Load local catalog from directory instead of embedded")
+
+	return cmd
+}
@@ -0,0 +1,100 @@
+// Package initialize provides the init command that prepares a machine for
+// its first starmap run.
+package initialize
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+type catalogExportPathProvider interface {
+	CatalogExportPath() (string, error)
+}
+
+// NewCommand creates the init command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var copyCatalog, force bool
+
+	cmd := &cobra.Command{
+		Use:     "init",
+		GroupID: "setup",
+		Short:   "Set up starmap on this machine",
+		Long: `Prepare this machine for its first starmap run.
+
+init writes a commented config file (see --config), creates the cache, logs,
+and sources directories, and detects which provider credentials are set in
+the environment. It finishes with a summary of what starmap can do here:
+offline catalog access, live provider sync, and an editable local catalog.
+
+With --catalog the active catalog is also written as editable YAML to the
+catalog export path. Existing files are kept unless --force is given, so
+init is safe to re-run.`,
+		Example: `  starmap init                 # Scaffold config and directories
+  starmap init --catalog       # Also write an editable local catalog copy
+  starmap init -o json         # Machine-readable capability summary`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			options, err := resolveOptions(cmd, app)
+			if err != nil {
+				return err
+			}
+			options.CopyCatalog = copyCatalog
+			options.Force = force
+
+			catalog, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			result, err := Run(catalog, options)
+			if err != nil {
+				return err
+			}
+			return printResult(cmd, app.OutputFormat(), result)
+		},
+	}
+
+	cmd.Flags().BoolVar(&copyCatalog, "catalog", false,
+		"Write the active catalog as editable YAML to the catalog export path")
+	cmd.Flags().BoolVarP(&force, "force", "f", false,
+		"Overwrite an existing config file and local catalog copy")
+
+	return cmd
+}
+
+func resolveOptions(cmd *cobra.Command, app any) (Options, error) {
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil || configPath == "" {
+		configPath = constants.DefaultConfigPath
+	}
+	options := Options{ConfigPath: paths.ExpandHome(configPath)}
+	for _, dir := range []string{constants.DefaultCachePath, constants.DefaultLogsPath, constants.DefaultSourcesPath} {
+		options.Directories = append(options.Directories, paths.ExpandHome(dir))
+	}
+	options.ExportPath = paths.ExpandHome(constants.DefaultCatalogExportPath)
+	if provider, ok := app.(catalogExportPathProvider); ok {
+		if options.ExportPath, err = provider.CatalogExportPath(); err != nil {
+			return Options{}, err
+		}
+	}
+	return options, nil
+}
+
+func printResult(cmd *cobra.Command, outputFormat string, result *Result) error {
+	switch detected := format.DetectFormat(outputFormat); detected {
+	case format.FormatJSON, format.FormatYAML:
+		return format.NewFormatter(detected).Format(cmd.OutOrStdout(), result)
+	case format.FormatTable, format.FormatWide:
+		return writeSummary(cmd.OutOrStdout(), result, detected == format.FormatWide)
+	default:
+		return &errors.ValidationError{
+			Field:   "output",
+			Value:   outputFormat,
+			Message: "unsupported format (use table, wide, json, or yaml)",
+		}
+	}
+}
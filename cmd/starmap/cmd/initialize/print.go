@@ -0,0 +1,72 @@
+package initialize
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+)
+
+// writeSummary writes what init wrote, the detected credentials, and the
+// capability summary. Wide output lists every provider; otherwise optional
+// and unsupported providers are only counted.
+func writeSummary(w io.Writer, result *Result, wide bool) error {
+	fmt.Fprintf(w, "Config:      %s\n", describePath(result.Config))
+	for _, dir := range result.Directories {
+		fmt.Fprintf(w, "Directory:   %s\n", describePath(dir))
+	}
+	if result.Catalog != nil {
+		fmt.Fprintf(w, "Catalog:     %s\n", describePath(*result.Catalog))
+	}
+
+	fmt.Fprintf(w, "\nProvider credentials:\n")
+	rows := make([][]string, 0, len(result.Providers))
+	hidden := 0
+	for _, provider := range result.Providers {
+		if !wide && provider.State != stateConfigured && provider.State != stateMissing && provider.State != stateInvalid {
+			hidden++
+			continue
+		}
+		rows = append(rows, []string{stateSymbol(provider.State) + " " + provider.Name, string(provider.ID), provider.Detail})
+	}
+	if err := format.NewFormatter(format.FormatTable).Format(w, format.Data{
+		Headers: []string{"Provider", "ID", "Status"},
+		Rows:    rows,
+	}); err != nil {
+		return err
+	}
+	if hidden > 0 {
+		fmt.Fprintf(w, "  (%d providers need no credentials or have no client; use -o wide to list them)\n", hidden)
+	}
+
+	fmt.Fprintf(w, "\nCapabilities:\n")
+	for _, capability := range result.Capabilities {
+		symbol := emoji.Success
+		if !capability.Available {
+			symbol = emoji.Error
+		}
+		fmt.Fprintf(w, "  %s %-24s %s\n", symbol, capability.Name, capability.Detail)
+	}
+	return nil
+}
+
+func describePath(path Path) string {
+	if path.Created {
+		return path.Path + " (created)"
+	}
+	return path.Path + " (exists, kept)"
+}
+
+func stateSymbol(state string) string {
+	switch state {
+	case stateConfigured:
+		return emoji.Success
+	case stateMissing, stateInvalid:
+		return emoji.Error
+	case stateOptional:
+		return emoji.Optional
+	default:
+		return emoji.Unsupported
+	}
+}
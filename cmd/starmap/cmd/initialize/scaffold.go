@@ -0,0 +1,247 @@
+package initialize
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agentstation/starmap/internal/auth"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/save"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// Options controls what initialization writes.
+type Options struct {
+	ConfigPath  string   // Config file to scaffold
+	Directories []string // Local directories to create
+	ExportPath  string   // Editable catalog directory for the local copy
+	CopyCatalog bool     // Write the catalog to ExportPath
+	Force       bool     // Overwrite an existing config file and catalog copy
+}
+
+// Result reports what initialization found and wrote.
+type Result struct {
+	Config       Path             `json:"config" yaml:"config"`
+	Directories  []Path           `json:"directories" yaml:"directories"`
+	Catalog      *Path            `json:"catalog,omitempty" yaml:"catalog,omitempty"`
+	Providers    []ProviderStatus `json:"providers" yaml:"providers"`
+	Capabilities []Capability     `json:"capabilities" yaml:"capabilities"`
+}
+
+// Path is a file or directory initialization considered.
+type Path struct {
+	Path    string `json:"path" yaml:"path"`
+	Created bool   `json:"created" yaml:"created"` // False when it already existed and was kept
+}
+
+// ProviderStatus is the detected credential state of one provider.
+type ProviderStatus struct {
+	ID     catalogs.ProviderID `json:"id" yaml:"id"`
+	Name   string              `json:"name" yaml:"name"`
+	State  string              `json:"state" yaml:"state"` // configured, missing, invalid, optional, or unsupported
+	EnvVar string              `json:"env_var,omitempty" yaml:"env_var,omitempty"`
+	Detail string              `json:"detail" yaml:"detail"`
+}
+
+// Capability is a feature and whether this machine can use it.
+type Capability struct {
+	Name      string `json:"name" yaml:"name"`
+	Available bool   `json:"available" yaml:"available"`
+	Detail    string `json:"detail" yaml:"detail"`
+}
+
+// Run scaffolds the config file and directories, optionally copies catalog
+// to the export path, and detects provider credentials.
+func Run(catalog catalogs.Reader, options Options) (*Result, error) {
+	providers := detectProviders(catalog)
+	result := &Result{Providers: providers}
+
+	for _, dir := range options.Directories {
+		created, err := ensureDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		result.Directories = append(result.Directories, Path{Path: dir, Created: created})
+	}
+
+	created, err := writeConfig(options.ConfigPath, providers, options.Force)
+	if err != nil {
+		return nil, err
+	}
+	result.Config = Path{Path: options.ConfigPath, Created: created}
+
+	if options.CopyCatalog {
+		created, err := copyCatalog(catalog, options.ExportPath, options.Force)
+		if err != nil {
+			return nil, err
+		}
+		result.Catalog = &Path{Path: options.ExportPath, Created: created}
+	}
+
+	result.Capabilities = capabilities(catalog, providers, options.ExportPath)
+	return result, nil
+}
+
+func ensureDir(dir string) (bool, error) {
+	if info, err := os.Stat(dir); err == nil {
+		if !info.IsDir() {
+			return false, &errors.ValidationError{Field: "directory", Value: dir, Message: "exists and is not a directory"}
+		}
+		return false, nil
+	}
+	if err := os.MkdirAll(dir, constants.DirPermissions); err != nil {
+		return false, errors.WrapIO("create", dir, err)
+	}
+	return true, nil
+}
+
+func writeConfig(path string, providers []ProviderStatus, force bool) (bool, error) {
+	if _, err := os.Stat(path); err == nil && !force {
+		return false, nil
+	}
+	if _, err := ensureDir(filepath.Dir(path)); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(path, []byte(configTemplate(providers)), constants.FilePermissions); err != nil {
+		return false, errors.WrapIO("write", path, err)
+	}
+	return true, nil
+}
+
+// configTemplate returns a config file whose settings are commented-out
+// defaults, so writing it does not change behavior.
+func configTemplate(providers []ProviderStatus) string {
+	var configured, missing []string
+	for _, provider := range providers {
+		switch {
+		case provider.EnvVar == "":
+		case provider.State == stateConfigured:
+			configured = append(configured, provider.EnvVar)
+		case provider.State == stateMissing:
+			missing = append(missing, provider.EnvVar)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# Starmap configuration, written by starmap init.\n")
+	b.WriteString("# Settings show their defaults; uncomment a line to change it.\n\n")
+	fmt.Fprintf(&b, "# catalog_path: %s\n", constants.DefaultCatalogDatabasePath)
+	fmt.Fprintf(&b, "# catalog_export_path: %s\n", constants.DefaultCatalogExportPath)
+	b.WriteString("# reconciliation_strategy: field-authority\n")
+	b.WriteString("# review_new_models: false\n\n")
+	if len(configured) > 0 {
+		fmt.Fprintf(&b, "# Credentials found in the environment: %s\n", strings.Join(configured, ", "))
+	}
+	b.WriteString("# Provider credentials, used when the environment does not set them.\n")
+	b.WriteString("# credentials:\n")
+	for _, name := range missing {
+		fmt.Fprintf(&b, "#   %s: \"\"\n", name)
+	}
+	b.WriteString("\n# Named profiles for managing several deployments; select one with --profile.\n")
+	b.WriteString("# profiles:\n")
+	b.WriteString("#   prod:\n")
+	b.WriteString("#     catalog_path: /srv/starmap/prod/catalog\n")
+	b.WriteString("#     commands:\n")
+	b.WriteString("#       serve:\n")
+	b.WriteString("#         port: 8080\n")
+	return b.String()
+}
+
+func copyCatalog(catalog catalogs.Reader, path string, force bool) (bool, error) {
+	if entries, err := os.ReadDir(path); err == nil && len(entries) > 0 && !force {
+		return false, nil
+	}
+	local := catalogs.NewEmpty()
+	if err := local.ReplaceWith(catalog); err != nil {
+		return false, errors.WrapResource("copy", "catalog", path, err)
+	}
+	if err := local.Save(save.WithPath(path)); err != nil {
+		return false, errors.WrapIO("write", path, err)
+	}
+	return true, nil
+}
+
+const (
+	stateConfigured  = "configured"
+	stateMissing     = "missing"
+	stateInvalid     = "invalid"
+	stateOptional    = "optional"
+	stateUnsupported = "unsupported"
+)
+
+var stateNames = map[auth.State]string{
+	auth.StateConfigured:  stateConfigured,
+	auth.StateMissing:     stateMissing,
+	auth.StateInvalid:     stateInvalid,
+	auth.StateOptional:    stateOptional,
+	auth.StateUnsupported: stateUnsupported,
+}
+
+// detectProviders checks local credentials for every catalog provider. No
+// network calls are made.
+func detectProviders(catalog catalogs.Reader) []ProviderStatus {
+	supported := make(map[string]bool)
+	for _, id := range sources.NewProviderFetcher(catalog.Providers()).List() {
+		supported[string(id)] = true
+	}
+	checker := auth.NewChecker()
+
+	list := catalog.Providers().List()
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	statuses := make([]ProviderStatus, 0, len(list))
+	for i := range list {
+		provider := &list[i]
+		status := checker.CheckProvider(provider, supported)
+		entry := ProviderStatus{
+			ID:     provider.ID,
+			Name:   provider.Name,
+			State:  stateNames[status.State],
+			Detail: status.Summary,
+		}
+		if provider.APIKey != nil && status.State != auth.StateUnsupported {
+			entry.EnvVar = provider.APIKey.Name
+		}
+		statuses = append(statuses, entry)
+	}
+	return statuses
+}
+
+func capabilities(catalog catalogs.Reader, providers []ProviderStatus, exportPath string) []Capability {
+	var live, supported int
+	for _, provider := range providers {
+		switch provider.State {
+		case stateConfigured:
+			live++
+			supported++
+		case stateMissing, stateInvalid:
+			supported++
+		}
+	}
+
+	result := []Capability{
+		{
+			Name:      "Offline catalog",
+			Available: true,
+			Detail:    fmt.Sprintf("%d models from %d providers", catalog.Models().Len(), len(providers)),
+		},
+		{
+			Name:      "Live provider sync",
+			Available: live > 0,
+			Detail:    fmt.Sprintf("%d of %d API providers have credentials (starmap update)", live, supported),
+		},
+	}
+
+	entries, err := os.ReadDir(exportPath)
+	local := Capability{Name: "Editable local catalog", Available: err == nil && len(entries) > 0}
+	if local.Available {
+		local.Detail = exportPath
+	} else {
+		local.Detail = "not written (starmap init --catalog)"
+	}
+	return append(result, local)
+}
@@ -0,0 +1,118 @@
+package initialize
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+)
+
+func testCatalog(t *testing.T) catalogs.Reader {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	for _, provider := range []catalogs.Provider{
+		{ID: "openai", Name: "OpenAI", APIKey: &catalogs.ProviderAPIKey{Name: "STARMAP_INIT_TEST_OPENAI_KEY"}},
+		{ID: "anthropic", Name: "Anthropic", APIKey: &catalogs.ProviderAPIKey{Name: "STARMAP_INIT_TEST_ANTHROPIC_KEY"}},
+	} {
+		provider.Catalog = &catalogs.ProviderCatalog{Endpoint: catalogs.ProviderEndpoint{
+			Type: catalogs.EndpointTypeOpenAI, URL: "https://api.example.com/v1/models", AuthRequired: true,
+		}}
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider: %v", err)
+		}
+	}
+	if err := builder.SetProviderModel("openai", catalogs.Model{ID: "gpt-4o", Name: "GPT-4o"}); err != nil {
+		t.Fatalf("SetProviderModel: %v", err)
+	}
+	return builder
+}
+
+func testOptions(t *testing.T) Options {
+	t.Helper()
+	root := t.TempDir()
+	return Options{
+		ConfigPath:  filepath.Join(root, ".starmap", "config.yaml"),
+		Directories: []string{filepath.Join(root, ".starmap", "cache"), filepath.Join(root, ".starmap", "logs")},
+		ExportPath:  filepath.Join(root, ".starmap", "exports", "catalog"),
+	}
+}
+
+func TestRunScaffoldsConfigAndDetectsCredentials(t *testing.T) {
+	t.Setenv("STARMAP_INIT_TEST_OPENAI_KEY", "sk-test")
+	options := testOptions(t)
+	options.CopyCatalog = true
+
+	result, err := Run(testCatalog(t), options)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !result.Config.Created || result.Catalog == nil || !result.Catalog.Created {
+		t.Fatalf("result = %#v, want config and catalog created", result)
+	}
+	for _, dir := range options.Directories {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			t.Errorf("directory %s not created: %v", dir, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(options.ExportPath, "providers.yaml")); err != nil {
+		t.Errorf("catalog copy missing providers.yaml: %v", err)
+	}
+
+	config, err := os.ReadFile(options.ConfigPath)
+	if err != nil {
+		t.Fatalf("ReadFile config: %v", err)
+	}
+	if !strings.Contains(string(config), "found in the environment: STARMAP_INIT_TEST_OPENAI_KEY") ||
+		!strings.Contains(string(config), `#   STARMAP_INIT_TEST_ANTHROPIC_KEY: ""`) {
+		t.Errorf("config does not list detected and missing credentials:\n%s", config)
+	}
+
+	states := map[catalogs.ProviderID]string{}
+	for _, provider := range result.Providers {
+		states[provider.ID] = provider.State
+	}
+	if states["openai"] != stateConfigured || states["anthropic"] != stateMissing {
+		t.Errorf("provider states = %v, want openai configured and anthropic missing", states)
+	}
+
+	var buf bytes.Buffer
+	if err := writeSummary(&buf, result, false); err != nil {
+		t.Fatalf("writeSummary: %v", err)
+	}
+	for _, want := range []string{"(created)", "Live provider sync", "Editable local catalog"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("summary missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestRunKeepsExistingConfigUnlessForced(t *testing.T) {
+	options := testOptions(t)
+	if err := os.MkdirAll(filepath.Dir(options.ConfigPath), constants.DirPermissions); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(options.ConfigPath, []byte("catalog_path: /custom\n"), constants.FilePermissions); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	result, err := Run(testCatalog(t), options)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	config, _ := os.ReadFile(options.ConfigPath)
+	if result.Config.Created || string(config) != "catalog_path: /custom\n" {
+		t.Fatalf("existing config was replaced: created=%v content=%q", result.Config.Created, config)
+	}
+
+	options.Force = true
+	if result, err = Run(testCatalog(t), options); err != nil {
+		t.Fatalf("Run forced: %v", err)
+	}
+	if !result.Config.Created {
+		t.Fatal("forced run kept the existing config")
+	}
+}
@@ -0,0 +1,107 @@
+// Package migrate provides the catalog schema migration command.
+package migrate
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+)
+
+type catalogExportPathProvider interface {
+	CatalogExportPath() (string, error)
+}
+
+// NewCommand creates the migrate command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:     "migrate [path]",
+		GroupID: "catalog",
+		Short:   "Upgrade an on-disk catalog to the current schema",
+		Long: `Upgrade an editable YAML catalog or overlay directory to the current schema.
+
+Catalog directories record their layout version in schema.yaml. Directories
+without that file use the original unversioned layout (version 0). Each
+registered migration is applied in order and the recorded version is updated
+after every step, so an interrupted run can be resumed.
+
+When no path is given, the configured catalog export directory is migrated.`,
+		Example: `  starmap migrate
+  starmap migrate ./internal/embedded/catalog --dry-run
+  starmap migrate ~/overlays/catalog -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			explicit := ""
+			if len(args) == 1 {
+				explicit = args[0]
+			}
+			path, err := resolveCatalogPath(app, explicit)
+			if err != nil {
+				return err
+			}
+			report, err := catalogs.MigrateYAMLDirectory(path, dryRun)
+			if err != nil {
+				return err
+			}
+			return printReport(cmd.OutOrStdout(), app.OutputFormat(), report)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report pending migrations without writing")
+
+	return cmd
+}
+
+func resolveCatalogPath(app any, explicit string) (string, error) {
+	if explicit != "" {
+		return paths.ExpandHome(explicit), nil
+	}
+	if provider, ok := app.(catalogExportPathProvider); ok {
+		return provider.CatalogExportPath()
+	}
+	return paths.ExpandHome(constants.DefaultCatalogExportPath), nil
+}
+
+func printReport(w io.Writer, outputFormat string, report catalogs.YAMLMigrationReport) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, report)
+	}
+
+	if report.UpToDate() {
+		_, err := fmt.Fprintf(w, "%s Catalog at %s is already at schema version %d\n",
+			emoji.Success, report.Path, report.ToVersion)
+		return err
+	}
+
+	rows := make([][]string, 0, len(report.Steps))
+	for _, step := range report.Steps {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d → %d", step.From, step.To),
+			step.Description,
+		})
+	}
+	if err := format.NewFormatter(format.FormatTable).Format(w, format.Data{
+		Headers: []string{"Migration", "Description"},
+		Rows:    rows,
+	}); err != nil {
+		return err
+	}
+
+	verb := "Migrated"
+	if report.DryRun {
+		verb = "Would migrate"
+	}
+	_, err := fmt.Fprintf(w, "\n%s %s %s from schema version %d to %d\n",
+		emoji.Success, verb, report.Path, report.FromVersion, report.ToVersion)
+	return err
+}
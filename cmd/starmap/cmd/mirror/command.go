@@ -0,0 +1,165 @@
+// Package mirror provides the air-gapped catalog mirror command.
+package mirror
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/embedded"
+	"github.com/agentstation/starmap/internal/embedded/openapi"
+	"github.com/agentstation/starmap/pkg/catalogartifact"
+	"github.com/agentstation/starmap/pkg/catalogmirror"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// assetExtensions lists the embedded catalog files carried as static assets.
+var assetExtensions = []string{".svg", ".png", ".jpg", ".jpeg", ".webp"}
+
+// Report describes a written mirror bundle.
+type Report struct {
+	Path         string `json:"path" yaml:"path"`
+	GenerationID string `json:"generation_id" yaml:"generation_id"`
+	Checksum     string `json:"checksum" yaml:"checksum"`
+	SizeBytes    int64  `json:"size_bytes" yaml:"size_bytes"`
+	Assets       int    `json:"assets" yaml:"assets"`
+	Schemas      int    `json:"schemas" yaml:"schemas"`
+}
+
+// NewCommand creates the mirror command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:     "mirror",
+		GroupID: "catalog",
+		Short:   "Package the catalog for air-gapped environments",
+		Long: `Package the current catalog generation, provider and author logos, and the
+API schema into a single self-contained tarball.
+
+The bundle carries the catalog artifact with its in-toto attestation and an
+index that binds every member to a SHA-256 checksum. Copy it into an
+air-gapped network and run:
+
+  starmap serve --from-mirror ./mirror
+
+to serve the catalog without any outbound network access.`,
+		Example: `  starmap mirror --out ./mirror
+  starmap mirror --out /media/usb/starmap -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			sm, err := app.Starmap()
+			if err != nil {
+				return err
+			}
+			generation, err := sm.CurrentGeneration(cmd.Context())
+			if err != nil {
+				return errors.WrapResource("load", "current catalog generation", "", err)
+			}
+			artifact, err := catalogartifact.Build(generation)
+			if err != nil {
+				return err
+			}
+			report, err := Write(out, artifact)
+			if err != nil {
+				return err
+			}
+			return printReport(cmd.OutOrStdout(), app.OutputFormat(), report)
+		},
+	}
+
+	cmd.Flags().StringVar(&out, "out", "", "Directory to write "+catalogmirror.Filename+" into")
+	_ = cmd.MarkFlagRequired("out")
+
+	return cmd
+}
+
+// Write packages artifact with the embedded assets and API schema and writes
+// the bundle into dir.
+func Write(dir string, artifact catalogartifact.Artifact) (Report, error) {
+	assets, err := Assets()
+	if err != nil {
+		return Report{}, err
+	}
+	schemas := Schemas()
+	data, err := catalogmirror.Build(artifact, append(assets, schemas...)...)
+	if err != nil {
+		return Report{}, err
+	}
+	if err := os.MkdirAll(dir, constants.DirPermissions); err != nil {
+		return Report{}, errors.WrapIO("create", dir, err)
+	}
+	target := filepath.Join(dir, catalogmirror.Filename)
+	if err := os.WriteFile(target, data, constants.FilePermissions); err != nil {
+		return Report{}, errors.WrapIO("write", target, err)
+	}
+	digest := sha256.Sum256(data)
+	return Report{
+		Path:         target,
+		GenerationID: artifact.GenerationID,
+		Checksum:     "sha256:" + hex.EncodeToString(digest[:]),
+		SizeBytes:    int64(len(data)),
+		Assets:       len(assets),
+		Schemas:      len(schemas),
+	}, nil
+}
+
+// Assets returns the embedded catalog logos as mirror files.
+func Assets() ([]catalogmirror.File, error) {
+	var files []catalogmirror.File
+	err := fs.WalkDir(embedded.FS, "catalog", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		ext := strings.ToLower(path.Ext(name))
+		if !slices.Contains(assetExtensions, ext) {
+			return nil
+		}
+		data, err := fs.ReadFile(embedded.FS, name)
+		if err != nil {
+			return err
+		}
+		files = append(files, catalogmirror.File{
+			Name:      catalogmirror.AssetsDir + strings.TrimPrefix(name, "catalog/"),
+			MediaType: mime.TypeByExtension(ext),
+			Data:      data,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WrapResource("read", "embedded catalog assets", "catalog", err)
+	}
+	return files, nil
+}
+
+// Schemas returns the API schema as mirror files.
+func Schemas() []catalogmirror.File {
+	return []catalogmirror.File{
+		{Name: catalogmirror.SchemaDir + "openapi.json", MediaType: "application/json", Data: openapi.SpecJSON},
+		{Name: catalogmirror.SchemaDir + "openapi.yaml", MediaType: "application/yaml", Data: openapi.SpecYAML},
+	}
+}
+
+func printReport(w io.Writer, outputFormat string, report Report) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, report)
+	}
+	_, err := fmt.Fprintf(w, "%s Wrote mirror of generation %s to %s\n   %d assets, %d schema files, %d bytes\n   %s\n",
+		emoji.Success, report.GenerationID, report.Path, report.Assets, report.Schemas, report.SizeBytes, report.Checksum)
+	return err
+}
@@ -0,0 +1,107 @@
+// Package mock provides the mock command, which serves a fake
+// OpenAI-compatible provider API populated from the catalog.
+package mock
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/mockprovider"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// shutdownTimeout bounds how long in-flight requests may finish on exit.
+const shutdownTimeout = 5 * time.Second
+
+// NewCommand creates the mock command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var (
+		cfg      mockprovider.Config
+		provider string
+		host     string
+		port     int
+	)
+
+	cmd := &cobra.Command{
+		Use:     "mock",
+		GroupID: "server",
+		Short:   "Serve a fake OpenAI-compatible provider API from the catalog",
+		Long: `Serve a fake OpenAI-compatible provider API whose models are a provider's
+models in the catalog, so application test suites can run against realistic
+model lists offline and without API keys.
+
+Routes are served with and without the /v1 prefix:
+
+  GET  /v1/models               List the provider's models
+  GET  /v1/models/{id}          Get one model
+  POST /v1/chat/completions     Canned completion (with --completions)
+
+Completions answer every request for a known model with --reply, streamed
+when the request sets "stream": true. Token usage is estimated at four
+bytes per token. Any API key is accepted.`,
+		Args: cobra.NoArgs,
+		Example: `  starmap mock --provider openai --port 8081
+  starmap mock -p anthropic --completions --reply "Hello from the mock"
+  OPENAI_BASE_URL=http://localhost:8081/v1 go test ./...`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg.Provider = catalogs.ProviderID(provider)
+			cat, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			mock, err := mockprovider.New(cat, cfg)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+			if err != nil {
+				cmd.SilenceUsage = true
+				return errors.WrapIO("listen", net.JoinHostPort(host, strconv.Itoa(port)), err)
+			}
+			server := &http.Server{Handler: mock.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+			app.Logger().Info().
+				Str("provider", string(cfg.Provider)).
+				Int("models", mock.Models()).
+				Bool("completions", cfg.Completions).
+				Str("base_url", "http://"+listener.Addr().String()+"/v1").
+				Msg("Mock provider listening")
+
+			return serve(cmd.Context(), server, listener)
+		},
+	}
+
+	cmd.Flags().StringVarP(&provider, "provider", "p", "openai", "Provider whose models are served")
+	cmd.Flags().StringVar(&host, "host", "localhost", "Bind address")
+	cmd.Flags().IntVar(&port, "port", 8081, "Port to listen on")
+	cmd.Flags().BoolVar(&cfg.Completions, "completions", false, "Serve canned chat completions at /v1/chat/completions")
+	cmd.Flags().StringVar(&cfg.Reply, "reply", mockprovider.DefaultReply, "Content of canned chat completions")
+
+	return cmd
+}
+
+// serve runs server on listener until ctx is cancelled, then shuts it down.
+func serve(ctx context.Context, server *http.Server, listener net.Listener) error {
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
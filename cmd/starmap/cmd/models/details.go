@@ -6,9 +6,11 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/agentstation/starmap/internal/cli/emoji"
 	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/locale"
 	"github.com/agentstation/starmap/internal/cli/table"
 	"github.com/agentstation/starmap/pkg/catalogs"
 )
@@ -28,6 +30,17 @@ func printModelDetails(model *catalogs.Model, provider catalogs.Provider) {
 	rows = addModeRows(rows, model)
 	rows = addMetadataRows(rows, model)
 	rows = addFeatureRows(rows, model)
+	rows = addToolCallingRows(rows, model, provider)
+	rows = addVisionRows(rows, model, provider)
+	rows = addDocumentRows(rows, model, provider)
+	rows = addPromptCachingRows(rows, model, provider)
+	rows = addPerformanceRows(rows, model)
+	rows = addUsageRestrictionRows(rows, model, provider)
+	rows = addCurationRows(rows, model)
+	rows = addReviewRows(rows, model)
+	rows = addSustainabilityRows(rows, model, provider)
+	rows = addEvaluationRows(rows, model)
+	rows = addRealtimeRows(rows, model)
 	rows = addArchitectureRows(rows, model)
 	rows = addExtensionRows(rows, model)
 	rows = addDescriptionRow(rows, model)
@@ -106,13 +119,13 @@ func addPricingRows(rows [][]string, model *catalogs.Model) [][]string {
 
 	if model.Pricing.Tokens != nil {
 		if model.Pricing.Tokens.Input != nil && model.Pricing.Tokens.Input.Per1M > 0 {
-			rows = append(rows, []string{"Input Price", fmt.Sprintf("$%.2f per 1M tokens", model.Pricing.Tokens.Input.Per1M)})
+			rows = append(rows, []string{"Input Price", locale.Money(model.Pricing.Tokens.Input.Per1M, string(model.Pricing.Currency), 2) + " per 1M tokens"})
 		}
 		if model.Pricing.Tokens.Output != nil && model.Pricing.Tokens.Output.Per1M > 0 {
-			rows = append(rows, []string{"Output Price", fmt.Sprintf("$%.2f per 1M tokens", model.Pricing.Tokens.Output.Per1M)})
+			rows = append(rows, []string{"Output Price", locale.Money(model.Pricing.Tokens.Output.Per1M, string(model.Pricing.Currency), 2) + " per 1M tokens"})
 		}
 		if model.Pricing.Tokens.Reasoning != nil && model.Pricing.Tokens.Reasoning.Per1M > 0 {
-			rows = append(rows, []string{"Reasoning Price", fmt.Sprintf("$%.2f per 1M tokens", model.Pricing.Tokens.Reasoning.Per1M)})
+			rows = append(rows, []string{"Reasoning Price", locale.Money(model.Pricing.Tokens.Reasoning.Per1M, string(model.Pricing.Currency), 2) + " per 1M tokens"})
 		}
 	}
 
@@ -180,6 +193,271 @@ func addFeatureRows(rows [][]string, model *catalogs.Model) [][]string {
 	return rows
 }
 
+// addToolCallingRows adds the tool calling dialect profile to the table.
+func addToolCallingRows(rows [][]string, model *catalogs.Model, provider catalogs.Provider) [][]string {
+	profile := model.ToolCalling(&provider)
+	if !profile.Supported {
+		return rows
+	}
+	if len(profile.Dialects) > 0 {
+		dialects := make([]string, len(profile.Dialects))
+		for i, dialect := range profile.Dialects {
+			dialects[i] = dialect.String()
+		}
+		rows = append(rows, []string{"Tool Dialects", strings.Join(dialects, ", ")})
+	}
+	if profile.ParallelCalls != nil {
+		rows = append(rows, []string{"Parallel Tool Calls", formatBool(*profile.ParallelCalls)})
+	}
+	if profile.StreamingDeltas != nil {
+		rows = append(rows, []string{"Streaming Tool Deltas", formatBool(*profile.StreamingDeltas)})
+	}
+	return rows
+}
+
+// addVisionRows adds resolved image input constraints to the table.
+func addVisionRows(rows [][]string, model *catalogs.Model, provider catalogs.Provider) [][]string {
+	vision := model.VisionFor(&provider)
+	if vision == nil {
+		return rows
+	}
+	if vision.MaxImages != nil {
+		rows = append(rows, []string{"Max Images", table.FormatNumber(int64(*vision.MaxImages))})
+	}
+	if vision.MaxWidth > 0 && vision.MaxHeight > 0 {
+		rows = append(rows, []string{"Max Image Resolution", fmt.Sprintf("%dx%d", vision.MaxWidth, vision.MaxHeight)})
+	}
+	if len(vision.Formats) > 0 {
+		rows = append(rows, []string{"Image Formats", strings.Join(vision.Formats, ", ")})
+	}
+	if formula := vision.TokenCost; formula != nil {
+		rows = append(rows, []string{"Image Token Cost", formatImageTokenFormula(formula)})
+	}
+	return rows
+}
+
+// addDocumentRows adds resolved document (PDF) input support to the table.
+func addDocumentRows(rows [][]string, model *catalogs.Model, provider catalogs.Provider) [][]string {
+	documents := model.DocumentsFor(&provider)
+	if documents == nil {
+		return rows
+	}
+	if documents.MaxPages != nil {
+		rows = append(rows, []string{"Max Document Pages", table.FormatNumber(int64(*documents.MaxPages))})
+	}
+	if documents.MaxFileSize != nil {
+		rows = append(rows, []string{"Max Document Size", fmt.Sprintf("%d MB", *documents.MaxFileSize/(1<<20))})
+	}
+	if documents.OCR != "" {
+		rows = append(rows, []string{"Document OCR", documents.OCR.String()})
+	}
+	if documents.PageTokens > 0 {
+		rows = append(rows, []string{"Tokens per Page", "~" + table.FormatNumber(documents.PageTokens)})
+	}
+	return rows
+}
+
+// formatImageTokenFormula renders an image token formula in one line.
+func formatImageTokenFormula(formula *catalogs.ImageTokenFormula) string {
+	var value string
+	switch formula.Method {
+	case catalogs.ImageTokenMethodTiles:
+		value = fmt.Sprintf("%d per %dpx tile", formula.TileTokens, formula.TileSize)
+	case catalogs.ImageTokenMethodPixels:
+		value = fmt.Sprintf("width x height / %d", formula.PixelsPerToken)
+	default:
+		value = fmt.Sprintf("%d per image", formula.BaseTokens)
+	}
+	if formula.Method != catalogs.ImageTokenMethodFixed && formula.BaseTokens > 0 {
+		value = fmt.Sprintf("%d + %s", formula.BaseTokens, value)
+	}
+	if formula.FitLongEdge > 0 {
+		value += fmt.Sprintf(" (long edge <= %dpx)", formula.FitLongEdge)
+	}
+	return value
+}
+
+// addUsageRestrictionRows adds the acceptable-use restrictions that apply to
+// the model, including those inherited from the provider's usage policy.
+func addUsageRestrictionRows(rows [][]string, model *catalogs.Model, provider catalogs.Provider) [][]string {
+	restrictions := model.UsageRestrictionsFor(&provider)
+	if restrictions == nil {
+		return rows
+	}
+	if len(restrictions.Restricted) > 0 {
+		uses := make([]string, len(restrictions.Restricted))
+		for i, use := range restrictions.Restricted {
+			uses[i] = use.String()
+		}
+		rows = append(rows, []string{"Restricted Uses", strings.Join(uses, ", ")})
+	}
+	if len(restrictions.BlockedRegions) > 0 {
+		rows = append(rows, []string{"Blocked Regions", strings.Join(restrictions.BlockedRegions, ", ")})
+	}
+	if restrictions.PolicyURL != nil && *restrictions.PolicyURL != "" {
+		rows = append(rows, []string{"Usage Policy", *restrictions.PolicyURL})
+	}
+	return rows
+}
+
+// addCurationRows adds the user-managed curation tags.
+func addCurationRows(rows [][]string, model *catalogs.Model) [][]string {
+	if tags := model.CurationTags(); len(tags) > 0 {
+		rows = append(rows, []string{"Curation Tags", strings.Join(tags, ", ")})
+	}
+	return rows
+}
+
+// addReviewRows adds the review state and the most recent review decision.
+func addReviewRows(rows [][]string, model *catalogs.Model) [][]string {
+	if model.Review == nil {
+		return rows
+	}
+	rows = append(rows, []string{"Review", model.Review.State.String()})
+	if n := len(model.Review.Log); n > 0 {
+		last := model.Review.Log[n-1]
+		value := fmt.Sprintf("%s (%s)", last.Reviewer, locale.DateTime(last.At.Time())+" "+last.At.Format("MST"))
+		if last.Note != "" {
+			value += ": " + last.Note
+		}
+		rows = append(rows, []string{"Last Reviewed", value})
+	}
+	return rows
+}
+
+// addSustainabilityRows adds the estimated inference energy and the
+// provider's carbon claims to the table. Estimates are labeled with their
+// methodology so they are not mistaken for measurements.
+func addSustainabilityRows(rows [][]string, model *catalogs.Model, provider catalogs.Provider) [][]string {
+	if sustainability := model.Sustainability; sustainability != nil {
+		if sustainability.EnergyPer1MInputTokens > 0 || sustainability.EnergyPer1MOutputTokens > 0 {
+			rows = append(rows, []string{"Est. Energy (1M tokens)", fmt.Sprintf("%s Wh input, %s Wh output",
+				locale.Decimal(sustainability.EnergyPer1MInputTokens, -1),
+				locale.Decimal(sustainability.EnergyPer1MOutputTokens, -1))})
+		}
+		if sustainability.Methodology != "" {
+			methodology := sustainability.Methodology.String()
+			if sustainability.EstimatedAt != nil {
+				methodology += " " + locale.Date(sustainability.EstimatedAt.Time())
+			}
+			rows = append(rows, []string{"Estimate Methodology", methodology})
+		}
+	}
+	if grams, ok := model.EstimatedEmissions(&provider, 0, 1_000_000); ok {
+		rows = append(rows, []string{"Est. Emissions (1M output)", locale.Decimal(grams, 1) + " gCO2e"})
+	}
+	if claims := provider.Sustainability; claims != nil && claims.CarbonNeutral != nil {
+		rows = append(rows, []string{"Provider Carbon Neutral", fmt.Sprintf("%t (claimed)", *claims.CarbonNeutral)})
+	}
+	return rows
+}
+
+// addEvaluationRows adds published benchmark scores to the table.
+func addEvaluationRows(rows [][]string, model *catalogs.Model) [][]string {
+	evaluations := model.Evaluations
+	if evaluations.IsEmpty() {
+		return rows
+	}
+	if evaluations.MMLU != nil {
+		rows = append(rows, []string{"MMLU", locale.Decimal(*evaluations.MMLU, -1) + "%"})
+	}
+	if evaluations.ArenaElo != nil {
+		rows = append(rows, []string{"Arena Elo", locale.Decimal(*evaluations.ArenaElo, -1)})
+	}
+	if evaluations.HumanEval != nil {
+		rows = append(rows, []string{"HumanEval", locale.Decimal(*evaluations.HumanEval, -1) + "%"})
+	}
+	if evaluations.UpdatedAt != nil {
+		rows = append(rows, []string{"Evaluations Updated", locale.Date(evaluations.UpdatedAt.Time())})
+	}
+	return rows
+}
+
+// addPerformanceRows adds serving throughput and latency to the table.
+func addPerformanceRows(rows [][]string, model *catalogs.Model) [][]string {
+	performance := model.Performance
+	if performance == nil {
+		return rows
+	}
+	if performance.OutputTokensPerSecond > 0 {
+		rows = append(rows, []string{"Output Speed", table.FormatSpeed(performance)})
+	}
+	if ttft := performance.TimeToFirstToken; ttft != nil {
+		var percentiles []string
+		for _, p := range []struct {
+			label string
+			value *time.Duration
+		}{{"p50", ttft.P50}, {"p90", ttft.P90}, {"p95", ttft.P95}, {"p99", ttft.P99}} {
+			if p.value != nil {
+				percentiles = append(percentiles, fmt.Sprintf("%s %s", p.label, p.value.Round(time.Millisecond)))
+			}
+		}
+		if len(percentiles) > 0 {
+			rows = append(rows, []string{"Time to First Token", strings.Join(percentiles, ", ")})
+		}
+	}
+	if performance.Source != "" {
+		source := performance.Source.String()
+		if performance.MeasuredAt != nil {
+			source += " " + locale.Date(performance.MeasuredAt.Time())
+		}
+		rows = append(rows, []string{"Performance Source", source})
+	}
+	return rows
+}
+
+// addPromptCachingRows adds resolved prompt caching support to the table.
+func addPromptCachingRows(rows [][]string, model *catalogs.Model, provider catalogs.Provider) [][]string {
+	caching := model.PromptCachingFor(&provider)
+	if !caching.Supported() {
+		return rows
+	}
+	modes := make([]string, len(caching.Modes))
+	for i, mode := range caching.Modes {
+		modes[i] = mode.String()
+	}
+	rows = append(rows, []string{"Prompt Caching", strings.Join(modes, ", ")})
+	if caching.MinTokens > 0 {
+		rows = append(rows, []string{"Cache Min Tokens", table.FormatNumber(caching.MinTokens)})
+	}
+	if len(caching.TTLs) > 0 {
+		ttls := make([]string, len(caching.TTLs))
+		for i, ttl := range caching.TTLs {
+			ttls[i] = ttl.String()
+		}
+		rows = append(rows, []string{"Cache TTLs", strings.Join(ttls, ", ")})
+	}
+	return rows
+}
+
+// addRealtimeRows adds realtime voice session support to the table.
+func addRealtimeRows(rows [][]string, model *catalogs.Model) [][]string {
+	if model.Delivery == nil || model.Delivery.Realtime == nil {
+		return rows
+	}
+	realtime := model.Delivery.Realtime
+	transports := make([]string, len(realtime.Transports))
+	for i, transport := range realtime.Transports {
+		transports[i] = string(transport)
+	}
+	value := realtime.API.String()
+	if len(transports) > 0 {
+		value += " (" + strings.Join(transports, ", ") + ")"
+	}
+	rows = append(rows, []string{"Realtime API", value})
+	if len(realtime.InputAudioFormats) > 0 {
+		formats := make([]string, len(realtime.InputAudioFormats))
+		for i, f := range realtime.InputAudioFormats {
+			formats[i] = f.String()
+		}
+		rows = append(rows, []string{"Realtime Audio In", strings.Join(formats, ", ")})
+	}
+	if realtime.MaxSessionDuration != nil {
+		rows = append(rows, []string{"Realtime Max Session", realtime.MaxSessionDuration.String()})
+	}
+	return rows
+}
+
 // addAudioFeatures adds audio feature information to the table.
 func addAudioFeatures(rows [][]string, features *catalogs.ModelFeatures) [][]string {
 	hasAudioInput := false
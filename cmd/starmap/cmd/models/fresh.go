@@ -0,0 +1,34 @@
+package models
+
+import (
+	"context"
+
+	"github.com/agentstation/starmap/internal/cli/provider"
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// fetchFreshModels fetches a provider's models from its API instead of
+// reading the catalog. The fetch refreshes the provider fetch cache, so a
+// sync within constants.ProviderFetchCacheTTL reuses it.
+func fetchFreshModels(ctx context.Context, cat catalogs.Reader, providerID string) ([]catalogs.Model, error) {
+	prov, err := provider.Get(cat, providerID)
+	if err != nil {
+		return nil, err
+	}
+	cache := sources.NewFetchCache(paths.ExpandHome(constants.DefaultProviderFetchCachePath), constants.ProviderFetchCacheTTL)
+	fetcher := sources.NewProviderFetcher(cat.Providers(),
+		sources.WithTimeout(constants.ProviderFetchTimeout),
+		sources.WithFetchCache(cache),
+		sources.WithHTTPCache(paths.ExpandHome(constants.DefaultProviderHTTPCachePath), constants.ProviderHTTPCacheTTL),
+		sources.WithFreshFetch(),
+	)
+	models, err := fetcher.FetchModels(ctx, prov)
+	if err != nil {
+		return nil, errors.WrapResource("fetch", "models", providerID, err)
+	}
+	return models, nil
+}
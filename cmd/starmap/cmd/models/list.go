@@ -10,6 +10,7 @@ import (
 
 	"github.com/agentstation/starmap/internal/application"
 	"github.com/agentstation/starmap/internal/catalog/query"
+	"github.com/agentstation/starmap/internal/catalog/view"
 	"github.com/agentstation/starmap/internal/cli/constants"
 	"github.com/agentstation/starmap/internal/cli/format"
 	"github.com/agentstation/starmap/internal/cli/globals"
@@ -30,6 +31,14 @@ func NewListCommand(app application.Application) *cobra.Command {
   starmap models list --capability vision      # Filter by capability
   starmap models list --min-context 100000     # Filter by context window
   starmap models list --max-price 0.50         # Filter by price
+  starmap models list --min-speed 100 --sort speed  # Fastest models first
+  starmap models list --filter "pricing.input < 1.0 && features.vision && limits.context >= 128000"
+  starmap models list --use medical_advice --region DE  # Usable for medical advice in Germany
+  starmap models list --curation approved-for-prod --exclude-curation banned  # Governance tags
+  starmap models list --review pending-review  # Models awaiting review
+  starmap models list --view frontend-team     # Models of a named view (see starmap views)
+  starmap models list --view frontend-team --export openai  # Export a view
+  starmap models list --provider openai --fresh  # Fetch live from the OpenAI API
   starmap models list --details                # Show detailed information`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			// Get logger from app
@@ -42,8 +51,81 @@ func NewListCommand(app application.Application) *cobra.Command {
 			minContext := mustGetInt64(cmd, "min-context")
 			maxPrice := mustGetFloat64(cmd, "max-price")
 			exportFormat := mustGetString(cmd, "export")
+			opts := query.ModelOptions{
+				Author:     resourceFlags.Author,
+				Capability: capability,
+				MinContext: minContext,
+				MaxPrice:   maxPrice,
+				MinSpeed:   mustGetFloat64(cmd, "min-speed"),
+				Search:     resourceFlags.Search,
+				Sort:       mustGetString(cmd, "sort"),
+				Limit:      resourceFlags.Limit,
+			}
+			uses, err := query.ParseUses(mustGetString(cmd, "use"))
+			if err != nil {
+				return err
+			}
+			opts.Uses = uses
+			if opts.Curation, err = query.ParseCurationTags(mustGetString(cmd, "curation")); err != nil {
+				return err
+			}
+			if opts.ExcludeCuration, err = query.ParseCurationTags(mustGetString(cmd, "exclude-curation")); err != nil {
+				return err
+			}
+			if opts.Review, err = query.ParseReviewFilter(mustGetString(cmd, "review")); err != nil {
+				return err
+			}
+			for _, expr := range resourceFlags.Filter {
+				filter, err := catalogs.ParseModelQuery(expr)
+				if err != nil {
+					return err
+				}
+				opts.Filters = append(opts.Filters, filter)
+			}
+			opts.Region = mustGetString(cmd, "region")
+			if err := query.ValidateRegion(opts.Region); err != nil {
+				return err
+			}
+			switch opts.Sort {
+			case "", query.ModelSortID, query.ModelSortSpeed, query.ModelSortLatency:
+			default:
+				return &errors.ValidationError{
+					Field:   "sort",
+					Value:   opts.Sort,
+					Message: "unsupported sort (use id, speed, or latency)",
+				}
+			}
 
-			return listModels(cmd, app, logger, resourceFlags, capability, minContext, maxPrice, showDetails, exportFormat)
+			fresh := mustGetBool(cmd, "fresh")
+			if fresh && resourceFlags.Provider == "" {
+				return &errors.ValidationError{
+					Field:   "fresh",
+					Message: "requires --provider",
+				}
+			}
+
+			var selected *view.View
+			if name := mustGetString(cmd, "view"); name != "" {
+				if fresh {
+					return &errors.ValidationError{
+						Field:   "fresh",
+						Message: "cannot be combined with --view",
+					}
+				}
+				views, err := view.Load(mustGetString(cmd, "views-file"))
+				if err != nil {
+					return err
+				}
+				if selected, err = views.Get(name); err != nil {
+					return err
+				}
+				// The view already selects review states.
+				if opts.Review == "" {
+					opts.Review = query.ReviewAny
+				}
+			}
+
+			return listModels(cmd, app, logger, resourceFlags.Provider, selected, fresh, opts, showDetails, exportFormat)
 		},
 	}
 
@@ -57,33 +139,56 @@ func NewListCommand(app application.Application) *cobra.Command {
 		"Minimum context window size")
 	cmd.Flags().Float64("max-price", 0,
 		"Maximum price per 1M input tokens")
+	cmd.Flags().Float64("min-speed", 0,
+		"Minimum output tokens per second")
+	cmd.Flags().String("sort", "",
+		"Sort by id, speed (fastest first), or latency (lowest time to first token first)")
+	cmd.Flags().String("use", "",
+		"Comma-separated intended uses the model's usage restrictions must permit (e.g., medical_advice)")
+	cmd.Flags().String("region", "",
+		"Deployment region (ISO 3166-1 alpha-2 code or EU) the model must not be blocked in")
+	cmd.Flags().String("curation", "",
+		"Comma-separated curation tags the model must carry (see starmap tag)")
+	cmd.Flags().String("exclude-curation", "",
+		"Comma-separated curation tags the model must not carry")
+	cmd.Flags().String("review", "",
+		"Review state to list: pending-review, approved, rejected, or any (default: models cleared to serve)")
+	cmd.Flags().String("view", "",
+		"Only list models of this named view, with its overrides applied")
+	cmd.Flags().String("views-file", "",
+		"Views file defining named views (default: ~/.starmap/views.yaml)")
+	cmd.Flags().Bool("fresh", false,
+		"Fetch the --provider models from its API instead of the catalog (a sync within 15 minutes reuses the fetch)")
 	cmd.Flags().String("export", "",
 		"Export models in specified format (openai, openrouter)")
 
 	return cmd
 }
 
-// listModels lists all models with optional filters.
-func listModels(cmd *cobra.Command, app application.Application, logger *zerolog.Logger, flags *globals.ResourceFlags, capability string, minContext int64, maxPrice float64, showDetails bool, exportFormat string) error {
+// listModels lists all models, the models of view when set, or the models
+// fetched live from provider's API when fresh, with optional filters.
+func listModels(cmd *cobra.Command, app application.Application, logger *zerolog.Logger, provider string, selected *view.View, fresh bool, opts query.ModelOptions, showDetails bool, exportFormat string) error {
 	// Get catalog from app
 	cat, err := app.Catalog()
 	if err != nil {
 		return err
 	}
 
-	allModels, err := query.CatalogModels(cat, flags.Provider)
+	var allModels []catalogs.Model
+	switch {
+	case fresh:
+		allModels, err = fetchFreshModels(cmd.Context(), cat, provider)
+	case selected != nil:
+		allModels, err = selected.Models(cat, provider)
+	default:
+		allModels, err = query.CatalogModels(cat, provider)
+	}
 	if err != nil {
 		return err
 	}
+	opts.UsageProvider, _ = cat.Providers().Get(catalogs.ProviderID(provider))
 
-	filtered := query.Models(allModels, query.ModelOptions{
-		Author:     flags.Author,
-		Capability: capability,
-		MinContext: minContext,
-		MaxPrice:   maxPrice,
-		Search:     flags.Search,
-		Limit:      flags.Limit,
-	})
+	filtered := query.Models(allModels, opts)
 
 	// Handle export format if specified
 	if exportFormat != "" {
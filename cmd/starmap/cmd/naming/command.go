@@ -0,0 +1,156 @@
+// Package naming provides the naming command, which reports models whose
+// display names differ across providers and suggests normalized names.
+package naming
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/naming"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/table"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// NewCommand creates the naming command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var (
+		providerID string
+		apply      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "naming",
+		GroupID: "catalog",
+		Short:   "Report inconsistent model display names across providers",
+		Long: `Show the models whose display names differ across the providers that offer
+them, such as "Claude 3.5 Sonnet" and "claude-3-5-sonnet-20241022", with a
+suggested normalized name for each.
+
+Model IDs are compared ignoring case and any organization prefix. The
+suggested name is the author's name for the model when it has one, otherwise
+the display name most providers use. Names that are the model ID or written
+like one are never suggested.
+
+Use --apply to rename the offerings to the suggested names in the local
+catalog layer and publish a new generation. Provider APIs own model names,
+so a later update may restore a name a provider reports.`,
+		Args: cobra.NoArgs,
+		Example: `  starmap naming
+  starmap naming -p bedrock -o json
+  starmap naming --apply`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cat, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			report, err := naming.Build(cat)
+			if err != nil {
+				return err
+			}
+			if providerID != "" {
+				report.Findings = onlyProvider(report.Findings, catalogs.ProviderID(providerID))
+			}
+			if err := printReport(cmd.OutOrStdout(), app.OutputFormat(), report); err != nil {
+				return err
+			}
+			if !apply {
+				return nil
+			}
+
+			renames := report.Renames()
+			if providerID != "" {
+				kept := renames[:0]
+				for _, rename := range renames {
+					if rename.Provider == catalogs.ProviderID(providerID) {
+						kept = append(kept, rename)
+					}
+				}
+				renames = kept
+			}
+			sm, err := app.Starmap()
+			if err != nil {
+				return err
+			}
+			edits := make([]starmap.ModelRename, 0, len(renames))
+			for _, rename := range renames {
+				edits = append(edits, starmap.ModelRename{Provider: rename.Provider, Model: rename.Model, Name: rename.To})
+			}
+			renamed, err := sm.RenameModels(cmd.Context(), edits)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.ErrOrStderr(), "%s Renamed %d model offerings\n", emoji.Success, renamed)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&providerID, "provider", "p", "", "Only report models this provider offers")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Rename offerings to the suggested names")
+
+	return cmd
+}
+
+// onlyProvider keeps the findings with an offering by providerID.
+func onlyProvider(findings []naming.Finding, providerID catalogs.ProviderID) []naming.Finding {
+	kept := findings[:0]
+	for _, finding := range findings {
+		for _, name := range finding.Names {
+			if hasProvider(name.Offerings, providerID) {
+				kept = append(kept, finding)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+func hasProvider(offerings []naming.Offering, providerID catalogs.ProviderID) bool {
+	for _, offering := range offerings {
+		if offering.Provider == providerID {
+			return true
+		}
+	}
+	return false
+}
+
+func printReport(w io.Writer, outputFormat string, report *naming.Report) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, report)
+	}
+	if len(report.Findings) == 0 {
+		_, err := fmt.Fprintln(w, "No inconsistent model names found.")
+		return err
+	}
+
+	rows := [][]string{}
+	for _, finding := range report.Findings {
+		suggested := finding.Suggested
+		if suggested == "" {
+			suggested = "-"
+		}
+		for i, name := range finding.Names {
+			providers := make([]string, 0, len(name.Offerings))
+			for _, offering := range name.Offerings {
+				providers = append(providers, string(offering.Provider))
+			}
+			row := []string{"", name.Name, strings.Join(providers, ", "), ""}
+			if i == 0 {
+				row[0], row[3] = finding.Model, suggested
+			}
+			rows = append(rows, row)
+		}
+	}
+	return format.NewFormatter(detected).Format(w, format.Data{
+		Headers:         []string{"Model", "Name", "Providers", "Suggested"},
+		Rows:            rows,
+		ColumnAlignment: []table.Align{table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignLeft},
+	})
+}
@@ -0,0 +1,116 @@
+// Package pricing provides the model pricing commands.
+package pricing
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/locale"
+	"github.com/agentstation/starmap/internal/cli/table"
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/pricehistory"
+)
+
+// NewCommand creates the pricing command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pricing",
+		GroupID: "catalog",
+		Short:   "Track model token prices over time",
+		Long: `Track model token prices over time.
+
+Each applied starmap update records the token prices of every provider
+offering whose prices changed since the previous update, so the recorded
+history shows when and by how much each provider changed its prices.`,
+	}
+
+	cmd.AddCommand(newHistoryCommand(app))
+
+	return cmd
+}
+
+func newHistoryCommand(app application.Application) *cobra.Command {
+	var (
+		provider    string
+		historyFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history <model-id>",
+		Short: "Show the recorded token prices of a model",
+		Long: `Show the token prices recorded for a model, oldest first, one row per price
+change of each provider offering. Prices are per one million tokens.`,
+		Args: cobra.ExactArgs(1),
+		Example: `  starmap pricing history gpt-4o                    # Every provider offering gpt-4o
+  starmap pricing history gpt-4o --provider openai  # Only OpenAI's prices
+  starmap pricing history gpt-4o -o json            # Price points as JSON`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := pricehistory.NewStore(paths.ExpandHome(historyFile))
+			points, err := store.History(args[0], catalogs.ProviderID(provider))
+			if err != nil {
+				return err
+			}
+			if len(points) == 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("no pricing history found for model %q\n\nRun 'starmap update' to record prices", args[0])
+			}
+
+			detected := format.DetectFormat(app.OutputFormat())
+			if detected != format.FormatTable && detected != format.FormatWide {
+				return format.NewFormatter(detected).Format(os.Stdout, points)
+			}
+			return format.NewFormatter(detected).Format(os.Stdout, pointsTable(points))
+		},
+	}
+
+	cmd.Flags().StringVarP(&provider, "provider", "p", "",
+		"Only show this provider's offering of the model (default: every offering)")
+	cmd.Flags().StringVar(&historyFile, "history-file", constants.DefaultPricingHistoryPath,
+		"Recorded price history to read")
+
+	return cmd
+}
+
+// pointsTable lays out price points with the change from the previous point
+// of the same offering.
+func pointsTable(points []pricehistory.Point) format.Data {
+	previous := make(map[catalogs.ProviderID]pricehistory.Point)
+	rows := make([][]string, 0, len(points))
+	for _, point := range points {
+		before, seen := previous[point.ProviderID]
+		rows = append(rows, []string{
+			point.RecordedAt.Format(constants.TimeFormatISO8601),
+			string(point.ProviderID),
+			string(point.Currency),
+			priceCell(point.Input, before.Input, seen),
+			priceCell(point.Output, before.Output, seen),
+			priceCell(point.CacheRead, before.CacheRead, seen),
+			priceCell(point.CacheWrite, before.CacheWrite, seen),
+		})
+		previous[point.ProviderID] = point
+	}
+	return format.Data{
+		Headers:         []string{"Recorded", "Provider", "Currency", "Input", "Output", "Cache Read", "Cache Write"},
+		Rows:            rows,
+		ColumnAlignment: []table.Align{table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignRight, table.AlignRight, table.AlignRight, table.AlignRight},
+	}
+}
+
+// priceCell formats a price, followed by its percentage change from the
+// previous point when it changed.
+func priceCell(price, before *float64, seen bool) string {
+	if price == nil {
+		return "-"
+	}
+	cell := locale.Decimal(*price, -1)
+	if !seen || before == nil || *before == *price || *before == 0 {
+		return cell
+	}
+	return fmt.Sprintf("%s (%+.1f%%)", cell, (*price-*before) / *before * 100)
+}
@@ -0,0 +1,27 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/pricehistory"
+)
+
+func TestPointsTableShowsChangePerProvider(t *testing.T) {
+	price := func(v float64) *float64 { return &v }
+	recorded := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	data := pointsTable([]pricehistory.Point{
+		{ProviderID: "openai", RecordedAt: recorded, Currency: "USD", Input: price(5)},
+		{ProviderID: "azure", RecordedAt: recorded, Currency: "USD", Input: price(6)},
+		{ProviderID: "openai", RecordedAt: recorded.AddDate(0, 1, 0), Currency: "USD", Input: price(2.5)},
+	})
+	if got := data.Rows[1][3]; got != "6" {
+		t.Errorf("first azure input = %q, want no change against openai", got)
+	}
+	if got := data.Rows[2][3]; got != "2.5 (-50.0%)" {
+		t.Errorf("second openai input = %q, want 2.5 (-50.0%%)", got)
+	}
+	if got := data.Rows[2][4]; got != "-" {
+		t.Errorf("unlisted output = %q, want -", got)
+	}
+}
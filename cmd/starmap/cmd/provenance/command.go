@@ -0,0 +1,175 @@
+// Package provenance provides the field provenance command for models.
+package provenance
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/constants"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/globals"
+	"github.com/agentstation/starmap/internal/cli/table"
+	"github.com/agentstation/starmap/internal/paths"
+	pkgconstants "github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+	pkgprovenance "github.com/agentstation/starmap/pkg/provenance"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// Options selects the provenance shown for a model.
+type Options struct {
+	Fields      []string // Case-insensitive field patterns; empty shows every field
+	Current     bool     // Show only the value in effect for each field
+	HistoryFile string   // Durable provenance history merged into the output; empty skips it
+}
+
+// NewCommand creates the provenance command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var opts Options
+
+	cmd := &cobra.Command{
+		Use:     "provenance <model-id>",
+		GroupID: "catalog",
+		Short:   "Show where each field of a model came from",
+		Long: `Show, for each field of a model, which source set its value, when, and with
+what authority and confidence, followed by the values it held before.
+
+Provenance is recorded by starmap update; run an update first if a model has
+none. Each applied update also appends the values in effect to a durable
+history file, so earlier values remain visible after later updates.
+starmap models history shows the same data.`,
+		Args: cobra.ExactArgs(1),
+		Example: `  starmap provenance gpt-4o                       # Every field with its history
+  starmap provenance gpt-4o --current             # Only the values in effect
+  starmap provenance gpt-4o --fields='pricing.*'  # Pricing fields only
+  starmap provenance gpt-4o -o json               # Output as JSON`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Show(cmd, app, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Fields, "fields", []string{},
+		"Filter to specific fields (comma-separated, case-insensitive, supports wildcards like 'pricing.*')")
+	cmd.Flags().BoolVar(&opts.Current, "current", false,
+		"Show only the value in effect for each field, without prior values")
+	cmd.Flags().StringVar(&opts.HistoryFile, "history-file", pkgconstants.DefaultProvenanceHistoryPath,
+		"Durable provenance history to include (empty to skip)")
+
+	return cmd
+}
+
+// Show prints the field provenance of a model in the output format of cmd.
+func Show(cmd *cobra.Command, app application.Application, modelID string, opts Options) error {
+	cat, err := app.Catalog()
+	if err != nil {
+		return err
+	}
+
+	// Find model across all providers
+	var found bool
+	for _, provider := range cat.Providers().List() {
+		if _, exists := provider.Models[modelID]; exists {
+			found = true
+			break
+		}
+	}
+	if !found {
+		cmd.SilenceUsage = true
+		return &errors.NotFoundError{
+			Resource: "model",
+			ID:       modelID,
+		}
+	}
+
+	fieldProvenance := cat.Provenance().FindByResource(sources.ResourceTypeModel, modelID)
+	if opts.HistoryFile != "" {
+		stored, err := pkgprovenance.NewStore(paths.ExpandHome(opts.HistoryFile), 0).FindByResource(sources.ResourceTypeModel, modelID)
+		if err != nil {
+			return err
+		}
+		fieldProvenance = mergeHistory(fieldProvenance, stored)
+	}
+	if len(fieldProvenance) == 0 {
+		return fmt.Errorf("no history data found for model %q\n\nRun 'starmap update' to generate history tracking data", modelID)
+	}
+
+	if len(opts.Fields) > 0 {
+		filtered := make(map[string][]pkgprovenance.Provenance)
+		for field, provList := range fieldProvenance {
+			if table.MatchField(field, opts.Fields) {
+				filtered[field] = provList
+			}
+		}
+		fieldProvenance = filtered
+
+		if len(fieldProvenance) == 0 {
+			return fmt.Errorf("no history data found for model %q matching fields: %s", modelID, strings.Join(opts.Fields, ", "))
+		}
+	}
+	if opts.Current {
+		fieldProvenance = currentOnly(fieldProvenance)
+	}
+
+	globalFlags, err := globals.Parse(cmd)
+	if err != nil {
+		return err
+	}
+	formatter := format.NewFormatter(format.Format(globalFlags.Output))
+
+	// For structured output (JSON/YAML), return raw data
+	if globalFlags.Output != constants.FormatTable && globalFlags.Output != "" {
+		return formatter.Format(os.Stdout, fieldProvenance)
+	}
+
+	tableData := table.ProvenanceToTableData(fieldProvenance)
+	return formatter.Format(os.Stdout, format.Data{
+		Headers:         tableData.Headers,
+		Rows:            tableData.Rows,
+		ColumnAlignment: tableData.ColumnAlignment,
+	})
+}
+
+// currentOnly keeps the newest entry of each field's history.
+func currentOnly(fieldProvenance map[string][]pkgprovenance.Provenance) map[string][]pkgprovenance.Provenance {
+	current := make(map[string][]pkgprovenance.Provenance, len(fieldProvenance))
+	for field, history := range fieldProvenance {
+		if len(history) == 0 {
+			continue
+		}
+		newest := slices.MaxFunc(history, func(a, b pkgprovenance.Provenance) int {
+			return a.Timestamp.Compare(b.Timestamp)
+		})
+		current[field] = []pkgprovenance.Provenance{newest}
+	}
+	return current
+}
+
+// mergeHistory adds the stored history of each field to its current
+// provenance, dropping entries recorded by both, oldest first.
+func mergeHistory(current, stored map[string][]pkgprovenance.Provenance) map[string][]pkgprovenance.Provenance {
+	merged := make(map[string][]pkgprovenance.Provenance, len(current))
+	for field, entries := range current {
+		merged[field] = append([]pkgprovenance.Provenance(nil), entries...)
+	}
+	for field, entries := range stored {
+		for _, entry := range entries {
+			duplicate := slices.ContainsFunc(merged[field], func(existing pkgprovenance.Provenance) bool {
+				return existing.Source == entry.Source && existing.Timestamp.Equal(entry.Timestamp)
+			})
+			if !duplicate {
+				merged[field] = append(merged[field], entry)
+			}
+		}
+	}
+	for field := range merged {
+		slices.SortStableFunc(merged[field], func(a, b pkgprovenance.Provenance) int {
+			return a.Timestamp.Compare(b.Timestamp)
+		})
+	}
+	return merged
+}
@@ -0,0 +1,25 @@
+package provenance
+
+import (
+	"testing"
+	"time"
+
+	pkgprovenance "github.com/agentstation/starmap/pkg/provenance"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+func TestCurrentOnlyKeepsNewestEntry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	history := map[string][]pkgprovenance.Provenance{
+		"Name": {
+			{Source: sources.ModelsDevHTTPID, Value: "Old Name", Timestamp: now.Add(-time.Hour)},
+			{Source: sources.ProvidersID, Value: "New Name", Timestamp: now},
+			{Source: sources.LocalCatalogID, Value: "Oldest Name", Timestamp: now.Add(-2 * time.Hour)},
+		},
+	}
+
+	current := currentOnly(history)
+	if len(current["Name"]) != 1 || current["Name"][0].Value != "New Name" {
+		t.Fatalf("currentOnly = %+v, want only the newest Name entry", current)
+	}
+}
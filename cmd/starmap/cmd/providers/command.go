@@ -33,10 +33,13 @@ show provider details, and fetch from provider APIs.`,
 		Args: cobra.MaximumNArgs(1),
 		Example: `  starmap providers                    # List all providers with auth status
   starmap providers --test             # Test all provider credentials
+  starmap providers --min-uptime 99.9  # Providers committing to 99.9% uptime
+  starmap providers --use facial_recognition --region DE  # Policy permits the use in Germany
   starmap providers openai             # Show OpenAI provider details
   starmap providers openai --test      # Test OpenAI credentials
   starmap providers fetch              # Fetch from all provider APIs
-  starmap providers fetch openai       # Fetch from OpenAI API`,
+  starmap providers fetch openai       # Fetch from OpenAI API
+  starmap providers verify openai gpt-4o-mini  # Probe advertised capabilities`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Check if --test flag is present
 			testMode, _ := cmd.Flags().GetBool("test")
@@ -67,8 +70,17 @@ show provider details, and fetch from provider APIs.`,
 	cmd.Flags().Bool("test", false, "Test provider credentials by making API calls")
 	cmd.Flags().Duration("timeout", 10*time.Second, "Timeout for API calls when testing")
 
+	// Add reliability filters
+	cmd.Flags().Bool("sla", false, "Only list providers with a published SLA")
+	cmd.Flags().Float64("min-uptime", 0, "Minimum committed uptime percentage (e.g. 99.9)")
+
+	// Add acceptable-use filters
+	cmd.Flags().String("use", "", "Comma-separated intended uses the usage policy must permit (e.g. facial_recognition)")
+	cmd.Flags().String("region", "", "Deployment region (ISO 3166-1 alpha-2 code or EU) the provider must serve")
+
 	// Add subcommands
 	cmd.AddCommand(NewFetchCommand(app))
+	cmd.AddCommand(NewVerifyCommand(app))
 
 	return cmd
 }
@@ -84,9 +96,29 @@ func listProviders(cmd *cobra.Command, app application.Application, logger *zero
 	// Get all providers
 	allProviders := cat.Providers().List()
 
+	minUptime := mustGetFloat64(cmd, "min-uptime")
+	if minUptime < 0 || minUptime > 100 {
+		return &errors.ValidationError{
+			Field:   "min-uptime",
+			Value:   minUptime,
+			Message: "must be a percentage between 0 and 100",
+		}
+	}
+	uses, err := query.ParseUses(mustGetString(cmd, "use"))
+	if err != nil {
+		return err
+	}
+	region := mustGetString(cmd, "region")
+	if err := query.ValidateRegion(region); err != nil {
+		return err
+	}
 	filtered := query.Providers(allProviders, query.ProviderOptions{
-		Search: flags.Search,
-		Limit:  flags.Limit,
+		Search:     flags.Search,
+		RequireSLA: mustGetBool(cmd, "sla"),
+		MinUptime:  minUptime,
+		Uses:       uses,
+		Region:     region,
+		Limit:      flags.Limit,
 	})
 
 	// Create auth checker and get supported providers
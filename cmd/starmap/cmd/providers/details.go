@@ -3,6 +3,9 @@ package providers
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/agentstation/starmap/internal/cli/format"
 	"github.com/agentstation/starmap/pkg/catalogs"
@@ -37,6 +40,179 @@ func printProviderDetails(provider *catalogs.Provider) {
 	_ = formatter.Format(os.Stdout, basicTable)
 	fmt.Println()
 
+	if rows := reliabilityRows(provider); len(rows) > 0 {
+		fmt.Println("Reliability:")
+		_ = formatter.Format(os.Stdout, format.Data{
+			Headers: []string{"Property", "Value"},
+			Rows:    rows,
+		})
+		fmt.Println()
+	}
+
+	if rows := supportRows(provider); len(rows) > 0 {
+		fmt.Println("Support:")
+		_ = formatter.Format(os.Stdout, format.Data{
+			Headers: []string{"Channel", "Contact"},
+			Rows:    rows,
+		})
+		fmt.Println()
+	}
+
+	if rows := usageRestrictionRows(provider.UsageRestrictions); len(rows) > 0 {
+		fmt.Println("Acceptable Use:")
+		_ = formatter.Format(os.Stdout, format.Data{
+			Headers: []string{"Property", "Value"},
+			Rows:    rows,
+		})
+		fmt.Println()
+	}
+
+	if rows := sustainabilityRows(provider.Sustainability); len(rows) > 0 {
+		fmt.Println("Sustainability (provider claims):")
+		_ = formatter.Format(os.Stdout, format.Data{
+			Headers: []string{"Property", "Value"},
+			Rows:    rows,
+		})
+		fmt.Println()
+	}
+
 	// Model count
 	fmt.Printf("Models: %d\n", len(provider.Models))
 }
+
+// reliabilityRows returns the provider's SLA commitments as table rows.
+func reliabilityRows(provider *catalogs.Provider) [][]string {
+	sla := provider.SLA
+	if sla == nil {
+		return nil
+	}
+	var rows [][]string
+	if sla.UptimeCommitment != nil {
+		rows = append(rows, []string{"Uptime Commitment", strconv.FormatFloat(*sla.UptimeCommitment, 'f', -1, 64) + "%"})
+	}
+	if sla.ServiceCredits != nil {
+		rows = append(rows, []string{"Service Credits", yesNo(*sla.ServiceCredits)})
+	}
+	if sla.CreditsPolicy != nil && *sla.CreditsPolicy != "" {
+		rows = append(rows, []string{"Credits Policy", *sla.CreditsPolicy})
+	}
+	if sla.EnterpriseTier != nil {
+		rows = append(rows, []string{"Enterprise Tier", yesNo(*sla.EnterpriseTier)})
+	}
+	if sla.EnterpriseOnly != nil {
+		rows = append(rows, []string{"SLA Enterprise Only", yesNo(*sla.EnterpriseOnly)})
+	}
+	if sla.URL != nil && *sla.URL != "" {
+		rows = append(rows, []string{"SLA Terms", *sla.URL})
+	}
+	return rows
+}
+
+// usageRestrictionRows returns the provider's acceptable-use restrictions as
+// table rows.
+func usageRestrictionRows(restrictions *catalogs.UsageRestrictions) [][]string {
+	if restrictions == nil {
+		return nil
+	}
+	var rows [][]string
+	if len(restrictions.Restricted) > 0 {
+		uses := make([]string, len(restrictions.Restricted))
+		for i, use := range restrictions.Restricted {
+			uses[i] = use.String()
+		}
+		rows = append(rows, []string{"Restricted Uses", strings.Join(uses, ", ")})
+	}
+	if len(restrictions.BlockedRegions) > 0 {
+		rows = append(rows, []string{"Blocked Regions", strings.Join(restrictions.BlockedRegions, ", ")})
+	}
+	if restrictions.PolicyURL != nil && *restrictions.PolicyURL != "" {
+		rows = append(rows, []string{"Usage Policy", *restrictions.PolicyURL})
+	}
+	return rows
+}
+
+// sustainabilityRows returns the provider's environmental claims as table
+// rows.
+func sustainabilityRows(claims *catalogs.ProviderSustainability) [][]string {
+	if claims == nil {
+		return nil
+	}
+	var rows [][]string
+	if claims.CarbonNeutral != nil {
+		rows = append(rows, []string{"Carbon Neutral", yesNo(*claims.CarbonNeutral)})
+	}
+	if claims.RenewableEnergyShare != nil {
+		rows = append(rows, []string{"Renewable Energy", strconv.FormatFloat(*claims.RenewableEnergyShare, 'f', -1, 64) + "%"})
+	}
+	if claims.CarbonIntensity != nil {
+		rows = append(rows, []string{"Carbon Intensity", strconv.FormatFloat(*claims.CarbonIntensity, 'f', -1, 64) + " gCO2e/kWh"})
+	}
+	if claims.Claim != nil && *claims.Claim != "" {
+		rows = append(rows, []string{"Claim", *claims.Claim})
+	}
+	if claims.ClaimURL != nil && *claims.ClaimURL != "" {
+		rows = append(rows, []string{"Source", *claims.ClaimURL})
+	}
+	return rows
+}
+
+// supportRows returns the provider's support channels and tiers as table
+// rows.
+func supportRows(provider *catalogs.Provider) [][]string {
+	support := provider.Support
+	if support == nil {
+		return nil
+	}
+	var rows [][]string
+	for _, channel := range []struct {
+		name  string
+		value *string
+	}{
+		{"Email", support.Email},
+		{"Help Center", support.URL},
+		{"Discord", support.Discord},
+		{"Forum", support.Forum},
+	} {
+		if channel.value != nil && *channel.value != "" {
+			rows = append(rows, []string{channel.name, *channel.value})
+		}
+	}
+	for _, tier := range support.Tiers {
+		var details []string
+		if tier.Enterprise {
+			details = append(details, "enterprise")
+		}
+		if tier.ResponseTime != nil {
+			details = append(details, "response within "+formatResponseTime(*tier.ResponseTime))
+		}
+		if tier.Contact != nil && *tier.Contact != "" {
+			details = append(details, *tier.Contact)
+		}
+		value := strings.Join(details, ", ")
+		if value == "" {
+			value = "-"
+		}
+		rows = append(rows, []string{tier.Name + " Support", value})
+	}
+	return rows
+}
+
+// formatResponseTime renders whole hours and minutes compactly (1h, 15m)
+// instead of the time.Duration form (1h0m0s).
+func formatResponseTime(d time.Duration) string {
+	value := d.String()
+	if strings.HasSuffix(value, "m0s") {
+		value = strings.TrimSuffix(value, "0s")
+	}
+	if strings.HasSuffix(value, "h0m") {
+		value = strings.TrimSuffix(value, "0m")
+	}
+	return value
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
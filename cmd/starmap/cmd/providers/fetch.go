@@ -17,7 +17,9 @@ import (
 	"github.com/agentstation/starmap/internal/cli/format"
 	"github.com/agentstation/starmap/internal/cli/provider"
 	"github.com/agentstation/starmap/internal/cli/table"
+	"github.com/agentstation/starmap/internal/paths"
 	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
 	"github.com/agentstation/starmap/pkg/errors"
 	"github.com/agentstation/starmap/pkg/sources"
 )
@@ -33,6 +35,7 @@ func NewFetchCommand(app application.Application) *cobra.Command {
 		timeoutFlag int
 		rawFlag     bool
 		statsFlag   bool
+		freshFlag   bool
 	)
 
 	cmd := &cobra.Command{
@@ -45,7 +48,8 @@ func NewFetchCommand(app application.Application) *cobra.Command {
   starmap providers fetch groq --raw   # Get raw API response from Groq
   starmap providers fetch -o json      # Output as JSON instead of table
   starmap providers fetch --raw --stats # Raw response with statistics
-  starmap providers fetch --stats      # All providers with statistics`,
+  starmap providers fetch --stats      # All providers with statistics
+  starmap providers fetch openai --fresh  # Revalidate the cached response now`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			logger := app.Logger()
@@ -53,11 +57,11 @@ func NewFetchCommand(app application.Application) *cobra.Command {
 
 			// No provider specified - fetch all
 			if len(args) == 0 {
-				return fetchAllProviders(ctx, app, timeoutFlag, quiet, rawFlag, statsFlag)
+				return fetchAllProviders(ctx, app, timeoutFlag, quiet, rawFlag, statsFlag, freshFlag)
 			}
 
 			// Provider specified as positional arg
-			return fetchProviderModels(cmd, app, args[0], timeoutFlag, quiet, rawFlag, statsFlag)
+			return fetchProviderModels(cmd, app, args[0], timeoutFlag, quiet, rawFlag, statsFlag, freshFlag)
 		},
 	}
 
@@ -68,12 +72,14 @@ func NewFetchCommand(app application.Application) *cobra.Command {
 		"Return raw JSON response from provider API")
 	cmd.Flags().BoolVar(&statsFlag, "stats", false,
 		"Show request statistics (latency, payload size, auth method)")
+	cmd.Flags().BoolVar(&freshFlag, "fresh", false,
+		"Revalidate cached API responses instead of reusing them for up to 15 minutes")
 
 	return cmd
 }
 
 // fetchProviderModels fetches models from a specific provider using app context.
-func fetchProviderModels(cmd *cobra.Command, app application.Application, providerID string, timeout int, quiet bool, raw bool, stats bool) error {
+func fetchProviderModels(cmd *cobra.Command, app application.Application, providerID string, timeout int, quiet bool, raw bool, stats bool, fresh bool) error {
 	// Get context from command
 	ctx := cmd.Context()
 	// Create context with timeout
@@ -95,7 +101,7 @@ func fetchProviderModels(cmd *cobra.Command, app application.Application, provid
 	}
 
 	// Use provider fetcher
-	fetcher := sources.NewProviderFetcher(cat.Providers())
+	fetcher := newFetcher(cat, fresh)
 
 	// Handle raw response mode
 	if raw {
@@ -217,14 +223,14 @@ func fetchProviderModels(cmd *cobra.Command, app application.Application, provid
 }
 
 // fetchAllProviders fetches models from all configured providers concurrently using app context.
-func fetchAllProviders(ctx context.Context, app application.Application, timeout int, quiet bool, raw bool, stats bool) error {
+func fetchAllProviders(ctx context.Context, app application.Application, timeout int, quiet bool, raw bool, stats bool, fresh bool) error {
 	cat, err := app.Catalog()
 	if err != nil {
 		return err
 	}
 
 	providers := cat.Providers().List()
-	fetcher := sources.NewProviderFetcher(cat.Providers())
+	fetcher := newFetcher(cat, fresh)
 
 	// Filter to only providers with clients
 	// Convert to pointer slice for compatibility
@@ -423,6 +429,18 @@ func fetchAllProvidersRaw(ctx context.Context, app application.Application, vali
 	return formatter.Format(os.Stdout, rawResponses)
 }
 
+// newFetcher returns a provider fetcher that reuses API responses from the
+// provider HTTP cache, revalidating every one when fresh is set.
+func newFetcher(cat catalogs.Reader, fresh bool) *sources.ProviderFetcher {
+	opts := []sources.ProviderOption{
+		sources.WithHTTPCache(paths.ExpandHome(constants.DefaultProviderHTTPCachePath), constants.ProviderHTTPCacheTTL),
+	}
+	if fresh {
+		opts = append(opts, sources.WithFreshFetch())
+	}
+	return sources.NewProviderFetcher(cat.Providers(), opts...)
+}
+
 // displayFetchStats displays fetch statistics to the provided writer (typically stderr).
 //
 //nolint:errcheck // Ignoring write errors for display output
@@ -434,6 +452,9 @@ func displayFetchStats(w io.Writer, providerID catalogs.ProviderID, stats *sourc
 	fmt.Fprintf(w, "  Latency:      %dms\n", stats.Latency.Milliseconds())
 	fmt.Fprintf(w, "  Payload:      %s (%d bytes)\n", stats.HumanSize(), stats.PayloadSize)
 	fmt.Fprintf(w, "  Content-Type: %s\n", stats.ContentType)
+	if stats.Cache != "" {
+		fmt.Fprintf(w, "  Cache:        %s\n", stats.Cache)
+	}
 
 	if stats.AuthMethod != "None" {
 		if stats.AuthMethod == "Query" {
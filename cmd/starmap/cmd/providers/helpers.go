@@ -17,6 +17,16 @@ func mustGetBool(cmd *cobra.Command, name string) bool {
 	return val
 }
 
+// mustGetString retrieves a string flag value or panics if the flag doesn't exist.
+// This should only be used for flags defined in this package.
+func mustGetString(cmd *cobra.Command, name string) string {
+	val, err := cmd.Flags().GetString(name)
+	if err != nil {
+		panic(fmt.Sprintf("programming error: failed to get flag %q: %v", name, err))
+	}
+	return val
+}
+
 // mustGetDuration retrieves a duration flag value or panics if the flag doesn't exist.
 // This should only be used for flags defined in this package.
 func mustGetDuration(cmd *cobra.Command, name string) time.Duration {
@@ -26,3 +36,13 @@ func mustGetDuration(cmd *cobra.Command, name string) time.Duration {
 	}
 	return val
 }
+
+// mustGetFloat64 retrieves a float64 flag value or panics if the flag doesn't exist.
+// This should only be used for flags defined in this package.
+func mustGetFloat64(cmd *cobra.Command, name string) float64 {
+	val, err := cmd.Flags().GetFloat64(name)
+	if err != nil {
+		panic(fmt.Sprintf("programming error: failed to get flag %q: %v", name, err))
+	}
+	return val
+}
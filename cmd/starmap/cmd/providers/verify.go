@@ -0,0 +1,190 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/provider"
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/internal/providers/probe"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// NewVerifyCommand creates the verify subcommand for capability probes.
+func NewVerifyCommand(app application.Application) *cobra.Command {
+	var (
+		capabilityFlags []string
+		allFlag         bool
+		saveFlag        bool
+		storeDir        string
+		timeout         time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify <provider> <model-id>...",
+		Short: "Verify advertised model capabilities with live probes",
+		Long: `Send tiny targeted requests to a provider's inference API to confirm that
+advertised capabilities actually work: a forced tool call, a JSON-mode
+request, and a 1x1 image input.
+
+By default only capabilities the catalog claims are probed. Use --all to also
+probe unclaimed capabilities and detect under-reported features. Each probe
+consumes a few tokens and requires the provider's API key.
+
+With --save, results are merged into a per-provider YAML record (claimed flag,
+verified outcome, probe method, endpoint, and time) under
+~/.starmap/sources/capability-verifications.`,
+		Args: cobra.MinimumNArgs(2),
+		Example: `  starmap providers verify openai gpt-4o-mini
+  starmap providers verify anthropic claude-haiku-4-5-20251001 --capability tools
+  starmap providers verify groq llama-3.3-70b-versatile --all --save`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			capabilities := make([]probe.Capability, 0, len(capabilityFlags))
+			for _, value := range capabilityFlags {
+				capability, err := probe.ParseCapability(value)
+				if err != nil {
+					return err
+				}
+				capabilities = append(capabilities, capability)
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			results, err := verifyModels(ctx, app, args[0], args[1:], capabilities, allFlag)
+			if err != nil {
+				return err
+			}
+
+			if saveFlag {
+				dir := storeDir
+				if dir == "" {
+					dir = paths.ExpandHome(constants.DefaultCapabilityVerificationsPath)
+				}
+				if err := (probe.Store{Dir: dir}).Record(results[0].ProviderID, results); err != nil {
+					return err
+				}
+			}
+			return printVerifyResults(app, results)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&capabilityFlags, "capability", nil,
+		"Capabilities to probe (repeatable): tools, json_mode, vision")
+	cmd.Flags().BoolVar(&allFlag, "all", false,
+		"Probe every capability, including ones the catalog does not claim")
+	cmd.Flags().BoolVar(&saveFlag, "save", false,
+		"Record results with provenance in the verification store")
+	cmd.Flags().StringVar(&storeDir, "store-dir", "",
+		"Verification store directory (default: ~/.starmap/sources/capability-verifications)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Overall timeout for all probes")
+
+	return cmd
+}
+
+// verifyModels runs the selected probes for each model of a provider.
+func verifyModels(ctx context.Context, app application.Application, providerID string, modelIDs []string, capabilities []probe.Capability, all bool) ([]probe.Result, error) {
+	cat, err := app.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	prov, err := provider.Get(cat, providerID)
+	if err != nil {
+		return nil, err
+	}
+	prov.LoadAPIKey()
+	prov.LoadEnvVars()
+
+	prober, err := probe.New(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []probe.Result{}
+	for _, modelID := range modelIDs {
+		model, ok := prov.Models[modelID]
+		if !ok {
+			return nil, &errors.NotFoundError{Resource: "model", ID: string(prov.ID) + "/" + modelID}
+		}
+		selected := selectCapabilities(model, capabilities, all)
+		if len(selected) == 0 {
+			continue
+		}
+		results = append(results, prober.Verify(ctx, model, selected)...)
+	}
+	if len(results) == 0 {
+		return nil, &errors.ValidationError{
+			Field:   "capability",
+			Message: "no claimed capabilities to probe; pass --capability or --all",
+		}
+	}
+	return results, nil
+}
+
+// selectCapabilities returns the explicit capabilities, every capability with
+// all set, or otherwise only the capabilities the catalog claims.
+func selectCapabilities(model *catalogs.Model, explicit []probe.Capability, all bool) []probe.Capability {
+	if len(explicit) > 0 {
+		return explicit
+	}
+	if all {
+		return probe.Capabilities()
+	}
+	claimed := []probe.Capability{}
+	for _, capability := range probe.Capabilities() {
+		if probe.Claimed(model, capability) {
+			claimed = append(claimed, capability)
+		}
+	}
+	return claimed
+}
+
+func printVerifyResults(app application.Application, results []probe.Result) error {
+	outputFormat := format.DetectFormat(app.OutputFormat())
+	if outputFormat != format.FormatTable && outputFormat != format.FormatWide {
+		return format.NewFormatter(outputFormat).Format(os.Stdout, results)
+	}
+
+	rows := make([][]string, 0, len(results))
+	mismatches := 0
+	for _, result := range results {
+		verdict := string(result.Status)
+		if result.Mismatch() {
+			verdict = emoji.Warning + " " + verdict
+			mismatches++
+		}
+		rows = append(rows, []string{
+			result.ModelID,
+			string(result.Capability),
+			claimedLabel(result.Claimed),
+			verdict,
+			result.Detail,
+		})
+	}
+	if err := format.NewFormatter(format.FormatTable).Format(os.Stdout, format.Data{
+		Headers: []string{"Model", "Capability", "Claimed", "Result", "Detail"},
+		Rows:    rows,
+	}); err != nil {
+		return err
+	}
+	if mismatches > 0 {
+		fmt.Printf("\n%s %d probe(s) disagree with the catalog\n", emoji.Warning, mismatches)
+	}
+	return nil
+}
+
+func claimedLabel(claimed bool) string {
+	if claimed {
+		return "yes"
+	}
+	return "no"
+}
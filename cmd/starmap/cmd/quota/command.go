@@ -0,0 +1,147 @@
+// Package quota provides the quota command for provider spend and usage.
+package quota
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/locale"
+	"github.com/agentstation/starmap/internal/providers/quota"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// NewCommand creates the quota command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var provider, since, until, keyEnv string
+	var limit float64
+	var details bool
+
+	cmd := &cobra.Command{
+		Use:     "quota",
+		GroupID: "catalog",
+		Short:   "Show spend and usage per provider key",
+		Long: `Show spend and token usage per provider key for a period.
+
+Spend and usage come from the provider usage APIs, which require an
+organization admin key separate from the inference key:
+
+  OpenAI     OPENAI_ADMIN_KEY     (organization usage and costs APIs)
+  Anthropic  ANTHROPIC_ADMIN_KEY  (Admin API usage and cost reports)
+
+Without --provider, every provider whose admin key is set is reported. Usage
+is also priced with the catalog's token prices, so the estimate can be
+compared with the provider's own spend figure.`,
+		Example: `  starmap quota                               # month-to-date, every configured key
+  starmap quota -p openai --limit 500         # remaining of a $500 monthly allowance
+  starmap quota -p anthropic --since 2026-09-01 --until 2026-10-01
+  starmap quota -p openai --key-env OPENAI_TEAM_B_ADMIN_KEY --details`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if limit < 0 {
+				return &errors.ValidationError{Field: "limit", Value: limit, Message: "must not be negative"}
+			}
+			start, end, err := quota.ParsePeriod(since, until, time.Now())
+			if err != nil {
+				return err
+			}
+			cat, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			reports, err := quota.Track(cmd.Context(), cat, catalogs.ProviderID(provider), start, end, quota.WithKeyEnv(keyEnv))
+			if err != nil {
+				return err
+			}
+			for _, report := range reports {
+				report.SetLimit(limit)
+			}
+			return printReports(cmd.OutOrStdout(), app.OutputFormat(), reports, details)
+		},
+	}
+
+	cmd.Flags().StringVarP(&provider, "provider", "p", "",
+		"Only report this provider (openai, anthropic)")
+	cmd.Flags().StringVar(&since, "since", "",
+		"Period start as YYYY-MM-DD or RFC 3339 (default: start of the current month)")
+	cmd.Flags().StringVar(&until, "until", "",
+		"Period end as YYYY-MM-DD or RFC 3339 (default: now)")
+	cmd.Flags().StringVar(&keyEnv, "key-env", "",
+		"Environment variable holding the admin key to report (default: <PROVIDER>_ADMIN_KEY)")
+	cmd.Flags().Float64Var(&limit, "limit", 0,
+		"Spend allowed for the period; reports the remainder")
+	cmd.Flags().BoolVar(&details, "details", false,
+		"Show usage per model")
+
+	return cmd
+}
+
+func printReports(w io.Writer, outputFormat string, reports []*quota.Report, details bool) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, reports)
+	}
+	if len(reports) == 0 {
+		_, err := fmt.Fprintf(w, "%s No provider admin keys set (OPENAI_ADMIN_KEY, ANTHROPIC_ADMIN_KEY)\n", emoji.Info)
+		return err
+	}
+
+	rows := make([][]string, 0, len(reports))
+	for _, r := range reports {
+		limit, remaining := "-", "-"
+		if r.Remaining != nil {
+			limit, remaining = money(r.Limit), money(*r.Remaining)
+		}
+		reported := "-"
+		if r.ReportedSpend != nil {
+			reported = money(*r.ReportedSpend)
+		}
+		rows = append(rows, []string{
+			string(r.ProviderID), r.KeyEnv + " " + r.Key,
+			locale.Date(r.Start) + " – " + locale.Date(r.End),
+			reported, money(r.EstimatedSpend), limit, remaining, r.Currency,
+		})
+	}
+	formatter := format.NewFormatter(detected)
+	if err := formatter.Format(w, format.Data{
+		Headers: []string{"Provider", "Key", "Period", "Spend", "Estimated", "Limit", "Remaining", "Currency"},
+		Rows:    rows,
+	}); err != nil {
+		return err
+	}
+	if !details {
+		return nil
+	}
+
+	rows = rows[:0]
+	for _, r := range reports {
+		for _, m := range r.Models {
+			estimated := "unpriced"
+			if m.Cost != nil {
+				estimated = money(*m.Cost)
+			}
+			rows = append(rows, []string{
+				string(r.ProviderID), m.Model,
+				strconv.FormatInt(m.Tokens.Input, 10), strconv.FormatInt(m.Tokens.CacheRead, 10),
+				strconv.FormatInt(m.Tokens.CacheWrite, 10), strconv.FormatInt(m.Tokens.Output, 10),
+				estimated,
+			})
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+	return formatter.Format(w, format.Data{
+		Headers: []string{"Provider", "Model", "Input", "Cache Read", "Cache Write", "Output", "Estimated"},
+		Rows:    rows,
+	})
+}
+
+func money(amount float64) string {
+	return locale.Decimal(amount, 2)
+}
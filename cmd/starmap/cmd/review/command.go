@@ -0,0 +1,162 @@
+// Package review provides the model review workflow commands.
+package review
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// Result describes a model's review after a decision.
+type Result struct {
+	Model    string                `json:"model" yaml:"model"`
+	Provider string                `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Review   *catalogs.ModelReview `json:"review" yaml:"review"`
+}
+
+// Pending describes a provider model awaiting review.
+type Pending struct {
+	Provider string `json:"provider" yaml:"provider"`
+	Model    string `json:"model" yaml:"model"`
+	Name     string `json:"name" yaml:"name"`
+	Since    string `json:"since,omitempty" yaml:"since,omitempty"`
+}
+
+// NewCommand creates the review command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "review",
+		GroupID: "catalog",
+		Short:   "Approve or reject models held for review",
+		Long: `Approve or reject models held in the pending-review state.
+
+When the review workflow is enabled (starmap update --review-new-models, or
+review_new_models: true in ~/.starmap/config.yaml), models a sync discovers
+for the first time enter pending-review. They stay in the catalog but are not
+listed or served by default until approved.
+
+Every decision is appended to the model's review audit log with the reviewer
+and an optional note. Review state lives in the local catalog layer, so later
+syncs keep it.`,
+	}
+
+	cmd.AddCommand(newListCommand(app))
+	cmd.AddCommand(newDecisionCommand(app, catalogs.ModelReviewApproved, "approve", "Approve a model for serving",
+		`  starmap review approve gpt-5
+  starmap review approve gpt-5 --provider openai --note "passed eval suite"`))
+	cmd.AddCommand(newDecisionCommand(app, catalogs.ModelReviewRejected, "reject", "Reject a model",
+		`  starmap review reject experimental-model --note "license not cleared"`))
+
+	return cmd
+}
+
+func newListCommand(app application.Application) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List models pending review",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cat, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			var pending []Pending
+			for _, provider := range cat.Providers().List() {
+				for _, model := range provider.Models {
+					if model.ReviewState() != catalogs.ModelReviewPending {
+						continue
+					}
+					item := Pending{Provider: string(provider.ID), Model: model.ID, Name: model.Name}
+					if n := len(model.Review.Log); n > 0 {
+						item.Since = model.Review.Log[n-1].At.String()
+					}
+					pending = append(pending, item)
+				}
+			}
+			slices.SortFunc(pending, func(a, b Pending) int {
+				return strings.Compare(a.Provider+"/"+a.Model, b.Provider+"/"+b.Model)
+			})
+			return printPending(cmd.OutOrStdout(), app.OutputFormat(), pending)
+		},
+	}
+}
+
+func newDecisionCommand(app application.Application, decision catalogs.ModelReviewState, use, short, example string) *cobra.Command {
+	var provider, reviewer, note string
+
+	cmd := &cobra.Command{
+		Use:     use + " <model-id>",
+		Short:   short,
+		Example: example,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sm, err := app.Starmap()
+			if err != nil {
+				return err
+			}
+			if reviewer == "" {
+				reviewer = currentUser()
+			}
+			review, err := sm.ReviewModel(cmd.Context(), args[0], catalogs.ProviderID(provider), decision, reviewer, note)
+			if err != nil {
+				return err
+			}
+			return printResult(cmd.OutOrStdout(), app.OutputFormat(), Result{Model: args[0], Provider: provider, Review: review})
+		},
+	}
+
+	cmd.Flags().StringVarP(&provider, "provider", "p", "",
+		"Only review this provider's offering of the model (default: every offering)")
+	cmd.Flags().StringVar(&reviewer, "reviewer", "",
+		"Reviewer recorded in the audit log (default: current user)")
+	cmd.Flags().StringVar(&note, "note", "",
+		"Note recorded with the decision")
+
+	return cmd
+}
+
+// currentUser returns the login name of the user running the command.
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+func printPending(w io.Writer, outputFormat string, pending []Pending) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, pending)
+	}
+	if len(pending) == 0 {
+		_, err := fmt.Fprintf(w, "%s No models pending review\n", emoji.Success)
+		return err
+	}
+	rows := make([][]string, 0, len(pending))
+	for _, item := range pending {
+		rows = append(rows, []string{item.Provider, item.Model, item.Name, item.Since})
+	}
+	return format.NewFormatter(detected).Format(w, format.Data{
+		Headers: []string{"Provider", "Model", "Name", "Pending Since"},
+		Rows:    rows,
+	})
+}
+
+func printResult(w io.Writer, outputFormat string, result Result) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, result)
+	}
+	_, err := fmt.Fprintf(w, "%s %s is now %s\n", emoji.Success, result.Model, result.Review.State)
+	return err
+}
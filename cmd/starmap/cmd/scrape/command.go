@@ -0,0 +1,143 @@
+// Package scrape provides the commands for documentation scraping extractors.
+package scrape
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/table"
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/internal/providers/docscrape"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+)
+
+// NewCommand creates the scrape command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "scrape",
+		GroupID: "catalog",
+		Short:   "Check documentation scraping extractors",
+		Long: `Check the extractors that read pricing and limits from provider
+documentation pages during 'starmap update --scrape-docs'.`,
+	}
+
+	cmd.AddCommand(newVerifyCommand(app))
+
+	return cmd
+}
+
+func newVerifyCommand(app application.Application) *cobra.Command {
+	var extractorsDir string
+
+	cmd := &cobra.Command{
+		Use:   "verify [provider...]",
+		Short: "Check extractors against the live documentation pages",
+		Long: `Fetch each documentation page named by an extractor config and check that
+the extractor still reads it. A page is broken when it cannot be fetched, when
+the extractor finds no records on it, or when a configured field is empty in
+every record — the usual sign that the provider changed the page's layout and
+the extractor now silently returns nothing.
+
+Pages are fetched fresh, bypassing the scrape cache, and robots.txt is
+honored. The command fails when any page is broken.`,
+		Example: `  starmap scrape verify                 # Every provider with an extractor
+  starmap scrape verify anthropic       # One provider
+  starmap scrape verify -o json         # Page reports as JSON`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configs, err := docscrape.LoadConfigs(paths.ExpandHome(extractorsDir))
+			if err != nil {
+				return err
+			}
+			scraper := docscrape.New(docscrape.NewFetcher(), configs)
+
+			providerIDs := scraper.Providers()
+			if len(args) > 0 {
+				providerIDs = make([]catalogs.ProviderID, 0, len(args))
+				for _, arg := range args {
+					providerIDs = append(providerIDs, catalogs.ProviderID(arg))
+				}
+			}
+			if len(providerIDs) == 0 {
+				_, err := fmt.Fprintf(cmd.OutOrStdout(), "%s No documentation extractor configs found\n", emoji.Info)
+				return err
+			}
+
+			var reports []docscrape.PageReport
+			for _, providerID := range providerIDs {
+				providerReports, err := scraper.Verify(cmd.Context(), providerID)
+				if err != nil {
+					return err
+				}
+				reports = append(reports, providerReports...)
+			}
+			if err := printReports(cmd.OutOrStdout(), app.OutputFormat(), reports); err != nil {
+				return err
+			}
+
+			broken := 0
+			for _, report := range reports {
+				if report.Broken() {
+					broken++
+				}
+			}
+			if broken > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d of %d documentation pages are broken", broken, len(reports))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&extractorsDir, "extractors-dir", constants.DefaultDocsExtractorsPath,
+		"Directory of extractor configs that replace the shipped ones per provider")
+
+	return cmd
+}
+
+func printReports(w io.Writer, outputFormat string, reports []docscrape.PageReport) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, reports)
+	}
+
+	rows := make([][]string, 0, len(reports))
+	for _, report := range reports {
+		rows = append(rows, []string{
+			string(report.Provider),
+			report.URL,
+			strconv.Itoa(report.Records),
+			status(report),
+		})
+	}
+	return format.NewFormatter(detected).Format(w, format.Data{
+		Headers:         []string{"Provider", "Page", "Records", "Status"},
+		Rows:            rows,
+		ColumnAlignment: []table.Align{table.AlignLeft, table.AlignLeft, table.AlignRight, table.AlignLeft},
+	})
+}
+
+// status describes why a page is broken, or reports it as ok.
+func status(report docscrape.PageReport) string {
+	switch {
+	case report.Error != "":
+		return emoji.Error + " " + report.Error
+	case report.Records == 0:
+		return emoji.Error + " no records found"
+	case len(report.Missing) > 0:
+		fields := make([]string, 0, len(report.Missing))
+		for _, field := range report.Missing {
+			fields = append(fields, string(field))
+		}
+		return emoji.Error + " empty: " + strings.Join(fields, ", ")
+	default:
+		return emoji.Success + " ok"
+	}
+}
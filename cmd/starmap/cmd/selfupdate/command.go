@@ -0,0 +1,153 @@
+// Package selfupdate provides the self-update command.
+package selfupdate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/attestation"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/selfupdate"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Status describes the installed and latest available versions.
+type Status struct {
+	Channel         string `json:"channel" yaml:"channel"`
+	Current         string `json:"current" yaml:"current"`
+	Latest          string `json:"latest" yaml:"latest"`
+	UpdateAvailable bool   `json:"update_available" yaml:"update_available"`
+	Updated         bool   `json:"updated" yaml:"updated"`
+	Executable      string `json:"executable,omitempty" yaml:"executable,omitempty"`
+}
+
+type options struct {
+	channel       string
+	check         bool
+	skipSignature bool
+	apiURL        string
+}
+
+// NewCommand creates the self-update command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:     "self-update",
+		GroupID: "setup",
+		Short:   "Update the starmap binary to the latest release",
+		Long: `Download the latest starmap release for this platform and replace the
+running binary.
+
+The archive is verified against the release checksums.txt and the GitHub build
+provenance signed by the release workflow (requires the gh CLI) before the
+binary is atomically replaced. Binaries installed by Homebrew or Scoop must be
+upgraded through their package manager.
+
+Use --check in CI images to report whether a newer release exists without
+installing it; the command exits non-zero when an update is available.`,
+		Example: `  starmap self-update
+  starmap self-update --channel nightly
+  starmap self-update --check -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return run(cmd.Context(), cmd.OutOrStdout(), app, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.channel, "channel", string(selfupdate.ChannelStable), "Release channel (stable, nightly)")
+	cmd.Flags().BoolVar(&opts.check, "check", false, "Report whether an update is available without installing it")
+	cmd.Flags().BoolVar(&opts.skipSignature, "skip-signature-verification", false,
+		"Skip release provenance verification (checksums are still verified)")
+	cmd.Flags().StringVar(&opts.apiURL, "api-url", selfupdate.DefaultAPIURL, "GitHub API URL for release discovery")
+	_ = cmd.Flags().MarkHidden("api-url")
+
+	return cmd
+}
+
+func run(ctx context.Context, w io.Writer, app application.Application, opts options) error {
+	channel, err := selfupdate.ParseChannel(opts.channel)
+	if err != nil {
+		return err
+	}
+	verify := attestation.GitHub(attestation.ReleaseWorkflow)
+	if opts.skipSignature {
+		verify = nil
+	}
+	client := selfupdate.NewClient(opts.apiURL, nil, verify)
+	release, err := client.Latest(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	status := Status{Channel: string(channel), Current: app.Version(), Latest: release.Version.String()}
+	current, ok := selfupdate.ParseVersion(app.Version())
+	// Development builds have no release version and are always outdated.
+	status.UpdateAvailable = !ok || release.Version.Compare(current) > 0
+
+	if opts.check {
+		if err := printStatus(w, app.OutputFormat(), status); err != nil {
+			return err
+		}
+		if status.UpdateAvailable {
+			return &errors.ValidationError{Field: "version", Value: status.Current, Message: "update available: " + status.Latest}
+		}
+		return nil
+	}
+	if !status.UpdateAvailable {
+		return printStatus(w, app.OutputFormat(), status)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return errors.WrapIO("locate", "starmap executable", err)
+	}
+	if manager, upgrade, managed := selfupdate.ManagedBy(executable); managed {
+		return &errors.ConfigError{
+			Component: "self-update",
+			Message:   fmt.Sprintf("%s is managed by %s; run '%s' instead", executable, manager, upgrade),
+		}
+	}
+	if opts.skipSignature {
+		fmt.Fprintf(os.Stderr, "%s Skipping release signature verification\n", emoji.Warning)
+	}
+	name, archive, err := client.Download(ctx, release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	binary, err := selfupdate.ExtractBinary(name, archive, runtime.GOOS)
+	if err != nil {
+		return err
+	}
+	if err := selfupdate.Replace(executable, binary); err != nil {
+		return err
+	}
+	status.Updated = true
+	status.Executable = executable
+	return printStatus(w, app.OutputFormat(), status)
+}
+
+func printStatus(w io.Writer, outputFormat string, status Status) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, status)
+	}
+	var err error
+	switch {
+	case status.Updated:
+		_, err = fmt.Fprintf(w, "%s Updated starmap %s → %s (%s)\n", emoji.Success, status.Current, status.Latest, status.Executable)
+	case status.UpdateAvailable:
+		_, err = fmt.Fprintf(w, "%s starmap %s is available on the %s channel (installed: %s)\n",
+			emoji.Warning, status.Latest, status.Channel, status.Current)
+	default:
+		_, err = fmt.Fprintf(w, "%s starmap %s is up to date on the %s channel\n", emoji.Success, status.Current, status.Channel)
+	}
+	return err
+}
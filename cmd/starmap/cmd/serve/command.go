@@ -4,6 +4,7 @@ package serve
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"strconv"
@@ -11,10 +12,14 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 
 	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/view"
 	"github.com/agentstation/starmap/internal/cli/emoji"
 	"github.com/agentstation/starmap/internal/server"
+	"github.com/agentstation/starmap/internal/server/events"
+	"github.com/agentstation/starmap/pkg/errors"
 )
 
 // NewCommand creates the serve command using app context.
@@ -33,11 +38,16 @@ Features:
   - In-memory caching with configurable TTL
   - Rate limiting (requests per minute per IP)
   - API key authentication (optional)
-  - CORS support for web applications
+  - Read-only mode for public deployments (--read-only)
+  - CORS support for web applications, with configurable origins, methods, and headers
+  - Browser security headers and request body size limits
   - Request logging and panic recovery
   - Graceful shutdown with connection draining
   - Health checks and metrics endpoints
   - OpenAPI 3.1 documentation (/api/v1/openapi.json)
+  - gRPC API (starmap.v1.CatalogService) on a second port (--grpc-port)
+  - Offline serving from a mirror bundle (--from-mirror)
+  - Hot reload of a catalog directory being edited (--watch)
 
 The API provides programmatic access to the starmap catalog with
 comprehensive filtering, search, and real-time notification capabilities.`,
@@ -50,11 +60,32 @@ comprehensive filtering, search, and real-time notification capabilities.`,
   # Enable CORS for specific origins
   starmap serve --cors-origins "https://example.com,https://app.example.com"
 
-  # Enable rate limiting
-  starmap serve --rate-limit 60
+  # Enable rate limiting per IP, and per API key across all IPs
+  starmap serve --rate-limit 60 --token-rate-limit 600
 
   # Full configuration
-  starmap serve --port 8080 --cors --auth --rate-limit 100`,
+  starmap serve --port 8080 --cors --auth --rate-limit 100
+
+  # Public deployment: reject every write endpoint and sync trigger
+  starmap serve --read-only
+
+  # Also serve the gRPC API for non-Go clients
+  starmap serve --grpc-port 9090
+
+  # Serve a bundle written by starmap mirror inside an air-gapped network
+  starmap serve --from-mirror ./mirror
+
+  # Serve the embedded catalog sources and reload them as they are edited
+  starmap serve --watch
+
+  # Reload from a catalog export instead
+  starmap serve --watch=./my-catalog
+
+  # Post catalog changes to Slack with a custom message template
+  starmap serve --notify-slack https://hooks.slack.com/services/T/B/X --notify-template ./slack.tmpl
+
+  # Alert Slack at 80% and 100% of a $500 monthly OpenAI budget
+  starmap serve --budget openai=500 --notify-slack https://hooks.slack.com/services/T/B/X`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runServer(cmd, args, app)
 		},
@@ -63,18 +94,30 @@ comprehensive filtering, search, and real-time notification capabilities.`,
 	// Server configuration flags
 	cmd.Flags().Int("port", 8080, "Server port")
 	cmd.Flags().String("host", "localhost", "Bind address")
+	cmd.Flags().Int("grpc-port", 0, "Serve the gRPC catalog API on this port (0 to disable)")
 
 	// CORS flags
 	cmd.Flags().Bool("cors", false, "Enable CORS for all origins")
 	cmd.Flags().StringSlice("cors-origins", []string{}, "Allowed CORS origins (comma-separated)")
+	cmd.Flags().StringSlice("cors-methods", []string{}, "Allowed CORS request methods (comma-separated; default GET, POST, PUT, DELETE, OPTIONS)")
+	cmd.Flags().StringSlice("cors-headers", []string{}, "Allowed CORS request headers (comma-separated; default Content-Type, Authorization, X-API-Key)")
+
+	// Security flags
+	cmd.Flags().Bool("security-headers", true, "Send browser security headers (nosniff, frame denial, no referrer, CSP)")
+	cmd.Flags().Duration("hsts-max-age", 0, "Send Strict-Transport-Security with this max-age; only for servers reached over HTTPS (0 to omit)")
+	cmd.Flags().Int64("max-body-size", 1<<20, "Largest accepted request body in bytes (0 for no limit)")
 
 	// Authentication flags
 	cmd.Flags().Bool("auth", false, "Enable API key authentication")
 	cmd.Flags().String("auth-header", "X-API-Key", "Authentication header name")
+	cmd.Flags().Bool("read-only", false, "Reject write endpoints and sync triggers so the catalog cannot be changed remotely")
 
 	// Performance flags
 	cmd.Flags().Int("rate-limit", 100, "Requests per minute per IP (0 to disable)")
+	cmd.Flags().Int("token-rate-limit", 0, "Requests per minute per API key, on top of the per-IP limit (0 to disable)")
 	cmd.Flags().Int("cache-ttl", 300, "Cache TTL in seconds")
+	cmd.Flags().Bool("compress", true, "Compress responses with gzip or zstd when the client accepts it")
+	cmd.Flags().Int("compress-min-size", 1024, "Smallest response body in bytes worth compressing")
 
 	// Timeout flags
 	cmd.Flags().Duration("read-timeout", 10*time.Second, "HTTP read timeout")
@@ -82,9 +125,32 @@ comprehensive filtering, search, and real-time notification capabilities.`,
 	cmd.Flags().Duration("idle-timeout", 120*time.Second, "HTTP idle timeout")
 
 	// Features flags
-	cmd.Flags().Bool("metrics", true, "Enable metrics endpoint")
+	cmd.Flags().Bool("metrics", true, "Serve Prometheus metrics at /metrics")
+	cmd.Flags().Bool("quota", false, "Serve provider spend and usage at /quota (reads OPENAI_ADMIN_KEY, ANTHROPIC_ADMIN_KEY)")
+	cmd.Flags().Bool("test-events", false, "Serve POST /admin/test-event to publish synthetic price changes and model removals")
 	cmd.Flags().String("prefix", "/api/v1", "API path prefix")
 
+	// Offline flags
+	cmd.Flags().String("from-mirror", "", "Serve offline from a mirror bundle or directory written by starmap mirror")
+
+	// Watch flags
+	cmd.Flags().String("watch", "", "Serve the catalog files in this directory and reload them when they change")
+	cmd.Flags().Lookup("watch").NoOptDefVal = "internal/embedded/catalog"
+
+	// Notification flags
+	cmd.Flags().StringArray("notify-webhook", nil, "Post catalog events as JSON to this URL (repeatable)")
+	cmd.Flags().StringArray("notify-slack", nil, "Post catalog events to this Slack incoming webhook (repeatable)")
+	cmd.Flags().String("notify-template", "", "Go template file rendering the notification payload for every webhook")
+	cmd.Flags().StringSlice("notify-events", []string{string(events.CatalogPublished)}, "Event types to notify (comma-separated)")
+
+	// Budget flags
+	cmd.Flags().StringArray("budget", nil, "Monthly spend budget as provider=amount or provider:KEY_ENV=amount (repeatable)")
+	cmd.Flags().String("budget-thresholds", "80,100", "Budget percentages that send a budget.threshold notification")
+	cmd.Flags().Duration("budget-interval", 15*time.Minute, "How often to check spend against budgets")
+
+	// View flags
+	cmd.Flags().String("views-file", "", "Views file defining named sub-catalogs (default: ~/.starmap/views.yaml)")
+
 	return cmd
 }
 
@@ -93,9 +159,38 @@ func runServer(cmd *cobra.Command, _ []string, app application.Application) erro
 	// Parse flags into configuration
 	cfg := parseConfig(cmd)
 	logger := app.Logger()
+	webhooks, err := parseWebhooks(cmd)
+	if err != nil {
+		return err
+	}
+	cfg.Webhooks = webhooks
+	if cfg.Budgets, err = parseBudgets(cmd); err != nil {
+		return err
+	}
+	cfg.BudgetInterval = mustGetDuration(cmd, "budget-interval")
+	if cfg.Views, err = view.LoadDefinitions(mustGetString(cmd, "views-file")); err != nil {
+		return err
+	}
 
 	logger.Debug().Msg("Parsed server configuration")
 
+	watchDir := mustGetString(cmd, "watch")
+	if location := mustGetString(cmd, "from-mirror"); location != "" {
+		if watchDir != "" {
+			return &errors.ConfigError{Component: "serve", Message: "--watch cannot be combined with --from-mirror"}
+		}
+		mirrored, err := newMirrorApp(cmd.Context(), app, location)
+		if err != nil {
+			return err
+		}
+		app = mirrored
+		state, _ := app.CatalogState()
+		logger.Info().
+			Str("mirror", location).
+			Str("generation_id", state.GenerationID).
+			Msg("Serving offline from catalog mirror")
+	}
+
 	logger.Info().
 		Int("port", cfg.Port).
 		Str("host", cfg.Host).
@@ -103,8 +198,20 @@ func runServer(cmd *cobra.Command, _ []string, app application.Application) erro
 		Bool("cors", cfg.CORSEnabled).
 		Bool("auth", cfg.AuthEnabled).
 		Int("rate_limit", cfg.RateLimit).
+		Int("token_rate_limit", cfg.TokenRateLimit).
 		Dur("cache_ttl", cfg.CacheTTL).
 		Msg("Starting API server")
+	if cfg.TestEvents {
+		logger.Warn().Msg("Test events enabled: POST /admin/test-event publishes synthetic catalog changes to every subscriber")
+	}
+
+	var watcher *catalogWatcher
+	if watchDir != "" {
+		if watcher, err = newWatchedCatalog(cmd.Context(), app, watchDir); err != nil {
+			return err
+		}
+		logger.Info().Str("dir", watchDir).Msg("Serving catalog files with hot reload")
+	}
 
 	// Create server
 	logger.Debug().Msg("Creating server instance")
@@ -119,6 +226,12 @@ func runServer(cmd *cobra.Command, _ []string, app application.Application) erro
 	srv.Start()
 	logger.Debug().Msg("Background services started")
 
+	// Reloads publish through the client, whose hooks the server now
+	// forwards to WebSocket, SSE, and webhook subscribers.
+	if watcher != nil {
+		go watcher.run(cmd.Context())
+	}
+
 	// Log that server is starting (after background services initialize)
 	logger.Info().
 		Str("addr", fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)).
@@ -141,10 +254,20 @@ func runServer(cmd *cobra.Command, _ []string, app application.Application) erro
 		IdleTimeout:  cfg.IdleTimeout,
 	}
 
+	var grpcServer *grpc.Server
+	var grpcListener net.Listener
+	if cfg.GRPCPort > 0 {
+		addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.GRPCPort))
+		if grpcListener, err = net.Listen("tcp", addr); err != nil {
+			return fmt.Errorf("gRPC listen on %s: %w", addr, err)
+		}
+		grpcServer = srv.GRPCServer()
+	}
+
 	// Start HTTP server with graceful shutdown
 	// Pass cmd.Context() which has signal handling from main.go
 	logger.Debug().Msg("Starting HTTP server listener with graceful shutdown handling")
-	return startWithGracefulShutdown(cmd.Context(), httpServer, srv, logger)
+	return startWithGracefulShutdown(cmd.Context(), httpServer, grpcServer, grpcListener, srv, logger)
 }
 
 // parseConfig parses command flags into server configuration.
@@ -152,16 +275,28 @@ func parseConfig(cmd *cobra.Command) server.Config {
 	// Get flags with error checking - these should never fail since flags are defined in this package
 	port := mustGetInt(cmd, "port")
 	host := mustGetString(cmd, "host")
+	grpcPort := mustGetInt(cmd, "grpc-port")
 	corsEnabled := mustGetBool(cmd, "cors")
 	corsOrigins := mustGetStringSlice(cmd, "cors-origins")
+	corsMethods := mustGetStringSlice(cmd, "cors-methods")
+	corsHeaders := mustGetStringSlice(cmd, "cors-headers")
+	securityHeaders := mustGetBool(cmd, "security-headers")
+	hstsMaxAge := mustGetDuration(cmd, "hsts-max-age")
+	maxBodySize := mustGetInt64(cmd, "max-body-size")
 	authEnabled := mustGetBool(cmd, "auth")
 	authHeader := mustGetString(cmd, "auth-header")
+	readOnly := mustGetBool(cmd, "read-only")
 	rateLimit := mustGetInt(cmd, "rate-limit")
+	tokenRateLimit := mustGetInt(cmd, "token-rate-limit")
 	cacheTTL := mustGetInt(cmd, "cache-ttl")
+	compressionEnabled := mustGetBool(cmd, "compress")
+	compressionMinSize := mustGetInt(cmd, "compress-min-size")
 	readTimeout := mustGetDuration(cmd, "read-timeout")
 	writeTimeout := mustGetDuration(cmd, "write-timeout")
 	idleTimeout := mustGetDuration(cmd, "idle-timeout")
 	metricsEnabled := mustGetBool(cmd, "metrics")
+	quotaEnabled := mustGetBool(cmd, "quota")
+	testEvents := mustGetBool(cmd, "test-events")
 	pathPrefix := mustGetString(cmd, "prefix")
 
 	// Override with environment variables
@@ -175,19 +310,31 @@ func parseConfig(cmd *cobra.Command) server.Config {
 	}
 
 	return server.Config{
-		Host:           host,
-		Port:           port,
-		PathPrefix:     pathPrefix,
-		CORSEnabled:    corsEnabled,
-		CORSOrigins:    corsOrigins,
-		AuthEnabled:    authEnabled,
-		AuthHeader:     authHeader,
-		RateLimit:      rateLimit,
-		CacheTTL:       time.Duration(cacheTTL) * time.Second,
-		ReadTimeout:    readTimeout,
-		WriteTimeout:   writeTimeout,
-		IdleTimeout:    idleTimeout,
-		MetricsEnabled: metricsEnabled,
+		Host:               host,
+		Port:               port,
+		GRPCPort:           grpcPort,
+		PathPrefix:         pathPrefix,
+		CORSEnabled:        corsEnabled,
+		CORSOrigins:        corsOrigins,
+		CORSMethods:        corsMethods,
+		CORSHeaders:        corsHeaders,
+		SecurityHeaders:    securityHeaders,
+		HSTSMaxAge:         hstsMaxAge,
+		MaxBodySize:        maxBodySize,
+		AuthEnabled:        authEnabled,
+		AuthHeader:         authHeader,
+		ReadOnly:           readOnly,
+		RateLimit:          rateLimit,
+		TokenRateLimit:     tokenRateLimit,
+		CacheTTL:           time.Duration(cacheTTL) * time.Second,
+		CompressionEnabled: compressionEnabled,
+		CompressionMinSize: compressionMinSize,
+		ReadTimeout:        readTimeout,
+		WriteTimeout:       writeTimeout,
+		IdleTimeout:        idleTimeout,
+		MetricsEnabled:     metricsEnabled,
+		QuotaEnabled:       quotaEnabled,
+		TestEvents:         testEvents,
 	}
 }
 
@@ -205,9 +352,24 @@ func parsePort(portStr string) (int, error) {
 
 // startWithGracefulShutdown starts the HTTP server with graceful shutdown.
 // The context is used to detect shutdown signals - when cancelled, server will shutdown gracefully.
-func startWithGracefulShutdown(ctx context.Context, httpServer *http.Server, srv *server.Server, logger *zerolog.Logger) error {
+func startWithGracefulShutdown(ctx context.Context, httpServer *http.Server, grpcServer *grpc.Server, grpcListener net.Listener, srv *server.Server, logger *zerolog.Logger) error {
 	// Server errors channel
-	serverErr := make(chan error, 1)
+	serverErr := make(chan error, 2)
+
+	if grpcServer != nil {
+		go func() {
+			logger.Info().
+				Str("addr", grpcListener.Addr().String()).
+				Str("service", "gRPC").
+				Msg("gRPC server listening")
+
+			fmt.Printf("🚀 gRPC server listening on %s\n", grpcListener.Addr())
+
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				serverErr <- fmt.Errorf("gRPC server failed: %w", err)
+			}
+		}()
+	}
 
 	// Start server in goroutine
 	go func() {
@@ -248,12 +410,31 @@ func startWithGracefulShutdown(ctx context.Context, httpServer *http.Server, srv
 			logger.Warn().Err(err).Msg("Background services shutdown had issues")
 		}
 
+		// Shutdown gRPC server after background services end its watch streams
+		if grpcServer != nil {
+			stopGRPC(shutdownCtx, grpcServer)
+		}
+
 		logger.Info().Msg("Server stopped gracefully")
 		fmt.Printf("%s API server stopped gracefully\n", emoji.Success)
 		return nil
 	}
 }
 
+// stopGRPC stops the gRPC server gracefully, or forcibly once ctx is done.
+func stopGRPC(ctx context.Context, grpcServer *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+}
+
 // mustGetInt retrieves an integer flag value or panics if the flag doesn't exist.
 // This should only be used for flags defined in this package.
 func mustGetInt(cmd *cobra.Command, name string) int {
@@ -264,6 +445,16 @@ func mustGetInt(cmd *cobra.Command, name string) int {
 	return val
 }
 
+// mustGetInt64 retrieves an int64 flag value or panics if the flag doesn't exist.
+// This should only be used for flags defined in this package.
+func mustGetInt64(cmd *cobra.Command, name string) int64 {
+	val, err := cmd.Flags().GetInt64(name)
+	if err != nil {
+		panic(fmt.Sprintf("programming error: failed to get flag %q: %v", name, err))
+	}
+	return val
+}
+
 // mustGetString retrieves a string flag value or panics if the flag doesn't exist.
 // This should only be used for flags defined in this package.
 func mustGetString(cmd *cobra.Command, name string) string {
@@ -303,3 +494,13 @@ func mustGetDuration(cmd *cobra.Command, name string) time.Duration {
 	}
 	return val
 }
+
+// mustGetStringArray retrieves a string array flag value or panics if the flag doesn't exist.
+// This should only be used for flags defined in this package.
+func mustGetStringArray(cmd *cobra.Command, name string) []string {
+	val, err := cmd.Flags().GetStringArray(name)
+	if err != nil {
+		panic(fmt.Sprintf("programming error: failed to get flag %q: %v", name, err))
+	}
+	return val
+}
@@ -0,0 +1,88 @@
+package serve
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/pkg/catalogmirror"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogscheduler"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// mirrorApp serves the catalog from a verified mirror bundle. Its client is
+// offline, so sync triggers fail instead of reaching the network.
+type mirrorApp struct {
+	application.Application
+	client     *starmap.Client
+	operations *catalogscheduler.Operations
+}
+
+// Ensure mirrorApp implements application.Application at compile time.
+var _ application.Application = (*mirrorApp)(nil)
+
+// newMirrorApp opens the mirror at location, which is either a bundle file or
+// a directory written by starmap mirror, and wraps app around its catalog.
+func newMirrorApp(ctx context.Context, app application.Application, location string) (*mirrorApp, error) {
+	if info, err := os.Stat(location); err == nil && info.IsDir() {
+		location = filepath.Join(location, catalogmirror.Filename)
+	}
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil, errors.WrapIO("read", location, err)
+	}
+	mirror, err := catalogmirror.Open(data)
+	if err != nil {
+		return nil, errors.WrapResource("open", "catalog mirror", location, err)
+	}
+	store := catalogstore.NewMemory()
+	if err := store.Commit(ctx, mirror.Generation, ""); err != nil {
+		return nil, errors.WrapResource("load", "catalog mirror generation", mirror.Index.GenerationID, err)
+	}
+	client, err := starmap.New(starmap.WithCatalogStore(store), starmap.WithOffline())
+	if err != nil {
+		return nil, errors.WrapResource("create", "starmap", "from mirror", err)
+	}
+	operations, err := catalogscheduler.NewOperations()
+	if err != nil {
+		return nil, err
+	}
+	return &mirrorApp{Application: app, client: client, operations: operations}, nil
+}
+
+// Starmap returns the offline mirror client. Custom client options are
+// rejected because they could reintroduce network sources.
+func (a *mirrorApp) Starmap(opts ...starmap.Option) (*starmap.Client, error) {
+	if len(opts) > 0 {
+		return nil, &errors.ConfigError{Component: "catalog mirror", Message: "custom client options are not supported when serving from a mirror"}
+	}
+	return a.client, nil
+}
+
+// Catalog returns the mirrored catalog.
+func (a *mirrorApp) Catalog() (*catalogs.Catalog, error) {
+	return a.client.Catalog(), nil
+}
+
+// CatalogState returns the mirrored catalog and its generation identity.
+func (a *mirrorApp) CatalogState() (starmap.CatalogState, error) {
+	return a.client.CurrentCatalogState(), nil
+}
+
+// Readiness reports the mirrored catalog's availability.
+func (a *mirrorApp) Readiness() (starmap.CatalogReadiness, error) {
+	return a.client.Readiness(), nil
+}
+
+// OperationalState composes the mirrored generation identity.
+func (a *mirrorApp) OperationalState(ctx context.Context) (catalogscheduler.OperationalState, error) {
+	state := a.client.CurrentCatalogState()
+	return a.operations.State(ctx, catalogscheduler.CatalogIdentity{
+		GenerationID: state.GenerationID,
+		Sequence:     state.Sequence,
+	})
+}
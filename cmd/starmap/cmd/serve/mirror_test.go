@@ -0,0 +1,59 @@
+package serve
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/agentstation/starmap/cmd/starmap/cmd/mirror"
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/bootstrap"
+	"github.com/agentstation/starmap/pkg/catalogartifact"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func TestMirrorAppServesMirroredGenerationOffline(t *testing.T) {
+	generation, err := bootstrap.Generation()
+	if err != nil {
+		t.Fatalf("bootstrap.Generation: %v", err)
+	}
+	artifact, err := catalogartifact.Build(generation)
+	if err != nil {
+		t.Fatalf("catalogartifact.Build: %v", err)
+	}
+	dir := t.TempDir()
+	if _, err := mirror.Write(dir, artifact); err != nil {
+		t.Fatalf("mirror.Write: %v", err)
+	}
+
+	app, err := newMirrorApp(context.Background(), &application.Mock{}, dir)
+	if err != nil {
+		t.Fatalf("newMirrorApp: %v", err)
+	}
+	state, err := app.CatalogState()
+	if err != nil {
+		t.Fatalf("CatalogState: %v", err)
+	}
+	if state.GenerationID != generation.Manifest.GenerationID {
+		t.Fatalf("generation = %q, want %q", state.GenerationID, generation.Manifest.GenerationID)
+	}
+	catalog, err := app.Catalog()
+	if err != nil || len(catalog.Providers().List()) == 0 {
+		t.Fatalf("Catalog = %v providers, %v; want mirrored providers", catalog, err)
+	}
+
+	client, err := app.Starmap()
+	if err != nil {
+		t.Fatalf("Starmap: %v", err)
+	}
+	var configErr *pkgerrors.ConfigError
+	if _, err := client.Sync(context.Background()); !stderrors.As(err, &configErr) {
+		t.Fatalf("Sync error = %T: %v, want *errors.ConfigError", err, err)
+	}
+}
+
+func TestMirrorAppRejectsMissingBundle(t *testing.T) {
+	if _, err := newMirrorApp(context.Background(), &application.Mock{}, t.TempDir()); err == nil {
+		t.Fatal("newMirrorApp accepted a directory without a mirror bundle")
+	}
+}
@@ -0,0 +1,79 @@
+package serve
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/providers/quota"
+	"github.com/agentstation/starmap/internal/server/events"
+	"github.com/agentstation/starmap/internal/server/events/adapters"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// parseWebhooks builds notification endpoints from the notify flags. A
+// --notify-template file replaces the built-in payload of every endpoint.
+func parseWebhooks(cmd *cobra.Command) ([]adapters.WebhookConfig, error) {
+	var template string
+	if path := mustGetString(cmd, "notify-template"); path != "" {
+		data, err := os.ReadFile(path) //nolint:gosec // operator-supplied template path.
+		if err != nil {
+			return nil, errors.WrapIO("read", path, err)
+		}
+		if _, err := adapters.ParseNotificationTemplate(string(data)); err != nil {
+			return nil, err
+		}
+		template = string(data)
+	}
+
+	var selected []events.EventType
+	for _, name := range mustGetStringSlice(cmd, "notify-events") {
+		selected = append(selected, events.EventType(name))
+	}
+	// Budgets exist to alert, so their events are delivered unless the
+	// operator chose the event types.
+	if len(mustGetStringArray(cmd, "budget")) > 0 && !cmd.Flags().Changed("notify-events") {
+		selected = append(selected, events.BudgetThreshold)
+	}
+
+	var webhooks []adapters.WebhookConfig
+	for _, target := range []struct {
+		flag   string
+		format adapters.WebhookFormat
+	}{
+		{"notify-webhook", adapters.WebhookFormatJSON},
+		{"notify-slack", adapters.WebhookFormatSlack},
+	} {
+		urls, err := cmd.Flags().GetStringArray(target.flag)
+		if err != nil {
+			panic(fmt.Sprintf("programming error: failed to get flag %q: %v", target.flag, err))
+		}
+		for _, url := range urls {
+			webhooks = append(webhooks, adapters.WebhookConfig{URL: url, Format: target.format, Template: template, Events: selected})
+		}
+	}
+	return webhooks, nil
+}
+
+// parseBudgets parses the --budget flags, applying --budget-thresholds.
+func parseBudgets(cmd *cobra.Command) ([]quota.Budget, error) {
+	values := mustGetStringArray(cmd, "budget")
+	if len(values) == 0 {
+		return nil, nil
+	}
+	thresholds, err := quota.ParseThresholds(mustGetString(cmd, "budget-thresholds"))
+	if err != nil {
+		return nil, err
+	}
+	budgets := make([]quota.Budget, 0, len(values))
+	for _, value := range values {
+		budget, err := quota.ParseBudget(value)
+		if err != nil {
+			return nil, err
+		}
+		budget.Thresholds = thresholds
+		budgets = append(budgets, budget)
+	}
+	return budgets, nil
+}
@@ -0,0 +1,144 @@
+package serve
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// watchDebounce is how long the catalog directory must be quiet before a
+// reload, so an editor's burst of writes or a starmap fmt run reloads once.
+const watchDebounce = 250 * time.Millisecond
+
+// catalogWatcher reloads the served catalog when YAML files under a catalog
+// directory change. fsnotify does not watch recursively, so every directory
+// in the tree is watched, including ones created later.
+type catalogWatcher struct {
+	dir      string
+	watcher  *fsnotify.Watcher
+	reload   func(context.Context) error
+	logger   *zerolog.Logger
+	debounce time.Duration
+}
+
+// newCatalogWatcher watches the directory tree at dir. reload is called
+// after each burst of changes.
+func newCatalogWatcher(dir string, reload func(context.Context) error, logger *zerolog.Logger) (*catalogWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.WrapIO("watch", dir, err)
+	}
+	w := &catalogWatcher{
+		dir:      dir,
+		watcher:  watcher,
+		reload:   reload,
+		logger:   logger,
+		debounce: watchDebounce,
+	}
+	if err := w.addTree(dir); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// addTree watches root and every directory below it.
+func (w *catalogWatcher) addTree(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return errors.WrapIO("walk", path, err)
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := w.watcher.Add(path); err != nil {
+			return errors.WrapIO("watch", path, err)
+		}
+		return nil
+	})
+}
+
+// run reloads the catalog after changes until ctx is done. A failed reload
+// is logged and the current catalog keeps serving until the next change.
+func (w *catalogWatcher) run(ctx context.Context) {
+	defer func() { _ = w.watcher.Close() }()
+
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			changed := catalogChange(event)
+			if event.Has(fsnotify.Create) {
+				// A directory moved into the tree may already hold model files.
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					changed = true
+					if err := w.addTree(event.Name); err != nil {
+						w.logger.Warn().Err(err).Str("dir", event.Name).Msg("Cannot watch new catalog directory")
+					}
+				}
+			}
+			if changed {
+				timer.Reset(w.debounce)
+			}
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Warn().Err(err).Str("dir", w.dir).Msg("Catalog watcher error")
+
+		case <-timer.C:
+			if err := w.reload(ctx); err != nil {
+				w.logger.Warn().Err(err).Str("dir", w.dir).Msg("Catalog reload failed; still serving the previous catalog")
+				continue
+			}
+			w.logger.Info().Str("dir", w.dir).Msg("Catalog reloaded from disk")
+		}
+	}
+}
+
+// catalogChange reports whether event can change the loaded catalog: a
+// write to a YAML file, or a removal or rename, which may take a whole
+// directory of model files with it.
+func catalogChange(event fsnotify.Event) bool {
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		return true
+	}
+	if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+		return false
+	}
+	ext := filepath.Ext(event.Name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// newWatchedCatalog publishes the catalog files in dir and returns a watcher
+// that republishes them on change. Startup fails when the files do not load,
+// rather than serving a catalog other than the one being edited.
+func newWatchedCatalog(ctx context.Context, app application.Application, dir string) (*catalogWatcher, error) {
+	sm, err := app.Starmap()
+	if err != nil {
+		return nil, err
+	}
+	if err := sm.ReloadFromPath(ctx, dir); err != nil {
+		return nil, err
+	}
+	return newCatalogWatcher(dir, func(ctx context.Context) error {
+		return sm.ReloadFromPath(ctx, dir)
+	}, app.Logger())
+}
@@ -0,0 +1,74 @@
+package serve
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestCatalogWatcherReloadsOnYAMLChanges(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "providers", "openai"), 0o750); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	reloads := make(chan struct{}, 16)
+	logger := zerolog.Nop()
+	watcher, err := newCatalogWatcher(dir, func(context.Context) error {
+		reloads <- struct{}{}
+		return nil
+	}, &logger)
+	if err != nil {
+		t.Fatalf("newCatalogWatcher: %v", err)
+	}
+	watcher.debounce = 20 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.run(ctx)
+
+	write := func(path string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, path), []byte("id: x\n"), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	expectReload := func(what string) {
+		t.Helper()
+		select {
+		case <-reloads:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no reload after %s", what)
+		}
+	}
+	expectNoReload := func(what string) {
+		t.Helper()
+		select {
+		case <-reloads:
+			t.Fatalf("unexpected reload after %s", what)
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+
+	// A burst of writes reloads once.
+	write("providers.yaml")
+	write("authors.yaml")
+	expectReload("writing YAML files")
+	expectNoReload("a single burst")
+
+	write("notes.txt")
+	expectNoReload("writing a non-YAML file")
+
+	// Directories created after startup are watched too.
+	models := filepath.Join(dir, "providers", "openai", "models")
+	if err := os.Mkdir(models, 0o750); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	expectReload("creating a directory")
+	write(filepath.Join("providers", "openai", "models", "gpt-a.yaml"))
+	expectReload("writing a model file in a new directory")
+}
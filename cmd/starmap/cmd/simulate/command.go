@@ -0,0 +1,116 @@
+// Package simulate provides the simulate command for projecting workload
+// cost across models.
+package simulate
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+)
+
+// NewCommand creates the simulate command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	var workloadPath string
+	var opts Options
+
+	cmd := &cobra.Command{
+		Use:     "simulate [model-id...] --workload <file>",
+		GroupID: "catalog",
+		Short:   "Project monthly cost of a workload across models",
+		Long: `Project the monthly cost of a workload on every candidate model and
+provider, cheapest first.
+
+The workload file declares the monthly request volume and a weighted mix of
+request shapes:
+
+  name: support-bot
+  monthly_requests: 2000000
+  mix:
+    - name: chat
+      weight: 0.8
+      input_tokens: 1200
+      output_tokens: 300
+      cache_hit_rate: 0.6      # share of input tokens read from the prompt cache
+    - name: screenshot
+      weight: 0.2
+      input_tokens: 400
+      output_tokens: 500
+      images: 1
+      image_width: 1280
+      image_height: 720
+  candidates:                  # optional; default: every offering
+    - openai/gpt-4o-mini
+    - anthropic/claude-haiku-4-5
+
+Costs use the catalog's token prices; cached input is billed at the cache
+read price when one is recorded. Offerings without token prices, or without
+an image token formula when the mix sends images, are listed as skipped.`,
+		Example: `  starmap simulate --workload workload.yaml
+  starmap simulate --workload workload.yaml -p openai -p anthropic --limit 10
+  starmap simulate gpt-4o-mini claude-haiku-4-5 --workload workload.yaml -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workload, err := LoadWorkload(workloadPath)
+			if err != nil {
+				return err
+			}
+			cat, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			opts.Models = args
+			result, err := Run(cat, workload, opts)
+			if err != nil {
+				return err
+			}
+			return printResult(cmd.OutOrStdout(), app.OutputFormat(), result)
+		},
+	}
+
+	cmd.Flags().StringVar(&workloadPath, "workload", "",
+		"Workload file declaring the request mix and volume")
+	cmd.Flags().StringSliceVarP(&opts.Providers, "provider", "p", nil,
+		"Only simulate models from these providers")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0,
+		"Show only the N cheapest offerings")
+	_ = cmd.MarkFlagRequired("workload")
+
+	return cmd
+}
+
+func printResult(w io.Writer, outputFormat string, result *Result) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, result)
+	}
+	if len(result.Projections) == 0 {
+		_, err := fmt.Fprintf(w, "%s No priced offerings match the workload (%d skipped)\n", emoji.Info, len(result.Skipped))
+		return err
+	}
+
+	rows := make([][]string, 0, len(result.Projections))
+	for _, p := range result.Projections {
+		rows = append(rows, []string{
+			p.Provider, p.Model, p.Name,
+			strconv.FormatFloat(p.CostPerRequest, 'f', 6, 64),
+			strconv.FormatFloat(p.MonthlyCost, 'f', 2, 64),
+		})
+	}
+	formatter := format.NewFormatter(detected)
+	if err := formatter.Format(w, format.Data{
+		Headers: []string{"Provider", "Model", "Name", "Per Request (USD)", "Monthly (USD)"},
+		Rows:    rows,
+	}); err != nil {
+		return err
+	}
+	if len(result.Skipped) > 0 {
+		_, err := fmt.Fprintf(w, "\n%s %d offerings skipped (unpriced or no image pricing); use -o json for reasons\n", emoji.Info, len(result.Skipped))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package simulate
+
+import (
+	"cmp"
+	stderrors "errors"
+	"math"
+	"slices"
+
+	"github.com/agentstation/starmap/internal/catalog/query"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Projection is the projected cost of a workload on one provider offering.
+type Projection struct {
+	Provider       string  `json:"provider" yaml:"provider"`
+	Model          string  `json:"model" yaml:"model"`
+	Name           string  `json:"name" yaml:"name"`
+	CostPerRequest float64 `json:"cost_per_request" yaml:"cost_per_request"` // Weighted across the request mix
+	MonthlyCost    float64 `json:"monthly_cost" yaml:"monthly_cost"`
+}
+
+// Skipped is a candidate offering that cannot be priced for the workload.
+type Skipped struct {
+	Provider string `json:"provider" yaml:"provider"`
+	Model    string `json:"model" yaml:"model"`
+	Reason   string `json:"reason" yaml:"reason"`
+}
+
+// Result is a workload simulation, cheapest offering first.
+type Result struct {
+	Workload        string       `json:"workload,omitempty" yaml:"workload,omitempty"`
+	MonthlyRequests int64        `json:"monthly_requests" yaml:"monthly_requests"`
+	Projections     []Projection `json:"projections" yaml:"projections"`
+	Skipped         []Skipped    `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+}
+
+// Options narrows the offerings a workload is simulated on, in addition to
+// the workload's own candidates.
+type Options struct {
+	Providers []string
+	Models    []string
+	Limit     int
+}
+
+// Run projects the monthly cost of workload on every matching offering.
+// Offerings without token prices, or without an image token formula when
+// the mix sends images, are reported as skipped rather than failing the run.
+func Run(catalog catalogs.Reader, workload *Workload, opts Options) (*Result, error) {
+	if err := workload.Validate(); err != nil {
+		return nil, err
+	}
+	candidates, _ := workload.candidates()
+
+	providerIDs := slices.Clone(opts.Providers)
+	if len(providerIDs) == 0 {
+		for _, candidate := range candidates {
+			providerIDs = append(providerIDs, string(candidate.Provider))
+		}
+	}
+	if len(providerIDs) == 0 {
+		for _, provider := range catalog.Providers().List() {
+			providerIDs = append(providerIDs, string(provider.ID))
+		}
+	}
+	slices.Sort(providerIDs)
+	providerIDs = slices.Compact(providerIDs)
+
+	result := &Result{Workload: workload.Name, MonthlyRequests: workload.MonthlyRequests, Projections: []Projection{}}
+	for _, providerID := range providerIDs {
+		provider, ok := catalog.Providers().Get(catalogs.ProviderID(providerID))
+		if !ok {
+			return nil, &errors.NotFoundError{Resource: "provider", ID: providerID}
+		}
+		models, err := query.CatalogModels(catalog, providerID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range models {
+			model := &models[i]
+			if !selected(candidates, opts.Models, provider.ID, model.ID) {
+				continue
+			}
+			perRequest, err := costPerRequest(workload, provider, model)
+			if err != nil {
+				result.Skipped = append(result.Skipped, Skipped{Provider: providerID, Model: model.ID, Reason: reason(err)})
+				continue
+			}
+			result.Projections = append(result.Projections, Projection{
+				Provider:       providerID,
+				Model:          model.ID,
+				Name:           model.Name,
+				CostPerRequest: perRequest,
+				MonthlyCost:    perRequest * float64(workload.MonthlyRequests),
+			})
+		}
+	}
+
+	slices.SortStableFunc(result.Projections, func(a, b Projection) int {
+		return cmp.Or(
+			cmp.Compare(a.MonthlyCost, b.MonthlyCost),
+			cmp.Compare(a.Provider, b.Provider),
+			cmp.Compare(a.Model, b.Model),
+		)
+	})
+	if opts.Limit > 0 && len(result.Projections) > opts.Limit {
+		result.Projections = result.Projections[:opts.Limit]
+	}
+	return result, nil
+}
+
+func selected(candidates []Candidate, models []string, providerID catalogs.ProviderID, modelID string) bool {
+	if len(models) > 0 && !slices.Contains(models, modelID) {
+		return false
+	}
+	if len(candidates) == 0 {
+		return true
+	}
+	return slices.Contains(candidates, Candidate{Provider: providerID, Model: modelID})
+}
+
+// costPerRequest returns the cost of one request averaged over the mix by
+// weight.
+func costPerRequest(workload *Workload, provider *catalogs.Provider, model *catalogs.Model) (float64, error) {
+	total := workload.totalWeight()
+	var cost float64
+	for _, shape := range workload.Mix {
+		cached := int64(math.Round(float64(shape.InputTokens) * shape.CacheHitRate))
+		tokens, ok := model.Pricing.TokenCost(catalogs.TokenUsage{
+			Input:     shape.InputTokens - cached,
+			CacheRead: cached,
+			Output:    shape.OutputTokens,
+		})
+		if !ok {
+			return 0, &errors.ValidationError{Field: "pricing.tokens", Message: "is not recorded for this model"}
+		}
+		if shape.Images > 0 {
+			image, err := model.ImageInputCost(provider, shape.ImageWidth, shape.ImageHeight)
+			if err != nil {
+				return 0, err
+			}
+			tokens += image * float64(shape.Images)
+		}
+		cost += tokens * shape.Weight / total
+	}
+	return cost, nil
+}
+
+func reason(err error) string {
+	var validation *errors.ValidationError
+	if stderrors.As(err, &validation) {
+		return validation.Field + " " + validation.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,140 @@
+package simulate
+
+import (
+	"bytes"
+	stderrors "errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func simulateTestCatalog(t *testing.T) *catalogs.Catalog {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	for _, provider := range []catalogs.Provider{
+		{ID: "alpha", Name: "Alpha", Models: map[string]*catalogs.Model{
+			"alpha-big": {
+				ID: "alpha-big", Name: "Alpha Big",
+				Pricing: &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{
+					Input:     &catalogs.ModelTokenCost{Per1M: 10},
+					Output:    &catalogs.ModelTokenCost{Per1M: 30},
+					CacheRead: &catalogs.ModelTokenCost{Per1M: 1},
+				}},
+				Vision: &catalogs.ModelVision{TokenCost: &catalogs.ImageTokenFormula{Method: catalogs.ImageTokenMethodFixed, BaseTokens: 1000}},
+			},
+			"alpha-unpriced": {ID: "alpha-unpriced", Name: "Alpha Unpriced"},
+		}},
+		{ID: "beta", Name: "Beta", Models: map[string]*catalogs.Model{
+			"beta-small": {
+				ID: "beta-small", Name: "Beta Small",
+				Pricing: &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{
+					Input:  &catalogs.ModelTokenCost{Per1M: 1},
+					Output: &catalogs.ModelTokenCost{Per1M: 2},
+				}},
+			},
+		}},
+	} {
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider(%s): %v", provider.ID, err)
+		}
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return catalog
+}
+
+func TestRunSortsCheapestFirstAndSkipsUnpriced(t *testing.T) {
+	workload := &Workload{
+		MonthlyRequests: 1000,
+		Mix: []RequestShape{
+			{Name: "chat", Weight: 3, InputTokens: 1000, OutputTokens: 500, CacheHitRate: 0.5},
+			{Name: "summary", Weight: 1, InputTokens: 4000, OutputTokens: 100},
+		},
+	}
+	result, err := Run(simulateTestCatalog(t), workload, Options{})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Projections) != 2 || result.Projections[0].Model != "beta-small" || result.Projections[1].Model != "alpha-big" {
+		t.Fatalf("projections = %#v", result.Projections)
+	}
+	// alpha-big chat: 500 uncached × $10 + 500 cached × $1 + 500 out × $30 = $0.0205;
+	// summary: 4000 × $10 + 100 × $30 = $0.043. Weighted 3:1 = $0.026125.
+	if got := result.Projections[1].CostPerRequest; math.Abs(got-0.026125) > 1e-12 {
+		t.Fatalf("alpha-big per request = %v", got)
+	}
+	if got := result.Projections[1].MonthlyCost; math.Abs(got-26.125) > 1e-9 {
+		t.Fatalf("alpha-big monthly = %v", got)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Model != "alpha-unpriced" {
+		t.Fatalf("skipped = %#v", result.Skipped)
+	}
+}
+
+func TestRunPricesImagesAndHonorsCandidates(t *testing.T) {
+	workload := &Workload{
+		MonthlyRequests: 10,
+		Mix:             []RequestShape{{Weight: 1, InputTokens: 0, OutputTokens: 0, Images: 2, ImageWidth: 512, ImageHeight: 512}},
+		Candidates:      []string{"alpha/alpha-big", "beta/beta-small"},
+	}
+	result, err := Run(simulateTestCatalog(t), workload, Options{})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Projections) != 1 || math.Abs(result.Projections[0].CostPerRequest-0.02) > 1e-12 {
+		t.Fatalf("projections = %#v", result.Projections)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Model != "beta-small" || !strings.Contains(result.Skipped[0].Reason, "vision") {
+		t.Fatalf("skipped = %#v", result.Skipped)
+	}
+}
+
+func TestLoadWorkloadValidates(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"volume":     "mix: [{weight: 1, input_tokens: 10, output_tokens: 10}]\n",
+		"cache":      "monthly_requests: 5\nmix: [{weight: 1, input_tokens: 10, output_tokens: 10, cache_hit_rate: 2}]\n",
+		"images":     "monthly_requests: 5\nmix: [{weight: 1, input_tokens: 10, output_tokens: 10, images: 1}]\n",
+		"candidates": "monthly_requests: 5\nmix: [{weight: 1, input_tokens: 10, output_tokens: 10}]\ncandidates: [gpt-4o]\n",
+	} {
+		path := filepath.Join(dir, name+".yaml")
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadWorkload(path)
+		var validation *pkgerrors.ValidationError
+		if !stderrors.As(err, &validation) {
+			t.Fatalf("%s: err = %v, want validation error", name, err)
+		}
+	}
+
+	path := filepath.Join(dir, "unknown.yaml")
+	if err := os.WriteFile(path, []byte("monthly_requests: 5\nrequests: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadWorkload(path); err == nil {
+		t.Fatal("unknown field accepted")
+	}
+}
+
+func TestPrintResultTable(t *testing.T) {
+	var out bytes.Buffer
+	err := printResult(&out, "table", &Result{
+		MonthlyRequests: 1,
+		Projections:     []Projection{{Provider: "beta", Model: "beta-small", CostPerRequest: 0.0015, MonthlyCost: 1.5}},
+		Skipped:         []Skipped{{Provider: "alpha", Model: "alpha-unpriced"}},
+	})
+	if err != nil {
+		t.Fatalf("printResult: %v", err)
+	}
+	if !strings.Contains(out.String(), "beta-small") || !strings.Contains(out.String(), "1.50") || !strings.Contains(out.String(), "1 offerings skipped") {
+		t.Fatalf("output = %s", out.String())
+	}
+}
@@ -0,0 +1,113 @@
+package simulate
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Workload declares a monthly request volume and the mix of request shapes
+// that make it up.
+type Workload struct {
+	Name            string         `json:"name,omitempty" yaml:"name,omitempty"`
+	MonthlyRequests int64          `json:"monthly_requests" yaml:"monthly_requests"`
+	Mix             []RequestShape `json:"mix" yaml:"mix"`
+
+	// Candidates limits the simulation to these offerings, written as
+	// provider/model. Empty means every offering that can serve the mix.
+	Candidates []string `json:"candidates,omitempty" yaml:"candidates,omitempty"`
+}
+
+// RequestShape is one kind of request in a workload.
+type RequestShape struct {
+	Name         string  `json:"name,omitempty" yaml:"name,omitempty"`
+	Weight       float64 `json:"weight" yaml:"weight"` // Relative share of the monthly requests
+	InputTokens  int64   `json:"input_tokens" yaml:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens" yaml:"output_tokens"`
+	CacheHitRate float64 `json:"cache_hit_rate,omitempty" yaml:"cache_hit_rate,omitempty"` // Fraction of input tokens read from the prompt cache
+	Images       int     `json:"images,omitempty" yaml:"images,omitempty"`                 // Input images per request
+	ImageWidth   int     `json:"image_width,omitempty" yaml:"image_width,omitempty"`
+	ImageHeight  int     `json:"image_height,omitempty" yaml:"image_height,omitempty"`
+}
+
+// Candidate is one provider offering named by a workload.
+type Candidate struct {
+	Provider catalogs.ProviderID
+	Model    string
+}
+
+// LoadWorkload reads and validates a workload file.
+func LoadWorkload(path string) (*Workload, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // operator-supplied workload path.
+	if err != nil {
+		return nil, errors.WrapIO("read", path, err)
+	}
+	var workload Workload
+	if err := yaml.UnmarshalWithOptions(data, &workload, yaml.Strict()); err != nil {
+		return nil, errors.WrapParse("yaml", path, err)
+	}
+	if err := workload.Validate(); err != nil {
+		return nil, err
+	}
+	return &workload, nil
+}
+
+// Validate checks that the workload describes a positive volume and a
+// well-formed request mix.
+func (w *Workload) Validate() error {
+	if w.MonthlyRequests <= 0 {
+		return &errors.ValidationError{Field: "monthly_requests", Value: w.MonthlyRequests, Message: "must be positive"}
+	}
+	if len(w.Mix) == 0 {
+		return &errors.ValidationError{Field: "mix", Message: "must declare at least one request shape"}
+	}
+	for i, shape := range w.Mix {
+		field := "mix[" + shape.label(i) + "]"
+		switch {
+		case shape.Weight <= 0:
+			return &errors.ValidationError{Field: field + ".weight", Value: shape.Weight, Message: "must be positive"}
+		case shape.InputTokens < 0 || shape.OutputTokens < 0:
+			return &errors.ValidationError{Field: field, Message: "token counts must not be negative"}
+		case shape.CacheHitRate < 0 || shape.CacheHitRate > 1:
+			return &errors.ValidationError{Field: field + ".cache_hit_rate", Value: shape.CacheHitRate, Message: "must be between 0 and 1"}
+		case shape.Images < 0:
+			return &errors.ValidationError{Field: field + ".images", Value: shape.Images, Message: "must not be negative"}
+		case shape.Images > 0 && (shape.ImageWidth <= 0 || shape.ImageHeight <= 0):
+			return &errors.ValidationError{Field: field, Message: "image_width and image_height are required with images"}
+		}
+	}
+	_, err := w.candidates()
+	return err
+}
+
+func (w *Workload) candidates() ([]Candidate, error) {
+	candidates := make([]Candidate, 0, len(w.Candidates))
+	for _, value := range w.Candidates {
+		provider, model, ok := strings.Cut(value, "/")
+		if !ok || provider == "" || model == "" {
+			return nil, &errors.ValidationError{Field: "candidates", Value: value, Message: "must be provider/model"}
+		}
+		candidates = append(candidates, Candidate{Provider: catalogs.ProviderID(provider), Model: model})
+	}
+	return candidates, nil
+}
+
+func (w *Workload) totalWeight() float64 {
+	var total float64
+	for _, shape := range w.Mix {
+		total += shape.Weight
+	}
+	return total
+}
+
+func (s RequestShape) label(index int) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return strconv.Itoa(index)
+}
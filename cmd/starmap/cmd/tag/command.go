@@ -0,0 +1,98 @@
+// Package tag provides the model curation tag commands.
+package tag
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// Result describes a model's curation tags after a change.
+type Result struct {
+	Model    string   `json:"model" yaml:"model"`
+	Provider string   `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Tags     []string `json:"tags" yaml:"tags"`
+}
+
+// NewCommand creates the tag command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "tag",
+		GroupID: "catalog",
+		Short:   "Manage model curation tags",
+		Long: `Manage user-defined curation tags such as approved-for-prod, evaluation,
+or banned for internal model governance.
+
+Tags are stored in the local catalog layer, so provider syncs never overwrite
+them. Filter on them with starmap models list --curation, the API's curation
+query parameter, and every export format.
+
+Tags are lowercase and may contain letters, digits, '-', '_', '.', and ':'.`,
+	}
+
+	cmd.AddCommand(newChangeCommand(app, "add", "Add curation tags to a model",
+		`  starmap tag add claude-sonnet-4-5 approved-for-prod
+  starmap tag add gpt-4o evaluation --provider openai`))
+	cmd.AddCommand(newChangeCommand(app, "remove", "Remove curation tags from a model",
+		`  starmap tag remove claude-sonnet-4-5 evaluation
+  starmap tag remove gpt-4o banned --provider openai`))
+
+	return cmd
+}
+
+func newChangeCommand(app application.Application, action, short, example string) *cobra.Command {
+	var provider string
+
+	cmd := &cobra.Command{
+		Use:     action + " <model-id> <tag>...",
+		Short:   short,
+		Example: example,
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sm, err := app.Starmap()
+			if err != nil {
+				return err
+			}
+			var add, remove []string
+			if action == "add" {
+				add = args[1:]
+			} else {
+				remove = args[1:]
+			}
+			tags, err := sm.TagModel(cmd.Context(), args[0], catalogs.ProviderID(provider), add, remove)
+			if err != nil {
+				return err
+			}
+			return printResult(cmd.OutOrStdout(), app.OutputFormat(), Result{
+				Model:    args[0],
+				Provider: provider,
+				Tags:     append([]string{}, tags...),
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&provider, "provider", "p", "",
+		"Only tag this provider's offering of the model (default: every offering)")
+
+	return cmd
+}
+
+func printResult(w io.Writer, outputFormat string, result Result) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, result)
+	}
+	tags := strings.Join(result.Tags, ", ")
+	if tags == "" {
+		tags = "(none)"
+	}
+	_, err := fmt.Fprintf(w, "%s %s curation tags: %s\n", emoji.Success, result.Model, tags)
+	return err
+}
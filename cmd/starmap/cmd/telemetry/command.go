@@ -0,0 +1,101 @@
+// Package telemetry provides the telemetry opt-in command.
+package telemetry
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/telemetry"
+)
+
+// NewCommand creates the telemetry command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "telemetry",
+		GroupID: "setup",
+		Short:   "Opt in to or out of anonymous usage metrics",
+		Long: `Telemetry is off by default. When enabled, every command reports the
+command name, the names of the flags given, whether it succeeded, the error
+category if it failed, its duration, and the starmap version, OS, and
+architecture, together with a random install ID created on opt-in.
+
+Argument and flag values, catalog contents, file paths, error messages, and
+credentials are never reported. The payload schema is documented in
+docs/TELEMETRY.md.
+
+STARMAP_TELEMETRY=off or DO_NOT_TRACK=1 disables telemetry regardless of the
+opt-in, and STARMAP_TELEMETRY_URL overrides the endpoint.`,
+		Example: `  starmap telemetry on
+  starmap telemetry status -o json
+  starmap telemetry off`,
+	}
+
+	cmd.AddCommand(
+		newSetCommand(app, "on", "Enable anonymous usage metrics", telemetry.Enable),
+		newSetCommand(app, "off", "Disable usage metrics and delete the install ID", telemetry.Disable),
+		newStatusCommand(app),
+	)
+
+	return cmd
+}
+
+func newSetCommand(app application.Application, use, short string, set func(string) (telemetry.Settings, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path, err := telemetry.DefaultPath()
+			if err != nil {
+				return err
+			}
+			if _, err := set(path); err != nil {
+				return err
+			}
+			return printStatus(cmd.OutOrStdout(), app.OutputFormat(), path)
+		},
+	}
+}
+
+func newStatusCommand(app application.Application) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether usage metrics are reported",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path, err := telemetry.DefaultPath()
+			if err != nil {
+				return err
+			}
+			return printStatus(cmd.OutOrStdout(), app.OutputFormat(), path)
+		},
+	}
+}
+
+func printStatus(w io.Writer, outputFormat, path string) error {
+	status, err := telemetry.CurrentStatus(path)
+	if err != nil {
+		return err
+	}
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, status)
+	}
+
+	symbol := emoji.Info
+	if status.Enabled {
+		symbol = emoji.Success
+	}
+	fmt.Fprintf(w, "%s Telemetry %s\n", symbol, status.Reason)
+	if status.Enabled {
+		fmt.Fprintf(w, "   Install ID: %s\n", status.InstallID)
+		fmt.Fprintf(w, "   Endpoint:   %s\n", status.Endpoint)
+	}
+	_, err = fmt.Fprintf(w, "   Settings:   %s\n", status.Settings)
+	return err
+}
@@ -1,9 +1,13 @@
 package update
 
 import (
+	"os"
+
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 
 	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/cli/globals"
 )
 
 // NewCommand creates the update command using app context.
@@ -12,6 +16,7 @@ func NewCommand(app application.Application) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:     "update [provider]",
+		Aliases: []string{"sync"},
 		GroupID: "catalog",
 		Short:   "Synchronize catalog with the default or selected sources",
 		Args:    cobra.MaximumNArgs(1),
@@ -29,14 +34,36 @@ The command will:
 • Reconcile all sources using field-level authority
 • Save the updated catalog to disk
 
+With --dry the full fetch and reconcile pipeline runs and every
+model, provider, and author change is printed, colored by change type, but
+nothing is written.
+
+When sources disagree on a field and no field authority covers any of them,
+update asks on a terminal whether to keep ours (the current catalog), theirs
+(the incoming sources), or the authority strategy's pick; an uppercase answer
+applies to every remaining conflict. --resolve makes the same choice for
+every conflict without prompting, and without a terminal or with -y the
+strategy's pick is kept. The choice is recorded in the field's provenance.
+
 By default, materializes editable YAML at ~/.starmap/exports/catalog. The
-durable canonical generation database remains separate at ~/.starmap/catalog.`,
+durable canonical generation database remains separate at ~/.starmap/catalog.
+
+With --data-only, update skips every source and instead downloads the latest
+catalog generation published to a distribution channel. The download is
+checked against its SHA-256 descriptors, attestation statement, and schema
+compatibility, and its publisher signature is verified with the GitHub CLI
+before it is activated. This keeps model data fresh between binary releases
+without upgrading starmap itself.`,
 		Example: `  starmap update                            # Update entire catalog
   starmap update openai                     # Update specific provider
   starmap update --dry                      # Preview changes
+  starmap sync --dry                        # Same, via the sync alias
   starmap update -y                         # Auto-approve changes
+  starmap update --resolve theirs -y        # Take incoming values in conflicts
   starmap update --force                    # Force fresh update
-  starmap update openai --dry               # Preview OpenAI updates`,
+  starmap update openai --dry               # Preview OpenAI updates
+  starmap update --data-only                # Activate the latest signed catalog
+  starmap update --data-only --channel canary --dry`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			logger := app.Logger()
@@ -46,6 +73,12 @@ durable canonical generation database remains separate at ~/.starmap/catalog.`,
 			if len(args) == 1 {
 				flags.Provider = args[0]
 			}
+			globalFlags, err := globals.Parse(cmd)
+			if err != nil {
+				return err
+			}
+			flags.Color = !globalFlags.NoColor && isatty.IsTerminal(os.Stderr.Fd())
+			flags.Interactive = isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stderr.Fd())
 
 			return ExecuteUpdate(ctx, app, flags, logger)
 		},
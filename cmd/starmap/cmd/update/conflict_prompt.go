@@ -0,0 +1,157 @@
+package update
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/reconciler"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// maxConflictValueWidth truncates long values, such as nested structures, in
+// the conflict prompt.
+const maxConflictValueWidth = 72
+
+// conflictResolver returns the resolver for flags: the --resolve choice, an
+// interactive prompt when a terminal is attached and changes are not
+// auto-approved, or nil to keep the strategy's pick.
+func conflictResolver(flags *Flags) (reconciler.ConflictResolver, error) {
+	if flags.Resolve != "" {
+		choice, err := reconciler.ParseConflictChoice(flags.Resolve)
+		if err != nil {
+			return nil, err
+		}
+		return reconciler.NewChoiceResolver(choice), nil
+	}
+	if flags.Interactive && !flags.AutoApprove {
+		return newConflictPrompt(os.Stdin, os.Stderr), nil
+	}
+	return nil, nil
+}
+
+// conflictPrompt asks which value to keep for each conflict. Answers are
+// remembered, so the sync that applies a previewed update reuses them
+// instead of asking again.
+type conflictPrompt struct {
+	in      *bufio.Reader
+	out     io.Writer
+	all     reconciler.ConflictChoice // Set by an uppercase answer
+	answers map[string]conflictAnswer
+}
+
+type conflictAnswer struct {
+	source sources.ID
+	how    string
+}
+
+func newConflictPrompt(in io.Reader, out io.Writer) *conflictPrompt {
+	return &conflictPrompt{
+		in:      bufio.NewReader(in),
+		out:     out,
+		answers: map[string]conflictAnswer{},
+	}
+}
+
+// ResolveFieldConflict prompts for conflict.
+func (p *conflictPrompt) ResolveFieldConflict(conflict reconciler.Conflict) (sources.ID, string, error) {
+	key := conflictKey(conflict)
+	if answer, ok := p.answers[key]; ok {
+		if _, exists := conflict.Values[answer.source]; exists {
+			return answer.source, answer.how, nil
+		}
+	}
+	if p.all != "" {
+		return p.answer(key, conflict, p.all)
+	}
+
+	p.printConflict(conflict)
+	for {
+		fmt.Fprint(p.out, "Keep [o]urs, [t]heirs, or [a]uthority? Uppercase applies to all remaining, [q] cancels: ")
+		response, err := p.in.ReadString('\n')
+		response = strings.TrimSpace(response)
+		if (err != nil && response == "") || response == "q" || response == "Q" {
+			return "", "", errors.ErrCanceled
+		}
+		choice, all, ok := parseConflictAnswer(response)
+		if !ok {
+			fmt.Fprintf(p.out, "Unrecognized answer %q\n", response)
+			continue
+		}
+		if all {
+			p.all = choice
+		}
+		return p.answer(key, conflict, choice)
+	}
+}
+
+func (p *conflictPrompt) answer(key string, conflict reconciler.Conflict, choice reconciler.ConflictChoice) (sources.ID, string, error) {
+	answer := conflictAnswer{source: conflict.Source(choice), how: "interactive " + string(choice)}
+	p.answers[key] = answer
+	return answer.source, answer.how, nil
+}
+
+func (p *conflictPrompt) printConflict(conflict reconciler.Conflict) {
+	resource := conflict.ResourceID
+	if conflict.ProviderID != "" {
+		resource = string(conflict.ProviderID) + "/" + resource
+	}
+	fmt.Fprintf(p.out, "\n⚠️  Conflict: %s %s field %s\n", conflict.Resource, resource, conflict.Field)
+	for _, choice := range reconciler.ConflictChoices {
+		source := conflict.Source(choice)
+		fmt.Fprintf(p.out, "   %-10s %-18s %s\n", choice, source, conflictValue(conflict.Values[source]))
+	}
+	for _, source := range sortedSources(conflict.Values) {
+		if source == conflict.Ours || source == conflict.Theirs || source == conflict.Authority {
+			continue
+		}
+		fmt.Fprintf(p.out, "   %-10s %-18s %s\n", "also", source, conflictValue(conflict.Values[source]))
+	}
+}
+
+func parseConflictAnswer(response string) (choice reconciler.ConflictChoice, all, ok bool) {
+	switch response {
+	case "o", "ours":
+		return reconciler.ConflictChoiceOurs, false, true
+	case "t", "theirs":
+		return reconciler.ConflictChoiceTheirs, false, true
+	case "a", "authority":
+		return reconciler.ConflictChoiceAuthority, false, true
+	case "O":
+		return reconciler.ConflictChoiceOurs, true, true
+	case "T":
+		return reconciler.ConflictChoiceTheirs, true, true
+	case "A":
+		return reconciler.ConflictChoiceAuthority, true, true
+	default:
+		return "", false, false
+	}
+}
+
+func conflictKey(conflict reconciler.Conflict) string {
+	return strings.Join([]string{string(conflict.Resource), string(conflict.ProviderID), conflict.ResourceID, conflict.Field}, "/")
+}
+
+func conflictValue(value any) string {
+	text := fmt.Sprintf("%+v", value)
+	if _, ok := value.(string); ok {
+		text = fmt.Sprintf("%q", value)
+	}
+	if len(text) > maxConflictValueWidth {
+		text = text[:maxConflictValueWidth-3] + "..."
+	}
+	return text
+}
+
+func sortedSources(values map[sources.ID]any) []sources.ID {
+	ids := make([]sources.ID, 0, len(values))
+	for source := range values {
+		ids = append(ids, source)
+	}
+	slices.Sort(ids)
+	return ids
+}
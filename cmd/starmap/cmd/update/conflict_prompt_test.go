@@ -0,0 +1,78 @@
+package update
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/reconciler"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+func testConflict(modelID string) reconciler.Conflict {
+	return reconciler.Conflict{
+		Resource:   sources.ResourceTypeModel,
+		ProviderID: "openai",
+		ResourceID: modelID,
+		Field:      "Name",
+		Values: map[sources.ID]any{
+			sources.LocalCatalogID:  "Local",
+			sources.ProvidersID:     "Provider",
+			sources.ModelsDevHTTPID: "Models.dev",
+		},
+		Ours:      sources.LocalCatalogID,
+		Theirs:    sources.ProvidersID,
+		Authority: sources.ModelsDevHTTPID,
+	}
+}
+
+func TestConflictPromptAnswers(t *testing.T) {
+	var out strings.Builder
+	prompt := newConflictPrompt(strings.NewReader("x\no\nT\n"), &out)
+
+	source, how, err := prompt.ResolveFieldConflict(testConflict("model-1"))
+	if err != nil || source != sources.LocalCatalogID || how != "interactive ours" {
+		t.Fatalf("first conflict = %q, %q, %v; want ours", source, how, err)
+	}
+	if !strings.Contains(out.String(), "openai/model-1 field Name") || !strings.Contains(out.String(), `Unrecognized answer "x"`) {
+		t.Errorf("prompt output = %q", out.String())
+	}
+
+	// The apply sync replays earlier answers without reading input.
+	if source, _, err := prompt.ResolveFieldConflict(testConflict("model-1")); err != nil || source != sources.LocalCatalogID {
+		t.Fatalf("replayed conflict = %q, %v; want ours", source, err)
+	}
+
+	// An uppercase answer applies to every remaining conflict.
+	for _, modelID := range []string{"model-2", "model-3"} {
+		if source, _, err := prompt.ResolveFieldConflict(testConflict(modelID)); err != nil || source != sources.ProvidersID {
+			t.Fatalf("%s = %q, %v; want theirs", modelID, source, err)
+		}
+	}
+}
+
+func TestConflictPromptCancels(t *testing.T) {
+	for _, input := range []string{"q\n", ""} {
+		prompt := newConflictPrompt(strings.NewReader(input), &strings.Builder{})
+		if _, _, err := prompt.ResolveFieldConflict(testConflict("model-1")); !errors.Is(err, pkgerrors.ErrCanceled) {
+			t.Errorf("input %q: err = %v, want canceled", input, err)
+		}
+	}
+}
+
+func TestConflictResolverFromFlags(t *testing.T) {
+	if resolver, err := conflictResolver(&Flags{}); err != nil || resolver != nil {
+		t.Errorf("noninteractive default = %v, %v; want nil to keep the strategy's pick", resolver, err)
+	}
+	if _, err := conflictResolver(&Flags{Resolve: "mine"}); err == nil {
+		t.Error("--resolve mine returned nil error")
+	}
+	resolver, err := conflictResolver(&Flags{Resolve: "theirs", Interactive: true})
+	if err != nil {
+		t.Fatalf("--resolve theirs: %v", err)
+	}
+	if source, _, _ := resolver.ResolveFieldConflict(testConflict("model-1")); source != sources.ProvidersID {
+		t.Errorf("--resolve theirs selected %q, want providers", source)
+	}
+}
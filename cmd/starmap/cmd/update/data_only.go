@@ -0,0 +1,116 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/attestation"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/pkg/catalogdistribution"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+type generationFetcher interface {
+	FetchChannelArtifact(context.Context, catalogdistribution.Channel) (catalogdistribution.PublishedGeneration, error)
+}
+
+type generationActivator interface {
+	CurrentGenerationID() string
+	ActivateGeneration(context.Context, catalogstore.Generation) error
+}
+
+// validateDataOnlyFlags rejects flags that select sources, because a
+// data-only update never runs a sync.
+func validateDataOnlyFlags(flags *Flags) error {
+	for _, conflict := range []struct {
+		name string
+		set  bool
+	}{
+		{"provider", flags.Provider != ""},
+		{"source", flags.Source != ""},
+		{"remote", flags.Remote != ""},
+		{"benchmarks", flags.Benchmarks != ""},
+		{"input-dir", flags.InputDir != ""},
+		{"force", flags.Force},
+		{"sandbox", flags.Sandbox != ""},
+	} {
+		if conflict.set {
+			return &errors.ValidationError{Field: "data-only", Value: conflict.name, Message: "cannot be combined with --" + conflict.name}
+		}
+	}
+	return nil
+}
+
+// executeDataOnlyUpdate replaces the catalog with the latest signed
+// generation published to a distribution channel.
+func executeDataOnlyUpdate(ctx context.Context, app application.Application, flags *Flags, quiet bool) error {
+	if err := validateDataOnlyFlags(flags); err != nil {
+		return err
+	}
+	channel, err := catalogdistribution.ParseChannel(flags.Channel)
+	if err != nil {
+		return err
+	}
+	baseURL := flags.DistributionURL
+	if baseURL == "" {
+		baseURL = catalogdistribution.DefaultBaseURL
+	}
+	distribution, err := catalogdistribution.NewClient(baseURL, nil, catalogs.CurrentCatalogSchemaVersion)
+	if err != nil {
+		return err
+	}
+	sm, err := app.Starmap()
+	if err != nil {
+		return err
+	}
+	verify := attestation.GitHub(attestation.CatalogWorkflow)
+	if flags.SkipSignature {
+		verify = nil
+	}
+	return updateDataOnly(ctx, distribution, sm, channel, verify, flags.DryRun, quiet)
+}
+
+func updateDataOnly(ctx context.Context, distribution generationFetcher, sm generationActivator,
+	channel catalogdistribution.Channel, verify attestation.Verifier, dryRun, quiet bool) error {
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "\n🔄 Fetching latest %s catalog data...\n", channel)
+	}
+	published, err := distribution.FetchChannelArtifact(ctx, channel)
+	if err != nil {
+		return &errors.ProcessError{Operation: "fetch published catalog", Command: "update", Err: err}
+	}
+	generation, artifact := published.Generation, published.Artifact
+	id := generation.Manifest.GenerationID
+	if id == sm.CurrentGenerationID() {
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "%s Catalog data is up to date (generation %s)\n", emoji.Success, id)
+		}
+		return nil
+	}
+
+	if verify == nil {
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "%s Skipping publisher signature verification\n", emoji.Warning)
+		}
+	} else if err := verify(ctx, artifact.Filename, artifact.Data); err != nil {
+		return &errors.ProcessError{Operation: "verify catalog signature", Command: "update", Err: err}
+	}
+
+	if dryRun {
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "🔍 Dry run mode - would activate generation %s\n", id)
+		}
+		return nil
+	}
+	if err := sm.ActivateGeneration(ctx, generation); err != nil {
+		return &errors.ProcessError{Operation: "activate published catalog", Command: "update", Err: err}
+	}
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "\n🎉 Catalog data updated to generation %s\n", id)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package update
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/agentstation/starmap/internal/bootstrap"
+	"github.com/agentstation/starmap/pkg/catalogartifact"
+	"github.com/agentstation/starmap/pkg/catalogdistribution"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+type fakeDistribution struct {
+	published catalogdistribution.PublishedGeneration
+	channel   catalogdistribution.Channel
+}
+
+func (d *fakeDistribution) FetchChannelArtifact(_ context.Context, channel catalogdistribution.Channel) (catalogdistribution.PublishedGeneration, error) {
+	d.channel = channel
+	return d.published, nil
+}
+
+type fakeActivator struct {
+	current   string
+	activated []string
+}
+
+func (a *fakeActivator) CurrentGenerationID() string { return a.current }
+
+func (a *fakeActivator) ActivateGeneration(_ context.Context, generation catalogstore.Generation) error {
+	a.activated = append(a.activated, generation.Manifest.GenerationID)
+	return nil
+}
+
+func TestUpdateDataOnly(t *testing.T) {
+	generation, err := bootstrap.Generation()
+	if err != nil {
+		t.Fatalf("bootstrap.Generation: %v", err)
+	}
+	// The downloaded bytes differ from a local rebuild, so verifying them
+	// proves the signature is checked over what was fetched
+	published := catalogdistribution.PublishedGeneration{
+		Generation: generation,
+		Artifact:   catalogartifact.Artifact{Filename: catalogartifact.Filename, Data: []byte("downloaded archive")},
+	}
+	id := generation.Manifest.GenerationID
+	verifyErr := stderrors.New("signature mismatch")
+
+	tests := []struct {
+		name          string
+		current       string
+		dryRun        bool
+		verifyErr     error
+		wantVerified  bool
+		wantActivated bool
+		wantErr       bool
+	}{
+		{name: "activates verified generation", current: "older", wantVerified: true, wantActivated: true},
+		{name: "skips current generation", current: id},
+		{name: "dry run does not activate", current: "older", dryRun: true, wantVerified: true},
+		{name: "signature failure does not activate", current: "older", verifyErr: verifyErr, wantVerified: true, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distribution := &fakeDistribution{published: published}
+			activator := &fakeActivator{current: test.current}
+			verified := false
+			verify := func(_ context.Context, name string, archive []byte) error {
+				verified = true
+				if name != catalogartifact.Filename {
+					t.Errorf("verified name = %q, want %q", name, catalogartifact.Filename)
+				}
+				if string(archive) != string(published.Artifact.Data) {
+					t.Error("verifier did not receive the downloaded archive bytes")
+				}
+				return test.verifyErr
+			}
+
+			err := updateDataOnly(context.Background(), distribution, activator,
+				catalogdistribution.ChannelCanary, verify, test.dryRun, true)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("updateDataOnly error = %v, wantErr %v", err, test.wantErr)
+			}
+			if distribution.channel != catalogdistribution.ChannelCanary {
+				t.Fatalf("fetched channel = %q, want canary", distribution.channel)
+			}
+			if verified != test.wantVerified {
+				t.Fatalf("verified = %v, want %v", verified, test.wantVerified)
+			}
+			if activated := len(activator.activated) == 1 && activator.activated[0] == id; activated != test.wantActivated {
+				t.Fatalf("activated = %v, want %v", activator.activated, test.wantActivated)
+			}
+		})
+	}
+}
+
+func TestValidateDataOnlyFlagsRejectsSourceSelection(t *testing.T) {
+	for _, flags := range []Flags{
+		{Provider: "openai"},
+		{Source: "models.dev"},
+		{Remote: "https://starmap.example.com/api/v1"},
+		{InputDir: "./catalog"},
+		{Force: true},
+	} {
+		var validationErr *pkgerrors.ValidationError
+		if err := validateDataOnlyFlags(&flags); !stderrors.As(err, &validationErr) {
+			t.Fatalf("validateDataOnlyFlags(%+v) error = %v, want *errors.ValidationError", flags, err)
+		}
+	}
+	if err := validateDataOnlyFlags(&Flags{DryRun: true, AutoApprove: true}); err != nil {
+		t.Fatalf("validateDataOnlyFlags rejected compatible flags: %v", err)
+	}
+}
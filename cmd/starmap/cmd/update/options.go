@@ -6,6 +6,7 @@ import (
 
 	"github.com/agentstation/starmap/pkg/catalogs"
 	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/reconciler"
 	"github.com/agentstation/starmap/pkg/sources"
 	"github.com/agentstation/starmap/pkg/sync"
 )
@@ -65,6 +66,46 @@ func BuildUpdateOptions(provider, source, output string, dryRun, force, cleanup,
 	return opts, nil
 }
 
+// buildSyncOptions returns the sync options for flags, including the options
+// that BuildUpdateOptions does not take as parameters. A federated remote
+// replaces the source selection with the local catalog layered on the remote.
+// The same resolver is passed to the preview and apply syncs so conflicts
+// are settled once.
+func buildSyncOptions(flags *Flags, resolver reconciler.ConflictResolver, outputPath, sourcesDir string, dryRun bool) ([]sync.Option, error) {
+	opts, err := BuildUpdateOptions(flags.Provider, flags.Source, outputPath, dryRun, flags.Force, flags.Cleanup, flags.Reformat, sourcesDir, flags.ModelsDevGitCommit, flags.AutoInstallDeps, flags.SkipDepPrompts, flags.RequireAllSources)
+	if err != nil {
+		return nil, err
+	}
+	if flags.WatchPolicies {
+		opts = append(opts, sync.WithPolicyWatch(true))
+	}
+	if flags.ScrapeDocs {
+		opts = append(opts, sync.WithDocsScrape(true))
+	}
+	if flags.ReviewNewModels {
+		opts = append(opts, sync.WithReviewNewModels(true))
+	}
+	if flags.MatchModels {
+		opts = append(opts, sync.WithModelMatching(true))
+	}
+	if flags.Benchmarks != "" {
+		opts = append(opts, sync.WithBenchmarks(flags.Benchmarks))
+	}
+	if flags.Sandbox != "" {
+		opts = append(opts, sync.WithSandbox(flags.Sandbox))
+	}
+	if resolver != nil {
+		opts = append(opts, sync.WithConflictResolver(resolver))
+	}
+	if flags.Remote != "" {
+		opts = append(opts,
+			sync.WithRemoteCatalog(flags.Remote, flags.RemoteAPIKey),
+			sync.WithSources(sources.LocalCatalogID, sources.RemoteCatalogID),
+		)
+	}
+	return opts, nil
+}
+
 func sourceSelection(source string) ([]sources.ID, error) {
 	switch strings.ToLower(strings.TrimSpace(source)) {
 	case "", "all":
@@ -1,9 +1,15 @@
 package update
 
 import (
+	stderrors "errors"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/agentstation/starmap/cmd/starmap/cmd/diff"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
 	"github.com/agentstation/starmap/pkg/sync"
 )
 
@@ -44,3 +50,74 @@ func displayResultsSummary(result *sync.Result) {
 		}
 	}
 }
+
+// displayPartialFetch reports which providers finished before an interrupted
+// sync, so users know the next update resumes with the pending ones.
+func displayPartialFetch(err error) {
+	var partial *errors.PartialFetchError
+	if !stderrors.As(err, &partial) {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\n%s Update interrupted\n", emoji.Warning)
+	if len(partial.Completed) > 0 {
+		fmt.Fprintf(os.Stderr, "  Completed: %s\n", strings.Join(partial.Completed, ", "))
+	}
+	if len(partial.Pending) > 0 {
+		fmt.Fprintf(os.Stderr, "  Pending:   %s\n", strings.Join(partial.Pending, ", "))
+	}
+	if len(partial.Completed) > 0 {
+		fmt.Fprintf(os.Stderr, "  Completed providers are reused by the next update within %.0f minutes.\n", constants.PartialFetchMaxAge.Minutes())
+	}
+}
+
+// displayChangesetPreview lists every model, provider, and author change a
+// previewed sync would apply, colored by change type when color is set.
+func displayChangesetPreview(result *sync.Result, color bool) {
+	if result.Changeset == nil {
+		return
+	}
+	report := diff.NewReport("current catalog", "synced catalog", result.Changeset)
+	_ = diff.WriteText(os.Stderr, report, color)
+	fmt.Fprintf(os.Stderr, "\n")
+}
+
+// displayPolicyChanges lists provider policy documents whose content changed
+// since the previous sync.
+func displayPolicyChanges(result *sync.Result) {
+	if len(result.PolicyChanges) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "📜 Policy changes:\n")
+	for _, change := range result.PolicyChanges {
+		fmt.Fprintf(os.Stderr, "  • %s (%s)\n", change, change.URL)
+	}
+	fmt.Fprintf(os.Stderr, "\n")
+}
+
+// displaySandboxInstructions tells the operator where the sandboxed catalog
+// was written and how to review and promote it.
+func displaySandboxInstructions(result *sync.Result, flags *Flags, outputPath string) {
+	review := "starmap diff --to " + result.SandboxDir
+	if outputPath != "" {
+		review = "starmap diff --from " + outputPath + " --to " + result.SandboxDir
+	}
+	promote := "starmap update"
+	if flags.Provider != "" {
+		promote += " " + flags.Provider
+	}
+	fmt.Fprintf(os.Stderr, "📦 Sandbox catalog written to %s; the real catalog was not modified\n", result.SandboxDir)
+	fmt.Fprintf(os.Stderr, "  Review:  %s -o html sandbox-report.html\n", review)
+	fmt.Fprintf(os.Stderr, "  Promote: %s -y   (re-run without --sandbox to apply)\n\n", promote)
+}
+
+// displayPinnedChanges lists incoming changes that field pins blocked.
+func displayPinnedChanges(result *sync.Result) {
+	if len(result.PinnedChanges) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "📌 Pinned fields kept:\n")
+	for _, change := range result.PinnedChanges {
+		fmt.Fprintf(os.Stderr, "  • %s\n", change)
+	}
+	fmt.Fprintf(os.Stderr, "\n")
+}
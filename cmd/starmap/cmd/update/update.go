@@ -4,17 +4,20 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
-	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 
 	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/bugreport"
 	"github.com/agentstation/starmap/internal/cli/emoji"
 	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/catalogdistribution"
 	"github.com/agentstation/starmap/pkg/constants"
 	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/reconciler"
 	"github.com/agentstation/starmap/pkg/sync"
 )
 
@@ -26,6 +29,7 @@ type Flags struct {
 	Force              bool
 	AutoApprove        bool
 	OutputDir          string
+	Sandbox            string // Write the merged catalog here instead of applying it
 	InputDir           string
 	Cleanup            bool
 	Reformat           bool
@@ -34,6 +38,20 @@ type Flags struct {
 	AutoInstallDeps    bool
 	SkipDepPrompts     bool
 	RequireAllSources  bool
+	WatchPolicies      bool
+	ScrapeDocs         bool
+	ReviewNewModels    bool
+	MatchModels        bool
+	Benchmarks         string // Benchmark dataset URL or file to ingest scores from
+	Remote             string // Versioned API root of a Starmap server to federate
+	RemoteAPIKey       string
+	DataOnly           bool   // Activate the latest published generation instead of syncing
+	Channel            string // Distribution channel for DataOnly: stable, canary, or dev
+	DistributionURL    string
+	SkipSignature      bool
+	Color              bool   // Color the changeset preview by change type
+	Resolve            string // Settle unresolvable conflicts with ours, theirs, or authority
+	Interactive        bool   // A terminal is attached, so conflicts may be prompted for
 }
 
 type syncClient interface {
@@ -61,6 +79,8 @@ func addUpdateFlags(cmd *cobra.Command) *Flags {
 		"Auto-approve changes without confirmation")
 	cmd.Flags().StringVar(&flags.OutputDir, "output-dir", "",
 		"Save updated catalog to directory")
+	cmd.Flags().StringVar(&flags.Sandbox, "sandbox", "",
+		"Write the fully merged catalog to this directory without touching the real catalog")
 	cmd.Flags().StringVar(&flags.InputDir, "input-dir", "",
 		"Load catalog from directory instead of embedded")
 	cmd.Flags().BoolVar(&flags.Cleanup, "cleanup", false,
@@ -77,6 +97,26 @@ func addUpdateFlags(cmd *cobra.Command) *Flags {
 		"Skip dependency prompts and continue without optional dependencies")
 	cmd.Flags().BoolVar(&flags.RequireAllSources, "require-all-sources", false,
 		"Require all sources to succeed (fail if any dependencies are missing)")
+	cmd.Flags().BoolVar(&flags.WatchPolicies, "watch-policies", false,
+		"Report changes to provider privacy policy and terms of service pages")
+	cmd.Flags().BoolVar(&flags.ScrapeDocs, "scrape-docs", false,
+		"Fill pricing and limits missing from provider APIs from provider documentation pages")
+	cmd.Flags().BoolVar(&flags.ReviewNewModels, "review-new-models", false,
+		"Hold newly discovered models in pending-review until approved with starmap review")
+	cmd.Flags().BoolVar(&flags.MatchModels, "match-models", false,
+		"Link the same model served by several providers to one shared definition")
+	cmd.Flags().StringVar(&flags.Benchmarks, "benchmarks", "",
+		"Benchmark dataset URL or file to ingest MMLU, Arena Elo, and HumanEval scores from")
+	cmd.Flags().BoolVar(&flags.DataOnly, "data-only", false,
+		"Download the latest published, signed catalog instead of syncing sources")
+	cmd.Flags().StringVar(&flags.Channel, "channel", "stable",
+		"Distribution channel for --data-only: stable, canary, or dev")
+	cmd.Flags().StringVar(&flags.DistributionURL, "distribution-url", "",
+		"Catalog distribution origin for --data-only (default: "+catalogdistribution.DefaultBaseURL+")")
+	cmd.Flags().BoolVar(&flags.SkipSignature, "skip-signature-verification", false,
+		"With --data-only, accept checksum-verified data without checking the publisher signature")
+	cmd.Flags().StringVar(&flags.Resolve, "resolve", "",
+		"Settle source conflicts no field authority decides: ours, theirs, or authority (default: prompt on a terminal, otherwise authority)")
 
 	return flags
 }
@@ -86,6 +126,10 @@ func ExecuteUpdate(ctx context.Context, app application.Application, flags *Flag
 	// Determine quiet mode from logger level
 	quiet := logger.GetLevel() > zerolog.InfoLevel
 
+	if flags.DataOnly {
+		return executeDataOnlyUpdate(ctx, app, flags, quiet)
+	}
+
 	// Validate force update if needed
 	if flags.Force {
 		proceed, err := ValidateForceUpdate(quiet, flags.AutoApprove)
@@ -120,7 +164,7 @@ func resolveUpdateOutputPath(app any, explicit string) (string, error) {
 	if provider, ok := app.(catalogExportPathProvider); ok {
 		return provider.CatalogExportPath()
 	}
-	return expandPath(constants.DefaultCatalogExportPath), nil
+	return paths.ExpandHome(constants.DefaultCatalogExportPath), nil
 }
 
 // updateCatalog executes the update operation using app context.
@@ -138,8 +182,12 @@ func updateCatalogWithConfirmation(ctx context.Context, sm syncClient, flags *Fl
 		sourcesDir = os.Getenv("STARMAP_SOURCES_DIR")
 	}
 
-	preview := flags.DryRun || !flags.AutoApprove
-	opts, err := BuildUpdateOptions(flags.Provider, flags.Source, outputPath, preview, flags.Force, flags.Cleanup, flags.Reformat, sourcesDir, flags.ModelsDevGitCommit, flags.AutoInstallDeps, flags.SkipDepPrompts, flags.RequireAllSources)
+	resolver, err := conflictResolver(flags)
+	if err != nil {
+		return err
+	}
+	preview := flags.DryRun || flags.Sandbox != "" || !flags.AutoApprove
+	opts, err := buildSyncOptions(flags, resolver, outputPath, sourcesDir, preview)
 	if err != nil {
 		return err
 	}
@@ -151,6 +199,9 @@ func updateCatalogWithConfirmation(ctx context.Context, sm syncClient, flags *Fl
 	// Perform the update
 	result, err := sm.Sync(ctx, opts...)
 	if err != nil {
+		if !quiet {
+			displayPartialFetch(err)
+		}
 		return &errors.ProcessError{
 			Operation: "update catalog",
 			Command:   "update",
@@ -166,26 +217,50 @@ func updateCatalogWithConfirmation(ctx context.Context, sm syncClient, flags *Fl
 	}
 
 	// Handle results
-	return handleResultsWithConfirmation(ctx, sm, result, flags, outputPath, sourcesDir, quiet, confirm)
+	return handleResultsWithConfirmation(ctx, sm, result, flags, resolver, outputPath, sourcesDir, quiet, confirm)
 }
 
-func handleResultsWithConfirmation(ctx context.Context, sm syncClient, result *sync.Result, flags *Flags, outputPath string, sourcesDir string, quiet bool, confirm func() (bool, error)) error {
+func handleResultsWithConfirmation(ctx context.Context, sm syncClient, result *sync.Result, flags *Flags, resolver reconciler.ConflictResolver, outputPath string, sourcesDir string, quiet bool, confirm func() (bool, error)) error {
+	if !quiet {
+		displayPolicyChanges(result)
+		displayPinnedChanges(result)
+	}
+
+	// A sandboxed preview is complete once the merged catalog is written
+	if result.SandboxDir != "" {
+		if !quiet {
+			if result.HasChanges() {
+				displayResultsSummary(result)
+			}
+			displaySandboxInstructions(result, flags, outputPath)
+		}
+		return nil
+	}
+
 	if !result.HasChanges() {
 		if !quiet {
-			fmt.Fprintf(os.Stderr, emoji.Success+" All providers are up to date - no changes needed\n")
+			fmt.Fprintln(os.Stderr, emoji.Success+" All providers are up to date - no changes needed")
 		}
 		return nil
 	}
 
-	// Show results summary
+	// Show results summary, and every change when it has not been applied yet
 	if !quiet {
 		displayResultsSummary(result)
+		if result.DryRun {
+			displayChangesetPreview(result, flags.Color)
+		}
 	}
 
 	// Handle dry run
 	if flags.DryRun {
 		if !quiet {
 			fmt.Fprintf(os.Stderr, "🔍 Dry run mode - no changes will be made\n")
+			apply := "starmap update"
+			if flags.Provider != "" {
+				apply += " " + flags.Provider
+			}
+			fmt.Fprintf(os.Stderr, "  Apply: %s -y\n", apply)
 		}
 		return nil
 	}
@@ -210,7 +285,7 @@ func handleResultsWithConfirmation(ctx context.Context, sm syncClient, result *s
 	}
 
 	// Rebuild options without dry-run
-	opts, err := BuildUpdateOptions(flags.Provider, flags.Source, outputPath, false, flags.Force, flags.Cleanup, flags.Reformat, sourcesDir, flags.ModelsDevGitCommit, flags.AutoInstallDeps, flags.SkipDepPrompts, flags.RequireAllSources)
+	opts, err := buildSyncOptions(flags, resolver, outputPath, sourcesDir, false)
 	if err != nil {
 		return err
 	}
@@ -218,6 +293,9 @@ func handleResultsWithConfirmation(ctx context.Context, sm syncClient, result *s
 	// Apply changes
 	finalResult, err := sm.Sync(ctx, opts...)
 	if err != nil {
+		if !quiet {
+			displayPartialFetch(err)
+		}
 		return &errors.ProcessError{
 			Operation: "apply changes",
 			Command:   "update",
@@ -228,8 +306,12 @@ func handleResultsWithConfirmation(ctx context.Context, sm syncClient, result *s
 	return finalizeChanges(quiet, finalResult)
 }
 
-// finalizeChanges displays the completion message.
+// finalizeChanges records the applied update for bug reports and displays
+// the completion message.
 func finalizeChanges(isQuiet bool, result *sync.Result) error {
+	if err := recordUpdate(result); err != nil && !isQuiet {
+		fmt.Fprintf(os.Stderr, "%s Could not record the update summary: %v\n", emoji.Warning, err)
+	}
 	if !isQuiet {
 		fmt.Fprintf(os.Stderr, "\n🎉 Update completed successfully!\n")
 		fmt.Fprintf(os.Stderr, "📊 Total: %s\n", result.Summary())
@@ -237,19 +319,15 @@ func finalizeChanges(isQuiet bool, result *sync.Result) error {
 	return nil
 }
 
-func expandPath(path string) string {
-	if !strings.HasPrefix(path, "~") {
-		return path
+// recordUpdate writes the change counts of an update that activated a
+// durable generation, which starmap bugreport includes.
+func recordUpdate(result *sync.Result) error {
+	if result.GenerationID == "" {
+		return nil
 	}
-	homeDir, err := os.UserHomeDir()
+	path, err := bugreport.DefaultLastUpdatePath()
 	if err != nil {
-		return path
-	}
-	if path == "~" {
-		return homeDir
-	}
-	if strings.HasPrefix(path, "~/") {
-		return filepath.Join(homeDir, path[2:])
+		return err
 	}
-	return path
+	return bugreport.RecordUpdate(path, bugreport.NewLastUpdate(result, time.Now()))
 }
@@ -29,6 +29,7 @@ func (c *recordingSyncClient) Sync(_ context.Context, opts ...pkgsync.Option) (*
 	return &pkgsync.Result{
 		TotalChanges: 1,
 		DryRun:       options.DryRun,
+		SandboxDir:   options.SandboxPath,
 	}, nil
 }
 
@@ -59,6 +60,14 @@ func TestUpdateCatalogCommitBehavior(t *testing.T) {
 			},
 			wantDryRuns: []bool{false},
 		},
+		{
+			name: "sandbox never commits or confirms",
+			flags: Flags{
+				Sandbox:     "sandbox",
+				AutoApprove: true,
+			},
+			wantDryRuns: []bool{true},
+		},
 		{
 			name: "explicit dry run never commits or confirms",
 			flags: Flags{
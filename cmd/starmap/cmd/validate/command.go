@@ -8,21 +8,36 @@ import (
 
 // NewCommand creates the validate command using app context.
 func NewCommand(app application.Application) *cobra.Command {
+	var flags lintFlags
+
 	cmd := &cobra.Command{
 		Use:     "validate",
 		GroupID: "development",
 		Short:   "Validate catalog structure",
 		Long: `Validate catalog configuration and structure.
 
-This command validates various aspects of the catalog including:
+Without a subcommand, runs the lint rules over the catalog: missing pricing,
+zero context windows, dangling author references, model IDs offered by
+several providers, and malformed URLs. Each rule has a severity of error,
+warning, or info; --severity hides less serious issues and the command fails
+when an issue reaches --fail-on. Use -o json or -o yaml for machine-readable
+output.
+
+The subcommands validate specific aspects of the catalog:
   - Model definitions
   - Provider configurations
   - Author information
   - Overall catalog consistency`,
+		Args: cobra.NoArgs,
+		Example: `  starmap validate
+  starmap validate --severity info -o json
+  starmap validate --rule dangling-author,malformed-url --fail-on warning
+  starmap validate --list-rules`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return cmd.Help()
+			return runLint(cmd, app, flags)
 		},
 	}
+	addLintFlags(cmd, &flags)
 
 	// Add subcommands with app context
 	cmd.AddCommand(NewModelsCommand(app))
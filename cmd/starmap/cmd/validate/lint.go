@@ -0,0 +1,118 @@
+package validate
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/lint"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+	"github.com/agentstation/starmap/internal/cli/table"
+)
+
+// lintFlags holds the flags of the bare validate command.
+type lintFlags struct {
+	rules     []string
+	severity  string
+	failOn    string
+	listRules bool
+}
+
+func addLintFlags(cmd *cobra.Command, flags *lintFlags) {
+	cmd.Flags().StringSliceVar(&flags.rules, "rule", nil, "Only run these rules (comma-separated or repeated)")
+	cmd.Flags().StringVar(&flags.severity, "severity", string(lint.SeverityWarning), "Only report issues at least this serious: error, warning, or info")
+	cmd.Flags().StringVar(&flags.failOn, "fail-on", string(lint.SeverityError), "Fail when an issue is at least this serious: error, warning, or info")
+	cmd.Flags().BoolVar(&flags.listRules, "list-rules", false, "List the rules and their severities")
+}
+
+// runLint runs the lint rule suite over the catalog.
+func runLint(cmd *cobra.Command, app application.Application, flags lintFlags) error {
+	w := cmd.OutOrStdout()
+	if flags.listRules {
+		return printRules(w, app.OutputFormat(), lint.Rules())
+	}
+
+	minSeverity, err := lint.ParseSeverity(flags.severity)
+	if err != nil {
+		return err
+	}
+	failOn, err := lint.ParseSeverity(flags.failOn)
+	if err != nil {
+		return err
+	}
+	cat, err := app.Catalog()
+	if err != nil {
+		return err
+	}
+	report, err := lint.Run(cat, lint.Options{Rules: flags.rules, MinSeverity: minSeverity})
+	if err != nil {
+		return err
+	}
+	if err := printIssues(w, app.OutputFormat(), report); err != nil {
+		return err
+	}
+	if failing := report.Count(failOn); failing > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d issues at severity %s or above", failing, failOn)
+	}
+	return nil
+}
+
+func printIssues(w io.Writer, outputFormat string, report *lint.Report) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, report)
+	}
+
+	if len(report.Issues) == 0 {
+		_, err := fmt.Fprintf(w, "%s No issues from %d rules\n", emoji.Success, len(report.Rules))
+		return err
+	}
+	rows := make([][]string, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		rows = append(rows, []string{severityLabel(issue.Severity), issue.Rule, issue.Resource, issue.Message})
+	}
+	if err := format.NewFormatter(detected).Format(w, format.Data{
+		Headers:         []string{"Severity", "Rule", "Resource", "Message"},
+		Rows:            rows,
+		ColumnAlignment: []table.Align{table.AlignLeft, table.AlignLeft, table.AlignLeft, table.AlignLeft},
+	}); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d errors, %d warnings, %d info\n",
+		report.Count(lint.SeverityError),
+		report.Count(lint.SeverityWarning)-report.Count(lint.SeverityError),
+		report.Count(lint.SeverityInfo)-report.Count(lint.SeverityWarning))
+	return err
+}
+
+func printRules(w io.Writer, outputFormat string, rules []lint.Rule) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, rules)
+	}
+
+	rows := make([][]string, 0, len(rules))
+	for _, rule := range rules {
+		rows = append(rows, []string{rule.ID, severityLabel(rule.Severity), rule.Description})
+	}
+	return format.NewFormatter(detected).Format(w, format.Data{
+		Headers:         []string{"Rule", "Severity", "Description"},
+		Rows:            rows,
+		ColumnAlignment: []table.Align{table.AlignLeft, table.AlignLeft, table.AlignLeft},
+	})
+}
+
+func severityLabel(severity lint.Severity) string {
+	switch severity {
+	case lint.SeverityError:
+		return emoji.Error + " error"
+	case lint.SeverityWarning:
+		return emoji.Warning + " warning"
+	default:
+		return emoji.Info + " info"
+	}
+}
@@ -105,6 +105,21 @@ func validateModelConsistency(app application.Application, verbose bool) error {
 				}
 			}
 
+			if err := model.Sustainability.Validate(); err != nil {
+				validationErrors = append(validationErrors,
+					fmt.Sprintf("model %s has invalid sustainability estimate: %v", model.ID, err))
+			}
+
+			if err := model.Evaluations.Validate(); err != nil {
+				validationErrors = append(validationErrors,
+					fmt.Sprintf("model %s has invalid evaluations: %v", model.ID, err))
+			}
+
+			if _, err := model.Redacted(); err != nil {
+				validationErrors = append(validationErrors,
+					fmt.Sprintf("model %s has invalid private fields: %v", model.ID, err))
+			}
+
 			if verbose {
 				fmt.Printf("  %s Validated model: %s\n", emoji.Success, model.Name)
 			}
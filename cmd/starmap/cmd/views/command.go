@@ -0,0 +1,117 @@
+// Package views provides commands for named catalog views.
+package views
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/view"
+	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/format"
+)
+
+// Summary describes a view and the size of its current materialization.
+type Summary struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Filter      string `json:"filter,omitempty" yaml:"filter,omitempty"`
+	Overrides   int    `json:"overrides" yaml:"overrides"`
+	Models      int    `json:"models" yaml:"models"`
+}
+
+// NewCommand creates the views command using app context.
+func NewCommand(app application.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "views",
+		GroupID: "catalog",
+		Short:   "List named views of the catalog",
+		Long: `List the named views defined in ~/.starmap/views.yaml.
+
+A view is a model filter, written as API query parameters, plus optional
+per-model overrides. It materializes a sub-catalog from the current catalog
+on every read, so teams can be given a governed slice of the catalog without
+copying data:
+
+  views:
+    - name: frontend-team
+      description: Approved chat models
+      filter: curation=approved-for-prod&modality_output=text&feature=streaming
+      overrides:
+        gpt-4o:
+          pricing:
+            currency: USD
+            tokens:
+              input: {per_1m: 2.0}
+              output: {per_1m: 8.0}
+
+Use starmap models list --view <name> to list or export a view, and
+starmap serve to serve views at /api/v1/views/{name}/models.`,
+	}
+
+	cmd.AddCommand(newListCommand(app))
+
+	return cmd
+}
+
+func newListCommand(app application.Application) *cobra.Command {
+	var viewsFile string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List views and how many models each selects",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			views, err := view.Load(viewsFile)
+			if err != nil {
+				return err
+			}
+			cat, err := app.Catalog()
+			if err != nil {
+				return err
+			}
+			summaries := make([]Summary, 0)
+			for _, v := range views.List() {
+				models, err := v.Models(cat, "")
+				if err != nil {
+					return err
+				}
+				summaries = append(summaries, Summary{
+					Name:        v.Name,
+					Description: v.Description,
+					Filter:      v.Filter,
+					Overrides:   len(v.Overrides),
+					Models:      len(models),
+				})
+			}
+			return printSummaries(cmd.OutOrStdout(), app.OutputFormat(), summaries)
+		},
+	}
+
+	cmd.Flags().StringVar(&viewsFile, "views-file", "",
+		"Views file defining named views (default: ~/.starmap/views.yaml)")
+
+	return cmd
+}
+
+func printSummaries(w io.Writer, outputFormat string, summaries []Summary) error {
+	detected := format.DetectFormat(outputFormat)
+	if detected != format.FormatTable && detected != format.FormatWide {
+		return format.NewFormatter(detected).Format(w, summaries)
+	}
+	if len(summaries) == 0 {
+		_, err := fmt.Fprintf(w, "%s No views defined\n", emoji.Info)
+		return err
+	}
+	rows := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		rows = append(rows, []string{s.Name, strconv.Itoa(s.Models), strconv.Itoa(s.Overrides), s.Filter, s.Description})
+	}
+	return format.NewFormatter(detected).Format(w, format.Data{
+		Headers: []string{"View", "Models", "Overrides", "Filter", "Description"},
+		Rows:    rows,
+	})
+}
@@ -0,0 +1,60 @@
+package starmap
+
+import (
+	"context"
+	"slices"
+
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const curationSourceID = catalogmeta.SourceID("curation")
+
+// TagModel adds and removes curation tags on every offering of modelID, or
+// only on providerID's offering when providerID is set, and publishes the
+// result as a new generation. Curation is owned by the local catalog layer, so
+// later syncs keep the tags. It returns the model's resulting tags.
+func (c *Client) TagModel(ctx context.Context, modelID string, providerID catalogs.ProviderID, add, remove []string) ([]string, error) {
+	add, err := normalizeCurationTags(add)
+	if err != nil {
+		return nil, err
+	}
+	remove, err = normalizeCurationTags(remove)
+	if err != nil {
+		return nil, err
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, &errors.ValidationError{Field: "tags", Message: "at least one tag to add or remove is required"}
+	}
+
+	var tags []string
+	err = c.editModel(ctx, curationSourceID, modelID, providerID, func(model *catalogs.Model) bool {
+		curation := catalogs.RetagCuration(model.Curation, add, remove)
+		tags = nil
+		if curation != nil {
+			tags = curation.Tags
+		}
+		if slices.Equal(model.CurationTags(), tags) {
+			return false
+		}
+		model.Curation = curation
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func normalizeCurationTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		value, err := catalogs.NormalizeCurationTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, value)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,98 @@
+package starmap
+
+import (
+	"context"
+	stderrors "errors"
+	"slices"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func TestTagModelPublishesCurationTags(t *testing.T) {
+	client, err := New(
+		WithCatalogStore(catalogstore.NewMemory()),
+		WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			for _, id := range []catalogs.ProviderID{"first", "second"} {
+				if err := candidate.SetProvider(catalogs.Provider{
+					ID:     id,
+					Name:   string(id),
+					Models: map[string]*catalogs.Model{"shared-model": {ID: "shared-model", Name: "Shared"}},
+				}); err != nil {
+					return nil, err
+				}
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+	if err := client.Update(ctx); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	tags, err := client.TagModel(ctx, "shared-model", "", []string{"Evaluation", "approved-for-prod"}, nil)
+	if err != nil {
+		t.Fatalf("TagModel add: %v", err)
+	}
+	if want := []string{"approved-for-prod", "evaluation"}; !slices.Equal(tags, want) {
+		t.Fatalf("tags = %v, want %v", tags, want)
+	}
+	generation := client.CurrentGenerationID()
+
+	tags, err = client.TagModel(ctx, "shared-model", "second", nil, []string{"evaluation"})
+	if err != nil {
+		t.Fatalf("TagModel remove: %v", err)
+	}
+	if want := []string{"approved-for-prod"}; !slices.Equal(tags, want) {
+		t.Fatalf("tags = %v, want %v", tags, want)
+	}
+	if client.CurrentGenerationID() == generation {
+		t.Fatal("TagModel did not publish a new generation")
+	}
+
+	for provider, want := range map[catalogs.ProviderID][]string{
+		"first":  {"approved-for-prod", "evaluation"},
+		"second": {"approved-for-prod"},
+	} {
+		model, err := client.Catalog().ProviderModel(provider, "shared-model")
+		if err != nil {
+			t.Fatalf("ProviderModel(%s): %v", provider, err)
+		}
+		if got := model.CurationTags(); !slices.Equal(got, want) {
+			t.Fatalf("%s tags = %v, want %v", provider, got, want)
+		}
+	}
+
+	generation = client.CurrentGenerationID()
+	if _, err := client.TagModel(ctx, "shared-model", "second", []string{"approved-for-prod"}, nil); err != nil {
+		t.Fatalf("TagModel no-op: %v", err)
+	}
+	if client.CurrentGenerationID() != generation {
+		t.Fatal("unchanged tags published a new generation")
+	}
+}
+
+func TestTagModelRejectsUnknownModelsAndInvalidTags(t *testing.T) {
+	client, err := New(WithCatalogStore(catalogstore.NewMemory()))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	var notFound *pkgerrors.NotFoundError
+	if _, err := client.TagModel(ctx, "missing-model", "", []string{"evaluation"}, nil); !stderrors.As(err, &notFound) {
+		t.Fatalf("missing model error = %v, want NotFoundError", err)
+	}
+	var validation *pkgerrors.ValidationError
+	if _, err := client.TagModel(ctx, "missing-model", "", []string{"not a tag"}, nil); !stderrors.As(err, &validation) {
+		t.Fatalf("invalid tag error = %v, want ValidationError", err)
+	}
+	if _, err := client.TagModel(ctx, "missing-model", "", nil, nil); !stderrors.As(err, &validation) {
+		t.Fatalf("empty tag error = %v, want ValidationError", err)
+	}
+}
@@ -64,6 +64,39 @@ func (c *Client) Generation(ctx context.Context, id string) (catalogstore.Genera
 	return catalogstore.Generation{}, &errors.NotFoundError{Resource: "catalog generation", ID: id}
 }
 
+// ActivateGeneration validates an externally received generation, such as one
+// downloaded from hosted catalog distribution, commits it to the catalog store,
+// and publishes it. Activating the current generation again is a no-op. No
+// source is contacted, so catalog data can be refreshed without a sync.
+func (c *Client) ActivateGeneration(ctx context.Context, generation catalogstore.Generation) error {
+	if err := c.requireWritableCatalogStore(); err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := generation.Validate(); err != nil {
+		return errors.WrapResource("validate", "received catalog generation", generation.Manifest.GenerationID, err)
+	}
+	if !generation.Manifest.ConsumerCompatibility.SupportsSchema(catalogs.CurrentCatalogSchemaVersion) {
+		return &errors.ValidationError{
+			Field:   "received catalog generation.consumer_compatibility",
+			Value:   generation.Manifest.ConsumerCompatibility,
+			Message: "does not support this client's catalog schema",
+		}
+	}
+	published, err := catalogstore.DecodeCatalogPayload(generation.Payload)
+	if err != nil {
+		return errors.WrapResource("decode", "received catalog generation", generation.Manifest.GenerationID, err)
+	}
+	release, err := c.updates.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return c.commitReceivedGeneration(ctx, published, generation)
+}
+
 const (
 	generationValidatorVersion = "starmap-v1"
 	generationValidationCheck  = "catalog_publication"
@@ -102,6 +135,7 @@ func (c *Client) commitAndPublish(
 		SyncRunID:    generation.Manifest.SyncRunID,
 		Sequence:     sequence,
 		Catalog:      published,
+		Previous:     oldCatalog,
 	}
 	c.hooks.dispatchUpdate(oldCatalog, published, event)
 	return pipeline.Publication{
@@ -139,6 +173,7 @@ func (c *Client) commitReceivedGeneration(ctx context.Context, published *catalo
 		SyncRunID:    generation.Manifest.SyncRunID,
 		Sequence:     sequence,
 		Catalog:      published,
+		Previous:     oldCatalog,
 	}
 	c.hooks.dispatchUpdate(oldCatalog, published, event)
 	return nil
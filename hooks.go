@@ -18,6 +18,8 @@ type (
 		SyncRunID    string
 		Sequence     uint64
 		Catalog      *catalogs.Catalog
+		// Previous is the catalog that was visible before this publication.
+		Previous *catalogs.Catalog
 	}
 
 	// CatalogPublishedHook is called after a catalog generation is durably
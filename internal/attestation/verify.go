@@ -0,0 +1,64 @@
+// Package attestation verifies the signed GitHub build provenance attached to
+// Starmap release binaries and catalog generations.
+//
+// Verification is delegated to the GitHub CLI (gh attestation verify) pinned
+// to the publishing repository, the exact signing workflow, and GitHub-hosted
+// runners, which is the same policy the release workflows enforce before
+// publication.
+package attestation
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const (
+	// Repository is the only repository trusted to publish Starmap artifacts.
+	Repository = "agentstation/starmap"
+	// ReleaseWorkflow signs binary release archives.
+	ReleaseWorkflow = Repository + "/.github/workflows/release.yaml"
+	// CatalogWorkflow signs published catalog generations.
+	CatalogWorkflow = Repository + "/.github/workflows/catalog-generation.yaml"
+)
+
+// Verifier checks the signed provenance of one named artifact.
+type Verifier func(ctx context.Context, name string, data []byte) error
+
+// GitHub returns a Verifier that requires signerWorkflow to have signed the
+// artifact bytes.
+func GitHub(signerWorkflow string) Verifier {
+	return func(ctx context.Context, name string, data []byte) error {
+		return verify(ctx, signerWorkflow, name, data)
+	}
+}
+
+func verify(ctx context.Context, signerWorkflow, name string, data []byte) error {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return &errors.ConfigError{
+			Component: "artifact signature",
+			Message:   "the GitHub CLI (gh) is required to verify signatures; install it or skip signature verification explicitly",
+		}
+	}
+	dir, err := os.MkdirTemp("", "starmap-attestation-")
+	if err != nil {
+		return errors.WrapIO("create", "temporary directory", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, filepath.Base(name))
+	if err := os.WriteFile(path, data, constants.SecureFilePermissions); err != nil {
+		return errors.WrapIO("write", path, err)
+	}
+	cmd := exec.CommandContext(ctx, "gh", "attestation", "verify", path,
+		"--repo", Repository,
+		"--signer-workflow", signerWorkflow,
+		"--deny-self-hosted-runners") //nolint:gosec // gh executable and policy are fixed; path is a private temp file.
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return &errors.ProcessError{Operation: "gh attestation verify", Command: "gh", Output: string(output), Err: err}
+	}
+	return nil
+}
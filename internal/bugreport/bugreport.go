@@ -0,0 +1,240 @@
+// Package bugreport collects sanitized diagnostics into a tarball users attach
+// to GitHub issues.
+//
+// A report holds the build and platform, the starmap-related environment
+// variables, the effective configuration, the catalog generation, a summary
+// of the last applied update, and the tail of recent log files. Values under
+// secret names such as API keys, tokens, and passwords are replaced with
+// [REDACTED], as are those values and URL passwords wherever they recur, and
+// the home directory is shortened to ~.
+package bugreport
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Report is the content of a bug report bundle.
+type Report struct {
+	CreatedAt   time.Time
+	Environment Environment
+	Config      Config
+	Catalog     Catalog
+	LastUpdate  *LastUpdate // Nil when no update has been recorded
+	Logs        []Log
+	Notes       []string // Diagnostics that could not be collected, and why
+}
+
+// Environment describes the starmap build, the platform, and the environment
+// variables that affect starmap.
+type Environment struct {
+	Version   string            `json:"version"`
+	Commit    string            `json:"commit"`
+	BuildDate string            `json:"build_date"`
+	BuiltBy   string            `json:"built_by"`
+	GoVersion string            `json:"go_version"`
+	OS        string            `json:"os"`
+	Arch      string            `json:"arch"`
+	CPUs      int               `json:"cpus"`
+	Variables map[string]string `json:"variables"` // Secret values redacted
+}
+
+// environmentNames are the variables outside the STARMAP_ and LOG_ prefixes
+// that affect starmap.
+var environmentNames = []string{
+	"OUTPUT", "FORMAT", "NO_COLOR", "DO_NOT_TRACK",
+	"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy",
+}
+
+// NewEnvironment describes the running build and the variables in environ,
+// given in os.Environ form, that affect starmap. Provider API keys are listed
+// so reports show which are set, but their values are redacted.
+func NewEnvironment(version, commit, date, builtBy string, environ []string, r *Redactor) Environment {
+	env := Environment{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: date,
+		BuiltBy:   builtBy,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+		Variables: map[string]string{},
+	}
+	for _, entry := range environ {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(name, "STARMAP_") || strings.HasPrefix(name, "LOG_") ||
+			strings.HasSuffix(name, "_API_KEY") || slices.Contains(environmentNames, name) {
+			env.Variables[name] = fmt.Sprint(r.Value(name, value))
+		}
+	}
+	return env
+}
+
+// Config is the effective configuration: the config file and profile merged
+// with environment variables and defaults.
+type Config struct {
+	File     string         `yaml:"file"`
+	Profile  string         `yaml:"profile,omitempty"`
+	Settings map[string]any `yaml:"settings"` // Secret values redacted
+}
+
+// NewConfig redacts the effective settings read from file.
+func NewConfig(file, profile string, settings map[string]any, r *Redactor) Config {
+	redacted, _ := r.Value("", settings).(map[string]any)
+	return Config{File: r.String(file), Profile: profile, Settings: redacted}
+}
+
+// Catalog identifies the catalog generation starmap is serving.
+type Catalog struct {
+	GenerationID       string                    `json:"generation_id,omitempty"`
+	Sequence           uint64                    `json:"sequence,omitempty"`
+	SchemaVersion      uint64                    `json:"schema_version,omitempty"`
+	GeneratedAt        *time.Time                `json:"generated_at,omitempty"`
+	Degraded           bool                      `json:"degraded"`
+	DegradationReasons []string                  `json:"degradation_reasons,omitempty"`
+	Providers          int                       `json:"providers"`
+	Models             int                       `json:"models"`
+	Readiness          *starmap.CatalogReadiness `json:"readiness,omitempty"`
+}
+
+// Log is the tail of one log file.
+type Log struct {
+	Path  string
+	Lines []string
+}
+
+// FileName returns the default bundle name for a report created at t.
+func FileName(t time.Time) string {
+	return "starmap-bugreport-" + t.UTC().Format("20060102-150405") + ".tar.gz"
+}
+
+// Write writes report to w as a gzipped tarball whose files sit under the
+// directory named by the bundle name without its extension. Every file is
+// scrubbed by r as it is written. Write returns the names of the files in
+// the bundle.
+func Write(w io.Writer, name string, report Report, r *Redactor) ([]string, error) {
+	dir := strings.TrimSuffix(filepath.Base(name), ".tar.gz")
+	files, err := report.files()
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		data := []byte(r.String(string(file.data)))
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     path.Join(dir, file.name),
+			Mode:     int64(constants.FilePermissions),
+			Size:     int64(len(data)),
+			ModTime:  report.CreatedAt,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, errors.WrapIO("write", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, errors.WrapIO("write", name, err)
+		}
+		names = append(names, file.name)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.WrapIO("write", name, err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, errors.WrapIO("write", name, err)
+	}
+	return names, nil
+}
+
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+func (report Report) files() ([]bundleFile, error) {
+	var files []bundleFile
+	addJSON := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return errors.WrapParse("json", name, err)
+		}
+		files = append(files, bundleFile{name, append(data, '\n')})
+		return nil
+	}
+
+	notes := slices.Clone(report.Notes)
+	if report.LastUpdate == nil {
+		notes = append(notes, "No update has been recorded; last-update.json is written when starmap update applies changes.")
+	}
+	files = append(files, bundleFile{"README.txt", []byte(readme(report.CreatedAt, notes))})
+
+	if err := addJSON("environment.json", report.Environment); err != nil {
+		return nil, err
+	}
+	config, err := yaml.Marshal(report.Config)
+	if err != nil {
+		return nil, errors.WrapParse("yaml", "config.yaml", err)
+	}
+	files = append(files, bundleFile{"config.yaml", config})
+	if err := addJSON("catalog.json", report.Catalog); err != nil {
+		return nil, err
+	}
+	if report.LastUpdate != nil {
+		if err := addJSON("last-update.json", report.LastUpdate); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := map[string]int{}
+	for _, log := range report.Logs {
+		base := filepath.Base(log.Path)
+		if n := seen[base]; n > 0 {
+			base = fmt.Sprintf("%d-%s", n, base)
+		}
+		seen[filepath.Base(log.Path)]++
+		var data bytes.Buffer
+		for _, line := range log.Lines {
+			data.WriteString(line)
+			data.WriteByte('\n')
+		}
+		files = append(files, bundleFile{path.Join("logs", base), data.Bytes()})
+	}
+	return files, nil
+}
+
+func readme(createdAt time.Time, notes []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "starmap bug report created %s\n\n", createdAt.UTC().Format(time.RFC3339))
+	b.WriteString("Values of API keys, tokens, passwords, and other secret settings have been\n")
+	b.WriteString("replaced with " + Redacted + ". Review the files before attaching this bundle\n")
+	b.WriteString("to a GitHub issue.\n")
+	if len(notes) > 0 {
+		b.WriteString("\nNotes:\n")
+		for _, note := range notes {
+			fmt.Fprintf(&b, "- %s\n", note)
+		}
+	}
+	return b.String()
+}
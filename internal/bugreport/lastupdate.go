@@ -0,0 +1,101 @@
+package bugreport
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sync"
+)
+
+// LastUpdate summarizes the last update applied to the local catalog. It
+// counts changes but records no model or provider contents.
+type LastUpdate struct {
+	CompletedAt      time.Time        `json:"completed_at"`
+	GenerationID     string           `json:"generation_id"`
+	SyncRunID        string           `json:"sync_run_id,omitempty"`
+	Sources          []string         `json:"sources"`
+	TotalChanges     int              `json:"total_changes"`
+	ProvidersChanged int              `json:"providers_changed"`
+	Providers        []ProviderUpdate `json:"providers"`
+}
+
+// ProviderUpdate counts the model changes of one provider.
+type ProviderUpdate struct {
+	ProviderID string `json:"provider_id"`
+	Added      int    `json:"added"`
+	Updated    int    `json:"updated"`
+	Removed    int    `json:"removed"`
+}
+
+// DefaultLastUpdatePath returns the last update record, ~/.starmap/last-update.json.
+func DefaultLastUpdatePath() (string, error) {
+	return paths.ResolveHome(constants.DefaultLastUpdatePath)
+}
+
+// NewLastUpdate summarizes an applied sync result.
+func NewLastUpdate(result *sync.Result, completedAt time.Time) LastUpdate {
+	update := LastUpdate{
+		CompletedAt:      completedAt.UTC().Truncate(time.Second),
+		GenerationID:     result.GenerationID,
+		SyncRunID:        result.SyncRunID,
+		Sources:          make([]string, 0, len(result.Sources)),
+		TotalChanges:     result.TotalChanges,
+		ProvidersChanged: result.ProvidersChanged,
+		Providers:        []ProviderUpdate{},
+	}
+	for _, source := range result.Sources {
+		update.Sources = append(update.Sources, string(source))
+	}
+	for _, provider := range result.ProviderResults {
+		if !provider.HasChanges() {
+			continue
+		}
+		update.Providers = append(update.Providers, ProviderUpdate{
+			ProviderID: string(provider.ProviderID),
+			Added:      provider.AddedCount,
+			Updated:    provider.UpdatedCount,
+			Removed:    provider.RemovedCount,
+		})
+	}
+	slices.SortFunc(update.Providers, func(a, b ProviderUpdate) int { return strings.Compare(a.ProviderID, b.ProviderID) })
+	return update
+}
+
+// RecordUpdate writes update to path, replacing the previous record.
+func RecordUpdate(path string, update LastUpdate) error {
+	data, err := json.MarshalIndent(update, "", "  ")
+	if err != nil {
+		return errors.WrapParse("json", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), constants.DirPermissions); err != nil {
+		return errors.WrapIO("create", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), constants.FilePermissions); err != nil {
+		return errors.WrapIO("write", path, err)
+	}
+	return nil
+}
+
+// LoadLastUpdate reads the record at path. It returns nil when no update has
+// been recorded.
+func LoadLastUpdate(path string) (*LastUpdate, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WrapIO("read", path, err)
+	}
+	var update LastUpdate
+	if err := json.Unmarshal(data, &update); err != nil {
+		return nil, errors.WrapParse("json", path, err)
+	}
+	return &update, nil
+}
@@ -0,0 +1,94 @@
+package bugreport
+
+import (
+	"bytes"
+	"cmp"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const (
+	// DefaultLogLines is how many trailing lines of each log file a report
+	// includes.
+	DefaultLogLines = 200
+
+	// maxLogFiles is how many files from the logs directory a report includes,
+	// newest first.
+	maxLogFiles = 3
+
+	// maxLogTail is how many trailing bytes of a log file are read, so one
+	// very large file cannot exhaust memory.
+	maxLogTail = 1 << 20
+)
+
+// LogPaths returns the log files a report includes: output, when the log
+// output is a file rather than stderr or stdout, then the most recently
+// modified files in dir.
+func LogPaths(output, dir string) []string {
+	var paths []string
+	switch strings.ToLower(output) {
+	case "", "stderr", "stdout":
+	default:
+		paths = append(paths, output)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return paths
+	}
+	type logFile struct {
+		path    string
+		modTime int64
+	}
+	var files []logFile
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, logFile{filepath.Join(dir, entry.Name()), info.ModTime().UnixNano()})
+	}
+	slices.SortFunc(files, func(a, b logFile) int { return cmp.Compare(b.modTime, a.modTime) })
+	for _, file := range files[:min(len(files), maxLogFiles)] {
+		if !slices.Contains(paths, file.path) {
+			paths = append(paths, file.path)
+		}
+	}
+	return paths
+}
+
+// TailLog returns the last n lines of the file at path.
+func TailLog(path string, n int) ([]string, error) {
+	file, err := os.Open(path) //nolint:gosec // path is the configured log output or a file in the logs directory
+	if err != nil {
+		return nil, errors.WrapIO("open", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, errors.WrapIO("stat", path, err)
+	}
+	offset := max(info.Size()-maxLogTail, 0)
+	data, err := io.ReadAll(io.NewSectionReader(file, offset, info.Size()-offset))
+	if err != nil {
+		return nil, errors.WrapIO("read", path, err)
+	}
+	if offset > 0 {
+		// Drop the partial first line
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return []string{}, nil
+	}
+	return lines[max(len(lines)-n, 0):], nil
+}
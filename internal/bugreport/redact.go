@@ -0,0 +1,184 @@
+package bugreport
+
+import (
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Redacted replaces secret values in a bug report.
+const Redacted = "[REDACTED]"
+
+// minSecretLength is the shortest value scrubbed from free text. Shorter
+// values are still redacted where they appear under a secret name, but would
+// match too much unrelated text to replace everywhere.
+const minSecretLength = 6
+
+// secretSuffixes mark a name part as secret, so api_key, apikey, and
+// OPENAI_API_KEY all match.
+var secretSuffixes = []string{"key", "token", "secret", "password", "passwd", "credential", "credentials"}
+
+// secretParts are name parts that are secret on their own.
+var secretParts = []string{"authorization", "cookie", "dsn"}
+
+// urlPattern finds URLs in free text so their passwords can be removed.
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'<>]+`)
+
+// Redactor removes secrets from settings, environment variables, and text.
+// Values it redacts under secret names are remembered and also scrubbed from
+// free text, such as log lines, that repeats them.
+type Redactor struct {
+	home    string
+	secrets []string
+}
+
+// NewRedactor creates a Redactor that already knows the values of the secret
+// variables in environ, given in os.Environ form.
+func NewRedactor(environ []string) *Redactor {
+	r := &Redactor{}
+	if home, err := os.UserHomeDir(); err == nil && home != "/" {
+		r.home = home
+	}
+	for _, entry := range environ {
+		if name, value, ok := strings.Cut(entry, "="); ok && SecretName(name) {
+			r.remember(value)
+		}
+	}
+	return r
+}
+
+// SecretName reports whether a setting or variable name holds a secret.
+func SecretName(name string) bool {
+	parts := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, part := range parts {
+		if slices.Contains(secretParts, part) {
+			return true
+		}
+		for _, suffix := range secretSuffixes {
+			if strings.HasSuffix(part, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Value returns a copy of v, the value of the setting name, with every value
+// beneath a secret name replaced by Redacted. Map keys are kept.
+func (r *Redactor) Value(name string, v any) any {
+	if SecretName(name) {
+		return r.redactAll(v)
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			out[key] = r.Value(key, value)
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			out[key] = r.Value(key, value)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, value := range v {
+			out[i] = r.Value(name, value)
+		}
+		return out
+	case string:
+		return r.String(v)
+	default:
+		return v
+	}
+}
+
+// redactAll replaces every leaf of v.
+func (r *Redactor) redactAll(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			out[key] = r.redactAll(value)
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			out[key] = r.redactAll(value)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, value := range v {
+			out[i] = r.redactAll(value)
+		}
+		return out
+	case string:
+		if v == "" {
+			return ""
+		}
+		r.remember(v)
+		return Redacted
+	case nil:
+		return nil
+	default:
+		return Redacted
+	}
+}
+
+// String removes remembered secrets and URL passwords from s and replaces
+// the home directory with ~.
+func (r *Redactor) String(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Redacted)
+	}
+	s = urlPattern.ReplaceAllStringFunc(s, redactURL)
+	if r.home != "" {
+		s = strings.ReplaceAll(s, r.home, "~")
+	}
+	return s
+}
+
+func (r *Redactor) remember(secret string) {
+	if len(secret) < minSecretLength || slices.Contains(r.secrets, secret) {
+		return
+	}
+	r.secrets = append(r.secrets, secret)
+	// Replace longer secrets first so one containing another is fully removed
+	slices.SortFunc(r.secrets, func(a, b string) int { return len(b) - len(a) })
+}
+
+// redactURL removes the password from a URL's user info and the values of
+// secret query parameters.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	changed := false
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), Redacted)
+		changed = true
+	}
+	query := u.Query()
+	for name := range query {
+		if SecretName(name) {
+			query.Set(name, Redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return raw
+	}
+	u.RawQuery = query.Encode()
+	// Keep the marker readable rather than percent-encoded
+	return strings.NewReplacer("%5BREDACTED%5D", Redacted).Replace(u.String())
+}
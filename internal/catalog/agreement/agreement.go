@@ -0,0 +1,279 @@
+// Package agreement compares the value of one model field across source
+// catalogs. It shows, per provider offering, what each source reported and
+// whether they agree, and flags providers where two sources disagree on most
+// models, which usually points at a unit or mapping error in one source
+// rather than a stale value.
+package agreement
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// Source is the catalog one source observed.
+type Source struct {
+	ID      sources.ID
+	Catalog catalogs.Reader
+}
+
+// Status describes whether the sources reporting a field agree.
+type Status string
+
+// Agreement statuses.
+const (
+	StatusAgree    Status = "agree"    // Every reporting source has the same value
+	StatusDisagree Status = "disagree" // At least two reporting sources differ
+	StatusSingle   Status = "single"   // Only one source reports the field
+)
+
+// Value is the value one source reported for a field.
+type Value struct {
+	Source sources.ID `json:"source" yaml:"source"`
+	Value  any        `json:"value" yaml:"value"`
+}
+
+// Row is the field of one provider offering across sources.
+type Row struct {
+	Provider catalogs.ProviderID `json:"provider" yaml:"provider"`
+	Model    string              `json:"model" yaml:"model"`
+	Values   []Value             `json:"values" yaml:"values"`
+	Status   Status              `json:"status" yaml:"status"`
+}
+
+// Value returns the value source reported, and whether it reported one.
+func (r Row) Value(source sources.ID) (any, bool) {
+	for _, value := range r.Values {
+		if value.Source == source {
+			return value.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Discrepancy summarizes how often two sources disagree on a provider's
+// models. Systematic discrepancies cover at least two models and at least
+// half of the models both sources report. Ratio is set when every numeric
+// disagreement has the same Right/Left ratio, as a unit error would.
+type Discrepancy struct {
+	Provider   catalogs.ProviderID `json:"provider" yaml:"provider"`
+	Left       sources.ID          `json:"left" yaml:"left"`
+	Right      sources.ID          `json:"right" yaml:"right"`
+	Compared   int                 `json:"compared" yaml:"compared"`
+	Disagreed  int                 `json:"disagreed" yaml:"disagreed"`
+	Ratio      float64             `json:"ratio,omitempty" yaml:"ratio,omitempty"`
+	Systematic bool                `json:"systematic" yaml:"systematic"`
+}
+
+// Report is the agreement of one field across sources.
+type Report struct {
+	Field         string        `json:"field" yaml:"field"`
+	Sources       []sources.ID  `json:"sources" yaml:"sources"`
+	Rows          []Row         `json:"rows" yaml:"rows"`
+	Discrepancies []Discrepancy `json:"discrepancies,omitempty" yaml:"discrepancies,omitempty"`
+}
+
+// Build compares field, a dotted path of model JSON names such as
+// pricing.tokens.input, across the source catalogs. Token prices compare by
+// their per-1M price, so a source giving only a per-token price still agrees
+// with one giving the same price per 1M tokens.
+func Build(field string, srcs []Source) (*Report, error) {
+	if err := ValidateField(field); err != nil {
+		return nil, err
+	}
+	path := strings.Split(field, ".")
+
+	type offering struct {
+		provider catalogs.ProviderID
+		model    string
+	}
+	values := make(map[offering][]Value)
+	report := &Report{Field: field}
+	for _, src := range srcs {
+		report.Sources = append(report.Sources, src.ID)
+		if src.Catalog == nil {
+			continue
+		}
+		for _, provider := range src.Catalog.Providers().List() {
+			for id, model := range provider.Models {
+				value, ok := fieldValue(model, path)
+				if !ok {
+					continue
+				}
+				key := offering{provider: provider.ID, model: id}
+				values[key] = append(values[key], Value{Source: src.ID, Value: value})
+			}
+		}
+	}
+
+	for key, reported := range values {
+		report.Rows = append(report.Rows, Row{Provider: key.provider, Model: key.model, Values: reported, Status: status(reported)})
+	}
+	slices.SortFunc(report.Rows, func(a, b Row) int {
+		return cmp.Or(strings.Compare(string(a.Provider), string(b.Provider)), strings.Compare(a.Model, b.Model))
+	})
+	report.Discrepancies = discrepancies(report.Sources, report.Rows)
+	return report, nil
+}
+
+func status(values []Value) Status {
+	if len(values) < 2 {
+		return StatusSingle
+	}
+	for _, value := range values[1:] {
+		if !equal(values[0].Value, value.Value) {
+			return StatusDisagree
+		}
+	}
+	return StatusAgree
+}
+
+// discrepancies compares every pair of sources within each provider and
+// returns the pairs that disagree at least once.
+func discrepancies(ids []sources.ID, rows []Row) []Discrepancy {
+	var result []Discrepancy
+	for i, left := range ids {
+		for _, right := range ids[i+1:] {
+			var current *Discrepancy
+			var ratios []float64
+			consistent := true
+			flush := func() {
+				if current == nil || current.Disagreed == 0 {
+					return
+				}
+				current.Systematic = current.Disagreed >= 2 && current.Disagreed*2 >= current.Compared
+				if consistent && len(ratios) > 0 {
+					current.Ratio = ratios[0]
+				}
+				result = append(result, *current)
+			}
+			for _, row := range rows {
+				if current == nil || current.Provider != row.Provider {
+					flush()
+					current = &Discrepancy{Provider: row.Provider, Left: left, Right: right}
+					ratios, consistent = nil, true
+				}
+				leftValue, leftOK := row.Value(left)
+				rightValue, rightOK := row.Value(right)
+				if !leftOK || !rightOK {
+					continue
+				}
+				current.Compared++
+				if equal(leftValue, rightValue) {
+					continue
+				}
+				current.Disagreed++
+				l, lNumber := leftValue.(float64)
+				r, rNumber := rightValue.(float64)
+				if !lNumber || !rNumber || l == 0 {
+					consistent = false
+					continue
+				}
+				ratio := r / l
+				if len(ratios) > 0 && math.Abs(ratio-ratios[0]) > 0.01*math.Abs(ratios[0]) {
+					consistent = false
+				}
+				ratios = append(ratios, ratio)
+			}
+			flush()
+		}
+	}
+	return result
+}
+
+// equal compares two JSON values, numbers within a relative tolerance.
+func equal(a, b any) bool {
+	x, xNumber := a.(float64)
+	y, yNumber := b.(float64)
+	if xNumber && yNumber {
+		return math.Abs(x-y) <= 1e-9*math.Max(math.Abs(x), math.Abs(y))
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// fieldValue reads path from the JSON form of model, reporting false when
+// the model does not set it.
+func fieldValue(model *catalogs.Model, path []string) (any, bool) {
+	if model == nil {
+		return nil, false
+	}
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, false
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, false
+	}
+	for _, name := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok || value == nil {
+			return nil, false
+		}
+	}
+	return tokenCost(value), true
+}
+
+// tokenCost reduces a token cost object to its per-1M price.
+func tokenCost(value any) any {
+	object, ok := value.(map[string]any)
+	if !ok || len(object) != 2 {
+		return value
+	}
+	perToken, tokenOK := object["per_token"].(float64)
+	per1M, millionOK := object["per_1m_tokens"].(float64)
+	if !tokenOK || !millionOK {
+		return value
+	}
+	if per1M == 0 {
+		return perToken * 1_000_000
+	}
+	return per1M
+}
+
+// ValidateField checks that field names fields of Model by their JSON
+// names. Map keys below a map field are not checked.
+func ValidateField(field string) error {
+	path := strings.Split(field, ".")
+	current := reflect.TypeFor[catalogs.Model]()
+	for i, name := range path {
+		for current.Kind() == reflect.Pointer || current.Kind() == reflect.Slice {
+			current = current.Elem()
+		}
+		switch current.Kind() {
+		case reflect.Map, reflect.Interface:
+			return nil
+		case reflect.Struct:
+		default:
+			return &errors.ValidationError{Field: "field", Value: field, Message: fmt.Sprintf("%q has no fields below it", strings.Join(path[:i], "."))}
+		}
+		field, ok := jsonField(current, name)
+		if !ok {
+			return &errors.ValidationError{Field: "field", Value: field, Message: fmt.Sprintf("%q is not a model field", strings.Join(path[:i+1], "."))}
+		}
+		current = field.Type
+	}
+	return nil
+}
+
+func jsonField(structType reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == name && field.IsExported() {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
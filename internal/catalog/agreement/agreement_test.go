@@ -0,0 +1,108 @@
+package agreement
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+func priced(id string, cost catalogs.ModelTokenCost) *catalogs.Model {
+	return &catalogs.Model{ID: id, Name: id, Pricing: &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{Input: &cost}}}
+}
+
+func testSource(t *testing.T, id sources.ID, models map[catalogs.ProviderID][]*catalogs.Model) Source {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	for providerID, providerModels := range models {
+		provider := catalogs.Provider{ID: providerID, Name: string(providerID), Models: map[string]*catalogs.Model{}}
+		for _, model := range providerModels {
+			provider.Models[model.ID] = model
+		}
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider: %v", err)
+		}
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return Source{ID: id, Catalog: catalog}
+}
+
+func TestBuild(t *testing.T) {
+	api := testSource(t, sources.ProvidersID, map[catalogs.ProviderID][]*catalogs.Model{
+		"openai": {
+			priced("gpt-a", catalogs.ModelTokenCost{Per1M: 2.5}),
+			priced("gpt-b", catalogs.ModelTokenCost{Per1M: 10}),
+			priced("gpt-c", catalogs.ModelTokenCost{PerToken: 0.000001}),
+		},
+		"groq": {
+			priced("llama-a", catalogs.ModelTokenCost{Per1M: 0.5}),
+			priced("llama-b", catalogs.ModelTokenCost{Per1M: 0.2}),
+			priced("llama-c", catalogs.ModelTokenCost{Per1M: 0.1}),
+		},
+	})
+	modelsDev := testSource(t, sources.ModelsDevHTTPID, map[catalogs.ProviderID][]*catalogs.Model{
+		"openai": {
+			priced("gpt-a", catalogs.ModelTokenCost{Per1M: 2.5}),
+			priced("gpt-b", catalogs.ModelTokenCost{Per1M: 12}),
+			priced("gpt-c", catalogs.ModelTokenCost{Per1M: 1}),
+			{ID: "gpt-unpriced", Name: "gpt-unpriced"},
+		},
+		"groq": {
+			// A per-token price entered as per-1M: every model off by 1e6.
+			priced("llama-a", catalogs.ModelTokenCost{Per1M: 0.0000005}),
+			priced("llama-b", catalogs.ModelTokenCost{Per1M: 0.0000002}),
+			priced("llama-d", catalogs.ModelTokenCost{Per1M: 0.3}),
+		},
+	})
+
+	report, err := Build("pricing.tokens.input", []Source{api, modelsDev})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	want := map[string]Status{
+		"groq/llama-a": StatusDisagree, "groq/llama-b": StatusDisagree,
+		"groq/llama-c": StatusSingle, "groq/llama-d": StatusSingle,
+		"openai/gpt-a": StatusAgree, "openai/gpt-b": StatusDisagree, "openai/gpt-c": StatusAgree,
+	}
+	if len(report.Rows) != len(want) {
+		t.Fatalf("rows = %+v, want %d rows", report.Rows, len(want))
+	}
+	for _, row := range report.Rows {
+		if got := row.Status; got != want[string(row.Provider)+"/"+row.Model] {
+			t.Errorf("%s/%s status = %s, want %s", row.Provider, row.Model, got, want[string(row.Provider)+"/"+row.Model])
+		}
+	}
+	if first := report.Rows[0]; first.Provider != "groq" || first.Model != "llama-a" {
+		t.Errorf("first row = %s/%s, want rows sorted by provider and model", first.Provider, first.Model)
+	}
+
+	if len(report.Discrepancies) != 2 {
+		t.Fatalf("discrepancies = %+v, want one per provider", report.Discrepancies)
+	}
+	groq, openAI := report.Discrepancies[0], report.Discrepancies[1]
+	if !groq.Systematic || groq.Compared != 2 || groq.Disagreed != 2 || groq.Ratio < 0.999e-6 || groq.Ratio > 1.001e-6 {
+		t.Errorf("groq discrepancy = %+v, want systematic with a 1e-6 ratio", groq)
+	}
+	if openAI.Systematic || openAI.Compared != 3 || openAI.Disagreed != 1 {
+		t.Errorf("openai discrepancy = %+v, want one isolated disagreement", openAI)
+	}
+}
+
+func TestBuildRejectsUnknownField(t *testing.T) {
+	for _, field := range []string{"pricing.tokens.inputs", "limits.context_window.max", "nope"} {
+		_, err := Build(field, nil)
+		var validation *errors.ValidationError
+		if !stderrors.As(err, &validation) {
+			t.Errorf("Build(%q) error = %v, want a validation error", field, err)
+		}
+	}
+	if _, err := Build("modes.fast.pricing", nil); err != nil {
+		t.Errorf("Build below a map field: %v", err)
+	}
+}
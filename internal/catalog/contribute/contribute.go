@@ -0,0 +1,463 @@
+// Package contribute checks a catalog directory the way CI checks the
+// embedded catalog, so a contributor can see every complaint about their
+// edits before opening a pull request. Errors fail CI; warnings point at
+// edits that are likely to be lost or that reviewers will ask to tidy.
+package contribute
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/agentstation/starmap/pkg/authority"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// Rule names the check that produced a finding.
+type Rule string
+
+// Contribution rules.
+const (
+	RuleSchema    Rule = "schema"    // Files decode into the catalog types without unknown fields
+	RuleLint      Rule = "lint"      // Required fields, references, and value ranges
+	RuleAuthority Rule = "authority" // Edits a higher-priority source would overwrite
+	RuleLogo      Rule = "logo"      // Providers and authors ship a logo.svg
+	RuleSorted    Rule = "sorted"    // Lists are sorted and model files are canonical
+)
+
+// Severity is how CI treats a finding.
+type Severity string
+
+// Finding severities.
+const (
+	SeverityError   Severity = "error"   // Fails CI
+	SeverityWarning Severity = "warning" // Reported, but does not fail CI
+)
+
+// Finding is one complaint about a catalog file.
+type Finding struct {
+	Rule     Rule     `json:"rule" yaml:"rule"`
+	Severity Severity `json:"severity" yaml:"severity"`
+	File     string   `json:"file" yaml:"file"`
+	Message  string   `json:"message" yaml:"message"`
+}
+
+// Change is a catalog file changed since a base revision. Base is the file
+// at that revision, or nil when the file is new.
+type Change struct {
+	Path string
+	Base []byte
+}
+
+// Options configures Check.
+type Options struct {
+	// Changes lists the files changed since the base revision. The authority
+	// rule only runs on changed model files.
+	Changes []Change
+}
+
+// Report is the result of checking a catalog directory.
+type Report struct {
+	Dir      string    `json:"dir" yaml:"dir"`
+	Files    int       `json:"files" yaml:"files"`
+	Findings []Finding `json:"findings" yaml:"findings"`
+}
+
+// Errors returns the number of findings that fail CI.
+func (r *Report) Errors() int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+// Check checks the catalog in dir. Findings are sorted by file; a catalog
+// that cannot be loaded is reported as a schema error rather than returned.
+func Check(dir string, opts Options) (*Report, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, errors.WrapIO("stat", dir, err)
+	}
+	c := &checker{fsys: os.DirFS(dir), report: &Report{Dir: dir}}
+	if err := c.decodeFiles(); err != nil {
+		return nil, err
+	}
+
+	catalog, err := catalogs.NewFromPath(dir)
+	if err != nil {
+		c.add(RuleSchema, SeverityError, "", "catalog does not load: %v", err)
+	} else {
+		c.catalog = catalog
+		c.lintProviders()
+		c.lintModels()
+		c.checkAuthority(opts.Changes)
+		c.checkLogos()
+	}
+	c.checkSorted()
+	c.checkCanonical()
+
+	slices.SortStableFunc(c.report.Findings, func(a, b Finding) int {
+		return cmp.Compare(a.File, b.File)
+	})
+	return c.report, nil
+}
+
+type checker struct {
+	fsys    fs.FS
+	catalog *catalogs.Builder
+	report  *Report
+
+	// models maps each model file that decoded to its provider or author
+	// directory and the model it holds.
+	models    map[string]modelFile
+	providers []catalogs.Provider
+	authors   []catalogs.Author
+}
+
+type modelFile struct {
+	owner    string // "providers" or "authors"
+	ownerID  string
+	model    catalogs.Model
+	contents []byte
+}
+
+func (c *checker) add(rule Rule, severity Severity, file, format string, args ...any) {
+	c.report.Findings = append(c.report.Findings, Finding{
+		Rule:     rule,
+		Severity: severity,
+		File:     file,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// decodeFiles strictly decodes providers.yaml, authors.yaml, and every model
+// file, reporting unknown fields and malformed YAML.
+func (c *checker) decodeFiles() error {
+	c.providers = decodeList[catalogs.Provider](c, "providers.yaml")
+	c.authors = decodeList[catalogs.Author](c, "authors.yaml")
+
+	c.models = make(map[string]modelFile)
+	for _, owner := range []string{"providers", "authors"} {
+		err := fs.WalkDir(c.fsys, owner, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(file, ".yaml") {
+				return nil
+			}
+			c.report.Files++
+			parts := strings.Split(file, "/")
+			if len(parts) < 4 || parts[2] != "models" {
+				c.add(RuleSchema, SeverityError, file, "model files belong in %s/<id>/models/", owner)
+				return nil
+			}
+			data, err := fs.ReadFile(c.fsys, file)
+			if err != nil {
+				return errors.WrapIO("read", file, err)
+			}
+			var model catalogs.Model
+			if err := yaml.UnmarshalWithOptions(data, &model, yaml.Strict()); err != nil {
+				c.add(RuleSchema, SeverityError, file, "%s", yamlError(err))
+				return nil
+			}
+			c.models[file] = modelFile{owner: owner, ownerID: parts[1], model: model, contents: data}
+			return nil
+		})
+		if err != nil {
+			return errors.WrapIO("walk", owner, err)
+		}
+	}
+	return nil
+}
+
+func decodeList[T any](c *checker, file string) []T {
+	data, err := fs.ReadFile(c.fsys, file)
+	if err != nil {
+		c.add(RuleSchema, SeverityError, file, "cannot read: %v", err)
+		return nil
+	}
+	c.report.Files++
+	var items []T
+	if err := yaml.UnmarshalWithOptions(data, &items, yaml.Strict()); err != nil {
+		c.add(RuleSchema, SeverityError, file, "%s", yamlError(err))
+		return nil
+	}
+	return items
+}
+
+// yamlError flattens a YAML error, which spans lines to quote the source,
+// to its first line.
+func yamlError(err error) string {
+	message, _, _ := strings.Cut(yaml.FormatError(err, false, false), "\n")
+	return strings.TrimSpace(message)
+}
+
+// lintProviders repeats the provider checks of starmap validate catalog.
+func (c *checker) lintProviders() {
+	seen := make(map[catalogs.ProviderID]bool)
+	for _, provider := range c.providers {
+		const file = "providers.yaml"
+		if provider.ID == "" {
+			c.add(RuleLint, SeverityError, file, "provider missing required field 'id'")
+			continue
+		}
+		if seen[provider.ID] {
+			c.add(RuleLint, SeverityError, file, "duplicate provider id %q", provider.ID)
+		}
+		seen[provider.ID] = true
+		if provider.Name == "" {
+			c.add(RuleLint, SeverityError, file, "provider %s missing required field 'name'", provider.ID)
+		}
+		if key := provider.APIKey; key != nil {
+			switch {
+			case key.Name == "":
+				c.add(RuleLint, SeverityError, file, "provider %s api_key is missing 'name'", provider.ID)
+			case key.Header == "" && key.QueryParam == "":
+				c.add(RuleLint, SeverityError, file, "provider %s api_key sets neither header nor query_param", provider.ID)
+			case key.Header != "" && key.QueryParam != "":
+				c.add(RuleLint, SeverityError, file, "provider %s api_key sets both header and query_param", provider.ID)
+			}
+		}
+		if catalog := provider.Catalog; catalog != nil {
+			if catalog.Endpoint.AuthRequired && provider.APIKey == nil {
+				c.add(RuleLint, SeverityError, file, "provider %s requires auth but has no api_key", provider.ID)
+			}
+			if catalog.Endpoint.URL != "" && !isURL(catalog.Endpoint.URL) {
+				c.add(RuleLint, SeverityError, file, "provider %s catalog endpoint url %q is not an http(s) URL", provider.ID, catalog.Endpoint.URL)
+			}
+		}
+	}
+}
+
+// lintModels checks model files: starmap validate catalog's model checks,
+// plus checks that a model is filed where the loader looks for it.
+func (c *checker) lintModels() {
+	for file, entry := range c.models {
+		model := entry.model
+		if model.ID == "" {
+			c.add(RuleLint, SeverityError, file, "model missing required field 'id'")
+			continue
+		}
+		if want := path.Join(entry.owner, entry.ownerID, "models", model.ID+".yaml"); file != want {
+			c.add(RuleLint, SeverityError, file, "model %s must be saved as %s", model.ID, want)
+		}
+		switch entry.owner {
+		case "providers":
+			if _, err := c.catalog.Provider(catalogs.ProviderID(entry.ownerID)); err != nil {
+				c.add(RuleLint, SeverityError, file, "provider %s is not in providers.yaml, so this file is ignored", entry.ownerID)
+			}
+		case "authors":
+			if _, err := c.catalog.Author(catalogs.AuthorID(entry.ownerID)); err != nil {
+				c.add(RuleLint, SeverityError, file, "author %s is not in authors.yaml, so this file is ignored", entry.ownerID)
+			}
+		}
+		if model.Name == "" {
+			c.add(RuleLint, SeverityError, file, "model %s missing required field 'name'", model.ID)
+		}
+		for _, author := range model.Authors {
+			if _, found := c.catalog.Authors().Resolve(author.ID); !found {
+				c.add(RuleLint, SeverityError, file, "model %s references unknown author %s", model.ID, author.ID)
+			}
+		}
+		if limits := model.Limits; limits != nil {
+			for name, value := range map[string]int64{
+				"context_window": limits.ContextWindow,
+				"input_tokens":   limits.InputTokens,
+				"output_tokens":  limits.OutputTokens,
+			} {
+				if value < 0 {
+					c.add(RuleLint, SeverityError, file, "model %s has negative %s %d", model.ID, name, value)
+				}
+			}
+		}
+		if err := model.Sustainability.Validate(); err != nil {
+			c.add(RuleLint, SeverityError, file, "model %s has an invalid sustainability estimate: %v", model.ID, err)
+		}
+		if err := model.Evaluations.Validate(); err != nil {
+			c.add(RuleLint, SeverityError, file, "model %s has invalid evaluations: %v", model.ID, err)
+		}
+	}
+}
+
+// checkAuthority warns about changed provider model fields that a source
+// outranks the local catalog for and that are not pinned: the next
+// starmap update overwrites them.
+func (c *checker) checkAuthority(changes []Change) {
+	authorities := authority.New()
+	for _, change := range changes {
+		entry, ok := c.models[change.Path]
+		if !ok || entry.owner != "providers" || change.Base == nil {
+			continue
+		}
+		var before, after map[string]any
+		if yaml.Unmarshal(change.Base, &before) != nil || yaml.Unmarshal(entry.contents, &after) != nil {
+			continue
+		}
+		var pins []string
+		for _, pin := range c.catalog.Pins() {
+			if string(pin.Provider) == entry.ownerID && pin.Model == entry.model.ID {
+				pins = append(pins, pin.Field)
+			}
+		}
+		for _, field := range changedFields(before, after, "") {
+			if pinned(field, pins) {
+				continue
+			}
+			top, _, _ := strings.Cut(field, ".")
+			name, ok := modelFieldName(top)
+			if !ok {
+				continue
+			}
+			owner := authorities.Find(sources.ResourceTypeModel, name)
+			if owner == nil || owner.Source == sources.LocalCatalogID {
+				continue
+			}
+			c.add(RuleAuthority, SeverityWarning, change.Path,
+				"%s changed, but %s outranks the local catalog for %s; pin it with # %s or starmap update will overwrite it",
+				field, owner.Source, top, catalogs.PinComment)
+		}
+	}
+}
+
+// changedFields returns the dotted paths of the leaves that differ between
+// two decoded YAML documents.
+func changedFields(before, after map[string]any, prefix string) []string {
+	keys := make(map[string]bool)
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+	var changed []string
+	for _, key := range slices.Sorted(func(yield func(string) bool) {
+		for key := range keys {
+			if !yield(key) {
+				return
+			}
+		}
+	}) {
+		left, right := before[key], after[key]
+		leftMap, leftOK := left.(map[string]any)
+		rightMap, rightOK := right.(map[string]any)
+		switch {
+		case leftOK && rightOK:
+			changed = append(changed, changedFields(leftMap, rightMap, prefix+key+".")...)
+		case !reflect.DeepEqual(left, right):
+			changed = append(changed, prefix+key)
+		}
+	}
+	return changed
+}
+
+// pinned reports whether a pin covers field: pinning a mapping pins every
+// field below it.
+func pinned(field string, pins []string) bool {
+	for _, pin := range pins {
+		if field == pin || strings.HasPrefix(field, pin+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// modelFieldName returns the Go name of the model field with a YAML name,
+// which is how authorities name fields.
+func modelFieldName(yamlName string) (string, bool) {
+	modelType := reflect.TypeFor[catalogs.Model]()
+	for i := range modelType.NumField() {
+		field := modelType.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if tag == yamlName && field.IsExported() {
+			return field.Name, true
+		}
+	}
+	return "", false
+}
+
+// checkLogos warns about providers and authors without a logo.svg, which the
+// docs and site render next to their models.
+func (c *checker) checkLogos() {
+	for _, provider := range c.providers {
+		if provider.ID == "" {
+			continue
+		}
+		c.checkLogo(path.Join("providers", string(provider.ID)), "provider", string(provider.ID))
+	}
+	for _, author := range c.authors {
+		if author.ID == "" {
+			continue
+		}
+		dir := path.Join("authors", string(author.ID))
+		if _, err := fs.Stat(c.fsys, dir); err != nil {
+			continue
+		}
+		c.checkLogo(dir, "author", string(author.ID))
+	}
+}
+
+func (c *checker) checkLogo(dir, kind, id string) {
+	logo := path.Join(dir, "logo.svg")
+	if _, err := fs.Stat(c.fsys, logo); err != nil {
+		c.add(RuleLogo, SeverityWarning, logo, "%s %s has no logo.svg", kind, id)
+	}
+}
+
+// checkSorted warns when providers.yaml or authors.yaml is not sorted by id,
+// the order starmap update writes them in.
+func (c *checker) checkSorted() {
+	checkOrder(c, "providers.yaml", c.providers, func(p catalogs.Provider) string { return string(p.ID) })
+	checkOrder(c, "authors.yaml", c.authors, func(a catalogs.Author) string { return string(a.ID) })
+}
+
+func checkOrder[T any](c *checker, file string, items []T, id func(T) string) {
+	for i := 1; i < len(items); i++ {
+		if previous, current := id(items[i-1]), id(items[i]); previous > current {
+			c.add(RuleSorted, SeverityWarning, file, "%s is listed after %s; entries are sorted by id, run starmap fmt", current, previous)
+		}
+	}
+}
+
+// checkCanonical warns about files that differ from the layout starmap
+// update writes, so the next update does not produce a noisy diff. Lists
+// already reported as unsorted are not reported again.
+func (c *checker) checkCanonical() {
+	files := map[string][]byte{}
+	for _, file := range []string{"providers.yaml", "authors.yaml"} {
+		if slices.ContainsFunc(c.report.Findings, func(f Finding) bool { return f.Rule == RuleSorted && f.File == file }) {
+			continue
+		}
+		if data, err := fs.ReadFile(c.fsys, file); err == nil {
+			files[file] = data
+		}
+	}
+	for file, entry := range c.models {
+		files[file] = entry.contents
+	}
+	for file, data := range files {
+		formatted, err := catalogs.FormatYAMLFile(file, data)
+		if err != nil || bytes.Equal(formatted, data) {
+			continue
+		}
+		c.add(RuleSorted, SeverityWarning, file, "file is not in canonical form; run starmap fmt")
+	}
+}
+
+func isURL(value string) bool {
+	return strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")
+}
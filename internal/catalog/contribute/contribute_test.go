@@ -0,0 +1,134 @@
+package contribute
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func writeFile(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func canonical(t *testing.T, model catalogs.Model) string {
+	t.Helper()
+	data, err := model.EncodeYAML()
+	if err != nil {
+		t.Fatalf("EncodeYAML: %v", err)
+	}
+	return data
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "providers.yaml", "- id: openai\n  name: OpenAI\n- id: groq\n  name: Groq\n")
+	writeFile(t, dir, "authors.yaml", "- id: openai\n  name: OpenAI\n")
+	writeFile(t, dir, "providers/openai/logo.svg", "<svg/>")
+	writeFile(t, dir, "authors/openai/logo.svg", "<svg/>")
+
+	good := catalogs.Model{ID: "gpt-a", Name: "GPT A", Authors: []catalogs.Author{{ID: "openai", Name: "OpenAI"}}}
+	writeFile(t, dir, "providers/openai/models/gpt-a.yaml", canonical(t, good))
+	writeFile(t, dir, "providers/openai/models/gpt-b.yaml", "id: gpt-b\nname: GPT B\ncontext: 128000\n")
+	writeFile(t, dir, "providers/openai/models/gpt-c.yaml", canonical(t, catalogs.Model{ID: "gpt-z", Name: "GPT Z"}))
+	writeFile(t, dir, "providers/openai/models/gpt-d.yaml",
+		canonical(t, catalogs.Model{ID: "gpt-d", Name: "GPT D", Authors: []catalogs.Author{{ID: "nobody", Name: "Nobody"}}}))
+	writeFile(t, dir, "providers/mistral/models/small.yaml", canonical(t, catalogs.Model{ID: "small", Name: "Small"}))
+	writeFile(t, dir, "providers/openai/gpt-e.yaml", "id: gpt-e\n")
+
+	report, err := Check(dir, Options{})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	want := []struct {
+		rule     Rule
+		severity Severity
+		file     string
+		message  string
+	}{
+		{RuleSorted, SeverityWarning, "providers.yaml", "groq is listed after openai"},
+		{RuleLogo, SeverityWarning, "providers/groq/logo.svg", "provider groq has no logo.svg"},
+		{RuleSchema, SeverityError, "providers/openai/models/gpt-b.yaml", "unknown field \"context\""},
+		{RuleLint, SeverityError, "providers/openai/models/gpt-c.yaml", "must be saved as providers/openai/models/gpt-z.yaml"},
+		{RuleLint, SeverityError, "providers/openai/models/gpt-d.yaml", "unknown author nobody"},
+		{RuleLint, SeverityError, "providers/mistral/models/small.yaml", "provider mistral is not in providers.yaml"},
+		{RuleSchema, SeverityError, "providers/openai/gpt-e.yaml", "model files belong in providers/<id>/models/"},
+	}
+	for _, w := range want {
+		found := false
+		for _, finding := range report.Findings {
+			if finding.Rule == w.rule && finding.Severity == w.severity && finding.File == w.file && strings.Contains(finding.Message, w.message) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing %s %s finding for %s containing %q; findings = %+v", w.severity, w.rule, w.file, w.message, report.Findings)
+		}
+	}
+	for _, finding := range report.Findings {
+		if finding.File == "providers/openai/models/gpt-a.yaml" {
+			t.Errorf("unexpected finding for a valid canonical model: %+v", finding)
+		}
+	}
+	if got := report.Errors(); got != 5 {
+		t.Errorf("Errors() = %d, want 5", got)
+	}
+}
+
+func TestCheckAuthority(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "providers.yaml", "- id: openai\n  name: OpenAI\n")
+	writeFile(t, dir, "authors.yaml", "- id: openai\n  name: OpenAI\n")
+	writeFile(t, dir, "providers/openai/logo.svg", "<svg/>")
+
+	price := func(input float64) *catalogs.ModelPricing {
+		return &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{Input: &catalogs.ModelTokenCost{Per1M: input}}}
+	}
+	base := catalogs.Model{ID: "gpt-a", Name: "GPT A", Description: "Old", Pricing: price(1)}
+	edited := base
+	edited.Description = "New"
+	edited.Pricing = price(2)
+	pinnedBase := catalogs.Model{ID: "gpt-b", Name: "GPT B", Pricing: price(1)}
+	pinnedEdit := pinnedBase
+	pinnedEdit.Pricing = price(2)
+	pinnedEdit.Pins = []string{"pricing"}
+
+	writeFile(t, dir, "providers/openai/models/gpt-a.yaml", canonical(t, edited))
+	writeFile(t, dir, "providers/openai/models/gpt-b.yaml", canonical(t, pinnedEdit))
+	writeFile(t, dir, "providers/openai/models/gpt-c.yaml", canonical(t, catalogs.Model{ID: "gpt-c", Name: "GPT C", Pricing: price(3)}))
+
+	report, err := Check(dir, Options{Changes: []Change{
+		{Path: "providers/openai/models/gpt-a.yaml", Base: []byte(canonical(t, base))},
+		{Path: "providers/openai/models/gpt-b.yaml", Base: []byte(canonical(t, pinnedBase))},
+		{Path: "providers/openai/models/gpt-c.yaml"},
+	}})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	var authority []Finding
+	for _, finding := range report.Findings {
+		if finding.Rule == RuleAuthority {
+			authority = append(authority, finding)
+		}
+	}
+	if len(authority) != 1 {
+		t.Fatalf("authority findings = %+v, want one for the unpinned price edit", authority)
+	}
+	if got := authority[0]; got.File != "providers/openai/models/gpt-a.yaml" || !strings.HasPrefix(got.Message, "pricing.tokens.input.per_1m changed, but providers outranks") {
+		t.Errorf("authority finding = %+v", got)
+	}
+	if report.Errors() != 0 {
+		t.Errorf("Errors() = %d, want 0; findings = %+v", report.Errors(), report.Findings)
+	}
+}
@@ -0,0 +1,41 @@
+package contribute
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// GitChanges lists the files under dir that differ from the git revision
+// base, including uncommitted edits, with their contents at base. dir must
+// be inside a git work tree.
+func GitChanges(ctx context.Context, dir, base string) ([]Change, error) {
+	args := []string{"diff", "--name-only", "--relative", base, "--", "."}
+	output, err := git(ctx, dir, args...)
+	if err != nil {
+		return nil, &errors.ProcessError{Operation: "list catalog changes", Command: "git " + strings.Join(args, " "), Output: output, Err: err}
+	}
+
+	var changes []Change
+	for _, file := range strings.Split(output, "\n") {
+		if file == "" {
+			continue
+		}
+		change := Change{Path: file}
+		// A file missing at base is new; git show fails and Base stays nil.
+		if contents, err := git(ctx, dir, "show", base+":./"+file); err == nil {
+			change.Base = []byte(contents)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...) //nolint:gosec // Fixed git subcommands; the revision is passed as an argument.
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	return string(output), err
+}
@@ -0,0 +1,139 @@
+// Package coverage reports, for each model author, which of the author's
+// known models each provider that serves the author does not list. Gaps
+// usually point at provider catalog entries that went stale when the author
+// released new models.
+package coverage
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/agentstation/starmap/internal/attribution"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Gap is the coverage of one author's known models by one provider.
+type Gap struct {
+	Author   catalogs.AuthorID   `json:"author" yaml:"author"`
+	Provider catalogs.ProviderID `json:"provider" yaml:"provider"`
+	Known    int                 `json:"known" yaml:"known"`     // Models known for the author
+	Listed   int                 `json:"listed" yaml:"listed"`   // Known models the provider lists
+	Missing  []string            `json:"missing" yaml:"missing"` // Known models the provider does not list
+}
+
+// Coverage returns the fraction of the author's known models the provider
+// lists, from 0 to 1.
+func (g Gap) Coverage() float64 {
+	if g.Known == 0 {
+		return 1
+	}
+	return float64(g.Listed) / float64(g.Known)
+}
+
+// Report is the coverage of every author by every provider serving it.
+type Report struct {
+	Gaps []Gap `json:"gaps" yaml:"gaps"`
+}
+
+// Build reports the coverage of each author in catalog. An author's known
+// models are its models in catalog plus the models of reference, such as the
+// models.dev catalog, that the author's attribution rules match. A provider
+// serves an author when it lists at least one of them. Model IDs are matched
+// ignoring case and any organization prefix, so "meta-llama/Llama-3.3-70B"
+// matches "llama-3.3-70b". A nil reference uses catalog alone.
+func Build(catalog, reference catalogs.Reader) (*Report, error) {
+	if catalog == nil {
+		return nil, &errors.ValidationError{Field: "catalog", Message: "is required"}
+	}
+	known, err := knownModels(catalog, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	for _, provider := range catalog.Providers().List() {
+		listed := make(map[string]bool, len(provider.Models))
+		for id := range provider.Models {
+			listed[ModelKey(id)] = true
+		}
+		for authorID, models := range known {
+			gap := Gap{Author: authorID, Provider: provider.ID, Known: len(models)}
+			for key, id := range models {
+				if listed[key] {
+					gap.Listed++
+				} else {
+					gap.Missing = append(gap.Missing, id)
+				}
+			}
+			if gap.Listed == 0 {
+				continue
+			}
+			slices.Sort(gap.Missing)
+			report.Gaps = append(report.Gaps, gap)
+		}
+	}
+	slices.SortFunc(report.Gaps, func(a, b Gap) int {
+		return cmp.Or(cmp.Compare(a.Author, b.Author), cmp.Compare(a.Provider, b.Provider))
+	})
+	return report, nil
+}
+
+// knownModels returns each author's known model IDs keyed by ModelKey.
+func knownModels(catalog, reference catalogs.Reader) (map[catalogs.AuthorID]map[string]string, error) {
+	known := map[catalogs.AuthorID]map[string]string{}
+	add := func(authorID catalogs.AuthorID, modelID string) {
+		if known[authorID] == nil {
+			known[authorID] = map[string]string{}
+		}
+		key := ModelKey(modelID)
+		if _, ok := known[authorID][key]; !ok {
+			known[authorID][key] = modelID
+		}
+	}
+
+	for _, author := range catalog.Authors().List() {
+		for id := range author.Models {
+			add(author.ID, id)
+		}
+	}
+	if reference == nil {
+		return known, nil
+	}
+
+	// Attribute the reference models with the catalog's author rules, since
+	// sources such as models.dev do not name model authors.
+	attributed := catalogs.NewEmpty()
+	for _, author := range catalog.Authors().List() {
+		author.Models = nil
+		if err := attributed.SetAuthor(author); err != nil {
+			return nil, errors.WrapResource("set", "author", string(author.ID), err)
+		}
+	}
+	for _, provider := range reference.Providers().List() {
+		if err := attributed.SetProvider(provider); err != nil {
+			return nil, errors.WrapResource("set", "provider", string(provider.ID), err)
+		}
+	}
+	if err := attribution.Apply(attributed); err != nil {
+		return nil, errors.WrapResource("attribute", "reference models", "", err)
+	}
+	for _, author := range attributed.Authors().List() {
+		for id := range author.Models {
+			add(author.ID, id)
+		}
+	}
+	return known, nil
+}
+
+// ModelKey returns the ID compared across providers: lowercase, without an
+// organization prefix. Reports that relate a model's listings at several
+// providers share it so they agree on which listings are the same model.
+func ModelKey(id string) string {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		id = id[i+1:]
+	}
+	return id
+}
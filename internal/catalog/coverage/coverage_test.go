@@ -0,0 +1,76 @@
+package coverage
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func testCatalog(t *testing.T, authors []catalogs.Author, models map[catalogs.ProviderID][]string) *catalogs.Builder {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	for _, author := range authors {
+		if err := builder.SetAuthor(author); err != nil {
+			t.Fatalf("SetAuthor: %v", err)
+		}
+	}
+	for providerID, ids := range models {
+		provider := catalogs.Provider{ID: providerID, Name: string(providerID), Models: map[string]*catalogs.Model{}}
+		for _, id := range ids {
+			provider.Models[id] = &catalogs.Model{ID: id, Name: id}
+		}
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider: %v", err)
+		}
+	}
+	return builder
+}
+
+func TestBuild(t *testing.T) {
+	meta := catalogs.Author{
+		ID:   "meta",
+		Name: "Meta",
+		Catalog: &catalogs.AuthorCatalog{Attribution: &catalogs.AuthorAttribution{
+			Patterns: []string{"*llama*"},
+		}},
+		Models: map[string]*catalogs.Model{
+			"llama-3.3-70b": {ID: "llama-3.3-70b"},
+			"llama-4-scout": {ID: "llama-4-scout"},
+		},
+	}
+	catalog := testCatalog(t, []catalogs.Author{meta}, map[catalogs.ProviderID][]string{
+		"groq":      {"llama-3.3-70b", "whisper-large-v3"},
+		"deepinfra": {"meta-llama/Llama-3.3-70B", "meta-llama/llama-4-scout"},
+		"openai":    {"gpt-4o"},
+	})
+	reference := testCatalog(t, nil, map[catalogs.ProviderID][]string{
+		"llama": {"llama-4-maverick", "llama-3.3-70b"},
+	})
+
+	report, err := Build(catalog, reference)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(report.Gaps) != 2 {
+		t.Fatalf("gaps = %+v, want deepinfra and groq for meta", report.Gaps)
+	}
+
+	deepinfra, groq := report.Gaps[0], report.Gaps[1]
+	if deepinfra.Provider != "deepinfra" || deepinfra.Known != 3 || deepinfra.Listed != 2 ||
+		!slices.Equal(deepinfra.Missing, []string{"llama-4-maverick"}) {
+		t.Fatalf("deepinfra gap = %+v, want prefixed IDs matched and maverick missing", deepinfra)
+	}
+	if groq.Provider != "groq" || groq.Listed != 1 || !slices.Equal(groq.Missing, []string{"llama-4-maverick", "llama-4-scout"}) {
+		t.Fatalf("groq gap = %+v, want scout and maverick missing", groq)
+	}
+	if got := groq.Coverage(); got < 0.33 || got > 0.34 {
+		t.Fatalf("groq coverage = %v, want 1/3", got)
+	}
+}
+
+func TestBuildRequiresCatalog(t *testing.T) {
+	if _, err := Build(nil, nil); err == nil {
+		t.Fatal("Build accepted a nil catalog")
+	}
+}
@@ -0,0 +1,281 @@
+// Package export flattens the catalog into one record per provider offering
+// for loading into data warehouses and infrastructure pipelines. Records have
+// a fixed field order and a versioned schema documented in docs/EXPORT.md,
+// so the JSON, NDJSON, and CSV encodings are stable across releases.
+package export
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// SchemaVersion is the version of the record schema. It changes only when a
+// field is removed, renamed, or changes type; new fields are appended.
+const SchemaVersion = 1
+
+// Format is an export encoding.
+type Format string
+
+// Supported export formats.
+const (
+	FormatJSON   Format = "json"   // A single JSON array of records
+	FormatNDJSON Format = "ndjson" // One JSON record per line
+	FormatCSV    Format = "csv"    // A header row followed by one row per record
+)
+
+// Formats lists the supported export formats.
+var Formats = []Format{FormatJSON, FormatNDJSON, FormatCSV}
+
+// listSeparator joins list fields in CSV cells.
+const listSeparator = ";"
+
+// Record is one model as offered by one provider. Fields keep their
+// declaration order in every format; optional values are null in JSON and
+// empty in CSV.
+type Record struct {
+	SchemaVersion        int      `json:"schema_version"`
+	ProviderID           string   `json:"provider_id"`
+	ProviderName         string   `json:"provider_name"`
+	ModelID              string   `json:"model_id"`
+	ModelName            string   `json:"model_name"`
+	Authors              []string `json:"authors"`
+	Family               string   `json:"family"`
+	Status               string   `json:"status"`
+	ReleaseDate          string   `json:"release_date"`
+	KnowledgeCutoff      string   `json:"knowledge_cutoff"`
+	OpenWeights          bool     `json:"open_weights"`
+	InputModalities      []string `json:"input_modalities"`
+	OutputModalities     []string `json:"output_modalities"`
+	ToolCalls            bool     `json:"tool_calls"`
+	Reasoning            bool     `json:"reasoning"`
+	ContextWindow        *int64   `json:"context_window"`
+	MaxInputTokens       *int64   `json:"max_input_tokens"`
+	MaxOutputTokens      *int64   `json:"max_output_tokens"`
+	Currency             string   `json:"currency"`
+	InputPricePer1M      *float64 `json:"input_price_per_1m"`
+	OutputPricePer1M     *float64 `json:"output_price_per_1m"`
+	CacheReadPricePer1M  *float64 `json:"cache_read_price_per_1m"`
+	CacheWritePricePer1M *float64 `json:"cache_write_price_per_1m"`
+	ReasoningPricePer1M  *float64 `json:"reasoning_price_per_1m"`
+}
+
+// Columns are the record field names in output order.
+var Columns = []string{
+	"schema_version",
+	"provider_id",
+	"provider_name",
+	"model_id",
+	"model_name",
+	"authors",
+	"family",
+	"status",
+	"release_date",
+	"knowledge_cutoff",
+	"open_weights",
+	"input_modalities",
+	"output_modalities",
+	"tool_calls",
+	"reasoning",
+	"context_window",
+	"max_input_tokens",
+	"max_output_tokens",
+	"currency",
+	"input_price_per_1m",
+	"output_price_per_1m",
+	"cache_read_price_per_1m",
+	"cache_write_price_per_1m",
+	"reasoning_price_per_1m",
+}
+
+// Records flattens the offerings of cat, sorted by provider and model ID.
+// A non-empty provider limits the records to that provider.
+func Records(cat catalogs.Reader, provider catalogs.ProviderID) []Record {
+	var records []Record
+	for _, p := range cat.Providers().List() {
+		if provider != "" && p.ID != provider {
+			continue
+		}
+		for _, model := range p.Models {
+			if model != nil {
+				records = append(records, NewRecord(p, *model))
+			}
+		}
+	}
+	slices.SortFunc(records, func(a, b Record) int {
+		return cmp.Or(cmp.Compare(a.ProviderID, b.ProviderID), cmp.Compare(a.ModelID, b.ModelID))
+	})
+	return records
+}
+
+// NewRecord flattens one provider offering of a model.
+func NewRecord(provider catalogs.Provider, model catalogs.Model) Record {
+	record := Record{
+		SchemaVersion:    SchemaVersion,
+		ProviderID:       provider.ID.String(),
+		ProviderName:     provider.Name,
+		ModelID:          model.ID,
+		ModelName:        model.Name,
+		Authors:          []string{},
+		Status:           string(model.Status),
+		InputModalities:  []string{},
+		OutputModalities: []string{},
+	}
+	for _, author := range model.Authors {
+		record.Authors = append(record.Authors, author.ID.String())
+	}
+	if model.Lineage != nil {
+		record.Family = model.Lineage.Family
+	}
+	if model.Metadata != nil {
+		if !model.Metadata.ReleaseDate.IsZero() {
+			record.ReleaseDate = model.Metadata.ReleaseDate.Format("2006-01-02")
+		}
+		if model.Metadata.KnowledgeCutoff != nil && !model.Metadata.KnowledgeCutoff.IsZero() {
+			record.KnowledgeCutoff = model.Metadata.KnowledgeCutoff.Format("2006-01-02")
+		}
+		record.OpenWeights = model.Metadata.OpenWeights
+	}
+	if model.Features != nil {
+		for _, modality := range model.Features.Modalities.Input {
+			record.InputModalities = append(record.InputModalities, modality.String())
+		}
+		for _, modality := range model.Features.Modalities.Output {
+			record.OutputModalities = append(record.OutputModalities, modality.String())
+		}
+		record.ToolCalls = model.Features.ToolCalls
+		record.Reasoning = model.Features.Reasoning
+	}
+	if model.Limits != nil {
+		record.ContextWindow = positive(model.Limits.ContextWindow)
+		record.MaxInputTokens = positive(model.Limits.InputTokens)
+		record.MaxOutputTokens = positive(model.Limits.OutputTokens)
+	}
+	if model.Pricing != nil {
+		record.Currency = string(model.Pricing.Currency)
+		if tokens := model.Pricing.Tokens; tokens != nil {
+			record.InputPricePer1M = per1M(tokens.Input)
+			record.OutputPricePer1M = per1M(tokens.Output)
+			record.ReasoningPricePer1M = per1M(tokens.Reasoning)
+			record.CacheReadPricePer1M = per1M(tokens.CacheRead)
+			record.CacheWritePricePer1M = per1M(tokens.CacheWrite)
+			if tokens.Cache != nil {
+				record.CacheReadPricePer1M = cmp.Or(per1M(tokens.Cache.Read), record.CacheReadPricePer1M)
+				record.CacheWritePricePer1M = cmp.Or(per1M(tokens.Cache.Write), record.CacheWritePricePer1M)
+			}
+		}
+	}
+	return record
+}
+
+// Write encodes records to w in format.
+func Write(w io.Writer, format Format, records []Record) error {
+	if records == nil {
+		records = []Record{}
+	}
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatCSV:
+		return writeCSV(w, records)
+	default:
+		return &errors.ValidationError{
+			Field:   "format",
+			Value:   format,
+			Message: "unsupported export format (use json, ndjson, or csv)",
+		}
+	}
+}
+
+func writeCSV(w io.Writer, records []Record) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(Columns); err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := writer.Write(record.row()); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// row returns the record's CSV cells in Columns order.
+func (r Record) row() []string {
+	return []string{
+		strconv.Itoa(r.SchemaVersion),
+		r.ProviderID,
+		r.ProviderName,
+		r.ModelID,
+		r.ModelName,
+		strings.Join(r.Authors, listSeparator),
+		r.Family,
+		r.Status,
+		r.ReleaseDate,
+		r.KnowledgeCutoff,
+		strconv.FormatBool(r.OpenWeights),
+		strings.Join(r.InputModalities, listSeparator),
+		strings.Join(r.OutputModalities, listSeparator),
+		strconv.FormatBool(r.ToolCalls),
+		strconv.FormatBool(r.Reasoning),
+		formatInt(r.ContextWindow),
+		formatInt(r.MaxInputTokens),
+		formatInt(r.MaxOutputTokens),
+		r.Currency,
+		formatFloat(r.InputPricePer1M),
+		formatFloat(r.OutputPricePer1M),
+		formatFloat(r.CacheReadPricePer1M),
+		formatFloat(r.CacheWritePricePer1M),
+		formatFloat(r.ReasoningPricePer1M),
+	}
+}
+
+func positive(v int64) *int64 {
+	if v <= 0 {
+		return nil
+	}
+	return &v
+}
+
+func per1M(cost *catalogs.ModelTokenCost) *float64 {
+	if cost == nil {
+		return nil
+	}
+	price := cost.Per1M
+	if price == 0 && cost.PerToken != 0 {
+		price = cost.PerToken * 1_000_000
+	}
+	return &price
+}
+
+func formatInt(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+func formatFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
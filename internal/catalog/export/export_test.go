@@ -0,0 +1,131 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func testCatalog(t *testing.T) catalogs.Reader {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	if err := builder.SetProvider(catalogs.Provider{
+		ID:   "openai",
+		Name: "OpenAI",
+		Models: map[string]*catalogs.Model{
+			"gpt-b": {ID: "gpt-b", Name: "GPT B"},
+			"gpt-a": {
+				ID:       "gpt-a",
+				Name:     "GPT A",
+				Authors:  []catalogs.Author{{ID: "openai"}},
+				Lineage:  &catalogs.ModelLineage{Family: "gpt"},
+				Features: &catalogs.ModelFeatures{Modalities: catalogs.ModelModalities{Input: []catalogs.ModelModality{"text", "image"}, Output: []catalogs.ModelModality{"text"}}, ToolCalls: true},
+				Limits:   &catalogs.ModelLimits{ContextWindow: 128000, OutputTokens: 16384},
+				Pricing: &catalogs.ModelPricing{Currency: "USD", Tokens: &catalogs.ModelTokenPricing{
+					Input:  &catalogs.ModelTokenCost{Per1M: 2.5},
+					Output: &catalogs.ModelTokenCost{Per1M: 10},
+					Cache:  &catalogs.ModelTokenCachePricing{Read: &catalogs.ModelTokenCost{Per1M: 1.25}},
+				}},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	if err := builder.SetProvider(catalogs.Provider{
+		ID:     "anthropic",
+		Name:   "Anthropic",
+		Models: map[string]*catalogs.Model{"claude": {ID: "claude", Name: "Claude"}},
+	}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	return builder
+}
+
+func TestRecordsAreSortedAndFiltered(t *testing.T) {
+	cat := testCatalog(t)
+
+	var keys []string
+	for _, record := range Records(cat, "") {
+		keys = append(keys, record.ProviderID+"/"+record.ModelID)
+	}
+	if want := []string{"anthropic/claude", "openai/gpt-a", "openai/gpt-b"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("records = %v, want %v", keys, want)
+	}
+	if got := Records(cat, "anthropic"); len(got) != 1 || got[0].ModelID != "claude" {
+		t.Errorf("filtered records = %+v, want only claude", got)
+	}
+}
+
+func TestColumnsMatchJSONFieldOrder(t *testing.T) {
+	fields := reflect.TypeFor[Record]()
+	if fields.NumField() != len(Columns) {
+		t.Fatalf("Record has %d fields, Columns has %d", fields.NumField(), len(Columns))
+	}
+	for i, column := range Columns {
+		if tag := fields.Field(i).Tag.Get("json"); tag != column {
+			t.Errorf("field %d json tag = %q, column = %q", i, tag, column)
+		}
+	}
+}
+
+func TestWriteFormats(t *testing.T) {
+	records := Records(testCatalog(t), "openai")
+
+	var out bytes.Buffer
+	if err := Write(&out, FormatJSON, records); err != nil {
+		t.Fatalf("Write json: %v", err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(decoded) != 2 || decoded[0]["input_price_per_1m"] != 2.5 || decoded[1]["input_price_per_1m"] != nil {
+		t.Errorf("json records = %v", decoded)
+	}
+
+	out.Reset()
+	if err := Write(&out, FormatNDJSON, records); err != nil {
+		t.Fatalf("Write ndjson: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], `{"schema_version":1,"provider_id":"openai","provider_name":"OpenAI","model_id":"gpt-a"`) {
+		t.Errorf("ndjson = %q", out.String())
+	}
+
+	out.Reset()
+	if err := Write(&out, FormatCSV, records); err != nil {
+		t.Fatalf("Write csv: %v", err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if len(rows) != 3 || !reflect.DeepEqual(rows[0], Columns) {
+		t.Fatalf("csv rows = %v", rows)
+	}
+	row := map[string]string{}
+	for i, column := range Columns {
+		row[column] = rows[1][i]
+	}
+	want := map[string]string{
+		"input_modalities":        "text;image",
+		"context_window":          "128000",
+		"max_input_tokens":        "",
+		"cache_read_price_per_1m": "1.25",
+		"tool_calls":              "true",
+	}
+	for column, value := range want {
+		if row[column] != value {
+			t.Errorf("csv %s = %q, want %q", column, row[column], value)
+		}
+	}
+
+	if err := Write(&out, "xml", records); err == nil {
+		t.Error("Write xml: expected an error")
+	}
+}
@@ -0,0 +1,189 @@
+// Package lint runs a suite of rules over a catalog and reports the issues
+// each finds. Every rule has a fixed severity; callers filter issues by
+// severity or rule and decide which severities fail.
+package lint
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Severity ranks how serious an issue is.
+type Severity string
+
+// Issue severities, from most to least serious.
+const (
+	SeverityError   Severity = "error"   // The catalog is wrong and consumers will misbehave
+	SeverityWarning Severity = "warning" // Data is missing or suspicious
+	SeverityInfo    Severity = "info"    // Worth knowing, usually intended
+)
+
+// rank orders severities; higher is more serious.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast reports whether s is as serious as other.
+func (s Severity) AtLeast(other Severity) bool {
+	return s.rank() >= other.rank()
+}
+
+// ParseSeverity parses a severity name.
+func ParseSeverity(value string) (Severity, error) {
+	severity := Severity(strings.ToLower(strings.TrimSpace(value)))
+	if severity.rank() == 0 {
+		return "", &errors.ValidationError{Field: "severity", Value: value, Message: "must be error, warning, or info"}
+	}
+	return severity, nil
+}
+
+// Issue is one problem a rule found.
+type Issue struct {
+	Rule     string   `json:"rule" yaml:"rule"`
+	Severity Severity `json:"severity" yaml:"severity"`
+	Resource string   `json:"resource" yaml:"resource"` // e.g. provider/openai, model/openai/gpt-4o, author/meta
+	Message  string   `json:"message" yaml:"message"`
+}
+
+// Rule is one lint check.
+type Rule struct {
+	ID          string   `json:"id" yaml:"id"`
+	Severity    Severity `json:"severity" yaml:"severity"`
+	Description string   `json:"description" yaml:"description"`
+
+	// check returns the resource and message of each issue.
+	check func(catalogs.Reader, func(resource, format string, args ...any))
+}
+
+// Rules returns the rule suite in ID order.
+func Rules() []Rule {
+	rules := []Rule{
+		{
+			ID:          "missing-pricing",
+			Severity:    SeverityWarning,
+			Description: "Provider model has no token, operation, or compute prices",
+			check:       checkMissingPricing,
+		},
+		{
+			ID:          "zero-context-window",
+			Severity:    SeverityWarning,
+			Description: "Provider model has no context window",
+			check:       checkZeroContextWindow,
+		},
+		{
+			ID:          "dangling-author",
+			Severity:    SeverityError,
+			Description: "Model references an author that is not in the catalog",
+			check:       checkDanglingAuthors,
+		},
+		{
+			ID:          "duplicate-model-id",
+			Severity:    SeverityInfo,
+			Description: "Model ID is offered by more than one provider",
+			check:       checkDuplicateModelIDs,
+		},
+		{
+			ID:          "malformed-url",
+			Severity:    SeverityError,
+			Description: "Provider or author URL is not an absolute http(s) URL",
+			check:       checkURLs,
+		},
+	}
+	slices.SortFunc(rules, func(a, b Rule) int { return cmp.Compare(a.ID, b.ID) })
+	return rules
+}
+
+// Options selects which issues Run reports.
+type Options struct {
+	Rules       []string // Only run these rules; all rules when empty
+	MinSeverity Severity // Only report issues at least this serious; all when empty
+}
+
+// Report is the result of a lint run.
+type Report struct {
+	Rules  []string `json:"rules" yaml:"rules"`
+	Issues []Issue  `json:"issues" yaml:"issues"`
+}
+
+// Count returns the number of issues at least as serious as severity.
+func (r *Report) Count(severity Severity) int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity.AtLeast(severity) {
+			count++
+		}
+	}
+	return count
+}
+
+// Run runs the selected rules over catalog. Issues are sorted by severity,
+// most serious first, then by rule and resource.
+func Run(catalog catalogs.Reader, opts Options) (*Report, error) {
+	rules := Rules()
+	if len(opts.Rules) > 0 {
+		selected := make([]Rule, 0, len(opts.Rules))
+		for _, id := range opts.Rules {
+			index := slices.IndexFunc(rules, func(rule Rule) bool { return rule.ID == id })
+			if index < 0 {
+				return nil, &errors.ValidationError{Field: "rule", Value: id, Message: fmt.Sprintf("unknown rule %q; see starmap validate --list-rules", id)}
+			}
+			selected = append(selected, rules[index])
+		}
+		rules = selected
+	}
+
+	report := &Report{}
+	for _, rule := range rules {
+		report.Rules = append(report.Rules, rule.ID)
+		if opts.MinSeverity != "" && !rule.Severity.AtLeast(opts.MinSeverity) {
+			continue
+		}
+		rule.check(catalog, func(resource, format string, args ...any) {
+			report.Issues = append(report.Issues, Issue{
+				Rule:     rule.ID,
+				Severity: rule.Severity,
+				Resource: resource,
+				Message:  fmt.Sprintf(format, args...),
+			})
+		})
+	}
+	slices.SortStableFunc(report.Issues, func(a, b Issue) int {
+		return cmp.Or(
+			cmp.Compare(b.Severity.rank(), a.Severity.rank()),
+			cmp.Compare(a.Rule, b.Rule),
+			cmp.Compare(a.Resource, b.Resource),
+		)
+	})
+	return report, nil
+}
+
+func modelResource(provider catalogs.ProviderID, model string) string {
+	return "model/" + string(provider) + "/" + model
+}
+
+// providerModels calls fn for each provider model in provider and model ID
+// order, so rules report deterministically.
+func providerModels(catalog catalogs.Reader, fn func(catalogs.ProviderID, *catalogs.Model)) {
+	for _, provider := range catalog.Providers().List() {
+		for _, id := range slices.Sorted(maps.Keys(provider.Models)) {
+			if model := provider.Models[id]; model != nil {
+				fn(provider.ID, model)
+			}
+		}
+	}
+}
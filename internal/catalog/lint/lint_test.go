@@ -0,0 +1,126 @@
+package lint
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+func testCatalog(t *testing.T) catalogs.Reader {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	if err := builder.SetAuthor(catalogs.Author{ID: "openai", Name: "OpenAI", Website: stringPtr("openai.com")}); err != nil {
+		t.Fatalf("SetAuthor: %v", err)
+	}
+
+	priced := &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{Input: &catalogs.ModelTokenCost{Per1M: 1}}}
+	complete := func(id string) *catalogs.Model {
+		return &catalogs.Model{
+			ID: id, Name: id,
+			Authors: []catalogs.Author{{ID: "openai", Name: "OpenAI"}},
+			Pricing: priced,
+			Limits:  &catalogs.ModelLimits{ContextWindow: 128_000},
+		}
+	}
+	unpriced := complete("gpt-unpriced")
+	unpriced.Pricing = nil
+	unbounded := complete("gpt-unbounded")
+	unbounded.Limits = &catalogs.ModelLimits{OutputTokens: 4096}
+	orphan := complete("gpt-orphan")
+	orphan.Authors = []catalogs.Author{{ID: "nobody", Name: "Nobody"}}
+
+	for _, provider := range []catalogs.Provider{
+		{
+			ID: "openai", Name: "OpenAI",
+			StatusPageURL: stringPtr("https://status.openai.com"),
+			Catalog: &catalogs.ProviderCatalog{Endpoint: catalogs.ProviderEndpoint{
+				URL: "https://{region}.api.openai.com/v1/models",
+			}},
+			Models: map[string]*catalogs.Model{
+				"gpt-a": complete("gpt-a"), "gpt-unpriced": unpriced, "gpt-unbounded": unbounded, "gpt-orphan": orphan,
+			},
+		},
+		{
+			ID: "azure", Name: "Azure",
+			IconURL: stringPtr("ftp://example.com/icon.svg"),
+			Models:  map[string]*catalogs.Model{"gpt-a": complete("gpt-a")},
+		},
+	} {
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider: %v", err)
+		}
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return catalog
+}
+
+func TestRun(t *testing.T) {
+	report, err := Run(testCatalog(t), Options{})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := []Issue{
+		{Rule: "dangling-author", Severity: SeverityError, Resource: "model/openai/gpt-orphan"},
+		{Rule: "malformed-url", Severity: SeverityError, Resource: "author/openai"},
+		{Rule: "malformed-url", Severity: SeverityError, Resource: "provider/azure"},
+		{Rule: "missing-pricing", Severity: SeverityWarning, Resource: "model/openai/gpt-unpriced"},
+		{Rule: "zero-context-window", Severity: SeverityWarning, Resource: "model/openai/gpt-unbounded"},
+		{Rule: "duplicate-model-id", Severity: SeverityInfo, Resource: "model/gpt-a"},
+	}
+	if len(report.Issues) != len(want) {
+		t.Fatalf("issues = %+v, want %d", report.Issues, len(want))
+	}
+	for i, issue := range report.Issues {
+		if issue.Rule != want[i].Rule || issue.Severity != want[i].Severity || issue.Resource != want[i].Resource {
+			t.Errorf("issue %d = %+v, want %s %s on %s", i, issue, want[i].Severity, want[i].Rule, want[i].Resource)
+		}
+	}
+	if got := report.Count(SeverityWarning); got != 5 {
+		t.Errorf("Count(warning) = %d, want 5", got)
+	}
+}
+
+func TestRunOptions(t *testing.T) {
+	catalog := testCatalog(t)
+
+	report, err := Run(catalog, Options{MinSeverity: SeverityError})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(report.Issues) != 3 || report.Count(SeverityError) != 3 {
+		t.Errorf("issues at error = %+v, want the three errors", report.Issues)
+	}
+
+	report, err = Run(catalog, Options{Rules: []string{"missing-pricing"}})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(report.Rules) != 1 || len(report.Issues) != 1 || report.Issues[0].Rule != "missing-pricing" {
+		t.Errorf("report = %+v, want only missing-pricing", report)
+	}
+
+	_, err = Run(catalog, Options{Rules: []string{"nope"}})
+	var validation *errors.ValidationError
+	if !stderrors.As(err, &validation) {
+		t.Errorf("unknown rule error = %v, want a validation error", err)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	if got, err := ParseSeverity(" Warning "); err != nil || got != SeverityWarning {
+		t.Errorf("ParseSeverity(Warning) = %q, %v", got, err)
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Error("ParseSeverity(fatal) returned no error")
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,122 @@
+package lint
+
+import (
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func checkMissingPricing(catalog catalogs.Reader, report func(resource, format string, args ...any)) {
+	providerModels(catalog, func(provider catalogs.ProviderID, model *catalogs.Model) {
+		if pricing := model.Pricing; pricing != nil && (pricing.Tokens != nil || pricing.Operations != nil || pricing.Compute != nil) {
+			return
+		}
+		report(modelResource(provider, model.ID), "no token, operation, or compute prices")
+	})
+}
+
+func checkZeroContextWindow(catalog catalogs.Reader, report func(resource, format string, args ...any)) {
+	providerModels(catalog, func(provider catalogs.ProviderID, model *catalogs.Model) {
+		if model.Limits != nil && model.Limits.ContextWindow > 0 {
+			return
+		}
+		report(modelResource(provider, model.ID), "context window is unset or zero")
+	})
+}
+
+func checkDanglingAuthors(catalog catalogs.Reader, report func(resource, format string, args ...any)) {
+	providerModels(catalog, func(provider catalogs.ProviderID, model *catalogs.Model) {
+		for _, author := range model.Authors {
+			if _, found := catalog.Authors().Resolve(author.ID); !found {
+				report(modelResource(provider, model.ID), "author %s is not in the catalog", author.ID)
+			}
+		}
+	})
+}
+
+func checkDuplicateModelIDs(catalog catalogs.Reader, report func(resource, format string, args ...any)) {
+	offeredBy := make(map[string][]string)
+	providerModels(catalog, func(provider catalogs.ProviderID, model *catalogs.Model) {
+		offeredBy[model.ID] = append(offeredBy[model.ID], string(provider))
+	})
+	for _, id := range slices.Sorted(maps.Keys(offeredBy)) {
+		if providers := offeredBy[id]; len(providers) > 1 {
+			report("model/"+id, "offered by %d providers: %s; lookups by ID alone need a provider", len(providers), strings.Join(providers, ", "))
+		}
+	}
+}
+
+// placeholder matches template segments such as {region} that endpoint
+// URLs fill in at request time.
+var placeholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+func checkURLs(catalog catalogs.Reader, report func(resource, format string, args ...any)) {
+	for _, provider := range catalog.Providers().List() {
+		resource := "provider/" + string(provider.ID)
+		check := func(field string, value *string) {
+			if value != nil {
+				checkURL(report, resource, field, *value)
+			}
+		}
+		check("icon_url", provider.IconURL)
+		check("status_page_url", provider.StatusPageURL)
+		if c := provider.Catalog; c != nil {
+			check("catalog.docs", c.Docs)
+			if c.Endpoint.URL != "" {
+				check("catalog.endpoint.url", &c.Endpoint.URL)
+			}
+		}
+		if cc := provider.ChatCompletions; cc != nil {
+			check("chat_completions.url", cc.URL)
+			check("chat_completions.health_api_url", cc.HealthAPIURL)
+		}
+		if policy := provider.PrivacyPolicy; policy != nil {
+			check("privacy_policy.privacy_policy_url", policy.PrivacyPolicyURL)
+			check("privacy_policy.terms_of_service_url", policy.TermsOfServiceURL)
+		}
+		if sla := provider.SLA; sla != nil {
+			check("sla.url", sla.URL)
+		}
+		if support := provider.Support; support != nil {
+			check("support.url", support.URL)
+			check("support.discord", support.Discord)
+			check("support.forum", support.Forum)
+		}
+	}
+	for _, author := range catalog.Authors().List() {
+		resource := "author/" + string(author.ID)
+		for _, link := range []struct {
+			field string
+			value *string
+		}{
+			{"icon_url", author.IconURL},
+			{"website", author.Website},
+			{"huggingface", author.HuggingFace},
+			{"github", author.GitHub},
+			{"twitter", author.Twitter},
+		} {
+			if link.value != nil {
+				checkURL(report, resource, link.field, *link.value)
+			}
+		}
+	}
+}
+
+func checkURL(report func(resource, format string, args ...any), resource, field, value string) {
+	if value == "" {
+		return
+	}
+	parsed, err := url.Parse(placeholder.ReplaceAllString(value, "x"))
+	switch {
+	case err != nil:
+		report(resource, "%s %q does not parse: %v", field, value, err)
+	case parsed.Scheme != "http" && parsed.Scheme != "https":
+		report(resource, "%s %q is not an http(s) URL", field, value)
+	case parsed.Host == "":
+		report(resource, "%s %q has no host", field, value)
+	}
+}
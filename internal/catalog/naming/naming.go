@@ -0,0 +1,172 @@
+// Package naming reports models whose display names differ across the
+// providers that offer them, such as "Claude 3.5 Sonnet" on one provider and
+// "claude-3-5-sonnet-20241022" on another, and suggests one normalized name
+// for each.
+package naming
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/agentstation/starmap/internal/catalog/coverage"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Offering is one provider's listing of a model.
+type Offering struct {
+	Provider catalogs.ProviderID `json:"provider" yaml:"provider"`
+	Model    string              `json:"model" yaml:"model"`
+}
+
+// Name is one display name used for a model and the offerings that use it.
+type Name struct {
+	Name      string     `json:"name" yaml:"name"`
+	IDLike    bool       `json:"id_like" yaml:"id_like"` // Name is the model ID or formatted like one
+	Offerings []Offering `json:"offerings" yaml:"offerings"`
+}
+
+// Finding is a model whose offerings use more than one display name.
+type Finding struct {
+	Model     string `json:"model" yaml:"model"` // Canonical model ID, lowercase without an organization prefix
+	Names     []Name `json:"names" yaml:"names"`
+	Suggested string `json:"suggested,omitempty" yaml:"suggested,omitempty"` // Empty when every name is ID-like
+}
+
+// Rename is a suggested display name change for one offering.
+type Rename struct {
+	Offering
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+}
+
+// Renames returns the changes that give every offering the suggested name.
+func (f Finding) Renames() []Rename {
+	if f.Suggested == "" {
+		return nil
+	}
+	var renames []Rename
+	for _, name := range f.Names {
+		if name.Name == f.Suggested {
+			continue
+		}
+		for _, offering := range name.Offerings {
+			renames = append(renames, Rename{Offering: offering, From: name.Name, To: f.Suggested})
+		}
+	}
+	return renames
+}
+
+// Report is every model with inconsistent display names.
+type Report struct {
+	Findings []Finding `json:"findings" yaml:"findings"`
+}
+
+// Renames returns the suggested changes of every finding.
+func (r *Report) Renames() []Rename {
+	var renames []Rename
+	for _, finding := range r.Findings {
+		renames = append(renames, finding.Renames()...)
+	}
+	return renames
+}
+
+// Build groups the provider offerings in catalog by canonical model ID,
+// ignoring case and any organization prefix, and reports the models whose
+// offerings use more than one display name.
+//
+// The suggested name is the author's name for the model when it reads as a
+// display name, otherwise the display name most offerings use, preferring the
+// shorter and then the alphabetically first on ties. ID-like names, such as
+// the model ID itself, are never suggested.
+func Build(catalog catalogs.Reader) (*Report, error) {
+	if catalog == nil {
+		return nil, &errors.ValidationError{Field: "catalog", Message: "is required"}
+	}
+
+	authored := map[string]string{}
+	for _, author := range catalog.Authors().List() {
+		for id, model := range author.Models {
+			if model != nil && !idLike(model.Name, id) {
+				authored[coverage.ModelKey(id)] = strings.TrimSpace(model.Name)
+			}
+		}
+	}
+
+	grouped := map[string]map[string]*Name{}
+	for _, provider := range catalog.Providers().List() {
+		for id, model := range provider.Models {
+			if model == nil {
+				continue
+			}
+			key := coverage.ModelKey(id)
+			display := strings.TrimSpace(model.Name)
+			if grouped[key] == nil {
+				grouped[key] = map[string]*Name{}
+			}
+			name := grouped[key][display]
+			if name == nil {
+				name = &Name{Name: display, IDLike: idLike(display, id)}
+				grouped[key][display] = name
+			}
+			name.Offerings = append(name.Offerings, Offering{Provider: provider.ID, Model: id})
+		}
+	}
+
+	report := &Report{}
+	for key, names := range grouped {
+		if len(names) < 2 {
+			continue
+		}
+		finding := Finding{Model: key}
+		for _, name := range names {
+			slices.SortFunc(name.Offerings, func(a, b Offering) int {
+				return cmp.Or(cmp.Compare(a.Provider, b.Provider), cmp.Compare(a.Model, b.Model))
+			})
+			finding.Names = append(finding.Names, *name)
+		}
+		slices.SortFunc(finding.Names, func(a, b Name) int {
+			return cmp.Or(cmp.Compare(len(b.Offerings), len(a.Offerings)), cmp.Compare(a.Name, b.Name))
+		})
+		finding.Suggested = suggest(finding.Names, authored[key])
+		report.Findings = append(report.Findings, finding)
+	}
+	slices.SortFunc(report.Findings, func(a, b Finding) int { return cmp.Compare(a.Model, b.Model) })
+	return report, nil
+}
+
+// suggest returns the normalized display name among names, which are sorted
+// by use.
+func suggest(names []Name, authored string) string {
+	if authored != "" {
+		return authored
+	}
+	var best *Name
+	for i, name := range names {
+		if name.IDLike {
+			continue
+		}
+		if best == nil || len(name.Offerings) == len(best.Offerings) && len(name.Name) < len(best.Name) {
+			best = &names[i]
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return best.Name
+}
+
+// idLike reports whether name is the model ID or written like one: a single
+// lowercase token joined by separators, such as "claude-3-5-sonnet-20241022".
+func idLike(name, id string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, id) || strings.EqualFold(name, coverage.ModelKey(id)) {
+		return true
+	}
+	if strings.ContainsFunc(name, unicode.IsSpace) || strings.ContainsFunc(name, unicode.IsUpper) {
+		return false
+	}
+	return strings.ContainsAny(name, "-_/.:")
+}
@@ -0,0 +1,117 @@
+package naming
+
+import (
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func testCatalog(t *testing.T, authors []catalogs.Author, names map[catalogs.ProviderID]map[string]string) *catalogs.Builder {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	for _, author := range authors {
+		if err := builder.SetAuthor(author); err != nil {
+			t.Fatalf("SetAuthor: %v", err)
+		}
+	}
+	for providerID, models := range names {
+		provider := catalogs.Provider{ID: providerID, Name: string(providerID), Models: map[string]*catalogs.Model{}}
+		for id, name := range models {
+			provider.Models[id] = &catalogs.Model{ID: id, Name: name}
+		}
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider: %v", err)
+		}
+	}
+	return builder
+}
+
+func TestBuild(t *testing.T) {
+	catalog := testCatalog(t, nil, map[catalogs.ProviderID]map[string]string{
+		"anthropic":  {"claude-3-5-sonnet-20241022": "Claude 3.5 Sonnet", "claude-3-haiku-20240307": "Claude Haiku 3"},
+		"vertex":     {"claude-3-5-sonnet-20241022": "Claude 3.5 Sonnet", "claude-3-haiku-20240307": "Claude Haiku 3"},
+		"bedrock":    {"claude-3-5-sonnet-20241022": "claude-3-5-sonnet-20241022"},
+		"openrouter": {"anthropic/claude-3-5-sonnet-20241022": "Anthropic: Claude 3.5 Sonnet"},
+	})
+
+	report, err := Build(catalog)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(report.Findings) != 1 {
+		t.Fatalf("findings = %+v, want only the sonnet model", report.Findings)
+	}
+	finding := report.Findings[0]
+	if finding.Model != "claude-3-5-sonnet-20241022" || len(finding.Names) != 3 {
+		t.Fatalf("finding = %+v, want three names for the sonnet model", finding)
+	}
+	if finding.Suggested != "Claude 3.5 Sonnet" {
+		t.Fatalf("suggested = %q, want the most used display name", finding.Suggested)
+	}
+
+	renames := report.Renames()
+	if len(renames) != 2 {
+		t.Fatalf("renames = %+v, want bedrock and openrouter", renames)
+	}
+	for _, rename := range renames {
+		if rename.To != "Claude 3.5 Sonnet" || rename.Provider == "anthropic" || rename.Provider == "vertex" {
+			t.Errorf("rename = %+v, want only the inconsistent offerings renamed", rename)
+		}
+	}
+}
+
+func TestBuildPrefersAuthorName(t *testing.T) {
+	author := catalogs.Author{
+		ID:     "meta",
+		Name:   "Meta",
+		Models: map[string]*catalogs.Model{"llama-3.3-70b": {ID: "llama-3.3-70b", Name: "Llama 3.3 70B"}},
+	}
+	catalog := testCatalog(t, []catalogs.Author{author}, map[catalogs.ProviderID]map[string]string{
+		"groq":      {"llama-3.3-70b": "LLaMA 3.3 70B Versatile"},
+		"deepinfra": {"meta-llama/Llama-3.3-70B": "meta-llama/Llama-3.3-70B"},
+	})
+
+	report, err := Build(catalog)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Suggested != "Llama 3.3 70B" {
+		t.Fatalf("findings = %+v, want the author's name suggested", report.Findings)
+	}
+}
+
+func TestBuildSuggestsNothingForIDLikeNames(t *testing.T) {
+	catalog := testCatalog(t, nil, map[catalogs.ProviderID]map[string]string{
+		"first":  {"model-a": "model-a"},
+		"second": {"org/model-a": "model_a-latest"},
+	})
+
+	report, err := Build(catalog)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Suggested != "" || len(report.Renames()) != 0 {
+		t.Fatalf("findings = %+v, want a finding without a suggestion", report.Findings)
+	}
+}
+
+func TestIDLike(t *testing.T) {
+	for name, want := range map[string]bool{
+		"claude-3-5-sonnet-20241022": true,
+		"CLAUDE-3-5-SONNET-20241022": true,
+		"gpt-4o-mini":                true,
+		"GPT-4o":                     false,
+		"Claude 3.5 Sonnet":          false,
+		"Mistral":                    false,
+	} {
+		if got := idLike(name, "claude-3-5-sonnet-20241022"); got != want {
+			t.Errorf("idLike(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestBuildRequiresCatalog(t *testing.T) {
+	if _, err := Build(nil); err == nil {
+		t.Fatal("Build accepted a nil catalog")
+	}
+}
@@ -0,0 +1,43 @@
+package pipeline
+
+import (
+	"path/filepath"
+
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/internal/providers/docscrape"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/logging"
+	pkgsync "github.com/agentstation/starmap/pkg/sync"
+)
+
+// docsScraper returns the scraper for provider documentation pages, with the
+// shipped extractor configs and any in the user's extractor directory. It
+// returns nil, and the sync goes on without scraping, when the configs cannot
+// be loaded.
+func docsScraper(options *pkgsync.Options) *docscrape.Scraper {
+	configs, err := docscrape.LoadConfigs(docsExtractorsDir(options))
+	if err != nil {
+		logging.Warn().Err(err).Msg("Failed to load documentation extractor configs")
+		return nil
+	}
+	fetcher := docscrape.NewFetcher(docscrape.WithCacheDir(docsScrapeCacheDir(options), constants.DocsScrapeCacheTTL))
+	return docscrape.New(fetcher, configs)
+}
+
+// docsExtractorsDir returns where user extractor configs are read from,
+// honoring a configured sources directory.
+func docsExtractorsDir(options *pkgsync.Options) string {
+	if options.SourcesDir != "" {
+		return filepath.Join(options.SourcesDir, "docs-extractors")
+	}
+	return paths.ExpandHome(constants.DefaultDocsExtractorsPath)
+}
+
+// docsScrapeCacheDir returns where scraped pages are cached, honoring a
+// configured sources directory.
+func docsScrapeCacheDir(options *pkgsync.Options) string {
+	if options.SourcesDir != "" {
+		return filepath.Join(options.SourcesDir, "docs-cache")
+	}
+	return paths.ExpandHome(constants.DefaultDocsScrapeCachePath)
+}
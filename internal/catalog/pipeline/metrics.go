@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"sync"
+	"time"
+)
+
+// SyncMetrics summarizes the sync runs of a process.
+type SyncMetrics struct {
+	Runs              int           // Sync runs, including failed ones
+	Failures          int           // Failed sync runs
+	Duration          time.Duration // Total time spent in sync runs
+	Merges            int           // Completed reconciliations
+	MergeDuration     time.Duration // Total time spent reconciling
+	ConflictsDetected int           // Fields whose sources provided distinct values
+	LastSuccess       time.Time     // Completion time of the last successful run
+}
+
+// Metrics collects SyncMetrics.
+type Metrics struct {
+	mu   sync.Mutex
+	sync SyncMetrics
+}
+
+// DefaultMetrics collects the metrics of pipelines created by New.
+var DefaultMetrics = NewMetrics()
+
+// NewMetrics returns an empty metrics collector.
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// Snapshot returns the metrics recorded so far.
+func (m *Metrics) Snapshot() SyncMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sync
+}
+
+func (m *Metrics) recordRun(duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sync.Runs++
+	m.sync.Duration += duration
+	if err != nil {
+		m.sync.Failures++
+		return
+	}
+	m.sync.LastSuccess = time.Now()
+}
+
+func (m *Metrics) recordMerge(duration time.Duration, conflicts int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sync.Merges++
+	m.sync.MergeDuration += duration
+	m.sync.ConflictsDetected += conflicts
+}
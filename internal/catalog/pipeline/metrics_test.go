@@ -0,0 +1,41 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/reconciler"
+	pkgsync "github.com/agentstation/starmap/pkg/sync"
+)
+
+func TestPipelineRecordsSyncMetrics(t *testing.T) {
+	store := &pipelineTestStore{catalog: asSnapshot(catalogs.NewEmpty())}
+	runner := newStubPipeline(store, &reconciler.Result{
+		Catalog: catalogs.NewEmpty(),
+		Metadata: reconciler.ResultMetadata{
+			Duration: 40 * time.Millisecond,
+			Stats:    reconciler.ResultStatistics{ConflictsDetected: 3},
+		},
+	})
+	runner.metrics = NewMetrics()
+
+	if _, err := runner.Sync(context.Background(), pkgsync.WithDryRun(true)); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if _, err := runner.Sync(context.Background(), pkgsync.WithProvider("missing-provider")); err == nil {
+		t.Fatal("Expected missing provider validation to fail")
+	}
+
+	got := runner.metrics.Snapshot()
+	if got.Runs != 2 || got.Failures != 1 {
+		t.Errorf("runs = %d, failures = %d, want 2 and 1", got.Runs, got.Failures)
+	}
+	if got.Merges != 1 || got.MergeDuration != 40*time.Millisecond || got.ConflictsDetected != 3 {
+		t.Errorf("merges = %d, merge duration = %s, conflicts = %d, want 1, 40ms, 3", got.Merges, got.MergeDuration, got.ConflictsDetected)
+	}
+	if got.LastSuccess.IsZero() {
+		t.Error("LastSuccess is zero after a successful run")
+	}
+}
@@ -0,0 +1,256 @@
+package pipeline
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/differ"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/logging"
+)
+
+// applyPins restores every pinned field of local's models in catalog and
+// carries the inline pin annotations onto catalog's models so a save keeps
+// them. It returns the incoming changes the pins blocked. Pins on models
+// that either catalog lacks are ignored.
+func applyPins(catalog *catalogs.Builder, local *catalogs.Builder) ([]differ.PinnedChange, error) {
+	var blocked []differ.PinnedChange
+	pins := local.Pins()
+	for start := 0; start < len(pins); {
+		end := start
+		for end < len(pins) && pins[end].Provider == pins[start].Provider && pins[end].Model == pins[start].Model {
+			end++
+		}
+		changes, err := applyModelPins(catalog, local, pins[start:end])
+		if err != nil {
+			return nil, err
+		}
+		blocked = append(blocked, changes...)
+		start = end
+	}
+	if len(blocked) > 0 {
+		logging.Info().Int("fields", len(blocked)).Msg("Pinned fields blocked incoming changes")
+	}
+	return blocked, nil
+}
+
+// applyModelPins applies the pins of one provider model.
+func applyModelPins(catalog, local *catalogs.Builder, pins []catalogs.FieldPin) ([]differ.PinnedChange, error) {
+	providerID, modelID := pins[0].Provider, pins[0].Model
+	pinned, err := local.ProviderModel(providerID, modelID)
+	if err != nil {
+		return nil, nil
+	}
+	model, err := catalog.ProviderModel(providerID, modelID)
+	if err != nil {
+		return nil, nil
+	}
+	pinned = catalogs.DeepCopyModel(pinned)
+
+	var blocked []differ.PinnedChange
+	for _, pin := range pins {
+		path := strings.Split(pin.Field, ".")
+		if err := checkPinPath(reflect.TypeOf(pinned), path); err != nil {
+			return nil, pinError(pin, err)
+		}
+		pinnedValue, pinnedSet, err := pinField(reflect.ValueOf(pinned), path)
+		if err != nil {
+			return nil, pinError(pin, err)
+		}
+		incomingValue, incomingSet, err := pinField(reflect.ValueOf(model), path)
+		if err != nil {
+			return nil, pinError(pin, err)
+		}
+		if pinValuesEqual(pinnedValue, pinnedSet, incomingValue, incomingSet) {
+			continue
+		}
+		change := differ.PinnedChange{
+			ProviderID:    providerID,
+			ModelID:       modelID,
+			Path:          pin.Field,
+			PinnedValue:   formatPinValue(pinnedValue, pinnedSet),
+			IncomingValue: formatPinValue(incomingValue, incomingSet),
+		}
+		if err := copyPinField(reflect.ValueOf(&model).Elem(), reflect.ValueOf(pinned), path); err != nil {
+			return nil, pinError(pin, err)
+		}
+		blocked = append(blocked, change)
+	}
+
+	if len(blocked) == 0 && reflect.DeepEqual(model.Pins, pinned.Pins) {
+		return nil, nil
+	}
+	model.Pins = pinned.Pins
+	if err := catalog.SetProviderModel(providerID, model); err != nil {
+		return nil, pkgerrors.WrapResource("pin", "model", modelID, err)
+	}
+	return blocked, nil
+}
+
+func pinError(pin catalogs.FieldPin, err error) error {
+	return &pkgerrors.ValidationError{
+		Field:   "pin",
+		Value:   fmt.Sprintf("%s/%s %s", pin.Provider, pin.Model, pin.Field),
+		Message: err.Error(),
+	}
+}
+
+// checkPinPath reports whether path names a field of modelType.
+func checkPinPath(modelType reflect.Type, path []string) error {
+	for _, name := range path {
+		for modelType.Kind() == reflect.Pointer {
+			modelType = modelType.Elem()
+		}
+		switch modelType.Kind() {
+		case reflect.Struct:
+			field, ok := yamlFieldIndex(modelType, name)
+			if !ok {
+				return fmt.Errorf("unknown field %q", name)
+			}
+			modelType = modelType.Field(field).Type
+		case reflect.Map:
+			modelType = modelType.Elem()
+		default:
+			return fmt.Errorf("%q has no fields", name)
+		}
+	}
+	return nil
+}
+
+// pinField returns the value at a dotted YAML path and whether it is set.
+// A nil pointer or missing map entry along the path leaves it unset.
+func pinField(value reflect.Value, path []string) (reflect.Value, bool, error) {
+	for _, name := range path {
+		for value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return reflect.Value{}, false, nil
+			}
+			value = value.Elem()
+		}
+		switch value.Kind() {
+		case reflect.Struct:
+			field, ok := yamlFieldIndex(value.Type(), name)
+			if !ok {
+				return reflect.Value{}, false, fmt.Errorf("unknown field %q", name)
+			}
+			value = value.Field(field)
+		case reflect.Map:
+			value = value.MapIndex(reflect.ValueOf(name).Convert(value.Type().Key()))
+			if !value.IsValid() {
+				return reflect.Value{}, false, nil
+			}
+		default:
+			return reflect.Value{}, false, fmt.Errorf("%q has no fields", name)
+		}
+	}
+	return value, true, nil
+}
+
+// copyPinField sets the value at path in dst to the value at the same path
+// in src, allocating pointers and maps in dst as needed. dst must be
+// addressable.
+func copyPinField(dst, src reflect.Value, path []string) error {
+	if len(path) == 0 {
+		dst.Set(src)
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			src = reflect.New(dst.Type().Elem())
+		}
+		// Copy the pointee so structures shared with other catalogs stay untouched.
+		clone := reflect.New(dst.Type().Elem())
+		if !dst.IsNil() {
+			clone.Elem().Set(dst.Elem())
+		}
+		dst.Set(clone)
+		return copyPinField(dst.Elem(), src.Elem(), path)
+	case reflect.Struct:
+		field, ok := yamlFieldIndex(dst.Type(), path[0])
+		if !ok {
+			return fmt.Errorf("unknown field %q", path[0])
+		}
+		return copyPinField(dst.Field(field), src.Field(field), path[1:])
+	case reflect.Map:
+		key := reflect.ValueOf(path[0]).Convert(dst.Type().Key())
+		srcElem := src.MapIndex(key)
+		if len(path) == 1 {
+			if !srcElem.IsValid() {
+				if !dst.IsNil() {
+					dst.SetMapIndex(key, reflect.Value{})
+				}
+				return nil
+			}
+			if dst.IsNil() {
+				dst.Set(reflect.MakeMap(dst.Type()))
+			}
+			dst.SetMapIndex(key, srcElem)
+			return nil
+		}
+		dstElem := reflect.New(dst.Type().Elem()).Elem()
+		if current := dst.MapIndex(key); current.IsValid() {
+			dstElem.Set(current)
+		}
+		srcCopy := reflect.New(dst.Type().Elem()).Elem()
+		if srcElem.IsValid() {
+			srcCopy.Set(srcElem)
+		}
+		if err := copyPinField(dstElem, srcCopy, path[1:]); err != nil {
+			return err
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(dst.Type()))
+		}
+		dst.SetMapIndex(key, dstElem)
+		return nil
+	default:
+		return fmt.Errorf("%q has no fields", path[0])
+	}
+}
+
+// yamlFieldIndex returns the index of the struct field serialized as name.
+func yamlFieldIndex(structType reflect.Type, name string) (int, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		tag, _, _ := strings.Cut(structType.Field(i).Tag.Get("yaml"), ",")
+		if tag == name && tag != "-" {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// pinValuesEqual treats an unset value and a zero value as equal.
+func pinValuesEqual(a reflect.Value, aSet bool, b reflect.Value, bSet bool) bool {
+	switch {
+	case aSet && bSet:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	case aSet:
+		return a.IsZero()
+	case bSet:
+		return b.IsZero()
+	default:
+		return true
+	}
+}
+
+func formatPinValue(value reflect.Value, set bool) string {
+	if !set || value.IsZero() {
+		return "(unset)"
+	}
+	for value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice:
+		data, err := yaml.MarshalWithOptions(value.Interface(), yaml.Flow(true))
+		if err == nil {
+			return strings.TrimSpace(string(data))
+		}
+	}
+	return fmt.Sprint(value.Interface())
+}
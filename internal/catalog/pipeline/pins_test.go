@@ -0,0 +1,99 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/reconciler"
+	pkgsync "github.com/agentstation/starmap/pkg/sync"
+)
+
+func pinTestCatalog(t *testing.T, model catalogs.Model) *catalogs.Builder {
+	t.Helper()
+	catalog := catalogs.NewEmpty()
+	if err := catalog.SetProvider(catalogs.Provider{
+		ID: "pinned", Name: "Pinned",
+		Models: map[string]*catalogs.Model{model.ID: &model},
+	}); err != nil {
+		t.Fatalf("Seed catalog: %v", err)
+	}
+	return catalog
+}
+
+func pinTestPricing(input float64) *catalogs.ModelPricing {
+	return &catalogs.ModelPricing{
+		Currency: catalogs.ModelPricingCurrencyUSD,
+		Tokens:   &catalogs.ModelTokenPricing{Input: &catalogs.ModelTokenCost{Per1M: input}},
+	}
+}
+
+func TestPipelinePinsBlockIncomingChanges(t *testing.T) {
+	curated := catalogs.Model{
+		ID: "model", Name: "Curated Name", Description: "Curated description",
+		Modes: map[string]catalogs.ModelMode{"fast": {Pricing: pinTestPricing(2)}},
+		Pins:  []string{"modes.fast.pricing.tokens.input", "name"},
+	}
+	local := pinTestCatalog(t, curated)
+	existing := curated
+	existing.Pins = nil
+
+	incoming := curated
+	incoming.Pins = nil
+	incoming.Name = "Upstream Name"
+	incoming.Description = "Upstream description"
+	incoming.Modes = map[string]catalogs.ModelMode{"fast": {Pricing: pinTestPricing(3)}}
+
+	store := &pipelineTestStore{catalog: asSnapshot(pinTestCatalog(t, existing))}
+	runner := newStubPipeline(store, &reconciler.Result{
+		Catalog: pinTestCatalog(t, incoming), Changeset: emptyChangeset(),
+		ProviderAPICounts: map[catalogs.ProviderID]int{}, ModelProviderMap: map[string]catalogs.ProviderID{},
+	})
+	runner.loadLocal = func(string) (*catalogs.Builder, error) { return local, nil }
+
+	result, err := runner.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(result.PinnedChanges) != 2 {
+		t.Fatalf("pinned changes = %v, want name and fast input price", result.PinnedChanges)
+	}
+	if got := result.PinnedChanges[1].String(); got != "pinned/model name pinned: kept Curated Name, blocked Upstream Name" {
+		t.Fatalf("pinned change = %q", got)
+	}
+	if !strings.Contains(result.PinnedChanges[0].PinnedValue, "2") || !strings.Contains(result.PinnedChanges[0].IncomingValue, "3") {
+		t.Fatalf("pinned price change = %#v", result.PinnedChanges[0])
+	}
+	if result.TotalChanges != 1 || store.applyCalls != 1 {
+		t.Fatalf("total = %d, apply calls = %d, want only the description update applied", result.TotalChanges, store.applyCalls)
+	}
+
+	model, err := store.appliedCatalog.ProviderModel("pinned", "model")
+	if err != nil {
+		t.Fatalf("ProviderModel: %v", err)
+	}
+	if model.Name != "Curated Name" || model.Description != "Upstream description" {
+		t.Fatalf("applied name = %q, description = %q", model.Name, model.Description)
+	}
+	if got := model.Modes["fast"].Pricing.Tokens.Input.Per1M; got != 2 {
+		t.Fatalf("applied fast input price = %v, want pinned 2", got)
+	}
+	if len(model.Pins) != 2 {
+		t.Fatalf("applied pins = %v, want inline pins carried for save", model.Pins)
+	}
+}
+
+func TestPipelineRejectsUnknownPinField(t *testing.T) {
+	local := pinTestCatalog(t, catalogs.Model{ID: "model", Name: "Model", Pins: []string{"pricing.nope"}})
+	store := &pipelineTestStore{catalog: asSnapshot(catalogs.NewEmpty())}
+	runner := newStubPipeline(store, &reconciler.Result{
+		Catalog: pinTestCatalog(t, catalogs.Model{ID: "model", Name: "Model"}), Changeset: emptyChangeset(),
+		ProviderAPICounts: map[catalogs.ProviderID]int{}, ModelProviderMap: map[string]catalogs.ProviderID{},
+	})
+	runner.loadLocal = func(string) (*catalogs.Builder, error) { return local, nil }
+
+	if _, err := runner.Sync(context.Background(), pkgsync.WithDryRun(true)); err == nil || !strings.Contains(err.Error(), `unknown field "nope"`) {
+		t.Fatalf("Sync error = %v, want unknown pin field", err)
+	}
+}
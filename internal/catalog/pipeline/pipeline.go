@@ -3,6 +3,7 @@ package pipeline
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -12,6 +13,7 @@ import (
 	pkgerrors "github.com/agentstation/starmap/pkg/errors"
 	"github.com/agentstation/starmap/pkg/logging"
 	"github.com/agentstation/starmap/pkg/reconciler"
+	"github.com/agentstation/starmap/pkg/save"
 	"github.com/agentstation/starmap/pkg/sources"
 	pkgsync "github.com/agentstation/starmap/pkg/sync"
 )
@@ -33,7 +35,8 @@ type sourcesFunc func(*pkgsync.Options, *catalogs.Catalog) []sources.Source
 type resolveDependenciesFunc func(context.Context, []sources.Source, *pkgsync.Options) ([]sources.Source, error)
 type cleanupFunc func(context.Context, []sources.Source) error
 type observeFunc func(context.Context, []sources.Source, []sources.Option) ([]sources.Observation, error)
-type reconcileFunc func(context.Context, *catalogs.Catalog, []sources.Observation) (*reconciler.Result, error)
+type reconcileFunc func(context.Context, *catalogs.Catalog, []sources.Observation, *pkgsync.Options) (*reconciler.Result, error)
+type watchPoliciesFunc func(context.Context, *pkgsync.Options, []catalogs.Provider) []differ.PolicyChange
 
 // Pipeline executes catalog sync through source observation, reconciliation, and persistence.
 type Pipeline struct {
@@ -45,6 +48,8 @@ type Pipeline struct {
 	cleanup             cleanupFunc
 	observe             observeFunc
 	reconcile           reconcileFunc
+	watchPolicies       watchPoliciesFunc
+	metrics             *Metrics
 }
 
 // New creates a catalog sync pipeline with production dependencies.
@@ -57,11 +62,20 @@ func New(store Store) *Pipeline {
 		cleanup:             cleanup,
 		observe:             observe,
 		reconcile:           reconcile,
+		watchPolicies:       watchPolicies,
+		metrics:             DefaultMetrics,
 	}
 }
 
 // Sync synchronizes the catalog through source observation, reconciliation, and optional persistence.
 func (p *Pipeline) Sync(ctx context.Context, opts ...pkgsync.Option) (*pkgsync.Result, error) {
+	start := time.Now()
+	result, err := p.sync(ctx, opts...)
+	p.metrics.recordRun(time.Since(start), err)
+	return result, err
+}
+
+func (p *Pipeline) sync(ctx context.Context, opts ...pkgsync.Option) (*pkgsync.Result, error) {
 	if p.store == nil {
 		return nil, &pkgerrors.ConfigError{
 			Component: "pipeline",
@@ -133,6 +147,7 @@ func (p *Pipeline) Sync(ctx context.Context, opts ...pkgsync.Option) (*pkgsync.R
 		}
 		logging.Debug().Msg("No existing catalog found, using empty baseline")
 	}
+	baseline := existing
 	if options.Fresh {
 		empty := catalogs.NewEmpty()
 		existing, err = empty.Build()
@@ -142,13 +157,38 @@ func (p *Pipeline) Sync(ctx context.Context, opts ...pkgsync.Option) (*pkgsync.R
 		logging.Info().Msg("Fresh sync uses an empty reconciliation baseline")
 	}
 
-	result, err := p.reconcile(ctx, existing, observations)
+	result, err := p.reconcile(ctx, existing, observations, options)
 	if err != nil {
 		return nil, err
 	}
+	p.metrics.recordMerge(result.Metadata.Duration, result.Metadata.Stats.ConflictsDetected)
+
+	if result.Catalog != nil {
+		pinned, pinErr := applyPins(result.Catalog, local)
+		if pinErr != nil {
+			return nil, pinErr
+		}
+		if len(pinned) > 0 {
+			result.Changeset = differ.New(differ.WithRules(options.DiffRules)).Catalogs(existing, result.Catalog)
+			result.Changeset.Pinned = pinned
+		}
+	}
 
 	logChanges(result)
 
+	if options.ReviewNewModels && result.Catalog != nil {
+		if err := holdNewModels(result.Catalog, baseline); err != nil {
+			return nil, pkgerrors.WrapResource("hold", "new models for review", "", err)
+		}
+	}
+
+	if options.WatchPolicies && result.Catalog != nil {
+		if result.Changeset == nil {
+			result.Changeset = &differ.Changeset{}
+		}
+		result.Changeset.Policies = p.watchPolicies(ctx, options, result.Catalog.Providers().List())
+	}
+
 	syncResult := pkgsync.ChangesetToResultWithProvenance(
 		result.Changeset,
 		options.DryRun,
@@ -165,6 +205,12 @@ func (p *Pipeline) Sync(ctx context.Context, opts ...pkgsync.Option) (*pkgsync.R
 	}
 
 	if options.DryRun {
+		if options.SandboxPath != "" {
+			if err := writeSandbox(result.Catalog, existing, options.SandboxPath); err != nil {
+				return nil, err
+			}
+			syncResult.SandboxDir = options.SandboxPath
+		}
 		logging.Info().Bool("dry_run", true).Msg("Dry run completed - no changes applied")
 		return syncResult, nil
 	}
@@ -180,11 +226,33 @@ func (p *Pipeline) Sync(ctx context.Context, opts ...pkgsync.Option) (*pkgsync.R
 		}
 		syncResult.GenerationID = publication.GenerationID
 		syncResult.SyncRunID = publication.SyncRunID
+		recordProvenanceHistory(options, result.Catalog)
+		recordPricingHistory(options, result.Catalog)
 	}
 
 	return syncResult, nil
 }
 
+// writeSandbox saves the merged catalog, or the unchanged baseline when
+// reconciliation produced none, to a fresh catalog at path. Copying into a new
+// builder keeps the save from following a write path the merged catalog may
+// carry back to the real catalog.
+func writeSandbox(merged *catalogs.Builder, baseline *catalogs.Catalog, path string) error {
+	var source catalogs.Reader = baseline
+	if merged != nil {
+		source = merged
+	}
+	sandbox := catalogs.NewEmpty()
+	if err := sandbox.ReplaceWith(source); err != nil {
+		return pkgerrors.WrapResource("copy", "catalog", "sandbox", err)
+	}
+	if err := sandbox.Save(save.WithPath(path)); err != nil {
+		return pkgerrors.WrapIO("write", path, err)
+	}
+	logging.Info().Str("sandbox", path).Msg("Wrote merged catalog to sandbox")
+	return nil
+}
+
 func activeSourceIDs(observations []sources.Observation) []sources.ID {
 	ids := make([]sources.ID, 0, len(observations))
 	for _, observation := range observations {
@@ -3,6 +3,7 @@ package pipeline
 import (
 	"context"
 	stderrors "errors"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -105,6 +106,39 @@ func TestPipelineDryRunSkipsApplyEvenWithChanges(t *testing.T) {
 	}
 }
 
+func TestPipelineSandboxWritesMergedCatalogWithoutApplying(t *testing.T) {
+	merged := catalogs.NewEmpty()
+	if err := merged.SetProvider(catalogs.Provider{ID: "test-provider", Name: "Test Provider", Models: map[string]*catalogs.Model{
+		"sandbox-model": {ID: "sandbox-model", Name: "Sandbox Model"},
+	}}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	store := &pipelineTestStore{catalog: asSnapshot(catalogs.NewEmpty())}
+	runner := newStubPipeline(store, &reconciler.Result{
+		Catalog:   merged,
+		Changeset: changesetWithAddedModel("sandbox-model"),
+	})
+	sandbox := filepath.Join(t.TempDir(), "sandbox")
+
+	result, err := runner.Sync(context.Background(), pkgsync.WithSandbox(sandbox))
+	if err != nil {
+		t.Fatalf("Sandbox sync failed: %v", err)
+	}
+	if store.applyCalls != 0 {
+		t.Fatalf("Expected sandbox to skip apply, got %d calls", store.applyCalls)
+	}
+	if !result.DryRun || result.SandboxDir != sandbox {
+		t.Fatalf("result DryRun = %v, SandboxDir = %q", result.DryRun, result.SandboxDir)
+	}
+	written, err := catalogs.NewFromPath(sandbox)
+	if err != nil {
+		t.Fatalf("load sandbox: %v", err)
+	}
+	if _, err := written.ProviderModel("test-provider", "sandbox-model"); err != nil {
+		t.Fatalf("sandbox catalog is missing the merged model: %v", err)
+	}
+}
+
 func TestPipelineAddsSourceRunCorrelationBeforeObservation(t *testing.T) {
 	store := &pipelineTestStore{catalog: asSnapshot(catalogs.NewEmpty())}
 	runner := newStubPipeline(store, &reconciler.Result{
@@ -226,7 +260,7 @@ func TestPipelineFreshReconcilesAgainstEmptyBaseline(t *testing.T) {
 		ProviderAPICounts: map[catalogs.ProviderID]int{},
 		ModelProviderMap:  map[string]catalogs.ProviderID{},
 	})
-	runner.reconcile = func(_ context.Context, baseline *catalogs.Catalog, _ []sources.Observation) (*reconciler.Result, error) {
+	runner.reconcile = func(_ context.Context, baseline *catalogs.Catalog, _ []sources.Observation, _ *pkgsync.Options) (*reconciler.Result, error) {
 		if baseline.Providers().Len() != 0 {
 			t.Fatalf("Fresh reconciliation baseline contains %d providers, want 0", baseline.Providers().Len())
 		}
@@ -269,7 +303,7 @@ func newStubPipeline(store Store, result *reconciler.Result) *Pipeline {
 	runner.cleanup = func(context.Context, []sources.Source) error {
 		return nil
 	}
-	runner.reconcile = func(context.Context, *catalogs.Catalog, []sources.Observation) (*reconciler.Result, error) {
+	runner.reconcile = func(context.Context, *catalogs.Catalog, []sources.Observation, *pkgsync.Options) (*reconciler.Result, error) {
 		return result, nil
 	}
 	return runner
@@ -292,3 +326,86 @@ func changesetWithAddedModel(modelID string) *differ.Changeset {
 	}
 	return changeset
 }
+
+func TestPipelineReportsPolicyChangesOnlyWhenWatching(t *testing.T) {
+	store := &pipelineTestStore{catalog: asSnapshot(catalogs.NewEmpty())}
+	runner := newStubPipeline(store, &reconciler.Result{
+		Catalog: catalogs.NewEmpty(), Changeset: emptyChangeset(),
+		ProviderAPICounts: map[catalogs.ProviderID]int{}, ModelProviderMap: map[string]catalogs.ProviderID{},
+	})
+	calls := 0
+	runner.watchPolicies = func(context.Context, *pkgsync.Options, []catalogs.Provider) []differ.PolicyChange {
+		calls++
+		return []differ.PolicyChange{{ProviderID: "anthropic", Document: differ.PolicyDocumentPrivacyPolicy}}
+	}
+
+	result, err := runner.Sync(context.Background(), pkgsync.WithDryRun(true))
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if calls != 0 || len(result.PolicyChanges) != 0 {
+		t.Fatalf("unwatched sync called watcher %d times, policy changes = %v", calls, result.PolicyChanges)
+	}
+
+	result, err = runner.Sync(context.Background(), pkgsync.WithPolicyWatch(true))
+	if err != nil {
+		t.Fatalf("watched Sync: %v", err)
+	}
+	if len(result.PolicyChanges) != 1 || result.PolicyChanges[0].String() != "anthropic privacy policy changed" {
+		t.Fatalf("policy changes = %v", result.PolicyChanges)
+	}
+	if result.HasChanges() || store.applyCalls != 0 {
+		t.Fatalf("policy-only changes were treated as catalog changes: total = %d, apply calls = %d", result.TotalChanges, store.applyCalls)
+	}
+}
+
+func TestPipelineHoldsOnlyNewModelsForReview(t *testing.T) {
+	existing := catalogs.NewEmpty()
+	if err := existing.SetProvider(catalogs.Provider{
+		ID: "reviewed", Name: "Reviewed",
+		Models: map[string]*catalogs.Model{"known": {ID: "known", Name: "Known"}},
+	}); err != nil {
+		t.Fatalf("Seed existing catalog: %v", err)
+	}
+	newResult := func() *reconciler.Result {
+		reconciled := catalogs.NewEmpty()
+		if err := reconciled.SetProvider(catalogs.Provider{
+			ID: "reviewed", Name: "Reviewed",
+			Models: map[string]*catalogs.Model{
+				"known": {ID: "known", Name: "Known"},
+				"new":   {ID: "new", Name: "New"},
+			},
+		}); err != nil {
+			t.Fatalf("Seed reconciled catalog: %v", err)
+		}
+		return &reconciler.Result{
+			Catalog: reconciled, Changeset: changesetWithAddedModel("new"),
+			ProviderAPICounts: map[catalogs.ProviderID]int{}, ModelProviderMap: map[string]catalogs.ProviderID{},
+		}
+	}
+
+	store := &pipelineTestStore{catalog: asSnapshot(existing)}
+	if _, err := newStubPipeline(store, newResult()).Sync(context.Background()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	model, err := store.appliedCatalog.ProviderModel("reviewed", "new")
+	if err != nil || model.Review != nil {
+		t.Fatalf("review disabled: new model review = %#v, err = %v", model.Review, err)
+	}
+
+	if _, err := newStubPipeline(store, newResult()).Sync(context.Background(), pkgsync.WithReviewNewModels(true)); err != nil {
+		t.Fatalf("Sync with review: %v", err)
+	}
+	model, err = store.appliedCatalog.ProviderModel("reviewed", "new")
+	if err != nil {
+		t.Fatalf("ProviderModel(new): %v", err)
+	}
+	if model.ReviewState() != catalogs.ModelReviewPending || len(model.Review.Log) != 1 ||
+		model.Review.Log[0].Reviewer != catalogs.ModelReviewSystemReviewer {
+		t.Fatalf("new model review = %#v", model.Review)
+	}
+	known, err := store.appliedCatalog.ProviderModel("reviewed", "known")
+	if err != nil || known.Review != nil {
+		t.Fatalf("known model review = %#v, err = %v", known.Review, err)
+	}
+}
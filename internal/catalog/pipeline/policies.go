@@ -0,0 +1,60 @@
+package pipeline
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/internal/providers/policywatch"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/differ"
+	"github.com/agentstation/starmap/pkg/logging"
+	pkgsync "github.com/agentstation/starmap/pkg/sync"
+)
+
+// watchPolicies hashes each provider's privacy policy and terms of service
+// and reports documents whose content changed since the last recorded sync.
+// Like provider response shapes, hashes are recorded on dry runs too, so each
+// change is reported once. Fetch failures are logged and never fail the sync.
+func watchPolicies(ctx context.Context, options *pkgsync.Options, providers []catalogs.Provider) []differ.PolicyChange {
+	store := policywatch.Store{Dir: policyWatchDir(options)}
+	watcher := policywatch.New(nil)
+
+	var changes []differ.PolicyChange
+	for i := range providers {
+		provider := &providers[i]
+		if options.ProviderID != nil && provider.ID != *options.ProviderID {
+			continue
+		}
+		previous, err := store.Load(provider.ID)
+		if err != nil {
+			logging.Warn().Err(err).Str("provider", string(provider.ID)).Msg("Failed to load policy hashes")
+			continue
+		}
+		records, providerChanges, err := watcher.Check(ctx, provider, previous)
+		if err != nil {
+			logging.Warn().Err(err).Str("provider", string(provider.ID)).Msg("Failed to fetch policy documents")
+		}
+		changes = append(changes, providerChanges...)
+		if len(records) == 0 {
+			continue
+		}
+		if err := store.Save(provider.ID, records); err != nil {
+			logging.Warn().Err(err).Str("provider", string(provider.ID)).Msg("Failed to record policy hashes")
+		}
+	}
+	for _, change := range changes {
+		logging.Info().Str("provider", string(change.ProviderID)).Str("url", change.URL).Msg(change.String())
+	}
+	return changes
+}
+
+// policyWatchDir returns where policy document hashes are recorded, honoring
+// a configured sources directory.
+func policyWatchDir(options *pkgsync.Options) string {
+	if options.SourcesDir != "" {
+		return filepath.Join(options.SourcesDir, "policy-watch")
+	}
+	return paths.ExpandHome(constants.DefaultPolicyWatchPath)
+}
@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/logging"
+	"github.com/agentstation/starmap/pkg/pricehistory"
+	pkgsync "github.com/agentstation/starmap/pkg/sync"
+)
+
+// recordPricingHistory appends the token prices of an applied catalog that
+// changed since the last sync to the price history. A failure is logged and
+// never fails the sync.
+func recordPricingHistory(options *pkgsync.Options, catalog *catalogs.Builder) {
+	if catalog == nil {
+		return
+	}
+	added, err := pricehistory.NewStore(pricingHistoryPath(options)).Record(catalog, time.Now())
+	if err != nil {
+		logging.Warn().Err(err).Msg("Failed to record pricing history")
+		return
+	}
+	logging.Debug().Int("points", added).Msg("Recorded pricing history")
+}
+
+// pricingHistoryPath returns where the price history is kept, honoring a
+// configured sources directory.
+func pricingHistoryPath(options *pkgsync.Options) string {
+	if options.SourcesDir != "" {
+		return filepath.Join(options.SourcesDir, "pricing-history.jsonl")
+	}
+	return paths.ExpandHome(constants.DefaultPricingHistoryPath)
+}
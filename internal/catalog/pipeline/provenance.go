@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"path/filepath"
+
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/logging"
+	"github.com/agentstation/starmap/pkg/provenance"
+	pkgsync "github.com/agentstation/starmap/pkg/sync"
+)
+
+// recordProvenanceHistory appends the field values of an applied catalog to
+// the durable provenance history, which outlives the per-sync tracker. A
+// failure is logged and never fails the sync.
+func recordProvenanceHistory(options *pkgsync.Options, catalog *catalogs.Builder) {
+	if catalog == nil {
+		return
+	}
+	store := provenance.NewStore(provenanceHistoryPath(options), constants.ProvenanceHistoryDepth)
+	added, err := store.Record(catalog.Provenance().Map())
+	if err != nil {
+		logging.Warn().Err(err).Msg("Failed to record provenance history")
+		return
+	}
+	logging.Debug().Int("values", added).Msg("Recorded provenance history")
+}
+
+// provenanceHistoryPath returns where the provenance history is kept,
+// honoring a configured sources directory.
+func provenanceHistoryPath(options *pkgsync.Options) string {
+	if options.SourcesDir != "" {
+		return filepath.Join(options.SourcesDir, "provenance-history.jsonl")
+	}
+	return paths.ExpandHome(constants.DefaultProvenanceHistoryPath)
+}
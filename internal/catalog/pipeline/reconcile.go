@@ -5,12 +5,14 @@ import (
 
 	"github.com/agentstation/starmap/pkg/authority"
 	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/differ"
 	pkgerrors "github.com/agentstation/starmap/pkg/errors"
 	"github.com/agentstation/starmap/pkg/reconciler"
 	"github.com/agentstation/starmap/pkg/sources"
+	pkgsync "github.com/agentstation/starmap/pkg/sync"
 )
 
-func reconcile(ctx context.Context, baseline *catalogs.Catalog, srcs []sources.Observation) (*reconciler.Result, error) {
+func reconcile(ctx context.Context, baseline *catalogs.Catalog, srcs []sources.Observation, options *pkgsync.Options) (*reconciler.Result, error) {
 	primary := reconciliationPrimary(srcs)
 	var err error
 	srcs, err = reconciliationSources(baseline, srcs, primary)
@@ -22,13 +24,28 @@ func reconcile(ctx context.Context, baseline *catalogs.Catalog, srcs []sources.O
 	}
 	primary = reconciliationPrimaryAfterEnrichment(baseline, srcs, primary)
 
+	strategyName := options.Strategy
+	if strategyName == "" {
+		strategyName = string(reconciler.StrategyTypeFieldAuthority)
+	}
+	strategy, err := reconciler.NewStrategy(reconciler.StrategyType(strategyName), authority.New())
+	if err != nil {
+		return nil, err
+	}
 	opts := []reconciler.Option{
-		reconciler.WithStrategy(reconciler.NewAuthorityStrategy(authority.New())),
+		reconciler.WithStrategy(strategy),
+		reconciler.WithDiffOptions(differ.WithRules(options.DiffRules)),
 	}
 
 	if baseline != nil {
 		opts = append(opts, reconciler.WithBaseline(baseline))
 	}
+	if options.ConflictResolver != nil {
+		opts = append(opts, reconciler.WithConflictResolver(options.ConflictResolver))
+	}
+	if options.MatchModels {
+		opts = append(opts, reconciler.WithModelMatching(true))
+	}
 
 	reconcile, err := reconciler.New(opts...)
 	if err != nil {
@@ -164,6 +181,12 @@ func needsBaselineEnrichment(baseline *catalogs.Catalog, srcs []sources.Observat
 }
 
 func reconciliationPrimary(srcs []sources.Observation) sources.ID {
+	// Federation layers whole catalogs. Without live provider observations to
+	// define what is served, keep providers and models from every catalog and
+	// let authority resolve conflicting fields.
+	if hasSource(srcs, sources.RemoteCatalogID) && !hasSource(srcs, sources.ProvidersID) {
+		return ""
+	}
 	for _, preferred := range []sources.ID{
 		sources.ProvidersID,
 		sources.LocalCatalogID,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/agentstation/starmap/pkg/catalogs"
 	"github.com/agentstation/starmap/pkg/sources"
+	pkgsync "github.com/agentstation/starmap/pkg/sync"
 )
 
 func TestReconcileUsesSelectedSourceAsPrimaryWhenProvidersSourceAbsent(t *testing.T) {
@@ -29,7 +30,7 @@ func TestReconcileUsesSelectedSourceAsPrimaryWhenProvidersSourceAbsent(t *testin
 
 	result, err := reconcile(context.Background(), asSnapshot(catalogs.NewEmpty()), []sources.Observation{
 		{SourceID: sources.ModelsDevHTTPID, Catalog: asSnapshot(cat)},
-	})
+	}, pkgsync.Defaults())
 	if err != nil {
 		t.Fatalf("reconcile models.dev-only source: %v", err)
 	}
@@ -41,6 +42,61 @@ func TestReconcileUsesSelectedSourceAsPrimaryWhenProvidersSourceAbsent(t *testin
 	}
 }
 
+func TestReconcileFederatedRemoteAddsCatalogsAndDefersToLocal(t *testing.T) {
+	local := catalogs.NewEmpty()
+	internalModel := catalogs.Model{ID: "shared-model", Name: "Shared Model (internal name)"}
+	if err := local.SetProvider(catalogs.Provider{
+		ID:     "shared-provider",
+		Name:   "Shared Provider",
+		Models: map[string]*catalogs.Model{internalModel.ID: &internalModel},
+	}); err != nil {
+		t.Fatalf("SetProvider local: %v", err)
+	}
+
+	remote := catalogs.NewEmpty()
+	communityModel := catalogs.Model{
+		ID:          "shared-model",
+		Name:        "Shared Model (community name)",
+		Description: "Community description",
+	}
+	remoteOnlyModel := catalogs.Model{ID: "community-model", Name: "Community Model"}
+	if err := remote.SetProvider(catalogs.Provider{
+		ID:     "shared-provider",
+		Name:   "Shared Provider",
+		Models: map[string]*catalogs.Model{communityModel.ID: &communityModel, remoteOnlyModel.ID: &remoteOnlyModel},
+	}); err != nil {
+		t.Fatalf("SetProvider remote: %v", err)
+	}
+	if err := remote.SetProvider(catalogs.Provider{ID: "community-provider", Name: "Community Provider"}); err != nil {
+		t.Fatalf("SetProvider remote-only: %v", err)
+	}
+
+	result, err := reconcile(context.Background(), asSnapshot(local), []sources.Observation{
+		{SourceID: sources.LocalCatalogID, Catalog: asSnapshot(local)},
+		{SourceID: sources.RemoteCatalogID, Catalog: asSnapshot(remote)},
+	}, pkgsync.Defaults())
+	if err != nil {
+		t.Fatalf("reconcile federated sources: %v", err)
+	}
+
+	if _, err := result.Catalog.Provider("community-provider"); err != nil {
+		t.Fatalf("remote-only provider missing from federated catalog: %v", err)
+	}
+	if _, err := result.Catalog.FindModel(remoteOnlyModel.ID); err != nil {
+		t.Fatalf("remote-only model missing from federated catalog: %v", err)
+	}
+	shared, err := result.Catalog.FindModel(internalModel.ID)
+	if err != nil {
+		t.Fatalf("shared model missing: %v", err)
+	}
+	if shared.Name != internalModel.Name {
+		t.Fatalf("shared model name = %q, want local %q", shared.Name, internalModel.Name)
+	}
+	if shared.Description != communityModel.Description {
+		t.Fatalf("shared model description = %q, want remote fill %q", shared.Description, communityModel.Description)
+	}
+}
+
 func TestReconcileModelsDevOnlyEnrichesBaselineProviderData(t *testing.T) {
 	baseline := catalogs.NewEmpty()
 	inputCost := 1.25
@@ -109,7 +165,7 @@ func TestReconcileModelsDevOnlyEnrichesBaselineProviderData(t *testing.T) {
 
 	result, err := reconcile(context.Background(), asSnapshot(baseline), []sources.Observation{
 		{SourceID: sources.ModelsDevHTTPID, Catalog: asSnapshot(modelsDev)},
-	})
+	}, pkgsync.Defaults())
 	if err != nil {
 		t.Fatalf("reconcile models.dev-only source: %v", err)
 	}
@@ -191,7 +247,7 @@ func TestReconcileModelsDevPrimaryIsRestrictedToBaselineProviders(t *testing.T)
 
 	result, err := reconcile(context.Background(), asSnapshot(baseline), []sources.Observation{
 		{SourceID: sources.ModelsDevHTTPID, Catalog: asSnapshot(modelsDev)},
-	})
+	}, pkgsync.Defaults())
 	if err != nil {
 		t.Fatalf("reconcile models.dev-only source: %v", err)
 	}
@@ -0,0 +1,45 @@
+package pipeline
+
+import (
+	"github.com/agentstation/utc"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/logging"
+)
+
+// holdNewModels puts every provider model in catalog that baseline does not
+// offer into pending-review and records the transition in its audit log.
+// Models that already carry a review keep it, and an empty baseline is an
+// initial import that holds nothing.
+func holdNewModels(catalog *catalogs.Builder, baseline catalogs.Reader) error {
+	if len(baseline.Providers().List()) == 0 {
+		return nil
+	}
+	now := utc.Now()
+	held := 0
+	for _, provider := range catalog.Providers().List() {
+		for _, model := range provider.Models {
+			if model == nil || model.Review != nil {
+				continue
+			}
+			if _, err := baseline.ProviderModel(provider.ID, model.ID); err == nil {
+				continue
+			}
+			model.Review = &catalogs.ModelReview{}
+			model.Review.Record(catalogs.ModelReviewEntry{
+				State:    catalogs.ModelReviewPending,
+				Reviewer: catalogs.ModelReviewSystemReviewer,
+				At:       now,
+				Note:     "discovered by sync",
+			})
+			if err := catalog.SetProviderModel(provider.ID, *model); err != nil {
+				return err
+			}
+			held++
+		}
+	}
+	if held > 0 {
+		logging.Info().Int("models", held).Msg("Holding new models for review")
+	}
+	return nil
+}
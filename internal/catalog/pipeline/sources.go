@@ -1,12 +1,17 @@
 package pipeline
 
 import (
+	"path/filepath"
 	"slices"
 
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/internal/sources/benchmarks"
 	"github.com/agentstation/starmap/internal/sources/local"
 	"github.com/agentstation/starmap/internal/sources/modelsdev"
 	"github.com/agentstation/starmap/internal/sources/providers"
+	"github.com/agentstation/starmap/internal/sources/remote"
 	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
 	"github.com/agentstation/starmap/pkg/sources"
 	pkgsync "github.com/agentstation/starmap/pkg/sync"
 )
@@ -22,7 +27,8 @@ func filterSources(options *pkgsync.Options, localCatalog *catalogs.Catalog) []s
 	if len(options.Sources) > 0 {
 		filtered := make([]sources.Source, 0, len(options.Sources))
 		for _, src := range configuredSources {
-			if slices.Contains(options.Sources, src.ID()) {
+			// A benchmark dataset is requested by location, so it joins any selection.
+			if slices.Contains(options.Sources, src.ID()) || src.ID() == sources.BenchmarksID {
 				filtered = append(filtered, src)
 			}
 		}
@@ -33,9 +39,21 @@ func filterSources(options *pkgsync.Options, localCatalog *catalogs.Catalog) []s
 }
 
 func createSourcesWithConfig(options *pkgsync.Options, localCatalog *catalogs.Catalog) []sources.Source {
+	providerOptions := []providers.SourceOption{
+		providers.WithShapeDir(providerShapesDir(options)),
+		providers.WithPartialFetchDir(partialFetchDir(options)),
+		providers.WithFetchCacheDir(providerFetchCacheDir(options)),
+		providers.WithHTTPCacheDir(providerHTTPCacheDir(options)),
+		providers.WithFreshFetch(options.Fresh),
+	}
+	if options.ScrapeDocs {
+		if scraper := docsScraper(options); scraper != nil {
+			providerOptions = append(providerOptions, providers.WithDocsScraper(scraper))
+		}
+	}
 	srcs := []sources.Source{
 		local.New(local.WithCatalog(localCatalog)),
-		providers.New(localCatalog.Providers()),
+		providers.New(localCatalog.Providers(), providerOptions...),
 	}
 
 	useGit := slices.Contains(options.Sources, sources.ModelsDevGitID)
@@ -54,5 +72,47 @@ func createSourcesWithConfig(options *pkgsync.Options, localCatalog *catalogs.Ca
 			srcs = append(srcs, modelsdev.NewHTTPSource())
 		}
 	}
+	if options.RemoteCatalogURL != "" {
+		srcs = append(srcs, remote.New(options.RemoteCatalogURL, remote.WithAPIKey(options.RemoteCatalogAPIKey)))
+	}
+	if options.BenchmarksURL != "" {
+		srcs = append(srcs, benchmarks.New(options.BenchmarksURL, localCatalog))
+	}
 	return srcs
 }
+
+// providerShapesDir returns where provider response shapes are recorded for
+// format drift detection, honoring a configured sources directory.
+func providerShapesDir(options *pkgsync.Options) string {
+	if options.SourcesDir != "" {
+		return filepath.Join(options.SourcesDir, "provider-shapes")
+	}
+	return paths.ExpandHome(constants.DefaultProviderShapesPath)
+}
+
+// partialFetchDir returns where provider results are kept when a sync is
+// interrupted, honoring a configured sources directory.
+func partialFetchDir(options *pkgsync.Options) string {
+	if options.SourcesDir != "" {
+		return filepath.Join(options.SourcesDir, "partial-fetch")
+	}
+	return paths.ExpandHome(constants.DefaultPartialFetchPath)
+}
+
+// providerFetchCacheDir returns where provider fetches are cached for reuse
+// by other commands, honoring a configured sources directory.
+func providerFetchCacheDir(options *pkgsync.Options) string {
+	if options.SourcesDir != "" {
+		return filepath.Join(options.SourcesDir, "provider-fetch-cache")
+	}
+	return paths.ExpandHome(constants.DefaultProviderFetchCachePath)
+}
+
+// providerHTTPCacheDir returns where provider API responses are cached for
+// revalidation, honoring a configured sources directory.
+func providerHTTPCacheDir(options *pkgsync.Options) string {
+	if options.SourcesDir != "" {
+		return filepath.Join(options.SourcesDir, "provider-http-cache")
+	}
+	return paths.ExpandHome(constants.DefaultProviderHTTPCachePath)
+}
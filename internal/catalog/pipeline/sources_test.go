@@ -65,6 +65,7 @@ func TestModelsDevSourceSelectionFallbackMatrix(t *testing.T) {
 	tests := []struct {
 		name    string
 		sources []sources.ID
+		remote  string
 		want    []sources.ID
 	}{
 		{
@@ -81,6 +82,12 @@ func TestModelsDevSourceSelectionFallbackMatrix(t *testing.T) {
 			sources: []sources.ID{sources.ModelsDevGitID},
 			want:    []sources.ID{sources.ModelsDevGitID},
 		},
+		{
+			name:    "federation layers the local catalog with a remote",
+			sources: []sources.ID{sources.LocalCatalogID, sources.RemoteCatalogID},
+			remote:  "https://starmap.example.com/api/v1",
+			want:    []sources.ID{sources.LocalCatalogID, sources.RemoteCatalogID},
+		},
 		{
 			name:    "provider-only does not add a models.dev fallback transport",
 			sources: []sources.ID{sources.ProvidersID},
@@ -90,7 +97,7 @@ func TestModelsDevSourceSelectionFallbackMatrix(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := sourceIDs(filterSources(&pkgsync.Options{Sources: test.sources}, localCatalog))
+			got := sourceIDs(filterSources(&pkgsync.Options{Sources: test.sources, RemoteCatalogURL: test.remote}, localCatalog))
 			if len(got) != len(test.want) {
 				t.Fatalf("source IDs = %v, want %v", got, test.want)
 			}
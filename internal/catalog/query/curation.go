@@ -0,0 +1,42 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// ParseCurationTags parses comma-separated curation tags, rejecting invalid
+// tags.
+func ParseCurationTags(values ...string) ([]string, error) {
+	var tags []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			tag, err := catalogs.NormalizeCurationTag(part)
+			if err != nil {
+				return nil, err
+			}
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// curationMatches reports whether model carries every required tag and none
+// of the excluded tags.
+func curationMatches(model catalogs.Model, required, excluded []string) bool {
+	for _, tag := range required {
+		if !model.HasCurationTag(tag) {
+			return false
+		}
+	}
+	for _, tag := range excluded {
+		if model.HasCurationTag(tag) {
+			return false
+		}
+	}
+	return true
+}
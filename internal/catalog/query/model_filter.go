@@ -30,6 +30,15 @@ type ModelFilter struct {
 	Tags        []string
 	OpenWeights *bool
 
+	// Curation filters; a model must carry every Curation tag and none of
+	// ExcludeCuration
+	Curation        []string
+	ExcludeCuration []string
+
+	// Review selects models by review state; empty serves only models cleared
+	// for use, ReviewAny disables the filter
+	Review string
+
 	// Numeric range filters
 	MinContext int64
 	MaxContext int64
@@ -38,6 +47,15 @@ type ModelFilter struct {
 	MinOutput  int64
 	MaxOutput  int64
 
+	// Performance filters
+	MinOutputSpeed      float64       // Minimum output tokens per second
+	MaxTimeToFirstToken time.Duration // Maximum median time to first token
+
+	// Usage filters; UsageProvider contributes provider-wide restrictions
+	Uses          []catalogs.UsageRestriction
+	Region        string
+	UsageProvider *catalogs.Provider
+
 	// Date filters
 	ReleasedAfter  *time.Time
 	ReleasedBefore *time.Time
@@ -62,6 +80,8 @@ const (
 	sortContextWindow = "context_window"
 	sortCreatedAt     = "created_at"
 	sortUpdatedAt     = "updated_at"
+	sortOutputSpeed   = "output_speed"
+	sortTTFT          = "time_to_first_token"
 )
 
 // Validate rejects unsupported or ambiguous filter, sort, range, and page
@@ -69,7 +89,8 @@ const (
 func (f ModelFilter) Validate() error {
 	if f.Sort != "" {
 		switch f.Sort {
-		case sortID, sortName, sortReleaseDate, sortContextWindow, sortCreatedAt, sortUpdatedAt:
+		case sortID, sortName, sortReleaseDate, sortContextWindow, sortCreatedAt, sortUpdatedAt,
+			sortOutputSpeed, sortTTFT:
 		default:
 			return &errors.ValidationError{Field: "model_filter.sort", Value: f.Sort, Message: "is not supported"}
 		}
@@ -102,6 +123,25 @@ func (f ModelFilter) Validate() error {
 			return &errors.ValidationError{Field: "model_filter." + bounds.name + "_range", Value: bounds, Message: "must be non-negative and ordered"}
 		}
 	}
+	if f.MinOutputSpeed < 0 || f.MaxTimeToFirstToken < 0 {
+		return &errors.ValidationError{Field: "model_filter.performance", Value: f.MinOutputSpeed, Message: "must not be negative"}
+	}
+	for _, use := range f.Uses {
+		if !slices.Contains(catalogs.UsageRestrictionTags(), use) {
+			return &errors.ValidationError{Field: "model_filter.use", Value: use, Message: "is not a known usage restriction tag"}
+		}
+	}
+	if err := ValidateRegion(f.Region); err != nil {
+		return err
+	}
+	for _, tag := range append(slices.Clone(f.Curation), f.ExcludeCuration...) {
+		if _, err := catalogs.NormalizeCurationTag(tag); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseReviewFilter(f.Review); err != nil {
+		return err
+	}
 	for feature := range f.Features {
 		if _, found := validFeatureFilters[feature]; !found {
 			return &errors.ValidationError{Field: "model_filter.feature", Value: feature, Message: "is not supported"}
@@ -154,6 +194,8 @@ func (f ModelFilter) matches(model catalogs.Model) bool {
 		f.matchesFeaturesFilter(model) &&
 		f.matchesMetadataFilters(model) &&
 		f.matchesLimitFilters(model) &&
+		f.matchesPerformanceFilters(model) &&
+		f.matchesUsageFilters(model) &&
 		f.matchesDateFilters(model)
 }
 
@@ -215,7 +257,7 @@ func (f ModelFilter) matchesFeaturesFilter(model catalogs.Model) bool {
 	return true
 }
 
-// matchesMetadataFilters checks tags and open weights filters.
+// matchesMetadataFilters checks tags, review, curation, and open weights filters.
 func (f ModelFilter) matchesMetadataFilters(model catalogs.Model) bool {
 	if len(f.Tags) > 0 {
 		if model.Metadata == nil {
@@ -225,6 +267,12 @@ func (f ModelFilter) matchesMetadataFilters(model catalogs.Model) bool {
 			return false
 		}
 	}
+	if !reviewMatches(model, f.Review) {
+		return false
+	}
+	if !curationMatches(model, f.Curation, f.ExcludeCuration) {
+		return false
+	}
 	if f.OpenWeights != nil {
 		if model.Metadata == nil {
 			return false
@@ -272,6 +320,30 @@ func limitWithinRange(value, minValue, maxValue int64) bool {
 	return true
 }
 
+// matchesPerformanceFilters checks output speed and latency filters. Models
+// without recorded numbers never match an active performance filter.
+func (f ModelFilter) matchesPerformanceFilters(model catalogs.Model) bool {
+	if f.MinOutputSpeed > 0 && modelOutputSpeed(model) < f.MinOutputSpeed {
+		return false
+	}
+	if f.MaxTimeToFirstToken > 0 {
+		ttft := model.Performance.MedianTimeToFirstToken()
+		if ttft <= 0 || ttft > f.MaxTimeToFirstToken {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesUsageFilters checks intended-use and region filters against the
+// model's resolved acceptable-use restrictions.
+func (f ModelFilter) matchesUsageFilters(model catalogs.Model) bool {
+	if len(f.Uses) == 0 && f.Region == "" {
+		return true
+	}
+	return usagePermitted(model.UsageRestrictionsFor(f.UsageProvider), f.Uses, f.Region)
+}
+
 // matchesDateFilters checks release date range filters.
 func (f ModelFilter) matchesDateFilters(model catalogs.Model) bool {
 	if f.ReleasedAfter == nil && f.ReleasedBefore == nil {
@@ -326,6 +398,10 @@ func modelSortMissing(model catalogs.Model, field string) bool {
 		return model.CreatedAt.IsZero()
 	case sortUpdatedAt:
 		return model.UpdatedAt.IsZero()
+	case sortOutputSpeed:
+		return modelOutputSpeed(model) <= 0
+	case sortTTFT:
+		return model.Performance.MedianTimeToFirstToken() <= 0
 	default:
 		return false
 	}
@@ -343,6 +419,10 @@ func compareModelField(left, right catalogs.Model, field string) int {
 		return compareOptionalTime(left.CreatedAt.Time(), right.CreatedAt.Time())
 	case sortUpdatedAt:
 		return compareOptionalTime(left.UpdatedAt.Time(), right.UpdatedAt.Time())
+	case sortOutputSpeed:
+		return cmp.Compare(modelOutputSpeed(left), modelOutputSpeed(right))
+	case sortTTFT:
+		return cmp.Compare(left.Performance.MedianTimeToFirstToken(), right.Performance.MedianTimeToFirstToken())
 	default:
 		return strings.Compare(left.ID, right.ID)
 	}
@@ -362,6 +442,13 @@ func modelContextWindow(model catalogs.Model) int64 {
 	return model.Limits.ContextWindow
 }
 
+func modelOutputSpeed(model catalogs.Model) float64 {
+	if model.Performance == nil {
+		return 0
+	}
+	return model.Performance.OutputTokensPerSecond
+}
+
 func compareOptionalTime(left, right time.Time) int {
 	return left.Compare(right)
 }
@@ -10,11 +10,14 @@ import (
 )
 
 func TestModelFilterSortImplementsDeclaredFieldsWithMissingValuesLast(t *testing.T) {
+	fast, slow := 200*time.Millisecond, 400*time.Millisecond
 	models := []catalogs.Model{
 		{ID: "b", Name: "Alpha", CreatedAt: utc.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)), UpdatedAt: utc.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)), Limits: &catalogs.ModelLimits{ContextWindow: 20}, Metadata: &catalogs.ModelMetadata{ReleaseDate: utc.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))}},
 		{ID: "a", Name: "Zulu", CreatedAt: utc.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)), UpdatedAt: utc.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)), Limits: &catalogs.ModelLimits{ContextWindow: 10}, Metadata: &catalogs.ModelMetadata{ReleaseDate: utc.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))}},
 		{ID: "c", Name: ""},
 	}
+	models[0].Performance = &catalogs.ModelPerformance{OutputTokensPerSecond: 50, TimeToFirstToken: &catalogs.LatencyPercentiles{P50: &fast}}
+	models[1].Performance = &catalogs.ModelPerformance{OutputTokensPerSecond: 100, TimeToFirstToken: &catalogs.LatencyPercentiles{P50: &slow}}
 	for _, test := range []struct {
 		name  string
 		sort  string
@@ -27,6 +30,8 @@ func TestModelFilterSortImplementsDeclaredFieldsWithMissingValuesLast(t *testing
 		{name: "context asc", sort: "context_window", order: "asc", want: []string{"a", "b", "c"}},
 		{name: "created asc", sort: "created_at", order: "asc", want: []string{"b", "a", "c"}},
 		{name: "updated desc", sort: "updated_at", order: "desc", want: []string{"b", "a", "c"}},
+		{name: "speed desc", sort: "output_speed", order: "desc", want: []string{"a", "b", "c"}},
+		{name: "ttft asc", sort: "time_to_first_token", order: "asc", want: []string{"b", "a", "c"}},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			filter := ModelFilter{Sort: test.sort, Order: test.order, Limit: 100, MaxResults: 1000}
@@ -56,6 +61,9 @@ func TestModelFilterValidateRejectsUnsupportedAndAmbiguousInputs(t *testing.T) {
 		{Limit: 100, Features: map[string]bool{"invented": true}},
 		{Limit: 100, ModalityInput: []string{"hologram"}},
 		{Limit: 100, Status: "retired"},
+		{Limit: 100, Uses: []catalogs.UsageRestriction{"astrology"}},
+		{Limit: 100, Region: "EUR"},
+		{Limit: 100, Curation: []string{"approved for prod"}},
 	} {
 		if err := filter.Validate(); err == nil {
 			t.Fatalf("filter %#v passed validation", filter)
@@ -401,3 +409,77 @@ func boolPtr(b bool) *bool {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestModelFilterPerformanceRequiresRecordedNumbers(t *testing.T) {
+	ttft := 300 * time.Millisecond
+	models := []catalogs.Model{
+		{ID: "fast", Performance: &catalogs.ModelPerformance{OutputTokensPerSecond: 250, TimeToFirstToken: &catalogs.LatencyPercentiles{P50: &ttft}}},
+		{ID: "slow", Performance: &catalogs.ModelPerformance{OutputTokensPerSecond: 40}},
+		{ID: "unknown"},
+	}
+
+	got := ModelFilter{MinOutputSpeed: 100}.Apply(models)
+	if len(got) != 1 || got[0].ID != "fast" {
+		t.Fatalf("min speed = %#v", got)
+	}
+	got = ModelFilter{MaxTimeToFirstToken: time.Second}.Apply(models)
+	if len(got) != 1 || got[0].ID != "fast" {
+		t.Fatalf("max ttft = %#v", got)
+	}
+	if err := (ModelFilter{MinOutputSpeed: -1, Limit: 1}).Validate(); err == nil {
+		t.Fatal("negative speed passed validation")
+	}
+}
+
+func TestModelFilterCurationTags(t *testing.T) {
+	models := []catalogs.Model{
+		{ID: "approved", Curation: &catalogs.ModelCuration{Tags: []string{"approved-for-prod"}}},
+		{ID: "banned", Curation: &catalogs.ModelCuration{Tags: []string{"approved-for-prod", "banned"}}},
+		{ID: "untagged"},
+	}
+
+	got := ModelFilter{Curation: []string{"approved-for-prod"}, ExcludeCuration: []string{"banned"}}.Apply(models)
+	if len(got) != 1 || got[0].ID != "approved" {
+		t.Fatalf("curation filter = %#v", got)
+	}
+	got = Models(models, ModelOptions{ExcludeCuration: []string{"banned"}})
+	if len(got) != 2 || got[0].ID != "approved" || got[1].ID != "untagged" {
+		t.Fatalf("exclude curation = %#v", got)
+	}
+
+	tags, err := ParseCurationTags("Approved-For-Prod, evaluation", "")
+	if err != nil || len(tags) != 2 || tags[0] != "approved-for-prod" || tags[1] != "evaluation" {
+		t.Fatalf("ParseCurationTags = %v, %v", tags, err)
+	}
+	if _, err := ParseCurationTags("not valid!"); err == nil {
+		t.Fatal("invalid curation tag parsed")
+	}
+}
+
+func TestModelFilterReviewServesOnlyClearedModelsByDefault(t *testing.T) {
+	models := []catalogs.Model{
+		{ID: "approved", Review: &catalogs.ModelReview{State: catalogs.ModelReviewApproved}},
+		{ID: "pending", Review: &catalogs.ModelReview{State: catalogs.ModelReviewPending}},
+		{ID: "rejected", Review: &catalogs.ModelReview{State: catalogs.ModelReviewRejected}},
+		{ID: "unreviewed"},
+	}
+
+	got := ModelFilter{}.Apply(models)
+	if len(got) != 2 || got[0].ID != "approved" || got[1].ID != "unreviewed" {
+		t.Fatalf("default review filter = %#v", got)
+	}
+	review, err := ParseReviewFilter("Pending")
+	if err != nil || review != catalogs.ModelReviewPending.String() {
+		t.Fatalf("ParseReviewFilter = %q, %v", review, err)
+	}
+	got = Models(models, ModelOptions{Review: review})
+	if len(got) != 1 || got[0].ID != "pending" {
+		t.Fatalf("pending review filter = %#v", got)
+	}
+	if got = (ModelFilter{Review: ReviewAny}).Apply(models); len(got) != 4 {
+		t.Fatalf("any review filter = %#v", got)
+	}
+	if err := (ModelFilter{Review: "maybe", Limit: 1}).Validate(); err == nil {
+		t.Fatal("unknown review state passed validation")
+	}
+}
@@ -60,10 +60,38 @@ type ModelOptions struct {
 	Capability string
 	MinContext int64
 	MaxPrice   float64
+	MinSpeed   float64 // Minimum output tokens per second
 	Search     string
+	Sort       string // id (default), speed (fastest first), or latency (lowest first)
 	Limit      int
+
+	// Usage filters exclude models whose acceptable-use restrictions forbid
+	// an intended use or block the deployment region. UsageProvider, when
+	// set, contributes its provider-wide restrictions.
+	Uses          []catalogs.UsageRestriction
+	Region        string
+	UsageProvider *catalogs.Provider
+
+	// Curation filters keep models carrying every Curation tag and none of
+	// ExcludeCuration.
+	Curation        []string
+	ExcludeCuration []string
+
+	// Review selects models by review state. Empty keeps only models that
+	// never entered review or were approved; ReviewAny keeps every model.
+	Review string
+
+	// Filters keep only models matching every filter expression.
+	Filters []*catalogs.ModelQuery
 }
 
+// Model list sort keys for ModelOptions.Sort.
+const (
+	ModelSortID      = "id"
+	ModelSortSpeed   = "speed"
+	ModelSortLatency = "latency"
+)
+
 // CatalogModels returns either all legacy flattened models or the exact
 // provider-specific offerings from the catalog's provider index.
 func CatalogModels(catalog catalogs.Reader, provider string) ([]catalogs.Model, error) {
@@ -99,6 +127,12 @@ func Models(models []catalogs.Model, opts ModelOptions) []catalogs.Model {
 	slices.SortFunc(filtered, func(a, b catalogs.Model) int {
 		return strings.Compare(a.ID, b.ID)
 	})
+	switch opts.Sort {
+	case ModelSortSpeed:
+		filtered = ModelFilter{Sort: sortOutputSpeed, Order: "desc"}.sort(filtered)
+	case ModelSortLatency:
+		filtered = ModelFilter{Sort: sortTTFT}.sort(filtered)
+	}
 
 	if opts.Limit > 0 && len(filtered) > opts.Limit {
 		filtered = filtered[:opts.Limit]
@@ -119,10 +153,22 @@ func modelMatches(model catalogs.Model, opts ModelOptions) bool {
 	if opts.MaxPrice > 0 && !modelMatchesMaxPrice(model, opts.MaxPrice) {
 		return false
 	}
+	if opts.MinSpeed > 0 && modelOutputSpeed(model) < opts.MinSpeed {
+		return false
+	}
 	if opts.Search != "" && !modelMatchesSearch(model, opts.Search) {
 		return false
 	}
-	return true
+	for _, filter := range opts.Filters {
+		if !filter.Match(&model) {
+			return false
+		}
+	}
+	if (len(opts.Uses) > 0 || opts.Region != "") &&
+		!usagePermitted(model.UsageRestrictionsFor(opts.UsageProvider), opts.Uses, opts.Region) {
+		return false
+	}
+	return reviewMatches(model, opts.Review) && curationMatches(model, opts.Curation, opts.ExcludeCuration)
 }
 
 func modelMatchesAuthor(model catalogs.Model, authorQuery string) bool {
@@ -149,6 +195,10 @@ func modelMatchesCapability(model catalogs.Model, capability string) bool {
 		return model.Features.Streaming
 	case "vision", "image":
 		return slices.Contains(model.Features.Modalities.Input, catalogs.ModelModalityImage)
+	case "structured_outputs":
+		return model.Features.StructuredOutputs
+	case "web_search":
+		return model.Features.WebSearch
 	default:
 		return false
 	}
@@ -182,15 +232,20 @@ func modelMatchesSearch(model catalogs.Model, query string) bool {
 
 // ProviderOptions controls provider list filtering.
 type ProviderOptions struct {
-	Search string
-	Limit  int
+	Search     string
+	RequireSLA bool                        // Only providers with a published SLA
+	MinUptime  float64                     // Minimum committed uptime percentage
+	Uses       []catalogs.UsageRestriction // Intended uses the usage policy must permit
+	Region     string                      // Deployment region the provider must serve
+	Limit      int
 }
 
 // Providers filters, sorts, and limits provider results.
 func Providers(providers []catalogs.Provider, opts ProviderOptions) []catalogs.Provider {
 	filtered := make([]catalogs.Provider, 0, len(providers))
 	for _, provider := range providers {
-		if providerMatches(provider, opts.Search) {
+		if providerMatches(provider, opts.Search) && providerMatchesSLA(provider, opts) &&
+			usagePermitted(provider.UsageRestrictions, opts.Uses, opts.Region) {
 			filtered = append(filtered, provider)
 		}
 	}
@@ -205,6 +260,13 @@ func Providers(providers []catalogs.Provider, opts ProviderOptions) []catalogs.P
 	return filtered
 }
 
+func providerMatchesSLA(provider catalogs.Provider, opts ProviderOptions) bool {
+	if opts.RequireSLA && provider.SLA == nil {
+		return false
+	}
+	return opts.MinUptime <= 0 || provider.SLA.Uptime() >= opts.MinUptime
+}
+
 func providerMatches(provider catalogs.Provider, query string) bool {
 	if query == "" {
 		return true
@@ -71,6 +71,31 @@ func TestModelsFiltersSortsAndLimits(t *testing.T) {
 	if len(sorted) != 2 || sorted[0].ID != "a-model" || sorted[1].ID != "z-model" {
 		t.Fatalf("Expected models sorted by ID, got %#v", sorted)
 	}
+
+	models[1].Performance = &catalogs.ModelPerformance{OutputTokensPerSecond: 80}
+	models[0].Performance = &catalogs.ModelPerformance{OutputTokensPerSecond: 300}
+	fastest := Models(models, ModelOptions{Sort: ModelSortSpeed, MinSpeed: 50})
+	if len(fastest) != 2 || fastest[0].ID != "z-model" {
+		t.Fatalf("Expected fastest model first, got %#v", fastest)
+	}
+	if got := Models(models, ModelOptions{MinSpeed: 100}); len(got) != 1 || got[0].ID != "z-model" {
+		t.Fatalf("Expected only fast models, got %#v", got)
+	}
+
+	var filters []*catalogs.ModelQuery
+	for _, expr := range []string{"limits.context >= 128000", "pricing.input > 1 || features.reasoning"} {
+		filter, err := catalogs.ParseModelQuery(expr)
+		if err != nil {
+			t.Fatalf("ParseModelQuery(%q): %v", expr, err)
+		}
+		filters = append(filters, filter)
+	}
+	if got := Models(models, ModelOptions{Filters: filters}); len(got) != 2 {
+		t.Fatalf("Expected both models to match the filters, got %#v", got)
+	}
+	if got := Models(models, ModelOptions{Filters: filters, Capability: "streaming"}); len(got) != 1 || got[0].ID != "a-model" {
+		t.Fatalf("Expected filters to combine with options, got %#v", got)
+	}
 }
 
 func TestModelsFiltersByProvider(t *testing.T) {
@@ -184,6 +209,55 @@ func TestProvidersFiltersSortsAndLimits(t *testing.T) {
 	if len(sorted) != 2 || sorted[0].ID != "a-provider" || sorted[1].ID != "z-provider" {
 		t.Fatalf("Expected providers sorted by ID, got %#v", sorted)
 	}
+
+	uptime := 99.5
+	providers[0].SLA = &catalogs.ProviderSLA{UptimeCommitment: &uptime}
+	if got := Providers(providers, ProviderOptions{RequireSLA: true}); len(got) != 1 || got[0].ID != "z-provider" {
+		t.Fatalf("Expected only providers with an SLA, got %#v", got)
+	}
+	if got := Providers(providers, ProviderOptions{MinUptime: 99.9}); len(got) != 0 {
+		t.Fatalf("Expected no providers above 99.9%% uptime, got %#v", got)
+	}
+
+	providers[1].UsageRestrictions = &catalogs.UsageRestrictions{
+		Restricted:     []catalogs.UsageRestriction{catalogs.UsageRestrictionFacialRecognition},
+		BlockedRegions: []string{"EU"},
+	}
+	if got := Providers(providers, ProviderOptions{Uses: []catalogs.UsageRestriction{catalogs.UsageRestrictionFacialRecognition}}); len(got) != 1 || got[0].ID != "z-provider" {
+		t.Fatalf("Expected only providers permitting facial recognition, got %#v", got)
+	}
+	if got := Providers(providers, ProviderOptions{Region: "de"}); len(got) != 1 || got[0].ID != "z-provider" {
+		t.Fatalf("Expected only providers serving Germany, got %#v", got)
+	}
+}
+
+func TestModelsFiltersByUsageRestrictions(t *testing.T) {
+	provider := &catalogs.Provider{ID: "provider", UsageRestrictions: &catalogs.UsageRestrictions{
+		Restricted: []catalogs.UsageRestriction{catalogs.UsageRestrictionWeapons},
+	}}
+	models := []catalogs.Model{
+		{ID: "open"},
+		{ID: "clinical", UsageRestrictions: &catalogs.UsageRestrictions{
+			Restricted:     []catalogs.UsageRestriction{catalogs.UsageRestrictionMedicalAdvice},
+			BlockedRegions: []string{"US"},
+		}},
+	}
+
+	medical := []catalogs.UsageRestriction{catalogs.UsageRestrictionMedicalAdvice}
+	if got := Models(models, ModelOptions{Uses: medical}); len(got) != 1 || got[0].ID != "open" {
+		t.Fatalf("Expected only models permitting medical advice, got %#v", got)
+	}
+	if got := Models(models, ModelOptions{Region: "US"}); len(got) != 1 || got[0].ID != "open" {
+		t.Fatalf("Expected only models available in the US, got %#v", got)
+	}
+
+	weapons := []catalogs.UsageRestriction{catalogs.UsageRestrictionWeapons}
+	if got := Models(models, ModelOptions{Uses: weapons}); len(got) != 2 {
+		t.Fatalf("Expected model-level data alone to permit weapons, got %#v", got)
+	}
+	if got := Models(models, ModelOptions{Uses: weapons, UsageProvider: provider}); len(got) != 0 {
+		t.Fatalf("Expected the provider policy to restrict every model, got %#v", got)
+	}
 }
 
 func TestAuthorsFiltersSortsAndLimits(t *testing.T) {
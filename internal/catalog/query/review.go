@@ -0,0 +1,38 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// ReviewAny selects models in every review state, including those pending
+// review or rejected.
+const ReviewAny = "any"
+
+// ParseReviewFilter parses a review filter: empty for models cleared to
+// serve, ReviewAny, or a review state.
+func ParseReviewFilter(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || value == ReviewAny {
+		return value, nil
+	}
+	state, err := catalogs.ParseModelReviewState(value)
+	if err != nil {
+		return "", err
+	}
+	return state.String(), nil
+}
+
+// reviewMatches reports whether model is selected by review. An empty filter
+// serves only models that never entered review or were approved.
+func reviewMatches(model catalogs.Model, review string) bool {
+	switch review {
+	case "":
+		return model.ReviewCleared()
+	case ReviewAny:
+		return true
+	default:
+		return model.ReviewState().String() == review
+	}
+}
@@ -0,0 +1,56 @@
+package query
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// ParseUses parses comma-separated intended uses into usage restriction
+// tags, rejecting unknown tags.
+func ParseUses(values ...string) ([]catalogs.UsageRestriction, error) {
+	var uses []catalogs.UsageRestriction
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			use := catalogs.UsageRestriction(strings.ToLower(strings.TrimSpace(part)))
+			if use == "" {
+				continue
+			}
+			if !slices.Contains(catalogs.UsageRestrictionTags(), use) {
+				return nil, &errors.ValidationError{Field: "use", Value: part, Message: "is not a known usage restriction tag"}
+			}
+			if !slices.Contains(uses, use) {
+				uses = append(uses, use)
+			}
+		}
+	}
+	return uses, nil
+}
+
+// ValidateRegion accepts an ISO 3166-1 alpha-2 code or EU.
+func ValidateRegion(region string) error {
+	if region == "" {
+		return nil
+	}
+	valid := len(region) == 2
+	for _, r := range strings.ToUpper(region) {
+		valid = valid && r >= 'A' && r <= 'Z'
+	}
+	if !valid {
+		return &errors.ValidationError{Field: "region", Value: region, Message: "must be an ISO 3166-1 alpha-2 code or EU"}
+	}
+	return nil
+}
+
+// usagePermitted reports whether restrictions allow every intended use and
+// the deployment region.
+func usagePermitted(restrictions *catalogs.UsageRestrictions, uses []catalogs.UsageRestriction, region string) bool {
+	for _, use := range uses {
+		if !restrictions.Permits(use) {
+			return false
+		}
+	}
+	return region == "" || restrictions.AvailableIn(region)
+}
@@ -0,0 +1,29 @@
+package query
+
+import "testing"
+
+// TestParseIntOrDefault tests integer parsing helper.
+func TestParseIntOrDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		def      int
+		expected int
+	}{
+		{"empty string returns default", "", 100, 100},
+		{"valid integer", "42", 100, 42},
+		{"zero value", "0", 100, 0},
+		{"negative value", "-5", 100, -5},
+		{"invalid string returns default", "abc", 100, 100},
+		{"float returns default", "3.14", 100, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseIntOrDefault(tt.input, tt.def)
+			if result != tt.expected {
+				t.Errorf("parseIntOrDefault(%q, %d) = %d, want %d", tt.input, tt.def, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Package view materializes named sub-catalogs from a model filter expression
+// and per-model overrides. A view such as "frontend-team" can expose only
+// approved chat models without copying catalog data: it is evaluated against
+// the current catalog generation on every read.
+package view
+
+import (
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/agentstation/starmap/internal/catalog/query"
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// DefaultFilename is the views file read from the starmap config directory.
+const DefaultFilename = "views.yaml"
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// paginationParameters belong to the request reading a view, not the view.
+var paginationParameters = []string{"limit", "offset", "max_results"}
+
+// Definition declares a named view.
+type Definition struct {
+	Name        string                   `json:"name" yaml:"name"`
+	Description string                   `json:"description,omitempty" yaml:"description,omitempty"`
+	Filter      string                   `json:"filter,omitempty" yaml:"filter,omitempty"`       // Model filter in API query-string form, e.g. "curation=approved-for-prod&modality_output=text"
+	Overrides   map[string]ModelOverride `json:"overrides,omitempty" yaml:"overrides,omitempty"` // Keyed by model ID
+}
+
+// ModelOverride replaces fields of a model as seen through one view.
+type ModelOverride struct {
+	Name        string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Pricing     *catalogs.ModelPricing `json:"pricing,omitempty" yaml:"pricing,omitempty"` // e.g. team-negotiated rates
+}
+
+// View is a compiled view definition.
+type View struct {
+	Definition
+	filter query.ModelFilter
+}
+
+// Compile validates def and parses its filter expression.
+func Compile(def Definition) (*View, error) {
+	if !namePattern.MatchString(def.Name) {
+		return nil, &errors.ValidationError{Field: "view.name", Value: def.Name, Message: "must be 1-64 lowercase letters, digits, '.', '_', or '-'"}
+	}
+	values, err := url.ParseQuery(def.Filter)
+	if err != nil {
+		return nil, &errors.ValidationError{Field: "view.filter", Value: def.Filter, Message: "must be a URL query string: " + err.Error()}
+	}
+	for _, parameter := range paginationParameters {
+		if values.Has(parameter) {
+			return nil, &errors.ValidationError{Field: "view.filter", Value: parameter, Message: "pagination is chosen by the request, not the view"}
+		}
+	}
+	filter, err := query.ParseModelFilterValues(values)
+	if err != nil {
+		return nil, errors.WrapResource("compile", "view", def.Name, err)
+	}
+	return &View{Definition: def, filter: filter}, nil
+}
+
+// Models returns the view's models from catalog with overrides applied.
+// provider, when set, further narrows the view to one provider's offerings.
+func (v *View) Models(catalog catalogs.Reader, provider string) ([]catalogs.Model, error) {
+	filter := v.filter
+	if provider != "" {
+		if filter.Provider != "" && filter.Provider != provider {
+			return []catalogs.Model{}, nil
+		}
+		filter.Provider = provider
+	}
+	models, err := query.CatalogModels(catalog, filter.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Provider != "" {
+		filter.UsageProvider, _ = catalog.Providers().Get(catalogs.ProviderID(filter.Provider))
+	}
+	models = filter.Apply(models)
+	for i := range models {
+		override, ok := v.Overrides[models[i].ID]
+		if !ok {
+			continue
+		}
+		if override.Name != "" {
+			models[i].Name = override.Name
+		}
+		if override.Description != "" {
+			models[i].Description = override.Description
+		}
+		if override.Pricing != nil {
+			models[i].Pricing = override.Pricing
+		}
+	}
+	if models == nil {
+		models = []catalogs.Model{}
+	}
+	return models, nil
+}
+
+// Set is a collection of compiled views keyed by name.
+type Set struct {
+	views map[string]*View
+	names []string
+}
+
+// NewSet compiles defs, rejecting duplicate names.
+func NewSet(defs []Definition) (*Set, error) {
+	set := &Set{views: make(map[string]*View, len(defs))}
+	for _, def := range defs {
+		view, err := Compile(def)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := set.views[view.Name]; exists {
+			return nil, &errors.ValidationError{Field: "view.name", Value: view.Name, Message: "is defined more than once"}
+		}
+		set.views[view.Name] = view
+		set.names = append(set.names, view.Name)
+	}
+	slices.Sort(set.names)
+	return set, nil
+}
+
+// Get returns the named view.
+func (s *Set) Get(name string) (*View, error) {
+	if s != nil {
+		if view, ok := s.views[name]; ok {
+			return view, nil
+		}
+	}
+	return nil, &errors.NotFoundError{Resource: "view", ID: name}
+}
+
+// List returns every view sorted by name.
+func (s *Set) List() []*View {
+	if s == nil {
+		return nil
+	}
+	views := make([]*View, 0, len(s.names))
+	for _, name := range s.names {
+		views = append(views, s.views[name])
+	}
+	return views
+}
+
+// File is the on-disk views document.
+type File struct {
+	Views []Definition `json:"views" yaml:"views"`
+}
+
+// LoadDefinitions reads the view definitions in the views file at path, or in
+// ~/.starmap/views.yaml when path is empty. Only the default file may be
+// missing, in which case no views are defined.
+func LoadDefinitions(path string) ([]Definition, error) {
+	explicit := path != ""
+	if !explicit {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !explicit {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WrapIO("read", path, err)
+	}
+	var file File
+	if err := yaml.UnmarshalWithOptions(data, &file, yaml.Strict()); err != nil {
+		return nil, errors.WrapParse("yaml", path, err)
+	}
+	return file.Views, nil
+}
+
+// Load reads and compiles the views file at path; see LoadDefinitions.
+func Load(path string) (*Set, error) {
+	defs, err := LoadDefinitions(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewSet(defs)
+}
+
+// DefaultPath returns ~/.starmap/views.yaml.
+func DefaultPath() (string, error) {
+	return paths.ResolveHome("~/.starmap/" + DefaultFilename)
+}
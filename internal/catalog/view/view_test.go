@@ -0,0 +1,139 @@
+package view
+
+import (
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+func testCatalog(t *testing.T) *catalogs.Builder {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	if err := builder.SetProvider(catalogs.Provider{
+		ID:   "openai",
+		Name: "OpenAI",
+		Models: map[string]*catalogs.Model{
+			"approved-chat": {ID: "approved-chat", Name: "Approved", Curation: &catalogs.ModelCuration{Tags: []string{"approved-for-prod"}}},
+			"other-chat":    {ID: "other-chat", Name: "Other"},
+			"held-chat": {
+				ID: "held-chat", Name: "Held", Curation: &catalogs.ModelCuration{Tags: []string{"approved-for-prod"}},
+				Review: &catalogs.ModelReview{State: catalogs.ModelReviewPending},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	return builder
+}
+
+func TestViewModelsAppliesFilterAndOverrides(t *testing.T) {
+	v, err := Compile(Definition{
+		Name:   "frontend-team",
+		Filter: "curation=approved-for-prod",
+		Overrides: map[string]ModelOverride{
+			"approved-chat": {Name: "Team Chat", Pricing: &catalogs.ModelPricing{Currency: "USD"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	catalog := testCatalog(t)
+
+	models, err := v.Models(catalog, "")
+	if err != nil {
+		t.Fatalf("Models: %v", err)
+	}
+	if len(models) != 1 || models[0].ID != "approved-chat" {
+		t.Fatalf("models = %+v, want only approved-chat", models)
+	}
+	if models[0].Name != "Team Chat" || models[0].Pricing == nil || models[0].Pricing.Currency != "USD" {
+		t.Fatalf("override not applied: %+v", models[0])
+	}
+
+	source, err := catalog.FindModel("approved-chat")
+	if err != nil {
+		t.Fatalf("FindModel: %v", err)
+	}
+	if source.Name != "Approved" {
+		t.Fatalf("view override leaked into catalog: name = %q", source.Name)
+	}
+
+	models, err = v.Models(catalog, "anthropic")
+	if err == nil && len(models) != 0 {
+		t.Fatalf("provider outside the view returned %d models", len(models))
+	}
+}
+
+func TestCompileRejectsInvalidDefinitions(t *testing.T) {
+	for name, def := range map[string]Definition{
+		"bad name":        {Name: "Frontend Team"},
+		"bad filter":      {Name: "team", Filter: "min_context=large"},
+		"bad review":      {Name: "team", Filter: "review=maybe"},
+		"pagination":      {Name: "team", Filter: "limit=5"},
+		"malformed query": {Name: "team", Filter: "a=%zz"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Compile(def); err == nil {
+				t.Fatal("Compile succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestNewSetRejectsDuplicateNames(t *testing.T) {
+	_, err := NewSet([]Definition{{Name: "team"}, {Name: "team"}})
+	var validation *errors.ValidationError
+	if !stderrors.As(err, &validation) {
+		t.Fatalf("NewSet error = %v, want ValidationError", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFilename)
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load of a missing explicit file succeeded")
+	}
+
+	if err := os.WriteFile(path, []byte(`views:
+  - name: zeta
+  - name: alpha
+    filter: provider=openai
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	set, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	views := set.List()
+	if len(views) != 2 || views[0].Name != "alpha" || views[1].Name != "zeta" {
+		t.Fatalf("views = %+v, want alpha, zeta", views)
+	}
+	var notFound *errors.NotFoundError
+	if _, err := set.Get("missing"); !stderrors.As(err, &notFound) {
+		t.Fatalf("Get error = %v, want NotFoundError", err)
+	}
+
+	if err := os.WriteFile(path, []byte("views:\n  - name: alpha\n    unknown: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load accepted an unknown field")
+	}
+}
+
+func TestDefaultPathUsesHomeDirectory(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath: %v", err)
+	}
+	if want := filepath.Join(home, ".starmap", DefaultFilename); path != want {
+		t.Fatalf("DefaultPath = %q, want %q", path, want)
+	}
+}
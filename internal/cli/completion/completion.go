@@ -169,7 +169,7 @@ func UninstallAll() error {
 	}
 
 	if len(errors) > 0 {
-		fmt.Printf(emoji.Error + " Some errors occurred:\n")
+		fmt.Println(emoji.Error + " Some errors occurred:")
 		for _, err := range errors {
 			fmt.Printf("  - %s\n", err)
 		}
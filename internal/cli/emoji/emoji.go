@@ -1,10 +1,15 @@
-// Package emoji provides symbol constants for CLI output.
+// Package emoji provides symbols for CLI output.
 // These symbols create a consistent visual language across all command-line commands.
+// In plain mode, for screen readers and terminals without Unicode support,
+// they are replaced with text labels.
 package emoji
 
-// Symbol constants for CLI output provide a consistent visual language across commands.
+import "os"
+
+// Symbols for CLI output provide a consistent visual language across commands.
 // These symbols are used for status indicators, alerts, and user feedback in terminal output.
-const (
+// They are variables so SetPlain can replace them; do not assign them directly.
+var (
 	// Success symbols indicate positive outcomes or configured states.
 
 	// Success represents successful completion of an operation.
@@ -49,3 +54,50 @@ const (
 	// Note: For animated spinners, use a dedicated spinner library.
 	Spinner = "..."
 )
+
+// symbols and labels hold the default symbols and their plain text labels,
+// in the order SetPlain assigns them.
+var (
+	symbols = [...]string{Success, Error, Stop, Warning, Optional, Unsupported, Unknown, Info, Spinner}
+	labels  = [...]string{"[ok]", "[error]", "[stop]", "[warning]", "-", "[unsupported]", "[unknown]", "[info]", "..."}
+	plain   bool
+)
+
+// SetPlain switches every symbol to a text label, such as "[ok]" for
+// Success, or back to the default symbols. Call it before any output is
+// written; it is not safe for concurrent use.
+func SetPlain(enabled bool) {
+	values := symbols
+	if enabled {
+		values = labels
+	}
+	plain = enabled
+	Success, Error, Stop, Warning, Optional, Unsupported, Unknown, Info, Spinner =
+		values[0], values[1], values[2], values[3], values[4], values[5], values[6], values[7], values[8]
+}
+
+// Plain reports whether symbols are text labels.
+func Plain() bool {
+	return plain
+}
+
+// Check returns Success when ok, or "-". In plain mode it returns "yes" or
+// "no", which read better than a label in a table cell.
+func Check(ok bool) string {
+	switch {
+	case plain && ok:
+		return "yes"
+	case plain:
+		return "no"
+	case ok:
+		return Success
+	default:
+		return "-"
+	}
+}
+
+// PlainRequested reports whether the environment asks for plain output:
+// STARMAP_NO_EMOJI is set to a non-empty value or TERM is "dumb".
+func PlainRequested() bool {
+	return os.Getenv("STARMAP_NO_EMOJI") != "" || os.Getenv("TERM") == "dumb"
+}
@@ -0,0 +1,23 @@
+package emoji
+
+import "testing"
+
+func TestSetPlain(t *testing.T) {
+	t.Cleanup(func() { SetPlain(false) })
+
+	SetPlain(true)
+	if Success != "[ok]" || Error != "[error]" || Warning != "[warning]" || !Plain() {
+		t.Fatalf("plain symbols = %q %q %q, want text labels", Success, Error, Warning)
+	}
+	if Check(true) != "yes" || Check(false) != "no" {
+		t.Fatalf("plain Check = %q/%q, want yes/no", Check(true), Check(false))
+	}
+
+	SetPlain(false)
+	if Success != "✓" || Error != "✗" || Plain() {
+		t.Fatalf("default symbols = %q %q, want restored", Success, Error)
+	}
+	if Check(true) != "✓" || Check(false) != "-" {
+		t.Fatalf("Check = %q/%q, want ✓/-", Check(true), Check(false))
+	}
+}
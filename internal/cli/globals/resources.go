@@ -19,12 +19,17 @@ func ParseResources(cmd *cobra.Command) *ResourceFlags {
 	author := mustGetString(cmd, "author")
 	search := mustGetString(cmd, "search")
 	limit := mustGetInt(cmd, "limit")
+	filter, err := cmd.Flags().GetStringArray("filter")
+	if err != nil {
+		panic("programming error: failed to get flag filter: " + err.Error())
+	}
 
 	return &ResourceFlags{
 		Provider: provider,
 		Author:   author,
 		Search:   search,
 		Limit:    limit,
+		Filter:   filter,
 	}
 }
 
@@ -40,8 +45,8 @@ func AddResourceFlags(cmd *cobra.Command) *ResourceFlags {
 		"Limit number of results")
 	cmd.Flags().StringVar(&flags.Search, "search", "",
 		"Search term to filter results")
-	cmd.Flags().StringSliceVar(&flags.Filter, "filter", nil,
-		"Filter expressions (e.g., 'context>100000')")
+	cmd.Flags().StringArrayVar(&flags.Filter, "filter", nil,
+		"Filter expression, repeatable (e.g., 'limits.context >= 100000')")
 	cmd.Flags().BoolVar(&flags.All, "all", false,
 		"Include all results (no filtering)")
 
@@ -0,0 +1,170 @@
+// Package locale formats numbers, prices, and dates in table output for the
+// user's locale. The locale is chosen once per command from --locale, the
+// locale config setting, or the LC_ALL, LC_NUMERIC, and LANG environment
+// variables, and defaults to American English. Machine-readable output such
+// as JSON and YAML is never localized.
+package locale
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Default is the locale used when none is configured.
+var Default = language.AmericanEnglish
+
+// EnvironmentVariables are the variables Detect reads, in precedence order.
+var EnvironmentVariables = []string{"LC_ALL", "LC_NUMERIC", "LANG"}
+
+type state struct {
+	tag     language.Tag
+	printer *message.Printer
+}
+
+var current atomic.Pointer[state]
+
+func init() {
+	Set(Default)
+}
+
+// Parse parses a BCP 47 tag such as de-DE or a POSIX locale such as
+// de_DE.UTF-8. The POSIX C and POSIX locales and an empty value parse as
+// Default.
+func Parse(value string) (language.Tag, error) {
+	value = strings.TrimSpace(value)
+	if i := strings.IndexAny(value, ".@"); i >= 0 {
+		value = value[:i]
+	}
+	if value == "" || value == "C" || value == "POSIX" {
+		return Default, nil
+	}
+	tag, err := language.Parse(strings.ReplaceAll(value, "_", "-"))
+	if err != nil {
+		return language.Und, &errors.ValidationError{Field: "locale", Value: value, Message: "must be a locale such as en-US or de_DE.UTF-8"}
+	}
+	return tag, nil
+}
+
+// Detect returns the locale named by the first set EnvironmentVariables
+// entry, or Default when none is set or the value does not parse.
+func Detect() language.Tag {
+	for _, name := range EnvironmentVariables {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		tag, err := Parse(value)
+		if err != nil {
+			return Default
+		}
+		return tag
+	}
+	return Default
+}
+
+// Set makes tag the locale of all later formatting.
+func Set(tag language.Tag) {
+	current.Store(&state{tag: tag, printer: message.NewPrinter(tag)})
+}
+
+// Current returns the locale in use.
+func Current() language.Tag {
+	return current.Load().tag
+}
+
+// Number formats n with the locale's digit grouping, such as 1,048,576 or
+// 1.048.576.
+func Number(n int64) string {
+	return current.Load().printer.Sprint(number.Decimal(n))
+}
+
+// Decimal formats v with digits fraction digits and the locale's separators.
+// Negative digits use as many as needed to represent v exactly.
+func Decimal(v float64, digits int) string {
+	if digits < 0 {
+		digits = 0
+		if s := strconv.FormatFloat(v, 'f', -1, 64); strings.Contains(s, ".") {
+			digits = len(s) - strings.Index(s, ".") - 1
+		}
+	}
+	return current.Load().printer.Sprint(number.Decimal(v, number.Scale(digits)))
+}
+
+// Money formats amount in the ISO 4217 currency code with digits fraction
+// digits, placing the currency symbol where the locale writes it, such as
+// $0.15 or 0,15 €. An empty code is USD; an unknown code is shown as is.
+func Money(amount float64, code string, digits int) string {
+	s := current.Load()
+	symbol := strings.ToUpper(strings.TrimSpace(code))
+	if symbol == "" {
+		symbol = "USD"
+	}
+	if unit, err := currency.ParseISO(symbol); err == nil {
+		symbol = s.printer.Sprint(currency.Symbol(unit))
+	}
+	value := Decimal(amount, digits)
+	if symbolFollows(s.tag) {
+		return value + " " + symbol
+	}
+	if len(symbol) > 1 && !strings.ContainsAny(symbol, "$€£¥₹₩") {
+		// Letter codes such as CHF read better apart from the amount.
+		return symbol + " " + value
+	}
+	return symbol + value
+}
+
+// Date formats the date of t in the locale's numeric order. American English
+// and locales without a known convention use ISO 8601 (2006-01-02).
+func Date(t time.Time) string {
+	return t.Format(dateLayout(Current()))
+}
+
+// DateTime formats t as Date followed by a 24-hour time.
+func DateTime(t time.Time) string {
+	return Date(t) + " " + t.Format("15:04")
+}
+
+func dateLayout(tag language.Tag) string {
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	switch base.String() {
+	case "en":
+		switch region.String() {
+		case "GB", "IE", "AU", "NZ", "IN", "ZA":
+			return "02/01/2006"
+		}
+	case "fr", "es", "it", "pt", "el", "vi":
+		return "02/01/2006"
+	case "de", "ru", "pl", "cs", "sk", "fi", "nb", "no", "da", "tr", "uk", "ro":
+		return "02.01.2006"
+	case "nl":
+		return "02-01-2006"
+	case "ja", "zh":
+		return "2006/01/02"
+	}
+	return time.DateOnly
+}
+
+// symbolFollows reports whether the locale writes the currency symbol after
+// the amount.
+func symbolFollows(tag language.Tag) bool {
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	switch base.String() {
+	case "de", "fr", "es", "it", "fi", "sv", "nb", "no", "da", "pl", "cs", "sk", "ru", "uk", "ro", "el", "vi":
+		return region.String() != "CH"
+	case "pt":
+		return region.String() != "BR"
+	}
+	return false
+}
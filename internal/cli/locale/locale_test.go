@@ -0,0 +1,82 @@
+package locale
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func TestParse(t *testing.T) {
+	for value, want := range map[string]language.Tag{
+		"":            Default,
+		"C":           Default,
+		"POSIX":       Default,
+		"C.UTF-8":     Default,
+		"de_DE.UTF-8": language.MustParse("de-DE"),
+		"fr-FR":       language.MustParse("fr-FR"),
+		"en_GB@euro":  language.BritishEnglish,
+	} {
+		got, err := Parse(value)
+		if err != nil || got != want {
+			t.Errorf("Parse(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	if _, err := Parse("not a locale!"); err == nil {
+		t.Error("Parse accepted an invalid locale")
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_NUMERIC", "de_DE.UTF-8")
+	t.Setenv("LANG", "fr_FR.UTF-8")
+	if got := Detect(); got != language.MustParse("de-DE") {
+		t.Fatalf("Detect = %v, want LC_NUMERIC's de-DE", got)
+	}
+	t.Setenv("LC_NUMERIC", "")
+	t.Setenv("LANG", "")
+	if got := Detect(); got != Default {
+		t.Fatalf("Detect = %v, want Default without locale variables", got)
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	t.Cleanup(func() { Set(Default) })
+	date := time.Date(2026, time.March, 4, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		tag    language.Tag
+		number string
+		money  string
+		euros  string
+		date   string
+	}{
+		{Default, "1,048,576", "$0.150000", "€1,234.50", "2026-03-04"},
+		{language.MustParse("de-DE"), "1.048.576", "0,150000 $", "1.234,50 €", "04.03.2026"},
+		{language.BritishEnglish, "1,048,576", "US$0.150000", "€1,234.50", "04/03/2026"},
+		{language.MustParse("de-CH"), "1’048’576", "$0.150000", "EUR 1’234.50", "04.03.2026"},
+	}
+	for _, tt := range tests {
+		Set(tt.tag)
+		if got := Number(1048576); got != tt.number {
+			t.Errorf("%v Number = %q, want %q", tt.tag, got, tt.number)
+		}
+		if got := Money(0.15, "", 6); got != tt.money {
+			t.Errorf("%v Money USD = %q, want %q", tt.tag, got, tt.money)
+		}
+		if got := Money(1234.5, "EUR", 2); got != tt.euros {
+			t.Errorf("%v Money EUR = %q, want %q", tt.tag, got, tt.euros)
+		}
+		if got := Date(date); got != tt.date {
+			t.Errorf("%v Date = %q, want %q", tt.tag, got, tt.date)
+		}
+	}
+}
+
+func TestDecimalExactDigits(t *testing.T) {
+	t.Cleanup(func() { Set(Default) })
+	Set(language.MustParse("de-DE"))
+	if got := Decimal(0.0000025, -1); got != "0,0000025" {
+		t.Fatalf("Decimal = %q, want every digit", got)
+	}
+}
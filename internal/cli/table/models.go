@@ -5,11 +5,11 @@ import (
 	"fmt"
 	"os"
 	"slices"
-	"strconv"
 	"strings"
 
 	"github.com/agentstation/starmap/internal/auth"
 	"github.com/agentstation/starmap/internal/cli/emoji"
+	"github.com/agentstation/starmap/internal/cli/locale"
 	"github.com/agentstation/starmap/pkg/catalogs"
 )
 
@@ -38,7 +38,7 @@ type Data struct {
 func ModelsToTableData(models []*catalogs.Model, showDetails bool) Data {
 	headers := []string{"ID", "Name", "Context", "Output", "Input Price", "Output Price"}
 	if showDetails {
-		headers = append(headers, "Features", "Authors", "Description")
+		headers = append(headers, "Speed", "Features", "Authors", "Description")
 	}
 
 	rows := make([][]string, 0, len(models))
@@ -71,7 +71,7 @@ func ModelsToTableData(models []*catalogs.Model, showDetails bool) Data {
 				description = "-"
 			}
 
-			row = append(row, features, authors, description)
+			row = append(row, FormatSpeed(model.Performance), features, authors, description)
 		}
 
 		rows = append(rows, row)
@@ -83,6 +83,14 @@ func ModelsToTableData(models []*catalogs.Model, showDetails bool) Data {
 	}
 }
 
+// FormatSpeed formats median output throughput in tokens per second.
+func FormatSpeed(performance *catalogs.ModelPerformance) string {
+	if performance == nil || performance.OutputTokensPerSecond <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f tok/s", performance.OutputTokensPerSecond)
+}
+
 // FormatTokens formats context window information.
 func FormatTokens(limits *catalogs.ModelLimits) string {
 	if limits == nil {
@@ -126,25 +134,12 @@ func FormatPrice(pricing *catalogs.ModelPricing, input bool) string {
 		return "-"
 	}
 
-	return fmt.Sprintf("$%.6f", cost)
+	return locale.Money(cost, string(pricing.Currency), 6)
 }
 
-// FormatNumber formats large numbers with comma separators.
+// FormatNumber formats large numbers with the locale's digit grouping.
 func FormatNumber(n int64) string {
-	str := strconv.FormatInt(n, 10)
-	if len(str) <= 3 {
-		return str
-	}
-
-	// Add commas every 3 digits
-	var result strings.Builder
-	for i, r := range str {
-		if i > 0 && (len(str)-i)%3 == 0 {
-			result.WriteString(",")
-		}
-		result.WriteRune(r)
-	}
-	return result.String()
+	return locale.Number(n)
 }
 
 // BuildFeaturesString creates a comma-separated list of model features.
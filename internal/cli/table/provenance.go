@@ -9,6 +9,7 @@ import (
 
 	"github.com/goccy/go-yaml"
 
+	"github.com/agentstation/starmap/internal/cli/locale"
 	"github.com/agentstation/starmap/pkg/provenance"
 )
 
@@ -210,5 +211,5 @@ func formatTimestamp(t time.Time) string {
 	}
 
 	// For older timestamps, show the date
-	return t.Format("2006-01-02 15:04")
+	return locale.DateTime(t)
 }
@@ -0,0 +1,387 @@
+// Package docscheck verifies the links and images in a tree of markdown
+// files: the hand-written guides under docs/ and the package READMEs that
+// gomarkdoc generates. Relative links must name a file that exists and, when
+// they carry a fragment, a heading or anchor in that file. Images such as
+// provider logos must exist. External URLs are only requested when asked, and
+// their results are cached so repeated runs stay fast.
+package docscheck
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Rule names the check that produced a finding.
+type Rule string
+
+// Documentation rules.
+const (
+	RuleLink     Rule = "link"     // Relative links name an existing file
+	RuleAnchor   Rule = "anchor"   // Link fragments name a heading or anchor in their file
+	RuleAsset    Rule = "asset"    // Images, such as provider logos, exist
+	RuleExternal Rule = "external" // External URLs respond without an error
+)
+
+// Severity is how CI treats a finding.
+type Severity string
+
+// Finding severities.
+const (
+	SeverityError   Severity = "error"   // Fails CI
+	SeverityWarning Severity = "warning" // Reported, but does not fail CI
+)
+
+// Finding is one broken link or image.
+type Finding struct {
+	Rule     Rule     `json:"rule" yaml:"rule"`
+	Severity Severity `json:"severity" yaml:"severity"`
+	File     string   `json:"file" yaml:"file"`
+	Line     int      `json:"line" yaml:"line"`
+	Target   string   `json:"target" yaml:"target"`
+	Message  string   `json:"message" yaml:"message"`
+}
+
+// Report is the result of checking a tree of markdown files.
+type Report struct {
+	Root      string    `json:"root" yaml:"root"`
+	Files     int       `json:"files" yaml:"files"`
+	Links     int       `json:"links" yaml:"links"`
+	External  int       `json:"external" yaml:"external"`
+	Findings  []Finding `json:"findings" yaml:"findings"`
+	CacheHits int       `json:"cache_hits,omitempty" yaml:"cache_hits,omitempty"`
+}
+
+// Errors returns the number of findings that fail CI.
+func (r *Report) Errors() int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+// Options configures Check.
+type Options struct {
+	// External requests every external URL. Without it external links are
+	// counted but not checked.
+	External bool
+
+	// Checker checks external URLs. Nil uses an HTTPChecker with defaults.
+	Checker *HTTPChecker
+}
+
+// skippedDirs are directories whose markdown is not shipped documentation.
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"testdata":     true,
+}
+
+// Check checks the markdown files under root, or root itself when it is a
+// file. Findings are sorted by file and line.
+func Check(ctx context.Context, root string, opts Options) (*Report, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, errors.WrapIO("stat", root, err)
+	}
+	report := &Report{Root: root, Findings: []Finding{}}
+	files := []string{root}
+	if info.IsDir() {
+		files, err = markdownFiles(root)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c := &checker{report: report, anchors: map[string]map[string]bool{}}
+	external := map[string][]Finding{}
+	for _, file := range files {
+		links, err := parseFile(file)
+		if err != nil {
+			return nil, err
+		}
+		report.Files++
+		for _, link := range links {
+			report.Links++
+			if isExternal(link.target) {
+				external[link.target] = append(external[link.target], Finding{File: file, Line: link.line, Target: link.target})
+				continue
+			}
+			c.checkLocal(file, link)
+		}
+	}
+	report.External = len(external)
+
+	if opts.External && len(external) > 0 {
+		checker := opts.Checker
+		if checker == nil {
+			checker = NewHTTPChecker()
+		}
+		results, hits, err := checker.CheckAll(ctx, slices.Sorted(maps.Keys(external)))
+		if err != nil {
+			return nil, err
+		}
+		report.CacheHits = hits
+		for target, result := range results {
+			if result.OK() {
+				continue
+			}
+			severity := SeverityError
+			if result.Transient() {
+				severity = SeverityWarning
+			}
+			for _, finding := range external[target] {
+				finding.Rule = RuleExternal
+				finding.Severity = severity
+				finding.Message = result.String()
+				report.Findings = append(report.Findings, finding)
+			}
+		}
+	}
+
+	slices.SortStableFunc(report.Findings, func(a, b Finding) int {
+		return cmp.Or(cmp.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line), cmp.Compare(a.Target, b.Target))
+	})
+	return report, nil
+}
+
+// markdownFiles returns the markdown files under root in lexical order,
+// skipping hidden and vendored directories.
+func markdownFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != root && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.EqualFold(filepath.Ext(path), ".md") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WrapIO("walk", root, err)
+	}
+	return files, nil
+}
+
+type checker struct {
+	report  *Report
+	anchors map[string]map[string]bool
+}
+
+func (c *checker) add(rule Rule, file string, link link, format string, args ...any) {
+	c.report.Findings = append(c.report.Findings, Finding{
+		Rule:     rule,
+		Severity: SeverityError,
+		File:     file,
+		Line:     link.line,
+		Target:   link.target,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// checkLocal checks a link or image that points into the tree.
+func (c *checker) checkLocal(file string, link link) {
+	target, fragment, _ := strings.Cut(link.target, "#")
+	if i := strings.IndexByte(target, '?'); i >= 0 {
+		target = target[:i]
+	}
+	if decoded, err := url.PathUnescape(target); err == nil {
+		target = decoded
+	}
+
+	resolved := file
+	if target != "" {
+		resolved = filepath.Join(filepath.Dir(file), filepath.FromSlash(target))
+		info, err := os.Stat(resolved)
+		switch {
+		case err != nil && link.image:
+			c.add(RuleAsset, file, link, "image %s does not exist", filepath.ToSlash(resolved))
+			return
+		case err != nil:
+			c.add(RuleLink, file, link, "%s does not exist", filepath.ToSlash(resolved))
+			return
+		case info.IsDir():
+			return
+		}
+	}
+	if fragment == "" || !strings.EqualFold(filepath.Ext(resolved), ".md") {
+		return
+	}
+	anchors, err := c.anchorsOf(resolved)
+	if err != nil {
+		c.add(RuleAnchor, file, link, "reading %s: %v", filepath.ToSlash(resolved), err)
+		return
+	}
+	if !anchors[strings.ToLower(fragment)] {
+		c.add(RuleAnchor, file, link, "%s has no heading or anchor #%s", filepath.ToSlash(resolved), fragment)
+	}
+}
+
+// anchorsOf returns the fragments a markdown file defines, caching them
+// per file.
+func (c *checker) anchorsOf(file string) (map[string]bool, error) {
+	if anchors, ok := c.anchors[file]; ok {
+		return anchors, nil
+	}
+	data, err := os.ReadFile(file) //nolint:gosec // Paths come from walking the checked tree.
+	if err != nil {
+		return nil, err
+	}
+	anchors := Anchors(data)
+	c.anchors[file] = anchors
+	return anchors, nil
+}
+
+// link is a link or image found in a markdown file.
+type link struct {
+	target string
+	line   int
+	image  bool
+}
+
+var (
+	inlineLink    = regexp.MustCompile(`(!?)\[(?:[^\[\]]|\[[^\[\]]*\])*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	referenceLink = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*<?(\S+?)>?(?:\s+"[^"]*")?\s*$`)
+	htmlLink      = regexp.MustCompile(`<(a|img)\b[^>]*?\s(href|src)\s*=\s*"([^"]+)"`)
+	htmlAnchor    = regexp.MustCompile(`<a\b[^>]*?\s(?:name|id)\s*=\s*"([^"]+)"`)
+	inlineCode    = regexp.MustCompile("`+[^`]*`+")
+	atxHeading    = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+)
+
+func parseFile(file string) ([]link, error) {
+	data, err := os.ReadFile(file) //nolint:gosec // Paths come from walking the checked tree.
+	if err != nil {
+		return nil, errors.WrapIO("read", file, err)
+	}
+	var links []link
+	eachLine(data, func(number int, line string) {
+		line = inlineCode.ReplaceAllString(line, "")
+		for _, match := range inlineLink.FindAllStringSubmatch(line, -1) {
+			links = append(links, link{target: match[2], line: number, image: match[1] == "!"})
+		}
+		if match := referenceLink.FindStringSubmatch(line); match != nil {
+			links = append(links, link{target: match[1], line: number})
+		}
+		for _, match := range htmlLink.FindAllStringSubmatch(line, -1) {
+			links = append(links, link{target: match[3], line: number, image: match[1] == "img"})
+		}
+	})
+	kept := links[:0]
+	for _, l := range links {
+		if checkable(l.target) {
+			kept = append(kept, l)
+		}
+	}
+	return kept, nil
+}
+
+// eachLine calls fn with each line outside fenced code blocks, numbered
+// from one.
+func eachLine(data []byte, fn func(number int, line string)) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	fence := ""
+	for number := 1; scanner.Scan(); number++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		fn(number, line)
+	}
+}
+
+// checkable reports whether a link target is a file, fragment, or web URL,
+// rather than a mail address or another scheme.
+func checkable(target string) bool {
+	if target == "" {
+		return false
+	}
+	if isExternal(target) {
+		return true
+	}
+	parsed, err := url.Parse(target)
+	return err == nil && parsed.Scheme == "" && parsed.Host == "" && !strings.HasPrefix(target, "/")
+}
+
+func isExternal(target string) bool {
+	return strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://")
+}
+
+// Anchors returns the fragments a markdown document defines: the slug of
+// each heading, as GitHub renders it, and each explicit <a name> or id
+// anchor. Fragments are lower case.
+func Anchors(data []byte) map[string]bool {
+	anchors := map[string]bool{}
+	used := map[string]int{}
+	eachLine(data, func(_ int, line string) {
+		for _, match := range htmlAnchor.FindAllStringSubmatch(line, -1) {
+			anchors[strings.ToLower(match[1])] = true
+		}
+		match := atxHeading.FindStringSubmatch(line)
+		if match == nil {
+			return
+		}
+		slug := Slug(match[2])
+		if n := used[slug]; n > 0 {
+			anchors[fmt.Sprintf("%s-%d", slug, n)] = true
+		} else {
+			anchors[slug] = true
+		}
+		used[slug]++
+	})
+	return anchors
+}
+
+var (
+	markdownLinkText = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	htmlTag          = regexp.MustCompile(`<[^>]+>`)
+)
+
+// Slug returns the fragment GitHub generates for a heading: the heading
+// text lower-cased, without markup or punctuation, with spaces as hyphens.
+func Slug(heading string) string {
+	heading = markdownLinkText.ReplaceAllString(heading, "$1")
+	heading = htmlTag.ReplaceAllString(heading, "")
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package docscheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestCheckReportsBrokenLocalLinks(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"README.md": "# Guide\n\n" +
+			"[ok](docs/CLI.md#update-command) [dup](docs/CLI.md#flags-1) [dir](docs)\n" +
+			"[self](#guide) ![logo](logos/openai.svg) <img src=\"logos/missing.svg\">\n" +
+			"[missing](docs/GONE.md) [bad anchor](docs/CLI.md#nope) `[code](docs/CODE.md)`\n" +
+			"[mail](mailto:team@example.com) [named](docs/CLI.md#Client.Save)\n" +
+			"```\n[fenced](docs/FENCED.md)\n```\n" +
+			"[ref]: docs/REF.md\n",
+		"docs/CLI.md":         "## Update Command\n\n### Flags\n\n### Flags\n\n<a name=\"Client.Save\"></a>\n",
+		"logos/openai.svg":    "<svg/>",
+		".hidden/BROKEN.md":   "[x](nowhere.md)",
+		"testdata/BROKEN.md":  "[x](nowhere.md)",
+		"docs/nested/Page.md": "[up](../CLI.md#update-command) [root](../../README.md#guide)",
+	})
+
+	report, err := Check(context.Background(), root, Options{})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if report.Files != 3 {
+		t.Fatalf("files = %d, want hidden and testdata directories skipped", report.Files)
+	}
+	want := []struct {
+		rule   Rule
+		target string
+	}{
+		{RuleAsset, "logos/missing.svg"},
+		{RuleAnchor, "docs/CLI.md#nope"},
+		{RuleLink, "docs/GONE.md"},
+		{RuleLink, "docs/REF.md"},
+	}
+	if len(report.Findings) != len(want) {
+		t.Fatalf("findings = %+v", report.Findings)
+	}
+	for i, finding := range report.Findings {
+		if finding.Rule != want[i].rule || finding.Target != want[i].target || finding.Severity != SeverityError {
+			t.Errorf("finding %d = %+v, want %s %s", i, finding, want[i].rule, want[i].target)
+		}
+	}
+	if report.Errors() != len(want) {
+		t.Fatalf("errors = %d", report.Errors())
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"Update Command":                  "update-command",
+		"`--catalog-dir` flag":            "--catalog-dir-flag",
+		"type [Client](<https://x/y#L1>)": "type-client",
+		"What's new? (v2.0)":              "whats-new-v20",
+		"Field_History <em>Tracking</em>": "field_history-tracking",
+	}
+	for heading, want := range tests {
+		if got := Slug(heading); got != want {
+			t.Errorf("Slug(%q) = %q, want %q", heading, got, want)
+		}
+	}
+}
+
+func TestCheckExternalCachesSuccessesOnly(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	count := func(path string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests[path]
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/ok":
+		case "/head-only-get":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/busy":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	root := writeFiles(t, map[string]string{
+		"README.md": "[a](" + server.URL + "/ok) [b](" + server.URL + "/gone)\n" +
+			"[c](" + server.URL + "/head-only-get) [d](" + server.URL + "/busy) [e](" + server.URL + "/ok)\n",
+	})
+	now := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	checker := &HTTPChecker{
+		Client:    server.Client(),
+		CachePath: filepath.Join(t.TempDir(), "links.json"),
+		Now:       func() time.Time { return now },
+	}
+
+	report, err := Check(context.Background(), root, Options{External: true, Checker: checker})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if report.External != 4 || len(report.Findings) != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+	if gone := report.Findings[0]; gone.Rule != RuleExternal || gone.Severity != SeverityError {
+		t.Fatalf("gone finding = %+v", gone)
+	}
+	if busy := report.Findings[1]; busy.Severity != SeverityWarning {
+		t.Fatalf("busy finding = %+v", busy)
+	}
+
+	if _, err := Check(context.Background(), root, Options{External: true, Checker: checker}); err != nil {
+		t.Fatalf("second Check: %v", err)
+	}
+	if count("/ok") != 1 || count("/gone") != 4 {
+		t.Fatalf("requests = %d ok, %d gone, want cached successes and rechecked failures", count("/ok"), count("/gone"))
+	}
+
+	now = now.Add(DefaultCacheTTL)
+	report, err = Check(context.Background(), root, Options{External: true, Checker: checker})
+	if err != nil {
+		t.Fatalf("third Check: %v", err)
+	}
+	if count("/ok") != 2 || report.CacheHits != 0 {
+		t.Fatalf("ok requests = %d, hits = %d, want expired entries rechecked", count("/ok"), report.CacheHits)
+	}
+}
+
+func TestCheckSkipsExternalByDefault(t *testing.T) {
+	root := writeFiles(t, map[string]string{"README.md": "[x](https://invalid.example/)"})
+	report, err := Check(context.Background(), root, Options{})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if report.External != 1 || len(report.Findings) != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+}
@@ -0,0 +1,205 @@
+package docscheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// DefaultCacheTTL is how long a checked external URL is trusted before it is
+// requested again.
+const DefaultCacheTTL = 24 * time.Hour
+
+// defaultConcurrency bounds how many external URLs are requested at once.
+const defaultConcurrency = 8
+
+// Result is the outcome of requesting one external URL.
+type Result struct {
+	Status    int       `json:"status,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// OK reports whether the URL responded without an error status.
+func (r Result) OK() bool {
+	return r.Error == "" && r.Status > 0 && r.Status < http.StatusBadRequest
+}
+
+// Transient reports whether the failure may pass on its own: a network
+// error, rate limiting, or a server error. Transient failures are warnings.
+func (r Result) Transient() bool {
+	return r.Error != "" || r.Status == http.StatusTooManyRequests || r.Status >= http.StatusInternalServerError
+}
+
+// String describes the result for a finding.
+func (r Result) String() string {
+	if r.Error != "" {
+		return "request failed: " + r.Error
+	}
+	return fmt.Sprintf("responded %d %s", r.Status, http.StatusText(r.Status))
+}
+
+// HTTPChecker requests external URLs, reusing recent results from a cache
+// file. Only successful results are cached, so a broken URL is checked again
+// on every run until it is fixed.
+type HTTPChecker struct {
+	// Client sends requests. Nil uses a client with constants.DefaultHTTPTimeout.
+	Client *http.Client
+
+	// CachePath is the JSON file results are kept in. Empty disables caching.
+	CachePath string
+
+	// CacheTTL is how long a cached result is reused. Zero uses DefaultCacheTTL.
+	CacheTTL time.Duration
+
+	// Concurrency bounds the requests in flight. Zero uses a default of 8.
+	Concurrency int
+
+	// Now returns the current time. Nil uses time.Now.
+	Now func() time.Time
+}
+
+// NewHTTPChecker returns a checker without a cache.
+func NewHTTPChecker() *HTTPChecker {
+	return &HTTPChecker{}
+}
+
+// CheckAll checks each URL and returns the results with the number served
+// from the cache.
+func (h *HTTPChecker) CheckAll(ctx context.Context, urls []string) (map[string]Result, int, error) {
+	now := time.Now
+	if h.Now != nil {
+		now = h.Now
+	}
+	ttl := h.CacheTTL
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	cache, err := h.loadCache()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make(map[string]Result, len(urls))
+	var pending []string
+	hits := 0
+	for _, target := range urls {
+		if cached, ok := cache[target]; ok && cached.OK() && now().Sub(cached.CheckedAt) < ttl {
+			results[target] = cached
+			hits++
+			continue
+		}
+		pending = append(pending, target)
+	}
+
+	concurrency := h.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+	for _, target := range pending {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result := h.check(ctx, target)
+			result.CheckedAt = now().UTC()
+			mu.Lock()
+			results[target] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	for target, result := range results {
+		if result.OK() {
+			cache[target] = result
+		} else {
+			delete(cache, target)
+		}
+	}
+	if err := h.saveCache(cache); err != nil {
+		return nil, 0, err
+	}
+	return results, hits, nil
+}
+
+// check requests target with HEAD, falling back to GET for servers that do
+// not answer HEAD requests.
+func (h *HTTPChecker) check(ctx context.Context, target string) Result {
+	client := h.Client
+	if client == nil {
+		client = &http.Client{Timeout: constants.DefaultHTTPTimeout}
+	}
+	var result Result
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequestWithContext(ctx, method, target, nil)
+		if err != nil {
+			return Result{Error: err.Error()}
+		}
+		req.Header.Set("User-Agent", "starmap-docs-verify")
+		resp, err := client.Do(req)
+		if err != nil {
+			result = Result{Error: err.Error()}
+			continue
+		}
+		_ = resp.Body.Close()
+		result = Result{Status: resp.StatusCode}
+		if result.OK() {
+			return result
+		}
+	}
+	return result
+}
+
+func (h *HTTPChecker) loadCache() (map[string]Result, error) {
+	cache := map[string]Result{}
+	if h.CachePath == "" {
+		return cache, nil
+	}
+	data, err := os.ReadFile(h.CachePath)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, errors.WrapIO("read", h.CachePath, err)
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		// A corrupt cache only costs a full recheck.
+		return map[string]Result{}, nil
+	}
+	return cache, nil
+}
+
+func (h *HTTPChecker) saveCache(cache map[string]Result) error {
+	if h.CachePath == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.CachePath), constants.DirPermissions); err != nil {
+		return errors.WrapIO("create", filepath.Dir(h.CachePath), err)
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return errors.WrapParse("json", h.CachePath, err)
+	}
+	if err := os.WriteFile(h.CachePath, data, constants.FilePermissions); err != nil {
+		return errors.WrapIO("write", h.CachePath, err)
+	}
+	return nil
+}
@@ -1,17 +1,17 @@
-// Package openapi embeds the OpenAPI 3.0 specification files for the Starmap HTTP API.
+// Package openapi embeds the OpenAPI 3.1 specification files for the Starmap HTTP API.
 // These files are embedded at build time and served by the API server at runtime.
 package openapi
 
 import _ "embed"
 
-// SpecJSON contains the OpenAPI 3.0 specification in JSON format.
-// Served at: GET /api/v1/openapi.json
+// SpecJSON contains the OpenAPI 3.1 specification in JSON format.
+// Served at: GET /openapi.json and GET /api/v1/openapi.json
 //
 //go:embed openapi.json
 var SpecJSON []byte
 
-// SpecYAML contains the OpenAPI 3.0 specification in YAML format.
-// Served at: GET /api/v1/openapi.yaml
+// SpecYAML contains the OpenAPI 3.1 specification in YAML format.
+// Served at: GET /openapi.yaml and GET /api/v1/openapi.yaml
 //
 //go:embed openapi.yaml
 var SpecYAML []byte
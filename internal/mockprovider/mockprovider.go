@@ -0,0 +1,210 @@
+// Package mockprovider serves a fake OpenAI-compatible provider API whose
+// models come from the catalog. Application test suites point their OpenAI
+// client at it to list realistic models, and optionally to receive canned
+// chat completions, without network access or API keys.
+package mockprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/convert"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// DefaultReply is the content of canned chat completions.
+const DefaultReply = "This is a mock response from starmap."
+
+// Config configures a mock provider.
+type Config struct {
+	Provider    catalogs.ProviderID // Provider whose models are served
+	Completions bool                // Serve canned chat completions
+	Reply       string              // Completion content (default DefaultReply)
+}
+
+// Server serves the mock provider API.
+type Server struct {
+	config Config
+	models []*catalogs.Model
+	now    func() time.Time
+}
+
+// New builds a mock of cfg.Provider from cat.
+func New(cat catalogs.Reader, cfg Config) (*Server, error) {
+	if cfg.Provider == "" {
+		return nil, &errors.ValidationError{Field: "provider", Message: "is required"}
+	}
+	provider, err := cat.Provider(cfg.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Reply == "" {
+		cfg.Reply = DefaultReply
+	}
+	models := make([]*catalogs.Model, 0, len(provider.Models))
+	for _, model := range provider.Models {
+		if model != nil {
+			models = append(models, model)
+		}
+	}
+	slices.SortFunc(models, func(a, b *catalogs.Model) int { return strings.Compare(a.ID, b.ID) })
+	return &Server{config: cfg, models: models, now: time.Now}, nil
+}
+
+// Models returns the number of models served.
+func (s *Server) Models() int {
+	return len(s.models)
+}
+
+// Handler returns the HTTP handler of the mock API. Routes are served with
+// and without the /v1 prefix, as OpenAI clients differ in their base URLs.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, prefix := range []string{"/v1", ""} {
+		mux.HandleFunc("GET "+prefix+"/models", s.handleListModels)
+		mux.HandleFunc("GET "+prefix+"/models/{id...}", s.handleGetModel)
+		if s.config.Completions {
+			mux.HandleFunc("POST "+prefix+"/chat/completions", s.handleChatCompletion)
+		}
+	}
+	return mux
+}
+
+func (s *Server) handleListModels(w http.ResponseWriter, _ *http.Request) {
+	data := make([]convert.OpenAIModel, 0, len(s.models))
+	for _, model := range s.models {
+		data = append(data, convert.ToOpenAIModel(model))
+	}
+	writeJSON(w, http.StatusOK, convert.OpenAIModelsResponse{Object: "list", Data: data})
+}
+
+func (s *Server) handleGetModel(w http.ResponseWriter, r *http.Request) {
+	model, ok := s.model(r.PathValue("id"))
+	if !ok {
+		writeModelNotFound(w, r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, convert.ToOpenAIModel(model))
+}
+
+// chatCompletionRequest holds the request fields the mock reads.
+type chatCompletionRequest struct {
+	Model    string            `json:"model"`
+	Messages []json.RawMessage `json:"messages"`
+	Stream   bool              `json:"stream"`
+}
+
+func (s *Server) handleChatCompletion(w http.ResponseWriter, r *http.Request) {
+	var request chatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "", "Invalid JSON body: "+err.Error())
+		return
+	}
+	if len(request.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "", "'messages' must contain at least one message")
+		return
+	}
+	model, ok := s.model(request.Model)
+	if !ok {
+		writeModelNotFound(w, request.Model)
+		return
+	}
+
+	id := fmt.Sprintf("chatcmpl-mock-%d", s.now().UnixNano())
+	created := s.now().Unix()
+	if request.Stream {
+		s.streamCompletion(w, id, created, model.ID)
+		return
+	}
+
+	promptTokens := 0
+	for _, message := range request.Messages {
+		promptTokens += estimateTokens(string(message))
+	}
+	completionTokens := estimateTokens(s.config.Reply)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":      id,
+		"object":  "chat.completion",
+		"created": created,
+		"model":   model.ID,
+		"choices": []map[string]any{{
+			"index":         0,
+			"message":       map[string]any{"role": "assistant", "content": s.config.Reply},
+			"finish_reason": "stop",
+		}},
+		"usage": map[string]int{
+			"prompt_tokens":     promptTokens,
+			"completion_tokens": completionTokens,
+			"total_tokens":      promptTokens + completionTokens,
+		},
+	})
+}
+
+// streamCompletion sends the reply as one content chunk and a finish chunk
+// in the server-sent events format of streamed chat completions.
+func (s *Server) streamCompletion(w http.ResponseWriter, id string, created int64, model string) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	chunk := func(delta map[string]any, finishReason any) map[string]any {
+		return map[string]any{
+			"id":      id,
+			"object":  "chat.completion.chunk",
+			"created": created,
+			"model":   model,
+			"choices": []map[string]any{{"index": 0, "delta": delta, "finish_reason": finishReason}},
+		}
+	}
+	for _, event := range []map[string]any{
+		chunk(map[string]any{"role": "assistant", "content": s.config.Reply}, nil),
+		chunk(map[string]any{}, "stop"),
+	} {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			return
+		}
+	}
+	_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+}
+
+func (s *Server) model(id string) (*catalogs.Model, bool) {
+	i, found := slices.BinarySearchFunc(s.models, id, func(model *catalogs.Model, id string) int {
+		return strings.Compare(model.ID, id)
+	})
+	if !found {
+		return nil, false
+	}
+	return s.models[i], true
+}
+
+// estimateTokens approximates a token count at four bytes per token.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+func writeModelNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, "invalid_request_error", "model_not_found",
+		fmt.Sprintf("The model '%s' does not exist or you do not have access to it.", id))
+}
+
+// writeError writes an error in the OpenAI error format.
+func writeError(w http.ResponseWriter, status int, errorType, code, message string) {
+	body := map[string]any{"message": message, "type": errorType, "param": nil, "code": nil}
+	if code != "" {
+		body["code"] = code
+	}
+	writeJSON(w, status, map[string]any{"error": body})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
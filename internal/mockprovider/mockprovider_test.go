@@ -0,0 +1,139 @@
+package mockprovider
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/convert"
+)
+
+func newTestServer(t *testing.T, cfg Config) *httptest.Server {
+	t.Helper()
+	cat := catalogs.NewEmpty()
+	if err := cat.SetProvider(catalogs.Provider{ID: "openai", Name: "OpenAI", Models: map[string]*catalogs.Model{
+		"gpt-b": {ID: "gpt-b", Name: "GPT B", Authors: []catalogs.Author{{ID: "openai"}}},
+		"gpt-a": {ID: "gpt-a", Name: "GPT A", Authors: []catalogs.Author{{ID: "openai"}}},
+	}}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	mock, err := New(cat, cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	mock.now = func() time.Time { return time.Unix(1700000000, 0) }
+	server := httptest.NewServer(mock.Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func do(t *testing.T, method, url, body string) *http.Response {
+	t.Helper()
+	request, err := http.NewRequestWithContext(t.Context(), method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	t.Cleanup(func() { _ = response.Body.Close() })
+	return response
+}
+
+func TestNewRejectsUnknownProvider(t *testing.T) {
+	if _, err := New(catalogs.NewEmpty(), Config{Provider: "missing"}); err == nil {
+		t.Fatal("New: expected an error for an unknown provider")
+	}
+	if _, err := New(catalogs.NewEmpty(), Config{}); err == nil {
+		t.Fatal("New: expected an error without a provider")
+	}
+}
+
+func TestModelsEndpoints(t *testing.T) {
+	server := newTestServer(t, Config{Provider: "openai"})
+
+	for _, path := range []string{"/v1/models", "/models"} {
+		var list convert.OpenAIModelsResponse
+		if err := json.NewDecoder(do(t, http.MethodGet, server.URL+path, "").Body).Decode(&list); err != nil {
+			t.Fatalf("decode %s: %v", path, err)
+		}
+		if list.Object != "list" || len(list.Data) != 2 || list.Data[0].ID != "gpt-a" || list.Data[0].OwnedBy != "openai" {
+			t.Errorf("%s = %+v, want gpt-a and gpt-b owned by openai", path, list)
+		}
+	}
+
+	if response := do(t, http.MethodGet, server.URL+"/v1/models/gpt-b", ""); response.StatusCode != http.StatusOK {
+		t.Errorf("get model status = %d, want %d", response.StatusCode, http.StatusOK)
+	}
+	response := do(t, http.MethodGet, server.URL+"/v1/models/missing", "")
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if response.StatusCode != http.StatusNotFound || body.Error.Code != "model_not_found" {
+		t.Errorf("missing model = %d %q, want 404 model_not_found", response.StatusCode, body.Error.Code)
+	}
+
+	if response := do(t, http.MethodPost, server.URL+"/v1/chat/completions", `{}`); response.StatusCode != http.StatusNotFound {
+		t.Errorf("completions without --completions status = %d, want 404", response.StatusCode)
+	}
+}
+
+func TestChatCompletions(t *testing.T) {
+	server := newTestServer(t, Config{Provider: "openai", Completions: true, Reply: "pong"})
+	url := server.URL + "/v1/chat/completions"
+
+	var completion struct {
+		Model   string `json:"model"`
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	response := do(t, http.MethodPost, url, `{"model":"gpt-a","messages":[{"role":"user","content":"ping"}]}`)
+	if err := json.NewDecoder(response.Body).Decode(&completion); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if completion.Model != "gpt-a" || len(completion.Choices) != 1 || completion.Choices[0].Message.Content != "pong" || completion.Usage.CompletionTokens != 1 {
+		t.Errorf("completion = %+v", completion)
+	}
+
+	stream := do(t, http.MethodPost, url, `{"model":"gpt-a","stream":true,"messages":[{"role":"user","content":"ping"}]}`)
+	if got := stream.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("stream Content-Type = %q", got)
+	}
+	var events []string
+	scanner := bufio.NewScanner(stream.Body)
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			events = append(events, data)
+		}
+	}
+	if len(events) != 3 || !strings.Contains(events[0], `"content":"pong"`) || events[2] != "[DONE]" {
+		t.Errorf("stream events = %q", events)
+	}
+
+	for body, want := range map[string]int{
+		`{"model":"missing","messages":[{"role":"user","content":"ping"}]}`: http.StatusNotFound,
+		`{"model":"gpt-a","messages":[]}`:                                   http.StatusBadRequest,
+		`not json`:                                                          http.StatusBadRequest,
+	} {
+		if response := do(t, http.MethodPost, url, body); response.StatusCode != want {
+			t.Errorf("%s status = %d, want %d", body, response.StatusCode, want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Package paths resolves the ~-relative file locations used by starmap
+// defaults and command flags.
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// ExpandHome replaces a leading ~ or ~/ with the user's home directory.
+// Other paths, and every path when the home directory cannot be determined,
+// are returned unchanged.
+func ExpandHome(path string) string {
+	expanded, err := ResolveHome(path)
+	if err != nil {
+		return path
+	}
+	return expanded
+}
+
+// ResolveHome is ExpandHome for callers that must not fall back to a
+// literal ~ path: it reports a home directory that cannot be determined.
+func ResolveHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.WrapResource("resolve", "home directory", path, err)
+	}
+	if path == "~" {
+		return home, nil
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~/")), nil
+}
@@ -0,0 +1,36 @@
+package paths
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{"~", home},
+		{"~/.starmap/cache", filepath.Join(home, ".starmap", "cache")},
+		{"/etc/starmap", "/etc/starmap"},
+		{"relative/dir", "relative/dir"},
+		{"~other/dir", "~other/dir"},
+	} {
+		if got := ExpandHome(tt.path); got != tt.want {
+			t.Errorf("ExpandHome(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestResolveHomeReportsUnknownHome(t *testing.T) {
+	t.Setenv("HOME", "")
+	if _, err := ResolveHome("~/.starmap"); err == nil {
+		t.Fatal("ResolveHome succeeded without a home directory")
+	}
+	if got, err := ResolveHome("/abs"); err != nil || got != "/abs" {
+		t.Fatalf("ResolveHome(/abs) = %q, %v", got, err)
+	}
+}
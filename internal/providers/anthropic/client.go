@@ -5,7 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,9 +20,19 @@ import (
 	"github.com/agentstation/starmap/pkg/sourcepayload"
 )
 
+// modelsPageLimit is the largest page the models endpoint returns, and
+// maxModelsPages bounds how many pages one listing follows.
+const (
+	modelsPageLimit = 1000
+	maxModelsPages  = 20
+)
+
 // Response structures for Anthropic API.
 type modelsResponse struct {
 	Data          []modelResponse                  `json:"data"`
+	FirstID       string                           `json:"first_id,omitempty"`
+	LastID        string                           `json:"last_id,omitempty"`
+	HasMore       bool                             `json:"has_more,omitempty"`
 	UnknownFields []sourcepayload.UnknownJSONField `json:"-"`
 }
 
@@ -152,15 +165,57 @@ func (c *Client) ListModels(ctx context.Context) ([]catalogs.Model, error) {
 	}
 
 	// Build URL - use provider's URL if available, otherwise use default
-	url := "https://api.anthropic.com/v1/models"
+	endpoint := "https://api.anthropic.com/v1/models"
 	if rb := transport.NewRequestBuilder(provider); rb.GetBaseURL() != "" {
-		url = rb.GetBaseURL()
+		endpoint = rb.GetBaseURL()
+	}
+
+	// Follow the paginated listing until the API reports no more models
+	listed, err := transport.Paginate(ctx, provider.ID.String(), maxModelsPages,
+		func(m modelResponse) string { return m.ID },
+		func(ctx context.Context, afterID string) (transport.Page[modelResponse], error) {
+			result, err := c.fetchModelsPage(ctx, provider, endpoint, afterID)
+			if err != nil {
+				return transport.Page[modelResponse]{}, err
+			}
+			page := transport.Page[modelResponse]{Items: result.Data}
+			for i := range page.Items {
+				page.Items[i].UnknownFields = append(page.Items[i].UnknownFields, result.UnknownFields...)
+			}
+			if result.HasMore {
+				page.Next = result.LastID
+			}
+			return page, nil
+		})
+	if listed == nil && err != nil {
+		return nil, err
+	}
+
+	// A partial listing keeps the models of the pages fetched so far
+	models := make([]catalogs.Model, 0, len(listed))
+	for _, m := range listed {
+		models = append(models, *c.convertToModel(m))
+	}
+	return models, err
+}
+
+// fetchModelsPage fetches the page of models after afterID, or the first page.
+func (c *Client) fetchModelsPage(ctx context.Context, provider *catalogs.Provider, endpoint, afterID string) (*modelsResponse, error) {
+	pageURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.WrapResource("parse", "url", endpoint, err)
+	}
+	query := pageURL.Query()
+	query.Set("limit", strconv.Itoa(modelsPageLimit))
+	if afterID != "" {
+		query.Set("after_id", afterID)
 	}
+	pageURL.RawQuery = query.Encode()
 
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL.String(), nil)
 	if err != nil {
-		return nil, errors.WrapResource("create", "request", url, err)
+		return nil, errors.WrapResource("create", "request", endpoint, err)
 	}
 
 	// Add Anthropic-specific headers
@@ -171,7 +226,7 @@ func (c *Client) ListModels(ctx context.Context) ([]catalogs.Model, error) {
 	if err != nil {
 		return nil, &errors.APIError{
 			Provider: "anthropic",
-			Endpoint: url,
+			Endpoint: endpoint,
 			Message:  "request failed",
 			Err:      err,
 		}
@@ -186,16 +241,7 @@ func (c *Client) ListModels(ctx context.Context) ([]catalogs.Model, error) {
 	if result.Data == nil {
 		return nil, errors.NewParseError("json", "anthropic response", "required data array is missing or null", nil)
 	}
-
-	// Convert Anthropic models to starmap models
-	models := make([]catalogs.Model, 0, len(result.Data))
-	for _, m := range result.Data {
-		m.UnknownFields = append(m.UnknownFields, result.UnknownFields...)
-		model := c.convertToModel(m)
-		models = append(models, *model)
-	}
-
-	return models, nil
+	return &result, nil
 }
 
 // convertToModel converts an Anthropic model response to a starmap Model.
@@ -215,6 +261,7 @@ func (c *Client) convertToModel(m modelResponse) *catalogs.Model {
 	model.Authors = []catalogs.Author{
 		{ID: catalogs.AuthorIDAnthropic, Name: "Anthropic"},
 	}
+	model.Lineage = &catalogs.ModelLineage{Family: family(m.ID)}
 
 	// Set basic features based on model ID patterns
 	// Note: Detailed limits and pricing will be enhanced by models.dev integration
@@ -350,6 +397,22 @@ func (c *Client) extensionSource() string {
 	return catalogs.ProviderIDAnthropic.String()
 }
 
+// family returns the model family named by a Claude model ID, such as
+// claude-opus for claude-opus-4-1-20250805 and claude-haiku for
+// claude-3-haiku-20240307. IDs without a tier name belong to claude.
+func family(modelID string) string {
+	parts := strings.Split(strings.ToLower(modelID), "-")
+	if len(parts) == 0 || parts[0] != "claude" {
+		return "claude"
+	}
+	for _, part := range parts[1:] {
+		if strings.Trim(strings.TrimPrefix(part, "v"), "0123456789.") != "" {
+			return "claude-" + part
+		}
+	}
+	return "claude"
+}
+
 // inferFeatures infers model features based on the model ID.
 func (c *Client) inferFeatures(modelID string) *catalogs.ModelFeatures {
 	features := &catalogs.ModelFeatures{
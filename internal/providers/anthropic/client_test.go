@@ -91,6 +91,60 @@ func TestAnthropicParsing(t *testing.T) {
 	}
 }
 
+func TestListModelsFollowsPagination(t *testing.T) {
+	var afterIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		afterIDs = append(afterIDs, r.URL.Query().Get("after_id"))
+		if r.URL.Query().Get("limit") != "1000" {
+			t.Errorf("limit = %q, want 1000", r.URL.Query().Get("limit"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("after_id") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"claude-opus-4-1-20250805","display_name":"Claude Opus 4.1"}],"first_id":"claude-opus-4-1-20250805","last_id":"claude-opus-4-1-20250805","has_more":true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"claude-3-haiku-20240307","display_name":"Claude Haiku 3"}],"first_id":"claude-3-haiku-20240307","last_id":"claude-3-haiku-20240307","has_more":false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(&catalogs.Provider{
+		ID: catalogs.ProviderIDAnthropic, Name: "Anthropic",
+		Catalog: &catalogs.ProviderCatalog{Endpoint: catalogs.ProviderEndpoint{Type: catalogs.EndpointTypeAnthropic, URL: server.URL}},
+	})
+	models, err := client.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels: %v", err)
+	}
+	if !slices.Equal(afterIDs, []string{"", "claude-opus-4-1-20250805"}) {
+		t.Fatalf("after_id requests = %q, want first page then the last ID", afterIDs)
+	}
+	if len(models) != 2 {
+		t.Fatalf("models = %d, want both pages", len(models))
+	}
+	for _, model := range models {
+		if _, ok := model.Extensions["anthropic"].Fields["unknown_fields"]; ok {
+			t.Errorf("model %s recorded pagination fields as unknown", model.ID)
+		}
+	}
+	if models[0].Lineage.Family != "claude-opus" || models[1].Lineage.Family != "claude-haiku" {
+		t.Errorf("families = %q, %q, want claude-opus and claude-haiku", models[0].Lineage.Family, models[1].Lineage.Family)
+	}
+}
+
+func TestFamily(t *testing.T) {
+	for id, want := range map[string]string{
+		"claude-opus-4-1-20250805":   "claude-opus",
+		"claude-3-5-sonnet-20241022": "claude-sonnet",
+		"claude-fable-5":             "claude-fable",
+		"claude-2.1":                 "claude",
+		"unknown-model":              "claude",
+	} {
+		if got := family(id); got != want {
+			t.Errorf("family(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
 func TestAnthropicModelConversion(t *testing.T) {
 	// Load testdata and convert to starmap models
 	response := loadTestdataResponse(t, "models_list.json")
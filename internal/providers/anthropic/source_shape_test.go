@@ -50,6 +50,10 @@ func TestAnthropicProviderShapePathsAreClassified(t *testing.T) {
 
 func anthropicPathDecisions() map[string]anthropicPathDecision {
 	return map[string]anthropicPathDecision{
+		"first_id": {outcome: anthropicOutcomeIgnored, note: "pagination cursor"},
+		"last_id":  {outcome: anthropicOutcomeIgnored, note: "pagination cursor followed with after_id"},
+		"has_more": {outcome: anthropicOutcomeIgnored, note: "pagination flag"},
+
 		"data.[].type":         {outcome: anthropicOutcomeIgnored, note: "provider response object type"},
 		"data.[].id":           {outcome: anthropicOutcomeCanonical, note: "model ID"},
 		"data.[].display_name": {outcome: anthropicOutcomeCanonical, note: "model display name"},
@@ -147,5 +151,8 @@ const anthropicProviderShapeFixture = `{
         }
       }
     }
-  ]
+  ],
+  "first_id": "claude-sonnet-4-5",
+  "has_more": false,
+  "last_id": "claude-sonnet-4-5"
 }`
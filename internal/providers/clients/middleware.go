@@ -0,0 +1,396 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/logging"
+)
+
+// Middleware wraps a provider client to add behavior around its calls, such
+// as logging or retries, so the behavior is implemented once for every
+// provider instead of in each client.
+type Middleware func(provider *catalogs.Provider, next ProviderClient) ProviderClient
+
+// Chain wraps client in middleware. The first middleware is the outermost:
+// it sees each call first and its result last.
+func Chain(provider *catalogs.Provider, client ProviderClient, middleware ...Middleware) ProviderClient {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		client = middleware[i](provider, client)
+	}
+	return client
+}
+
+var registry = struct {
+	mu         sync.RWMutex
+	middleware []Middleware
+}{middleware: DefaultMiddleware()}
+
+// DefaultMiddleware returns the chain NewProvider applies to every client:
+// logging, DefaultMetrics, per-provider circuit breaking, retries of
+// rate-limited and unavailable responses, and per-provider rate limiting of
+// each attempt.
+func DefaultMiddleware() []Middleware {
+	return []Middleware{
+		Logging(),
+		DefaultMetrics.Middleware(),
+		CircuitBreaker(constants.CircuitBreakerThreshold, constants.CircuitBreakerCooldown),
+		Retry(constants.MaxRateLimitRetries, constants.RateLimitRetryDelay),
+		RateLimit(constants.DefaultRateLimit, constants.BurstSize),
+	}
+}
+
+// Use appends middleware to the chain NewProvider applies, inside the
+// existing middleware. It returns a restore function intended for tests and
+// temporary integrations.
+func Use(middleware ...Middleware) func() {
+	registry.mu.Lock()
+	previous := registry.middleware
+	registry.middleware = append(previous[:len(previous):len(previous)], middleware...)
+	registry.mu.Unlock()
+
+	return func() {
+		registry.mu.Lock()
+		registry.middleware = previous
+		registry.mu.Unlock()
+	}
+}
+
+func registeredMiddleware() []Middleware {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.middleware
+}
+
+// middlewareClient replaces the ListModels call of the client it embeds.
+type middlewareClient struct {
+	ProviderClient
+	listModels func(ctx context.Context) ([]catalogs.Model, error)
+}
+
+func (c middlewareClient) ListModels(ctx context.Context) ([]catalogs.Model, error) {
+	return c.listModels(ctx)
+}
+
+// Unwrap returns the client the middleware wraps.
+func (c middlewareClient) Unwrap() ProviderClient { return c.ProviderClient }
+
+// Unwrap returns the provider's own client beneath any middleware.
+func Unwrap(client ProviderClient) ProviderClient {
+	for {
+		wrapped, ok := client.(interface{ Unwrap() ProviderClient })
+		if !ok {
+			return client
+		}
+		client = wrapped.Unwrap()
+	}
+}
+
+// Logging logs each ListModels call with its duration and model count.
+func Logging() Middleware {
+	return func(provider *catalogs.Provider, next ProviderClient) ProviderClient {
+		return middlewareClient{ProviderClient: next, listModels: func(ctx context.Context) ([]catalogs.Model, error) {
+			logger := logging.FromContext(logging.WithProvider(ctx, string(provider.ID)))
+			start := time.Now()
+			models, err := next.ListModels(ctx)
+			if err != nil {
+				logger.Debug().Err(err).Dur("duration", time.Since(start)).Int("model_count", len(models)).Msg("Provider list models failed")
+				return models, err // Partial listings keep their models
+			}
+			logger.Debug().Dur("duration", time.Since(start)).Int("model_count", len(models)).Msg("Provider listed models")
+			return models, nil
+		}}
+	}
+}
+
+// Retry retries ListModels calls that fail because the provider is rate
+// limiting (429) or unavailable (5xx), up to retries times. The wait starts at
+// delay and doubles after each attempt, with random jitter of up to half the
+// wait so clients retrying together spread out. A provider's
+// catalog.endpoint.requests max_retries and retry_delay override retries and
+// delay.
+func Retry(retries int, delay time.Duration) Middleware {
+	return func(provider *catalogs.Provider, next ProviderClient) ProviderClient {
+		retries, delay := retries, delay
+		if policy := requestPolicy(provider); policy != nil {
+			if policy.MaxRetries != nil {
+				retries = *policy.MaxRetries
+			}
+			if policy.RetryDelay != nil {
+				delay = *policy.RetryDelay
+			}
+		}
+		return middlewareClient{ProviderClient: next, listModels: func(ctx context.Context) ([]catalogs.Model, error) {
+			wait := delay
+			for attempt := 0; ; attempt++ {
+				models, err := next.ListModels(ctx)
+				if err == nil || attempt >= retries || !(errors.IsRateLimited(err) || errors.IsProviderUnavailable(err)) {
+					return models, err
+				}
+				jittered := wait - rand.N(wait/2+1) //nolint:gosec // Jitter does not need a secure source.
+				logging.FromContext(logging.WithProvider(ctx, string(provider.ID))).Debug().
+					Err(err).
+					Int("attempt", attempt+1).
+					Dur("wait", jittered).
+					Msg("Retrying provider list models")
+				timer := time.NewTimer(jittered)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return nil, err
+				case <-timer.C:
+				}
+				wait *= 2
+			}
+		}}
+	}
+}
+
+// RateLimit spaces ListModels calls to each provider to perMinute calls a
+// minute, allowing bursts of burst calls. The limit is shared by every client
+// the middleware wraps for the same provider. A provider's
+// catalog.endpoint.requests requests_per_minute and burst override perMinute
+// and burst.
+func RateLimit(perMinute, burst int) Middleware {
+	var mu sync.Mutex
+	buckets := map[catalogs.ProviderID]*tokenBucket{}
+	return func(provider *catalogs.Provider, next ProviderClient) ProviderClient {
+		perMinute, burst := perMinute, burst
+		if policy := requestPolicy(provider); policy != nil {
+			if policy.RequestsPerMinute > 0 {
+				perMinute = policy.RequestsPerMinute
+			}
+			if policy.Burst > 0 {
+				burst = policy.Burst
+			}
+		}
+		mu.Lock()
+		bucket, ok := buckets[provider.ID]
+		if !ok {
+			bucket = &tokenBucket{tokens: float64(burst), burst: float64(burst), interval: time.Minute / time.Duration(perMinute), last: time.Now()}
+			buckets[provider.ID] = bucket
+		}
+		mu.Unlock()
+		return middlewareClient{ProviderClient: next, listModels: func(ctx context.Context) ([]catalogs.Model, error) {
+			if err := bucket.wait(ctx); err != nil {
+				return nil, err
+			}
+			return next.ListModels(ctx)
+		}}
+	}
+}
+
+// CircuitBreaker stops calling a provider after threshold consecutive failed
+// ListModels calls. For cooldown afterwards, calls fail at once with an error
+// that reports the provider unavailable; the first call after the cooldown is
+// let through and closes the circuit when it succeeds. The circuit is shared
+// by every client the middleware wraps for the same provider. A provider's
+// catalog.endpoint.requests failure_threshold and circuit_cooldown override
+// threshold and cooldown; a threshold of 0 disables the breaker.
+func CircuitBreaker(threshold int, cooldown time.Duration) Middleware {
+	var mu sync.Mutex
+	circuits := map[catalogs.ProviderID]*circuit{}
+	return func(provider *catalogs.Provider, next ProviderClient) ProviderClient {
+		threshold, cooldown := threshold, cooldown
+		if policy := requestPolicy(provider); policy != nil {
+			if policy.FailureThreshold != nil {
+				threshold = *policy.FailureThreshold
+			}
+			if policy.CircuitCooldown != nil {
+				cooldown = *policy.CircuitCooldown
+			}
+		}
+		if threshold <= 0 {
+			return next
+		}
+		mu.Lock()
+		state, ok := circuits[provider.ID]
+		if !ok {
+			state = &circuit{}
+			circuits[provider.ID] = state
+		}
+		mu.Unlock()
+		return middlewareClient{ProviderClient: next, listModels: func(ctx context.Context) ([]catalogs.Model, error) {
+			if until, open := state.open(); open {
+				return nil, &errors.APIError{
+					Provider:   string(provider.ID),
+					StatusCode: http.StatusServiceUnavailable,
+					Message:    fmt.Sprintf("circuit open after %d consecutive failures; retrying after %s", threshold, until.Format(time.RFC3339)),
+				}
+			}
+			models, err := next.ListModels(ctx)
+			if err != nil && ctx.Err() != nil {
+				return models, err // The caller gave up; the provider did not fail.
+			}
+			state.record(err == nil, threshold, cooldown)
+			return models, err
+		}}
+	}
+}
+
+// circuit counts consecutive failures of one provider.
+type circuit struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// open reports whether calls are rejected, and until when.
+func (c *circuit) open() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.openUntil, time.Now().Before(c.openUntil)
+}
+
+// record counts a call, opening the circuit for cooldown once threshold
+// consecutive calls have failed.
+func (c *circuit) record(success bool, threshold int, cooldown time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if success {
+		c.failures = 0
+		c.openUntil = time.Time{}
+		return
+	}
+	c.failures++
+	if c.failures >= threshold {
+		c.openUntil = time.Now().Add(cooldown)
+	}
+}
+
+// requestPolicy returns the provider's configured request policy, or nil.
+func requestPolicy(provider *catalogs.Provider) *catalogs.ProviderRequestPolicy {
+	if provider == nil || provider.Catalog == nil {
+		return nil
+	}
+	return provider.Catalog.Endpoint.Requests
+}
+
+// tokenBucket refills one token every interval up to burst tokens.
+type tokenBucket struct {
+	mu       sync.Mutex
+	tokens   float64
+	burst    float64
+	interval time.Duration
+	last     time.Time
+}
+
+// wait takes a token, waiting for one to refill when the bucket is empty.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+float64(now.Sub(b.last))/float64(b.interval))
+	b.last = now
+	b.tokens--
+	delay := time.Duration(0)
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens * float64(b.interval))
+	}
+	b.mu.Unlock()
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Cache reuses each provider's models for ttl within this process. Unlike
+// the on-disk fetch cache of the providers source, it is not shared across
+// commands; it is not part of DefaultMiddleware.
+func Cache(ttl time.Duration) Middleware {
+	var mu sync.Mutex
+	entries := map[string]cachedModels{}
+	return func(provider *catalogs.Provider, next ProviderClient) ProviderClient {
+		apiKey, _ := provider.APIKeyValue()
+		key := string(provider.ID) + "\x00" + provider.CatalogEndpointURL() + "\x00" + apiKey
+		return middlewareClient{ProviderClient: next, listModels: func(ctx context.Context) ([]catalogs.Model, error) {
+			mu.Lock()
+			entry, ok := entries[key]
+			mu.Unlock()
+			if ok && time.Since(entry.fetchedAt) <= ttl {
+				return append([]catalogs.Model(nil), entry.models...), nil
+			}
+			models, err := next.ListModels(ctx)
+			if err != nil {
+				return models, err // Partial listings are not cached
+			}
+			mu.Lock()
+			entries[key] = cachedModels{models: append([]catalogs.Model(nil), models...), fetchedAt: time.Now()}
+			mu.Unlock()
+			return models, nil
+		}}
+	}
+}
+
+type cachedModels struct {
+	models    []catalogs.Model
+	fetchedAt time.Time
+}
+
+// ProviderMetrics counts the ListModels calls to one provider.
+type ProviderMetrics struct {
+	Calls    int           // Calls, including failed ones
+	Errors   int           // Failed calls
+	Models   int           // Models returned by successful calls
+	Duration time.Duration // Total time spent in calls
+}
+
+// Metrics collects ProviderMetrics per provider.
+type Metrics struct {
+	mu         sync.Mutex
+	byProvider map[catalogs.ProviderID]ProviderMetrics
+}
+
+// DefaultMetrics collects the metrics of clients created by NewProvider.
+var DefaultMetrics = NewMetrics()
+
+// NewMetrics returns an empty metrics collector.
+func NewMetrics() *Metrics {
+	return &Metrics{byProvider: map[catalogs.ProviderID]ProviderMetrics{}}
+}
+
+// Middleware records the ListModels calls of the clients it wraps.
+func (m *Metrics) Middleware() Middleware {
+	return func(provider *catalogs.Provider, next ProviderClient) ProviderClient {
+		return middlewareClient{ProviderClient: next, listModels: func(ctx context.Context) ([]catalogs.Model, error) {
+			start := time.Now()
+			models, err := next.ListModels(ctx)
+			m.mu.Lock()
+			metrics := m.byProvider[provider.ID]
+			metrics.Calls++
+			metrics.Duration += time.Since(start)
+			if err != nil {
+				metrics.Errors++
+			} else {
+				metrics.Models += len(models)
+			}
+			m.byProvider[provider.ID] = metrics
+			m.mu.Unlock()
+			return models, err
+		}}
+	}
+}
+
+// Snapshot returns the metrics recorded so far, by provider.
+func (m *Metrics) Snapshot() map[catalogs.ProviderID]ProviderMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := make(map[catalogs.ProviderID]ProviderMetrics, len(m.byProvider))
+	for id, metrics := range m.byProvider {
+		snapshot[id] = metrics
+	}
+	return snapshot
+}
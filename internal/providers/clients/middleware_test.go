@@ -0,0 +1,165 @@
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/internal/providers/openai"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+type scriptedClient struct {
+	calls int
+	errs  []error
+}
+
+func (c *scriptedClient) ListModels(context.Context) ([]catalogs.Model, error) {
+	c.calls++
+	if c.calls <= len(c.errs) {
+		return nil, c.errs[c.calls-1]
+	}
+	return []catalogs.Model{{ID: "model-a"}}, nil
+}
+
+func (c *scriptedClient) IsAPIKeyRequired() bool { return false }
+
+func (c *scriptedClient) HasAPIKey() bool { return true }
+
+func TestChainOrdersMiddlewareOutermostFirst(t *testing.T) {
+	var order []string
+	trace := func(name string) Middleware {
+		return func(_ *catalogs.Provider, next ProviderClient) ProviderClient {
+			return middlewareClient{ProviderClient: next, listModels: func(ctx context.Context) ([]catalogs.Model, error) {
+				order = append(order, name)
+				return next.ListModels(ctx)
+			}}
+		}
+	}
+	base := &scriptedClient{}
+	client := Chain(testProvider(catalogs.EndpointTypeOpenAI), base, trace("outer"), trace("inner"))
+	if _, err := client.ListModels(context.Background()); err != nil {
+		t.Fatalf("ListModels returned error: %v", err)
+	}
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("middleware order = %v, want [outer inner]", order)
+	}
+	if Unwrap(client) != base {
+		t.Errorf("Unwrap returned %T, want the wrapped client", Unwrap(client))
+	}
+}
+
+func TestRetryRetriesOnlyTransientFailures(t *testing.T) {
+	provider := testProvider(catalogs.EndpointTypeOpenAI)
+	rateLimited := pkgerrors.NewAPIError("test-provider", 429, "slow down")
+	flaky := &scriptedClient{errs: []error{rateLimited, rateLimited}}
+	if _, err := Retry(3, time.Millisecond)(provider, flaky).ListModels(context.Background()); err != nil {
+		t.Fatalf("ListModels returned error: %v", err)
+	}
+	if flaky.calls != 3 {
+		t.Errorf("calls = %d, want 3", flaky.calls)
+	}
+
+	unauthorized := &scriptedClient{errs: []error{pkgerrors.NewAPIError("test-provider", 401, "bad key")}}
+	if _, err := Retry(3, time.Millisecond)(provider, unauthorized).ListModels(context.Background()); err == nil {
+		t.Fatal("ListModels returned nil error for an unauthorized response")
+	}
+	if unauthorized.calls != 1 {
+		t.Errorf("calls = %d, want 1 for a non-transient failure", unauthorized.calls)
+	}
+}
+
+func TestRetryUsesProviderRequestPolicy(t *testing.T) {
+	provider := testProvider(catalogs.EndpointTypeOpenAI)
+	retries := 1
+	provider.Catalog.Endpoint.Requests = &catalogs.ProviderRequestPolicy{MaxRetries: &retries}
+	unavailable := pkgerrors.NewAPIError("test-provider", 503, "down")
+	flaky := &scriptedClient{errs: []error{unavailable, unavailable, unavailable}}
+	if _, err := Retry(5, time.Millisecond)(provider, flaky).ListModels(context.Background()); err == nil {
+		t.Fatal("ListModels returned nil error after the configured retries")
+	}
+	if flaky.calls != 2 {
+		t.Errorf("calls = %d, want 2 with max_retries 1", flaky.calls)
+	}
+}
+
+func TestCircuitBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	provider := testProvider(catalogs.EndpointTypeOpenAI)
+	unavailable := pkgerrors.NewAPIError("test-provider", 503, "down")
+	failing := &scriptedClient{errs: []error{unavailable, unavailable, unavailable}}
+	breaker := CircuitBreaker(2, time.Hour)
+	client := breaker(provider, failing)
+	for range 2 {
+		if _, err := client.ListModels(context.Background()); err == nil {
+			t.Fatal("ListModels returned nil error for a failing provider")
+		}
+	}
+
+	_, err := breaker(provider, failing).ListModels(context.Background())
+	if !pkgerrors.IsProviderUnavailable(err) {
+		t.Fatalf("error = %v, want provider unavailable while the circuit is open", err)
+	}
+	if failing.calls != 2 {
+		t.Errorf("calls = %d, want 2; the open circuit must not call the provider", failing.calls)
+	}
+
+	disabled := 0
+	provider.Catalog.Endpoint.Requests = &catalogs.ProviderRequestPolicy{FailureThreshold: &disabled}
+	if _, err := CircuitBreaker(1, time.Hour)(provider, &scriptedClient{}).ListModels(context.Background()); err != nil {
+		t.Fatalf("ListModels returned error with the breaker disabled: %v", err)
+	}
+}
+
+func TestRateLimitSharesBucketPerProvider(t *testing.T) {
+	provider := testProvider(catalogs.EndpointTypeOpenAI)
+	limit := RateLimit(60, 1)
+	first := limit(provider, &scriptedClient{})
+	second := limit(provider, &scriptedClient{})
+	if _, err := first.ListModels(context.Background()); err != nil {
+		t.Fatalf("first ListModels returned error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := second.ListModels(ctx); err == nil {
+		t.Fatal("second client skipped the shared rate limit")
+	}
+}
+
+func TestCacheAndMetricsMiddleware(t *testing.T) {
+	provider := testProvider(catalogs.EndpointTypeOpenAI)
+	metrics := NewMetrics()
+	base := &scriptedClient{}
+	client := Chain(provider, base, metrics.Middleware(), Cache(time.Minute))
+	for range 2 {
+		if _, err := client.ListModels(context.Background()); err != nil {
+			t.Fatalf("ListModels returned error: %v", err)
+		}
+	}
+	if base.calls != 1 {
+		t.Errorf("provider calls = %d, want 1 with the cache", base.calls)
+	}
+	got := metrics.Snapshot()["test-provider"]
+	if got.Calls != 2 || got.Models != 2 || got.Errors != 0 {
+		t.Errorf("metrics = %+v, want 2 calls returning 2 models", got)
+	}
+}
+
+func TestUseAddsRegistryMiddleware(t *testing.T) {
+	calls := 0
+	restore := Use(func(_ *catalogs.Provider, next ProviderClient) ProviderClient {
+		calls++
+		return next
+	})
+	client, err := NewProvider(testProvider(catalogs.EndpointTypeOpenAI))
+	restore()
+	if err != nil {
+		t.Fatalf("NewProvider returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("registered middleware applied %d times, want 1", calls)
+	}
+	if _, ok := Unwrap(client).(*openai.Client); !ok {
+		t.Errorf("Unwrap(client) = %T, want *openai.Client", Unwrap(client))
+	}
+}
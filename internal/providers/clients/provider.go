@@ -17,8 +17,13 @@ import (
 	// Import provider implementations for clients.
 
 	"github.com/agentstation/starmap/internal/providers/anthropic"
+	"github.com/agentstation/starmap/internal/providers/cohere"
 	"github.com/agentstation/starmap/internal/providers/google"
 	"github.com/agentstation/starmap/internal/providers/openai"
+	"github.com/agentstation/starmap/internal/providers/openrouter"
+	"github.com/agentstation/starmap/internal/providers/perplexity"
+	"github.com/agentstation/starmap/internal/providers/replicate"
+	"github.com/agentstation/starmap/internal/providers/together"
 )
 
 // ProviderClient defines the interface for provider API clients.
@@ -34,8 +39,45 @@ type ProviderClient interface {
 	HasAPIKey() bool
 }
 
-// NewProvider creates a new provider client for the given provider.
+// PricingFetcher is an optional interface for provider clients that can fetch
+// live, machine-readable prices, keyed by model ID, separately from the models
+// listing. Clients whose listing already carries prices need not implement it.
+type PricingFetcher interface {
+	FetchPricing(ctx context.Context) (map[string]*catalogs.ModelPricing, error)
+}
+
+var _ PricingFetcher = (*openrouter.Client)(nil)
+
+// NewProvider creates a new provider client for the given provider, wrapped
+// in the registered middleware (DefaultMiddleware unless changed with Use).
 func NewProvider(provider *catalogs.Provider) (ProviderClient, error) {
+	client, err := newEndpointClient(provider)
+	if err != nil {
+		return nil, err
+	}
+	return Chain(provider, client, registeredMiddleware()...), nil
+}
+
+// newEndpointClient creates the client registered for the provider with
+// catalogs.RegisterProvider, or else the client for its endpoint type.
+func newEndpointClient(provider *catalogs.Provider) (ProviderClient, error) {
+	if factory := catalogs.RegisteredProviderClientFactory(provider.ID); factory != nil {
+		client, err := factory(provider)
+		if err != nil {
+			return nil, errors.WrapResource("create", "provider client", string(provider.ID), err)
+		}
+		if client == nil {
+			return nil, &errors.ValidationError{Field: "provider.client", Value: provider.ID, Message: "factory returned nil"}
+		}
+		return client, nil
+	}
+	if provider.Catalog == nil {
+		return nil, &errors.ValidationError{
+			Field:   "provider.catalog.endpoint.type",
+			Value:   provider.ID,
+			Message: "provider has no catalog endpoint",
+		}
+	}
 	switch provider.Catalog.Endpoint.Type {
 	case catalogs.EndpointTypeOpenAI:
 		client, err := openai.NewClient(provider)
@@ -49,6 +91,20 @@ func NewProvider(provider *catalogs.Provider) (ProviderClient, error) {
 		return google.NewClient(provider), nil
 	case catalogs.EndpointTypeGoogleCloud:
 		return google.NewClient(provider), nil
+	case catalogs.EndpointTypeOpenRouter:
+		return openrouter.NewClient(provider), nil
+	case catalogs.EndpointTypeCohere:
+		return cohere.NewClient(provider), nil
+	case catalogs.EndpointTypeReplicate:
+		return replicate.NewClient(provider), nil
+	case catalogs.EndpointTypeTogether:
+		return together.NewClient(provider), nil
+	case catalogs.EndpointTypePerplexity:
+		client, err := perplexity.NewClient(provider)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
 	}
 	return nil, &errors.ValidationError{
 		Field:   "provider.catalog.endpoint.type",
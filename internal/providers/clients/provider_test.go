@@ -70,7 +70,7 @@ func TestNewProviderRoutesByEndpointType(t *testing.T) {
 			if err != nil {
 				t.Fatalf("NewProvider returned error: %v", err)
 			}
-			tt.assertClient(t, client)
+			tt.assertClient(t, Unwrap(client))
 		})
 	}
 }
@@ -93,6 +93,28 @@ func TestNewProviderRejectsUnsupportedEndpointType(t *testing.T) {
 	}
 }
 
+func TestNewProviderUsesRegisteredClientFactory(t *testing.T) {
+	registered := &scriptedClient{}
+	restore, err := catalogs.RegisterProvider(catalogs.Provider{ID: "acme"}, func(*catalogs.Provider) (catalogs.ProviderClient, error) {
+		return registered, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterProvider returned error: %v", err)
+	}
+	defer restore()
+
+	client, err := NewProvider(&catalogs.Provider{ID: "acme"})
+	if err != nil {
+		t.Fatalf("NewProvider returned error: %v", err)
+	}
+	if Unwrap(client) != registered {
+		t.Fatalf("client = %T, want the registered client", Unwrap(client))
+	}
+	if _, err := client.ListModels(context.Background()); err != nil || registered.calls != 1 {
+		t.Fatalf("ListModels err = %v after %d calls, want one call through middleware", err, registered.calls)
+	}
+}
+
 func TestNewProviderMappingValidationReturnsTypedFailureBeforeAdapterCreation(t *testing.T) {
 	provider := testProvider(catalogs.EndpointTypeOpenAI)
 	provider.Catalog.Endpoint.FieldMappings = []catalogs.FieldMapping{{
@@ -0,0 +1,345 @@
+// Package cohere provides a client for the Cohere models API.
+//
+// Cohere serves chat, embedding, and rerank models from one listing. Each
+// model names the endpoints that accept it, which the client uses to set the
+// model's class, so embed and rerank models are not mistaken for chat models.
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/agentstation/starmap/internal/transport"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sourcepayload"
+)
+
+// DefaultModelsURL is the Cohere models endpoint.
+const DefaultModelsURL = "https://api.cohere.com/v1/models"
+
+// modelsPageSize is the largest page the models endpoint returns, and
+// maxModelsPages bounds how many pages one listing follows.
+const (
+	modelsPageSize = 1000
+	maxModelsPages = 20
+)
+
+// Cohere endpoint names reported for each model.
+const (
+	endpointChat     = "chat"
+	endpointGenerate = "generate"
+	endpointEmbed    = "embed"
+	endpointRerank   = "rerank"
+)
+
+// Response structures for the Cohere API.
+type modelsResponse struct {
+	Models        []modelResponse                  `json:"models"`
+	NextPageToken string                           `json:"next_page_token,omitempty"`
+	UnknownFields []sourcepayload.UnknownJSONField `json:"-"`
+}
+
+func (r *modelsResponse) UnmarshalJSON(data []byte) error {
+	type responseAlias modelsResponse
+	var decoded responseAlias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	unknown, err := sourcepayload.UnknownJSONFields(data, decoded, "$")
+	if err != nil {
+		return err
+	}
+	*r = modelsResponse(decoded)
+	r.UnknownFields = unknown
+	return nil
+}
+
+type modelResponse struct {
+	Name             string                           `json:"name"`
+	IsDeprecated     bool                             `json:"is_deprecated"`
+	Endpoints        []string                         `json:"endpoints"`
+	DefaultEndpoints []string                         `json:"default_endpoints"`
+	Finetuned        bool                             `json:"finetuned"`
+	ContextLength    float64                          `json:"context_length"`
+	TokenizerURL     string                           `json:"tokenizer_url"`
+	Features         []string                         `json:"features"`
+	UnknownFields    []sourcepayload.UnknownJSONField `json:"-"`
+}
+
+func (m *modelResponse) UnmarshalJSON(data []byte) error {
+	type modelAlias modelResponse
+	var decoded modelAlias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	unknown, err := sourcepayload.UnknownJSONFields(data, decoded, "models[]")
+	if err != nil {
+		return err
+	}
+	*m = modelResponse(decoded)
+	m.UnknownFields = unknown
+	return nil
+}
+
+// Client implements the catalogs.Client interface for Cohere.
+type Client struct {
+	provider  *catalogs.Provider
+	transport *transport.Client
+	mu        sync.RWMutex
+}
+
+// NewClient creates a new Cohere client.
+func NewClient(provider *catalogs.Provider) *Client {
+	return &Client{
+		provider:  provider,
+		transport: transport.New(provider),
+	}
+}
+
+// IsAPIKeyRequired returns true if the client requires an API key.
+func (c *Client) IsAPIKeyRequired() bool {
+	return c.provider.IsAPIKeyRequired()
+}
+
+// HasAPIKey returns true if the client has an API key.
+func (c *Client) HasAPIKey() bool {
+	return c.provider.HasAPIKey()
+}
+
+// Configure sets the provider for this client (used by registry pattern).
+func (c *Client) Configure(provider *catalogs.Provider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.provider = provider
+	c.transport = transport.New(provider)
+}
+
+// ListModels retrieves all models from Cohere, following the paginated
+// listing to its end.
+func (c *Client) ListModels(ctx context.Context) ([]catalogs.Model, error) {
+	c.mu.RLock()
+	provider := c.provider
+	client := c.transport
+	c.mu.RUnlock()
+
+	if provider == nil {
+		return nil, &errors.ConfigError{
+			Component: "cohere",
+			Message:   "provider not configured",
+		}
+	}
+
+	modelsURL := transport.NewRequestBuilder(provider).GetModelsURL(DefaultModelsURL)
+	listed, err := transport.Paginate(ctx, provider.ID.String(), maxModelsPages,
+		func(m modelResponse) string { return m.Name },
+		func(ctx context.Context, pageToken string) (transport.Page[modelResponse], error) {
+			result, err := c.fetchModelsPage(ctx, provider, client, modelsURL, pageToken)
+			if err != nil {
+				return transport.Page[modelResponse]{}, err
+			}
+			for i := range result.Models {
+				result.Models[i].UnknownFields = append(result.Models[i].UnknownFields, result.UnknownFields...)
+			}
+			return transport.Page[modelResponse]{Items: result.Models, Next: result.NextPageToken}, nil
+		})
+	if listed == nil && err != nil {
+		return nil, err
+	}
+
+	// A partial listing keeps the models of the pages fetched so far
+	models := make([]catalogs.Model, 0, len(listed))
+	for _, m := range listed {
+		models = append(models, *c.convertToModel(m))
+	}
+	return models, err
+}
+
+// fetchModelsPage fetches the page of models named by pageToken, or the
+// first page.
+func (c *Client) fetchModelsPage(ctx context.Context, provider *catalogs.Provider, client *transport.Client, modelsURL, pageToken string) (*modelsResponse, error) {
+	pageURL, err := url.Parse(modelsURL)
+	if err != nil {
+		return nil, errors.WrapResource("parse", "url", modelsURL, err)
+	}
+	query := pageURL.Query()
+	query.Set("page_size", strconv.Itoa(modelsPageSize))
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+	pageURL.RawQuery = query.Encode()
+
+	resp, err := client.Get(ctx, pageURL.String(), provider)
+	if err != nil {
+		return nil, &errors.APIError{
+			Provider: provider.ID.String(),
+			Endpoint: modelsURL,
+			Message:  "request failed",
+			Err:      err,
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result modelsResponse
+	if err := transport.DecodeResponse(resp, &result); err != nil {
+		return nil, errors.WrapParse("json", "cohere response", err)
+	}
+	if result.Models == nil {
+		return nil, errors.NewParseError("json", "cohere response", "required models array is missing or null", nil)
+	}
+	return &result, nil
+}
+
+// convertToModel converts a Cohere model response to a starmap Model.
+func (c *Client) convertToModel(m modelResponse) *catalogs.Model {
+	model := catalogs.Model{
+		ID:      m.Name,
+		Name:    m.Name,
+		Class:   classify(m.Endpoints),
+		Authors: []catalogs.Author{{ID: catalogs.AuthorIDCohere, Name: "Cohere"}},
+	}
+	if m.IsDeprecated {
+		model.Status = catalogs.ModelStatusDeprecated
+	}
+	if m.ContextLength > 0 {
+		model.Limits = &catalogs.ModelLimits{ContextWindow: int64(m.ContextLength)}
+	}
+
+	switch model.Class {
+	case catalogs.ModelClassEmbedding:
+		model.Embedding = embedding(m.Name)
+		model.Features = &catalogs.ModelFeatures{Modalities: catalogs.ModelModalities{
+			Input:  []catalogs.ModelModality{catalogs.ModelModalityText},
+			Output: []catalogs.ModelModality{catalogs.ModelModalityEmbedding},
+		}}
+		if embedsImages(m.Name) {
+			model.Features.Modalities.Input = append(model.Features.Modalities.Input, catalogs.ModelModalityImage)
+		}
+	case catalogs.ModelClassRerank:
+		model.Features = &catalogs.ModelFeatures{Modalities: catalogs.ModelModalities{
+			Input: []catalogs.ModelModality{catalogs.ModelModalityText},
+		}}
+	default:
+		model.Features = chatFeatures(m.Features)
+	}
+
+	fields := map[string]any{}
+	if len(m.Endpoints) > 0 {
+		fields["endpoints"] = slices.Clone(m.Endpoints)
+	}
+	if m.Finetuned {
+		fields["finetuned"] = true
+	}
+	if m.TokenizerURL != "" {
+		fields["tokenizer_url"] = m.TokenizerURL
+	}
+	if len(m.UnknownFields) > 0 {
+		fields["unknown_fields"] = m.UnknownFields
+	}
+	if len(fields) > 0 {
+		model.Extensions = catalogs.SourceExtensions{c.extensionSource(): {Fields: fields}}
+	}
+	return &model
+}
+
+// classify returns the class of a model served by endpoints. Models that
+// accept embed or rerank requests and no chat requests are not chat models.
+func classify(endpoints []string) catalogs.ModelClass {
+	switch {
+	case slices.Contains(endpoints, endpointChat), slices.Contains(endpoints, endpointGenerate):
+		return catalogs.ModelClassChat
+	case slices.Contains(endpoints, endpointEmbed):
+		return catalogs.ModelClassEmbedding
+	case slices.Contains(endpoints, endpointRerank):
+		return catalogs.ModelClassRerank
+	default:
+		return catalogs.ModelClassChat
+	}
+}
+
+// embeddingDimensions holds the documented vector lengths of Cohere's
+// embedding models, since the models listing does not report them. The
+// first length is the default.
+var embeddingDimensions = map[string][]int{
+	"embed-v4.0":                    {1536, 256, 512, 1024},
+	"embed-english-v3.0":            {1024},
+	"embed-multilingual-v3.0":       {1024},
+	"embed-english-light-v3.0":      {384},
+	"embed-multilingual-light-v3.0": {384},
+	"embed-english-v2.0":            {4096},
+	"embed-english-light-v2.0":      {1024},
+	"embed-multilingual-v2.0":       {768},
+}
+
+// embedding returns the vectors an embedding model returns, or nil for an
+// unknown model.
+func embedding(name string) *catalogs.ModelEmbedding {
+	dimensions, ok := embeddingDimensions[strings.ToLower(name)]
+	if !ok {
+		return nil
+	}
+	result := &catalogs.ModelEmbedding{Dimensions: dimensions[0], Types: []string{"float"}}
+	if len(dimensions) > 1 {
+		result.SupportedDimensions = slices.Sorted(slices.Values(dimensions))
+	}
+	if !strings.HasSuffix(name, "-v2.0") {
+		result.Types = []string{"float", "int8", "uint8", "binary", "ubinary"}
+	}
+	return result
+}
+
+// embedsImages reports whether an embedding model accepts images, which
+// Cohere supports from the v3 models on.
+func embedsImages(name string) bool {
+	return !strings.HasSuffix(name, "-v2.0")
+}
+
+// chatFeatures maps Cohere's feature names to the features of a chat model.
+func chatFeatures(names []string) *catalogs.ModelFeatures {
+	features := &catalogs.ModelFeatures{
+		Modalities: catalogs.ModelModalities{
+			Input:  []catalogs.ModelModality{catalogs.ModelModalityText},
+			Output: []catalogs.ModelModality{catalogs.ModelModalityText},
+		},
+		Temperature: true,
+		TopP:        true,
+		TopK:        true,
+		MaxTokens:   true,
+		Stop:        true,
+		Seed:        true,
+		Streaming:   true,
+	}
+	for _, name := range names {
+		switch name {
+		case "tools", "strict_tools":
+			features.Tools = true
+			features.ToolCalls = true
+		case "tool_choice":
+			features.ToolChoice = true
+		case "json_mode":
+			features.FormatResponse = true
+		case "json_schema":
+			features.FormatResponse = true
+			features.StructuredOutputs = true
+		case "vision":
+			features.Modalities.Input = append(features.Modalities.Input, catalogs.ModelModalityImage)
+		case "reasoning":
+			features.Reasoning = true
+		}
+	}
+	return features
+}
+
+func (c *Client) extensionSource() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.provider != nil && c.provider.ID != "" {
+		return c.provider.ID.String()
+	}
+	return catalogs.ProviderIDCohere.String()
+}
@@ -0,0 +1,161 @@
+package cohere
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sourcepayload"
+)
+
+func newTestClient(url string) *Client {
+	return NewClient(&catalogs.Provider{
+		ID: catalogs.ProviderIDCohere, Name: "Cohere",
+		Catalog: &catalogs.ProviderCatalog{Endpoint: catalogs.ProviderEndpoint{Type: catalogs.EndpointTypeCohere, URL: url}},
+	})
+}
+
+func TestSchemaDriftMutationMatrix(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     string
+		wantErr     bool
+		wantModels  int
+		wantUnknown int
+	}{
+		{name: "valid", payload: `{"models":[{"name":"command-a-03-2025","endpoints":["chat"]}]}`, wantModels: 1},
+		{name: "missing", payload: `{}`, wantErr: true},
+		{name: "renamed", payload: `{"data":[]}`, wantErr: true},
+		{name: "null", payload: `{"models":null}`, wantErr: true},
+		{name: "wrong type", payload: `{"models":{}}`, wantErr: true},
+		{name: "unknown additive", payload: `{"models":[{"name":"command-a-03-2025","endpoints":["chat"],"new_capability":true}],"new_page":1}`, wantModels: 1, wantUnknown: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(test.payload))
+			}))
+			defer server.Close()
+			models, err := newTestClient(server.URL).ListModels(context.Background())
+			if test.wantErr && err == nil {
+				t.Fatal("ListModels returned nil error")
+			}
+			if !test.wantErr && err != nil {
+				t.Fatalf("ListModels: %v", err)
+			}
+			if len(models) != test.wantModels {
+				t.Fatalf("models = %d, want %d", len(models), test.wantModels)
+			}
+			if test.wantUnknown > 0 {
+				items := models[0].Extensions["cohere"].Fields["unknown_fields"].([]sourcepayload.UnknownJSONField)
+				if len(items) != test.wantUnknown {
+					t.Fatalf("unknown evidence = %#v", items)
+				}
+			}
+		})
+	}
+}
+
+func TestListModelsFollowsPagination(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("page_size"); got != "1000" {
+			t.Errorf("page_size = %q, want 1000", got)
+		}
+		token := r.URL.Query().Get("page_token")
+		tokens = append(tokens, token)
+		w.Header().Set("Content-Type", "application/json")
+		if token == "" {
+			_, _ = w.Write([]byte(`{"models":[{"name":"command-a-03-2025","endpoints":["chat"]}],"next_page_token":"page-2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"models":[{"name":"rerank-v3.5","endpoints":["rerank"]}]}`))
+	}))
+	defer server.Close()
+
+	models, err := newTestClient(server.URL).ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels: %v", err)
+	}
+	if !slices.Equal(tokens, []string{"", "page-2"}) {
+		t.Fatalf("page tokens = %q", tokens)
+	}
+	if len(models) != 2 || models[1].ID != "rerank-v3.5" {
+		t.Fatalf("models = %+v", models)
+	}
+}
+
+func TestListModelsStopsOnRepeatedToken(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page_token") == "" {
+			_, _ = w.Write([]byte(`{"models":[{"name":"command-a-03-2025","endpoints":["chat"]}],"next_page_token":"same"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"models":[{"name":"rerank-v3.5","endpoints":["rerank"]}],"next_page_token":"same"}`))
+	}))
+	defer server.Close()
+
+	models, err := newTestClient(server.URL).ListModels(context.Background())
+	var partial *pkgerrors.PartialListError
+	if !errors.As(err, &partial) {
+		t.Fatalf("ListModels error = %v, want a partial listing", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("models = %d, want the 2 models listed before the repeated token", len(models))
+	}
+	if requests != 2 {
+		t.Fatalf("requests = %d, want 2", requests)
+	}
+}
+
+func TestConvertToModelClassifiesModels(t *testing.T) {
+	client := newTestClient("")
+
+	chat := client.convertToModel(modelResponse{
+		Name:          "command-a-vision-07-2025",
+		Endpoints:     []string{"chat"},
+		ContextLength: 128000,
+		Features:      []string{"tools", "json_schema", "vision"},
+	})
+	if chat.ClassOrDefault() != catalogs.ModelClassChat || chat.Embedding != nil {
+		t.Fatalf("chat class = %q, embedding = %+v", chat.Class, chat.Embedding)
+	}
+	if !chat.Features.Tools || !chat.Features.StructuredOutputs || !slices.Contains(chat.Features.Modalities.Input, catalogs.ModelModalityImage) {
+		t.Fatalf("chat features = %+v", chat.Features)
+	}
+	if chat.Limits == nil || chat.Limits.ContextWindow != 128000 {
+		t.Fatalf("chat limits = %+v", chat.Limits)
+	}
+
+	embed := client.convertToModel(modelResponse{Name: "embed-v4.0", Endpoints: []string{"embed"}})
+	if embed.Class != catalogs.ModelClassEmbedding {
+		t.Fatalf("embed class = %q", embed.Class)
+	}
+	if embed.Embedding == nil || embed.Embedding.Dimensions != 1536 ||
+		!slices.Equal(embed.Embedding.SupportedDimensions, []int{256, 512, 1024, 1536}) {
+		t.Fatalf("embed embedding = %+v", embed.Embedding)
+	}
+	if embed.Features.Tools || !slices.Contains(embed.Features.Modalities.Output, catalogs.ModelModalityEmbedding) {
+		t.Fatalf("embed features = %+v", embed.Features)
+	}
+
+	legacy := client.convertToModel(modelResponse{Name: "embed-english-v2.0", Endpoints: []string{"embed"}, IsDeprecated: true})
+	if legacy.Status != catalogs.ModelStatusDeprecated || legacy.Embedding.Dimensions != 4096 ||
+		!slices.Equal(legacy.Embedding.Types, []string{"float"}) {
+		t.Fatalf("legacy embed = %+v %+v", legacy.Status, legacy.Embedding)
+	}
+
+	rerank := client.convertToModel(modelResponse{Name: "rerank-v3.5", Endpoints: []string{"rerank"}})
+	if rerank.Class != catalogs.ModelClassRerank || len(rerank.Features.Modalities.Output) != 0 {
+		t.Fatalf("rerank = %q %+v", rerank.Class, rerank.Features)
+	}
+}
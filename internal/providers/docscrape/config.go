@@ -0,0 +1,197 @@
+// Package docscrape fills model data that provider APIs do not return, most
+// often pricing, from provider documentation pages. Each provider has a
+// declarative extractor config naming its pricing and limits pages and how
+// to read them, with CSS selectors over HTML tables or with JSON-LD records.
+// Pages are fetched politely: robots.txt is honored, requests to a host are
+// spaced out, and fetched pages are cached on disk. Scraping is opt-in
+// because it makes requests to provider websites rather than to their APIs.
+package docscrape
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+//go:embed extractors
+var embeddedExtractors embed.FS
+
+// Field is a model field an extractor can fill.
+type Field string
+
+// Fields an extractor can fill. Prices are in the currency of the page per
+// one million tokens.
+const (
+	FieldModel         Field = "model"                 // Model ID, or a name mapped by Page.Models
+	FieldInputPrice    Field = "pricing.input"         // Input token price
+	FieldOutputPrice   Field = "pricing.output"        // Output token price
+	FieldCacheRead     Field = "pricing.cache_read"    // Cache read token price
+	FieldCacheWrite    Field = "pricing.cache_write"   // Cache write token price
+	FieldContextWindow Field = "limits.context_window" // Context window in tokens
+	FieldOutputTokens  Field = "limits.output_tokens"  // Maximum output tokens
+)
+
+var valueFields = []Field{FieldInputPrice, FieldOutputPrice, FieldCacheRead, FieldCacheWrite, FieldContextWindow, FieldOutputTokens}
+
+// ExtractorKind selects how a page is read.
+type ExtractorKind string
+
+const (
+	// ExtractorCSS reads records from HTML elements matched by CSS selectors.
+	ExtractorCSS ExtractorKind = "css"
+
+	// ExtractorJSONLD reads records from the page's JSON-LD script blocks.
+	ExtractorJSONLD ExtractorKind = "jsonld"
+)
+
+// Config is the extractor config of one provider.
+type Config struct {
+	Provider catalogs.ProviderID `yaml:"provider"`
+	Pages    []Page              `yaml:"pages"`
+}
+
+// Page describes how to read records from one documentation page.
+//
+// With the css extractor, Rows selects one element per record and each field
+// is a selector inside that element whose text holds the value. With the
+// jsonld extractor, Type selects the JSON-LD objects that are records and each
+// field is a dotted path inside such an object.
+type Page struct {
+	URL       string                        `yaml:"url"`
+	Extractor ExtractorKind                 `yaml:"extractor"`
+	Rows      string                        `yaml:"rows,omitempty"`
+	Type      string                        `yaml:"type,omitempty"`
+	Fields    map[Field]string              `yaml:"fields"`
+	Models    map[string]string             `yaml:"models,omitempty"`   // Names on the page mapped to model IDs
+	Scale     map[Field]float64             `yaml:"scale,omitempty"`    // Factors applied to values, such as 1000 for prices per 1K tokens
+	Currency  catalogs.ModelPricingCurrency `yaml:"currency,omitempty"` // Defaults to USD
+}
+
+// Validate reports the first problem that would keep the config from being
+// applied.
+func (c Config) Validate() error {
+	if c.Provider == "" {
+		return &errors.ValidationError{Field: "provider", Message: "is required"}
+	}
+	if len(c.Pages) == 0 {
+		return &errors.ValidationError{Field: "pages", Message: "must list at least one page"}
+	}
+	for i, page := range c.Pages {
+		if err := page.validate(); err != nil {
+			return fmt.Errorf("%s page %d: %w", c.Provider, i, err)
+		}
+	}
+	return nil
+}
+
+func (p Page) validate() error {
+	parsed, err := url.Parse(p.URL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return &errors.ValidationError{Field: "url", Value: p.URL, Message: "must be an absolute http or https URL"}
+	}
+	if _, ok := p.Fields[FieldModel]; !ok {
+		return &errors.ValidationError{Field: "fields", Message: "must include model"}
+	}
+	for field := range p.Fields {
+		if field != FieldModel && !slices.Contains(valueFields, field) {
+			return &errors.ValidationError{Field: "fields", Value: field, Message: "is not a supported field"}
+		}
+	}
+	for field := range p.Scale {
+		if !slices.Contains(valueFields, field) {
+			return &errors.ValidationError{Field: "scale", Value: field, Message: "is not a supported field"}
+		}
+	}
+	switch p.Extractor {
+	case ExtractorCSS:
+		if p.Rows == "" {
+			return &errors.ValidationError{Field: "rows", Message: "is required by the css extractor"}
+		}
+		if _, err := parseSelector(p.Rows); err != nil {
+			return err
+		}
+		for _, selector := range p.Fields {
+			if _, err := parseSelector(selector); err != nil {
+				return err
+			}
+		}
+	case ExtractorJSONLD:
+		if p.Type == "" {
+			return &errors.ValidationError{Field: "type", Message: "is required by the jsonld extractor"}
+		}
+	default:
+		return &errors.ValidationError{Field: "extractor", Value: p.Extractor, Message: "must be css or jsonld"}
+	}
+	return nil
+}
+
+// LoadConfigs returns the extractor configs shipped with starmap, replaced
+// per provider by the configs in dir. A missing dir adds nothing.
+func LoadConfigs(dir string) ([]Config, error) {
+	embedded, err := fs.Sub(embeddedExtractors, "extractors")
+	if err != nil {
+		return nil, err
+	}
+	configs, err := readConfigs(embedded)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return configs, nil
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return configs, nil
+	}
+	overrides, err := readConfigs(os.DirFS(dir))
+	if err != nil {
+		return nil, errors.WrapResource("load", "extractor configs", dir, err)
+	}
+
+	byProvider := make(map[catalogs.ProviderID]Config, len(configs)+len(overrides))
+	for _, config := range append(configs, overrides...) {
+		byProvider[config.Provider] = config
+	}
+	merged := make([]Config, 0, len(byProvider))
+	for _, config := range byProvider {
+		merged = append(merged, config)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Provider < merged[j].Provider })
+	return merged, nil
+}
+
+func readConfigs(fsys fs.FS) ([]Config, error) {
+	paths, err := fs.Glob(fsys, "*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	configs := make([]Config, 0, len(paths))
+	for _, path := range paths {
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, errors.WrapIO("read", path, err)
+		}
+		var config Config
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, errors.WrapParse("yaml", path, err)
+		}
+		if config.Provider == "" {
+			config.Provider = catalogs.ProviderID(strings.TrimSuffix(filepath.Base(path), ".yaml"))
+		}
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
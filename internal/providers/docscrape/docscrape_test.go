@@ -0,0 +1,149 @@
+package docscrape
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+const pricingPage = `<html><body>
+<table class="pricing">
+  <thead><tr><th>Model</th><th>Input</th><th>Output</th><th>Context</th></tr></thead>
+  <tbody>
+    <tr><td>Example Large</td><td>$3 / MTok</td><td>$15 / MTok</td><td>200K</td></tr>
+    <tr><td>example-small</td><td>$0.25 / MTok</td><td>$1.25 / MTok</td><td>128,000</td></tr>
+    <tr><td colspan="4">Prices exclude tax</td></tr>
+  </tbody>
+</table>
+<script type="application/ld+json">
+{"@context": "https://schema.org", "@graph": [
+  {"@type": "Product", "sku": "example-small", "offers": [{"@type": "Offer", "price": "0.5"}]},
+  {"@type": "Organization", "name": "Example"}
+]}
+</script>
+</body></html>`
+
+func TestScrapeFillsMissingValues(t *testing.T) {
+	var pageRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			_, _ = w.Write([]byte("User-agent: *\nDisallow: /private\n"))
+		case "/pricing":
+			pageRequests++
+			_, _ = w.Write([]byte(pricingPage))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := Config{Provider: "example", Pages: []Page{{
+		URL:       server.URL + "/pricing",
+		Extractor: ExtractorCSS,
+		Rows:      "table.pricing tbody > tr",
+		Fields: map[Field]string{
+			FieldModel:         "td:first-child",
+			FieldInputPrice:    "td:nth-child(2)",
+			FieldOutputPrice:   "td:nth-child(3)",
+			FieldContextWindow: "td:nth-child(4)",
+		},
+		Models: map[string]string{"Example Large": "example-large"},
+	}}}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	fetcher := NewFetcher(WithHTTPClient(server.Client()), WithCacheDir(t.TempDir(), time.Hour), WithInterval(0))
+	scraper := New(fetcher, []Config{config})
+
+	for range 2 {
+		if _, err := scraper.Scrape(context.Background(), "example"); err != nil {
+			t.Fatalf("Scrape failed: %v", err)
+		}
+	}
+	if pageRequests != 1 {
+		t.Errorf("pricing page fetched %d times, want 1 with the cache", pageRequests)
+	}
+
+	records, err := scraper.Scrape(context.Background(), "example")
+	if err != nil {
+		t.Fatalf("Scrape failed: %v", err)
+	}
+	apiPrice := &catalogs.ModelPricing{Currency: catalogs.ModelPricingCurrencyUSD, Tokens: &catalogs.ModelTokenPricing{
+		Input: &catalogs.ModelTokenCost{Per1M: 0.2},
+	}}
+	models := []catalogs.Model{
+		{ID: "example-large", Name: "Example Large"},
+		{ID: "example-small", Name: "Example Small", Pricing: apiPrice},
+	}
+	if filled := Apply(models, records); filled != 2 {
+		t.Errorf("Apply filled %d models, want 2", filled)
+	}
+
+	large := models[0]
+	if large.Pricing == nil || large.Pricing.Tokens.Input.Per1M != 3 || large.Pricing.Tokens.Output.Per1M != 15 {
+		t.Errorf("example-large pricing = %+v, want 3 in and 15 out", large.Pricing)
+	}
+	if large.Limits == nil || large.Limits.ContextWindow != 200_000 {
+		t.Errorf("example-large limits = %+v, want a 200K context window", large.Limits)
+	}
+	small := models[1]
+	if small.Pricing.Tokens.Input.Per1M != 0.2 {
+		t.Errorf("example-small input price = %v, want the API price 0.2 kept", small.Pricing.Tokens.Input.Per1M)
+	}
+	if small.Pricing.Tokens.Output == nil || small.Pricing.Tokens.Output.Per1M != 1.25 {
+		t.Errorf("example-small output price = %+v, want 1.25 filled", small.Pricing.Tokens.Output)
+	}
+}
+
+func TestExtractJSONLD(t *testing.T) {
+	page := Page{
+		URL:       "https://example.com/pricing",
+		Extractor: ExtractorJSONLD,
+		Type:      "Product",
+		Fields:    map[Field]string{FieldModel: "sku", FieldInputPrice: "offers.price"},
+		Scale:     map[Field]float64{FieldInputPrice: 1000},
+	}
+	records, err := page.Extract([]byte(pricingPage))
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Model != "example-small" || records[0].Values[FieldInputPrice] != 500 {
+		t.Errorf("records = %+v, want example-small at 500", records)
+	}
+}
+
+func TestFetcherHonorsRobots(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			_, _ = w.Write([]byte("User-agent: *\nAllow: /\n\nUser-agent: starmap\nDisallow: /pricing\nAllow: /pricing/public$\n"))
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	fetcher := NewFetcher(WithHTTPClient(server.Client()), WithInterval(0))
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/pricing"); !errors.Is(err, ErrDisallowed) {
+		t.Errorf("Fetch of a disallowed page returned %v, want ErrDisallowed", err)
+	}
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/pricing/public"); err != nil {
+		t.Errorf("Fetch of an allowed page failed: %v", err)
+	}
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/docs"); err != nil {
+		t.Errorf("Fetch of an unlisted page failed: %v", err)
+	}
+}
+
+func TestParseSelectorRejectsUnsupportedSyntax(t *testing.T) {
+	for _, text := range []string{"", "tr + td", "td:hover", "div >", "a[href"} {
+		if _, err := parseSelector(text); err == nil {
+			t.Errorf("parseSelector(%q) returned nil error", text)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package docscrape
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// numberPattern finds the first number in a cell, such as 3 in "$3 / MTok"
+// or 200K in "200K tokens".
+var numberPattern = regexp.MustCompile(`(\d[\d,]*(?:\.\d+)?|\.\d+)\s?([kKmM]\b)?`)
+
+// Record holds the values one page gives for one model.
+type Record struct {
+	Model    string                        `json:"model" yaml:"model"` // Model ID, or the name on the page when it has no mapping
+	Values   map[Field]float64             `json:"values" yaml:"values"`
+	Currency catalogs.ModelPricingCurrency `json:"currency" yaml:"currency"`
+}
+
+// Extract reads the records of a fetched page.
+func (p Page) Extract(body []byte) ([]Record, error) {
+	switch p.Extractor {
+	case ExtractorCSS:
+		return p.extractCSS(body)
+	case ExtractorJSONLD:
+		return p.extractJSONLD(body)
+	default:
+		return nil, &errors.ValidationError{Field: "extractor", Value: p.Extractor, Message: "must be css or jsonld"}
+	}
+}
+
+func (p Page) extractCSS(body []byte) ([]Record, error) {
+	document, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.WrapParse("html", p.URL, err)
+	}
+	rows, err := parseSelector(p.Rows)
+	if err != nil {
+		return nil, err
+	}
+	selectors := make(map[Field]*selector, len(p.Fields))
+	for field, text := range p.Fields {
+		if selectors[field], err = parseSelector(text); err != nil {
+			return nil, err
+		}
+	}
+
+	var records []Record
+	for _, row := range rows.selectAll(document) {
+		cells := make(map[Field]string, len(selectors))
+		for field, sel := range selectors {
+			if cell := sel.selectFirst(row); cell != nil {
+				cells[field] = textContent(cell)
+			}
+		}
+		if record, ok := p.record(cells); ok {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (p Page) extractJSONLD(body []byte) ([]Record, error) {
+	document, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.WrapParse("html", p.URL, err)
+	}
+	scripts, err := parseSelector(`script[type="application/ld+json"]`)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []map[string]any
+	for _, script := range scripts.selectAll(document) {
+		var content strings.Builder
+		for child := script.FirstChild; child != nil; child = child.NextSibling {
+			content.WriteString(child.Data)
+		}
+		var data any
+		if err := json.Unmarshal([]byte(content.String()), &data); err != nil {
+			// A malformed block elsewhere on the page must not hide the records.
+			continue
+		}
+		objects = collectTyped(data, p.Type, objects)
+	}
+
+	var records []Record
+	for _, object := range objects {
+		cells := make(map[Field]string, len(p.Fields))
+		for field, path := range p.Fields {
+			if value, ok := lookupPath(object, path); ok {
+				cells[field] = value
+			}
+		}
+		if record, ok := p.record(cells); ok {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// record converts the text of each field to a record. Rows without a model
+// or without any value are skipped; headers and notes commonly share a table
+// with the records.
+func (p Page) record(cells map[Field]string) (Record, bool) {
+	name := strings.TrimSpace(cells[FieldModel])
+	if name == "" {
+		return Record{}, false
+	}
+	if id, ok := p.Models[name]; ok {
+		name = id
+	}
+	record := Record{Model: name, Values: make(map[Field]float64), Currency: p.Currency}
+	if record.Currency == "" {
+		record.Currency = catalogs.ModelPricingCurrencyUSD
+	}
+	for _, field := range valueFields {
+		value, ok := parseValue(field, cells[field])
+		if !ok {
+			continue
+		}
+		if scale, ok := p.Scale[field]; ok {
+			value *= scale
+		}
+		record.Values[field] = value
+	}
+	return record, len(record.Values) > 0
+}
+
+// parseValue reads the first number in text. Token limits may carry a K or M
+// suffix.
+func parseValue(field Field, text string) (float64, bool) {
+	match := numberPattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(strings.ReplaceAll(match[1], ",", ""), 64)
+	if err != nil {
+		return 0, false
+	}
+	if strings.HasPrefix(string(field), "limits.") {
+		switch strings.ToLower(match[2]) {
+		case "k":
+			value *= 1_000
+		case "m":
+			value *= 1_000_000
+		}
+	}
+	return value, true
+}
+
+// collectTyped appends the JSON-LD objects in data whose @type is typ,
+// including objects nested in other objects and @graph arrays.
+func collectTyped(data any, typ string, objects []map[string]any) []map[string]any {
+	switch value := data.(type) {
+	case []any:
+		for _, item := range value {
+			objects = collectTyped(item, typ, objects)
+		}
+	case map[string]any:
+		if hasType(value["@type"], typ) {
+			objects = append(objects, value)
+		}
+		for key, child := range value {
+			if key != "@type" {
+				objects = collectTyped(child, typ, objects)
+			}
+		}
+	}
+	return objects
+}
+
+func hasType(value any, typ string) bool {
+	switch types := value.(type) {
+	case string:
+		return types == typ
+	case []any:
+		return slices.Contains(types, any(typ))
+	}
+	return false
+}
+
+// lookupPath returns the text of the value at a dotted path, taking the first
+// element of any array on the way.
+func lookupPath(object map[string]any, path string) (string, bool) {
+	var current any = object
+	for _, key := range strings.Split(path, ".") {
+		if list, ok := current.([]any); ok {
+			if len(list) == 0 {
+				return "", false
+			}
+			current = list[0]
+		}
+		fields, ok := current.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		if current, ok = fields[key]; !ok {
+			return "", false
+		}
+	}
+	switch value := current.(type) {
+	case string:
+		return value, true
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
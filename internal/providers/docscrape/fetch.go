@@ -0,0 +1,235 @@
+package docscrape
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// UserAgent identifies the scraper to provider websites and selects its
+// robots.txt group.
+const UserAgent = "starmap"
+
+const maxPageBytes = 8 << 20
+
+// ErrDisallowed reports a page that robots.txt does not allow the scraper to fetch.
+var ErrDisallowed = stderrors.New("disallowed by robots.txt")
+
+// Fetcher fetches documentation pages politely. It honors each site's
+// robots.txt, waits at least the configured interval between requests to the
+// same host, and reuses pages fetched within the cache TTL.
+type Fetcher struct {
+	client   *http.Client
+	cacheDir string
+	ttl      time.Duration
+	interval time.Duration
+	now      func() time.Time
+
+	mu     sync.Mutex
+	robots map[string]*robotsRules // By scheme and host
+	hosts  map[string]*hostLimiter
+}
+
+// hostLimiter serializes the requests to one host.
+type hostLimiter struct {
+	mu   sync.Mutex
+	last time.Time
+}
+
+// FetcherOption configures a Fetcher.
+type FetcherOption func(*Fetcher)
+
+// WithHTTPClient sets the client used for requests.
+func WithHTTPClient(client *http.Client) FetcherOption {
+	return func(f *Fetcher) {
+		f.client = client
+	}
+}
+
+// WithCacheDir caches fetched pages under dir for ttl. Without it pages are
+// fetched on every call.
+func WithCacheDir(dir string, ttl time.Duration) FetcherOption {
+	return func(f *Fetcher) {
+		f.cacheDir = dir
+		f.ttl = ttl
+	}
+}
+
+// WithInterval sets the minimum time between requests to one host.
+func WithInterval(interval time.Duration) FetcherOption {
+	return func(f *Fetcher) {
+		f.interval = interval
+	}
+}
+
+// NewFetcher creates a fetcher with the default HTTP timeout and
+// constants.DocsScrapeInterval between requests to a host.
+func NewFetcher(opts ...FetcherOption) *Fetcher {
+	f := &Fetcher{
+		client:   &http.Client{Timeout: constants.DefaultHTTPTimeout},
+		interval: constants.DocsScrapeInterval,
+		now:      time.Now,
+		robots:   make(map[string]*robotsRules),
+		hosts:    make(map[string]*hostLimiter),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Fetch returns the body of the page at rawURL.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	if body, ok := f.cached(rawURL); ok {
+		return body, nil
+	}
+	page, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.WrapParse("url", rawURL, err)
+	}
+	rules, err := f.robotsFor(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	if !rules.allowed(page.RequestURI()) {
+		return nil, errors.WrapResource("fetch", "documentation page", rawURL, ErrDisallowed)
+	}
+	body, status, err := f.get(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, &errors.APIError{Endpoint: rawURL, StatusCode: status, Message: "unexpected documentation page status"}
+	}
+	f.store(rawURL, body)
+	return body, nil
+}
+
+// robotsFor returns the robots.txt rules of the page's site, fetching them
+// once per fetcher. A missing robots.txt allows everything; one that cannot
+// be read disallows everything.
+func (f *Fetcher) robotsFor(ctx context.Context, page *url.URL) (*robotsRules, error) {
+	site := page.Scheme + "://" + page.Host
+	f.mu.Lock()
+	rules, ok := f.robots[site]
+	f.mu.Unlock()
+	if ok {
+		return rules, nil
+	}
+
+	robotsURL := &url.URL{Scheme: page.Scheme, Host: page.Host, Path: "/robots.txt"}
+	body, status, err := f.get(ctx, robotsURL)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		return nil, err
+	case err != nil || status >= http.StatusInternalServerError:
+		rules = disallowAll
+	case status >= http.StatusBadRequest:
+		rules = allowAll
+	default:
+		rules = parseRobots(string(body), UserAgent)
+	}
+	f.mu.Lock()
+	f.robots[site] = rules
+	f.mu.Unlock()
+	return rules, nil
+}
+
+// get requests target after the host's interval has passed.
+func (f *Fetcher) get(ctx context.Context, target *url.URL) ([]byte, int, error) {
+	limiter := f.hostLimiter(target.Host)
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	if wait := limiter.last.Add(f.interval).Sub(f.now()); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, 0, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	defer func() { limiter.last = f.now() }()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, 0, errors.WrapResource("create", "documentation request", target.String(), err)
+	}
+	request.Header.Set("User-Agent", UserAgent)
+	request.Header.Set("Accept", "text/html, text/plain;q=0.9, */*;q=0.5")
+	response, err := f.client.Do(request) //nolint:gosec // Documentation URLs come from curated extractor configs.
+	if err != nil {
+		return nil, 0, &errors.APIError{Endpoint: target.String(), Message: "documentation request failed", Err: err}
+	}
+	defer func() { _ = response.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxPageBytes))
+	if err != nil {
+		return nil, 0, errors.WrapIO("read", target.String(), err)
+	}
+	return body, response.StatusCode, nil
+}
+
+func (f *Fetcher) hostLimiter(host string) *hostLimiter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	limiter, ok := f.hosts[host]
+	if !ok {
+		limiter = &hostLimiter{}
+		f.hosts[host] = limiter
+	}
+	return limiter
+}
+
+func (f *Fetcher) cachePath(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return filepath.Join(f.cacheDir, hex.EncodeToString(sum[:])+".html")
+}
+
+// cached returns a page fetched within the cache TTL.
+func (f *Fetcher) cached(rawURL string) ([]byte, bool) {
+	if f.cacheDir == "" {
+		return nil, false
+	}
+	path := f.cachePath(rawURL)
+	info, err := os.Stat(path)
+	if err != nil || f.now().Sub(info.ModTime()) > f.ttl {
+		return nil, false
+	}
+	body, err := os.ReadFile(path) //nolint:gosec // Path is derived from a hash inside the cache directory.
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+// store caches a fetched page. The cache is an optimization, so a failure
+// to write it is not an error.
+func (f *Fetcher) store(rawURL string, body []byte) {
+	if f.cacheDir == "" {
+		return
+	}
+	if err := os.MkdirAll(f.cacheDir, 0o755); err != nil {
+		return
+	}
+	path := f.cachePath(rawURL)
+	temporary := path + ".tmp"
+	if err := os.WriteFile(temporary, body, 0o644); err != nil {
+		return
+	}
+	if err := os.Rename(temporary, path); err != nil {
+		_ = os.Remove(temporary)
+		return
+	}
+	_ = os.Chtimes(path, f.now(), f.now())
+}
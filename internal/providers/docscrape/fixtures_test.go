@@ -0,0 +1,158 @@
+package docscrape
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Each directory under testdata/fixtures holds a recorded copy of a
+// provider's documentation pages: extractor.yaml is the extractor config,
+// page-<n>.html the recorded body of its nth page, and expected.yaml the
+// records the pages must yield.
+const fixturesDir = "testdata/fixtures"
+
+func TestFixtures(t *testing.T) {
+	dirs, err := os.ReadDir(fixturesDir)
+	if err != nil {
+		t.Fatalf("reading fixtures: %v", err)
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		t.Run(dir.Name(), func(t *testing.T) {
+			path := filepath.Join(fixturesDir, dir.Name())
+			config := loadFixtureConfig(t, path)
+
+			var records []Record
+			for i, page := range config.Pages {
+				body, err := os.ReadFile(filepath.Join(path, fmt.Sprintf("page-%d.html", i)))
+				if err != nil {
+					t.Fatalf("reading recorded page %d: %v", i, err)
+				}
+				if report := page.Check(body); report.Broken() {
+					t.Errorf("page %s is broken: %+v", page.URL, report)
+				}
+				pageRecords, err := page.Extract(body)
+				if err != nil {
+					t.Fatalf("Extract(%s) failed: %v", page.URL, err)
+				}
+				records = append(records, pageRecords...)
+			}
+
+			data, err := os.ReadFile(filepath.Join(path, "expected.yaml"))
+			if err != nil {
+				t.Fatalf("reading expected records: %v", err)
+			}
+			var expected []Record
+			if err := yaml.Unmarshal(data, &expected); err != nil {
+				t.Fatalf("parsing expected records: %v", err)
+			}
+			if !sameRecords(records, expected) {
+				t.Errorf("records = %+v, want %+v", records, expected)
+			}
+		})
+	}
+}
+
+func TestCheckDetectsRedesignedPage(t *testing.T) {
+	path := filepath.Join(fixturesDir, "example")
+	config := loadFixtureConfig(t, path)
+	body, err := os.ReadFile(filepath.Join(path, "redesigned.html"))
+	if err != nil {
+		t.Fatalf("reading redesigned page: %v", err)
+	}
+	report := config.Pages[0].Check(body)
+	if !report.Broken() || report.Records != 0 {
+		t.Errorf("redesigned page report = %+v, want broken with no records", report)
+	}
+
+	// A dropped column leaves the records but empties one field.
+	page := config.Pages[0]
+	page.Fields = map[Field]string{FieldModel: "td:first-child", FieldInputPrice: "td:nth-child(2)", FieldOutputTokens: "td:nth-child(9)"}
+	body, err = os.ReadFile(filepath.Join(path, "page-0.html"))
+	if err != nil {
+		t.Fatalf("reading recorded page: %v", err)
+	}
+	report = page.Check(body)
+	if !report.Broken() || !slices.Equal(report.Missing, []Field{FieldOutputTokens}) {
+		t.Errorf("report = %+v, want limits.output_tokens missing", report)
+	}
+}
+
+func TestVerifyReportsLivePages(t *testing.T) {
+	path := filepath.Join(fixturesDir, "example")
+	pages := map[string]string{"/pricing": "page-0.html", "/pricing/v2": "redesigned.html"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, filepath.Join(path, name))
+	}))
+	defer server.Close()
+
+	config := loadFixtureConfig(t, path)
+	recorded := config.Pages[0]
+	recorded.URL = server.URL + "/pricing"
+	redesigned := recorded
+	redesigned.URL = server.URL + "/pricing/v2"
+	config.Pages = []Page{recorded, redesigned}
+	fetcher := NewFetcher(WithHTTPClient(server.Client()), WithCacheDir(t.TempDir(), time.Hour), WithInterval(0))
+	scraper := New(fetcher, []Config{config})
+
+	reports, err := scraper.Verify(context.Background(), "example")
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if len(reports) != 2 || reports[0].Broken() || reports[0].Records != 2 || !reports[1].Broken() {
+		t.Errorf("reports = %+v, want the recorded page ok and the redesigned page broken", reports)
+	}
+	if _, err := scraper.Verify(context.Background(), "missing"); err == nil {
+		t.Error("Verify of a provider without a config returned nil error")
+	}
+}
+
+func loadFixtureConfig(t *testing.T, path string) Config {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(path, "extractor.yaml"))
+	if err != nil {
+		t.Fatalf("reading extractor config: %v", err)
+	}
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("parsing extractor config: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("extractor config is invalid: %v", err)
+	}
+	return config
+}
+
+func sameRecords(got, want []Record) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i].Model != want[i].Model || got[i].Currency != want[i].Currency || len(got[i].Values) != len(want[i].Values) {
+			return false
+		}
+		for field, value := range want[i].Values {
+			if math.Abs(got[i].Values[field]-value) > 1e-9 {
+				return false
+			}
+		}
+	}
+	return true
+}
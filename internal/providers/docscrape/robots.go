@@ -0,0 +1,114 @@
+package docscrape
+
+import (
+	"bufio"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// robotsRules are the allow and disallow rules robots.txt applies to the
+// scraper, following RFC 9309: the group naming the scraper's product token
+// applies, otherwise the * group, and the longest matching rule decides with
+// allow winning ties.
+type robotsRules struct {
+	rules []robotsRule
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	match   *regexp.Regexp
+}
+
+// allowAll is the rule set of a site without robots.txt.
+var allowAll = &robotsRules{}
+
+// disallowAll is the rule set of a site whose robots.txt cannot be read.
+var disallowAll = &robotsRules{rules: []robotsRule{{pattern: "/", match: regexp.MustCompile(`^/`)}}}
+
+// parseRobots returns the rules robots.txt applies to agent.
+func parseRobots(body, agent string) *robotsRules {
+	agent = strings.ToLower(agent)
+	var (
+		named, wildcard []robotsRule
+		sawNamed        bool
+		groupAgents     []string
+		groupStarted    bool
+		inNamed, inStar bool
+	)
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		switch key {
+		case "user-agent":
+			if groupStarted {
+				groupAgents, groupStarted = nil, false
+			}
+			groupAgents = append(groupAgents, strings.ToLower(value))
+			inNamed = slices.Contains(groupAgents, agent)
+			inStar = slices.Contains(groupAgents, "*")
+			sawNamed = sawNamed || inNamed
+		case "allow", "disallow":
+			groupStarted = true
+			if value == "" {
+				continue
+			}
+			rule := robotsRule{allow: key == "allow", pattern: value, match: robotsPattern(value)}
+			if inNamed {
+				named = append(named, rule)
+			}
+			if inStar {
+				wildcard = append(wildcard, rule)
+			}
+		default:
+			if len(groupAgents) > 0 {
+				groupStarted = true
+			}
+		}
+	}
+	if sawNamed {
+		return &robotsRules{rules: named}
+	}
+	return &robotsRules{rules: wildcard}
+}
+
+// robotsPattern compiles a path pattern, in which * matches any characters
+// and a trailing $ anchors the end of the path.
+func robotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	expression := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expression += "$"
+	}
+	return regexp.MustCompile(expression)
+}
+
+// allowed reports whether the rules allow fetching path, which includes any
+// query string.
+func (r *robotsRules) allowed(path string) bool {
+	if path == "" {
+		path = "/"
+	}
+	allow, length := true, -1
+	for _, rule := range r.rules {
+		if !rule.match.MatchString(path) {
+			continue
+		}
+		if n := len(rule.pattern); n > length || n == length && rule.allow {
+			allow, length = rule.allow, n
+		}
+	}
+	return allow
+}
@@ -0,0 +1,155 @@
+package docscrape
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Scraper reads the documentation pages of providers with an extractor config.
+type Scraper struct {
+	fetcher *Fetcher
+	configs map[catalogs.ProviderID]Config
+}
+
+// New creates a scraper that fetches pages with fetcher. A nil fetcher uses
+// NewFetcher without a cache.
+func New(fetcher *Fetcher, configs []Config) *Scraper {
+	if fetcher == nil {
+		fetcher = NewFetcher()
+	}
+	s := &Scraper{fetcher: fetcher, configs: make(map[catalogs.ProviderID]Config, len(configs))}
+	for _, config := range configs {
+		s.configs[config.Provider] = config
+	}
+	return s
+}
+
+// Has reports whether the provider has an extractor config.
+func (s *Scraper) Has(providerID catalogs.ProviderID) bool {
+	_, ok := s.configs[providerID]
+	return ok
+}
+
+// Scrape returns the records on the provider's documentation pages. Pages
+// that cannot be fetched or read are reported in the returned error along
+// with the records of the other pages.
+func (s *Scraper) Scrape(ctx context.Context, providerID catalogs.ProviderID) ([]Record, error) {
+	config, ok := s.configs[providerID]
+	if !ok {
+		return nil, &errors.NotFoundError{Resource: "extractor config", ID: string(providerID)}
+	}
+	var (
+		records []Record
+		errs    []error
+	)
+	for _, page := range config.Pages {
+		body, err := s.fetcher.Fetch(ctx, page.URL)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		pageRecords, err := page.Extract(body)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		records = append(records, pageRecords...)
+	}
+	return records, stderrors.Join(errs...)
+}
+
+// Apply fills the pricing and limits that models lack from records, matching
+// a record to a model by ID or, case-insensitively, by name. Values the
+// provider API returned are never replaced. It returns the number of models
+// that gained a value.
+func Apply(models []catalogs.Model, records []Record) int {
+	filled := 0
+	for i := range models {
+		model := &models[i]
+		changed := false
+		for _, record := range records {
+			if record.Model != model.ID && !strings.EqualFold(record.Model, model.Name) {
+				continue
+			}
+			if applyRecord(model, record) {
+				changed = true
+			}
+		}
+		if changed {
+			filled++
+		}
+	}
+	return filled
+}
+
+func applyRecord(model *catalogs.Model, record Record) bool {
+	changed := false
+	for field, value := range record.Values {
+		switch field {
+		case FieldInputPrice, FieldOutputPrice, FieldCacheRead, FieldCacheWrite:
+			if setPrice(model, record.Currency, field, value) {
+				changed = true
+			}
+		case FieldContextWindow, FieldOutputTokens:
+			if setLimit(model, field, int64(value)) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+func setPrice(model *catalogs.Model, currency catalogs.ModelPricingCurrency, field Field, perMillion float64) bool {
+	if model.Pricing == nil {
+		model.Pricing = &catalogs.ModelPricing{Currency: currency}
+	}
+	if model.Pricing.Currency != currency {
+		return false
+	}
+	if model.Pricing.Tokens == nil {
+		model.Pricing.Tokens = &catalogs.ModelTokenPricing{}
+	}
+	tokens := model.Pricing.Tokens
+	var target **catalogs.ModelTokenCost
+	switch field {
+	case FieldInputPrice:
+		target = &tokens.Input
+	case FieldOutputPrice:
+		target = &tokens.Output
+	case FieldCacheRead, FieldCacheWrite:
+		if tokens.Cache == nil {
+			tokens.Cache = &catalogs.ModelTokenCachePricing{}
+		}
+		target = &tokens.Cache.Read
+		if field == FieldCacheWrite {
+			target = &tokens.Cache.Write
+		}
+	}
+	if *target != nil {
+		return false
+	}
+	*target = &catalogs.ModelTokenCost{PerToken: perMillion / 1_000_000, Per1M: perMillion}
+	return true
+}
+
+func setLimit(model *catalogs.Model, field Field, value int64) bool {
+	if value <= 0 {
+		return false
+	}
+	if model.Limits == nil {
+		model.Limits = &catalogs.ModelLimits{}
+	}
+	target := &model.Limits.ContextWindow
+	if field == FieldOutputTokens {
+		target = &model.Limits.OutputTokens
+	}
+	if *target != 0 {
+		return false
+	}
+	*target = value
+	return true
+}
@@ -0,0 +1,309 @@
+package docscrape
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// selector is a parsed CSS selector. The supported subset covers what
+// pricing tables need: type, #id, .class, and [attr], [attr=v], [attr*=v],
+// [attr^=v], and [attr$=v] conditions, the :first-child, :last-child, and
+// :nth-child(n) pseudo-classes, and the descendant and > combinators.
+type selector struct {
+	steps []selectorStep
+}
+
+type selectorStep struct {
+	combinator byte // ' ' for descendant or '>' for child; relates the step to the previous one
+	tag        string
+	id         string
+	classes    []string
+	attrs      []attrCondition
+	nthChild   int
+	lastChild  bool
+}
+
+type attrCondition struct {
+	name     string
+	operator string // "", "=", "*=", "^=", or "$="
+	value    string
+}
+
+// parseSelector parses a selector in the supported subset.
+func parseSelector(text string) (*selector, error) {
+	invalid := func(message string) error {
+		return &errors.ValidationError{Field: "selector", Value: text, Message: message}
+	}
+	input := strings.TrimSpace(text)
+	if input == "" {
+		return nil, invalid("is empty")
+	}
+	sel := &selector{}
+	combinator := byte(' ')
+	for input != "" {
+		step, rest, err := parseStep(input)
+		if err != nil {
+			return nil, invalid(err.Error())
+		}
+		step.combinator = combinator
+		sel.steps = append(sel.steps, step)
+
+		trimmed := strings.TrimLeft(rest, " \t\r\n")
+		switch {
+		case trimmed == "":
+		case trimmed[0] == '>':
+			combinator = '>'
+			trimmed = strings.TrimLeft(trimmed[1:], " \t\r\n")
+			if trimmed == "" {
+				return nil, invalid("ends with a combinator")
+			}
+		case len(trimmed) == len(rest):
+			return nil, invalid("has unsupported syntax at " + strconv.Quote(rest))
+		default:
+			combinator = ' '
+		}
+		input = trimmed
+	}
+	return sel, nil
+}
+
+func parseStep(input string) (selectorStep, string, error) {
+	var step selectorStep
+	i := identLength(input)
+	if i > 0 {
+		step.tag = strings.ToLower(input[:i])
+	} else if input[0] == '*' {
+		i = 1
+	}
+	for i < len(input) {
+		switch input[i] {
+		case '#', '.':
+			n := identLength(input[i+1:])
+			if n == 0 {
+				return step, "", errors.New("expected a name after " + string(input[i]))
+			}
+			name := input[i+1 : i+1+n]
+			if input[i] == '#' {
+				step.id = name
+			} else {
+				step.classes = append(step.classes, name)
+			}
+			i += 1 + n
+		case '[':
+			end := strings.IndexByte(input[i:], ']')
+			if end < 0 {
+				return step, "", errors.New("has an unclosed attribute condition")
+			}
+			condition, err := parseAttrCondition(input[i+1 : i+end])
+			if err != nil {
+				return step, "", err
+			}
+			step.attrs = append(step.attrs, condition)
+			i += end + 1
+		case ':':
+			n := identLength(input[i+1:])
+			name := input[i+1 : i+1+n]
+			i += 1 + n
+			switch name {
+			case "first-child":
+				step.nthChild = 1
+			case "last-child":
+				step.lastChild = true
+			case "nth-child":
+				end := strings.IndexByte(input[i:], ')')
+				if !strings.HasPrefix(input[i:], "(") || end < 0 {
+					return step, "", errors.New("expected :nth-child(n)")
+				}
+				n, err := strconv.Atoi(strings.TrimSpace(input[i+1 : i+end]))
+				if err != nil || n < 1 {
+					return step, "", errors.New("supports only :nth-child with a positive index")
+				}
+				step.nthChild = n
+				i += end + 1
+			default:
+				return step, "", errors.New("does not support the :" + name + " pseudo-class")
+			}
+		default:
+			if i == 0 {
+				return step, "", errors.New("has unsupported syntax at " + strconv.Quote(input))
+			}
+			return step, input[i:], nil
+		}
+	}
+	return step, "", nil
+}
+
+func parseAttrCondition(text string) (attrCondition, error) {
+	for _, operator := range []string{"*=", "^=", "$=", "="} {
+		name, value, found := strings.Cut(text, operator)
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		name = strings.TrimSpace(name)
+		if identLength(name) != len(name) || name == "" {
+			return attrCondition{}, errors.New("has an invalid attribute name " + strconv.Quote(name))
+		}
+		return attrCondition{name: strings.ToLower(name), operator: operator, value: value}, nil
+	}
+	name := strings.TrimSpace(text)
+	if name == "" || identLength(name) != len(name) {
+		return attrCondition{}, errors.New("has an invalid attribute name " + strconv.Quote(name))
+	}
+	return attrCondition{name: strings.ToLower(name)}, nil
+}
+
+func identLength(input string) int {
+	for i, r := range input {
+		if !(r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return i
+		}
+	}
+	return len(input)
+}
+
+// selectAll returns the elements below root that match sel, in document order.
+func (sel *selector) selectAll(root *html.Node) []*html.Node {
+	var matches []*html.Node
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.ElementNode && sel.matchesAt(child, len(sel.steps)-1) {
+				matches = append(matches, child)
+			}
+			walk(child)
+		}
+	}
+	walk(root)
+	return matches
+}
+
+// selectFirst returns the first element below root that matches sel.
+func (sel *selector) selectFirst(root *html.Node) *html.Node {
+	if matches := sel.selectAll(root); len(matches) > 0 {
+		return matches[0]
+	}
+	return nil
+}
+
+func (sel *selector) matchesAt(node *html.Node, index int) bool {
+	step := sel.steps[index]
+	if !step.matches(node) {
+		return false
+	}
+	if index == 0 {
+		return true
+	}
+	if step.combinator == '>' {
+		return node.Parent != nil && node.Parent.Type == html.ElementNode && sel.matchesAt(node.Parent, index-1)
+	}
+	for ancestor := node.Parent; ancestor != nil; ancestor = ancestor.Parent {
+		if ancestor.Type == html.ElementNode && sel.matchesAt(ancestor, index-1) {
+			return true
+		}
+	}
+	return false
+}
+
+func (step selectorStep) matches(node *html.Node) bool {
+	if step.tag != "" && node.Data != step.tag {
+		return false
+	}
+	if step.id != "" && attribute(node, "id") != step.id {
+		return false
+	}
+	if len(step.classes) > 0 {
+		classes := strings.Fields(attribute(node, "class"))
+		for _, class := range step.classes {
+			if !slices.Contains(classes, class) {
+				return false
+			}
+		}
+	}
+	for _, condition := range step.attrs {
+		if !condition.matches(node) {
+			return false
+		}
+	}
+	if step.nthChild > 0 && elementIndex(node) != step.nthChild {
+		return false
+	}
+	if step.lastChild {
+		for sibling := node.NextSibling; sibling != nil; sibling = sibling.NextSibling {
+			if sibling.Type == html.ElementNode {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (condition attrCondition) matches(node *html.Node) bool {
+	for _, attr := range node.Attr {
+		if attr.Key != condition.name {
+			continue
+		}
+		switch condition.operator {
+		case "":
+			return true
+		case "=":
+			return attr.Val == condition.value
+		case "*=":
+			return strings.Contains(attr.Val, condition.value)
+		case "^=":
+			return strings.HasPrefix(attr.Val, condition.value)
+		case "$=":
+			return strings.HasSuffix(attr.Val, condition.value)
+		}
+	}
+	return false
+}
+
+func attribute(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// elementIndex returns the 1-based position of node among its element siblings.
+func elementIndex(node *html.Node) int {
+	index := 1
+	for sibling := node.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+		if sibling.Type == html.ElementNode {
+			index++
+		}
+	}
+	return index
+}
+
+// textContent returns the visible text of node with whitespace collapsed.
+func textContent(node *html.Node) string {
+	var builder strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			builder.WriteString(n.Data)
+			builder.WriteByte(' ')
+			return
+		}
+		if n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style") {
+			return
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
@@ -0,0 +1,85 @@
+package docscrape
+
+import (
+	"context"
+	"slices"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// PageReport is the result of checking one documentation page against its
+// extractor config.
+type PageReport struct {
+	Provider catalogs.ProviderID `json:"provider" yaml:"provider"`
+	URL      string              `json:"url" yaml:"url"`
+	Records  int                 `json:"records" yaml:"records"`
+	Missing  []Field             `json:"missing,omitempty" yaml:"missing,omitempty"` // Configured fields no record has a value for
+	Error    string              `json:"error,omitempty" yaml:"error,omitempty"`     // Why the page could not be fetched or read
+}
+
+// Broken reports whether the page no longer yields what its config expects:
+// it could not be read, it has no records, or a configured field is empty in
+// every record. A pricing page whose layout changed usually still parses but
+// shows up here as no records or as missing fields.
+func (r PageReport) Broken() bool {
+	return r.Error != "" || r.Records == 0 || len(r.Missing) > 0
+}
+
+// Check extracts the records of a fetched page and reports whether every
+// configured field was found.
+func (p Page) Check(body []byte) PageReport {
+	report := PageReport{URL: p.URL}
+	records, err := p.Extract(body)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Records = len(records)
+	for _, field := range valueFields {
+		if _, configured := p.Fields[field]; !configured {
+			continue
+		}
+		found := slices.ContainsFunc(records, func(record Record) bool {
+			_, ok := record.Values[field]
+			return ok
+		})
+		if !found {
+			report.Missing = append(report.Missing, field)
+		}
+	}
+	return report
+}
+
+// Providers returns the providers with an extractor config, sorted by ID.
+func (s *Scraper) Providers() []catalogs.ProviderID {
+	ids := make([]catalogs.ProviderID, 0, len(s.configs))
+	for id := range s.configs {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Verify fetches each documentation page of the provider and checks it
+// against its extractor config. Fetch failures are reported per page rather
+// than returned, so one unreachable page does not hide the others.
+func (s *Scraper) Verify(ctx context.Context, providerID catalogs.ProviderID) ([]PageReport, error) {
+	config, ok := s.configs[providerID]
+	if !ok {
+		return nil, &errors.NotFoundError{Resource: "extractor config", ID: string(providerID)}
+	}
+	reports := make([]PageReport, 0, len(config.Pages))
+	for _, page := range config.Pages {
+		var report PageReport
+		body, err := s.fetcher.Fetch(ctx, page.URL)
+		if err != nil {
+			report = PageReport{URL: page.URL, Error: err.Error()}
+		} else {
+			report = page.Check(body)
+		}
+		report.Provider = providerID
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
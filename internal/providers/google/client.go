@@ -27,6 +27,9 @@ import (
 	"github.com/agentstation/starmap/pkg/sourcepayload"
 )
 
+// maxModelsPages bounds how many pages one model listing follows.
+const maxModelsPages = 50
+
 type aiStudioModelsResponse struct {
 	Models        []aiStudioModel                  `json:"models"`
 	NextPageToken string                           `json:"nextPageToken,omitempty"`
@@ -378,34 +381,39 @@ func (c *Client) listModelsAIStudioREST(ctx context.Context) ([]catalogs.Model,
 	}
 
 	httpClient := transport.New(provider)
-	pageToken := ""
-	models := make([]catalogs.Model, 0)
-	for {
-		requestURL, err := googleListURL(provider.CatalogEndpointURL(), pageToken)
-		if err != nil {
-			return nil, err
-		}
-		resp, err := httpClient.Get(ctx, requestURL, provider)
-		if err != nil {
-			return nil, err
-		}
-		var result aiStudioModelsResponse
-		if err := transport.DecodeResponse(resp, &result); err != nil {
-			return nil, err
-		}
-		if result.Models == nil {
-			return nil, errors.NewParseError("json", "google AI Studio response", "required models array is missing or null", nil)
-		}
-		for _, rawModel := range result.Models {
-			rawModel.UnknownFields = append(rawModel.UnknownFields, result.UnknownFields...)
-			models = append(models, *c.convertAIStudioModel(rawModel))
-		}
-		if result.NextPageToken == "" {
-			break
-		}
-		pageToken = result.NextPageToken
+	listed, err := transport.Paginate(ctx, provider.ID.String(), maxModelsPages,
+		func(m aiStudioModel) string { return m.Name },
+		func(ctx context.Context, pageToken string) (transport.Page[aiStudioModel], error) {
+			requestURL, err := googleListURL(provider.CatalogEndpointURL(), pageToken)
+			if err != nil {
+				return transport.Page[aiStudioModel]{}, err
+			}
+			resp, err := httpClient.Get(ctx, requestURL, provider)
+			if err != nil {
+				return transport.Page[aiStudioModel]{}, err
+			}
+			var result aiStudioModelsResponse
+			if err := transport.DecodeResponse(resp, &result); err != nil {
+				return transport.Page[aiStudioModel]{}, err
+			}
+			if result.Models == nil {
+				return transport.Page[aiStudioModel]{}, errors.NewParseError("json", "google AI Studio response", "required models array is missing or null", nil)
+			}
+			for i := range result.Models {
+				result.Models[i].UnknownFields = append(result.Models[i].UnknownFields, result.UnknownFields...)
+			}
+			return transport.Page[aiStudioModel]{Items: result.Models, Next: result.NextPageToken}, nil
+		})
+	if listed == nil && err != nil {
+		return nil, err
 	}
-	return models, nil
+
+	// A partial listing keeps the models of the pages fetched so far
+	models := make([]catalogs.Model, 0, len(listed))
+	for _, rawModel := range listed {
+		models = append(models, *c.convertAIStudioModel(rawModel))
+	}
+	return models, err
 }
 
 func googleListURL(endpoint, pageToken string) (string, error) {
@@ -495,14 +503,15 @@ func (c *Client) listModelsVertex(ctx context.Context) ([]catalogs.Model, error)
 	// Wait for result or timeout
 	select {
 	case res := <-resultChan:
-		if res.err != nil {
+		var partial *errors.PartialListError
+		if res.err != nil && !stderrors.As(res.err, &partial) {
 			return nil, res.err
 		}
 
 		// Add Model Garden models from pre-defined list
 		modelGardenModels := c.getModelGardenModels()
 		models := c.mergeModels(res.models, modelGardenModels)
-		return models, nil
+		return models, res.err
 
 	case <-vertexCtx.Done():
 		return nil, &errors.APIError{
@@ -526,22 +535,24 @@ func (c *Client) listModelsViaGenAI(ctx context.Context, client *genai.Client) (
 	c.mu.RUnlock()
 	logger := logging.FromContext(logging.WithProvider(ctx, providerID))
 
-	// Get all base models with pagination
-	baseModels, err := c.getAllModelsGenAI(ctx, client, true)
-	if err != nil {
-		logger.Warn().Err(err).Str("model_scope", "base").Msg("Could not list Google models")
-	} else {
-		for _, model := range baseModels {
-			models = append(models, *model)
+	// Get all base and tuned/custom models with pagination. A partial listing
+	// keeps its models and is reported with the result.
+	var partial error
+	var err error
+	for _, scope := range []struct {
+		name string
+		base bool
+	}{{name: "base", base: true}, {name: "tuned"}} {
+		var scoped []*catalogs.Model
+		scoped, err = c.getAllModelsGenAI(ctx, client, scope.base)
+		if err != nil {
+			logger.Warn().Err(err).Str("model_scope", scope.name).Msg("Could not list Google models")
 		}
-	}
-
-	// Get all tuned/custom models with pagination
-	tunedModels, err := c.getAllModelsGenAI(ctx, client, false)
-	if err != nil {
-		logger.Warn().Err(err).Str("model_scope", "tuned").Msg("Could not list Google models")
-	} else {
-		for _, model := range tunedModels {
+		var partialErr *errors.PartialListError
+		if stderrors.As(err, &partialErr) {
+			partial = err
+		}
+		for _, model := range scoped {
 			models = append(models, *model)
 		}
 	}
@@ -550,7 +561,7 @@ func (c *Client) listModelsViaGenAI(ctx context.Context, client *genai.Client) (
 		return nil, err // Return error if we got no models at all
 	}
 
-	return models, nil
+	return models, partial
 }
 
 // extractModelID extracts the model ID from the full name.
@@ -693,47 +704,46 @@ func (c *Client) applyFeatureRule(features *catalogs.ModelFeatures, modelID stri
 }
 
 // getAllModelsGenAI fetches all models with pagination support using GenAI SDK.
+// A partial listing returns the models of the pages fetched so far with a
+// *errors.PartialListError.
 func (c *Client) getAllModelsGenAI(ctx context.Context, client *genai.Client, queryBase bool) ([]*catalogs.Model, error) {
-	var allModels []*catalogs.Model
-	pageToken := ""
-
-	for {
-		config := &genai.ListModelsConfig{
-			QueryBase: genai.Ptr(queryBase),
-			PageSize:  100, // Get more models per request
-		}
-
-		if pageToken != "" {
-			config.PageToken = pageToken
-		}
-
-		response, err := client.Models.List(ctx, config)
-		if err != nil {
-			return nil, err
-		}
+	providerID := "google"
+	c.mu.RLock()
+	if c.provider != nil {
+		providerID = string(c.provider.ID)
+	}
+	c.mu.RUnlock()
 
-		// Process models in this page
-		for _, model := range response.Items {
-			// Try to get detailed model information
-			detailedModel, err := c.getDetailedModel(ctx, client, model.Name)
+	listed, err := transport.Paginate(ctx, providerID, maxModelsPages,
+		func(m *genai.Model) string { return m.Name },
+		func(ctx context.Context, pageToken string) (transport.Page[*genai.Model], error) {
+			config := &genai.ListModelsConfig{
+				QueryBase: genai.Ptr(queryBase),
+				PageSize:  100, // Get more models per request
+				PageToken: pageToken,
+			}
+			response, err := client.Models.List(ctx, config)
 			if err != nil {
-				// Use basic model data as fallback
-				starmapModel := c.convertGenAIModel(model)
-				allModels = append(allModels, starmapModel)
-			} else {
-				starmapModel := c.convertGenAIModel(detailedModel)
-				allModels = append(allModels, starmapModel)
+				return transport.Page[*genai.Model]{}, err
 			}
-		}
+			return transport.Page[*genai.Model]{Items: response.Items, Next: response.NextPageToken}, nil
+		})
+	if listed == nil && err != nil {
+		return nil, err
+	}
 
-		// Check if there are more pages
-		if response.NextPageToken == "" {
-			break
+	allModels := make([]*catalogs.Model, 0, len(listed))
+	for _, model := range listed {
+		// Try to get detailed model information
+		detailedModel, detailErr := c.getDetailedModel(ctx, client, model.Name)
+		if detailErr != nil {
+			// Use basic model data as fallback
+			allModels = append(allModels, c.convertGenAIModel(model))
+		} else {
+			allModels = append(allModels, c.convertGenAIModel(detailedModel))
 		}
-		pageToken = response.NextPageToken
 	}
-
-	return allModels, nil
+	return allModels, err
 }
 
 // getDetailedModel fetches detailed information for a specific model.
@@ -804,6 +814,12 @@ func (c *Client) convertGenAIModel(genaiModel *genai.Model) *catalogs.Model {
 			model.Features.MaxTokens = true
 		case "streamGenerateContent":
 			model.Features.Streaming = true
+		case "bidiGenerateContent":
+			// Live API sessions
+			if model.Delivery == nil {
+				model.Delivery = &catalogs.ModelDelivery{}
+			}
+			model.Delivery.Realtime = catalogs.GeminiLive()
 		case "countTokens":
 			// Token counting capability
 		case "embedContent":
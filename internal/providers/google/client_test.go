@@ -228,3 +228,29 @@ func TestConvertAIStudioModelPreservesRESTOnlyFields(t *testing.T) {
 		t.Fatalf("supported generation methods = %#v", methods)
 	}
 }
+
+func TestConvertAIStudioModelMapsLiveAPI(t *testing.T) {
+	client := NewClient(&catalogs.Provider{
+		ID:   catalogs.ProviderIDGoogleAIStudio,
+		Name: "Google AI Studio",
+	})
+
+	live := client.convertAIStudioModel(aiStudioModel{
+		Name:                       "models/gemini-live-test",
+		DisplayName:                "Gemini Live Test",
+		SupportedGenerationMethods: []string{"bidiGenerateContent", "countTokens"},
+	})
+	if live.Delivery == nil || live.Delivery.Realtime == nil ||
+		live.Delivery.Realtime.API != catalogs.RealtimeAPIGeminiLive ||
+		live.Delivery.Realtime.InputSampleRate != 16000 {
+		t.Fatalf("delivery = %#v", live.Delivery)
+	}
+
+	chat := client.convertAIStudioModel(aiStudioModel{
+		Name:                       "models/gemini-chat-test",
+		SupportedGenerationMethods: []string{"generateContent"},
+	})
+	if chat.Delivery != nil {
+		t.Fatalf("chat delivery = %#v, want nil", chat.Delivery)
+	}
+}
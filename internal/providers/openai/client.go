@@ -28,6 +28,9 @@ const (
 	fieldID           = "id"
 	fieldOwnedBy      = "owned_by"
 	fieldMetadataTags = "metadata.tags"
+
+	// featureRealtime is the feature rule that marks OpenAI Realtime API models.
+	featureRealtime = "realtime"
 )
 
 // Response represents the OpenAI API list models response.
@@ -126,6 +129,8 @@ type ModelPricing struct {
 	Completion     *float64 `json:"completion,omitempty"`
 	InputCacheRead *float64 `json:"input_cache_read,omitempty"`
 	Image          *float64 `json:"image,omitempty"`
+	Input          *float64 `json:"input,omitempty"`  // Together AI
+	Output         *float64 `json:"output,omitempty"` // Together AI
 }
 
 // UnmarshalJSON accepts provider pricing values as either numbers or numeric strings.
@@ -136,6 +141,8 @@ func (p *ModelPricing) UnmarshalJSON(data []byte) error {
 		Completion     json.RawMessage `json:"completion"`
 		InputCacheRead json.RawMessage `json:"input_cache_read"`
 		Image          json.RawMessage `json:"image"`
+		Input          json.RawMessage `json:"input"`
+		Output         json.RawMessage `json:"output"`
 	}
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
@@ -157,6 +164,12 @@ func (p *ModelPricing) UnmarshalJSON(data []byte) error {
 	if p.Image, err = parseOptionalFloat(raw.Image, "image"); err != nil {
 		return err
 	}
+	if p.Input, err = parseOptionalFloat(raw.Input, "input"); err != nil {
+		return err
+	}
+	if p.Output, err = parseOptionalFloat(raw.Output, "output"); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -334,6 +347,7 @@ func (c *Client) ConvertToModel(m Model) *catalogs.Model {
 
 	// Apply dynamic feature rules
 	model.Features = c.applyFeatureRules(m)
+	c.applyRealtimeRules(model, m)
 
 	c.applyProviderDefaults(model, m)
 
@@ -454,6 +468,12 @@ func (c *Client) applyProviderFeatures(model *catalogs.Model, apiModel Model) {
 			features.StructuredOutputs = true
 		case "reasoning", "thinking":
 			features.Reasoning = true
+		case "parallel_tool_calls":
+			if model.Tools == nil {
+				model.Tools = &catalogs.ModelTools{}
+			}
+			parallel := true
+			model.Tools.ParallelCalls = &parallel
 		}
 	}
 	for _, parameter := range apiModel.SupportedSamplingParameters {
@@ -520,6 +540,13 @@ func applyOpenAICompatiblePricing(pricing *catalogs.ModelPricing, source *ModelP
 	if source.Completion != nil && pricing.Tokens.Output == nil {
 		pricing.Tokens.Output = &catalogs.ModelTokenCost{Per1M: *source.Completion}
 	}
+	// Together AI reports input and output prices in USD per 1M tokens.
+	if source.Input != nil && pricing.Tokens.Input == nil {
+		pricing.Tokens.Input = &catalogs.ModelTokenCost{Per1M: *source.Input}
+	}
+	if source.Output != nil && pricing.Tokens.Output == nil {
+		pricing.Tokens.Output = &catalogs.ModelTokenCost{Per1M: *source.Output}
+	}
 	if source.InputCacheRead != nil {
 		ensureTokenCachePricing(pricing.Tokens)
 		if pricing.Tokens.Cache.Read == nil {
@@ -1058,6 +1085,58 @@ func (c *Client) applyFeatureRules(apiModel Model) *catalogs.ModelFeatures {
 
 // applyFeatureRule applies a single feature rule to the model features.
 func (c *Client) applyFeatureRule(features *catalogs.ModelFeatures, apiModel Model, rule catalogs.FeatureRule) {
+	if !c.featureRuleMatches(apiModel, rule) {
+		return
+	}
+
+	// Apply the feature value
+	switch rule.Feature {
+	case "tools":
+		features.Tools = rule.Value
+	case "tool_choice":
+		features.ToolChoice = rule.Value
+	case "structured_outputs":
+		features.StructuredOutputs = rule.Value
+	case "reasoning":
+		features.Reasoning = rule.Value
+	case "top_k":
+		features.TopK = rule.Value
+	case "format_response":
+		features.FormatResponse = rule.Value
+	}
+}
+
+// applyRealtimeRules marks models matched by a "realtime" feature rule as
+// serving the OpenAI Realtime API.
+func (c *Client) applyRealtimeRules(model *catalogs.Model, apiModel Model) {
+	c.mu.RLock()
+	provider := c.provider
+	c.mu.RUnlock()
+	if provider == nil || provider.Catalog == nil {
+		return
+	}
+	for _, rule := range provider.Catalog.Endpoint.FeatureRules {
+		if rule.Feature != featureRealtime || !c.featureRuleMatches(apiModel, rule) {
+			continue
+		}
+		if !rule.Value {
+			if model.Delivery != nil {
+				model.Delivery.Realtime = nil
+			}
+			continue
+		}
+		if model.Delivery == nil {
+			model.Delivery = &catalogs.ModelDelivery{}
+		}
+		model.Delivery.Realtime = catalogs.OpenAIRealtime()
+		model.Features.Modalities.Input = appendUniqueModality(model.Features.Modalities.Input, catalogs.ModelModalityAudio)
+		model.Features.Modalities.Output = appendUniqueModality(model.Features.Modalities.Output, catalogs.ModelModalityAudio)
+	}
+}
+
+// featureRuleMatches reports whether a feature rule's field contains one of
+// its values.
+func (c *Client) featureRuleMatches(apiModel Model, rule catalogs.FeatureRule) bool {
 	// Get field value to check
 	var fieldValues []string
 	switch rule.Field {
@@ -1070,7 +1149,7 @@ func (c *Client) applyFeatureRule(features *catalogs.ModelFeatures, apiModel Mod
 			fieldValues = apiModel.Metadata.Tags
 		}
 	default:
-		return // Unknown field
+		return false // Unknown field
 	}
 
 	// Check if any of the "contains" values match
@@ -1088,25 +1167,7 @@ func (c *Client) applyFeatureRule(features *catalogs.ModelFeatures, apiModel Mod
 		}
 	}
 
-	if !matches {
-		return
-	}
-
-	// Apply the feature value
-	switch rule.Feature {
-	case "tools":
-		features.Tools = rule.Value
-	case "tool_choice":
-		features.ToolChoice = rule.Value
-	case "structured_outputs":
-		features.StructuredOutputs = rule.Value
-	case "reasoning":
-		features.Reasoning = rule.Value
-	case "top_k":
-		features.TopK = rule.Value
-	case "format_response":
-		features.FormatResponse = rule.Value
-	}
+	return matches
 }
 
 // validateFieldMappings validates that all configured field mappings use valid paths.
@@ -540,7 +540,7 @@ func TestConvertToModelPreservesOpenAICompatibleProviderFields(t *testing.T) {
 		HuggingFaceID:               "meta-llama/Llama-3.3-70B-Instruct",
 		InputModalities:             []string{"text"},
 		OutputModalities:            []string{"text"},
-		SupportedFeatures:           []string{"tools", "json_mode", "reasoning"},
+		SupportedFeatures:           []string{"tools", "parallel_tool_calls", "json_mode", "reasoning"},
 		SupportedSamplingParameters: []string{"temperature", "top_p", "stop", "seed"},
 		Pricing: &ModelPricing{
 			Request:        &requestPrice,
@@ -574,6 +574,9 @@ func TestConvertToModelPreservesOpenAICompatibleProviderFields(t *testing.T) {
 		!model.Features.Seed {
 		t.Fatalf("features = %#v", model.Features)
 	}
+	if model.Tools == nil || model.Tools.ParallelCalls == nil || !*model.Tools.ParallelCalls {
+		t.Fatalf("tools = %#v", model.Tools)
+	}
 	if model.Pricing == nil ||
 		model.Pricing.Tokens == nil ||
 		model.Pricing.Tokens.Input == nil ||
@@ -969,3 +972,56 @@ func TestAPIFormatChanges(t *testing.T) {
 		}
 	})
 }
+
+func TestConvertToModelRealtimeFeatureRule(t *testing.T) {
+	provider := &catalogs.Provider{
+		ID:   catalogs.ProviderIDOpenAI,
+		Name: "OpenAI",
+		Catalog: &catalogs.ProviderCatalog{
+			Endpoint: catalogs.ProviderEndpoint{
+				Type: catalogs.EndpointTypeOpenAI,
+				FeatureRules: []catalogs.FeatureRule{
+					{Field: "id", Contains: []string{"realtime"}, Feature: "realtime", Value: true},
+				},
+			},
+		},
+	}
+	client := newTestClient(t, provider)
+
+	realtime := client.ConvertToModel(Model{ID: "gpt-realtime-mini", Object: "model", OwnedBy: "system"})
+	if realtime.Delivery == nil || realtime.Delivery.Realtime == nil ||
+		realtime.Delivery.Realtime.API != catalogs.RealtimeAPIOpenAI {
+		t.Fatalf("delivery = %#v", realtime.Delivery)
+	}
+	if !slices.Contains(realtime.Delivery.Realtime.Transports, catalogs.ModelResponseProtocolWebRTC) ||
+		!slices.Contains(realtime.Features.Modalities.Input, catalogs.ModelModalityAudio) {
+		t.Fatalf("realtime model = %#v", realtime)
+	}
+
+	chat := client.ConvertToModel(Model{ID: "gpt-5-mini", Object: "model", OwnedBy: "system"})
+	if chat.Delivery != nil {
+		t.Fatalf("chat model delivery = %#v, want nil", chat.Delivery)
+	}
+}
+
+func TestConvertToModelWithTogetherPricing(t *testing.T) {
+	client := newTestClient(t, &catalogs.Provider{
+		ID:   "together",
+		Name: "Together AI",
+		Catalog: &catalogs.ProviderCatalog{
+			Endpoint: catalogs.ProviderEndpoint{Type: catalogs.EndpointTypeOpenAI},
+		},
+	})
+	var model Model
+	payload := []byte(`{"id": "meta-llama/Llama-3.3-70B-Instruct-Turbo", "object": "model", "pricing": {"hourly": 0, "input": 0.88, "output": "0.88", "base": 0, "finetune": 0}}`)
+	if err := json.Unmarshal(payload, &model); err != nil {
+		t.Fatalf("unmarshal model: %v", err)
+	}
+
+	converted := client.ConvertToModel(model)
+	if converted.Pricing == nil || converted.Pricing.Tokens == nil ||
+		converted.Pricing.Tokens.Input == nil || converted.Pricing.Tokens.Input.Per1M != 0.88 ||
+		converted.Pricing.Tokens.Output == nil || converted.Pricing.Tokens.Output.Per1M != 0.88 {
+		t.Fatalf("pricing = %#v, want 0.88 USD per 1M input and output tokens", converted.Pricing)
+	}
+}
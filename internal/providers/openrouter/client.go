@@ -0,0 +1,641 @@
+// Package openrouter provides a client for the OpenRouter models API.
+//
+// OpenRouter routes each model to several upstream providers. Besides the
+// model list, the client reads every model's endpoints listing so the
+// catalog records the per-provider variants with their own pricing, limits,
+// and quantization.
+package openrouter
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentstation/utc"
+
+	"github.com/agentstation/starmap/internal/transport"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/logging"
+	"github.com/agentstation/starmap/pkg/sourcepayload"
+)
+
+// DefaultModelsURL is the public OpenRouter models endpoint.
+const DefaultModelsURL = "https://openrouter.ai/api/v1/models"
+
+// endpointConcurrency bounds the concurrent per-model endpoint requests.
+const endpointConcurrency = 8
+
+// Response structures for the OpenRouter API.
+type modelsResponse struct {
+	Data          []modelResponse                  `json:"data"`
+	UnknownFields []sourcepayload.UnknownJSONField `json:"-"`
+}
+
+func (r *modelsResponse) UnmarshalJSON(data []byte) error {
+	type responseAlias modelsResponse
+	var decoded responseAlias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	unknown, err := sourcepayload.UnknownJSONFields(data, decoded, "$")
+	if err != nil {
+		return err
+	}
+	*r = modelsResponse(decoded)
+	r.UnknownFields = unknown
+	return nil
+}
+
+type modelResponse struct {
+	ID                  string                           `json:"id"`
+	CanonicalSlug       string                           `json:"canonical_slug"`
+	HuggingFaceID       string                           `json:"hugging_face_id"`
+	Name                string                           `json:"name"`
+	Created             int64                            `json:"created"`
+	Description         string                           `json:"description"`
+	ContextLength       int64                            `json:"context_length"`
+	Architecture        architecture                     `json:"architecture"`
+	Pricing             pricing                          `json:"pricing"`
+	TopProvider         topProvider                      `json:"top_provider"`
+	PerRequestLimits    any                              `json:"per_request_limits"`
+	SupportedParameters []string                         `json:"supported_parameters"`
+	DefaultParameters   any                              `json:"default_parameters"`
+	UnknownFields       []sourcepayload.UnknownJSONField `json:"-"`
+}
+
+func (m *modelResponse) UnmarshalJSON(data []byte) error {
+	type modelAlias modelResponse
+	var decoded modelAlias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	unknown, err := sourcepayload.UnknownJSONFields(data, decoded, "data[]")
+	if err != nil {
+		return err
+	}
+	*m = modelResponse(decoded)
+	m.UnknownFields = unknown
+	return nil
+}
+
+type architecture struct {
+	Modality         string   `json:"modality"`
+	InputModalities  []string `json:"input_modalities"`
+	OutputModalities []string `json:"output_modalities"`
+	Tokenizer        string   `json:"tokenizer"`
+	InstructType     *string  `json:"instruct_type"`
+}
+
+// pricing holds OpenRouter prices as decimal strings in USD per token, per
+// request, per image, or per web search.
+type pricing struct {
+	Prompt            string `json:"prompt"`
+	Completion        string `json:"completion"`
+	Request           string `json:"request"`
+	Image             string `json:"image"`
+	Audio             string `json:"audio"`
+	WebSearch         string `json:"web_search"`
+	InternalReasoning string `json:"internal_reasoning"`
+	InputCacheRead    string `json:"input_cache_read"`
+	InputCacheWrite   string `json:"input_cache_write"`
+	Discount          any    `json:"discount"`
+}
+
+type topProvider struct {
+	ContextLength       int64 `json:"context_length"`
+	MaxCompletionTokens int64 `json:"max_completion_tokens"`
+	IsModerated         bool  `json:"is_moderated"`
+}
+
+type endpointsResponse struct {
+	Data struct {
+		ID        string     `json:"id"`
+		Endpoints []endpoint `json:"endpoints"`
+	} `json:"data"`
+}
+
+type endpoint struct {
+	Name                string   `json:"name"`
+	ProviderName        string   `json:"provider_name"`
+	Tag                 string   `json:"tag"`
+	ContextLength       int64    `json:"context_length"`
+	MaxCompletionTokens int64    `json:"max_completion_tokens"`
+	MaxPromptTokens     int64    `json:"max_prompt_tokens"`
+	Quantization        string   `json:"quantization"`
+	Pricing             pricing  `json:"pricing"`
+	SupportedParameters []string `json:"supported_parameters"`
+	Status              *int     `json:"status"`
+	UptimeLast30m       *float64 `json:"uptime_last_30m"`
+}
+
+// Client implements the catalogs.Client interface for OpenRouter.
+type Client struct {
+	provider  *catalogs.Provider
+	transport *transport.Client
+	mu        sync.RWMutex
+}
+
+// NewClient creates a new OpenRouter client.
+func NewClient(provider *catalogs.Provider) *Client {
+	return &Client{
+		provider:  provider,
+		transport: transport.New(provider),
+	}
+}
+
+// IsAPIKeyRequired returns true if the client requires an API key.
+func (c *Client) IsAPIKeyRequired() bool {
+	return c.provider.IsAPIKeyRequired()
+}
+
+// HasAPIKey returns true if the client has an API key.
+func (c *Client) HasAPIKey() bool {
+	return c.provider.HasAPIKey()
+}
+
+// Configure sets the provider for this client (used by registry pattern).
+func (c *Client) Configure(provider *catalogs.Provider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.provider = provider
+	c.transport = transport.New(provider)
+}
+
+// ListModels retrieves all models from OpenRouter together with the
+// provider variants that serve them. A model whose endpoints listing
+// cannot be fetched is still returned, without variants.
+func (c *Client) ListModels(ctx context.Context) ([]catalogs.Model, error) {
+	provider, client, modelsURL, data, err := c.fetchModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := c.fetchEndpoints(ctx, provider, client, modelsURL, data)
+
+	models := make([]catalogs.Model, 0, len(data))
+	for i, m := range data {
+		models = append(models, *c.convertToModel(m, variants[i]))
+	}
+	return models, nil
+}
+
+// FetchPricing returns the live prices OpenRouter publishes for every model,
+// keyed by model ID, without fetching the per-model endpoint listings.
+func (c *Client) FetchPricing(ctx context.Context) (map[string]*catalogs.ModelPricing, error) {
+	_, _, _, data, err := c.fetchModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prices := make(map[string]*catalogs.ModelPricing, len(data))
+	for _, m := range data {
+		if price := convertPricing(m.Pricing); price != nil {
+			prices[m.ID] = price
+		}
+	}
+	return prices, nil
+}
+
+// fetchModels fetches the OpenRouter models listing.
+func (c *Client) fetchModels(ctx context.Context) (*catalogs.Provider, *transport.Client, string, []modelResponse, error) {
+	c.mu.RLock()
+	provider := c.provider
+	client := c.transport
+	c.mu.RUnlock()
+
+	if provider == nil {
+		return nil, nil, "", nil, &errors.ConfigError{
+			Component: "openrouter",
+			Message:   "provider not configured",
+		}
+	}
+
+	modelsURL := transport.NewRequestBuilder(provider).GetModelsURL(DefaultModelsURL)
+	resp, err := client.Get(ctx, modelsURL, provider)
+	if err != nil {
+		return nil, nil, "", nil, &errors.APIError{
+			Provider: provider.ID.String(),
+			Endpoint: modelsURL,
+			Message:  "request failed",
+			Err:      err,
+		}
+	}
+
+	var result modelsResponse
+	if err := transport.DecodeResponse(resp, &result); err != nil {
+		return nil, nil, "", nil, errors.WrapParse("json", "openrouter response", err)
+	}
+	if result.Data == nil {
+		return nil, nil, "", nil, errors.NewParseError("json", "openrouter response", "required data array is missing or null", nil)
+	}
+	for i := range result.Data {
+		result.Data[i].UnknownFields = append(result.Data[i].UnknownFields, result.UnknownFields...)
+	}
+	return provider, client, modelsURL, result.Data, nil
+}
+
+// fetchEndpoints fetches the endpoints listing of every model, indexed like
+// models. Failures are logged and leave the model's entry nil.
+func (c *Client) fetchEndpoints(ctx context.Context, provider *catalogs.Provider, client *transport.Client, modelsURL string, models []modelResponse) [][]endpoint {
+	results := make([][]endpoint, len(models))
+	semaphore := make(chan struct{}, endpointConcurrency)
+	var wg sync.WaitGroup
+	for i, m := range models {
+		if m.ID == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, modelID string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			endpoints, err := fetchModelEndpoints(ctx, provider, client, endpointsURL(modelsURL, modelID))
+			if err != nil {
+				logging.Warn().Err(err).Str("model", modelID).Msg("Failed to fetch OpenRouter model endpoints")
+				return
+			}
+			results[i] = endpoints
+		}(i, m.ID)
+	}
+	wg.Wait()
+	return results
+}
+
+func fetchModelEndpoints(ctx context.Context, provider *catalogs.Provider, client *transport.Client, endpointURL string) ([]endpoint, error) {
+	resp, err := client.Get(ctx, endpointURL, provider)
+	if err != nil {
+		return nil, &errors.APIError{
+			Provider: provider.ID.String(),
+			Endpoint: endpointURL,
+			Message:  "request failed",
+			Err:      err,
+		}
+	}
+	var result endpointsResponse
+	if err := transport.DecodeResponse(resp, &result); err != nil {
+		return nil, errors.WrapParse("json", "openrouter endpoints response", err)
+	}
+	return result.Data.Endpoints, nil
+}
+
+// endpointsURL returns the endpoints listing URL of a model, such as
+// https://openrouter.ai/api/v1/models/openai/gpt-4o/endpoints.
+func endpointsURL(modelsURL, modelID string) string {
+	segments := strings.Split(modelID, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.TrimRight(modelsURL, "/") + "/" + strings.Join(segments, "/") + "/endpoints"
+}
+
+// convertToModel converts an OpenRouter model and its endpoints to a starmap Model.
+func (c *Client) convertToModel(m modelResponse, endpoints []endpoint) *catalogs.Model {
+	model := &catalogs.Model{
+		ID:          m.ID,
+		Name:        m.Name,
+		Description: m.Description,
+	}
+	if model.Name == "" {
+		model.Name = m.ID
+	}
+	if m.Created > 0 {
+		model.CreatedAt = utc.New(time.Unix(m.Created, 0))
+		model.UpdatedAt = model.CreatedAt
+	}
+	if author, _, ok := strings.Cut(m.ID, "/"); ok && author != "" {
+		authorID := catalogs.ParseAuthorID(author)
+		model.Authors = []catalogs.Author{{ID: authorID, Name: authorID.String()}}
+	}
+
+	contextWindow := m.ContextLength
+	if contextWindow == 0 {
+		contextWindow = m.TopProvider.ContextLength
+	}
+	if contextWindow > 0 || m.TopProvider.MaxCompletionTokens > 0 {
+		model.Limits = &catalogs.ModelLimits{
+			ContextWindow: contextWindow,
+			OutputTokens:  m.TopProvider.MaxCompletionTokens,
+		}
+	}
+
+	model.Features = convertFeatures(m.Architecture, m.SupportedParameters)
+	model.Pricing = convertPricing(m.Pricing)
+	if tokenizer := convertTokenizer(m.Architecture.Tokenizer); tokenizer != "" {
+		model.Metadata = &catalogs.ModelMetadata{
+			Architecture: &catalogs.ModelArchitecture{Tokenizer: tokenizer},
+		}
+	}
+
+	c.applyExtensions(model, m, endpoints)
+	return model
+}
+
+func (c *Client) applyExtensions(model *catalogs.Model, m modelResponse, endpoints []endpoint) {
+	fields := make(map[string]any)
+	if m.CanonicalSlug != "" && m.CanonicalSlug != m.ID {
+		fields["canonical_slug"] = m.CanonicalSlug
+	}
+	if m.HuggingFaceID != "" {
+		fields["hugging_face_id"] = m.HuggingFaceID
+	}
+	if m.Architecture.Tokenizer != "" {
+		fields["tokenizer"] = m.Architecture.Tokenizer
+	}
+	if m.Architecture.InstructType != nil && *m.Architecture.InstructType != "" {
+		fields["instruct_type"] = *m.Architecture.InstructType
+	}
+	if m.TopProvider.IsModerated {
+		fields["is_moderated"] = true
+	}
+	if variants := convertVariants(endpoints); len(variants) > 0 {
+		fields["variants"] = variants
+	}
+	if len(m.UnknownFields) > 0 {
+		fields["unknown_fields"] = m.UnknownFields
+	}
+	if len(fields) == 0 {
+		return
+	}
+	if model.Extensions == nil {
+		model.Extensions = catalogs.SourceExtensions{}
+	}
+	model.Extensions[c.extensionSource()] = catalogs.SourceExtension{
+		Fields: catalogs.NormalizeExtensionFields(fields),
+	}
+}
+
+// convertVariants describes every upstream provider endpoint of a model,
+// sorted by provider and tag.
+func convertVariants(endpoints []endpoint) []any {
+	sorted := slices.Clone(endpoints)
+	slices.SortFunc(sorted, func(a, b endpoint) int {
+		if a.ProviderName != b.ProviderName {
+			return strings.Compare(a.ProviderName, b.ProviderName)
+		}
+		return strings.Compare(a.Tag, b.Tag)
+	})
+
+	variants := make([]any, 0, len(sorted))
+	for _, e := range sorted {
+		variant := map[string]any{"provider": e.ProviderName}
+		if e.Tag != "" {
+			variant["tag"] = e.Tag
+		}
+		if e.ContextLength > 0 {
+			variant["context_length"] = e.ContextLength
+		}
+		if e.MaxCompletionTokens > 0 {
+			variant["max_completion_tokens"] = e.MaxCompletionTokens
+		}
+		if e.MaxPromptTokens > 0 {
+			variant["max_prompt_tokens"] = e.MaxPromptTokens
+		}
+		if e.Quantization != "" && e.Quantization != "unknown" {
+			variant["quantization"] = e.Quantization
+		}
+		if e.Status != nil {
+			variant["status"] = *e.Status
+		}
+		if prices := variantPricing(e.Pricing); len(prices) > 0 {
+			variant["pricing"] = prices
+		}
+		if len(e.SupportedParameters) > 0 {
+			variant["supported_parameters"] = slices.Sorted(slices.Values(e.SupportedParameters))
+		}
+		variants = append(variants, variant)
+	}
+	return variants
+}
+
+// variantPricing returns a variant's prices in catalog units: USD per 1M
+// tokens for token prices and USD per operation otherwise.
+func variantPricing(p pricing) map[string]any {
+	prices := make(map[string]any)
+	for name, value := range map[string]string{
+		"input_per_1m":       p.Prompt,
+		"output_per_1m":      p.Completion,
+		"reasoning_per_1m":   p.InternalReasoning,
+		"cache_read_per_1m":  p.InputCacheRead,
+		"cache_write_per_1m": p.InputCacheWrite,
+	} {
+		if cost := tokenCost(value); cost != nil {
+			prices[name] = cost.Per1M
+		}
+	}
+	for name, value := range map[string]string{
+		"request":    p.Request,
+		"image":      p.Image,
+		"web_search": p.WebSearch,
+	} {
+		if price, ok := parsePrice(value); ok && price > 0 {
+			prices[name] = price
+		}
+	}
+	return prices
+}
+
+// convertPricing converts OpenRouter per-token prices to catalog pricing.
+func convertPricing(p pricing) *catalogs.ModelPricing {
+	tokens := &catalogs.ModelTokenPricing{
+		Input:     tokenCost(p.Prompt),
+		Output:    tokenCost(p.Completion),
+		Reasoning: tokenCost(p.InternalReasoning),
+	}
+	if read, write := tokenCost(p.InputCacheRead), tokenCost(p.InputCacheWrite); read != nil || write != nil {
+		tokens.Cache = &catalogs.ModelTokenCachePricing{Read: read, Write: write}
+	}
+
+	operations := &catalogs.ModelOperationPricing{
+		Request:    operationCost(p.Request),
+		ImageInput: operationCost(p.Image),
+		AudioInput: operationCost(p.Audio),
+		WebSearch:  operationCost(p.WebSearch),
+	}
+
+	result := &catalogs.ModelPricing{Currency: catalogs.ModelPricingCurrencyUSD}
+	if tokens.Input != nil || tokens.Output != nil || tokens.Reasoning != nil || tokens.Cache != nil {
+		result.Tokens = tokens
+	}
+	if operations.Request != nil || operations.ImageInput != nil || operations.AudioInput != nil || operations.WebSearch != nil {
+		result.Operations = operations
+	}
+	if result.Tokens == nil && result.Operations == nil {
+		return nil
+	}
+	return result
+}
+
+func tokenCost(value string) *catalogs.ModelTokenCost {
+	perToken, ok := parsePrice(value)
+	if !ok {
+		return nil
+	}
+	cost := &catalogs.ModelTokenCost{PerToken: perToken}
+	cost.Per1M = *cost.PerMillion()
+	return cost
+}
+
+// operationCost returns a positive per-operation price; OpenRouter reports
+// "0" for operations it does not bill separately.
+func operationCost(value string) *float64 {
+	price, ok := parsePrice(value)
+	if !ok || price == 0 {
+		return nil
+	}
+	return &price
+}
+
+// parsePrice parses an OpenRouter decimal price. Empty, malformed, and
+// negative prices (OpenRouter uses -1 for dynamically priced routers) are
+// reported as absent.
+func parsePrice(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil || price < 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		return 0, false
+	}
+	return price, true
+}
+
+func convertFeatures(arch architecture, parameters []string) *catalogs.ModelFeatures {
+	features := &catalogs.ModelFeatures{
+		Modalities: catalogs.ModelModalities{
+			Input:  convertModalities(arch.InputModalities),
+			Output: convertModalities(arch.OutputModalities),
+		},
+	}
+	if len(features.Modalities.Input) == 0 {
+		features.Modalities.Input = []catalogs.ModelModality{catalogs.ModelModalityText}
+	}
+	if len(features.Modalities.Output) == 0 {
+		features.Modalities.Output = []catalogs.ModelModality{catalogs.ModelModalityText}
+	}
+	for _, modality := range features.Modalities.Input {
+		if modality != catalogs.ModelModalityText {
+			features.Attachments = true
+		}
+	}
+
+	for _, parameter := range parameters {
+		switch parameter {
+		case "tools":
+			features.Tools = true
+		case "tool_choice":
+			features.ToolChoice = true
+		case "temperature":
+			features.Temperature = true
+		case "top_p":
+			features.TopP = true
+		case "top_k":
+			features.TopK = true
+		case "top_a":
+			features.TopA = true
+		case "min_p":
+			features.MinP = true
+		case "max_tokens":
+			features.MaxTokens = true
+		case "stop":
+			features.Stop = true
+		case "seed":
+			features.Seed = true
+		case "logprobs":
+			features.Logprobs = true
+		case "top_logprobs":
+			features.TopLogprobs = true
+		case "frequency_penalty":
+			features.FrequencyPenalty = true
+		case "presence_penalty":
+			features.PresencePenalty = true
+		case "repetition_penalty":
+			features.RepetitionPenalty = true
+		case "logit_bias":
+			features.LogitBias = true
+		case "reasoning":
+			features.Reasoning = true
+		case "include_reasoning":
+			features.IncludeReasoning = true
+		case "reasoning_effort":
+			features.ReasoningEffort = true
+		case "response_format":
+			features.FormatResponse = true
+		case "structured_outputs":
+			features.StructuredOutputs = true
+		case "web_search_options":
+			features.WebSearch = true
+		}
+	}
+	// Every OpenRouter chat model streams through the unified API.
+	features.Streaming = true
+	return features
+}
+
+func convertModalities(values []string) []catalogs.ModelModality {
+	modalities := make([]catalogs.ModelModality, 0, len(values))
+	for _, value := range values {
+		var modality catalogs.ModelModality
+		switch strings.ToLower(value) {
+		case "text":
+			modality = catalogs.ModelModalityText
+		case "image":
+			modality = catalogs.ModelModalityImage
+		case "audio":
+			modality = catalogs.ModelModalityAudio
+		case "video":
+			modality = catalogs.ModelModalityVideo
+		case "file":
+			modality = catalogs.ModelModalityPDF
+		case "embeddings", "embedding":
+			modality = catalogs.ModelModalityEmbedding
+		default:
+			continue
+		}
+		if !slices.Contains(modalities, modality) {
+			modalities = append(modalities, modality)
+		}
+	}
+	return modalities
+}
+
+// convertTokenizer maps OpenRouter tokenizer names to catalog tokenizers.
+// Tokenizers the catalog does not model are left to the extension fields.
+func convertTokenizer(value string) catalogs.Tokenizer {
+	switch strings.ToLower(value) {
+	case "gpt":
+		return catalogs.TokenizerGPT
+	case "claude":
+		return catalogs.TokenizerClaude
+	case "gemini":
+		return catalogs.TokenizerGemini
+	case "llama2":
+		return catalogs.TokenizerLlama2
+	case "llama3":
+		return catalogs.TokenizerLlama3
+	case "mistral":
+		return catalogs.TokenizerMistral
+	case "deepseek":
+		return catalogs.TokenizerDeepSeek
+	case "cohere":
+		return catalogs.TokenizerCohere
+	default:
+		return ""
+	}
+}
+
+func (c *Client) extensionSource() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.provider != nil && c.provider.ID != "" {
+		return c.provider.ID.String()
+	}
+	return catalogs.ProviderIDOpenRouter.String()
+}
@@ -0,0 +1,212 @@
+package openrouter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+const testModelsPayload = `{"data":[
+	{
+		"id": "openai/gpt-4o",
+		"canonical_slug": "openai/gpt-4o-2024-05-13",
+		"name": "OpenAI: GPT-4o",
+		"created": 1715558400,
+		"description": "GPT-4o is OpenAI's flagship model.",
+		"context_length": 128000,
+		"architecture": {"modality": "text+image->text", "input_modalities": ["text", "image", "file"], "output_modalities": ["text"], "tokenizer": "GPT", "instruct_type": null},
+		"pricing": {"prompt": "0.0000025", "completion": "0.00001", "request": "0", "image": "0.003613", "web_search": "0", "internal_reasoning": "0", "input_cache_read": "0.00000125"},
+		"top_provider": {"context_length": 128000, "max_completion_tokens": 16384, "is_moderated": true},
+		"per_request_limits": null,
+		"supported_parameters": ["max_tokens", "temperature", "tools", "tool_choice", "response_format", "structured_outputs", "seed"],
+		"new_field": true
+	},
+	{
+		"id": "openrouter/auto",
+		"name": "Auto Router",
+		"context_length": 2000000,
+		"architecture": {"input_modalities": ["text"], "output_modalities": ["text"], "tokenizer": "Router"},
+		"pricing": {"prompt": "-1", "completion": "-1"},
+		"top_provider": {"context_length": null, "max_completion_tokens": null, "is_moderated": false},
+		"supported_parameters": []
+	}
+]}`
+
+const testEndpointsPayload = `{"data":{"id":"openai/gpt-4o","endpoints":[
+	{"name": "OpenAI | openai/gpt-4o", "provider_name": "OpenAI", "tag": "openai", "context_length": 128000, "max_completion_tokens": 16384, "quantization": "unknown", "pricing": {"prompt": "0.0000025", "completion": "0.00001"}, "status": 0},
+	{"name": "Azure | openai/gpt-4o", "provider_name": "Azure", "tag": "azure", "context_length": 128000, "max_completion_tokens": 4096, "quantization": "fp16", "pricing": {"prompt": "0.000005", "completion": "0.000015"}, "status": 0}
+]}}`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/models":
+			_, _ = w.Write([]byte(testModelsPayload))
+		case "/api/v1/models/openai/gpt-4o/endpoints":
+			_, _ = w.Write([]byte(testEndpointsPayload))
+		default:
+			http.Error(w, `{"error":{"message":"not found"}}`, http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestClient(url string) *Client {
+	return NewClient(&catalogs.Provider{
+		ID: catalogs.ProviderIDOpenRouter, Name: "OpenRouter",
+		Catalog: &catalogs.ProviderCatalog{Endpoint: catalogs.ProviderEndpoint{
+			Type: catalogs.EndpointTypeOpenRouter, URL: url + "/api/v1/models",
+		}},
+	})
+}
+
+func TestListModels(t *testing.T) {
+	server := newTestServer(t)
+	models, err := newTestClient(server.URL).ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels: %v", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("models = %d, want 2", len(models))
+	}
+
+	model := models[0]
+	if model.ID != "openai/gpt-4o" || model.Name != "OpenAI: GPT-4o" {
+		t.Fatalf("model = %s %q", model.ID, model.Name)
+	}
+	if len(model.Authors) != 1 || model.Authors[0].ID != catalogs.AuthorIDOpenAI {
+		t.Errorf("authors = %+v, want openai", model.Authors)
+	}
+	if model.Limits == nil || model.Limits.ContextWindow != 128000 || model.Limits.OutputTokens != 16384 {
+		t.Errorf("limits = %+v", model.Limits)
+	}
+
+	pricing := model.Pricing
+	if pricing == nil || pricing.Tokens == nil {
+		t.Fatalf("pricing = %+v", pricing)
+	}
+	if got := pricing.Tokens.Input.Per1M; got != 2.5 {
+		t.Errorf("input per 1M = %v, want 2.5", got)
+	}
+	if got := pricing.Tokens.Output.Per1M; got != 10 {
+		t.Errorf("output per 1M = %v, want 10", got)
+	}
+	if pricing.Tokens.Cache == nil || pricing.Tokens.Cache.Read.Per1M != 1.25 {
+		t.Errorf("cache = %+v, want read 1.25", pricing.Tokens.Cache)
+	}
+	if pricing.Tokens.Reasoning == nil || pricing.Tokens.Reasoning.Per1M != 0 {
+		t.Errorf("reasoning = %+v, want free", pricing.Tokens.Reasoning)
+	}
+	if pricing.Operations == nil || pricing.Operations.ImageInput == nil || *pricing.Operations.ImageInput != 0.003613 {
+		t.Errorf("operations = %+v, want image input price", pricing.Operations)
+	}
+	if pricing.Operations.Request != nil || pricing.Operations.WebSearch != nil {
+		t.Errorf("zero operation prices should be absent: %+v", pricing.Operations)
+	}
+
+	features := model.Features
+	if !features.Tools || !features.ToolChoice || !features.StructuredOutputs || !features.Seed || features.TopK {
+		t.Errorf("features = %+v", features)
+	}
+	if len(features.Modalities.Input) != 3 || features.Modalities.Input[2] != catalogs.ModelModalityPDF {
+		t.Errorf("input modalities = %v", features.Modalities.Input)
+	}
+	if model.Metadata == nil || model.Metadata.Architecture.Tokenizer != catalogs.TokenizerGPT {
+		t.Errorf("metadata = %+v", model.Metadata)
+	}
+
+	fields := model.Extensions["openrouter"].Fields
+	if fields["canonical_slug"] != "openai/gpt-4o-2024-05-13" || fields["is_moderated"] != true {
+		t.Errorf("extension fields = %#v", fields)
+	}
+	if fields["unknown_fields"] == nil {
+		t.Error("unknown response field was not recorded")
+	}
+	variants, ok := fields["variants"].([]any)
+	if !ok || len(variants) != 2 {
+		t.Fatalf("variants = %#v", fields["variants"])
+	}
+	azure := variants[0].(map[string]any)
+	if azure["provider"] != "Azure" || azure["quantization"] != "fp16" {
+		t.Errorf("first variant = %#v, want Azure fp16", azure)
+	}
+	if prices := azure["pricing"].(map[string]any); fmt.Sprint(prices["input_per_1m"]) != "5" || fmt.Sprint(prices["output_per_1m"]) != "15" {
+		t.Errorf("Azure pricing = %#v", prices)
+	}
+	if _, ok := variants[1].(map[string]any)["quantization"]; ok {
+		t.Error("unknown quantization should be omitted")
+	}
+}
+
+func TestListModelsDynamicPricingAndMissingEndpoints(t *testing.T) {
+	server := newTestServer(t)
+	models, err := newTestClient(server.URL).ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels: %v", err)
+	}
+	router := models[1]
+	if router.Pricing != nil {
+		t.Errorf("negative router prices should be absent, got %+v", router.Pricing)
+	}
+	if router.Metadata != nil {
+		t.Errorf("unmodeled tokenizer should not set metadata, got %+v", router.Metadata)
+	}
+	if router.Authors[0].ID != "openrouter" {
+		t.Errorf("authors = %+v", router.Authors)
+	}
+	if _, ok := router.Extensions["openrouter"].Fields["variants"]; ok {
+		t.Error("failed endpoints listing should leave the model without variants")
+	}
+	if router.Extensions["openrouter"].Fields["tokenizer"] != "Router" {
+		t.Errorf("extension fields = %#v", router.Extensions["openrouter"].Fields)
+	}
+}
+
+func TestListModelsSchemaDrift(t *testing.T) {
+	for name, payload := range map[string]string{
+		"missing":    `{}`,
+		"null":       `{"data":null}`,
+		"wrong type": `{"data":{}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(payload))
+			}))
+			defer server.Close()
+			if _, err := newTestClient(server.URL).ListModels(context.Background()); err == nil {
+				t.Fatal("ListModels returned nil error")
+			}
+		})
+	}
+}
+
+func TestEndpointsURL(t *testing.T) {
+	got := endpointsURL("https://openrouter.ai/api/v1/models/", "meta-llama/llama-3.1-8b-instruct:free")
+	want := "https://openrouter.ai/api/v1/models/meta-llama/llama-3.1-8b-instruct:free/endpoints"
+	if got != want {
+		t.Errorf("endpointsURL = %q, want %q", got, want)
+	}
+}
+
+func TestFetchPricing(t *testing.T) {
+	server := newTestServer(t)
+	prices, err := newTestClient(server.URL).FetchPricing(context.Background())
+	if err != nil {
+		t.Fatalf("FetchPricing: %v", err)
+	}
+	gpt4o := prices["openai/gpt-4o"]
+	if gpt4o == nil || gpt4o.Tokens == nil || gpt4o.Tokens.Input == nil || gpt4o.Tokens.Output == nil {
+		t.Fatalf("openai/gpt-4o pricing = %#v, want input and output prices", gpt4o)
+	}
+	if _, ok := prices["openrouter/auto"]; ok {
+		t.Error("router with negative prices should be unpriced")
+	}
+}
@@ -0,0 +1,259 @@
+// Package perplexity provides a client for the Perplexity API.
+//
+// Perplexity lists its models through an OpenAI-compatible endpoint that
+// reports little beyond model IDs, so the client fills in context windows,
+// prices, and search support from Perplexity's published model table. Sonar
+// models answer from live web search; their search support is recorded as
+// Features.WebSearch along with the search context sizes they accept.
+package perplexity
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/agentstation/starmap/internal/providers/openai"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// sonarModel is Perplexity's published metadata for one model. Token prices
+// are USD per 1M tokens; request fees are USD per 1K requests at each search
+// context size.
+type sonarModel struct {
+	contextWindow int64
+	outputTokens  int64
+	online        bool
+	reasoning     bool
+	input         float64
+	output        float64
+	reasoningCost float64
+	citationCost  float64
+	searchCost    float64 // USD per 1K search queries, billed separately from requests
+	requestFees   map[catalogs.ModelControlLevel]float64
+}
+
+// sonarModels is Perplexity's model table, from
+// https://docs.perplexity.ai/getting-started/pricing.
+var sonarModels = map[string]sonarModel{
+	"sonar": {
+		contextWindow: 128000, online: true, input: 1, output: 1,
+		requestFees: fees(5, 8, 12),
+	},
+	"sonar-pro": {
+		contextWindow: 200000, outputTokens: 8000, online: true, input: 3, output: 15,
+		requestFees: fees(6, 10, 14),
+	},
+	"sonar-reasoning": {
+		contextWindow: 128000, online: true, reasoning: true, input: 1, output: 5,
+		requestFees: fees(5, 8, 12),
+	},
+	"sonar-reasoning-pro": {
+		contextWindow: 128000, online: true, reasoning: true, input: 2, output: 8,
+		requestFees: fees(6, 10, 14),
+	},
+	"sonar-deep-research": {
+		contextWindow: 128000, online: true, reasoning: true, input: 2, output: 8,
+		reasoningCost: 3, citationCost: 2, searchCost: 5,
+	},
+	"r1-1776": {
+		contextWindow: 128000, reasoning: true, input: 2, output: 8,
+	},
+}
+
+// searchContextSizes are the search_context_size values sonar models accept.
+var searchContextSizes = []catalogs.ModelControlLevel{
+	catalogs.ModelControlLevelLow,
+	catalogs.ModelControlLevelMedium,
+	catalogs.ModelControlLevelHigh,
+}
+
+func fees(low, medium, high float64) map[catalogs.ModelControlLevel]float64 {
+	return map[catalogs.ModelControlLevel]float64{
+		catalogs.ModelControlLevelLow:    low,
+		catalogs.ModelControlLevelMedium: medium,
+		catalogs.ModelControlLevelHigh:   high,
+	}
+}
+
+// Client implements the catalogs.Client interface for Perplexity.
+type Client struct {
+	provider *catalogs.Provider
+	models   *openai.Client
+	mu       sync.RWMutex
+}
+
+// NewClient creates a new Perplexity client.
+func NewClient(provider *catalogs.Provider) (*Client, error) {
+	models, err := openai.NewClient(provider)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{provider: provider, models: models}, nil
+}
+
+// IsAPIKeyRequired returns true if the client requires an API key.
+func (c *Client) IsAPIKeyRequired() bool {
+	return c.listClient().IsAPIKeyRequired()
+}
+
+// HasAPIKey returns true if the client has an API key.
+func (c *Client) HasAPIKey() bool {
+	return c.listClient().HasAPIKey()
+}
+
+// Configure sets the provider for this client (used by registry pattern).
+func (c *Client) Configure(provider *catalogs.Provider) error {
+	if err := c.listClient().Configure(provider); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.provider = provider
+	return nil
+}
+
+// ListModels retrieves Perplexity's models and adds their published metadata.
+func (c *Client) ListModels(ctx context.Context) ([]catalogs.Model, error) {
+	models, err := c.listClient().ListModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	source := c.extensionSource()
+	for i := range models {
+		applySonarMetadata(&models[i], source)
+	}
+	return models, nil
+}
+
+func (c *Client) listClient() *openai.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.models
+}
+
+func (c *Client) extensionSource() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.provider != nil && c.provider.ID != "" {
+		return c.provider.ID.String()
+	}
+	return catalogs.ProviderIDPerplexity.String()
+}
+
+// applySonarMetadata fills in what the listing does not report. Values the
+// listing does report are kept. Request fees for each search context size
+// are recorded under the source extension.
+func applySonarMetadata(model *catalogs.Model, source string) {
+	if len(model.Authors) == 0 {
+		model.Authors = []catalogs.Author{{ID: catalogs.AuthorIDPerplexity, Name: "Perplexity"}}
+	}
+	meta, ok := sonarModels[strings.ToLower(model.ID)]
+	if !ok {
+		// Unlisted sonar models are search-augmented like the rest of the family.
+		if strings.HasPrefix(strings.ToLower(model.ID), "sonar") {
+			setWebSearch(model)
+		}
+		return
+	}
+
+	if model.Limits == nil {
+		model.Limits = &catalogs.ModelLimits{}
+	}
+	if model.Limits.ContextWindow == 0 {
+		model.Limits.ContextWindow = meta.contextWindow
+	}
+	if model.Limits.OutputTokens == 0 {
+		model.Limits.OutputTokens = meta.outputTokens
+	}
+
+	if model.Features == nil {
+		model.Features = &catalogs.ModelFeatures{}
+	}
+	if len(model.Features.Modalities.Input) == 0 {
+		model.Features.Modalities.Input = []catalogs.ModelModality{catalogs.ModelModalityText}
+	}
+	if len(model.Features.Modalities.Output) == 0 {
+		model.Features.Modalities.Output = []catalogs.ModelModality{catalogs.ModelModalityText}
+	}
+	if meta.reasoning {
+		model.Features.Reasoning = true
+		model.Features.IncludeReasoning = true
+	}
+	if meta.online {
+		setWebSearch(model)
+	}
+
+	if model.Pricing == nil || model.Pricing.Tokens == nil {
+		model.Pricing = meta.pricing()
+	}
+
+	fields := map[string]any{}
+	if len(meta.requestFees) > 0 {
+		requestFees := make(map[string]any, len(meta.requestFees))
+		for size, fee := range meta.requestFees {
+			requestFees[size.String()] = fee
+		}
+		fields["request_fees_per_1k"] = requestFees
+	}
+	if meta.citationCost > 0 {
+		fields["citation_tokens_per_1m"] = meta.citationCost
+	}
+	if len(fields) > 0 {
+		if model.Extensions == nil {
+			model.Extensions = catalogs.SourceExtensions{}
+		}
+		extension := model.Extensions[source]
+		if extension.Fields == nil {
+			extension.Fields = map[string]any{}
+		}
+		for key, value := range catalogs.NormalizeExtensionFields(fields) {
+			extension.Fields[key] = value
+		}
+		model.Extensions[source] = extension
+	}
+}
+
+// setWebSearch marks a model as answering from live web search.
+func setWebSearch(model *catalogs.Model) {
+	if model.Features == nil {
+		model.Features = &catalogs.ModelFeatures{}
+	}
+	model.Features.WebSearch = true
+	if model.Tools == nil {
+		model.Tools = &catalogs.ModelTools{}
+	}
+	if model.Tools.WebSearch == nil {
+		low := catalogs.ModelControlLevelLow
+		model.Tools.WebSearch = &catalogs.ModelWebSearch{
+			SearchContextSizes: append([]catalogs.ModelControlLevel(nil), searchContextSizes...),
+			DefaultContextSize: &low,
+		}
+	}
+}
+
+// pricing converts the published prices. The per-request fee is the fee at
+// the default, low, search context size.
+func (m sonarModel) pricing() *catalogs.ModelPricing {
+	pricing := &catalogs.ModelPricing{
+		Currency: catalogs.ModelPricingCurrencyUSD,
+		Tokens: &catalogs.ModelTokenPricing{
+			Input:  &catalogs.ModelTokenCost{Per1M: m.input},
+			Output: &catalogs.ModelTokenCost{Per1M: m.output},
+		},
+	}
+	if m.reasoningCost > 0 {
+		pricing.Tokens.Reasoning = &catalogs.ModelTokenCost{Per1M: m.reasoningCost}
+	}
+	if fee, ok := m.requestFees[catalogs.ModelControlLevelLow]; ok {
+		perRequest := fee / 1000
+		pricing.Operations = &catalogs.ModelOperationPricing{Request: &perRequest}
+	}
+	if m.searchCost > 0 {
+		perSearch := m.searchCost / 1000
+		if pricing.Operations == nil {
+			pricing.Operations = &catalogs.ModelOperationPricing{}
+		}
+		pricing.Operations.WebSearch = &perSearch
+	}
+	return pricing
+}
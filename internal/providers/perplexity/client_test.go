@@ -0,0 +1,105 @@
+package perplexity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func newTestClient(t *testing.T, url string) *Client {
+	t.Helper()
+	client, err := NewClient(&catalogs.Provider{
+		ID: catalogs.ProviderIDPerplexity, Name: "Perplexity",
+		Catalog: &catalogs.ProviderCatalog{Endpoint: catalogs.ProviderEndpoint{Type: catalogs.EndpointTypePerplexity, URL: url}},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func serve(t *testing.T, payload string) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestSchemaDriftMutationMatrix(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		wantErr    bool
+		wantModels int
+	}{
+		{name: "valid", payload: `{"object":"list","data":[{"id":"sonar","object":"model"}]}`, wantModels: 1},
+		{name: "missing", payload: `{}`, wantErr: true},
+		{name: "renamed", payload: `{"models":[]}`, wantErr: true},
+		{name: "null", payload: `{"data":null}`, wantErr: true},
+		{name: "wrong type", payload: `{"data":{}}`, wantErr: true},
+		{name: "unknown additive", payload: `{"data":[{"id":"sonar","search_modes":["web"]}],"new_page":1}`, wantModels: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			models, err := newTestClient(t, serve(t, test.payload)).ListModels(context.Background())
+			if test.wantErr && err == nil {
+				t.Fatal("ListModels returned nil error")
+			}
+			if !test.wantErr && err != nil {
+				t.Fatalf("ListModels: %v", err)
+			}
+			if len(models) != test.wantModels {
+				t.Fatalf("models = %d, want %d", len(models), test.wantModels)
+			}
+		})
+	}
+}
+
+func TestListModelsAddsSonarMetadata(t *testing.T) {
+	url := serve(t, `{"data":[{"id":"sonar-pro"},{"id":"sonar-deep-research"},{"id":"r1-1776"},{"id":"sonar-next"}]}`)
+	models, err := newTestClient(t, url).ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels: %v", err)
+	}
+	byID := map[string]catalogs.Model{}
+	for _, model := range models {
+		byID[model.ID] = model
+	}
+
+	pro := byID["sonar-pro"]
+	if !pro.Features.WebSearch || pro.Tools == nil || pro.Tools.WebSearch == nil ||
+		len(pro.Tools.WebSearch.SearchContextSizes) != 3 || *pro.Tools.WebSearch.DefaultContextSize != catalogs.ModelControlLevelLow {
+		t.Fatalf("sonar-pro web search = %+v %+v", pro.Features, pro.Tools)
+	}
+	if pro.Limits.ContextWindow != 200000 || pro.Limits.OutputTokens != 8000 {
+		t.Fatalf("sonar-pro limits = %+v", pro.Limits)
+	}
+	if pro.Pricing.Tokens.Output.Per1M != 15 || *pro.Pricing.Operations.Request != 0.006 {
+		t.Fatalf("sonar-pro pricing = %+v %+v", pro.Pricing.Tokens, pro.Pricing.Operations)
+	}
+	fees := pro.Extensions["perplexity"].Fields["request_fees_per_1k"].(map[string]any)
+	if fees["high"] != int64(14) {
+		t.Fatalf("sonar-pro request fees = %#v", fees)
+	}
+
+	research := byID["sonar-deep-research"]
+	if !research.Features.Reasoning || research.Pricing.Tokens.Reasoning.Per1M != 3 || *research.Pricing.Operations.WebSearch != 0.005 {
+		t.Fatalf("deep research = %+v %+v", research.Features, research.Pricing)
+	}
+
+	offline := byID["r1-1776"]
+	if offline.Features.WebSearch || offline.Tools != nil {
+		t.Fatalf("r1-1776 should not search: %+v %+v", offline.Features, offline.Tools)
+	}
+
+	unlisted := byID["sonar-next"]
+	if !unlisted.Features.WebSearch || unlisted.Pricing != nil {
+		t.Fatalf("unlisted sonar model = %+v %+v", unlisted.Features, unlisted.Pricing)
+	}
+}
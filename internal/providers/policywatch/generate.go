@@ -0,0 +1,2 @@
+//go:generate gomarkdoc -e -o README.md . --repository.url https://github.com/agentstation/starmap --repository.default-branch main --repository.path /internal/providers/policywatch
+package policywatch
@@ -0,0 +1,65 @@
+package policywatch
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Store persists the latest document hashes as one YAML file per provider.
+type Store struct {
+	Dir string
+}
+
+func (s Store) path(providerID catalogs.ProviderID) string {
+	return filepath.Join(s.Dir, string(providerID)+".yaml")
+}
+
+// Load returns the recorded documents for a provider, or nil if none exist.
+func (s Store) Load(providerID catalogs.ProviderID) ([]Record, error) {
+	path := s.path(providerID)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WrapIO("read", path, err)
+	}
+	var records []Record
+	if err := yaml.Unmarshal(data, &records); err != nil {
+		return nil, errors.WrapParse("yaml", path, err)
+	}
+	return records, nil
+}
+
+// Save replaces the provider's recorded documents.
+func (s Store) Save(providerID catalogs.ProviderID, records []Record) error {
+	ordered := slices.Clone(records)
+	slices.SortFunc(ordered, func(a, b Record) int {
+		return cmp.Compare(a.Document, b.Document)
+	})
+
+	data, err := yaml.Marshal(ordered)
+	if err != nil {
+		return errors.WrapParse("yaml", "policy hashes", err)
+	}
+	if err := os.MkdirAll(s.Dir, constants.DirPermissions); err != nil {
+		return errors.WrapIO("create", s.Dir, err)
+	}
+	path := s.path(providerID)
+	temporary := path + ".tmp"
+	if err := os.WriteFile(temporary, data, constants.FilePermissions); err != nil {
+		return errors.WrapIO("write", temporary, err)
+	}
+	if err := os.Rename(temporary, path); err != nil {
+		return errors.WrapIO("rename", path, err)
+	}
+	return nil
+}
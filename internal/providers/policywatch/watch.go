@@ -0,0 +1,143 @@
+// Package policywatch detects changes to provider privacy policies and terms
+// of service. Each sync fetches the documents linked from a provider's
+// privacy_policy block, hashes their normalized text, and compares the hash
+// with the one recorded by the previous sync. Watching is opt-in because it
+// makes requests to provider websites rather than to their APIs.
+package policywatch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/differ"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const maxDocumentBytes = 8 << 20
+
+var (
+	nonContentPattern = regexp.MustCompile(`(?is)<(script|style|noscript)\b.*?</(script|style|noscript)>|<!--.*?-->`)
+	tagPattern        = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// Record is the last observed state of one provider document.
+type Record struct {
+	Document  differ.PolicyDocument `yaml:"document"`
+	URL       string                `yaml:"url"`
+	SHA256    string                `yaml:"sha256"`
+	CheckedAt time.Time             `yaml:"checked_at"`
+}
+
+// Watcher fetches and hashes provider policy documents.
+type Watcher struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// New creates a watcher. A nil client uses the default HTTP timeout.
+func New(client *http.Client) *Watcher {
+	if client == nil {
+		client = &http.Client{Timeout: constants.DefaultHTTPTimeout}
+	}
+	return &Watcher{client: client, now: time.Now}
+}
+
+// Check fetches the documents linked from provider and compares them with the
+// previously recorded state. It returns the records to persist and a change
+// for every document whose content hash differs from the recorded one. A
+// document seen for the first time only establishes a baseline. Documents
+// that cannot be fetched keep their previous record and are reported in the
+// returned error.
+func (w *Watcher) Check(ctx context.Context, provider *catalogs.Provider, previous []Record) ([]Record, []differ.PolicyChange, error) {
+	known := make(map[differ.PolicyDocument]Record, len(previous))
+	for _, record := range previous {
+		known[record.Document] = record
+	}
+
+	var (
+		records []Record
+		changes []differ.PolicyChange
+		errs    []error
+	)
+	for _, document := range documents(provider) {
+		old, seen := known[document.kind]
+		hash, err := w.hash(ctx, provider.ID, document.url)
+		if err != nil {
+			errs = append(errs, err)
+			if seen {
+				records = append(records, old)
+			}
+			continue
+		}
+		records = append(records, Record{Document: document.kind, URL: document.url, SHA256: hash, CheckedAt: w.now().UTC()})
+		if seen && old.SHA256 != hash {
+			changes = append(changes, differ.PolicyChange{
+				ProviderID: provider.ID,
+				Document:   document.kind,
+				URL:        document.url,
+				OldHash:    old.SHA256,
+				NewHash:    hash,
+			})
+		}
+	}
+	return records, changes, stderrors.Join(errs...)
+}
+
+type document struct {
+	kind differ.PolicyDocument
+	url  string
+}
+
+func documents(provider *catalogs.Provider) []document {
+	if provider == nil || provider.PrivacyPolicy == nil {
+		return nil
+	}
+	var docs []document
+	if url := provider.PrivacyPolicy.PrivacyPolicyURL; url != nil && *url != "" {
+		docs = append(docs, document{kind: differ.PolicyDocumentPrivacyPolicy, url: *url})
+	}
+	if url := provider.PrivacyPolicy.TermsOfServiceURL; url != nil && *url != "" {
+		docs = append(docs, document{kind: differ.PolicyDocumentTermsOfService, url: *url})
+	}
+	return docs
+}
+
+func (w *Watcher) hash(ctx context.Context, providerID catalogs.ProviderID, url string) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", errors.WrapResource("create", "policy request", url, err)
+	}
+	request.Header.Set("Accept", "text/html, text/plain;q=0.9, */*;q=0.5")
+	response, err := w.client.Do(request) //nolint:gosec // Policy URLs are curated catalog data.
+	if err != nil {
+		return "", &errors.APIError{Provider: string(providerID), Endpoint: url, Message: "policy request failed", Err: err}
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+		return "", &errors.APIError{Provider: string(providerID), Endpoint: url, StatusCode: response.StatusCode, Message: "unexpected policy response status"}
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxDocumentBytes))
+	if err != nil {
+		return "", errors.WrapIO("read", url, err)
+	}
+	sum := sha256.Sum256([]byte(Normalize(string(body))))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Normalize reduces a fetched document to its visible text so that markup,
+// inline scripts, and whitespace churn do not register as policy changes.
+func Normalize(body string) string {
+	text := nonContentPattern.ReplaceAllString(body, " ")
+	text = tagPattern.ReplaceAllString(text, " ")
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,87 @@
+package policywatch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/differ"
+)
+
+func TestNormalizeIgnoresMarkupAndScripts(t *testing.T) {
+	a := Normalize("<html><script>var nonce = 1;</script><p>We   collect\n data.</p></html>")
+	b := Normalize("<html><script>var nonce = 2;</script><div><p>We collect data.</p></div><!-- build 42 --></html>")
+	if a != b || a != "We collect data." {
+		t.Fatalf("Normalize = %q and %q, want equal visible text", a, b)
+	}
+}
+
+func TestWatcherReportsChangedDocuments(t *testing.T) {
+	privacy := "<p>Privacy v1</p>"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/privacy":
+			_, _ = w.Write([]byte(privacy))
+		case "/terms":
+			_, _ = w.Write([]byte("<p>Terms</p>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	privacyURL, termsURL := server.URL+"/privacy", server.URL+"/terms"
+	provider := &catalogs.Provider{ID: "anthropic", PrivacyPolicy: &catalogs.ProviderPrivacyPolicy{
+		PrivacyPolicyURL: &privacyURL, TermsOfServiceURL: &termsURL,
+	}}
+	store := Store{Dir: t.TempDir()}
+	watcher := New(server.Client())
+
+	records, changes, err := watcher.Check(context.Background(), provider, nil)
+	if err != nil {
+		t.Fatalf("baseline Check: %v", err)
+	}
+	if len(records) != 2 || len(changes) != 0 {
+		t.Fatalf("baseline records = %d, changes = %v; want 2 records and no changes", len(records), changes)
+	}
+	if err := store.Save(provider.ID, records); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	privacy = "<p>Privacy v2</p>"
+	previous, err := store.Load(provider.ID)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	_, changes, err = watcher.Check(context.Background(), provider, previous)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Document != differ.PolicyDocumentPrivacyPolicy {
+		t.Fatalf("changes = %v, want one privacy policy change", changes)
+	}
+	if got := changes[0].String(); got != "anthropic privacy policy changed" {
+		t.Fatalf("change = %q", got)
+	}
+}
+
+func TestWatcherKeepsRecordWhenFetchFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	url := server.URL + "/privacy"
+	provider := &catalogs.Provider{ID: "openai", PrivacyPolicy: &catalogs.ProviderPrivacyPolicy{PrivacyPolicyURL: &url}}
+	previous := []Record{{Document: differ.PolicyDocumentPrivacyPolicy, URL: url, SHA256: "abc"}}
+
+	records, changes, err := New(server.Client()).Check(context.Background(), provider, previous)
+	if err == nil {
+		t.Fatal("Check returned nil error for an unavailable document")
+	}
+	if len(changes) != 0 || len(records) != 1 || records[0].SHA256 != "abc" {
+		t.Fatalf("records = %v, changes = %v; want previous record kept and no changes", records, changes)
+	}
+}
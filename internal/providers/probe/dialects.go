@@ -0,0 +1,303 @@
+package probe
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// probeImagePNG is a 1x1 PNG, the smallest image every dialect accepts.
+const probeImagePNG = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mP8z8BQDwAEhQGAhKmMIQAAAABJRU5ErkJggg=="
+
+const (
+	probeToolName   = "report_status"
+	probeJSONPrompt = `Reply with the JSON object {"ok": true} and nothing else.`
+	probeImageQuery = "Name the color of this image in one word."
+)
+
+// probeResponse decodes the fields every dialect's checks inspect.
+type probeResponse struct {
+	// OpenAI chat completions
+	Choices []struct {
+		Message struct {
+			Content   json.RawMessage   `json:"content"`
+			ToolCalls []json.RawMessage `json:"tool_calls"`
+		} `json:"message"`
+	} `json:"choices"`
+
+	// Anthropic messages
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+
+	// Gemini generateContent
+	Candidates []struct {
+		Content struct {
+			Parts []struct {
+				Text         string          `json:"text"`
+				FunctionCall json.RawMessage `json:"functionCall"`
+			} `json:"parts"`
+		} `json:"content"`
+	} `json:"candidates"`
+}
+
+// capabilityProbe builds a request and checks its decoded response. check
+// returns an empty string when the capability was demonstrated.
+type capabilityProbe struct {
+	request func(modelID string) any
+	check   func(probeResponse) string
+}
+
+// dialect groups the probes for one inference API style.
+type dialect struct {
+	name   string
+	url    func(endpoint, modelID string) string
+	probes map[Capability]capabilityProbe
+}
+
+var dialects = map[catalogs.EndpointType]dialect{
+	catalogs.EndpointTypeOpenAI:     openAIDialect,
+	catalogs.EndpointTypeAnthropic:  anthropicDialect,
+	catalogs.EndpointTypeGoogle:     geminiDialect,
+	catalogs.EndpointTypeOpenRouter: openAICompatibleDialect,
+	catalogs.EndpointTypeTogether:   openAICompatibleDialect,
+	catalogs.EndpointTypePerplexity: openAICompatibleDialect,
+}
+
+// OpenAI bounds output with max_completion_tokens, which also counts the
+// hidden reasoning tokens of reasoning models, so its probes get room to
+// think before answering. OpenAI-compatible APIs take max_tokens.
+var (
+	openAIDialect           = openAIChatDialect("max_completion_tokens", 1024)
+	openAICompatibleDialect = openAIChatDialect("max_tokens", 0)
+)
+
+// openAIChatDialect returns the chat completions probes, bounding each
+// request's output with limitField: the probe's answer budget plus
+// reasoningTokens.
+func openAIChatDialect(limitField string, reasoningTokens int) dialect {
+	return dialect{
+		name:   "openai.chat_completions",
+		url:    func(endpoint, _ string) string { return endpoint },
+		probes: openAIChatProbes(limitField, reasoningTokens),
+	}
+}
+
+func openAIChatProbes(limitField string, reasoningTokens int) map[Capability]capabilityProbe {
+	return map[Capability]capabilityProbe{
+		CapabilityTools: {
+			request: func(modelID string) any {
+				return map[string]any{
+					"model":    modelID,
+					limitField: 64 + reasoningTokens,
+					"messages": []any{userText("Report your status using the tool.")},
+					"tools": []any{map[string]any{
+						"type": "function",
+						"function": map[string]any{
+							"name":        probeToolName,
+							"description": "Report that the model is available.",
+							"parameters":  emptyObjectSchema(),
+						},
+					}},
+					"tool_choice": map[string]any{"type": "function", "function": map[string]any{"name": probeToolName}},
+				}
+			},
+			check: func(resp probeResponse) string {
+				if len(resp.Choices) == 0 || len(resp.Choices[0].Message.ToolCalls) == 0 {
+					return "response contained no tool call"
+				}
+				return ""
+			},
+		},
+		CapabilityJSONMode: {
+			request: func(modelID string) any {
+				return map[string]any{
+					"model":           modelID,
+					limitField:        64 + reasoningTokens,
+					"messages":        []any{userText(probeJSONPrompt)},
+					"response_format": map[string]any{"type": "json_object"},
+				}
+			},
+			check: func(resp probeResponse) string {
+				if len(resp.Choices) == 0 {
+					return "response contained no choices"
+				}
+				var text string
+				if err := json.Unmarshal(resp.Choices[0].Message.Content, &text); err != nil {
+					return "response content was not text"
+				}
+				return checkJSONObject(text)
+			},
+		},
+		CapabilityVision: {
+			request: func(modelID string) any {
+				return map[string]any{
+					"model":    modelID,
+					limitField: 16 + reasoningTokens,
+					"messages": []any{map[string]any{
+						"role": "user",
+						"content": []any{
+							map[string]any{"type": "text", "text": probeImageQuery},
+							map[string]any{"type": "image_url", "image_url": map[string]any{"url": "data:image/png;base64," + probeImagePNG}},
+						},
+					}},
+				}
+			},
+			check: func(resp probeResponse) string {
+				if len(resp.Choices) == 0 || len(resp.Choices[0].Message.Content) == 0 {
+					return "response contained no answer"
+				}
+				return ""
+			},
+		},
+	}
+}
+
+// Anthropic has no JSON response mode, so only tools and vision are probed.
+var anthropicDialect = dialect{
+	name: "anthropic.messages",
+	url:  func(endpoint, _ string) string { return endpoint },
+	probes: map[Capability]capabilityProbe{
+		CapabilityTools: {
+			request: func(modelID string) any {
+				return map[string]any{
+					"model":      modelID,
+					"max_tokens": 64,
+					"messages":   []any{userText("Report your status using the tool.")},
+					"tools": []any{map[string]any{
+						"name":         probeToolName,
+						"description":  "Report that the model is available.",
+						"input_schema": emptyObjectSchema(),
+					}},
+					"tool_choice": map[string]any{"type": "tool", "name": probeToolName},
+				}
+			},
+			check: func(resp probeResponse) string {
+				for _, block := range resp.Content {
+					if block.Type == "tool_use" {
+						return ""
+					}
+				}
+				return "response contained no tool_use block"
+			},
+		},
+		CapabilityVision: {
+			request: func(modelID string) any {
+				return map[string]any{
+					"model":      modelID,
+					"max_tokens": 16,
+					"messages": []any{map[string]any{
+						"role": "user",
+						"content": []any{
+							map[string]any{"type": "image", "source": map[string]any{
+								"type": "base64", "media_type": "image/png", "data": probeImagePNG,
+							}},
+							map[string]any{"type": "text", "text": probeImageQuery},
+						},
+					}},
+				}
+			},
+			check: func(resp probeResponse) string {
+				for _, block := range resp.Content {
+					if block.Type == "text" && block.Text != "" {
+						return ""
+					}
+				}
+				return "response contained no answer"
+			},
+		},
+	},
+}
+
+var geminiDialect = dialect{
+	name: "gemini.generate_content",
+	url: func(endpoint, modelID string) string {
+		return strings.TrimRight(endpoint, "/") + "/" + strings.TrimPrefix(modelID, "models/") + ":generateContent"
+	},
+	probes: map[Capability]capabilityProbe{
+		CapabilityTools: {
+			request: func(string) any {
+				return map[string]any{
+					"contents": []any{geminiText("Report your status using the tool.")},
+					"tools": []any{map[string]any{"functionDeclarations": []any{map[string]any{
+						"name":        probeToolName,
+						"description": "Report that the model is available.",
+					}}}},
+					"toolConfig": map[string]any{"functionCallingConfig": map[string]any{"mode": "ANY"}},
+				}
+			},
+			check: func(resp probeResponse) string {
+				for _, candidate := range resp.Candidates {
+					for _, part := range candidate.Content.Parts {
+						if len(part.FunctionCall) > 0 {
+							return ""
+						}
+					}
+				}
+				return "response contained no functionCall"
+			},
+		},
+		CapabilityJSONMode: {
+			request: func(string) any {
+				return map[string]any{
+					"contents":         []any{geminiText(probeJSONPrompt)},
+					"generationConfig": map[string]any{"responseMimeType": "application/json"},
+				}
+			},
+			check: func(resp probeResponse) string {
+				return checkJSONObject(geminiOutput(resp))
+			},
+		},
+		CapabilityVision: {
+			request: func(string) any {
+				return map[string]any{
+					"contents": []any{map[string]any{
+						"role": "user",
+						"parts": []any{
+							map[string]any{"inlineData": map[string]any{"mimeType": "image/png", "data": probeImagePNG}},
+							map[string]any{"text": probeImageQuery},
+						},
+					}},
+				}
+			},
+			check: func(resp probeResponse) string {
+				if geminiOutput(resp) == "" {
+					return "response contained no answer"
+				}
+				return ""
+			},
+		},
+	},
+}
+
+func userText(text string) map[string]any {
+	return map[string]any{"role": "user", "content": text}
+}
+
+func geminiText(text string) map[string]any {
+	return map[string]any{"role": "user", "parts": []any{map[string]any{"text": text}}}
+}
+
+func emptyObjectSchema() map[string]any {
+	return map[string]any{"type": "object", "properties": map[string]any{}}
+}
+
+func geminiOutput(resp probeResponse) string {
+	var b strings.Builder
+	for _, candidate := range resp.Candidates {
+		for _, part := range candidate.Content.Parts {
+			b.WriteString(part.Text)
+		}
+	}
+	return b.String()
+}
+
+func checkJSONObject(text string) string {
+	var object map[string]any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(text)), &object); err != nil {
+		return "response was not a JSON object"
+	}
+	return ""
+}
@@ -0,0 +1,225 @@
+// Package probe verifies advertised model capabilities by sending tiny
+// targeted requests to a provider's inference API. Probes are opt-in: they
+// consume (minimal) tokens and require credentials, so they never run as part
+// of a normal catalog sync.
+package probe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/agentstation/starmap/internal/transport"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Capability names one verifiable model capability.
+type Capability string
+
+// Verifiable capabilities.
+const (
+	// CapabilityTools checks that the model emits a tool call when forced.
+	CapabilityTools Capability = "tools"
+	// CapabilityJSONMode checks that the model honors a JSON response format.
+	CapabilityJSONMode Capability = "json_mode"
+	// CapabilityVision checks that the model accepts an image input.
+	CapabilityVision Capability = "vision"
+)
+
+// Capabilities returns all verifiable capabilities in probe order.
+func Capabilities() []Capability {
+	return []Capability{CapabilityTools, CapabilityJSONMode, CapabilityVision}
+}
+
+// ParseCapability validates a capability name.
+func ParseCapability(value string) (Capability, error) {
+	capability := Capability(value)
+	if !slices.Contains(Capabilities(), capability) {
+		return "", &errors.ValidationError{
+			Field:   "capability",
+			Value:   value,
+			Message: "unsupported probe (use tools, json_mode, or vision)",
+		}
+	}
+	return capability, nil
+}
+
+// Claimed reports whether the catalog advertises a capability for a model.
+func Claimed(model *catalogs.Model, capability Capability) bool {
+	if model == nil || model.Features == nil {
+		return false
+	}
+	features := model.Features
+	switch capability {
+	case CapabilityTools:
+		return features.ToolCalls || features.Tools
+	case CapabilityJSONMode:
+		return features.StructuredOutputs || features.FormatResponse
+	case CapabilityVision:
+		return slices.Contains(features.Modalities.Input, catalogs.ModelModalityImage)
+	default:
+		return false
+	}
+}
+
+// Status is the outcome of one probe.
+type Status string
+
+// Probe outcomes.
+const (
+	// StatusVerified means the model demonstrated the capability.
+	StatusVerified Status = "verified"
+	// StatusFailed means the provider answered but the capability was rejected or not exercised.
+	StatusFailed Status = "failed"
+	// StatusError means the probe could not reach a verdict (network, auth, or server error).
+	StatusError Status = "error"
+	// StatusSkipped means the provider dialect has no probe for the capability.
+	StatusSkipped Status = "skipped"
+)
+
+// Result records a claimed capability flag next to its verified outcome,
+// with the provenance of the observation.
+type Result struct {
+	ProviderID catalogs.ProviderID `json:"provider_id" yaml:"provider_id"`
+	ModelID    string              `json:"model_id" yaml:"model_id"`
+	Capability Capability          `json:"capability" yaml:"capability"`
+	Claimed    bool                `json:"claimed" yaml:"claimed"`
+	Status     Status              `json:"status" yaml:"status"`
+	Detail     string              `json:"detail,omitempty" yaml:"detail,omitempty"`
+
+	// Provenance
+	Method    string    `json:"method" yaml:"method"`
+	Endpoint  string    `json:"endpoint" yaml:"endpoint"`
+	CheckedAt time.Time `json:"checked_at" yaml:"checked_at"`
+}
+
+// Verified reports whether the probe demonstrated the capability.
+func (r Result) Verified() bool {
+	return r.Status == StatusVerified
+}
+
+// Mismatch reports whether a conclusive probe disagrees with the catalog.
+func (r Result) Mismatch() bool {
+	conclusive := r.Status == StatusVerified || r.Status == StatusFailed
+	return conclusive && r.Claimed != r.Verified()
+}
+
+// Prober sends capability probes to one provider.
+type Prober struct {
+	provider *catalogs.Provider
+	dialect  dialect
+	endpoint string
+	client   *transport.Client
+	now      func() time.Time
+}
+
+// New creates a prober for a provider's inference API.
+func New(provider *catalogs.Provider) (*Prober, error) {
+	if provider == nil || provider.Catalog == nil {
+		return nil, &errors.ValidationError{Field: "provider", Message: "provider catalog configuration is required"}
+	}
+	if provider.ChatCompletions == nil || provider.ChatCompletions.URL == nil || *provider.ChatCompletions.URL == "" {
+		return nil, &errors.ValidationError{
+			Field:   "provider.chat_completions.url",
+			Value:   provider.ID,
+			Message: "provider has no inference endpoint to probe",
+		}
+	}
+	d, ok := dialects[provider.Catalog.Endpoint.Type]
+	if !ok {
+		return nil, &errors.ValidationError{
+			Field:   "provider.catalog.endpoint.type",
+			Value:   provider.Catalog.Endpoint.Type,
+			Message: "no capability probes for this API dialect",
+		}
+	}
+	return &Prober{
+		provider: provider,
+		dialect:  d,
+		endpoint: *provider.ChatCompletions.URL,
+		client:   transport.New(provider),
+		now:      time.Now,
+	}, nil
+}
+
+// Verify runs one probe per capability against a model. Probes run
+// sequentially to keep request volume predictable.
+func (p *Prober) Verify(ctx context.Context, model *catalogs.Model, capabilities []Capability) []Result {
+	results := make([]Result, 0, len(capabilities))
+	for _, capability := range capabilities {
+		results = append(results, p.verify(ctx, model, capability))
+	}
+	return results
+}
+
+func (p *Prober) verify(ctx context.Context, model *catalogs.Model, capability Capability) Result {
+	result := Result{
+		ProviderID: p.provider.ID,
+		ModelID:    model.ID,
+		Capability: capability,
+		Claimed:    Claimed(model, capability),
+		Method:     p.dialect.name,
+		Endpoint:   p.dialect.url(p.endpoint, model.ID),
+		CheckedAt:  p.now().UTC(),
+	}
+
+	probe, ok := p.dialect.probes[capability]
+	if !ok {
+		result.Status = StatusSkipped
+		result.Detail = "no " + string(capability) + " probe for " + p.dialect.name
+		return result
+	}
+
+	body, err := json.Marshal(probe.request(model.ID))
+	if err != nil {
+		result.Status = StatusError
+		result.Detail = errors.WrapParse("json", "probe request", err).Error()
+		return result
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, result.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		result.Status = StatusError
+		result.Detail = err.Error()
+		return result
+	}
+	resp, err := p.client.DoWithContext(ctx, req, p.provider)
+	if err != nil {
+		result.Status = StatusError
+		result.Detail = err.Error()
+		return result
+	}
+
+	var decoded probeResponse
+	if err := transport.DecodeResponse(resp, &decoded); err != nil {
+		result.Status, result.Detail = classifyError(err)
+		return result
+	}
+	if detail := probe.check(decoded); detail != "" {
+		result.Status = StatusFailed
+		result.Detail = detail
+		return result
+	}
+	result.Status = StatusVerified
+	return result
+}
+
+// classifyError separates provider rejections of the probed feature from
+// failures that say nothing about the capability.
+func classifyError(err error) (Status, string) {
+	var apiErr *errors.APIError
+	if stderrors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+			return StatusError, err.Error()
+		}
+		if apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 {
+			return StatusFailed, err.Error()
+		}
+	}
+	return StatusError, err.Error()
+}
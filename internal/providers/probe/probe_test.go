@@ -0,0 +1,173 @@
+package probe
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func probeTestProvider(endpointType catalogs.EndpointType, url string) *catalogs.Provider {
+	return &catalogs.Provider{
+		ID:              "probe-test",
+		Name:            "Probe Test",
+		Catalog:         &catalogs.ProviderCatalog{Endpoint: catalogs.ProviderEndpoint{Type: endpointType, URL: url + "/models"}},
+		ChatCompletions: &catalogs.ProviderChatCompletions{URL: &url},
+	}
+}
+
+func TestOpenAIProbesCompareClaimedAndVerified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("decode probe request: %v", err)
+		}
+		switch {
+		case request["tools"] != nil:
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"content":null,"tool_calls":[{"id":"call_1"}]}}]}`))
+		case request["response_format"] != nil:
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"not json"}}]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"image input is not supported"}}`))
+		}
+	}))
+	defer server.Close()
+
+	prober, err := New(probeTestProvider(catalogs.EndpointTypeOpenAI, server.URL))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	model := &catalogs.Model{ID: "m1", Features: &catalogs.ModelFeatures{
+		ToolCalls:  true,
+		Modalities: catalogs.ModelModalities{Input: []catalogs.ModelModality{catalogs.ModelModalityImage}},
+	}}
+
+	results := prober.Verify(t.Context(), model, Capabilities())
+	if len(results) != 3 {
+		t.Fatalf("results = %#v", results)
+	}
+	tools, jsonMode, vision := results[0], results[1], results[2]
+	if tools.Status != StatusVerified || !tools.Claimed || tools.Mismatch() {
+		t.Fatalf("tools = %#v", tools)
+	}
+	if jsonMode.Status != StatusFailed || jsonMode.Claimed || jsonMode.Mismatch() {
+		t.Fatalf("json_mode = %#v", jsonMode)
+	}
+	if vision.Status != StatusFailed || !vision.Claimed || !vision.Mismatch() {
+		t.Fatalf("vision = %#v", vision)
+	}
+	if tools.Method != "openai.chat_completions" || tools.Endpoint != server.URL || tools.CheckedAt.IsZero() {
+		t.Fatalf("tools provenance = %#v", tools)
+	}
+}
+
+func TestOpenAIDialectProbesBoundOutput(t *testing.T) {
+	for _, tt := range []struct {
+		endpointType catalogs.EndpointType
+		limitField   string
+	}{
+		{catalogs.EndpointTypeOpenAI, "max_completion_tokens"},
+		{catalogs.EndpointTypeOpenRouter, "max_tokens"},
+		{catalogs.EndpointTypeTogether, "max_tokens"},
+		{catalogs.EndpointTypePerplexity, "max_tokens"},
+	} {
+		var unbounded []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Errorf("decode probe request: %v", err)
+			}
+			if limit, ok := request[tt.limitField].(float64); !ok || limit <= 0 {
+				unbounded = append(unbounded, r.URL.Path)
+			}
+			_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"{\"ok\": true}","tool_calls":[{"id":"call_1"}]}}]}`))
+		}))
+
+		prober, err := New(probeTestProvider(tt.endpointType, server.URL))
+		if err != nil {
+			t.Fatalf("%s: New: %v", tt.endpointType, err)
+		}
+		for _, result := range prober.Verify(t.Context(), &catalogs.Model{ID: "m1"}, Capabilities()) {
+			if result.Status != StatusVerified || result.Method != "openai.chat_completions" {
+				t.Errorf("%s %s = %#v, want verified over chat completions", tt.endpointType, result.Capability, result)
+			}
+		}
+		server.Close()
+		if len(unbounded) > 0 {
+			t.Errorf("%s: %d probe requests without %s", tt.endpointType, len(unbounded), tt.limitField)
+		}
+	}
+}
+
+func TestProbeAuthFailureIsInconclusive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	prober, err := New(probeTestProvider(catalogs.EndpointTypeAnthropic, server.URL))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	results := prober.Verify(t.Context(), &catalogs.Model{ID: "m1"}, []Capability{CapabilityTools, CapabilityJSONMode})
+	if results[0].Status != StatusError || results[0].Mismatch() {
+		t.Fatalf("tools = %#v", results[0])
+	}
+	if results[1].Status != StatusSkipped {
+		t.Fatalf("anthropic json_mode = %#v, want skipped", results[1])
+	}
+}
+
+func TestGeminiProbeTargetsModelEndpoint(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte(`{"candidates":[{"content":{"parts":[{"text":"{\"ok\": true}"}]}}]}`))
+	}))
+	defer server.Close()
+
+	prober, err := New(probeTestProvider(catalogs.EndpointTypeGoogle, server.URL+"/v1beta/models"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	results := prober.Verify(t.Context(), &catalogs.Model{ID: "models/gemini-test"}, []Capability{CapabilityJSONMode})
+	if results[0].Status != StatusVerified {
+		t.Fatalf("json_mode = %#v", results[0])
+	}
+	if path != "/v1beta/models/gemini-test:generateContent" {
+		t.Fatalf("request path = %q", path)
+	}
+}
+
+func TestNewRequiresInferenceEndpoint(t *testing.T) {
+	provider := probeTestProvider(catalogs.EndpointTypeOpenAI, "http://example.invalid")
+	provider.ChatCompletions = nil
+	if _, err := New(provider); err == nil || !strings.Contains(err.Error(), "inference endpoint") {
+		t.Fatalf("New without chat endpoint error = %v", err)
+	}
+}
+
+func TestStoreRecordReplacesByModelAndCapability(t *testing.T) {
+	store := Store{Dir: t.TempDir()}
+	first := []Result{
+		{ProviderID: "p", ModelID: "b", Capability: CapabilityTools, Status: StatusFailed},
+		{ProviderID: "p", ModelID: "a", Capability: CapabilityVision, Status: StatusVerified},
+	}
+	if err := store.Record("p", first); err != nil {
+		t.Fatalf("Record first: %v", err)
+	}
+	if err := store.Record("p", []Result{{ProviderID: "p", ModelID: "b", Capability: CapabilityTools, Status: StatusVerified}}); err != nil {
+		t.Fatalf("Record second: %v", err)
+	}
+	results, err := store.Load("p")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(results) != 2 || results[0].ModelID != "a" || results[1].Status != StatusVerified {
+		t.Fatalf("results = %#v", results)
+	}
+}
@@ -0,0 +1,85 @@
+package probe
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Store persists the latest probe result per provider, model, and
+// capability as one YAML file per provider.
+type Store struct {
+	Dir string
+}
+
+func (s Store) path(providerID catalogs.ProviderID) string {
+	return filepath.Join(s.Dir, string(providerID)+".yaml")
+}
+
+// Load returns the recorded results for a provider, or nil if none exist.
+func (s Store) Load(providerID catalogs.ProviderID) ([]Result, error) {
+	path := s.path(providerID)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WrapIO("read", path, err)
+	}
+	var results []Result
+	if err := yaml.Unmarshal(data, &results); err != nil {
+		return nil, errors.WrapParse("yaml", path, err)
+	}
+	return results, nil
+}
+
+// Record merges results into the provider's file, replacing earlier results
+// for the same model and capability.
+func (s Store) Record(providerID catalogs.ProviderID, results []Result) error {
+	existing, err := s.Load(providerID)
+	if err != nil {
+		return err
+	}
+	type key struct {
+		model      string
+		capability Capability
+	}
+	merged := make(map[key]Result, len(existing)+len(results))
+	for _, result := range existing {
+		merged[key{result.ModelID, result.Capability}] = result
+	}
+	for _, result := range results {
+		merged[key{result.ModelID, result.Capability}] = result
+	}
+	ordered := make([]Result, 0, len(merged))
+	for _, result := range merged {
+		ordered = append(ordered, result)
+	}
+	slices.SortFunc(ordered, func(a, b Result) int {
+		return cmp.Or(cmp.Compare(a.ModelID, b.ModelID), cmp.Compare(a.Capability, b.Capability))
+	})
+
+	data, err := yaml.Marshal(ordered)
+	if err != nil {
+		return errors.WrapParse("yaml", "capability verifications", err)
+	}
+	if err := os.MkdirAll(s.Dir, constants.DirPermissions); err != nil {
+		return errors.WrapIO("create", s.Dir, err)
+	}
+	path := s.path(providerID)
+	temporary := path + ".tmp"
+	if err := os.WriteFile(temporary, data, constants.FilePermissions); err != nil {
+		return errors.WrapIO("write", temporary, err)
+	}
+	if err := os.Rename(temporary, path); err != nil {
+		return errors.WrapIO("rename", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package quota
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// DefaultThresholds are the budget percentages that alert when no
+// thresholds are configured.
+var DefaultThresholds = []float64{80, 100}
+
+// Budget is a monthly spend allowance for one provider key.
+type Budget struct {
+	ProviderID catalogs.ProviderID `json:"provider_id" yaml:"provider_id"`
+	KeyEnv     string              `json:"key_env,omitempty" yaml:"key_env,omitempty"` // Default: the provider's admin key variable
+	Monthly    float64             `json:"monthly" yaml:"monthly"`
+	Thresholds []float64           `json:"thresholds,omitempty" yaml:"thresholds,omitempty"` // Percent of Monthly; default 80 and 100
+}
+
+// ParseBudget parses "provider=amount" or "provider:KEY_ENV=amount".
+func ParseBudget(value string) (Budget, error) {
+	target, amount, ok := strings.Cut(value, "=")
+	if !ok {
+		return Budget{}, &errors.ValidationError{Field: "budget", Value: value, Message: "must be provider=amount or provider:KEY_ENV=amount"}
+	}
+	provider, keyEnv, _ := strings.Cut(strings.TrimSpace(target), ":")
+	monthly, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+	if err != nil {
+		return Budget{}, &errors.ValidationError{Field: "budget", Value: value, Message: "amount must be a number"}
+	}
+	budget := Budget{ProviderID: catalogs.ProviderID(provider), KeyEnv: keyEnv, Monthly: monthly}
+	return budget, budget.Validate()
+}
+
+// ParseThresholds parses comma-separated budget percentages, e.g. "80,100".
+func ParseThresholds(value string) ([]float64, error) {
+	var thresholds []float64
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		threshold, err := strconv.ParseFloat(strings.TrimSuffix(part, "%"), 64)
+		if err != nil || threshold <= 0 {
+			return nil, &errors.ValidationError{Field: "thresholds", Value: part, Message: "must be a positive percentage"}
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	slices.Sort(thresholds)
+	return slices.Compact(thresholds), nil
+}
+
+// Validate checks that the budget names a tracked provider and a positive
+// amount.
+func (b Budget) Validate() error {
+	if _, ok := dialects[b.ProviderID]; !ok {
+		return &errors.ValidationError{Field: "budget.provider", Value: b.ProviderID, Message: "no usage API for this provider"}
+	}
+	if b.Monthly <= 0 {
+		return &errors.ValidationError{Field: "budget.monthly", Value: b.Monthly, Message: "must be positive"}
+	}
+	for _, threshold := range b.Thresholds {
+		if threshold <= 0 {
+			return &errors.ValidationError{Field: "budget.thresholds", Value: threshold, Message: "must be positive percentages"}
+		}
+	}
+	return nil
+}
+
+func (b Budget) thresholds() []float64 {
+	if len(b.Thresholds) == 0 {
+		return DefaultThresholds
+	}
+	thresholds := slices.Clone(b.Thresholds)
+	slices.Sort(thresholds)
+	return thresholds
+}
+
+func (b Budget) keyEnv() string {
+	if b.KeyEnv != "" {
+		return b.KeyEnv
+	}
+	return KeyEnv(b.ProviderID)
+}
+
+// Alert reports that month-to-date spend crossed a budget threshold.
+type Alert struct {
+	ProviderID catalogs.ProviderID `json:"provider_id" yaml:"provider_id"`
+	KeyEnv     string              `json:"key_env" yaml:"key_env"`
+	Key        string              `json:"key" yaml:"key"`
+	Month      string              `json:"month" yaml:"month"`         // YYYY-MM
+	Threshold  float64             `json:"threshold" yaml:"threshold"` // Percent of the budget
+	Budget     float64             `json:"budget" yaml:"budget"`
+	Spend      float64             `json:"spend" yaml:"spend"`
+	Percent    float64             `json:"percent" yaml:"percent"`
+	Currency   string              `json:"currency" yaml:"currency"`
+}
+
+// Message describes the alert in one line.
+func (a Alert) Message() string {
+	return fmt.Sprintf("%s spend for %s (%s) is %.2f %s, %.0f%% of the %.2f %s monthly budget (threshold %.0f%%)",
+		a.ProviderID, a.Month, a.KeyEnv, a.Spend, a.Currency, a.Percent, a.Budget, a.Currency, a.Threshold)
+}
+
+// Data returns the alert as notification event data.
+func (a Alert) Data() map[string]any {
+	return map[string]any{
+		"provider_id": a.ProviderID,
+		"key_env":     a.KeyEnv,
+		"key":         a.Key,
+		"month":       a.Month,
+		"threshold":   a.Threshold,
+		"budget":      a.Budget,
+		"spend":       a.Spend,
+		"percent":     a.Percent,
+		"currency":    a.Currency,
+		"message":     a.Message(),
+	}
+}
+
+// Evaluate returns the alert for the highest threshold report's spend has
+// crossed, or false when it crossed none.
+func (b Budget) Evaluate(report *Report) (Alert, bool) {
+	spend := report.Spend()
+	percent := spend / b.Monthly * 100
+	crossed := 0.0
+	for _, threshold := range b.thresholds() {
+		if percent >= threshold {
+			crossed = threshold
+		}
+	}
+	if crossed == 0 {
+		return Alert{}, false
+	}
+	return Alert{
+		ProviderID: report.ProviderID,
+		KeyEnv:     report.KeyEnv,
+		Key:        report.Key,
+		Month:      report.Start.Format("2006-01"),
+		Threshold:  crossed,
+		Budget:     b.Monthly,
+		Spend:      spend,
+		Percent:    percent,
+		Currency:   report.Currency,
+	}, true
+}
+
+// BudgetWatcher checks month-to-date spend against budgets and reports each
+// crossed threshold once per month.
+type BudgetWatcher struct {
+	budgets []Budget
+	opts    []Option
+	now     func() time.Time
+
+	mu    sync.Mutex
+	fired map[string]float64 // Highest alerted threshold by budget and month
+}
+
+// NewBudgetWatcher validates budgets and returns a watcher. opts configure
+// the trackers, except for the admin key, which comes from each budget.
+func NewBudgetWatcher(budgets []Budget, opts ...Option) (*BudgetWatcher, error) {
+	seen := make(map[string]bool, len(budgets))
+	for _, budget := range budgets {
+		if err := budget.Validate(); err != nil {
+			return nil, err
+		}
+		id := string(budget.ProviderID) + ":" + budget.keyEnv()
+		if seen[id] {
+			return nil, &errors.ValidationError{Field: "budget", Value: id, Message: "is defined more than once"}
+		}
+		seen[id] = true
+	}
+	return &BudgetWatcher{budgets: budgets, opts: opts, now: time.Now, fired: map[string]float64{}}, nil
+}
+
+// Check fetches month-to-date spend for every budget and returns the alerts
+// not reported before. A budget whose spend cannot be fetched does not stop
+// the others; the errors are joined.
+func (w *BudgetWatcher) Check(ctx context.Context, catalog catalogs.Reader) ([]Alert, error) {
+	now := w.now()
+	var alerts []Alert
+	var errs []error
+	for _, budget := range w.budgets {
+		alert, ok, err := w.check(ctx, catalog, budget, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if ok {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts, stderrors.Join(errs...)
+}
+
+func (w *BudgetWatcher) check(ctx context.Context, catalog catalogs.Reader, budget Budget, now time.Time) (Alert, bool, error) {
+	provider, ok := catalog.Providers().Get(budget.ProviderID)
+	if !ok {
+		return Alert{}, false, &errors.NotFoundError{Resource: "provider", ID: string(budget.ProviderID)}
+	}
+	tracker, err := New(provider, append(slices.Clone(w.opts), WithKeyEnv(budget.keyEnv()))...)
+	if err != nil {
+		return Alert{}, false, err
+	}
+	report, err := tracker.Fetch(ctx, MonthStart(now), now)
+	if err != nil {
+		return Alert{}, false, err
+	}
+	alert, ok := budget.Evaluate(report)
+	if !ok {
+		return Alert{}, false, nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	id := string(budget.ProviderID) + ":" + budget.keyEnv() + ":" + alert.Month
+	if alert.Threshold <= w.fired[id] {
+		return Alert{}, false, nil
+	}
+	w.fired[id] = alert.Threshold
+	return alert, true, nil
+}
@@ -0,0 +1,108 @@
+package quota
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func TestParseBudget(t *testing.T) {
+	budget, err := ParseBudget("openai:OPENAI_TEAM_KEY=500")
+	if err != nil {
+		t.Fatalf("ParseBudget: %v", err)
+	}
+	if budget.ProviderID != catalogs.ProviderIDOpenAI || budget.KeyEnv != "OPENAI_TEAM_KEY" || budget.Monthly != 500 {
+		t.Fatalf("budget = %+v", budget)
+	}
+	for _, value := range []string{"openai", "openai=lots", "openai=0", "groq=100"} {
+		if _, err := ParseBudget(value); err == nil {
+			t.Errorf("ParseBudget(%q) succeeded", value)
+		}
+	}
+
+	thresholds, err := ParseThresholds("100, 50%,80,80")
+	if err != nil {
+		t.Fatalf("ParseThresholds: %v", err)
+	}
+	if !slices.Equal(thresholds, []float64{50, 80, 100}) {
+		t.Fatalf("thresholds = %v", thresholds)
+	}
+	if _, err := ParseThresholds("80,-1"); err == nil {
+		t.Fatal("ParseThresholds accepted a negative threshold")
+	}
+}
+
+func TestBudgetEvaluateReportsHighestCrossedThreshold(t *testing.T) {
+	budget := Budget{ProviderID: catalogs.ProviderIDOpenAI, Monthly: 100}
+	report := func(spend float64) *Report {
+		return &Report{ProviderID: catalogs.ProviderIDOpenAI, ReportedSpend: &spend, Start: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}
+	}
+	if _, ok := budget.Evaluate(report(79.99)); ok {
+		t.Fatal("alerted below 80%")
+	}
+	alert, ok := budget.Evaluate(report(85))
+	if !ok || alert.Threshold != 80 || alert.Month != "2026-10" {
+		t.Fatalf("alert at 85%% = %+v, %v", alert, ok)
+	}
+	if alert, _ := budget.Evaluate(report(140)); alert.Threshold != 100 || alert.Percent != 140 {
+		t.Fatalf("alert at 140%% = %+v", alert)
+	}
+}
+
+func TestBudgetWatcherAlertsOncePerThreshold(t *testing.T) {
+	t.Setenv("QUOTA_TEST_KEY", "sk-admin-test-1234")
+	spend := "50"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organization/usage/completions":
+			_, _ = w.Write([]byte(`{"data":[],"has_more":false}`))
+		case "/organization/costs":
+			_, _ = w.Write([]byte(`{"data":[{"results":[{"amount":{"value":` + spend + `,"currency":"usd"}}]}],"has_more":false}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	catalog := catalogs.NewEmpty()
+	if err := catalog.SetProvider(catalogs.Provider{ID: catalogs.ProviderIDOpenAI, Name: "OpenAI"}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	watcher, err := NewBudgetWatcher([]Budget{{ProviderID: catalogs.ProviderIDOpenAI, KeyEnv: "QUOTA_TEST_KEY", Monthly: 100}}, WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewBudgetWatcher: %v", err)
+	}
+
+	check := func(amount string) []Alert {
+		t.Helper()
+		spend = amount
+		alerts, err := watcher.Check(t.Context(), catalog)
+		if err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+		return alerts
+	}
+	if alerts := check("50"); len(alerts) != 0 {
+		t.Fatalf("alerts at 50%% = %+v", alerts)
+	}
+	if alerts := check("81"); len(alerts) != 1 || alerts[0].Threshold != 80 || alerts[0].KeyEnv != "QUOTA_TEST_KEY" {
+		t.Fatalf("alerts at 81%% = %+v", alerts)
+	}
+	if alerts := check("90"); len(alerts) != 0 {
+		t.Fatalf("80%% threshold alerted twice: %+v", alerts)
+	}
+	if alerts := check("101"); len(alerts) != 1 || alerts[0].Threshold != 100 {
+		t.Fatalf("alerts at 101%% = %+v", alerts)
+	}
+
+	if _, err := NewBudgetWatcher([]Budget{
+		{ProviderID: catalogs.ProviderIDOpenAI, Monthly: 1},
+		{ProviderID: catalogs.ProviderIDOpenAI, Monthly: 2},
+	}); err == nil {
+		t.Fatal("NewBudgetWatcher accepted duplicate budgets")
+	}
+}
@@ -0,0 +1,200 @@
+package quota
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// dialect describes one provider's usage and cost APIs.
+type dialect struct {
+	name    string
+	keyEnv  string
+	baseURL string
+	usage   func(ctx context.Context, t *Tracker, start, end time.Time) ([]ModelUsage, error)
+	cost    func(ctx context.Context, t *Tracker, start, end time.Time) (float64, string, error)
+}
+
+var dialects = map[catalogs.ProviderID]dialect{
+	catalogs.ProviderIDOpenAI: {
+		name:    "openai.organization_usage",
+		keyEnv:  "OPENAI_ADMIN_KEY",
+		baseURL: "https://api.openai.com/v1",
+		usage:   openAIUsage,
+		cost:    openAICost,
+	},
+	catalogs.ProviderIDAnthropic: {
+		name:    "anthropic.usage_report",
+		keyEnv:  "ANTHROPIC_ADMIN_KEY",
+		baseURL: "https://api.anthropic.com/v1",
+		usage:   anthropicUsage,
+		cost:    anthropicCost,
+	},
+}
+
+// page is the pagination envelope shared by both providers' usage APIs.
+type page[T any] struct {
+	Data     []bucket[T] `json:"data"`
+	HasMore  bool        `json:"has_more"`
+	NextPage string      `json:"next_page"`
+}
+
+type bucket[T any] struct {
+	Results []T `json:"results"`
+}
+
+// visitPages calls visit with every result of a paginated usage endpoint.
+func visitPages[T any](ctx context.Context, t *Tracker, path string, query url.Values, visit func(T)) error {
+	for range maxPages {
+		var decoded page[T]
+		if err := t.get(ctx, t.baseURL+path+"?"+query.Encode(), &decoded); err != nil {
+			return err
+		}
+		for _, b := range decoded.Data {
+			for _, result := range b.Results {
+				visit(result)
+			}
+		}
+		if !decoded.HasMore || decoded.NextPage == "" {
+			return nil
+		}
+		query.Set("page", decoded.NextPage)
+	}
+	return &errors.ValidationError{Field: "next_page", Value: path, Message: "usage API returned too many pages"}
+}
+
+// accumulate sums usage by model.
+type accumulate map[string]*ModelUsage
+
+func (a accumulate) add(model string, tokens catalogs.TokenUsage, requests int64) {
+	usage, ok := a[model]
+	if !ok {
+		usage = &ModelUsage{Model: model}
+		a[model] = usage
+	}
+	usage.Tokens = usage.Tokens.Add(tokens)
+	usage.Requests += requests
+}
+
+func (a accumulate) list() []ModelUsage {
+	usage := make([]ModelUsage, 0, len(a))
+	for _, u := range a {
+		usage = append(usage, *u)
+	}
+	return usage
+}
+
+// OpenAI organization usage and costs APIs.
+
+type openAIUsageResult struct {
+	Model             string `json:"model"`
+	InputTokens       int64  `json:"input_tokens"` // Includes cached tokens
+	InputCachedTokens int64  `json:"input_cached_tokens"`
+	OutputTokens      int64  `json:"output_tokens"`
+	NumModelRequests  int64  `json:"num_model_requests"`
+}
+
+type openAICostResult struct {
+	Amount struct {
+		Value    float64 `json:"value"`
+		Currency string  `json:"currency"`
+	} `json:"amount"`
+}
+
+func openAIQuery(start, end time.Time) url.Values {
+	return url.Values{
+		"start_time":   {strconv.FormatInt(start.Unix(), 10)},
+		"end_time":     {strconv.FormatInt(end.Unix(), 10)},
+		"bucket_width": {"1d"},
+		"limit":        {"31"},
+	}
+}
+
+func openAIUsage(ctx context.Context, t *Tracker, start, end time.Time) ([]ModelUsage, error) {
+	query := openAIQuery(start, end)
+	query.Set("group_by", "model")
+	models := accumulate{}
+	err := visitPages(ctx, t, "/organization/usage/completions", query, func(r openAIUsageResult) {
+		models.add(r.Model, catalogs.TokenUsage{
+			Input:     r.InputTokens - r.InputCachedTokens,
+			CacheRead: r.InputCachedTokens,
+			Output:    r.OutputTokens,
+		}, r.NumModelRequests)
+	})
+	return models.list(), err
+}
+
+func openAICost(ctx context.Context, t *Tracker, start, end time.Time) (float64, string, error) {
+	var spend float64
+	var currency string
+	err := visitPages(ctx, t, "/organization/costs", openAIQuery(start, end), func(r openAICostResult) {
+		spend += r.Amount.Value
+		currency = r.Amount.Currency
+	})
+	return spend, currency, err
+}
+
+// Anthropic Admin API usage and cost reports.
+
+type anthropicUsageResult struct {
+	Model                string `json:"model"`
+	UncachedInputTokens  int64  `json:"uncached_input_tokens"`
+	CacheReadInputTokens int64  `json:"cache_read_input_tokens"`
+	CacheCreation        struct {
+		Ephemeral1h int64 `json:"ephemeral_1h_input_tokens"`
+		Ephemeral5m int64 `json:"ephemeral_5m_input_tokens"`
+	} `json:"cache_creation"`
+	OutputTokens int64 `json:"output_tokens"`
+}
+
+type anthropicCostResult struct {
+	Amount   string `json:"amount"` // Decimal string in cents
+	Currency string `json:"currency"`
+}
+
+func anthropicQuery(start, end time.Time) url.Values {
+	return url.Values{
+		"starting_at": {start.UTC().Format(time.RFC3339)},
+		"ending_at":   {end.UTC().Format(time.RFC3339)},
+		"limit":       {"31"},
+	}
+}
+
+func anthropicUsage(ctx context.Context, t *Tracker, start, end time.Time) ([]ModelUsage, error) {
+	query := anthropicQuery(start, end)
+	query.Set("bucket_width", "1d")
+	query.Set("group_by[]", "model")
+	models := accumulate{}
+	err := visitPages(ctx, t, "/organizations/usage_report/messages", query, func(r anthropicUsageResult) {
+		models.add(r.Model, catalogs.TokenUsage{
+			Input:      r.UncachedInputTokens,
+			CacheRead:  r.CacheReadInputTokens,
+			CacheWrite: r.CacheCreation.Ephemeral1h + r.CacheCreation.Ephemeral5m,
+			Output:     r.OutputTokens,
+		}, 0)
+	})
+	return models.list(), err
+}
+
+func anthropicCost(ctx context.Context, t *Tracker, start, end time.Time) (float64, string, error) {
+	var cents float64
+	var currency string
+	var parseErr error
+	err := visitPages(ctx, t, "/organizations/cost_report", anthropicQuery(start, end), func(r anthropicCostResult) {
+		amount, err := strconv.ParseFloat(r.Amount, 64)
+		if err != nil {
+			parseErr = errors.WrapParse("decimal", "cost amount", err)
+			return
+		}
+		cents += amount
+		currency = r.Currency
+	})
+	if err == nil {
+		err = parseErr
+	}
+	return cents / 100, currency, err
+}
@@ -0,0 +1,310 @@
+// Package quota reports the spend and token usage of provider API keys by
+// querying provider usage and cost APIs, and prices that usage with the
+// catalog. Tracking is opt-in: the usage APIs require organization admin keys
+// that are separate from the inference keys a catalog sync uses.
+package quota
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agentstation/starmap/internal/transport"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// maxPages bounds pagination so a misbehaving API cannot loop forever.
+const maxPages = 100
+
+// ModelUsage is one model's token usage over a report period.
+type ModelUsage struct {
+	Model    string              `json:"model" yaml:"model"`
+	Tokens   catalogs.TokenUsage `json:"tokens" yaml:"tokens"`
+	Requests int64               `json:"requests,omitempty" yaml:"requests,omitempty"`
+	Cost     *float64            `json:"estimated_cost,omitempty" yaml:"estimated_cost,omitempty"` // Nil when the catalog has no price for the model
+}
+
+// Report is the spend and usage of one provider key over a period.
+type Report struct {
+	ProviderID catalogs.ProviderID `json:"provider_id" yaml:"provider_id"`
+	KeyEnv     string              `json:"key_env" yaml:"key_env"` // Environment variable holding the admin key
+	Key        string              `json:"key" yaml:"key"`         // Masked key
+	Start      time.Time           `json:"start" yaml:"start"`
+	End        time.Time           `json:"end" yaml:"end"`
+	Currency   string              `json:"currency" yaml:"currency"`
+
+	// ReportedSpend is the provider's own cost figure when its cost API
+	// answered; EstimatedSpend prices the usage at catalog rates.
+	ReportedSpend  *float64     `json:"reported_spend,omitempty" yaml:"reported_spend,omitempty"`
+	EstimatedSpend float64      `json:"estimated_spend" yaml:"estimated_spend"`
+	Unpriced       []string     `json:"unpriced_models,omitempty" yaml:"unpriced_models,omitempty"`
+	Models         []ModelUsage `json:"models" yaml:"models"`
+
+	// Limit, when set, is the spend allowed for the period.
+	Limit     float64  `json:"limit,omitempty" yaml:"limit,omitempty"`
+	Remaining *float64 `json:"remaining,omitempty" yaml:"remaining,omitempty"`
+
+	// Provenance
+	Method    string    `json:"method" yaml:"method"`
+	CheckedAt time.Time `json:"checked_at" yaml:"checked_at"`
+}
+
+// Spend returns the provider-reported spend when available, otherwise the
+// catalog estimate.
+func (r *Report) Spend() float64 {
+	if r.ReportedSpend != nil {
+		return *r.ReportedSpend
+	}
+	return r.EstimatedSpend
+}
+
+// SetLimit records the spend allowed for the period and the remainder.
+func (r *Report) SetLimit(limit float64) {
+	if limit <= 0 {
+		return
+	}
+	remaining := limit - r.Spend()
+	r.Limit = limit
+	r.Remaining = &remaining
+}
+
+// Supported returns the providers with a usage API, sorted by ID.
+func Supported() []catalogs.ProviderID {
+	ids := make([]catalogs.ProviderID, 0, len(dialects))
+	for id := range dialects {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// KeyEnv returns the environment variable that holds a provider's admin key
+// by default, or an empty string when the provider has no usage API.
+func KeyEnv(providerID catalogs.ProviderID) string {
+	return dialects[providerID].keyEnv
+}
+
+// Option configures a Tracker.
+type Option func(*Tracker)
+
+// WithKeyEnv reads the admin key from the named environment variable, so
+// several keys of one provider can be tracked separately.
+func WithKeyEnv(name string) Option {
+	return func(t *Tracker) {
+		if name != "" {
+			t.keyEnv = name
+		}
+	}
+}
+
+// WithBaseURL sends usage requests to baseURL instead of the provider's API.
+func WithBaseURL(baseURL string) Option {
+	return func(t *Tracker) {
+		if baseURL != "" {
+			t.baseURL = strings.TrimSuffix(baseURL, "/")
+		}
+	}
+}
+
+// Tracker queries one provider key's usage and cost APIs.
+type Tracker struct {
+	provider *catalogs.Provider
+	dialect  dialect
+	keyEnv   string
+	baseURL  string
+	client   *transport.Client
+	auth     *catalogs.Provider
+	now      func() time.Time
+}
+
+// New creates a tracker for a catalog provider. The provider's models
+// supply the prices used to estimate spend.
+func New(provider *catalogs.Provider, opts ...Option) (*Tracker, error) {
+	if provider == nil {
+		return nil, &errors.ValidationError{Field: "provider", Message: "provider is required"}
+	}
+	d, ok := dialects[provider.ID]
+	if !ok {
+		return nil, &errors.ValidationError{
+			Field:   "provider",
+			Value:   provider.ID,
+			Message: "no usage API for this provider",
+		}
+	}
+	t := &Tracker{
+		provider: provider,
+		dialect:  d,
+		keyEnv:   d.keyEnv,
+		baseURL:  d.baseURL,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	// Usage APIs authenticate like the inference API, but with the admin key.
+	apiKey := &catalogs.ProviderAPIKey{Name: t.keyEnv}
+	if provider.APIKey != nil {
+		apiKey.Header = provider.APIKey.Header
+		apiKey.Scheme = provider.APIKey.Scheme
+	}
+	t.auth = &catalogs.Provider{
+		ID:      provider.ID,
+		Name:    provider.Name,
+		APIKey:  apiKey,
+		Catalog: &catalogs.ProviderCatalog{Endpoint: catalogs.ProviderEndpoint{AuthRequired: true}},
+	}
+	t.client = transport.New(t.auth)
+	return t, nil
+}
+
+// Configured reports whether the tracker's admin key is set.
+func (t *Tracker) Configured() bool {
+	return os.Getenv(t.keyEnv) != ""
+}
+
+// Fetch reports the key's usage and spend in [start, end).
+func (t *Tracker) Fetch(ctx context.Context, start, end time.Time) (*Report, error) {
+	if !end.After(start) {
+		return nil, &errors.ValidationError{Field: "period", Value: start.Format(time.RFC3339) + ".." + end.Format(time.RFC3339), Message: "end must be after start"}
+	}
+	key, err := t.auth.APIKeyValue()
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := t.dialect.usage(ctx, t, start, end)
+	if err != nil {
+		return nil, errors.WrapResource("fetch", "usage", string(t.provider.ID), err)
+	}
+	report := &Report{
+		ProviderID: t.provider.ID,
+		KeyEnv:     t.keyEnv,
+		Key:        maskKey(key),
+		Start:      start.UTC(),
+		End:        end.UTC(),
+		Currency:   "USD",
+		Models:     usage,
+		Method:     t.dialect.name,
+		CheckedAt:  t.now().UTC(),
+	}
+	t.price(report)
+
+	spend, currency, err := t.dialect.cost(ctx, t, start, end)
+	if err != nil {
+		return nil, errors.WrapResource("fetch", "cost", string(t.provider.ID), err)
+	}
+	report.ReportedSpend = &spend
+	if currency != "" {
+		report.Currency = strings.ToUpper(currency)
+	}
+	return report, nil
+}
+
+// price joins usage with the catalog's token prices.
+func (t *Tracker) price(report *Report) {
+	slices.SortFunc(report.Models, func(a, b ModelUsage) int { return strings.Compare(a.Model, b.Model) })
+	for i := range report.Models {
+		usage := &report.Models[i]
+		model, ok := t.provider.Models[usage.Model]
+		if !ok {
+			report.Unpriced = append(report.Unpriced, usage.Model)
+			continue
+		}
+		cost, ok := model.Pricing.TokenCost(usage.Tokens)
+		if !ok {
+			report.Unpriced = append(report.Unpriced, usage.Model)
+			continue
+		}
+		usage.Cost = &cost
+		report.EstimatedSpend += cost
+	}
+}
+
+// get decodes one usage API page.
+func (t *Tracker) get(ctx context.Context, url string, target any) error {
+	resp, err := t.client.Get(ctx, url, t.auth)
+	if err != nil {
+		return err
+	}
+	return transport.DecodeResponse(resp, target)
+}
+
+// Track reports the provider with providerID, or when providerID is empty,
+// every supported provider in catalog whose admin key is set.
+func Track(ctx context.Context, catalog catalogs.Reader, providerID catalogs.ProviderID, start, end time.Time, opts ...Option) ([]*Report, error) {
+	ids := []catalogs.ProviderID{providerID}
+	if providerID == "" {
+		ids = Supported()
+	}
+	reports := make([]*Report, 0, len(ids))
+	for _, id := range ids {
+		provider, ok := catalog.Providers().Get(id)
+		if !ok && providerID == "" {
+			continue
+		}
+		if !ok {
+			return nil, &errors.NotFoundError{Resource: "provider", ID: string(id)}
+		}
+		tracker, err := New(provider, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if providerID == "" && !tracker.Configured() {
+			continue
+		}
+		report, err := tracker.Fetch(ctx, start, end)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// MonthStart returns the start of the UTC calendar month containing at.
+func MonthStart(at time.Time) time.Time {
+	at = at.UTC()
+	return time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// ParsePeriod parses a report period from dates (YYYY-MM-DD) or RFC 3339
+// timestamps. An empty since starts the period at the beginning of the
+// current month; an empty until ends it at now.
+func ParsePeriod(since, until string, now time.Time) (time.Time, time.Time, error) {
+	start, end := MonthStart(now), now.UTC()
+	var err error
+	if since != "" {
+		if start, err = parseInstant("since", since); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if until != "" {
+		if end, err = parseInstant("until", until); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	return start, end, nil
+}
+
+func parseInstant(field, value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, &errors.ValidationError{Field: field, Value: value, Message: "must be a date (YYYY-MM-DD) or RFC 3339 timestamp"}
+	}
+	return t.UTC(), nil
+}
+
+// maskKey keeps only the last four characters of a key.
+func maskKey(key string) string {
+	if len(key) <= 8 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
@@ -0,0 +1,138 @@
+package quota
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func quotaTestProvider(id catalogs.ProviderID) *catalogs.Provider {
+	return &catalogs.Provider{
+		ID:     id,
+		APIKey: &catalogs.ProviderAPIKey{Name: "UNUSED", Header: "x-api-key"},
+		Models: map[string]*catalogs.Model{
+			"priced": {ID: "priced", Pricing: &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{
+				Input:     &catalogs.ModelTokenCost{Per1M: 2},
+				Output:    &catalogs.ModelTokenCost{Per1M: 10},
+				CacheRead: &catalogs.ModelTokenCost{Per1M: 1},
+			}}},
+		},
+	}
+}
+
+func TestOpenAITrackerJoinsUsageWithCatalogPricing(t *testing.T) {
+	t.Setenv("QUOTA_TEST_KEY", "sk-admin-test-1234")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("x-api-key"); got != "sk-admin-test-1234" {
+			t.Errorf("admin key header = %q", got)
+		}
+		switch r.URL.Path {
+		case "/organization/usage/completions":
+			if r.URL.Query().Get("group_by") != "model" {
+				t.Errorf("usage query = %s", r.URL.RawQuery)
+			}
+			if r.URL.Query().Get("page") == "" {
+				_, _ = w.Write([]byte(`{"data":[{"results":[{"model":"priced","input_tokens":1500000,"input_cached_tokens":500000,"output_tokens":100000,"num_model_requests":7}]}],"has_more":true,"next_page":"p2"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"results":[{"model":"priced","input_tokens":0,"output_tokens":100000,"num_model_requests":3},{"model":"unknown","input_tokens":10,"output_tokens":10}]}],"has_more":false}`))
+		case "/organization/costs":
+			_, _ = w.Write([]byte(`{"data":[{"results":[{"amount":{"value":1.25,"currency":"usd"}}]},{"results":[{"amount":{"value":2.5,"currency":"usd"}}]}],"has_more":false}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tracker, err := New(quotaTestProvider(catalogs.ProviderIDOpenAI), WithBaseURL(server.URL), WithKeyEnv("QUOTA_TEST_KEY"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	report, err := tracker.Fetch(t.Context(), start, start.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	if len(report.Models) != 2 || report.Models[0].Model != "priced" {
+		t.Fatalf("models = %+v", report.Models)
+	}
+	priced := report.Models[0]
+	if priced.Tokens.Input != 1_000_000 || priced.Tokens.CacheRead != 500_000 || priced.Tokens.Output != 200_000 || priced.Requests != 10 {
+		t.Fatalf("priced usage = %+v", priced)
+	}
+	// 1M input at $2 + 0.5M cached at $1 + 0.2M output at $10.
+	if want := 2 + 0.5 + 2.0; priced.Cost == nil || math.Abs(*priced.Cost-want) > 1e-9 || math.Abs(report.EstimatedSpend-want) > 1e-9 {
+		t.Fatalf("estimated cost = %v / %v, want %v", priced.Cost, report.EstimatedSpend, want)
+	}
+	if len(report.Unpriced) != 1 || report.Unpriced[0] != "unknown" {
+		t.Fatalf("unpriced = %v", report.Unpriced)
+	}
+	if report.ReportedSpend == nil || *report.ReportedSpend != 3.75 || report.Currency != "USD" {
+		t.Fatalf("reported spend = %v %s", report.ReportedSpend, report.Currency)
+	}
+	if report.Key != "****1234" || report.KeyEnv != "QUOTA_TEST_KEY" {
+		t.Fatalf("key = %s from %s", report.Key, report.KeyEnv)
+	}
+
+	report.SetLimit(10)
+	if report.Remaining == nil || *report.Remaining != 6.25 {
+		t.Fatalf("remaining = %v, want 6.25", report.Remaining)
+	}
+}
+
+func TestAnthropicTrackerConvertsCentsAndCacheWrites(t *testing.T) {
+	t.Setenv("QUOTA_TEST_KEY", "sk-ant-admin-test-5678")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organizations/usage_report/messages":
+			if r.URL.Query().Get("starting_at") != "2026-10-01T00:00:00Z" {
+				t.Errorf("usage query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"data":[{"results":[{"model":"priced","uncached_input_tokens":100,"cache_read_input_tokens":20,"cache_creation":{"ephemeral_5m_input_tokens":5,"ephemeral_1h_input_tokens":3},"output_tokens":40}]}],"has_more":false}`))
+		case "/organizations/cost_report":
+			_, _ = w.Write([]byte(`{"data":[{"results":[{"amount":"1234.5","currency":"USD"}]}],"has_more":false}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tracker, err := New(quotaTestProvider(catalogs.ProviderIDAnthropic), WithBaseURL(server.URL), WithKeyEnv("QUOTA_TEST_KEY"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	report, err := tracker.Fetch(t.Context(), start, start.AddDate(0, 0, 10))
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	want := catalogs.TokenUsage{Input: 100, CacheRead: 20, CacheWrite: 8, Output: 40}
+	if len(report.Models) != 1 || report.Models[0].Tokens != want {
+		t.Fatalf("models = %+v", report.Models)
+	}
+	if report.ReportedSpend == nil || math.Abs(*report.ReportedSpend-12.345) > 1e-9 {
+		t.Fatalf("reported spend = %v, want 12.345", report.ReportedSpend)
+	}
+}
+
+func TestTrackerRequiresAdminKeyAndSupportedProvider(t *testing.T) {
+	if _, err := New(quotaTestProvider("groq")); err == nil {
+		t.Fatal("New accepted a provider without a usage API")
+	}
+	tracker, err := New(quotaTestProvider(catalogs.ProviderIDOpenAI), WithKeyEnv("QUOTA_TEST_UNSET_KEY"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if tracker.Configured() {
+		t.Fatal("Configured with an unset key")
+	}
+	now := time.Now()
+	if _, err := tracker.Fetch(t.Context(), now.Add(-time.Hour), now); err == nil {
+		t.Fatal("Fetch succeeded without an admin key")
+	}
+}
@@ -0,0 +1,337 @@
+// Package replicate provides a client for the Replicate models API.
+//
+// Replicate hosts public models that anyone can run, most of them open
+// models published by their authors. Predictions are billed by the second on
+// the hardware the model runs on, so the client records the provider's
+// per-second hardware rates as compute pricing rather than token prices.
+package replicate
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/agentstation/starmap/internal/transport"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sourcepayload"
+)
+
+// DefaultModelsURL is the Replicate models endpoint.
+const DefaultModelsURL = "https://api.replicate.com/v1/models"
+
+// maxModelsPages bounds how many pages one listing follows.
+const maxModelsPages = 500
+
+// visibilityPublic marks models anyone can run.
+const visibilityPublic = "public"
+
+// Response structures for the Replicate API.
+type modelsResponse struct {
+	Next          *string                          `json:"next"`
+	Previous      *string                          `json:"previous"`
+	Results       []modelResponse                  `json:"results"`
+	UnknownFields []sourcepayload.UnknownJSONField `json:"-"`
+}
+
+func (r *modelsResponse) UnmarshalJSON(data []byte) error {
+	type responseAlias modelsResponse
+	var decoded responseAlias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	unknown, err := sourcepayload.UnknownJSONFields(data, decoded, "$")
+	if err != nil {
+		return err
+	}
+	*r = modelsResponse(decoded)
+	r.UnknownFields = unknown
+	return nil
+}
+
+type modelResponse struct {
+	URL            string                           `json:"url"`
+	Owner          string                           `json:"owner"`
+	Name           string                           `json:"name"`
+	Description    *string                          `json:"description"`
+	Visibility     string                           `json:"visibility"`
+	GitHubURL      *string                          `json:"github_url"`
+	PaperURL       *string                          `json:"paper_url"`
+	LicenseURL     *string                          `json:"license_url"`
+	RunCount       int64                            `json:"run_count"`
+	CoverImageURL  *string                          `json:"cover_image_url"`
+	DefaultExample json.RawMessage                  `json:"default_example"`
+	LatestVersion  *versionResponse                 `json:"latest_version"`
+	UnknownFields  []sourcepayload.UnknownJSONField `json:"-"`
+}
+
+func (m *modelResponse) UnmarshalJSON(data []byte) error {
+	type modelAlias modelResponse
+	var decoded modelAlias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	unknown, err := sourcepayload.UnknownJSONFields(data, decoded, "results[]")
+	if err != nil {
+		return err
+	}
+	*m = modelResponse(decoded)
+	m.UnknownFields = unknown
+	return nil
+}
+
+type versionResponse struct {
+	ID            string          `json:"id"`
+	CreatedAt     string          `json:"created_at"`
+	CogVersion    string          `json:"cog_version"`
+	OpenAPISchema json.RawMessage `json:"openapi_schema"`
+}
+
+type hardwareResponse struct {
+	Name string `json:"name"`
+	SKU  string `json:"sku"`
+}
+
+// hardwarePerSecond holds Replicate's published per-second prices in USD by
+// hardware SKU, since the hardware endpoint lists SKUs without prices.
+var hardwarePerSecond = map[string]float64{
+	"cpu":               0.000100,
+	"gpu-t4":            0.000225,
+	"gpu-l40s":          0.000975,
+	"gpu-l40s-2x":       0.001950,
+	"gpu-l40s-4x":       0.003900,
+	"gpu-l40s-8x":       0.007800,
+	"gpu-a100-large":    0.001400,
+	"gpu-a100-large-2x": 0.002800,
+	"gpu-a100-large-4x": 0.005600,
+	"gpu-a100-large-8x": 0.011200,
+	"gpu-h100":          0.001525,
+	"gpu-h100-2x":       0.003050,
+	"gpu-h100-4x":       0.006100,
+	"gpu-h100-8x":       0.012200,
+}
+
+// ownerAuthors maps Replicate owners to catalog authors. Models of other
+// owners are recorded without an author.
+var ownerAuthors = map[string]catalogs.AuthorID{
+	"meta":            catalogs.AuthorIDMeta,
+	"mistralai":       catalogs.AuthorIDMistralAI,
+	"stability-ai":    catalogs.AuthorIDStabilityAI,
+	"deepseek-ai":     catalogs.AuthorIDDeepSeek,
+	"qwen":            catalogs.AuthorIDQwen,
+	"google":          catalogs.AuthorIDGoogle,
+	"google-deepmind": catalogs.AuthorIDDeepMind,
+	"nvidia":          catalogs.AuthorIDNVIDIA,
+	"microsoft":       catalogs.AuthorIDMicrosoft,
+	"ibm-granite":     catalogs.AuthorIDIBM,
+	"bytedance":       catalogs.AuthorIDByteDance,
+	"openai":          catalogs.AuthorIDOpenAI,
+	"anthropic":       catalogs.AuthorIDAnthropic,
+	"xai":             catalogs.AuthorIDXAI,
+}
+
+// Client implements the catalogs.Client interface for Replicate.
+type Client struct {
+	provider  *catalogs.Provider
+	transport *transport.Client
+	mu        sync.RWMutex
+}
+
+// NewClient creates a new Replicate client.
+func NewClient(provider *catalogs.Provider) *Client {
+	return &Client{
+		provider:  provider,
+		transport: transport.New(provider),
+	}
+}
+
+// IsAPIKeyRequired returns true if the client requires an API key.
+func (c *Client) IsAPIKeyRequired() bool {
+	return c.provider.IsAPIKeyRequired()
+}
+
+// HasAPIKey returns true if the client has an API key.
+func (c *Client) HasAPIKey() bool {
+	return c.provider.HasAPIKey()
+}
+
+// Configure sets the provider for this client (used by registry pattern).
+func (c *Client) Configure(provider *catalogs.Provider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.provider = provider
+	c.transport = transport.New(provider)
+}
+
+// ListModels retrieves the public models on Replicate with the latest
+// version of each and the per-second rates of the hardware they run on.
+func (c *Client) ListModels(ctx context.Context) ([]catalogs.Model, error) {
+	c.mu.RLock()
+	provider := c.provider
+	client := c.transport
+	c.mu.RUnlock()
+
+	if provider == nil {
+		return nil, &errors.ConfigError{
+			Component: "replicate",
+			Message:   "provider not configured",
+		}
+	}
+
+	modelsURL := transport.NewRequestBuilder(provider).GetModelsURL(DefaultModelsURL)
+	compute, err := c.fetchCompute(ctx, provider, client, modelsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	listed, err := transport.Paginate(ctx, provider.ID.String(), maxModelsPages,
+		func(m modelResponse) string { return m.Owner + "/" + m.Name },
+		func(ctx context.Context, next string) (transport.Page[modelResponse], error) {
+			pageURL := modelsURL
+			if next != "" {
+				pageURL = next
+			}
+			result := modelsResponse{}
+			if err := c.getJSON(ctx, provider, client, pageURL, &result); err != nil {
+				return transport.Page[modelResponse]{}, err
+			}
+			if result.Results == nil {
+				return transport.Page[modelResponse]{}, errors.NewParseError("json", "replicate response", "required results array is missing or null", nil)
+			}
+			for i := range result.Results {
+				result.Results[i].UnknownFields = append(result.Results[i].UnknownFields, result.UnknownFields...)
+			}
+			page := transport.Page[modelResponse]{Items: result.Results}
+			if result.Next != nil {
+				page.Next = *result.Next
+			}
+			return page, nil
+		})
+	if listed == nil && err != nil {
+		return nil, err
+	}
+
+	// A partial listing keeps the models of the pages fetched so far
+	var models []catalogs.Model
+	for _, m := range listed {
+		if m.Visibility != visibilityPublic || m.LatestVersion == nil {
+			continue
+		}
+		models = append(models, *c.convertToModel(m, compute))
+	}
+	return models, err
+}
+
+// fetchCompute returns the rates of the hardware Replicate offers, or nil
+// when none of it has a known price.
+func (c *Client) fetchCompute(ctx context.Context, provider *catalogs.Provider, client *transport.Client, modelsURL string) (*catalogs.ModelComputePricing, error) {
+	hardwareURL, err := url.Parse(modelsURL)
+	if err != nil {
+		return nil, errors.WrapResource("parse", "url", modelsURL, err)
+	}
+	hardwareURL.Path = path.Join(path.Dir(hardwareURL.Path), "hardware")
+	hardwareURL.RawQuery = ""
+
+	var hardware []hardwareResponse
+	if err := c.getJSON(ctx, provider, client, hardwareURL.String(), &hardware); err != nil {
+		return nil, err
+	}
+	compute := &catalogs.ModelComputePricing{}
+	for _, item := range hardware {
+		perSecond, ok := hardwarePerSecond[item.SKU]
+		if !ok {
+			continue
+		}
+		compute.Rates = append(compute.Rates, catalogs.ModelComputeRate{
+			Hardware:  item.SKU,
+			Name:      item.Name,
+			PerSecond: perSecond,
+		})
+	}
+	if len(compute.Rates) == 0 {
+		return nil, nil
+	}
+	return compute, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, provider *catalogs.Provider, client *transport.Client, requestURL string, target any) error {
+	resp, err := client.Get(ctx, requestURL, provider)
+	if err != nil {
+		return &errors.APIError{
+			Provider: provider.ID.String(),
+			Endpoint: requestURL,
+			Message:  "request failed",
+			Err:      err,
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := transport.DecodeResponse(resp, target); err != nil {
+		return errors.WrapParse("json", "replicate response", err)
+	}
+	return nil
+}
+
+// convertToModel converts a Replicate model response to a starmap Model.
+// Model IDs take the owner/name form used to run the model.
+func (c *Client) convertToModel(m modelResponse, compute *catalogs.ModelComputePricing) *catalogs.Model {
+	model := catalogs.Model{
+		ID:   m.Owner + "/" + m.Name,
+		Name: m.Name,
+	}
+	if m.Description != nil {
+		model.Description = strings.TrimSpace(*m.Description)
+	}
+	if author, ok := ownerAuthors[m.Owner]; ok {
+		model.Authors = []catalogs.Author{{ID: author}}
+	}
+	if compute != nil {
+		model.Pricing = &catalogs.ModelPricing{
+			Compute:  &catalogs.ModelComputePricing{Rates: append([]catalogs.ModelComputeRate(nil), compute.Rates...)},
+			Currency: catalogs.ModelPricingCurrencyUSD,
+		}
+	}
+
+	fields := map[string]any{
+		"owner":   m.Owner,
+		"version": m.LatestVersion.ID,
+	}
+	if m.LatestVersion.CreatedAt != "" {
+		fields["version_created_at"] = m.LatestVersion.CreatedAt
+	}
+	if m.LatestVersion.CogVersion != "" {
+		fields["cog_version"] = m.LatestVersion.CogVersion
+	}
+	if m.URL != "" {
+		fields["url"] = m.URL
+	}
+	if m.RunCount > 0 {
+		fields["run_count"] = m.RunCount
+	}
+	for name, value := range map[string]*string{
+		"github_url":  m.GitHubURL,
+		"paper_url":   m.PaperURL,
+		"license_url": m.LicenseURL,
+	} {
+		if value != nil && *value != "" {
+			fields[name] = *value
+		}
+	}
+	if len(m.UnknownFields) > 0 {
+		fields["unknown_fields"] = m.UnknownFields
+	}
+	model.Extensions = catalogs.SourceExtensions{c.extensionSource(): {Fields: fields}}
+	return &model
+}
+
+func (c *Client) extensionSource() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.provider != nil && c.provider.ID != "" {
+		return c.provider.ID.String()
+	}
+	return catalogs.ProviderIDReplicate.String()
+}
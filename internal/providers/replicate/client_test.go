@@ -0,0 +1,125 @@
+package replicate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/sourcepayload"
+)
+
+const hardwarePayload = `[{"name":"CPU","sku":"cpu"},{"name":"Nvidia A100 (80GB) GPU","sku":"gpu-a100-large"},{"name":"Future GPU","sku":"gpu-future"}]`
+
+func newTestServer(t *testing.T, pages map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/hardware" {
+			_, _ = w.Write([]byte(hardwarePayload))
+			return
+		}
+		payload, ok := pages[r.URL.Query().Get("cursor")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(strings.ReplaceAll(payload, "{{server}}", "http://"+r.Host)))
+	}))
+}
+
+func newTestClient(url string) *Client {
+	return NewClient(&catalogs.Provider{
+		ID: catalogs.ProviderIDReplicate, Name: "Replicate",
+		Catalog: &catalogs.ProviderCatalog{Endpoint: catalogs.ProviderEndpoint{Type: catalogs.EndpointTypeReplicate, URL: url + "/v1/models"}},
+	})
+}
+
+func TestSchemaDriftMutationMatrix(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     string
+		wantErr     bool
+		wantModels  int
+		wantUnknown int
+	}{
+		{name: "valid", payload: `{"results":[{"owner":"meta","name":"llama","visibility":"public","latest_version":{"id":"v1"}}]}`, wantModels: 1},
+		{name: "missing", payload: `{}`, wantErr: true},
+		{name: "renamed", payload: `{"models":[]}`, wantErr: true},
+		{name: "null", payload: `{"results":null}`, wantErr: true},
+		{name: "wrong type", payload: `{"results":{}}`, wantErr: true},
+		{name: "unknown additive", payload: `{"results":[{"owner":"meta","name":"llama","visibility":"public","latest_version":{"id":"v1"},"new_capability":true}],"new_page":1}`, wantModels: 1, wantUnknown: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t, map[string]string{"": test.payload})
+			defer server.Close()
+			models, err := newTestClient(server.URL).ListModels(context.Background())
+			if test.wantErr && err == nil {
+				t.Fatal("ListModels returned nil error")
+			}
+			if !test.wantErr && err != nil {
+				t.Fatalf("ListModels: %v", err)
+			}
+			if len(models) != test.wantModels {
+				t.Fatalf("models = %d, want %d", len(models), test.wantModels)
+			}
+			if test.wantUnknown > 0 {
+				items := models[0].Extensions["replicate"].Fields["unknown_fields"].([]sourcepayload.UnknownJSONField)
+				if len(items) != test.wantUnknown {
+					t.Fatalf("unknown evidence = %#v", items)
+				}
+			}
+		})
+	}
+}
+
+func TestListModelsFollowsNextAndPricesHardware(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"": `{"next":"{{server}}/v1/models?cursor=page-2","results":[
+			{"owner":"meta","name":"meta-llama-3-8b","description":" Base Llama 3 ","visibility":"public","run_count":42,"latest_version":{"id":"v1","cog_version":"0.9.0"}}
+		]}`,
+		"page-2": `{"next":null,"results":[
+			{"owner":"stability-ai","name":"sdxl","visibility":"public","latest_version":{"id":"v2","created_at":"2025-01-02T00:00:00Z"}},
+			{"owner":"someone","name":"private-model","visibility":"private","latest_version":{"id":"v3"}},
+			{"owner":"someone","name":"unversioned","visibility":"public"}
+		]}`,
+	})
+	defer server.Close()
+
+	models, err := newTestClient(server.URL).ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels: %v", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("models = %d, want 2 public versioned models", len(models))
+	}
+
+	llama := models[0]
+	if llama.ID != "meta/meta-llama-3-8b" || llama.Description != "Base Llama 3" {
+		t.Fatalf("llama = %q %q", llama.ID, llama.Description)
+	}
+	if len(llama.Authors) != 1 || llama.Authors[0].ID != catalogs.AuthorIDMeta {
+		t.Fatalf("llama authors = %+v", llama.Authors)
+	}
+	if err := llama.Pricing.Validate(); err != nil {
+		t.Fatalf("pricing.Validate: %v", err)
+	}
+	if len(llama.Pricing.Compute.Rates) != 2 {
+		t.Fatalf("rates = %+v, want only priced hardware", llama.Pricing.Compute.Rates)
+	}
+	rate, ok := llama.Pricing.Compute.Rate("gpu-a100-large")
+	if !ok || rate.PerSecond != 0.0014 || rate.Name != "Nvidia A100 (80GB) GPU" {
+		t.Fatalf("a100 rate = %+v, %v", rate, ok)
+	}
+	fields := llama.Extensions["replicate"].Fields
+	if fields["version"] != "v1" || fields["run_count"] != int64(42) {
+		t.Fatalf("fields = %+v", fields)
+	}
+
+	if models[1].ID != "stability-ai/sdxl" || models[1].Authors[0].ID != catalogs.AuthorIDStabilityAI {
+		t.Fatalf("second model = %+v", models[1])
+	}
+}
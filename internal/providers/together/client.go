@@ -0,0 +1,312 @@
+// Package together provides a client for the Together AI models API.
+//
+// Together serves most models two ways: serverless, billed per token, and on
+// dedicated endpoints, billed per hour of hardware. The catalog model carries
+// the serverless prices, and the client records each way a model can be
+// deployed as a provider-specific variant.
+package together
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentstation/utc"
+
+	"github.com/agentstation/starmap/internal/transport"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sourcepayload"
+)
+
+// DefaultModelsURL is the Together AI models endpoint.
+const DefaultModelsURL = "https://api.together.xyz/v1/models"
+
+// Deployments a Together model can be served through.
+const (
+	deploymentServerless = "serverless"
+	deploymentDedicated  = "dedicated"
+)
+
+// Together model types.
+const (
+	typeEmbedding = "embedding"
+	typeRerank    = "rerank"
+	typeImage     = "image"
+	typeAudio     = "audio"
+)
+
+// Response structures for the Together API. The models endpoint returns a
+// bare array.
+type modelResponse struct {
+	ID            string                           `json:"id"`
+	Object        string                           `json:"object"`
+	Created       int64                            `json:"created"`
+	Type          string                           `json:"type"`
+	Running       bool                             `json:"running"`
+	DisplayName   string                           `json:"display_name"`
+	Organization  string                           `json:"organization"`
+	Link          string                           `json:"link"`
+	License       string                           `json:"license"`
+	ContextLength int64                            `json:"context_length"`
+	Config        json.RawMessage                  `json:"config"`
+	Pricing       *pricing                         `json:"pricing"`
+	UnknownFields []sourcepayload.UnknownJSONField `json:"-"`
+}
+
+func (m *modelResponse) UnmarshalJSON(data []byte) error {
+	type modelAlias modelResponse
+	var decoded modelAlias
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	unknown, err := sourcepayload.UnknownJSONFields(data, decoded, "[]")
+	if err != nil {
+		return err
+	}
+	*m = modelResponse(decoded)
+	m.UnknownFields = unknown
+	return nil
+}
+
+// pricing holds Together's prices: token prices in USD per 1M tokens and
+// the dedicated endpoint price in USD per hour.
+type pricing struct {
+	Hourly   float64 `json:"hourly"`
+	Input    float64 `json:"input"`
+	Output   float64 `json:"output"`
+	Base     float64 `json:"base"`
+	Finetune float64 `json:"finetune"`
+}
+
+// Client implements the catalogs.Client interface for Together AI.
+type Client struct {
+	provider  *catalogs.Provider
+	transport *transport.Client
+	mu        sync.RWMutex
+}
+
+// NewClient creates a new Together AI client.
+func NewClient(provider *catalogs.Provider) *Client {
+	return &Client{
+		provider:  provider,
+		transport: transport.New(provider),
+	}
+}
+
+// IsAPIKeyRequired returns true if the client requires an API key.
+func (c *Client) IsAPIKeyRequired() bool {
+	return c.provider.IsAPIKeyRequired()
+}
+
+// HasAPIKey returns true if the client has an API key.
+func (c *Client) HasAPIKey() bool {
+	return c.provider.HasAPIKey()
+}
+
+// Configure sets the provider for this client (used by registry pattern).
+func (c *Client) Configure(provider *catalogs.Provider) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.provider = provider
+	c.transport = transport.New(provider)
+}
+
+// ListModels retrieves all models from Together AI.
+func (c *Client) ListModels(ctx context.Context) ([]catalogs.Model, error) {
+	c.mu.RLock()
+	provider := c.provider
+	client := c.transport
+	c.mu.RUnlock()
+
+	if provider == nil {
+		return nil, &errors.ConfigError{
+			Component: "together",
+			Message:   "provider not configured",
+		}
+	}
+
+	modelsURL := transport.NewRequestBuilder(provider).GetModelsURL(DefaultModelsURL)
+	resp, err := client.Get(ctx, modelsURL, provider)
+	if err != nil {
+		return nil, &errors.APIError{
+			Provider: provider.ID.String(),
+			Endpoint: modelsURL,
+			Message:  "request failed",
+			Err:      err,
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result []modelResponse
+	if err := transport.DecodeResponse(resp, &result); err != nil {
+		return nil, errors.WrapParse("json", "together response", err)
+	}
+	if result == nil {
+		return nil, errors.NewParseError("json", "together response", "required models array is missing or null", nil)
+	}
+
+	models := make([]catalogs.Model, 0, len(result))
+	for _, m := range result {
+		models = append(models, *c.convertToModel(m))
+	}
+	return models, nil
+}
+
+// convertToModel converts a Together model response to a starmap Model.
+func (c *Client) convertToModel(m modelResponse) *catalogs.Model {
+	model := &catalogs.Model{
+		ID:   m.ID,
+		Name: m.DisplayName,
+	}
+	if model.Name == "" {
+		model.Name = m.ID
+	}
+	if m.Created > 0 {
+		model.CreatedAt = utc.New(time.Unix(m.Created, 0))
+		model.UpdatedAt = model.CreatedAt
+	}
+	if author, _, ok := strings.Cut(m.ID, "/"); ok && author != "" {
+		authorID := catalogs.ParseAuthorID(strings.ToLower(author))
+		model.Authors = []catalogs.Author{{ID: authorID, Name: m.Organization}}
+	}
+	if m.ContextLength > 0 {
+		model.Limits = &catalogs.ModelLimits{ContextWindow: m.ContextLength}
+	}
+
+	switch m.Type {
+	case typeEmbedding:
+		model.Class = catalogs.ModelClassEmbedding
+		model.Features = modalities(catalogs.ModelModalityText, catalogs.ModelModalityEmbedding)
+	case typeRerank:
+		model.Class = catalogs.ModelClassRerank
+		model.Features = modalities(catalogs.ModelModalityText)
+	case typeImage:
+		model.Features = modalities(catalogs.ModelModalityText, catalogs.ModelModalityImage)
+	case typeAudio:
+		model.Features = modalities(catalogs.ModelModalityText, catalogs.ModelModalityAudio)
+	default:
+		model.Features = modalities(catalogs.ModelModalityText, catalogs.ModelModalityText)
+	}
+
+	if q := quantization(m.ID); q != "" {
+		model.Metadata = &catalogs.ModelMetadata{
+			Architecture: &catalogs.ModelArchitecture{Quantization: q},
+		}
+	}
+	model.Pricing = serverlessPricing(m.Pricing)
+
+	fields := map[string]any{}
+	if m.Type != "" {
+		fields["type"] = m.Type
+	}
+	if m.Organization != "" {
+		fields["organization"] = m.Organization
+	}
+	if m.Link != "" {
+		fields["link"] = m.Link
+	}
+	if m.License != "" {
+		fields["license"] = m.License
+	}
+	if variants := convertVariants(m); len(variants) > 0 {
+		fields["variants"] = variants
+	}
+	if len(m.UnknownFields) > 0 {
+		fields["unknown_fields"] = m.UnknownFields
+	}
+	if len(fields) > 0 {
+		model.Extensions = catalogs.SourceExtensions{
+			c.extensionSource(): {Fields: catalogs.NormalizeExtensionFields(fields)},
+		}
+	}
+	return model
+}
+
+// modalities returns features with one input and, when given, one output
+// modality.
+func modalities(input catalogs.ModelModality, output ...catalogs.ModelModality) *catalogs.ModelFeatures {
+	return &catalogs.ModelFeatures{Modalities: catalogs.ModelModalities{
+		Input:  []catalogs.ModelModality{input},
+		Output: slices.Clone(output),
+	}}
+}
+
+// quantization returns the precision Together documents for its model
+// tiers: Turbo models are served in FP8 and Lite models in INT4. Other
+// models are served at their published precision, which the listing does
+// not report.
+func quantization(id string) catalogs.Quantization {
+	name := strings.ToLower(id)
+	switch {
+	case strings.Contains(name, "-turbo"):
+		return catalogs.QuantizationFP8
+	case strings.Contains(name, "-lite"):
+		return catalogs.QuantizationINT4
+	default:
+		return ""
+	}
+}
+
+// serverlessPricing returns a model's per-token prices, or nil when it is
+// not served serverless.
+func serverlessPricing(p *pricing) *catalogs.ModelPricing {
+	if p == nil || (p.Input <= 0 && p.Output <= 0) {
+		return nil
+	}
+	return &catalogs.ModelPricing{
+		Currency: catalogs.ModelPricingCurrencyUSD,
+		Tokens: &catalogs.ModelTokenPricing{
+			Input:  &catalogs.ModelTokenCost{Per1M: p.Input},
+			Output: &catalogs.ModelTokenCost{Per1M: p.Output},
+		},
+	}
+}
+
+// convertVariants describes how a model can be deployed: serverless when it
+// has token prices, and on a dedicated endpoint when it has an hourly price.
+func convertVariants(m modelResponse) []any {
+	if m.Pricing == nil {
+		return nil
+	}
+	var variants []any
+	if m.Pricing.Input > 0 || m.Pricing.Output > 0 {
+		variant := map[string]any{
+			"deployment": deploymentServerless,
+			"pricing": map[string]any{
+				"input_per_1m":  m.Pricing.Input,
+				"output_per_1m": m.Pricing.Output,
+			},
+		}
+		if q := quantization(m.ID); q != "" {
+			variant["quantization"] = q.String()
+		}
+		if m.ContextLength > 0 {
+			variant["context_length"] = m.ContextLength
+		}
+		variants = append(variants, variant)
+	}
+	if m.Pricing.Hourly > 0 {
+		variant := map[string]any{
+			"deployment": deploymentDedicated,
+			"pricing":    map[string]any{"hourly": m.Pricing.Hourly},
+		}
+		if m.Running {
+			variant["running"] = true
+		}
+		variants = append(variants, variant)
+	}
+	return variants
+}
+
+func (c *Client) extensionSource() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.provider != nil && c.provider.ID != "" {
+		return c.provider.ID.String()
+	}
+	return catalogs.ProviderIDTogetherAI.String()
+}
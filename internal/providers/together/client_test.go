@@ -0,0 +1,137 @@
+package together
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func newTestClient(url string) *Client {
+	return NewClient(&catalogs.Provider{
+		ID: catalogs.ProviderIDTogetherAI, Name: "Together AI",
+		Catalog: &catalogs.ProviderCatalog{Endpoint: catalogs.ProviderEndpoint{Type: catalogs.EndpointTypeTogether, URL: url}},
+	})
+}
+
+func TestSchemaDriftMutationMatrix(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     string
+		wantErr     bool
+		wantModels  int
+		wantUnknown int
+	}{
+		{name: "valid", payload: `[{"id":"meta-llama/Llama-3.3-70B-Instruct-Turbo","type":"chat"}]`, wantModels: 1},
+		{name: "missing", payload: ``, wantErr: true},
+		{name: "renamed", payload: `{"data":[]}`, wantErr: true},
+		{name: "null", payload: `null`, wantErr: true},
+		{name: "wrong type", payload: `{}`, wantErr: true},
+		{name: "unknown additive", payload: `[{"id":"meta-llama/Llama-3.3-70B-Instruct-Turbo","type":"chat","new_capability":true,"new_tier":"x"}]`, wantModels: 1, wantUnknown: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(test.payload))
+			}))
+			defer server.Close()
+			models, err := newTestClient(server.URL).ListModels(context.Background())
+			if test.wantErr && err == nil {
+				t.Fatal("ListModels returned nil error")
+			}
+			if !test.wantErr && err != nil {
+				t.Fatalf("ListModels: %v", err)
+			}
+			if len(models) != test.wantModels {
+				t.Fatalf("models = %d, want %d", len(models), test.wantModels)
+			}
+			if test.wantUnknown > 0 {
+				items := models[0].Extensions["together"].Fields["unknown_fields"].([]any)
+				if len(items) != test.wantUnknown {
+					t.Fatalf("unknown evidence = %#v", items)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertToModelRecordsDeploymentVariants(t *testing.T) {
+	client := newTestClient("")
+
+	model := client.convertToModel(modelResponse{
+		ID:            "meta-llama/Llama-3.3-70B-Instruct-Turbo",
+		Type:          "chat",
+		DisplayName:   "Meta Llama 3.3 70B Instruct Turbo",
+		Organization:  "Meta",
+		ContextLength: 131072,
+		Running:       true,
+		Pricing:       &pricing{Input: 0.88, Output: 0.88, Hourly: 3.5},
+	})
+	if model.Name != "Meta Llama 3.3 70B Instruct Turbo" || len(model.Authors) != 1 || model.Authors[0].Name != "Meta" {
+		t.Fatalf("model = %q, authors = %+v", model.Name, model.Authors)
+	}
+	if model.Limits == nil || model.Limits.ContextWindow != 131072 {
+		t.Fatalf("limits = %+v", model.Limits)
+	}
+	if model.Metadata == nil || model.Metadata.Architecture.Quantization != catalogs.QuantizationFP8 {
+		t.Fatalf("metadata = %+v", model.Metadata)
+	}
+	if model.Pricing == nil || model.Pricing.Tokens.Input.Per1M != 0.88 || model.Pricing.Tokens.Output.Per1M != 0.88 {
+		t.Fatalf("pricing = %+v", model.Pricing)
+	}
+
+	variants, ok := model.Extensions["together"].Fields["variants"].([]any)
+	if !ok || len(variants) != 2 {
+		t.Fatalf("variants = %#v", model.Extensions["together"].Fields["variants"])
+	}
+	serverless := variants[0].(map[string]any)
+	if serverless["deployment"] != deploymentServerless || serverless["quantization"] != "fp8" || serverless["context_length"] != int64(131072) {
+		t.Fatalf("serverless variant = %#v", serverless)
+	}
+	dedicated := variants[1].(map[string]any)
+	if dedicated["deployment"] != deploymentDedicated || dedicated["running"] != true ||
+		dedicated["pricing"].(map[string]any)["hourly"] != 3.5 {
+		t.Fatalf("dedicated variant = %#v", dedicated)
+	}
+}
+
+func TestConvertToModelDedicatedOnly(t *testing.T) {
+	model := newTestClient("").convertToModel(modelResponse{
+		ID:      "Qwen/Qwen2.5-72B-Instruct",
+		Type:    "chat",
+		Pricing: &pricing{Hourly: 7},
+	})
+	if model.Pricing != nil {
+		t.Fatalf("dedicated-only model has token pricing %+v", model.Pricing)
+	}
+	if model.Metadata != nil {
+		t.Fatalf("untiered model has metadata %+v", model.Metadata)
+	}
+	variants := model.Extensions["together"].Fields["variants"].([]any)
+	if len(variants) != 1 || variants[0].(map[string]any)["deployment"] != deploymentDedicated {
+		t.Fatalf("variants = %#v", variants)
+	}
+}
+
+func TestConvertToModelClassifiesModels(t *testing.T) {
+	client := newTestClient("")
+
+	embed := client.convertToModel(modelResponse{ID: "BAAI/bge-large-en-v1.5", Type: "embedding"})
+	if embed.Class != catalogs.ModelClassEmbedding || embed.Features.Modalities.Output[0] != catalogs.ModelModalityEmbedding {
+		t.Fatalf("embed = %q %+v", embed.Class, embed.Features)
+	}
+	if len(embed.Authors) != 1 || embed.Authors[0].ID != catalogs.AuthorID("baai") {
+		t.Fatalf("embed authors = %+v", embed.Authors)
+	}
+	rerank := client.convertToModel(modelResponse{ID: "Salesforce/Llama-Rank-V1", Type: "rerank"})
+	if rerank.Class != catalogs.ModelClassRerank || len(rerank.Features.Modalities.Output) != 0 {
+		t.Fatalf("rerank = %q %+v", rerank.Class, rerank.Features)
+	}
+	lite := client.convertToModel(modelResponse{ID: "meta-llama/Meta-Llama-3-8B-Instruct-Lite", Type: "chat"})
+	if lite.ClassOrDefault() != catalogs.ModelClassChat || lite.Metadata.Architecture.Quantization != catalogs.QuantizationINT4 {
+		t.Fatalf("lite = %q %+v", lite.Class, lite.Metadata)
+	}
+}
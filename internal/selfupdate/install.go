@@ -0,0 +1,189 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const binaryName = "starmap"
+
+// ArchiveName returns the release archive name GoReleaser publishes for
+// version, goos, and goarch.
+func ArchiveName(version Version, goos, goarch string) string {
+	arch := goarch
+	if goarch == "amd64" {
+		arch = "x86_64"
+	}
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s%s", binaryName, strings.TrimPrefix(version.String(), "v"), goos, arch, ext)
+}
+
+// VerifyChecksum checks data against the entry for name in a sha256sum-style
+// checksums file.
+func VerifyChecksum(checksums []byte, name string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return &errors.ValidationError{Field: "checksum", Value: name, Message: "SHA-256 digest does not match checksums.txt"}
+		}
+		return nil
+	}
+	return &errors.NotFoundError{Resource: "checksum", ID: name}
+}
+
+// ExtractBinary returns the starmap executable from a release archive.
+func ExtractBinary(archiveName string, data []byte, goos string) ([]byte, error) {
+	want := binaryName
+	if goos == "windows" {
+		want += ".exe"
+	}
+	if strings.HasSuffix(archiveName, ".zip") {
+		return extractZip(archiveName, data, want)
+	}
+	return extractTarGz(archiveName, data, want)
+}
+
+func extractTarGz(archiveName string, data []byte, want string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.WrapParse("gzip", archiveName, err)
+	}
+	defer func() { _ = gz.Close() }()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, &errors.NotFoundError{Resource: "archive member", ID: want}
+		}
+		if err != nil {
+			return nil, errors.WrapParse("tar", archiveName, err)
+		}
+		if header.Typeflag != tar.TypeReg || path.Base(header.Name) != want {
+			continue
+		}
+		return readLimited(archiveName, tr)
+	}
+}
+
+func extractZip(archiveName string, data []byte, want string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.WrapParse("zip", archiveName, err)
+	}
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() || path.Base(file.Name) != want {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, errors.WrapParse("zip", archiveName, err)
+		}
+		defer func() { _ = rc.Close() }()
+		return readLimited(archiveName, rc)
+	}
+	return nil, &errors.NotFoundError{Resource: "archive member", ID: want}
+}
+
+func readLimited(archiveName string, r io.Reader) ([]byte, error) {
+	binary, err := io.ReadAll(io.LimitReader(r, maxArchiveBytes+1))
+	if err != nil {
+		return nil, errors.WrapIO("read", archiveName, err)
+	}
+	if len(binary) > maxArchiveBytes {
+		return nil, &errors.ValidationError{Field: "binary", Value: archiveName, Message: fmt.Sprintf("exceeds %d bytes", maxArchiveBytes)}
+	}
+	return binary, nil
+}
+
+// ManagedBy reports the package manager that owns executable, if any. Binaries
+// installed by a package manager must be upgraded through it so its metadata
+// stays correct.
+func ManagedBy(executable string) (manager, upgrade string, managed bool) {
+	normalized := strings.ToLower(strings.ReplaceAll(executable, `\`, "/"))
+	switch {
+	case strings.Contains(normalized, "/cellar/"), strings.Contains(normalized, "/homebrew/"), strings.Contains(normalized, "/linuxbrew/"):
+		return "Homebrew", "brew upgrade starmap", true
+	case strings.Contains(normalized, "/scoop/apps/"):
+		return "Scoop", "scoop update starmap", true
+	case strings.Contains(normalized, "/go/pkg/mod/"):
+		return "go run", "go install github.com/agentstation/starmap/cmd/starmap@latest", true
+	default:
+		return "", "", false
+	}
+}
+
+// Replace atomically replaces the executable at target with binary. The new
+// file is written beside target so the final rename never crosses devices.
+func Replace(target string, binary []byte) error {
+	resolved, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		return errors.WrapIO("resolve", target, err)
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return errors.WrapIO("stat", resolved, err)
+	}
+	dir := filepath.Dir(resolved)
+	tmp, err := os.CreateTemp(dir, ".starmap-update-*")
+	if err != nil {
+		return errors.WrapIO("create", dir, err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+	if _, err := tmp.Write(binary); err != nil {
+		_ = tmp.Close()
+		return errors.WrapIO("write", tmpName, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return errors.WrapIO("sync", tmpName, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.WrapIO("close", tmpName, err)
+	}
+	if err := os.Chmod(tmpName, info.Mode().Perm()|0o111); err != nil { //nolint:gosec // executables must be executable.
+		return errors.WrapIO("chmod", tmpName, err)
+	}
+
+	if runtime.GOOS != "windows" {
+		if err := os.Rename(tmpName, resolved); err != nil {
+			return errors.WrapIO("rename", tmpName, err)
+		}
+		return nil
+	}
+	// Windows cannot rename over a running executable, but it can rename the
+	// running executable aside first.
+	old := resolved + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(resolved, old); err != nil {
+		return errors.WrapIO("rename", resolved, err)
+	}
+	if err := os.Rename(tmpName, resolved); err != nil {
+		_ = os.Rename(old, resolved)
+		return errors.WrapIO("rename", tmpName, err)
+	}
+	_ = os.Remove(old)
+	return nil
+}
@@ -0,0 +1,190 @@
+// Package selfupdate replaces a directly installed starmap binary with the
+// latest GitHub release for a channel.
+//
+// Every download is checked against the release checksums.txt and, unless a
+// caller opts out, against the GitHub build provenance signed by the release
+// workflow before the running executable is atomically replaced.
+package selfupdate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/agentstation/starmap/internal/attestation"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const (
+	// DefaultAPIURL is the GitHub REST API root used for release discovery.
+	DefaultAPIURL = "https://api.github.com"
+	// ChecksumsAsset is the release asset listing SHA-256 digests of every archive.
+	ChecksumsAsset = "checksums.txt"
+
+	maxReleaseResponseBytes = 8 << 20
+	maxArchiveBytes         = 256 << 20
+)
+
+// Channel selects which releases are eligible for an update.
+type Channel string
+
+const (
+	// ChannelStable considers only full releases.
+	ChannelStable Channel = "stable"
+	// ChannelNightly also considers prereleases such as release candidates.
+	ChannelNightly Channel = "nightly"
+)
+
+// ParseChannel validates a channel name.
+func ParseChannel(value string) (Channel, error) {
+	switch channel := Channel(strings.ToLower(strings.TrimSpace(value))); channel {
+	case ChannelStable, ChannelNightly:
+		return channel, nil
+	case "":
+		return ChannelStable, nil
+	default:
+		return "", &errors.ValidationError{Field: "channel", Value: value, Message: "must be stable or nightly"}
+	}
+}
+
+// Asset is a downloadable file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Release is a published application release.
+type Release struct {
+	Tag        string  `json:"tag_name"`
+	Draft      bool    `json:"draft"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+	Version    Version `json:"-"`
+}
+
+// Asset returns the named release asset.
+func (r Release) Asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Client discovers and downloads Starmap releases.
+type Client struct {
+	apiURL     string
+	httpClient *http.Client
+	verify     attestation.Verifier
+}
+
+// NewClient returns a release client for apiURL. A nil httpClient uses a
+// client with a conservative timeout. A nil verify skips provenance checks;
+// checksums are always verified.
+func NewClient(apiURL string, httpClient *http.Client, verify attestation.Verifier) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Minute}
+	}
+	return &Client{apiURL: strings.TrimRight(apiURL, "/"), httpClient: httpClient, verify: verify}
+}
+
+// Latest returns the newest release eligible for channel. Catalog payload
+// prereleases and other tags that are not application versions are ignored.
+func (c *Client) Latest(ctx context.Context, channel Channel) (Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=100", c.apiURL, attestation.Repository)
+	body, err := c.get(ctx, url, maxReleaseResponseBytes)
+	if err != nil {
+		return Release{}, err
+	}
+	var releases []Release
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return Release{}, errors.WrapParse("json", "GitHub releases", err)
+	}
+
+	var latest Release
+	found := false
+	for _, release := range releases {
+		if release.Draft || !strings.HasPrefix(release.Tag, "v") {
+			continue
+		}
+		version, ok := ParseVersion(release.Tag)
+		if !ok {
+			continue
+		}
+		if channel == ChannelStable && (release.Prerelease || version.Prerelease != "") {
+			continue
+		}
+		release.Version = version
+		if !found || version.Compare(latest.Version) > 0 {
+			latest, found = release, true
+		}
+	}
+	if !found {
+		return Release{}, &errors.NotFoundError{Resource: "release", ID: string(channel)}
+	}
+	return latest, nil
+}
+
+// Download fetches the archive for goos/goarch from release and verifies its
+// checksum and signed provenance.
+func (c *Client) Download(ctx context.Context, release Release, goos, goarch string) (string, []byte, error) {
+	name := ArchiveName(release.Version, goos, goarch)
+	asset, ok := release.Asset(name)
+	if !ok {
+		return "", nil, &errors.NotFoundError{Resource: "release asset", ID: name}
+	}
+	checksums, ok := release.Asset(ChecksumsAsset)
+	if !ok {
+		return "", nil, &errors.NotFoundError{Resource: "release asset", ID: ChecksumsAsset}
+	}
+
+	sums, err := c.get(ctx, checksums.URL, maxReleaseResponseBytes)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := c.get(ctx, asset.URL, maxArchiveBytes)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := VerifyChecksum(sums, name, data); err != nil {
+		return "", nil, err
+	}
+	if c.verify != nil {
+		if err := c.verify(ctx, name, data); err != nil {
+			return "", nil, &errors.ProcessError{Operation: "verify release signature", Command: "self-update", Err: err}
+		}
+	}
+	return name, data, nil
+}
+
+func (c *Client) get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.WrapResource("create", "request", url, err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, &errors.APIError{Provider: "github", Endpoint: url, Message: "request failed", Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &errors.APIError{Provider: "github", Endpoint: url, StatusCode: resp.StatusCode, Message: resp.Status}
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, errors.WrapIO("read", url, err)
+	}
+	if int64(len(body)) > limit {
+		return nil, &errors.ValidationError{Field: "response", Value: url, Message: fmt.Sprintf("exceeds %d bytes", limit)}
+	}
+	return body, nil
+}
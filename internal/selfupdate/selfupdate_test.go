@@ -0,0 +1,189 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		left, right string
+		want        int
+	}{
+		{"v1.2.3", "1.2.3", 0},
+		{"v1.10.0", "v1.9.9", 1},
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		{"v1.0.0-rc.2", "v1.0.0-rc.10", -1},
+		{"v1.0.0-rc.1", "v1.0.0-beta.9", 1},
+		{"v0.2.0", "v0.10.0", -1},
+	}
+	for _, test := range tests {
+		left, ok := ParseVersion(test.left)
+		if !ok {
+			t.Fatalf("ParseVersion(%q) failed", test.left)
+		}
+		right, ok := ParseVersion(test.right)
+		if !ok {
+			t.Fatalf("ParseVersion(%q) failed", test.right)
+		}
+		if got := left.Compare(right); got != test.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", test.left, test.right, got, test.want)
+		}
+	}
+	for _, invalid := range []string{"dev", "", "v1.2", "catalog-payload-1", "v1.2.3-"} {
+		if _, ok := ParseVersion(invalid); ok {
+			t.Errorf("ParseVersion(%q) succeeded, want failure", invalid)
+		}
+	}
+}
+
+func TestArchiveName(t *testing.T) {
+	version, _ := ParseVersion("v0.3.1")
+	if got := ArchiveName(version, "linux", "amd64"); got != "starmap_0.3.1_linux_x86_64.tar.gz" {
+		t.Errorf("linux/amd64 = %q", got)
+	}
+	if got := ArchiveName(version, "windows", "arm64"); got != "starmap_0.3.1_windows_arm64.zip" {
+		t.Errorf("windows/arm64 = %q", got)
+	}
+}
+
+func TestLatestSelectsByChannel(t *testing.T) {
+	releases := []Release{
+		{Tag: "catalog-payload-20261017", Prerelease: true},
+		{Tag: "v0.4.0-rc.1", Prerelease: true},
+		{Tag: "v0.5.0", Draft: true},
+		{Tag: "v0.3.1"},
+		{Tag: "v0.3.0"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(releases)
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, server.Client(), nil)
+
+	for channel, want := range map[Channel]string{ChannelStable: "v0.3.1", ChannelNightly: "v0.4.0-rc.1"} {
+		release, err := client.Latest(context.Background(), channel)
+		if err != nil {
+			t.Fatalf("Latest(%s): %v", channel, err)
+		}
+		if release.Tag != want {
+			t.Errorf("Latest(%s) = %s, want %s", channel, release.Tag, want)
+		}
+	}
+}
+
+func TestDownloadVerifiesChecksumAndSignature(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho starmap\n")
+	archive := tarGz(t, "starmap", binary)
+	version, _ := ParseVersion("v0.3.1")
+	name := ArchiveName(version, "linux", "amd64")
+	sum := sha256.Sum256(archive)
+	checksums := hex.EncodeToString(sum[:]) + "  " + name + "\n"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/archive", func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write(archive) })
+	mux.HandleFunc("/checksums", func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte(checksums)) })
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	release := Release{Tag: "v0.3.1", Version: version, Assets: []Asset{
+		{Name: name, URL: server.URL + "/archive"},
+		{Name: ChecksumsAsset, URL: server.URL + "/checksums"},
+	}}
+
+	var verified string
+	client := NewClient(server.URL, server.Client(), func(_ context.Context, name string, _ []byte) error {
+		verified = name
+		return nil
+	})
+	gotName, data, err := client.Download(context.Background(), release, "linux", "amd64")
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if gotName != name || verified != name {
+		t.Fatalf("downloaded %q, verified %q, want %q", gotName, verified, name)
+	}
+	extracted, err := ExtractBinary(gotName, data, "linux")
+	if err != nil || !bytes.Equal(extracted, binary) {
+		t.Fatalf("ExtractBinary = %q, %v", extracted, err)
+	}
+
+	signatureErr := stderrors.New("untrusted signer")
+	client = NewClient(server.URL, server.Client(), func(context.Context, string, []byte) error { return signatureErr })
+	if _, _, err := client.Download(context.Background(), release, "linux", "amd64"); !stderrors.Is(err, signatureErr) {
+		t.Fatalf("Download with bad signature error = %v, want %v", err, signatureErr)
+	}
+
+	var validationErr *pkgerrors.ValidationError
+	if err := VerifyChecksum([]byte(checksums), name, []byte("tampered")); !stderrors.As(err, &validationErr) {
+		t.Fatalf("VerifyChecksum(tampered) error = %v, want *errors.ValidationError", err)
+	}
+}
+
+func TestReplaceSwapsExecutable(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "starmap")
+	if err := os.WriteFile(target, []byte("old"), 0o755); err != nil { //nolint:gosec // test executable.
+		t.Fatal(err)
+	}
+	if err := Replace(target, []byte("new")); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	data, err := os.ReadFile(target)
+	if err != nil || string(data) != "new" {
+		t.Fatalf("target = %q, %v; want new", data, err)
+	}
+	info, err := os.Stat(target)
+	if err != nil || info.Mode().Perm()&0o111 == 0 {
+		t.Fatalf("replaced binary is not executable: %v, %v", info.Mode(), err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(target))
+	if len(entries) != 1 {
+		t.Fatalf("temporary files left behind: %v", entries)
+	}
+}
+
+func TestManagedBy(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/opt/homebrew/bin/starmap":                           true,
+		"/usr/local/Cellar/starmap/0.3.1/bin/starmap":         true,
+		`C:\Users\dev\scoop\apps\starmap\current\starmap.exe`: true,
+		"/usr/local/bin/starmap":                              false,
+		"/home/dev/.local/bin/starmap":                        false,
+	} {
+		if _, _, managed := ManagedBy(path); managed != want {
+			t.Errorf("ManagedBy(%q) = %v, want %v", path, managed, want)
+		}
+	}
+}
+
+func tarGz(t *testing.T, name string, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
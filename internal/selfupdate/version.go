@@ -0,0 +1,103 @@
+package selfupdate
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version such as v1.4.0 or v1.5.0-nightly.20261017.
+type Version struct {
+	Major, Minor, Patch int
+	Prerelease          string
+}
+
+// ParseVersion parses a semantic version with an optional leading "v". It
+// reports false for development builds and other non-release versions.
+func ParseVersion(value string) (Version, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if build := strings.IndexByte(value, '+'); build >= 0 {
+		value = value[:build]
+	}
+	var version Version
+	if pre := strings.IndexByte(value, '-'); pre >= 0 {
+		version.Prerelease = value[pre+1:]
+		value = value[:pre]
+		if version.Prerelease == "" {
+			return Version{}, false
+		}
+	}
+	parts := strings.Split(value, ".")
+	if len(parts) != 3 {
+		return Version{}, false
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return Version{}, false
+		}
+		numbers[i] = number
+	}
+	version.Major, version.Minor, version.Patch = numbers[0], numbers[1], numbers[2]
+	return version, true
+}
+
+// String returns the version with a leading "v".
+func (v Version) String() string {
+	s := "v" + strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor) + "." + strconv.Itoa(v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare returns -1, 0, or 1 as v sorts before, equal to, or after other
+// under semantic versioning precedence.
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			return compareInts(pair[0], pair[1])
+		}
+	}
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	}
+	left, right := strings.Split(v.Prerelease, "."), strings.Split(other.Prerelease, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		if result := compareIdentifiers(left[i], right[i]); result != 0 {
+			return result
+		}
+	}
+	return compareInts(len(left), len(right))
+}
+
+func compareIdentifiers(left, right string) int {
+	leftNumber, leftErr := strconv.Atoi(left)
+	rightNumber, rightErr := strconv.Atoi(right)
+	switch {
+	case leftErr == nil && rightErr == nil:
+		return compareInts(leftNumber, rightNumber)
+	case leftErr == nil:
+		return -1
+	case rightErr == nil:
+		return 1
+	default:
+		return strings.Compare(left, right)
+	}
+}
+
+func compareInts(left, right int) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	default:
+		return 0
+	}
+}
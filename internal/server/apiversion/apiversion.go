@@ -0,0 +1,109 @@
+// Package apiversion provides HTTP API version routing and per-version
+// response shaping. Each version owns a Shaper that converts the current
+// catalog response into the contract that version promised, so the catalog
+// schema can evolve without breaking existing HTTP consumers.
+package apiversion
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/agentstation/starmap/internal/server/response"
+)
+
+// Version identifies a published HTTP API contract.
+type Version string
+
+// Supported API versions.
+const (
+	// V1 is the original API contract. Its response shape is frozen.
+	V1 Version = "v1"
+	// V2 extends V1 with version metadata in the success envelope.
+	V2 Version = "v2"
+
+	// Default is used when a request did not pass through a version route.
+	Default = V1
+	// Latest is the newest published version.
+	Latest = V2
+)
+
+// HeaderName is the response header reporting the API version that served
+// the request.
+const HeaderName = "Starmap-API-Version"
+
+// Shaper converts a success response into the shape promised by a version.
+// Shapers must not mutate data shared with the response cache.
+type Shaper func(response.Response) response.Response
+
+// shapers holds one response shaper per supported version.
+var shapers = map[Version]Shaper{
+	V1: func(resp response.Response) response.Response { return resp },
+	V2: func(resp response.Response) response.Response {
+		resp.APIVersion = string(V2)
+		return resp
+	},
+}
+
+// Supported returns all published versions, oldest first.
+func Supported() []Version {
+	return []Version{V1, V2}
+}
+
+// Prefixes returns the mount path for every supported version derived from
+// the configured V1 path prefix. A prefix ending in "/v1" has that segment
+// replaced ("/api/v1" mounts "/api/v2"); any other prefix serves V1 directly
+// and nests later versions beneath it.
+func Prefixes(v1Prefix string) map[Version]string {
+	prefixes := make(map[Version]string, len(shapers))
+	base, versioned := strings.CutSuffix(v1Prefix, "/"+string(V1))
+	for _, version := range Supported() {
+		switch {
+		case version == V1:
+			prefixes[version] = v1Prefix
+		case versioned:
+			prefixes[version] = base + "/" + string(version)
+		default:
+			prefixes[version] = v1Prefix + "/" + string(version)
+		}
+	}
+	return prefixes
+}
+
+type contextKey struct{}
+
+// WithVersion returns a context carrying the API version of a request.
+func WithVersion(ctx context.Context, version Version) context.Context {
+	return context.WithValue(ctx, contextKey{}, version)
+}
+
+// FromContext returns the API version of a request, or Default.
+func FromContext(ctx context.Context) Version {
+	if version, ok := ctx.Value(contextKey{}).(Version); ok {
+		return version
+	}
+	return Default
+}
+
+// Middleware tags requests with an API version and reports it to clients.
+func Middleware(version Version) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(HeaderName, string(version))
+			next.ServeHTTP(w, r.WithContext(WithVersion(r.Context(), version)))
+		})
+	}
+}
+
+// Shape applies the request version's shaper to a success response.
+func Shape(ctx context.Context, resp response.Response) response.Response {
+	if shaper, ok := shapers[FromContext(ctx)]; ok {
+		return shaper(resp)
+	}
+	return resp
+}
+
+// OK writes a 200 success response shaped for the request's API version.
+func OK(w http.ResponseWriter, r *http.Request, data any) {
+	response.JSON(w, http.StatusOK, Shape(r.Context(), response.Success(data)))
+}
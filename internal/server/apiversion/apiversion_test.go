@@ -0,0 +1,71 @@
+package apiversion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPrefixes(t *testing.T) {
+	for _, test := range []struct {
+		v1Prefix string
+		want     map[Version]string
+	}{
+		{v1Prefix: "/api/v1", want: map[Version]string{V1: "/api/v1", V2: "/api/v2"}},
+		{v1Prefix: "/starmap", want: map[Version]string{V1: "/starmap", V2: "/starmap/v2"}},
+	} {
+		got := Prefixes(test.v1Prefix)
+		for version, want := range test.want {
+			if got[version] != want {
+				t.Fatalf("Prefixes(%q)[%s] = %q, want %q", test.v1Prefix, version, got[version], want)
+			}
+		}
+	}
+}
+
+func TestShapersCoverSupportedVersions(t *testing.T) {
+	for _, version := range Supported() {
+		if _, ok := shapers[version]; !ok {
+			t.Fatalf("version %s has no response shaper", version)
+		}
+	}
+	if FromContext(context.Background()) != Default {
+		t.Fatalf("FromContext without version = %s, want %s", FromContext(context.Background()), Default)
+	}
+}
+
+func TestOKShapesEnvelopePerVersion(t *testing.T) {
+	for _, test := range []struct {
+		version Version
+		want    string
+	}{
+		{version: V1, want: ""},
+		{version: V2, want: "v2"},
+	} {
+		handler := Middleware(test.version)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			OK(w, r, map[string]string{"id": "model"})
+		}))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if got := recorder.Header().Get(HeaderName); got != string(test.version) {
+			t.Fatalf("%s header = %q", test.version, got)
+		}
+		var body map[string]any
+		if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode %s: %v", test.version, err)
+		}
+		got, present := body["api_version"]
+		if test.want == "" && present {
+			t.Fatalf("v1 envelope changed: %s", recorder.Body.String())
+		}
+		if test.want != "" && got != test.want {
+			t.Fatalf("%s api_version = %v", test.version, got)
+		}
+		if body["data"] == nil {
+			t.Fatalf("%s envelope lost data: %s", test.version, recorder.Body.String())
+		}
+	}
+}
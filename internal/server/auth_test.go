@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+func TestAuthLeavesEveryVersionsHealthRoutesPublic(t *testing.T) {
+	t.Setenv("API_KEY", "secret")
+	client, err := starmap.New(starmap.WithCatalogStore(catalogstore.NewMemory()))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{
+		PathPrefix: "/api/v1", CacheTTL: time.Minute, AuthEnabled: true, AuthHeader: "X-API-Key",
+	})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "/api/v1/health", want: http.StatusOK},
+		{path: "/api/v1/ready", want: http.StatusOK},
+		{path: "/api/v2/health", want: http.StatusOK},
+		{path: "/api/v2/ready", want: http.StatusOK},
+		{path: "/api/v2/openapi.json", want: http.StatusOK},
+		{path: "/api/v2/models", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		resp, err := http.Get(httpServer.URL + tt.path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", tt.path, err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("GET %s without a key = %d, want %d", tt.path, resp.StatusCode, tt.want)
+		}
+	}
+}
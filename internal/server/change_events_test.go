@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/internal/server/events"
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+	"github.com/agentstation/starmap/pkg/catalogremote"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+func TestWebSocketReceivesTypedCatalogChangeEvents(t *testing.T) {
+	modelName := "Typed Model"
+	client, err := starmap.New(
+		starmap.WithCatalogStore(smallCatalogStore(t)),
+		starmap.WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			if err := candidate.SetProvider(catalogs.Provider{ID: "typed", Name: "Typed"}); err != nil {
+				return nil, err
+			}
+			if err := candidate.SetProviderModel("typed", catalogs.Model{ID: "typed-model", Name: modelName}); err != nil {
+				return nil, err
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{PathPrefix: "/api/v1"})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	server.Start()
+	t.Cleanup(func() { _ = server.Shutdown(context.Background()) })
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/api/v1/updates/ws"
+	connection, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		t.Fatalf("Connect WebSocket: %v", err)
+	}
+	t.Cleanup(func() { _ = connection.Close() })
+	deadline := time.Now().Add(time.Second)
+	for server.WSHub().ClientCount() != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	type message struct {
+		Type string `json:"type"`
+		Data struct {
+			ProviderID string                      `json:"provider_id"`
+			ModelID    string                      `json:"model_id"`
+			Changes    []catalogremote.FieldChange `json:"changes"`
+		} `json:"data"`
+	}
+	readUntil := func(eventType events.EventType) message {
+		t.Helper()
+		var received message
+		for received.Type != string(eventType) {
+			received = message{}
+			if err := connection.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+				t.Fatalf("SetReadDeadline: %v", err)
+			}
+			if err := connection.ReadJSON(&received); err != nil {
+				t.Fatalf("Read %s: %v", eventType, err)
+			}
+		}
+		return received
+	}
+
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := readUntil(events.ProviderAdded); got.Data.ProviderID != "typed" {
+		t.Fatalf("provider.added = %#v, want provider typed", got.Data)
+	}
+	if got := readUntil(events.ModelAdded); got.Data.ProviderID != "typed" || got.Data.ModelID != "typed-model" {
+		t.Fatalf("model.added = %#v, want typed/typed-model", got.Data)
+	}
+
+	modelName = "Renamed Model"
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	updated := readUntil(events.ModelUpdated)
+	if updated.Data.ModelID != "typed-model" || len(updated.Data.Changes) != 1 {
+		t.Fatalf("model.updated = %#v, want one field change", updated.Data)
+	}
+	if change := updated.Data.Changes[0]; change.Path != "name" || change.OldValue != "Typed Model" || change.NewValue != "Renamed Model" {
+		t.Fatalf("model.updated change = %#v, want name Typed Model -> Renamed Model", change)
+	}
+}
+
+// smallCatalogStore returns a store whose current generation is a one-provider
+// catalog, so updates under test do not reconcile the embedded catalog.
+func smallCatalogStore(t *testing.T) *catalogstore.Memory {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	if err := builder.SetProvider(catalogs.Provider{ID: "seed", Name: "Seed"}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	payload, err := catalogstore.EncodeCatalogPayload(catalog)
+	if err != nil {
+		t.Fatalf("EncodeCatalogPayload: %v", err)
+	}
+	generatedAt := time.Date(2026, time.July, 10, 0, 0, 0, 0, time.UTC)
+	descriptor := catalogs.DescribeCatalogPayload(payload)
+	generation := catalogstore.Generation{
+		Manifest: catalogs.GenerationManifest{
+			ManifestVersion: catalogs.CurrentGenerationManifestVersion,
+			SchemaVersion:   catalogs.CurrentCatalogSchemaVersion,
+			GenerationID:    "seed-generation",
+			GeneratedAt:     generatedAt,
+			Payload:         descriptor,
+			Validation: catalogs.GenerationValidationReport{
+				ValidatorVersion: "test/v1",
+				ValidatedAt:      generatedAt,
+				Status:           catalogs.GenerationValidationPassed,
+				Checks:           []catalogs.GenerationValidationCheck{{Name: "catalog", Status: catalogs.GenerationValidationCheckPassed}},
+			},
+			SyncRunID: "sync-seed",
+			SourceObservations: []catalogs.SourceObservationLink{{
+				Source:           catalogmeta.LocalCatalogID,
+				ObservationID:    "observation-seed",
+				ObservedAt:       generatedAt,
+				Revision:         catalogmeta.ObservationRevision{Kind: catalogmeta.ObservationRevisionKindContentDigest, Value: descriptor.Checksum},
+				Completeness:     catalogmeta.ObservationCompletenessComplete,
+				Status:           catalogmeta.ObservationStatusSucceeded,
+				EvidenceChecksum: descriptor.Checksum,
+			}},
+			Completeness: catalogs.GenerationCompletenessComplete,
+			ConsumerCompatibility: catalogs.ConsumerCompatibility{
+				MinSchemaVersion: catalogs.CurrentCatalogSchemaVersion,
+				MaxSchemaVersion: catalogs.CurrentCatalogSchemaVersion,
+			},
+		},
+		Payload: payload,
+	}
+	store := catalogstore.NewMemory()
+	if err := store.Commit(context.Background(), generation, ""); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	return store
+}
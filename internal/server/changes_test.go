@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/pkg/catalogremote"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+func TestChangesEndpointReturnsDeltaSinceGeneration(t *testing.T) {
+	models := map[string]*catalogs.Model{
+		"kept":    {ID: "kept", Name: "Kept"},
+		"retired": {ID: "retired", Name: "Retired"},
+	}
+	client, err := starmap.New(
+		starmap.WithCatalogStore(catalogstore.NewMemory()),
+		starmap.WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			provider := catalogs.Provider{ID: "acme", Name: "Acme", Models: map[string]*catalogs.Model{}}
+			for id, model := range models {
+				copied := *model
+				provider.Models[id] = &copied
+			}
+			if err := candidate.SetProvider(provider); err != nil {
+				return nil, err
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	since := client.CurrentGenerationID()
+
+	models["kept"] = &catalogs.Model{ID: "kept", Name: "Kept v2"}
+	models["fresh"] = &catalogs.Model{ID: "fresh", Name: "Fresh"}
+	delete(models, "retired")
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("second Update: %v", err)
+	}
+	current := client.CurrentGenerationID()
+	if current == since {
+		t.Fatal("second update did not publish a new generation")
+	}
+
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{PathPrefix: "/api/v1", CacheTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	get := func(query string) (*http.Response, catalogremote.Changes) {
+		t.Helper()
+		response, err := http.Get(httpServer.URL + "/api/v1/changes" + query) //nolint:noctx
+		if err != nil {
+			t.Fatalf("GET changes: %v", err)
+		}
+		defer func() { _ = response.Body.Close() }()
+		var body struct {
+			Data catalogremote.Changes `json:"data"`
+		}
+		if response.StatusCode == http.StatusOK {
+			if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+				t.Fatalf("decode changes: %v", err)
+			}
+		}
+		return response, body.Data
+	}
+
+	response, changes := get("?since=" + since)
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", response.StatusCode)
+	}
+	if changes.Since != since || changes.Current != current {
+		t.Fatalf("since/current = %q/%q, want %q/%q", changes.Since, changes.Current, since, current)
+	}
+	if len(changes.Models.Added) != 1 || changes.Models.Added[0].Model.ID != "fresh" || changes.Models.Added[0].ProviderID != "acme" {
+		t.Fatalf("added = %#v", changes.Models.Added)
+	}
+	if len(changes.Models.Updated) != 1 || changes.Models.Updated[0].Model.Name != "Kept v2" || len(changes.Models.Updated[0].Changes) == 0 {
+		t.Fatalf("updated = %#v", changes.Models.Updated)
+	}
+	if len(changes.Models.Removed) != 1 || changes.Models.Removed[0].Model.ID != "retired" {
+		t.Fatalf("removed = %#v", changes.Models.Removed)
+	}
+
+	if _, changes := get("?since=" + current); changes.Summary.TotalChanges != 0 {
+		t.Fatalf("changes since current = %#v, want none", changes.Summary)
+	}
+	if response, _ := get(""); response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("missing since status = %d, want 400", response.StatusCode)
+	}
+	if response, _ := get("?since=unknown"); response.StatusCode != http.StatusNotFound {
+		t.Fatalf("unknown since status = %d, want 404", response.StatusCode)
+	}
+}
@@ -1,12 +1,19 @@
 package server
 
-import "time"
+import (
+	"time"
+
+	"github.com/agentstation/starmap/internal/catalog/view"
+	"github.com/agentstation/starmap/internal/providers/quota"
+	"github.com/agentstation/starmap/internal/server/events/adapters"
+)
 
 // Config holds server configuration.
 type Config struct {
 	// Server settings
-	Host string
-	Port int
+	Host     string
+	Port     int
+	GRPCPort int // Port of the starmap.v1.CatalogService gRPC API (0 to disable)
 
 	// API settings
 	PathPrefix string
@@ -14,14 +21,29 @@ type Config struct {
 	// CORS settings
 	CORSEnabled bool
 	CORSOrigins []string
+	CORSMethods []string // Allowed request methods (empty for the middleware default)
+	CORSHeaders []string // Allowed request headers (empty for the middleware default)
+
+	// Browser hardening settings
+	SecurityHeaders bool          // Send nosniff, frame, referrer, and CSP headers
+	HSTSMaxAge      time.Duration // Strict-Transport-Security max-age (0 to omit)
+	MaxBodySize     int64         // Largest accepted request body in bytes (0 for no limit)
 
 	// Authentication settings
 	AuthEnabled bool
 	AuthHeader  string
 
+	// ReadOnly rejects write endpoints and sync triggers in middleware
+	ReadOnly bool
+
 	// Performance settings
-	RateLimit int // Requests per minute per IP (0 to disable)
-	CacheTTL  time.Duration
+	RateLimit      int // Requests per minute per IP (0 to disable)
+	TokenRateLimit int // Requests per minute per API key (0 to disable)
+	CacheTTL       time.Duration
+
+	// Compression settings (gzip/zstd negotiated from Accept-Encoding)
+	CompressionEnabled bool
+	CompressionMinSize int // Smallest response body in bytes worth compressing
 
 	// HTTP timeouts
 	ReadTimeout  time.Duration
@@ -33,6 +55,19 @@ type Config struct {
 
 	// Features
 	MetricsEnabled bool
+	QuotaEnabled   bool // Serve provider spend and usage at /quota
+	TestEvents     bool // Serve POST /admin/test-event to publish synthetic change events
+
+	// Notification settings (templated webhook and Slack payloads)
+	Webhooks []adapters.WebhookConfig
+
+	// Views are named sub-catalogs served at /views/{name}/models
+	Views []view.Definition
+
+	// Budget alert settings (monthly provider spend budgets checked every
+	// BudgetInterval; alerts are published as budget.threshold events)
+	Budgets        []quota.Budget
+	BudgetInterval time.Duration
 }
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -43,14 +78,19 @@ func DefaultConfig() Config {
 		PathPrefix:          "/api/v1",
 		CORSEnabled:         false,
 		CORSOrigins:         []string{},
+		SecurityHeaders:     true,
+		MaxBodySize:         1 << 20,
 		AuthEnabled:         false,
 		AuthHeader:          "X-API-Key",
 		RateLimit:           100,
 		CacheTTL:            5 * time.Minute,
+		CompressionEnabled:  true,
+		CompressionMinSize:  1024,
 		ReadTimeout:         10 * time.Second,
 		WriteTimeout:        10 * time.Second,
 		IdleTimeout:         120 * time.Second,
 		ShutdownGracePeriod: 100 * time.Millisecond,
 		MetricsEnabled:      true,
+		BudgetInterval:      15 * time.Minute,
 	}
 }
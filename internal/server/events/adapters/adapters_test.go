@@ -1,6 +1,10 @@
 package adapters
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,6 +13,7 @@ import (
 	"github.com/agentstation/starmap/internal/server/events"
 	"github.com/agentstation/starmap/internal/server/sse"
 	ws "github.com/agentstation/starmap/internal/server/websocket"
+	"github.com/agentstation/starmap/pkg/differ"
 )
 
 // TestNewSSESubscriber tests SSE subscriber creation.
@@ -349,3 +354,98 @@ func TestAdapters_ConcurrentSend(t *testing.T) {
 		}
 	})
 }
+
+// TestWebhookSubscriber_RendersChangesetTemplate tests templated webhook delivery.
+func TestWebhookSubscriber_RendersChangesetTemplate(t *testing.T) {
+	var received []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		received = append(received, body)
+	}))
+	defer server.Close()
+
+	sub, err := NewWebhookSubscriber(WebhookConfig{
+		URL:      server.URL,
+		Template: `{"alert": {{ json (printf "%s %s: +%d models" (upper "starmap") .GenerationID .Summary.ModelsAdded) }}}`,
+	}, server.Client())
+	if err != nil {
+		t.Fatalf("NewWebhookSubscriber: %v", err)
+	}
+	changeset := &differ.Changeset{Summary: differ.ChangesetSummary{ModelsAdded: 2}}
+
+	if err := sub.Send(events.Event{Type: events.ModelAdded, Data: map[string]any{"model": "gpt-4"}}); err != nil {
+		t.Fatalf("Send(model.added): %v", err)
+	}
+	event := events.Event{Type: events.CatalogPublished, Data: map[string]any{"generation_id": "gen-1"}, Changeset: changeset}
+	if err := sub.Send(event); err != nil {
+		t.Fatalf("Send(catalog.published): %v", err)
+	}
+	if len(received) != 1 || received[0]["alert"] != "STARMAP gen-1: +2 models" {
+		t.Fatalf("received = %v, want one catalog.published alert", received)
+	}
+}
+
+// TestWebhookSubscriber_SlackFormat tests the built-in Slack payload.
+func TestWebhookSubscriber_SlackFormat(t *testing.T) {
+	sub, err := NewWebhookSubscriber(WebhookConfig{URL: "http://example.invalid", Format: WebhookFormatSlack}, nil)
+	if err != nil {
+		t.Fatalf("NewWebhookSubscriber: %v", err)
+	}
+	body, err := sub.Render(events.Event{
+		Type:      events.CatalogPublished,
+		Data:      map[string]any{"generation_id": "gen-2"},
+		Changeset: &differ.Changeset{Summary: differ.ChangesetSummary{ModelsUpdated: 3}},
+	})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	var payload struct{ Text string }
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("slack payload is not JSON: %v\n%s", err, body)
+	}
+	if !strings.Contains(payload.Text, "gen-2") || !strings.Contains(payload.Text, "3 updated") {
+		t.Fatalf("slack text = %q, want generation and changeset summary", payload.Text)
+	}
+}
+
+// TestWebhookSubscriber_SlackFormatMessage tests that event messages reach Slack.
+func TestWebhookSubscriber_SlackFormatMessage(t *testing.T) {
+	sub, err := NewWebhookSubscriber(WebhookConfig{URL: "http://example.invalid", Format: WebhookFormatSlack}, nil)
+	if err != nil {
+		t.Fatalf("NewWebhookSubscriber: %v", err)
+	}
+	body, err := sub.Render(events.Event{
+		Type: events.BudgetThreshold,
+		Data: map[string]any{"message": "openai spend is 80% of budget"},
+	})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	var payload struct{ Text string }
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("slack payload is not JSON: %v\n%s", err, body)
+	}
+	if !strings.Contains(payload.Text, "budget.threshold") || !strings.Contains(payload.Text, "80% of budget") {
+		t.Fatalf("slack text = %q, want event type and message", payload.Text)
+	}
+}
+
+// TestWebhookSubscriber_RejectsInvalidTemplates tests template validation.
+func TestWebhookSubscriber_RejectsInvalidTemplates(t *testing.T) {
+	if _, err := NewWebhookSubscriber(WebhookConfig{URL: "http://example.invalid", Template: "{{ .Type"}, nil); err == nil {
+		t.Fatal("NewWebhookSubscriber accepted an unparseable template")
+	}
+	if _, err := NewWebhookSubscriber(WebhookConfig{URL: "http://example.invalid", Format: "teams"}, nil); err == nil {
+		t.Fatal("NewWebhookSubscriber accepted an unknown format")
+	}
+	sub, err := NewWebhookSubscriber(WebhookConfig{URL: "http://example.invalid", Template: "not json {{ .Type }}"}, nil)
+	if err != nil {
+		t.Fatalf("NewWebhookSubscriber: %v", err)
+	}
+	if _, err := sub.Render(events.Event{Type: events.CatalogPublished}); err == nil {
+		t.Fatal("Render accepted a payload that is not JSON")
+	}
+}
@@ -17,13 +17,16 @@ func NewSSESubscriber(broadcaster *sse.Broadcaster) *SSESubscriber {
 	return &SSESubscriber{broadcaster: broadcaster}
 }
 
-// Send delivers an event to all SSE clients.
+// Send delivers an event to all SSE clients. It returns
+// events.ErrBackpressure when the broadcaster cannot accept the event.
 func (s *SSESubscriber) Send(event events.Event) error {
-	s.broadcaster.Broadcast(sse.Event{
+	if !s.broadcaster.Broadcast(sse.Event{
 		Event: string(event.Type),
 		ID:    fmt.Sprintf("%d", event.Timestamp.Unix()),
 		Data:  event.Data,
-	})
+	}) {
+		return events.ErrBackpressure
+	}
 	return nil
 }
 
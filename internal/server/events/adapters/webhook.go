@@ -0,0 +1,199 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/agentstation/starmap/internal/server/events"
+	"github.com/agentstation/starmap/pkg/differ"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// WebhookFormat selects a built-in notification payload template.
+type WebhookFormat string
+
+const (
+	// WebhookFormatJSON posts the event, its data, and the changeset summary.
+	WebhookFormatJSON WebhookFormat = "json"
+	// WebhookFormatSlack posts a Slack incoming-webhook message.
+	WebhookFormatSlack WebhookFormat = "slack"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// builtinTemplates are the payloads used when no custom template is given.
+var builtinTemplates = map[WebhookFormat]string{
+	WebhookFormatJSON: `{"type": {{ json .Type }}, "timestamp": {{ json .Timestamp }}, "data": {{ json .Data }}` +
+		`{{ with .Changeset }}, "summary": {{ json .Summary }}{{ end }}}`,
+	WebhookFormatSlack: `{{- define "text" -}}
+*Starmap {{ .Type }}*{{ with .GenerationID }} generation ` + "`{{ . }}`" + `{{ end }}
+{{- with .Message }}
+{{ . }}
+{{- end }}
+{{- with .Changeset }}
+Models: {{ .Summary.ModelsAdded }} added, {{ .Summary.ModelsUpdated }} updated, {{ .Summary.ModelsRemoved }} removed
+Providers: {{ .Summary.ProvidersAdded }} added, {{ .Summary.ProvidersUpdated }} updated, {{ .Summary.ProvidersRemoved }} removed
+{{- end }}
+{{- end -}}
+{"text": {{ json (include "text" .) }}}`,
+}
+
+// WebhookConfig configures one notification endpoint.
+type WebhookConfig struct {
+	URL string
+	// Format selects a built-in payload when Template is empty.
+	Format WebhookFormat
+	// Template is a Go text/template that renders the JSON request body from a
+	// Notification.
+	Template string
+	// Events limits delivery to these event types. Empty means
+	// catalog.published only.
+	Events []events.EventType
+}
+
+// Notification is the data available to webhook templates.
+type Notification struct {
+	Type         events.EventType
+	Timestamp    time.Time
+	GenerationID string
+	// Message is a one-line description, set for events such as
+	// budget.threshold that carry one.
+	Message string
+	Data    any
+	// Changeset is set for catalog.published events that replaced a previous
+	// catalog; Summary is its summary or zero.
+	Changeset *differ.Changeset
+	Summary   differ.ChangesetSummary
+}
+
+// WebhookSubscriber posts templated notifications to an HTTP endpoint.
+type WebhookSubscriber struct {
+	url      string
+	template *template.Template
+	events   map[events.EventType]bool
+	client   *http.Client
+}
+
+// NewWebhookSubscriber parses cfg's template and returns a subscriber. A nil
+// client uses one with a short timeout.
+func NewWebhookSubscriber(cfg WebhookConfig, client *http.Client) (*WebhookSubscriber, error) {
+	if cfg.URL == "" {
+		return nil, &errors.ValidationError{Field: "webhook.url", Message: "is required"}
+	}
+	text := cfg.Template
+	if text == "" {
+		format := cfg.Format
+		if format == "" {
+			format = WebhookFormatJSON
+		}
+		builtin, ok := builtinTemplates[format]
+		if !ok {
+			return nil, &errors.ValidationError{Field: "webhook.format", Value: format, Message: "must be json or slack"}
+		}
+		text = builtin
+	}
+	tmpl, err := ParseNotificationTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+	selected := cfg.Events
+	if len(selected) == 0 {
+		selected = []events.EventType{events.CatalogPublished}
+	}
+	filter := make(map[events.EventType]bool, len(selected))
+	for _, eventType := range selected {
+		filter[eventType] = true
+	}
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	return &WebhookSubscriber{url: cfg.URL, template: tmpl, events: filter, client: client}, nil
+}
+
+// ParseNotificationTemplate parses a notification payload template. Besides
+// the standard functions, templates can call json (encode a value as JSON),
+// include (render a named template to a string), join, lower, and upper.
+func ParseNotificationTemplate(text string) (*template.Template, error) {
+	tmpl := template.New("notification")
+	tmpl.Funcs(template.FuncMap{
+		"json": func(value any) (string, error) {
+			data, err := json.Marshal(value)
+			return string(data), err
+		},
+		"include": func(name string, data any) (string, error) {
+			var buf bytes.Buffer
+			err := tmpl.ExecuteTemplate(&buf, name, data)
+			return buf.String(), err
+		},
+		"join":  strings.Join,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+	})
+	if _, err := tmpl.Parse(text); err != nil {
+		return nil, errors.WrapParse("template", "notification template", err)
+	}
+	return tmpl, nil
+}
+
+// Render executes the template for event and returns the JSON request body.
+func (w *WebhookSubscriber) Render(event events.Event) ([]byte, error) {
+	notification := Notification{
+		Type:      event.Type,
+		Timestamp: event.Timestamp,
+		Data:      event.Data,
+		Changeset: event.Changeset,
+	}
+	if data, ok := event.Data.(map[string]any); ok {
+		notification.GenerationID, _ = data["generation_id"].(string)
+		notification.Message, _ = data["message"].(string)
+	}
+	if event.Changeset != nil {
+		notification.Summary = event.Changeset.Summary
+	}
+	var buf bytes.Buffer
+	if err := w.template.Execute(&buf, notification); err != nil {
+		return nil, errors.WrapResource("render", "notification template", string(event.Type), err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, &errors.ValidationError{Field: "notification template", Value: string(event.Type), Message: "did not render valid JSON"}
+	}
+	return buf.Bytes(), nil
+}
+
+// Send renders and posts event when its type is selected.
+func (w *WebhookSubscriber) Send(event events.Event) error {
+	if !w.events[event.Type] {
+		return nil
+	}
+	body, err := w.Render(event)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.WrapResource("create", "webhook request", w.url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return &errors.APIError{Provider: "webhook", Endpoint: w.url, Message: "request failed", Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &errors.APIError{Provider: "webhook", Endpoint: w.url, StatusCode: resp.StatusCode, Message: resp.Status}
+	}
+	return nil
+}
+
+// Close releases idle connections.
+func (w *WebhookSubscriber) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
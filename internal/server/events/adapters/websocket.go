@@ -16,13 +16,16 @@ func NewWebSocketSubscriber(hub *ws.Hub) *WebSocketSubscriber {
 	return &WebSocketSubscriber{hub: hub}
 }
 
-// Send delivers an event to all WebSocket clients.
+// Send delivers an event to all WebSocket clients. It returns
+// events.ErrBackpressure when the hub cannot accept the event.
 func (w *WebSocketSubscriber) Send(event events.Event) error {
-	w.hub.Broadcast(ws.Message{
+	if !w.hub.Broadcast(ws.Message{
 		Type:      string(event.Type),
 		Timestamp: event.Timestamp,
 		Data:      event.Data,
-	})
+	}) {
+		return events.ErrBackpressure
+	}
 	return nil
 }
 
@@ -2,6 +2,7 @@ package events
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -10,6 +11,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/differ"
 )
 
 const brokerSubscriberQueueSize = constants.ChannelBufferSize
@@ -17,30 +19,38 @@ const brokerSubscriberQueueSize = constants.ChannelBufferSize
 // Broker manages event distribution to multiple subscribers.
 // It provides a central hub for catalog events, fanning them out to
 // all registered subscribers (WebSocket, SSE, etc.) concurrently.
+// Events travel in batches: one per Publish call, or one per generation
+// for PublishChanges. Subscribers that miss a catalog change receive a
+// catalog.resync_required event once they catch up.
 type Broker struct {
 	subscribers     []*brokerSubscriber
-	events          chan Event
+	events          chan []Event
 	register        chan Subscriber
 	unregister      chan Subscriber
 	mu              sync.RWMutex
 	logger          *zerolog.Logger
-	fanout          *Fanout[Event]
+	fanout          *Fanout[[]Event]
 	eventsPublished uint64 // atomic counter
 	eventsDropped   uint64 // atomic counter
 }
 
 type brokerSubscriber struct {
 	subscriber Subscriber
-	queue      chan Event
+	queue      chan []Event
+	wake       chan struct{}
 	done       chan struct{}
 	closeOnce  sync.Once
 	logger     *zerolog.Logger
+
+	mu     sync.Mutex
+	resync string // newest generation with changes this subscriber missed
 }
 
 func newBrokerSubscriber(subscriber Subscriber, logger *zerolog.Logger) *brokerSubscriber {
 	return &brokerSubscriber{
 		subscriber: subscriber,
-		queue:      make(chan Event, brokerSubscriberQueueSize),
+		queue:      make(chan []Event, brokerSubscriberQueueSize),
+		wake:       make(chan struct{}, 1),
 		done:       make(chan struct{}),
 		logger:     logger,
 	}
@@ -51,19 +61,66 @@ func (s *brokerSubscriber) Run() {
 		select {
 		case <-s.done:
 			return
-		case event := <-s.queue:
-			if err := s.subscriber.Send(event); err != nil {
-				s.logger.Warn().
-					Err(err).
-					Str("subscriber_type", fmt.Sprintf("%T", s.subscriber)).
-					Msg("Event subscriber send failed")
+		case batch := <-s.queue:
+			for _, event := range batch {
+				s.deliver(event)
+			}
+		case <-s.wake:
+		}
+		if len(s.queue) == 0 {
+			if generationID := s.takeResync(); generationID != "" {
+				s.deliver(ResyncEvent(generationID))
 			}
 		}
 	}
 }
 
-func (s *brokerSubscriber) Send(event Event) error {
-	return TrySend(s.queue, event)
+// deliver hands event to the subscriber. A catalog change the subscriber
+// could not accept is replaced by a resync marker.
+func (s *brokerSubscriber) deliver(event Event) {
+	err := s.subscriber.Send(event)
+	if err == nil {
+		return
+	}
+	if errors.Is(err, ErrBackpressure) {
+		if generationID := GenerationID(event.Data); generationID != "" {
+			s.markResync(generationID)
+		}
+	}
+	s.logger.Warn().
+		Err(err).
+		Str("subscriber_type", fmt.Sprintf("%T", s.subscriber)).
+		Msg("Event subscriber send failed")
+}
+
+func (s *brokerSubscriber) Send(batch []Event) error {
+	err := TrySend(s.queue, batch)
+	if err != nil {
+		if generationID := batchGenerationID(batch); generationID != "" {
+			s.markResync(generationID)
+		}
+	}
+	return err
+}
+
+// markResync records that the subscriber missed changes of generationID
+// and wakes Run to report it.
+func (s *brokerSubscriber) markResync(generationID string) {
+	s.mu.Lock()
+	s.resync = generationID
+	s.mu.Unlock()
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *brokerSubscriber) takeResync() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	generationID := s.resync
+	s.resync = ""
+	return generationID
 }
 
 func (s *brokerSubscriber) Close() error {
@@ -79,11 +136,11 @@ func (s *brokerSubscriber) Close() error {
 func NewBroker(logger *zerolog.Logger) *Broker {
 	return &Broker{
 		subscribers: make([]*brokerSubscriber, 0),
-		events:      make(chan Event, 256),
+		events:      make(chan []Event, 256),
 		register:    make(chan Subscriber, 10), // Buffer to prevent blocking during setup
 		unregister:  make(chan Subscriber, 10), // Buffer to prevent blocking during shutdown
 		logger:      logger,
-		fanout:      NewFanout[Event](BackpressureSkip, logger),
+		fanout:      NewFanout[[]Event](BackpressureSkip, logger),
 	}
 }
 
@@ -127,27 +184,28 @@ func (b *Broker) Run(ctx context.Context) {
 				Int("total_subscribers", len(b.subscribers)).
 				Msg("Internal subscriber unregistered")
 
-		case event := <-b.events:
-			atomic.AddUint64(&b.eventsPublished, 1)
+		case batch := <-b.events:
+			atomic.AddUint64(&b.eventsPublished, uint64(len(batch)))
 
 			b.mu.RLock()
 			subs := make([]*brokerSubscriber, len(b.subscribers))
 			copy(subs, b.subscribers)
 			b.mu.RUnlock()
 
-			targets := make([]DeliveryTarget[Event], 0, len(subs))
+			targets := make([]DeliveryTarget[[]Event], 0, len(subs))
 			for _, sub := range subs {
 				subscriber := sub
-				targets = append(targets, DeliveryTarget[Event]{
+				targets = append(targets, DeliveryTarget[[]Event]{
 					ID:    fmt.Sprintf("%T", subscriber),
 					Send:  subscriber.Send,
 					Close: subscriber.Close,
 				})
 			}
-			result := b.fanout.Deliver(targets, event)
+			result := b.fanout.Deliver(targets, batch)
 
 			b.logger.Debug().
-				Str("event_type", string(event.Type)).
+				Str("event_type", string(batch[0].Type)).
+				Int("events", len(batch)).
 				Int("subscribers", len(subs)).
 				Int("sent", result.Sent).
 				Int("failed", result.Failed).
@@ -158,19 +216,43 @@ func (b *Broker) Run(ctx context.Context) {
 
 // Publish sends an event to all subscribers.
 func (b *Broker) Publish(eventType EventType, data any) {
-	event := Event{
+	b.publish(Event{
 		Type:      eventType,
 		Timestamp: time.Now(),
 		Data:      data,
-	}
+	})
+}
 
+// PublishChangeset sends an event carrying the catalog changeset it describes.
+func (b *Broker) PublishChangeset(eventType EventType, data any, changeset *differ.Changeset) {
+	b.publish(Event{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Data:      data,
+		Changeset: changeset,
+	})
+}
+
+func (b *Broker) publish(event Event) {
+	b.publishBatch([]Event{event})
+}
+
+func (b *Broker) publishBatch(batch []Event) {
 	select {
-	case b.events <- event:
+	case b.events <- batch:
 	default:
-		atomic.AddUint64(&b.eventsDropped, 1)
+		atomic.AddUint64(&b.eventsDropped, uint64(len(batch)))
 		b.logger.Warn().
-			Str("event_type", string(eventType)).
-			Msg("Event channel full, event dropped")
+			Str("event_type", string(batch[0].Type)).
+			Int("events", len(batch)).
+			Msg("Event channel full, events dropped")
+		if generationID := batchGenerationID(batch); generationID != "" {
+			b.mu.RLock()
+			for _, sub := range b.subscribers {
+				sub.markResync(generationID)
+			}
+			b.mu.RUnlock()
+		}
 	}
 }
 
@@ -201,7 +283,7 @@ func (b *Broker) EventsDropped() uint64 {
 	return atomic.LoadUint64(&b.eventsDropped)
 }
 
-// QueueDepth returns the current number of events in the queue.
+// QueueDepth returns the current number of event batches in the queue.
 func (b *Broker) QueueDepth() int {
 	return len(b.events)
 }
@@ -42,6 +42,12 @@ func (m *mockSubscriber) EventCount() int {
 	return len(m.events)
 }
 
+func (m *mockSubscriber) Events() []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Event(nil), m.events...)
+}
+
 type blockingSubscriber struct {
 	started   chan struct{}
 	release   chan struct{}
@@ -0,0 +1,153 @@
+package events
+
+import (
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogremote"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/differ"
+)
+
+// ModelChange is the data of model.added, model.updated, and model.deleted
+// events. Deleted models carry the last known model.
+type ModelChange struct {
+	GenerationID string                      `json:"generation_id"`
+	ProviderID   catalogs.ProviderID         `json:"provider_id,omitempty"`
+	ModelID      string                      `json:"model_id"`
+	Model        catalogs.Model              `json:"model"`
+	Changes      []catalogremote.FieldChange `json:"changes,omitempty"` // Field diff of model.updated
+	Test         bool                        `json:"test,omitempty"`    // Synthetic change published for testing
+}
+
+// ProviderChange is the data of provider.added, provider.updated, and
+// provider.removed events. Provider models are reported as model events.
+type ProviderChange struct {
+	GenerationID string                      `json:"generation_id"`
+	ProviderID   catalogs.ProviderID         `json:"provider_id"`
+	Provider     catalogs.Provider           `json:"provider"`
+	Changes      []catalogremote.FieldChange `json:"changes,omitempty"` // Field diff of provider.updated
+}
+
+// Resync is the data of catalog.resync_required events.
+type Resync struct {
+	GenerationID string `json:"generation_id"`
+}
+
+// ResyncEvent returns the catalog.resync_required event for generationID.
+func ResyncEvent(generationID string) Event {
+	return Event{Type: ResyncRequired, Timestamp: time.Now(), Data: Resync{GenerationID: generationID}}
+}
+
+// GenerationID returns the generation a catalog change or resync event
+// concerns, or "" for events whose loss does not leave a client's catalog
+// stale, such as budget alerts and synthetic test changes.
+func GenerationID(data any) string {
+	switch data := data.(type) {
+	case ModelChange:
+		if data.Test {
+			return ""
+		}
+		return data.GenerationID
+	case ProviderChange:
+		return data.GenerationID
+	case Resync:
+		return data.GenerationID
+	case map[string]any:
+		id, _ := data["generation_id"].(string)
+		return id
+	default:
+		return ""
+	}
+}
+
+// batchGenerationID returns the newest generation of the catalog change
+// events in batch.
+func batchGenerationID(batch []Event) string {
+	for i := len(batch) - 1; i >= 0; i-- {
+		if id := GenerationID(batch[i].Data); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// ChangesetEvents returns one typed event per provider and model change in
+// the changeset published as generationID. Provider events come first so
+// clients learn about a provider before its models.
+func ChangesetEvents(generationID string, changeset *differ.Changeset) []Event {
+	if changeset == nil {
+		return nil
+	}
+	now := time.Now()
+	var result []Event
+	add := func(eventType EventType, data any) {
+		result = append(result, Event{Type: eventType, Timestamp: now, Data: data})
+	}
+
+	if providers := changeset.Providers; providers != nil {
+		for _, provider := range providers.Added {
+			add(ProviderAdded, providerChange(generationID, provider, nil))
+		}
+		for _, update := range providers.Updated {
+			add(ProviderUpdated, providerChange(generationID, update.New, update.Changes))
+		}
+		for _, provider := range providers.Removed {
+			add(ProviderRemoved, providerChange(generationID, provider, nil))
+		}
+	}
+
+	if models := changeset.Models; models != nil {
+		for _, change := range scopedModels(models.AddedScoped, models.Added) {
+			add(ModelAdded, modelChange(generationID, change.ProviderID, change.Model, nil))
+		}
+		for _, update := range models.Updated {
+			add(ModelUpdated, modelChange(generationID, update.ProviderID, update.New, update.Changes))
+		}
+		for _, change := range scopedModels(models.RemovedScoped, models.Removed) {
+			add(ModelDeleted, modelChange(generationID, change.ProviderID, change.Model, nil))
+		}
+	}
+	return result
+}
+
+// PublishChanges sends the typed events for a published changeset. They
+// travel through the broker as one batch, so a generation with many changes
+// takes a single queue slot; subscribers that still fall behind receive a
+// catalog.resync_required event instead of losing changes silently.
+func (b *Broker) PublishChanges(generationID string, changeset *differ.Changeset) {
+	if changes := ChangesetEvents(generationID, changeset); len(changes) > 0 {
+		b.publishBatch(changes)
+	}
+}
+
+// scopedModels prefers provider-scoped changes and falls back to the unscoped
+// list for changesets built without provider scope.
+func scopedModels(scoped []differ.ModelChange, models []catalogs.Model) []differ.ModelChange {
+	if len(scoped) > 0 {
+		return scoped
+	}
+	result := make([]differ.ModelChange, 0, len(models))
+	for _, model := range models {
+		result = append(result, differ.ModelChange{Model: model})
+	}
+	return result
+}
+
+func modelChange(generationID string, providerID catalogs.ProviderID, model catalogs.Model, changes []differ.FieldChange) ModelChange {
+	return ModelChange{
+		GenerationID: generationID,
+		ProviderID:   providerID,
+		ModelID:      model.ID,
+		Model:        model,
+		Changes:      catalogremote.NewFieldChanges(changes),
+	}
+}
+
+func providerChange(generationID string, provider catalogs.Provider, changes []differ.FieldChange) ProviderChange {
+	return ProviderChange{
+		GenerationID: generationID,
+		ProviderID:   provider.ID,
+		Provider:     provider,
+		Changes:      catalogremote.NewFieldChanges(changes),
+	}
+}
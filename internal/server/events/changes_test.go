@@ -0,0 +1,175 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/differ"
+)
+
+func TestChangesetEvents(t *testing.T) {
+	changeset := &differ.Changeset{
+		Models: &differ.ModelChangeset{
+			AddedScoped: []differ.ModelChange{{ProviderID: "openai", Model: catalogs.Model{ID: "gpt-new"}}},
+			Updated: []differ.ModelUpdate{{
+				ID:         "gpt-4o",
+				ProviderID: "openai",
+				New:        catalogs.Model{ID: "gpt-4o"},
+				Changes:    []differ.FieldChange{{Path: "name", OldValue: "GPT-4o", NewValue: "GPT 4o", Type: differ.ChangeTypeUpdate}},
+			}},
+			Removed: []catalogs.Model{{ID: "gpt-old"}},
+		},
+		Providers: &differ.ProviderChangeset{
+			Removed: []catalogs.Provider{{ID: "legacy"}},
+		},
+	}
+
+	got := ChangesetEvents("gen-2", changeset)
+	want := []EventType{ProviderRemoved, ModelAdded, ModelUpdated, ModelDeleted}
+	if len(got) != len(want) {
+		t.Fatalf("events = %d, want %d", len(got), len(want))
+	}
+	for i, event := range got {
+		if event.Type != want[i] {
+			t.Errorf("event %d type = %s, want %s", i, event.Type, want[i])
+		}
+	}
+
+	provider := got[0].Data.(ProviderChange)
+	if provider.GenerationID != "gen-2" || provider.ProviderID != "legacy" {
+		t.Errorf("provider.removed = %#v, want legacy", provider)
+	}
+	added := got[1].Data.(ModelChange)
+	if added.ProviderID != "openai" || added.ModelID != "gpt-new" {
+		t.Errorf("model.added = %#v, want openai/gpt-new", added)
+	}
+	updated := got[2].Data.(ModelChange)
+	if len(updated.Changes) != 1 || updated.Changes[0].Path != "name" || updated.Changes[0].NewValue != "GPT 4o" {
+		t.Errorf("model.updated changes = %#v, want name diff", updated.Changes)
+	}
+	if deleted := got[3].Data.(ModelChange); deleted.ModelID != "gpt-old" || deleted.ProviderID != "" {
+		t.Errorf("model.deleted = %#v, want unscoped gpt-old", deleted)
+	}
+
+	if events := ChangesetEvents("gen-2", nil); events != nil {
+		t.Errorf("nil changeset events = %#v, want none", events)
+	}
+}
+
+func addedModels(n int) *differ.Changeset {
+	changeset := &differ.Changeset{Models: &differ.ModelChangeset{}}
+	for i := range n {
+		changeset.Models.AddedScoped = append(changeset.Models.AddedScoped, differ.ModelChange{
+			ProviderID: "openai",
+			Model:      catalogs.Model{ID: fmt.Sprintf("model-%03d", i)},
+		})
+	}
+	return changeset
+}
+
+func waitForEvents(t *testing.T, sub *mockSubscriber, n int) []Event {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		if events := sub.Events(); len(events) >= n {
+			return events
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("subscriber received %d events, want %d", sub.EventCount(), n)
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+func TestPublishChangesDeliversWholeGeneration(t *testing.T) {
+	logger := zerolog.Nop()
+	b := NewBroker(&logger)
+	go b.Run(t.Context())
+
+	sub := newMockSubscriber()
+	b.Subscribe(sub)
+	for b.SubscriberCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	const changes = 3 * brokerSubscriberQueueSize
+	b.PublishChanges("gen-1", addedModels(changes))
+
+	got := waitForEvents(t, sub, changes)
+	if len(got) != changes {
+		t.Fatalf("events = %d, want %d", len(got), changes)
+	}
+	for i, event := range got {
+		change, ok := event.Data.(ModelChange)
+		if event.Type != ModelAdded || !ok || change.ModelID != fmt.Sprintf("model-%03d", i) {
+			t.Fatalf("event %d = %s %#v, want model.added model-%03d", i, event.Type, event.Data, i)
+		}
+	}
+	if dropped := b.EventsDropped(); dropped != 0 {
+		t.Errorf("EventsDropped() = %d, want 0", dropped)
+	}
+}
+
+// gatedSubscriber records events once its gate is closed.
+type gatedSubscriber struct {
+	*mockSubscriber
+	started   chan struct{}
+	startOnce sync.Once
+	gate      chan struct{}
+}
+
+func (g *gatedSubscriber) Send(event Event) error {
+	g.startOnce.Do(func() { close(g.started) })
+	<-g.gate
+	return g.mockSubscriber.Send(event)
+}
+
+func TestPublishChangesReportsDroppedGenerations(t *testing.T) {
+	logger := zerolog.Nop()
+	b := NewBroker(&logger)
+	go b.Run(t.Context())
+
+	sub := &gatedSubscriber{
+		mockSubscriber: newMockSubscriber(),
+		started:        make(chan struct{}),
+		gate:           make(chan struct{}),
+	}
+	b.Subscribe(sub)
+	for b.SubscriberCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// One generation is held by the stalled subscriber, a queue's worth
+	// waits behind it, and the rest are dropped.
+	const generations = brokerSubscriberQueueSize + 20
+	b.PublishChanges("gen-1", addedModels(1))
+	<-sub.started
+	for i := 2; i <= generations; i++ {
+		b.PublishChanges(fmt.Sprintf("gen-%d", i), addedModels(1))
+	}
+	for b.DeliveryStats().Skipped < generations-brokerSubscriberQueueSize-1 {
+		time.Sleep(time.Millisecond)
+	}
+	close(sub.gate)
+
+	delivered := brokerSubscriberQueueSize + 1
+	got := waitForEvents(t, sub.mockSubscriber, delivered+1)
+	for i, event := range got[:delivered] {
+		if id := GenerationID(event.Data); event.Type != ModelAdded || id != fmt.Sprintf("gen-%d", i+1) {
+			t.Fatalf("event %d = %s for %q, want model.added for gen-%d", i, event.Type, id, i+1)
+		}
+	}
+	resync := got[delivered]
+	if resync.Type != ResyncRequired {
+		t.Fatalf("event after queued generations = %s, want %s", resync.Type, ResyncRequired)
+	}
+	if data, ok := resync.Data.(Resync); !ok || data.GenerationID != fmt.Sprintf("gen-%d", generations) {
+		t.Errorf("resync data = %#v, want gen-%d", resync.Data, generations)
+	}
+}
@@ -0,0 +1,105 @@
+package events
+
+import (
+	"strconv"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/differ"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// TestEventKind names a synthetic catalog change published for testing.
+type TestEventKind string
+
+// Synthetic catalog changes.
+const (
+	// TestPriceChange publishes model.updated with scaled token prices.
+	TestPriceChange TestEventKind = "price_change"
+	// TestModelRemoval publishes model.deleted.
+	TestModelRemoval TestEventKind = "model_removal"
+)
+
+// TestGenerationID is the generation ID of synthetic events. No catalog
+// generation has it, so it must not be used as a changes cursor.
+const TestGenerationID = "test"
+
+// DefaultTestPriceFactor scales the token prices of synthetic price changes.
+const DefaultTestPriceFactor = 2.0
+
+// TestEvents returns the events of a synthetic change of kind to a provider's
+// model, shaped like the events of a real published change and marked Test.
+// The catalog itself is left unchanged. Price changes multiply the model's
+// input and output token prices by priceFactor.
+func TestEvents(kind TestEventKind, providerID catalogs.ProviderID, model catalogs.Model, priceFactor float64) ([]Event, error) {
+	models := &differ.ModelChangeset{}
+	switch kind {
+	case TestPriceChange:
+		updated, changes, err := scaleTokenPrices(model, priceFactor)
+		if err != nil {
+			return nil, err
+		}
+		models.Updated = []differ.ModelUpdate{{
+			ID:         model.ID,
+			ProviderID: providerID,
+			Existing:   model,
+			New:        updated,
+			Changes:    changes,
+		}}
+	case TestModelRemoval:
+		models.RemovedScoped = []differ.ModelChange{{ProviderID: providerID, Model: model}}
+	default:
+		return nil, &errors.ValidationError{Field: "kind", Value: kind, Message: "must be price_change or model_removal"}
+	}
+
+	result := ChangesetEvents(TestGenerationID, &differ.Changeset{Models: models})
+	for i := range result {
+		if change, ok := result[i].Data.(ModelChange); ok {
+			change.Test = true
+			result[i].Data = change
+		}
+	}
+	return result, nil
+}
+
+// PublishTest sends the events of a synthetic change and returns them.
+func (b *Broker) PublishTest(kind TestEventKind, providerID catalogs.ProviderID, model catalogs.Model, priceFactor float64) ([]Event, error) {
+	result, err := TestEvents(kind, providerID, model, priceFactor)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range result {
+		b.publish(event)
+	}
+	return result, nil
+}
+
+// scaleTokenPrices returns a copy of model with its input and output token
+// prices multiplied by factor, and the field changes that describes.
+func scaleTokenPrices(model catalogs.Model, factor float64) (catalogs.Model, []differ.FieldChange, error) {
+	if factor <= 0 || factor == 1 {
+		return model, nil, &errors.ValidationError{Field: "factor", Value: factor, Message: "must be positive and not 1"}
+	}
+	if model.Pricing == nil || model.Pricing.Tokens == nil || (model.Pricing.Tokens.Input == nil && model.Pricing.Tokens.Output == nil) {
+		return model, nil, &errors.ValidationError{Field: "model", Value: model.ID, Message: "has no token prices to change"}
+	}
+
+	updated := catalogs.DeepCopyModel(model)
+	var changes []differ.FieldChange
+	scale := func(path string, cost *catalogs.ModelTokenCost) {
+		if cost == nil {
+			return
+		}
+		old := cost.Per1M
+		cost.PerToken *= factor
+		cost.Per1M *= factor
+		changes = append(changes, differ.FieldChange{
+			Path:     path,
+			OldValue: strconv.FormatFloat(old, 'g', -1, 64),
+			NewValue: strconv.FormatFloat(cost.Per1M, 'g', -1, 64),
+			Type:     differ.ChangeTypeUpdate,
+		})
+	}
+	scale("pricing.tokens.input.per_1m_tokens", updated.Pricing.Tokens.Input)
+	scale("pricing.tokens.output.per_1m_tokens", updated.Pricing.Tokens.Output)
+	return updated, changes, nil
+}
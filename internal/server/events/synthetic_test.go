@@ -0,0 +1,50 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func TestTestEvents(t *testing.T) {
+	model := catalogs.Model{ID: "gpt", Pricing: &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{
+		Input:  &catalogs.ModelTokenCost{Per1M: 2.5},
+		Output: &catalogs.ModelTokenCost{Per1M: 10},
+	}}}
+
+	priced, err := TestEvents(TestPriceChange, "openai", model, 2)
+	if err != nil {
+		t.Fatalf("TestEvents(price_change): %v", err)
+	}
+	if len(priced) != 1 || priced[0].Type != ModelUpdated {
+		t.Fatalf("price_change events = %+v, want one model.updated", priced)
+	}
+	change := priced[0].Data.(ModelChange)
+	if !change.Test || change.GenerationID != TestGenerationID || change.ProviderID != "openai" {
+		t.Errorf("price_change data = %+v, want a test change to openai/gpt", change)
+	}
+	if got := change.Model.Pricing.Tokens.Output.Per1M; got != 20 {
+		t.Errorf("output price = %v, want 20", got)
+	}
+	if len(change.Changes) != 2 || change.Changes[0].OldValue != "2.5" || change.Changes[0].NewValue != "5" {
+		t.Errorf("changes = %+v, want input 2.5 -> 5 and output", change.Changes)
+	}
+	if model.Pricing.Tokens.Input.Per1M != 2.5 {
+		t.Error("TestEvents changed the catalog model")
+	}
+
+	removed, err := TestEvents(TestModelRemoval, "openai", model, 0)
+	if err != nil {
+		t.Fatalf("TestEvents(model_removal): %v", err)
+	}
+	if len(removed) != 1 || removed[0].Type != ModelDeleted || !removed[0].Data.(ModelChange).Test {
+		t.Errorf("model_removal events = %+v, want one test model.deleted", removed)
+	}
+
+	if _, err := TestEvents("outage", "openai", model, 2); err == nil {
+		t.Error("TestEvents: expected an error for an unknown kind")
+	}
+	if _, err := TestEvents(TestPriceChange, "openai", catalogs.Model{ID: "free"}, 2); err == nil {
+		t.Error("TestEvents: expected an error for a model without token prices")
+	}
+}
@@ -6,23 +6,41 @@
 // event distribution.
 package events
 
-import "time"
+import (
+	"time"
+
+	"github.com/agentstation/starmap/pkg/differ"
+)
 
 // EventType represents the type of catalog event.
 type EventType string
 
 // Event types for catalog changes.
 const (
-	// Model events (from Starmap hooks).
+	// Model events (from published catalog changesets).
 	ModelAdded   EventType = "model.added"
 	ModelUpdated EventType = "model.updated"
 	ModelDeleted EventType = "model.deleted"
 
+	// Provider events (from published catalog changesets).
+	ProviderAdded   EventType = "provider.added"
+	ProviderUpdated EventType = "provider.updated"
+	ProviderRemoved EventType = "provider.removed"
+
 	// Sync events (from sync operations).
 	SyncStarted   EventType = "sync.started"
 	SyncCompleted EventType = "sync.completed"
 	// CatalogPublished is emitted once a durable generation becomes visible.
 	CatalogPublished EventType = "catalog.published"
+	// ResyncRequired is sent in place of catalog change events a stream
+	// could not keep up with. Its data names the newest generation missed;
+	// clients refetch the catalog or the changes since their last complete
+	// generation.
+	ResyncRequired EventType = "catalog.resync_required"
+
+	// BudgetThreshold is emitted when month-to-date provider spend crosses a
+	// budget alert threshold.
+	BudgetThreshold EventType = "budget.threshold"
 
 	// Client events (from transport layers).
 	ClientConnected EventType = "client.connected"
@@ -33,4 +51,8 @@ type Event struct {
 	Type      EventType `json:"type"`
 	Timestamp time.Time `json:"timestamp"`
 	Data      any       `json:"data"`
+
+	// Changeset describes what a catalog.published event changed. It is
+	// rendered by notification templates and never sent to stream clients.
+	Changeset *differ.Changeset `json:"-"`
 }
@@ -0,0 +1,46 @@
+package grpcapi
+
+import (
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogremote"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// ListModelsRequest selects a page of models.
+type ListModelsRequest struct {
+	ProviderID string `json:"provider_id"` // Only models offered by this provider
+	PageSize   int32  `json:"page_size"`   // Models per page (default 100, at most 1000)
+	PageToken  string `json:"page_token"`  // next_page_token of the previous page
+}
+
+// ListModelsResponse is one page of models, ordered by ID.
+type ListModelsResponse struct {
+	Models        []catalogs.Model `json:"models"`
+	NextPageToken string           `json:"next_page_token"` // Empty on the last page
+	GenerationID  string           `json:"generation_id"`   // Catalog generation the page was read from
+}
+
+// GetModelRequest selects one model.
+type GetModelRequest struct {
+	ID         string `json:"id"`
+	ProviderID string `json:"provider_id"` // Return this provider's offering of the model
+}
+
+// WatchChangesRequest selects the catalog changes to stream.
+type WatchChangesRequest struct {
+	ProviderID string `json:"provider_id"` // Only changes to this provider and its models
+}
+
+// ChangeEvent is one catalog change streamed by WatchChanges.
+type ChangeEvent struct {
+	Type         string                      `json:"type"` // Event type, such as model.updated
+	Timestamp    time.Time                   `json:"timestamp"`
+	GenerationID string                      `json:"generation_id"`
+	ProviderID   string                      `json:"provider_id"`
+	ModelID      string                      `json:"model_id"`
+	Model        *catalogs.Model             `json:"model"`    // Set for model events
+	Provider     *catalogs.Provider          `json:"provider"` // Set for provider events
+	Changes      []catalogremote.FieldChange `json:"changes"`  // Changed fields of updated models and providers
+	Test         bool                        `json:"test"`     // Synthetic change published for testing
+}
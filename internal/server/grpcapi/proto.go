@@ -0,0 +1,121 @@
+package grpcapi
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+//go:generate go run ./protogen ../../../proto/starmap/v1/catalog.proto
+
+// rpcComments documents the service methods in the generated .proto file.
+var rpcComments = map[protoreflect.Name]string{
+	"ListModels":   "ListModels returns a page of models ordered by ID.",
+	"GetModel":     "GetModel returns one model, or NOT_FOUND.",
+	"WatchChanges": "WatchChanges streams provider and model changes as catalog generations are published.",
+}
+
+// WriteProto writes the schema as a .proto source file.
+func (s *Schema) WriteProto(w io.Writer) error {
+	p := &protoPrinter{}
+	p.line("// Code generated by go generate in internal/server/grpcapi; DO NOT EDIT.")
+	p.line("//")
+	p.line("// Messages mirror the JSON form of the starmap catalogs types. Field")
+	p.line("// numbers follow Go field order, so new catalog fields are appended.")
+	p.line("")
+	p.line(`syntax = "proto3";`)
+	p.line("")
+	p.line("package %s;", s.File.Package())
+	p.line("")
+	imports := s.File.Imports()
+	for i := range imports.Len() {
+		p.line("import %q;", imports.Get(i).Path())
+	}
+
+	services := s.File.Services()
+	for i := range services.Len() {
+		service := services.Get(i)
+		p.line("")
+		p.line("// %s serves the starmap model catalog.", service.Name())
+		p.line("service %s {", service.Name())
+		methods := service.Methods()
+		for j := range methods.Len() {
+			m := methods.Get(j)
+			if j > 0 {
+				p.line("")
+			}
+			if comment := rpcComments[m.Name()]; comment != "" {
+				p.line("  // %s", comment)
+			}
+			output := s.typeName(m.Output())
+			if m.IsStreamingServer() {
+				output = "stream " + output
+			}
+			p.line("  rpc %s(%s) returns (%s);", m.Name(), s.typeName(m.Input()), output)
+		}
+		p.line("}")
+	}
+
+	messages := s.File.Messages()
+	for i := range messages.Len() {
+		s.writeMessage(p, messages.Get(i))
+	}
+
+	_, err := io.WriteString(w, p.String())
+	return err
+}
+
+func (s *Schema) writeMessage(p *protoPrinter, msg protoreflect.MessageDescriptor) {
+	p.line("")
+	p.line("message %s {", msg.Name())
+	fields := msg.Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		var typ string
+		switch {
+		case field.IsMap():
+			typ = fmt.Sprintf("map<%s, %s>", s.fieldType(field.MapKey()), s.fieldType(field.MapValue()))
+		case field.IsList():
+			typ = "repeated " + s.fieldType(field)
+		case field.HasOptionalKeyword():
+			typ = "optional " + s.fieldType(field)
+		default:
+			typ = s.fieldType(field)
+		}
+		option := ""
+		if field.JSONName() != string(field.Name()) {
+			option = fmt.Sprintf(" [json_name = %q]", field.JSONName())
+		}
+		p.line("  %s %s = %d%s;", typ, field.Name(), field.Number(), option)
+	}
+	p.line("}")
+}
+
+func (s *Schema) fieldType(field protoreflect.FieldDescriptor) string {
+	if field.Kind() == protoreflect.MessageKind {
+		return s.typeName(field.Message())
+	}
+	return field.Kind().String()
+}
+
+// typeName returns the name of msg relative to the schema package.
+func (s *Schema) typeName(msg protoreflect.MessageDescriptor) string {
+	if msg.ParentFile().Package() == s.File.Package() {
+		return string(msg.Name())
+	}
+	return string(msg.FullName())
+}
+
+type protoPrinter struct {
+	strings.Builder
+}
+
+func (p *protoPrinter) line(format string, args ...any) {
+	if format == "" {
+		p.WriteString("\n")
+		return
+	}
+	fmt.Fprintf(p, format+"\n", args...)
+}
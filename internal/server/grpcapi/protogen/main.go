@@ -0,0 +1,38 @@
+// Command protogen writes the starmap.v1 catalog schema as a .proto file.
+//
+//	go run ./internal/server/grpcapi/protogen proto/starmap/v1/catalog.proto
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentstation/starmap/internal/server/grpcapi"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: protogen <output.proto>")
+		os.Exit(2)
+	}
+	if err := run(os.Args[1]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(path string) error {
+	schema, err := grpcapi.NewSchema()
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := schema.WriteProto(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
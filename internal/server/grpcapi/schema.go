@@ -0,0 +1,392 @@
+// Package grpcapi serves the catalog as the starmap.v1.CatalogService gRPC API.
+//
+// The protobuf schema is generated from the catalogs types by reflection, so
+// the typed contract always matches the JSON form served by the REST API:
+// messages are named after the Go types, fields after their json tags, and
+// field numbers follow field declaration order. The published copy of the
+// schema is proto/starmap/v1/catalog.proto.
+package grpcapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/agentstation/utc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const (
+	// Package is the protobuf package of the catalog API.
+	Package = "starmap.v1"
+
+	// ServiceName is the fully qualified gRPC service name.
+	ServiceName = Package + ".CatalogService"
+
+	// ProtoPath is the import path of the generated schema file.
+	ProtoPath = "starmap/v1/catalog.proto"
+
+	timestampType = ".google.protobuf.Timestamp"
+	valueType     = ".google.protobuf.Value"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	utcTimeType       = reflect.TypeFor[utc.Time]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// Schema is the resolved starmap.v1 protobuf schema.
+type Schema struct {
+	File  protoreflect.FileDescriptor
+	Files *protoregistry.Files // File and its well-known type imports
+}
+
+// Message returns the descriptor of the named starmap.v1 message.
+func (s *Schema) Message(name string) protoreflect.MessageDescriptor {
+	return s.File.Messages().ByName(protoreflect.Name(name))
+}
+
+// Service returns the CatalogService descriptor.
+func (s *Schema) Service() protoreflect.ServiceDescriptor {
+	return s.File.Services().ByName("CatalogService")
+}
+
+// NewSchema builds the starmap.v1 schema from the catalogs types.
+func NewSchema() (*Schema, error) {
+	b := &schemaBuilder{
+		file: &descriptorpb.FileDescriptorProto{
+			Name:    proto.String(ProtoPath),
+			Package: proto.String(Package),
+			Syntax:  proto.String("proto3"),
+		},
+		names:   make(map[reflect.Type]string),
+		types:   make(map[string]reflect.Type),
+		imports: make(map[string]bool),
+	}
+	for _, t := range []reflect.Type{
+		reflect.TypeFor[ListModelsRequest](),
+		reflect.TypeFor[ListModelsResponse](),
+		reflect.TypeFor[GetModelRequest](),
+		reflect.TypeFor[WatchChangesRequest](),
+		reflect.TypeFor[ChangeEvent](),
+	} {
+		if _, err := b.message(t); err != nil {
+			return nil, err
+		}
+	}
+	b.file.Service = []*descriptorpb.ServiceDescriptorProto{{
+		Name: proto.String("CatalogService"),
+		Method: []*descriptorpb.MethodDescriptorProto{
+			method("ListModels", "ListModelsRequest", "ListModelsResponse", false),
+			method("GetModel", "GetModelRequest", "Model", false),
+			method("WatchChanges", "WatchChangesRequest", "ChangeEvent", true),
+		},
+	}}
+	for name := range b.imports {
+		b.file.Dependency = append(b.file.Dependency, name)
+	}
+	sort.Strings(b.file.Dependency)
+
+	files := new(protoregistry.Files)
+	for _, dependency := range []protoreflect.FileDescriptor{
+		timestamppb.File_google_protobuf_timestamp_proto,
+		structpb.File_google_protobuf_struct_proto,
+	} {
+		if err := files.RegisterFile(dependency); err != nil {
+			return nil, errors.WrapParse("protobuf", dependency.Path(), err)
+		}
+	}
+	file, err := protodesc.NewFile(b.file, files)
+	if err != nil {
+		return nil, errors.WrapParse("protobuf", ProtoPath, err)
+	}
+	if err := files.RegisterFile(file); err != nil {
+		return nil, errors.WrapParse("protobuf", ProtoPath, err)
+	}
+	return &Schema{File: file, Files: files}, nil
+}
+
+func method(name, input, output string, serverStreaming bool) *descriptorpb.MethodDescriptorProto {
+	m := &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String(name),
+		InputType:  proto.String("." + Package + "." + input),
+		OutputType: proto.String("." + Package + "." + output),
+	}
+	if serverStreaming {
+		m.ServerStreaming = proto.Bool(true)
+	}
+	return m
+}
+
+// schemaBuilder collects one message per Go struct type.
+type schemaBuilder struct {
+	file    *descriptorpb.FileDescriptorProto
+	names   map[reflect.Type]string
+	types   map[string]reflect.Type
+	imports map[string]bool
+}
+
+// message returns the fully qualified message name for struct type t,
+// adding the message and the messages it references on first use.
+func (b *schemaBuilder) message(t reflect.Type) (string, error) {
+	if name, ok := b.names[t]; ok {
+		return name, nil
+	}
+	if t.Name() == "" {
+		return "", &errors.ValidationError{Field: "type", Value: t.String(), Message: "anonymous structs have no message name"}
+	}
+	if other, ok := b.types[t.Name()]; ok {
+		return "", &errors.ValidationError{Field: "type", Value: t.String(), Message: fmt.Sprintf("message name is already used by %s", other)}
+	}
+	name := "." + Package + "." + t.Name()
+	b.names[t] = name
+	b.types[t.Name()] = t
+
+	msg := &descriptorpb.DescriptorProto{Name: proto.String(t.Name())}
+	b.file.MessageType = append(b.file.MessageType, msg)
+	if err := b.fields(msg, t); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// fields adds the JSON-visible fields of t to msg, promoting the fields of
+// untagged embedded structs the way encoding/json does.
+func (b *schemaBuilder) fields(msg *descriptorpb.DescriptorProto, t reflect.Type) error {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := b.fields(msg, embedded); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fd, err := b.field(msg, name, field.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+		fd.Number = proto.Int32(int32(len(msg.Field) + 1))
+		msg.Field = append(msg.Field, fd)
+	}
+	return nil
+}
+
+// field describes one message field holding values of type t.
+func (b *schemaBuilder) field(msg *descriptorpb.DescriptorProto, name string, t reflect.Type) (*descriptorpb.FieldDescriptorProto, error) {
+	fd := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(fieldName(name)),
+		JsonName: proto.String(name),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	pointer := t.Kind() == reflect.Pointer
+	if pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case isList(t) && !isContainer(elem(t.Elem())):
+		fd.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return fd, b.setType(fd, elem(t.Elem()))
+	case t.Kind() == reflect.Map && mapKey(t.Key()) != 0 && !isContainer(elem(t.Elem())):
+		entry, err := b.mapEntry(name, t)
+		if err != nil {
+			return nil, err
+		}
+		msg.NestedType = append(msg.NestedType, entry)
+		fd.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		fd.TypeName = proto.String("." + Package + "." + msg.GetName() + "." + entry.GetName())
+		return fd, nil
+	case isList(t) || t.Kind() == reflect.Map:
+		// Nested containers have no protobuf equivalent; carry them as JSON.
+		b.imports["google/protobuf/struct.proto"] = true
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		fd.TypeName = proto.String(valueType)
+		return fd, nil
+	}
+
+	if err := b.setType(fd, t); err != nil {
+		return nil, err
+	}
+	if pointer && fd.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+		// A nil pointer is omitted from JSON, so scalars track presence.
+		fd.Proto3Optional = proto.Bool(true)
+		fd.OneofIndex = proto.Int32(int32(len(msg.OneofDecl)))
+		msg.OneofDecl = append(msg.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String("_" + fd.GetName())})
+	}
+	return fd, nil
+}
+
+// mapEntry returns the synthetic map entry message for a map field.
+func (b *schemaBuilder) mapEntry(name string, t reflect.Type) (*descriptorpb.DescriptorProto, error) {
+	key := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("key"),
+		JsonName: proto.String("key"),
+		Number:   proto.Int32(1),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     mapKey(t.Key()).Enum(),
+	}
+	value := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("value"),
+		JsonName: proto.String("value"),
+		Number:   proto.Int32(2),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if err := b.setType(value, elem(t.Elem())); err != nil {
+		return nil, err
+	}
+	return &descriptorpb.DescriptorProto{
+		Name:    proto.String(entryName(fieldName(name))),
+		Field:   []*descriptorpb.FieldDescriptorProto{key, value},
+		Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+	}, nil
+}
+
+// setType sets the protobuf type of fd from a non-container Go type.
+func (b *schemaBuilder) setType(fd *descriptorpb.FieldDescriptorProto, t reflect.Type) error {
+	switch {
+	case t == timeType || t == utcTimeType:
+		b.imports["google/protobuf/timestamp.proto"] = true
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		fd.TypeName = proto.String(timestampType)
+		return nil
+	case t.Kind() == reflect.Interface || implements(t, jsonMarshalerType):
+		b.imports["google/protobuf/struct.proto"] = true
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		fd.TypeName = proto.String(valueType)
+		return nil
+	case implements(t, textMarshalerType):
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+		return nil
+	case t.Kind() == reflect.Struct:
+		name, err := b.message(t)
+		if err != nil {
+			return err
+		}
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		fd.TypeName = proto.String(name)
+		return nil
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		fd.Type = descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum()
+		return nil
+	}
+	scalar, ok := scalarTypes[t.Kind()]
+	if !ok {
+		return &errors.ValidationError{Field: "type", Value: t.String(), Message: "has no protobuf equivalent"}
+	}
+	fd.Type = scalar.Enum()
+	return nil
+}
+
+var scalarTypes = map[reflect.Kind]descriptorpb.FieldDescriptorProto_Type{
+	reflect.String:  descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	reflect.Bool:    descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+	reflect.Int:     descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	reflect.Int8:    descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	reflect.Int16:   descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	reflect.Int32:   descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	reflect.Int64:   descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	reflect.Uint8:   descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	reflect.Uint16:  descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	reflect.Uint32:  descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	reflect.Uint:    descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	reflect.Uint64:  descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	reflect.Float32: descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+	reflect.Float64: descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+}
+
+// mapKey returns the protobuf map key type for Go key type t, or zero when
+// t cannot key a protobuf map.
+func mapKey(t reflect.Type) descriptorpb.FieldDescriptorProto_Type {
+	if t.Kind() == reflect.Bool || t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64 {
+		return 0
+	}
+	if t.Kind() != reflect.String && implements(t, textMarshalerType) {
+		return 0
+	}
+	return scalarTypes[t.Kind()]
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+func isList(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8
+}
+
+// isContainer reports whether values of t cannot be a repeated or map value.
+func isContainer(t reflect.Type) bool {
+	return isList(t) || t.Kind() == reflect.Map
+}
+
+// elem strips one level of pointer indirection.
+func elem(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// fieldName returns the protobuf field name for a json field name.
+func fieldName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) && i > 0):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// entryName returns the map entry message name protoc uses for a field.
+func entryName(field string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range field {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String() + "Entry"
+}
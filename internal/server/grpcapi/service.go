@@ -0,0 +1,349 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/query"
+	"github.com/agentstation/starmap/internal/server/events"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const (
+	// DefaultPageSize is the ListModels page size when none is requested.
+	DefaultPageSize = 100
+
+	// MaxPageSize is the largest ListModels page size.
+	MaxPageSize = 1000
+
+	// watchBuffer is how many events a slow WatchChanges stream may fall
+	// behind before further events are dropped for it.
+	watchBuffer = 64
+)
+
+// Service implements starmap.v1.CatalogService over the application catalog.
+// It is also an events.Subscriber: changes published to the broker are
+// streamed to WatchChanges callers.
+type Service struct {
+	app    application.Application
+	schema *Schema
+	logger *zerolog.Logger
+
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+	done     chan struct{}
+	close    sync.Once
+}
+
+type watcher struct {
+	providerID string
+	events     chan ChangeEvent
+	resync     string // newest generation with dropped changes; guarded by Service.mu
+}
+
+// NewService creates a CatalogService backed by app.
+func NewService(app application.Application) (*Service, error) {
+	schema, err := NewSchema()
+	if err != nil {
+		return nil, err
+	}
+	return &Service{
+		app:      app,
+		schema:   schema,
+		logger:   app.Logger(),
+		watchers: make(map[*watcher]struct{}),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Schema returns the schema the service is served with.
+func (s *Service) Schema() *Schema {
+	return s.schema
+}
+
+// Register registers CatalogService and gRPC server reflection on server.
+func (s *Service) Register(server *grpc.Server) {
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: ServiceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{
+			{MethodName: "ListModels", Handler: unary(s, "ListModels", s.listModels)},
+			{MethodName: "GetModel", Handler: unary(s, "GetModel", s.getModel)},
+		},
+		Streams: []grpc.StreamDesc{
+			{StreamName: "WatchChanges", Handler: s.watchChanges, ServerStreams: true},
+		},
+		Metadata: ProtoPath,
+	}, s)
+	reflectionpb.RegisterServerReflectionServer(server, reflection.NewServerV1(reflection.ServerOptions{
+		Services:           server,
+		DescriptorResolver: s.schema.Files,
+	}))
+}
+
+// unary adapts a typed method to a gRPC handler. Requests and responses
+// cross between the dynamic messages and the Go types through their shared
+// JSON form.
+func unary[Req, Resp any](s *Service, name string, call func(context.Context, *Req) (Resp, error)) grpc.MethodHandler {
+	input := s.schema.Message(messageName[Req]())
+	output := messageName[Resp]()
+	return func(_ any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := dynamicpb.NewMessage(input)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		handler := func(ctx context.Context, req any) (any, error) {
+			var request Req
+			if err := decode(req.(proto.Message), &request); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			response, err := call(ctx, &request)
+			if err != nil {
+				return nil, err
+			}
+			return s.encode(output, &response)
+		}
+		if interceptor == nil {
+			return handler(ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{Server: s, FullMethod: "/" + ServiceName + "/" + name}
+		return interceptor(ctx, in, info, handler)
+	}
+}
+
+func messageName[T any]() string {
+	return reflect.TypeFor[T]().Name()
+}
+
+// encode converts v to the named schema message.
+func (s *Service) encode(name string, v any) (proto.Message, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	msg := dynamicpb.NewMessage(s.schema.Message(name))
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, msg); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return msg, nil
+}
+
+// decode converts a schema message to its Go type.
+func decode(msg proto.Message, v any) error {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (s *Service) listModels(_ context.Context, req *ListModelsRequest) (ListModelsResponse, error) {
+	size := int(req.PageSize)
+	switch {
+	case size < 0:
+		return ListModelsResponse{}, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	case size == 0:
+		size = DefaultPageSize
+	case size > MaxPageSize:
+		size = MaxPageSize
+	}
+	offset := 0
+	if req.PageToken != "" {
+		var err error
+		if offset, err = strconv.Atoi(req.PageToken); err != nil || offset < 0 {
+			return ListModelsResponse{}, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+	}
+
+	state, err := s.app.CatalogState()
+	if err != nil {
+		return ListModelsResponse{}, statusError(err)
+	}
+	models, err := query.CatalogModels(state.Catalog, req.ProviderID)
+	if err != nil {
+		return ListModelsResponse{}, statusError(err)
+	}
+	slices.SortFunc(models, func(a, b catalogs.Model) int { return strings.Compare(a.ID, b.ID) })
+
+	page := query.Paginate(models, size, offset)
+	response := ListModelsResponse{Models: page.Items, GenerationID: state.GenerationID}
+	if next := offset + page.Count; next < page.Total {
+		response.NextPageToken = strconv.Itoa(next)
+	}
+	return response, nil
+}
+
+func (s *Service) getModel(_ context.Context, req *GetModelRequest) (catalogs.Model, error) {
+	if req.ID == "" {
+		return catalogs.Model{}, status.Error(codes.InvalidArgument, "id is required")
+	}
+	state, err := s.app.CatalogState()
+	if err != nil {
+		return catalogs.Model{}, statusError(err)
+	}
+	var models catalogs.ModelsReader = state.Catalog.Models()
+	if req.ProviderID != "" {
+		provided, err := state.Catalog.ProviderModels(catalogs.ProviderID(req.ProviderID))
+		if err != nil {
+			return catalogs.Model{}, statusError(err)
+		}
+		models = provided
+	}
+	model, ok := models.Get(req.ID)
+	if !ok {
+		return catalogs.Model{}, status.Errorf(codes.NotFound, "model %q not found", req.ID)
+	}
+	return *model, nil
+}
+
+func (s *Service) watchChanges(_ any, stream grpc.ServerStream) error {
+	in := dynamicpb.NewMessage(s.schema.Message(messageName[WatchChangesRequest]()))
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	var request WatchChangesRequest
+	if err := decode(in, &request); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	w := &watcher{providerID: request.ProviderID, events: make(chan ChangeEvent, watchBuffer)}
+	s.mu.Lock()
+	s.watchers[w] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, w)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case event := <-w.events:
+			if err := s.sendChange(stream, &event); err != nil {
+				return err
+			}
+			// Events are dropped only while the buffer is full, so it
+			// empties again only after everything queued before the drop.
+			if len(w.events) > 0 {
+				continue
+			}
+			s.mu.Lock()
+			generationID := w.resync
+			w.resync = ""
+			s.mu.Unlock()
+			if generationID == "" {
+				continue
+			}
+			resync := ChangeEvent{Type: string(events.ResyncRequired), Timestamp: time.Now(), GenerationID: generationID}
+			if err := s.sendChange(stream, &resync); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Service) sendChange(stream grpc.ServerStream, event *ChangeEvent) error {
+	msg, err := s.encode(messageName[ChangeEvent](), event)
+	if err != nil {
+		return err
+	}
+	return stream.SendMsg(msg)
+}
+
+// Send streams a catalog event to the WatchChanges callers it concerns.
+// Events are dropped for callers too slow to keep up; those callers receive
+// a catalog.resync_required event naming the newest generation they missed.
+func (s *Service) Send(event events.Event) error {
+	change, ok := changeEvent(event)
+	if !ok {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for w := range s.watchers {
+		if w.providerID != "" && w.providerID != change.ProviderID && change.ProviderID != "" {
+			continue
+		}
+		select {
+		case w.events <- change:
+		default:
+			s.logger.Warn().Str("event_type", change.Type).Msg("gRPC watcher too slow, event dropped")
+			if !change.Test && change.GenerationID != "" {
+				w.resync = change.GenerationID
+			}
+		}
+	}
+	return nil
+}
+
+// Close ends every WatchChanges stream.
+func (s *Service) Close() error {
+	s.close.Do(func() { close(s.done) })
+	return nil
+}
+
+// changeEvent converts the broker events WatchChanges streams.
+func changeEvent(event events.Event) (ChangeEvent, bool) {
+	change := ChangeEvent{Type: string(event.Type), Timestamp: event.Timestamp}
+	switch data := event.Data.(type) {
+	case events.ModelChange:
+		change.GenerationID = data.GenerationID
+		change.ProviderID = string(data.ProviderID)
+		change.ModelID = data.ModelID
+		change.Model = &data.Model
+		change.Changes = data.Changes
+		change.Test = data.Test
+	case events.ProviderChange:
+		change.GenerationID = data.GenerationID
+		change.ProviderID = string(data.ProviderID)
+		change.Provider = &data.Provider
+		change.Changes = data.Changes
+	case events.Resync:
+		change.GenerationID = data.GenerationID
+	case map[string]any:
+		if event.Type != events.CatalogPublished {
+			return ChangeEvent{}, false
+		}
+		change.GenerationID, _ = data["generation_id"].(string)
+	default:
+		return ChangeEvent{}, false
+	}
+	return change, true
+}
+
+// statusError maps catalog errors to gRPC status codes.
+func statusError(err error) error {
+	var notFound *errors.NotFoundError
+	var validation *errors.ValidationError
+	switch {
+	case stderrors.As(err, &notFound):
+		return status.Error(codes.NotFound, err.Error())
+	case stderrors.As(err, &validation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
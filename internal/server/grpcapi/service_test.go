@@ -0,0 +1,283 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/server/events"
+	"github.com/agentstation/starmap/pkg/catalogremote"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func TestProtoFileIsCurrent(t *testing.T) {
+	schema, err := NewSchema()
+	if err != nil {
+		t.Fatalf("NewSchema: %v", err)
+	}
+	var generated bytes.Buffer
+	if err := schema.WriteProto(&generated); err != nil {
+		t.Fatalf("WriteProto: %v", err)
+	}
+	committed, err := os.ReadFile("../../../proto/" + ProtoPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !bytes.Equal(generated.Bytes(), committed) {
+		t.Fatal("proto/starmap/v1/catalog.proto is stale; run go generate ./internal/server/grpcapi")
+	}
+}
+
+// Every embedded model and provider must encode without dropping fields,
+// so the schema covers the JSON form of the catalogs types.
+func TestSchemaCoversEmbeddedCatalog(t *testing.T) {
+	schema, err := NewSchema()
+	if err != nil {
+		t.Fatalf("NewSchema: %v", err)
+	}
+	builder, err := catalogs.NewEmbedded()
+	if err != nil {
+		t.Fatalf("NewEmbedded: %v", err)
+	}
+	strict := func(name string, v any) {
+		t.Helper()
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal %s: %v", name, err)
+		}
+		if err := protojson.Unmarshal(data, dynamicpb.NewMessage(schema.Message(name))); err != nil {
+			t.Fatalf("%s does not match the schema: %v", name, err)
+		}
+	}
+	for _, model := range builder.Models().List() {
+		strict("Model", &model)
+	}
+	for _, provider := range builder.Providers().List() {
+		strict("Provider", &provider)
+	}
+}
+
+func testClient(t *testing.T, options ...grpc.ServerOption) (*Service, *grpc.ClientConn) {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	if err := builder.SetProvider(catalogs.Provider{ID: "openai", Name: "OpenAI"}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	for _, id := range []string{"gpt-4o", "gpt-4o-mini", "o3"} {
+		if err := builder.SetProviderModel("openai", catalogs.Model{ID: id, Name: id}); err != nil {
+			t.Fatalf("SetProviderModel: %v", err)
+		}
+	}
+	catalog, err := catalogs.NewCatalog(builder)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+	service, err := NewService(&application.Mock{
+		CatalogStateFunc: func() (starmap.CatalogState, error) {
+			return starmap.CatalogState{Catalog: catalog, GenerationID: "gen-1"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(options...)
+	service.Register(server)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return service, conn
+}
+
+func request(t *testing.T, schema *Schema, name, body string) proto.Message {
+	t.Helper()
+	msg := dynamicpb.NewMessage(schema.Message(name))
+	if err := protojson.Unmarshal([]byte(body), msg); err != nil {
+		t.Fatalf("request %s: %v", name, err)
+	}
+	return msg
+}
+
+func field(msg proto.Message, name string) protoreflect.Value {
+	m := msg.ProtoReflect()
+	return m.Get(m.Descriptor().Fields().ByName(protoreflect.Name(name)))
+}
+
+func TestListModelsPaginatesByID(t *testing.T) {
+	service, conn := testClient(t)
+	ctx := context.Background()
+
+	var ids []string
+	token := ""
+	for page := 0; page < 3; page++ {
+		response := dynamicpb.NewMessage(service.Schema().Message("ListModelsResponse"))
+		in := request(t, service.Schema(), "ListModelsRequest", `{"provider_id": "openai", "page_size": 2, "page_token": "`+token+`"}`)
+		if err := conn.Invoke(ctx, "/"+ServiceName+"/ListModels", in, response); err != nil {
+			t.Fatalf("ListModels: %v", err)
+		}
+		models := field(response, "models").List()
+		for i := range models.Len() {
+			ids = append(ids, field(models.Get(i).Message().Interface(), "id").String())
+		}
+		if got := field(response, "generation_id").String(); got != "gen-1" {
+			t.Errorf("generation_id = %q, want gen-1", got)
+		}
+		if token = field(response, "next_page_token").String(); token == "" {
+			break
+		}
+	}
+	if want := []string{"gpt-4o", "gpt-4o-mini", "o3"}; !slices.Equal(ids, want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+
+	response := dynamicpb.NewMessage(service.Schema().Message("ListModelsResponse"))
+	err := conn.Invoke(ctx, "/"+ServiceName+"/ListModels", request(t, service.Schema(), "ListModelsRequest", `{"page_token": "x"}`), response)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("bad page_token error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestGetModel(t *testing.T) {
+	service, conn := testClient(t)
+	ctx := context.Background()
+
+	model := dynamicpb.NewMessage(service.Schema().Message("Model"))
+	if err := conn.Invoke(ctx, "/"+ServiceName+"/GetModel", request(t, service.Schema(), "GetModelRequest", `{"id": "o3", "provider_id": "openai"}`), model); err != nil {
+		t.Fatalf("GetModel: %v", err)
+	}
+	if got := field(model, "name").String(); got != "o3" {
+		t.Errorf("name = %q, want o3", got)
+	}
+
+	err := conn.Invoke(ctx, "/"+ServiceName+"/GetModel", request(t, service.Schema(), "GetModelRequest", `{"id": "missing"}`), model)
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("missing model error = %v, want NotFound", err)
+	}
+}
+
+func TestWatchChangesStreamsModelChanges(t *testing.T) {
+	service, conn := testClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, "/"+ServiceName+"/WatchChanges")
+	if err != nil {
+		t.Fatalf("NewStream: %v", err)
+	}
+	if err := stream.SendMsg(request(t, service.Schema(), "WatchChangesRequest", `{"provider_id": "openai"}`)); err != nil {
+		t.Fatalf("SendMsg: %v", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+	for deadline := time.Now().Add(time.Second); service.watcherCount() == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("WatchChanges stream was not registered")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	_ = service.Send(events.Event{Type: events.ModelUpdated, Timestamp: time.Now(), Data: events.ModelChange{
+		GenerationID: "gen-2", ProviderID: "anthropic", ModelID: "claude", Model: catalogs.Model{ID: "claude", Name: "Claude"},
+	}})
+	_ = service.Send(events.Event{Type: events.ModelUpdated, Timestamp: time.Now(), Data: events.ModelChange{
+		GenerationID: "gen-2",
+		ProviderID:   "openai",
+		ModelID:      "o3",
+		Model:        catalogs.Model{ID: "o3", Name: "o3 (renamed)"},
+		Changes:      []catalogremote.FieldChange{{Path: "name", Type: "update", OldValue: "o3", NewValue: "o3 (renamed)"}},
+	}})
+
+	event := dynamicpb.NewMessage(service.Schema().Message("ChangeEvent"))
+	if err := stream.RecvMsg(event); err != nil {
+		t.Fatalf("RecvMsg: %v", err)
+	}
+	if got := field(event, "model_id").String(); got != "o3" {
+		t.Fatalf("model_id = %q, want the openai change only", got)
+	}
+	if got := field(field(event, "model").Message().Interface(), "name").String(); got != "o3 (renamed)" {
+		t.Errorf("model name = %q, want o3 (renamed)", got)
+	}
+	if changes := field(event, "changes").List(); changes.Len() != 1 {
+		t.Errorf("changes = %d, want 1", changes.Len())
+	}
+
+	_ = service.Close()
+	if err := stream.RecvMsg(event); status.Code(err) != codes.Unavailable {
+		t.Fatalf("RecvMsg after Close = %v, want Unavailable", err)
+	}
+}
+
+func TestWatchChangesMarksDroppedGenerations(t *testing.T) {
+	service, _ := testClient(t)
+	w := &watcher{events: make(chan ChangeEvent, 1)}
+	service.mu.Lock()
+	service.watchers[w] = struct{}{}
+	service.mu.Unlock()
+
+	for i, change := range []events.ModelChange{
+		{GenerationID: "gen-1", ModelID: "o3"},
+		{GenerationID: "gen-2", ModelID: "o3"},
+		{GenerationID: "gen-3", ModelID: "o3", Test: true},
+	} {
+		if err := service.Send(events.Event{Type: events.ModelUpdated, Timestamp: time.Now(), Data: change}); err != nil {
+			t.Fatalf("Send %d: %v", i, err)
+		}
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	if w.resync != "gen-2" {
+		t.Errorf("resync = %q, want gen-2: dropped test changes must not require a resync", w.resync)
+	}
+	if queued := <-w.events; queued.GenerationID != "gen-1" {
+		t.Errorf("queued generation = %q, want gen-1", queued.GenerationID)
+	}
+}
+
+func TestAuthRequiresAPIKey(t *testing.T) {
+	unary, stream := Auth("x-api-key", "secret")
+	service, conn := testClient(t, grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	in := request(t, service.Schema(), "GetModelRequest", `{"id": "o3"}`)
+	model := dynamicpb.NewMessage(service.Schema().Message("Model"))
+
+	if err := conn.Invoke(context.Background(), "/"+ServiceName+"/GetModel", in, model); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("error without key = %v, want Unauthenticated", err)
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret")
+	if err := conn.Invoke(ctx, "/"+ServiceName+"/GetModel", in, model); err != nil {
+		t.Fatalf("GetModel with key: %v", err)
+	}
+}
+
+func (s *Service) watcherCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.watchers)
+}
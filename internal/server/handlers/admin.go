@@ -5,9 +5,12 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/agentstation/starmap/internal/cli/provider"
+	"github.com/agentstation/starmap/internal/server/apiversion"
 	"github.com/agentstation/starmap/internal/server/events"
 	"github.com/agentstation/starmap/internal/server/response"
 	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
 	"github.com/agentstation/starmap/pkg/sources"
 	"github.com/agentstation/starmap/pkg/sync"
 )
@@ -58,7 +61,7 @@ func (h *Handlers) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 		"sync_run_id":       result.SyncRunID,
 	})
 
-	response.OK(w, map[string]any{
+	apiversion.OK(w, r, map[string]any{
 		"status":            "completed",
 		"total_changes":     result.TotalChanges,
 		"providers_changed": result.ProvidersChanged,
@@ -68,6 +71,74 @@ func (h *Handlers) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// TestEventRequest represents the POST /api/v1/admin/test-event request body.
+type TestEventRequest struct {
+	Kind     string  `json:"kind"`             // price_change or model_removal
+	Provider string  `json:"provider"`         // Provider of the model
+	Model    string  `json:"model"`            // Model ID
+	Factor   float64 `json:"factor,omitempty"` // Token price multiplier of price_change (default 2)
+}
+
+// HandleTestEvent handles POST /api/v1/admin/test-event.
+// @Summary Publish test event
+// @Description Publish a synthetic price change (model.updated) or model removal (model.deleted) for a catalog model to every event subscriber, so consumers can test their change handling. The catalog is not changed; event data carries "test": true and generation_id "test". Served only with serve --test-events.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param event body TestEventRequest true "Synthetic change"
+// @Success 200 {object} response.Response{data=object}
+// @Failure 400 {object} response.Response{error=response.Error}
+// @Failure 404 {object} response.Response{error=response.Error}
+// @Failure 500 {object} response.Response{error=response.Error}
+// @Security ApiKeyAuth
+// @Router /api/v1/admin/test-event [post].
+func (h *Handlers) HandleTestEvent(w http.ResponseWriter, r *http.Request) {
+	var req TestEventRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	if req.Provider == "" || req.Model == "" {
+		response.BadRequest(w, "provider and model are required", "")
+		return
+	}
+	if req.Factor == 0 {
+		req.Factor = events.DefaultTestPriceFactor
+	}
+
+	cat, err := h.app.Catalog()
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+	prov, err := provider.Get(cat, req.Provider)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+	model, ok := prov.Models[req.Model]
+	if !ok || model == nil {
+		response.ErrorFromType(w, &errors.NotFoundError{Resource: "model", ID: req.Model})
+		return
+	}
+
+	published, err := h.broker.PublishTest(events.TestEventKind(req.Kind), prov.ID, *model, req.Factor)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+
+	h.logger.Info().
+		Str("kind", req.Kind).
+		Str("provider", string(prov.ID)).
+		Str("model", model.ID).
+		Msg("Published test event")
+
+	apiversion.OK(w, r, map[string]any{
+		"status": "published",
+		"events": published,
+	})
+}
+
 // HandleStats handles GET /api/v1/stats.
 // @Summary Catalog statistics
 // @Description Get comprehensive server and catalog statistics
@@ -78,7 +149,7 @@ func (h *Handlers) HandleUpdate(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} response.Response{error=response.Error}
 // @Security ApiKeyAuth
 // @Router /api/v1/stats [get].
-func (h *Handlers) HandleStats(w http.ResponseWriter, _ *http.Request) {
+func (h *Handlers) HandleStats(w http.ResponseWriter, r *http.Request) {
 	cat, err := h.app.Catalog()
 	if err != nil {
 		response.InternalError(w, err)
@@ -97,7 +168,7 @@ func (h *Handlers) HandleStats(w http.ResponseWriter, _ *http.Request) {
 		uptime = time.Since(h.startTime)
 	}
 
-	response.OK(w, map[string]any{
+	apiversion.OK(w, r, map[string]any{
 		"runtime": map[string]any{
 			"uptime_seconds": int64(uptime.Seconds()),
 			"goroutines":     runtime.NumGoroutine(),
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+
+	"github.com/agentstation/starmap/internal/server/response"
+)
+
+// decodeJSONBody decodes the request body into v. When it cannot, it writes
+// the error response and returns false: 413 for a body cut off by the
+// server's size limit, 400 for anything else.
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err == nil {
+		return true
+	}
+	var tooLarge *http.MaxBytesError
+	if stderrors.As(err, &tooLarge) {
+		response.PayloadTooLarge(w, err.Error())
+		return false
+	}
+	response.BadRequest(w, "Invalid JSON request body", err.Error())
+	return false
+}
@@ -1,14 +1,26 @@
 package handlers
 
 import (
+	"bytes"
 	"net/http"
 
 	"github.com/agentstation/starmap/internal/server/response"
 	"github.com/agentstation/starmap/pkg/catalogremote"
 	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	"github.com/agentstation/starmap/pkg/errors"
 )
 
 // HandleCatalogManifest serves the current strict generation manifest.
+// @Summary Get catalog manifest
+// @Description Return the manifest of the current catalog generation: its generation ID, payload digest, and size. The digest and size describe the public snapshot, with private model fields withheld. Clients poll it to learn when a new generation is published, then fetch the snapshot by generation ID.
+// @Tags catalog
+// @Produce application/vnd.agentstation.starmap.catalog-manifest+json
+// @Success 200 {object} object "Generation manifest"
+// @Header 200 {string} X-Starmap-Generation-ID "Current generation ID"
+// @Failure 500 {object} response.Response{error=response.Error}
+// @Security ApiKeyAuth
+// @Router /api/v1/catalog/manifest [get].
 func (h *Handlers) HandleCatalogManifest(writer http.ResponseWriter, request *http.Request) {
 	client, err := h.app.Starmap()
 	if err != nil {
@@ -16,6 +28,9 @@ func (h *Handlers) HandleCatalogManifest(writer http.ResponseWriter, request *ht
 		return
 	}
 	generation, err := client.CurrentGeneration(request.Context())
+	if err == nil {
+		generation, err = publicGeneration(generation)
+	}
 	if err != nil {
 		response.InternalError(writer, err)
 		return
@@ -32,6 +47,17 @@ func (h *Handlers) HandleCatalogManifest(writer http.ResponseWriter, request *ht
 }
 
 // HandleCatalogSnapshot serves an immutable canonical payload by generation ID.
+// @Summary Get catalog snapshot
+// @Description Return the canonical catalog payload of a generation, with private model fields withheld. Snapshots never change, so responses may be cached indefinitely.
+// @Tags catalog
+// @Produce application/vnd.agentstation.starmap.catalog+json
+// @Param id path string true "Generation ID"
+// @Success 200 {object} object "Canonical catalog payload"
+// @Header 200 {string} X-Starmap-Generation-ID "Generation ID of the payload"
+// @Failure 404 {string} string "Unknown generation"
+// @Failure 500 {object} response.Response{error=response.Error}
+// @Security ApiKeyAuth
+// @Router /api/v1/catalog/generations/{id}/snapshot [get].
 func (h *Handlers) HandleCatalogSnapshot(writer http.ResponseWriter, request *http.Request, generationID string) {
 	client, err := h.app.Starmap()
 	if err != nil {
@@ -43,8 +69,43 @@ func (h *Handlers) HandleCatalogSnapshot(writer http.ResponseWriter, request *ht
 		http.NotFound(writer, request)
 		return
 	}
+	if generation, err = publicGeneration(generation); err != nil {
+		response.InternalError(writer, err)
+		return
+	}
 	writer.Header().Set("Content-Type", catalogs.CatalogPayloadMediaType)
 	writer.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
 	writer.Header().Set("X-Starmap-Generation-ID", generation.Manifest.GenerationID)
 	_, _ = writer.Write(generation.Payload)
 }
+
+// publicGeneration returns generation with its payload replaced by the public
+// view of the catalog (see catalogs.Catalog.Public) and the manifest's payload
+// descriptor updated to match, so remote clients verify and decode exactly
+// what they are allowed to see. Payloads without private fields are returned
+// as stored.
+func publicGeneration(generation catalogstore.Generation) (catalogstore.Generation, error) {
+	if !bytes.Contains(generation.Payload, []byte(`"private":`)) {
+		return generation, nil
+	}
+	id := generation.Manifest.GenerationID
+	catalog, err := catalogstore.DecodeCatalogPayload(generation.Payload)
+	if err != nil {
+		return catalogstore.Generation{}, errors.WrapResource("decode", "catalog generation", id, err)
+	}
+	public, err := catalog.Public()
+	if err != nil {
+		return catalogstore.Generation{}, err
+	}
+	if public == catalog {
+		return generation, nil
+	}
+	payload, err := catalogstore.EncodeCatalogPayload(public)
+	if err != nil {
+		return catalogstore.Generation{}, errors.WrapResource("encode", "public catalog generation", id, err)
+	}
+	generation = generation.Copy()
+	generation.Payload = payload
+	generation.Manifest.Payload = catalogs.DescribeCatalogPayload(payload)
+	return generation, nil
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/agentstation/starmap/internal/server/apiversion"
+	"github.com/agentstation/starmap/internal/server/response"
+	"github.com/agentstation/starmap/pkg/catalogremote"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	"github.com/agentstation/starmap/pkg/differ"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// HandleGetChanges handles GET /api/v1/changes.
+// @Summary Get catalog changes since a generation
+// @Description Return the changes between a past catalog generation and the current one so clients can sync incrementally. Pass the generation ID from a previous response's X-Starmap-Generation-ID header or the catalog manifest. Added and updated entries carry full resources; removed providers and authors carry only IDs.
+// @Tags catalog
+// @Accept json
+// @Produce json
+// @Param since query string true "Generation ID the client last synced"
+// @Success 200 {object} response.Response{data=catalogremote.Changes}
+// @Failure 400 {object} response.Response{error=response.Error}
+// @Failure 404 {object} response.Response{error=response.Error}
+// @Failure 500 {object} response.Response{error=response.Error}
+// @Security ApiKeyAuth
+// @Router /api/v1/changes [get].
+func (h *Handlers) HandleGetChanges(w http.ResponseWriter, r *http.Request) {
+	since := strings.TrimSpace(r.URL.Query().Get("since"))
+	if since == "" {
+		response.ErrorFromType(w, &errors.ValidationError{Field: "since", Message: "is required"})
+		return
+	}
+	state, err := h.app.CatalogState()
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+	w.Header().Set("X-Starmap-Generation-ID", state.GenerationID)
+
+	cacheKey := "changes:" + since
+	if cached, found := h.cache.GetGeneration(state.Sequence, state.GenerationID, cacheKey); found {
+		apiversion.OK(w, r, cached)
+		return
+	}
+
+	previous, err := h.sinceCatalog(r, since, state.GenerationID, state.Catalog)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+	result := catalogremote.NewChanges(since, state.GenerationID, differ.New().Catalogs(previous, state.Catalog))
+
+	h.cache.SetGeneration(state.Sequence, state.GenerationID, cacheKey, result)
+
+	apiversion.OK(w, r, result)
+}
+
+// sinceCatalog loads the public view of the catalog published as generation
+// since from the catalog store.
+func (h *Handlers) sinceCatalog(r *http.Request, since, current string, catalog *catalogs.Catalog) (catalogs.Reader, error) {
+	if since == current {
+		return catalog, nil
+	}
+	client, err := h.app.Starmap()
+	if err != nil {
+		return nil, err
+	}
+	generation, err := client.Generation(r.Context(), since)
+	if err != nil {
+		return nil, err
+	}
+	published, err := catalogstore.DecodeCatalogPayload(generation.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return published.Public()
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/agentstation/starmap/internal/server/params"
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/provenance"
+)
+
+// expandedDefinition is a model definition with requested optional sections.
+// Pricing, benchmarks, and evaluations are provider facts, so they are keyed
+// by provider ID.
+type expandedDefinition struct {
+	catalogs.ModelDefinition
+	Pricing     map[catalogs.ProviderID]*catalogs.ModelPricing     `json:"pricing,omitempty"`
+	Benchmarks  map[catalogs.ProviderID]*catalogs.ModelPerformance `json:"benchmarks,omitempty"`
+	Evaluations map[catalogs.ProviderID]*catalogs.ModelEvaluations `json:"evaluations,omitempty"`
+	Provenance  map[string][]provenance.Provenance                 `json:"provenance,omitempty"`
+}
+
+// expandDefinition attaches the requested sections to definition. It returns
+// definition unchanged when nothing was requested.
+func expandDefinition(cat catalogs.Reader, definition catalogs.ModelDefinition, expand params.Expand) any {
+	if !expand.Any() {
+		return definition
+	}
+	expanded := expandedDefinition{ModelDefinition: definition}
+	id := string(definition.ID)
+	if expand.Has(params.ExpandPricing) || expand.Has(params.ExpandBenchmarks) || expand.Has(params.ExpandEvaluations) {
+		for owner, model := range catalogs.ModelRecords(cat, id) {
+			providerID, ok := strings.CutPrefix(owner, "providers/")
+			if !ok {
+				continue
+			}
+			if expand.Has(params.ExpandPricing) && model.Pricing != nil {
+				if expanded.Pricing == nil {
+					expanded.Pricing = make(map[catalogs.ProviderID]*catalogs.ModelPricing)
+				}
+				expanded.Pricing[catalogs.ProviderID(providerID)] = model.Pricing
+			}
+			if expand.Has(params.ExpandBenchmarks) && model.Performance != nil {
+				if expanded.Benchmarks == nil {
+					expanded.Benchmarks = make(map[catalogs.ProviderID]*catalogs.ModelPerformance)
+				}
+				expanded.Benchmarks[catalogs.ProviderID(providerID)] = model.Performance
+			}
+			if expand.Has(params.ExpandEvaluations) && model.Evaluations != nil {
+				if expanded.Evaluations == nil {
+					expanded.Evaluations = make(map[catalogs.ProviderID]*catalogs.ModelEvaluations)
+				}
+				expanded.Evaluations[catalogs.ProviderID(providerID)] = model.Evaluations
+			}
+		}
+	}
+	if expand.Has(params.ExpandProvenance) {
+		expanded.Provenance = modelProvenance(cat, id)
+	}
+	return expanded
+}
+
+// expandedModel is a provider model with requested optional sections.
+type expandedModel struct {
+	catalogs.Model
+	Provenance map[string][]provenance.Provenance `json:"provenance,omitempty"`
+}
+
+// expandModels applies expansion to provider model listings. Their v1 shape
+// inlines pricing, performance, and evaluations, so without expand the models are returned
+// unchanged; with expand, only the named sections are kept.
+func expandModels(cat catalogs.Reader, models []catalogs.Model, expand params.Expand) any {
+	if !expand.Requested {
+		return models
+	}
+	expanded := make([]expandedModel, 0, len(models))
+	for _, model := range models {
+		if !expand.Has(params.ExpandPricing) {
+			model.Pricing = nil
+		}
+		if !expand.Has(params.ExpandBenchmarks) {
+			model.Performance = nil
+		}
+		if !expand.Has(params.ExpandEvaluations) {
+			model.Evaluations = nil
+		}
+		item := expandedModel{Model: model}
+		if expand.Has(params.ExpandProvenance) {
+			item.Provenance = modelProvenance(cat, model.ID)
+		}
+		expanded = append(expanded, item)
+	}
+	return expanded
+}
+
+func modelProvenance(cat catalogs.Reader, modelID string) map[string][]provenance.Provenance {
+	fields := cat.Provenance().FindByResource(catalogmeta.ResourceTypeModel, modelID)
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/view"
 	"github.com/agentstation/starmap/internal/server/cache"
 	"github.com/agentstation/starmap/internal/server/events"
 	"github.com/agentstation/starmap/internal/server/sse"
@@ -24,6 +25,7 @@ type Handlers struct {
 	upgrader       websocket.Upgrader
 	logger         *zerolog.Logger
 	startTime      time.Time
+	views          *view.Set
 }
 
 // New creates a new Handlers instance.
@@ -36,6 +38,7 @@ func New(
 	upgrader websocket.Upgrader,
 	logger *zerolog.Logger,
 	startTime time.Time,
+	views *view.Set,
 ) *Handlers {
 	return &Handlers{
 		app:            app,
@@ -46,5 +49,6 @@ func New(
 		upgrader:       upgrader,
 		logger:         logger,
 		startTime:      startTime,
+		views:          views,
 	}
 }
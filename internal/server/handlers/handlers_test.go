@@ -85,6 +85,127 @@ func TestHandleListModelsUsesSharedPagination(t *testing.T) {
 	}
 }
 
+func TestHandleBatchGetModelsReportsMissingIDs(t *testing.T) {
+	cat := catalogs.NewEmpty()
+	if err := cat.SetProvider(catalogs.Provider{
+		ID:   "provider",
+		Name: "Provider",
+		Models: map[string]*catalogs.Model{
+			"model-a": {ID: "model-a", Name: "Model A"},
+			"model-b": {ID: "model-b", Name: "Model B"},
+		},
+	}); err != nil {
+		t.Fatalf("Failed to seed catalog: %v", err)
+	}
+	h := newTestHandlers(cat)
+
+	body := `{"ids": ["model-b", "unknown", "model-a", "model-b"]}`
+	rec := httptest.NewRecorder()
+	h.HandleBatchGetModels(rec, httptest.NewRequest(http.MethodPost, "/api/v1/models:batchGet", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var got struct {
+		Data struct {
+			Models []struct {
+				ID string `json:"id"`
+			} `json:"models"`
+			Missing []string `json:"missing"`
+			Count   int      `json:"count"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if got.Data.Count != 2 || got.Data.Models[0].ID != "model-b" || got.Data.Models[1].ID != "model-a" {
+		t.Fatalf("Unexpected models: %#v", got.Data)
+	}
+	if len(got.Data.Missing) != 1 || got.Data.Missing[0] != "unknown" {
+		t.Fatalf("Unexpected missing IDs: %v", got.Data.Missing)
+	}
+
+	tooMany := `{"ids": [` + strings.TrimSuffix(strings.Repeat(`"m",`, MaxBatchGetModels+1), ",") + `]}`
+	for _, body := range []string{`{"ids": []}`, tooMany, `not json`} {
+		rec := httptest.NewRecorder()
+		h.HandleBatchGetModels(rec, httptest.NewRequest(http.MethodPost, "/api/v1/models:batchGet", strings.NewReader(body)))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("body %.20q status = %d, want %d", body, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestHandleModelsExpandOptionalSections(t *testing.T) {
+	mmlu := 88.7
+	cat := catalogs.NewEmpty()
+	if err := cat.SetProvider(catalogs.Provider{
+		ID:   "provider",
+		Name: "Provider",
+		Models: map[string]*catalogs.Model{
+			"model-a": {
+				ID:          "model-a",
+				Name:        "Model A",
+				Pricing:     &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{Input: &catalogs.ModelTokenCost{Per1M: 1}}},
+				Performance: &catalogs.ModelPerformance{OutputTokensPerSecond: 90},
+				Evaluations: &catalogs.ModelEvaluations{MMLU: &mmlu},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("Failed to seed catalog: %v", err)
+	}
+	h := newTestHandlers(cat)
+
+	get := func(query string) map[string]any {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.HandleGetModel(rec, httptest.NewRequest(http.MethodGet, "/api/v1/models/model-a"+query, nil), "model-a")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %q status = %d: %s", query, rec.Code, rec.Body.String())
+		}
+		var got response.Response
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return got.Data.(map[string]any)
+	}
+	if data := get(""); data["pricing"] != nil || data["benchmarks"] != nil || data["evaluations"] != nil {
+		t.Fatalf("Default model payload includes optional sections: %#v", data)
+	}
+	data := get("?expand=pricing,benchmarks,evaluations")
+	pricing, _ := data["pricing"].(map[string]any)
+	benchmarks, _ := data["benchmarks"].(map[string]any)
+	evaluations, _ := data["evaluations"].(map[string]any)
+	if pricing["provider"] == nil || benchmarks["provider"] == nil || evaluations["provider"] == nil || data["id"] != "model-a" {
+		t.Fatalf("Expanded model payload = %#v", data)
+	}
+
+	rec := httptest.NewRecorder()
+	h.HandleListModels(rec, httptest.NewRequest(http.MethodGet, "/api/v1/models?expand=benchmarks", nil))
+	var list response.Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	model := list.Data.(map[string]any)["models"].([]any)[0].(map[string]any)
+	if model["pricing"] != nil || model["performance"] == nil || model["evaluations"] != nil {
+		t.Fatalf("List with expand=benchmarks = %#v", model)
+	}
+
+	rec = httptest.NewRecorder()
+	h.HandleListModels(rec, httptest.NewRequest(http.MethodGet, "/api/v1/models?expand=evaluations", nil))
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	model = list.Data.(map[string]any)["models"].([]any)[0].(map[string]any)
+	if model["performance"] != nil || model["evaluations"] == nil {
+		t.Fatalf("List with expand=evaluations = %#v", model)
+	}
+
+	rec = httptest.NewRecorder()
+	h.HandleGetModel(rec, httptest.NewRequest(http.MethodGet, "/api/v1/models/model-a?expand=weights", nil), "model-a")
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Unknown expand section status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
 func TestHandleListModelsRejectsInvalidSortAndFilterParameters(t *testing.T) {
 	h := newTestHandlers(catalogs.NewEmpty())
 	for _, query := range []string{"sort=price", "limit=invalid", "feature=invented", "min_context=100&max_context=10"} {
@@ -233,6 +354,38 @@ func TestHandleListProvidersUsesSharedProviderQuery(t *testing.T) {
 	}
 }
 
+func TestHandleListProvidersFiltersBySLA(t *testing.T) {
+	uptime := 99.9
+	cat := catalogs.NewEmpty()
+	if err := cat.SetProvider(catalogs.Provider{ID: "covered", Name: "Covered", SLA: &catalogs.ProviderSLA{UptimeCommitment: &uptime}}); err != nil {
+		t.Fatalf("Failed to seed covered provider: %v", err)
+	}
+	if err := cat.SetProvider(catalogs.Provider{ID: "uncovered", Name: "Uncovered"}); err != nil {
+		t.Fatalf("Failed to seed uncovered provider: %v", err)
+	}
+	h := newTestHandlers(cat)
+
+	rec := httptest.NewRecorder()
+	h.HandleListProviders(rec, httptest.NewRequest(http.MethodGet, "/api/v1/providers?min_uptime=99.5", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var got response.Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	providers := got.Data.(map[string]any)["providers"].([]any)
+	if len(providers) != 1 || providers[0].(map[string]any)["id"] != "covered" {
+		t.Fatalf("Expected only the covered provider, got %#v", providers)
+	}
+
+	rec = httptest.NewRecorder()
+	h.HandleListProviders(rec, httptest.NewRequest(http.MethodGet, "/api/v1/providers?min_uptime=high", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status %d for malformed min_uptime, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
 func newTestHandlers(cat *catalogs.Builder) *Handlers {
 	return &Handlers{
 		app: &application.Mock{
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/agentstation/starmap/internal/server/apiversion"
 	"github.com/agentstation/starmap/internal/server/response"
 )
 
@@ -15,11 +16,11 @@ import (
 // @Produce json
 // @Success 200 {object} response.Response{data=object}
 // @Router /api/v1/health [get].
-func (h *Handlers) HandleHealth(w http.ResponseWriter, _ *http.Request) {
-	response.OK(w, map[string]any{
+func (h *Handlers) HandleHealth(w http.ResponseWriter, r *http.Request) {
+	apiversion.OK(w, r, map[string]any{
 		"status":  "healthy",
 		"service": "starmap-api",
-		"version": "v1",
+		"version": string(apiversion.FromContext(r.Context())),
 	})
 }
 
@@ -32,7 +33,7 @@ func (h *Handlers) HandleHealth(w http.ResponseWriter, _ *http.Request) {
 // @Success 200 {object} response.Response{data=object}
 // @Failure 503 {object} response.Response{error=response.Error}
 // @Router /api/v1/ready [get].
-func (h *Handlers) HandleReady(w http.ResponseWriter, _ *http.Request) {
+func (h *Handlers) HandleReady(w http.ResponseWriter, r *http.Request) {
 	readiness, err := h.app.Readiness()
 	if err != nil {
 		response.ServiceUnavailable(w, "Catalog not available")
@@ -47,7 +48,7 @@ func (h *Handlers) HandleReady(w http.ResponseWriter, _ *http.Request) {
 		return
 	}
 
-	response.OK(w, map[string]any{
+	apiversion.OK(w, r, map[string]any{
 		"status":  "ready",
 		"catalog": readiness,
 		"cache": map[string]any{
@@ -9,6 +9,7 @@ import (
 
 	"github.com/agentstation/starmap"
 	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/server/apiversion"
 	"github.com/agentstation/starmap/internal/server/response"
 )
 
@@ -34,3 +35,23 @@ func TestEmbeddedBudgetReadinessEndpointFailsWithStableReason(t *testing.T) {
 		t.Fatalf("response = %#v", body)
 	}
 }
+
+func TestHealthReportsRequestAPIVersion(t *testing.T) {
+	handler := &Handlers{}
+	for _, version := range apiversion.Supported() {
+		request := httptest.NewRequest(http.MethodGet, "/api/"+string(version)+"/health", nil)
+		request = request.WithContext(apiversion.WithVersion(request.Context(), version))
+		recorder := httptest.NewRecorder()
+		handler.HandleHealth(recorder, request)
+
+		var body struct {
+			Data map[string]string `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Unmarshal response: %v", err)
+		}
+		if body.Data["version"] != string(version) {
+			t.Errorf("%s health version = %q", version, body.Data["version"])
+		}
+	}
+}
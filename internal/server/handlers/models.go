@@ -1,19 +1,22 @@
 package handlers
 
 import (
-	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/agentstation/starmap/internal/catalog/query"
+	"github.com/agentstation/starmap/internal/server/apiversion"
 	"github.com/agentstation/starmap/internal/server/params"
 	"github.com/agentstation/starmap/internal/server/response"
 	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
 )
 
 // HandleListModels handles GET /api/v1/models.
 // @Summary List models
-// @Description List all models with optional filtering
+// @Description List all models with optional filtering. Passing expand keeps only the named optional sections (pricing, benchmarks) and can add provenance.
 // @Tags models
 // @Accept json
 // @Produce json
@@ -31,10 +34,18 @@ import (
 // @Param max_context query integer false "Maximum context window size"
 // @Param min_input query integer false "Minimum input token limit"
 // @Param max_input query integer false "Maximum input token limit"
-// @Param sort query string false "Sort field (id, name, release_date, context_window, created_at, updated_at)"
+// @Param min_output_speed query number false "Minimum output tokens per second"
+// @Param max_ttft_ms query integer false "Maximum median time to first token in milliseconds"
+// @Param use query string false "Comma-separated intended uses the model's usage restrictions must permit (e.g., medical_advice,facial_recognition)"
+// @Param region query string false "Deployment region (ISO 3166-1 alpha-2 code or EU) the model must not be blocked in"
+// @Param curation query string false "Comma-separated curation tags the model must carry (e.g., approved-for-prod)"
+// @Param exclude_curation query string false "Comma-separated curation tags the model must not carry (e.g., banned)"
+// @Param review query string false "Review state to list (pending-review, approved, rejected, or any); default lists models cleared to serve"
+// @Param sort query string false "Sort field (id, name, release_date, context_window, created_at, updated_at, output_speed, time_to_first_token)"
 // @Param order query string false "Sort order (asc, desc)"
 // @Param limit query integer false "Maximum number of results (default: 100, max: 1000)"
 // @Param offset query integer false "Result offset for pagination"
+// @Param expand query string false "Comma-separated optional sections to serialize: pricing, benchmarks, evaluations, provenance, or none"
 // @Success 200 {object} response.Response{data=object}
 // @Failure 400 {object} response.Response{error=response.Error}
 // @Failure 500 {object} response.Response{error=response.Error}
@@ -50,7 +61,7 @@ func (h *Handlers) HandleListModels(w http.ResponseWriter, r *http.Request) {
 	// Check cache
 	cacheKey := "models:" + r.URL.RawQuery
 	if cached, found := h.cache.GetGeneration(state.Sequence, state.GenerationID, cacheKey); found {
-		response.OK(w, cached)
+		apiversion.OK(w, r, cached)
 		return
 	}
 
@@ -63,6 +74,11 @@ func (h *Handlers) HandleListModels(w http.ResponseWriter, r *http.Request) {
 		response.ErrorFromType(w, err)
 		return
 	}
+	expand, err := params.ParseExpand(r)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
 
 	// Get exact provider offerings before applying model field filters.
 	allModels, err := query.CatalogModels(cat, f.Provider)
@@ -70,13 +86,14 @@ func (h *Handlers) HandleListModels(w http.ResponseWriter, r *http.Request) {
 		response.ErrorFromType(w, err)
 		return
 	}
+	f.UsageProvider, _ = cat.Providers().Get(catalogs.ProviderID(f.Provider))
 	filtered := f.Apply(allModels)
 
 	page := query.Paginate(filtered, f.Limit, f.Offset)
 
 	// Build response
 	result := map[string]any{
-		"models": page.Items,
+		"models": expandModels(cat, page.Items, expand),
 		"pagination": map[string]any{
 			"total":  page.Total,
 			"limit":  page.Limit,
@@ -88,32 +105,42 @@ func (h *Handlers) HandleListModels(w http.ResponseWriter, r *http.Request) {
 	// Cache result
 	h.cache.SetGeneration(state.Sequence, state.GenerationID, cacheKey, result)
 
-	response.OK(w, result)
+	apiversion.OK(w, r, result)
 }
 
 // HandleGetModel handles GET /api/v1/models/{id}.
 // @Summary Get model by ID
-// @Description Retrieve detailed information about a specific model
+// @Description Retrieve detailed information about a specific model. The ETag header carries the model's revision for If-Match on write calls.
 // @Tags models
 // @Accept json
 // @Produce json
 // @Param id path string true "Model ID"
+// @Param expand query string false "Comma-separated optional sections to serialize: pricing, benchmarks, evaluations, provenance, or none"
 // @Success 200 {object} response.Response{data=catalogs.ModelDefinition}
+// @Header 200 {string} ETag "Model revision"
 // @Failure 404 {object} response.Response{error=response.Error}
 // @Failure 500 {object} response.Response{error=response.Error}
 // @Security ApiKeyAuth
 // @Router /api/v1/models/{id} [get].
-func (h *Handlers) HandleGetModel(w http.ResponseWriter, _ *http.Request, modelID string) {
+func (h *Handlers) HandleGetModel(w http.ResponseWriter, r *http.Request, modelID string) {
+	expand, err := params.ParseExpand(r)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
 	state, err := h.app.CatalogState()
 	if err != nil {
 		response.InternalError(w, err)
 		return
 	}
 	w.Header().Set("X-Starmap-Generation-ID", state.GenerationID)
+	h.setETag(w, state, "model:"+modelID, func() (string, error) {
+		return catalogs.ModelRevision(state.Catalog, modelID)
+	})
 	// Check cache
 	cacheKey := "model:" + modelID
 	if cached, found := h.cache.GetGeneration(state.Sequence, state.GenerationID, cacheKey); found {
-		response.OK(w, cached)
+		apiversion.OK(w, r, expandDefinition(state.Catalog, cached.(catalogs.ModelDefinition), expand))
 		return
 	}
 
@@ -130,26 +157,116 @@ func (h *Handlers) HandleGetModel(w http.ResponseWriter, _ *http.Request, modelI
 	// Cache result
 	h.cache.SetGeneration(state.Sequence, state.GenerationID, cacheKey, model)
 
-	response.OK(w, model)
+	apiversion.OK(w, r, expandDefinition(cat, model, expand))
+}
+
+// MaxBatchGetModels is the most model IDs one batchGet request may resolve.
+const MaxBatchGetModels = 500
+
+// BatchGetRequest represents the POST /api/v1/models:batchGet request body.
+type BatchGetRequest struct {
+	IDs []string `json:"ids"` // Model IDs to resolve, at most MaxBatchGetModels
+}
+
+// HandleBatchGetModels handles POST /api/v1/models:batchGet.
+// @Summary Batch get models by ID
+// @Description Resolve many models in one round trip. Models are returned in request order with duplicates removed; IDs that do not resolve are listed in missing instead of failing the request. At most 500 IDs per request.
+// @Tags models
+// @Accept json
+// @Produce json
+// @Param batch body BatchGetRequest true "Model IDs"
+// @Param expand query string false "Comma-separated optional sections to serialize: pricing, benchmarks, evaluations, provenance, or none"
+// @Success 200 {object} response.Response{data=object}
+// @Failure 400 {object} response.Response{error=response.Error}
+// @Failure 500 {object} response.Response{error=response.Error}
+// @Security ApiKeyAuth
+// @Router /api/v1/models:batchGet [post].
+func (h *Handlers) HandleBatchGetModels(w http.ResponseWriter, r *http.Request) {
+	var req BatchGetRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	if len(req.IDs) == 0 {
+		response.BadRequest(w, "ids is required", "")
+		return
+	}
+	if len(req.IDs) > MaxBatchGetModels {
+		response.BadRequest(w, "Too many model IDs", fmt.Sprintf("at most %d IDs per request, got %d", MaxBatchGetModels, len(req.IDs)))
+		return
+	}
+	expand, err := params.ParseExpand(r)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+
+	state, err := h.app.CatalogState()
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+	w.Header().Set("X-Starmap-Generation-ID", state.GenerationID)
+
+	models := make([]any, 0, len(req.IDs))
+	missing := make([]string, 0)
+	seen := make(map[string]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		// Share cache entries with GET /models/{id}
+		cacheKey := "model:" + id
+		if cached, found := h.cache.GetGeneration(state.Sequence, state.GenerationID, cacheKey); found {
+			models = append(models, expandDefinition(state.Catalog, cached.(catalogs.ModelDefinition), expand))
+			continue
+		}
+		model, err := state.Catalog.FindModel(id)
+		if errors.IsNotFound(err) {
+			missing = append(missing, id)
+			continue
+		}
+		if err != nil {
+			response.ErrorFromType(w, err)
+			return
+		}
+		h.cache.SetGeneration(state.Sequence, state.GenerationID, cacheKey, model)
+		models = append(models, expandDefinition(state.Catalog, model, expand))
+	}
+
+	apiversion.OK(w, r, map[string]any{
+		"models":  models,
+		"missing": missing,
+		"count":   len(models),
+	})
 }
 
 // SearchRequest represents the POST /api/v1/models/search request body.
 type SearchRequest struct {
-	IDs           []string          `json:"ids,omitempty"`
-	NameContains  string            `json:"name_contains,omitempty"`
-	Provider      string            `json:"provider,omitempty"`
-	Status        string            `json:"status,omitempty"`
-	Modalities    *SearchModalities `json:"modalities,omitempty"`
-	Features      map[string]bool   `json:"features,omitempty"`
-	Tags          []string          `json:"tags,omitempty"`
-	OpenWeights   *bool             `json:"open_weights,omitempty"`
-	ContextWindow *IntRange         `json:"context_window,omitempty"`
-	InputTokens   *IntRange         `json:"input_tokens,omitempty"`
-	OutputTokens  *IntRange         `json:"output_tokens,omitempty"`
-	ReleaseDate   *DateRange        `json:"release_date,omitempty"`
-	Sort          string            `json:"sort,omitempty"`
-	Order         string            `json:"order,omitempty"`
-	MaxResults    int               `json:"max_results,omitempty"`
+	IDs             []string          `json:"ids,omitempty"`
+	NameContains    string            `json:"name_contains,omitempty"`
+	Provider        string            `json:"provider,omitempty"`
+	Status          string            `json:"status,omitempty"`
+	Modalities      *SearchModalities `json:"modalities,omitempty"`
+	Features        map[string]bool   `json:"features,omitempty"`
+	Tags            []string          `json:"tags,omitempty"`
+	OpenWeights     *bool             `json:"open_weights,omitempty"`
+	ContextWindow   *IntRange         `json:"context_window,omitempty"`
+	InputTokens     *IntRange         `json:"input_tokens,omitempty"`
+	MinOutputSpeed  float64           `json:"min_output_speed,omitempty"`
+	MaxTTFTMs       int64             `json:"max_ttft_ms,omitempty"`
+	Uses            []string          `json:"uses,omitempty"`
+	Region          string            `json:"region,omitempty"`
+	Curation        []string          `json:"curation,omitempty"`
+	ExcludeCuration []string          `json:"exclude_curation,omitempty"`
+	Review          string            `json:"review,omitempty"`
+	OutputTokens    *IntRange         `json:"output_tokens,omitempty"`
+	ReleaseDate     *DateRange        `json:"release_date,omitempty"`
+	Sort            string            `json:"sort,omitempty"`
+	Order           string            `json:"order,omitempty"`
+	MaxResults      int               `json:"max_results,omitempty"`
 }
 
 // SearchModalities specifies modality requirements.
@@ -177,6 +294,7 @@ type DateRange struct {
 // @Accept json
 // @Produce json
 // @Param search body SearchRequest true "Search criteria"
+// @Param expand query string false "Comma-separated optional sections to serialize: pricing, benchmarks, evaluations, provenance, or none"
 // @Success 200 {object} response.Response{data=object}
 // @Failure 400 {object} response.Response{error=response.Error}
 // @Failure 500 {object} response.Response{error=response.Error}
@@ -184,8 +302,12 @@ type DateRange struct {
 // @Router /api/v1/models/search [post].
 func (h *Handlers) HandleSearchModels(w http.ResponseWriter, r *http.Request) {
 	var req SearchRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.BadRequest(w, "Invalid JSON request body", err.Error())
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	expand, err := params.ParseExpand(r)
+	if err != nil {
+		response.ErrorFromType(w, err)
 		return
 	}
 
@@ -229,6 +351,19 @@ func (h *Handlers) HandleSearchModels(w http.ResponseWriter, r *http.Request) {
 		f.MaxOutput = req.OutputTokens.Max
 	}
 
+	f.MinOutputSpeed = req.MinOutputSpeed
+	f.MaxTimeToFirstToken = time.Duration(req.MaxTTFTMs) * time.Millisecond
+	for _, use := range req.Uses {
+		f.Uses = append(f.Uses, catalogs.UsageRestriction(strings.ToLower(strings.TrimSpace(use))))
+	}
+	f.Region = req.Region
+	f.Curation = req.Curation
+	f.ExcludeCuration = req.ExcludeCuration
+	if f.Review, err = query.ParseReviewFilter(req.Review); err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+
 	if req.ReleaseDate != nil {
 		if req.ReleaseDate.After != "" {
 			after, err := time.Parse(time.RFC3339, req.ReleaseDate.After)
@@ -258,6 +393,7 @@ func (h *Handlers) HandleSearchModels(w http.ResponseWriter, r *http.Request) {
 		response.ErrorFromType(w, err)
 		return
 	}
+	f.UsageProvider, _ = cat.Providers().Get(catalogs.ProviderID(f.Provider))
 	results := f.Apply(allModels)
 
 	// Filter by IDs if specified
@@ -282,9 +418,9 @@ func (h *Handlers) HandleSearchModels(w http.ResponseWriter, r *http.Request) {
 
 	// Build response
 	result := map[string]any{
-		"models": results,
+		"models": expandModels(cat, results, expand),
 		"count":  len(results),
 	}
 
-	response.OK(w, result)
+	apiversion.OK(w, r, result)
 }
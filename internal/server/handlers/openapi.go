@@ -12,7 +12,8 @@ import (
 // @Tags meta
 // @Produce json
 // @Success 200 {object} object "OpenAPI 3.1 specification"
-// @Router /api/v1/openapi.json [get].
+// @Router /api/v1/openapi.json [get]
+// @Router /openapi.json [get].
 func (h *Handlers) HandleOpenAPIJSON(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Cache-Control", "public, max-age=3600") // Cache for 1 hour
@@ -28,7 +29,8 @@ func (h *Handlers) HandleOpenAPIJSON(w http.ResponseWriter, _ *http.Request) {
 // @Tags meta
 // @Produce application/x-yaml
 // @Success 200 {string} string "OpenAPI 3.1 specification"
-// @Router /api/v1/openapi.yaml [get].
+// @Router /api/v1/openapi.yaml [get]
+// @Router /openapi.yaml [get].
 func (h *Handlers) HandleOpenAPIYAML(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "application/x-yaml")
 	w.Header().Set("Cache-Control", "public, max-age=3600") // Cache for 1 hour
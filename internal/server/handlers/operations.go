@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/agentstation/starmap/internal/server/apiversion"
 	"github.com/agentstation/starmap/internal/server/response"
 )
 
@@ -21,5 +22,5 @@ func (h *Handlers) HandleOperations(w http.ResponseWriter, r *http.Request) {
 		response.InternalError(w, err)
 		return
 	}
-	response.OK(w, state)
+	apiversion.OK(w, r, state)
 }
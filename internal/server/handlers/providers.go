@@ -5,36 +5,51 @@ import (
 
 	"github.com/agentstation/starmap/internal/catalog/query"
 	"github.com/agentstation/starmap/internal/cli/provider"
+	"github.com/agentstation/starmap/internal/server/apiversion"
+	"github.com/agentstation/starmap/internal/server/params"
 	"github.com/agentstation/starmap/internal/server/response"
+	"github.com/agentstation/starmap/pkg/catalogs"
 )
 
 // HandleListProviders handles GET /api/v1/providers.
 // @Summary List providers
-// @Description List all providers
+// @Description List all providers, optionally filtered by SLA commitments
 // @Tags providers
 // @Accept json
 // @Produce json
+// @Param sla query boolean false "Only providers with a published SLA"
+// @Param min_uptime query number false "Minimum committed uptime percentage"
+// @Param use query string false "Comma-separated intended uses the provider's usage policy must permit"
+// @Param region query string false "Deployment region (ISO 3166-1 alpha-2 code or EU) the provider must serve"
 // @Success 200 {object} response.Response{data=object}
+// @Failure 400 {object} response.Response{error=response.Error}
 // @Failure 500 {object} response.Response{error=response.Error}
 // @Security ApiKeyAuth
 // @Router /api/v1/providers [get].
-func (h *Handlers) HandleListProviders(w http.ResponseWriter, _ *http.Request) {
+func (h *Handlers) HandleListProviders(w http.ResponseWriter, r *http.Request) {
 	state, err := h.app.CatalogState()
 	if err != nil {
 		response.InternalError(w, err)
 		return
 	}
 	w.Header().Set("X-Starmap-Generation-ID", state.GenerationID)
+	opts, err := params.ParseProviderOptions(r)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+
 	// Check cache
-	if cached, found := h.cache.GetGeneration(state.Sequence, state.GenerationID, "providers"); found {
-		response.OK(w, cached)
+	cacheKey := "providers:" + r.URL.RawQuery
+	if cached, found := h.cache.GetGeneration(state.Sequence, state.GenerationID, cacheKey); found {
+		apiversion.OK(w, r, cached)
 		return
 	}
 
 	// Get catalog
 	cat := state.Catalog
 
-	providers := query.Providers(cat.Providers().List(), query.ProviderOptions{})
+	providers := query.Providers(cat.Providers().List(), opts)
 
 	// Build simplified provider list
 	providerList := make([]map[string]any, 0, len(providers))
@@ -62,34 +77,42 @@ func (h *Handlers) HandleListProviders(w http.ResponseWriter, _ *http.Request) {
 	}
 
 	// Cache result
-	h.cache.SetGeneration(state.Sequence, state.GenerationID, "providers", result)
+	h.cache.SetGeneration(state.Sequence, state.GenerationID, cacheKey, result)
 
-	response.OK(w, result)
+	apiversion.OK(w, r, result)
 }
 
 // HandleGetProvider handles GET /api/v1/providers/{id}.
 // @Summary Get provider by ID
-// @Description Retrieve detailed information about a specific provider
+// @Description Retrieve detailed information about a specific provider. The ETag header carries the provider's revision.
 // @Tags providers
 // @Accept json
 // @Produce json
 // @Param id path string true "Provider ID"
 // @Success 200 {object} response.Response{data=catalogs.Provider}
+// @Header 200 {string} ETag "Provider revision"
 // @Failure 404 {object} response.Response{error=response.Error}
 // @Failure 500 {object} response.Response{error=response.Error}
 // @Security ApiKeyAuth
 // @Router /api/v1/providers/{id} [get].
-func (h *Handlers) HandleGetProvider(w http.ResponseWriter, _ *http.Request, providerID string) {
+func (h *Handlers) HandleGetProvider(w http.ResponseWriter, r *http.Request, providerID string) {
 	state, err := h.app.CatalogState()
 	if err != nil {
 		response.InternalError(w, err)
 		return
 	}
 	w.Header().Set("X-Starmap-Generation-ID", state.GenerationID)
+	h.setETag(w, state, "provider:"+providerID, func() (string, error) {
+		prov, err := provider.Get(state.Catalog, providerID)
+		if err != nil {
+			return "", err
+		}
+		return catalogs.ProviderRevision(*prov)
+	})
 	// Check cache
 	cacheKey := "provider:" + providerID
 	if cached, found := h.cache.GetGeneration(state.Sequence, state.GenerationID, cacheKey); found {
-		response.OK(w, cached)
+		apiversion.OK(w, r, cached)
 		return
 	}
 
@@ -106,7 +129,7 @@ func (h *Handlers) HandleGetProvider(w http.ResponseWriter, _ *http.Request, pro
 	// Cache result
 	h.cache.SetGeneration(state.Sequence, state.GenerationID, cacheKey, prov)
 
-	response.OK(w, prov)
+	apiversion.OK(w, r, prov)
 }
 
 // HandleGetProviderModels handles GET /api/v1/providers/{id}/models.
@@ -116,12 +139,20 @@ func (h *Handlers) HandleGetProvider(w http.ResponseWriter, _ *http.Request, pro
 // @Accept json
 // @Produce json
 // @Param id path string true "Provider ID"
+// @Param review query string false "Review state to list (pending-review, approved, rejected, or any); default lists models cleared to serve"
 // @Success 200 {object} response.Response{data=object}
+// @Failure 400 {object} response.Response{error=response.Error}
 // @Failure 404 {object} response.Response{error=response.Error}
 // @Failure 500 {object} response.Response{error=response.Error}
 // @Security ApiKeyAuth
 // @Router /api/v1/providers/{id}/models [get].
-func (h *Handlers) HandleGetProviderModels(w http.ResponseWriter, _ *http.Request, providerID string) {
+func (h *Handlers) HandleGetProviderModels(w http.ResponseWriter, r *http.Request, providerID string) {
+	review, err := query.ParseReviewFilter(r.URL.Query().Get("review"))
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+
 	// Get catalog
 	cat, err := h.app.Catalog()
 	if err != nil {
@@ -141,7 +172,10 @@ func (h *Handlers) HandleGetProviderModels(w http.ResponseWriter, _ *http.Reques
 		response.ErrorFromType(w, err)
 		return
 	}
-	models := modelsIndex.List()
+	models := query.ModelFilter{Review: review}.Apply(modelsIndex.List())
+	if models == nil {
+		models = []catalogs.Model{}
+	}
 
 	result := map[string]any{
 		"provider": map[string]any{
@@ -152,5 +186,5 @@ func (h *Handlers) HandleGetProviderModels(w http.ResponseWriter, _ *http.Reques
 		"count":  len(models),
 	}
 
-	response.OK(w, result)
+	apiversion.OK(w, r, result)
 }
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/agentstation/starmap/internal/providers/quota"
+	"github.com/agentstation/starmap/internal/server/apiversion"
+	"github.com/agentstation/starmap/internal/server/response"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// HandleQuota handles GET /api/v1/quota.
+// @Summary Provider spend and usage
+// @Description Report spend and token usage per provider admin key from the provider usage APIs, priced with the catalog. Providers without an admin key are skipped unless requested explicitly.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param provider query string false "Only report this provider (openai, anthropic)"
+// @Param since query string false "Period start as YYYY-MM-DD or RFC 3339 (default: start of the current month)"
+// @Param until query string false "Period end as YYYY-MM-DD or RFC 3339 (default: now)"
+// @Success 200 {object} response.Response{data=object}
+// @Failure 400 {object} response.Response{error=response.Error}
+// @Failure 500 {object} response.Response{error=response.Error}
+// @Security ApiKeyAuth
+// @Router /api/v1/quota [get].
+func (h *Handlers) HandleQuota(w http.ResponseWriter, r *http.Request) {
+	state, err := h.app.CatalogState()
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+	w.Header().Set("X-Starmap-Generation-ID", state.GenerationID)
+	// Provider usage APIs are slow and rate limited, so reports are cached
+	// for the cache TTL.
+	cacheKey := "quota:" + r.URL.RawQuery
+	if cached, found := h.cache.GetGeneration(state.Sequence, state.GenerationID, cacheKey); found {
+		apiversion.OK(w, r, cached)
+		return
+	}
+
+	q := r.URL.Query()
+	start, end, err := quota.ParsePeriod(q.Get("since"), q.Get("until"), time.Now())
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+	reports, err := quota.Track(r.Context(), state.Catalog, catalogs.ProviderID(q.Get("provider")), start, end)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+
+	result := map[string]any{
+		"reports": reports,
+		"count":   len(reports),
+	}
+	h.cache.SetGeneration(state.Sequence, state.GenerationID, cacheKey, result)
+
+	apiversion.OK(w, r, result)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/internal/server/apiversion"
+	"github.com/agentstation/starmap/internal/server/response"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// ReviewRequest represents the POST /api/v1/models/{id}/review request body.
+type ReviewRequest struct {
+	Decision string `json:"decision"`           // approved or rejected
+	Reviewer string `json:"reviewer"`           // Recorded in the review audit log
+	Provider string `json:"provider,omitempty"` // Review only this provider's offering
+	Note     string `json:"note,omitempty"`
+}
+
+// RevisionConflict is the 409 response body for a write made against a stale
+// revision. Current holds the model's records as they are now, with private
+// fields withheld, keyed "providers/<id>" or "authors/<id>"; retry with
+// If-Match set to Revision.
+type RevisionConflict struct {
+	Model    string                    `json:"model"`
+	Expected string                    `json:"expected_revision"`
+	Revision string                    `json:"revision"`
+	Current  map[string]catalogs.Model `json:"current"`
+}
+
+// HandleReviewModel handles POST /api/v1/models/{id}/review.
+// @Summary Review model
+// @Description Approve or reject a model held in pending-review. The decision and reviewer are appended to the model's review audit log and published as a new catalog generation. If-Match must carry the model's ETag from GET /api/v1/models/{id} (or "*"); a stale revision is rejected with 409 and the model's current records.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Model ID"
+// @Param If-Match header string true "Model revision (ETag) the decision was made against, or *"
+// @Param review body ReviewRequest true "Review decision"
+// @Success 200 {object} response.Response{data=object}
+// @Header 200 {string} ETag "Model revision after the review"
+// @Failure 400 {object} response.Response{error=response.Error}
+// @Failure 404 {object} response.Response{error=response.Error}
+// @Failure 409 {object} response.Response{data=RevisionConflict,error=response.Error}
+// @Failure 428 {object} response.Response{error=response.Error}
+// @Failure 500 {object} response.Response{error=response.Error}
+// @Security ApiKeyAuth
+// @Router /api/v1/models/{id}/review [post].
+func (h *Handlers) HandleReviewModel(w http.ResponseWriter, r *http.Request, modelID string) {
+	revision, ok := ifMatch(r)
+	if !ok {
+		response.PreconditionRequired(w, "If-Match must name the model's current ETag (or *) from GET /models/{id}")
+		return
+	}
+	var req ReviewRequest
+	if !decodeJSONBody(w, r, &req) {
+		return
+	}
+	decision, err := catalogs.ParseModelReviewState(req.Decision)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+
+	sm, err := h.app.Starmap()
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+	ctx := starmap.WithExpectedRevision(r.Context(), revision)
+	review, err := sm.ReviewModel(ctx, modelID, catalogs.ProviderID(req.Provider), decision, req.Reviewer, req.Note)
+	var conflict *starmap.RevisionConflictError
+	if stderrors.As(err, &conflict) {
+		// The conflict carries full records; serve them as public readers see them
+		current := make(map[string]catalogs.Model, len(conflict.Current))
+		for owner, record := range conflict.Current {
+			if current[owner], err = record.Redacted(); err != nil {
+				response.InternalError(w, err)
+				return
+			}
+		}
+		w.Header().Set("ETag", quoteETag(conflict.Actual))
+		response.Conflict(w, conflict.Error(), RevisionConflict{
+			Model:    conflict.ModelID,
+			Expected: conflict.Expected,
+			Revision: conflict.Actual,
+			Current:  current,
+		})
+		return
+	}
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+	if current, err := catalogs.ModelRevision(sm.Catalog(), modelID); err == nil {
+		w.Header().Set("ETag", quoteETag(current))
+	}
+
+	apiversion.OK(w, r, map[string]any{
+		"model":    modelID,
+		"provider": req.Provider,
+		"review":   review,
+	})
+}
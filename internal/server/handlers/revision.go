@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/agentstation/starmap"
+)
+
+// setETag sets the ETag header to a resource revision computed by revision,
+// caching it per generation alongside the resource. Resources without a
+// revision are served without an ETag.
+func (h *Handlers) setETag(w http.ResponseWriter, state starmap.CatalogState, key string, revision func() (string, error)) {
+	cacheKey := "revision:" + key
+	if cached, found := h.cache.GetGeneration(state.Sequence, state.GenerationID, cacheKey); found {
+		w.Header().Set("ETag", quoteETag(cached.(string)))
+		return
+	}
+	value, err := revision()
+	if err != nil {
+		return
+	}
+	h.cache.SetGeneration(state.Sequence, state.GenerationID, cacheKey, value)
+	w.Header().Set("ETag", quoteETag(value))
+}
+
+// ifMatch returns the single revision named by the request's If-Match header.
+// "*" matches any current revision and is returned as "". ok is false when the
+// header is missing, weak, or names more than one entity tag.
+func ifMatch(r *http.Request) (revision string, ok bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || strings.Contains(header, ",") || strings.HasPrefix(header, "W/") {
+		return "", false
+	}
+	if header == "*" {
+		return "", true
+	}
+	revision = strings.Trim(header, `"`)
+	return revision, revision != ""
+}
+
+func quoteETag(revision string) string {
+	return `"` + revision + `"`
+}
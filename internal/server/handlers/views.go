@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/agentstation/starmap/internal/catalog/query"
+	"github.com/agentstation/starmap/internal/server/apiversion"
+	"github.com/agentstation/starmap/internal/server/params"
+	"github.com/agentstation/starmap/internal/server/response"
+)
+
+// ViewSummary describes a configured view.
+type ViewSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Filter      string `json:"filter,omitempty"`
+	Overrides   int    `json:"overrides"`
+}
+
+// HandleListViews handles GET /api/v1/views.
+// @Summary List views
+// @Description List the configured named views (filtered sub-catalogs)
+// @Tags views
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{data=object}
+// @Security ApiKeyAuth
+// @Router /api/v1/views [get].
+func (h *Handlers) HandleListViews(w http.ResponseWriter, r *http.Request) {
+	views := h.views.List()
+	summaries := make([]ViewSummary, 0, len(views))
+	for _, view := range views {
+		summaries = append(summaries, ViewSummary{
+			Name:        view.Name,
+			Description: view.Description,
+			Filter:      view.Filter,
+			Overrides:   len(view.Overrides),
+		})
+	}
+	apiversion.OK(w, r, map[string]any{
+		"views": summaries,
+		"count": len(summaries),
+	})
+}
+
+// HandleGetViewModels handles GET /api/v1/views/{name}/models.
+// @Summary List view models
+// @Description List the models of a named view with its overrides applied. The model list query parameters further filter and paginate the view.
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param name path string true "View name"
+// @Param provider query string false "Filter by provider ID"
+// @Param sort query string false "Sort field"
+// @Param limit query integer false "Maximum number of results (default: 100, max: 1000)"
+// @Param offset query integer false "Result offset for pagination"
+// @Success 200 {object} response.Response{data=object}
+// @Failure 400 {object} response.Response{error=response.Error}
+// @Failure 404 {object} response.Response{error=response.Error}
+// @Failure 500 {object} response.Response{error=response.Error}
+// @Security ApiKeyAuth
+// @Router /api/v1/views/{name}/models [get].
+func (h *Handlers) HandleGetViewModels(w http.ResponseWriter, r *http.Request, name string) {
+	view, err := h.views.Get(name)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+	state, err := h.app.CatalogState()
+	if err != nil {
+		response.InternalError(w, err)
+		return
+	}
+	w.Header().Set("X-Starmap-Generation-ID", state.GenerationID)
+	cacheKey := "view:" + name + ":" + r.URL.RawQuery
+	if cached, found := h.cache.GetGeneration(state.Sequence, state.GenerationID, cacheKey); found {
+		apiversion.OK(w, r, cached)
+		return
+	}
+
+	f, err := params.ParseModelFilterStrict(r)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+	// The view already selects review states.
+	if f.Review == "" {
+		f.Review = query.ReviewAny
+	}
+	models, err := view.Models(state.Catalog, f.Provider)
+	if err != nil {
+		response.ErrorFromType(w, err)
+		return
+	}
+	page := query.Paginate(f.Apply(models), f.Limit, f.Offset)
+
+	result := map[string]any{
+		"view":   view.Name,
+		"models": page.Items,
+		"pagination": map[string]any{
+			"total":  page.Total,
+			"limit":  page.Limit,
+			"offset": page.Offset,
+			"count":  page.Count,
+		},
+	}
+	h.cache.SetGeneration(state.Sequence, state.GenerationID, cacheKey, result)
+
+	apiversion.OK(w, r, result)
+}
@@ -57,3 +57,55 @@ func TestHierarchicalIDRoutePreservesCompleteOpaqueModelID(t *testing.T) {
 		}
 	}
 }
+
+func TestVersionedRoutesServeSameCatalog(t *testing.T) {
+	builder := catalogs.NewEmpty()
+	if err := builder.SetProvider(catalogs.Provider{
+		ID: "provider", Name: "Provider", Models: map[string]*catalogs.Model{
+			"model": {ID: "model", Name: "Model"},
+		},
+	}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	client, err := starmap.New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	logger := zerolog.Nop()
+	app := &application.Mock{
+		CatalogFunc: func() (*catalogs.Catalog, error) { return catalog, nil },
+		CatalogStateFunc: func() (starmap.CatalogState, error) {
+			return starmap.CatalogState{Catalog: catalog, GenerationID: "versioned-generation", Sequence: 1}, nil
+		},
+		StarmapFunc: func(...starmap.Option) (*starmap.Client, error) { return client, nil },
+		LoggerFunc:  func() *zerolog.Logger { return &logger },
+	}
+	server, err := New(app, Config{PathPrefix: "/api/v1", CacheTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	for _, test := range []struct {
+		target  string
+		version string
+	}{
+		{target: "/api/v1/models/model", version: "v1"},
+		{target: "/api/v2/models/model", version: "v2"},
+	} {
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.target, nil))
+		body := recorder.Body.String()
+		if recorder.Code != http.StatusOK || !strings.Contains(body, `"id":"model"`) {
+			t.Fatalf("GET %s = %d %s", test.target, recorder.Code, body)
+		}
+		if got := recorder.Header().Get("Starmap-API-Version"); got != test.version {
+			t.Fatalf("GET %s version header = %q", test.target, got)
+		}
+		if strings.Contains(body, `"api_version"`) != (test.version == "v2") {
+			t.Fatalf("GET %s envelope = %s", test.target, body)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestMain runs the tests with a temporary home directory, so syncs under
+// test keep their source state, such as provider shapes and the provenance
+// and pricing histories, out of the real ~/.starmap.
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "starmap-test-home-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.Setenv("HOME", home); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	_ = os.RemoveAll(home)
+	os.Exit(code)
+}
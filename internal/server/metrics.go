@@ -0,0 +1,128 @@
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agentstation/starmap/internal/catalog/pipeline"
+	"github.com/agentstation/starmap/internal/providers/clients"
+	"github.com/agentstation/starmap/internal/server/apiversion"
+	"github.com/agentstation/starmap/internal/server/middleware"
+)
+
+// metricsContentType is the Prometheus text exposition format.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// labelEscaper escapes label values as the text exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// handleMetrics serves server, sync, and provider fetch metrics in the
+// Prometheus text format. Durations are exposed as summaries without
+// quantiles: a _sum in seconds and a _count.
+func (s *Server) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", metricsContentType)
+	m := &metricsWriter{w: w}
+
+	m.family("starmap_api_info", "gauge", "API versions served.")
+	for _, version := range apiversion.Supported() {
+		m.sample("starmap_api_info", 1, "version", string(version))
+	}
+	m.family("starmap_uptime_seconds", "gauge", "Seconds since the server started.")
+	m.sample("starmap_uptime_seconds", time.Since(s.startTime).Seconds())
+
+	if s.httpMetrics != nil {
+		requests := s.httpMetrics.Snapshot()
+		keys := slices.SortedFunc(maps.Keys(requests), func(a, b middleware.RequestKey) int {
+			return cmp.Or(cmp.Compare(a.Method, b.Method), cmp.Compare(a.Status, b.Status))
+		})
+		m.family("starmap_http_requests_total", "counter", "HTTP requests served, by method and status code.")
+		for _, key := range keys {
+			m.sample("starmap_http_requests_total", float64(requests[key].Requests), "method", key.Method, "code", strconv.Itoa(key.Status))
+		}
+		m.family("starmap_http_request_duration_seconds", "summary", "Time spent serving HTTP requests, by method and status code.")
+		for _, key := range keys {
+			labels := []string{"method", key.Method, "code", strconv.Itoa(key.Status)}
+			m.sample("starmap_http_request_duration_seconds_sum", requests[key].Duration.Seconds(), labels...)
+			m.sample("starmap_http_request_duration_seconds_count", float64(requests[key].Requests), labels...)
+		}
+	}
+
+	m.family("starmap_websocket_clients", "gauge", "Connected WebSocket clients.")
+	m.sample("starmap_websocket_clients", float64(s.wsHub.ClientCount()))
+	m.family("starmap_sse_clients", "gauge", "Connected Server-Sent Events clients.")
+	m.sample("starmap_sse_clients", float64(s.sseBroadcaster.ClientCount()))
+
+	sync := pipeline.DefaultMetrics.Snapshot()
+	m.family("starmap_sync_runs_total", "counter", "Catalog sync runs, including failed ones.")
+	m.sample("starmap_sync_runs_total", float64(sync.Runs))
+	m.family("starmap_sync_failures_total", "counter", "Failed catalog sync runs.")
+	m.sample("starmap_sync_failures_total", float64(sync.Failures))
+	m.family("starmap_sync_duration_seconds", "summary", "Time spent in catalog sync runs.")
+	m.sample("starmap_sync_duration_seconds_sum", sync.Duration.Seconds())
+	m.sample("starmap_sync_duration_seconds_count", float64(sync.Runs))
+	m.family("starmap_sync_merge_duration_seconds", "summary", "Time spent reconciling source observations.")
+	m.sample("starmap_sync_merge_duration_seconds_sum", sync.MergeDuration.Seconds())
+	m.sample("starmap_sync_merge_duration_seconds_count", float64(sync.Merges))
+	m.family("starmap_sync_conflicts_detected_total", "counter", "Fields whose sources provided distinct values during reconciliation.")
+	m.sample("starmap_sync_conflicts_detected_total", float64(sync.ConflictsDetected))
+	if !sync.LastSuccess.IsZero() {
+		m.family("starmap_sync_last_success_timestamp_seconds", "gauge", "Unix time of the last successful catalog sync.")
+		m.sample("starmap_sync_last_success_timestamp_seconds", float64(sync.LastSuccess.Unix()))
+	}
+
+	fetches := clients.DefaultMetrics.Snapshot()
+	providers := slices.Sorted(maps.Keys(fetches))
+	m.family("starmap_provider_fetch_duration_seconds", "summary", "Time spent listing models from provider APIs, by provider.")
+	for _, provider := range providers {
+		m.sample("starmap_provider_fetch_duration_seconds_sum", fetches[provider].Duration.Seconds(), "provider", string(provider))
+		m.sample("starmap_provider_fetch_duration_seconds_count", float64(fetches[provider].Calls), "provider", string(provider))
+	}
+	m.family("starmap_provider_fetch_errors_total", "counter", "Failed provider model list calls, by provider.")
+	for _, provider := range providers {
+		m.sample("starmap_provider_fetch_errors_total", float64(fetches[provider].Errors), "provider", string(provider))
+	}
+	m.family("starmap_provider_models_fetched_total", "counter", "Models returned by provider APIs, by provider.")
+	for _, provider := range providers {
+		m.sample("starmap_provider_models_fetched_total", float64(fetches[provider].Models), "provider", string(provider))
+	}
+
+	if m.err != nil {
+		s.logger.Error().Err(m.err).Msg("Failed to write metrics")
+	}
+}
+
+// metricsWriter writes Prometheus text exposition lines and keeps the first
+// write error.
+type metricsWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (m *metricsWriter) family(name, kind, help string) {
+	m.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// sample writes one sample; labels alternate names and values.
+func (m *metricsWriter) sample(name string, value float64, labels ...string) {
+	var pairs []string
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, labels[i]+`="`+labelEscaper.Replace(labels[i+1])+`"`)
+	}
+	if len(pairs) > 0 {
+		name += "{" + strings.Join(pairs, ",") + "}"
+	}
+	m.printf("%s %s\n", name, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func (m *metricsWriter) printf(format string, args ...any) {
+	if m.err == nil {
+		_, m.err = fmt.Fprintf(m.w, format, args...)
+	}
+}
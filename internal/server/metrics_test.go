@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	client, err := starmap.New(starmap.WithCatalogStore(catalogstore.NewMemory()))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	logger := zerolog.Nop()
+
+	get := func(t *testing.T, handler http.Handler, path string) (*http.Response, string) {
+		t.Helper()
+		httpServer := httptest.NewServer(handler)
+		t.Cleanup(httpServer.Close)
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, httpServer.URL+path, nil)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		defer func() { _ = response.Body.Close() }()
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Fatalf("ReadAll: %v", err)
+		}
+		return response, string(body)
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		server, err := New(&mockApplication{logger: &logger, sm: client}, Config{PathPrefix: "/api/v1", CacheTTL: time.Minute, MetricsEnabled: true})
+		if err != nil {
+			t.Fatalf("New server: %v", err)
+		}
+		handler := server.Handler()
+		get(t, handler, "/api/v1/models")
+		get(t, handler, "/api/v1/models/missing-model")
+
+		response, body := get(t, handler, "/metrics")
+		if got := response.Header.Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+			t.Errorf("Content-Type = %q, want the Prometheus text format", got)
+		}
+		for _, want := range []string{
+			"# TYPE starmap_http_requests_total counter\n",
+			`starmap_http_requests_total{method="GET",code="200"} 1` + "\n",
+			`starmap_http_requests_total{method="GET",code="404"} 1` + "\n",
+			`starmap_http_request_duration_seconds_count{method="GET",code="200"} 1` + "\n",
+			"starmap_websocket_clients 0\n",
+			"starmap_sse_clients 0\n",
+			"# TYPE starmap_sync_runs_total counter\n",
+			"# TYPE starmap_sync_merge_duration_seconds summary\n",
+			"# TYPE starmap_sync_conflicts_detected_total counter\n",
+			"# TYPE starmap_provider_fetch_duration_seconds summary\n",
+			"# TYPE starmap_provider_models_fetched_total counter\n",
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("metrics missing %q:\n%s", want, body)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		server, err := New(&mockApplication{logger: &logger, sm: client}, Config{PathPrefix: "/api/v1", CacheTTL: time.Minute})
+		if err != nil {
+			t.Fatalf("New server: %v", err)
+		}
+		if response, _ := get(t, server.Handler(), "/metrics"); response.StatusCode != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", response.StatusCode, http.StatusNotFound)
+		}
+	})
+}
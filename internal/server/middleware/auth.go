@@ -19,13 +19,15 @@ type AuthConfig struct {
 	BearerPrefix bool
 }
 
-// DefaultAuthConfig returns default authentication configuration.
+// DefaultAuthConfig returns default authentication configuration. Its
+// public paths are the unversioned root routes; servers add the health,
+// readiness, and spec routes of each API version they mount.
 func DefaultAuthConfig() AuthConfig {
 	return AuthConfig{
 		Enabled:      false,
 		APIKey:       os.Getenv("API_KEY"),
 		HeaderName:   "X-API-Key",
-		PublicPaths:  []string{"/health", "/api/v1/health", "/api/v1/ready", "/api/v1/openapi.json"},
+		PublicPaths:  []string{"/health", "/openapi.json", "/openapi.yaml"},
 		BearerPrefix: false,
 	}
 }
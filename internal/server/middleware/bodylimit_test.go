@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestMaxBodySize tests that oversized bodies are rejected or cut off.
+func TestMaxBodySize(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         int64
+		body          string
+		chunked       bool
+		expectedCode  int
+		expectedPass  bool
+		expectReadErr bool
+	}{
+		{name: "within limit", limit: 8, body: "12345678", expectedCode: http.StatusOK, expectedPass: true},
+		{name: "declared length over limit", limit: 8, body: "123456789", expectedCode: http.StatusRequestEntityTooLarge},
+		{name: "undeclared length over limit", limit: 8, body: "123456789", chunked: true, expectedCode: http.StatusOK, expectedPass: true, expectReadErr: true},
+		{name: "limit disabled", limit: 0, body: "123456789", expectedCode: http.StatusOK, expectedPass: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed := false
+			var readErr error
+			handler := MaxBodySize(tt.limit)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				passed = true
+				_, readErr = io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/models", strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.expectedCode)
+			}
+			if passed != tt.expectedPass {
+				t.Errorf("handler called = %v, want %v", passed, tt.expectedPass)
+			}
+			if (readErr != nil) != tt.expectReadErr {
+				t.Errorf("read error = %v, want error %v", readErr, tt.expectReadErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Supported response content encodings.
+const (
+	EncodingZstd = "zstd"
+	EncodingGzip = "gzip"
+)
+
+// CompressConfig holds response compression configuration.
+type CompressConfig struct {
+	// MinSize is the smallest response body, in bytes, worth compressing.
+	// Smaller responses are sent as-is.
+	MinSize int
+	// Encodings lists the offered encodings in server preference order.
+	Encodings []string
+}
+
+// DefaultCompressConfig returns the default compression configuration.
+func DefaultCompressConfig() CompressConfig {
+	return CompressConfig{
+		MinSize:   1024,
+		Encodings: []string{EncodingZstd, EncodingGzip},
+	}
+}
+
+// encoder is the streaming compressor shared by gzip and zstd.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+var encoderPools = map[string]*sync.Pool{
+	EncodingGzip: {New: func() any {
+		writer, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return writer
+	}},
+	EncodingZstd: {New: func() any {
+		writer, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderConcurrency(1))
+		return writer
+	}},
+}
+
+// Compress negotiates a response encoding from Accept-Encoding and
+// transparently compresses bodies of at least MinSize bytes. Responses that
+// are streamed (flushed before reaching MinSize), already encoded, or
+// event streams are passed through, as are WebSocket upgrades.
+//
+// A response to a request that negotiated an encoding is a different
+// representation, so its strong ETag gets the encoding as a suffix ("rev"
+// becomes "rev-gzip"). The suffix follows the negotiation rather than the
+// body size, so 304 and HEAD responses carry the same tag as the GET they
+// stand for. It is removed from If-Match and If-None-Match before the
+// handler sees them, so handlers keep comparing their own revisions.
+func Compress(config CompressConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			for _, name := range []string{"If-Match", "If-None-Match"} {
+				if header := r.Header.Get(name); header != "" {
+					r.Header.Set(name, stripEncodingETags(header))
+				}
+			}
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), config.Encodings)
+			if encoding == "" || r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: config.MinSize}
+			defer cw.close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding returns the first offered encoding the client accepts
+// with a non-zero quality, or "" for identity.
+func negotiateEncoding(header string, offered []string) string {
+	if header == "" {
+		return ""
+	}
+	qualities := make(map[string]float64)
+	for part := range strings.SplitSeq(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		qualities[strings.ToLower(strings.TrimSpace(name))] = quality
+	}
+	for _, encoding := range offered {
+		if quality, ok := qualities[encoding]; ok {
+			if quality > 0 {
+				return encoding
+			}
+			continue
+		}
+		if quality, ok := qualities["*"]; ok && quality > 0 {
+			return encoding
+		}
+	}
+	return ""
+}
+
+// stripEncodingETags removes the encoding suffixes Compress adds to strong
+// entity tags from a comma-separated If-Match or If-None-Match header.
+func stripEncodingETags(header string) string {
+	tags := strings.Split(header, ",")
+	for i, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		for encoding := range encoderPools {
+			if stripped, found := strings.CutSuffix(tag, "-"+encoding+`"`); found && strings.HasPrefix(tag, `"`) {
+				tag = stripped + `"`
+				break
+			}
+		}
+		tags[i] = tag
+	}
+	return strings.Join(tags, ", ")
+}
+
+// compressWriter buffers the start of a response until it can decide whether
+// compressing it is worthwhile, then either streams it through an encoder or
+// writes it unchanged.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	status  int
+	buf     []byte
+	decided bool
+	encoder encoder
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.decided || cw.status != 0 {
+		return
+	}
+	if code < http.StatusOK {
+		cw.ResponseWriter.WriteHeader(code)
+		return
+	}
+	cw.status = code
+	if code == http.StatusNoContent || code == http.StatusNotModified {
+		_ = cw.start(false)
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		cw.buf = append(cw.buf, p...)
+		if len(cw.buf) < cw.minSize {
+			return len(p), nil
+		}
+		if err := cw.start(true); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if cw.encoder != nil {
+		return cw.encoder.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// start sends the headers and any buffered body, compressing when compress is
+// set and the response is eligible. Eligible responses get the encoding's
+// ETag even when they are sent uncompressed.
+func (cw *compressWriter) start(compress bool) error {
+	cw.decided = true
+	header := cw.Header()
+	eligible := header.Get("Content-Encoding") == "" &&
+		!strings.HasPrefix(header.Get("Content-Type"), "text/event-stream")
+	if etag := header.Get("ETag"); eligible && strings.HasPrefix(etag, `"`) && strings.HasSuffix(etag, `"`) && len(etag) > 1 {
+		header.Set("ETag", strings.TrimSuffix(etag, `"`)+"-"+cw.encoding+`"`)
+	}
+	if compress && eligible {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		cw.encoder = encoderPools[cw.encoding].Get().(encoder)
+		cw.encoder.Reset(cw.ResponseWriter)
+	}
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	buffered := cw.buf
+	cw.buf = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	if cw.encoder != nil {
+		_, err := cw.encoder.Write(buffered)
+		return err
+	}
+	_, err := cw.ResponseWriter.Write(buffered)
+	return err
+}
+
+// close finishes the response: short bodies are sent uncompressed and the
+// encoder is flushed and returned to its pool.
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if len(cw.buf) == 0 && cw.status == 0 {
+			return
+		}
+		_ = cw.start(false)
+	}
+	if cw.encoder != nil {
+		_ = cw.encoder.Close()
+		cw.encoder.Reset(io.Discard)
+		encoderPools[cw.encoding].Put(cw.encoder)
+		cw.encoder = nil
+	}
+}
+
+// Unwrap allows net/http response controllers to reach the transport writer.
+func (cw *compressWriter) Unwrap() http.ResponseWriter { return cw.ResponseWriter }
+
+// Flush sends buffered data immediately. A response flushed before reaching
+// the size threshold is treated as a stream and left uncompressed.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		_ = cw.start(false)
+	}
+	if cw.encoder != nil {
+		_ = cw.encoder.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack preserves connection takeover for handlers that need it.
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := cw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	cw.decided = true
+	return hijacker.Hijack()
+}
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// TestNegotiateEncoding tests Accept-Encoding negotiation.
+func TestNegotiateEncoding(t *testing.T) {
+	offered := DefaultCompressConfig().Encodings
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", EncodingGzip},
+		{"gzip, deflate, br, zstd", EncodingZstd},
+		{"zstd;q=0, gzip;q=0.5", EncodingGzip},
+		{"*", EncodingZstd},
+		{"*;q=0", ""},
+		{"GZIP", EncodingGzip},
+	}
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.header, offered); got != tt.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+// TestCompress tests response compression thresholds and round trips.
+func TestCompress(t *testing.T) {
+	large := strings.Repeat(`{"id":"model","name":"Model"},`, 200)
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		body           string
+		wantEncoding   string
+	}{
+		{name: "gzip", acceptEncoding: "gzip", contentType: "application/json", body: large, wantEncoding: EncodingGzip},
+		{name: "zstd", acceptEncoding: "zstd, gzip", contentType: "application/json", body: large, wantEncoding: EncodingZstd},
+		{name: "below threshold", acceptEncoding: "gzip", contentType: "application/json", body: `{"ok":true}`},
+		{name: "not accepted", contentType: "application/json", body: large},
+		{name: "event stream", acceptEncoding: "gzip", contentType: "text/event-stream", body: large},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Compress(DefaultCompressConfig())(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(http.StatusCreated)
+				// Write in chunks to cross the threshold mid-response
+				for chunk := range strings.SplitAfterSeq(tt.body, ",") {
+					_, _ = io.WriteString(w, chunk)
+				}
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/models", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusCreated {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusCreated)
+			}
+			if got := rec.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", got)
+			}
+
+			var reader io.Reader = rec.Body
+			switch tt.wantEncoding {
+			case EncodingGzip:
+				gz, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("gzip.NewReader: %v", err)
+				}
+				reader = gz
+			case EncodingZstd:
+				zr, err := zstd.NewReader(rec.Body)
+				if err != nil {
+					t.Fatalf("zstd.NewReader: %v", err)
+				}
+				defer zr.Close()
+				reader = zr
+			}
+			body, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+			if string(body) != tt.body {
+				t.Fatalf("decoded body length = %d, want %d", len(body), len(tt.body))
+			}
+		})
+	}
+}
+
+// TestCompressETags tests that each encoding gets its own strong ETag and
+// that conditional headers reach the handler without the encoding suffix.
+func TestCompressETags(t *testing.T) {
+	large := strings.Repeat(`{"id":"model","name":"Model"},`, 200)
+	var ifMatch, ifNoneMatch string
+	handler := Compress(DefaultCompressConfig())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifMatch, ifNoneMatch = r.Header.Get("If-Match"), r.Header.Get("If-None-Match")
+		w.Header().Set("ETag", `"abc123"`)
+		_, _ = io.WriteString(w, large)
+	}))
+
+	for _, tt := range []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", `"abc123"`},
+		{"gzip", `"abc123-gzip"`},
+		{"zstd", `"abc123-zstd"`},
+	} {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept-Encoding", tt.acceptEncoding)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if got := recorder.Header().Get("ETag"); got != tt.want {
+			t.Errorf("Accept-Encoding %q: ETag = %s, want %s", tt.acceptEncoding, got, tt.want)
+		}
+	}
+
+	// Revalidation and HEAD answer for the representation GET would send,
+	// as does a body too small to be worth compressing.
+	for _, tt := range []struct {
+		name    string
+		method  string
+		status  int
+		body    string
+		want    string
+		encoded bool
+	}{
+		{name: "not modified", method: http.MethodGet, status: http.StatusNotModified, want: `"abc123-gzip"`},
+		{name: "head", method: http.MethodHead, status: http.StatusOK, want: `"abc123-gzip"`},
+		{name: "head with body", method: http.MethodHead, status: http.StatusOK, body: large, want: `"abc123-gzip"`, encoded: true},
+		{name: "small body", method: http.MethodGet, status: http.StatusOK, body: `{}`, want: `"abc123-gzip"`},
+	} {
+		handler := Compress(DefaultCompressConfig())(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("ETag", `"abc123"`)
+			w.WriteHeader(tt.status)
+			_, _ = io.WriteString(w, tt.body)
+		}))
+		request := httptest.NewRequest(tt.method, "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if got := recorder.Header().Get("ETag"); got != tt.want {
+			t.Errorf("%s: ETag = %s, want %s", tt.name, got, tt.want)
+		}
+		if encoded := recorder.Header().Get("Content-Encoding") != ""; encoded != tt.encoded {
+			t.Errorf("%s: Content-Encoding = %q, want encoded %v", tt.name, recorder.Header().Get("Content-Encoding"), tt.encoded)
+		}
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/", nil)
+	request.Header.Set("If-Match", `"abc123-gzip"`)
+	request.Header.Set("If-None-Match", `"abc123-zstd", W/"weak", "other"`)
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	if ifMatch != `"abc123"` || ifNoneMatch != `"abc123", W/"weak", "other"` {
+		t.Fatalf("handler saw If-Match %s, If-None-Match %s", ifMatch, ifNoneMatch)
+	}
+}
@@ -277,6 +277,26 @@ func TestCORS_ActualRequestPassthrough(t *testing.T) {
 	}
 }
 
+// TestCORS_PreservesVary tests that Vary: Origin is added to, not replacing,
+// a Vary header set by outer middleware such as compression.
+func TestCORS_PreservesVary(t *testing.T) {
+	handler := CORS(CORSConfig{AllowedOrigins: []string{"https://example.com"}})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/api/v1/models", nil)
+	req.Header.Set("Origin", "https://example.com")
+	w := httptest.NewRecorder()
+	w.Header().Set("Vary", "Accept-Encoding")
+
+	handler.ServeHTTP(w, req)
+
+	vary := w.Header().Values("Vary")
+	if len(vary) != 2 || vary[0] != "Accept-Encoding" || vary[1] != "Origin" {
+		t.Errorf("Vary = %v, want [Accept-Encoding Origin]", vary)
+	}
+}
+
 // TestCORS_MultipleOrigins tests handling multiple allowed origins.
 func TestCORS_MultipleOrigins(t *testing.T) {
 	config := CORSConfig{
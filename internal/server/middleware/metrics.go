@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestKey identifies a class of HTTP requests. Paths are left out to keep
+// the number of series bounded.
+type RequestKey struct {
+	Method string
+	Status int
+}
+
+// RequestMetrics counts the requests of one RequestKey.
+type RequestMetrics struct {
+	Requests int           // Completed requests
+	Duration time.Duration // Total time spent serving them
+}
+
+// HTTPMetrics collects RequestMetrics per method and status code.
+type HTTPMetrics struct {
+	mu       sync.Mutex
+	requests map[RequestKey]RequestMetrics
+}
+
+// NewHTTPMetrics returns an empty HTTP metrics collector.
+func NewHTTPMetrics() *HTTPMetrics {
+	return &HTTPMetrics{requests: map[RequestKey]RequestMetrics{}}
+}
+
+// Middleware records every request served by next.
+func (m *HTTPMetrics) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+			next.ServeHTTP(wrapped, r)
+
+			key := RequestKey{Method: requestMethod(r.Method), Status: wrapped.statusCode}
+			m.mu.Lock()
+			metrics := m.requests[key]
+			metrics.Requests++
+			metrics.Duration += time.Since(start)
+			m.requests[key] = metrics
+			m.mu.Unlock()
+		})
+	}
+}
+
+// Snapshot returns the metrics recorded so far.
+func (m *HTTPMetrics) Snapshot() map[RequestKey]RequestMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := make(map[RequestKey]RequestMetrics, len(m.requests))
+	for key, metrics := range m.requests {
+		snapshot[key] = metrics
+	}
+	return snapshot
+}
+
+// requestMethod folds nonstandard methods into one label value so clients
+// cannot create series at will.
+func requestMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions, http.MethodConnect, http.MethodTrace:
+		return method
+	default:
+		return "OTHER"
+	}
+}
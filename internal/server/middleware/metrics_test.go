@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestHTTPMetrics tests that requests are counted by method and status.
+func TestHTTPMetrics(t *testing.T) {
+	metrics := NewHTTPMetrics()
+	handler := metrics.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	for _, request := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/models", nil),
+		httptest.NewRequest(http.MethodGet, "/api/v1/providers", nil),
+		httptest.NewRequest(http.MethodGet, "/missing", nil),
+		httptest.NewRequest("PURGE", "/api/v1/models", nil),
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	snapshot := metrics.Snapshot()
+	for key, want := range map[RequestKey]int{
+		{Method: http.MethodGet, Status: http.StatusOK}:       2,
+		{Method: http.MethodGet, Status: http.StatusNotFound}: 1,
+		{Method: "OTHER", Status: http.StatusOK}:              1,
+	} {
+		if got := snapshot[key].Requests; got != want {
+			t.Errorf("%v requests = %d, want %d", key, got, want)
+		}
+	}
+	if len(snapshot) != 3 {
+		t.Errorf("snapshot has %d keys, want 3: %v", len(snapshot), snapshot)
+	}
+}
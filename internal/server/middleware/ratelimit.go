@@ -1,15 +1,20 @@
 package middleware
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
 	"net"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
 )
 
-// RateLimiter implements token bucket rate limiting per IP address.
+// RateLimiter implements token bucket rate limiting per key, such as an IP
+// address or API key.
 type RateLimiter struct {
 	mu          sync.RWMutex
 	visitors    map[string]*visitor
@@ -24,7 +29,7 @@ const (
 	rateLimitVisitorMaxIdle  = 10 * time.Minute
 )
 
-// visitor tracks rate limit state for a single IP.
+// visitor tracks rate limit state for a single key.
 type visitor struct {
 	tokens    int
 	lastReset time.Time
@@ -32,7 +37,7 @@ type visitor struct {
 }
 
 // NewRateLimiter creates a new rate limiter.
-// limit is requests per minute per IP.
+// limit is requests per minute per key.
 func NewRateLimiter(limit int, logger *zerolog.Logger) *RateLimiter {
 	rl := &RateLimiter{
 		visitors:    make(map[string]*visitor),
@@ -62,7 +67,7 @@ func (rl *RateLimiter) cleanup(now time.Time) {
 	rl.lastCleanup = now
 }
 
-// getVisitor returns or creates a visitor for the IP.
+// getVisitor returns or creates a visitor for the key.
 func (rl *RateLimiter) getVisitor(ip string) *visitor {
 	now := time.Now()
 	rl.cleanup(now)
@@ -87,9 +92,16 @@ func (rl *RateLimiter) getVisitor(ip string) *visitor {
 	return v
 }
 
-// allow checks if a request from the IP is allowed.
-func (rl *RateLimiter) allow(ip string) bool {
-	v := rl.getVisitor(ip)
+// allow checks if a request for the key is allowed.
+func (rl *RateLimiter) allow(key string) bool {
+	allowed, _ := rl.take(key)
+	return allowed
+}
+
+// take consumes a token for the key. When none is left it reports how long
+// until the key's tokens are reset.
+func (rl *RateLimiter) take(key string) (bool, time.Duration) {
+	v := rl.getVisitor(key)
 
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -103,10 +115,10 @@ func (rl *RateLimiter) allow(ip string) bool {
 	// Check if tokens available
 	if v.tokens > 0 {
 		v.tokens--
-		return true
+		return true, 0
 	}
 
-	return false
+	return false, rl.interval - time.Since(v.lastReset)
 }
 
 // RateLimit middleware limits requests per IP address.
@@ -118,18 +130,41 @@ func RateLimit(rl *RateLimiter) func(http.Handler) http.Handler {
 			ip := clientAddress(r.RemoteAddr)
 
 			// Check rate limit
-			if !rl.allow(ip) {
+			if allowed, retryAfter := rl.take(ip); !allowed {
 				rl.logger.Warn().
 					Str("ip", ip).
 					Str("path", r.URL.Path).
 					Msg("Rate limit exceeded")
+				rl.reject(w, retryAfter)
+				return
+			}
 
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusTooManyRequests)
-				// Write error response; if this fails, connection is likely broken
-				if _, writeErr := w.Write([]byte(`{"data":null,"error":{"code":"RATE_LIMITED","message":"Rate limit exceeded","details":"Too many requests. Please try again later."}}`)); writeErr != nil {
-					rl.logger.Error().Err(writeErr).Msg("Failed to write rate limit error response")
-				}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// TokenRateLimit middleware limits requests per API key, read from
+// headerName or the Authorization header as Auth reads it. Requests without
+// a key pass; the per-IP limit still covers them. Keys are tracked by hash,
+// so the limiter holds no credentials.
+func TokenRateLimit(rl *RateLimiter, headerName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := extractAPIKey(r, AuthConfig{HeaderName: headerName})
+			if token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := tokenKey(token)
+			if allowed, retryAfter := rl.take(key); !allowed {
+				rl.logger.Warn().
+					Str("token", key).
+					Str("ip", clientAddress(r.RemoteAddr)).
+					Str("path", r.URL.Path).
+					Msg("Token rate limit exceeded")
+				rl.reject(w, retryAfter)
 				return
 			}
 
@@ -138,6 +173,24 @@ func RateLimit(rl *RateLimiter) func(http.Handler) http.Handler {
 	}
 }
 
+// reject writes a 429 response telling the client when to retry.
+func (rl *RateLimiter) reject(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(max(1, int(math.Ceil(retryAfter.Seconds())))))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	// Write error response; if this fails, connection is likely broken
+	if _, writeErr := w.Write([]byte(`{"data":null,"error":{"code":"RATE_LIMITED","message":"Rate limit exceeded","details":"Too many requests. Please try again later."}}`)); writeErr != nil {
+		rl.logger.Error().Err(writeErr).Msg("Failed to write rate limit error response")
+	}
+}
+
+// tokenKey identifies an API key in the limiter and in logs without
+// revealing it.
+func tokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
 func clientAddress(remoteAddress string) string {
 	host, _, err := net.SplitHostPort(remoteAddress)
 	if err == nil && host != "" {
@@ -373,6 +373,65 @@ func TestRateLimiter_Middleware_ErrorResponse(t *testing.T) {
 	if !contains(body, "RATE_LIMITED") {
 		t.Error("expected RATE_LIMITED in response body")
 	}
+	if retryAfter := w.Header().Get("Retry-After"); retryAfter != "60" {
+		t.Errorf("expected Retry-After=60, got %q", retryAfter)
+	}
+}
+
+// TestTokenRateLimit tests that API keys are limited independently of IPs.
+func TestTokenRateLimit(t *testing.T) {
+	logger := zerolog.Nop()
+	rl := NewRateLimiter(2, &logger)
+	rl.interval = 30 * time.Second
+	handler := TokenRateLimit(rl, "X-API-Key")(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(remoteAddr string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/models", nil)
+		req.RemoteAddr = remoteAddr
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// One key shared across IPs and header forms draws on one budget.
+	for i, headers := range []map[string]string{
+		{"X-API-Key": "shared-key"},
+		{"Authorization": "Bearer shared-key"},
+	} {
+		if w := serve("192.0.2."+strconv.Itoa(i+1)+":1234", headers); w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, w.Code)
+		}
+	}
+	w := serve("192.0.2.9:1234", map[string]string{"X-API-Key": "shared-key"})
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 after the key's budget, got %d", w.Code)
+	}
+	if retryAfter := w.Header().Get("Retry-After"); retryAfter != "30" {
+		t.Errorf("expected Retry-After=30, got %q", retryAfter)
+	}
+
+	// Other keys and keyless requests are unaffected.
+	if w := serve("192.0.2.9:1234", map[string]string{"X-API-Key": "other-key"}); w.Code != http.StatusOK {
+		t.Errorf("other key: expected 200, got %d", w.Code)
+	}
+	for i := range 5 {
+		if w := serve("192.0.2.9:1234", nil); w.Code != http.StatusOK {
+			t.Errorf("keyless request %d: expected 200, got %d", i, w.Code)
+		}
+	}
+
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	for key := range rl.visitors {
+		if contains(key, "key") {
+			t.Errorf("limiter stores a raw API key: %q", key)
+		}
+	}
 }
 
 // TestRateLimiter_Cleanup tests request-driven stale visitor cleanup.
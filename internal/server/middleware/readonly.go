@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/rs/zerolog"
+)
+
+// ReadOnlyConfig holds read-only mode configuration.
+type ReadOnlyConfig struct {
+	Enabled bool
+	// QueryPaths accept POST for queries too large for a URL, such as model
+	// search, and stay open in read-only mode.
+	QueryPaths []string
+}
+
+// ReadOnly middleware rejects every request that could mutate the catalog
+// or trigger a sync. Only safe methods and POSTs to query paths pass, so an
+// endpoint added later is blocked until it is known not to write.
+func ReadOnly(config ReadOnlyConfig, logger *zerolog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !config.Enabled || readOnlyAllowed(r, config.QueryPaths) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			logger.Warn().
+				Str("method", r.Method).
+				Str("path", r.URL.Path).
+				Str("remote_addr", r.RemoteAddr).
+				Msg("Write rejected by read-only server")
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			// Write error response; if this fails, connection is likely broken
+			if _, writeErr := w.Write([]byte(`{"data":null,"error":{"code":"READ_ONLY","message":"Server is read-only","details":"This server was started with --read-only and does not accept writes or sync triggers"}}`)); writeErr != nil {
+				logger.Error().Err(writeErr).Msg("Failed to write read-only error response")
+			}
+		})
+	}
+}
+
+// readOnlyAllowed reports whether r is safe to serve in read-only mode.
+func readOnlyAllowed(r *http.Request, queryPaths []string) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return slices.Contains(queryPaths, r.URL.Path)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestReadOnly tests that read-only mode passes only reads and queries.
+func TestReadOnly(t *testing.T) {
+	logger := zerolog.Nop()
+	config := ReadOnlyConfig{Enabled: true, QueryPaths: []string{"/api/v1/models"}}
+
+	tests := []struct {
+		name         string
+		config       ReadOnlyConfig
+		method       string
+		path         string
+		expectedPass bool
+	}{
+		{name: "disabled passes writes", config: ReadOnlyConfig{}, method: http.MethodPost, path: "/api/v1/update", expectedPass: true},
+		{name: "GET passes", config: config, method: http.MethodGet, path: "/api/v1/models", expectedPass: true},
+		{name: "HEAD passes", config: config, method: http.MethodHead, path: "/api/v1/models", expectedPass: true},
+		{name: "OPTIONS passes", config: config, method: http.MethodOptions, path: "/api/v1/update", expectedPass: true},
+		{name: "POST to query path passes", config: config, method: http.MethodPost, path: "/api/v1/models", expectedPass: true},
+		{name: "POST sync trigger rejected", config: config, method: http.MethodPost, path: "/api/v1/update", expectedPass: false},
+		{name: "POST review rejected", config: config, method: http.MethodPost, path: "/api/v1/models/gpt-4o/review", expectedPass: false},
+		{name: "PUT rejected", config: config, method: http.MethodPut, path: "/api/v1/models", expectedPass: false},
+		{name: "DELETE rejected", config: config, method: http.MethodDelete, path: "/api/v1/models/gpt-4o", expectedPass: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed := false
+			handler := ReadOnly(tt.config, &logger)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				passed = true
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if passed != tt.expectedPass {
+				t.Errorf("handler called = %v, want %v", passed, tt.expectedPass)
+			}
+			if !tt.expectedPass && rec.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+			}
+		})
+	}
+}
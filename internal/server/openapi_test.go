@@ -0,0 +1,161 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/internal/embedded/openapi"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+var routerAnnotation = regexp.MustCompile(`^//\s*@Router\s+(\S+)\s+\[(\w+)\]`)
+
+// annotatedOperations returns "METHOD path" for every @Router annotation on
+// the HTTP handlers, which is what the spec generator reads.
+func annotatedOperations(t *testing.T) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join("handlers", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var operations []string
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(file) //nolint:gosec // Test reads its own package sources.
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if match := routerAnnotation.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+				operations = append(operations, strings.ToUpper(match[2])+" "+match[1])
+			}
+		}
+	}
+	slices.Sort(operations)
+	return operations
+}
+
+func specOperations(t *testing.T) []string {
+	t.Helper()
+	var spec struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openapi.SpecJSON, &spec); err != nil {
+		t.Fatalf("embedded spec: %v", err)
+	}
+	if spec.OpenAPI != "3.1.0" {
+		t.Fatalf("openapi = %q, want 3.1.0", spec.OpenAPI)
+	}
+	var yamlSpec struct {
+		Paths map[string]map[string]any `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(openapi.SpecYAML, &yamlSpec); err != nil {
+		t.Fatalf("embedded YAML spec: %v", err)
+	}
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			if _, ok := yamlSpec.Paths[path][method]; !ok {
+				t.Errorf("%s %s is in openapi.json but not openapi.yaml", strings.ToUpper(method), path)
+			}
+		}
+	}
+	if len(yamlSpec.Paths) != len(spec.Paths) {
+		t.Errorf("openapi.yaml has %d paths, openapi.json has %d", len(yamlSpec.Paths), len(spec.Paths))
+	}
+	var operations []string
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			operations = append(operations, strings.ToUpper(method)+" "+path)
+		}
+	}
+	slices.Sort(operations)
+	return operations
+}
+
+// TestOpenAPISpecMatchesHandlerAnnotations fails when a handler annotation
+// and the embedded spec disagree; run `make openapi` to regenerate the spec.
+func TestOpenAPISpecMatchesHandlerAnnotations(t *testing.T) {
+	annotated := annotatedOperations(t)
+	if len(annotated) == 0 {
+		t.Fatal("no @Router annotations found in handlers")
+	}
+	documented := specOperations(t)
+	for _, operation := range annotated {
+		if !slices.Contains(documented, operation) {
+			t.Errorf("%s is annotated but missing from the embedded spec; run make openapi", operation)
+		}
+	}
+	for _, operation := range documented {
+		if !slices.Contains(annotated, operation) {
+			t.Errorf("%s is in the embedded spec but no handler annotates it", operation)
+		}
+	}
+}
+
+func TestOpenAPISpecServedAtRoot(t *testing.T) {
+	t.Setenv("API_KEY", "secret")
+	client, err := starmap.New(starmap.WithCatalogStore(catalogstore.NewMemory()))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{
+		PathPrefix: "/api/v1", CacheTTL: time.Minute, AuthEnabled: true, AuthHeader: "X-API-Key",
+	})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	specs := map[string][]byte{
+		"/openapi.json":        openapi.SpecJSON,
+		"/api/v1/openapi.json": openapi.SpecJSON,
+		"/openapi.yaml":        openapi.SpecYAML,
+		"/api/v1/openapi.yaml": openapi.SpecYAML,
+		"/api/v2/openapi.yaml": openapi.SpecYAML,
+	}
+	for path, spec := range specs {
+		resp, err := http.Get(httpServer.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !bytes.Equal(body, spec) {
+			t.Fatalf("GET %s without a key = %d, want the embedded spec", path, resp.StatusCode)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, httpServer.URL+"/api/v1/models/search", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-API-Key", "secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST /api/v1/models/search: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST /api/v1/models/search = %d, want the documented search route", resp.StatusCode)
+	}
+}
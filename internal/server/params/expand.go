@@ -0,0 +1,66 @@
+package params
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Optional model sections a request can expand.
+const (
+	ExpandPricing     = "pricing"
+	ExpandBenchmarks  = "benchmarks"
+	ExpandEvaluations = "evaluations"
+	ExpandProvenance  = "provenance"
+	// ExpandNone requests no optional sections.
+	ExpandNone = "none"
+)
+
+var expandSections = []string{ExpandPricing, ExpandBenchmarks, ExpandEvaluations, ExpandProvenance}
+
+// Expand lists the optional model sections a request asked to serialize.
+type Expand struct {
+	// Requested reports whether the request passed expand at all. Endpoints
+	// whose frozen default shape already inlines a section only trim it when
+	// expansion was requested.
+	Requested bool
+	sections  []string
+}
+
+// Has reports whether section was requested.
+func (e Expand) Has(section string) bool {
+	return slices.Contains(e.sections, section)
+}
+
+// Any reports whether at least one section was requested.
+func (e Expand) Any() bool {
+	return len(e.sections) > 0
+}
+
+// ParseExpand parses the comma-separated expand query parameter. Unknown
+// sections are rejected so typos do not silently return a lean payload.
+func ParseExpand(r *http.Request) (Expand, error) {
+	values, present := r.URL.Query()["expand"]
+	expand := Expand{Requested: present}
+	for _, value := range values {
+		for section := range strings.SplitSeq(value, ",") {
+			section = strings.ToLower(strings.TrimSpace(section))
+			switch {
+			case section == "" || section == ExpandNone:
+			case slices.Contains(expandSections, section):
+				if !expand.Has(section) {
+					expand.sections = append(expand.sections, section)
+				}
+			default:
+				return Expand{}, &errors.ValidationError{
+					Field:   "expand",
+					Value:   section,
+					Message: "must be one of " + strings.Join(expandSections, ", ") + ", or none",
+				}
+			}
+		}
+	}
+	return expand, nil
+}
@@ -0,0 +1,41 @@
+package params
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/agentstation/starmap/internal/catalog/query"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// ParseProviderOptions extracts and validates provider list filters from an
+// HTTP request.
+func ParseProviderOptions(r *http.Request) (query.ProviderOptions, error) {
+	q := r.URL.Query()
+	var opts query.ProviderOptions
+
+	if value := q.Get("sla"); value != "" {
+		sla, err := strconv.ParseBool(value)
+		if err != nil {
+			return query.ProviderOptions{}, &errors.ValidationError{Field: "provider_filter.sla", Value: value, Message: "must be a boolean"}
+		}
+		opts.RequireSLA = sla
+	}
+	if value := q.Get("min_uptime"); value != "" {
+		uptime, err := strconv.ParseFloat(value, 64)
+		if err != nil || uptime < 0 || uptime > 100 {
+			return query.ProviderOptions{}, &errors.ValidationError{Field: "provider_filter.min_uptime", Value: value, Message: "must be a percentage between 0 and 100"}
+		}
+		opts.MinUptime = uptime
+	}
+	uses, err := query.ParseUses(q.Get("use"))
+	if err != nil {
+		return query.ProviderOptions{}, err
+	}
+	opts.Uses = uses
+	if err := query.ValidateRegion(q.Get("region")); err != nil {
+		return query.ProviderOptions{}, err
+	}
+	opts.Region = q.Get("region")
+	return opts, nil
+}
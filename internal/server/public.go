@@ -0,0 +1,37 @@
+package server
+
+import (
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// publicApplication gives API handlers the public view of the catalog (see
+// catalogs.Catalog.Public), so model fields marked private in the overlay
+// are never served. Background work such as budget checks keeps the full
+// catalog.
+type publicApplication struct {
+	application.Application
+}
+
+// Catalog returns the public view of the current catalog.
+func (a publicApplication) Catalog() (*catalogs.Catalog, error) {
+	catalog, err := a.Application.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	return catalog.Public()
+}
+
+// CatalogState returns the current generation with the public view of its
+// catalog.
+func (a publicApplication) CatalogState() (starmap.CatalogState, error) {
+	state, err := a.Application.CatalogState()
+	if err != nil || state.Catalog == nil {
+		return state, err
+	}
+	if state.Catalog, err = state.Catalog.Public(); err != nil {
+		return starmap.CatalogState{}, err
+	}
+	return state, nil
+}
@@ -0,0 +1,249 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/internal/server/events"
+	"github.com/agentstation/starmap/pkg/catalogremote"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+// privateModelServer serves a catalog whose only model has a private
+// description.
+func privateModelServer(t *testing.T) (*starmap.Client, *httptest.Server) {
+	t.Helper()
+	client, err := starmap.New(
+		starmap.WithCatalogStore(smallCatalogStore(t)),
+		starmap.WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			if err := candidate.SetProvider(catalogs.Provider{
+				ID:   "teamco",
+				Name: "TeamCo",
+				Models: map[string]*catalogs.Model{
+					"team-model": {
+						ID:          "team-model",
+						Name:        "Team Model",
+						Description: "Negotiated under contract 42",
+						Private:     []string{"description"},
+					},
+				},
+			}); err != nil {
+				return nil, err
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if full, _ := client.Catalog().ProviderModel("teamco", "team-model"); full.Description == "" {
+		t.Fatal("catalog lost the private description")
+	}
+
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{PathPrefix: "/api/v1", CacheTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+	return client, httpServer
+}
+
+func TestAPIOmitsPrivateModelFields(t *testing.T) {
+	_, httpServer := privateModelServer(t)
+
+	response, err := http.Get(httpServer.URL + "/api/v1/providers/teamco/models") //nolint:noctx
+	if err != nil {
+		t.Fatalf("GET provider models: %v", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("GET provider models status = %d", response.StatusCode)
+	}
+	var body struct {
+		Data struct {
+			Models []catalogs.Model `json:"models"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		t.Fatalf("decode provider models: %v", err)
+	}
+	if len(body.Data.Models) != 1 {
+		t.Fatalf("models = %+v, want one", body.Data.Models)
+	}
+	if model := body.Data.Models[0]; model.Description != "" || model.Private != nil {
+		t.Fatalf("served model = %+v, want description and private list withheld", model)
+	}
+}
+
+func TestReviewOfModelWithPrivateFieldsUsesPublicView(t *testing.T) {
+	_, httpServer := privateModelServer(t)
+
+	model, err := http.Get(httpServer.URL + "/api/v1/models/team-model") //nolint:noctx
+	if err != nil {
+		t.Fatalf("GET model: %v", err)
+	}
+	_ = model.Body.Close()
+	etag := model.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("GET model returned no ETag")
+	}
+
+	review := func(body string) *http.Response {
+		t.Helper()
+		request, err := http.NewRequest(http.MethodPost, httpServer.URL+"/api/v1/models/team-model/review", strings.NewReader(body)) //nolint:noctx
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("If-Match", etag)
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("POST review: %v", err)
+		}
+		t.Cleanup(func() { _ = response.Body.Close() })
+		return response
+	}
+	if response := review(`{"decision": "approved", "reviewer": "alice"}`); response.StatusCode != http.StatusOK {
+		t.Fatalf("review with the served ETag status = %d, want 200", response.StatusCode)
+	}
+
+	stale := review(`{"decision": "rejected", "reviewer": "bob"}`)
+	if stale.StatusCode != http.StatusConflict {
+		t.Fatalf("stale review status = %d, want 409", stale.StatusCode)
+	}
+	var conflict struct {
+		Data struct {
+			Current map[string]catalogs.Model `json:"current"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(stale.Body).Decode(&conflict); err != nil {
+		t.Fatalf("decode conflict: %v", err)
+	}
+	current, found := conflict.Data.Current["providers/teamco"]
+	if !found || current.Description != "" || current.Private != nil {
+		t.Fatalf("conflict record = %+v, want description and private list withheld", current)
+	}
+}
+
+func TestCatalogSnapshotOmitsPrivateModelFields(t *testing.T) {
+	client, httpServer := privateModelServer(t)
+
+	remote, err := catalogremote.NewClient(httpServer.URL+"/api/v1", httpServer.Client(), catalogs.CurrentCatalogSchemaVersion)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	// FetchCurrent verifies the snapshot against the manifest's payload digest
+	generation, err := remote.FetchCurrent(context.Background())
+	if err != nil {
+		t.Fatalf("FetchCurrent: %v", err)
+	}
+	stored, err := client.CurrentGeneration(context.Background())
+	if err != nil {
+		t.Fatalf("CurrentGeneration: %v", err)
+	}
+	if generation.Manifest.GenerationID != stored.Manifest.GenerationID || generation.Manifest.Payload == stored.Manifest.Payload {
+		t.Fatalf("manifest = %+v, want the stored generation with a public payload digest", generation.Manifest)
+	}
+
+	catalog, err := catalogstore.DecodeCatalogPayload(generation.Payload)
+	if err != nil {
+		t.Fatalf("DecodeCatalogPayload: %v", err)
+	}
+	model, err := catalog.ProviderModel("teamco", "team-model")
+	if err != nil {
+		t.Fatalf("ProviderModel: %v", err)
+	}
+	if model.Description != "" || model.Private != nil {
+		t.Fatalf("snapshot model = %+v, want description and private list withheld", model)
+	}
+}
+
+func TestChangeEventsOmitPrivateModelFields(t *testing.T) {
+	name, description := "Team Model", "Negotiated under contract 42"
+	client, err := starmap.New(
+		starmap.WithCatalogStore(smallCatalogStore(t)),
+		starmap.WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			if err := candidate.SetProvider(catalogs.Provider{ID: "teamco", Name: "TeamCo"}); err != nil {
+				return nil, err
+			}
+			model := catalogs.Model{ID: "team-model", Name: name, Description: description, Private: []string{"description"}}
+			if err := candidate.SetProviderModel("teamco", model); err != nil {
+				return nil, err
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{PathPrefix: "/api/v1"})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	server.Start()
+	t.Cleanup(func() { _ = server.Shutdown(context.Background()) })
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/api/v1/updates/ws"
+	connection, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("Connect WebSocket: %v", err)
+	}
+	t.Cleanup(func() { _ = connection.Close() })
+	deadline := time.Now().Add(time.Second)
+	for server.WSHub().ClientCount() != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	name, description = "Team Model 2", "Renegotiated under contract 43"
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	for {
+		var received struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := connection.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+			t.Fatalf("SetReadDeadline: %v", err)
+		}
+		if err := connection.ReadJSON(&received); err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		if strings.Contains(string(received.Data), "contract") {
+			t.Fatalf("%s event carries the private description: %s", received.Type, received.Data)
+		}
+		if received.Type != string(events.ModelUpdated) {
+			continue
+		}
+		var updated events.ModelChange
+		if err := json.Unmarshal(received.Data, &updated); err != nil {
+			t.Fatalf("decode model.updated: %v", err)
+		}
+		if len(updated.Changes) != 1 || updated.Changes[0].Path != "name" || updated.Model.Description != "" || updated.Model.Private != nil {
+			t.Fatalf("model.updated = %+v, want only the public name change", updated)
+		}
+		return
+	}
+}
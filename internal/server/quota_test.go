@@ -0,0 +1,57 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestQuotaEndpointIsOptIn(t *testing.T) {
+	t.Setenv("OPENAI_ADMIN_KEY", "")
+	t.Setenv("ANTHROPIC_ADMIN_KEY", "")
+
+	get := func(t *testing.T, enabled bool, query string) *http.Response {
+		t.Helper()
+		server, err := New(newMockApplication(), Config{PathPrefix: "/api/v1", CacheTTL: time.Minute, QuotaEnabled: enabled})
+		if err != nil {
+			t.Fatalf("New server: %v", err)
+		}
+		httpServer := httptest.NewServer(server.Handler())
+		t.Cleanup(httpServer.Close)
+		response, err := http.Get(httpServer.URL + "/api/v1/quota" + query) //nolint:noctx
+		if err != nil {
+			t.Fatalf("GET quota: %v", err)
+		}
+		t.Cleanup(func() { _ = response.Body.Close() })
+		return response
+	}
+
+	if response := get(t, false, ""); response.StatusCode != http.StatusNotFound {
+		t.Fatalf("disabled quota status = %d, want 404", response.StatusCode)
+	}
+
+	response := get(t, true, "")
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("quota status = %d, want 200", response.StatusCode)
+	}
+	var body struct {
+		Data struct {
+			Count int `json:"count"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		t.Fatalf("decode quota: %v", err)
+	}
+	if body.Data.Count != 0 {
+		t.Fatalf("reports without admin keys = %d, want 0", body.Data.Count)
+	}
+
+	if response := get(t, true, "?provider=groq"); response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unsupported provider status = %d, want 400", response.StatusCode)
+	}
+	if response := get(t, true, "?since=yesterday"); response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("invalid since status = %d, want 400", response.StatusCode)
+	}
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+func TestRateLimitsRunBeforeAuthentication(t *testing.T) {
+	t.Setenv("API_KEY", "test-key")
+	client, err := starmap.New(starmap.WithCatalogStore(catalogstore.NewMemory()))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{
+		PathPrefix:     "/api/v1",
+		CacheTTL:       time.Minute,
+		AuthEnabled:    true,
+		AuthHeader:     "X-API-Key",
+		RateLimit:      3,
+		TokenRateLimit: 1,
+	})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	get := func(key string) *http.Response {
+		t.Helper()
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, httpServer.URL+"/api/v1/models", nil)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		if key != "" {
+			request.Header.Set("X-API-Key", key)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		_ = response.Body.Close()
+		return response
+	}
+
+	for i, tt := range []struct {
+		key  string
+		want int
+	}{
+		{key: "test-key", want: http.StatusOK},
+		{key: "test-key", want: http.StatusTooManyRequests}, // per-key limit
+		{key: "", want: http.StatusUnauthorized},
+		{key: "", want: http.StatusTooManyRequests}, // per-IP limit, before auth
+	} {
+		response := get(tt.key)
+		if response.StatusCode != tt.want {
+			t.Errorf("request %d status = %d, want %d", i, response.StatusCode, tt.want)
+		}
+		if tt.want == http.StatusTooManyRequests && response.Header.Get("Retry-After") == "" {
+			t.Errorf("request %d: 429 without Retry-After", i)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+func TestReadOnlyServerRejectsWrites(t *testing.T) {
+	client, err := starmap.New(
+		starmap.WithCatalogStore(catalogstore.NewMemory()),
+		starmap.WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			if err := candidate.SetProvider(catalogs.Provider{
+				ID:   "held",
+				Name: "Held",
+				Models: map[string]*catalogs.Model{"held-model": {
+					ID: "held-model", Name: "Held", Review: &catalogs.ModelReview{State: catalogs.ModelReviewPending},
+				}},
+			}); err != nil {
+				return nil, err
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	generation := client.CurrentGenerationID()
+
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{PathPrefix: "/api/v1", CacheTTL: time.Minute, ReadOnly: true})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{method: http.MethodGet, path: "/api/v1/models", want: http.StatusOK},
+		{method: http.MethodPost, path: "/api/v1/models", body: `{}`, want: http.StatusOK},
+		{method: http.MethodPost, path: "/api/v1/models/search", body: `{"name_contains":"held"}`, want: http.StatusOK},
+		{method: http.MethodPost, path: "/api/v2/models/search", body: `{}`, want: http.StatusOK},
+		{method: http.MethodPost, path: "/api/v1/update", want: http.StatusForbidden},
+		{method: http.MethodPost, path: "/api/v1/models/held-model/review", body: `{"decision":"approved","reviewer":"ops"}`, want: http.StatusForbidden},
+		{method: http.MethodPost, path: "/api/v2/update", want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		request, err := http.NewRequestWithContext(context.Background(), tt.method, httpServer.URL+tt.path, strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.method, tt.path, err)
+		}
+		_ = response.Body.Close()
+		if response.StatusCode != tt.want {
+			t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, response.StatusCode, tt.want)
+		}
+	}
+
+	if client.CurrentGenerationID() != generation {
+		t.Fatal("read-only server published a new catalog generation")
+	}
+}
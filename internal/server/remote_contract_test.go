@@ -6,8 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
 	"github.com/agentstation/starmap/pkg/catalogremote"
 	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	"github.com/agentstation/starmap/pkg/client"
 )
 
 func TestRemoteCatalogClientAndServerShareVersionedManifestSnapshotContract(t *testing.T) {
@@ -39,3 +44,72 @@ func TestRemoteCatalogClientAndServerShareVersionedManifestSnapshotContract(t *t
 		t.Fatalf("remote generation does not match server current generation")
 	}
 }
+
+func TestCachedClientAppliesServerChangesSinceGeneration(t *testing.T) {
+	models := map[string]*catalogs.Model{
+		"kept":    {ID: "kept", Name: "Kept"},
+		"retired": {ID: "retired", Name: "Retired"},
+	}
+	sm, err := starmap.New(
+		starmap.WithCatalogStore(catalogstore.NewMemory()),
+		starmap.WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			provider := catalogs.Provider{ID: "acme", Name: "Acme", Models: map[string]*catalogs.Model{}}
+			for id, model := range models {
+				copied := *model
+				provider.Models[id] = &copied
+			}
+			if err := candidate.SetProvider(provider); err != nil {
+				return nil, err
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("starmap.New: %v", err)
+	}
+	if err := sm.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: sm}, Config{PathPrefix: "/api/v1", CacheTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	cache, err := client.New(context.Background(), httpServer.URL+"/api/v1",
+		client.WithHTTPClient(httpServer.Client()), client.WithRefreshInterval(0))
+	if err != nil {
+		t.Fatalf("client.New: %v", err)
+	}
+	defer func() { _ = cache.Close() }()
+	if cache.GenerationID() != sm.CurrentGenerationID() {
+		t.Fatalf("cache generation = %q, want %q", cache.GenerationID(), sm.CurrentGenerationID())
+	}
+
+	models["kept"] = &catalogs.Model{ID: "kept", Name: "Kept v2"}
+	models["fresh"] = &catalogs.Model{ID: "fresh", Name: "Fresh"}
+	delete(models, "retired")
+	if err := sm.Update(context.Background()); err != nil {
+		t.Fatalf("second Update: %v", err)
+	}
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if cache.GenerationID() != sm.CurrentGenerationID() {
+		t.Fatalf("refreshed generation = %q, want %q", cache.GenerationID(), sm.CurrentGenerationID())
+	}
+	if model, err := cache.ProviderModel("acme", "kept"); err != nil || model.Name != "Kept v2" {
+		t.Fatalf("kept = %#v, %v", model, err)
+	}
+	if _, err := cache.ProviderModel("acme", "fresh"); err != nil {
+		t.Fatalf("fresh: %v", err)
+	}
+	if _, err := cache.ProviderModel("acme", "retired"); err == nil {
+		t.Fatal("retired model still served after refresh")
+	}
+	if provider, err := cache.Provider("acme"); err != nil || provider.Name != "Acme" {
+		t.Fatalf("provider = %#v, %v", provider, err)
+	}
+}
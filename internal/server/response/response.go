@@ -17,6 +17,8 @@ import (
 type Response struct {
 	Data  any    `json:"data" swaggertype:"object"`
 	Error *Error `json:"error,omitempty"`
+	// APIVersion is set by version shapers that report it (v2 and later).
+	APIVersion string `json:"api_version,omitempty"`
 }
 
 // Error represents an API error with code, message, and optional details.
@@ -88,6 +90,32 @@ func MethodNotAllowed(w http.ResponseWriter, method string) {
 	))
 }
 
+// Conflict writes a 409 error response. data carries the conflicting state so
+// the client can reconcile before retrying; it may be nil.
+func Conflict(w http.ResponseWriter, message string, data any) {
+	resp := Fail("CONFLICT", message, "")
+	resp.Data = data
+	JSON(w, http.StatusConflict, resp)
+}
+
+// PreconditionRequired writes a 428 error response.
+func PreconditionRequired(w http.ResponseWriter, message string) {
+	JSON(w, http.StatusPreconditionRequired, Fail(
+		"PRECONDITION_REQUIRED",
+		"Precondition required",
+		message,
+	))
+}
+
+// PayloadTooLarge writes a 413 error response.
+func PayloadTooLarge(w http.ResponseWriter, message string) {
+	JSON(w, http.StatusRequestEntityTooLarge, Fail(
+		"PAYLOAD_TOO_LARGE",
+		"Request body too large",
+		message,
+	))
+}
+
 // RateLimited writes a 429 error response.
 func RateLimited(w http.ResponseWriter, message string) {
 	JSON(w, http.StatusTooManyRequests, Fail(
@@ -124,6 +152,8 @@ func ErrorFromType(w http.ResponseWriter, err error) {
 		NotFound(w, e.Error(), "")
 	case *errors.ValidationError:
 		BadRequest(w, e.Error(), "")
+	case *errors.ConflictError:
+		Conflict(w, e.Error(), nil)
 	case *errors.SyncError:
 		InternalError(w, err)
 	case *errors.APIError:
@@ -220,6 +220,12 @@ func TestErrorFromType(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedCode:   "BAD_REQUEST",
 		},
+		{
+			name:           "ConflictError",
+			err:            &starmapErrors.ConflictError{Resource: "catalog current generation", Expected: "a", Actual: "b"},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "CONFLICT",
+		},
 		{
 			name:           "SyncError",
 			err:            &starmapErrors.SyncError{Provider: "openai", Err: errors.New("sync failed")},
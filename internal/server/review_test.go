@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+func TestReviewEndpointApprovesPendingModel(t *testing.T) {
+	client, err := starmap.New(
+		starmap.WithCatalogStore(catalogstore.NewMemory()),
+		starmap.WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			if err := candidate.SetProvider(catalogs.Provider{
+				ID:   "held",
+				Name: "Held",
+				Models: map[string]*catalogs.Model{"held-model": {
+					ID: "held-model", Name: "Held", Review: &catalogs.ModelReview{State: catalogs.ModelReviewPending},
+				}},
+			}); err != nil {
+				return nil, err
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{PathPrefix: "/api/v1", CacheTTL: time.Minute})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	count := func(query string) int {
+		t.Helper()
+		response, err := http.Get(httpServer.URL + "/api/v1/providers/held/models" + query) //nolint:noctx
+		if err != nil {
+			t.Fatalf("GET provider models: %v", err)
+		}
+		defer func() { _ = response.Body.Close() }()
+		var body struct {
+			Data struct {
+				Count int `json:"count"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+			t.Fatalf("decode provider models: %v", err)
+		}
+		return body.Data.Count
+	}
+	if got := count(""); got != 0 {
+		t.Fatalf("pending model served by default: count = %d", got)
+	}
+	if got := count("?review=pending-review"); got != 1 {
+		t.Fatalf("pending review count = %d, want 1", got)
+	}
+
+	review := func(ifMatch, body string) *http.Response {
+		t.Helper()
+		request, err := http.NewRequest(http.MethodPost, httpServer.URL+"/api/v1/models/held-model/review", strings.NewReader(body)) //nolint:noctx
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			request.Header.Set("If-Match", ifMatch)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("POST review: %v", err)
+		}
+		t.Cleanup(func() { _ = response.Body.Close() })
+		return response
+	}
+	approve := `{"decision": "approved", "reviewer": "alice", "note": "ok"}`
+
+	if response := review("", approve); response.StatusCode != http.StatusPreconditionRequired {
+		t.Fatalf("review without If-Match status = %d, want 428", response.StatusCode)
+	}
+
+	model, err := http.Get(httpServer.URL + "/api/v1/models/held-model") //nolint:noctx
+	if err != nil {
+		t.Fatalf("GET model: %v", err)
+	}
+	_ = model.Body.Close()
+	etag := model.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("GET model returned no ETag")
+	}
+
+	response := review(etag, approve)
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("POST review status = %d", response.StatusCode)
+	}
+	if next := response.Header.Get("ETag"); next == "" || next == etag {
+		t.Fatalf("review ETag = %q, want a new revision after %q", next, etag)
+	}
+	if got := count(""); got != 1 {
+		t.Fatalf("approved model count = %d, want 1", got)
+	}
+
+	stale := review(etag, `{"decision": "rejected", "reviewer": "bob"}`)
+	if stale.StatusCode != http.StatusConflict {
+		t.Fatalf("stale review status = %d, want 409", stale.StatusCode)
+	}
+	var conflict struct {
+		Data struct {
+			Revision string                    `json:"revision"`
+			Current  map[string]catalogs.Model `json:"current"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(stale.Body).Decode(&conflict); err != nil {
+		t.Fatalf("decode conflict: %v", err)
+	}
+	current := conflict.Data.Current["providers/held"]
+	if `"`+conflict.Data.Revision+`"` != response.Header.Get("ETag") || current.Review == nil || current.Review.State != catalogs.ModelReviewApproved {
+		t.Fatalf("conflict = %#v", conflict.Data)
+	}
+
+	if response := review("*", `{"decision": "maybe", "reviewer": "alice"}`); response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("invalid decision status = %d, want 400", response.StatusCode)
+	}
+}
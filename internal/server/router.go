@@ -1,11 +1,11 @@
 package server
 
 import (
-	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
 
+	"github.com/agentstation/starmap/internal/server/apiversion"
 	"github.com/agentstation/starmap/internal/server/handlers"
 	"github.com/agentstation/starmap/internal/server/middleware"
 )
@@ -16,7 +16,7 @@ func (s *Server) setupRouter() http.Handler {
 
 	// Create handlers instance
 	h := handlers.New(
-		s.app,
+		publicApplication{s.app},
 		s.cache,
 		s.broker,
 		s.wsHub,
@@ -24,6 +24,7 @@ func (s *Server) setupRouter() http.Handler {
 		s.upgrader,
 		s.logger,
 		s.startTime,
+		s.views,
 	)
 
 	// Register routes
@@ -37,15 +38,37 @@ func (s *Server) setupRouter() http.Handler {
 
 // registerRoutes registers all HTTP routes.
 func (s *Server) registerRoutes(mux *http.ServeMux, h *handlers.Handlers) {
-	prefix := s.config.PathPrefix
-
 	// Favicon handler (return 204 No Content to avoid 404 logs)
 	mux.HandleFunc("/favicon.ico", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusNoContent)
 	})
 
-	// Public health endpoints (no auth required)
+	// Public health endpoint (no auth required)
 	mux.HandleFunc("/health", h.HandleHealth)
+
+	// OpenAPI specification at the conventional root path, for client
+	// generators and docs tooling that do not know the API prefix
+	mux.HandleFunc("/openapi.json", h.HandleOpenAPIJSON)
+	mux.HandleFunc("/openapi.yaml", h.HandleOpenAPIYAML)
+
+	// Every API version serves the same routes; responses are shaped per version
+	prefixes := apiversion.Prefixes(s.config.PathPrefix)
+	for _, version := range apiversion.Supported() {
+		prefix := prefixes[version]
+		versioned := http.NewServeMux()
+		s.registerAPIRoutes(versioned, h, prefix)
+		mux.Handle(prefix+"/", apiversion.Middleware(version)(versioned))
+	}
+
+	// Metrics endpoint (optional)
+	if s.config.MetricsEnabled {
+		mux.HandleFunc("/metrics", s.handleMetrics)
+	}
+}
+
+// registerAPIRoutes registers the API routes mounted under one version prefix.
+func (s *Server) registerAPIRoutes(mux *http.ServeMux, h *handlers.Handlers, prefix string) {
+	// Public health endpoints (no auth required)
 	mux.HandleFunc(prefix+"/health", h.HandleHealth)
 	mux.HandleFunc(prefix+"/ready", h.HandleReady)
 
@@ -67,16 +90,34 @@ func (s *Server) registerRoutes(mux *http.ServeMux, h *handlers.Handlers) {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	})
 
+	mux.HandleFunc(prefix+"/models:batchGet", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			h.HandleBatchGetModels(w, r)
+			return
+		}
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	})
+
 	mux.HandleFunc(prefix+"/models/", func(w http.ResponseWriter, r *http.Request) {
 		modelID, err := extractPathParam(r, prefix+"/models/")
 		if err != nil {
 			http.Error(w, "Invalid model ID", http.StatusBadRequest)
 			return
 		}
+		if modelID == "search" && r.Method == http.MethodPost {
+			// POST /models/search
+			h.HandleSearchModels(w, r)
+			return
+		}
 		if modelID != "" && r.Method == http.MethodGet {
 			h.HandleGetModel(w, r, modelID)
 			return
 		}
+		if reviewID, ok := strings.CutSuffix(modelID, "/review"); ok && reviewID != "" && r.Method == http.MethodPost {
+			// POST /models/{id}/review
+			h.HandleReviewModel(w, r, reviewID)
+			return
+		}
 		http.Error(w, "Not found", http.StatusNotFound)
 	})
 
@@ -117,6 +158,34 @@ func (s *Server) registerRoutes(mux *http.ServeMux, h *handlers.Handlers) {
 		http.Error(w, "Not found", http.StatusNotFound)
 	})
 
+	// Views endpoints
+	mux.HandleFunc(prefix+"/views", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			h.HandleListViews(w, r)
+			return
+		}
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	})
+
+	mux.HandleFunc(prefix+"/views/", func(w http.ResponseWriter, r *http.Request) {
+		parts := splitPath(strings.TrimPrefix(r.URL.Path, prefix+"/views/"))
+		if len(parts) == 2 && parts[1] == "models" && r.Method == http.MethodGet {
+			// GET /views/{name}/models
+			h.HandleGetViewModels(w, r, parts[0])
+			return
+		}
+		http.Error(w, "Not found", http.StatusNotFound)
+	})
+
+	// Incremental sync
+	mux.HandleFunc(prefix+"/changes", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			h.HandleGetChanges(w, r)
+			return
+		}
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	})
+
 	// Admin endpoints
 	mux.HandleFunc(prefix+"/catalog/manifest", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet {
@@ -135,6 +204,17 @@ func (s *Server) registerRoutes(mux *http.ServeMux, h *handlers.Handlers) {
 		http.Error(w, "Not found", http.StatusNotFound)
 	})
 
+	// Quota endpoint (optional: queries provider usage APIs with admin keys)
+	if s.config.QuotaEnabled {
+		mux.HandleFunc(prefix+"/quota", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				h.HandleQuota(w, r)
+				return
+			}
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		})
+	}
+
 	mux.HandleFunc(prefix+"/update", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
 			h.HandleUpdate(w, r)
@@ -143,6 +223,17 @@ func (s *Server) registerRoutes(mux *http.ServeMux, h *handlers.Handlers) {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	})
 
+	// Synthetic change events (optional: lets consumers test change handling)
+	if s.config.TestEvents {
+		mux.HandleFunc(prefix+"/admin/test-event", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				h.HandleTestEvent(w, r)
+				return
+			}
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		})
+	}
+
 	mux.HandleFunc(prefix+"/stats", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet {
 			h.HandleStats(w, r)
@@ -166,26 +257,24 @@ func (s *Server) registerRoutes(mux *http.ServeMux, h *handlers.Handlers) {
 	// OpenAPI specification endpoints
 	mux.HandleFunc(prefix+"/openapi.json", h.HandleOpenAPIJSON)
 	mux.HandleFunc(prefix+"/openapi.yaml", h.HandleOpenAPIYAML)
-
-	// Metrics endpoint (optional)
-	if s.config.MetricsEnabled {
-		mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
-			w.Header().Set("Content-Type", "text/plain")
-			_, _ = fmt.Fprintf(w, "# Starmap API Metrics\n")
-			_, _ = fmt.Fprintf(w, "# TYPE starmap_api_info gauge\n")
-			_, _ = fmt.Fprintf(w, "starmap_api_info{version=\"v1\"} 1\n")
-		})
-	}
 }
 
 // applyMiddleware wraps handler with middleware chain.
 func (s *Server) applyMiddleware(handler http.Handler) http.Handler {
 	cfg := s.config
 
-	// Rate limiting (if enabled)
-	if cfg.RateLimit > 0 {
-		rateLimiter := middleware.NewRateLimiter(cfg.RateLimit, s.logger)
-		handler = middleware.RateLimit(rateLimiter)(handler)
+	// Request body size limit (if enabled)
+	handler = middleware.MaxBodySize(cfg.MaxBodySize)(handler)
+
+	// Read-only mode (if enabled). Model search and batch get take POST
+	// bodies but never write, so they stay open.
+	if cfg.ReadOnly {
+		readOnlyConfig := middleware.ReadOnlyConfig{Enabled: true}
+		for _, prefix := range apiversion.Prefixes(cfg.PathPrefix) {
+			readOnlyConfig.QueryPaths = append(readOnlyConfig.QueryPaths,
+				prefix+"/models", prefix+"/models/", prefix+"/models/search", prefix+"/models:batchGet")
+		}
+		handler = middleware.ReadOnly(readOnlyConfig, s.logger)(handler)
 	}
 
 	// Authentication (if enabled)
@@ -193,9 +282,27 @@ func (s *Server) applyMiddleware(handler http.Handler) http.Handler {
 		authConfig := middleware.DefaultAuthConfig()
 		authConfig.Enabled = true
 		authConfig.HeaderName = cfg.AuthHeader
+		prefixes := apiversion.Prefixes(cfg.PathPrefix)
+		for _, version := range apiversion.Supported() {
+			prefix := prefixes[version]
+			authConfig.PublicPaths = append(authConfig.PublicPaths,
+				prefix+"/health", prefix+"/ready", prefix+"/openapi.json", prefix+"/openapi.yaml")
+		}
 		handler = middleware.Auth(authConfig, s.logger)(handler)
 	}
 
+	// Rate limiting (if enabled). It runs before authentication so floods and
+	// key guessing are throttled too. A request carrying an API key must pass
+	// both the per-IP and the per-key limit.
+	if cfg.TokenRateLimit > 0 {
+		tokenLimiter := middleware.NewRateLimiter(cfg.TokenRateLimit, s.logger)
+		handler = middleware.TokenRateLimit(tokenLimiter, cfg.AuthHeader)(handler)
+	}
+	if cfg.RateLimit > 0 {
+		rateLimiter := middleware.NewRateLimiter(cfg.RateLimit, s.logger)
+		handler = middleware.RateLimit(rateLimiter)(handler)
+	}
+
 	// CORS (if enabled)
 	if cfg.CORSEnabled {
 		corsConfig := middleware.DefaultCORSConfig()
@@ -205,9 +312,34 @@ func (s *Server) applyMiddleware(handler http.Handler) http.Handler {
 		} else {
 			corsConfig.AllowAll = true
 		}
+		if len(cfg.CORSMethods) > 0 {
+			corsConfig.AllowedMethods = cfg.CORSMethods
+		}
+		if len(cfg.CORSHeaders) > 0 {
+			corsConfig.AllowedHeaders = cfg.CORSHeaders
+		}
 		handler = middleware.CORS(corsConfig)(handler)
 	}
 
+	// Response compression (if enabled)
+	if cfg.CompressionEnabled {
+		compressConfig := middleware.DefaultCompressConfig()
+		if cfg.CompressionMinSize > 0 {
+			compressConfig.MinSize = cfg.CompressionMinSize
+		}
+		handler = middleware.Compress(compressConfig)(handler)
+	}
+
+	// Security headers (if enabled)
+	if cfg.SecurityHeaders {
+		handler = middleware.SecurityHeaders(middleware.SecurityHeadersConfig{HSTSMaxAge: cfg.HSTSMaxAge})(handler)
+	}
+
+	// Request metrics (if enabled)
+	if s.httpMetrics != nil {
+		handler = s.httpMetrics.Middleware()(handler)
+	}
+
 	// Logging and recovery (always enabled)
 	handler = middleware.Logger(s.logger)(handler)
 	handler = middleware.Recovery(s.logger)(handler)
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+func TestServerBrowserHardening(t *testing.T) {
+	client, err := starmap.New(starmap.WithCatalogStore(catalogstore.NewMemory()))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{
+		PathPrefix:         "/api/v1",
+		CacheTTL:           time.Minute,
+		CORSEnabled:        true,
+		CORSOrigins:        []string{"https://app.example.com"},
+		CORSMethods:        []string{"GET", "POST"},
+		CORSHeaders:        []string{"Content-Type"},
+		CompressionEnabled: true,
+		SecurityHeaders:    true,
+		MaxBodySize:        64,
+	})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	do := func(method, path string, body io.Reader) *http.Response {
+		t.Helper()
+		request, err := http.NewRequestWithContext(context.Background(), method, httpServer.URL+path, body)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		request.Header.Set("Origin", "https://app.example.com")
+		request.Header.Set("Content-Type", "application/json")
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		_ = response.Body.Close()
+		return response
+	}
+
+	preflight := do(http.MethodOptions, "/api/v1/models", nil)
+	if got := preflight.Header.Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+		t.Errorf("Access-Control-Allow-Methods = %q, want %q", got, "GET, POST")
+	}
+	if got := preflight.Header.Get("Access-Control-Allow-Headers"); got != "Content-Type" {
+		t.Errorf("Access-Control-Allow-Headers = %q, want %q", got, "Content-Type")
+	}
+
+	list := do(http.MethodGet, "/api/v1/models", nil)
+	if got := list.Header.Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Errorf("X-Content-Type-Options = %q, want nosniff", got)
+	}
+	if got := list.Header.Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+	if vary := list.Header.Values("Vary"); !slices.Contains(vary, "Origin") || !slices.Contains(vary, "Accept-Encoding") {
+		t.Errorf("Vary = %v, want Origin and Accept-Encoding", vary)
+	}
+
+	// A body without a declared length is cut off while the handler decodes it.
+	oversized := io.MultiReader(strings.NewReader(`{"query":"`), strings.NewReader(strings.Repeat("x", 128)), strings.NewReader(`"}`))
+	if got := do(http.MethodPost, "/api/v1/models", oversized).StatusCode; got != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized search status = %d, want %d", got, http.StatusRequestEntityTooLarge)
+	}
+	if got := do(http.MethodPost, "/api/v1/models", strings.NewReader(`{}`)).StatusCode; got != http.StatusOK {
+		t.Errorf("search status = %d, want %d", got, http.StatusOK)
+	}
+}
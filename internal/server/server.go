@@ -3,6 +3,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -10,17 +11,27 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
 
 	"github.com/agentstation/starmap"
 	"github.com/agentstation/starmap/internal/application"
+	"github.com/agentstation/starmap/internal/catalog/view"
+	"github.com/agentstation/starmap/internal/providers/quota"
 	"github.com/agentstation/starmap/internal/server/cache"
 	"github.com/agentstation/starmap/internal/server/events"
 	"github.com/agentstation/starmap/internal/server/events/adapters"
+	"github.com/agentstation/starmap/internal/server/grpcapi"
+	"github.com/agentstation/starmap/internal/server/middleware"
 	"github.com/agentstation/starmap/internal/server/sse"
 	ws "github.com/agentstation/starmap/internal/server/websocket"
-	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/differ"
+	"github.com/agentstation/starmap/pkg/errors"
 )
 
+// maxWebhooks keeps broker registration within its setup buffer alongside the
+// WebSocket and SSE transports.
+const maxWebhooks = 8
+
 // Server holds the HTTP server state and dependencies.
 type Server struct {
 	app            application.Application
@@ -34,6 +45,10 @@ type Server struct {
 	ctx            context.Context
 	cancel         context.CancelFunc
 	startTime      time.Time
+	views          *view.Set
+	budgets        *quota.BudgetWatcher
+	grpc           *grpcapi.Service
+	httpMetrics    *middleware.HTTPMetrics
 }
 
 // New creates a new server instance with the given configuration.
@@ -70,6 +85,40 @@ func New(app application.Application, cfg Config) (*Server, error) {
 	broker.Subscribe(adapters.NewSSESubscriber(sseBroadcaster))
 	logger.Debug().Msg("SSE transport subscribed - streaming updates active")
 
+	if len(cfg.Webhooks) > maxWebhooks {
+		return nil, &errors.ValidationError{Field: "webhooks", Value: len(cfg.Webhooks), Message: fmt.Sprintf("at most %d are supported", maxWebhooks)}
+	}
+	for _, webhook := range cfg.Webhooks {
+		subscriber, err := adapters.NewWebhookSubscriber(webhook, nil)
+		if err != nil {
+			return nil, err
+		}
+		broker.Subscribe(subscriber)
+		logger.Debug().Str("url", webhook.URL).Msg("Webhook notifications subscribed")
+	}
+
+	views, err := view.NewSet(cfg.Views)
+	if err != nil {
+		return nil, err
+	}
+
+	var budgets *quota.BudgetWatcher
+	if len(cfg.Budgets) > 0 {
+		if budgets, err = quota.NewBudgetWatcher(cfg.Budgets); err != nil {
+			return nil, err
+		}
+		if cfg.BudgetInterval <= 0 {
+			cfg.BudgetInterval = DefaultConfig().BudgetInterval
+		}
+	}
+
+	var catalogService *grpcapi.Service
+	if cfg.GRPCPort > 0 {
+		if catalogService, err = grpcapi.NewService(publicApplication{app}); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create context for managing background services
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -91,6 +140,12 @@ func New(app application.Application, cfg Config) (*Server, error) {
 		ctx:       ctx,
 		cancel:    cancel,
 		startTime: time.Now(),
+		views:     views,
+		budgets:   budgets,
+		grpc:      catalogService,
+	}
+	if cfg.MetricsEnabled {
+		server.httpMetrics = middleware.NewHTTPMetrics()
 	}
 
 	// Connect Starmap hooks to event broker
@@ -138,43 +193,37 @@ func (s *Server) connectHooks() error {
 		if sm.CurrentCatalogState().GenerationID == event.GenerationID {
 			s.cache.ActivateGeneration(event.Sequence, event.GenerationID)
 		}
-		s.broker.Publish(events.CatalogPublished, map[string]any{
+		data := map[string]any{
 			"generation_id": event.GenerationID,
 			"sync_run_id":   event.SyncRunID,
 			"sequence":      event.Sequence,
-		})
-		return nil
-	})
-
-	// Model added
-	sm.OnModelAdded(func(model catalogs.Model) {
-		s.broker.Publish(events.ModelAdded, map[string]any{
-			"model": model,
-		})
-		s.logger.Debug().
-			Str("model_id", model.ID).
-			Msg("Model added event published")
-	})
-
-	// Model updated
-	sm.OnModelUpdated(func(old, updated catalogs.Model) {
-		s.broker.Publish(events.ModelUpdated, map[string]any{
-			"old_model": old,
-			"new_model": updated,
-		})
-		s.logger.Debug().
-			Str("model_id", updated.ID).
-			Msg("Model updated event published")
-	})
-
-	// Model removed
-	sm.OnModelRemoved(func(model catalogs.Model) {
-		s.broker.Publish(events.ModelDeleted, map[string]any{
-			"model": model,
-		})
+		}
+		if event.Previous == nil || event.Catalog == nil {
+			s.broker.Publish(events.CatalogPublished, data)
+			return nil
+		}
+		// Stream clients receive one typed event per provider and model
+		// change after the catalog.published event that announces them.
+		// Changes are diffed between public views so private fields and
+		// their old and new values are never streamed.
+		previous, err := event.Previous.Public()
+		if err != nil {
+			s.broker.Publish(events.CatalogPublished, data)
+			return err
+		}
+		current, err := event.Catalog.Public()
+		if err != nil {
+			s.broker.Publish(events.CatalogPublished, data)
+			return err
+		}
+		changeset := differ.New().Catalogs(previous, current)
+		s.broker.PublishChangeset(events.CatalogPublished, data, changeset)
+		s.broker.PublishChanges(event.GenerationID, changeset)
 		s.logger.Debug().
-			Str("model_id", model.ID).
-			Msg("Model deleted event published")
+			Str("generation_id", event.GenerationID).
+			Int("changes", changeset.Summary.TotalChanges).
+			Msg("Catalog change events published")
+		return nil
 	})
 
 	s.logger.Info().Msg("Starmap hooks connected to event broker")
@@ -194,14 +243,81 @@ func (s *Server) Start() {
 	s.logger.Debug().Msg("Starting SSE broadcaster")
 	go s.sseBroadcaster.Run(s.ctx)
 
+	if s.grpc != nil {
+		// Subscribed once the broker runs: the setup buffer is sized for
+		// the transports and webhooks registered in New.
+		s.logger.Debug().Msg("Subscribing gRPC transport to event broker")
+		s.broker.Subscribe(s.grpc)
+	}
+
+	if s.budgets != nil {
+		s.logger.Debug().Dur("interval", s.config.BudgetInterval).Msg("Starting budget watcher")
+		go s.watchBudgets(s.ctx)
+	}
+
 	s.logger.Debug().Msg("All background services started")
 }
 
+// watchBudgets checks provider spend against the configured budgets until ctx
+// is done, publishing a budget.threshold event for every crossed threshold.
+func (s *Server) watchBudgets(ctx context.Context) {
+	ticker := time.NewTicker(s.config.BudgetInterval)
+	defer ticker.Stop()
+	for {
+		s.checkBudgets(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) checkBudgets(ctx context.Context) {
+	catalog, err := s.app.Catalog()
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Budget check skipped: catalog unavailable")
+		return
+	}
+	alerts, err := s.budgets.Check(ctx, catalog)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Budget check incomplete")
+	}
+	for _, alert := range alerts {
+		s.logger.Warn().
+			Str("provider", string(alert.ProviderID)).
+			Float64("spend", alert.Spend).
+			Float64("budget", alert.Budget).
+			Float64("threshold", alert.Threshold).
+			Msg("Provider spend crossed budget threshold")
+		s.broker.Publish(events.BudgetThreshold, alert.Data())
+	}
+}
+
 // Handler returns the configured http.Handler with middleware chain applied.
 func (s *Server) Handler() http.Handler {
 	return s.setupRouter()
 }
 
+// GRPCServer returns a gRPC server serving starmap.v1.CatalogService, or nil
+// when Config.GRPCPort is 0. API key authentication applies as it does to
+// the HTTP API.
+func (s *Server) GRPCServer() *grpc.Server {
+	if s.grpc == nil {
+		return nil
+	}
+	var options []grpc.ServerOption
+	if s.config.AuthEnabled {
+		auth := middleware.DefaultAuthConfig()
+		auth.HeaderName = s.config.AuthHeader
+		unary, stream := grpcapi.Auth(auth.HeaderName, auth.APIKey)
+		options = append(options, grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	}
+	server := grpc.NewServer(options...)
+	s.grpc.Register(server)
+	return server
+}
+
 // Shutdown gracefully shuts down background services.
 // The context controls the shutdown timeout - shutdown will abort if context is cancelled.
 func (s *Server) Shutdown(ctx context.Context) error {
@@ -209,6 +325,9 @@ func (s *Server) Shutdown(ctx context.Context) error {
 
 	// Cancel the context to stop all background services
 	s.cancel()
+	if s.grpc != nil {
+		_ = s.grpc.Close() // End WatchChanges streams so gRPC can stop gracefully
+	}
 
 	// Give background services a grace period to finish in-flight operations
 	// The grace period is a minimum delay; context timeout is the maximum
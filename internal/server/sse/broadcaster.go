@@ -14,9 +14,12 @@ import (
 	"github.com/agentstation/starmap/internal/server/events"
 )
 
-// Broadcaster manages Server-Sent Events connections.
+// Broadcaster manages Server-Sent Events connections. Slow clients are
+// skipped rather than disconnected; a client that misses a catalog change
+// receives a catalog.resync_required event once it has caught up.
 type Broadcaster struct {
 	clients    map[chan Event]bool
+	resync     map[chan Event]string // newest generation each client missed
 	newClients chan chan Event
 	closed     chan chan Event
 	events     chan Event
@@ -29,6 +32,7 @@ type Broadcaster struct {
 func NewBroadcaster(logger *zerolog.Logger) *Broadcaster {
 	return &Broadcaster{
 		clients:    make(map[chan Event]bool),
+		resync:     make(map[chan Event]string),
 		newClients: make(chan chan Event, 10), // Buffered to prevent blocking when clients connect before Run() starts
 		closed:     make(chan chan Event, 10), // Buffered to prevent blocking during client cleanup
 		events:     make(chan Event, 256),
@@ -64,6 +68,7 @@ func (b *Broadcaster) Run(ctx context.Context) {
 		case client := <-b.closed:
 			b.mu.Lock()
 			delete(b.clients, client)
+			delete(b.resync, client)
 			close(client)
 			b.mu.Unlock()
 			b.logger.Info().
@@ -76,12 +81,16 @@ func (b *Broadcaster) Run(ctx context.Context) {
 	}
 }
 
-// Broadcast sends an event to all connected SSE clients.
-func (b *Broadcaster) Broadcast(event Event) {
+// Broadcast sends an event to all connected SSE clients. It reports
+// whether the event was queued; it is dropped when the broadcast channel
+// is full.
+func (b *Broadcaster) Broadcast(event Event) bool {
 	select {
 	case b.events <- event:
+		return true
 	default:
 		b.logger.Warn().Msg("SSE broadcast channel full, event dropped")
+		return false
 	}
 }
 
@@ -106,13 +115,36 @@ func (b *Broadcaster) deliveryTargets() []events.DeliveryTarget[Event] {
 		targets = append(targets, events.DeliveryTarget[Event]{
 			ID: fmt.Sprintf("%p", client),
 			Send: func(event Event) error {
-				return events.TrySend(client, event)
+				err := events.TrySend(client, event)
+				if err != nil {
+					b.markResync(client, event)
+				}
+				return err
 			},
 		})
 	}
 	return targets
 }
 
+// markResync records that client missed event, if it is a catalog change.
+func (b *Broadcaster) markResync(client chan Event, event Event) {
+	generationID := events.GenerationID(event.Data)
+	if generationID == "" {
+		return
+	}
+	b.mu.Lock()
+	b.resync[client] = generationID
+	b.mu.Unlock()
+}
+
+func (b *Broadcaster) takeResync(client chan Event) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	generationID := b.resync[client]
+	delete(b.resync, client)
+	return generationID
+}
+
 // ServeHTTP handles SSE connections.
 func (b *Broadcaster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Set SSE headers
@@ -154,6 +186,17 @@ func (b *Broadcaster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		select {
 		case event := <-client:
 			b.writeEvent(w, flusher, event)
+			// A skipped client's buffer was full, so it reaches empty again
+			// only after every event queued before the skip was written.
+			if len(client) == 0 {
+				if generationID := b.takeResync(client); generationID != "" {
+					b.writeEvent(w, flusher, Event{
+						Event: string(events.ResyncRequired),
+						ID:    fmt.Sprintf("%d", time.Now().Unix()),
+						Data:  events.Resync{GenerationID: generationID},
+					})
+				}
+			}
 
 		case <-r.Context().Done():
 			return
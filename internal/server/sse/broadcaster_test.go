@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap/internal/server/events"
 )
 
 // TestBroadcaster_NewBroadcaster tests broadcaster creation.
@@ -325,6 +327,35 @@ func TestBroadcaster_ClientBufferFullRecordsSkippedDelivery(t *testing.T) {
 	}
 }
 
+func TestBroadcaster_SkippedCatalogChangeRequiresResync(t *testing.T) {
+	logger := zerolog.Nop()
+	b := NewBroadcaster(&logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go b.Run(ctx)
+	time.Sleep(10 * time.Millisecond)
+
+	client := make(chan Event, 1)
+	b.newClients <- client
+	time.Sleep(10 * time.Millisecond)
+
+	b.Broadcast(Event{Event: "budget.threshold", Data: map[string]any{"percent": 90}})
+	time.Sleep(10 * time.Millisecond)
+	b.Broadcast(Event{Event: "budget.threshold", Data: map[string]any{"percent": 100}})
+	time.Sleep(10 * time.Millisecond)
+	if generationID := b.takeResync(client); generationID != "" {
+		t.Fatalf("skipped budget alert requires resync of %q, want none", generationID)
+	}
+
+	b.Broadcast(Event{Event: "model.updated", Data: events.ModelChange{GenerationID: "gen-7", ModelID: "gpt-4o"}})
+	time.Sleep(10 * time.Millisecond)
+	if generationID := b.takeResync(client); generationID != "gen-7" {
+		t.Fatalf("skipped model change requires resync of %q, want gen-7", generationID)
+	}
+}
+
 // TestBroadcaster_ServeHTTP tests the SSE HTTP handler.
 func TestBroadcaster_ServeHTTP(t *testing.T) {
 	logger := zerolog.Nop()
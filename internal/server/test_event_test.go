@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/internal/server/events"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+func TestTestEventEndpointPublishesSyntheticChanges(t *testing.T) {
+	client, err := starmap.New(
+		starmap.WithCatalogStore(catalogstore.NewMemory()),
+		starmap.WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			if err := candidate.SetProvider(catalogs.Provider{ID: "priced", Name: "Priced"}); err != nil {
+				return nil, err
+			}
+			if err := candidate.SetProviderModel("priced", catalogs.Model{
+				ID: "priced-model", Name: "Priced",
+				Pricing: &catalogs.ModelPricing{Tokens: &catalogs.ModelTokenPricing{Input: &catalogs.ModelTokenCost{Per1M: 1}}},
+			}); err != nil {
+				return nil, err
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	generation := client.CurrentGenerationID()
+
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{PathPrefix: "/api/v1", TestEvents: true})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	server.Start()
+	t.Cleanup(func() { _ = server.Shutdown(context.Background()) })
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	wsURL := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/api/v1/updates/ws"
+	connection, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		t.Fatalf("Connect WebSocket: %v", err)
+	}
+	t.Cleanup(func() { _ = connection.Close() })
+	if err := connection.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("SetReadDeadline: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for server.WSHub().ClientCount() != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	post := func(body string) int {
+		t.Helper()
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, httpServer.URL+"/api/v1/admin/test-event", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("POST test-event: %v", err)
+		}
+		_ = response.Body.Close()
+		return response.StatusCode
+	}
+
+	for body, want := range map[string]int{
+		`{"kind":"outage","provider":"priced","model":"priced-model"}`:         http.StatusBadRequest,
+		`{"kind":"model_removal","provider":"priced"}`:                         http.StatusBadRequest,
+		`{"kind":"model_removal","provider":"priced","model":"missing-model"}`: http.StatusNotFound,
+	} {
+		if got := post(body); got != want {
+			t.Errorf("%s status = %d, want %d", body, got, want)
+		}
+	}
+	if got := post(`{"kind":"price_change","provider":"priced","model":"priced-model","factor":3}`); got != http.StatusOK {
+		t.Fatalf("price_change status = %d, want %d", got, http.StatusOK)
+	}
+
+	var received struct {
+		Type string `json:"type"`
+		Data struct {
+			ModelID      string `json:"model_id"`
+			GenerationID string `json:"generation_id"`
+			Test         bool   `json:"test"`
+		} `json:"data"`
+	}
+	for received.Type != string(events.ModelUpdated) {
+		if err := connection.ReadJSON(&received); err != nil {
+			t.Fatalf("Read model.updated: %v", err)
+		}
+	}
+	if !received.Data.Test || received.Data.ModelID != "priced-model" || received.Data.GenerationID != events.TestGenerationID {
+		t.Errorf("model.updated data = %+v, want a test change to priced-model", received.Data)
+	}
+	if client.CurrentGenerationID() != generation {
+		t.Fatal("test event published a new catalog generation")
+	}
+}
+
+func TestTestEventEndpointRequiresTestEvents(t *testing.T) {
+	client, err := starmap.New(starmap.WithCatalogStore(catalogstore.NewMemory()))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{PathPrefix: "/api/v1"})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/admin/test-event", strings.NewReader(`{}`))
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("status without --test-events = %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
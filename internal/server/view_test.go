@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/internal/catalog/view"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+func TestViewModelsEndpointServesMaterializedView(t *testing.T) {
+	client, err := starmap.New(
+		starmap.WithCatalogStore(catalogstore.NewMemory()),
+		starmap.WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			if err := candidate.SetProvider(catalogs.Provider{
+				ID:   "teamco",
+				Name: "TeamCo",
+				Models: map[string]*catalogs.Model{
+					"approved-model": {ID: "approved-model", Name: "Approved", Curation: &catalogs.ModelCuration{Tags: []string{"approved-for-prod"}}},
+					"other-model":    {ID: "other-model", Name: "Other"},
+				},
+			}); err != nil {
+				return nil, err
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{
+		PathPrefix: "/api/v1",
+		CacheTTL:   time.Minute,
+		Views: []view.Definition{{
+			Name:      "frontend-team",
+			Filter:    "provider=teamco&curation=approved-for-prod",
+			Overrides: map[string]view.ModelOverride{"approved-model": {Name: "Team Model"}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	httpServer := httptest.NewServer(server.Handler())
+	t.Cleanup(httpServer.Close)
+
+	response, err := http.Get(httpServer.URL + "/api/v1/views/frontend-team/models") //nolint:noctx
+	if err != nil {
+		t.Fatalf("GET view models: %v", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("GET view models status = %d", response.StatusCode)
+	}
+	var body struct {
+		Data struct {
+			View   string           `json:"view"`
+			Models []catalogs.Model `json:"models"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		t.Fatalf("decode view models: %v", err)
+	}
+	if body.Data.View != "frontend-team" || len(body.Data.Models) != 1 {
+		t.Fatalf("view response = %+v, want one model", body.Data)
+	}
+	if model := body.Data.Models[0]; model.ID != "approved-model" || model.Name != "Team Model" {
+		t.Fatalf("view model = %s %q, want approved-model with override name", model.ID, model.Name)
+	}
+
+	missing, err := http.Get(httpServer.URL + "/api/v1/views/unknown/models") //nolint:noctx
+	if err != nil {
+		t.Fatalf("GET unknown view: %v", err)
+	}
+	_ = missing.Body.Close()
+	if missing.StatusCode != http.StatusNotFound {
+		t.Fatalf("unknown view status = %d, want 404", missing.StatusCode)
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/agentstation/starmap"
+	"github.com/agentstation/starmap/internal/server/events/adapters"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+)
+
+func TestWebhookNotificationRendersPublishedChangeset(t *testing.T) {
+	client, err := starmap.New(
+		starmap.WithCatalogStore(catalogstore.NewMemory()),
+		starmap.WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			if err := candidate.SetProvider(catalogs.Provider{ID: "notified", Name: "Notified"}); err != nil {
+				return nil, err
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	bodies := make(chan string, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- string(body)
+	}))
+	t.Cleanup(webhook.Close)
+
+	logger := zerolog.Nop()
+	server, err := New(&mockApplication{logger: &logger, sm: client}, Config{
+		PathPrefix: "/api/v1", CacheTTL: time.Minute,
+		Webhooks: []adapters.WebhookConfig{{
+			URL:      webhook.URL,
+			Template: `{"providers_added": {{ .Summary.ProvidersAdded }}{{ range .Changeset.Providers.Added }}, "id": {{ json .ID }}{{ end }}}`,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("New server: %v", err)
+	}
+	server.Start()
+	t.Cleanup(func() { _ = server.Shutdown(context.Background()) })
+
+	if err := client.Update(context.Background()); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	select {
+	case body := <-bodies:
+		if body != `{"providers_added": 1, "id": "notified"}` {
+			t.Fatalf("webhook body = %s", body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not notified of the published generation")
+	}
+}
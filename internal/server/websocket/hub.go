@@ -75,12 +75,15 @@ func (h *Hub) Register(client *Client) {
 	h.register <- client
 }
 
-// Broadcast sends a message to all connected clients.
-func (h *Hub) Broadcast(message Message) {
+// Broadcast sends a message to all connected clients. It reports whether
+// the message was queued; it is dropped when the broadcast channel is full.
+func (h *Hub) Broadcast(message Message) bool {
 	select {
 	case h.broadcast <- message:
+		return true
 	default:
 		h.logger.Warn().Msg("Broadcast channel full, message dropped")
+		return false
 	}
 }
 
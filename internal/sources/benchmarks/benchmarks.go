@@ -0,0 +1,273 @@
+// Package benchmarks provides a source that ingests public benchmark and
+// leaderboard scores, such as MMLU, LMSYS Chatbot Arena Elo, and HumanEval,
+// from a published dataset. Scores are keyed by model and attached to every
+// provider offering of that model in the catalog, so they are reconciled
+// under the benchmarks authority tier like any other source data.
+package benchmarks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agentstation/utc"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// Dataset is the published benchmark document the source reads.
+//
+//	{
+//	  "source_url": "https://lmarena.ai/leaderboard",
+//	  "updated_at": "2026-10-01T00:00:00Z",
+//	  "models": [
+//	    {"model": "gpt-4o", "mmlu": 88.7, "arena_elo": 1287, "humaneval": 90.2}
+//	  ]
+//	}
+type Dataset struct {
+	SourceURL string      `json:"source_url,omitempty"` // Where the scores are published
+	UpdatedAt *utc.Time   `json:"updated_at,omitempty"` // When the scores were published
+	Models    []ModelEval `json:"models"`               // Scores per model
+}
+
+// ModelEval is the scores of one model in a dataset. Model is a definition
+// ID or provider model ID, compared without case.
+type ModelEval struct {
+	Model     string   `json:"model"`
+	MMLU      *float64 `json:"mmlu,omitempty"`
+	ArenaElo  *float64 `json:"arena_elo,omitempty"`
+	HumanEval *float64 `json:"humaneval,omitempty"`
+	SourceURL string   `json:"source_url,omitempty"` // Overrides the dataset source URL
+}
+
+// Source observes benchmark scores for the models of a catalog.
+type Source struct {
+	location   string
+	catalog    catalogs.Reader
+	httpClient *http.Client
+}
+
+var _ sources.Source = (*Source)(nil)
+
+// New creates a benchmarks source. location is an HTTP(S) URL or a file path
+// of a Dataset; catalog supplies the provider models scores are attached to.
+func New(location string, catalog catalogs.Reader, opts ...Option) *Source {
+	s := &Source{location: location, catalog: catalog}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Option configures a benchmarks source.
+type Option func(*Source)
+
+// WithHTTPClient sets the HTTP client used to fetch a dataset URL.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Source) {
+		s.httpClient = client
+	}
+}
+
+// ID returns the ID of this source.
+func (s *Source) ID() sources.ID {
+	return sources.BenchmarksID
+}
+
+// Name returns the human-friendly name of this source.
+func (s *Source) Name() string {
+	return "Benchmarks"
+}
+
+// Observe reads the dataset and returns a catalog holding the scores of
+// every provider model it names. Entries naming no catalog model are
+// skipped; entries with out-of-range scores are rejected and make the
+// observation partial.
+func (s *Source) Observe(ctx context.Context, _ ...sources.Option) (sources.Observation, error) {
+	data, err := s.read(ctx)
+	if err != nil {
+		return sources.Observation{}, err
+	}
+	var dataset Dataset
+	if err := json.Unmarshal(data, &dataset); err != nil {
+		return sources.Observation{}, errors.WrapParse("json", s.location, err)
+	}
+
+	offerings := s.offeringIndex()
+	providers := make(map[catalogs.ProviderID]*catalogs.Provider)
+	var records sources.ObservationRecordCounts
+	var issues []sources.ObservationIssue
+	for _, entry := range dataset.Models {
+		evaluations := dataset.evaluations(entry)
+		if err := evaluations.Validate(); err != nil {
+			records.Rejected++
+			issues = append(issues, sources.ObservationIssue{
+				Scope: sources.ObservationIssueScopeRecord, Code: sources.ObservationIssueCodeInvalidRecord,
+				Subject: entry.Model, Message: err.Error(),
+			})
+			continue
+		}
+		matched := offerings[strings.ToLower(strings.TrimSpace(entry.Model))]
+		if len(matched) == 0 || evaluations.IsEmpty() {
+			continue
+		}
+		records.Accepted++
+		for _, offering := range matched {
+			provider, found := providers[offering.provider.ID]
+			if !found {
+				provider = &catalogs.Provider{ID: offering.provider.ID, Name: offering.provider.Name, Models: map[string]*catalogs.Model{}}
+				providers[offering.provider.ID] = provider
+			}
+			provider.Models[offering.model.ID] = &catalogs.Model{
+				ID:          offering.model.ID,
+				Name:        offering.model.Name,
+				Evaluations: dataset.evaluations(entry),
+			}
+		}
+	}
+
+	builder := catalogs.NewEmpty()
+	for _, provider := range providers {
+		if err := builder.SetProvider(*provider); err != nil {
+			return sources.Observation{}, errors.WrapResource("set", "provider", string(provider.ID), err)
+		}
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		return sources.Observation{}, errors.WrapResource("build", "benchmarks catalog", "", err)
+	}
+	metadata := sources.ObservationMetadata{
+		ObservedAt:   time.Now().UTC(),
+		Revision:     sources.Revision{Kind: sources.RevisionKindContentDigest},
+		Completeness: sources.ObservationCompletenessComplete,
+		Status:       sources.ObservationStatusSucceeded,
+		Records:      records,
+	}
+	if len(issues) > 0 {
+		metadata.Completeness = sources.ObservationCompletenessPartial
+		metadata.Status = sources.ObservationStatusDegraded
+		metadata.Issues = issues
+	}
+	return sources.NewObservation(s.ID(), catalog, metadata)
+}
+
+// evaluations converts one dataset entry to model evaluations.
+func (d Dataset) evaluations(entry ModelEval) *catalogs.ModelEvaluations {
+	evaluations := &catalogs.ModelEvaluations{
+		MMLU:      entry.MMLU,
+		ArenaElo:  entry.ArenaElo,
+		HumanEval: entry.HumanEval,
+		UpdatedAt: d.UpdatedAt,
+	}
+	sourceURL := entry.SourceURL
+	if sourceURL == "" {
+		sourceURL = d.SourceURL
+	}
+	if sourceURL != "" {
+		evaluations.SourceURL = &sourceURL
+	}
+	return evaluations
+}
+
+// offering is one provider model scores can be attached to.
+type offering struct {
+	provider catalogs.Provider
+	model    *catalogs.Model
+}
+
+// offeringIndex maps lowercase model and definition IDs to the provider
+// models they name. A definition ID reaches every linked provider model.
+func (s *Source) offeringIndex() map[string][]offering {
+	index := make(map[string][]offering)
+	if s.catalog == nil {
+		return index
+	}
+	providers := s.catalog.Providers().List()
+	slices.SortFunc(providers, func(left, right catalogs.Provider) int {
+		return strings.Compare(string(left.ID), string(right.ID))
+	})
+	for _, provider := range providers {
+		for _, model := range provider.Models {
+			if model == nil {
+				continue
+			}
+			keys := []string{strings.ToLower(model.ID)}
+			if definition := strings.ToLower(model.Definition); definition != "" && definition != keys[0] {
+				keys = append(keys, definition)
+			}
+			for _, key := range keys {
+				index[key] = append(index[key], offering{provider: provider, model: model})
+			}
+		}
+	}
+	return index
+}
+
+// read returns the dataset from its URL or file path.
+func (s *Source) read(ctx context.Context) ([]byte, error) {
+	parsed, err := url.Parse(s.location)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		data, err := os.ReadFile(s.location)
+		if err != nil {
+			return nil, errors.WrapIO("read", s.location, err)
+		}
+		return data, nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.location, nil)
+	if err != nil {
+		return nil, errors.WrapResource("create", "benchmarks request", s.location, err)
+	}
+	client := s.httpClient
+	if client == nil {
+		client = &http.Client{Timeout: constants.DefaultHTTPTimeout}
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, errors.WrapResource("fetch", "benchmarks dataset", s.location, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		return nil, &errors.APIError{
+			Provider:   string(sources.BenchmarksID),
+			StatusCode: response.StatusCode,
+			Message:    "unexpected status " + response.Status,
+			Endpoint:   s.location,
+		}
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, constants.MaxSourcePayloadBytes+1))
+	if err != nil {
+		return nil, errors.WrapIO("read", s.location, err)
+	}
+	if len(data) > constants.MaxSourcePayloadBytes {
+		return nil, &errors.ValidationError{Field: "benchmarks dataset", Value: len(data), Message: "exceeds the source payload size limit"}
+	}
+	return data, nil
+}
+
+// Cleanup releases any resources.
+func (s *Source) Cleanup() error {
+	return nil
+}
+
+// Dependencies returns the list of external dependencies.
+// The benchmarks source only reads a file or URL.
+func (s *Source) Dependencies() []sources.Dependency {
+	return nil
+}
+
+// IsOptional returns whether this source is optional. Benchmarks are
+// requested explicitly, so a sync that cannot read the dataset fails rather
+// than silently publishing without scores.
+func (s *Source) IsOptional() bool {
+	return false
+}
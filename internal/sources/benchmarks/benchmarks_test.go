@@ -0,0 +1,123 @@
+package benchmarks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+const testDataset = `{
+  "source_url": "https://example.com/leaderboard",
+  "updated_at": "2026-10-01T00:00:00Z",
+  "models": [
+    {"model": "GPT-4o", "mmlu": 88.7, "arena_elo": 1287, "humaneval": 90.2},
+    {"model": "llama-3.1-70b", "mmlu": 86, "source_url": "https://example.com/llama"},
+    {"model": "unknown-model", "mmlu": 70},
+    {"model": "broken", "mmlu": 140}
+  ]
+}`
+
+// testCatalog serves gpt-4o at openai and Llama 3.1 70B at two providers
+// linked to one definition.
+func testCatalog(t *testing.T) *catalogs.Catalog {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	for _, provider := range []catalogs.Provider{
+		{ID: "openai", Name: "OpenAI", Models: map[string]*catalogs.Model{
+			"gpt-4o": {ID: "gpt-4o", Name: "GPT-4o"},
+		}},
+		{ID: "groq", Name: "Groq", Models: map[string]*catalogs.Model{
+			"llama-3.1-70b-versatile": {ID: "llama-3.1-70b-versatile", Name: "Llama 3.1 70B", Definition: "llama-3.1-70b"},
+		}},
+		{ID: "bedrock", Name: "Bedrock", Models: map[string]*catalogs.Model{
+			"meta.llama3-1-70b-instruct-v1:0": {ID: "meta.llama3-1-70b-instruct-v1:0", Name: "Llama 3.1 70B Instruct", Definition: "llama-3.1-70b"},
+		}},
+	} {
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider(%s): %v", provider.ID, err)
+		}
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return catalog
+}
+
+func TestObserveAttachesScoresByModelAndDefinition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "benchmarks.json")
+	if err := os.WriteFile(path, []byte(testDataset), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	observation, err := New(path, testCatalog(t)).Observe(context.Background())
+	if err != nil {
+		t.Fatalf("Observe: %v", err)
+	}
+	if observation.SourceID != sources.BenchmarksID {
+		t.Fatalf("SourceID = %q, want %q", observation.SourceID, sources.BenchmarksID)
+	}
+	if observation.Records.Accepted != 2 || observation.Records.Rejected != 1 {
+		t.Fatalf("Records = %+v, want 2 accepted and 1 rejected", observation.Records)
+	}
+	if observation.Status != sources.ObservationStatusDegraded || len(observation.Issues) != 1 || observation.Issues[0].Subject != "broken" {
+		t.Fatalf("Status = %q, Issues = %+v, want the out-of-range entry reported", observation.Status, observation.Issues)
+	}
+
+	gpt, err := observation.Catalog.ProviderModel("openai", "gpt-4o")
+	if err != nil {
+		t.Fatalf("gpt-4o missing: %v", err)
+	}
+	if e := gpt.Evaluations; e == nil || *e.MMLU != 88.7 || *e.ArenaElo != 1287 || *e.HumanEval != 90.2 ||
+		*e.SourceURL != "https://example.com/leaderboard" || e.UpdatedAt == nil {
+		t.Fatalf("gpt-4o evaluations = %+v", gpt.Evaluations)
+	}
+	for _, key := range []catalogs.OfferingKey{
+		{ProviderID: "groq", ProviderModelID: "llama-3.1-70b-versatile"},
+		{ProviderID: "bedrock", ProviderModelID: "meta.llama3-1-70b-instruct-v1:0"},
+	} {
+		model, err := observation.Catalog.ProviderModel(key.ProviderID, string(key.ProviderModelID))
+		if err != nil {
+			t.Fatalf("%s missing: %v", key.ProviderModelID, err)
+		}
+		if e := model.Evaluations; e == nil || *e.MMLU != 86 || e.ArenaElo != nil || *e.SourceURL != "https://example.com/llama" {
+			t.Fatalf("%s evaluations = %+v", key.ProviderModelID, model.Evaluations)
+		}
+	}
+	if got := len(observation.Catalog.Models().List()); got != 3 {
+		t.Fatalf("observed %d models, want 3", got)
+	}
+}
+
+func TestObserveFetchesDatasetURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"models": [{"model": "gpt-4o", "arena_elo": 1287}]}`))
+	}))
+	defer server.Close()
+
+	observation, err := New(server.URL, testCatalog(t), WithHTTPClient(server.Client())).Observe(context.Background())
+	if err != nil {
+		t.Fatalf("Observe: %v", err)
+	}
+	if observation.Status != sources.ObservationStatusSucceeded || observation.Records.Accepted != 1 {
+		t.Fatalf("Status = %q, Records = %+v, want one accepted record", observation.Status, observation.Records)
+	}
+}
+
+func TestObserveFailsForUnreachableDataset(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := New(server.URL, testCatalog(t), WithHTTPClient(server.Client())).Observe(context.Background()); err == nil {
+		t.Fatal("Observe succeeded for a missing dataset")
+	}
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), testCatalog(t)).Observe(context.Background()); err == nil {
+		t.Fatal("Observe succeeded for a missing file")
+	}
+}
@@ -15,6 +15,7 @@ import (
 // processFetch handles the common logic for fetching models from models.dev API.
 func processFetch(catalog *catalogs.Builder, api *API, opts ...sources.Option) (int, int, []sources.ObservationIssue, error) {
 	options := sources.Defaults().Apply(opts...)
+	reportSchema(api)
 	candidateCount := modelsDevCandidateCount(api, options.ProviderID)
 
 	// Set the default merge strategy for models.dev catalog enrichment.
@@ -36,7 +37,11 @@ func processFetch(catalog *catalogs.Builder, api *API, opts ...sources.Option) (
 		if options.ProviderID != nil && providerID != *options.ProviderID {
 			continue
 		}
+		issues = append(issues, mdProvider.SchemaIssues...)
 		if mdProvider.Models == nil {
+			if len(mdProvider.SchemaIssues) > 0 {
+				continue
+			}
 			issues = append(issues, sources.ObservationIssue{
 				Scope: sources.ObservationIssueScopeProvider, Code: sources.ObservationIssueCodeSchemaDrift,
 				Subject: string(providerID), Message: "required models object is missing or null",
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/agentstation/starmap/internal/paths"
 	"github.com/agentstation/starmap/pkg/catalogs"
 	"github.com/agentstation/starmap/pkg/constants"
 	"github.com/agentstation/starmap/pkg/errors"
@@ -65,7 +66,7 @@ func (s *GitSource) Name() string { return "models.dev (Git)" }
 // ensureGitRepo loads models.dev data for this call and configured directory.
 func ensureGitRepo(ctx context.Context, outputDir, commit string) (*API, sources.Revision, error) {
 	if outputDir == "" {
-		outputDir = expandPath(constants.DefaultSourcesPath)
+		outputDir = paths.ExpandHome(constants.DefaultSourcesPath)
 	}
 	client := NewPinnedGitClient(outputDir, commit)
 	inputs, err := client.PrepareRepository(ctx)
@@ -107,7 +108,7 @@ func (s *GitSource) Observe(ctx context.Context, opts ...sources.Option) (source
 	// Use configured sources directory or default
 	outputDir := s.sourcesDir
 	if outputDir == "" {
-		outputDir = expandPath(constants.DefaultSourcesPath)
+		outputDir = paths.ExpandHome(constants.DefaultSourcesPath)
 	}
 
 	// Initialize models.dev data once
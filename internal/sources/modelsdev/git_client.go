@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/agentstation/starmap/internal/paths"
 	"github.com/agentstation/starmap/pkg/constants"
 	"github.com/agentstation/starmap/pkg/errors"
 	"github.com/agentstation/starmap/pkg/logging"
@@ -41,7 +42,7 @@ type Client = GitClient
 // NewClient creates a new models.dev git client.
 func NewClient(outputDir string) *Client {
 	if outputDir == "" {
-		outputDir = expandPath(constants.DefaultSourcesPath)
+		outputDir = paths.ExpandHome(constants.DefaultSourcesPath)
 	}
 	repoPath := filepath.Join(outputDir, "models.dev-git")
 	return &Client{
@@ -53,7 +54,7 @@ func NewClient(outputDir string) *Client {
 // NewGitClient creates a new models.dev git client.
 func NewGitClient(outputDir string) *GitClient {
 	if outputDir == "" {
-		outputDir = expandPath(constants.DefaultSourcesPath)
+		outputDir = paths.ExpandHome(constants.DefaultSourcesPath)
 	}
 	repoPath := filepath.Join(outputDir, "models.dev-git")
 	return &GitClient{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/agentstation/starmap/internal/paths"
 	"github.com/agentstation/starmap/pkg/catalogs"
 	"github.com/agentstation/starmap/pkg/constants"
 	"github.com/agentstation/starmap/pkg/errors"
@@ -50,7 +51,7 @@ func (s *HTTPSource) Name() string { return "models.dev (HTTP)" }
 
 func acquireHTTPAPI(ctx context.Context, outputDir string) (*API, HTTPAcquisitionResult, error) {
 	if outputDir == "" {
-		outputDir = expandPath(constants.DefaultCachePath)
+		outputDir = paths.ExpandHome(constants.DefaultCachePath)
 	}
 	client := NewHTTPClient(outputDir)
 	acquisition, err := client.AcquireAPI(ctx)
@@ -76,7 +77,7 @@ func (s *HTTPSource) Observe(ctx context.Context, opts ...sources.Option) (sourc
 	// Use configured sources directory or default
 	outputDir := s.sourcesDir
 	if outputDir == "" {
-		outputDir = expandPath(constants.DefaultCachePath)
+		outputDir = paths.ExpandHome(constants.DefaultCachePath)
 	}
 
 	// Initialize models.dev data once
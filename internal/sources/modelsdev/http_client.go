@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/agentstation/starmap/internal/embedded"
+	"github.com/agentstation/starmap/internal/paths"
 	"github.com/agentstation/starmap/pkg/constants"
 	"github.com/agentstation/starmap/pkg/errors"
 	"github.com/agentstation/starmap/pkg/logging"
@@ -90,7 +91,7 @@ type httpCacheMetadata struct {
 // NewHTTPClient creates a new models.dev HTTP client.
 func NewHTTPClient(outputDir string) *HTTPClient {
 	if outputDir == "" {
-		outputDir = expandPath(constants.DefaultCachePath)
+		outputDir = paths.ExpandHome(constants.DefaultCachePath)
 	}
 	cacheDir := filepath.Join(outputDir, "models.dev")
 	return &HTTPClient{
@@ -415,7 +416,16 @@ func validateAPISemantics(api *API) (apiSemanticStats, error) {
 	if api == nil {
 		return apiSemanticStats{}, &errors.ValidationError{Field: "models_dev.api", Message: "is required"}
 	}
-	stats := apiSemanticStats{providers: len(*api)}
+	providerKeys := make([]string, 0, len(*api))
+	for key, provider := range *api {
+		// Providers skipped while decoding are schema drift, reported by the
+		// source; they neither count toward nor fail promotion.
+		if provider.Models == nil && len(provider.SchemaIssues) > 0 {
+			continue
+		}
+		providerKeys = append(providerKeys, key)
+	}
+	stats := apiSemanticStats{providers: len(providerKeys)}
 	if stats.providers < minimumModelsDevProviders {
 		return stats, &errors.ValidationError{
 			Field: "models_dev.providers", Value: stats.providers,
@@ -423,13 +433,14 @@ func validateAPISemantics(api *API) (apiSemanticStats, error) {
 		}
 	}
 
-	providerKeys := make([]string, 0, len(*api))
-	for key := range *api {
-		providerKeys = append(providerKeys, key)
-	}
 	sort.Strings(providerKeys)
 	for _, providerKey := range providerKeys {
 		provider := (*api)[providerKey]
+		for _, issue := range provider.SchemaIssues {
+			if issue.Scope == sources.ObservationIssueScopeRecord {
+				stats.rejectedModels++
+			}
+		}
 		if strings.TrimSpace(providerKey) == "" || provider.ID != providerKey {
 			return stats, &errors.ValidationError{
 				Field: "models_dev.provider.id", Value: provider.ID,
@@ -90,9 +90,27 @@ func largeMockAPIJSON() string {
 	return string(data)
 }
 
+// largeSchemaIncompatibleAPIJSON wraps the mock providers in an envelope
+// without a valid schema version, which no record-level degradation can read.
 func largeSchemaIncompatibleAPIJSON(t *testing.T) string {
 	t.Helper()
 
+	var api map[string]any
+	if err := json.Unmarshal([]byte(largeMockAPIJSON()), &api); err != nil {
+		t.Fatalf("unmarshal large mock API: %v", err)
+	}
+	data, err := json.Marshal(map[string]any{"schema_version": "next", "providers": api})
+	if err != nil {
+		t.Fatalf("marshal incompatible mock API: %v", err)
+	}
+	return string(data)
+}
+
+// largeModelDriftAPIJSON changes the type of one model's field, which
+// quarantines only that model.
+func largeModelDriftAPIJSON(t *testing.T) string {
+	t.Helper()
+
 	var api map[string]any
 	if err := json.Unmarshal([]byte(largeMockAPIJSON()), &api); err != nil {
 		t.Fatalf("unmarshal large mock API: %v", err)
@@ -221,6 +239,40 @@ func TestHTTPClient_EnsureAPI_DoesNotPromoteSchemaIncompatibleDownload(t *testin
 	}
 }
 
+func TestHTTPClient_EnsureAPI_PromotesDownloadWithIsolatedModelDrift(t *testing.T) {
+	download := largeModelDriftAPIJSON(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(download))
+	}))
+	defer server.Close()
+
+	client := &HTTPClient{
+		CacheDir: filepath.Join(t.TempDir(), "models.dev"),
+		APIURL:   server.URL,
+		Client:   &http.Client{Timeout: constants.DefaultHTTPTimeout},
+	}
+	if err := client.EnsureAPI(context.Background()); err != nil {
+		t.Fatalf("EnsureAPI: %v", err)
+	}
+	got, err := os.ReadFile(client.GetAPIPath())
+	if err != nil {
+		t.Fatalf("read promoted cache: %v", err)
+	}
+	if string(got) != download {
+		t.Fatal("download with one drifted model was not promoted")
+	}
+	api, err := ParseAPI(client.GetAPIPath())
+	if err != nil {
+		t.Fatalf("ParseAPI: %v", err)
+	}
+	openAI := (*api)["openai"]
+	if _, ok := openAI.Models["gpt-4"]; ok || len(openAI.SchemaIssues) != 1 || len(openAI.Models) == 0 {
+		t.Fatalf("openai models = %d, issues = %#v; want gpt-4 skipped with one issue and its siblings kept", len(openAI.Models), openAI.SchemaIssues)
+	}
+}
+
 func TestHTTPClient_EnsureAPI_SuccessfulDownload(t *testing.T) {
 	// Create mock server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
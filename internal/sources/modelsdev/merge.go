@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/agentstation/starmap/internal/paths"
 	"github.com/agentstation/starmap/pkg/catalogs"
 	"github.com/agentstation/starmap/pkg/constants"
 	"github.com/agentstation/starmap/pkg/errors"
@@ -15,7 +16,7 @@ import (
 // It tries the provider ID first, then checks aliases if the primary ID isn't found.
 func CopyProviderLogos(outputDir string, providers []*catalogs.Provider) error {
 	// The models.dev repo is always cloned to this location by git.Fetch()
-	sourcesPath := expandPath(constants.DefaultSourcesPath)
+	sourcesPath := paths.ExpandHome(constants.DefaultSourcesPath)
 	modelsDevRepo := filepath.Join(sourcesPath, "models.dev-git")
 	providersPath := filepath.Join(modelsDevRepo, "providers")
 
@@ -75,7 +76,7 @@ func CopyProviderLogos(outputDir string, providers []*catalogs.Provider) error {
 // directory when the author ID matches a provider ID (or alias).
 func CopyAuthorLogos(outputDir string, authors []catalogs.Author, providers catalogs.ProvidersReader) error {
 	// The models.dev repo is always cloned to this location by git.Fetch()
-	sourcesPath := expandPath(constants.DefaultSourcesPath)
+	sourcesPath := paths.ExpandHome(constants.DefaultSourcesPath)
 	modelsDevRepo := filepath.Join(sourcesPath, "models.dev-git")
 	providersPath := filepath.Join(modelsDevRepo, "providers")
 
@@ -30,21 +30,35 @@ type Provider struct {
 	Doc           string                           `json:"doc"`
 	Models        map[string]Model                 `json:"models"`
 	UnknownFields []sourcepayload.UnknownJSONField `json:"-"`
+	SchemaVersion SchemaVersion                    `json:"-"` // Layout the provider was read in
+	SchemaIssues  []sources.ObservationIssue       `json:"-"` // Models, or the whole provider, skipped while decoding
 }
 
-// UnmarshalJSON retains fingerprints for additive provider fields.
+// UnmarshalJSON retains fingerprints for additive provider fields and decodes
+// models one at a time, so a model that no longer matches the schema is
+// skipped and reported in SchemaIssues instead of failing the provider.
 func (p *Provider) UnmarshalJSON(data []byte) error {
 	type providerAlias Provider
-	var decoded providerAlias
+	var decoded struct {
+		providerAlias
+		Models json.RawMessage `json:"models"`
+	}
 	if err := json.Unmarshal(data, &decoded); err != nil {
 		return err
 	}
-	unknown, err := sourcepayload.UnknownJSONFields(data, decoded, "provider")
+	unknown, err := sourcepayload.UnknownJSONFields(data, decoded.providerAlias, "provider")
 	if err != nil {
 		return err
 	}
-	*p = Provider(decoded)
+	*p = Provider(decoded.providerAlias)
 	p.UnknownFields = unknown
+	models, issues, legacy := decodeModels(p.ID, decoded.Models)
+	p.Models = models
+	p.SchemaIssues = issues
+	p.SchemaVersion = SchemaVersionCurrent
+	if legacy {
+		p.SchemaVersion = SchemaVersionLegacy
+	}
 	return nil
 }
 
@@ -238,11 +252,14 @@ func parseAPIData(data []byte) (*API, error) {
 	if err := sources.ValidateJSONPayload(data); err != nil {
 		return nil, err
 	}
-	var api API
-	if err := json.Unmarshal(data, &api); err != nil {
+	declared, providers, err := decodeSchema(data)
+	if err != nil {
 		return nil, errors.WrapParse("json", "api.json", err)
 	}
-
+	api := make(API, len(providers))
+	for key, raw := range providers {
+		api[key] = decodeProvider(key, raw, declared)
+	}
 	return &api, nil
 }
 
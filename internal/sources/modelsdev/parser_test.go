@@ -28,7 +28,11 @@ func TestSchemaDriftMutationMatrix(t *testing.T) {
 		{name: "missing", payload: `{"provider":{"id":"provider","name":"Provider"}}`, wantIssues: 1},
 		{name: "renamed", payload: `{"provider":{"id":"provider","name":"Provider","items":[]}}`, wantIssues: 1},
 		{name: "null", payload: `{"provider":{"id":"provider","name":"Provider","models":null}}`, wantIssues: 1},
-		{name: "wrong type", payload: `{"provider":{"id":"provider","name":"Provider","models":[]}}`, wantParseErr: true},
+		{name: "wrong type", payload: `{"provider":{"id":"provider","name":"Provider","models":[]}}`, wantIssues: 1},
+		{name: "undecodable model", payload: `{"provider":{"id":"provider","name":"Provider","models":{"model-a":{"id":"model-a","name":"Model A","description":"valid"},"model-b":{"id":"model-b","name":"Model B","limit":{"context":"128k"}}}}}`, wantModels: 1, wantIssues: 1},
+		{name: "undecodable provider", payload: `{"provider":{"id":"provider","name":"Provider","models":{"model-a":{"id":"model-a","name":"Model A","description":"valid"}}},"other":"renamed"}`, wantModels: 1, wantIssues: 1},
+		{name: "declared newer version", payload: `{"schema_version":3,"providers":{"provider":{"id":"provider","name":"Provider","models":{"model-a":{"id":"model-a","name":"Model A","description":"valid","new_capability":true}}}}}`, wantModels: 1, wantUnknown: false},
+		{name: "invalid envelope", payload: `{"schema_version":0,"providers":{}}`, wantParseErr: true},
 		{name: "unknown additive", payload: `{"provider":{"id":"provider","name":"Provider","models":{"model-a":{"id":"model-a","name":"Model A","description":"valid","new_capability":true}},"new_page":1}}`, wantModels: 1, wantUnknown: true},
 		{name: "oversized", payload: strings.Repeat(" ", constants.MaxSourcePayloadBytes+1), wantParseErr: true},
 	}
@@ -68,6 +72,40 @@ func TestSchemaDriftMutationMatrix(t *testing.T) {
 	}
 }
 
+func TestLegacySchemaIsConverted(t *testing.T) {
+	api, err := parseAPIData([]byte(`{"provider":{"id":"provider","name":"Provider","models":{"model-a":{
+		"id":"model-a","name":"Model A","cost":{"input":1,"output":2,"cache":0.5},"experimental":true}}}}`))
+	if err != nil {
+		t.Fatalf("parseAPIData: %v", err)
+	}
+	provider := (*api)["provider"]
+	if provider.SchemaVersion != SchemaVersionLegacy {
+		t.Errorf("schema version = %d, want legacy", provider.SchemaVersion)
+	}
+	source := provider.Models["model-a"]
+	if source.Cost == nil || source.Cost.Cache != nil || source.Cost.CacheWrite == nil || *source.Cost.CacheWrite != 0.5 {
+		t.Fatalf("cost = %#v, want cache converted to cache_write", source.Cost)
+	}
+	if len(source.UnknownFields) != 0 {
+		t.Errorf("unknown fields = %#v, want none after conversion", source.UnknownFields)
+	}
+	model, err := source.ToStarmapModel()
+	if err != nil {
+		t.Fatalf("ToStarmapModel: %v", err)
+	}
+	if model.Extensions[modelsDevExtensionSource].Fields["experimental"] != true {
+		t.Errorf("extensions = %#v, want the experimental marker kept", model.Extensions)
+	}
+
+	current, err := parseAPIData([]byte(`{"provider":{"id":"provider","name":"Provider","models":{"model-a":{"id":"model-a","name":"Model A","cost":{"cache_read":0.5}}}}}`))
+	if err != nil {
+		t.Fatalf("parseAPIData: %v", err)
+	}
+	if version := (*current)["provider"].SchemaVersion; version != SchemaVersionCurrent {
+		t.Errorf("schema version = %d, want current", version)
+	}
+}
+
 func TestPayloadLimitModelsDevModelCount(t *testing.T) {
 	models := make(map[string]Model, constants.MaxCatalogModels+1)
 	for index := 0; index <= constants.MaxCatalogModels; index++ {
@@ -0,0 +1,216 @@
+package modelsdev
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/logging"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// SchemaVersion identifies a layout of the models.dev api.json payload.
+type SchemaVersion int
+
+const (
+	// SchemaVersionLegacy is the original layout: a single cost.cache price
+	// and a boolean experimental marker on models.
+	SchemaVersionLegacy SchemaVersion = 1
+
+	// SchemaVersionCurrent is the layout the parser reads: separate
+	// cache_read and cache_write prices and experimental mode objects.
+	SchemaVersionCurrent SchemaVersion = 2
+)
+
+// schemaEnvelope is how a payload declares its schema version explicitly:
+// {"schema_version": 3, "providers": {...}}. A bare provider map has its
+// version detected from the shape of its models instead.
+type schemaEnvelope struct {
+	SchemaVersion SchemaVersion              `json:"schema_version"`
+	Providers     map[string]json.RawMessage `json:"providers"`
+}
+
+// decodeSchema splits a payload into its declared schema version, zero for
+// a bare provider map, and its raw providers.
+func decodeSchema(data []byte) (SchemaVersion, map[string]json.RawMessage, error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return 0, nil, err
+	}
+	if _, enveloped := top["schema_version"]; !enveloped {
+		return 0, top, nil
+	}
+	var envelope schemaEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return 0, nil, err
+	}
+	if envelope.SchemaVersion < SchemaVersionLegacy {
+		return 0, nil, &errors.ValidationError{Field: "schema_version", Value: envelope.SchemaVersion, Message: "must be a positive integer"}
+	}
+	if envelope.Providers == nil {
+		return 0, nil, &errors.ValidationError{Field: "providers", Message: "is required when schema_version is declared"}
+	}
+	return envelope.SchemaVersion, envelope.Providers, nil
+}
+
+// decodeProvider decodes one raw provider leniently. A provider that is not
+// an object keeps its map key as ID and carries the failure as a schema
+// issue, so it is skipped rather than failing the payload.
+func decodeProvider(key string, raw json.RawMessage, declared SchemaVersion) Provider {
+	var provider Provider
+	if err := json.Unmarshal(raw, &provider); err != nil {
+		return Provider{ID: key, SchemaVersion: declared, SchemaIssues: []sources.ObservationIssue{{
+			Scope: sources.ObservationIssueScopeProvider, Code: sources.ObservationIssueCodeSchemaDrift,
+			Subject: key, Message: fmt.Sprintf("provider could not be decoded and was skipped: %v", err),
+		}}}
+	}
+	if declared != 0 {
+		provider.SchemaVersion = declared
+	}
+	return provider
+}
+
+// decodeModels decodes the models object of a provider one model at a time,
+// upgrading legacy models to the current layout first. Models that cannot be
+// decoded are left out and reported as issues. It reports whether any model
+// used the legacy layout.
+func decodeModels(providerID string, raw json.RawMessage) (map[string]Model, []sources.ObservationIssue, bool) {
+	if len(raw) == 0 || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return nil, nil, false
+	}
+	var rawModels map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &rawModels); err != nil {
+		return nil, []sources.ObservationIssue{{
+			Scope: sources.ObservationIssueScopeProvider, Code: sources.ObservationIssueCodeSchemaDrift,
+			Subject: providerID, Message: "models must be an object; provider models were skipped",
+		}}, false
+	}
+
+	keys := make([]string, 0, len(rawModels))
+	for key := range rawModels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	models := make(map[string]Model, len(rawModels))
+	var (
+		issues []sources.ObservationIssue
+		legacy bool
+	)
+	for _, key := range keys {
+		upgraded, wasLegacy := upgradeLegacyModel(rawModels[key])
+		legacy = legacy || wasLegacy
+		var model Model
+		if err := json.Unmarshal(upgraded, &model); err != nil {
+			issues = append(issues, sources.ObservationIssue{
+				Scope: sources.ObservationIssueScopeRecord, Code: sources.ObservationIssueCodeSchemaDrift,
+				Subject: providerID + "/" + key, Message: fmt.Sprintf("model could not be decoded and was skipped: %v", err),
+			})
+			continue
+		}
+		models[key] = model
+	}
+	return models, issues, legacy
+}
+
+// upgradeLegacyModel converts a model in the legacy layout to the current
+// one: cost.cache becomes cost.cache_write, as legacy payloads priced cache
+// writes with it, and a boolean experimental marker becomes an empty mode
+// object or is dropped. Models already in the current layout, and values
+// that are not objects, are returned unchanged.
+func upgradeLegacyModel(raw json.RawMessage) (json.RawMessage, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return raw, false
+	}
+	legacy := false
+
+	var cost map[string]json.RawMessage
+	if costRaw, ok := fields["cost"]; ok && json.Unmarshal(costRaw, &cost) == nil {
+		if cache, ok := cost["cache"]; ok {
+			_, hasRead := cost["cache_read"]
+			_, hasWrite := cost["cache_write"]
+			if !hasRead && !hasWrite {
+				cost["cache_write"] = cache
+			}
+			delete(cost, "cache")
+			if encoded, err := json.Marshal(cost); err == nil {
+				fields["cost"] = encoded
+				legacy = true
+			}
+		}
+	}
+
+	if experimental, ok := fields["experimental"]; ok {
+		var enabled bool
+		if json.Unmarshal(experimental, &enabled) == nil {
+			if enabled {
+				fields["experimental"] = json.RawMessage(`{}`)
+			} else {
+				delete(fields, "experimental")
+			}
+			legacy = true
+		}
+	}
+
+	if !legacy {
+		return raw, false
+	}
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return raw, false
+	}
+	return encoded, true
+}
+
+// reportSchema logs, once per fetch, payloads converted from an older schema
+// version, payloads newer than the parser knows, and the unknown fields that
+// were skipped. None of these fail the source.
+func reportSchema(api *API) {
+	if api == nil {
+		return
+	}
+	var (
+		legacy, newer []string
+		newest        SchemaVersion
+		unknown       = make(map[string]int)
+	)
+	for key, provider := range *api {
+		switch {
+		case provider.SchemaVersion == SchemaVersionLegacy:
+			legacy = append(legacy, key)
+		case provider.SchemaVersion > SchemaVersionCurrent:
+			newer = append(newer, key)
+			newest = max(newest, provider.SchemaVersion)
+		}
+		for _, field := range provider.UnknownFields {
+			unknown[field.Path]++
+		}
+		for _, model := range provider.Models {
+			for _, field := range model.UnknownFields {
+				unknown[field.Path]++
+			}
+		}
+	}
+	if len(legacy) > 0 {
+		sort.Strings(legacy)
+		logging.Info().Strs("providers", legacy).Msg("Converted models.dev providers from the legacy schema")
+	}
+	if len(newer) > 0 {
+		sort.Strings(newer)
+		logging.Warn().
+			Int("schema_version", int(newest)).
+			Int("supported_version", int(SchemaVersionCurrent)).
+			Strs("providers", newer).
+			Msg("models.dev schema is newer than supported; reading known fields only")
+	}
+	paths := make([]string, 0, len(unknown))
+	for path := range unknown {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		logging.Warn().Str("field", path).Int("records", unknown[path]).Msg("Skipped unknown models.dev field")
+	}
+}
@@ -0,0 +1,79 @@
+package providers
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+// PartialFetch is one provider's models kept from an interrupted sync.
+type PartialFetch struct {
+	ProviderID catalogs.ProviderID `json:"provider_id"`
+	FetchedAt  time.Time           `json:"fetched_at"`
+	Models     []catalogs.Model    `json:"models"`
+}
+
+// partialStore keeps the providers that completed before a sync was
+// interrupted, so the next sync reuses them instead of fetching them again.
+// Entries are removed once a sync completes without interruption.
+type partialStore struct {
+	dir    string
+	maxAge time.Duration
+}
+
+func (s partialStore) path(providerID catalogs.ProviderID) string {
+	return filepath.Join(s.dir, string(providerID)+".json")
+}
+
+// Load returns the kept models for a provider, or nil when there are none or
+// they are older than the store's maximum age.
+func (s partialStore) Load(providerID catalogs.ProviderID, now time.Time) (*PartialFetch, error) {
+	data, err := os.ReadFile(s.path(providerID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, pkgerrors.WrapIO("read", s.path(providerID), err)
+	}
+	var fetch PartialFetch
+	if err := json.Unmarshal(data, &fetch); err != nil {
+		return nil, pkgerrors.WrapParse("json", s.path(providerID), err)
+	}
+	if fetch.ProviderID != providerID || now.Sub(fetch.FetchedAt) > s.maxAge {
+		return nil, nil
+	}
+	return &fetch, nil
+}
+
+// Store replaces the kept models for a provider.
+func (s partialStore) Store(fetch PartialFetch) error {
+	if err := os.MkdirAll(s.dir, constants.DirPermissions); err != nil {
+		return pkgerrors.WrapIO("create", s.dir, err)
+	}
+	data, err := json.Marshal(fetch)
+	if err != nil {
+		return pkgerrors.WrapParse("json", "partial provider fetch", err)
+	}
+	path := s.path(fetch.ProviderID)
+	temporary := path + ".tmp"
+	if err := os.WriteFile(temporary, data, constants.FilePermissions); err != nil {
+		return pkgerrors.WrapIO("write", temporary, err)
+	}
+	if err := os.Rename(temporary, path); err != nil {
+		return pkgerrors.WrapIO("rename", path, err)
+	}
+	return nil
+}
+
+// Remove deletes the kept models for a provider.
+func (s partialStore) Remove(providerID catalogs.ProviderID) error {
+	if err := os.Remove(s.path(providerID)); err != nil && !os.IsNotExist(err) {
+		return pkgerrors.WrapIO("remove", s.path(providerID), err)
+	}
+	return nil
+}
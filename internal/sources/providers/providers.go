@@ -5,15 +5,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 	"unicode"
 
+	"github.com/agentstation/starmap/internal/providers/docscrape"
 	"github.com/agentstation/starmap/pkg/catalogs"
 	"github.com/agentstation/starmap/pkg/constants"
 	pkgerrors "github.com/agentstation/starmap/pkg/errors"
 	"github.com/agentstation/starmap/pkg/logging"
+	"github.com/agentstation/starmap/pkg/sourcepayload"
 	"github.com/agentstation/starmap/pkg/sources"
 )
 
@@ -26,6 +29,12 @@ type SourceOption func(*sourceOptions)
 type sourceOptions struct {
 	clientFactory  ClientFactory
 	maxConcurrency int
+	shapeDir       string
+	partialDir     string
+	fetchCacheDir  string
+	httpCacheDir   string
+	freshFetch     bool
+	docs           *docscrape.Scraper
 }
 
 // Source fetches models from all provider APIs concurrently.
@@ -33,6 +42,9 @@ type Source struct {
 	providers      catalogs.ProvidersReader // Provider configs injected during setup
 	fetcher        *sources.ProviderFetcher
 	maxConcurrency int
+	shapes         *shapeStore        // Optional response-shape history for format drift detection
+	partial        *partialStore      // Optional results kept from an interrupted sync
+	docs           *docscrape.Scraper // Optional documentation scraper filling missing pricing and limits
 }
 
 var _ sources.Source = (*Source)(nil)
@@ -45,15 +57,32 @@ func New(providers catalogs.ProvidersReader, opts ...SourceOption) *Source {
 	for _, opt := range opts {
 		opt(&options)
 	}
-	fetcherOptions := make([]sources.ProviderOption, 0, 1)
+	fetcherOptions := make([]sources.ProviderOption, 0, 4)
 	if options.clientFactory != nil {
 		fetcherOptions = append(fetcherOptions, sources.WithProviderClientFactory(options.clientFactory))
 	}
-	return &Source{
+	if options.fetchCacheDir != "" {
+		fetcherOptions = append(fetcherOptions, sources.WithFetchCache(sources.NewFetchCache(options.fetchCacheDir, constants.ProviderFetchCacheTTL)))
+	}
+	if options.httpCacheDir != "" {
+		fetcherOptions = append(fetcherOptions, sources.WithHTTPCache(options.httpCacheDir, constants.ProviderHTTPCacheTTL))
+	}
+	if options.freshFetch {
+		fetcherOptions = append(fetcherOptions, sources.WithFreshFetch())
+	}
+	source := &Source{
 		providers:      providers,
 		fetcher:        sources.NewProviderFetcher(providers, fetcherOptions...),
 		maxConcurrency: options.maxConcurrency,
+		docs:           options.docs,
+	}
+	if options.shapeDir != "" {
+		source.shapes = &shapeStore{dir: options.shapeDir}
 	}
+	if options.partialDir != "" {
+		source.partial = &partialStore{dir: options.partialDir, maxAge: constants.PartialFetchMaxAge}
+	}
+	return source
 }
 
 // WithClientFactory configures the factory used to create provider clients.
@@ -70,6 +99,60 @@ func WithMaxConcurrency(maxConcurrency int) SourceOption {
 	}
 }
 
+// WithShapeDir enables provider API format drift detection. The structural
+// fingerprint of each list-models response is recorded under dir and compared
+// with the previous sync.
+func WithShapeDir(dir string) SourceOption {
+	return func(s *sourceOptions) {
+		s.shapeDir = dir
+	}
+}
+
+// WithPartialFetchDir keeps the models of providers that completed before a
+// sync is interrupted under dir. The next sync reuses them for up to
+// constants.PartialFetchMaxAge instead of fetching those providers again, and
+// the interruption is reported as a *errors.PartialFetchError naming the
+// completed and pending providers.
+func WithPartialFetchDir(dir string) SourceOption {
+	return func(s *sourceOptions) {
+		s.partialDir = dir
+	}
+}
+
+// WithFetchCacheDir shares provider fetches with other commands through the
+// cache under dir: models fetched within constants.ProviderFetchCacheTTL are
+// reused instead of fetched again, and every fetch refreshes the cache.
+func WithFetchCacheDir(dir string) SourceOption {
+	return func(s *sourceOptions) {
+		s.fetchCacheDir = dir
+	}
+}
+
+// WithHTTPCacheDir stores provider API responses under dir. Responses stored
+// within constants.ProviderHTTPCacheTTL are reused; older ones are revalidated
+// with their ETag and Last-Modified instead of downloaded again.
+func WithHTTPCacheDir(dir string) SourceOption {
+	return func(s *sourceOptions) {
+		s.httpCacheDir = dir
+	}
+}
+
+// WithFreshFetch fetches every provider from its API even when the fetch
+// cache holds its models. Cached API responses are revalidated.
+func WithFreshFetch(fresh bool) SourceOption {
+	return func(s *sourceOptions) {
+		s.freshFetch = fresh
+	}
+}
+
+// WithDocsScraper fills pricing and limits that provider APIs leave empty from
+// the providers' documentation pages. Values the APIs return are kept.
+func WithDocsScraper(scraper *docscrape.Scraper) SourceOption {
+	return func(s *sourceOptions) {
+		s.docs = scraper
+	}
+}
+
 // ID returns the ID of this source.
 func (s *Source) ID() sources.ID { return sources.ProvidersID }
 
@@ -82,6 +165,8 @@ type providerModels struct {
 	models     []*catalogs.Model
 	rejected   int
 	issues     []sources.ObservationIssue
+	completed  bool // The fetch finished, successfully or not, before any interruption
+	reused     bool // The models were kept from an interrupted sync
 }
 
 // Observe returns a new immutable provider catalog without retaining result state.
@@ -154,6 +239,10 @@ func (s *Source) Observe(ctx context.Context, opts ...sources.Option) (sources.O
 	semaphore := make(chan struct{}, s.effectiveMaxConcurrency(len(providerConfigs)))
 
 	for _, provider := range providerConfigs {
+		if result, ok := s.reusePartialFetch(ctx, provider.ID); ok {
+			resultChan <- result
+			continue
+		}
 		wg.Add(1)
 		go func(p *catalogs.Provider) {
 			defer wg.Done()
@@ -162,19 +251,29 @@ func (s *Source) Observe(ctx context.Context, opts ...sources.Option) (sources.O
 
 			result := providerModels{providerID: p.ID}
 
-			logger := logging.WithProvider(ctx, string(p.ID))
+			recorder := sourcepayload.NewShapeRecorder()
+			logger := sourcepayload.WithShapeRecorder(logging.WithProvider(ctx, string(p.ID)), recorder)
 			models, err := s.fetcher.FetchModels(logger, p)
+			result.completed = err == nil || ctx.Err() == nil
 			if err != nil {
 				logging.Ctx(logger).Warn().
 					Err(err).
 					Str("provider_id", string(p.ID)).
+					Int("model_count", len(models)).
 					Msg("Provider observation degraded")
 				result.issues = append(result.issues, classifyProviderFetchIssue(p.ID, err))
-				resultChan <- result
-				return
+				if len(models) == 0 {
+					resultChan <- result
+					return
+				}
+				// A partial listing keeps the models of the pages fetched
 			}
 
-			result.models, result.rejected, result.issues = quarantineProviderModels(p.ID, models)
+			s.checkResponseShape(logger, p.ID, recorder)
+			s.scrapeDocs(logger, p.ID, models)
+			var issues []sources.ObservationIssue
+			result.models, result.rejected, issues = quarantineProviderModels(p.ID, models)
+			result.issues = append(result.issues, issues...)
 			resultChan <- result
 
 			logging.Ctx(logger).Info().
@@ -189,7 +288,9 @@ func (s *Source) Observe(ctx context.Context, opts ...sources.Option) (sources.O
 
 	// Process results and update catalog
 	records := sources.ObservationRecordCounts{}
+	results := make([]providerModels, 0, len(providerConfigs))
 	for result := range resultChan {
+		results = append(results, result)
 		issues = append(issues, result.issues...)
 		records.Rejected += result.rejected
 		if len(result.models) == 0 {
@@ -237,7 +338,102 @@ func (s *Source) Observe(ctx context.Context, opts ...sources.Option) (sources.O
 		// Sources should only create catalogs, not persist them
 	}
 
-	return s.observation(catalog, issues, records)
+	observation, err := s.observation(catalog, issues, records)
+	if err != nil {
+		return observation, err
+	}
+	return observation, s.settlePartialFetch(ctx, results)
+}
+
+// scrapeDocs fills pricing and limits missing from models with the values on
+// the provider's documentation pages. Scraping failures are logged and leave
+// the models as the API returned them.
+func (s *Source) scrapeDocs(ctx context.Context, providerID catalogs.ProviderID, models []catalogs.Model) {
+	if s.docs == nil || !s.docs.Has(providerID) {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	records, err := s.docs.Scrape(ctx, providerID)
+	if err != nil {
+		logger.Warn().Err(err).Str("provider_id", string(providerID)).Msg("Failed to scrape provider documentation")
+	}
+	if filled := docscrape.Apply(models, records); filled > 0 {
+		logger.Info().Str("provider_id", string(providerID)).Int("model_count", filled).
+			Msg("Filled models from provider documentation")
+	}
+}
+
+// reusePartialFetch returns the models kept for a provider from an
+// interrupted sync, if they are recent enough to stand in for a fetch.
+func (s *Source) reusePartialFetch(ctx context.Context, providerID catalogs.ProviderID) (providerModels, bool) {
+	if s.partial == nil {
+		return providerModels{}, false
+	}
+	fetch, err := s.partial.Load(providerID, time.Now())
+	if err != nil {
+		logging.FromContext(ctx).Debug().
+			Err(err).
+			Str("provider_id", string(providerID)).
+			Msg("Could not read provider results kept from an interrupted sync")
+		return providerModels{}, false
+	}
+	if fetch == nil {
+		return providerModels{}, false
+	}
+	logging.FromContext(ctx).Info().
+		Str("provider_id", string(providerID)).
+		Time("fetched_at", fetch.FetchedAt).
+		Int("model_count", len(fetch.Models)).
+		Msg("Reusing models fetched before an interrupted sync")
+	result := providerModels{providerID: providerID, completed: true, reused: true}
+	result.models, result.rejected, result.issues = quarantineProviderModels(providerID, fetch.Models)
+	return result, true
+}
+
+// settlePartialFetch keeps the providers that completed when ctx was
+// interrupted and reports them, or clears kept results once a sync runs to
+// completion.
+func (s *Source) settlePartialFetch(ctx context.Context, results []providerModels) error {
+	if s.partial == nil {
+		return nil
+	}
+	logger := logging.FromContext(ctx)
+	interrupted := ctx.Err()
+	if interrupted == nil {
+		for _, result := range results {
+			if err := s.partial.Remove(result.providerID); err != nil {
+				logger.Debug().Err(err).Str("provider_id", string(result.providerID)).
+					Msg("Could not remove provider results kept from an interrupted sync")
+			}
+		}
+		return nil
+	}
+
+	partial := &pkgerrors.PartialFetchError{Err: interrupted}
+	now := time.Now().UTC()
+	for _, result := range results {
+		if !result.completed {
+			partial.Pending = append(partial.Pending, string(result.providerID))
+			continue
+		}
+		partial.Completed = append(partial.Completed, string(result.providerID))
+		if result.reused || len(result.models) == 0 {
+			continue
+		}
+		fetch := PartialFetch{ProviderID: result.providerID, FetchedAt: now, Models: make([]catalogs.Model, 0, len(result.models))}
+		for _, model := range result.models {
+			fetch.Models = append(fetch.Models, *model)
+		}
+		if err := s.partial.Store(fetch); err != nil {
+			logger.Warn().Err(err).Str("provider_id", string(result.providerID)).
+				Msg("Could not keep provider results from the interrupted sync")
+			partial.Completed = partial.Completed[:len(partial.Completed)-1]
+			partial.Pending = append(partial.Pending, string(result.providerID))
+		}
+	}
+	slices.Sort(partial.Completed)
+	slices.Sort(partial.Pending)
+	return partial
 }
 
 func (s *Source) effectiveMaxConcurrency(providerCount int) int {
@@ -292,7 +488,10 @@ func classifyProviderFetchIssue(providerID catalogs.ProviderID, err error) sourc
 	var authenticationErr *pkgerrors.AuthenticationError
 	var configurationErr *pkgerrors.ConfigError
 	var parseErr *pkgerrors.ParseError
+	var partialErr *pkgerrors.PartialListError
 	switch {
+	case errors.As(err, &partialErr):
+		code = sources.ObservationIssueCodePartialListing
 	case errors.As(err, &authenticationErr):
 		code = sources.ObservationIssueCodeMissingCredentials
 	case errors.As(err, &configurationErr):
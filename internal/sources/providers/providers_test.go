@@ -429,6 +429,36 @@ func TestSourceObserveReturnsPartialFailuresAndKeepsSuccessfulModels(t *testing.
 	}
 }
 
+func TestSourceObserveKeepsPartialListingModels(t *testing.T) {
+	src := New(newProviderSet(providerForTest("paged")), WithClientFactory(func(*catalogs.Provider) (sources.ProviderClient, error) {
+		return fakeProviderClient{
+			models: []catalogs.Model{{ID: "first-page-model", Name: "First Page Model"}},
+			err:    &pkgerrors.PartialListError{Provider: "paged", Pages: 1, Reason: "provider repeated a next-page token"},
+		}, nil
+	}))
+
+	observation, err := src.Observe(context.Background())
+	if err != nil {
+		t.Fatalf("Observe: %v", err)
+	}
+	if err := observation.Validate(); err != nil {
+		t.Fatalf("Validate partial observation: %v", err)
+	}
+	if observation.Completeness != sources.ObservationCompletenessPartial || observation.Status != sources.ObservationStatusDegraded {
+		t.Fatalf("Partial state = (%q, %q)", observation.Completeness, observation.Status)
+	}
+	if len(observation.Issues) != 1 || observation.Issues[0].Code != sources.ObservationIssueCodePartialListing {
+		t.Fatalf("Partial issues = %#v", observation.Issues)
+	}
+	provider, err := observation.Catalog.Provider("paged")
+	if err != nil {
+		t.Fatalf("Provider: %v", err)
+	}
+	if _, ok := provider.Models["first-page-model"]; !ok || observation.Records.Accepted != 1 {
+		t.Fatalf("models = %#v, accepted = %d; want the listed page kept", provider.Models, observation.Records.Accepted)
+	}
+}
+
 func TestSourceObserveBoundsProviderConcurrency(t *testing.T) {
 	const maxConcurrency = 2
 
@@ -562,3 +592,46 @@ func providerForTest(id catalogs.ProviderID) catalogs.Provider {
 		},
 	}
 }
+
+func TestSourceObserveKeepsCompletedProvidersWhenInterrupted(t *testing.T) {
+	dir := t.TempDir()
+	providerSet := newProviderSet(providerForTest("fast"), providerForTest("slow"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interrupted := New(providerSet, WithPartialFetchDir(dir), WithClientFactory(func(provider *catalogs.Provider) (sources.ProviderClient, error) {
+		if provider.ID == "slow" {
+			return fakeProviderClient{err: context.Canceled, onList: cancel}, nil
+		}
+		return fakeProviderClient{models: []catalogs.Model{{ID: "fast-model", Name: "Fast Model"}}}, nil
+	}))
+	_, err := interrupted.Observe(ctx)
+	var partial *pkgerrors.PartialFetchError
+	if !stderrors.As(err, &partial) {
+		t.Fatalf("Observe error = %v, want *PartialFetchError", err)
+	}
+	if !reflect.DeepEqual(partial.Completed, []string{"fast"}) || !reflect.DeepEqual(partial.Pending, []string{"slow"}) {
+		t.Fatalf("completed = %v, pending = %v; want [fast] and [slow]", partial.Completed, partial.Pending)
+	}
+
+	resumed := New(providerSet, WithPartialFetchDir(dir), WithClientFactory(func(provider *catalogs.Provider) (sources.ProviderClient, error) {
+		if provider.ID == "fast" {
+			t.Error("fast provider fetched again; want its kept results reused")
+		}
+		return fakeProviderClient{models: []catalogs.Model{{ID: "slow-model", Name: "Slow Model"}}}, nil
+	}))
+	observation, err := resumed.Observe(context.Background())
+	if err != nil {
+		t.Fatalf("resumed Observe: %v", err)
+	}
+	fast, err := observation.Catalog.Provider("fast")
+	if err != nil {
+		t.Fatalf("fast provider: %v", err)
+	}
+	if _, ok := fast.Models["fast-model"]; !ok {
+		t.Fatalf("fast models = %v, want the kept fast-model", fast.Models)
+	}
+	if fetch, err := (partialStore{dir: dir, maxAge: time.Hour}).Load("fast", time.Now()); err != nil || fetch != nil {
+		t.Fatalf("kept results after a completed sync = %v, %v; want removed", fetch, err)
+	}
+}
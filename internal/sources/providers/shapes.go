@@ -0,0 +1,115 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/logging"
+	"github.com/agentstation/starmap/pkg/sourcepayload"
+)
+
+// ResponseShape is the recorded structural fingerprint of one provider's
+// list-models response. Only JSON key paths are retained, never values.
+type ResponseShape struct {
+	ProviderID catalogs.ProviderID `json:"provider_id"`
+	ObservedAt time.Time           `json:"observed_at"`
+	Paths      []string            `json:"paths"`
+}
+
+// shapeStore persists the last observed response shape per provider so
+// consecutive syncs can detect upstream API format drift.
+type shapeStore struct {
+	dir string
+}
+
+func (s shapeStore) path(providerID catalogs.ProviderID) string {
+	return filepath.Join(s.dir, string(providerID)+".json")
+}
+
+// Load returns the previously recorded shape for a provider, if any.
+func (s shapeStore) Load(providerID catalogs.ProviderID) (*ResponseShape, error) {
+	data, err := os.ReadFile(s.path(providerID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, pkgerrors.WrapIO("read", s.path(providerID), err)
+	}
+	var shape ResponseShape
+	if err := json.Unmarshal(data, &shape); err != nil {
+		return nil, pkgerrors.WrapParse("json", s.path(providerID), err)
+	}
+	return &shape, nil
+}
+
+// Store replaces the recorded shape for a provider.
+func (s shapeStore) Store(shape ResponseShape) error {
+	if err := os.MkdirAll(s.dir, constants.DirPermissions); err != nil {
+		return pkgerrors.WrapIO("create", s.dir, err)
+	}
+	data, err := json.MarshalIndent(shape, "", "  ")
+	if err != nil {
+		return pkgerrors.WrapParse("json", "provider response shape", err)
+	}
+	path := s.path(shape.ProviderID)
+	temporary := path + ".tmp"
+	if err := os.WriteFile(temporary, data, constants.FilePermissions); err != nil {
+		return pkgerrors.WrapIO("write", temporary, err)
+	}
+	if err := os.Rename(temporary, path); err != nil {
+		return pkgerrors.WrapIO("rename", path, err)
+	}
+	return nil
+}
+
+// Compare records the current shape and returns drift against the previous
+// observation. The first observation of a provider never reports drift.
+func (s shapeStore) Compare(providerID catalogs.ProviderID, paths []string) (sourcepayload.ShapeDrift, error) {
+	previous, err := s.Load(providerID)
+	if err != nil {
+		return sourcepayload.ShapeDrift{}, err
+	}
+	if err := s.Store(ResponseShape{ProviderID: providerID, ObservedAt: time.Now().UTC(), Paths: paths}); err != nil {
+		return sourcepayload.ShapeDrift{}, err
+	}
+	if previous == nil {
+		return sourcepayload.ShapeDrift{}, nil
+	}
+	return sourcepayload.CompareKeyPaths(previous.Paths, paths), nil
+}
+
+// checkResponseShape compares a provider's recorded response shape with the
+// previous sync and warns maintainers about upstream format changes.
+// Drift is advisory: it never degrades the observation because typed decoding
+// already succeeded.
+func (s *Source) checkResponseShape(ctx context.Context, providerID catalogs.ProviderID, recorder *sourcepayload.ShapeRecorder) {
+	if s.shapes == nil {
+		return
+	}
+	paths := recorder.Paths()
+	if len(paths) == 0 {
+		return
+	}
+	drift, err := s.shapes.Compare(providerID, paths)
+	if err != nil {
+		logging.Ctx(ctx).Debug().
+			Err(err).
+			Str("provider_id", string(providerID)).
+			Msg("Could not record provider response shape")
+		return
+	}
+	if !drift.HasDrift() {
+		return
+	}
+	logging.Ctx(ctx).Warn().
+		Str("provider_id", string(providerID)).
+		Strs("added_keys", drift.Added).
+		Strs("removed_keys", drift.Removed).
+		Msg("Provider API response format changed since last sync")
+}
@@ -0,0 +1,80 @@
+package providers
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/sourcepayload"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+func TestShapeStoreReportsDriftBetweenObservations(t *testing.T) {
+	store := shapeStore{dir: t.TempDir()}
+
+	drift, err := store.Compare("openai", []string{"data", "data[].id", "data[].owned_by"})
+	if err != nil {
+		t.Fatalf("first Compare: %v", err)
+	}
+	if drift.HasDrift() {
+		t.Fatalf("first observation reported drift: %#v", drift)
+	}
+
+	drift, err = store.Compare("openai", []string{"data", "data[].id", "data[].pricing"})
+	if err != nil {
+		t.Fatalf("second Compare: %v", err)
+	}
+	if !slices.Equal(drift.Added, []string{"data[].pricing"}) || !slices.Equal(drift.Removed, []string{"data[].owned_by"}) {
+		t.Fatalf("drift = %#v", drift)
+	}
+
+	stored, err := store.Load("openai")
+	if err != nil || stored == nil {
+		t.Fatalf("Load = %#v, %v", stored, err)
+	}
+	if !slices.Equal(stored.Paths, []string{"data", "data[].id", "data[].pricing"}) {
+		t.Fatalf("stored paths = %v", stored.Paths)
+	}
+}
+
+func TestProviderSourceRecordsResponseShape(t *testing.T) {
+	dir := t.TempDir()
+	provider := providerForTest("shape")
+	source := New(newProviderSet(provider),
+		WithShapeDir(dir),
+		WithClientFactory(func(*catalogs.Provider) (sources.ProviderClient, error) {
+			return shapeRecordingClient{payload: `{"object":"list","data":[{"id":"m1"}]}`}, nil
+		}),
+	)
+
+	if _, err := source.Observe(context.Background()); err != nil {
+		t.Fatalf("Observe: %v", err)
+	}
+
+	stored, err := (shapeStore{dir: dir}).Load("shape")
+	if err != nil || stored == nil {
+		t.Fatalf("Load = %#v, %v", stored, err)
+	}
+	if !slices.Equal(stored.Paths, []string{"data", "data[].id", "object"}) {
+		t.Fatalf("recorded paths = %v", stored.Paths)
+	}
+}
+
+// shapeRecordingClient mimics a transport-backed client decoding one payload.
+type shapeRecordingClient struct {
+	payload string
+}
+
+func (c shapeRecordingClient) ListModels(ctx context.Context) ([]catalogs.Model, error) {
+	if recorder := sourcepayload.ShapeRecorderFromContext(ctx); recorder != nil {
+		if err := recorder.Record([]byte(c.payload)); err != nil {
+			return nil, err
+		}
+	}
+	return []catalogs.Model{{ID: "m1", Name: "m1"}}, nil
+}
+
+func (c shapeRecordingClient) IsAPIKeyRequired() bool { return false }
+
+func (c shapeRecordingClient) HasAPIKey() bool { return true }
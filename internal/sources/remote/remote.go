@@ -0,0 +1,136 @@
+// Package remote provides a source that federates another Starmap server's
+// published catalog. The remote generation is fetched through the versioned
+// catalog protocol, verified against its manifest, and reconciled like any
+// other source, so local authority rules decide every conflicting field.
+package remote
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogremote"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// Source observes the current catalog generation of a remote Starmap server.
+type Source struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+var _ sources.Source = (*Source)(nil)
+
+// New creates a remote catalog source. baseURL is the versioned API root of
+// the remote server, for example https://starmap.example.com/api/v1.
+func New(baseURL string, opts ...Option) *Source {
+	s := &Source{baseURL: baseURL}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Option configures a remote source.
+type Option func(*Source)
+
+// WithAPIKey sends key as a bearer token to the remote server.
+func WithAPIKey(key string) Option {
+	return func(s *Source) {
+		s.apiKey = key
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to reach the remote server.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Source) {
+		s.httpClient = client
+	}
+}
+
+// ID returns the ID of this source.
+func (s *Source) ID() sources.ID {
+	return sources.RemoteCatalogID
+}
+
+// Name returns the human-friendly name of this source.
+func (s *Source) Name() string {
+	if parsed, err := url.Parse(s.baseURL); err == nil && parsed.Host != "" {
+		return "Remote Catalog (" + parsed.Host + ")"
+	}
+	return "Remote Catalog"
+}
+
+// Observe fetches and verifies the remote server's current generation.
+func (s *Source) Observe(ctx context.Context, _ ...sources.Option) (sources.Observation, error) {
+	client, err := catalogremote.NewClient(s.baseURL, s.client(), catalogs.CurrentCatalogSchemaVersion)
+	if err != nil {
+		return sources.Observation{}, err
+	}
+	generation, err := client.FetchCurrent(ctx)
+	if err != nil {
+		return sources.Observation{}, errors.WrapResource("fetch", "remote catalog", s.baseURL, err)
+	}
+	catalog, err := catalogstore.DecodeCatalogPayload(generation.Payload)
+	if err != nil {
+		return sources.Observation{}, errors.WrapResource("decode", "remote catalog generation", generation.Manifest.GenerationID, err)
+	}
+	return sources.NewObservation(s.ID(), catalog, sources.ObservationMetadata{
+		ObservedAt:   time.Now().UTC(),
+		Revision:     sources.Revision{Kind: sources.RevisionKindSourceVersion, Value: generation.Manifest.GenerationID},
+		Completeness: sources.ObservationCompletenessComplete,
+		Status:       sources.ObservationStatusSucceeded,
+	})
+}
+
+func (s *Source) client() *http.Client {
+	client := s.httpClient
+	if client == nil {
+		client = &http.Client{Timeout: constants.DefaultHTTPTimeout}
+	}
+	if s.apiKey == "" {
+		return client
+	}
+	authorized := *client
+	base := authorized.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	authorized.Transport = bearerTransport{base: base, token: s.apiKey}
+	return &authorized
+}
+
+type bearerTransport struct {
+	base  http.RoundTripper
+	token string
+}
+
+func (t bearerTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	clone := request.Clone(request.Context())
+	clone.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(clone)
+}
+
+// Cleanup releases any resources.
+func (s *Source) Cleanup() error {
+	return nil
+}
+
+// Dependencies returns the list of external dependencies.
+// The remote source only needs network access to the configured server.
+func (s *Source) Dependencies() []sources.Dependency {
+	return nil
+}
+
+// IsOptional returns whether this source is optional. A federated remote is
+// requested explicitly, so a sync that cannot reach it fails rather than
+// silently publishing an unfederated catalog.
+func (s *Source) IsOptional() bool {
+	return false
+}
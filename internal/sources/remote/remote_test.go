@@ -0,0 +1,114 @@
+package remote
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+	"github.com/agentstation/starmap/pkg/catalogremote"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+func TestObserveFetchesRemoteGenerationWithBearerToken(t *testing.T) {
+	generation := testGeneration(t)
+	manifest, err := catalogremote.MarshalManifest(generation.Manifest)
+	if err != nil {
+		t.Fatalf("MarshalManifest: %v", err)
+	}
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/api/v1" + catalogremote.ManifestPath:
+			w.Header().Set("Content-Type", catalogremote.ManifestMediaType)
+			_, _ = w.Write(manifest)
+		case "/api/v1" + catalogremote.SnapshotPath(generation.Manifest.GenerationID):
+			w.Header().Set("Content-Type", catalogs.CatalogPayloadMediaType)
+			_, _ = w.Write(generation.Payload)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	source := New(server.URL+"/api/v1", WithAPIKey("secret"), WithHTTPClient(server.Client()))
+	observation, err := source.Observe(context.Background())
+	if err != nil {
+		t.Fatalf("Observe: %v", err)
+	}
+	if authorization != "Bearer secret" {
+		t.Fatalf("Authorization = %q, want bearer token", authorization)
+	}
+	if observation.SourceID != sources.RemoteCatalogID {
+		t.Fatalf("SourceID = %q, want %q", observation.SourceID, sources.RemoteCatalogID)
+	}
+	if observation.Revision.Kind != sources.RevisionKindSourceVersion || observation.Revision.Value != generation.Manifest.GenerationID {
+		t.Fatalf("Revision = %+v, want remote generation ID", observation.Revision)
+	}
+	if _, err := observation.Catalog.Provider("community"); err != nil {
+		t.Fatalf("remote provider missing: %v", err)
+	}
+}
+
+func TestObserveFailsForUnreachableRemote(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	source := New(server.URL+"/api/v1", WithHTTPClient(server.Client()))
+	if _, err := source.Observe(context.Background()); err == nil {
+		t.Fatal("Observe returned nil error for a server without a catalog")
+	}
+	if source.IsOptional() {
+		t.Fatal("an explicitly federated remote must not be optional")
+	}
+}
+
+func testGeneration(t *testing.T) catalogstore.Generation {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	if err := builder.SetProvider(catalogs.Provider{ID: "community", Name: "Community"}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	payload, err := catalogstore.EncodeCatalogPayload(catalog)
+	if err != nil {
+		t.Fatalf("EncodeCatalogPayload: %v", err)
+	}
+	descriptor := catalogs.DescribeCatalogPayload(payload)
+	generatedAt := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	generation := catalogstore.Generation{
+		Manifest: catalogs.GenerationManifest{
+			ManifestVersion: catalogs.CurrentGenerationManifestVersion,
+			SchemaVersion:   catalogs.CurrentCatalogSchemaVersion, GenerationID: "community-generation",
+			GeneratedAt: generatedAt, Payload: descriptor,
+			Validation: catalogs.GenerationValidationReport{
+				ValidatorVersion: "remote-test/v1", ValidatedAt: generatedAt, Status: catalogs.GenerationValidationPassed,
+				Checks: []catalogs.GenerationValidationCheck{{Name: "test", Status: catalogs.GenerationValidationCheckPassed}},
+			},
+			SyncRunID: "community-sync-run",
+			SourceObservations: []catalogs.SourceObservationLink{{
+				Source: catalogmeta.LocalCatalogID, ObservationID: "community-observation", ObservedAt: generatedAt,
+				Revision:     catalogmeta.ObservationRevision{Kind: catalogmeta.ObservationRevisionKindContentDigest, Value: descriptor.Checksum},
+				Completeness: catalogmeta.ObservationCompletenessComplete, Status: catalogmeta.ObservationStatusSucceeded,
+				EvidenceChecksum: descriptor.Checksum,
+			}},
+			Completeness: catalogs.GenerationCompletenessComplete,
+			ConsumerCompatibility: catalogs.ConsumerCompatibility{
+				MinSchemaVersion: catalogs.CurrentCatalogSchemaVersion, MaxSchemaVersion: catalogs.CurrentCatalogSchemaVersion,
+			},
+		},
+		Payload: payload,
+	}
+	if err := generation.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	return generation
+}
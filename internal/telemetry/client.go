@@ -0,0 +1,48 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// DefaultTimeout bounds how long a command waits to report its event.
+const DefaultTimeout = time.Second
+
+// Client posts events to a telemetry endpoint.
+type Client struct {
+	endpoint string
+	http     *http.Client
+}
+
+// NewClient creates a client posting to endpoint.
+func NewClient(endpoint string) *Client {
+	return &Client{endpoint: endpoint, http: &http.Client{Timeout: DefaultTimeout}}
+}
+
+// Send posts event as JSON.
+func (c *Client) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.WrapParse("json", "telemetry event", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return &errors.ValidationError{Field: "endpoint", Value: c.endpoint, Message: err.Error()}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := c.http.Do(request)
+	if err != nil {
+		return errors.WrapResource("send", "telemetry event", c.endpoint, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode >= http.StatusBadRequest {
+		return errors.WrapResource("send", "telemetry event", c.endpoint, fmt.Errorf("status %d", response.StatusCode))
+	}
+	return nil
+}
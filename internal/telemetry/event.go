@@ -0,0 +1,105 @@
+package telemetry
+
+import (
+	"context"
+	stderrors "errors"
+	"runtime"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Schema identifies the Event payload version.
+const Schema = "starmap.telemetry/v1"
+
+// Outcomes of a command.
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// Event is the anonymous usage report of one command run.
+type Event struct {
+	Schema        string    `json:"schema"`
+	InstallID     string    `json:"install_id"`
+	Timestamp     time.Time `json:"timestamp"`
+	Command       string    `json:"command"`                  // Command path without the binary name, e.g. "models list"
+	Flags         []string  `json:"flags,omitempty"`          // Names of the flags given, never their values
+	DurationMS    int64     `json:"duration_ms"`              // Wall time of the command
+	Outcome       string    `json:"outcome"`                  // success or error
+	ErrorCategory string    `json:"error_category,omitempty"` // Category of a failed command's error
+	Version       string    `json:"version"`                  // starmap version
+	OS            string    `json:"os"`
+	Arch          string    `json:"arch"`
+}
+
+// NewEvent describes a command run that took duration and returned err.
+func NewEvent(installID, version, command string, flags []string, duration time.Duration, err error) Event {
+	event := Event{
+		Schema:     Schema,
+		InstallID:  installID,
+		Timestamp:  time.Now().UTC().Truncate(time.Second),
+		Command:    command,
+		Flags:      flags,
+		DurationMS: duration.Milliseconds(),
+		Outcome:    OutcomeSuccess,
+		Version:    version,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+	}
+	if err != nil {
+		event.Outcome = OutcomeError
+		event.ErrorCategory = Category(err)
+	}
+	return event
+}
+
+// Category returns the coarse category of err reported in place of its
+// message, which may contain paths, model IDs, or provider responses.
+func Category(err error) string {
+	var (
+		validation *errors.ValidationError
+		notFound   *errors.NotFoundError
+		conflict   *errors.ConflictError
+		config     *errors.ConfigError
+		dependency *errors.DependencyError
+		auth       *errors.AuthenticationError
+		api        *errors.APIError
+		timeout    *errors.TimeoutError
+		parse      *errors.ParseError
+		io         *errors.IOError
+		process    *errors.ProcessError
+	)
+	switch {
+	case err == nil:
+		return ""
+	case stderrors.Is(err, context.Canceled), stderrors.Is(err, errors.ErrCanceled):
+		return "canceled"
+	case stderrors.Is(err, context.DeadlineExceeded), stderrors.As(err, &timeout):
+		return "timeout"
+	case stderrors.Is(err, errors.ErrRateLimited):
+		return "rate_limit"
+	case stderrors.As(err, &auth), stderrors.Is(err, errors.ErrAPIKeyRequired), stderrors.Is(err, errors.ErrAPIKeyInvalid):
+		return "authentication"
+	case stderrors.As(err, &api):
+		return "provider_api"
+	case stderrors.As(err, &validation), stderrors.Is(err, errors.ErrInvalidInput):
+		return "validation"
+	case stderrors.As(err, &notFound), stderrors.Is(err, errors.ErrNotFound):
+		return "not_found"
+	case stderrors.As(err, &conflict), stderrors.Is(err, errors.ErrConflict):
+		return "conflict"
+	case stderrors.As(err, &config):
+		return "config"
+	case stderrors.As(err, &dependency):
+		return "dependency"
+	case stderrors.As(err, &parse):
+		return "parse"
+	case stderrors.As(err, &io):
+		return "io"
+	case stderrors.As(err, &process):
+		return "process"
+	default:
+		return "other"
+	}
+}
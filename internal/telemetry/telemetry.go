@@ -0,0 +1,148 @@
+// Package telemetry reports anonymous CLI usage metrics when the user opts in.
+//
+// Telemetry is off until enabled with starmap telemetry on. Each command then
+// sends one Event naming the command, the flags given (names only), the
+// outcome and error category, the duration, and the starmap version and
+// platform. Events never contain argument or flag values, catalog contents,
+// file paths, or credentials. See docs/TELEMETRY.md for the payload schema.
+package telemetry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/uuid"
+
+	"github.com/agentstation/starmap/internal/paths"
+	"github.com/agentstation/starmap/pkg/catalogdistribution"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const (
+	// DefaultEndpoint receives telemetry events.
+	DefaultEndpoint = catalogdistribution.DefaultBaseURL + "/v1/telemetry"
+
+	// EnvTelemetry disables telemetry when set to off, false, or 0,
+	// regardless of the opt-in.
+	EnvTelemetry = "STARMAP_TELEMETRY"
+
+	// EnvEndpoint overrides DefaultEndpoint.
+	EnvEndpoint = "STARMAP_TELEMETRY_URL"
+
+	// EnvDoNotTrack is the cross-tool opt-out; any value but 0 or false
+	// disables telemetry.
+	EnvDoNotTrack = "DO_NOT_TRACK"
+)
+
+// DefaultPath returns the settings file, ~/.starmap/telemetry.yaml.
+func DefaultPath() (string, error) {
+	return paths.ResolveHome(constants.DefaultTelemetryPath)
+}
+
+// Settings is the persisted telemetry opt-in.
+type Settings struct {
+	Enabled   bool      `yaml:"enabled"`
+	InstallID string    `yaml:"install_id,omitempty"` // Random ID generated on opt-in, deleted on opt-out
+	UpdatedAt time.Time `yaml:"updated_at"`
+}
+
+// LoadSettings reads the settings at path. A missing file means telemetry
+// was never enabled.
+func LoadSettings(path string) (Settings, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Settings{}, nil
+	}
+	if err != nil {
+		return Settings{}, errors.WrapIO("read", path, err)
+	}
+	var settings Settings
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return Settings{}, errors.WrapParse("yaml", path, err)
+	}
+	return settings, nil
+}
+
+// SaveSettings writes settings to path.
+func SaveSettings(path string, settings Settings) error {
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return errors.WrapParse("yaml", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), constants.DirPermissions); err != nil {
+		return errors.WrapIO("create", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, constants.FilePermissions); err != nil {
+		return errors.WrapIO("write", path, err)
+	}
+	return nil
+}
+
+// Enable opts in, keeping the install ID of an earlier opt-in.
+func Enable(path string) (Settings, error) {
+	settings, err := LoadSettings(path)
+	if err != nil {
+		return Settings{}, err
+	}
+	settings.Enabled = true
+	if settings.InstallID == "" {
+		settings.InstallID = uuid.NewString()
+	}
+	settings.UpdatedAt = time.Now().UTC()
+	return settings, SaveSettings(path, settings)
+}
+
+// Disable opts out and deletes the install ID.
+func Disable(path string) (Settings, error) {
+	settings := Settings{UpdatedAt: time.Now().UTC()}
+	return settings, SaveSettings(path, settings)
+}
+
+// Status is the effective telemetry state.
+type Status struct {
+	Enabled   bool   `json:"enabled" yaml:"enabled"`
+	Reason    string `json:"reason" yaml:"reason"`
+	InstallID string `json:"install_id,omitempty" yaml:"install_id,omitempty"`
+	Endpoint  string `json:"endpoint" yaml:"endpoint"`
+	Settings  string `json:"settings" yaml:"settings"` // Settings file path
+}
+
+// CurrentStatus combines the settings at path with the environment.
+func CurrentStatus(path string) (Status, error) {
+	settings, err := LoadSettings(path)
+	if err != nil {
+		return Status{}, err
+	}
+	status := Status{Endpoint: DefaultEndpoint, Settings: path}
+	if endpoint := os.Getenv(EnvEndpoint); endpoint != "" {
+		status.Endpoint = endpoint
+	}
+	switch {
+	case !settings.Enabled:
+		status.Reason = "not enabled (starmap telemetry on)"
+	case envIs(EnvTelemetry, "off", "false", "0"):
+		status.Reason = "disabled by " + EnvTelemetry
+	case os.Getenv(EnvDoNotTrack) != "" && !envIs(EnvDoNotTrack, "0", "false"):
+		status.Reason = "disabled by " + EnvDoNotTrack
+	default:
+		status.Enabled = true
+		status.Reason = "enabled with starmap telemetry on"
+		status.InstallID = settings.InstallID
+	}
+	return status, nil
+}
+
+// envIs reports whether environment variable name is set to one of values.
+func envIs(name string, values ...string) bool {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv(name)))
+	for _, v := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
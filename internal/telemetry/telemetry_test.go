@@ -0,0 +1,114 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+func TestEnableDisableAndStatus(t *testing.T) {
+	t.Setenv(EnvTelemetry, "")
+	t.Setenv(EnvDoNotTrack, "")
+	t.Setenv(EnvEndpoint, "")
+	path := filepath.Join(t.TempDir(), "telemetry.yaml")
+
+	status, err := CurrentStatus(path)
+	if err != nil {
+		t.Fatalf("CurrentStatus: %v", err)
+	}
+	if status.Enabled || status.Endpoint != DefaultEndpoint {
+		t.Fatalf("status = %+v, want disabled by default", status)
+	}
+
+	enabled, err := Enable(path)
+	if err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	again, err := Enable(path)
+	if err != nil {
+		t.Fatalf("Enable again: %v", err)
+	}
+	if enabled.InstallID == "" || again.InstallID != enabled.InstallID {
+		t.Fatalf("install IDs = %q then %q, want one stable ID", enabled.InstallID, again.InstallID)
+	}
+	if status, _ = CurrentStatus(path); !status.Enabled || status.InstallID != enabled.InstallID {
+		t.Fatalf("status = %+v, want enabled", status)
+	}
+
+	t.Setenv(EnvDoNotTrack, "1")
+	if status, _ = CurrentStatus(path); status.Enabled || status.InstallID != "" {
+		t.Fatalf("status with DO_NOT_TRACK = %+v, want disabled", status)
+	}
+	t.Setenv(EnvDoNotTrack, "")
+	t.Setenv(EnvTelemetry, "off")
+	if status, _ = CurrentStatus(path); status.Enabled {
+		t.Fatalf("status with STARMAP_TELEMETRY=off = %+v, want disabled", status)
+	}
+
+	if _, err := Disable(path); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	settings, err := LoadSettings(path)
+	if err != nil {
+		t.Fatalf("LoadSettings: %v", err)
+	}
+	if settings.Enabled || settings.InstallID != "" {
+		t.Fatalf("settings = %+v, want disabled without install ID", settings)
+	}
+}
+
+func TestCategory(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{&errors.ValidationError{Field: "id", Message: "secret-model-id is invalid"}, "validation"},
+		{fmt.Errorf("load: %w", &errors.NotFoundError{Resource: "model", ID: "x"}), "not_found"},
+		{errors.NewAPIError("openai", 429, "slow down"), "rate_limit"},
+		{errors.NewAPIError("openai", 400, "bad request"), "provider_api"},
+		{errors.WrapIO("read", "/home/user/catalog", fmt.Errorf("denied")), "io"},
+		{context.Canceled, "canceled"},
+		{fmt.Errorf("unknown flag: --foo"), "other"},
+	}
+	for _, tt := range tests {
+		if got := Category(tt.err); got != tt.want {
+			t.Errorf("Category(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestClientSendsEvent(t *testing.T) {
+	received := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Decode: %v", err)
+		}
+		received <- body
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	event := NewEvent("install", "v1.2.3", "models list", []string{"provider"}, 1500*time.Millisecond,
+		&errors.ValidationError{Field: "provider", Value: "acme", Message: "unknown provider acme"})
+	if err := NewClient(server.URL).Send(context.Background(), event); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	body := <-received
+	if body["schema"] != Schema || body["command"] != "models list" || body["outcome"] != OutcomeError ||
+		body["error_category"] != "validation" || body["duration_ms"] != float64(1500) {
+		t.Fatalf("payload = %v", body)
+	}
+	if data, _ := json.Marshal(body); strings.Contains(string(data), "acme") {
+		t.Fatalf("payload leaks the error message: %s", data)
+	}
+}
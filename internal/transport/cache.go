@@ -0,0 +1,211 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/constants"
+)
+
+// CacheStatusHeader is set on responses served by an HTTPCache: "hit" for a
+// response reused within the TTL, "revalidated" for one the server confirmed
+// unchanged with 304 Not Modified. Responses fetched in full do not carry it.
+const CacheStatusHeader = "X-Starmap-Cache"
+
+// Cache statuses reported in CacheStatusHeader.
+const (
+	CacheHit         = "hit"
+	CacheRevalidated = "revalidated"
+)
+
+// HTTPCache keeps successful GET responses on disk so repeated fetches do not
+// download unchanged payloads again. Responses stored within the TTL are
+// served without a request. Older responses are revalidated with
+// If-None-Match and If-Modified-Since from their ETag and Last-Modified, and
+// a 304 Not Modified serves the stored body. Entries are keyed by a hash of
+// the request URL and headers, so credentials are never written.
+type HTTPCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// cachedResponse is one stored response.
+type cachedResponse struct {
+	ContentType  string    `json:"content_type,omitempty"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	StoredAt     time.Time `json:"stored_at"`
+	Body         []byte    `json:"body"`
+}
+
+// NewHTTPCache returns a cache that stores responses under dir and reuses them
+// for ttl. A zero ttl revalidates every response.
+func NewHTTPCache(dir string, ttl time.Duration) *HTTPCache {
+	return &HTTPCache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// Revalidating returns a cache over the same entries that revalidates every
+// response instead of reusing it within the TTL.
+func (c *HTTPCache) Revalidating() *HTTPCache {
+	revalidating := *c
+	revalidating.ttl = 0
+	return &revalidating
+}
+
+type httpCacheContextKey struct{}
+
+// WithHTTPCache returns a context whose GET requests made through Client are
+// served from cache.
+func WithHTTPCache(ctx context.Context, cache *HTTPCache) context.Context {
+	return context.WithValue(ctx, httpCacheContextKey{}, cache)
+}
+
+// HTTPCacheFromContext returns the cache set by WithHTTPCache, or nil.
+func HTTPCacheFromContext(ctx context.Context) *HTTPCache {
+	cache, _ := ctx.Value(httpCacheContextKey{}).(*HTTPCache)
+	return cache
+}
+
+// do sends req with client, serving and storing GET responses from the cache.
+// The cache is an optimization: entries that cannot be read or written are
+// treated as misses.
+func (c *HTTPCache) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return client.Do(req) //nolint:gosec // Same trusted endpoints as Client.DoWithContext.
+	}
+
+	path := c.path(req)
+	entry, cached := c.load(path)
+	if cached && c.now().Sub(entry.StoredAt) < c.ttl {
+		return entry.response(req, CacheHit), nil
+	}
+	if cached {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := client.Do(req) //nolint:gosec // Same trusted endpoints as Client.DoWithContext.
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached {
+		_ = resp.Body.Close()
+		entry.ETag = headerOr(resp.Header, "ETag", entry.ETag)
+		entry.LastModified = headerOr(resp.Header, "Last-Modified", entry.LastModified)
+		entry.StoredAt = c.now()
+		c.store(path, entry)
+		return entry.response(req, CacheRevalidated), nil
+	}
+	if resp.StatusCode != http.StatusOK || strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, constants.MaxSourcePayloadBytes+1))
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) <= constants.MaxSourcePayloadBytes {
+		c.store(path, cachedResponse{
+			ContentType:  resp.Header.Get("Content-Type"),
+			ETag:         strings.TrimSpace(resp.Header.Get("ETag")),
+			LastModified: strings.TrimSpace(resp.Header.Get("Last-Modified")),
+			StoredAt:     c.now(),
+			Body:         body,
+		})
+	}
+	return resp, nil
+}
+
+// path returns where the response to req is stored. The key covers the URL
+// and every request header, so requests made with different credentials or
+// API versions never share an entry.
+func (c *HTTPCache) path(req *http.Request) string {
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, req.Method+" "+req.URL.String()+"\n")
+	for _, name := range slices.Sorted(maps.Keys(req.Header)) {
+		_, _ = io.WriteString(hash, name+": "+strings.Join(req.Header.Values(name), ", ")+"\n")
+	}
+	return filepath.Join(c.dir, hex.EncodeToString(hash.Sum(nil))+".json")
+}
+
+func (c *HTTPCache) load(path string) (cachedResponse, bool) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is derived from a hash inside the cache directory.
+	if err != nil {
+		return cachedResponse{}, false
+	}
+	var entry cachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil || entry.StoredAt.IsZero() {
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+func (c *HTTPCache) store(path string, entry cachedResponse) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, constants.DirPermissions); err != nil {
+		return
+	}
+	temporary := path + ".tmp"
+	if err := os.WriteFile(temporary, data, constants.FilePermissions); err != nil {
+		return
+	}
+	if err := os.Rename(temporary, path); err != nil {
+		_ = os.Remove(temporary)
+	}
+}
+
+// response rebuilds the stored response to req.
+func (e cachedResponse) response(req *http.Request, status string) *http.Response {
+	header := http.Header{}
+	header.Set(CacheStatusHeader, status)
+	header.Set("Content-Length", strconv.Itoa(len(e.Body)))
+	if e.ContentType != "" {
+		header.Set("Content-Type", e.ContentType)
+	}
+	if e.ETag != "" {
+		header.Set("ETag", e.ETag)
+	}
+	if e.LastModified != "" {
+		header.Set("Last-Modified", e.LastModified)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+func headerOr(header http.Header, name, fallback string) string {
+	if value := strings.TrimSpace(header.Get(name)); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,57 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPCacheReusesAndRevalidatesResponses(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"data":[]}`)
+	}))
+	defer server.Close()
+
+	cache := NewHTTPCache(t.TempDir(), time.Hour)
+	client := &Client{http: server.Client(), auth: &NoAuth{}}
+	get := func(cache *HTTPCache) (string, string) {
+		t.Helper()
+		ctx := WithHTTPCache(context.Background(), cache)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/models", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		resp, err := client.Do(req, nil)
+		if err != nil {
+			t.Fatalf("Do returned error: %v", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("Failed to read body: %v", err)
+		}
+		return string(body), resp.Header.Get(CacheStatusHeader)
+	}
+
+	if body, status := get(cache); body != `{"data":[]}` || status != "" {
+		t.Fatalf("first fetch = %q (%q), want downloaded body", body, status)
+	}
+	if body, status := get(cache); body != `{"data":[]}` || status != CacheHit || requests != 1 {
+		t.Fatalf("second fetch = %q (%q) after %d requests, want cache hit without a request", body, status, requests)
+	}
+	if body, status := get(cache.Revalidating()); body != `{"data":[]}` || status != CacheRevalidated || notModified != 1 {
+		t.Fatalf("revalidated fetch = %q (%q) after %d 304s, want stored body revalidated", body, status, notModified)
+	}
+}
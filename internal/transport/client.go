@@ -63,6 +63,9 @@ func (c *Client) DoWithContext(ctx context.Context, req *http.Request, provider
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	if cache := HTTPCacheFromContext(ctx); cache != nil {
+		return cache.do(c.http, req)
+	}
 	return c.http.Do(req) //nolint:gosec // Provider endpoints are trusted catalog configuration or caller-supplied integration points.
 }
 
@@ -0,0 +1,78 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Page is one page of a paginated listing.
+type Page[T any] struct {
+	Items []T
+	Next  string // Token, cursor, or URL of the next page; empty on the last page
+}
+
+// PageFetcher fetches the page named by next, or the first page when next is
+// empty.
+type PageFetcher[T any] func(ctx context.Context, next string) (Page[T], error)
+
+// Paginate follows a provider's paginated listing until a page has no next
+// token, guarding against providers that never end it:
+//
+//   - at most maxPages pages are fetched;
+//   - a next token that was already followed ends the listing;
+//   - a page whose items all appeared on earlier pages, as identified by key,
+//     ends the listing without being added;
+//   - a failure fetching a page after the first ends the listing.
+//
+// In each of these cases the items of the pages fetched so far are returned
+// with a *errors.PartialListError, so callers can keep them and report the
+// listing partial. A failure fetching the first page, or an interruption by
+// ctx, is returned as is without items.
+func Paginate[T any](ctx context.Context, provider string, maxPages int, key func(T) string, fetch PageFetcher[T]) ([]T, error) {
+	var items []T
+	seenItems := make(map[string]bool)
+	seenTokens := make(map[string]bool)
+	next := ""
+	for page := 0; ; page++ {
+		if page == maxPages {
+			return items, &errors.PartialListError{
+				Provider: provider,
+				Pages:    page,
+				Reason:   fmt.Sprintf("page limit of %d reached", maxPages),
+			}
+		}
+		result, err := fetch(ctx, next)
+		if err != nil {
+			if page == 0 || ctx.Err() != nil {
+				return nil, err
+			}
+			return items, &errors.PartialListError{Provider: provider, Pages: page, Reason: "next page failed", Err: err}
+		}
+		if page > 0 && len(result.Items) > 0 && allSeen(result.Items, key, seenItems) {
+			return items, &errors.PartialListError{Provider: provider, Pages: page, Reason: "provider repeated an earlier page"}
+		}
+		for _, item := range result.Items {
+			seenItems[key(item)] = true
+		}
+		items = append(items, result.Items...)
+		if result.Next == "" {
+			return items, nil
+		}
+		if seenTokens[result.Next] {
+			return items, &errors.PartialListError{Provider: provider, Pages: page + 1, Reason: "provider repeated a next-page token"}
+		}
+		seenTokens[result.Next] = true
+		next = result.Next
+	}
+}
+
+func allSeen[T any](items []T, key func(T) string, seen map[string]bool) bool {
+	for _, item := range items {
+		if !seen[key(item)] {
+			return false
+		}
+	}
+	return true
+}
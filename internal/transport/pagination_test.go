@@ -0,0 +1,132 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func identity(item string) string { return item }
+
+// pages serves pages[i] for the token "i", with "" naming page 0.
+func pages(calls *int, pages ...Page[string]) PageFetcher[string] {
+	return func(_ context.Context, next string) (Page[string], error) {
+		*calls++
+		index := 0
+		if next != "" {
+			index, _ = strconv.Atoi(next)
+		}
+		return pages[index], nil
+	}
+}
+
+func TestPaginateFollowsPagesToTheEnd(t *testing.T) {
+	calls := 0
+	items, err := Paginate(context.Background(), "test", 10, identity, pages(&calls,
+		Page[string]{Items: []string{"a"}, Next: "1"},
+		Page[string]{Items: []string{"b"}, Next: "2"},
+		Page[string]{Items: []string{"c"}},
+	))
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	if !slices.Equal(items, []string{"a", "b", "c"}) || calls != 3 {
+		t.Fatalf("items = %q after %d calls", items, calls)
+	}
+}
+
+func TestPaginateReturnsPartialResults(t *testing.T) {
+	failure := errors.New("boom")
+	tests := []struct {
+		name      string
+		maxPages  int
+		fetch     func(calls *int) PageFetcher[string]
+		wantItems []string
+		wantPages int
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:     "page limit",
+			maxPages: 2,
+			fetch: func(calls *int) PageFetcher[string] {
+				return pages(calls,
+					Page[string]{Items: []string{"a"}, Next: "1"},
+					Page[string]{Items: []string{"b"}, Next: "2"},
+					Page[string]{Items: []string{"c"}},
+				)
+			},
+			wantItems: []string{"a", "b"}, wantPages: 2, wantCalls: 2,
+		},
+		{
+			name:     "repeated token",
+			maxPages: 10,
+			fetch: func(calls *int) PageFetcher[string] {
+				return pages(calls,
+					Page[string]{Items: []string{"a"}, Next: "1"},
+					Page[string]{Items: []string{"b"}, Next: "1"},
+				)
+			},
+			wantItems: []string{"a", "b"}, wantPages: 2, wantCalls: 2,
+		},
+		{
+			name:     "repeated page under a new token",
+			maxPages: 10,
+			fetch: func(calls *int) PageFetcher[string] {
+				return func(_ context.Context, _ string) (Page[string], error) {
+					*calls++
+					return Page[string]{Items: []string{"a", "b"}, Next: strconv.Itoa(*calls)}, nil
+				}
+			},
+			wantItems: []string{"a", "b"}, wantPages: 1, wantCalls: 2,
+		},
+		{
+			name:     "later page fails",
+			maxPages: 10,
+			fetch: func(calls *int) PageFetcher[string] {
+				return func(_ context.Context, next string) (Page[string], error) {
+					*calls++
+					if next != "" {
+						return Page[string]{}, failure
+					}
+					return Page[string]{Items: []string{"a"}, Next: "1"}, nil
+				}
+			},
+			wantItems: []string{"a"}, wantPages: 1, wantCalls: 2, wantErr: failure,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			items, err := Paginate(context.Background(), "test", test.maxPages, identity, test.fetch(&calls))
+			var partial *pkgerrors.PartialListError
+			if !errors.As(err, &partial) {
+				t.Fatalf("err = %v, want *pkgerrors.PartialListError", err)
+			}
+			if partial.Pages != test.wantPages || partial.Provider != "test" {
+				t.Fatalf("partial = %+v, want %d pages", partial, test.wantPages)
+			}
+			if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+				t.Fatalf("err = %v, want it to wrap %v", err, test.wantErr)
+			}
+			if !slices.Equal(items, test.wantItems) || calls != test.wantCalls {
+				t.Fatalf("items = %q after %d calls, want %q after %d", items, calls, test.wantItems, test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPaginateReturnsFirstPageFailure(t *testing.T) {
+	failure := errors.New("boom")
+	items, err := Paginate(context.Background(), "test", 10, identity, func(context.Context, string) (Page[string], error) {
+		return Page[string]{}, failure
+	})
+	var partial *pkgerrors.PartialListError
+	if items != nil || !errors.Is(err, failure) || errors.As(err, &partial) {
+		t.Fatalf("items = %q, err = %v; want the first page's error alone", items, err)
+	}
+}
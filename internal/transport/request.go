@@ -89,5 +89,14 @@ func DecodeResponse(resp *http.Response, target any) error {
 		return errors.WrapParse("json", "response", err)
 	}
 
+	// Report the accepted payload structure for format drift detection.
+	if resp.Request != nil {
+		if recorder := sourcepayload.ShapeRecorderFromContext(resp.Request.Context()); recorder != nil {
+			if err := recorder.Record(body); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,149 @@
+package starmap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+type expectedRevisionKey struct{}
+
+// WithExpectedRevision returns a context that makes model edits such as
+// ReviewModel and TagModel conditional: the edit fails with a
+// *RevisionConflictError unless the model's current catalogs.ModelRevision
+// equals revision. An empty revision leaves edits unconditional.
+func WithExpectedRevision(ctx context.Context, revision string) context.Context {
+	return context.WithValue(ctx, expectedRevisionKey{}, revision)
+}
+
+func expectedRevision(ctx context.Context) string {
+	revision, _ := ctx.Value(expectedRevisionKey{}).(string)
+	return revision
+}
+
+// RevisionConflictError reports a conditional model edit rejected because the
+// model changed after the caller read it. Current holds the model's records as
+// they are now, keyed like catalogs.ModelRecords, so the caller can reconcile
+// and retry against Actual.
+type RevisionConflictError struct {
+	ModelID  string
+	Expected string
+	Actual   string
+	Current  map[string]catalogs.Model
+}
+
+// Error implements the error interface.
+func (e *RevisionConflictError) Error() string {
+	return fmt.Sprintf("model %s revision conflict: expected %q, actual %q", e.ModelID, e.Expected, e.Actual)
+}
+
+// Is implements errors.Is support.
+func (e *RevisionConflictError) Is(target error) bool {
+	return target == errors.ErrConflict
+}
+
+// editModel applies edit to every offering of modelID, or only to providerID's
+// offering when providerID is set, and publishes the result as a new
+// generation attributed to sourceID. edit reports whether it changed the
+// model; nothing is published when no offering changed.
+func (c *Client) editModel(ctx context.Context, sourceID catalogmeta.SourceID, modelID string, providerID catalogs.ProviderID, edit func(*catalogs.Model) bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.editCatalog(ctx, sourceID, func(builder *catalogs.Builder) (bool, error) {
+		if expected := expectedRevision(ctx); expected != "" {
+			actual, err := catalogs.ModelRevision(builder, modelID)
+			if err != nil {
+				return false, err
+			}
+			if actual != expected {
+				return false, &RevisionConflictError{
+					ModelID:  modelID,
+					Expected: expected,
+					Actual:   actual,
+					Current:  catalogs.ModelRecords(builder, modelID),
+				}
+			}
+		}
+		found, changed := false, false
+		for _, provider := range builder.Providers().List() {
+			if providerID != "" && provider.ID != providerID {
+				continue
+			}
+			model, ok := provider.Models[modelID]
+			if !ok {
+				continue
+			}
+			found = true
+			if !edit(model) {
+				continue
+			}
+			changed = true
+			if err := builder.SetProviderModel(provider.ID, *model); err != nil {
+				return false, err
+			}
+		}
+		if providerID == "" {
+			for _, author := range builder.Authors().List() {
+				model, ok := author.Models[modelID]
+				if !ok {
+					continue
+				}
+				found = true
+				if !edit(model) {
+					continue
+				}
+				changed = true
+				if err := builder.SetAuthor(author); err != nil {
+					return false, err
+				}
+			}
+		}
+		if !found {
+			return false, &errors.NotFoundError{Resource: "model", ID: modelID}
+		}
+		return changed, nil
+	})
+}
+
+// editCatalog applies edit to a copy of the current catalog and publishes the
+// result as a new generation attributed to sourceID. edit reports whether it
+// changed the catalog; nothing is published when it did not.
+func (c *Client) editCatalog(ctx context.Context, sourceID catalogmeta.SourceID, edit func(*catalogs.Builder) (bool, error)) error {
+	if err := c.requireWritableCatalogStore(); err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	release, err := c.updates.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	builder, err := c.catalogCopy()
+	if err != nil {
+		return err
+	}
+	changed, err := edit(builder)
+	if err != nil || !changed {
+		return err
+	}
+
+	published, err := snapshotBuilder(builder)
+	if err != nil {
+		return err
+	}
+	observation, err := c.catalogObservation(sourceID, published, sources.Revision{Kind: sources.RevisionKindContentDigest})
+	if err != nil {
+		return err
+	}
+	_, err = c.commitAndPublish(ctx, published, []sources.Observation{observation})
+	return err
+}
@@ -0,0 +1,62 @@
+package starmap
+
+import (
+	"context"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const namingSourceID = catalogmeta.SourceID("naming")
+
+// ModelRename sets the display name of one provider's offering of a model.
+type ModelRename struct {
+	Provider catalogs.ProviderID `json:"provider" yaml:"provider"`
+	Model    string              `json:"model" yaml:"model"`
+	Name     string              `json:"name" yaml:"name"`
+}
+
+// RenameModels sets the display names of provider offerings and publishes
+// the result as one new generation. Offerings that already carry their name
+// are left alone. It returns how many offerings were renamed.
+//
+// Provider APIs own model names, so a later update may restore the name a
+// provider reports.
+func (c *Client) RenameModels(ctx context.Context, renames []ModelRename) (int, error) {
+	for _, rename := range renames {
+		if strings.TrimSpace(rename.Name) == "" {
+			return 0, &errors.ValidationError{Field: "name", Value: rename.Model, Message: "must not be empty"}
+		}
+	}
+
+	renamed := 0
+	err := c.editCatalog(ctx, namingSourceID, func(builder *catalogs.Builder) (bool, error) {
+		renamed = 0
+		for _, rename := range renames {
+			provider, ok := builder.Providers().Get(rename.Provider)
+			if !ok {
+				return false, &errors.NotFoundError{Resource: "provider", ID: string(rename.Provider)}
+			}
+			model, ok := provider.Models[rename.Model]
+			if !ok {
+				return false, &errors.NotFoundError{Resource: "model", ID: rename.Model}
+			}
+			name := strings.TrimSpace(rename.Name)
+			if model.Name == name {
+				continue
+			}
+			model.Name = name
+			if err := builder.SetProviderModel(provider.ID, *model); err != nil {
+				return false, err
+			}
+			renamed++
+		}
+		return renamed > 0, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return renamed, nil
+}
@@ -0,0 +1,72 @@
+package starmap
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func TestRenameModelsPublishesOneGeneration(t *testing.T) {
+	client, err := New(
+		WithCatalogStore(catalogstore.NewMemory()),
+		WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			for id, name := range map[catalogs.ProviderID]string{"first": "Shared Model", "second": "shared-model"} {
+				if err := candidate.SetProvider(catalogs.Provider{
+					ID:     id,
+					Name:   string(id),
+					Models: map[string]*catalogs.Model{"shared-model": {ID: "shared-model", Name: name}},
+				}); err != nil {
+					return nil, err
+				}
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+	if err := client.Update(ctx); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	generation := client.CurrentGenerationID()
+
+	renamed, err := client.RenameModels(ctx, []ModelRename{
+		{Provider: "first", Model: "shared-model", Name: "Shared Model"},
+		{Provider: "second", Model: "shared-model", Name: "Shared Model"},
+	})
+	if err != nil {
+		t.Fatalf("RenameModels: %v", err)
+	}
+	if renamed != 1 {
+		t.Fatalf("renamed = %d, want only the second offering", renamed)
+	}
+	if client.CurrentGenerationID() == generation {
+		t.Fatal("RenameModels did not publish a new generation")
+	}
+
+	model, err := client.Catalog().ProviderModel("second", "shared-model")
+	if err != nil {
+		t.Fatalf("ProviderModel: %v", err)
+	}
+	if model.Name != "Shared Model" {
+		t.Fatalf("second name = %q, want Shared Model", model.Name)
+	}
+
+	generation = client.CurrentGenerationID()
+	if renamed, err := client.RenameModels(ctx, []ModelRename{{Provider: "second", Model: "shared-model", Name: "Shared Model"}}); err != nil || renamed != 0 {
+		t.Fatalf("RenameModels unchanged = %d, %v; want 0, nil", renamed, err)
+	}
+	if client.CurrentGenerationID() != generation {
+		t.Fatal("RenameModels published a generation without changes")
+	}
+
+	_, err = client.RenameModels(ctx, []ModelRename{{Provider: "second", Model: "missing", Name: "Missing"}})
+	if !stderrors.Is(err, pkgerrors.ErrNotFound) {
+		t.Fatalf("RenameModels missing model error = %v, want not found", err)
+	}
+}
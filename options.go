@@ -8,7 +8,9 @@ import (
 	"github.com/agentstation/starmap/internal/utils/ptr"
 	"github.com/agentstation/starmap/pkg/catalogs"
 	"github.com/agentstation/starmap/pkg/catalogstore"
+	"github.com/agentstation/starmap/pkg/differ"
 	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/reconciler"
 )
 
 // ============================================================================
@@ -35,12 +37,24 @@ type options struct {
 	embeddedCatalogEnabled        bool
 	embeddedBootstrapMaxAge       time.Duration
 	embeddedBootstrapMaxSizeBytes int64
+
+	// offline disables every catalog update path
+	offline bool
+
+	// reviewNewModels holds models first discovered by sync in pending-review
+	reviewNewModels bool
+
+	// reconciliationStrategy names the registered strategy every sync uses
+	reconciliationStrategy string
+
+	// diffRules are the change detection tolerance rules every sync uses
+	diffRules differ.Rules
 }
 
 func defaults() *options {
 	return &options{
 		updateFunc:                    nil,   // Default to pipeline-based updates
-		catalogExportPath:              "",    // Default to no YAML import/export tree
+		catalogExportPath:             "",    // Default to no YAML import/export tree
 		catalogStore:                  nil,   // Mutation requires an explicit writable store
 		embeddedCatalogEnabled:        false, // Default to no embedded catalog
 		embeddedBootstrapMaxAge:       0,     // Disabled until explicitly configured
@@ -48,6 +62,8 @@ func defaults() *options {
 		remoteServerURL:               nil,   // Default to no remote server
 		remoteServerAPIKey:            nil,   // Default to no remote server API key
 		remoteServerOnly:              false, // Default to not only use remote server
+		offline:                       false, // Default to allowing catalog updates
+		reviewNewModels:               false, // Default to serving newly synced models
 	}
 }
 
@@ -159,6 +175,51 @@ func WithEmbeddedCatalog() Option {
 	}
 }
 
+// WithOffline serves the loaded catalog without ever contacting a source.
+// Sync and Update return a configuration error instead of fetching, which
+// guarantees no outbound requests in air-gapped deployments.
+func WithOffline() Option {
+	return func(o *options) error {
+		o.offline = true
+		return nil
+	}
+}
+
+// WithModelReview enables the review workflow for every sync run by this
+// client: models a sync discovers for the first time enter pending-review and
+// are not served by default until approved with ReviewModel.
+func WithModelReview() Option {
+	return func(o *options) error {
+		o.reviewNewModels = true
+		return nil
+	}
+}
+
+// WithReconciliationStrategy selects, for every sync run by this client, the
+// conflict resolution strategy registered under name with
+// reconciler.RegisterStrategy. Register custom strategies before calling New.
+func WithReconciliationStrategy(name string) Option {
+	return func(o *options) error {
+		if _, err := reconciler.NewStrategy(reconciler.StrategyType(name), nil); err != nil {
+			return err
+		}
+		o.reconciliationStrategy = name
+		return nil
+	}
+}
+
+// WithDiffRules applies, for every sync run by this client, tolerance and
+// ignore rules that keep cosmetic source variations out of changesets.
+func WithDiffRules(rules differ.Rules) Option {
+	return func(o *options) error {
+		if err := rules.Validate(); err != nil {
+			return err
+		}
+		o.diffRules = rules
+		return nil
+	}
+}
+
 // WithEmbeddedBootstrapMaxAge fails readiness while the active catalog is the
 // embedded bootstrap and its generation age exceeds maxAge.
 func WithEmbeddedBootstrapMaxAge(maxAge time.Duration) Option {
@@ -14,7 +14,9 @@ import (
 	"github.com/agentstation/starmap/pkg/catalogs"
 	"github.com/agentstation/starmap/pkg/catalogstore"
 	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/differ"
 	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/reconciler"
 	"github.com/agentstation/starmap/pkg/save"
 	"github.com/agentstation/starmap/pkg/sources"
 )
@@ -200,3 +202,63 @@ func TestRemoteServerOnlyUsesRemoteCatalog(t *testing.T) {
 	}
 	assertNoCatalogEvent(t, events)
 }
+
+func TestOfflineClientRejectsSyncAndUpdate(t *testing.T) {
+	called := false
+	opts, err := defaults().apply(
+		WithCatalogStore(catalogstore.NewMemory()),
+		WithOffline(),
+		WithUpdateFunc(func(_ context.Context, catalog *catalogs.Builder) (*catalogs.Builder, error) {
+			called = true
+			return catalog, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Apply options: %v", err)
+	}
+
+	client := &Client{options: opts, catalog: mustTestCatalog(t, catalogs.NewEmpty()), hooks: newHooks()}
+	var configErr *pkgerrors.ConfigError
+	if err := client.Update(context.Background()); !stderrors.As(err, &configErr) {
+		t.Fatalf("offline Update error = %T: %v, want *errors.ConfigError", err, err)
+	}
+	if _, err := client.Sync(context.Background()); !stderrors.As(err, &configErr) {
+		t.Fatalf("offline Sync error = %T: %v, want *errors.ConfigError", err, err)
+	}
+	if called {
+		t.Fatal("offline client invoked the configured update module")
+	}
+}
+
+func TestReconciliationStrategyMustBeRegistered(t *testing.T) {
+	client, err := New(WithReconciliationStrategy("median-price"))
+	var validationErr *pkgerrors.ValidationError
+	if client != nil || !stderrors.As(err, &validationErr) {
+		t.Fatalf("New = (%v, %v), want *errors.ValidationError", client, err)
+	}
+
+	client, err = New(WithReconciliationStrategy(string(reconciler.StrategyTypeSourceOrder)))
+	if err != nil {
+		t.Fatalf("New with source-order: %v", err)
+	}
+	if got := client.options.reconciliationStrategy; got != string(reconciler.StrategyTypeSourceOrder) {
+		t.Fatalf("reconciliationStrategy = %q", got)
+	}
+}
+
+func TestDiffRulesAreValidated(t *testing.T) {
+	client, err := New(WithDiffRules(differ.Rules{PriceTolerance: 1.5}))
+	var validationErr *pkgerrors.ValidationError
+	if client != nil || !stderrors.As(err, &validationErr) {
+		t.Fatalf("New = (%v, %v), want *errors.ValidationError", client, err)
+	}
+
+	rules := differ.Rules{PriceTolerance: 0.001, IgnoreTimestampOnly: true}
+	client, err = New(WithDiffRules(rules))
+	if err != nil {
+		t.Fatalf("New with diff rules: %v", err)
+	}
+	if got := client.options.diffRules; got.PriceTolerance != 0.001 || !got.IgnoreTimestampOnly {
+		t.Fatalf("diffRules = %+v", got)
+	}
+}
@@ -150,6 +150,43 @@ func defaultModelAuthorities() []Field {
 		{Path: "Delivery", Source: sources.ModelsDevHTTPID, Priority: 90},
 		{Path: "Delivery", Source: sources.ModelsDevGitID, Priority: 85},
 
+		// Vision and document input constraints - curated locally from provider docs
+		{Path: "Vision", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Vision", Source: sources.ProvidersID, Priority: 90},
+		{Path: "Documents", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Documents", Source: sources.ProvidersID, Priority: 90},
+
+		// Performance - measured or published numbers are curated locally
+		{Path: "Performance", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Performance", Source: sources.ProvidersID, Priority: 90},
+
+		// Usage restrictions - curated locally from license and policy text
+		{Path: "UsageRestrictions", Source: sources.LocalCatalogID, Priority: 95},
+
+		// Curation tags - user-managed governance, owned by the local layer
+		{Path: "Curation", Source: sources.LocalCatalogID, Priority: 95},
+
+		// Review state and audit log - recorded by sync and reviewers in the local layer
+		{Path: "Review", Source: sources.LocalCatalogID, Priority: 95},
+
+		// Private field paths - set in the local overlay to redact public output
+		{Path: "Private", Source: sources.LocalCatalogID, Priority: 95},
+
+		// Definition links - curated locally or written back by model matching
+		{Path: "Definition", Source: sources.LocalCatalogID, Priority: 95},
+
+		// Evaluations - the benchmarks source publishes fresh scores; the local
+		// catalog keeps the last published scores when it is not run
+		{Path: "Evaluations", Source: sources.BenchmarksID, Priority: 100},
+		{Path: "Evaluations", Source: sources.LocalCatalogID, Priority: 80},
+
+		// Sustainability - energy estimates are curated locally with their methodology
+		{Path: "Sustainability", Source: sources.LocalCatalogID, Priority: 95},
+
+		// Prompt caching - curated locally; provider model listings rarely report it
+		{Path: "Caching", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Caching", Source: sources.ProvidersID, Priority: 90},
+
 		// Limits - models.dev has better data (HTTP preferred)
 		{Path: "Limits", Source: sources.ModelsDevHTTPID, Priority: 100},
 		{Path: "Limits", Source: sources.ModelsDevGitID, Priority: 90},
@@ -177,6 +214,13 @@ func defaultModelAuthorities() []Field {
 		{Path: "Status", Source: sources.ModelsDevGitID, Priority: 85},
 		{Path: "Status", Source: sources.ProvidersID, Priority: 70},
 
+		// Model class and embedding dimensions - the provider API says which
+		// endpoints serve the model; local edits fill in what it omits.
+		{Path: "Class", Source: sources.ProvidersID, Priority: 95},
+		{Path: "Class", Source: sources.LocalCatalogID, Priority: 90},
+		{Path: "Embedding", Source: sources.ProvidersID, Priority: 95},
+		{Path: "Embedding", Source: sources.LocalCatalogID, Priority: 90},
+
 		// Lineage - models.dev is best for family; provider APIs can fill root/parent.
 		{Path: "Lineage", Source: sources.ModelsDevHTTPID, Priority: 90},
 		{Path: "Lineage", Source: sources.ModelsDevGitID, Priority: 85},
@@ -216,6 +260,20 @@ func defaultProviderAuthorities() []Field {
 		{Path: "ChatCompletions", Source: sources.LocalCatalogID, Priority: 95},
 		{Path: "ChatCompletions.URL", Source: sources.LocalCatalogID, Priority: 95},
 		{Path: "ChatCompletions.HealthAPIURL", Source: sources.LocalCatalogID, Priority: 90},
+		{Path: "SLA", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "SLA.*", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Support", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Support.*", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "PromptCaching", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "PromptCaching.*", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Vision", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Vision.*", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Documents", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Documents.*", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "UsageRestrictions", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "UsageRestrictions.*", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Sustainability", Source: sources.LocalCatalogID, Priority: 95},
+		{Path: "Sustainability.*", Source: sources.LocalCatalogID, Priority: 95},
 
 		// Core info - prefer manual edits (using Go field names)
 		{Path: "Name", Source: sources.LocalCatalogID, Priority: 90},
@@ -74,8 +74,8 @@ func FindCanonicalPolicy(resource sources.ResourceType, path string) (AttributeP
 }
 
 func definitionPolicies() []AttributePolicy {
-	curated := []sources.ID{sources.LocalCatalogID, sources.ModelsDevHTTPID, sources.ModelsDevGitID, sources.ProvidersID}
-	observedCapability := []sources.ID{sources.ProvidersID, sources.ModelsDevHTTPID, sources.ModelsDevGitID, sources.LocalCatalogID}
+	curated := []sources.ID{sources.LocalCatalogID, sources.ModelsDevHTTPID, sources.ModelsDevGitID, sources.ProvidersID, sources.RemoteCatalogID}
+	observedCapability := []sources.ID{sources.ProvidersID, sources.ModelsDevHTTPID, sources.ModelsDevGitID, sources.LocalCatalogID, sources.RemoteCatalogID}
 	return []AttributePolicy{
 		{sources.ResourceTypeModelDefinition, "ID", curated, MergeIdentity, EmptyReject, "Canonical definition identity is curated and provider-independent."},
 		{sources.ResourceTypeModelDefinition, "Name", curated, MergeReplace, EmptyReject, "A stable curated display name avoids provider branding drift."},
@@ -93,8 +93,8 @@ func definitionPolicies() []AttributePolicy {
 }
 
 func offeringPolicies() []AttributePolicy {
-	providerFirst := []sources.ID{sources.ProvidersID, sources.ModelsDevHTTPID, sources.ModelsDevGitID, sources.LocalCatalogID}
-	curatedIdentity := []sources.ID{sources.LocalCatalogID, sources.ModelsDevHTTPID, sources.ModelsDevGitID, sources.ProvidersID}
+	providerFirst := []sources.ID{sources.ProvidersID, sources.ModelsDevHTTPID, sources.ModelsDevGitID, sources.LocalCatalogID, sources.RemoteCatalogID}
+	curatedIdentity := []sources.ID{sources.LocalCatalogID, sources.ModelsDevHTTPID, sources.ModelsDevGitID, sources.ProvidersID, sources.RemoteCatalogID}
 	return []AttributePolicy{
 		{sources.ResourceTypeProviderOffering, "ProviderID", providerFirst, MergeIdentity, EmptyReject, "Offering identity is scoped to the provider that serves it."},
 		{sources.ResourceTypeProviderOffering, "ProviderModelID", providerFirst, MergeIdentity, EmptyReject, "The provider model ID is the exact opaque inference identifier."},
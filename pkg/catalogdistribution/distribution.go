@@ -332,13 +332,21 @@ func (c *Client) FetchLatest(ctx context.Context) (catalogstore.Generation, erro
 // FetchChannel resolves, downloads, and verifies the latest compatible
 // immutable generation selected for one explicit promotion channel.
 func (c *Client) FetchChannel(ctx context.Context, channel Channel) (catalogstore.Generation, error) {
-	generation, _, err := c.fetchChannel(ctx, channel)
-	return generation, err
+	published, _, err := c.fetchChannel(ctx, channel)
+	return published.Generation, err
 }
 
-func (c *Client) fetchChannel(ctx context.Context, channel Channel) (catalogstore.Generation, LatestPointer, error) {
+// FetchChannelArtifact is FetchChannel that also returns the exact archive and
+// attestation bytes that were downloaded and checksum-verified, so callers can
+// check the publisher's signature over what was actually fetched.
+func (c *Client) FetchChannelArtifact(ctx context.Context, channel Channel) (PublishedGeneration, error) {
+	published, _, err := c.fetchChannel(ctx, channel)
+	return published, err
+}
+
+func (c *Client) fetchChannel(ctx context.Context, channel Channel) (PublishedGeneration, LatestPointer, error) {
 	if err := channel.Validate(); err != nil {
-		return catalogstore.Generation{}, LatestPointer{}, err
+		return PublishedGeneration{}, LatestPointer{}, err
 	}
 	latest := c.baseURL.ResolveReference(&url.URL{Path: APIPrefix + "/latest"})
 	query := latest.Query()
@@ -347,53 +355,60 @@ func (c *Client) fetchChannel(ctx context.Context, channel Channel) (catalogstor
 	latest.RawQuery = query.Encode()
 	pointerData, pointerMediaType, err := c.fetch(ctx, latest, "application/json")
 	if err != nil {
-		return catalogstore.Generation{}, LatestPointer{}, err
+		return PublishedGeneration{}, LatestPointer{}, err
 	}
 	if pointerMediaType != "application/json" {
-		return catalogstore.Generation{}, LatestPointer{}, &errors.ValidationError{Field: "catalog_distribution.latest", Value: pointerMediaType, Message: "has unexpected media type"}
+		return PublishedGeneration{}, LatestPointer{}, &errors.ValidationError{Field: "catalog_distribution.latest", Value: pointerMediaType, Message: "has unexpected media type"}
 	}
 	var pointer LatestPointer
 	if err := decodeStrictJSON(pointerData, &pointer); err != nil {
-		return catalogstore.Generation{}, LatestPointer{}, err
+		return PublishedGeneration{}, LatestPointer{}, err
 	}
 	if pointer.Version != PointerVersion || pointer.Channel != channel || pointer.GenerationID == "" ||
 		!pointer.ConsumerCompatibility.SupportsSchema(c.schemaVersion) ||
 		pointer.SchemaVersion < pointer.ConsumerCompatibility.MinSchemaVersion ||
 		pointer.SchemaVersion > pointer.ConsumerCompatibility.MaxSchemaVersion {
-		return catalogstore.Generation{}, LatestPointer{}, &errors.ValidationError{Field: "catalog_distribution.latest", Value: pointer.GenerationID, Message: "is incompatible or malformed"}
+		return PublishedGeneration{}, LatestPointer{}, &errors.ValidationError{Field: "catalog_distribution.latest", Value: pointer.GenerationID, Message: "is incompatible or malformed"}
 	}
 	artifactURL, err := c.assetURL(pointer.Artifact.URL)
 	if err != nil {
-		return catalogstore.Generation{}, LatestPointer{}, err
+		return PublishedGeneration{}, LatestPointer{}, err
 	}
 	attestationURL, err := c.assetURL(pointer.Attestation.URL)
 	if err != nil {
-		return catalogstore.Generation{}, LatestPointer{}, err
+		return PublishedGeneration{}, LatestPointer{}, err
 	}
 	artifact, mediaType, err := c.fetch(ctx, artifactURL, pointer.Artifact.MediaType)
 	if err != nil {
-		return catalogstore.Generation{}, LatestPointer{}, err
+		return PublishedGeneration{}, LatestPointer{}, err
 	}
 	if err := verifyAsset(pointer.Artifact, artifact, mediaType); err != nil {
-		return catalogstore.Generation{}, LatestPointer{}, err
+		return PublishedGeneration{}, LatestPointer{}, err
 	}
 	attestation, mediaType, err := c.fetch(ctx, attestationURL, pointer.Attestation.MediaType)
 	if err != nil {
-		return catalogstore.Generation{}, LatestPointer{}, err
+		return PublishedGeneration{}, LatestPointer{}, err
 	}
 	if err := verifyAsset(pointer.Attestation, attestation, mediaType); err != nil {
-		return catalogstore.Generation{}, LatestPointer{}, err
+		return PublishedGeneration{}, LatestPointer{}, err
 	}
 	generation, err := catalogartifact.Open(artifact, attestation)
 	if err != nil {
-		return catalogstore.Generation{}, LatestPointer{}, err
+		return PublishedGeneration{}, LatestPointer{}, err
 	}
 	manifest := generation.Manifest
 	if manifest.GenerationID != pointer.GenerationID || manifest.SchemaVersion != pointer.SchemaVersion ||
 		manifest.ConsumerCompatibility != pointer.ConsumerCompatibility {
-		return catalogstore.Generation{}, LatestPointer{}, &errors.ValidationError{Field: "catalog_distribution.latest", Value: pointer.GenerationID, Message: "does not match downloaded generation"}
-	}
-	return generation, pointer, nil
+		return PublishedGeneration{}, LatestPointer{}, &errors.ValidationError{Field: "catalog_distribution.latest", Value: pointer.GenerationID, Message: "does not match downloaded generation"}
+	}
+	return PublishedGeneration{
+		Generation: generation,
+		Artifact: catalogartifact.Artifact{
+			GenerationID: manifest.GenerationID, Filename: catalogartifact.Filename, MediaType: pointer.Artifact.MediaType,
+			Data: artifact, Checksum: pointer.Artifact.Checksum,
+			AttestationFilename: catalogartifact.AttestationFilename, Attestation: attestation,
+		},
+	}, pointer, nil
 }
 
 func (c *Client) assetURL(reference string) (*url.URL, error) {
@@ -77,6 +77,14 @@ func TestHostedDistributionVerifiedLatestPointerReturnsExactGeneration(t *testin
 		got.Manifest.Payload != published.Generation.Manifest.Payload || string(got.Payload) != string(published.Generation.Payload) {
 		t.Fatalf("hosted generation mismatch: %#v", got.Manifest)
 	}
+	fetched, err := client.FetchChannelArtifact(context.Background(), ChannelStable)
+	if err != nil {
+		t.Fatalf("FetchChannelArtifact: %v", err)
+	}
+	if fetched.Artifact.Filename != published.Artifact.Filename || fetched.Artifact.Checksum != published.Artifact.Checksum ||
+		string(fetched.Artifact.Data) != string(published.Artifact.Data) || string(fetched.Artifact.Attestation) != string(published.Artifact.Attestation) {
+		t.Fatalf("fetched artifact %s does not match the published archive %s", fetched.Artifact.Checksum, published.Artifact.Checksum)
+	}
 }
 
 func TestHostedDistributionRejectsCrossOriginLatestAsset(t *testing.T) {
@@ -230,16 +230,16 @@ func (c *Client) ProbeChannel(ctx context.Context, channel Channel, policy Promo
 		return probe
 	}
 	started := time.Now()
-	generation, pointer, err := c.fetchChannel(ctx, channel)
+	published, pointer, err := c.fetchChannel(ctx, channel)
 	probe.Latency = time.Since(started)
 	if err != nil {
 		probe.Failure = err.Error()
 		return probe
 	}
 	probe.Available = true
-	probe.GenerationID = generation.Manifest.GenerationID
+	probe.GenerationID = published.Generation.Manifest.GenerationID
 	probe.ArtifactChecksum = pointer.Artifact.Checksum
-	age := observedAt.Sub(generation.Manifest.GeneratedAt)
+	age := observedAt.Sub(published.Generation.Manifest.GeneratedAt)
 	probe.Fresh = age >= 0 && age <= policy.MaxGenerationAge
 	if !probe.Fresh {
 		probe.Failure = fmt.Sprintf("generation age %s exceeds freshness policy %s or is in the future", age, policy.MaxGenerationAge)
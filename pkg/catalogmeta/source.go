@@ -24,6 +24,12 @@ const (
 
 	// LocalCatalogID identifies the local filesystem catalog source.
 	LocalCatalogID SourceID = "local_catalog"
+
+	// RemoteCatalogID identifies another Starmap server federated as a source.
+	RemoteCatalogID SourceID = "remote_catalog"
+
+	// BenchmarksID identifies a published benchmark and leaderboard dataset.
+	BenchmarksID SourceID = "benchmarks"
 )
 
 // SourceIDs returns all available source identifiers.
@@ -34,6 +40,8 @@ func SourceIDs() []SourceID {
 		ModelsDevGitID,
 		ModelsDevHTTPID,
 		LocalCatalogID,
+		RemoteCatalogID,
+		BenchmarksID,
 	}
 }
 
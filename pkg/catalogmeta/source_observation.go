@@ -83,6 +83,8 @@ const (
 	ObservationIssueCodeConfiguration ObservationIssueCode = "configuration"
 	// ObservationIssueCodeFetchFailed means upstream acquisition failed.
 	ObservationIssueCodeFetchFailed ObservationIssueCode = "fetch_failed"
+	// ObservationIssueCodePartialListing means a paginated listing stopped before its last page.
+	ObservationIssueCodePartialListing ObservationIssueCode = "partial_listing"
 	// ObservationIssueCodeStaleFallback means last-known-good stale evidence was used.
 	ObservationIssueCodeStaleFallback ObservationIssueCode = "stale_fallback"
 	// ObservationIssueCodeBootstrapFallback means embedded bootstrap evidence was used.
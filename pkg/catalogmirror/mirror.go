@@ -0,0 +1,285 @@
+// Package catalogmirror defines the self-contained mirror bundle used to carry
+// a Starmap catalog, its static assets, and its API schema into air-gapped
+// networks.
+//
+// A mirror is a deterministic tar.gz holding a mirror.json index, the verified
+// catalog artifact and its detached attestation under catalog/, provider and
+// author logos under assets/, and the API schema under schema/. Every member
+// is bound to exact bytes by the index, and the embedded catalog artifact is
+// verified with catalogartifact.Open when the mirror is opened.
+package catalogmirror
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogartifact"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const (
+	// FormatVersion is the current mirror bundle format.
+	FormatVersion uint64 = 1
+	// MediaType is the media type of the compressed mirror bundle.
+	MediaType = "application/vnd.agentstation.starmap.mirror.v1+tar+gzip"
+	// Filename is the stable mirror bundle filename.
+	Filename = "starmap-mirror.tar.gz"
+	// IndexFilename is the bundle member that binds every other member.
+	IndexFilename = "mirror.json"
+	// CatalogDir holds the catalog artifact and its attestation.
+	CatalogDir = "catalog/"
+	// AssetsDir holds static catalog assets such as logos.
+	AssetsDir = "assets/"
+	// SchemaDir holds the API schema.
+	SchemaDir = "schema/"
+
+	maxMirrorBytes = 256 << 20
+	fileMode       = 0o644
+)
+
+// File is one asset or schema member of a mirror.
+type File struct {
+	Name      string
+	MediaType string
+	Data      []byte
+}
+
+// Index describes the complete contents of a mirror bundle.
+type Index struct {
+	FormatVersion uint64                           `json:"format_version"`
+	MediaType     string                           `json:"media_type"`
+	GenerationID  string                           `json:"generation_id"`
+	SchemaVersion uint64                           `json:"schema_version"`
+	Files         []catalogartifact.FileDescriptor `json:"files"`
+}
+
+// Mirror is an opened and fully verified mirror bundle.
+type Mirror struct {
+	Index      Index
+	Generation catalogstore.Generation
+	Files      []File
+}
+
+// File returns the asset or schema member with the given name.
+func (m Mirror) File(name string) (File, bool) {
+	for _, file := range m.Files {
+		if file.Name == name {
+			return file, true
+		}
+	}
+	return File{}, false
+}
+
+// Build packages a catalog artifact with assets and schema files into a
+// deterministic mirror bundle. Asset names must live under AssetsDir and
+// schema names under SchemaDir.
+func Build(artifact catalogartifact.Artifact, files ...File) ([]byte, error) {
+	generation, err := catalogartifact.Open(artifact.Data, artifact.Attestation)
+	if err != nil {
+		return nil, errors.WrapResource("verify", "mirror catalog artifact", artifact.GenerationID, err)
+	}
+	members := []File{
+		{Name: catalogMember(catalogartifact.Filename), MediaType: catalogartifact.MediaType, Data: artifact.Data},
+		{Name: catalogMember(catalogartifact.AttestationFilename), MediaType: "application/json", Data: artifact.Attestation},
+	}
+	seen := make(map[string]bool, len(files))
+	for _, file := range files {
+		if err := validateFileName(file.Name); err != nil {
+			return nil, err
+		}
+		if seen[file.Name] {
+			return nil, mirrorValidation("files", file.Name, "is duplicated")
+		}
+		seen[file.Name] = true
+		members = append(members, file)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+
+	index := Index{
+		FormatVersion: FormatVersion,
+		MediaType:     MediaType,
+		GenerationID:  generation.Manifest.GenerationID,
+		SchemaVersion: generation.Manifest.SchemaVersion,
+		Files:         make([]catalogartifact.FileDescriptor, 0, len(members)),
+	}
+	for _, member := range members {
+		index.Files = append(index.Files, describeFile(member))
+	}
+	indexData, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, mirrorValidation("index", index.GenerationID, err.Error())
+	}
+	return encodeArchive(append([]File{{Name: IndexFilename, Data: indexData}}, members...))
+}
+
+// Open verifies a mirror bundle, including every member checksum and the
+// embedded catalog artifact attestation, and returns its contents.
+func Open(data []byte) (Mirror, error) {
+	if len(data) > maxMirrorBytes {
+		return Mirror{}, mirrorValidation("archive", len(data), "exceeds maximum mirror size")
+	}
+	members, err := decodeArchive(data)
+	if err != nil {
+		return Mirror{}, err
+	}
+	indexData, ok := members[IndexFilename]
+	if !ok {
+		return Mirror{}, mirrorValidation("archive", IndexFilename, "required member is missing")
+	}
+	var index Index
+	decoder := json.NewDecoder(bytes.NewReader(indexData))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&index); err != nil {
+		return Mirror{}, &errors.ParseError{Format: "json", File: IndexFilename, Message: err.Error(), Err: err}
+	}
+	if index.FormatVersion != FormatVersion || index.MediaType != MediaType {
+		return Mirror{}, mirrorValidation("index.format", index.FormatVersion, "is not supported")
+	}
+	if len(members) != len(index.Files)+1 {
+		return Mirror{}, mirrorValidation("archive", len(members), "member count does not match index")
+	}
+
+	mirror := Mirror{Index: index}
+	for _, descriptor := range index.Files {
+		member, ok := members[descriptor.Name]
+		if !ok {
+			return Mirror{}, mirrorValidation("archive", descriptor.Name, "indexed member is missing")
+		}
+		if describeFile(File{Name: descriptor.Name, MediaType: descriptor.MediaType, Data: member}) != descriptor {
+			return Mirror{}, mirrorValidation("archive.member", descriptor.Name, "does not match indexed checksum")
+		}
+		if strings.HasPrefix(descriptor.Name, CatalogDir) {
+			continue
+		}
+		if err := validateFileName(descriptor.Name); err != nil {
+			return Mirror{}, err
+		}
+		mirror.Files = append(mirror.Files, File{Name: descriptor.Name, MediaType: descriptor.MediaType, Data: member})
+	}
+
+	archive, ok := members[catalogMember(catalogartifact.Filename)]
+	if !ok {
+		return Mirror{}, mirrorValidation("archive", catalogMember(catalogartifact.Filename), "required member is missing")
+	}
+	attestation, ok := members[catalogMember(catalogartifact.AttestationFilename)]
+	if !ok {
+		return Mirror{}, mirrorValidation("archive", catalogMember(catalogartifact.AttestationFilename), "required member is missing")
+	}
+	generation, err := catalogartifact.Open(archive, attestation)
+	if err != nil {
+		return Mirror{}, errors.WrapResource("verify", "mirror catalog artifact", index.GenerationID, err)
+	}
+	if generation.Manifest.GenerationID != index.GenerationID || generation.Manifest.SchemaVersion != index.SchemaVersion {
+		return Mirror{}, mirrorValidation("index.generation", index.GenerationID, "does not match catalog artifact")
+	}
+	mirror.Generation = generation
+	return mirror, nil
+}
+
+func catalogMember(name string) string {
+	return CatalogDir + name
+}
+
+func validateFileName(name string) error {
+	if name == "" || path.Clean(name) != name || path.IsAbs(name) || strings.HasPrefix(name, "../") {
+		return mirrorValidation("files", name, "must be a clean relative path")
+	}
+	if !strings.HasPrefix(name, AssetsDir) && !strings.HasPrefix(name, SchemaDir) {
+		return mirrorValidation("files", name, "must be under "+AssetsDir+" or "+SchemaDir)
+	}
+	return nil
+}
+
+func describeFile(file File) catalogartifact.FileDescriptor {
+	digest := sha256.Sum256(file.Data)
+	return catalogartifact.FileDescriptor{
+		Name:      file.Name,
+		MediaType: file.MediaType,
+		Checksum:  "sha256:" + hex.EncodeToString(digest[:]),
+		SizeBytes: int64(len(file.Data)),
+	}
+}
+
+func encodeArchive(members []File) ([]byte, error) {
+	var output bytes.Buffer
+	gzipWriter, err := gzip.NewWriterLevel(&output, gzip.BestCompression)
+	if err != nil {
+		return nil, mirrorValidation("archive", nil, err.Error())
+	}
+	canonicalTime := time.Unix(0, 0).UTC()
+	gzipWriter.ModTime = canonicalTime
+	gzipWriter.OS = 255
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, member := range members {
+		header := &tar.Header{
+			Name: member.Name, Mode: fileMode, Size: int64(len(member.Data)),
+			ModTime: canonicalTime, Typeflag: tar.TypeReg, Format: tar.FormatUSTAR,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			_ = tarWriter.Close()
+			_ = gzipWriter.Close()
+			return nil, mirrorValidation("archive", member.Name, err.Error())
+		}
+		if _, err := tarWriter.Write(member.Data); err != nil {
+			_ = tarWriter.Close()
+			_ = gzipWriter.Close()
+			return nil, mirrorValidation("archive", member.Name, err.Error())
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		_ = gzipWriter.Close()
+		return nil, mirrorValidation("archive", nil, err.Error())
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, mirrorValidation("archive", nil, err.Error())
+	}
+	return output.Bytes(), nil
+}
+
+func decodeArchive(data []byte) (map[string][]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, &errors.ParseError{Format: "tar+gzip", File: Filename, Message: err.Error(), Err: err}
+	}
+	defer func() { _ = gzipReader.Close() }()
+	tarReader := tar.NewReader(io.LimitReader(gzipReader, maxMirrorBytes+1))
+	members := make(map[string][]byte)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, &errors.ParseError{Format: "tar", File: Filename, Message: err.Error(), Err: err}
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, mirrorValidation("archive.member", header.Name, "must be a regular file")
+		}
+		if _, exists := members[header.Name]; exists {
+			return nil, mirrorValidation("archive.member", header.Name, "is duplicated")
+		}
+		if header.Size < 0 || header.Size > maxMirrorBytes {
+			return nil, mirrorValidation("archive.member", header.Name, "exceeds maximum member size")
+		}
+		member, err := io.ReadAll(io.LimitReader(tarReader, maxMirrorBytes+1))
+		if err != nil {
+			return nil, &errors.ParseError{Format: "tar", File: header.Name, Message: err.Error(), Err: err}
+		}
+		members[header.Name] = member
+	}
+	return members, nil
+}
+
+func mirrorValidation(field string, value any, message string) error {
+	return &errors.ValidationError{Field: "catalog_mirror." + field, Value: value, Message: message}
+}
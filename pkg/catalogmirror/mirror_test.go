@@ -0,0 +1,139 @@
+package catalogmirror
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	stderrors "errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogartifact"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func TestMirrorRoundTripIsDeterministic(t *testing.T) {
+	artifact := mirrorFixtureArtifact(t)
+	files := []File{
+		{Name: "schema/openapi.json", MediaType: "application/json", Data: []byte(`{"openapi":"3.0.0"}`)},
+		{Name: "assets/providers/openai/logo.svg", MediaType: "image/svg+xml", Data: []byte("<svg/>")},
+	}
+	first, err := Build(artifact, files...)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	second, err := Build(artifact, files[1], files[0])
+	if err != nil {
+		t.Fatalf("Build reordered: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Fatal("identical mirror inputs produced different bytes")
+	}
+
+	mirror, err := Open(first)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if mirror.Generation.Manifest.GenerationID != artifact.GenerationID || mirror.Index.GenerationID != artifact.GenerationID {
+		t.Fatalf("mirror generation = %q, index = %q, want %q",
+			mirror.Generation.Manifest.GenerationID, mirror.Index.GenerationID, artifact.GenerationID)
+	}
+	logo, ok := mirror.File("assets/providers/openai/logo.svg")
+	if !ok || string(logo.Data) != "<svg/>" || logo.MediaType != "image/svg+xml" {
+		t.Fatalf("logo = %#v, %v", logo, ok)
+	}
+	if len(mirror.Files) != 2 || len(mirror.Index.Files) != 4 {
+		t.Fatalf("files = %d, indexed = %d; want 2 files and 4 indexed members", len(mirror.Files), len(mirror.Index.Files))
+	}
+}
+
+func TestMirrorRejectsInvalidFileNames(t *testing.T) {
+	artifact := mirrorFixtureArtifact(t)
+	for _, name := range []string{"", "logo.svg", "assets/../catalog/x", "/assets/logo.svg", "catalog/extra.json"} {
+		_, err := Build(artifact, File{Name: name, Data: []byte("x")})
+		var validationErr *pkgerrors.ValidationError
+		if !stderrors.As(err, &validationErr) {
+			t.Fatalf("Build(%q) error = %T: %v, want *errors.ValidationError", name, err, err)
+		}
+	}
+	_, err := Build(artifact, File{Name: "assets/a.svg"}, File{Name: "assets/a.svg"})
+	if err == nil {
+		t.Fatal("Build accepted a duplicated file name")
+	}
+}
+
+func TestMirrorRejectsTamperedMember(t *testing.T) {
+	artifact := mirrorFixtureArtifact(t)
+	data, err := Build(artifact, File{Name: "schema/openapi.json", MediaType: "application/json", Data: []byte("{}")})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	members := readMembers(t, data)
+	members["schema/openapi.json"] = []byte(`{"tampered":true}`)
+	var tampered []File
+	for _, name := range []string{IndexFilename, "catalog/" + catalogartifact.AttestationFilename, "catalog/" + catalogartifact.Filename, "schema/openapi.json"} {
+		tampered = append(tampered, File{Name: name, Data: members[name]})
+	}
+	archive, err := encodeArchive(tampered)
+	if err != nil {
+		t.Fatalf("encodeArchive: %v", err)
+	}
+	var validationErr *pkgerrors.ValidationError
+	if _, err := Open(archive); !stderrors.As(err, &validationErr) {
+		t.Fatalf("Open tampered error = %T: %v, want *errors.ValidationError", err, err)
+	}
+}
+
+func readMembers(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	members := make(map[string][]byte)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return members
+		}
+		if err != nil {
+			t.Fatalf("tar: %v", err)
+		}
+		member, err := io.ReadAll(tarReader)
+		if err != nil {
+			t.Fatalf("read %s: %v", header.Name, err)
+		}
+		members[header.Name] = member
+	}
+}
+
+func mirrorFixtureArtifact(t *testing.T) catalogartifact.Artifact {
+	t.Helper()
+	manifestData, err := os.ReadFile("../catalogs/testdata/generation/manifest.json")
+	if err != nil {
+		t.Fatalf("Read manifest fixture: %v", err)
+	}
+	manifest, err := catalogs.ParseGenerationManifestJSON(manifestData)
+	if err != nil {
+		t.Fatalf("Parse manifest fixture: %v", err)
+	}
+	catalog, err := catalogs.NewEmpty().Build()
+	if err != nil {
+		t.Fatalf("Build empty catalog: %v", err)
+	}
+	payload, err := catalogstore.EncodeCatalogPayload(catalog)
+	if err != nil {
+		t.Fatalf("Encode canonical payload: %v", err)
+	}
+	manifest.GenerationID = "mirror-fixture-generation-v1"
+	manifest.Payload = catalogs.DescribeCatalogPayload(payload)
+	artifact, err := catalogartifact.Build(catalogstore.Generation{Manifest: manifest, Payload: payload})
+	if err != nil {
+		t.Fatalf("Build artifact: %v", err)
+	}
+	return artifact
+}
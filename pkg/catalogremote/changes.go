@@ -0,0 +1,231 @@
+package catalogremote
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/differ"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// ChangesPath returns the delta between a past generation and the current one.
+const ChangesPath = "/changes"
+
+// Changes is the delta between a past catalog generation and the current one.
+// Provenance and endpoints are not part of the delta.
+type Changes struct {
+	Since     string                  `json:"since"`   // Generation the client last synced
+	Current   string                  `json:"current"` // Generation the delta brings the client to
+	Summary   differ.ChangesetSummary `json:"summary"`
+	Models    ModelChanges            `json:"models"`
+	Providers ProviderChanges         `json:"providers"`
+	Authors   AuthorChanges           `json:"authors"`
+}
+
+// ModelChanges lists provider-scoped model changes.
+type ModelChanges struct {
+	Added   []ScopedModel `json:"added"`
+	Updated []ScopedModel `json:"updated"`
+	Removed []ScopedModel `json:"removed"`
+}
+
+// ScopedModel is a model as served by one provider. Removed entries carry the
+// last known model.
+type ScopedModel struct {
+	ProviderID catalogs.ProviderID `json:"provider_id"`
+	Model      catalogs.Model      `json:"model"`
+	Changes    []FieldChange       `json:"changes,omitempty"`
+}
+
+// ProviderChanges lists provider changes. Provider models are listed under
+// models.
+type ProviderChanges struct {
+	Added   []catalogs.Provider   `json:"added"`
+	Updated []catalogs.Provider   `json:"updated"`
+	Removed []catalogs.ProviderID `json:"removed"`
+}
+
+// AuthorChanges lists author changes.
+type AuthorChanges struct {
+	Added   []catalogs.Author   `json:"added"`
+	Updated []catalogs.Author   `json:"updated"`
+	Removed []catalogs.AuthorID `json:"removed"`
+}
+
+// FieldChange is one changed field of an updated resource.
+type FieldChange struct {
+	Path     string            `json:"path"`
+	Key      string            `json:"key,omitempty"`
+	Type     differ.ChangeType `json:"type"`
+	OldValue string            `json:"old_value,omitempty"`
+	NewValue string            `json:"new_value,omitempty"`
+}
+
+// NewChanges converts a catalog changeset into its wire form.
+func NewChanges(since, current string, changeset *differ.Changeset) Changes {
+	changes := Changes{
+		Since:   since,
+		Current: current,
+		Summary: changeset.Summary,
+		Models: ModelChanges{
+			Added:   []ScopedModel{},
+			Updated: []ScopedModel{},
+			Removed: []ScopedModel{},
+		},
+		Providers: ProviderChanges{
+			Added:   []catalogs.Provider{},
+			Updated: []catalogs.Provider{},
+			Removed: []catalogs.ProviderID{},
+		},
+		Authors: AuthorChanges{
+			Added:   []catalogs.Author{},
+			Updated: []catalogs.Author{},
+			Removed: []catalogs.AuthorID{},
+		},
+	}
+	if models := changeset.Models; models != nil {
+		for _, change := range models.AddedScoped {
+			changes.Models.Added = append(changes.Models.Added, ScopedModel{ProviderID: change.ProviderID, Model: change.Model})
+		}
+		for _, update := range models.Updated {
+			changes.Models.Updated = append(changes.Models.Updated, ScopedModel{
+				ProviderID: update.ProviderID,
+				Model:      update.New,
+				Changes:    NewFieldChanges(update.Changes),
+			})
+		}
+		for _, change := range models.RemovedScoped {
+			changes.Models.Removed = append(changes.Models.Removed, ScopedModel{ProviderID: change.ProviderID, Model: change.Model})
+		}
+	}
+	if providers := changeset.Providers; providers != nil {
+		changes.Providers.Added = append(changes.Providers.Added, providers.Added...)
+		for _, update := range providers.Updated {
+			changes.Providers.Updated = append(changes.Providers.Updated, update.New)
+		}
+		for _, provider := range providers.Removed {
+			changes.Providers.Removed = append(changes.Providers.Removed, provider.ID)
+		}
+	}
+	if authors := changeset.Authors; authors != nil {
+		changes.Authors.Added = append(changes.Authors.Added, authors.Added...)
+		for _, update := range authors.Updated {
+			changes.Authors.Updated = append(changes.Authors.Updated, update.New)
+		}
+		for _, author := range authors.Removed {
+			changes.Authors.Removed = append(changes.Authors.Removed, author.ID)
+		}
+	}
+	return changes
+}
+
+// NewFieldChanges returns the wire form of differ field changes.
+func NewFieldChanges(changes []differ.FieldChange) []FieldChange {
+	if len(changes) == 0 {
+		return nil
+	}
+	result := make([]FieldChange, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, FieldChange{
+			Path:     change.Path,
+			Key:      change.Key,
+			Type:     change.Type,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	return result
+}
+
+// Apply returns base with the delta applied. Providers and authors keep the
+// models base already holds for them; model changes are applied per provider.
+func (c Changes) Apply(base catalogs.Reader) (*catalogs.Catalog, error) {
+	builder, err := catalogs.NewBuilderFrom(base)
+	if err != nil {
+		return nil, err
+	}
+	for _, provider := range append(append([]catalogs.Provider{}, c.Providers.Added...), c.Providers.Updated...) {
+		if existing, err := builder.Provider(provider.ID); err == nil {
+			provider.Models = existing.Models
+		}
+		if err := builder.SetProvider(provider); err != nil {
+			return nil, errors.WrapResource("apply", "provider change", string(provider.ID), err)
+		}
+	}
+	for _, change := range c.Models.Removed {
+		if err := builder.DeleteProviderModel(change.ProviderID, change.Model.ID); err != nil && !errors.IsNotFound(err) {
+			return nil, errors.WrapResource("apply", "model removal", change.Model.ID, err)
+		}
+	}
+	for _, change := range append(append([]ScopedModel{}, c.Models.Added...), c.Models.Updated...) {
+		if err := builder.SetProviderModel(change.ProviderID, change.Model); err != nil {
+			return nil, errors.WrapResource("apply", "model change", change.Model.ID, err)
+		}
+	}
+	for _, id := range c.Providers.Removed {
+		if err := builder.DeleteProvider(id); err != nil && !errors.IsNotFound(err) {
+			return nil, errors.WrapResource("apply", "provider removal", string(id), err)
+		}
+	}
+	for _, author := range append(append([]catalogs.Author{}, c.Authors.Added...), c.Authors.Updated...) {
+		if existing, err := builder.Author(author.ID); err == nil {
+			author.Models = existing.Models
+		}
+		if err := builder.SetAuthor(author); err != nil {
+			return nil, errors.WrapResource("apply", "author change", string(author.ID), err)
+		}
+	}
+	for _, id := range c.Authors.Removed {
+		if err := builder.DeleteAuthor(id); err != nil && !errors.IsNotFound(err) {
+			return nil, errors.WrapResource("apply", "author removal", string(id), err)
+		}
+	}
+	return builder.Build()
+}
+
+// FetchChanges fetches the delta from generation since to the current
+// generation. Servers that no longer keep since answer with a 404 API error;
+// callers should then fall back to FetchCurrent.
+func (c *Client) FetchChanges(ctx context.Context, since string) (Changes, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	target := *c.baseURL
+	target.Path = path.Join(strings.TrimSuffix(c.baseURL.Path, "/"), ChangesPath)
+	target.RawQuery = url.Values{"since": {since}}.Encode()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return Changes{}, errors.WrapResource("create", "remote catalog request", target.String(), err)
+	}
+	request.Header.Set("Accept", "application/json")
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return Changes{}, &errors.APIError{Provider: "starmap-server", Endpoint: target.String(), Message: "request failed", Err: err}
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+		return Changes{}, &errors.APIError{Provider: "starmap-server", Endpoint: target.String(), StatusCode: response.StatusCode, Message: "unexpected response status"}
+	}
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return Changes{}, &errors.ValidationError{Field: "catalog_remote.content_type", Value: response.Header.Get("Content-Type"), Message: "does not match application/json"}
+	}
+	var body struct {
+		Data *Changes `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(response.Body, maxBodyBytes)).Decode(&body); err != nil {
+		return Changes{}, errors.WrapParse("json", target.String(), err)
+	}
+	if body.Data == nil || body.Data.Since != since || body.Data.Current == "" {
+		return Changes{}, &errors.ValidationError{Field: "catalog_remote.changes", Value: since, Message: "response does not describe the requested generation"}
+	}
+	return *body.Data, nil
+}
@@ -0,0 +1,63 @@
+package catalogremote
+
+import (
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func TestChangesApplyKeepsProviderModelsAndAppliesRemovals(t *testing.T) {
+	builder := catalogs.NewEmpty()
+	for _, provider := range []catalogs.Provider{
+		{ID: "acme", Name: "Acme", Models: map[string]*catalogs.Model{
+			"kept":    {ID: "kept", Name: "Kept"},
+			"retired": {ID: "retired", Name: "Retired"},
+		}},
+		{ID: "gone", Name: "Gone"},
+	} {
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider: %v", err)
+		}
+	}
+	base, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	changes := Changes{
+		Since:   "before",
+		Current: "after",
+		Models: ModelChanges{
+			Added:   []ScopedModel{{ProviderID: "acme", Model: catalogs.Model{ID: "fresh", Name: "Fresh"}}},
+			Removed: []ScopedModel{{ProviderID: "acme", Model: catalogs.Model{ID: "retired"}}},
+		},
+		Providers: ProviderChanges{
+			Updated: []catalogs.Provider{{ID: "acme", Name: "Acme Inc"}},
+			Removed: []catalogs.ProviderID{"gone"},
+		},
+	}
+	applied, err := changes.Apply(base)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	provider, err := applied.Provider("acme")
+	if err != nil || provider.Name != "Acme Inc" {
+		t.Fatalf("provider = %#v, %v", provider, err)
+	}
+	if _, err := applied.ProviderModel("acme", "kept"); err != nil {
+		t.Fatalf("provider update dropped existing model: %v", err)
+	}
+	if _, err := applied.ProviderModel("acme", "fresh"); err != nil {
+		t.Fatalf("added model: %v", err)
+	}
+	if _, err := applied.ProviderModel("acme", "retired"); err == nil {
+		t.Fatal("removed model still present")
+	}
+	if _, err := applied.Provider("gone"); err == nil {
+		t.Fatal("removed provider still present")
+	}
+	if _, err := base.ProviderModel("acme", "retired"); err != nil {
+		t.Fatalf("Apply mutated base: %v", err)
+	}
+}
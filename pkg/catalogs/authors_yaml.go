@@ -40,13 +40,9 @@ func formatAuthorsYAML(authors []Author) (string, error) {
 	// Create comment map for proper headers
 	commentMap := yaml.CommentMap{}
 
-	// Add header comment using root path
-	commentMap["$"] = []*yaml.Comment{
-		yaml.HeadComment(" Known model authors and organizations with their metadata and social links"),
-		yaml.HeadComment(" This file contains the complete author information that can be loaded at runtime"),
-	}
-
-	// Add comments above each author entry using their name
+	// Add comments above each author entry using their name. There is no
+	// file header: a root comment competes with the first author's for the
+	// same line and the encoder keeps either one at random.
 	for i, author := range authors {
 		path := fmt.Sprintf("$[%d]", i)
 		commentMap[path] = []*yaml.Comment{
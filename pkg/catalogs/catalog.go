@@ -37,6 +37,7 @@ import (
 	"io/fs"
 	"maps"
 	"os"
+	"slices"
 
 	"github.com/agentstation/starmap/pkg/errors"
 	"github.com/agentstation/starmap/pkg/provenance"
@@ -75,6 +76,7 @@ type Builder struct {
 	authors    *Authors
 	endpoints  *Endpoints
 	provenance *Provenance
+	pins       []FieldPin // Pins declared in PinsFile
 }
 
 // New creates a new builder with the given options
@@ -101,9 +103,17 @@ func New(opt Option, opts ...Option) (*Builder, error) {
 
 // NewEmbedded creates a catalog backed by embedded files.
 // This is the recommended catalog for production use as it includes
-// all model data compiled into the binary.
+// all model data compiled into the binary, along with the providers and
+// authors added with RegisterProvider and RegisterAuthor.
 func NewEmbedded() (*Builder, error) {
-	return New(WithEmbedded())
+	cat, err := New(WithEmbedded())
+	if err != nil {
+		return nil, err
+	}
+	if err := cat.addRegistered(); err != nil {
+		return nil, err
+	}
+	return cat, nil
 }
 
 // NewFromPath creates a catalog backed by files on disk.
@@ -157,6 +167,7 @@ func NewLocal(path string) (*Builder, error) {
 	if err := embedded.MergeWith(fileCatalog); err != nil {
 		return nil, errors.WrapResource("merge", "catalogs", "", err)
 	}
+	embedded.pins = append(embedded.pins, fileCatalog.pins...)
 
 	return embedded, nil
 }
@@ -583,6 +594,7 @@ func (cat *Builder) Copy() (*Builder, error) {
 		endpoints:  NewEndpoints(),
 		provenance: NewProvenance(),
 		config:     cat.config.copy(),
+		pins:       slices.Clone(cat.pins),
 	}
 
 	// Copy all data
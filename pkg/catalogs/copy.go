@@ -1,6 +1,10 @@
 package catalogs
 
-import "maps"
+import (
+	"maps"
+	"slices"
+	"time"
+)
 
 func copyPtr[T any](value *T) *T {
 	if value == nil {
@@ -70,11 +74,23 @@ func DeepCopyModel(model Model) Model {
 	modelCopy.ReasoningTokens = copyPtr(model.ReasoningTokens)
 	modelCopy.Verbosity = deepCopyModelControlLevels(model.Verbosity)
 	modelCopy.Tools = deepCopyModelTools(model.Tools)
+	modelCopy.Vision = deepCopyModelVision(model.Vision)
+	modelCopy.Documents = deepCopyModelDocumentInput(model.Documents)
+	modelCopy.Caching = deepCopyPromptCaching(model.Caching)
 	modelCopy.Delivery = deepCopyModelDelivery(model.Delivery)
+	modelCopy.Performance = deepCopyModelPerformance(model.Performance)
+	modelCopy.Embedding = deepCopyModelEmbedding(model.Embedding)
+	modelCopy.UsageRestrictions = deepCopyUsageRestrictions(model.UsageRestrictions)
+	modelCopy.Sustainability = deepCopyModelSustainability(model.Sustainability)
+	modelCopy.Evaluations = deepCopyModelEvaluations(model.Evaluations)
+	modelCopy.Curation = deepCopyModelCuration(model.Curation)
+	modelCopy.Review = deepCopyModelReview(model.Review)
 	modelCopy.Modes = deepCopyModelModes(model.Modes)
 	modelCopy.Pricing = deepCopyModelPricing(model.Pricing)
 	modelCopy.Limits = copyPtr(model.Limits)
 	modelCopy.Extensions = model.Extensions.Copy()
+	modelCopy.Private = slices.Clone(model.Private)
+	modelCopy.Pins = slices.Clone(model.Pins)
 	return modelCopy
 }
 
@@ -113,9 +129,16 @@ func DeepCopyProvider(provider Provider) Provider {
 	providerCopy.Models = DeepCopyProviderModels(provider.Models)
 	providerCopy.StatusPageURL = copyPtr(provider.StatusPageURL)
 	providerCopy.ChatCompletions = deepCopyProviderChatCompletions(provider.ChatCompletions)
+	providerCopy.SLA = deepCopyProviderSLA(provider.SLA)
+	providerCopy.Support = deepCopyProviderSupport(provider.Support)
+	providerCopy.PromptCaching = deepCopyPromptCaching(provider.PromptCaching)
+	providerCopy.Vision = deepCopyModelVision(provider.Vision)
+	providerCopy.Documents = deepCopyModelDocumentInput(provider.Documents)
 	providerCopy.PrivacyPolicy = deepCopyProviderPrivacyPolicy(provider.PrivacyPolicy)
 	providerCopy.RetentionPolicy = deepCopyProviderRetentionPolicy(provider.RetentionPolicy)
 	providerCopy.GovernancePolicy = deepCopyProviderGovernancePolicy(provider.GovernancePolicy)
+	providerCopy.UsageRestrictions = deepCopyUsageRestrictions(provider.UsageRestrictions)
+	providerCopy.Sustainability = deepCopyProviderSustainability(provider.Sustainability)
 	providerCopy.Extensions = provider.Extensions.Copy()
 	providerCopy.EnvVarValues = copyMap(provider.EnvVarValues)
 	return providerCopy
@@ -246,10 +269,122 @@ func deepCopyModelTools(tools *ModelTools) *ModelTools {
 	}
 	copied := *tools
 	copied.ToolChoices = append([]ToolChoice(nil), tools.ToolChoices...)
+	copied.Dialects = append([]ToolDialect(nil), tools.Dialects...)
+	copied.ParallelCalls = copyPtr(tools.ParallelCalls)
+	copied.StreamingDeltas = copyPtr(tools.StreamingDeltas)
 	copied.WebSearch = deepCopyModelWebSearch(tools.WebSearch)
 	return &copied
 }
 
+func deepCopyPromptCaching(caching *PromptCaching) *PromptCaching {
+	if caching == nil {
+		return nil
+	}
+	copied := *caching
+	copied.Modes = append([]PromptCachingMode(nil), caching.Modes...)
+	copied.TTLs = append([]time.Duration(nil), caching.TTLs...)
+	copied.DefaultTTL = copyPtr(caching.DefaultTTL)
+	return &copied
+}
+
+func deepCopyModelVision(vision *ModelVision) *ModelVision {
+	if vision == nil {
+		return nil
+	}
+	copied := *vision
+	copied.MaxImages = copyPtr(vision.MaxImages)
+	copied.MaxImageSize = copyPtr(vision.MaxImageSize)
+	copied.Formats = append([]string(nil), vision.Formats...)
+	copied.TokenCost = copyPtr(vision.TokenCost)
+	return &copied
+}
+
+func deepCopyModelDocumentInput(documents *ModelDocumentInput) *ModelDocumentInput {
+	if documents == nil {
+		return nil
+	}
+	copied := *documents
+	copied.MaxPages = copyPtr(documents.MaxPages)
+	copied.MaxFileSize = copyPtr(documents.MaxFileSize)
+	copied.Formats = append([]string(nil), documents.Formats...)
+	return &copied
+}
+
+func deepCopyUsageRestrictions(restrictions *UsageRestrictions) *UsageRestrictions {
+	if restrictions == nil {
+		return nil
+	}
+	copied := *restrictions
+	copied.Restricted = append([]UsageRestriction(nil), restrictions.Restricted...)
+	copied.BlockedRegions = append([]string(nil), restrictions.BlockedRegions...)
+	copied.PolicyURL = copyPtr(restrictions.PolicyURL)
+	return &copied
+}
+
+func deepCopyModelSustainability(sustainability *ModelSustainability) *ModelSustainability {
+	if sustainability == nil {
+		return nil
+	}
+	copied := *sustainability
+	copied.SourceURL = copyPtr(sustainability.SourceURL)
+	copied.EstimatedAt = copyPtr(sustainability.EstimatedAt)
+	return &copied
+}
+
+func deepCopyModelEvaluations(evaluations *ModelEvaluations) *ModelEvaluations {
+	if evaluations == nil {
+		return nil
+	}
+	copied := *evaluations
+	copied.MMLU = copyPtr(evaluations.MMLU)
+	copied.ArenaElo = copyPtr(evaluations.ArenaElo)
+	copied.HumanEval = copyPtr(evaluations.HumanEval)
+	copied.SourceURL = copyPtr(evaluations.SourceURL)
+	copied.UpdatedAt = copyPtr(evaluations.UpdatedAt)
+	return &copied
+}
+
+func deepCopyProviderSustainability(sustainability *ProviderSustainability) *ProviderSustainability {
+	if sustainability == nil {
+		return nil
+	}
+	copied := *sustainability
+	copied.CarbonNeutral = copyPtr(sustainability.CarbonNeutral)
+	copied.RenewableEnergyShare = copyPtr(sustainability.RenewableEnergyShare)
+	copied.CarbonIntensity = copyPtr(sustainability.CarbonIntensity)
+	copied.Claim = copyPtr(sustainability.Claim)
+	copied.ClaimURL = copyPtr(sustainability.ClaimURL)
+	return &copied
+}
+
+func deepCopyModelEmbedding(embedding *ModelEmbedding) *ModelEmbedding {
+	if embedding == nil {
+		return nil
+	}
+	copied := *embedding
+	copied.SupportedDimensions = append([]int(nil), embedding.SupportedDimensions...)
+	copied.Types = append([]string(nil), embedding.Types...)
+	return &copied
+}
+
+func deepCopyModelPerformance(performance *ModelPerformance) *ModelPerformance {
+	if performance == nil {
+		return nil
+	}
+	copied := *performance
+	if performance.TimeToFirstToken != nil {
+		ttft := *performance.TimeToFirstToken
+		ttft.P50 = copyPtr(ttft.P50)
+		ttft.P90 = copyPtr(ttft.P90)
+		ttft.P95 = copyPtr(ttft.P95)
+		ttft.P99 = copyPtr(ttft.P99)
+		copied.TimeToFirstToken = &ttft
+	}
+	copied.SourceURL = copyPtr(performance.SourceURL)
+	copied.MeasuredAt = copyPtr(performance.MeasuredAt)
+	return &copied
+}
+
 func deepCopyModelWebSearch(search *ModelWebSearch) *ModelWebSearch {
 	if search == nil {
 		return nil
@@ -270,6 +405,19 @@ func deepCopyModelDelivery(delivery *ModelDelivery) *ModelDelivery {
 	copied.Protocols = append([]ModelResponseProtocol(nil), delivery.Protocols...)
 	copied.Streaming = append([]ModelStreaming(nil), delivery.Streaming...)
 	copied.Formats = append([]ModelResponseFormat(nil), delivery.Formats...)
+	copied.Realtime = deepCopyModelRealtime(delivery.Realtime)
+	return &copied
+}
+
+func deepCopyModelRealtime(realtime *ModelRealtime) *ModelRealtime {
+	if realtime == nil {
+		return nil
+	}
+	copied := *realtime
+	copied.Transports = append([]ModelResponseProtocol(nil), realtime.Transports...)
+	copied.InputAudioFormats = append([]RealtimeAudioFormat(nil), realtime.InputAudioFormats...)
+	copied.OutputAudioFormats = append([]RealtimeAudioFormat(nil), realtime.OutputAudioFormats...)
+	copied.MaxSessionDuration = copyPtr(realtime.MaxSessionDuration)
 	return &copied
 }
 
@@ -306,6 +454,8 @@ func deepCopyModelPricing(pricing *ModelPricing) *ModelPricing {
 	copied.EffectiveUntil = copyPtr(pricing.EffectiveUntil)
 	copied.Tokens = deepCopyModelTokenPricing(pricing.Tokens)
 	copied.Operations = deepCopyModelOperationPricing(pricing.Operations)
+	copied.Realtime = deepCopyModelRealtimePricing(pricing.Realtime)
+	copied.Compute = deepCopyModelComputePricing(pricing.Compute)
 	copied.Tiers = deepCopyModelPricingTiers(pricing.Tiers)
 	return &copied
 }
@@ -337,6 +487,27 @@ func deepCopyModelTokenPricing(pricing *ModelTokenPricing) *ModelTokenPricing {
 	return &copied
 }
 
+func deepCopyModelRealtimePricing(pricing *ModelRealtimePricing) *ModelRealtimePricing {
+	if pricing == nil {
+		return nil
+	}
+	copied := *pricing
+	copied.AudioInput = copyPtr(pricing.AudioInput)
+	copied.AudioOutput = copyPtr(pricing.AudioOutput)
+	copied.AudioCacheRead = copyPtr(pricing.AudioCacheRead)
+	copied.SessionMinute = copyPtr(pricing.SessionMinute)
+	return &copied
+}
+
+func deepCopyModelComputePricing(pricing *ModelComputePricing) *ModelComputePricing {
+	if pricing == nil {
+		return nil
+	}
+	copied := *pricing
+	copied.Rates = slices.Clone(pricing.Rates)
+	return &copied
+}
+
 func deepCopyModelTokenCachePricing(pricing *ModelTokenCachePricing) *ModelTokenCachePricing {
 	if pricing == nil {
 		return nil
@@ -354,6 +525,7 @@ func deepCopyModelOperationPricing(pricing *ModelOperationPricing) *ModelOperati
 	copied := *pricing
 	copied.Request = copyPtr(pricing.Request)
 	copied.ImageInput = copyPtr(pricing.ImageInput)
+	copied.DocumentPage = copyPtr(pricing.DocumentPage)
 	copied.AudioInput = copyPtr(pricing.AudioInput)
 	copied.VideoInput = copyPtr(pricing.VideoInput)
 	copied.ImageGen = copyPtr(pricing.ImageGen)
@@ -374,10 +546,23 @@ func deepCopyProviderCatalog(catalog *ProviderCatalog) *ProviderCatalog {
 	copied.Endpoint.FieldMappings = append([]FieldMapping(nil), catalog.Endpoint.FieldMappings...)
 	copied.Endpoint.FeatureRules = deepCopyFeatureRules(catalog.Endpoint.FeatureRules)
 	copied.Endpoint.AuthorMapping = deepCopyAuthorMapping(catalog.Endpoint.AuthorMapping)
+	copied.Endpoint.Requests = deepCopyProviderRequestPolicy(catalog.Endpoint.Requests)
 	copied.Authors = append([]AuthorID(nil), catalog.Authors...)
 	return &copied
 }
 
+func deepCopyProviderRequestPolicy(policy *ProviderRequestPolicy) *ProviderRequestPolicy {
+	if policy == nil {
+		return nil
+	}
+	copied := *policy
+	copied.MaxRetries = copyPtr(policy.MaxRetries)
+	copied.RetryDelay = copyPtr(policy.RetryDelay)
+	copied.FailureThreshold = copyPtr(policy.FailureThreshold)
+	copied.CircuitCooldown = copyPtr(policy.CircuitCooldown)
+	return &copied
+}
+
 func deepCopyFeatureRules(rules []FeatureRule) []FeatureRule {
 	if rules == nil {
 		return nil
@@ -410,6 +595,40 @@ func deepCopyProviderChatCompletions(chat *ProviderChatCompletions) *ProviderCha
 	return &copied
 }
 
+func deepCopyProviderSLA(sla *ProviderSLA) *ProviderSLA {
+	if sla == nil {
+		return nil
+	}
+	copied := *sla
+	copied.UptimeCommitment = copyPtr(sla.UptimeCommitment)
+	copied.ServiceCredits = copyPtr(sla.ServiceCredits)
+	copied.CreditsPolicy = copyPtr(sla.CreditsPolicy)
+	copied.EnterpriseTier = copyPtr(sla.EnterpriseTier)
+	copied.EnterpriseOnly = copyPtr(sla.EnterpriseOnly)
+	copied.URL = copyPtr(sla.URL)
+	return &copied
+}
+
+func deepCopyProviderSupport(support *ProviderSupport) *ProviderSupport {
+	if support == nil {
+		return nil
+	}
+	copied := *support
+	copied.Email = copyPtr(support.Email)
+	copied.URL = copyPtr(support.URL)
+	copied.Discord = copyPtr(support.Discord)
+	copied.Forum = copyPtr(support.Forum)
+	if support.Tiers != nil {
+		copied.Tiers = make([]ProviderSupportTier, len(support.Tiers))
+		for i, tier := range support.Tiers {
+			copied.Tiers[i] = tier
+			copied.Tiers[i].ResponseTime = copyPtr(tier.ResponseTime)
+			copied.Tiers[i].Contact = copyPtr(tier.Contact)
+		}
+	}
+	return &copied
+}
+
 func deepCopyProviderPrivacyPolicy(policy *ProviderPrivacyPolicy) *ProviderPrivacyPolicy {
 	if policy == nil {
 		return nil
@@ -195,6 +195,29 @@ func TestDeepCopyProvider(t *testing.T) {
 			t.Error("Provider fields should be copied")
 		}
 	})
+	t.Run("provider support and SLA", func(t *testing.T) {
+		uptime := 99.9
+		responseTime := time.Hour
+		original := Provider{
+			ID:  "test-provider",
+			SLA: &ProviderSLA{UptimeCommitment: &uptime},
+			Support: &ProviderSupport{
+				Email: stringPtr("support@example.com"),
+				Tiers: []ProviderSupportTier{{Name: "Premium", ResponseTime: &responseTime}},
+			},
+		}
+
+		copy := DeepCopyProvider(original)
+		*copy.SLA.UptimeCommitment = 95
+		*copy.Support.Email = "changed@example.com"
+		*copy.Support.Tiers[0].ResponseTime = time.Minute
+		copy.Support.Tiers[0].Name = "Changed"
+
+		if *original.SLA.UptimeCommitment != 99.9 || *original.Support.Email != "support@example.com" ||
+			*original.Support.Tiers[0].ResponseTime != time.Hour || original.Support.Tiers[0].Name != "Premium" {
+			t.Errorf("original mutated through copy: %#v %#v", original.SLA, original.Support)
+		}
+	})
 }
 
 func TestDeepCopyAuthor(t *testing.T) {
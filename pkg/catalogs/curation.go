@@ -0,0 +1,80 @@
+package catalogs
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Curation tags conventionally used for internal model governance. Any tag
+// accepted by NormalizeCurationTag may be used.
+const (
+	CurationTagApprovedForProd = "approved-for-prod"
+	CurationTagEvaluation      = "evaluation"
+	CurationTagBanned          = "banned"
+)
+
+const maxCurationTagLength = 64
+
+// ModelCuration holds user-managed governance labels for a model. No source
+// reports curation; it lives in the local catalog layer and survives syncs.
+type ModelCuration struct {
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"` // Sorted, lowercase curation tags
+}
+
+// NormalizeCurationTag lowercases tag and checks that it contains only
+// letters, digits, '-', '_', '.', or ':'.
+func NormalizeCurationTag(tag string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(tag))
+	if normalized == "" || len(normalized) > maxCurationTagLength {
+		return "", &errors.ValidationError{Field: "tag", Value: tag, Message: "must be 1-64 characters"}
+	}
+	for _, r := range normalized {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && !strings.ContainsRune("-_.:", r) {
+			return "", &errors.ValidationError{Field: "tag", Value: tag, Message: "may contain only letters, digits, '-', '_', '.', and ':'"}
+		}
+	}
+	return normalized, nil
+}
+
+// HasCurationTag reports whether the model carries tag.
+func (m *Model) HasCurationTag(tag string) bool {
+	if m == nil || m.Curation == nil {
+		return false
+	}
+	return slices.Contains(m.Curation.Tags, strings.ToLower(strings.TrimSpace(tag)))
+}
+
+// CurationTags returns the model's curation tags.
+func (m *Model) CurationTags() []string {
+	if m == nil || m.Curation == nil {
+		return nil
+	}
+	return m.Curation.Tags
+}
+
+// RetagCuration returns curation with add applied and remove removed, sorted
+// and de-duplicated. It returns nil when no tags remain. Tags must already be
+// normalized.
+func RetagCuration(curation *ModelCuration, add, remove []string) *ModelCuration {
+	var tags []string
+	if curation != nil {
+		tags = slices.Clone(curation.Tags)
+	}
+	tags = append(tags, add...)
+	tags = slices.DeleteFunc(tags, func(tag string) bool { return slices.Contains(remove, tag) })
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+	if len(tags) == 0 {
+		return nil
+	}
+	return &ModelCuration{Tags: tags}
+}
+
+func deepCopyModelCuration(curation *ModelCuration) *ModelCuration {
+	if curation == nil {
+		return nil
+	}
+	return &ModelCuration{Tags: append([]string(nil), curation.Tags...)}
+}
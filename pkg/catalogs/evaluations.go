@@ -0,0 +1,45 @@
+package catalogs
+
+import (
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/utc"
+)
+
+// ModelEvaluations records public benchmark and leaderboard scores of a
+// model. Scores are reported as published and are not reproduced by
+// starmap; compare them across models, not as absolute quality.
+type ModelEvaluations struct {
+	MMLU      *float64  `json:"mmlu,omitempty" yaml:"mmlu,omitempty"`             // MMLU accuracy, percent 0-100
+	ArenaElo  *float64  `json:"arena_elo,omitempty" yaml:"arena_elo,omitempty"`   // LMSYS Chatbot Arena Elo rating
+	HumanEval *float64  `json:"humaneval,omitempty" yaml:"humaneval,omitempty"`   // HumanEval pass@1, percent 0-100
+	SourceURL *string   `json:"source_url,omitempty" yaml:"source_url,omitempty"` // Where the scores are published
+	UpdatedAt *utc.Time `json:"updated_at,omitempty" yaml:"updated_at,omitempty"` // When the scores were published
+}
+
+// Validate checks that percentage scores are within 0-100 and that the
+// Arena Elo rating is positive.
+func (e *ModelEvaluations) Validate() error {
+	if e == nil {
+		return nil
+	}
+	for _, score := range []struct {
+		field string
+		value *float64
+	}{
+		{"evaluations.mmlu", e.MMLU},
+		{"evaluations.humaneval", e.HumanEval},
+	} {
+		if score.value != nil && (*score.value < 0 || *score.value > 100) {
+			return &errors.ValidationError{Field: score.field, Value: *score.value, Message: "must be a percentage between 0 and 100"}
+		}
+	}
+	if e.ArenaElo != nil && *e.ArenaElo <= 0 {
+		return &errors.ValidationError{Field: "evaluations.arena_elo", Value: *e.ArenaElo, Message: "must be positive"}
+	}
+	return nil
+}
+
+// IsEmpty reports whether no score is recorded.
+func (e *ModelEvaluations) IsEmpty() bool {
+	return e == nil || e.MMLU == nil && e.ArenaElo == nil && e.HumanEval == nil
+}
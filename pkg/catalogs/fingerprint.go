@@ -0,0 +1,59 @@
+package catalogs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/agentstation/utc"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// ModelFingerprint returns a stable content digest of model, formatted as
+// sha256:<hex>. Bookkeeping timestamps (created_at and updated_at) are
+// excluded, so two records with the same content share a fingerprint even
+// when they were written at different times. Dates that describe the model,
+// such as metadata.release_date, are content and are included.
+//
+// Equal fingerprints mean the records are unchanged, so diffing and delta
+// computation can skip field-by-field comparison of unchanged models. The
+// immutable Catalog stores the fingerprint of each provider model; see
+// Catalog.ModelFingerprint.
+func ModelFingerprint(model Model) (string, error) {
+	model.CreatedAt = utc.Time{}
+	model.UpdatedAt = utc.Time{}
+	data, err := json.Marshal(model)
+	if err != nil {
+		return "", errors.WrapResource("fingerprint", "model", model.ID, err)
+	}
+	digest := sha256.Sum256(data)
+	return checksumAlgorithmPrefix + hex.EncodeToString(digest[:]), nil
+}
+
+// ModelFingerprint returns the fingerprint of a provider's model, keyed by
+// canonical provider ID and model ID. Fingerprints are computed once, on first
+// use, and kept for the life of the catalog, so comparing two catalogs costs a
+// string comparison per unchanged model.
+func (r *Catalog) ModelFingerprint(providerID ProviderID, modelID string) (string, bool) {
+	r.fingerprintsOnce.Do(r.computeFingerprints)
+	fingerprint, found := r.fingerprints[OfferingKey{ProviderID: providerID, ProviderModelID: ProviderModelID(modelID)}]
+	return fingerprint, found
+}
+
+// computeFingerprints indexes every provider model. A model whose fingerprint
+// cannot be computed is left out, which makes callers compare it field by
+// field.
+func (r *Catalog) computeFingerprints() {
+	r.fingerprints = make(map[OfferingKey]string)
+	for _, provider := range r.source.Providers().List() {
+		for _, model := range provider.Models {
+			if model == nil {
+				continue
+			}
+			if fingerprint, err := ModelFingerprint(*model); err == nil {
+				r.fingerprints[OfferingKey{ProviderID: provider.ID, ProviderModelID: ProviderModelID(model.ID)}] = fingerprint
+			}
+		}
+	}
+}
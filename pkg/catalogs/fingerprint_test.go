@@ -0,0 +1,65 @@
+package catalogs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentstation/utc"
+)
+
+func TestModelFingerprintExcludesBookkeepingTimestamps(t *testing.T) {
+	model := Model{
+		ID:        "model",
+		Name:      "Model",
+		Metadata:  &ModelMetadata{ReleaseDate: utc.New(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC))},
+		CreatedAt: utc.New(time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)),
+	}
+	base, err := ModelFingerprint(model)
+	if err != nil {
+		t.Fatalf("ModelFingerprint: %v", err)
+	}
+	if !strings.HasPrefix(base, "sha256:") || len(base) != len("sha256:")+64 {
+		t.Fatalf("fingerprint = %q, want sha256:<hex>", base)
+	}
+
+	touched := DeepCopyModel(model)
+	touched.CreatedAt = utc.Time{}
+	touched.UpdatedAt = utc.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if got, _ := ModelFingerprint(touched); got != base {
+		t.Fatalf("timestamp-only change altered fingerprint: %s != %s", got, base)
+	}
+
+	released := DeepCopyModel(model)
+	released.Metadata.ReleaseDate = utc.New(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	if got, _ := ModelFingerprint(released); got == base {
+		t.Fatal("release date change did not alter fingerprint")
+	}
+
+	priced := DeepCopyModel(model)
+	priced.Pricing = &ModelPricing{Currency: ModelPricingCurrencyUSD}
+	if got, _ := ModelFingerprint(priced); got == base {
+		t.Fatal("pricing change did not alter fingerprint")
+	}
+}
+
+func TestCatalogModelFingerprintIsStored(t *testing.T) {
+	builder := NewEmpty()
+	model := &Model{ID: "model", Name: "Model"}
+	if err := builder.SetProvider(Provider{ID: "provider", Models: map[string]*Model{model.ID: model}}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	catalog, err := NewCatalog(builder)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+
+	stored, found := catalog.ModelFingerprint("provider", "model")
+	want, _ := ModelFingerprint(*model)
+	if !found || stored != want {
+		t.Fatalf("stored fingerprint = %q, %v; want %q", stored, found, want)
+	}
+	if _, found := catalog.ModelFingerprint("provider", "missing"); found {
+		t.Fatal("missing model has a fingerprint")
+	}
+}
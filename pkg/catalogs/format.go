@@ -0,0 +1,73 @@
+package catalogs
+
+import (
+	"path"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// FormatYAMLFile returns the canonical form of a catalog YAML file: the
+// bytes Save writes for the same content. file is the slash-separated path
+// within the catalog and selects the layout: providers.yaml, authors.yaml,
+// or a model file under providers/<id>/models/ or authors/<id>/models/.
+//
+// Formatting is lossless only for fields the catalog types know, so data
+// with unknown fields is rejected rather than rewritten without them. Model
+// file comments other than PinComment annotations are not kept, as with Save.
+func FormatYAMLFile(file string, data []byte) ([]byte, error) {
+	switch {
+	case file == "providers.yaml":
+		var items []Provider
+		if err := yaml.UnmarshalWithOptions(data, &items, yaml.Strict()); err != nil {
+			return nil, errors.WrapParse("yaml", file, err)
+		}
+		providers := NewProviders()
+		for i := range items {
+			if err := providers.Set(items[i].ID, &items[i]); err != nil {
+				return nil, errors.WrapResource("format", "provider", string(items[i].ID), err)
+			}
+		}
+		formatted, err := providers.EncodeYAML()
+		return []byte(formatted), err
+
+	case file == "authors.yaml":
+		var items []Author
+		if err := yaml.UnmarshalWithOptions(data, &items, yaml.Strict()); err != nil {
+			return nil, errors.WrapParse("yaml", file, err)
+		}
+		authors := NewAuthors()
+		for i := range items {
+			if err := authors.Set(items[i].ID, &items[i]); err != nil {
+				return nil, errors.WrapResource("format", "author", string(items[i].ID), err)
+			}
+		}
+		formatted, err := authors.EncodeYAML()
+		return []byte(formatted), err
+
+	case IsModelFile(file):
+		var model Model
+		comments := yaml.CommentMap{}
+		if err := yaml.UnmarshalWithOptions(data, &model, yaml.Strict(), yaml.CommentToMap(comments)); err != nil {
+			return nil, errors.WrapParse("yaml", file, err)
+		}
+		model.Pins = modelPins(comments)
+		formatted, err := model.EncodeYAML()
+		return []byte(formatted), err
+
+	default:
+		return nil, &errors.ValidationError{Field: "file", Value: file, Message: "is not a catalog file starmap formats"}
+	}
+}
+
+// IsModelFile reports whether file, a slash-separated path within a
+// catalog, is where Load reads a provider or author model from.
+func IsModelFile(file string) bool {
+	parts := strings.Split(file, "/")
+	return len(parts) >= 4 &&
+		(parts[0] == "providers" || parts[0] == "authors") &&
+		parts[2] == "models" &&
+		path.Ext(file) == ".yaml"
+}
@@ -0,0 +1,93 @@
+package catalogs
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+func TestFormatYAMLFile(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want []string // substrings of the formatted file, in order
+	}{
+		{
+			name: "providers sorted by id",
+			file: "providers.yaml",
+			data: "- id: openai\n  name: OpenAI\n- id: anthropic\n  name: Anthropic\n",
+			want: []string{"# Anthropic\n- id: anthropic", "# OpenAI\n- id: openai"},
+		},
+		{
+			name: "authors sorted by id",
+			file: "authors.yaml",
+			data: "- id: openai\n  name: OpenAI\n- id: meta\n  name: Meta\n",
+			want: []string{"# Meta\n- id: meta", "# OpenAI\n- id: openai"},
+		},
+		{
+			name: "model keeps pins",
+			file: "providers/openai/models/gpt-a.yaml",
+			data: "name: GPT A # starmap:pin\nid: gpt-a\n",
+			want: []string{"id: gpt-a", "name: GPT A # starmap:pin"},
+		},
+		{
+			name: "quoted description as literal block",
+			file: "authors/openai/models/gpt-a.yaml",
+			data: "id: gpt-a\nname: gpt-a\ndescription: 'Says \"hi\": e.g. \\\\quiet\\\\ tone'\n",
+			want: []string{"description: |-\n  Says \"hi\": e.g. \\\\quiet\\\\ tone\n"},
+		},
+		{
+			name: "multiline description stays out of the header",
+			file: "providers/openai/models/gpt-a.yaml",
+			data: "id: gpt-a\nname: gpt-a\ndescription: |-\n  First line\n  second line\n",
+			want: []string{"# gpt-a - First line second line\n", "description: |-\n  First line\n  second line\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatted, err := FormatYAMLFile(tt.file, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("FormatYAMLFile: %v", err)
+			}
+			rest := string(formatted)
+			for _, want := range tt.want {
+				index := strings.Index(rest, want)
+				if index < 0 {
+					t.Fatalf("formatted file missing %q after earlier matches:\n%s", want, formatted)
+				}
+				rest = rest[index+len(want):]
+			}
+
+			again, err := FormatYAMLFile(tt.file, formatted)
+			if err != nil {
+				t.Fatalf("FormatYAMLFile on formatted output: %v", err)
+			}
+			if string(again) != string(formatted) {
+				t.Errorf("formatting is not idempotent:\nfirst:\n%s\nsecond:\n%s", formatted, again)
+			}
+		})
+	}
+}
+
+func TestFormatYAMLFileRejects(t *testing.T) {
+	tests := []struct {
+		file string
+		data string
+	}{
+		{file: "providers/openai/models/gpt-a.yaml", data: "id: gpt-a\ncontext: 1000\n"},
+		{file: "providers.yaml", data: "- id: openai\n  nmae: OpenAI\n"},
+		{file: "providers/openai/logo.yaml", data: "id: gpt-a\n"},
+		{file: "endpoints.yaml", data: "[]\n"},
+	}
+	for _, tt := range tests {
+		_, err := FormatYAMLFile(tt.file, []byte(tt.data))
+		var parse *errors.ParseError
+		var validation *errors.ValidationError
+		if !stderrors.As(err, &parse) && !stderrors.As(err, &validation) {
+			t.Errorf("FormatYAMLFile(%s) error = %v, want a parse or validation error", tt.file, err)
+		}
+	}
+}
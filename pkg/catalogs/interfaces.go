@@ -1,6 +1,8 @@
 package catalogs
 
 import (
+	"context"
+
 	"github.com/agentstation/starmap/pkg/catalogmeta"
 	"github.com/agentstation/starmap/pkg/provenance"
 )
@@ -74,3 +76,28 @@ type Reader interface {
 	ProviderModels(id ProviderID) (ModelsReader, error)
 	ProviderModel(providerID ProviderID, modelID string) (Model, error)
 }
+
+// Writer begins transactions that apply a batch of catalog mutations
+// atomically. Persistence implementations stage mutations against a private
+// copy of the current catalog and publish them only on Commit.
+type Writer interface {
+	Begin(context.Context) (Transaction, error)
+}
+
+// Transaction is one pending batch of provider, model, and author mutations.
+// Reads observe the transaction's staged writes. Commit publishes the batch as
+// a single unit or not at all; Rollback discards it. Either call closes the
+// transaction and later mutations fail.
+type Transaction interface {
+	Reader
+
+	SetProvider(provider Provider) error
+	DeleteProvider(id ProviderID) error
+	SetProviderModel(providerID ProviderID, model Model) error
+	DeleteProviderModel(providerID ProviderID, modelID string) error
+	SetAuthor(author Author) error
+	DeleteAuthor(id AuthorID) error
+
+	Commit(ctx context.Context) error
+	Rollback() error
+}
@@ -186,7 +186,7 @@ func legacyModelDefinition(legacy Model, fallbackAuthors []AuthorID) ModelDefini
 		authorIDs = append(authorIDs, fallbackAuthors...)
 	}
 	definition := ModelDefinition{
-		ID:          ModelDefinitionID(copied.ID),
+		ID:          copied.DefinitionID(),
 		Name:        copied.Name,
 		AuthorIDs:   authorIDs,
 		Description: copied.Description,
@@ -275,7 +275,7 @@ func legacyProviderOffering(providerID ProviderID, legacy Model) (ProviderOfferi
 	offering := ProviderOffering{
 		ProviderID:      providerID,
 		ProviderModelID: ProviderModelID(copied.ID),
-		DefinitionID:    ModelDefinitionID(copied.ID),
+		DefinitionID:    copied.DefinitionID(),
 		Pricing:         copied.Pricing,
 		Limits:          copied.Limits,
 		Availability:    OfferingAvailabilityAvailable,
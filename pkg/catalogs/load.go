@@ -18,6 +18,11 @@ func (cat *Builder) Load() error {
 		return nil // Memory catalog - nothing to load
 	}
 
+	// Refuse layouts this build cannot read without migration
+	if err := checkYAMLSchemaVersion(cat.config.readFilesystem()); err != nil {
+		return err
+	}
+
 	// Load providers.yaml
 	if err := cat.loadProvidersYAML(); err != nil {
 		return err
@@ -43,6 +48,11 @@ func (cat *Builder) Load() error {
 		return err
 	}
 
+	// Load pins.yaml
+	if err := cat.loadPinsYAML(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -152,9 +162,11 @@ func (cat *Builder) loadAuthorModel(pathParts []string, model *Model) error {
 // loadModelFile parses and loads a model file.
 func (cat *Builder) loadModelFile(path string, data []byte) error {
 	var model Model
-	if err := yaml.Unmarshal(data, &model); err != nil {
+	comments := yaml.CommentMap{}
+	if err := yaml.UnmarshalWithOptions(data, &model, yaml.CommentToMap(comments)); err != nil {
 		return errors.WrapParse("yaml", path, err)
 	}
+	model.Pins = modelPins(comments)
 
 	pathParts := strings.Split(path, "/")
 
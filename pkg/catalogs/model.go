@@ -12,6 +12,8 @@ type Model struct {
 	Authors     []Author    `json:"authors,omitempty" yaml:"authors,omitempty"`         // Authors/organizations of the model (if known)
 	Description string      `json:"description,omitempty" yaml:"description,omitempty"` // Description of the model and its use cases
 	Status      ModelStatus `json:"status,omitempty" yaml:"status,omitempty"`           // Lifecycle status such as active, beta, preview, or deprecated
+	Class       ModelClass  `json:"class,omitempty" yaml:"class,omitempty"`             // Chat, embedding, or rerank; empty means chat
+	Definition  string      `json:"definition,omitempty" yaml:"definition,omitempty"`   // Canonical model definition shared across providers; empty means the model ID
 
 	// Metadata - version and timing information
 	Metadata *ModelMetadata `json:"metadata,omitempty" yaml:"metadata,omitempty"` // Metadata for the model
@@ -40,9 +42,39 @@ type Model struct {
 	// Tools - external tool and capability integrations
 	Tools *ModelTools `json:"tools,omitempty" yaml:"tools,omitempty"`
 
+	// Vision - image input constraints (overrides the provider default)
+	Vision *ModelVision `json:"vision,omitempty" yaml:"vision,omitempty"`
+
+	// Documents - native document (PDF) input support (overrides the provider default)
+	Documents *ModelDocumentInput `json:"documents,omitempty" yaml:"documents,omitempty"`
+
+	// Caching - prompt caching support (overrides the provider default)
+	Caching *PromptCaching `json:"caching,omitempty" yaml:"caching,omitempty"`
+
 	// Delivery - technical response delivery capabilities (formats, protocols, streaming)
 	Delivery *ModelDelivery `json:"response,omitempty" yaml:"response,omitempty"`
 
+	// Embedding - vector dimensions of an embedding model
+	Embedding *ModelEmbedding `json:"embedding,omitempty" yaml:"embedding,omitempty"`
+
+	// Performance - serving throughput and latency for this provider offering
+	Performance *ModelPerformance `json:"performance,omitempty" yaml:"performance,omitempty"`
+
+	// UsageRestrictions - acceptable-use restrictions added to the provider's policy
+	UsageRestrictions *UsageRestrictions `json:"usage_restrictions,omitempty" yaml:"usage_restrictions,omitempty"`
+
+	// Sustainability - estimated inference energy for this provider offering
+	Sustainability *ModelSustainability `json:"sustainability,omitempty" yaml:"sustainability,omitempty"`
+
+	// Evaluations - public benchmark and leaderboard scores
+	Evaluations *ModelEvaluations `json:"evaluations,omitempty" yaml:"evaluations,omitempty"`
+
+	// Curation - user-managed governance tags such as approved-for-prod
+	Curation *ModelCuration `json:"curation,omitempty" yaml:"curation,omitempty"`
+
+	// Review - approval state and audit log for the optional review workflow
+	Review *ModelReview `json:"review,omitempty" yaml:"review,omitempty"`
+
 	// Modes - alternate service modes such as fast/priority variants
 	Modes map[string]ModelMode `json:"modes,omitempty" yaml:"modes,omitempty"`
 
@@ -53,6 +85,12 @@ type Model struct {
 	// Extensions - controlled source-specific fields that are not canonical schema
 	Extensions SourceExtensions `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 
+	// Private - dotted field paths withheld from public exports, the HTTP API, and docs
+	Private []string `json:"private,omitempty" yaml:"private,omitempty"`
+
+	// Pins - fields annotated with "# starmap:pin" in the catalog file; kept as comments, not data
+	Pins []string `json:"-" yaml:"-"`
+
 	// Timestamps for record keeping and auditing
 	CreatedAt utc.Time `json:"created_at" yaml:"created_at"` // Created date (YYYY-MM or YYYY-MM-DD format)
 	UpdatedAt utc.Time `json:"updated_at" yaml:"updated_at"` // Last updated date (YYYY-MM or YYYY-MM-DD format)
@@ -266,6 +304,13 @@ type ModelTools struct {
 	// Common values: ["auto"], ["auto", "none"], ["auto", "none", "required"]
 	ToolChoices []ToolChoice `json:"tool_choices,omitempty" yaml:"tool_choices,omitempty"` // Supported tool choice strategies
 
+	// Tool calling wire format
+	// Dialects overrides the dialect implied by the provider's API style, e.g. a
+	// model that accepts both OpenAI and Anthropic request shapes.
+	Dialects        []ToolDialect `json:"dialects,omitempty" yaml:"dialects,omitempty"`                 // Supported tool calling dialects
+	ParallelCalls   *bool         `json:"parallel_calls,omitempty" yaml:"parallel_calls,omitempty"`     // Can emit several tool calls in one response
+	StreamingDeltas *bool         `json:"streaming_deltas,omitempty" yaml:"streaming_deltas,omitempty"` // Streams tool call arguments as incremental deltas
+
 	// Web search configuration
 	// Only applicable if WebSearch=true in ModelFeatures
 	WebSearch *ModelWebSearch `json:"web_search,omitempty" yaml:"web_search,omitempty"`
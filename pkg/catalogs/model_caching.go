@@ -0,0 +1,51 @@
+package catalogs
+
+import (
+	"slices"
+	"time"
+)
+
+// PromptCachingMode describes how prompt caching is activated.
+type PromptCachingMode string
+
+// String returns the string representation of a PromptCachingMode.
+func (m PromptCachingMode) String() string {
+	return string(m)
+}
+
+// Prompt caching modes.
+const (
+	PromptCachingModeAutomatic PromptCachingMode = "automatic" // Repeated prefixes are cached without request changes
+	PromptCachingModeExplicit  PromptCachingMode = "explicit"  // Requests mark cache breakpoints (e.g. cache_control) or create caches
+)
+
+// PromptCaching describes prompt caching support. Providers record their
+// default behavior; models override it when they differ.
+type PromptCaching struct {
+	Modes      []PromptCachingMode `json:"modes,omitempty" yaml:"modes,omitempty"`             // How caching is activated (empty = not supported)
+	MinTokens  int64               `json:"min_tokens,omitempty" yaml:"min_tokens,omitempty"`   // Minimum prompt length that can be cached
+	TTLs       []time.Duration     `json:"ttls,omitempty" yaml:"ttls,omitempty"`               // Selectable cache lifetimes
+	DefaultTTL *time.Duration      `json:"default_ttl,omitempty" yaml:"default_ttl,omitempty"` // Lifetime when none is requested
+}
+
+// Supported reports whether any caching mode is available.
+func (c *PromptCaching) Supported() bool {
+	return c != nil && len(c.Modes) > 0
+}
+
+// HasMode reports whether caching can be activated with mode.
+func (c *PromptCaching) HasMode(mode PromptCachingMode) bool {
+	return c != nil && slices.Contains(c.Modes, mode)
+}
+
+// PromptCachingFor resolves the model's prompt caching support as served by
+// provider. Model-level data takes precedence over the provider default.
+func (m *Model) PromptCachingFor(provider *Provider) *PromptCaching {
+	if m != nil && m.Caching != nil {
+		return deepCopyPromptCaching(m.Caching)
+	}
+	if provider != nil && provider.PromptCaching != nil {
+		return deepCopyPromptCaching(provider.PromptCaching)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package catalogs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestModelPromptCachingForPrefersModelOverride(t *testing.T) {
+	ttl := 5 * time.Minute
+	provider := &Provider{PromptCaching: &PromptCaching{
+		Modes:      []PromptCachingMode{PromptCachingModeAutomatic},
+		MinTokens:  1024,
+		DefaultTTL: &ttl,
+	}}
+
+	inherited := (&Model{ID: "small"}).PromptCachingFor(provider)
+	if !inherited.HasMode(PromptCachingModeAutomatic) || inherited.MinTokens != 1024 {
+		t.Fatalf("inherited = %#v", inherited)
+	}
+	*inherited.DefaultTTL = time.Hour
+	if *provider.PromptCaching.DefaultTTL != ttl {
+		t.Fatal("resolved caching aliases the provider default")
+	}
+
+	override := (&Model{ID: "large", Caching: &PromptCaching{
+		Modes:     []PromptCachingMode{PromptCachingModeExplicit},
+		MinTokens: 4096,
+	}}).PromptCachingFor(provider)
+	if override.HasMode(PromptCachingModeAutomatic) || override.MinTokens != 4096 || override.DefaultTTL != nil {
+		t.Fatalf("override = %#v", override)
+	}
+
+	if caching := (&Model{ID: "none"}).PromptCachingFor(&Provider{}); caching.Supported() {
+		t.Fatalf("caching without data = %#v, want unsupported", caching)
+	}
+}
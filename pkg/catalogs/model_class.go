@@ -0,0 +1,33 @@
+package catalogs
+
+// ModelClass is the kind of work a model does, which decides the API it is
+// called through. Models without a class are chat models.
+type ModelClass string
+
+// String returns the string representation of a ModelClass.
+func (c ModelClass) String() string {
+	return string(c)
+}
+
+// Model classes.
+const (
+	ModelClassChat      ModelClass = "chat"      // Generates content from messages or a prompt
+	ModelClassEmbedding ModelClass = "embedding" // Returns vector embeddings of its input
+	ModelClassRerank    ModelClass = "rerank"    // Orders documents by relevance to a query
+)
+
+// ModelEmbedding describes the vectors an embedding model returns.
+type ModelEmbedding struct {
+	Dimensions          int      `json:"dimensions,omitempty" yaml:"dimensions,omitempty"`                     // Vector length returned by default
+	SupportedDimensions []int    `json:"supported_dimensions,omitempty" yaml:"supported_dimensions,omitempty"` // Lengths a request can select instead
+	Types               []string `json:"types,omitempty" yaml:"types,omitempty"`                               // Vector encodings such as float, int8, or binary
+}
+
+// ClassOrDefault returns the model's class, or ModelClassChat when it has
+// none.
+func (m *Model) ClassOrDefault() ModelClass {
+	if m == nil || m.Class == "" {
+		return ModelClassChat
+	}
+	return m.Class
+}
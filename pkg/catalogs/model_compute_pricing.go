@@ -0,0 +1,28 @@
+package catalogs
+
+// ModelComputePricing represents time-based billing, where a prediction is
+// charged for the seconds it runs on its hardware rather than per token or
+// per operation.
+type ModelComputePricing struct {
+	Rates []ModelComputeRate `json:"rates,omitempty" yaml:"rates,omitempty"` // Per-second rates of the hardware the model can run on
+}
+
+// ModelComputeRate is the price of one second on one hardware type.
+type ModelComputeRate struct {
+	Hardware  string  `json:"hardware" yaml:"hardware"`             // Provider hardware SKU, such as gpu-a100-large
+	Name      string  `json:"name,omitempty" yaml:"name,omitempty"` // Display name, such as Nvidia A100 (80GB) GPU
+	PerSecond float64 `json:"per_second" yaml:"per_second"`         // Cost per second of run time
+}
+
+// Rate returns the rate for a hardware SKU.
+func (p *ModelComputePricing) Rate(hardware string) (ModelComputeRate, bool) {
+	if p == nil {
+		return ModelComputeRate{}, false
+	}
+	for _, rate := range p.Rates {
+		if rate.Hardware == hardware {
+			return rate, true
+		}
+	}
+	return ModelComputeRate{}, false
+}
@@ -0,0 +1,63 @@
+package catalogs
+
+// TokenUsage counts the tokens of one or more requests by billing category.
+type TokenUsage struct {
+	Input      int64 `json:"input" yaml:"input"`                                 // Uncached input tokens
+	CacheRead  int64 `json:"cache_read,omitempty" yaml:"cache_read,omitempty"`   // Input tokens read from the prompt cache
+	CacheWrite int64 `json:"cache_write,omitempty" yaml:"cache_write,omitempty"` // Input tokens written to the prompt cache
+	Output     int64 `json:"output" yaml:"output"`                               // Output tokens, including reasoning
+}
+
+// Add returns the sum of u and other.
+func (u TokenUsage) Add(other TokenUsage) TokenUsage {
+	return TokenUsage{
+		Input:      u.Input + other.Input,
+		CacheRead:  u.CacheRead + other.CacheRead,
+		CacheWrite: u.CacheWrite + other.CacheWrite,
+		Output:     u.Output + other.Output,
+	}
+}
+
+// TokenCost prices usage at the base token rates, ignoring tiers. Cache
+// reads and writes without a cache price are billed as input. It reports
+// false when the pricing has no input or output token price.
+func (p *ModelPricing) TokenCost(usage TokenUsage) (float64, bool) {
+	if p == nil || p.Tokens == nil || (p.Tokens.Input == nil && p.Tokens.Output == nil) {
+		return 0, false
+	}
+	tokens := p.Tokens
+	input := tokenPrice(tokens.Input)
+	cacheRead, cacheWrite := input, input
+	if cost := tokens.cacheRead(); cost != nil {
+		cacheRead = tokenPrice(cost)
+	}
+	if cost := tokens.cacheWrite(); cost != nil {
+		cacheWrite = tokenPrice(cost)
+	}
+	return float64(usage.Input)*input +
+		float64(usage.CacheRead)*cacheRead +
+		float64(usage.CacheWrite)*cacheWrite +
+		float64(usage.Output)*tokenPrice(tokens.Output), true
+}
+
+func (t *ModelTokenPricing) cacheRead() *ModelTokenCost {
+	if t.Cache != nil && t.Cache.Read != nil {
+		return t.Cache.Read
+	}
+	return t.CacheRead
+}
+
+func (t *ModelTokenPricing) cacheWrite() *ModelTokenCost {
+	if t.Cache != nil && t.Cache.Write != nil {
+		return t.Cache.Write
+	}
+	return t.CacheWrite
+}
+
+// tokenPrice returns the per-token price of cost, or zero when unset.
+func tokenPrice(cost *ModelTokenCost) float64 {
+	if cost == nil {
+		return 0
+	}
+	return perTokenPrice(cost)
+}
@@ -0,0 +1,54 @@
+package catalogs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestModelPricingTokenCost(t *testing.T) {
+	pricing := &ModelPricing{Tokens: &ModelTokenPricing{
+		Input:     &ModelTokenCost{Per1M: 3},
+		Output:    &ModelTokenCost{Per1M: 15},
+		CacheRead: &ModelTokenCost{Per1M: 0.3},
+	}}
+
+	// 1M input + 2M cache reads + 1M cache writes (billed as input) + 0.5M output.
+	got, ok := pricing.TokenCost(TokenUsage{Input: 1_000_000, CacheRead: 2_000_000, CacheWrite: 1_000_000, Output: 500_000})
+	if !ok {
+		t.Fatal("TokenCost reported unpriced")
+	}
+	if want := 3 + 0.6 + 3 + 7.5; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("TokenCost = %v, want %v", got, want)
+	}
+
+	for name, pricing := range map[string]*ModelPricing{
+		"nil":       nil,
+		"no tokens": {Currency: "USD"},
+		"no rates":  {Tokens: &ModelTokenPricing{CacheRead: &ModelTokenCost{Per1M: 1}}},
+	} {
+		if _, ok := pricing.TokenCost(TokenUsage{Input: 1}); ok {
+			t.Errorf("%s: TokenCost reported priced", name)
+		}
+	}
+}
+
+func TestModelTokenCostPerMillion(t *testing.T) {
+	for name, tt := range map[string]struct {
+		cost *ModelTokenCost
+		want *float64
+	}{
+		"nil":            {nil, nil},
+		"per 1M":         {&ModelTokenCost{PerToken: 0.000003, Per1M: 3.5}, ptrFloat(3.5)},
+		"per token only": {&ModelTokenCost{PerToken: 0.000003}, ptrFloat(3)},
+		"free":           {&ModelTokenCost{}, ptrFloat(0)},
+	} {
+		got := tt.cost.PerMillion()
+		if (got == nil) != (tt.want == nil) || got != nil && *got != *tt.want {
+			t.Errorf("%s: PerMillion = %v, want %v", name, got, tt.want)
+		}
+	}
+}
+
+func ptrFloat(v float64) *float64 {
+	return &v
+}
@@ -118,3 +118,12 @@ func copyModelDefinition(definition ModelDefinition) ModelDefinition {
 	copyDefinition.Capabilities.Delivery = deepCopyModelDelivery(definition.Capabilities.Delivery)
 	return copyDefinition
 }
+
+// DefinitionID returns the canonical definition the model is an offering of:
+// its Definition link when set, otherwise its own ID.
+func (m *Model) DefinitionID() ModelDefinitionID {
+	if m.Definition != "" {
+		return ModelDefinitionID(m.Definition)
+	}
+	return ModelDefinitionID(m.ID)
+}
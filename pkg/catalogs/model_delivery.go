@@ -6,6 +6,9 @@ type ModelDelivery struct {
 	Protocols []ModelResponseProtocol `json:"protocols,omitempty" yaml:"protocols,omitempty"` // Supported delivery protocols (HTTP, gRPC, etc.)
 	Streaming []ModelStreaming        `json:"streaming,omitempty" yaml:"streaming,omitempty"` // Supported streaming modes (sse, websocket, chunked)
 	Formats   []ModelResponseFormat   `json:"formats,omitempty" yaml:"formats,omitempty"`     // Available response formats (if format_response feature enabled)
+
+	// Realtime voice sessions (OpenAI Realtime, Gemini Live)
+	Realtime *ModelRealtime `json:"realtime,omitempty" yaml:"realtime,omitempty"`
 }
 
 // ModelResponseFormat represents a supported response format.
@@ -42,6 +45,7 @@ const (
 	ModelResponseProtocolHTTP      ModelResponseProtocol = "http"      // HTTP/HTTPS REST API
 	ModelResponseProtocolGRPC      ModelResponseProtocol = "grpc"      // gRPC protocol
 	ModelResponseProtocolWebSocket ModelResponseProtocol = "websocket" // WebSocket protocol
+	ModelResponseProtocolWebRTC    ModelResponseProtocol = "webrtc"    // WebRTC peer connection (browser and mobile voice)
 )
 
 // ModelStreaming represents how responses can be delivered.
@@ -0,0 +1,46 @@
+package catalogs
+
+import "slices"
+
+// DocumentOCR describes how a model reads document pages without a text
+// layer, such as scanned PDFs.
+type DocumentOCR string
+
+// String returns the string representation of a DocumentOCR.
+func (o DocumentOCR) String() string {
+	return string(o)
+}
+
+// Document OCR behaviors.
+const (
+	DocumentOCRNone      DocumentOCR = "none"      // Only the embedded text layer is read; scanned pages are blank
+	DocumentOCRVision    DocumentOCR = "vision"    // Pages are rendered as images and read by the model's vision input
+	DocumentOCRDedicated DocumentOCR = "dedicated" // A dedicated OCR pass extracts text before the model sees it
+)
+
+// ModelDocumentInput describes native document (PDF) ingestion. Providers
+// record their default limits; models override them when they differ.
+type ModelDocumentInput struct {
+	MaxPages    *int        `json:"max_pages,omitempty" yaml:"max_pages,omitempty"`         // Maximum pages per request
+	MaxFileSize *int64      `json:"max_file_size,omitempty" yaml:"max_file_size,omitempty"` // Maximum document size in bytes
+	Formats     []string    `json:"formats,omitempty" yaml:"formats,omitempty"`             // Accepted document MIME types
+	OCR         DocumentOCR `json:"ocr,omitempty" yaml:"ocr,omitempty"`                     // How pages without a text layer are read
+	PageTokens  int64       `json:"page_tokens,omitempty" yaml:"page_tokens,omitempty"`     // Approximate input tokens per page, for estimates
+}
+
+// DocumentsFor resolves the model's document input support as served by
+// provider. Model-level data takes precedence over the provider default,
+// which only applies to models that accept PDF input.
+func (m *Model) DocumentsFor(provider *Provider) *ModelDocumentInput {
+	if m == nil {
+		return nil
+	}
+	if m.Documents != nil {
+		return deepCopyModelDocumentInput(m.Documents)
+	}
+	if provider == nil || provider.Documents == nil || m.Features == nil ||
+		!slices.Contains(m.Features.Modalities.Input, ModelModalityPDF) {
+		return nil
+	}
+	return deepCopyModelDocumentInput(provider.Documents)
+}
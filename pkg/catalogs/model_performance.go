@@ -0,0 +1,48 @@
+package catalogs
+
+import (
+	"time"
+
+	"github.com/agentstation/utc"
+)
+
+// PerformanceSource identifies where performance numbers come from.
+type PerformanceSource string
+
+// String returns the string representation of a PerformanceSource.
+func (s PerformanceSource) String() string {
+	return string(s)
+}
+
+// Performance sources.
+const (
+	PerformanceSourcePublished PerformanceSource = "published" // Numbers published by the provider
+	PerformanceSourceMeasured  PerformanceSource = "measured"  // Numbers measured by a benchmark run
+)
+
+// LatencyPercentiles records a latency distribution.
+type LatencyPercentiles struct {
+	P50 *time.Duration `json:"p50,omitempty" yaml:"p50,omitempty"` // Median
+	P90 *time.Duration `json:"p90,omitempty" yaml:"p90,omitempty"` // 90th percentile
+	P95 *time.Duration `json:"p95,omitempty" yaml:"p95,omitempty"` // 95th percentile
+	P99 *time.Duration `json:"p99,omitempty" yaml:"p99,omitempty"` // 99th percentile
+}
+
+// ModelPerformance records the serving speed of a model offering. Numbers
+// are specific to the provider serving the model.
+type ModelPerformance struct {
+	OutputTokensPerSecond float64             `json:"output_tokens_per_second,omitempty" yaml:"output_tokens_per_second,omitempty"` // Median output throughput
+	TimeToFirstToken      *LatencyPercentiles `json:"time_to_first_token,omitempty" yaml:"time_to_first_token,omitempty"`           // Latency until the first output token
+	Source                PerformanceSource   `json:"source,omitempty" yaml:"source,omitempty"`                                     // Published or measured
+	SourceURL             *string             `json:"source_url,omitempty" yaml:"source_url,omitempty"`                             // Where the numbers were published
+	MeasuredAt            *utc.Time           `json:"measured_at,omitempty" yaml:"measured_at,omitempty"`                           // When the numbers were taken
+}
+
+// MedianTimeToFirstToken returns the p50 time to first token, or zero when
+// it is not recorded.
+func (p *ModelPerformance) MedianTimeToFirstToken() time.Duration {
+	if p == nil || p.TimeToFirstToken == nil || p.TimeToFirstToken.P50 == nil {
+		return 0
+	}
+	return *p.TimeToFirstToken.P50
+}
@@ -1,6 +1,7 @@
 package catalogs
 
 import (
+	"math"
 	"time"
 
 	"github.com/agentstation/utc"
@@ -70,6 +71,12 @@ type ModelPricing struct {
 	// Fixed costs per operation
 	Operations *ModelOperationPricing `json:"operations,omitempty" yaml:"operations,omitempty"`
 
+	// Realtime voice session costs
+	Realtime *ModelRealtimePricing `json:"realtime,omitempty" yaml:"realtime,omitempty"`
+
+	// Time-based costs per second of hardware
+	Compute *ModelComputePricing `json:"compute,omitempty" yaml:"compute,omitempty"`
+
 	// Conditional/tiered pricing
 	Tiers []ModelPricingTier `json:"tiers,omitempty" yaml:"tiers,omitempty"`
 
@@ -173,6 +180,20 @@ type ModelTokenCost struct {
 	Per1M    float64 `json:"per_1m_tokens" yaml:"per_1m"` // Cost per 1M tokens
 }
 
+// PerMillion returns the cost per 1M tokens, or nil for a nil cost. Per1M is
+// used when set; otherwise PerToken is scaled and rounded to drop binary
+// floating point noise from the decimal source value.
+func (t *ModelTokenCost) PerMillion() *float64 {
+	if t == nil {
+		return nil
+	}
+	price := t.Per1M
+	if price == 0 {
+		price = math.Round(t.PerToken*tokenPriceScale*1e9) / 1e9
+	}
+	return &price
+}
+
 // MarshalYAML implements custom YAML marshaling for TokenCost to format decimals consistently.
 func (t *ModelTokenCost) MarshalYAML() (any, error) {
 	result := make(map[string]float64)
@@ -198,6 +219,9 @@ type ModelOperationPricing struct {
 	AudioInput *float64 `json:"audio_input,omitempty" yaml:"audio_input,omitempty"` // Cost per audio input
 	VideoInput *float64 `json:"video_input,omitempty" yaml:"video_input,omitempty"` // Cost per video input
 
+	// Document operations
+	DocumentPage *float64 `json:"document_page,omitempty" yaml:"document_page,omitempty"` // Cost per document page processed
+
 	// Generation operations
 	ImageGen *float64 `json:"image_gen,omitempty" yaml:"image_gen,omitempty"` // Cost per image generated
 	AudioGen *float64 `json:"audio_gen,omitempty" yaml:"audio_gen,omitempty"` // Cost per audio generated
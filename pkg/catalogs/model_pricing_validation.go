@@ -32,7 +32,15 @@ func (p *ModelPricing) Validate() error {
 	if err != nil {
 		return err
 	}
-	totalPrices := basePrices
+	realtimePrices, err := validateRealtimePricing("pricing.realtime", p.Realtime)
+	if err != nil {
+		return err
+	}
+	computePrices, err := validateComputePricing("pricing.compute", p.Compute)
+	if err != nil {
+		return err
+	}
+	totalPrices := basePrices + realtimePrices + computePrices
 	seenTiers := make(map[string]struct{}, len(p.Tiers))
 	for index, tier := range p.Tiers {
 		path := fmt.Sprintf("tiers[%d]", index)
@@ -120,6 +128,7 @@ func validatePricingComponents(path string, tokens *ModelTokenPricing, operation
 			{"image_input", operations.ImageInput},
 			{"audio_input", operations.AudioInput},
 			{"video_input", operations.VideoInput},
+			{"document_page", operations.DocumentPage},
 			{"image_gen", operations.ImageGen},
 			{"audio_gen", operations.AudioGen},
 			{"video_gen", operations.VideoGen},
@@ -140,6 +149,58 @@ func validatePricingComponents(path string, tokens *ModelTokenPricing, operation
 	return count, nil
 }
 
+func validateRealtimePricing(path string, realtime *ModelRealtimePricing) (int, error) {
+	if realtime == nil {
+		return 0, nil
+	}
+	count := 0
+	costs := []struct {
+		name string
+		cost *ModelTokenCost
+	}{
+		{"audio_input", realtime.AudioInput},
+		{"audio_output", realtime.AudioOutput},
+		{"audio_cache_read", realtime.AudioCacheRead},
+	}
+	for _, item := range costs {
+		if item.cost == nil {
+			continue
+		}
+		if err := validateTokenCost(path+"."+item.name, item.cost); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	if realtime.SessionMinute != nil {
+		if err := validatePrice(path+".session_minute", *realtime.SessionMinute); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+func validateComputePricing(path string, compute *ModelComputePricing) (int, error) {
+	if compute == nil {
+		return 0, nil
+	}
+	seen := make(map[string]struct{}, len(compute.Rates))
+	for index, rate := range compute.Rates {
+		ratePath := fmt.Sprintf("%s.rates[%d]", path, index)
+		if rate.Hardware == "" {
+			return 0, pricingValidationError(ratePath+".hardware", rate.Hardware, "is required")
+		}
+		if _, exists := seen[rate.Hardware]; exists {
+			return 0, pricingValidationError(ratePath+".hardware", rate.Hardware, "must be unique")
+		}
+		seen[rate.Hardware] = struct{}{}
+		if err := validatePrice(ratePath+".per_second", rate.PerSecond); err != nil {
+			return 0, err
+		}
+	}
+	return len(compute.Rates), nil
+}
+
 func validateTokenCost(path string, cost *ModelTokenCost) error {
 	if err := validatePrice(path+".per_token", cost.PerToken); err != nil {
 		return err
@@ -44,6 +44,15 @@ func TestPriceUnitCurrencyAndZeroValidation(t *testing.T) {
 		{name: "negative", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Tokens: &ModelTokenPricing{Input: &ModelTokenCost{Per1M: -1}}}, wantErr: true},
 		{name: "not finite", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Tokens: &ModelTokenPricing{Input: &ModelTokenCost{Per1M: math.Inf(1)}}}, wantErr: true},
 		{name: "inconsistent units", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Tokens: &ModelTokenPricing{Input: &ModelTokenCost{PerToken: 0.000001, Per1M: 2}}}, wantErr: true},
+		{name: "realtime audio only", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Realtime: &ModelRealtimePricing{AudioInput: &ModelTokenCost{Per1M: 32}}}},
+		{name: "realtime session minute", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Realtime: &ModelRealtimePricing{SessionMinute: pricingFloat64Pointer(0.06)}}},
+		{name: "document page", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Operations: &ModelOperationPricing{DocumentPage: pricingFloat64Pointer(0.001)}}},
+		{name: "negative document page", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Operations: &ModelOperationPricing{DocumentPage: pricingFloat64Pointer(-0.001)}}, wantErr: true},
+		{name: "negative realtime session minute", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Realtime: &ModelRealtimePricing{SessionMinute: pricingFloat64Pointer(-1)}}, wantErr: true},
+		{name: "compute per second", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Compute: &ModelComputePricing{Rates: []ModelComputeRate{{Hardware: "gpu-t4", PerSecond: 0.000225}}}}},
+		{name: "compute without hardware", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Compute: &ModelComputePricing{Rates: []ModelComputeRate{{PerSecond: 0.000225}}}}, wantErr: true},
+		{name: "duplicate compute hardware", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Compute: &ModelComputePricing{Rates: []ModelComputeRate{{Hardware: "cpu", PerSecond: 0.0001}, {Hardware: "cpu", PerSecond: 0.0002}}}}, wantErr: true},
+		{name: "empty compute", pricing: &ModelPricing{Currency: ModelPricingCurrencyUSD, Compute: &ModelComputePricing{}}, wantErr: true},
 	}
 
 	for _, test := range tests {
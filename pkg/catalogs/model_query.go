@@ -0,0 +1,478 @@
+package catalogs
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// ModelQuery is a parsed model filter expression such as
+//
+//	pricing.input < 1.0 && features.vision && limits.context >= 128000
+//
+// Comparisons (==, !=, <, <=, >, >=) combine with &&, ||, ! and
+// parentheses. A boolean field on its own tests that it is true. Prices are
+// per 1M tokens in the model's pricing currency, and string comparisons
+// ignore case. A comparison against a value the model does not record, such
+// as the price of an unpriced model, is false.
+type ModelQuery struct {
+	expr string
+	root queryNode
+}
+
+// ParseModelQuery parses a model filter expression, checking that every
+// field exists and is compared with a value of its type.
+func ParseModelQuery(expr string) (*ModelQuery, error) {
+	tokens, err := lexQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{expr: expr, tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != queryTokenEOF {
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	}
+	return &ModelQuery{expr: expr, root: root}, nil
+}
+
+// Match reports whether model satisfies the query. A nil query matches
+// every model.
+func (q *ModelQuery) Match(model *Model) bool {
+	if q == nil || model == nil {
+		return q == nil
+	}
+	return q.root.eval(model)
+}
+
+// String returns the expression the query was parsed from.
+func (q *ModelQuery) String() string {
+	if q == nil {
+		return ""
+	}
+	return q.expr
+}
+
+// queryKind is the type of a query field or literal.
+type queryKind int
+
+const (
+	queryNumber queryKind = iota
+	queryBool
+	queryString
+)
+
+func (k queryKind) String() string {
+	switch k {
+	case queryNumber:
+		return "number"
+	case queryBool:
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// queryValue is a field or literal value; only the member of its kind is set.
+type queryValue struct {
+	kind queryKind
+	num  float64
+	b    bool
+	str  string
+}
+
+// modelQueryField reads one field of a model, reporting false when the model
+// does not record it.
+type modelQueryField struct {
+	kind  queryKind
+	value func(m *Model) (queryValue, bool)
+}
+
+func numberField(get func(m *Model) (float64, bool)) modelQueryField {
+	return modelQueryField{kind: queryNumber, value: func(m *Model) (queryValue, bool) {
+		n, ok := get(m)
+		return queryValue{kind: queryNumber, num: n}, ok
+	}}
+}
+
+func boolField(get func(m *Model) bool) modelQueryField {
+	return modelQueryField{kind: queryBool, value: func(m *Model) (queryValue, bool) {
+		return queryValue{kind: queryBool, b: get(m)}, true
+	}}
+}
+
+func stringField(get func(m *Model) string) modelQueryField {
+	return modelQueryField{kind: queryString, value: func(m *Model) (queryValue, bool) {
+		s := get(m)
+		return queryValue{kind: queryString, str: s}, s != ""
+	}}
+}
+
+// tokenPriceField reads a token price per 1M tokens.
+func tokenPriceField(get func(t *ModelTokenPricing) *ModelTokenCost) modelQueryField {
+	return numberField(func(m *Model) (float64, bool) {
+		if m.Pricing == nil || m.Pricing.Tokens == nil {
+			return 0, false
+		}
+		cost := get(m.Pricing.Tokens)
+		if cost == nil {
+			return 0, false
+		}
+		return perTokenPrice(cost) * 1_000_000, true
+	})
+}
+
+// limitField reads a token limit; zero means the limit is unknown.
+func limitField(get func(l *ModelLimits) int64) modelQueryField {
+	return numberField(func(m *Model) (float64, bool) {
+		if m.Limits == nil {
+			return 0, false
+		}
+		n := get(m.Limits)
+		return float64(n), n > 0
+	})
+}
+
+func modalityField(output bool, modality ModelModality) modelQueryField {
+	return boolField(func(m *Model) bool {
+		if m.Features == nil {
+			return false
+		}
+		if output {
+			return slices.Contains(m.Features.Modalities.Output, modality)
+		}
+		return slices.Contains(m.Features.Modalities.Input, modality)
+	})
+}
+
+var modelQueryFields = func() map[string]modelQueryField {
+	fields := map[string]modelQueryField{
+		"id":     stringField(func(m *Model) string { return m.ID }),
+		"name":   stringField(func(m *Model) string { return m.Name }),
+		"status": stringField(func(m *Model) string { return string(m.Status) }),
+		"family": stringField(func(m *Model) string {
+			if m.Lineage == nil {
+				return ""
+			}
+			return m.Lineage.Family
+		}),
+
+		"pricing.input":       tokenPriceField(func(t *ModelTokenPricing) *ModelTokenCost { return t.Input }),
+		"pricing.output":      tokenPriceField(func(t *ModelTokenPricing) *ModelTokenCost { return t.Output }),
+		"pricing.reasoning":   tokenPriceField(func(t *ModelTokenPricing) *ModelTokenCost { return t.Reasoning }),
+		"pricing.cache_read":  tokenPriceField((*ModelTokenPricing).cacheRead),
+		"pricing.cache_write": tokenPriceField((*ModelTokenPricing).cacheWrite),
+		"pricing.request": numberField(func(m *Model) (float64, bool) {
+			if m.Pricing == nil || m.Pricing.Operations == nil || m.Pricing.Operations.Request == nil {
+				return 0, false
+			}
+			return *m.Pricing.Operations.Request, true
+		}),
+		"pricing.currency": stringField(func(m *Model) string {
+			if m.Pricing == nil {
+				return ""
+			}
+			return string(m.Pricing.Currency)
+		}),
+
+		"limits.context": limitField(func(l *ModelLimits) int64 { return l.ContextWindow }),
+		"limits.input":   limitField(func(l *ModelLimits) int64 { return l.InputTokens }),
+		"limits.output":  limitField(func(l *ModelLimits) int64 { return l.OutputTokens }),
+
+		"performance.output_speed": numberField(func(m *Model) (float64, bool) {
+			if m.Performance == nil {
+				return 0, false
+			}
+			return m.Performance.OutputTokensPerSecond, m.Performance.OutputTokensPerSecond > 0
+		}),
+		"performance.ttft_ms": numberField(func(m *Model) (float64, bool) {
+			ttft := m.Performance.MedianTimeToFirstToken()
+			return float64(ttft.Milliseconds()), ttft > 0
+		}),
+
+		"metadata.open_weights": boolField(func(m *Model) bool { return m.Metadata != nil && m.Metadata.OpenWeights }),
+		"features.vision":       modalityField(false, ModelModalityImage),
+	}
+	fields["limits.context_window"] = fields["limits.context"]
+
+	// Every ModelFeatures flag is a features.<json name> field.
+	featureType := reflect.TypeFor[ModelFeatures]()
+	for i := range featureType.NumField() {
+		field := featureType.Field(i)
+		if field.Type.Kind() != reflect.Bool {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		fields["features."+name] = boolField(func(m *Model) bool {
+			return m.Features != nil && reflect.ValueOf(m.Features).Elem().Field(i).Bool()
+		})
+	}
+	for _, modality := range []ModelModality{
+		ModelModalityText, ModelModalityAudio, ModelModalityImage,
+		ModelModalityVideo, ModelModalityPDF, ModelModalityEmbedding,
+	} {
+		fields["modalities.input."+string(modality)] = modalityField(false, modality)
+		fields["modalities.output."+string(modality)] = modalityField(true, modality)
+	}
+	return fields
+}()
+
+// queryNode is a node of a parsed query expression.
+type queryNode interface {
+	eval(m *Model) bool
+}
+
+type queryAnd struct{ left, right queryNode }
+
+func (n queryAnd) eval(m *Model) bool { return n.left.eval(m) && n.right.eval(m) }
+
+type queryOr struct{ left, right queryNode }
+
+func (n queryOr) eval(m *Model) bool { return n.left.eval(m) || n.right.eval(m) }
+
+type queryNot struct{ operand queryNode }
+
+func (n queryNot) eval(m *Model) bool { return !n.operand.eval(m) }
+
+type queryComparison struct {
+	field modelQueryField
+	op    string
+	value queryValue
+}
+
+func (n queryComparison) eval(m *Model) bool {
+	got, ok := n.field.value(m)
+	if !ok {
+		return false
+	}
+	var order int
+	switch got.kind {
+	case queryNumber:
+		order = cmp.Compare(got.num, n.value.num)
+	case queryBool:
+		if got.b != n.value.b {
+			order = 1
+		}
+	case queryString:
+		if !strings.EqualFold(got.str, n.value.str) {
+			order = 1
+		}
+	}
+	switch n.op {
+	case "==":
+		return order == 0
+	case "!=":
+		return order != 0
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	default:
+		return order >= 0
+	}
+}
+
+// queryTokenKind classifies a lexed query token.
+type queryTokenKind int
+
+const (
+	queryTokenEOF queryTokenKind = iota
+	queryTokenIdent
+	queryTokenNumber
+	queryTokenString
+	queryTokenOperator
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+// lexQuery splits a query expression into tokens.
+func lexQuery(expr string) ([]queryToken, error) {
+	var tokens []queryToken
+	for i := 0; i < len(expr); {
+		c := rune(expr[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case strings.HasPrefix(expr[i:], "&&") || strings.HasPrefix(expr[i:], "||") ||
+			strings.HasPrefix(expr[i:], "==") || strings.HasPrefix(expr[i:], "!=") ||
+			strings.HasPrefix(expr[i:], "<=") || strings.HasPrefix(expr[i:], ">="):
+			tokens = append(tokens, queryToken{kind: queryTokenOperator, text: expr[i : i+2], pos: i})
+			i += 2
+		case strings.ContainsRune("!<>()", c):
+			tokens = append(tokens, queryToken{kind: queryTokenOperator, text: string(c), pos: i})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexRune(expr[i+1:], c)
+			if end < 0 {
+				return nil, &errors.ValidationError{Field: "filter", Value: expr, Message: fmt.Sprintf("unterminated string at position %d", i+1)}
+			}
+			tokens = append(tokens, queryToken{kind: queryTokenString, text: expr[i+1 : i+1+end], pos: i})
+			i += end + 2
+		case c >= '0' && c <= '9' || c == '.' || c == '-':
+			start := i
+			for i < len(expr) && (expr[i] >= '0' && expr[i] <= '9' || strings.ContainsRune(".-_eE+", rune(expr[i]))) {
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: queryTokenNumber, text: expr[start:i], pos: start})
+		case c == '_' || unicode.IsLetter(c):
+			start := i
+			for i < len(expr) && (expr[i] == '_' || expr[i] == '.' || expr[i] == '-' ||
+				unicode.IsLetter(rune(expr[i])) || unicode.IsDigit(rune(expr[i]))) {
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: queryTokenIdent, text: expr[start:i], pos: start})
+		default:
+			return nil, &errors.ValidationError{Field: "filter", Value: expr, Message: fmt.Sprintf("unexpected %q at position %d", c, i+1)}
+		}
+	}
+	return append(tokens, queryToken{kind: queryTokenEOF, pos: len(expr)}), nil
+}
+
+// queryParser is a recursive-descent parser over lexed query tokens:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" or ")" | comparison
+//	comparison = field [ op value ]
+type queryParser struct {
+	expr   string
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() queryToken { return p.tokens[p.pos] }
+
+func (p *queryParser) next() queryToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != queryTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *queryParser) errorf(tok queryToken, format string, args ...any) error {
+	return &errors.ValidationError{
+		Field:   "filter",
+		Value:   p.expr,
+		Message: fmt.Sprintf(format, args...) + fmt.Sprintf(" at position %d", tok.pos+1),
+	}
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().text == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = queryOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().text == "&&" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = queryAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	tok := p.next()
+	switch {
+	case tok.kind == queryTokenOperator && tok.text == "!":
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return queryNot{operand: operand}, nil
+	case tok.kind == queryTokenOperator && tok.text == "(":
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.text != ")" || closing.kind != queryTokenOperator {
+			return nil, p.errorf(closing, "expected \")\"")
+		}
+		return node, nil
+	case tok.kind == queryTokenIdent:
+		return p.parseComparison(tok)
+	case tok.kind == queryTokenEOF:
+		return nil, p.errorf(tok, "expected a field")
+	default:
+		return nil, p.errorf(tok, "expected a field, found %q", tok.text)
+	}
+}
+
+func (p *queryParser) parseComparison(name queryToken) (queryNode, error) {
+	field, ok := modelQueryFields[name.text]
+	if !ok {
+		return nil, p.errorf(name, "unknown field %q", name.text)
+	}
+	switch p.peek().text {
+	case "==", "!=", "<", "<=", ">", ">=":
+	default:
+		if field.kind != queryBool {
+			return nil, p.errorf(name, "%s is a %s field and needs a comparison", name.text, field.kind)
+		}
+		return queryComparison{field: field, op: "==", value: queryValue{kind: queryBool, b: true}}, nil
+	}
+	op := p.next()
+	if field.kind != queryNumber && op.text != "==" && op.text != "!=" {
+		return nil, p.errorf(op, "%s is a %s field and only supports == and !=", name.text, field.kind)
+	}
+	value, err := p.parseValue(field.kind)
+	if err != nil {
+		return nil, err
+	}
+	return queryComparison{field: field, op: op.text, value: value}, nil
+}
+
+// parseValue parses a literal of kind. Unquoted words are accepted as
+// strings, so status == deprecated needs no quotes.
+func (p *queryParser) parseValue(kind queryKind) (queryValue, error) {
+	tok := p.next()
+	switch {
+	case kind == queryNumber && tok.kind == queryTokenNumber:
+		n, err := strconv.ParseFloat(strings.ReplaceAll(tok.text, "_", ""), 64)
+		if err != nil {
+			return queryValue{}, p.errorf(tok, "invalid number %q", tok.text)
+		}
+		return queryValue{kind: queryNumber, num: n}, nil
+	case kind == queryBool && tok.kind == queryTokenIdent && (tok.text == "true" || tok.text == "false"):
+		return queryValue{kind: queryBool, b: tok.text == "true"}, nil
+	case kind == queryString && tok.kind != queryTokenEOF && tok.kind != queryTokenOperator:
+		return queryValue{kind: queryString, str: tok.text}, nil
+	case tok.kind == queryTokenEOF:
+		return queryValue{}, p.errorf(tok, "expected a %s value", kind)
+	default:
+		return queryValue{}, p.errorf(tok, "expected a %s value, found %q", kind, tok.text)
+	}
+}
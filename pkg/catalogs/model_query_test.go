@@ -0,0 +1,90 @@
+package catalogs
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+func TestModelQueryMatch(t *testing.T) {
+	vision := &Model{
+		ID:     "vision-large",
+		Status: ModelStatusActive,
+		Features: &ModelFeatures{
+			Modalities: ModelModalities{Input: []ModelModality{ModelModalityText, ModelModalityImage}},
+			ToolCalls:  true,
+		},
+		Pricing: &ModelPricing{Tokens: &ModelTokenPricing{
+			Input:     &ModelTokenCost{Per1M: 0.5},
+			Output:    &ModelTokenCost{PerToken: 0.000002},
+			CacheRead: &ModelTokenCost{Per1M: 0.05},
+		}},
+		Limits: &ModelLimits{ContextWindow: 200_000},
+	}
+	unpriced := &Model{ID: "text-small", Status: ModelStatusDeprecated, Limits: &ModelLimits{ContextWindow: 8_000}}
+
+	tests := []struct {
+		expr     string
+		vision   bool
+		unpriced bool
+	}{
+		{expr: "pricing.input < 1.0 && features.vision && limits.context >= 128000", vision: true},
+		{expr: "pricing.output == 2", vision: true},
+		{expr: "pricing.cache_read <= 0.05", vision: true},
+		{expr: "pricing.input >= 0", vision: true},
+		{expr: "pricing.input != 3", vision: true},
+		{expr: "!(pricing.input < 1)", unpriced: true},
+		{expr: "features.tool_calls", vision: true},
+		{expr: "features.tool_calls == false", unpriced: true},
+		{expr: "!features.vision || limits.context_window > 100_000", vision: true, unpriced: true},
+		{expr: "modalities.input.image && !modalities.output.image", vision: true},
+		{expr: "status == deprecated", unpriced: true},
+		{expr: "id == 'VISION-LARGE' || id == \"text-small\"", vision: true, unpriced: true},
+		{expr: "limits.output > 0"},
+		{expr: "features.vision && limits.context < 10000 || status == active", vision: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			query, err := ParseModelQuery(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseModelQuery: %v", err)
+			}
+			if got := query.Match(vision); got != tt.vision {
+				t.Errorf("Match(vision) = %v, want %v", got, tt.vision)
+			}
+			if got := query.Match(unpriced); got != tt.unpriced {
+				t.Errorf("Match(unpriced) = %v, want %v", got, tt.unpriced)
+			}
+		})
+	}
+
+	var none *ModelQuery
+	if !none.Match(unpriced) {
+		t.Error("nil query did not match")
+	}
+}
+
+func TestParseModelQueryErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"pricing.unknown < 1",
+		"limits.context",
+		"features.vision < true",
+		"features.vision == 1",
+		"limits.context >= large",
+		"status > active",
+		"(features.vision",
+		"features.vision features.tools",
+		"features.vision &&",
+		"name == 'open",
+		"limits.context >= 1.2.3",
+		"features.vision # comment",
+	} {
+		_, err := ParseModelQuery(expr)
+		var validation *errors.ValidationError
+		if !stderrors.As(err, &validation) || validation.Field != "filter" {
+			t.Errorf("ParseModelQuery(%q) error = %v, want a filter validation error", expr, err)
+		}
+	}
+}
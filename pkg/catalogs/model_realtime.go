@@ -0,0 +1,81 @@
+package catalogs
+
+import "time"
+
+// RealtimeAPI identifies a bidirectional streaming (voice) API style.
+type RealtimeAPI string
+
+// String returns the string representation of a RealtimeAPI.
+func (r RealtimeAPI) String() string {
+	return string(r)
+}
+
+// Realtime API styles.
+const (
+	RealtimeAPIOpenAI     RealtimeAPI = "openai_realtime" // OpenAI Realtime API (session events over WebSocket or WebRTC)
+	RealtimeAPIGeminiLive RealtimeAPI = "gemini_live"     // Gemini Live API (BidiGenerateContent over WebSocket)
+)
+
+// RealtimeAudioFormat names an audio encoding accepted or produced by a
+// realtime session.
+type RealtimeAudioFormat string
+
+// String returns the string representation of a RealtimeAudioFormat.
+func (f RealtimeAudioFormat) String() string {
+	return string(f)
+}
+
+// Realtime audio formats.
+const (
+	RealtimeAudioFormatPCM16    RealtimeAudioFormat = "pcm16"     // 16-bit little-endian PCM
+	RealtimeAudioFormatG711ULaw RealtimeAudioFormat = "g711_ulaw" // G.711 mu-law, 8 kHz telephony
+	RealtimeAudioFormatG711ALaw RealtimeAudioFormat = "g711_alaw" // G.711 A-law, 8 kHz telephony
+)
+
+// ModelRealtime describes realtime (low-latency voice) session support.
+type ModelRealtime struct {
+	API                RealtimeAPI             `json:"api" yaml:"api"`                                                       // Session protocol style
+	Transports         []ModelResponseProtocol `json:"transports,omitempty" yaml:"transports,omitempty"`                     // Connection transports (websocket, webrtc)
+	InputAudioFormats  []RealtimeAudioFormat   `json:"input_audio_formats,omitempty" yaml:"input_audio_formats,omitempty"`   // Accepted input audio encodings
+	OutputAudioFormats []RealtimeAudioFormat   `json:"output_audio_formats,omitempty" yaml:"output_audio_formats,omitempty"` // Produced output audio encodings
+	InputSampleRate    int                     `json:"input_sample_rate,omitempty" yaml:"input_sample_rate,omitempty"`       // Input sample rate in Hz
+	OutputSampleRate   int                     `json:"output_sample_rate,omitempty" yaml:"output_sample_rate,omitempty"`     // Output sample rate in Hz
+	MaxSessionDuration *time.Duration          `json:"max_session_duration,omitempty" yaml:"max_session_duration,omitempty"` // Longest single session
+}
+
+// OpenAIRealtime returns the session profile of the OpenAI Realtime API.
+func OpenAIRealtime() *ModelRealtime {
+	maxSession := time.Hour
+	formats := []RealtimeAudioFormat{RealtimeAudioFormatPCM16, RealtimeAudioFormatG711ULaw, RealtimeAudioFormatG711ALaw}
+	return &ModelRealtime{
+		API:                RealtimeAPIOpenAI,
+		Transports:         []ModelResponseProtocol{ModelResponseProtocolWebSocket, ModelResponseProtocolWebRTC},
+		InputAudioFormats:  formats,
+		OutputAudioFormats: append([]RealtimeAudioFormat(nil), formats...),
+		InputSampleRate:    24000,
+		OutputSampleRate:   24000,
+		MaxSessionDuration: &maxSession,
+	}
+}
+
+// GeminiLive returns the session profile of the Gemini Live API.
+func GeminiLive() *ModelRealtime {
+	return &ModelRealtime{
+		API:                RealtimeAPIGeminiLive,
+		Transports:         []ModelResponseProtocol{ModelResponseProtocolWebSocket},
+		InputAudioFormats:  []RealtimeAudioFormat{RealtimeAudioFormatPCM16},
+		OutputAudioFormats: []RealtimeAudioFormat{RealtimeAudioFormatPCM16},
+		InputSampleRate:    16000,
+		OutputSampleRate:   24000,
+	}
+}
+
+// ModelRealtimePricing represents costs specific to realtime sessions.
+// Audio is billed per audio token; text in the same session uses the regular
+// token prices.
+type ModelRealtimePricing struct {
+	AudioInput     *ModelTokenCost `json:"audio_input,omitempty" yaml:"audio_input,omitempty"`           // Input audio tokens
+	AudioOutput    *ModelTokenCost `json:"audio_output,omitempty" yaml:"audio_output,omitempty"`         // Output audio tokens
+	AudioCacheRead *ModelTokenCost `json:"audio_cache_read,omitempty" yaml:"audio_cache_read,omitempty"` // Cached input audio tokens
+	SessionMinute  *float64        `json:"session_minute,omitempty" yaml:"session_minute,omitempty"`     // Flat cost per connected session minute
+}
@@ -0,0 +1,71 @@
+package catalogs
+
+import "slices"
+
+// ToolDialect identifies the wire format a model uses for tool/function calling.
+type ToolDialect string
+
+// String returns the string representation of a ToolDialect.
+func (td ToolDialect) String() string {
+	return string(td)
+}
+
+// Tool calling dialects.
+const (
+	ToolDialectOpenAITools                ToolDialect = "openai_tools"                 // OpenAI "tools" definitions with "tool_calls" in responses
+	ToolDialectAnthropicToolUse           ToolDialect = "anthropic_tool_use"           // Anthropic "tools" definitions with "tool_use" content blocks
+	ToolDialectGeminiFunctionDeclarations ToolDialect = "gemini_function_declarations" // Gemini "functionDeclarations" with "functionCall" parts
+)
+
+// ToolDialectForEndpoint returns the tool calling dialect implied by a
+// provider's API style, or an empty dialect for unknown styles.
+func ToolDialectForEndpoint(endpointType EndpointType) ToolDialect {
+	switch endpointType {
+	case EndpointTypeOpenAI, EndpointTypeOpenRouter, EndpointTypeTogether, EndpointTypePerplexity:
+		return ToolDialectOpenAITools
+	case EndpointTypeAnthropic:
+		return ToolDialectAnthropicToolUse
+	case EndpointTypeGoogle, EndpointTypeGoogleCloud:
+		return ToolDialectGeminiFunctionDeclarations
+	default:
+		return ""
+	}
+}
+
+// ToolCalling is the resolved tool calling profile of a model as served by
+// one provider. Nil booleans mean the catalog has no data for that property.
+type ToolCalling struct {
+	Supported       bool          `json:"supported" yaml:"supported"`
+	Dialects        []ToolDialect `json:"dialects,omitempty" yaml:"dialects,omitempty"`
+	ToolChoices     []ToolChoice  `json:"tool_choices,omitempty" yaml:"tool_choices,omitempty"`
+	ParallelCalls   *bool         `json:"parallel_calls,omitempty" yaml:"parallel_calls,omitempty"`
+	StreamingDeltas *bool         `json:"streaming_deltas,omitempty" yaml:"streaming_deltas,omitempty"`
+}
+
+// ToolCalling resolves the model's tool calling profile for a provider.
+// Dialects recorded on the model take precedence; otherwise the dialect is
+// derived from the provider's API style when the model accepts tools.
+func (m *Model) ToolCalling(provider *Provider) ToolCalling {
+	var profile ToolCalling
+	if m == nil {
+		return profile
+	}
+	if m.Features != nil {
+		profile.Supported = m.Features.Tools || m.Features.ToolCalls
+	}
+	if m.Tools != nil {
+		profile.Dialects = slices.Clone(m.Tools.Dialects)
+		profile.ToolChoices = slices.Clone(m.Tools.ToolChoices)
+		profile.ParallelCalls = copyPtr(m.Tools.ParallelCalls)
+		profile.StreamingDeltas = copyPtr(m.Tools.StreamingDeltas)
+		if len(profile.Dialects) > 0 {
+			profile.Supported = true
+		}
+	}
+	if profile.Supported && len(profile.Dialects) == 0 && provider != nil && provider.Catalog != nil {
+		if dialect := ToolDialectForEndpoint(provider.Catalog.Endpoint.Type); dialect != "" {
+			profile.Dialects = []ToolDialect{dialect}
+		}
+	}
+	return profile
+}
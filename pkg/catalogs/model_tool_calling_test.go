@@ -0,0 +1,55 @@
+package catalogs
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestModelToolCallingDerivesDialectFromProvider(t *testing.T) {
+	model := &Model{ID: "m", Features: &ModelFeatures{Tools: true, ToolCalls: true}}
+	for endpointType, want := range map[EndpointType]ToolDialect{
+		EndpointTypeOpenAI:      ToolDialectOpenAITools,
+		EndpointTypeAnthropic:   ToolDialectAnthropicToolUse,
+		EndpointTypeGoogle:      ToolDialectGeminiFunctionDeclarations,
+		EndpointTypeGoogleCloud: ToolDialectGeminiFunctionDeclarations,
+	} {
+		provider := &Provider{Catalog: &ProviderCatalog{Endpoint: ProviderEndpoint{Type: endpointType}}}
+		profile := model.ToolCalling(provider)
+		if !profile.Supported || !slices.Equal(profile.Dialects, []ToolDialect{want}) {
+			t.Fatalf("%s profile = %#v, want dialect %s", endpointType, profile, want)
+		}
+	}
+}
+
+func TestModelToolCallingPrefersRecordedDialects(t *testing.T) {
+	parallel := true
+	model := &Model{
+		ID:       "m",
+		Features: &ModelFeatures{Tools: true},
+		Tools: &ModelTools{
+			Dialects:      []ToolDialect{ToolDialectOpenAITools, ToolDialectAnthropicToolUse},
+			ParallelCalls: &parallel,
+		},
+	}
+	provider := &Provider{Catalog: &ProviderCatalog{Endpoint: ProviderEndpoint{Type: EndpointTypeGoogle}}}
+
+	profile := model.ToolCalling(provider)
+	if !slices.Equal(profile.Dialects, model.Tools.Dialects) {
+		t.Fatalf("dialects = %v, want recorded %v", profile.Dialects, model.Tools.Dialects)
+	}
+	if profile.ParallelCalls == nil || !*profile.ParallelCalls || profile.StreamingDeltas != nil {
+		t.Fatalf("profile = %#v", profile)
+	}
+	*profile.ParallelCalls = false
+	if !*model.Tools.ParallelCalls {
+		t.Fatal("profile aliases the model's ParallelCalls pointer")
+	}
+}
+
+func TestModelToolCallingWithoutToolSupport(t *testing.T) {
+	model := &Model{ID: "m", Features: &ModelFeatures{Streaming: true}}
+	provider := &Provider{Catalog: &ProviderCatalog{Endpoint: ProviderEndpoint{Type: EndpointTypeOpenAI}}}
+	if profile := model.ToolCalling(provider); profile.Supported || len(profile.Dialects) != 0 {
+		t.Fatalf("profile = %#v, want unsupported", profile)
+	}
+}
@@ -0,0 +1,143 @@
+package catalogs
+
+import (
+	"math"
+	"slices"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// ImageTokenMethod names how a provider converts an input image into tokens.
+type ImageTokenMethod string
+
+// String returns the string representation of an ImageTokenMethod.
+func (m ImageTokenMethod) String() string {
+	return string(m)
+}
+
+// Image token methods.
+const (
+	ImageTokenMethodFixed  ImageTokenMethod = "fixed"  // Every image costs BaseTokens
+	ImageTokenMethodTiles  ImageTokenMethod = "tiles"  // BaseTokens plus TileTokens per TileSize square
+	ImageTokenMethodPixels ImageTokenMethod = "pixels" // BaseTokens plus width × height / PixelsPerToken
+)
+
+// ImageTokenFormula describes how many input tokens one image costs.
+// Images are first scaled down to fit FitLongEdge and FitShortEdge, then
+// counted with Method.
+type ImageTokenFormula struct {
+	Method          ImageTokenMethod `json:"method" yaml:"method"`                                           // Counting method
+	BaseTokens      int64            `json:"base_tokens,omitempty" yaml:"base_tokens,omitempty"`             // Tokens charged for every image
+	TileTokens      int64            `json:"tile_tokens,omitempty" yaml:"tile_tokens,omitempty"`             // Tokens per tile (tiles method)
+	TileSize        int              `json:"tile_size,omitempty" yaml:"tile_size,omitempty"`                 // Tile edge in pixels (tiles method)
+	PixelsPerToken  int64            `json:"pixels_per_token,omitempty" yaml:"pixels_per_token,omitempty"`   // Pixels per token (pixels method)
+	FitLongEdge     int              `json:"fit_long_edge,omitempty" yaml:"fit_long_edge,omitempty"`         // Longest side after downscaling (0 = no limit)
+	FitShortEdge    int              `json:"fit_short_edge,omitempty" yaml:"fit_short_edge,omitempty"`       // Shortest side after downscaling (0 = no limit)
+	LowDetailTokens int64            `json:"low_detail_tokens,omitempty" yaml:"low_detail_tokens,omitempty"` // Flat cost of a low-detail request (0 = no low-detail mode)
+}
+
+// ModelVision describes image input constraints. Providers record their
+// default limits; models override them when they differ.
+type ModelVision struct {
+	MaxImages    *int               `json:"max_images,omitempty" yaml:"max_images,omitempty"`         // Maximum images per request
+	MaxWidth     int                `json:"max_width,omitempty" yaml:"max_width,omitempty"`           // Maximum accepted width in pixels
+	MaxHeight    int                `json:"max_height,omitempty" yaml:"max_height,omitempty"`         // Maximum accepted height in pixels
+	MaxImageSize *int64             `json:"max_image_size,omitempty" yaml:"max_image_size,omitempty"` // Maximum image size in bytes
+	Formats      []string           `json:"formats,omitempty" yaml:"formats,omitempty"`               // Accepted image MIME types
+	TokenCost    *ImageTokenFormula `json:"token_cost,omitempty" yaml:"token_cost,omitempty"`         // Input tokens per image
+}
+
+// AcceptsFormat reports whether mimeType is an accepted image format. An
+// empty format list accepts everything.
+func (v *ModelVision) AcceptsFormat(mimeType string) bool {
+	return v != nil && (len(v.Formats) == 0 || slices.Contains(v.Formats, mimeType))
+}
+
+// VisionFor resolves the model's image input constraints as served by
+// provider. Model-level data takes precedence over the provider default,
+// which only applies to models that accept image input.
+func (m *Model) VisionFor(provider *Provider) *ModelVision {
+	if m == nil {
+		return nil
+	}
+	if m.Vision != nil {
+		return deepCopyModelVision(m.Vision)
+	}
+	if provider == nil || provider.Vision == nil || m.Features == nil ||
+		!slices.Contains(m.Features.Modalities.Input, ModelModalityImage) {
+		return nil
+	}
+	return deepCopyModelVision(provider.Vision)
+}
+
+// Tokens returns the input tokens charged for one width × height image.
+func (f *ImageTokenFormula) Tokens(width, height int) (int64, error) {
+	if f == nil {
+		return 0, &errors.ValidationError{Field: "token_cost", Message: "is required"}
+	}
+	if width <= 0 || height <= 0 {
+		return 0, &errors.ValidationError{Field: "dimensions", Value: [2]int{width, height}, Message: "must be positive"}
+	}
+
+	w, h := float64(width), float64(height)
+	if f.FitLongEdge > 0 && math.Max(w, h) > float64(f.FitLongEdge) {
+		scale := float64(f.FitLongEdge) / math.Max(w, h)
+		w, h = w*scale, h*scale
+	}
+	if f.FitShortEdge > 0 && math.Min(w, h) > float64(f.FitShortEdge) {
+		scale := float64(f.FitShortEdge) / math.Min(w, h)
+		w, h = w*scale, h*scale
+	}
+
+	switch f.Method {
+	case ImageTokenMethodFixed:
+		return f.BaseTokens, nil
+	case ImageTokenMethodTiles:
+		if f.TileSize <= 0 {
+			return 0, &errors.ValidationError{Field: "token_cost.tile_size", Value: f.TileSize, Message: "must be positive for the tiles method"}
+		}
+		tiles := int64(math.Ceil(w/float64(f.TileSize))) * int64(math.Ceil(h/float64(f.TileSize)))
+		return f.BaseTokens + tiles*f.TileTokens, nil
+	case ImageTokenMethodPixels:
+		if f.PixelsPerToken <= 0 {
+			return 0, &errors.ValidationError{Field: "token_cost.pixels_per_token", Value: f.PixelsPerToken, Message: "must be positive for the pixels method"}
+		}
+		return f.BaseTokens + int64(math.Ceil(math.Round(w)*math.Round(h)/float64(f.PixelsPerToken))), nil
+	default:
+		return 0, &errors.ValidationError{Field: "token_cost.method", Value: f.Method, Message: "is not a known image token method"}
+	}
+}
+
+// ImageInputCost estimates the price of one width × height input image on
+// model as served by provider: its tokens at the input token price plus any
+// flat per-image operation price. It fails when the model has no image token
+// formula or the image exceeds the recorded resolution limits.
+func (m *Model) ImageInputCost(provider *Provider, width, height int) (float64, error) {
+	vision := m.VisionFor(provider)
+	if vision == nil || vision.TokenCost == nil {
+		return 0, &errors.ValidationError{Field: "vision.token_cost", Message: "is not recorded for this model"}
+	}
+	if (vision.MaxWidth > 0 && width > vision.MaxWidth) || (vision.MaxHeight > 0 && height > vision.MaxHeight) {
+		return 0, &errors.ValidationError{Field: "dimensions", Value: [2]int{width, height}, Message: "exceeds the model's maximum image resolution"}
+	}
+	tokens, err := vision.TokenCost.Tokens(width, height)
+	if err != nil {
+		return 0, err
+	}
+
+	var cost float64
+	if m.Pricing != nil && m.Pricing.Tokens != nil && m.Pricing.Tokens.Input != nil {
+		cost += float64(tokens) * perTokenPrice(m.Pricing.Tokens.Input)
+	}
+	if m.Pricing != nil && m.Pricing.Operations != nil && m.Pricing.Operations.ImageInput != nil {
+		cost += *m.Pricing.Operations.ImageInput
+	}
+	return cost, nil
+}
+
+func perTokenPrice(cost *ModelTokenCost) float64 {
+	if cost.PerToken != 0 {
+		return cost.PerToken
+	}
+	return cost.Per1M / 1_000_000
+}
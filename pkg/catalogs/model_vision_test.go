@@ -0,0 +1,122 @@
+package catalogs
+
+import (
+	stderrors "errors"
+	"math"
+	"testing"
+
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func TestImageTokenFormulaTokens(t *testing.T) {
+	openAI := &ImageTokenFormula{
+		Method: ImageTokenMethodTiles, BaseTokens: 85, TileTokens: 170, TileSize: 512,
+		FitLongEdge: 2048, FitShortEdge: 768,
+	}
+	anthropic := &ImageTokenFormula{Method: ImageTokenMethodPixels, PixelsPerToken: 750, FitLongEdge: 1568}
+	gemini := &ImageTokenFormula{Method: ImageTokenMethodTiles, TileTokens: 258, TileSize: 768}
+
+	tests := []struct {
+		name          string
+		formula       *ImageTokenFormula
+		width, height int
+		want          int64
+	}{
+		// 1024x1024 scales to 768x768: 4 tiles.
+		{name: "openai square", formula: openAI, width: 1024, height: 1024, want: 765},
+		// 2048x4096 fits to 1024x2048, then 768x1536: 2x3 tiles.
+		{name: "openai tall", formula: openAI, width: 2048, height: 4096, want: 1105},
+		{name: "anthropic small", formula: anthropic, width: 1000, height: 1000, want: 1334},
+		{name: "anthropic downscaled", formula: anthropic, width: 3136, height: 3136, want: 3279},
+		{name: "gemini small", formula: gemini, width: 384, height: 384, want: 258},
+		{name: "gemini tiled", formula: gemini, width: 1000, height: 1000, want: 1032},
+		{name: "fixed", formula: &ImageTokenFormula{Method: ImageTokenMethodFixed, BaseTokens: 560}, width: 4000, height: 3000, want: 560},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.formula.Tokens(test.width, test.height)
+			if err != nil {
+				t.Fatalf("Tokens: %v", err)
+			}
+			if got != test.want {
+				t.Fatalf("Tokens(%d, %d) = %d, want %d", test.width, test.height, got, test.want)
+			}
+		})
+	}
+}
+
+func TestImageTokenFormulaRejectsInvalidInput(t *testing.T) {
+	var validationErr *pkgerrors.ValidationError
+	if _, err := (&ImageTokenFormula{Method: ImageTokenMethodFixed}).Tokens(0, 10); !stderrors.As(err, &validationErr) {
+		t.Fatalf("zero width error = %v, want ValidationError", err)
+	}
+	if _, err := (&ImageTokenFormula{Method: ImageTokenMethodTiles}).Tokens(10, 10); !stderrors.As(err, &validationErr) {
+		t.Fatalf("missing tile size error = %v, want ValidationError", err)
+	}
+	if _, err := (&ImageTokenFormula{Method: "area"}).Tokens(10, 10); !stderrors.As(err, &validationErr) {
+		t.Fatalf("unknown method error = %v, want ValidationError", err)
+	}
+}
+
+func TestModelVisionForAppliesProviderDefaultToImageModels(t *testing.T) {
+	maxImages := 100
+	provider := &Provider{Vision: &ModelVision{
+		MaxImages: &maxImages,
+		Formats:   []string{"image/png"},
+		TokenCost: &ImageTokenFormula{Method: ImageTokenMethodPixels, PixelsPerToken: 750},
+	}}
+
+	vision := (&Model{ID: "vision", Features: &ModelFeatures{Modalities: ModelModalities{
+		Input: []ModelModality{ModelModalityText, ModelModalityImage},
+	}}}).VisionFor(provider)
+	if vision == nil || !vision.AcceptsFormat("image/png") || vision.AcceptsFormat("image/gif") {
+		t.Fatalf("inherited = %#v", vision)
+	}
+	*vision.MaxImages = 1
+	if *provider.Vision.MaxImages != 100 {
+		t.Fatal("resolved vision aliases the provider default")
+	}
+
+	textOnly := &Model{ID: "text", Features: &ModelFeatures{Modalities: ModelModalities{Input: []ModelModality{ModelModalityText}}}}
+	if got := textOnly.VisionFor(provider); got != nil {
+		t.Fatalf("text-only vision = %#v, want nil", got)
+	}
+
+	override := (&Model{ID: "override", Vision: &ModelVision{MaxWidth: 2000}}).VisionFor(provider)
+	if override.MaxWidth != 2000 || override.MaxImages != nil {
+		t.Fatalf("override = %#v", override)
+	}
+}
+
+func TestModelImageInputCost(t *testing.T) {
+	imagePrice := 0.001
+	model := &Model{
+		ID: "vision",
+		Vision: &ModelVision{
+			MaxWidth:  2000,
+			MaxHeight: 2000,
+			TokenCost: &ImageTokenFormula{Method: ImageTokenMethodPixels, PixelsPerToken: 750},
+		},
+		Pricing: &ModelPricing{
+			Currency:   ModelPricingCurrencyUSD,
+			Tokens:     &ModelTokenPricing{Input: &ModelTokenCost{Per1M: 3}},
+			Operations: &ModelOperationPricing{ImageInput: &imagePrice},
+		},
+	}
+
+	cost, err := model.ImageInputCost(nil, 750, 1000)
+	if err != nil {
+		t.Fatalf("ImageInputCost: %v", err)
+	}
+	if want := 1000*3.0/1_000_000 + imagePrice; math.Abs(cost-want) > 1e-12 {
+		t.Fatalf("cost = %v, want %v", cost, want)
+	}
+
+	if _, err := model.ImageInputCost(nil, 4000, 1000); err == nil {
+		t.Fatal("oversized image returned nil error")
+	}
+	if _, err := (&Model{ID: "blind"}).ImageInputCost(nil, 10, 10); err == nil {
+		t.Fatal("model without vision data returned nil error")
+	}
+}
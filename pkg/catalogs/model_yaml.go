@@ -70,6 +70,12 @@ func (m *Model) EncodeYAML() (string, error) {
 		yaml.HeadComment(" Timestamps"),
 	}
 
+	// Preserve field pins
+	for _, field := range m.Pins {
+		path := "$." + field
+		commentMap[path] = append(commentMap[path], yaml.LineComment(" "+PinComment))
+	}
+
 	// Marshal with proper formatting options (using IndentSequence(false) as requested)
 	yamlData, err := yaml.MarshalWithOptions(m,
 		yaml.Indent(2),                        // 2-space indentation
@@ -178,8 +184,7 @@ func postProcessModelYAML(yamlContent string) string {
 			processedLine = strings.Replace(line, "per_1m: 10.0", "per_1m: 10.00", 1)
 		} else if strings.Contains(line, "description: \"") {
 			// Convert quoted description to block scalar format
-			processedLine = strings.Replace(line, "description: \"", "description: |-\n  ", 1)
-			processedLine = strings.ReplaceAll(processedLine, "\"", "")
+			processedLine = literalDescription(line)
 		}
 
 		result = append(result, processedLine)
@@ -188,11 +193,36 @@ func postProcessModelYAML(yamlContent string) string {
 	return strings.Join(result, "\n")
 }
 
+// literalDescription rewrites a double-quoted description line as a literal
+// block scalar. The quoted value is decoded first so its escapes are not
+// written into the block, where they would be read back literally. Values a
+// single-line block cannot hold unchanged stay quoted.
+func literalDescription(line string) string {
+	key := strings.Index(line, "description: \"")
+	indent := line[:key]
+	if strings.TrimSpace(indent) != "" {
+		return line
+	}
+	var parsed struct {
+		Description string `yaml:"description"`
+	}
+	if err := yaml.Unmarshal([]byte(line[key:]), &parsed); err != nil {
+		return line
+	}
+	value := parsed.Description
+	if value == "" || strings.ContainsAny(value, "\n\r") || value != strings.TrimSpace(value) {
+		return line
+	}
+	return indent + "description: |-\n" + indent + "  " + value
+}
+
 // FormatYAMLHeaderComment returns a descriptive string for the model header comment.
 func (m *Model) FormatYAMLHeaderComment() string {
 	if m.Description != "" {
 		// Trim the description
 		desc := strings.TrimSpace(m.Description)
+		// A line break would end the comment and spill the rest into the document
+		desc = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(desc)
 		// Use first sentence or up to 60 characters of description
 		if len(desc) > 60 {
 			desc = desc[:60] + "..."
@@ -0,0 +1,142 @@
+package catalogs
+
+import (
+	stderrors "errors"
+	"io/fs"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// PinComment is the YAML comment that pins a model field. Sync keeps the
+// value written in the catalog file instead of overwriting it:
+//
+//	name: GPT-4o (curated) # starmap:pin
+const PinComment = "starmap:pin"
+
+// PinsFile is the catalog file that pins model fields without annotating the
+// model files themselves:
+//
+//	pins:
+//	- provider: openai
+//	  model: gpt-4o
+//	  fields: [name, pricing.tokens.input]
+const PinsFile = "pins.yaml"
+
+// FieldPin pins one field of a provider model. Field is a dotted YAML path
+// within the model file, such as name or pricing.tokens.input; pinning a
+// mapping pins everything below it.
+type FieldPin struct {
+	Provider ProviderID `json:"provider" yaml:"provider"`
+	Model    string     `json:"model" yaml:"model"`
+	Field    string     `json:"field" yaml:"field"`
+}
+
+// pinsFile is the on-disk layout of PinsFile.
+type pinsFile struct {
+	Pins []struct {
+		Provider ProviderID `yaml:"provider"`
+		Model    string     `yaml:"model"`
+		Fields   []string   `yaml:"fields"`
+	} `yaml:"pins"`
+}
+
+// Pins returns every field pin in the catalog: those declared in PinsFile and
+// those annotated with PinComment in provider model files, sorted and
+// de-duplicated.
+func (cat *Builder) Pins() []FieldPin {
+	pins := slices.Clone(cat.pins)
+	for _, provider := range cat.providers.List() {
+		for _, model := range provider.Models {
+			if model == nil {
+				continue
+			}
+			for _, field := range model.Pins {
+				pins = append(pins, FieldPin{Provider: provider.ID, Model: model.ID, Field: field})
+			}
+		}
+	}
+	sort.Slice(pins, func(i, j int) bool {
+		if pins[i].Provider != pins[j].Provider {
+			return pins[i].Provider < pins[j].Provider
+		}
+		if pins[i].Model != pins[j].Model {
+			return pins[i].Model < pins[j].Model
+		}
+		return pins[i].Field < pins[j].Field
+	})
+	return slices.Compact(pins)
+}
+
+// loadPinsYAML loads field pins from the pins file.
+func (cat *Builder) loadPinsYAML() error {
+	data, err := fs.ReadFile(cat.config.readFilesystem(), PinsFile)
+	if err != nil {
+		if stderrors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return errors.WrapIO("read", PinsFile, err)
+	}
+
+	var file pinsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return errors.WrapParse("yaml", PinsFile, err)
+	}
+
+	pins := make([]FieldPin, 0, len(file.Pins))
+	for _, entry := range file.Pins {
+		if entry.Provider == "" || entry.Model == "" {
+			return &errors.ValidationError{Field: "pins", Value: entry, Message: "provider and model are required"}
+		}
+		for _, field := range entry.Fields {
+			field = normalizePinField(field)
+			if field == "" {
+				return &errors.ValidationError{Field: "pins.fields", Value: entry, Message: "field paths must not be empty"}
+			}
+			pins = append(pins, FieldPin{Provider: entry.Provider, Model: entry.Model, Field: field})
+		}
+	}
+	cat.pins = pins
+	return nil
+}
+
+// modelPins returns the fields annotated with PinComment in a model file's
+// comments. A pin on a sequence item pins the whole sequence.
+func modelPins(comments yaml.CommentMap) []string {
+	var pins []string
+	for path, group := range comments {
+		if !hasPinComment(group) {
+			continue
+		}
+		if field := normalizePinField(path); field != "" {
+			pins = append(pins, field)
+		}
+	}
+	slices.Sort(pins)
+	return slices.Compact(pins)
+}
+
+func hasPinComment(comments []*yaml.Comment) bool {
+	for _, comment := range comments {
+		for _, text := range comment.Texts {
+			if strings.TrimSpace(text) == PinComment {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizePinField turns a YAML path such as $.pricing.tokens or
+// metadata.tags[0] into a dotted field path.
+func normalizePinField(path string) string {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+	if i := strings.IndexByte(path, '['); i >= 0 {
+		path = path[:i]
+	}
+	return strings.Trim(path, ".")
+}
@@ -0,0 +1,61 @@
+package catalogs
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFieldPins(t *testing.T) {
+	fsys := fstest.MapFS{
+		"providers.yaml": {Data: []byte("- id: openai\n  name: OpenAI\n")},
+		"providers/openai/models/gpt-4o.yaml": {Data: []byte(`id: gpt-4o
+name: GPT-4o (curated) # starmap:pin
+# starmap:pin
+description: Curated description
+pricing:
+  currency: USD
+  tokens:
+    input:
+      per_1m: 2.5 # starmap:pin
+`)},
+		PinsFile: {Data: []byte(`pins:
+- provider: openai
+  model: gpt-4o
+  fields: [limits, name]
+`)},
+	}
+	builder, err := NewFromFS(fsys, ".")
+	require.NoError(t, err)
+
+	assert.Equal(t, []FieldPin{
+		{Provider: "openai", Model: "gpt-4o", Field: "description"},
+		{Provider: "openai", Model: "gpt-4o", Field: "limits"},
+		{Provider: "openai", Model: "gpt-4o", Field: "name"},
+		{Provider: "openai", Model: "gpt-4o", Field: "pricing.tokens.input.per_1m"},
+	}, builder.Pins())
+
+	model, err := builder.ProviderModel("openai", "gpt-4o")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"description", "name", "pricing.tokens.input.per_1m"}, model.Pins, "pins file entries stay out of model files")
+
+	encoded, err := model.EncodeYAML()
+	require.NoError(t, err)
+	assert.Contains(t, encoded, "name: GPT-4o (curated) # starmap:pin")
+	assert.Contains(t, encoded, "per_1m: 2.5 # starmap:pin")
+
+	reloaded := NewEmpty()
+	require.NoError(t, reloaded.SetProvider(Provider{ID: "openai", Name: "OpenAI"}))
+	require.NoError(t, reloaded.loadModelFile("providers/openai/models/gpt-4o.yaml", []byte(encoded)))
+	again, err := reloaded.ProviderModel("openai", "gpt-4o")
+	require.NoError(t, err)
+	assert.Equal(t, model.Pins, again.Pins)
+}
+
+func TestLoadFieldPinsRejectsIncompleteEntries(t *testing.T) {
+	_, err := NewFromFS(fstest.MapFS{PinsFile: {Data: []byte("pins:\n- model: gpt-4o\n  fields: [name]\n")}}, ".")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "provider and model are required")
+}
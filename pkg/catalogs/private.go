@@ -0,0 +1,254 @@
+package catalogs
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/provenance"
+)
+
+// Redacted returns a copy of the model without the fields listed in
+// Private, for public exports, the HTTP API, and generated docs. Each entry is
+// a dotted JSON path such as pricing, pricing.tokens.input, or
+// extensions.internal; a path that names a mapping withholds everything below
+// it. The Private list itself is also withheld.
+//
+// A path that does not name a model field is an error rather than being
+// ignored, so a typo cannot publish a field meant to be private.
+func (m Model) Redacted() (Model, error) {
+	redacted := DeepCopyModel(m)
+	redacted.Private = nil
+	for _, path := range m.Private {
+		segments := strings.Split(path, ".")
+		if path == "" || slices.Contains(segments, "") {
+			return Model{}, &errors.ValidationError{Field: "private", Value: path, Message: "must be a dotted field path"}
+		}
+		if segments[0] == "id" {
+			return Model{}, &errors.ValidationError{Field: "private", Value: path, Message: "the model ID identifies the model and cannot be private"}
+		}
+		if !clearField(reflect.ValueOf(&redacted).Elem(), segments) {
+			return Model{}, &errors.ValidationError{Field: "private", Value: path, Message: "does not name a model field"}
+		}
+	}
+	return redacted, nil
+}
+
+// clearField zeroes the field of value named by path, descending through
+// structs by JSON name, pointers, and string-keyed maps. Map entries are
+// deleted rather than zeroed. It reports whether path names a field.
+func clearField(value reflect.Value, path []string) bool {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return fieldPathExists(value.Type().Elem(), path)
+		}
+		return clearField(value.Elem(), path)
+	case reflect.Struct:
+		for i := range value.NumField() {
+			if jsonFieldName(value.Type().Field(i)) != path[0] {
+				continue
+			}
+			field := value.Field(i)
+			if len(path) == 1 {
+				field.SetZero()
+				return true
+			}
+			return clearField(field, path[1:])
+		}
+		return false
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return false
+		}
+		key := reflect.ValueOf(path[0]).Convert(value.Type().Key())
+		entry := value.MapIndex(key)
+		if !entry.IsValid() {
+			// An absent entry has nothing to withhold
+			return true
+		}
+		if len(path) == 1 {
+			value.SetMapIndex(key, reflect.Value{})
+			return true
+		}
+		// Map entries are not addressable, so clear a copy and store it back
+		copied := reflect.New(entry.Type()).Elem()
+		copied.Set(entry)
+		if !clearField(copied, path[1:]) {
+			return false
+		}
+		value.SetMapIndex(key, copied)
+		return true
+	default:
+		return false
+	}
+}
+
+// fieldPathExists reports whether path names a field of an unset value of
+// type t, which has nothing to withhold but must still be spelled correctly.
+func fieldPathExists(t reflect.Type, path []string) bool {
+	for _, segment := range path {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			found := false
+			for i := range t.NumField() {
+				if jsonFieldName(t.Field(i)) == segment {
+					t, found = t.Field(i).Type, true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		case reflect.Map:
+			if t.Key().Kind() != reflect.String {
+				return false
+			}
+			t = t.Elem()
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// jsonFieldName returns the JSON name of an exported struct field, or "" for
+// fields that are not serialized.
+func jsonFieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// Public returns the catalog as public audiences see it: every model is
+// redacted (see Model.Redacted), and provenance recorded for withheld fields
+// is dropped. A catalog without private fields is its own public view. The
+// view is built once, on first use, and kept for the life of the catalog.
+//
+// Internal tooling keeps reading the catalog itself, which retains full data.
+func (r *Catalog) Public() (*Catalog, error) {
+	r.publicOnce.Do(func() {
+		r.public, r.publicErr = r.buildPublic()
+	})
+	return r.public, r.publicErr
+}
+
+// buildPublic redacts every provider and author model of the catalog.
+func (r *Catalog) buildPublic() (*Catalog, error) {
+	private := make(map[string][]string)
+	for _, provider := range r.source.Providers().List() {
+		collectPrivate(private, provider.Models)
+	}
+	for _, author := range r.source.Authors().List() {
+		collectPrivate(private, author.Models)
+	}
+	if len(private) == 0 {
+		return r, nil
+	}
+
+	builder, err := NewBuilderFrom(r.source)
+	if err != nil {
+		return nil, errors.WrapResource("create", "public catalog", "", err)
+	}
+	for _, provider := range builder.Providers().List() {
+		if provider.Models, err = redactModels(provider.Models); err != nil {
+			return nil, errors.WrapResource("redact", "provider", string(provider.ID), err)
+		}
+		if err := builder.SetProvider(provider); err != nil {
+			return nil, err
+		}
+	}
+	for _, author := range builder.Authors().List() {
+		if author.Models, err = redactModels(author.Models); err != nil {
+			return nil, errors.WrapResource("redact", "author", string(author.ID), err)
+		}
+		if err := builder.SetAuthor(author); err != nil {
+			return nil, err
+		}
+	}
+	builder.provenance.Set(publicProvenance(builder.provenance.Map(), private))
+
+	public, err := buildCatalog(builder)
+	if err != nil {
+		return nil, errors.WrapResource("create", "public catalog", "", err)
+	}
+	return public, nil
+}
+
+// collectPrivate adds the private field paths of models to private, keyed by
+// model ID.
+func collectPrivate(private map[string][]string, models map[string]*Model) {
+	for _, model := range models {
+		if model != nil && len(model.Private) > 0 {
+			private[model.ID] = append(private[model.ID], model.Private...)
+		}
+	}
+}
+
+// redactModels returns models with each one redacted.
+func redactModels(models map[string]*Model) (map[string]*Model, error) {
+	redacted := make(map[string]*Model, len(models))
+	for id, model := range models {
+		if model == nil || len(model.Private) == 0 {
+			redacted[id] = model
+			continue
+		}
+		public, err := model.Redacted()
+		if err != nil {
+			return nil, errors.WrapResource("redact", "model", model.ID, err)
+		}
+		redacted[id] = &public
+	}
+	return redacted, nil
+}
+
+// publicProvenance drops the provenance of withheld model fields, whose
+// recorded values would otherwise publish them. Provenance of a mapping that
+// contains a withheld field is dropped as well. Keys are split at the last
+// colon, since model IDs such as llama3:8b contain colons and field paths
+// never do.
+func publicProvenance(all provenance.Map, private map[string][]string) provenance.Map {
+	public := make(provenance.Map, len(all))
+	for key, history := range all {
+		resourceType, rest, _ := strings.Cut(key, ":")
+		separator := strings.LastIndex(rest, ":")
+		if separator >= 0 && resourceType == string(catalogmeta.ResourceTypeModel) &&
+			overlapsPrivateField(rest[separator+1:], private[rest[:separator]]) {
+			continue
+		}
+		public[key] = history
+	}
+	return public
+}
+
+// overlapsPrivateField reports whether a provenance field path, such as
+// Pricing or limits.context_window, is a withheld path or contains or lies
+// within one. Paths compare without case or underscores, since provenance
+// records Go field names as well as JSON paths.
+func overlapsPrivateField(field string, private []string) bool {
+	field = normalizeFieldPath(field)
+	for _, path := range private {
+		path = normalizeFieldPath(path)
+		if field == path || strings.HasPrefix(field, path+".") || strings.HasPrefix(path, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeFieldPath(path string) string {
+	return strings.ToLower(strings.ReplaceAll(path, "_", ""))
+}
@@ -0,0 +1,147 @@
+package catalogs
+
+import (
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/provenance"
+)
+
+func privateTestModel() Model {
+	return Model{
+		ID:          "gpt-4o",
+		Name:        "GPT-4o",
+		Description: "Internal notes",
+		Pricing: &ModelPricing{
+			Currency: ModelPricingCurrencyUSD,
+			Tokens: &ModelTokenPricing{
+				Input:  &ModelTokenCost{Per1M: 2.25},
+				Output: &ModelTokenCost{Per1M: 10},
+			},
+		},
+		Extensions: SourceExtensions{
+			"internal": {Fields: map[string]any{"owner": "platform"}},
+			"openai":   {Fields: map[string]any{"object": "model"}},
+		},
+		Private: []string{"description", "pricing.tokens.input", "extensions.internal", "limits"},
+	}
+}
+
+func TestModelRedactedWithholdsPrivateFields(t *testing.T) {
+	model := privateTestModel()
+	redacted, err := model.Redacted()
+	if err != nil {
+		t.Fatalf("Redacted: %v", err)
+	}
+
+	if redacted.Description != "" || redacted.Private != nil {
+		t.Fatalf("redacted description = %q, private = %v", redacted.Description, redacted.Private)
+	}
+	if redacted.Pricing.Tokens.Input != nil || redacted.Pricing.Tokens.Output == nil {
+		t.Fatalf("redacted token pricing = %+v", redacted.Pricing.Tokens)
+	}
+	if _, found := redacted.Extensions["internal"]; found {
+		t.Fatal("private extension was kept")
+	}
+	if _, found := redacted.Extensions["openai"]; !found {
+		t.Fatal("public extension was dropped")
+	}
+
+	// The original model keeps full data
+	if model.Description == "" || model.Pricing.Tokens.Input == nil || len(model.Extensions) != 2 {
+		t.Fatalf("Redacted modified the original model: %+v", model)
+	}
+}
+
+func TestModelRedactedRejectsUnknownFields(t *testing.T) {
+	for _, path := range []string{"pricing.tokens.inptu", "notes", "pricing..tokens", "id", "name.first"} {
+		model := Model{ID: "model", Private: []string{path}}
+		if _, err := model.Redacted(); err == nil {
+			t.Errorf("Redacted accepted private path %q", path)
+		}
+	}
+}
+
+func TestCatalogPublicRedactsModelsAndProvenance(t *testing.T) {
+	builder := NewEmpty()
+	model := privateTestModel()
+	other := Model{ID: "gpt-4o-mini", Name: "GPT-4o mini", Description: "Small"}
+	colon := Model{ID: "ft:gpt-4o:org:x", Name: "Fine-tuned", Description: "Customer data", Private: []string{"description"}}
+	if err := builder.SetProvider(Provider{ID: "openai", Models: map[string]*Model{model.ID: &model, other.ID: &other, colon.ID: &colon}}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	builder.SetProvenance(provenance.Map{
+		"model:gpt-4o:Pricing":              {{Source: "local_catalog", Value: 2.25}},
+		"model:gpt-4o:Name":                 {{Source: "providers", Value: "GPT-4o"}},
+		"model:gpt-4o-mini:Description":     {{Source: "providers", Value: "Small"}},
+		"model:ft:gpt-4o:org:x:Description": {{Source: "providers", Value: "Customer data"}},
+	})
+	catalog, err := NewCatalog(builder)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+
+	public, err := catalog.Public()
+	if err != nil {
+		t.Fatalf("Public: %v", err)
+	}
+	redacted, err := public.ProviderModel("openai", "gpt-4o")
+	if err != nil {
+		t.Fatalf("ProviderModel: %v", err)
+	}
+	if redacted.Description != "" || redacted.Pricing.Tokens.Input != nil {
+		t.Fatalf("public model = %+v", redacted)
+	}
+	if full, _ := catalog.ProviderModel("openai", "gpt-4o"); full.Description == "" {
+		t.Fatal("catalog lost private data")
+	}
+	if unchanged, _ := public.ProviderModel("openai", "gpt-4o-mini"); unchanged.Description != "Small" {
+		t.Fatalf("model without private fields = %+v", unchanged)
+	}
+
+	history := public.Provenance().Map()
+	if _, found := history["model:gpt-4o:Pricing"]; found {
+		t.Fatal("provenance of a private field was kept")
+	}
+	if _, found := history["model:ft:gpt-4o:org:x:Description"]; found {
+		t.Fatal("provenance of a private field of a model ID with colons was kept")
+	}
+	if len(history) != 2 {
+		t.Fatalf("public provenance = %v, want the two public entries", history)
+	}
+
+	if again, _ := catalog.Public(); again != public {
+		t.Fatal("public view was rebuilt")
+	}
+	if self, _ := public.Public(); self != public {
+		t.Fatal("public view of a catalog without private fields is not itself")
+	}
+
+	full, err := ModelRevision(catalog, "gpt-4o")
+	if err != nil {
+		t.Fatalf("ModelRevision: %v", err)
+	}
+	if served, _ := ModelRevision(public, "gpt-4o"); served != full {
+		t.Fatalf("public revision %q differs from catalog revision %q", served, full)
+	}
+
+	// A field withheld only on an author's model is redacted as well
+	authored := Model{ID: "o3", Name: "o3", Description: "Internal notes", Private: []string{"description"}}
+	authorOnly := NewEmpty()
+	if err := authorOnly.SetAuthor(Author{ID: "openai", Name: "OpenAI", Models: map[string]*Model{authored.ID: &authored}}); err != nil {
+		t.Fatalf("SetAuthor: %v", err)
+	}
+	catalog, err = NewCatalog(authorOnly)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+	if public, err = catalog.Public(); err != nil {
+		t.Fatalf("Public: %v", err)
+	}
+	author, found := public.Authors().Get("openai")
+	if !found {
+		t.Fatal("public catalog lost the author")
+	}
+	if got := author.Models["o3"]; got == nil || got.Description != "" || len(got.Private) != 0 {
+		t.Fatalf("public author model = %+v", got)
+	}
+}
@@ -140,9 +140,30 @@ func (p *Provenance) EncodeYAML() (string, error) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
+	// Values arrive as typed structs from a sync but as generic maps from a
+	// load, and the two encode their keys in different orders. Encode every
+	// value in the loaded form so a load followed by a save is byte-identical.
+	normalized := make(provenance.Map, len(p.provenance))
+	for key, entries := range p.provenance {
+		copied := make([]provenance.Provenance, len(entries))
+		for i, entry := range entries {
+			value, err := loadedYAMLValue(entry.Value)
+			if err != nil {
+				return "", errors.WrapParse("yaml", "provenance "+key, err)
+			}
+			previous, err := loadedYAMLValue(entry.PreviousValue)
+			if err != nil {
+				return "", errors.WrapParse("yaml", "provenance "+key, err)
+			}
+			entry.Value, entry.PreviousValue = value, previous
+			copied[i] = entry
+		}
+		normalized[key] = copied
+	}
+
 	// Wrap in ProvenanceFile structure for consistent file format
 	pf := provenance.ProvenanceFile{
-		Provenance: p.provenance,
+		Provenance: normalized,
 	}
 
 	data, err := yaml.Marshal(pf)
@@ -153,6 +174,23 @@ func (p *Provenance) EncodeYAML() (string, error) {
 	return string(data), nil
 }
 
+// loadedYAMLValue returns value as Load would decode it from YAML: structs
+// become maps, which the encoder writes with sorted keys.
+func loadedYAMLValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var loaded any
+	if err := yaml.Unmarshal(data, &loaded); err != nil {
+		return nil, err
+	}
+	return loaded, nil
+}
+
 // newKey returns a unique key for provenance tracking.
 // Format: "resourceType:resourceID:field".
 func newKey(resourceType catalogmeta.ResourceType, resourceID string, field string) string {
@@ -32,12 +32,29 @@ type Provider struct {
 	// Status & Health
 	StatusPageURL   *string                  `json:"status_page_url,omitempty" yaml:"status_page_url,omitempty"`   // Link to service status page
 	ChatCompletions *ProviderChatCompletions `json:"chat_completions,omitempty" yaml:"chat_completions,omitempty"` // Chat completions API configuration
+	SLA             *ProviderSLA             `json:"sla,omitempty" yaml:"sla,omitempty"`                           // Service level commitments
+	Support         *ProviderSupport         `json:"support,omitempty" yaml:"support,omitempty"`                   // Support and escalation channels
+
+	// Prompt caching defaults for this provider's models
+	PromptCaching *PromptCaching `json:"prompt_caching,omitempty" yaml:"prompt_caching,omitempty"`
+
+	// Image input defaults for this provider's vision models
+	Vision *ModelVision `json:"vision,omitempty" yaml:"vision,omitempty"`
+
+	// Document input defaults for this provider's PDF-capable models
+	Documents *ModelDocumentInput `json:"documents,omitempty" yaml:"documents,omitempty"`
 
 	// Privacy, Retention, and Governance Policies
 	PrivacyPolicy    *ProviderPrivacyPolicy    `json:"privacy_policy,omitempty" yaml:"privacy_policy,omitempty"`       // Data collection and usage practices
 	RetentionPolicy  *ProviderRetentionPolicy  `json:"retention_policy,omitempty" yaml:"retention_policy,omitempty"`   // Data retention and deletion practices
 	GovernancePolicy *ProviderGovernancePolicy `json:"governance_policy,omitempty" yaml:"governance_policy,omitempty"` // Oversight and moderation practices
 
+	// Acceptable-use restrictions that apply to every model this provider serves
+	UsageRestrictions *UsageRestrictions `json:"usage_restrictions,omitempty" yaml:"usage_restrictions,omitempty"`
+
+	// Environmental claims such as carbon neutrality and grid carbon intensity
+	Sustainability *ProviderSustainability `json:"sustainability,omitempty" yaml:"sustainability,omitempty"`
+
 	// Extensions - controlled source-specific fields that are not canonical schema
 	Extensions SourceExtensions `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 
@@ -58,6 +75,21 @@ const (
 	EndpointTypeGoogle EndpointType = "google"
 	// EndpointTypeGoogleCloud represents Google Vertex AI.
 	EndpointTypeGoogleCloud EndpointType = "google-cloud"
+	// EndpointTypeOpenRouter represents the OpenRouter models API, which
+	// lists per-provider routing variants alongside each model.
+	EndpointTypeOpenRouter EndpointType = "openrouter"
+	// EndpointTypeCohere represents the Cohere models API, which lists
+	// chat, embedding, and rerank models together.
+	EndpointTypeCohere EndpointType = "cohere"
+	// EndpointTypeReplicate represents the Replicate models API, whose
+	// public models are billed per second of hardware time.
+	EndpointTypeReplicate EndpointType = "replicate"
+	// EndpointTypeTogether represents the Together AI models API, which
+	// prices serverless and dedicated deployments of each model.
+	EndpointTypeTogether EndpointType = "together"
+	// EndpointTypePerplexity represents the Perplexity models API, an
+	// OpenAI-compatible listing completed from Perplexity's model table.
+	EndpointTypePerplexity EndpointType = "perplexity"
 )
 
 // FieldMapping defines how to map API response fields to model fields.
@@ -91,6 +123,20 @@ type ProviderEndpoint struct {
 	FieldMappings []FieldMapping `yaml:"field_mappings,omitempty" json:"field_mappings,omitempty"`     // Field mappings
 	FeatureRules  []FeatureRule  `yaml:"feature_rules,omitempty" json:"feature_rules,omitempty"`       // Feature inference rules
 	AuthorMapping *AuthorMapping `yaml:"author_mapping,omitempty" json:"author_mapping,omitempty"`     // Author extraction
+
+	Requests *ProviderRequestPolicy `yaml:"requests,omitempty" json:"requests,omitempty"` // Pacing, retry, and circuit breaking of catalog requests
+}
+
+// ProviderRequestPolicy configures how starmap paces, retries, and stops
+// requests to a provider's catalog endpoint. Unset fields use the defaults in
+// pkg/constants.
+type ProviderRequestPolicy struct {
+	RequestsPerMinute int            `yaml:"requests_per_minute,omitempty" json:"requests_per_minute,omitempty"` // Sustained request rate
+	Burst             int            `yaml:"burst,omitempty" json:"burst,omitempty"`                             // Requests allowed at once before pacing starts
+	MaxRetries        *int           `yaml:"max_retries,omitempty" json:"max_retries,omitempty"`                 // Retries of 429 and 5xx responses; 0 disables retries
+	RetryDelay        *time.Duration `yaml:"retry_delay,omitempty" json:"retry_delay,omitempty"`                 // Wait before the first retry, doubled for each later one
+	FailureThreshold  *int           `yaml:"failure_threshold,omitempty" json:"failure_threshold,omitempty"`     // Consecutive failed calls that open the circuit; 0 disables it
+	CircuitCooldown   *time.Duration `yaml:"circuit_cooldown,omitempty" json:"circuit_cooldown,omitempty"`       // How long an open circuit rejects calls before trying again
 }
 
 // ProviderCatalog represents information about a provider's models.
@@ -218,6 +264,43 @@ type ProviderRetentionPolicy struct {
 	Details  *string               `json:"details,omitempty" yaml:"details,omitempty"`   // Human-readable description
 }
 
+// ProviderSLA represents a provider's published service level commitments.
+type ProviderSLA struct {
+	UptimeCommitment *float64 `json:"uptime_commitment,omitempty" yaml:"uptime_commitment,omitempty"` // Committed monthly uptime percentage (e.g. 99.9)
+	ServiceCredits   *bool    `json:"service_credits,omitempty" yaml:"service_credits,omitempty"`     // Whether missed commitments earn service credits
+	CreditsPolicy    *string  `json:"credits_policy,omitempty" yaml:"credits_policy,omitempty"`       // Human-readable credits summary
+	EnterpriseTier   *bool    `json:"enterprise_tier,omitempty" yaml:"enterprise_tier,omitempty"`     // Whether an enterprise tier is offered
+	EnterpriseOnly   *bool    `json:"enterprise_only,omitempty" yaml:"enterprise_only,omitempty"`     // Whether the commitment only covers enterprise contracts
+	URL              *string  `json:"url,omitempty" yaml:"url,omitempty"`                             // Link to the SLA terms
+}
+
+// Uptime returns the committed uptime percentage, or zero when none is
+// published.
+func (s *ProviderSLA) Uptime() float64 {
+	if s == nil || s.UptimeCommitment == nil {
+		return 0
+	}
+	return *s.UptimeCommitment
+}
+
+// ProviderSupport represents the channels for reaching a provider's support,
+// for example during an incident.
+type ProviderSupport struct {
+	Email   *string               `json:"email,omitempty" yaml:"email,omitempty"`     // Support email address
+	URL     *string               `json:"url,omitempty" yaml:"url,omitempty"`         // Help center or ticket portal
+	Discord *string               `json:"discord,omitempty" yaml:"discord,omitempty"` // Community Discord invite
+	Forum   *string               `json:"forum,omitempty" yaml:"forum,omitempty"`     // Developer community forum
+	Tiers   []ProviderSupportTier `json:"tiers,omitempty" yaml:"tiers,omitempty"`     // Paid or enterprise support tiers
+}
+
+// ProviderSupportTier represents one support offering.
+type ProviderSupportTier struct {
+	Name         string         `json:"name" yaml:"name"`                                       // Tier name
+	ResponseTime *time.Duration `json:"response_time,omitempty" yaml:"response_time,omitempty"` // Initial response target
+	Enterprise   bool           `json:"enterprise,omitempty" yaml:"enterprise,omitempty"`       // Requires an enterprise contract
+	Contact      *string        `json:"contact,omitempty" yaml:"contact,omitempty"`             // Escalation email or URL
+}
+
 // ProviderGovernancePolicy represents oversight and moderation practices.
 type ProviderGovernancePolicy struct {
 	ModerationRequired *bool   `json:"moderation_required,omitempty" yaml:"moderation_required,omitempty"` // Whether the provider requires moderation
@@ -3,6 +3,7 @@ package catalogs
 import (
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/agentstation/starmap/pkg/catalogmeta"
 	"github.com/agentstation/starmap/pkg/errors"
@@ -35,6 +36,13 @@ type Catalog struct {
 	definitions       map[ModelDefinitionID]ModelDefinition
 	offerings         map[OfferingKey]ProviderOffering
 	providerOfferings map[ProviderID][]OfferingKey
+
+	fingerprintsOnce sync.Once
+	fingerprints     map[OfferingKey]string
+
+	publicOnce sync.Once
+	public     *Catalog
+	publicErr  error
 }
 
 func buildCatalog(source Reader) (*Catalog, error) {
@@ -190,11 +198,53 @@ func (r *Catalog) ProviderOfferings(providerID ProviderID) ([]ProviderOffering,
 	return offerings, nil
 }
 
+// DefinitionOfferings returns caller-owned offerings of one definition across
+// every provider, in provider and provider-model-ID order.
+func (r *Catalog) DefinitionOfferings(id ModelDefinitionID) []ProviderOffering {
+	keys := make([]OfferingKey, 0)
+	for key, offering := range r.offerings {
+		if offering.DefinitionID == id {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(left, right OfferingKey) int {
+		if c := strings.Compare(string(left.ProviderID), string(right.ProviderID)); c != 0 {
+			return c
+		}
+		return strings.Compare(string(left.ProviderModelID), string(right.ProviderModelID))
+	})
+	offerings := make([]ProviderOffering, 0, len(keys))
+	for _, key := range keys {
+		offerings = append(offerings, copyProviderOffering(r.offerings[key]))
+	}
+	return offerings
+}
+
 // FindModel returns the canonical provider-independent model definition.
+// An id that is not a definition ID resolves through the provider model IDs
+// linked to exactly one definition, so a provider-specific name such as
+// llama-3.1-70b-versatile finds its shared definition.
 // Use Offering for provider price, limits, availability, and request behavior;
 // use LegacyV0 when migrating code that requires the old flattened Model.
 func (r *Catalog) FindModel(id string) (ModelDefinition, error) {
-	return r.Definition(ModelDefinitionID(id))
+	definition, err := r.Definition(ModelDefinitionID(id))
+	if err == nil {
+		return definition, nil
+	}
+	var linked ModelDefinitionID
+	for key, offering := range r.offerings {
+		if key.ProviderModelID != ProviderModelID(id) {
+			continue
+		}
+		if linked != "" && linked != offering.DefinitionID {
+			return ModelDefinition{}, err
+		}
+		linked = offering.DefinitionID
+	}
+	if linked == "" {
+		return ModelDefinition{}, err
+	}
+	return r.Definition(linked)
 }
 
 type providersReader struct{ source ProvidersReader }
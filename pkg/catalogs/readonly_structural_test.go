@@ -217,6 +217,10 @@ func TestCatalogCanonicalOfferingLookupPreservesDuplicateModelIDs(t *testing.T)
 	if err != nil || len(all) != 1 || all[0].ProviderModelID != "shared" {
 		t.Fatalf("ProviderOfferings = (%#v, %v)", all, err)
 	}
+	linked := catalog.DefinitionOfferings("shared")
+	if len(linked) != 2 || linked[0].ProviderID != "provider-a" || linked[1].ProviderID != "provider-b" {
+		t.Fatalf("DefinitionOfferings = %#v", linked)
+	}
 }
 
 func mustCatalog(t *testing.T, source Reader) *Catalog {
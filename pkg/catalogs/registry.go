@@ -0,0 +1,158 @@
+package catalogs
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// ProviderClient lists the models of a provider registered with
+// RegisterProvider. Its methods match the clients starmap ships, so a
+// registered client is fetched, cached, and retried like a built-in one.
+type ProviderClient interface {
+	ListModels(ctx context.Context) ([]Model, error)
+	IsAPIKeyRequired() bool
+	HasAPIKey() bool
+}
+
+// ProviderClientFactory creates the client of a registered provider from its
+// catalog configuration, with credentials already loaded.
+type ProviderClientFactory func(provider *Provider) (ProviderClient, error)
+
+var providerRegistry = struct {
+	mu        sync.RWMutex
+	providers map[ProviderID]registeredProvider
+	authors   map[AuthorID]Author
+}{
+	providers: map[ProviderID]registeredProvider{},
+	authors:   map[AuthorID]Author{},
+}
+
+type registeredProvider struct {
+	provider Provider
+	factory  ProviderClientFactory
+}
+
+// RegisterProvider adds a provider that is not in starmap's embedded catalog,
+// so applications embedding starmap can fetch proprietary providers without
+// editing the ProviderID constants. NewEmbedded includes the provider, and a
+// non-nil factory creates its API client in place of the client for its
+// endpoint type. Registering an existing ID replaces it. It returns a restore
+// function intended for tests and temporary integrations.
+//
+//	restore, err := catalogs.RegisterProvider(catalogs.Provider{
+//		ID:   "acme",
+//		Name: "Acme AI",
+//	}, func(p *catalogs.Provider) (catalogs.ProviderClient, error) {
+//		return acme.NewClient(p), nil
+//	})
+func RegisterProvider(provider Provider, factory ProviderClientFactory) (func(), error) {
+	provider.ID = ProviderID(strings.TrimSpace(string(provider.ID)))
+	if provider.ID == "" {
+		return nil, &errors.ValidationError{Field: "provider.id", Message: "is required"}
+	}
+	if provider.Name == "" {
+		provider.Name = string(provider.ID)
+	}
+	provider = DeepCopyProvider(provider)
+
+	providerRegistry.mu.Lock()
+	previous, existed := providerRegistry.providers[provider.ID]
+	providerRegistry.providers[provider.ID] = registeredProvider{provider: provider, factory: factory}
+	providerRegistry.mu.Unlock()
+
+	return func() {
+		providerRegistry.mu.Lock()
+		if existed {
+			providerRegistry.providers[provider.ID] = previous
+		} else {
+			delete(providerRegistry.providers, provider.ID)
+		}
+		providerRegistry.mu.Unlock()
+	}, nil
+}
+
+// RegisterAuthor adds a model author that is not in starmap's embedded
+// catalog. NewEmbedded includes the author. Registering an existing ID
+// replaces it. It returns a restore function intended for tests and temporary
+// integrations.
+func RegisterAuthor(author Author) (func(), error) {
+	author.ID = AuthorID(strings.TrimSpace(string(author.ID)))
+	if author.ID == "" {
+		return nil, &errors.ValidationError{Field: "author.id", Message: "is required"}
+	}
+	if author.Name == "" {
+		author.Name = string(author.ID)
+	}
+	author = DeepCopyAuthor(author)
+
+	providerRegistry.mu.Lock()
+	previous, existed := providerRegistry.authors[author.ID]
+	providerRegistry.authors[author.ID] = author
+	providerRegistry.mu.Unlock()
+
+	return func() {
+		providerRegistry.mu.Lock()
+		if existed {
+			providerRegistry.authors[author.ID] = previous
+		} else {
+			delete(providerRegistry.authors, author.ID)
+		}
+		providerRegistry.mu.Unlock()
+	}, nil
+}
+
+// RegisteredProviders returns the IDs added with RegisterProvider in sorted
+// order.
+func RegisteredProviders() []ProviderID {
+	providerRegistry.mu.RLock()
+	defer providerRegistry.mu.RUnlock()
+	ids := make([]ProviderID, 0, len(providerRegistry.providers))
+	for id := range providerRegistry.providers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// RegisteredProviderClientFactory returns the client factory registered for
+// id, or nil when the provider was not registered or has no factory.
+func RegisteredProviderClientFactory(id ProviderID) ProviderClientFactory {
+	providerRegistry.mu.RLock()
+	defer providerRegistry.mu.RUnlock()
+	return providerRegistry.providers[id].factory
+}
+
+// addRegistered sets the registered providers and authors in the catalog,
+// replacing any with the same ID. Models already loaded for a provider are
+// kept.
+func (cat *Builder) addRegistered() error {
+	providerRegistry.mu.RLock()
+	providers := make([]Provider, 0, len(providerRegistry.providers))
+	for _, registered := range providerRegistry.providers {
+		providers = append(providers, registered.provider)
+	}
+	authors := make([]Author, 0, len(providerRegistry.authors))
+	for _, author := range providerRegistry.authors {
+		authors = append(authors, author)
+	}
+	providerRegistry.mu.RUnlock()
+
+	for _, author := range authors {
+		if err := cat.SetAuthor(author); err != nil {
+			return errors.WrapResource("register", "author", string(author.ID), err)
+		}
+	}
+	for _, provider := range providers {
+		if existing, ok := cat.providers.Get(provider.ID); ok && len(provider.Models) == 0 {
+			provider.Models = existing.Models
+		}
+		if err := cat.SetProvider(provider); err != nil {
+			return errors.WrapResource("register", "provider", string(provider.ID), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package catalogs
+
+import "testing"
+
+func TestRegisterProviderAddsToEmbeddedCatalog(t *testing.T) {
+	restoreAuthor, err := RegisterAuthor(Author{ID: "acme-labs", Name: "Acme Labs"})
+	if err != nil {
+		t.Fatalf("RegisterAuthor returned error: %v", err)
+	}
+	restoreProvider, err := RegisterProvider(Provider{ID: " acme "}, nil)
+	if err != nil {
+		t.Fatalf("RegisterProvider returned error: %v", err)
+	}
+
+	cat, err := NewEmbedded()
+	if err != nil {
+		t.Fatalf("NewEmbedded returned error: %v", err)
+	}
+	provider, err := cat.Provider("acme")
+	if err != nil {
+		t.Fatalf("registered provider missing: %v", err)
+	}
+	if provider.Name != "acme" {
+		t.Fatalf("provider name = %q, want ID as default name", provider.Name)
+	}
+	if _, err := cat.Author("acme-labs"); err != nil {
+		t.Fatalf("registered author missing: %v", err)
+	}
+	if ids := RegisteredProviders(); len(ids) != 1 || ids[0] != "acme" {
+		t.Fatalf("RegisteredProviders = %v, want [acme]", ids)
+	}
+
+	restoreProvider()
+	restoreAuthor()
+	cat, err = NewEmbedded()
+	if err != nil {
+		t.Fatalf("NewEmbedded returned error: %v", err)
+	}
+	if _, err := cat.Provider("acme"); err == nil {
+		t.Fatal("provider still present after restore")
+	}
+}
+
+func TestRegisterProviderRequiresID(t *testing.T) {
+	if _, err := RegisterProvider(Provider{Name: "Nameless"}, nil); err == nil {
+		t.Fatal("RegisterProvider accepted an empty ID")
+	}
+	if _, err := RegisterAuthor(Author{}); err == nil {
+		t.Fatal("RegisterAuthor accepted an empty ID")
+	}
+}
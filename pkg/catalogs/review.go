@@ -0,0 +1,86 @@
+package catalogs
+
+import (
+	"strings"
+
+	"github.com/agentstation/utc"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// ModelReviewState is a model's position in the optional review workflow.
+type ModelReviewState string
+
+// String returns the string representation of a ModelReviewState.
+func (s ModelReviewState) String() string {
+	return string(s)
+}
+
+// Model review states. Models that never entered review have no state and are
+// served like approved models.
+const (
+	ModelReviewPending  ModelReviewState = "pending-review" // Held by sync until a reviewer decides
+	ModelReviewApproved ModelReviewState = "approved"       // Cleared to serve
+	ModelReviewRejected ModelReviewState = "rejected"       // Kept in the catalog but never served by default
+)
+
+// ModelReviewSystemReviewer attributes review entries recorded by sync.
+const ModelReviewSystemReviewer = "starmap"
+
+// ParseModelReviewState parses a review state, accepting "pending" for
+// pending-review.
+func ParseModelReviewState(value string) (ModelReviewState, error) {
+	switch state := ModelReviewState(strings.ToLower(strings.TrimSpace(value))); state {
+	case ModelReviewPending, ModelReviewApproved, ModelReviewRejected:
+		return state, nil
+	case "pending":
+		return ModelReviewPending, nil
+	default:
+		return "", &errors.ValidationError{Field: "review", Value: value, Message: "must be pending-review, approved, or rejected"}
+	}
+}
+
+// ModelReview records a model's review state and the audit log of every
+// transition. Like curation, review lives in the local catalog layer and
+// survives syncs.
+type ModelReview struct {
+	State ModelReviewState   `json:"state" yaml:"state"`                 // Current review state
+	Log   []ModelReviewEntry `json:"log,omitempty" yaml:"log,omitempty"` // Transitions, oldest first
+}
+
+// ModelReviewEntry is one audited review transition.
+type ModelReviewEntry struct {
+	State    ModelReviewState `json:"state" yaml:"state"`                   // State entered
+	Reviewer string           `json:"reviewer" yaml:"reviewer"`             // Who made the transition
+	At       utc.Time         `json:"at" yaml:"at"`                         // When the transition was recorded
+	Note     string           `json:"note,omitempty" yaml:"note,omitempty"` // Optional reviewer note
+}
+
+// Record appends a transition to the audit log and makes it the current state.
+func (r *ModelReview) Record(entry ModelReviewEntry) {
+	r.State = entry.State
+	r.Log = append(r.Log, entry)
+}
+
+// ReviewState returns the model's review state, or "" when the model never
+// entered review.
+func (m *Model) ReviewState() ModelReviewState {
+	if m == nil || m.Review == nil {
+		return ""
+	}
+	return m.Review.State
+}
+
+// ReviewCleared reports whether the model may be served by default: it never
+// entered review or a reviewer approved it.
+func (m *Model) ReviewCleared() bool {
+	state := m.ReviewState()
+	return state == "" || state == ModelReviewApproved
+}
+
+func deepCopyModelReview(review *ModelReview) *ModelReview {
+	if review == nil {
+		return nil
+	}
+	return &ModelReview{State: review.State, Log: append([]ModelReviewEntry(nil), review.Log...)}
+}
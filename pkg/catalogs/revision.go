@@ -0,0 +1,76 @@
+package catalogs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// ModelRecords returns every record of modelID keyed by its owner:
+// "providers/<id>" for provider offerings and "authors/<id>" for author
+// entries.
+func ModelRecords(reader Reader, modelID string) map[string]Model {
+	records := make(map[string]Model)
+	for _, provider := range reader.Providers().List() {
+		if model, ok := provider.Models[modelID]; ok && model != nil {
+			records["providers/"+string(provider.ID)] = DeepCopyModel(*model)
+		}
+	}
+	for _, author := range reader.Authors().List() {
+		if model, ok := author.Models[modelID]; ok && model != nil {
+			records["authors/"+string(author.ID)] = DeepCopyModel(*model)
+		}
+	}
+	return records
+}
+
+// ModelRevision returns an opaque content digest of every record of modelID.
+// It changes whenever any record a model edit could touch changes and is
+// equal across processes serving the same generation, so it can be used as
+// an HTTP entity tag for optimistic concurrency.
+//
+// Records are digested as redacted (see Model.Redacted), so a catalog and its
+// public view give the same revision and clients can send back the ETag they
+// were served.
+func ModelRevision(reader Reader, modelID string) (string, error) {
+	records := ModelRecords(reader, modelID)
+	if len(records) == 0 {
+		return "", &errors.NotFoundError{Resource: "model", ID: modelID}
+	}
+	for owner, record := range records {
+		redacted, err := record.Redacted()
+		if err != nil {
+			return "", errors.WrapResource("redact", "model", modelID, err)
+		}
+		records[owner] = redacted
+	}
+	return recordsRevision("model", modelID, records)
+}
+
+// ProviderRevision returns the revision of provider, including its models.
+func ProviderRevision(provider Provider) (string, error) {
+	return recordsRevision("provider", string(provider.ID), map[string]Provider{"provider": provider})
+}
+
+func recordsRevision[T any](resource, id string, records map[string]T) (string, error) {
+	keys := make([]string, 0, len(records))
+	for key := range records {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	hash := sha256.New()
+	for _, key := range keys {
+		data, err := json.Marshal(records[key])
+		if err != nil {
+			return "", errors.WrapResource("encode", resource, id, err)
+		}
+		_, _ = hash.Write([]byte(key))
+		_, _ = hash.Write([]byte{0})
+		_, _ = hash.Write(data)
+		_, _ = hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:16]), nil
+}
@@ -49,6 +49,15 @@ func (cat *Builder) saveTo(basePath string) error {
 		return os.WriteFile(fullPath, data, constants.FilePermissions)
 	}
 
+	// Save schema.yaml so future layout changes can be migrated
+	schemaData, err := encodeYAMLSchema()
+	if err != nil {
+		return err
+	}
+	if err := writeFile(YAMLSchemaFile, schemaData); err != nil {
+		return errors.WrapIO("write", YAMLSchemaFile, err)
+	}
+
 	// Save providers.yaml
 	providers := cat.providers.List()
 	if len(providers) > 0 {
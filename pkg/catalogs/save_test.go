@@ -0,0 +1,137 @@
+package catalogs
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+	"github.com/agentstation/starmap/pkg/provenance"
+	"github.com/agentstation/starmap/pkg/save"
+)
+
+// TestSaveLoadSaveIsByteIdentical saves a catalog, loads the result, and
+// saves it again: every file must come back unchanged, or each sync would
+// rewrite files whose content did not change.
+func TestSaveLoadSaveIsByteIdentical(t *testing.T) {
+	cat := NewEmpty()
+	provider := Provider{
+		ID:      "openai",
+		Name:    "OpenAI",
+		Aliases: []ProviderID{"open-ai", "oai"},
+		EnvVars: []ProviderEnvVar{{Name: "OPENAI_API_KEY", Required: true}},
+		Models: map[string]*Model{
+			"gpt-b": {ID: "gpt-b", Name: "GPT B"},
+			"gpt-a": {
+				ID:          "gpt-a",
+				Name:        "GPT A",
+				Description: `Says "hi": e.g. \quiet\ tone`,
+				Modes: map[string]ModelMode{
+					"priority": {Provider: &ModelProviderMode{Headers: map[string]string{"X-Tier": "priority", "X-Beta": "on"}}},
+					"flex": {Provider: &ModelProviderMode{Body: map[string]any{
+						"service_tier": "flex",
+						"options":      map[string]any{"zeta": 1, "alpha": true},
+					}}},
+				},
+				Pins: []string{"name"},
+			},
+		},
+	}
+	if err := cat.SetProvider(provider); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	if err := cat.SetAuthor(Author{ID: "openai", Name: "OpenAI", Aliases: []AuthorID{"openai-inc"}}); err != nil {
+		t.Fatalf("SetAuthor: %v", err)
+	}
+
+	// Values tracked during a sync are typed structs; after a load they are
+	// generic maps. Both must encode alike.
+	type nameFirst struct {
+		Name string `yaml:"name"`
+		ID   string `yaml:"id"`
+	}
+	key := newKey(catalogmeta.ResourceTypeModel, "gpt-a", "Metadata")
+	cat.SetProvenance(provenance.Map{
+		key: {{
+			Source:        "providers",
+			Field:         "Metadata",
+			Value:         nameFirst{Name: "GPT A", ID: "gpt-a"},
+			PreviousValue: &nameFirst{Name: "GPT", ID: "gpt-a"},
+			Authority:     0.9,
+		}},
+	})
+
+	first := t.TempDir()
+	if err := cat.Save(save.WithPath(first)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	second := t.TempDir()
+	if err := os.CopyFS(second, os.DirFS(first)); err != nil {
+		t.Fatalf("CopyFS: %v", err)
+	}
+	reloaded, err := NewFromPath(second)
+	if err != nil {
+		t.Fatalf("NewFromPath: %v", err)
+	}
+	if err := reloaded.Save(); err != nil {
+		t.Fatalf("Save reloaded: %v", err)
+	}
+
+	assertSameFiles(t, first, second)
+}
+
+// TestEmbeddedCatalogSaveIsByteIdentical loads the embedded catalog and
+// saves it back: the committed files must already be in the form Save
+// writes.
+func TestEmbeddedCatalogSaveIsByteIdentical(t *testing.T) {
+	if testing.Short() {
+		t.Skip("saves the full embedded catalog")
+	}
+	source := filepath.Join("..", "..", "internal", "embedded", "catalog")
+	dir := t.TempDir()
+	if err := os.CopyFS(dir, os.DirFS(source)); err != nil {
+		t.Fatalf("CopyFS: %v", err)
+	}
+	cat, err := NewFromPath(dir)
+	if err != nil {
+		t.Fatalf("NewFromPath: %v", err)
+	}
+	if err := cat.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	assertSameFiles(t, source, dir)
+}
+
+// assertSameFiles fails for every YAML file under want that is missing from
+// or differs in got.
+func assertSameFiles(t *testing.T, want, got string) {
+	t.Helper()
+	err := filepath.WalkDir(want, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".yaml" {
+			return err
+		}
+		rel, err := filepath.Rel(want, path)
+		if err != nil {
+			return err
+		}
+		wantData, err := os.ReadFile(path) //nolint:gosec // Test walks its own temp directories.
+		if err != nil {
+			return err
+		}
+		gotData, err := os.ReadFile(filepath.Join(got, rel)) //nolint:gosec // Test walks its own temp directories.
+		if err != nil {
+			t.Errorf("%s: %v", rel, err)
+			return nil
+		}
+		if !bytes.Equal(wantData, gotData) {
+			t.Errorf("%s changed after load and save:\nbefore:\n%s\nafter:\n%s", rel, wantData, gotData)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk %s: %v", want, err)
+	}
+}
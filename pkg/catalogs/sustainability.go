@@ -0,0 +1,101 @@
+package catalogs
+
+import (
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/utc"
+)
+
+// EstimateMethodology identifies how an energy estimate was produced.
+// Energy figures are rarely disclosed, so every estimate must say how it was
+// derived and consumers should treat anything other than disclosed figures as
+// an order-of-magnitude guide.
+type EstimateMethodology string
+
+// String returns the string representation of an EstimateMethodology.
+func (m EstimateMethodology) String() string {
+	return string(m)
+}
+
+// Estimate methodologies, from most to least direct.
+const (
+	EstimateMethodologyDisclosed        EstimateMethodology = "disclosed"         // Published by the provider or model author
+	EstimateMethodologyMeasured         EstimateMethodology = "measured"          // Measured by a third party on comparable hardware
+	EstimateMethodologyParameterScaling EstimateMethodology = "parameter_scaling" // Derived from active parameters and typical accelerator efficiency
+)
+
+// EstimateMethodologies returns every known estimate methodology.
+func EstimateMethodologies() []EstimateMethodology {
+	return []EstimateMethodology{
+		EstimateMethodologyDisclosed,
+		EstimateMethodologyMeasured,
+		EstimateMethodologyParameterScaling,
+	}
+}
+
+// ModelSustainability records the estimated inference energy of a model
+// offering. Figures cover accelerator and server energy for serving tokens,
+// not training or embodied emissions.
+type ModelSustainability struct {
+	EnergyPer1MInputTokens  float64             `json:"energy_per_1m_input_tokens,omitempty" yaml:"energy_per_1m_input_tokens,omitempty"`   // Estimated Wh per 1M input tokens
+	EnergyPer1MOutputTokens float64             `json:"energy_per_1m_output_tokens,omitempty" yaml:"energy_per_1m_output_tokens,omitempty"` // Estimated Wh per 1M output tokens
+	Methodology             EstimateMethodology `json:"methodology,omitempty" yaml:"methodology,omitempty"`                                 // How the estimate was produced
+	SourceURL               *string             `json:"source_url,omitempty" yaml:"source_url,omitempty"`                                   // Where the figures or method are published
+	EstimatedAt             *utc.Time           `json:"estimated_at,omitempty" yaml:"estimated_at,omitempty"`                               // When the estimate was made
+}
+
+// Validate checks that energy figures are non-negative and carry a known
+// methodology.
+func (s *ModelSustainability) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.EnergyPer1MInputTokens < 0 {
+		return &errors.ValidationError{Field: "sustainability.energy_per_1m_input_tokens", Value: s.EnergyPer1MInputTokens, Message: "must not be negative"}
+	}
+	if s.EnergyPer1MOutputTokens < 0 {
+		return &errors.ValidationError{Field: "sustainability.energy_per_1m_output_tokens", Value: s.EnergyPer1MOutputTokens, Message: "must not be negative"}
+	}
+	if s.EnergyPer1MInputTokens == 0 && s.EnergyPer1MOutputTokens == 0 {
+		return nil
+	}
+	for _, methodology := range EstimateMethodologies() {
+		if s.Methodology == methodology {
+			return nil
+		}
+	}
+	return &errors.ValidationError{Field: "sustainability.methodology", Value: s.Methodology, Message: "energy estimates must name a known methodology"}
+}
+
+// EnergyWh returns the estimated energy in watt-hours for a request of the
+// given size.
+func (s *ModelSustainability) EnergyWh(inputTokens, outputTokens int64) float64 {
+	if s == nil {
+		return 0
+	}
+	return (float64(inputTokens)*s.EnergyPer1MInputTokens + float64(outputTokens)*s.EnergyPer1MOutputTokens) / 1_000_000
+}
+
+// ProviderSustainability records a provider's public environmental claims.
+// Claims are recorded as stated by the provider and are not verified.
+type ProviderSustainability struct {
+	CarbonNeutral        *bool    `json:"carbon_neutral,omitempty" yaml:"carbon_neutral,omitempty"`                 // Provider claims carbon-neutral operations
+	RenewableEnergyShare *float64 `json:"renewable_energy_share,omitempty" yaml:"renewable_energy_share,omitempty"` // Claimed share of renewable or carbon-free energy, 0-100
+	CarbonIntensity      *float64 `json:"carbon_intensity,omitempty" yaml:"carbon_intensity,omitempty"`             // Claimed grid intensity in gCO2e per kWh
+	Claim                *string  `json:"claim,omitempty" yaml:"claim,omitempty"`                                   // The claim in the provider's words
+	ClaimURL             *string  `json:"claim_url,omitempty" yaml:"claim_url,omitempty"`                           // Where the claim is published
+}
+
+// EstimatedEmissions returns the estimated grams of CO2e for a request of
+// the given size served by provider. It reports false when the model has no
+// energy estimate or the provider publishes no carbon intensity.
+func (m *Model) EstimatedEmissions(provider *Provider, inputTokens, outputTokens int64) (float64, bool) {
+	if m == nil || m.Sustainability == nil || provider == nil ||
+		provider.Sustainability == nil || provider.Sustainability.CarbonIntensity == nil {
+		return 0, false
+	}
+	energy := m.Sustainability.EnergyWh(inputTokens, outputTokens)
+	if energy == 0 {
+		return 0, false
+	}
+	return energy / 1000 * *provider.Sustainability.CarbonIntensity, true
+}
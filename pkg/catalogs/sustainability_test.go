@@ -0,0 +1,61 @@
+package catalogs
+
+import (
+	stderrors "errors"
+	"math"
+	"testing"
+
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func TestModelSustainabilityValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		sustainability *ModelSustainability
+		wantErr        bool
+	}{
+		{name: "nil", sustainability: nil},
+		{name: "estimate with methodology", sustainability: &ModelSustainability{EnergyPer1MOutputTokens: 300, Methodology: EstimateMethodologyParameterScaling}},
+		{name: "estimate without methodology", sustainability: &ModelSustainability{EnergyPer1MOutputTokens: 300}, wantErr: true},
+		{name: "unknown methodology", sustainability: &ModelSustainability{EnergyPer1MInputTokens: 40, Methodology: "guess"}, wantErr: true},
+		{name: "negative energy", sustainability: &ModelSustainability{EnergyPer1MInputTokens: -1, Methodology: EstimateMethodologyMeasured}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sustainability.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var validationErr *pkgerrors.ValidationError
+			if err != nil && !stderrors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %T, want *errors.ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestModelEstimatedEmissions(t *testing.T) {
+	intensity := 200.0
+	provider := &Provider{Sustainability: &ProviderSustainability{CarbonIntensity: &intensity}}
+	model := &Model{Sustainability: &ModelSustainability{
+		EnergyPer1MInputTokens:  50,
+		EnergyPer1MOutputTokens: 500,
+		Methodology:             EstimateMethodologyParameterScaling,
+	}}
+
+	grams, ok := model.EstimatedEmissions(provider, 2_000_000, 1_000_000)
+	if !ok {
+		t.Fatal("EstimatedEmissions reported no estimate")
+	}
+	// (2 * 50 Wh + 1 * 500 Wh) = 0.6 kWh at 200 gCO2e/kWh.
+	if math.Abs(grams-120) > 1e-9 {
+		t.Fatalf("EstimatedEmissions = %v, want 120", grams)
+	}
+
+	if _, ok := model.EstimatedEmissions(&Provider{}, 1, 1); ok {
+		t.Fatal("EstimatedEmissions without provider carbon intensity should report no estimate")
+	}
+	if _, ok := (&Model{}).EstimatedEmissions(provider, 1, 1); ok {
+		t.Fatal("EstimatedEmissions without an energy estimate should report no estimate")
+	}
+}
@@ -0,0 +1,114 @@
+package catalogs
+
+import (
+	"slices"
+	"strings"
+)
+
+// UsageRestriction tags a use that an acceptable-use policy prohibits or
+// allows only with extra safeguards such as professional review.
+type UsageRestriction string
+
+// String returns the string representation of a UsageRestriction.
+func (r UsageRestriction) String() string {
+	return string(r)
+}
+
+// Usage restriction tags.
+const (
+	UsageRestrictionMedicalAdvice        UsageRestriction = "medical_advice"        // Tailored medical advice or diagnosis
+	UsageRestrictionLegalAdvice          UsageRestriction = "legal_advice"          // Tailored legal advice
+	UsageRestrictionFinancialAdvice      UsageRestriction = "financial_advice"      // Tailored financial or investment advice
+	UsageRestrictionFacialRecognition    UsageRestriction = "facial_recognition"    // Identifying people from images of their faces
+	UsageRestrictionBiometric            UsageRestriction = "biometric"             // Biometric categorization or emotion inference
+	UsageRestrictionSurveillance         UsageRestriction = "surveillance"          // Tracking or monitoring individuals
+	UsageRestrictionPoliticalCampaigning UsageRestriction = "political_campaigning" // Political campaigning and lobbying
+	UsageRestrictionWeapons              UsageRestriction = "weapons"               // Weapons development
+	UsageRestrictionAutomatedDecisions   UsageRestriction = "automated_decisions"   // Consequential decisions (credit, employment, housing) without human review
+)
+
+// UsageRestrictionTags returns every known usage restriction tag.
+func UsageRestrictionTags() []UsageRestriction {
+	return []UsageRestriction{
+		UsageRestrictionMedicalAdvice,
+		UsageRestrictionLegalAdvice,
+		UsageRestrictionFinancialAdvice,
+		UsageRestrictionFacialRecognition,
+		UsageRestrictionBiometric,
+		UsageRestrictionSurveillance,
+		UsageRestrictionPoliticalCampaigning,
+		UsageRestrictionWeapons,
+		UsageRestrictionAutomatedDecisions,
+	}
+}
+
+// euMemberStates lists the ISO 3166-1 alpha-2 codes covered by an "EU" region block.
+var euMemberStates = []string{
+	"AT", "BE", "BG", "HR", "CY", "CZ", "DK", "EE", "FI", "FR", "DE", "GR", "HU", "IE",
+	"IT", "LV", "LT", "LU", "MT", "NL", "PL", "PT", "RO", "SK", "SI", "ES", "SE",
+}
+
+// UsageRestrictions records notable acceptable-use restrictions. Providers
+// record the restrictions of their usage policy; models record the
+// restrictions of their own license or policy on top of them.
+type UsageRestrictions struct {
+	Restricted     []UsageRestriction `json:"restricted,omitempty" yaml:"restricted,omitempty"`           // Uses that are prohibited or need extra safeguards
+	BlockedRegions []string           `json:"blocked_regions,omitempty" yaml:"blocked_regions,omitempty"` // ISO 3166-1 alpha-2 codes, or EU, where the service is unavailable
+	PolicyURL      *string            `json:"policy_url,omitempty" yaml:"policy_url,omitempty"`           // Policy the restrictions are sourced from
+}
+
+// Permits reports whether use is not restricted.
+func (r *UsageRestrictions) Permits(use UsageRestriction) bool {
+	return r == nil || !slices.Contains(r.Restricted, use)
+}
+
+// AvailableIn reports whether region is not blocked. A blocked "EU" also
+// blocks each EU member state.
+func (r *UsageRestrictions) AvailableIn(region string) bool {
+	if r == nil {
+		return true
+	}
+	region = strings.ToUpper(strings.TrimSpace(region))
+	for _, blocked := range r.BlockedRegions {
+		blocked = strings.ToUpper(blocked)
+		if blocked == region || (blocked == "EU" && slices.Contains(euMemberStates, region)) {
+			return false
+		}
+	}
+	return true
+}
+
+// UsageRestrictionsFor resolves the restrictions that apply to the model as
+// served by provider. Restrictions accumulate: the provider's usage policy
+// applies to every model it serves, and model-level restrictions add to it.
+func (m *Model) UsageRestrictionsFor(provider *Provider) *UsageRestrictions {
+	var layers []*UsageRestrictions
+	if provider != nil && provider.UsageRestrictions != nil {
+		layers = append(layers, provider.UsageRestrictions)
+	}
+	if m != nil && m.UsageRestrictions != nil {
+		layers = append(layers, m.UsageRestrictions)
+	}
+	if len(layers) == 0 {
+		return nil
+	}
+
+	resolved := &UsageRestrictions{}
+	for _, layer := range layers {
+		for _, use := range layer.Restricted {
+			if !slices.Contains(resolved.Restricted, use) {
+				resolved.Restricted = append(resolved.Restricted, use)
+			}
+		}
+		for _, region := range layer.BlockedRegions {
+			if !slices.Contains(resolved.BlockedRegions, region) {
+				resolved.BlockedRegions = append(resolved.BlockedRegions, region)
+			}
+		}
+		if layer.PolicyURL != nil {
+			url := *layer.PolicyURL
+			resolved.PolicyURL = &url
+		}
+	}
+	return resolved
+}
@@ -0,0 +1,56 @@
+package catalogs
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUsageRestrictionsAvailableIn(t *testing.T) {
+	restrictions := &UsageRestrictions{BlockedRegions: []string{"CN", "EU"}}
+	for region, want := range map[string]bool{
+		"cn": false,
+		"EU": false,
+		"DE": false, // EU member state
+		"GB": true,
+		"US": true,
+	} {
+		if got := restrictions.AvailableIn(region); got != want {
+			t.Errorf("AvailableIn(%q) = %v, want %v", region, got, want)
+		}
+	}
+
+	var unrestricted *UsageRestrictions
+	if !unrestricted.AvailableIn("CN") || !unrestricted.Permits(UsageRestrictionWeapons) {
+		t.Fatal("nil restrictions should permit everything")
+	}
+}
+
+func TestUsageRestrictionsForMergesProviderAndModel(t *testing.T) {
+	providerPolicy, modelLicense := "https://provider.example/aup", "https://model.example/license"
+	provider := &Provider{UsageRestrictions: &UsageRestrictions{
+		Restricted:     []UsageRestriction{UsageRestrictionWeapons, UsageRestrictionSurveillance},
+		BlockedRegions: []string{"RU"},
+		PolicyURL:      &providerPolicy,
+	}}
+	model := &Model{ID: "model", UsageRestrictions: &UsageRestrictions{
+		Restricted: []UsageRestriction{UsageRestrictionSurveillance, UsageRestrictionMedicalAdvice},
+		PolicyURL:  &modelLicense,
+	}}
+
+	resolved := model.UsageRestrictionsFor(provider)
+	want := []UsageRestriction{UsageRestrictionWeapons, UsageRestrictionSurveillance, UsageRestrictionMedicalAdvice}
+	if !slices.Equal(resolved.Restricted, want) {
+		t.Fatalf("Restricted = %v, want %v", resolved.Restricted, want)
+	}
+	if resolved.AvailableIn("RU") || *resolved.PolicyURL != modelLicense {
+		t.Fatalf("resolved = %#v", resolved)
+	}
+
+	resolved.Restricted[0] = UsageRestrictionLegalAdvice
+	if provider.UsageRestrictions.Restricted[0] != UsageRestrictionWeapons {
+		t.Fatal("resolved restrictions alias the provider policy")
+	}
+	if got := (&Model{ID: "bare"}).UsageRestrictionsFor(nil); got != nil {
+		t.Fatalf("bare model restrictions = %#v, want nil", got)
+	}
+}
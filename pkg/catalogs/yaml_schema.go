@@ -0,0 +1,203 @@
+package catalogs
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const (
+	// YAMLSchemaFile is the catalog-root file that records the on-disk YAML
+	// layout version of an editable catalog directory.
+	YAMLSchemaFile = "schema.yaml"
+
+	// CurrentYAMLSchemaVersion identifies the multi-file YAML layout written by
+	// Save. Directories without a schema file are version 0.
+	CurrentYAMLSchemaVersion uint64 = 1
+)
+
+// YAMLSchema is the content of the catalog-root schema file.
+type YAMLSchema struct {
+	SchemaVersion uint64 `json:"schema_version" yaml:"schema_version"`
+}
+
+// YAMLMigration upgrades a catalog directory from one YAML schema version to
+// the next. Apply receives the catalog root and must be idempotent so an
+// interrupted migration can be re-run.
+type YAMLMigration struct {
+	From        uint64
+	Description string
+	// Breaking migrations change the record layout; Load refuses directories
+	// older than a breaking step until they are migrated.
+	Breaking bool
+	Apply    func(root string) error
+}
+
+// To returns the schema version produced by the migration.
+func (m YAMLMigration) To() uint64 {
+	return m.From + 1
+}
+
+// yamlMigrations is the ordered registry of on-disk schema upgrades. Every
+// version below CurrentYAMLSchemaVersion must have exactly one entry.
+var yamlMigrations = []YAMLMigration{
+	{
+		From:        0,
+		Description: "record the YAML schema version; the v0 record layout is unchanged",
+		Apply:       func(string) error { return nil },
+	},
+}
+
+// YAMLMigrations returns the caller-owned ordered migration registry.
+func YAMLMigrations() []YAMLMigration {
+	return slices.Clone(yamlMigrations)
+}
+
+// YAMLMigrationStep is one applied or pending migration in a report.
+type YAMLMigrationStep struct {
+	From        uint64 `json:"from" yaml:"from"`
+	To          uint64 `json:"to" yaml:"to"`
+	Description string `json:"description" yaml:"description"`
+	Breaking    bool   `json:"breaking,omitempty" yaml:"breaking,omitempty"`
+}
+
+// YAMLMigrationReport summarizes a MigrateYAMLDirectory run.
+type YAMLMigrationReport struct {
+	Path        string              `json:"path" yaml:"path"`
+	FromVersion uint64              `json:"from_version" yaml:"from_version"`
+	ToVersion   uint64              `json:"to_version" yaml:"to_version"`
+	DryRun      bool                `json:"dry_run" yaml:"dry_run"`
+	Steps       []YAMLMigrationStep `json:"steps" yaml:"steps"`
+}
+
+// UpToDate reports whether the directory already had the current schema.
+func (r YAMLMigrationReport) UpToDate() bool {
+	return len(r.Steps) == 0
+}
+
+// ReadYAMLSchemaVersion returns the YAML schema version recorded in fsys.
+// A missing schema file identifies the unversioned v0 layout.
+func ReadYAMLSchemaVersion(fsys fs.FS) (uint64, error) {
+	data, err := fs.ReadFile(fsys, YAMLSchemaFile)
+	if err != nil {
+		if stderrors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, errors.WrapIO("read", YAMLSchemaFile, err)
+	}
+	var schema YAMLSchema
+	if err := yaml.Unmarshal(data, &schema); err != nil {
+		return 0, errors.WrapParse("yaml", YAMLSchemaFile, err)
+	}
+	return schema.SchemaVersion, nil
+}
+
+// checkYAMLSchemaVersion rejects directories this build cannot read safely.
+func checkYAMLSchemaVersion(fsys fs.FS) error {
+	version, err := ReadYAMLSchemaVersion(fsys)
+	if err != nil {
+		return err
+	}
+	if version > CurrentYAMLSchemaVersion {
+		return &errors.ValidationError{
+			Field:   "schema_version",
+			Value:   version,
+			Message: fmt.Sprintf("catalog was written by a newer starmap (supported: %d); upgrade starmap", CurrentYAMLSchemaVersion),
+		}
+	}
+	for _, migration := range yamlMigrations {
+		if migration.Breaking && migration.From >= version {
+			return &errors.ValidationError{
+				Field:   "schema_version",
+				Value:   version,
+				Message: fmt.Sprintf("catalog schema is outdated (current: %d); run 'starmap migrate'", CurrentYAMLSchemaVersion),
+			}
+		}
+	}
+	return nil
+}
+
+func encodeYAMLSchema() ([]byte, error) {
+	data, err := yaml.Marshal(YAMLSchema{SchemaVersion: CurrentYAMLSchemaVersion})
+	if err != nil {
+		return nil, errors.WrapParse("yaml", YAMLSchemaFile, err)
+	}
+	return data, nil
+}
+
+// MigrateYAMLDirectory upgrades the catalog directory at root to
+// CurrentYAMLSchemaVersion, applying each registered migration in order and
+// recording the new version after every step. With dryRun set, the pending
+// steps are reported and nothing is written.
+func MigrateYAMLDirectory(root string, dryRun bool) (YAMLMigrationReport, error) {
+	report := YAMLMigrationReport{Path: root, DryRun: dryRun, Steps: []YAMLMigrationStep{}}
+	info, err := os.Stat(root)
+	if err != nil {
+		return report, errors.WrapIO("stat", root, err)
+	}
+	if !info.IsDir() {
+		return report, &errors.ValidationError{Field: "path", Value: root, Message: "must be a catalog directory"}
+	}
+
+	version, err := ReadYAMLSchemaVersion(os.DirFS(root))
+	if err != nil {
+		return report, err
+	}
+	report.FromVersion = version
+	report.ToVersion = version
+	if version > CurrentYAMLSchemaVersion {
+		return report, &errors.ValidationError{
+			Field:   "schema_version",
+			Value:   version,
+			Message: fmt.Sprintf("catalog was written by a newer starmap (supported: %d)", CurrentYAMLSchemaVersion),
+		}
+	}
+
+	for _, migration := range yamlMigrations {
+		if migration.From < version {
+			continue
+		}
+		if migration.From != report.ToVersion {
+			return report, &errors.ConfigError{
+				Component: "catalog migrations",
+				Message:   fmt.Sprintf("no migration registered from schema version %d", report.ToVersion),
+			}
+		}
+		report.Steps = append(report.Steps, YAMLMigrationStep{
+			From:        migration.From,
+			To:          migration.To(),
+			Description: migration.Description,
+			Breaking:    migration.Breaking,
+		})
+		if !dryRun {
+			if err := migration.Apply(root); err != nil {
+				return report, errors.WrapResource("migrate", "catalog schema", fmt.Sprintf("v%d", migration.From), err)
+			}
+			if err := writeYAMLSchemaVersion(root, migration.To()); err != nil {
+				return report, err
+			}
+		}
+		report.ToVersion = migration.To()
+	}
+	return report, nil
+}
+
+func writeYAMLSchemaVersion(root string, version uint64) error {
+	data, err := yaml.Marshal(YAMLSchema{SchemaVersion: version})
+	if err != nil {
+		return errors.WrapParse("yaml", YAMLSchemaFile, err)
+	}
+	path := filepath.Join(root, YAMLSchemaFile)
+	if err := os.WriteFile(path, data, constants.FilePermissions); err != nil {
+		return errors.WrapIO("write", path, err)
+	}
+	return nil
+}
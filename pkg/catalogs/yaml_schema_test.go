@@ -0,0 +1,131 @@
+package catalogs
+
+import (
+	stderrors "errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/agentstation/starmap/internal/embedded"
+	"github.com/agentstation/starmap/pkg/constants"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/save"
+)
+
+func TestYAMLMigrationsCoverEverySchemaVersion(t *testing.T) {
+	migrations := YAMLMigrations()
+	if uint64(len(migrations)) != CurrentYAMLSchemaVersion {
+		t.Fatalf("registered migrations = %d, want %d", len(migrations), CurrentYAMLSchemaVersion)
+	}
+	for i, migration := range migrations {
+		if migration.From != uint64(i) {
+			t.Fatalf("migration %d starts at version %d", i, migration.From)
+		}
+		if migration.Apply == nil || migration.Description == "" {
+			t.Fatalf("migration from v%d is incomplete", migration.From)
+		}
+	}
+}
+
+func TestEmbeddedCatalogRecordsCurrentYAMLSchema(t *testing.T) {
+	catalogFS, err := fs.Sub(embedded.FS, "catalog")
+	if err != nil {
+		t.Fatalf("fs.Sub: %v", err)
+	}
+	version, err := ReadYAMLSchemaVersion(catalogFS)
+	if err != nil {
+		t.Fatalf("ReadYAMLSchemaVersion: %v", err)
+	}
+	if version != CurrentYAMLSchemaVersion {
+		t.Fatalf("embedded catalog schema = v%d, want v%d; run starmap migrate ./internal/embedded/catalog", version, CurrentYAMLSchemaVersion)
+	}
+}
+
+func TestReadYAMLSchemaVersion(t *testing.T) {
+	version, err := ReadYAMLSchemaVersion(fstest.MapFS{})
+	if err != nil || version != 0 {
+		t.Fatalf("unversioned directory = %d, %v; want 0", version, err)
+	}
+	version, err = ReadYAMLSchemaVersion(fstest.MapFS{
+		YAMLSchemaFile: {Data: []byte("schema_version: 7\n")},
+	})
+	if err != nil || version != 7 {
+		t.Fatalf("versioned directory = %d, %v; want 7", version, err)
+	}
+	_, err = ReadYAMLSchemaVersion(fstest.MapFS{
+		YAMLSchemaFile: {Data: []byte("schema_version: [\n")},
+	})
+	var parseErr *pkgerrors.ParseError
+	if !stderrors.As(err, &parseErr) {
+		t.Fatalf("corrupt schema error = %T: %v, want *errors.ParseError", err, err)
+	}
+}
+
+func TestMigrateYAMLDirectoryUpgradesUnversionedCatalog(t *testing.T) {
+	root := t.TempDir()
+	writeSchemaTestFile(t, root, "providers.yaml", "- id: test-provider\n  name: Test Provider\n")
+
+	report, err := MigrateYAMLDirectory(root, true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if report.FromVersion != 0 || report.ToVersion != CurrentYAMLSchemaVersion || report.UpToDate() {
+		t.Fatalf("dry run report = %#v", report)
+	}
+	if _, err := os.Stat(filepath.Join(root, YAMLSchemaFile)); !os.IsNotExist(err) {
+		t.Fatalf("dry run wrote %s: %v", YAMLSchemaFile, err)
+	}
+
+	if _, err := MigrateYAMLDirectory(root, false); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	version, err := ReadYAMLSchemaVersion(os.DirFS(root))
+	if err != nil || version != CurrentYAMLSchemaVersion {
+		t.Fatalf("migrated version = %d, %v", version, err)
+	}
+
+	report, err = MigrateYAMLDirectory(root, false)
+	if err != nil || !report.UpToDate() {
+		t.Fatalf("second migrate = %#v, %v; want up to date", report, err)
+	}
+	if _, err := NewFromPath(root); err != nil {
+		t.Fatalf("load migrated catalog: %v", err)
+	}
+}
+
+func TestYAMLSchemaRejectsNewerCatalog(t *testing.T) {
+	root := t.TempDir()
+	writeSchemaTestFile(t, root, YAMLSchemaFile, "schema_version: 99\n")
+
+	var validationErr *pkgerrors.ValidationError
+	if _, err := MigrateYAMLDirectory(root, false); !stderrors.As(err, &validationErr) {
+		t.Fatalf("migrate newer catalog error = %T: %v, want *errors.ValidationError", err, err)
+	}
+	if _, err := NewFromPath(root); !stderrors.As(err, &validationErr) {
+		t.Fatalf("load newer catalog error = %T: %v, want *errors.ValidationError", err, err)
+	}
+}
+
+func TestSaveRecordsYAMLSchemaVersion(t *testing.T) {
+	root := t.TempDir()
+	catalog := NewEmpty()
+	if err := catalog.SetProvider(Provider{ID: "test-provider", Name: "Test Provider"}); err != nil {
+		t.Fatalf("Set provider: %v", err)
+	}
+	if err := catalog.Save(save.WithPath(root)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	version, err := ReadYAMLSchemaVersion(os.DirFS(root))
+	if err != nil || version != CurrentYAMLSchemaVersion {
+		t.Fatalf("saved version = %d, %v; want %d", version, err, CurrentYAMLSchemaVersion)
+	}
+}
+
+func writeSchemaTestFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, name), []byte(content), constants.FilePermissions); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
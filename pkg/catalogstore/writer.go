@@ -0,0 +1,232 @@
+package catalogstore
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// DefaultWriterValidatorVersion identifies Writer-produced generations when
+// WriterOptions.ValidatorVersion is empty.
+const DefaultWriterValidatorVersion = "catalogstore-writer/v1"
+
+// WriterOptions supplies identity and time metadata for generations committed
+// by a Writer. Zero values use random UUIDs and the current UTC time.
+type WriterOptions struct {
+	NewID            func() (string, error)
+	Now              func() time.Time
+	ValidatorVersion string
+}
+
+// Writer implements catalogs.Writer over a Store. Each transaction stages
+// mutations on a copy of the generation that was current at Begin and commits
+// them as one new generation with compare-and-swap, so concurrent writers
+// cannot silently overwrite each other.
+type Writer struct {
+	store   Store
+	options WriterOptions
+}
+
+// NewWriter creates a transactional catalog writer backed by store.
+func NewWriter(store Store, options WriterOptions) (*Writer, error) {
+	if store == nil {
+		return nil, &errors.ValidationError{Field: "store", Message: "is required"}
+	}
+	if options.NewID == nil {
+		options.NewID = func() (string, error) {
+			id, err := uuid.NewRandom()
+			if err != nil {
+				return "", errors.WrapIO("generate", "catalog generation ID", err)
+			}
+			return id.String(), nil
+		}
+	}
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+	if strings.TrimSpace(options.ValidatorVersion) == "" {
+		options.ValidatorVersion = DefaultWriterValidatorVersion
+	}
+	return &Writer{store: store, options: options}, nil
+}
+
+// Begin starts a transaction from the current generation, or from an empty
+// catalog when the store has no current generation.
+func (w *Writer) Begin(ctx context.Context) (catalogs.Transaction, error) {
+	builder := catalogs.NewEmpty()
+	current, err := w.store.Current(ctx)
+	switch {
+	case err == nil:
+		catalog, err := DecodeCatalogPayload(current.Payload)
+		if err != nil {
+			return nil, errors.WrapResource("decode", "catalog generation", current.Manifest.GenerationID, err)
+		}
+		if err := builder.ReplaceWith(catalog); err != nil {
+			return nil, errors.WrapResource("copy", "catalog generation", current.Manifest.GenerationID, err)
+		}
+	case stderrors.Is(err, errors.ErrNotFound):
+	default:
+		return nil, err
+	}
+	return &transaction{
+		Reader:   builder,
+		writer:   w,
+		builder:  builder,
+		expected: current.Manifest.GenerationID,
+	}, nil
+}
+
+// transaction stages mutations on a private builder until Commit or Rollback.
+type transaction struct {
+	catalogs.Reader
+
+	writer   *Writer
+	builder  *catalogs.Builder
+	expected string
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func (tx *transaction) SetProvider(provider catalogs.Provider) error {
+	return tx.mutate(func() error { return tx.builder.SetProvider(provider) })
+}
+
+func (tx *transaction) DeleteProvider(id catalogs.ProviderID) error {
+	return tx.mutate(func() error { return tx.builder.DeleteProvider(id) })
+}
+
+func (tx *transaction) SetProviderModel(providerID catalogs.ProviderID, model catalogs.Model) error {
+	return tx.mutate(func() error { return tx.builder.SetProviderModel(providerID, model) })
+}
+
+func (tx *transaction) DeleteProviderModel(providerID catalogs.ProviderID, modelID string) error {
+	return tx.mutate(func() error { return tx.builder.DeleteProviderModel(providerID, modelID) })
+}
+
+func (tx *transaction) SetAuthor(author catalogs.Author) error {
+	return tx.mutate(func() error { return tx.builder.SetAuthor(author) })
+}
+
+func (tx *transaction) DeleteAuthor(id catalogs.AuthorID) error {
+	return tx.mutate(func() error { return tx.builder.DeleteAuthor(id) })
+}
+
+// Commit publishes the staged catalog as a new generation. It fails with a
+// conflict when another writer committed after Begin; callers should begin a
+// new transaction and reapply their mutations.
+func (tx *transaction) Commit(ctx context.Context) error {
+	if err := tx.close(); err != nil {
+		return err
+	}
+	catalog, err := tx.builder.Build()
+	if err != nil {
+		return errors.WrapResource("publish", "catalog transaction", "", err)
+	}
+	generation, err := tx.writer.newGeneration(catalog)
+	if err != nil {
+		return err
+	}
+	if err := tx.writer.store.Commit(ctx, generation, tx.expected); err != nil {
+		return errors.WrapResource("commit", "catalog transaction", generation.Manifest.GenerationID, err)
+	}
+	return nil
+}
+
+// Rollback discards the staged mutations.
+func (tx *transaction) Rollback() error {
+	return tx.close()
+}
+
+func (tx *transaction) mutate(apply func() error) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.closed {
+		return transactionClosed()
+	}
+	return apply()
+}
+
+func (tx *transaction) close() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.closed {
+		return transactionClosed()
+	}
+	tx.closed = true
+	return nil
+}
+
+func (w *Writer) newGeneration(catalog *catalogs.Catalog) (Generation, error) {
+	payload, err := EncodeCatalogPayload(catalog)
+	if err != nil {
+		return Generation{}, errors.WrapResource("encode", "catalog transaction", "", err)
+	}
+	descriptor := catalogs.DescribeCatalogPayload(payload)
+	generationID, err := w.options.NewID()
+	if err != nil {
+		return Generation{}, err
+	}
+	syncRunID, err := w.options.NewID()
+	if err != nil {
+		return Generation{}, err
+	}
+	generatedAt := w.options.Now().UTC()
+	generation := Generation{
+		Manifest: catalogs.GenerationManifest{
+			ManifestVersion: catalogs.CurrentGenerationManifestVersion,
+			SchemaVersion:   catalogs.CurrentCatalogSchemaVersion,
+			GenerationID:    generationID,
+			GeneratedAt:     generatedAt,
+			Payload:         descriptor,
+			Validation: catalogs.GenerationValidationReport{
+				ValidatorVersion: w.options.ValidatorVersion,
+				ValidatedAt:      generatedAt,
+				Status:           catalogs.GenerationValidationPassed,
+				Checks: []catalogs.GenerationValidationCheck{
+					{Name: "catalog_publication", Status: catalogs.GenerationValidationCheckPassed},
+					{Name: "schema_v1_encode", Status: catalogs.GenerationValidationCheckPassed},
+				},
+			},
+			SyncRunID: syncRunID,
+			SourceObservations: []catalogs.SourceObservationLink{
+				{
+					Source:        catalogmeta.LocalCatalogID,
+					ObservationID: generationID,
+					ObservedAt:    generatedAt,
+					Revision: catalogmeta.ObservationRevision{
+						Kind:  catalogmeta.ObservationRevisionKindContentDigest,
+						Value: descriptor.Checksum,
+					},
+					Completeness:     catalogmeta.ObservationCompletenessComplete,
+					Status:           catalogmeta.ObservationStatusSucceeded,
+					EvidenceChecksum: descriptor.Checksum,
+				},
+			},
+			Completeness: catalogs.GenerationCompletenessComplete,
+			ConsumerCompatibility: catalogs.ConsumerCompatibility{
+				MinSchemaVersion: catalogs.CurrentCatalogSchemaVersion,
+				MaxSchemaVersion: catalogs.CurrentCatalogSchemaVersion,
+			},
+		},
+		Payload: payload,
+	}
+	if err := generation.Validate(); err != nil {
+		return Generation{}, err
+	}
+	return generation, nil
+}
+
+func transactionClosed() error {
+	return &errors.ValidationError{Field: "transaction", Message: "is already committed or rolled back"}
+}
+
+var _ catalogs.Writer = (*Writer)(nil)
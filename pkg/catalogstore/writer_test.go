@@ -0,0 +1,133 @@
+package catalogstore
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func newTestWriter(t *testing.T, store Store) *Writer {
+	t.Helper()
+	next := 0
+	writer, err := NewWriter(store, WriterOptions{
+		NewID: func() (string, error) {
+			next++
+			return fmt.Sprintf("writer-%d", next), nil
+		},
+		Now: func() time.Time { return time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	return writer
+}
+
+func TestWriterCommitsBatchAsOneGeneration(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+	writer := newTestWriter(t, store)
+
+	tx, err := writer.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if err := tx.SetProvider(catalogs.Provider{ID: "acme", Name: "Acme"}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	for _, id := range []string{"acme-chat", "acme-mini"} {
+		if err := tx.SetProviderModel("acme", catalogs.Model{ID: id, Name: id}); err != nil {
+			t.Fatalf("SetProviderModel(%s): %v", id, err)
+		}
+	}
+	if _, err := tx.ProviderModel("acme", "acme-mini"); err != nil {
+		t.Fatalf("staged write not readable in transaction: %v", err)
+	}
+	if _, err := store.Current(ctx); !stderrors.Is(err, pkgerrors.ErrNotFound) {
+		t.Fatalf("staged write visible before commit: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if err := tx.SetAuthor(catalogs.Author{ID: "acme"}); err == nil {
+		t.Fatal("mutation after commit succeeded")
+	}
+
+	tx, err = writer.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin second: %v", err)
+	}
+	if err := tx.DeleteProviderModel("acme", "acme-mini"); err != nil {
+		t.Fatalf("DeleteProviderModel: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("Commit second: %v", err)
+	}
+
+	current, err := store.Current(ctx)
+	if err != nil {
+		t.Fatalf("Current: %v", err)
+	}
+	if current.Manifest.GenerationID != "writer-3" {
+		t.Fatalf("current generation = %q", current.Manifest.GenerationID)
+	}
+	catalog, err := DecodeCatalogPayload(current.Payload)
+	if err != nil {
+		t.Fatalf("DecodeCatalogPayload: %v", err)
+	}
+	if _, err := catalog.ProviderModel("acme", "acme-chat"); err != nil {
+		t.Fatalf("committed model missing: %v", err)
+	}
+	if _, err := catalog.ProviderModel("acme", "acme-mini"); err == nil {
+		t.Fatal("deleted model survived commit")
+	}
+}
+
+func TestWriterRollbackDiscardsBatch(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+	tx, err := newTestWriter(t, store).Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if err := tx.SetProvider(catalogs.Provider{ID: "acme", Name: "Acme"}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if err := tx.Commit(ctx); err == nil {
+		t.Fatal("commit after rollback succeeded")
+	}
+	if _, err := store.Current(ctx); !stderrors.Is(err, pkgerrors.ErrNotFound) {
+		t.Fatalf("rolled back write was committed: %v", err)
+	}
+}
+
+func TestWriterConcurrentTransactionsConflict(t *testing.T) {
+	ctx := context.Background()
+	writer := newTestWriter(t, NewMemory())
+	first, err := writer.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin first: %v", err)
+	}
+	second, err := writer.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin second: %v", err)
+	}
+	for _, tx := range []catalogs.Transaction{first, second} {
+		if err := tx.SetProvider(catalogs.Provider{ID: "acme", Name: "Acme"}); err != nil {
+			t.Fatalf("SetProvider: %v", err)
+		}
+	}
+	if err := first.Commit(ctx); err != nil {
+		t.Fatalf("Commit first: %v", err)
+	}
+	if err := second.Commit(ctx); !stderrors.Is(err, pkgerrors.ErrConflict) {
+		t.Fatalf("stale commit err = %v, want conflict", err)
+	}
+}
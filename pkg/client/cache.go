@@ -0,0 +1,264 @@
+// Package client provides a locally cached, self-refreshing view of a remote
+// Starmap catalog for embedding applications.
+//
+// A Cache loads the server's current generation once, then serves every read
+// from that in-memory snapshot. In the background it asks the server for the
+// changes since the snapshot's generation and applies them, falling back to a
+// full snapshot download when the server no longer keeps that generation.
+// Reads never block on the network.
+//
+//	cache, err := client.New(ctx, "https://starmap.example.com/api/v1")
+//	if err != nil {
+//		return err
+//	}
+//	defer cache.Close()
+//	model, err := cache.ProviderModel("openai", "gpt-4o")
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogremote"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Cache is a catalogs.Reader over a locally cached snapshot of a remote
+// catalog. It is safe for concurrent use.
+type Cache struct {
+	remote  *catalogremote.Client
+	options *options
+
+	snapshot  atomic.Pointer[snapshot]
+	refreshMu sync.Mutex
+	lastErr   atomic.Pointer[error]
+
+	cancel    context.CancelFunc
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// snapshot is one immutable catalog generation and when it was last confirmed
+// current.
+type snapshot struct {
+	catalog      *catalogs.Catalog
+	generationID string
+	refreshedAt  time.Time
+}
+
+// StaleError reports that the cache has not refreshed successfully within its
+// maximum staleness. Err is the most recent refresh failure, if any.
+type StaleError struct {
+	GenerationID string
+	RefreshedAt  time.Time
+	MaxStaleness time.Duration
+	Err          error
+}
+
+// Error implements the error interface.
+func (e *StaleError) Error() string {
+	message := fmt.Sprintf("catalog generation %s last refreshed at %s exceeds max staleness %s",
+		e.GenerationID, e.RefreshedAt.UTC().Format(time.RFC3339), e.MaxStaleness)
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Unwrap implements errors.Unwrap.
+func (e *StaleError) Unwrap() error {
+	return e.Err
+}
+
+// New loads the current catalog from the versioned API at baseURL, for
+// example https://starmap.example.com/api/v1, and starts refreshing it in the
+// background. Close stops the refresh loop.
+func New(ctx context.Context, baseURL string, opts ...Option) (*Cache, error) {
+	options, err := defaultOptions().apply(opts...)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := catalogremote.NewClient(baseURL, options.httpClient, catalogs.CurrentCatalogSchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	cache := &Cache{remote: remote, options: options, done: make(chan struct{})}
+	initial, err := cache.fetchSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cache.snapshot.Store(initial)
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	cache.cancel = cancel
+	if options.refreshInterval > 0 {
+		go cache.refreshLoop(loopCtx)
+	} else {
+		close(cache.done)
+	}
+	return cache, nil
+}
+
+// Close stops background refresh. Reads keep serving the last snapshot.
+func (c *Cache) Close() error {
+	c.closeOnce.Do(func() {
+		c.cancel()
+		<-c.done
+	})
+	return nil
+}
+
+// Refresh brings the cache up to the server's current generation. It applies
+// the delta since the cached generation and downloads a full snapshot when the
+// delta is unavailable. On failure the previous snapshot is kept.
+func (c *Cache) Refresh(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	next, err := c.fetchChanges(ctx, c.snapshot.Load())
+	if err != nil {
+		if ctx.Err() != nil {
+			return c.recordError(ctx.Err())
+		}
+		next, err = c.fetchSnapshot(ctx)
+		if err != nil {
+			return c.recordError(err)
+		}
+	}
+	c.snapshot.Store(next)
+	c.lastErr.Store(nil)
+	return nil
+}
+
+// Fresh returns a *StaleError when the cache has gone longer than its maximum
+// staleness without a successful refresh.
+func (c *Cache) Fresh() error {
+	current := c.snapshot.Load()
+	if c.options.maxStaleness == 0 || c.options.now().Sub(current.refreshedAt) <= c.options.maxStaleness {
+		return nil
+	}
+	stale := &StaleError{
+		GenerationID: current.generationID,
+		RefreshedAt:  current.refreshedAt,
+		MaxStaleness: c.options.maxStaleness,
+	}
+	if err := c.lastErr.Load(); err != nil {
+		stale.Err = *err
+	}
+	return stale
+}
+
+// Catalog returns the current snapshot.
+func (c *Cache) Catalog() *catalogs.Catalog {
+	return c.snapshot.Load().catalog
+}
+
+// GenerationID returns the generation ID of the current snapshot.
+func (c *Cache) GenerationID() string {
+	return c.snapshot.Load().generationID
+}
+
+// RefreshedAt returns when the current snapshot was last confirmed current.
+func (c *Cache) RefreshedAt() time.Time {
+	return c.snapshot.Load().refreshedAt
+}
+
+// Providers returns the providers in the current snapshot.
+func (c *Cache) Providers() catalogs.ProvidersReader { return c.Catalog().Providers() }
+
+// Authors returns the authors in the current snapshot.
+func (c *Cache) Authors() catalogs.AuthorsReader { return c.Catalog().Authors() }
+
+// Endpoints returns the endpoints in the current snapshot.
+func (c *Cache) Endpoints() catalogs.EndpointsReader { return c.Catalog().Endpoints() }
+
+// Models returns the models in the current snapshot.
+func (c *Cache) Models() catalogs.ModelsReader { return c.Catalog().Models() }
+
+// Provenance returns the provenance in the current snapshot.
+func (c *Cache) Provenance() catalogs.ProvenanceReader { return c.Catalog().Provenance() }
+
+// Provider returns a provider from the current snapshot.
+func (c *Cache) Provider(id catalogs.ProviderID) (catalogs.Provider, error) {
+	return c.Catalog().Provider(id)
+}
+
+// Author returns an author from the current snapshot.
+func (c *Cache) Author(id catalogs.AuthorID) (catalogs.Author, error) {
+	return c.Catalog().Author(id)
+}
+
+// Endpoint returns an endpoint from the current snapshot.
+func (c *Cache) Endpoint(id string) (catalogs.Endpoint, error) {
+	return c.Catalog().Endpoint(id)
+}
+
+// ProviderModels returns a provider's models from the current snapshot.
+func (c *Cache) ProviderModels(id catalogs.ProviderID) (catalogs.ModelsReader, error) {
+	return c.Catalog().ProviderModels(id)
+}
+
+// ProviderModel returns a provider's model from the current snapshot.
+func (c *Cache) ProviderModel(providerID catalogs.ProviderID, modelID string) (catalogs.Model, error) {
+	return c.Catalog().ProviderModel(providerID, modelID)
+}
+
+var _ catalogs.Reader = (*Cache)(nil)
+
+func (c *Cache) refreshLoop(ctx context.Context) {
+	defer close(c.done)
+	ticker := time.NewTicker(c.options.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(ctx); err != nil && ctx.Err() == nil && c.options.onError != nil {
+				c.options.onError(err)
+			}
+		}
+	}
+}
+
+func (c *Cache) fetchChanges(ctx context.Context, current *snapshot) (*snapshot, error) {
+	changes, err := c.remote.FetchChanges(ctx, current.generationID)
+	if err != nil {
+		return nil, err
+	}
+	next := &snapshot{catalog: current.catalog, generationID: changes.Current, refreshedAt: c.options.now()}
+	if changes.Current == current.generationID {
+		return next, nil
+	}
+	catalog, err := changes.Apply(current.catalog)
+	if err != nil {
+		return nil, errors.WrapResource("apply", "catalog changes", changes.Current, err)
+	}
+	next.catalog = catalog
+	return next, nil
+}
+
+func (c *Cache) fetchSnapshot(ctx context.Context) (*snapshot, error) {
+	generation, err := c.remote.FetchCurrent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := catalogstore.DecodeCatalogPayload(generation.Payload)
+	if err != nil {
+		return nil, errors.WrapResource("decode", "catalog generation", generation.Manifest.GenerationID, err)
+	}
+	return &snapshot{catalog: catalog, generationID: generation.Manifest.GenerationID, refreshedAt: c.options.now()}, nil
+}
+
+func (c *Cache) recordError(err error) error {
+	c.lastErr.Store(&err)
+	return err
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func TestCacheFreshReportsStaleSnapshot(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	options := defaultOptions()
+	options.maxStaleness = time.Minute
+	options.now = func() time.Time { return now }
+	cache := &Cache{options: options}
+	cache.snapshot.Store(&snapshot{catalog: &catalogs.Catalog{}, generationID: "gen-1", refreshedAt: now})
+
+	if err := cache.Fresh(); err != nil {
+		t.Fatalf("Fresh right after refresh: %v", err)
+	}
+
+	refreshErr := errors.New("server unavailable")
+	_ = cache.recordError(refreshErr)
+	now = now.Add(2 * time.Minute)
+	var stale *StaleError
+	if err := cache.Fresh(); !errors.As(err, &stale) {
+		t.Fatalf("Fresh = %v, want *StaleError", err)
+	}
+	if stale.GenerationID != "gen-1" || !errors.Is(stale, refreshErr) {
+		t.Fatalf("stale = %#v", stale)
+	}
+
+	options.maxStaleness = 0
+	if err := cache.Fresh(); err != nil {
+		t.Fatalf("Fresh with staleness check disabled: %v", err)
+	}
+}
+
+func TestOptionsRejectInvalidValues(t *testing.T) {
+	for _, opt := range []Option{WithRefreshInterval(-time.Second), WithMaxStaleness(-time.Second), WithHTTPClient(nil)} {
+		if _, err := defaultOptions().apply(opt); err == nil {
+			t.Fatal("apply accepted invalid option")
+		}
+	}
+}
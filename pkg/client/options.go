@@ -0,0 +1,89 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Default refresh settings.
+const (
+	// DefaultRefreshInterval is how often the cache checks for a new generation.
+	DefaultRefreshInterval = 5 * time.Minute
+	// DefaultMaxStaleness is how long the cache may go without a successful
+	// refresh before Fresh reports it stale.
+	DefaultMaxStaleness = 3 * DefaultRefreshInterval
+)
+
+type options struct {
+	httpClient      *http.Client
+	refreshInterval time.Duration
+	maxStaleness    time.Duration
+	onError         func(error)
+	now             func() time.Time
+}
+
+func defaultOptions() *options {
+	return &options{
+		refreshInterval: DefaultRefreshInterval,
+		maxStaleness:    DefaultMaxStaleness,
+		now:             time.Now,
+	}
+}
+
+// Option configures a Cache.
+type Option func(*options) error
+
+func (o *options) apply(opts ...Option) (*options, error) {
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// WithHTTPClient sets the HTTP client used to reach the server.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) error {
+		if httpClient == nil {
+			return &errors.ValidationError{Field: "http_client", Message: "cannot be nil"}
+		}
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithRefreshInterval sets how often the cache checks for a new generation.
+// A zero interval disables background refresh; call Refresh instead.
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(o *options) error {
+		if interval < 0 {
+			return &errors.ValidationError{Field: "refresh_interval", Value: interval, Message: "cannot be negative"}
+		}
+		o.refreshInterval = interval
+		return nil
+	}
+}
+
+// WithMaxStaleness sets how long the cache may go without a successful
+// refresh before Fresh reports it stale. Zero disables the check.
+func WithMaxStaleness(maxStaleness time.Duration) Option {
+	return func(o *options) error {
+		if maxStaleness < 0 {
+			return &errors.ValidationError{Field: "max_staleness", Value: maxStaleness, Message: "cannot be negative"}
+		}
+		o.maxStaleness = maxStaleness
+		return nil
+	}
+}
+
+// WithErrorHandler sets a callback for background refresh failures. The
+// cache keeps serving its last snapshot when a refresh fails.
+func WithErrorHandler(handler func(error)) Option {
+	return func(o *options) error {
+		o.onError = handler
+		return nil
+	}
+}
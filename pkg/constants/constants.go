@@ -19,6 +19,10 @@ const (
 	// ProviderFetchTimeout is the timeout for fetching models from a single provider.
 	ProviderFetchTimeout = 2 * time.Minute
 
+	// PartialFetchMaxAge is how long provider results kept from an interrupted
+	// sync are reused instead of fetched again.
+	PartialFetchMaxAge = time.Hour
+
 	// CommandTimeout is the default timeout for CLI commands.
 	CommandTimeout = 10 * time.Minute
 
@@ -102,6 +106,10 @@ const (
 
 	// MaxSourcePayloadBytes bounds one provider or catalog source JSON payload.
 	MaxSourcePayloadBytes = 16 << 20
+
+	// ProvenanceHistoryDepth is how many values the provenance history keeps
+	// per field.
+	ProvenanceHistoryDepth = 20
 )
 
 // Rate limiting constants.
@@ -117,6 +125,17 @@ const (
 
 	// MaxRateLimitRetries is the maximum number of retries for rate-limited requests.
 	MaxRateLimitRetries = 5
+
+	// CircuitBreakerThreshold is how many consecutive failed provider calls
+	// open the circuit, rejecting calls until CircuitBreakerCooldown passes.
+	CircuitBreakerThreshold = 5
+
+	// CircuitBreakerCooldown is how long an open circuit rejects provider calls.
+	CircuitBreakerCooldown = 1 * time.Minute
+
+	// DocsScrapeInterval is the minimum time between requests to one
+	// documentation website when scraping provider docs.
+	DocsScrapeInterval = 2 * time.Second
 )
 
 // Cache constants.
@@ -129,6 +148,18 @@ const (
 
 	// MaxCacheSize is the maximum number of items in the cache.
 	MaxCacheSize = 1000
+
+	// ProviderFetchCacheTTL is how long models fetched from a provider API are
+	// reused by later commands instead of fetched again.
+	ProviderFetchCacheTTL = CacheTTL
+
+	// ProviderHTTPCacheTTL is how long provider API responses are reused
+	// without a request before they are revalidated with the provider.
+	ProviderHTTPCacheTTL = CacheTTL
+
+	// DocsScrapeCacheTTL is how long scraped documentation pages are reused
+	// instead of fetched again.
+	DocsScrapeCacheTTL = 24 * time.Hour
 )
 
 // Logging constants.
@@ -199,8 +230,44 @@ const (
 	// DefaultModelsDevCachePath is the default path for models.dev HTTP cache.
 	DefaultModelsDevCachePath = "~/.starmap/cache/models.dev"
 
+	// DefaultProviderFetchCachePath is the default directory for cached provider API fetches.
+	DefaultProviderFetchCachePath = "~/.starmap/cache/providers"
+
+	// DefaultProviderHTTPCachePath is the default directory for cached provider API responses.
+	DefaultProviderHTTPCachePath = "~/.starmap/cache/http"
+
+	// DefaultDocsScrapeCachePath is the default directory for cached provider documentation pages.
+	DefaultDocsScrapeCachePath = "~/.starmap/cache/docs"
+
+	// DefaultDocsExtractorsPath is the default directory for user documentation extractor configs.
+	DefaultDocsExtractorsPath = "~/.starmap/docs-extractors"
+
+	// DefaultProviderShapesPath is the default directory for recorded provider response shapes.
+	DefaultProviderShapesPath = "~/.starmap/sources/provider-shapes"
+
+	// DefaultPartialFetchPath is the default directory for provider results kept from an interrupted sync.
+	DefaultPartialFetchPath = "~/.starmap/sources/partial-fetch"
+
+	// DefaultCapabilityVerificationsPath is the default directory for capability probe results.
+	DefaultCapabilityVerificationsPath = "~/.starmap/sources/capability-verifications"
+
+	// DefaultPolicyWatchPath is the default directory for provider policy document hashes.
+	DefaultPolicyWatchPath = "~/.starmap/sources/policy-watch"
+
+	// DefaultProvenanceHistoryPath is the default durable log of field values recorded by applied syncs.
+	DefaultProvenanceHistoryPath = "~/.starmap/sources/provenance-history.jsonl"
+
+	// DefaultPricingHistoryPath is the default log of model token prices recorded by applied syncs.
+	DefaultPricingHistoryPath = "~/.starmap/sources/pricing-history.jsonl"
+
 	// DefaultProvenancePath is the default provenance file in the editable export.
 	DefaultProvenancePath = "~/.starmap/exports/catalog/provenance.yaml"
+
+	// DefaultTelemetryPath is the default file recording the telemetry opt-in.
+	DefaultTelemetryPath = "~/.starmap/telemetry.yaml"
+
+	// DefaultLastUpdatePath is the default file summarizing the last applied update.
+	DefaultLastUpdatePath = "~/.starmap/last-update.json"
 )
 
 // Format constants.
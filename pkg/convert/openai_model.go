@@ -38,10 +38,21 @@ func ToOpenAIModel(m *catalogs.Model) OpenAIModel {
 		}
 	}
 
+	// Fall back to the release date, then to 0, rather than the negative
+	// Unix time of a zero CreatedAt.
+	created := m.CreatedAt
+	if created.IsZero() && m.Metadata != nil {
+		created = m.Metadata.ReleaseDate
+	}
+	var createdUnix int64
+	if !created.IsZero() {
+		createdUnix = created.Unix()
+	}
+
 	return OpenAIModel{
 		ID:      m.ID,
 		Object:  "model",
-		Created: m.CreatedAt.Unix(),
+		Created: createdUnix,
 		OwnedBy: ownedBy,
 	}
 }
@@ -113,6 +113,17 @@ func TestOpenAIModelAuthorWithoutID(t *testing.T) {
 	}
 }
 
+// TestOpenAIModelCreatedFallback tests the created time of models without CreatedAt.
+func TestOpenAIModelCreatedFallback(t *testing.T) {
+	released := &catalogs.Model{ID: "released", Metadata: &catalogs.ModelMetadata{ReleaseDate: mustParseUTC("2024-01-01")}}
+	if got, want := ToOpenAIModel(released).Created, mustParseUTC("2024-01-01").Unix(); got != want {
+		t.Errorf("Created with release date = %d, want %d", got, want)
+	}
+	if got := ToOpenAIModel(&catalogs.Model{ID: "undated"}).Created; got != 0 {
+		t.Errorf("Created without dates = %d, want 0", got)
+	}
+}
+
 // TestOpenAIModelsResponse tests the full response structure.
 func TestOpenAIModelsResponse(t *testing.T) {
 	models := []*catalogs.Model{
@@ -0,0 +1,291 @@
+// Package costs estimates the price of model usage from catalog pricing. An
+// Estimator prices a usage — tokens by billing category, images, and
+// per-request fees — on one provider offering of a model and returns an
+// itemized breakdown.
+//
+// Example usage:
+//
+//	estimator := costs.NewEstimator(catalog)
+//	breakdown, err := estimator.Estimate("gpt-4o", "", costs.Usage{
+//	    InputTokens:  10_000,
+//	    OutputTokens: 2_000,
+//	})
+//	fmt.Printf("%s %.6f\n", breakdown.Currency, breakdown.Total)
+package costs
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Component names a line item of a breakdown.
+type Component string
+
+// Components of a cost breakdown.
+const (
+	ComponentInput      Component = "input"
+	ComponentCacheRead  Component = "cache_read"
+	ComponentCacheWrite Component = "cache_write"
+	ComponentOutput     Component = "output"
+	ComponentReasoning  Component = "reasoning"
+	ComponentImages     Component = "images"
+	ComponentRequests   Component = "requests"
+)
+
+// Usage is the usage to price. Token counts are totals across all requests;
+// images are priced individually at ImageWidth × ImageHeight.
+type Usage struct {
+	InputTokens      int64 `json:"input_tokens" yaml:"input_tokens"`                                 // Uncached input tokens
+	CacheReadTokens  int64 `json:"cache_read_tokens,omitempty" yaml:"cache_read_tokens,omitempty"`   // Input tokens read from the prompt cache
+	CacheWriteTokens int64 `json:"cache_write_tokens,omitempty" yaml:"cache_write_tokens,omitempty"` // Input tokens written to the prompt cache
+	OutputTokens     int64 `json:"output_tokens" yaml:"output_tokens"`                               // Output tokens, excluding reasoning
+	ReasoningTokens  int64 `json:"reasoning_tokens,omitempty" yaml:"reasoning_tokens,omitempty"`     // Internal reasoning tokens
+	Images           int   `json:"images,omitempty" yaml:"images,omitempty"`                         // Input images
+	ImageWidth       int   `json:"image_width,omitempty" yaml:"image_width,omitempty"`
+	ImageHeight      int   `json:"image_height,omitempty" yaml:"image_height,omitempty"`
+	Requests         int64 `json:"requests,omitempty" yaml:"requests,omitempty"` // Requests billed a per-request fee; default 1
+}
+
+// Validate checks that the usage counts are not negative and that images
+// carry their dimensions.
+func (u Usage) Validate() error {
+	counts := []struct {
+		field string
+		value int64
+	}{
+		{"input_tokens", u.InputTokens},
+		{"cache_read_tokens", u.CacheReadTokens},
+		{"cache_write_tokens", u.CacheWriteTokens},
+		{"output_tokens", u.OutputTokens},
+		{"reasoning_tokens", u.ReasoningTokens},
+		{"images", int64(u.Images)},
+		{"requests", u.Requests},
+	}
+	for _, count := range counts {
+		if count.value < 0 {
+			return &errors.ValidationError{Field: count.field, Value: count.value, Message: "cannot be negative"}
+		}
+	}
+	if u.Images > 0 && (u.ImageWidth <= 0 || u.ImageHeight <= 0) {
+		return &errors.ValidationError{Field: "image_size", Value: [2]int{u.ImageWidth, u.ImageHeight}, Message: "must be positive when images are sent"}
+	}
+	return nil
+}
+
+// promptTokens returns the input tokens that count toward the context size.
+func (u Usage) promptTokens() int64 {
+	return u.InputTokens + u.CacheReadTokens + u.CacheWriteTokens
+}
+
+// LineItem is the cost of one component of a usage.
+type LineItem struct {
+	Component Component `json:"component" yaml:"component"`
+	Quantity  int64     `json:"quantity" yaml:"quantity"`     // Tokens, images, or requests
+	UnitPrice float64   `json:"unit_price" yaml:"unit_price"` // Per one million tokens, or per image or request
+	Cost      float64   `json:"cost" yaml:"cost"`
+}
+
+// Breakdown is the estimated cost of a usage on one provider offering.
+type Breakdown struct {
+	ProviderID catalogs.ProviderID           `json:"provider" yaml:"provider"`
+	ModelID    string                        `json:"model" yaml:"model"`
+	Currency   catalogs.ModelPricingCurrency `json:"currency" yaml:"currency"`
+	Tier       string                        `json:"tier,omitempty" yaml:"tier,omitempty"` // Pricing tier applied, if any
+	Items      []LineItem                    `json:"items" yaml:"items"`
+	Total      float64                       `json:"total" yaml:"total"`
+}
+
+// Estimator prices usage with the token and operation prices of a catalog.
+type Estimator struct {
+	catalog catalogs.Reader
+}
+
+// NewEstimator returns an estimator that prices usage with catalog.
+func NewEstimator(catalog catalogs.Reader) *Estimator {
+	return &Estimator{catalog: catalog}
+}
+
+// Estimate prices usage on providerID's offering of modelID. An empty
+// providerID picks the first provider, by ID, that prices the model's tokens.
+// Cache reads and writes without a cache price are billed as input, and
+// reasoning tokens without a reasoning price as output. When the prompt
+// exceeds a context pricing tier, the largest such tier's prices apply.
+func (e *Estimator) Estimate(modelID string, providerID catalogs.ProviderID, usage Usage) (*Breakdown, error) {
+	if e.catalog == nil {
+		return nil, &errors.ValidationError{Field: "catalog", Message: "catalog reader cannot be nil"}
+	}
+	if err := usage.Validate(); err != nil {
+		return nil, err
+	}
+	provider, model, err := e.offering(modelID, providerID)
+	if err != nil {
+		return nil, err
+	}
+	pricing := model.Pricing
+	if pricing == nil || pricing.Tokens == nil || (pricing.Tokens.Input == nil && pricing.Tokens.Output == nil) {
+		return nil, &errors.ValidationError{Field: "pricing.tokens", Value: modelID, Message: "is not recorded for this model"}
+	}
+
+	breakdown := &Breakdown{
+		ProviderID: provider.ID,
+		ModelID:    model.ID,
+		Currency:   pricing.Currency,
+		Items:      []LineItem{},
+	}
+	if breakdown.Currency == "" {
+		breakdown.Currency = catalogs.ModelPricingCurrencyUSD
+	}
+	prices, operations := tokenRates(pricing.Tokens), pricing.Operations
+	if tier := contextTier(pricing.Tiers, usage.promptTokens()); tier != nil {
+		breakdown.Tier = tier.Name
+		prices = prices.overlay(tokenRates(tier.Tokens))
+		if tier.Operations != nil {
+			operations = tier.Operations
+		}
+	}
+
+	input, output := value(prices.input), value(prices.output)
+	cacheRead, cacheWrite, reasoning := input, input, output
+	if prices.cacheRead != nil {
+		cacheRead = *prices.cacheRead
+	}
+	if prices.cacheWrite != nil {
+		cacheWrite = *prices.cacheWrite
+	}
+	if prices.reasoning != nil {
+		reasoning = *prices.reasoning
+	}
+	breakdown.addTokens(ComponentInput, usage.InputTokens, input)
+	breakdown.addTokens(ComponentCacheRead, usage.CacheReadTokens, cacheRead)
+	breakdown.addTokens(ComponentCacheWrite, usage.CacheWriteTokens, cacheWrite)
+	breakdown.addTokens(ComponentOutput, usage.OutputTokens, output)
+	breakdown.addTokens(ComponentReasoning, usage.ReasoningTokens, reasoning)
+
+	if usage.Images > 0 {
+		perImage, err := model.ImageInputCost(provider, usage.ImageWidth, usage.ImageHeight)
+		if err != nil {
+			return nil, err
+		}
+		breakdown.add(ComponentImages, int64(usage.Images), perImage, perImage*float64(usage.Images))
+	}
+	if operations != nil && operations.Request != nil {
+		requests := usage.Requests
+		if requests == 0 {
+			requests = 1
+		}
+		breakdown.add(ComponentRequests, requests, *operations.Request, *operations.Request*float64(requests))
+	}
+	return breakdown, nil
+}
+
+// offering finds the provider offering of modelID to price.
+func (e *Estimator) offering(modelID string, providerID catalogs.ProviderID) (*catalogs.Provider, *catalogs.Model, error) {
+	if providerID != "" {
+		provider, ok := e.catalog.Providers().Get(providerID)
+		if !ok {
+			return nil, nil, &errors.NotFoundError{Resource: "provider", ID: string(providerID)}
+		}
+		model, ok := provider.Models[modelID]
+		if !ok || model == nil {
+			return nil, nil, &errors.NotFoundError{Resource: "model", ID: modelID}
+		}
+		return provider, model, nil
+	}
+
+	providers := e.catalog.Providers().List()
+	slices.SortFunc(providers, func(a, b catalogs.Provider) int { return cmp.Compare(a.ID, b.ID) })
+	var unpriced *catalogs.Provider
+	for i := range providers {
+		model, ok := providers[i].Models[modelID]
+		if !ok || model == nil {
+			continue
+		}
+		if model.Pricing != nil && model.Pricing.Tokens != nil {
+			return &providers[i], model, nil
+		}
+		if unpriced == nil {
+			unpriced = &providers[i]
+		}
+	}
+	if unpriced != nil {
+		return unpriced, unpriced.Models[modelID], nil
+	}
+	return nil, nil, &errors.NotFoundError{Resource: "model", ID: modelID}
+}
+
+// contextTier returns the largest context tier the prompt exceeds, if any.
+func contextTier(tiers []catalogs.ModelPricingTier, promptTokens int64) *catalogs.ModelPricingTier {
+	var applied *catalogs.ModelPricingTier
+	for i := range tiers {
+		tier := &tiers[i]
+		if tier.Type != catalogs.ModelPricingTierTypeContext || tier.Size <= 0 || promptTokens <= tier.Size {
+			continue
+		}
+		if applied == nil || tier.Size > applied.Size {
+			applied = tier
+		}
+	}
+	return applied
+}
+
+// rates are token prices per one million tokens; nil prices are not listed.
+type rates struct {
+	input, output, reasoning, cacheRead, cacheWrite *float64
+}
+
+// tokenRates resolves tokens to per-million prices, preferring the nested
+// cache structure over the flat one.
+func tokenRates(tokens *catalogs.ModelTokenPricing) rates {
+	if tokens == nil {
+		return rates{}
+	}
+	r := rates{
+		input:      tokens.Input.PerMillion(),
+		output:     tokens.Output.PerMillion(),
+		reasoning:  tokens.Reasoning.PerMillion(),
+		cacheRead:  tokens.CacheRead.PerMillion(),
+		cacheWrite: tokens.CacheWrite.PerMillion(),
+	}
+	if tokens.Cache != nil {
+		if read := tokens.Cache.Read.PerMillion(); read != nil {
+			r.cacheRead = read
+		}
+		if write := tokens.Cache.Write.PerMillion(); write != nil {
+			r.cacheWrite = write
+		}
+	}
+	return r
+}
+
+// overlay returns r with the prices tier lists replacing its own.
+func (r rates) overlay(tier rates) rates {
+	return rates{
+		input:      cmp.Or(tier.input, r.input),
+		output:     cmp.Or(tier.output, r.output),
+		reasoning:  cmp.Or(tier.reasoning, r.reasoning),
+		cacheRead:  cmp.Or(tier.cacheRead, r.cacheRead),
+		cacheWrite: cmp.Or(tier.cacheWrite, r.cacheWrite),
+	}
+}
+
+func value(price *float64) float64 {
+	if price == nil {
+		return 0
+	}
+	return *price
+}
+
+func (b *Breakdown) addTokens(component Component, tokens int64, per1M float64) {
+	if tokens == 0 {
+		return
+	}
+	b.add(component, tokens, per1M, float64(tokens)*per1M/1_000_000)
+}
+
+func (b *Breakdown) add(component Component, quantity int64, unitPrice, cost float64) {
+	b.Items = append(b.Items, LineItem{Component: component, Quantity: quantity, UnitPrice: unitPrice, Cost: cost})
+	b.Total += cost
+}
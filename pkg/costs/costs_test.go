@@ -0,0 +1,131 @@
+package costs
+
+import (
+	stderrors "errors"
+	"math"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func costsTestCatalog(t *testing.T) *catalogs.Catalog {
+	t.Helper()
+	request := 0.01
+	builder := catalogs.NewEmpty()
+	for _, provider := range []catalogs.Provider{
+		{ID: "alpha", Name: "Alpha", Models: map[string]*catalogs.Model{
+			"shared": {ID: "shared", Name: "Shared"},
+		}},
+		{ID: "beta", Name: "Beta", Models: map[string]*catalogs.Model{
+			"shared": {
+				ID: "shared", Name: "Shared",
+				Pricing: &catalogs.ModelPricing{
+					Currency: catalogs.ModelPricingCurrencyUSD,
+					Tokens: &catalogs.ModelTokenPricing{
+						Input:     &catalogs.ModelTokenCost{Per1M: 2},
+						Output:    &catalogs.ModelTokenCost{Per1M: 8},
+						Cache:     &catalogs.ModelTokenCachePricing{Read: &catalogs.ModelTokenCost{Per1M: 0.5}},
+						Reasoning: &catalogs.ModelTokenCost{Per1M: 10},
+					},
+					Operations: &catalogs.ModelOperationPricing{Request: &request},
+					Tiers: []catalogs.ModelPricingTier{{
+						Name: "context_over_200k", Type: catalogs.ModelPricingTierTypeContext, Size: 200_000,
+						Tokens: &catalogs.ModelTokenPricing{Input: &catalogs.ModelTokenCost{Per1M: 4}},
+					}},
+				},
+				Vision: &catalogs.ModelVision{TokenCost: &catalogs.ImageTokenFormula{Method: catalogs.ImageTokenMethodFixed, BaseTokens: 500}},
+			},
+		}},
+	} {
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider(%s): %v", provider.ID, err)
+		}
+	}
+	catalog, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return catalog
+}
+
+func TestEstimateItemizesUsage(t *testing.T) {
+	estimator := NewEstimator(costsTestCatalog(t))
+	breakdown, err := estimator.Estimate("shared", "", Usage{
+		InputTokens:     10_000,
+		CacheReadTokens: 4_000,
+		OutputTokens:    2_000,
+		ReasoningTokens: 1_000,
+		Images:          2,
+		ImageWidth:      512,
+		ImageHeight:     512,
+	})
+	if err != nil {
+		t.Fatalf("Estimate failed: %v", err)
+	}
+	if breakdown.ProviderID != "beta" {
+		t.Errorf("provider = %s, want the priced offering beta", breakdown.ProviderID)
+	}
+
+	want := map[Component]float64{
+		ComponentInput:     0.02,
+		ComponentCacheRead: 0.002,
+		ComponentOutput:    0.016,
+		ComponentReasoning: 0.01,
+		ComponentImages:    0.002,
+		ComponentRequests:  0.01,
+	}
+	if len(breakdown.Items) != len(want) {
+		t.Fatalf("items = %+v, want %d items", breakdown.Items, len(want))
+	}
+	var total float64
+	for _, item := range breakdown.Items {
+		if math.Abs(item.Cost-want[item.Component]) > 1e-12 {
+			t.Errorf("%s cost = %v, want %v", item.Component, item.Cost, want[item.Component])
+		}
+		total += want[item.Component]
+	}
+	if math.Abs(breakdown.Total-total) > 1e-12 {
+		t.Errorf("total = %v, want %v", breakdown.Total, total)
+	}
+}
+
+func TestEstimateAppliesContextTier(t *testing.T) {
+	estimator := NewEstimator(costsTestCatalog(t))
+	breakdown, err := estimator.Estimate("shared", "beta", Usage{InputTokens: 250_000, CacheReadTokens: 1_000_000})
+	if err != nil {
+		t.Fatalf("Estimate failed: %v", err)
+	}
+	if breakdown.Tier != "context_over_200k" {
+		t.Errorf("tier = %q, want context_over_200k", breakdown.Tier)
+	}
+	if item := breakdown.Items[0]; item.Component != ComponentInput || item.UnitPrice != 4 {
+		t.Errorf("input item = %+v, want the tier price 4", item)
+	}
+	if item := breakdown.Items[1]; item.Component != ComponentCacheRead || item.UnitPrice != 0.5 {
+		t.Errorf("cache read item = %+v, want the base cache price 0.5", item)
+	}
+}
+
+func TestEstimateErrors(t *testing.T) {
+	estimator := NewEstimator(costsTestCatalog(t))
+
+	var notFound *pkgerrors.NotFoundError
+	if _, err := estimator.Estimate("missing", "", Usage{InputTokens: 1}); !stderrors.As(err, &notFound) {
+		t.Errorf("unknown model error = %v, want NotFoundError", err)
+	}
+	if _, err := estimator.Estimate("shared", "gamma", Usage{InputTokens: 1}); !stderrors.As(err, &notFound) {
+		t.Errorf("unknown provider error = %v, want NotFoundError", err)
+	}
+
+	var validation *pkgerrors.ValidationError
+	if _, err := estimator.Estimate("shared", "alpha", Usage{InputTokens: 1}); !stderrors.As(err, &validation) || validation.Field != "pricing.tokens" {
+		t.Errorf("unpriced offering error = %v, want a pricing.tokens ValidationError", err)
+	}
+	if _, err := estimator.Estimate("shared", "beta", Usage{OutputTokens: -1}); !stderrors.As(err, &validation) || validation.Field != "output_tokens" {
+		t.Errorf("negative usage error = %v, want an output_tokens ValidationError", err)
+	}
+	if _, err := estimator.Estimate("shared", "beta", Usage{Images: 1}); !stderrors.As(err, &validation) || validation.Field != "image_size" {
+		t.Errorf("sizeless image error = %v, want an image_size ValidationError", err)
+	}
+}
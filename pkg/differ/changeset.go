@@ -24,12 +24,22 @@ const (
 // FieldChange represents a change to a specific field.
 type FieldChange struct {
 	Path     string     // Field path (e.g., "pricing.input")
+	Key      string     // Element key for slice and map element changes (e.g., "openai" in "authors")
 	OldValue string     // Previous value (string representation)
 	NewValue string     // New value (string representation)
 	Type     ChangeType // Type of change
 	Source   sources.ID // Source that caused the change (for provenance)
 }
 
+// Field returns the changed field, including the element key when the
+// change is to one element of a slice or map (e.g., "authors[openai]").
+func (c FieldChange) Field() string {
+	if c.Key == "" {
+		return c.Path
+	}
+	return c.Path + "[" + c.Key + "]"
+}
+
 // ModelUpdate represents an update to an existing model.
 type ModelUpdate struct {
 	ID         string              // ID of the model being updated
@@ -89,6 +99,8 @@ type Changeset struct {
 	Models    *ModelChangeset    // Model changes
 	Providers *ProviderChangeset // Provider changes
 	Authors   *AuthorChangeset   // Author changes
+	Policies  []PolicyChange     // Provider legal document changes (advisory)
+	Pinned    []PinnedChange     // Incoming changes blocked by field pins (advisory)
 	Summary   ChangesetSummary   // Summary statistics
 }
 
@@ -161,7 +173,7 @@ func (a *AuthorChangeset) HasChanges() bool {
 
 // String returns a human-readable summary of the changeset.
 func (c *Changeset) String() string {
-	if c.IsEmpty() {
+	if c.IsEmpty() && len(c.Policies) == 0 && len(c.Pinned) == 0 {
 		return "No changes detected"
 	}
 
@@ -212,6 +224,14 @@ func (c *Changeset) String() string {
 		parts = append(parts, fmt.Sprintf("Authors: %s", strings.Join(authorParts, ", ")))
 	}
 
+	// Policy documents are advisory and not part of the total
+	if len(c.Policies) > 0 {
+		parts = append(parts, fmt.Sprintf("Policies: %d changed", len(c.Policies)))
+	}
+	if len(c.Pinned) > 0 {
+		parts = append(parts, fmt.Sprintf("Pinned: %d blocked", len(c.Pinned)))
+	}
+
 	return fmt.Sprintf("Changeset: %s (Total: %d changes)", strings.Join(parts, "; "), c.Summary.TotalChanges)
 }
 
@@ -234,6 +254,16 @@ func (c *Changeset) Print() {
 	if c.Authors.HasChanges() {
 		c.Authors.Print()
 	}
+
+	// Print policy document changes
+	if len(c.Policies) > 0 {
+		printPolicyChanges(c.Policies)
+	}
+
+	// Print changes blocked by pins
+	if len(c.Pinned) > 0 {
+		printPinnedChanges(c.Pinned)
+	}
 }
 
 // Print outputs model changes in a human-readable format.
@@ -257,7 +287,7 @@ func (m *ModelChangeset) Print() {
 		for _, update := range m.Updated {
 			fmt.Printf("  • %s:\n", update.ID)
 			for _, change := range update.Changes {
-				fmt.Printf("    - %s: %s → %s\n", change.Path, change.OldValue, change.NewValue)
+				fmt.Printf("    - %s: %s → %s\n", change.Field(), change.OldValue, change.NewValue)
 			}
 		}
 	}
@@ -294,7 +324,7 @@ func (p *ProviderChangeset) Print() {
 		for _, update := range p.Updated {
 			fmt.Printf("  • %s:\n", update.ID)
 			for _, change := range update.Changes {
-				fmt.Printf("    - %s: %s → %s\n", change.Path, change.OldValue, change.NewValue)
+				fmt.Printf("    - %s: %s → %s\n", change.Field(), change.OldValue, change.NewValue)
 			}
 		}
 	}
@@ -331,7 +361,7 @@ func (a *AuthorChangeset) Print() {
 		for _, update := range a.Updated {
 			fmt.Printf("  • %s:\n", update.ID)
 			for _, change := range update.Changes {
-				fmt.Printf("    - %s: %s → %s\n", change.Path, change.OldValue, change.NewValue)
+				fmt.Printf("    - %s: %s → %s\n", change.Field(), change.OldValue, change.NewValue)
 			}
 		}
 	}
@@ -371,6 +401,8 @@ func (c *Changeset) Filter(strategy ApplyStrategy) *Changeset {
 		Models:    &ModelChangeset{},
 		Providers: &ProviderChangeset{},
 		Authors:   &AuthorChangeset{},
+		Policies:  c.Policies, // Advisory, so every strategy keeps them
+		Pinned:    c.Pinned,
 	}
 
 	switch strategy {
@@ -3,7 +3,9 @@ package differ
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/agentstation/utc"
@@ -18,9 +20,11 @@ import (
 // useful.
 type Differ struct {
 	// Options for controlling diff behavior
-	ignoreFields   map[string]bool
-	deepComparison bool
-	tracking       bool
+	ignoreFields        map[string]bool
+	deepComparison      bool
+	tracking            bool
+	priceTolerance      float64
+	ignoreTimestampOnly bool
 }
 
 // New creates a updated Differ with default settings.
@@ -187,7 +191,7 @@ func (diff *Differ) Catalogs(existing, updated catalogs.Reader) *Changeset {
 	// Diff providers
 	existingProviders := existing.Providers().List()
 	newProviders := updated.Providers().List()
-	changeset.Models = diff.providerScopedModels(existingProviders, newProviders)
+	changeset.Models = diff.providerScopedModels(existingProviders, newProviders, unchangedModels(existing, updated))
 	changeset.Providers = diff.Providers(existingProviders, newProviders)
 
 	// Diff authors
@@ -201,7 +205,35 @@ func (diff *Differ) Catalogs(existing, updated catalogs.Reader) *Changeset {
 	return changeset
 }
 
-func (diff *Differ) providerScopedModels(existingProviders, updatedProviders []catalogs.Provider) *ModelChangeset {
+// fingerprinter is implemented by catalogs that store model fingerprints,
+// such as the immutable *catalogs.Catalog.
+type fingerprinter interface {
+	ModelFingerprint(providerID catalogs.ProviderID, modelID string) (string, bool)
+}
+
+// unchangedModels returns a predicate reporting whether a provider model has
+// the same stored fingerprint in both catalogs, or nil when either catalog
+// does not store fingerprints.
+func unchangedModels(existing, updated catalogs.Reader) func(catalogs.ProviderID, string) bool {
+	existingFingerprints, ok := existing.(fingerprinter)
+	if !ok {
+		return nil
+	}
+	updatedFingerprints, ok := updated.(fingerprinter)
+	if !ok {
+		return nil
+	}
+	return func(providerID catalogs.ProviderID, modelID string) bool {
+		left, found := existingFingerprints.ModelFingerprint(providerID, modelID)
+		if !found {
+			return false
+		}
+		right, found := updatedFingerprints.ModelFingerprint(providerID, modelID)
+		return found && left == right
+	}
+}
+
+func (diff *Differ) providerScopedModels(existingProviders, updatedProviders []catalogs.Provider, unchanged func(catalogs.ProviderID, string) bool) *ModelChangeset {
 	changeset := &ModelChangeset{
 		Added:         []catalogs.Model{},
 		AddedScoped:   []ModelChange{},
@@ -228,10 +260,12 @@ func (diff *Differ) providerScopedModels(existingProviders, updatedProviders []c
 			continue
 		}
 
-		providerChanges := diff.Models(
-			providerModelPointers(existingProvider),
-			providerModelPointers(updatedProvider),
-		)
+		existingModels := providerModelPointers(existingProvider)
+		updatedModels := providerModelPointers(updatedProvider)
+		if unchanged != nil {
+			existingModels, updatedModels = dropUnchangedModels(updatedProvider.ID, existingModels, updatedModels, unchanged)
+		}
+		providerChanges := diff.Models(existingModels, updatedModels)
 		for i := range providerChanges.Updated {
 			providerChanges.Updated[i].ProviderID = updatedProvider.ID
 		}
@@ -264,6 +298,26 @@ func providerModelPointers(provider catalogs.Provider) []*catalogs.Model {
 	return models
 }
 
+// dropUnchangedModels removes models present in both lists with equal
+// fingerprints, so only added, removed, and changed models are compared field
+// by field.
+func dropUnchangedModels(providerID catalogs.ProviderID, existing, updated []*catalogs.Model, unchanged func(catalogs.ProviderID, string) bool) ([]*catalogs.Model, []*catalogs.Model) {
+	skip := make(map[string]bool)
+	for _, model := range updated {
+		skip[model.ID] = unchanged(providerID, model.ID)
+	}
+	keep := func(models []*catalogs.Model) []*catalogs.Model {
+		kept := make([]*catalogs.Model, 0, len(models))
+		for _, model := range models {
+			if !skip[model.ID] {
+				kept = append(kept, model)
+			}
+		}
+		return kept
+	}
+	return keep(existing), keep(updated)
+}
+
 func providerModels(provider catalogs.Provider) []catalogs.Model {
 	models := make([]catalogs.Model, 0, len(provider.Models))
 	for _, model := range provider.Models {
@@ -317,6 +371,30 @@ func (diff *Differ) model(existing, updated catalogs.Model) *ModelUpdate {
 		})
 	}
 
+	if existing.Class != updated.Class && !diff.ignoreFields["class"] {
+		changes = append(changes, FieldChange{
+			Path:     "class",
+			OldValue: existing.Class.String(),
+			NewValue: updated.Class.String(),
+			Type:     ChangeTypeUpdate,
+		})
+	}
+
+	if existing.Definition != updated.Definition && !diff.ignoreFields["definition"] {
+		changes = append(changes, FieldChange{
+			Path:     "definition",
+			OldValue: existing.Definition,
+			NewValue: updated.Definition,
+			Type:     ChangeTypeUpdate,
+		})
+	}
+
+	if !diff.ignoreFields["authors"] {
+		changes = append(changes, diffElements("authors",
+			modelAuthorIDs(existing.Authors), modelAuthorIDs(updated.Authors),
+			stringElement[catalogs.AuthorID], stringElement[catalogs.AuthorID])...)
+	}
+
 	// Compare features
 	if diff.deepComparison && !diff.ignoreFields["features"] {
 		featureChanges := diffModelFeatures(existing.Features, updated.Features)
@@ -345,22 +423,66 @@ func (diff *Differ) model(existing, updated catalogs.Model) *ModelUpdate {
 		if !diff.ignoreFields["tools"] {
 			changes = append(changes, diffModelPointer("tools", existing.Tools, updated.Tools)...)
 		}
+		if !diff.ignoreFields["vision"] {
+			changes = append(changes, diffModelPointer("vision", existing.Vision, updated.Vision)...)
+		}
+		if !diff.ignoreFields["documents"] {
+			changes = append(changes, diffModelPointer("documents", existing.Documents, updated.Documents)...)
+		}
+		if !diff.ignoreFields["caching"] {
+			changes = append(changes, diffModelPointer("caching", existing.Caching, updated.Caching)...)
+		}
 		if !diff.ignoreFields["response"] {
 			changes = append(changes, diffModelPointer("response", existing.Delivery, updated.Delivery)...)
 		}
-		if !diff.ignoreFields["modes"] && !reflect.DeepEqual(existing.Modes, updated.Modes) {
+		if !diff.ignoreFields["performance"] {
+			changes = append(changes, diffModelPointer("performance", existing.Performance, updated.Performance)...)
+		}
+		if !diff.ignoreFields["usage_restrictions"] {
+			changes = append(changes, diffModelPointer("usage_restrictions", existing.UsageRestrictions, updated.UsageRestrictions)...)
+		}
+		if !diff.ignoreFields["embedding"] {
+			changes = append(changes, diffModelPointer("embedding", existing.Embedding, updated.Embedding)...)
+		}
+		if !diff.ignoreFields["curation"] && !reflect.DeepEqual(existing.Curation, updated.Curation) {
 			changes = append(changes, FieldChange{
-				Path:     "modes",
-				OldValue: fmt.Sprintf("%d modes", len(existing.Modes)),
-				NewValue: fmt.Sprintf("%d modes", len(updated.Modes)),
+				Path:     "curation.tags",
+				OldValue: strings.Join(existing.CurationTags(), ","),
+				NewValue: strings.Join(updated.CurationTags(), ","),
 				Type:     ChangeTypeUpdate,
 			})
 		}
+		if !diff.ignoreFields["review"] && existing.ReviewState() != updated.ReviewState() {
+			changes = append(changes, FieldChange{
+				Path:     "review.state",
+				OldValue: existing.ReviewState().String(),
+				NewValue: updated.ReviewState().String(),
+				Type:     ChangeTypeUpdate,
+			})
+		}
+		if !diff.ignoreFields["private"] && !slices.Equal(existing.Private, updated.Private) {
+			changes = append(changes, FieldChange{
+				Path:     "private",
+				OldValue: strings.Join(existing.Private, ","),
+				NewValue: strings.Join(updated.Private, ","),
+				Type:     ChangeTypeUpdate,
+			})
+		}
+		if !diff.ignoreFields["sustainability"] {
+			changes = append(changes, diffModelPointer("sustainability", existing.Sustainability, updated.Sustainability)...)
+		}
+		if !diff.ignoreFields["evaluations"] {
+			changes = append(changes, diffModelEvaluations(existing.Evaluations, updated.Evaluations)...)
+		}
+		if !diff.ignoreFields["modes"] {
+			changes = append(changes, diffMapElements("modes", existing.Modes, updated.Modes,
+				equalModelModes, formatModelMode)...)
+		}
 	}
 
 	// Compare pricing
 	if diff.deepComparison && !diff.ignoreFields["pricing"] {
-		pricingChanges := diffModelPricing(existing.Pricing, updated.Pricing)
+		pricingChanges := diffModelPricing(existing.Pricing, updated.Pricing, diff.priceTolerance)
 		changes = append(changes, pricingChanges...)
 	}
 
@@ -376,24 +498,17 @@ func (diff *Differ) model(existing, updated catalogs.Model) *ModelUpdate {
 		changes = append(changes, metadataChanges...)
 	}
 
-	if diff.deepComparison &&
-		!diff.ignoreFields["extensions"] &&
-		!reflect.DeepEqual(
-			catalogs.NormalizeSourceExtensions(existing.Extensions),
-			catalogs.NormalizeSourceExtensions(updated.Extensions),
-		) {
-		changes = append(changes, FieldChange{
-			Path:     "extensions",
-			OldValue: fmt.Sprintf("%d sources", len(existing.Extensions)),
-			NewValue: fmt.Sprintf("%d sources", len(updated.Extensions)),
-			Type:     ChangeTypeUpdate,
-		})
+	if diff.deepComparison && !diff.ignoreFields["extensions"] {
+		changes = append(changes, diffExtensions(existing.Extensions, updated.Extensions)...)
 	}
 
 	// If no changes, return nil
 	if len(changes) == 0 {
 		return nil
 	}
+	if diff.ignoreTimestampOnly && timestampOnly(changes) {
+		return nil
+	}
 
 	return &ModelUpdate{
 		ID:       existing.ID,
@@ -415,6 +530,53 @@ func diffModelPointer[T any](path string, existing, updated *T) []FieldChange {
 	}}
 }
 
+// diffModelEvaluations reports each benchmark score that changed, so a
+// leaderboard refresh shows the old and new scores.
+func diffModelEvaluations(existing, updated *catalogs.ModelEvaluations) []FieldChange {
+	var before, after catalogs.ModelEvaluations
+	if existing != nil {
+		before = *existing
+	}
+	if updated != nil {
+		after = *updated
+	}
+	var changes []FieldChange
+	for _, score := range []struct {
+		path              string
+		existing, updated *float64
+	}{
+		{"evaluations.mmlu", before.MMLU, after.MMLU},
+		{"evaluations.arena_elo", before.ArenaElo, after.ArenaElo},
+		{"evaluations.humaneval", before.HumanEval, after.HumanEval},
+	} {
+		if reflect.DeepEqual(score.existing, score.updated) {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			Path:     score.path,
+			OldValue: formatOptionalScore(score.existing),
+			NewValue: formatOptionalScore(score.updated),
+			Type:     ChangeTypeUpdate,
+		})
+	}
+	if !equalOptionalTime(before.UpdatedAt, after.UpdatedAt) {
+		changes = append(changes, FieldChange{
+			Path:     "evaluations.updated_at",
+			OldValue: formatOptionalTime(before.UpdatedAt),
+			NewValue: formatOptionalTime(after.UpdatedAt),
+			Type:     ChangeTypeUpdate,
+		})
+	}
+	return changes
+}
+
+func formatOptionalScore(score *float64) string {
+	if score == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*score, 'f', -1, 64)
+}
+
 func formatPointerPresence[T any](value *T) string {
 	if value == nil {
 		return "absent"
@@ -468,30 +630,20 @@ func diffModelFeatures(existing, updated *catalogs.ModelFeatures) []FieldChange
 		})
 	}
 
-	// Compare modalities
-	if !equalModalitySlices(existing.Modalities.Input, updated.Modalities.Input) {
-		changes = append(changes, FieldChange{
-			Path:     "features.modalities.input",
-			OldValue: joinModalities(existing.Modalities.Input),
-			NewValue: joinModalities(updated.Modalities.Input),
-			Type:     ChangeTypeUpdate,
-		})
-	}
-
-	if !equalModalitySlices(existing.Modalities.Output, updated.Modalities.Output) {
-		changes = append(changes, FieldChange{
-			Path:     "features.modalities.output",
-			OldValue: joinModalities(existing.Modalities.Output),
-			NewValue: joinModalities(updated.Modalities.Output),
-			Type:     ChangeTypeUpdate,
-		})
-	}
+	// Compare modalities element by element
+	changes = append(changes, diffElements("features.modalities.input",
+		existing.Modalities.Input, updated.Modalities.Input,
+		stringElement[catalogs.ModelModality], stringElement[catalogs.ModelModality])...)
+	changes = append(changes, diffElements("features.modalities.output",
+		existing.Modalities.Output, updated.Modalities.Output,
+		stringElement[catalogs.ModelModality], stringElement[catalogs.ModelModality])...)
 
 	return changes
 }
 
-// diffModelPricing compares model pricing.
-func diffModelPricing(existing, updated *catalogs.ModelPricing) []FieldChange {
+// diffModelPricing compares model pricing. Prices whose relative change is
+// below tolerance compare equal.
+func diffModelPricing(existing, updated *catalogs.ModelPricing, tolerance float64) []FieldChange {
 	changes := []FieldChange{}
 
 	if existing == nil && updated == nil {
@@ -517,7 +669,7 @@ func diffModelPricing(existing, updated *catalogs.ModelPricing) []FieldChange {
 		})
 	}
 
-	if !reflect.DeepEqual(existing.Tokens, updated.Tokens) {
+	if !pricesEqual(existing.Tokens, updated.Tokens, tolerance) {
 		changes = append(changes, FieldChange{
 			Path:     "pricing.tokens",
 			OldValue: formatPresent(existing.Tokens != nil),
@@ -526,7 +678,7 @@ func diffModelPricing(existing, updated *catalogs.ModelPricing) []FieldChange {
 		})
 	}
 
-	if !reflect.DeepEqual(existing.Operations, updated.Operations) {
+	if !pricesEqual(existing.Operations, updated.Operations, tolerance) {
 		changes = append(changes, FieldChange{
 			Path:     "pricing.operations",
 			OldValue: formatPresent(existing.Operations != nil),
@@ -535,7 +687,16 @@ func diffModelPricing(existing, updated *catalogs.ModelPricing) []FieldChange {
 		})
 	}
 
-	if !reflect.DeepEqual(existing.Tiers, updated.Tiers) {
+	if !pricesEqual(existing.Compute, updated.Compute, tolerance) {
+		changes = append(changes, FieldChange{
+			Path:     "pricing.compute",
+			OldValue: formatPresent(existing.Compute != nil),
+			NewValue: formatPresent(updated.Compute != nil),
+			Type:     ChangeTypeUpdate,
+		})
+	}
+
+	if !pricesEqual(existing.Tiers, updated.Tiers, tolerance) {
 		changes = append(changes, FieldChange{
 			Path:     "pricing.tiers",
 			OldValue: fmt.Sprintf("%d tiers", len(existing.Tiers)),
@@ -640,14 +801,9 @@ func diffModelMetadata(existing, updated *catalogs.ModelMetadata) []FieldChange
 		})
 	}
 
-	if !reflect.DeepEqual(existing.Tags, updated.Tags) {
-		changes = append(changes, FieldChange{
-			Path:     "metadata.tags",
-			OldValue: fmt.Sprintf("%d tags", len(existing.Tags)),
-			NewValue: fmt.Sprintf("%d tags", len(updated.Tags)),
-			Type:     ChangeTypeUpdate,
-		})
-	}
+	changes = append(changes, diffElements("metadata.tags",
+		existing.Tags, updated.Tags,
+		stringElement[catalogs.ModelTag], stringElement[catalogs.ModelTag])...)
 
 	if !reflect.DeepEqual(existing.Architecture, updated.Architecture) {
 		changes = append(changes, FieldChange{
@@ -674,13 +830,9 @@ func (diff *Differ) provider(existing, updated catalogs.Provider) *ProviderUpdat
 		})
 	}
 
-	if !reflect.DeepEqual(existing.Aliases, updated.Aliases) && !diff.ignoreFields["aliases"] {
-		changes = append(changes, FieldChange{
-			Path:     "aliases",
-			OldValue: fmt.Sprintf("%d aliases", len(existing.Aliases)),
-			NewValue: fmt.Sprintf("%d aliases", len(updated.Aliases)),
-			Type:     ChangeTypeUpdate,
-		})
+	if !diff.ignoreFields["aliases"] {
+		changes = append(changes, diffElements("aliases", existing.Aliases, updated.Aliases,
+			stringElement[catalogs.ProviderID], stringElement[catalogs.ProviderID])...)
 	}
 
 	if !equalOptionalString(existing.Headquarters, updated.Headquarters) && !diff.ignoreFields["headquarters"] {
@@ -711,13 +863,9 @@ func (diff *Differ) provider(existing, updated catalogs.Provider) *ProviderUpdat
 		})
 	}
 
-	if !reflect.DeepEqual(existing.EnvVars, updated.EnvVars) && !diff.ignoreFields["env_vars"] {
-		changes = append(changes, FieldChange{
-			Path:     "env_vars",
-			OldValue: fmt.Sprintf("%d env vars", len(existing.EnvVars)),
-			NewValue: fmt.Sprintf("%d env vars", len(updated.EnvVars)),
-			Type:     ChangeTypeUpdate,
-		})
+	if !diff.ignoreFields["env_vars"] {
+		changes = append(changes, diffElements("env_vars", existing.EnvVars, updated.EnvVars,
+			envVarName, formatEnvVar)...)
 	}
 
 	// Check catalog settings changes
@@ -748,6 +896,51 @@ func (diff *Differ) provider(existing, updated catalogs.Provider) *ProviderUpdat
 		})
 	}
 
+	if !reflect.DeepEqual(existing.SLA, updated.SLA) && !diff.ignoreFields["sla"] {
+		changes = append(changes, FieldChange{
+			Path:     "sla",
+			OldValue: formatPresent(existing.SLA != nil),
+			NewValue: formatPresent(updated.SLA != nil),
+			Type:     ChangeTypeUpdate,
+		})
+	}
+
+	if !reflect.DeepEqual(existing.Support, updated.Support) && !diff.ignoreFields["support"] {
+		changes = append(changes, FieldChange{
+			Path:     "support",
+			OldValue: formatPresent(existing.Support != nil),
+			NewValue: formatPresent(updated.Support != nil),
+			Type:     ChangeTypeUpdate,
+		})
+	}
+
+	if !reflect.DeepEqual(existing.PromptCaching, updated.PromptCaching) && !diff.ignoreFields["prompt_caching"] {
+		changes = append(changes, FieldChange{
+			Path:     "prompt_caching",
+			OldValue: formatPresent(existing.PromptCaching != nil),
+			NewValue: formatPresent(updated.PromptCaching != nil),
+			Type:     ChangeTypeUpdate,
+		})
+	}
+
+	if !reflect.DeepEqual(existing.Vision, updated.Vision) && !diff.ignoreFields["vision"] {
+		changes = append(changes, FieldChange{
+			Path:     "vision",
+			OldValue: formatPresent(existing.Vision != nil),
+			NewValue: formatPresent(updated.Vision != nil),
+			Type:     ChangeTypeUpdate,
+		})
+	}
+
+	if !reflect.DeepEqual(existing.Documents, updated.Documents) && !diff.ignoreFields["documents"] {
+		changes = append(changes, FieldChange{
+			Path:     "documents",
+			OldValue: formatPresent(existing.Documents != nil),
+			NewValue: formatPresent(updated.Documents != nil),
+			Type:     ChangeTypeUpdate,
+		})
+	}
+
 	if !reflect.DeepEqual(existing.PrivacyPolicy, updated.PrivacyPolicy) && !diff.ignoreFields["privacy_policy"] {
 		changes = append(changes, FieldChange{
 			Path:     "privacy_policy",
@@ -775,19 +968,28 @@ func (diff *Differ) provider(existing, updated catalogs.Provider) *ProviderUpdat
 		})
 	}
 
-	if !diff.ignoreFields["extensions"] &&
-		!reflect.DeepEqual(
-			catalogs.NormalizeSourceExtensions(existing.Extensions),
-			catalogs.NormalizeSourceExtensions(updated.Extensions),
-		) {
+	if !reflect.DeepEqual(existing.UsageRestrictions, updated.UsageRestrictions) && !diff.ignoreFields["usage_restrictions"] {
 		changes = append(changes, FieldChange{
-			Path:     "extensions",
-			OldValue: fmt.Sprintf("%d sources", len(existing.Extensions)),
-			NewValue: fmt.Sprintf("%d sources", len(updated.Extensions)),
+			Path:     "usage_restrictions",
+			OldValue: formatPresent(existing.UsageRestrictions != nil),
+			NewValue: formatPresent(updated.UsageRestrictions != nil),
 			Type:     ChangeTypeUpdate,
 		})
 	}
 
+	if !reflect.DeepEqual(existing.Sustainability, updated.Sustainability) && !diff.ignoreFields["sustainability"] {
+		changes = append(changes, FieldChange{
+			Path:     "sustainability",
+			OldValue: formatPresent(existing.Sustainability != nil),
+			NewValue: formatPresent(updated.Sustainability != nil),
+			Type:     ChangeTypeUpdate,
+		})
+	}
+
+	if !diff.ignoreFields["extensions"] {
+		changes = append(changes, diffExtensions(existing.Extensions, updated.Extensions)...)
+	}
+
 	if len(changes) == 0 {
 		return nil
 	}
@@ -827,6 +1029,11 @@ func (diff *Differ) author(existing, updated catalogs.Author) *AuthorUpdate {
 		})
 	}
 
+	if !diff.ignoreFields["aliases"] {
+		changes = append(changes, diffElements("aliases", existing.Aliases, updated.Aliases,
+			stringElement[catalogs.AuthorID], stringElement[catalogs.AuthorID])...)
+	}
+
 	var existingWebsite, newWebsite string
 	if existing.Website != nil {
 		existingWebsite = *existing.Website
@@ -974,27 +1181,57 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
-// equalModalitySlices compares two ModelModality slices for equality.
-func equalModalitySlices(a, b []catalogs.ModelModality) bool {
-	if len(a) != len(b) {
-		return false
+// modelAuthorIDs returns the IDs of a model's authors; a model's authors
+// are compared by identity, since author details live in the author catalog.
+func modelAuthorIDs(authors []catalogs.Author) []catalogs.AuthorID {
+	if len(authors) == 0 {
+		return nil
 	}
-	for i := range a {
-		if a[i] != b[i] {
-			return false
-		}
+	ids := make([]catalogs.AuthorID, len(authors))
+	for i, author := range authors {
+		ids[i] = author.ID
 	}
-	return true
+	return ids
 }
 
-// joinModalities joins ModelModality slices into a string.
-func joinModalities(modalities []catalogs.ModelModality) string {
-	if len(modalities) == 0 {
-		return ""
+// diffExtensions compares source extensions per source, ignoring dynamic
+// type differences such as int versus float64 for the same number.
+func diffExtensions(existing, updated catalogs.SourceExtensions) []FieldChange {
+	return diffMapElements("extensions",
+		catalogs.NormalizeSourceExtensions(existing),
+		catalogs.NormalizeSourceExtensions(updated),
+		func(a, b catalogs.SourceExtension) bool { return reflect.DeepEqual(a, b) },
+		func(extension catalogs.SourceExtension) string {
+			return fmt.Sprintf("%d fields", len(extension.Fields))
+		})
+}
+
+func equalModelModes(a, b catalogs.ModelMode) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// formatModelMode summarizes a mode by what it overrides.
+func formatModelMode(mode catalogs.ModelMode) string {
+	var parts []string
+	if mode.Pricing != nil {
+		parts = append(parts, "pricing")
+	}
+	if mode.Provider != nil {
+		parts = append(parts, "provider")
+	}
+	if len(parts) == 0 {
+		return "present"
 	}
-	strs := make([]string, len(modalities))
-	for i, m := range modalities {
-		strs[i] = string(m)
+	return strings.Join(parts, ",")
+}
+
+func envVarName(envVar catalogs.ProviderEnvVar) string {
+	return envVar.Name
+}
+
+func formatEnvVar(envVar catalogs.ProviderEnvVar) string {
+	if envVar.Required {
+		return envVar.Name + " (required)"
 	}
-	return strings.Join(strs, ",")
+	return envVar.Name
 }
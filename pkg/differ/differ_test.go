@@ -71,6 +71,33 @@ func TestCatalogsDiffModelsWithinProviderScope(t *testing.T) {
 	}
 }
 
+func TestCatalogsSkipModelsWithEqualStoredFingerprints(t *testing.T) {
+	existing := catalogs.NewEmpty()
+	updated := catalogs.NewEmpty()
+
+	setProviderForDiffTest(t, existing, "provider-a", &catalogs.Model{ID: "touched", Name: "A", UpdatedAt: utc.Now()})
+	setProviderForDiffTest(t, existing, "provider-b", &catalogs.Model{ID: "edited", Name: "B"})
+	setProviderForDiffTest(t, updated, "provider-a", &catalogs.Model{ID: "touched", Name: "A"})
+	setProviderForDiffTest(t, updated, "provider-b", &catalogs.Model{ID: "edited", Name: "B updated"})
+
+	existingCatalog, err := catalogs.NewCatalog(existing)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+	updatedCatalog, err := catalogs.NewCatalog(updated)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+
+	changes := New().Catalogs(existingCatalog, updatedCatalog)
+	if len(changes.Models.Updated) != 1 || changes.Models.Updated[0].ID != "edited" {
+		t.Fatalf("updated models = %#v, want only the edited model", changes.Models.Updated)
+	}
+	if len(changes.Models.Added) != 0 || len(changes.Models.Removed) != 0 {
+		t.Fatalf("added = %d, removed = %d, want none", len(changes.Models.Added), len(changes.Models.Removed))
+	}
+}
+
 func TestCatalogsDiffScopesAddedAndRemovedModelsByProvider(t *testing.T) {
 	existing := catalogs.NewEmpty()
 	updated := catalogs.NewEmpty()
@@ -0,0 +1,127 @@
+package differ
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// diffElements reports element-level changes between two slices whose
+// elements are identified by key: each element added, removed, or changed
+// under the same key is one change. When the elements only moved, a single
+// update of the whole field reports the new order.
+func diffElements[T any](path string, existing, updated []T, key func(T) string, format func(T) string) []FieldChange {
+	existingByKey := indexElements(existing, key)
+	updatedByKey := indexElements(updated, key)
+
+	var changes []FieldChange
+	for _, element := range existing {
+		k := key(element)
+		if !reflect.DeepEqual(existingByKey[k], element) {
+			continue // duplicate key; the first element represents it
+		}
+		current, ok := updatedByKey[k]
+		switch {
+		case !ok:
+			changes = append(changes, FieldChange{
+				Path:     path,
+				Key:      k,
+				OldValue: format(element),
+				Type:     ChangeTypeRemove,
+			})
+		case !reflect.DeepEqual(element, current):
+			changes = append(changes, FieldChange{
+				Path:     path,
+				Key:      k,
+				OldValue: format(element),
+				NewValue: format(current),
+				Type:     ChangeTypeUpdate,
+			})
+		}
+	}
+	for _, element := range updated {
+		k := key(element)
+		if _, ok := existingByKey[k]; ok || !reflect.DeepEqual(updatedByKey[k], element) {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			Path:     path,
+			Key:      k,
+			NewValue: format(element),
+			Type:     ChangeTypeAdd,
+		})
+	}
+
+	if len(changes) == 0 && !slices.Equal(elementKeys(existing, key), elementKeys(updated, key)) {
+		changes = append(changes, FieldChange{
+			Path:     path,
+			OldValue: joinElements(existing, format),
+			NewValue: joinElements(updated, format),
+			Type:     ChangeTypeUpdate,
+		})
+	}
+	return changes
+}
+
+// diffMapElements reports element-level changes between two maps, one change
+// per key added, removed, or holding a value that is not equal. Changes are
+// ordered by key.
+func diffMapElements[V any](path string, existing, updated map[string]V, equal func(V, V) bool, format func(V) string) []FieldChange {
+	keys := make([]string, 0, len(existing)+len(updated))
+	for key := range existing {
+		keys = append(keys, key)
+	}
+	for key := range updated {
+		if _, ok := existing[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var changes []FieldChange
+	for _, key := range keys {
+		before, hadBefore := existing[key]
+		after, hasAfter := updated[key]
+		switch {
+		case !hasAfter:
+			changes = append(changes, FieldChange{Path: path, Key: key, OldValue: format(before), Type: ChangeTypeRemove})
+		case !hadBefore:
+			changes = append(changes, FieldChange{Path: path, Key: key, NewValue: format(after), Type: ChangeTypeAdd})
+		case !equal(before, after):
+			changes = append(changes, FieldChange{Path: path, Key: key, OldValue: format(before), NewValue: format(after), Type: ChangeTypeUpdate})
+		}
+	}
+	return changes
+}
+
+func indexElements[T any](elements []T, key func(T) string) map[string]T {
+	index := make(map[string]T, len(elements))
+	for _, element := range elements {
+		if _, ok := index[key(element)]; !ok {
+			index[key(element)] = element
+		}
+	}
+	return index
+}
+
+func elementKeys[T any](elements []T, key func(T) string) []string {
+	keys := make([]string, len(elements))
+	for i, element := range elements {
+		keys[i] = key(element)
+	}
+	return keys
+}
+
+func joinElements[T any](elements []T, format func(T) string) string {
+	values := make([]string, len(elements))
+	for i, element := range elements {
+		values[i] = format(element)
+	}
+	return strings.Join(values, ",")
+}
+
+// stringElement identifies and formats string-like slice elements, such as
+// modalities, tags, and aliases, by their value.
+func stringElement[T ~string](value T) string {
+	return string(value)
+}
@@ -0,0 +1,81 @@
+package differ
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func TestModelsReportElementLevelChanges(t *testing.T) {
+	existing := &catalogs.Model{
+		ID: "model", Name: "Model",
+		Authors: []catalogs.Author{{ID: catalogs.AuthorIDOpenAI}, {ID: "microsoft"}},
+		Features: &catalogs.ModelFeatures{Modalities: catalogs.ModelModalities{
+			Input:  []catalogs.ModelModality{catalogs.ModelModalityText, catalogs.ModelModalityImage},
+			Output: []catalogs.ModelModality{catalogs.ModelModalityText},
+		}},
+	}
+	updated := &catalogs.Model{
+		ID: "model", Name: "Model",
+		Authors: []catalogs.Author{{ID: catalogs.AuthorIDOpenAI, Name: "OpenAI"}, {ID: "azure"}},
+		Features: &catalogs.ModelFeatures{Modalities: catalogs.ModelModalities{
+			Input:  []catalogs.ModelModality{catalogs.ModelModalityText, catalogs.ModelModalityImage, catalogs.ModelModalityAudio},
+			Output: []catalogs.ModelModality{catalogs.ModelModalityText},
+		}},
+	}
+
+	changes := New().Models([]*catalogs.Model{existing}, []*catalogs.Model{updated})
+	if len(changes.Updated) != 1 {
+		t.Fatalf("updated models = %d, want 1", len(changes.Updated))
+	}
+	want := []FieldChange{
+		{Path: "authors", Key: "microsoft", OldValue: "microsoft", Type: ChangeTypeRemove},
+		{Path: "authors", Key: "azure", NewValue: "azure", Type: ChangeTypeAdd},
+		{Path: "features.modalities.input", Key: "audio", NewValue: "audio", Type: ChangeTypeAdd},
+	}
+	if got := changes.Updated[0].Changes; !reflect.DeepEqual(got, want) {
+		t.Fatalf("changes = %#v, want %#v", got, want)
+	}
+	if field := want[0].Field(); field != "authors[microsoft]" {
+		t.Fatalf("Field() = %q, want authors[microsoft]", field)
+	}
+}
+
+func TestProvidersReportEnvVarElementChanges(t *testing.T) {
+	existing := catalogs.Provider{ID: "provider", Name: "Provider", EnvVars: []catalogs.ProviderEnvVar{
+		{Name: "PROVIDER_API_KEY", Required: true},
+		{Name: "PROVIDER_REGION"},
+	}}
+	updated := catalogs.Provider{ID: "provider", Name: "Provider", EnvVars: []catalogs.ProviderEnvVar{
+		{Name: "PROVIDER_REGION", Required: true},
+		{Name: "PROVIDER_API_KEY", Required: true},
+	}}
+
+	changes := New().Providers([]catalogs.Provider{existing}, []catalogs.Provider{updated})
+	if len(changes.Updated) != 1 {
+		t.Fatalf("updated providers = %d, want 1", len(changes.Updated))
+	}
+	want := []FieldChange{{
+		Path: "env_vars", Key: "PROVIDER_REGION",
+		OldValue: "PROVIDER_REGION", NewValue: "PROVIDER_REGION (required)",
+		Type: ChangeTypeUpdate,
+	}}
+	if got := changes.Updated[0].Changes; !reflect.DeepEqual(got, want) {
+		t.Fatalf("changes = %#v, want %#v", got, want)
+	}
+}
+
+func TestDiffElementsReportsReorderAsOneUpdate(t *testing.T) {
+	changes := diffElements("metadata.tags",
+		[]catalogs.ModelTag{"chat", "coding"}, []catalogs.ModelTag{"coding", "chat"},
+		stringElement[catalogs.ModelTag], stringElement[catalogs.ModelTag])
+	want := []FieldChange{{Path: "metadata.tags", OldValue: "chat,coding", NewValue: "coding,chat", Type: ChangeTypeUpdate}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("changes = %#v, want %#v", changes, want)
+	}
+	if changes := diffElements("metadata.tags", nil, []catalogs.ModelTag{},
+		stringElement[catalogs.ModelTag], stringElement[catalogs.ModelTag]); len(changes) != 0 {
+		t.Fatalf("nil and empty tags should be equal, got %#v", changes)
+	}
+}
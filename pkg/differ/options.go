@@ -25,3 +25,28 @@ func WithTracking(enabled bool) Option {
 		d.tracking = enabled
 	}
 }
+
+// WithPriceTolerance treats prices whose relative change is below tolerance
+// as unchanged, so 0.001 ignores price changes under 0.1%.
+func WithPriceTolerance(tolerance float64) Option {
+	return func(d *Differ) {
+		d.priceTolerance = tolerance
+	}
+}
+
+// WithIgnoreTimestampOnly drops model updates whose only changes are dates,
+// such as a source re-reporting a release date.
+func WithIgnoreTimestampOnly(enabled bool) Option {
+	return func(d *Differ) {
+		d.ignoreTimestampOnly = enabled
+	}
+}
+
+// WithRules applies tolerance and ignore rules.
+func WithRules(rules Rules) Option {
+	return func(d *Differ) {
+		for _, opt := range rules.Options() {
+			opt(d)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package differ
+
+import (
+	"fmt"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// PinnedChange records an incoming value that a field pin blocked: sync kept
+// the pinned value from the catalog file instead. Pinned changes are advisory:
+// they are reported with the changeset but are not catalog changes and do not
+// count toward TotalChanges.
+type PinnedChange struct {
+	ProviderID    catalogs.ProviderID // Provider offering the model
+	ModelID       string              // Model whose field is pinned
+	Path          string              // Pinned field path (e.g., "pricing.tokens.input")
+	PinnedValue   string              // Value kept (string representation)
+	IncomingValue string              // Value sync would have written (string representation)
+}
+
+// String returns a short description such as
+// "openai/gpt-4o name pinned: kept GPT-4o, blocked GPT 4o".
+func (p PinnedChange) String() string {
+	return fmt.Sprintf("%s/%s %s pinned: kept %s, blocked %s", p.ProviderID, p.ModelID, p.Path, p.PinnedValue, p.IncomingValue)
+}
+
+// printPinnedChanges outputs pinned changes in a human-readable format.
+func printPinnedChanges(changes []PinnedChange) {
+	fmt.Printf("\n📌 Pinned Fields (%d):\n", len(changes))
+	for _, change := range changes {
+		fmt.Printf("  • %s\n", change)
+	}
+}
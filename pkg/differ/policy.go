@@ -0,0 +1,58 @@
+package differ
+
+import (
+	"fmt"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// PolicyDocument identifies a provider legal document tracked for changes.
+type PolicyDocument string
+
+// String returns the string representation of a PolicyDocument.
+func (d PolicyDocument) String() string {
+	return string(d)
+}
+
+// Label returns the human-readable document name.
+func (d PolicyDocument) Label() string {
+	switch d {
+	case PolicyDocumentPrivacyPolicy:
+		return "privacy policy"
+	case PolicyDocumentTermsOfService:
+		return "terms of service"
+	default:
+		return string(d)
+	}
+}
+
+// Policy documents.
+const (
+	PolicyDocumentPrivacyPolicy  PolicyDocument = "privacy_policy"   // privacy_policy.privacy_policy_url
+	PolicyDocumentTermsOfService PolicyDocument = "terms_of_service" // privacy_policy.terms_of_service_url
+)
+
+// PolicyChange records that the content behind a provider's privacy policy or
+// terms of service URL changed since the previous sync. Policy changes are
+// advisory: they are reported with the changeset but are not catalog changes
+// and do not count toward TotalChanges.
+type PolicyChange struct {
+	ProviderID catalogs.ProviderID // Provider whose document changed
+	Document   PolicyDocument      // Which document changed
+	URL        string              // URL that was fetched
+	OldHash    string              // SHA-256 of the previously seen normalized content
+	NewHash    string              // SHA-256 of the current normalized content
+}
+
+// String returns a short description such as "anthropic privacy policy changed".
+func (p PolicyChange) String() string {
+	return fmt.Sprintf("%s %s changed", p.ProviderID, p.Document.Label())
+}
+
+// printPolicyChanges outputs policy changes in a human-readable format.
+func printPolicyChanges(changes []PolicyChange) {
+	fmt.Printf("\n📜 Policy Changes (%d):\n", len(changes))
+	for _, change := range changes {
+		fmt.Printf("  • %s (%s)\n", change, change.URL)
+	}
+}
@@ -0,0 +1,138 @@
+package differ
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// Rules are tolerance and ignore rules that keep cosmetic source variations,
+// such as floating point noise in prices, out of changesets. The zero value
+// reports every change.
+type Rules struct {
+	// PriceTolerance is the relative price change below which a price counts
+	// as unchanged; 0.001 ignores changes under 0.1%.
+	PriceTolerance float64 `json:"price_tolerance,omitempty" yaml:"price_tolerance,omitempty"`
+	// IgnoreTimestampOnly drops model updates whose only changes are dates.
+	IgnoreTimestampOnly bool `json:"ignore_timestamp_only,omitempty" yaml:"ignore_timestamp_only,omitempty"`
+	// IgnoreFields lists top-level fields never compared, such as description.
+	IgnoreFields []string `json:"ignore_fields,omitempty" yaml:"ignore_fields,omitempty"`
+}
+
+// Validate checks that the rules are usable.
+func (r Rules) Validate() error {
+	if math.IsNaN(r.PriceTolerance) || r.PriceTolerance < 0 || r.PriceTolerance >= 1 {
+		return &errors.ValidationError{
+			Field:   "price_tolerance",
+			Value:   r.PriceTolerance,
+			Message: "must be at least 0 and less than 1",
+		}
+	}
+	return nil
+}
+
+// IsZero reports whether the rules report every change.
+func (r Rules) IsZero() bool {
+	return r.PriceTolerance == 0 && !r.IgnoreTimestampOnly && len(r.IgnoreFields) == 0
+}
+
+// Options returns the differ options that apply the rules.
+func (r Rules) Options() []Option {
+	opts := []Option{}
+	if r.PriceTolerance > 0 {
+		opts = append(opts, WithPriceTolerance(r.PriceTolerance))
+	}
+	if r.IgnoreTimestampOnly {
+		opts = append(opts, WithIgnoreTimestampOnly(true))
+	}
+	if len(r.IgnoreFields) > 0 {
+		opts = append(opts, WithIgnoredFields(r.IgnoreFields...))
+	}
+	return opts
+}
+
+// timestampPaths are the model change paths that only carry dates.
+var timestampPaths = map[string]bool{
+	"metadata.release_date":     true,
+	"metadata.knowledge_cutoff": true,
+}
+
+// timestampOnly reports whether every change is to a date field.
+func timestampOnly(changes []FieldChange) bool {
+	for _, change := range changes {
+		if !timestampPaths[change.Path] {
+			return false
+		}
+	}
+	return len(changes) > 0
+}
+
+// pricesEqual compares pricing structures, treating floats whose relative
+// difference is below tolerance as equal.
+func pricesEqual(a, b any, tolerance float64) bool {
+	if tolerance == 0 {
+		return reflect.DeepEqual(a, b)
+	}
+	return approxEqual(reflect.ValueOf(a), reflect.ValueOf(b), tolerance)
+}
+
+func approxEqual(a, b reflect.Value, tolerance float64) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return withinTolerance(a.Float(), b.Float(), tolerance)
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return approxEqual(a.Elem(), b.Elem(), tolerance)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !a.Type().Field(i).IsExported() {
+				// Opaque values such as timestamps compare exactly.
+				return reflect.DeepEqual(a.Interface(), b.Interface())
+			}
+		}
+		for i := 0; i < a.NumField(); i++ {
+			if !approxEqual(a.Field(i), b.Field(i), tolerance) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !approxEqual(a.Index(i), b.Index(i), tolerance) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			if !approxEqual(a.MapIndex(key), b.MapIndex(key), tolerance) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
+func withinTolerance(a, b, tolerance float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) < tolerance*math.Max(math.Abs(a), math.Abs(b))
+}
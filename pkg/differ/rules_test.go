@@ -0,0 +1,111 @@
+package differ
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/agentstation/utc"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func pricedModel(input, output, request float64) *catalogs.Model {
+	return &catalogs.Model{
+		ID:   "model",
+		Name: "Model",
+		Pricing: &catalogs.ModelPricing{
+			Currency: catalogs.ModelPricingCurrencyUSD,
+			Tokens: &catalogs.ModelTokenPricing{
+				Input:  &catalogs.ModelTokenCost{Per1M: input},
+				Output: &catalogs.ModelTokenCost{Per1M: output},
+			},
+			Operations: &catalogs.ModelOperationPricing{Request: &request},
+		},
+	}
+}
+
+func TestPriceToleranceIgnoresSmallRelativeChanges(t *testing.T) {
+	existing := []*catalogs.Model{pricedModel(2.5, 10, 0.01)}
+
+	tests := []struct {
+		name    string
+		updated *catalogs.Model
+		want    []string
+	}{
+		{name: "floating point noise", updated: pricedModel(2.5000000001, 10, 0.01)},
+		{name: "below tolerance", updated: pricedModel(2.502, 10.009, 0.01)},
+		{name: "token change above tolerance", updated: pricedModel(2.51, 10, 0.01), want: []string{"pricing.tokens"}},
+		{name: "operation change above tolerance", updated: pricedModel(2.5, 10, 0.02), want: []string{"pricing.operations"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes := New(WithPriceTolerance(0.001)).Models(existing, []*catalogs.Model{test.updated})
+			if len(test.want) == 0 {
+				if len(changes.Updated) != 0 {
+					t.Fatalf("updated = %#v, want none", changes.Updated)
+				}
+				return
+			}
+			if len(changes.Updated) != 1 {
+				t.Fatalf("updated models = %d, want 1", len(changes.Updated))
+			}
+			got := changes.Updated[0].Changes
+			if len(got) != len(test.want) || got[0].Path != test.want[0] {
+				t.Fatalf("changes = %#v, want %v", got, test.want)
+			}
+		})
+	}
+
+	if changes := New().Models(existing, []*catalogs.Model{pricedModel(2.5000000001, 10, 0.01)}); len(changes.Updated) != 1 {
+		t.Fatalf("default differ should report any price change, got %#v", changes.Updated)
+	}
+}
+
+func TestIgnoreTimestampOnlyDropsDateOnlyUpdates(t *testing.T) {
+	cutoff := utc.New(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	existing := &catalogs.Model{
+		ID: "model", Name: "Model",
+		Metadata: &catalogs.ModelMetadata{ReleaseDate: utc.New(time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC))},
+	}
+	datesOnly := &catalogs.Model{
+		ID: "model", Name: "Model",
+		Metadata: &catalogs.ModelMetadata{
+			ReleaseDate:     utc.New(time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC)),
+			KnowledgeCutoff: &cutoff,
+		},
+	}
+	renamed := catalogs.DeepCopyModel(*datesOnly)
+	renamed.Name = "Model v2"
+
+	diff := New(WithIgnoreTimestampOnly(true))
+	if changes := diff.Models([]*catalogs.Model{existing}, []*catalogs.Model{datesOnly}); len(changes.Updated) != 0 {
+		t.Fatalf("date-only update reported: %#v", changes.Updated)
+	}
+	changes := diff.Models([]*catalogs.Model{existing}, []*catalogs.Model{&renamed})
+	if len(changes.Updated) != 1 || len(changes.Updated[0].Changes) != 3 {
+		t.Fatalf("update with a real change should keep every change, got %#v", changes.Updated)
+	}
+}
+
+func TestRulesOptionsAndValidation(t *testing.T) {
+	rules := Rules{PriceTolerance: 0.001, IgnoreTimestampOnly: true, IgnoreFields: []string{"description"}}
+	if err := rules.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	diff := New(WithRules(rules))
+	if diff.priceTolerance != 0.001 || !diff.ignoreTimestampOnly || !diff.ignoreFields["description"] {
+		t.Fatalf("differ = %+v, want rules applied", diff)
+	}
+	if !(Rules{}).IsZero() || rules.IsZero() {
+		t.Fatal("IsZero mismatch")
+	}
+
+	for _, tolerance := range []float64{-0.1, 1} {
+		var validationErr *pkgerrors.ValidationError
+		if err := (Rules{PriceTolerance: tolerance}).Validate(); !stderrors.As(err, &validationErr) {
+			t.Fatalf("Validate(%v) = %v, want *errors.ValidationError", tolerance, err)
+		}
+	}
+}
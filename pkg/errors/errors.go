@@ -6,6 +6,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // New returns an error that formats as the given text.
@@ -261,6 +262,61 @@ func NewSyncError(provider string, models []string, err error) *SyncError {
 	}
 }
 
+// PartialFetchError reports a provider fetch that was interrupted after some
+// providers completed. The completed results are kept so the next sync can
+// reuse them instead of fetching those providers again.
+type PartialFetchError struct {
+	Completed []string // Providers that finished before the interruption
+	Pending   []string // Providers that had not finished
+	Err       error
+}
+
+// Error implements the error interface.
+func (e *PartialFetchError) Error() string {
+	total := len(e.Completed) + len(e.Pending)
+	if len(e.Completed) == 0 {
+		return fmt.Sprintf("provider fetch interrupted before any of %d providers completed: %v", total, e.Err)
+	}
+	return fmt.Sprintf("provider fetch interrupted after %d of %d providers completed (%s): %v",
+		len(e.Completed), total, strings.Join(e.Completed, ", "), e.Err)
+}
+
+// Unwrap implements errors.Unwrap.
+func (e *PartialFetchError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports an interrupted fetch as canceled.
+func (e *PartialFetchError) Is(target error) bool {
+	return target == ErrCanceled
+}
+
+// PartialListError reports a paginated provider listing that stopped before
+// its last page: the page limit was reached, the provider repeated a page or
+// its next-page token, or a later page failed. It is returned together with
+// the items of the pages fetched so far, so callers can keep them and flag
+// the result partial instead of losing or silently truncating the listing.
+type PartialListError struct {
+	Provider string // Provider whose listing stopped
+	Pages    int    // Pages fetched before the listing stopped
+	Reason   string // Why the listing stopped, such as "page limit reached"
+	Err      error  // Error fetching the next page, if one failed
+}
+
+// Error implements the error interface.
+func (e *PartialListError) Error() string {
+	message := fmt.Sprintf("provider %s listing incomplete after %d pages: %s", e.Provider, e.Pages, e.Reason)
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Unwrap implements errors.Unwrap.
+func (e *PartialListError) Unwrap() error {
+	return e.Err
+}
+
 // Helper functions for error checking
 
 // IsNotFound checks if an error is a not found error.
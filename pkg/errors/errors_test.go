@@ -1,6 +1,7 @@
 package errors_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -217,6 +218,20 @@ func TestSyncError(t *testing.T) {
 	})
 }
 
+func TestPartialFetchError(t *testing.T) {
+	err := &pkgerrors.PartialFetchError{
+		Completed: []string{"anthropic", "openai"},
+		Pending:   []string{"groq"},
+		Err:       context.Canceled,
+	}
+	assert.Contains(t, err.Error(), "2 of 3 providers completed (anthropic, openai)")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, pkgerrors.IsCanceled(err))
+
+	none := &pkgerrors.PartialFetchError{Pending: []string{"groq"}, Err: context.Canceled}
+	assert.Contains(t, none.Error(), "before any of 1 providers completed")
+}
+
 func TestParseError(t *testing.T) {
 	t.Run("with file and position", func(t *testing.T) {
 		err := &pkgerrors.ParseError{
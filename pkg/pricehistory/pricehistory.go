@@ -0,0 +1,212 @@
+// Package pricehistory records model token prices over time. Each applied
+// sync appends a price point for every priced provider offering whose prices
+// changed since the last recorded point, so the history is a time series of
+// provider price changes.
+//
+// Example usage:
+//
+//	store := pricehistory.NewStore(path)
+//	points, err := store.History("gpt-4o", "")
+//	for _, point := range points {
+//	    fmt.Println(point.RecordedAt, point.ProviderID, *point.Input)
+//	}
+package pricehistory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+// Point is the token prices of one provider offering from RecordedAt until
+// the next point of the same offering. Prices are per one million tokens; a
+// nil price was not listed.
+type Point struct {
+	ProviderID catalogs.ProviderID           `json:"provider" yaml:"provider"`
+	ModelID    string                        `json:"model" yaml:"model"`
+	RecordedAt time.Time                     `json:"recorded_at" yaml:"recorded_at"`
+	Currency   catalogs.ModelPricingCurrency `json:"currency" yaml:"currency"`
+	Input      *float64                      `json:"input,omitempty" yaml:"input,omitempty"`
+	Output     *float64                      `json:"output,omitempty" yaml:"output,omitempty"`
+	CacheRead  *float64                      `json:"cache_read,omitempty" yaml:"cache_read,omitempty"`
+	CacheWrite *float64                      `json:"cache_write,omitempty" yaml:"cache_write,omitempty"`
+}
+
+// NewPoint returns the token prices of a model at recordedAt, or false when
+// the model has no token pricing.
+func NewPoint(providerID catalogs.ProviderID, model *catalogs.Model, recordedAt time.Time) (Point, bool) {
+	if model == nil || model.Pricing == nil || model.Pricing.Tokens == nil {
+		return Point{}, false
+	}
+	tokens := model.Pricing.Tokens
+	point := Point{
+		ProviderID: providerID,
+		ModelID:    model.ID,
+		RecordedAt: recordedAt.UTC(),
+		Currency:   model.Pricing.Currency,
+		Input:      tokens.Input.PerMillion(),
+		Output:     tokens.Output.PerMillion(),
+		CacheRead:  tokens.CacheRead.PerMillion(),
+		CacheWrite: tokens.CacheWrite.PerMillion(),
+	}
+	if tokens.Cache != nil {
+		if read := tokens.Cache.Read.PerMillion(); read != nil {
+			point.CacheRead = read
+		}
+		if write := tokens.Cache.Write.PerMillion(); write != nil {
+			point.CacheWrite = write
+		}
+	}
+	if point.Input == nil && point.Output == nil && point.CacheRead == nil && point.CacheWrite == nil {
+		return Point{}, false
+	}
+	return point, true
+}
+
+// SamePrices reports whether two points list the same prices.
+func (p Point) SamePrices(other Point) bool {
+	return p.Currency == other.Currency &&
+		samePrice(p.Input, other.Input) &&
+		samePrice(p.Output, other.Output) &&
+		samePrice(p.CacheRead, other.CacheRead) &&
+		samePrice(p.CacheWrite, other.CacheWrite)
+}
+
+func samePrice(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Store is a price history kept as JSON Lines, one point per line, in the
+// order the points were recorded.
+type Store struct {
+	path string
+}
+
+// NewStore returns the price history stored at path.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Record appends a point for every priced model of catalog whose prices
+// differ from its last recorded point. It returns the number of points added.
+func (s *Store) Record(catalog catalogs.Reader, recordedAt time.Time) (int, error) {
+	points, err := s.load()
+	if err != nil {
+		return 0, err
+	}
+	latest := make(map[string]Point, len(points))
+	for _, point := range points {
+		latest[key(point.ProviderID, point.ModelID)] = point
+	}
+
+	providers := catalog.Providers().List()
+	sort.Slice(providers, func(i, j int) bool { return providers[i].ID < providers[j].ID })
+	var added []Point
+	for _, provider := range providers {
+		modelIDs := make([]string, 0, len(provider.Models))
+		for id := range provider.Models {
+			modelIDs = append(modelIDs, id)
+		}
+		sort.Strings(modelIDs)
+		for _, id := range modelIDs {
+			point, ok := NewPoint(provider.ID, provider.Models[id], recordedAt)
+			if !ok {
+				continue
+			}
+			if previous, seen := latest[key(point.ProviderID, point.ModelID)]; seen && previous.SamePrices(point) {
+				continue
+			}
+			added = append(added, point)
+		}
+	}
+	if len(added) == 0 {
+		return 0, nil
+	}
+	return len(added), s.append(added)
+}
+
+// History returns the points of a model, oldest first. An empty providerID
+// returns the points of every provider offering the model.
+func (s *Store) History(modelID string, providerID catalogs.ProviderID) ([]Point, error) {
+	points, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	var history []Point
+	for _, point := range points {
+		if point.ModelID == modelID && (providerID == "" || point.ProviderID == providerID) {
+			history = append(history, point)
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].RecordedAt.Before(history[j].RecordedAt) })
+	return history, nil
+}
+
+func key(providerID catalogs.ProviderID, modelID string) string {
+	return string(providerID) + "/" + modelID
+}
+
+// load reads every recorded point. A missing file is an empty history.
+func (s *Store) load() ([]Point, error) {
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read price history: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var points []Point
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var point Point
+		if err := json.Unmarshal(line, &point); err != nil {
+			return nil, fmt.Errorf("failed to parse price history: %w", err)
+		}
+		points = append(points, point)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read price history: %w", err)
+	}
+	return points, nil
+}
+
+func (s *Store) append(points []Point) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, point := range points {
+		if err := encoder.Encode(point); err != nil {
+			return fmt.Errorf("failed to encode price history: %w", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create price history directory: %w", err)
+	}
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write price history: %w", err)
+	}
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write price history: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write price history: %w", err)
+	}
+	return nil
+}
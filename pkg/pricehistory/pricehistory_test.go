@@ -0,0 +1,59 @@
+package pricehistory
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func pricedCatalog(t *testing.T, input float64) catalogs.Reader {
+	t.Helper()
+	catalog := catalogs.NewEmpty()
+	provider := catalogs.Provider{ID: "openai", Name: "OpenAI", Models: map[string]*catalogs.Model{
+		"gpt-4o": {ID: "gpt-4o", Name: "GPT-4o", Pricing: &catalogs.ModelPricing{
+			Currency: catalogs.ModelPricingCurrencyUSD,
+			Tokens: &catalogs.ModelTokenPricing{
+				Input:  &catalogs.ModelTokenCost{Per1M: input},
+				Output: &catalogs.ModelTokenCost{Per1M: 10},
+			},
+		}},
+		"unpriced": {ID: "unpriced", Name: "Unpriced"},
+	}}
+	if err := catalog.SetProvider(provider); err != nil {
+		t.Fatalf("SetProvider failed: %v", err)
+	}
+	return catalog
+}
+
+func TestStoreRecordsPriceChanges(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "pricing-history.jsonl"))
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for day, input := range []float64{5, 5, 2.5} {
+		if _, err := store.Record(pricedCatalog(t, input), start.AddDate(0, 0, day)); err != nil {
+			t.Fatalf("Record on day %d failed: %v", day, err)
+		}
+	}
+
+	points, err := store.History("gpt-4o", "")
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("History returned %d points, want 2 price changes: %+v", len(points), points)
+	}
+	if *points[0].Input != 5 || !points[0].RecordedAt.Equal(start) {
+		t.Errorf("first point = %+v, want 5 on day 0", points[0])
+	}
+	if *points[1].Input != 2.5 || *points[1].Output != 10 || !points[1].RecordedAt.Equal(start.AddDate(0, 0, 2)) {
+		t.Errorf("second point = %+v, want 2.5 in and 10 out on day 2", points[1])
+	}
+	if points, err := store.History("unpriced", ""); err != nil || len(points) != 0 {
+		t.Errorf("History(unpriced) = %+v, %v; want no points", points, err)
+	}
+	if points, err := store.History("gpt-4o", "anthropic"); err != nil || len(points) != 0 {
+		t.Errorf("History for another provider = %+v, %v; want no points", points, err)
+	}
+}
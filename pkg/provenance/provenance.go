@@ -3,9 +3,11 @@ package provenance
 
 import (
 	"fmt"
+	"hash/fnv"
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/goccy/go-yaml"
@@ -40,6 +42,7 @@ type Rejection struct {
 type Map map[string][]Provenance // key is "resourceType:resourceID:fieldPath"
 
 // Tracker manages provenance tracking during reconciliation.
+// Implementations returned by NewTracker are safe for concurrent use.
 type Tracker interface {
 	// Track records provenance for a field
 	Track(resourceType catalogmeta.ResourceType, resourceID string, field string, history Provenance)
@@ -57,18 +60,31 @@ type Tracker interface {
 	Clear()
 }
 
-// tracker is the default implementation.
+// trackerShards is the number of independently locked partitions of a
+// tracker, so concurrent merges of different resources rarely contend.
+const trackerShards = 16
+
+// tracker is the default implementation. It is safe for concurrent use:
+// resources are spread over shards that each guard their part of the map, and
+// all fields of one resource share a shard.
 type tracker struct {
+	shards  [trackerShards]trackerShard
+	enabled bool
+}
+
+// trackerShard holds the provenance of the resources hashed to it.
+type trackerShard struct {
+	mu         sync.RWMutex
 	provenance Map
-	enabled    bool
 }
 
 // NewTracker creates a new provenance tracker.
 func NewTracker(enabled bool) Tracker {
-	return &tracker{
-		provenance: make(Map),
-		enabled:    enabled,
+	p := &tracker{enabled: enabled}
+	for i := range p.shards {
+		p.shards[i].provenance = make(Map)
 	}
+	return p
 }
 
 // Track records provenance for a field.
@@ -84,7 +100,10 @@ func (p *tracker) Track(resourceType catalogmeta.ResourceType, resourceID string
 		history.Timestamp = time.Now()
 	}
 
-	p.provenance[key] = append(p.provenance[key], history)
+	shard := p.shard(string(resourceType), resourceID)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	shard.provenance[key] = append(shard.provenance[key], history)
 }
 
 // Find retrieves provenance for a specific field.
@@ -94,7 +113,10 @@ func (p *tracker) FindByField(resourceType catalogmeta.ResourceType, resourceID
 	}
 
 	key := p.makeKey(string(resourceType), resourceID, field)
-	return cloneProvenance(p.provenance[key])
+	shard := p.shard(string(resourceType), resourceID)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	return cloneProvenance(shard.provenance[key])
 }
 
 // GetResourceProvenance retrieves all provenance for a resource.
@@ -106,7 +128,10 @@ func (p *tracker) FindByResource(resourceType catalogmeta.ResourceType, resource
 	result := make(map[string][]Provenance)
 	prefix := fmt.Sprintf("%s:%s:", string(resourceType), resourceID)
 
-	for key, info := range p.provenance {
+	shard := p.shard(string(resourceType), resourceID)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	for key, info := range shard.provenance {
 		if field, found := strings.CutPrefix(key, prefix); found {
 			result[field] = cloneProvenance(info)
 		}
@@ -123,8 +148,13 @@ func (p *tracker) Map() Map {
 
 	// Return a copy to prevent external modification
 	result := make(Map)
-	for k, v := range p.provenance {
-		result[k] = cloneProvenance(v)
+	for i := range p.shards {
+		shard := &p.shards[i]
+		shard.mu.RLock()
+		for k, v := range shard.provenance {
+			result[k] = cloneProvenance(v)
+		}
+		shard.mu.RUnlock()
 	}
 	return result
 }
@@ -140,7 +170,19 @@ func cloneProvenance(source []Provenance) []Provenance {
 
 // Clear removes all provenance data.
 func (p *tracker) Clear() {
-	p.provenance = make(Map)
+	for i := range p.shards {
+		shard := &p.shards[i]
+		shard.mu.Lock()
+		shard.provenance = make(Map)
+		shard.mu.Unlock()
+	}
+}
+
+// shard returns the shard that holds every field of a resource.
+func (p *tracker) shard(resourceType string, resourceID string) *trackerShard {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(resourceType + ":" + resourceID))
+	return &p.shards[hash.Sum32()%trackerShards]
 }
 
 // makeKey creates a unique key for provenance tracking.
@@ -0,0 +1,108 @@
+package provenance
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+)
+
+func TestTrackerConcurrentUse(t *testing.T) {
+	tracker := NewTracker(true)
+	const workers, fields = 8, 50
+
+	var wg sync.WaitGroup
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			modelID := fmt.Sprintf("model-%d", worker)
+			for field := range fields {
+				name := fmt.Sprintf("field-%d", field)
+				tracker.Track(catalogmeta.ResourceTypeModel, modelID, name, Provenance{Source: catalogmeta.ProvidersID, Value: field})
+				tracker.Track(catalogmeta.ResourceTypeModel, "shared", name, Provenance{Source: catalogmeta.ProvidersID, Value: worker})
+				_ = tracker.FindByField(catalogmeta.ResourceTypeModel, "shared", name)
+				_ = tracker.FindByResource(catalogmeta.ResourceTypeModel, modelID)
+				_ = tracker.Map()
+			}
+		}()
+	}
+	wg.Wait()
+
+	all := tracker.Map()
+	if len(all) != workers*fields+fields {
+		t.Fatalf("tracked keys = %d, want %d", len(all), workers*fields+fields)
+	}
+	for field := range fields {
+		history := tracker.FindByField(catalogmeta.ResourceTypeModel, "shared", fmt.Sprintf("field-%d", field))
+		if len(history) != workers {
+			t.Fatalf("shared field-%d history = %d entries, want %d", field, len(history), workers)
+		}
+	}
+	if got := tracker.FindByResource(catalogmeta.ResourceTypeModel, "model-3"); len(got) != fields {
+		t.Fatalf("model-3 fields = %d, want %d", len(got), fields)
+	}
+}
+
+func TestTrackerKeepsResourceFieldsInOneShard(t *testing.T) {
+	tracker := NewTracker(true).(*tracker)
+	for field := range 20 {
+		name := fmt.Sprintf("field-%d", field)
+		tracker.Track(catalogmeta.ResourceTypeModel, "gpt-4", name, Provenance{Source: catalogmeta.ProvidersID, Value: field})
+		tracker.Track(catalogmeta.ResourceTypeModel, "gpt-4o", name, Provenance{Source: catalogmeta.ProvidersID, Value: field})
+	}
+
+	holding := 0
+	for i := range tracker.shards {
+		for key := range tracker.shards[i].provenance {
+			if strings.HasPrefix(key, "model:gpt-4:") {
+				holding++
+				break
+			}
+		}
+	}
+	if holding != 1 {
+		t.Fatalf("gpt-4 fields are spread over %d shards, want 1", holding)
+	}
+	if got := tracker.FindByResource(catalogmeta.ResourceTypeModel, "gpt-4"); len(got) != 20 {
+		t.Fatalf("gpt-4 fields = %d, want 20", len(got))
+	}
+}
+
+func TestTrackerConcurrentClear(t *testing.T) {
+	tracker := NewTracker(true)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range 200 {
+			tracker.Track(catalogmeta.ResourceTypeProvider, "openai", fmt.Sprintf("field-%d", i), Provenance{Source: catalogmeta.ProvidersID})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			tracker.Clear()
+		}
+	}()
+	wg.Wait()
+
+	tracker.Clear()
+	if got := tracker.Map(); len(got) != 0 {
+		t.Fatalf("Map after Clear = %d keys, want 0", len(got))
+	}
+}
+
+func TestDisabledTrackerRecordsNothing(t *testing.T) {
+	tracker := NewTracker(false)
+	tracker.Track(catalogmeta.ResourceTypeModel, "gpt-4o", "name", Provenance{Source: catalogmeta.ProvidersID})
+	if got := tracker.FindByField(catalogmeta.ResourceTypeModel, "gpt-4o", "name"); got != nil {
+		t.Fatalf("FindByField = %+v, want nil", got)
+	}
+	if got := tracker.Map(); got != nil {
+		t.Fatalf("Map = %+v, want nil", got)
+	}
+}
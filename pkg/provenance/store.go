@@ -0,0 +1,207 @@
+package provenance
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+)
+
+// Store is a durable provenance history kept as JSON Lines, one field value
+// per line. Each applied sync records the value in effect for every field, so
+// the history outlives the tracker and shows when a value such as a price or
+// a limit changed and which source changed it. Only the newest depth values
+// of each field are kept.
+type Store struct {
+	path  string
+	depth int
+}
+
+// storeRecord is one line of the store.
+type storeRecord struct {
+	Key           string               `json:"key"`
+	Source        catalogmeta.SourceID `json:"source"`
+	Value         any                  `json:"value"`
+	Timestamp     time.Time            `json:"timestamp"`
+	ObservationID string               `json:"observation_id,omitempty"`
+	ObservedAt    time.Time            `json:"observed_at,omitzero"`
+	Authority     float64              `json:"authority"`
+	Confidence    float64              `json:"confidence"`
+	Reason        string               `json:"reason,omitempty"`
+}
+
+// NewStore returns the history stored at path, keeping depth values per field.
+func NewStore(path string, depth int) *Store {
+	return &Store{path: path, depth: depth}
+}
+
+// Record adds the value in effect for each field of m, the newest entry of
+// each key, when it differs in value or source from the last recorded one.
+// It returns the number of values added.
+func (s *Store) Record(m Map) (int, error) {
+	history, err := s.load()
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	for key, entries := range m {
+		if len(entries) == 0 {
+			continue
+		}
+		current := newestEntry(entries)
+		record, err := normalizedRecord(key, current)
+		if err != nil {
+			return 0, err
+		}
+		previous := history[key]
+		if n := len(previous); n > 0 && previous[n-1].Source == record.Source && reflect.DeepEqual(previous[n-1].Value, record.Value) {
+			continue
+		}
+		history[key] = append(previous, record)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	return added, s.write(history)
+}
+
+// FindByResource returns the recorded history of every field of a resource,
+// oldest first.
+func (s *Store) FindByResource(resourceType catalogmeta.ResourceType, resourceID string) (map[string][]Provenance, error) {
+	history, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	prefix := fmt.Sprintf("%s:%s:", resourceType, resourceID)
+	result := make(map[string][]Provenance)
+	for key, records := range history {
+		field, found := strings.CutPrefix(key, prefix)
+		if !found {
+			continue
+		}
+		for _, record := range records {
+			result[field] = append(result[field], Provenance{
+				Source:        record.Source,
+				Field:         field,
+				Value:         record.Value,
+				Timestamp:     record.Timestamp,
+				ObservationID: record.ObservationID,
+				ObservedAt:    record.ObservedAt,
+				Authority:     record.Authority,
+				Confidence:    record.Confidence,
+				Reason:        record.Reason,
+			})
+		}
+	}
+	return result, nil
+}
+
+// load reads the history by key, oldest first. A missing file is empty.
+func (s *Store) load() (map[string][]storeRecord, error) {
+	history := make(map[string][]storeRecord)
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provenance history: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var record storeRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("failed to parse provenance history: %w", err)
+		}
+		history[record.Key] = append(history[record.Key], record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read provenance history: %w", err)
+	}
+	return history, nil
+}
+
+// write replaces the history, trimmed to the store's depth, atomically.
+func (s *Store) write(history map[string][]storeRecord) error {
+	keys := make([]string, 0, len(history))
+	for key := range history {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, key := range keys {
+		records := history[key]
+		if s.depth > 0 && len(records) > s.depth {
+			records = records[len(records)-s.depth:]
+		}
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return fmt.Errorf("failed to encode provenance history: %w", err)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create provenance history directory: %w", err)
+	}
+	temporary := s.path + ".tmp"
+	if err := os.WriteFile(temporary, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write provenance history: %w", err)
+	}
+	if err := os.Rename(temporary, s.path); err != nil {
+		return fmt.Errorf("failed to write provenance history: %w", err)
+	}
+	return nil
+}
+
+// newestEntry returns the entry with the latest timestamp.
+func newestEntry(entries []Provenance) Provenance {
+	newest := entries[0]
+	for _, entry := range entries[1:] {
+		if !entry.Timestamp.Before(newest.Timestamp) {
+			newest = entry
+		}
+	}
+	return newest
+}
+
+// normalizedRecord converts an entry to a record whose value has the shape it
+// will have when read back, so it compares equal to its stored copy.
+func normalizedRecord(key string, entry Provenance) (storeRecord, error) {
+	record := storeRecord{
+		Key:           key,
+		Source:        entry.Source,
+		Timestamp:     entry.Timestamp,
+		ObservationID: entry.ObservationID,
+		ObservedAt:    entry.ObservedAt,
+		Authority:     entry.Authority,
+		Confidence:    entry.Confidence,
+		Reason:        entry.Reason,
+	}
+	data, err := json.Marshal(entry.Value)
+	if err != nil {
+		return storeRecord{}, fmt.Errorf("failed to encode provenance value for %s: %w", key, err)
+	}
+	if err := json.Unmarshal(data, &record.Value); err != nil {
+		return storeRecord{}, fmt.Errorf("failed to decode provenance value for %s: %w", key, err)
+	}
+	return record, nil
+}
@@ -0,0 +1,47 @@
+package provenance
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+)
+
+func TestStoreRecordsChangedValues(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "history.jsonl"), 2)
+	key := "model:gpt-4o:pricing.tokens.input.per_1m"
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	record := func(day int, source catalogmeta.SourceID, value float64) int {
+		t.Helper()
+		added, err := store.Record(Map{key: {{Source: source, Field: "pricing.tokens.input.per_1m", Value: value, Timestamp: start.AddDate(0, 0, day)}}})
+		if err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+		return added
+	}
+
+	if added := record(0, catalogmeta.ProvidersID, 2.5); added != 1 {
+		t.Errorf("first Record added %d values, want 1", added)
+	}
+	if added := record(1, catalogmeta.ProvidersID, 2.5); added != 0 {
+		t.Errorf("unchanged Record added %d values, want 0", added)
+	}
+	record(2, catalogmeta.ModelsDevHTTPID, 2.5)
+	record(3, catalogmeta.ModelsDevHTTPID, 2.0)
+
+	history, err := store.FindByResource(catalogmeta.ResourceTypeModel, "gpt-4o")
+	if err != nil {
+		t.Fatalf("FindByResource failed: %v", err)
+	}
+	entries := history["pricing.tokens.input.per_1m"]
+	if len(entries) != 2 {
+		t.Fatalf("history has %d entries, want 2 after trimming: %+v", len(entries), entries)
+	}
+	if entries[0].Source != catalogmeta.ModelsDevHTTPID || entries[0].Value != 2.5 {
+		t.Errorf("oldest kept entry = %+v, want models.dev at 2.5", entries[0])
+	}
+	if entries[1].Value != 2.0 || !entries[1].Timestamp.Equal(start.AddDate(0, 0, 3)) {
+		t.Errorf("newest entry = %+v, want 2.0 on day 3", entries[1])
+	}
+}
@@ -0,0 +1,189 @@
+package reconciler
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/agentstation/starmap/pkg/authority"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// ConflictChoice names how an unresolvable conflict is settled.
+type ConflictChoice string
+
+const (
+	// ConflictChoiceOurs keeps the value in the local catalog.
+	ConflictChoiceOurs ConflictChoice = "ours"
+	// ConflictChoiceTheirs takes the value from the incoming sources.
+	ConflictChoiceTheirs ConflictChoice = "theirs"
+	// ConflictChoiceAuthority keeps the value the strategy selected.
+	ConflictChoiceAuthority ConflictChoice = "authority"
+)
+
+// ConflictChoices lists the valid conflict choices.
+var ConflictChoices = []ConflictChoice{ConflictChoiceOurs, ConflictChoiceTheirs, ConflictChoiceAuthority}
+
+// ParseConflictChoice parses a conflict choice name.
+func ParseConflictChoice(name string) (ConflictChoice, error) {
+	choice := ConflictChoice(name)
+	if !slices.Contains(ConflictChoices, choice) {
+		return "", &errors.ValidationError{
+			Field:   "resolve",
+			Value:   name,
+			Message: fmt.Sprintf("must be one of %v", ConflictChoices),
+		}
+	}
+	return choice, nil
+}
+
+// Conflict is a field on which sources disagree and no field authority
+// covers any of the sources that provide a value. The strategy still selects
+// a value by its fallback rules; a ConflictResolver may choose another.
+type Conflict struct {
+	Resource   sources.ResourceType
+	ProviderID catalogs.ProviderID // Provider that owns the model; empty for providers
+	ResourceID string              // Model or provider ID
+	Field      string
+	Values     map[sources.ID]any
+
+	Ours      sources.ID // Local catalog source; empty when it has no value
+	Theirs    sources.ID // Strategy's pick among the incoming sources
+	Authority sources.ID // Strategy's pick among all sources
+}
+
+// Source returns the source that choice selects, falling back to the
+// strategy's pick when the chosen side has no value.
+func (c Conflict) Source(choice ConflictChoice) sources.ID {
+	switch choice {
+	case ConflictChoiceOurs:
+		if c.Ours != "" {
+			return c.Ours
+		}
+	case ConflictChoiceTheirs:
+		if c.Theirs != "" {
+			return c.Theirs
+		}
+	}
+	return c.Authority
+}
+
+// ConflictResolver chooses the source whose value is kept for a conflict.
+// It returns the chosen source and a short note on how the choice was made,
+// both of which are recorded in provenance. An error aborts reconciliation.
+type ConflictResolver interface {
+	ResolveFieldConflict(conflict Conflict) (sources.ID, string, error)
+}
+
+// ConflictResolverFunc adapts a function to a ConflictResolver.
+type ConflictResolverFunc func(conflict Conflict) (sources.ID, string, error)
+
+// ResolveFieldConflict calls f.
+func (f ConflictResolverFunc) ResolveFieldConflict(conflict Conflict) (sources.ID, string, error) {
+	return f(conflict)
+}
+
+// NewChoiceResolver returns a resolver that settles every conflict with choice.
+func NewChoiceResolver(choice ConflictChoice) ConflictResolver {
+	return ConflictResolverFunc(func(conflict Conflict) (sources.ID, string, error) {
+		return conflict.Source(choice), string(choice), nil
+	})
+}
+
+// fieldConflict returns the conflict for a field, or false when the values
+// agree, observation times decide, or a field authority covers a source that
+// provides one. base is the baseline catalog's value, offered as ours when
+// the local catalog is not one of the sources.
+func (merger *merger) fieldConflict(resourceType sources.ResourceType, fieldPath string, values map[sources.ID]any, base any, selected sources.ID) (Conflict, bool) {
+	if merger.resolver == nil || len(values) < 2 {
+		return Conflict{}, false
+	}
+	if !valuesDisagree(values) {
+		return Conflict{}, false
+	}
+	if _, _, _, ok := merger.resolveObservedConflict(resourceType, fieldPath, values); ok {
+		return Conflict{}, false
+	}
+	for _, auth := range authorityFields(merger.authorities, resourceType) {
+		if value, ok := values[auth.Source]; ok && value != nil && value != "" && authority.MatchesPattern(fieldPath, auth.Path) {
+			return Conflict{}, false
+		}
+	}
+
+	conflict := Conflict{
+		Resource:  resourceType,
+		Field:     fieldPath,
+		Values:    maps.Clone(values),
+		Theirs:    selected,
+		Authority: selected,
+	}
+	if _, ok := values[sources.LocalCatalogID]; ok {
+		conflict.Ours = sources.LocalCatalogID
+		incoming := maps.Clone(values)
+		delete(incoming, sources.LocalCatalogID)
+		_, conflict.Theirs, _ = merger.resolveConflict(resourceType, fieldPath, incoming)
+	} else if base != nil {
+		conflict.Ours = sources.LocalCatalogID
+		conflict.Values[sources.LocalCatalogID] = base
+	}
+	return conflict, true
+}
+
+// valuesDisagree reports whether values holds two or more distinct non-empty
+// values.
+func valuesDisagree(values map[sources.ID]any) bool {
+	if len(values) < 2 {
+		return false
+	}
+	var distinct []any
+	for _, source := range sortedValueSources(values) {
+		value := values[source]
+		if value == nil || value == "" {
+			continue
+		}
+		if !slices.ContainsFunc(distinct, func(seen any) bool { return reflect.DeepEqual(seen, value) }) {
+			distinct = append(distinct, value)
+		}
+	}
+	return len(distinct) > 1
+}
+
+// resolveFieldConflict lets the configured resolver settle conflict. It keeps
+// the strategy's pick once a resolver has failed.
+func (merger *merger) resolveFieldConflict(conflict Conflict, value any, source sources.ID, reason string) (any, sources.ID, string) {
+	if merger.resolverErr != nil {
+		return value, source, reason
+	}
+	chosen, how, err := merger.resolver.ResolveFieldConflict(conflict)
+	if err != nil {
+		merger.resolverErr = err
+		return value, source, reason
+	}
+	chosenValue, ok := conflict.Values[chosen]
+	if !ok {
+		merger.resolverErr = &errors.ValidationError{
+			Field:   "conflict resolution",
+			Value:   string(chosen),
+			Message: fmt.Sprintf("source has no value for %s", conflict.Field),
+		}
+		return value, source, reason
+	}
+	merger.conflictsResolved++
+	return chosenValue, chosen, fmt.Sprintf("conflict resolved in favor of %s (%s)", chosen, how)
+}
+
+func authorityFields(authorities authority.Authority, resourceType sources.ResourceType) []authority.Field {
+	switch resourceType {
+	case sources.ResourceTypeModel:
+		return authorities.ModelFields()
+	case sources.ResourceTypeProvider:
+		return authorities.ProviderFields()
+	case sources.ResourceTypeAuthor:
+		return authorities.AuthorFields()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,124 @@
+package reconciler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/authority"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/provenance"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// pricingOnlyAuthority leaves every field but Pricing without an authority.
+var pricingOnlyAuthority = seamAuthority{field: authority.Field{Path: "Pricing", Source: sources.ProvidersID, Priority: 100}}
+
+func TestConflictResolverChoices(t *testing.T) {
+	strategy := NewAuthorityStrategy(pricingOnlyAuthority)
+	values := map[sources.ID]any{
+		sources.LocalCatalogID:  "Local Name",
+		sources.ProvidersID:     "Provider Name",
+		sources.ModelsDevHTTPID: "Models.dev Name",
+	}
+	_, authoritySource, _ := strategy.ResolveConflict("Name", values)
+	incoming := map[sources.ID]any{sources.ProvidersID: values[sources.ProvidersID], sources.ModelsDevHTTPID: values[sources.ModelsDevHTTPID]}
+	_, theirsSource, _ := strategy.ResolveConflict("Name", incoming)
+
+	for _, tt := range []struct {
+		choice ConflictChoice
+		want   sources.ID
+	}{
+		{ConflictChoiceOurs, sources.LocalCatalogID},
+		{ConflictChoiceTheirs, theirsSource},
+		{ConflictChoiceAuthority, authoritySource},
+	} {
+		t.Run(string(tt.choice), func(t *testing.T) {
+			merger := newMerger(pricingOnlyAuthority, strategy, nil)
+			merger.resolver = NewChoiceResolver(tt.choice)
+			sourceModels := map[sources.ID]*catalogs.Model{}
+			for source, name := range values {
+				sourceModels[source] = &catalogs.Model{ID: "model-1", Name: name.(string)}
+			}
+
+			merged, history := merger.model("openai", "model-1", sourceModels)
+			if merged.Name != values[tt.want] {
+				t.Errorf("Name = %q, want %q", merged.Name, values[tt.want])
+			}
+			current := history["Name"].Current
+			if current.Source != tt.want {
+				t.Errorf("provenance source = %q, want %q", current.Source, tt.want)
+			}
+			if !strings.Contains(current.Reason, "conflict resolved") || !strings.Contains(current.Reason, string(tt.choice)) {
+				t.Errorf("provenance reason = %q, want the conflict resolution", current.Reason)
+			}
+			if merger.conflictsResolved != 1 {
+				t.Errorf("conflictsResolved = %d, want 1", merger.conflictsResolved)
+			}
+		})
+	}
+}
+
+func TestConflictResolverOffersBaselineAsOurs(t *testing.T) {
+	baseline := catalogs.NewEmpty()
+	provider := catalogs.Provider{ID: "openai", Models: map[string]*catalogs.Model{
+		"model-1": {ID: "model-1", Name: "Baseline Name"},
+	}}
+	mustSetProviderForReconcilerTest(t, baseline, provider)
+
+	var got Conflict
+	merger := newMerger(pricingOnlyAuthority, NewAuthorityStrategy(pricingOnlyAuthority), snapshotForTest(t, baseline))
+	merger.resolver = ConflictResolverFunc(func(conflict Conflict) (sources.ID, string, error) {
+		got = conflict
+		return conflict.Source(ConflictChoiceOurs), "test", nil
+	})
+	merged, _ := merger.model("openai", "model-1", map[sources.ID]*catalogs.Model{
+		sources.ProvidersID:     {ID: "model-1", Name: "Provider Name"},
+		sources.ModelsDevHTTPID: {ID: "model-1", Name: "Models.dev Name"},
+	})
+
+	if merged.Name != "Baseline Name" {
+		t.Errorf("Name = %q, want the baseline value", merged.Name)
+	}
+	if got.ProviderID != "openai" || got.ResourceID != "model-1" || got.Field != "Name" || got.Ours != sources.LocalCatalogID {
+		t.Errorf("conflict = %+v, want openai/model-1 Name with ours from the baseline", got)
+	}
+}
+
+func TestConflictResolverSkipsSettledFields(t *testing.T) {
+	calls := 0
+	merger := newMergerWithProvenance(pricingOnlyAuthority, NewAuthorityStrategy(pricingOnlyAuthority), provenance.NewTracker(true), nil)
+	merger.resolver = ConflictResolverFunc(func(conflict Conflict) (sources.ID, string, error) {
+		calls++
+		return conflict.Authority, "test", nil
+	})
+	merger.model("openai", "model-1", map[sources.ID]*catalogs.Model{
+		sources.ProvidersID:     {ID: "model-1", Name: "Same Name", Pricing: &catalogs.ModelPricing{Currency: "USD"}},
+		sources.ModelsDevHTTPID: {ID: "model-1", Name: "Same Name", Pricing: &catalogs.ModelPricing{Currency: "EUR"}},
+	})
+	if calls != 0 {
+		t.Errorf("resolver called %d times for agreeing and authority-covered fields, want 0", calls)
+	}
+}
+
+func TestMergerCountsDetectedConflictsWithoutResolver(t *testing.T) {
+	merger := newMerger(pricingOnlyAuthority, NewAuthorityStrategy(pricingOnlyAuthority), nil)
+	merger.model("openai", "model-1", map[sources.ID]*catalogs.Model{
+		sources.ProvidersID:     {ID: "model-1", Name: "Same Name", Description: "Provider"},
+		sources.ModelsDevHTTPID: {ID: "model-1", Name: "Same Name", Description: "Models.dev"},
+	})
+	if merger.conflictsDetected != 1 {
+		t.Errorf("conflictsDetected = %d, want 1 for the disagreeing description", merger.conflictsDetected)
+	}
+	if merger.conflictsResolved != 0 {
+		t.Errorf("conflictsResolved = %d, want 0 without a resolver", merger.conflictsResolved)
+	}
+}
+
+func TestParseConflictChoice(t *testing.T) {
+	if choice, err := ParseConflictChoice("theirs"); err != nil || choice != ConflictChoiceTheirs {
+		t.Errorf("ParseConflictChoice(theirs) = %q, %v", choice, err)
+	}
+	if _, err := ParseConflictChoice("mine"); err == nil {
+		t.Error("ParseConflictChoice(mine) returned nil error")
+	}
+}
@@ -97,7 +97,20 @@ var modelFieldRules = []fieldRule{
 	newFieldRule(sources.ResourceTypeModel, "ReasoningTokens"),
 	newFieldRule(sources.ResourceTypeModel, "Verbosity"),
 	newFieldRule(sources.ResourceTypeModel, "Tools"),
+	newFieldRule(sources.ResourceTypeModel, "Vision"),
+	newFieldRule(sources.ResourceTypeModel, "Documents"),
+	newFieldRule(sources.ResourceTypeModel, "Caching"),
 	newFieldRule(sources.ResourceTypeModel, "Delivery"),
+	newFieldRule(sources.ResourceTypeModel, "Performance"),
+	newFieldRule(sources.ResourceTypeModel, "Class"),
+	newFieldRule(sources.ResourceTypeModel, "Definition"),
+	newFieldRule(sources.ResourceTypeModel, "Embedding"),
+	newFieldRule(sources.ResourceTypeModel, "UsageRestrictions"),
+	newFieldRule(sources.ResourceTypeModel, "Curation"),
+	newFieldRule(sources.ResourceTypeModel, "Review"),
+	newFieldRule(sources.ResourceTypeModel, "Private"),
+	newFieldRule(sources.ResourceTypeModel, "Evaluations"),
+	newFieldRule(sources.ResourceTypeModel, "Sustainability"),
 	newProvenanceFieldRule(sources.ResourceTypeModel, "Modes", modelProvenanceModes),
 }
 
@@ -123,9 +136,16 @@ var providerFieldRules = []fieldRule{
 	newFieldRule(sources.ResourceTypeProvider, "EnvVars"),
 	newFieldRule(sources.ResourceTypeProvider, "Catalog"),
 	newFieldRule(sources.ResourceTypeProvider, "ChatCompletions"),
+	newFieldRule(sources.ResourceTypeProvider, "SLA"),
+	newFieldRule(sources.ResourceTypeProvider, "Support"),
+	newFieldRule(sources.ResourceTypeProvider, "PromptCaching"),
+	newFieldRule(sources.ResourceTypeProvider, "Vision"),
+	newFieldRule(sources.ResourceTypeProvider, "Documents"),
 	newFieldRule(sources.ResourceTypeProvider, "PrivacyPolicy"),
 	newFieldRule(sources.ResourceTypeProvider, "RetentionPolicy"),
 	newFieldRule(sources.ResourceTypeProvider, "GovernancePolicy"),
+	newFieldRule(sources.ResourceTypeProvider, "UsageRestrictions"),
+	newFieldRule(sources.ResourceTypeProvider, "Sustainability"),
 }
 
 var authorFieldRules = []fieldRule{
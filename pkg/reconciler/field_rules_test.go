@@ -140,7 +140,7 @@ func TestSourceExtensionsExcludedFromAuthorityRules(t *testing.T) {
 func TestFieldRuleAuthorityResolution(t *testing.T) {
 	authorities := authority.New()
 	strategy := NewAuthorityStrategy(authorities)
-	var resolver resourceConflictResolver = strategy
+	var resolver ResourceConflictResolver = strategy
 
 	tests := []struct {
 		name     string
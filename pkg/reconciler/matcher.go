@@ -0,0 +1,346 @@
+package reconciler
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// ModelMatch is one underlying model that several providers serve under
+// different model IDs, such as Llama 3.1 70B on Groq, Together, and Bedrock.
+type ModelMatch struct {
+	Key        string                 // Normalized identity the offerings share
+	Definition string                 // Canonical definition ID the offerings are linked to
+	Offerings  []catalogs.OfferingKey // Matched provider models, in provider and model ID order
+}
+
+// servingTokens name how a provider serves a model rather than which model it
+// is, so they are dropped from the identity key.
+var servingTokens = map[string]bool{
+	"instruct":  true,
+	"versatile": true,
+	"turbo":     true,
+	"instant":   true,
+	"fp8":       true,
+	"fp16":      true,
+	"bf16":      true,
+	"int8":      true,
+	"int4":      true,
+	"awq":       true,
+	"gptq":      true,
+	"hf":        true,
+}
+
+// matchCandidate is one provider model considered for matching.
+type matchCandidate struct {
+	key   catalogs.OfferingKey
+	model *catalogs.Model
+	name  string // Lowercase ID without organization, vendor prefix, version suffix, or serving qualifiers
+}
+
+// MatchModels finds provider models that are the same underlying model. IDs
+// are normalized by dropping organization paths (meta-llama/), vendor and
+// region prefixes (us.meta.), Bedrock version suffixes (-v1:0), serving
+// qualifiers (instruct, turbo, fp8), and an author name the organization path
+// repeats (meta-llama/Meta-Llama-3.1), and by ignoring case and separators.
+// Models with the same normalized ID at two or more providers match unless:
+//
+//   - one provider serves several of them, so the pairing is ambiguous
+//   - their listed authors share no author
+//   - they carry different curated Definition links
+//
+// The definition is the curated Definition when one model has it, otherwise
+// the model ID of a provider that is also the model's author, otherwise the
+// most common ID without its organization, prefixes, suffixes, and serving
+// qualifiers. Ties prefer separated words (llama-3 over llama3), then the
+// shortest ID, then alphabetical order.
+func MatchModels(reader catalogs.Reader) []ModelMatch {
+	authors := make(map[string]bool)
+	for _, author := range reader.Authors().List() {
+		authors[strings.ToLower(string(author.ID))] = true
+	}
+
+	groups := make(map[string][]matchCandidate)
+	modelKeys := make(map[string]string)
+	providers := reader.Providers().List()
+	slices.SortFunc(providers, func(left, right catalogs.Provider) int {
+		return strings.Compare(string(left.ID), string(right.ID))
+	})
+	for _, provider := range providers {
+		ids := make([]string, 0, len(provider.Models))
+		for id := range provider.Models {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+		for _, id := range ids {
+			model := provider.Models[id]
+			if model == nil {
+				continue
+			}
+			organization, stripped := stripModelID(model.ID)
+			key := modelKey(organization, stripped, authors)
+			if key == "" {
+				continue
+			}
+			modelKeys[model.ID] = key
+			groups[key] = append(groups[key], matchCandidate{
+				key:   catalogs.OfferingKey{ProviderID: provider.ID, ProviderModelID: catalogs.ProviderModelID(model.ID)},
+				model: model,
+				name:  servingName(stripped),
+			})
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var matches []ModelMatch
+	for _, key := range keys {
+		candidates := groups[key]
+		if !matchable(candidates) {
+			continue
+		}
+		definition, ok := canonicalDefinition(candidates)
+		if !ok {
+			continue
+		}
+		// A definition named after an unrelated model would merge the two.
+		if other, found := modelKeys[definition]; found && other != key {
+			continue
+		}
+		match := ModelMatch{Key: key, Definition: definition}
+		for _, candidate := range candidates {
+			match.Offerings = append(match.Offerings, candidate.key)
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// LinkModels sets the Definition of every matched provider model that has no
+// curated link, so the catalog's definition layer shares one definition
+// across the providers serving the model. It returns the number of models
+// linked.
+func LinkModels(builder *catalogs.Builder) (int, error) {
+	linked := 0
+	for _, match := range MatchModels(builder) {
+		for _, key := range match.Offerings {
+			model, err := builder.ProviderModel(key.ProviderID, string(key.ProviderModelID))
+			if err != nil {
+				return linked, err
+			}
+			if model.Definition != "" || model.ID == match.Definition {
+				continue
+			}
+			model.Definition = match.Definition
+			if err := builder.SetProviderModel(key.ProviderID, model); err != nil {
+				return linked, errors.WrapResource("link", "model", model.ID, err)
+			}
+			linked++
+		}
+	}
+	return linked, nil
+}
+
+// matchable reports whether candidates are one model served by several
+// providers.
+func matchable(candidates []matchCandidate) bool {
+	providers := make(map[catalogs.ProviderID]bool, len(candidates))
+	for _, candidate := range candidates {
+		if providers[candidate.key.ProviderID] {
+			return false
+		}
+		providers[candidate.key.ProviderID] = true
+	}
+	if len(providers) < 2 {
+		return false
+	}
+
+	var shared map[catalogs.AuthorID]bool
+	for _, candidate := range candidates {
+		if len(candidate.model.Authors) == 0 {
+			continue
+		}
+		listed := make(map[catalogs.AuthorID]bool, len(candidate.model.Authors))
+		for _, author := range candidate.model.Authors {
+			if shared == nil || shared[author.ID] {
+				listed[author.ID] = true
+			}
+		}
+		if len(listed) == 0 {
+			return false
+		}
+		shared = listed
+	}
+	return true
+}
+
+// canonicalDefinition picks the definition ID for matched candidates. It
+// reports false when curated links disagree.
+func canonicalDefinition(candidates []matchCandidate) (string, bool) {
+	curated := ""
+	for _, candidate := range candidates {
+		if candidate.model.Definition == "" {
+			continue
+		}
+		if curated != "" && curated != candidate.model.Definition {
+			return "", false
+		}
+		curated = candidate.model.Definition
+	}
+	if curated != "" {
+		return curated, true
+	}
+
+	for _, candidate := range candidates {
+		for _, author := range candidate.model.Authors {
+			if strings.EqualFold(string(author.ID), string(candidate.key.ProviderID)) {
+				return candidate.model.ID, true
+			}
+		}
+	}
+
+	counts := make(map[string]int, len(candidates))
+	for _, candidate := range candidates {
+		counts[candidate.name]++
+	}
+	ids := make([]string, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(left, right string) int {
+		if counts[left] != counts[right] {
+			return counts[right] - counts[left]
+		}
+		if joinedLeft, joinedRight := joinedWords(left), joinedWords(right); joinedLeft != joinedRight {
+			return joinedLeft - joinedRight
+		}
+		if len(left) != len(right) {
+			return len(left) - len(right)
+		}
+		return strings.Compare(left, right)
+	})
+	return ids[0], true
+}
+
+// stripModelID lowercases a provider model ID and splits off its
+// organization path, then removes all-letter vendor and region prefixes and
+// a Bedrock-style version suffix: us.meta.llama3-1-70b-instruct-v1:0 becomes
+// llama3-1-70b-instruct.
+func stripModelID(id string) (organization, stripped string) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if slash := strings.LastIndex(id, "/"); slash >= 0 {
+		organization = id[:slash]
+		if parent := strings.LastIndex(organization, "/"); parent >= 0 {
+			organization = organization[parent+1:]
+		}
+		id = id[slash+1:]
+	}
+	for {
+		dot := strings.IndexByte(id, '.')
+		if dot <= 0 || !isLetters(id[:dot]) {
+			break
+		}
+		id = id[dot+1:]
+	}
+	if colon := strings.LastIndexByte(id, ':'); colon >= 0 && isDigits(id[colon+1:]) {
+		id = id[:colon]
+		if dash := strings.LastIndex(id, "-v"); dash >= 0 && isDigits(id[dash+2:]) {
+			id = id[:dash]
+		}
+	}
+	return organization, id
+}
+
+// modelKey normalizes a stripped model ID into the identity it shares with
+// the same model at other providers. Tokens split on separators and on
+// letter-digit boundaries, serving qualifiers are dropped, and so is a
+// leading author name the organization path starts with, so
+// meta-llama/Meta-Llama-3.1-70B-Instruct-Turbo, llama3-1-70b-instruct, and
+// llama-3.1-70b-versatile all become llama 3 1 70 b.
+func modelKey(organization, stripped string, authors map[string]bool) string {
+	var tokens []string
+	for _, field := range strings.FieldsFunc(stripped, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if servingTokens[field] {
+			continue
+		}
+		tokens = append(tokens, splitLetterDigit(field)...)
+	}
+	if len(tokens) > 1 && authors[tokens[0]] && isLetters(tokens[1]) && strings.HasPrefix(organization, tokens[0]) {
+		tokens = tokens[1:]
+	}
+	return strings.Join(tokens, " ")
+}
+
+// servingName removes serving qualifier segments from a stripped model ID:
+// llama-3.1-70b-versatile becomes llama-3.1-70b.
+func servingName(stripped string) string {
+	var kept []string
+	for _, segment := range strings.Split(stripped, "-") {
+		if !servingTokens[segment] {
+			kept = append(kept, segment)
+		}
+	}
+	if len(kept) == 0 {
+		return stripped
+	}
+	return strings.Join(kept, "-")
+}
+
+// splitLetterDigit splits a token where letters and digits meet: llama3
+// becomes llama and 3, 70b becomes 70 and b.
+func splitLetterDigit(token string) []string {
+	var parts []string
+	start := 0
+	for i := 1; i < len(token); i++ {
+		if unicode.IsDigit(rune(token[i])) != unicode.IsDigit(rune(token[i-1])) {
+			parts = append(parts, token[start:i])
+			start = i
+		}
+	}
+	return append(parts, token[start:])
+}
+
+// joinedWords counts the places a letter and a digit meet without a
+// separator, such as the a3 in llama3.
+func joinedWords(id string) int {
+	joined := 0
+	for i := 1; i < len(id); i++ {
+		if isLetters(id[i-1:i]) && isDigits(id[i:i+1]) || isDigits(id[i-1:i]) && isLetters(id[i:i+1]) {
+			joined++
+		}
+	}
+	return joined
+}
+
+func isLetters(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+func TestModelKeyNormalizesProviderIDs(t *testing.T) {
+	authors := map[string]bool{"meta": true, "mistral": true}
+	for _, tt := range []struct {
+		id   string
+		want string
+	}{
+		{"llama-3.1-70b-versatile", "llama 3 1 70 b"},
+		{"meta-llama/Meta-Llama-3.1-70B-Instruct-Turbo", "llama 3 1 70 b"},
+		{"meta.llama3-1-70b-instruct-v1:0", "llama 3 1 70 b"},
+		{"us.meta.llama3-1-70b-instruct-v1:0", "llama 3 1 70 b"},
+		{"accounts/fireworks/models/llama-3.1-8b-instruct", "llama 3 1 8 b"},
+		{"gpt-4.1", "gpt 4 1"},
+		{"mistral-large-v2", "mistral large v 2"},
+		{"mistralai/Mistral-7B-Instruct", "mistral 7 b"},
+	} {
+		organization, stripped := stripModelID(tt.id)
+		if got := modelKey(organization, stripped, authors); got != tt.want {
+			t.Errorf("modelKey(%q) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+// matchingCatalog serves Llama 3.1 70B at three providers under different
+// IDs, plus models that must not be linked to it.
+func matchingCatalog(t *testing.T) *catalogs.Builder {
+	t.Helper()
+	builder := catalogs.NewEmpty()
+	if err := builder.SetAuthor(catalogs.Author{ID: "meta", Name: "Meta"}); err != nil {
+		t.Fatalf("SetAuthor: %v", err)
+	}
+	meta := []catalogs.Author{{ID: "meta", Name: "Meta"}}
+	providers := map[catalogs.ProviderID][]catalogs.Model{
+		"groq": {
+			{ID: "llama-3.1-70b-versatile", Name: "Llama 3.1 70B", Authors: meta},
+			{ID: "llama-3.1-8b-instant", Name: "Llama 3.1 8B", Authors: meta},
+		},
+		"together": {
+			{ID: "meta-llama/Meta-Llama-3.1-70B-Instruct-Turbo", Name: "Llama 3.1 70B Turbo", Authors: meta},
+		},
+		"bedrock": {
+			{ID: "meta.llama3-1-70b-instruct-v1:0", Name: "Llama 3.1 70B Instruct"},
+		},
+	}
+	for id, models := range providers {
+		provider := catalogs.Provider{ID: id, Name: string(id), Models: map[string]*catalogs.Model{}}
+		for _, model := range models {
+			provider.Models[model.ID] = &model
+		}
+		if err := builder.SetProvider(provider); err != nil {
+			t.Fatalf("SetProvider %s: %v", id, err)
+		}
+	}
+	return builder
+}
+
+func TestLinkModelsSharesOneDefinitionAcrossProviders(t *testing.T) {
+	builder := matchingCatalog(t)
+
+	matches := MatchModels(builder)
+	if len(matches) != 1 {
+		t.Fatalf("matches = %+v, want one", matches)
+	}
+	match := matches[0]
+	if match.Definition != "llama-3.1-70b" || len(match.Offerings) != 3 {
+		t.Fatalf("match = %+v, want llama-3.1-70b at three providers", match)
+	}
+
+	linked, err := LinkModels(builder)
+	if err != nil {
+		t.Fatalf("LinkModels: %v", err)
+	}
+	if linked != 3 {
+		t.Fatalf("linked = %d, want 3", linked)
+	}
+	if model, _ := builder.ProviderModel("groq", "llama-3.1-8b-instant"); model.Definition != "" {
+		t.Fatalf("8B model linked to %q", model.Definition)
+	}
+
+	catalog, err := catalogs.NewCatalog(builder)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+	offerings := catalog.DefinitionOfferings("llama-3.1-70b")
+	if len(offerings) != 3 {
+		t.Fatalf("definition offerings = %+v, want three", offerings)
+	}
+	definition, err := catalog.FindModel("llama-3.1-70b-versatile")
+	if err != nil || definition.ID != "llama-3.1-70b" {
+		t.Fatalf("FindModel by provider model ID = %+v, %v", definition, err)
+	}
+	if offering, _ := catalog.Offering("bedrock", "meta.llama3-1-70b-instruct-v1:0"); offering.ProviderModelID != "meta.llama3-1-70b-instruct-v1:0" {
+		t.Fatalf("provider model ID was rewritten: %+v", offering)
+	}
+}
+
+func TestMatchModelsRespectsCurationAndAmbiguity(t *testing.T) {
+	t.Run("curated definition wins", func(t *testing.T) {
+		builder := matchingCatalog(t)
+		model, _ := builder.ProviderModel("bedrock", "meta.llama3-1-70b-instruct-v1:0")
+		model.Definition = "llama-3.1-70b-instruct"
+		if err := builder.SetProviderModel("bedrock", model); err != nil {
+			t.Fatalf("SetProviderModel: %v", err)
+		}
+		matches := MatchModels(builder)
+		if len(matches) != 1 || matches[0].Definition != "llama-3.1-70b-instruct" {
+			t.Fatalf("matches = %+v, want the curated definition", matches)
+		}
+	})
+
+	t.Run("disjoint authors", func(t *testing.T) {
+		builder := matchingCatalog(t)
+		model, _ := builder.ProviderModel("together", "meta-llama/Meta-Llama-3.1-70B-Instruct-Turbo")
+		model.Authors = []catalogs.Author{{ID: "nvidia", Name: "NVIDIA"}}
+		if err := builder.SetProviderModel("together", model); err != nil {
+			t.Fatalf("SetProviderModel: %v", err)
+		}
+		if matches := MatchModels(builder); len(matches) != 0 {
+			t.Fatalf("matches = %+v, want none", matches)
+		}
+	})
+
+	t.Run("several candidates at one provider", func(t *testing.T) {
+		builder := matchingCatalog(t)
+		if err := builder.SetProviderModel("groq", catalogs.Model{ID: "llama-3.1-70b-instruct", Name: "Llama 3.1 70B"}); err != nil {
+			t.Fatalf("SetProviderModel: %v", err)
+		}
+		if matches := MatchModels(builder); len(matches) != 0 {
+			t.Fatalf("matches = %+v, want none", matches)
+		}
+	})
+}
+
+func TestReconcilerModelMatching(t *testing.T) {
+	// Authors come from the baseline, as in a catalog update
+	authors := catalogs.NewEmpty()
+	if err := authors.SetAuthor(catalogs.Author{ID: "meta", Name: "Meta"}); err != nil {
+		t.Fatalf("SetAuthor: %v", err)
+	}
+	baseline, err := catalogs.NewCatalog(authors)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+
+	for _, enabled := range []bool{false, true} {
+		reconcile, err := New(WithBaseline(baseline), WithModelMatching(enabled))
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		srcs := ConvertCatalogsMapToSources(map[sources.ID]*catalogs.Builder{sources.ProvidersID: matchingCatalog(t)})
+		result, err := reconcile.Sources(context.Background(), sources.ProvidersID, srcs)
+		if err != nil {
+			t.Fatalf("Sources: %v", err)
+		}
+		var linked []string
+		for _, model := range result.Catalog.Models().List() {
+			if model.Definition != "" {
+				linked = append(linked, model.ID)
+			}
+		}
+		if want := map[bool]int{false: 0, true: 3}[enabled]; len(linked) != want {
+			t.Errorf("matching %v linked %v", enabled, linked)
+		}
+	}
+}
@@ -24,6 +24,11 @@ type merger struct {
 	baselineModels map[catalogs.ProviderID]map[string]*catalogs.Model
 	pricingAt      time.Time
 	observations   map[sources.ID]sourceObservationEvidence
+
+	resolver          ConflictResolver // Settles unresolvable conflicts; nil keeps the strategy's pick
+	resolverErr       error            // First resolver failure, returned by the reconciler
+	conflictsResolved int
+	conflictsDetected int // Fields whose sources disagreed, however they were settled
 }
 
 type sourceObservationEvidence struct {
@@ -265,7 +270,7 @@ func (merger *merger) model(providerID catalogs.ProviderID, modelID string, sour
 
 	// Merge each field according to authorities
 	for _, rule := range fieldRulesFor(sources.ResourceTypeModel) {
-		value, sourceType, reason := merger.modelField(rule, sourceModels)
+		value, sourceType, reason := merger.modelField(providerID, modelID, baselineModelSnapshot, rule, sourceModels)
 		if value != nil {
 			merger.setModelFieldValue(merged, rule.reflectPath, value)
 			merger.recordModelHistory(&history, rule, sourceType, value, reason)
@@ -328,6 +333,7 @@ func sourceCreatedAt(sourceModels map[sources.ID]*catalogs.Model) utc.Time {
 		sources.ModelsDevHTTPID,
 		sources.ModelsDevGitID,
 		sources.LocalCatalogID,
+		sources.RemoteCatalogID,
 	} {
 		if model, ok := sourceModels[sourceType]; ok && model != nil && !model.CreatedAt.IsZero() {
 			return model.CreatedAt
@@ -342,6 +348,7 @@ func sourceUpdatedAt(sourceModels map[sources.ID]*catalogs.Model) utc.Time {
 		sources.ModelsDevGitID,
 		sources.ProvidersID,
 		sources.LocalCatalogID,
+		sources.RemoteCatalogID,
 	} {
 		if model, ok := sourceModels[sourceType]; ok && model != nil && !model.UpdatedAt.IsZero() {
 			return model.UpdatedAt
@@ -359,10 +366,14 @@ func (merger *merger) provider(providerID catalogs.ProviderID, sourceProviders m
 	// Start with a base provider
 	var merged catalogs.Provider
 	history := make(map[string]provenance.Field)
+	var base *catalogs.Provider
+	if merger.baseline != nil {
+		base, _ = merger.baseline.Providers().Get(providerID)
+	}
 
 	// Merge each field
 	for _, rule := range fieldRulesFor(sources.ResourceTypeProvider) {
-		value, sourceType := merger.providerField(rule, sourceProviders)
+		value, sourceType, reason := merger.providerField(providerID, base, rule, sourceProviders)
 		if value != nil {
 			merger.setProviderFieldValue(&merged, rule.reflectPath, value)
 
@@ -373,6 +384,7 @@ func (merger *merger) provider(providerID catalogs.ProviderID, sourceProviders m
 					Field:     provenancePath,
 					Value:     value,
 					Timestamp: time.Now(),
+					Reason:    reason,
 				},
 			}
 		}
@@ -386,7 +398,7 @@ func (merger *merger) provider(providerID catalogs.ProviderID, sourceProviders m
 }
 
 // modelField merges a single field from multiple model sources.
-func (merger *merger) modelField(rule fieldRule, sourceModels map[sources.ID]*catalogs.Model) (any, sources.ID, string) {
+func (merger *merger) modelField(providerID catalogs.ProviderID, modelID string, base *catalogs.Model, rule fieldRule, sourceModels map[sources.ID]*catalogs.Model) (any, sources.ID, string) {
 	// Collect all values from sources
 	values := make(map[sources.ID]any)
 	for source, model := range sourceModels {
@@ -396,9 +408,22 @@ func (merger *merger) modelField(rule fieldRule, sourceModels map[sources.ID]*ca
 	}
 
 	if len(values) > 0 {
+		if valuesDisagree(values) {
+			merger.conflictsDetected++
+		}
 		// Let the strategy decide - it will use authorities if it's AuthorityStrategy
 		// or source priority order if it's SourceOrderStrategy
 		value, source, reason := merger.resolveConflict(rule.resource, rule.authority(), values)
+		var baseValue any
+		if base != nil && merger.resolver != nil {
+			baseValue = merger.modelFieldValue(base, rule.reflectPath)
+		}
+		if conflict, ok := merger.fieldConflict(rule.resource, rule.authority(), values, baseValue, source); ok {
+			conflict.ProviderID = providerID
+			conflict.ResourceID = modelID
+			conflict.Field = rule.provenance()
+			return merger.resolveFieldConflict(conflict, value, source, reason)
+		}
 		return value, source, reason
 	}
 
@@ -406,7 +431,7 @@ func (merger *merger) modelField(rule fieldRule, sourceModels map[sources.ID]*ca
 }
 
 // providerField merges a single provider field from multiple sources.
-func (merger *merger) providerField(rule fieldRule, sourceProviders map[sources.ID]*catalogs.Provider) (any, sources.ID) {
+func (merger *merger) providerField(providerID catalogs.ProviderID, base *catalogs.Provider, rule fieldRule, sourceProviders map[sources.ID]*catalogs.Provider) (any, sources.ID, string) {
 	// Collect all values from sources
 	values := make(map[sources.ID]any)
 	for source, provider := range sourceProviders {
@@ -418,22 +443,53 @@ func (merger *merger) providerField(rule fieldRule, sourceProviders map[sources.
 	}
 
 	if len(values) > 0 {
+		if valuesDisagree(values) {
+			merger.conflictsDetected++
+		}
 		// Let the strategy decide - it will use authorities if it's AuthorityStrategy
 		// or source priority order if it's SourceOrderStrategy
-		value, source, _ := merger.resolveConflict(rule.resource, rule.authority(), values)
-		return value, source
+		value, source, reason := merger.resolveConflict(rule.resource, rule.authority(), values)
+		var baseValue any
+		if base != nil && merger.resolver != nil {
+			baseValue = merger.providerFieldValue(*base, rule.reflectPath)
+		}
+		if conflict, ok := merger.fieldConflict(rule.resource, rule.authority(), values, baseValue, source); ok {
+			conflict.ResourceID = string(providerID)
+			conflict.Field = rule.provenance()
+			return merger.resolveFieldConflict(conflict, value, source, reason)
+		}
+		return value, source, reason
 	}
 
-	return nil, ""
+	return nil, "", ""
 }
 
 func (merger *merger) resolveConflict(resourceType sources.ResourceType, fieldPath string, values map[sources.ID]any) (any, sources.ID, string) {
-	if resolver, ok := merger.strategy.(resourceConflictResolver); ok {
+	if value, source, reason, ok := merger.resolveObservedConflict(resourceType, fieldPath, values); ok {
+		return value, source, reason
+	}
+	if resolver, ok := merger.strategy.(ResourceConflictResolver); ok {
 		return resolver.ResolveResourceConflict(resourceType, fieldPath, values)
 	}
 	return merger.strategy.ResolveConflict(fieldPath, values)
 }
 
+// resolveObservedConflict lets strategies that weigh observation times pick a
+// value. ok is false when the strategy does not or cannot decide.
+func (merger *merger) resolveObservedConflict(resourceType sources.ResourceType, fieldPath string, values map[sources.ID]any) (any, sources.ID, string, bool) {
+	resolver, ok := merger.strategy.(ObservedConflictResolver)
+	if !ok {
+		return nil, "", "", false
+	}
+	observedAt := make(map[sources.ID]time.Time, len(values))
+	for source := range values {
+		if observation, found := merger.observations[source]; found && !observation.observedAt.IsZero() {
+			observedAt[source] = observation.observedAt
+		}
+	}
+	return resolver.ResolveObservedConflict(resourceType, fieldPath, values, observedAt)
+}
+
 func (merger *merger) recordModelHistory(history *map[string]provenance.Field, rule fieldRule, source sources.ID, value any, reason string) {
 	if history == nil {
 		return
@@ -595,12 +651,14 @@ func (merger *merger) complexModelStructures(merged *catalogs.Model, sourceModel
 		sources.ModelsDevHTTPID,
 		sources.ModelsDevGitID,
 		sources.ProvidersID,
+		sources.RemoteCatalogID,
 	}
 	providerLineagePriorities := []sources.ID{
 		sources.LocalCatalogID,
 		sources.ProvidersID,
 		sources.ModelsDevHTTPID,
 		sources.ModelsDevGitID,
+		sources.RemoteCatalogID,
 	}
 
 	// Merge Limits structure. models.dev is authoritative for subfields it
@@ -616,7 +674,7 @@ func (merger *merger) complexModelStructures(merged *catalogs.Model, sourceModel
 			break
 		}
 	}
-	for _, sourceType := range []sources.ID{sources.ProvidersID, sources.LocalCatalogID} {
+	for _, sourceType := range []sources.ID{sources.ProvidersID, sources.LocalCatalogID, sources.RemoteCatalogID} {
 		if model, exists := sourceModels[sourceType]; exists && model.Limits != nil {
 			if merged.Limits == nil {
 				merged.Limits = &catalogs.ModelLimits{}
@@ -679,7 +737,7 @@ func (merger *merger) complexModelStructures(merged *catalogs.Model, sourceModel
 			}
 		}
 	}
-	for _, sourceType := range []sources.ID{sources.ProvidersID, sources.LocalCatalogID} {
+	for _, sourceType := range []sources.ID{sources.ProvidersID, sources.LocalCatalogID, sources.RemoteCatalogID} {
 		if model, exists := sourceModels[sourceType]; exists && model.Metadata != nil {
 			merged.Metadata = mergeSupplementalMetadata(merged.Metadata, model.Metadata)
 		}
@@ -718,6 +776,8 @@ func (merger *merger) applyCanonicalPricing(merged *catalogs.Model, sourceModels
 	}
 
 	rejected := make([]provenance.Rejection, 0, len(policy.AuthorityOrder))
+	candidates := make(map[sources.ID]any, len(policy.AuthorityOrder))
+	var selected sources.ID
 	for _, sourceType := range policy.AuthorityOrder {
 		model, exists := sourceModels[sourceType]
 		if !exists || model == nil || model.Pricing == nil {
@@ -731,23 +791,42 @@ func (merger *merger) applyCanonicalPricing(merged *catalogs.Model, sourceModels
 			rejected = append(rejected, provenance.Rejection{Source: sourceType, Reason: fmt.Sprintf("pricing is not effective at %s", merger.pricingAt.Format(time.RFC3339))})
 			continue
 		}
+		if selected == "" {
+			selected = sourceType
+		}
+		candidates[sourceType] = model.Pricing
+		if _, observed := merger.strategy.(ObservedConflictResolver); !observed {
+			break
+		}
+	}
+	if selected == "" {
+		return
+	}
 
-		merged.Pricing = copyModelPricing(model.Pricing)
-		reason := fmt.Sprintf("selected complete provider-offering pricing from %s", sourceType)
-		if len(rejected) > 0 {
-			reasons := make([]string, 0, len(rejected))
-			for _, rejection := range rejected {
-				reasons = append(reasons, fmt.Sprintf("%s: %s", rejection.Source, rejection.Reason))
+	reason := fmt.Sprintf("selected complete provider-offering pricing from %s", selected)
+	if len(candidates) > 1 {
+		if _, source, observedReason, ok := merger.resolveObservedConflict(sources.ResourceTypeProviderOffering, "Pricing", candidates); ok {
+			if _, valid := candidates[source]; valid {
+				selected = source
+				reason = fmt.Sprintf("selected provider-offering pricing from %s: %s", selected, observedReason)
 			}
-			reason += fmt.Sprintf(" after rejecting %s", strings.Join(reasons, "; "))
 		}
-		rule := modelProvenanceRule(modelProvenancePricing)
-		merger.recordModelHistory(history, rule, sourceType, model.Pricing, reason)
-		field := (*history)[rule.provenance()]
-		field.Current.Rejections = append([]provenance.Rejection(nil), rejected...)
-		(*history)[rule.provenance()] = field
-		return
 	}
+
+	model := sourceModels[selected]
+	merged.Pricing = copyModelPricing(model.Pricing)
+	if len(rejected) > 0 {
+		reasons := make([]string, 0, len(rejected))
+		for _, rejection := range rejected {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", rejection.Source, rejection.Reason))
+		}
+		reason += fmt.Sprintf(" after rejecting %s", strings.Join(reasons, "; "))
+	}
+	rule := modelProvenanceRule(modelProvenancePricing)
+	merger.recordModelHistory(history, rule, selected, model.Pricing, reason)
+	field := (*history)[rule.provenance()]
+	field.Current.Rejections = append([]provenance.Rejection(nil), rejected...)
+	(*history)[rule.provenance()] = field
 }
 
 type modelLimitFieldSet struct {
@@ -870,6 +949,8 @@ func copyModelPricing(source *catalogs.ModelPricing) *catalogs.ModelPricing {
 	copied.EffectiveUntil = copyValuePtr(source.EffectiveUntil)
 	copied.Tokens = copyModelTokenPricing(source.Tokens)
 	copied.Operations = copyModelOperationPricing(source.Operations)
+	copied.Realtime = copyModelRealtimePricing(source.Realtime)
+	copied.Compute = copyModelComputePricing(source.Compute)
 	copied.Tiers = copyModelPricingTiers(source.Tiers)
 	return &copied
 }
@@ -911,6 +992,7 @@ func copyModelOperationPricing(source *catalogs.ModelOperationPricing) *catalogs
 	copied.ImageInput = copyValuePtr(source.ImageInput)
 	copied.AudioInput = copyValuePtr(source.AudioInput)
 	copied.VideoInput = copyValuePtr(source.VideoInput)
+	copied.DocumentPage = copyValuePtr(source.DocumentPage)
 	copied.ImageGen = copyValuePtr(source.ImageGen)
 	copied.AudioGen = copyValuePtr(source.AudioGen)
 	copied.VideoGen = copyValuePtr(source.VideoGen)
@@ -920,6 +1002,27 @@ func copyModelOperationPricing(source *catalogs.ModelOperationPricing) *catalogs
 	return &copied
 }
 
+func copyModelRealtimePricing(source *catalogs.ModelRealtimePricing) *catalogs.ModelRealtimePricing {
+	if source == nil {
+		return nil
+	}
+	copied := *source
+	copied.AudioInput = copyModelTokenCost(source.AudioInput)
+	copied.AudioOutput = copyModelTokenCost(source.AudioOutput)
+	copied.AudioCacheRead = copyModelTokenCost(source.AudioCacheRead)
+	copied.SessionMinute = copyValuePtr(source.SessionMinute)
+	return &copied
+}
+
+func copyModelComputePricing(source *catalogs.ModelComputePricing) *catalogs.ModelComputePricing {
+	if source == nil {
+		return nil
+	}
+	copied := *source
+	copied.Rates = append([]catalogs.ModelComputeRate(nil), source.Rates...)
+	return &copied
+}
+
 func copyModelPricingTiers(source []catalogs.ModelPricingTier) []catalogs.ModelPricingTier {
 	if source == nil {
 		return nil
@@ -1031,6 +1134,7 @@ func (merger *merger) mergeProviderExtensions(merged *catalogs.Provider, sourceP
 		sources.ModelsDevHTTPID,
 		sources.ModelsDevGitID,
 		sources.ProvidersID,
+		sources.RemoteCatalogID,
 	}
 	protectedExtensionFields := make(sourceExtensionFieldSet)
 	for _, sourceType := range priorities {
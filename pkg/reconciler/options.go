@@ -3,6 +3,7 @@ package reconciler
 import (
 	"github.com/agentstation/starmap/pkg/authority"
 	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/differ"
 	"github.com/agentstation/starmap/pkg/enhancer"
 	"github.com/agentstation/starmap/pkg/errors"
 )
@@ -14,6 +15,9 @@ type options struct {
 	enhancers   []enhancer.Enhancer
 	tracking    bool
 	baseline    *catalogs.Catalog // Existing catalog for comparison
+	diffOptions []differ.Option   // Options for change detection against the baseline
+	resolver    ConflictResolver  // Settles conflicts no field authority covers
+	matching    bool              // Link the same model across providers to one definition
 }
 
 func defaultOptions() *options {
@@ -97,3 +101,31 @@ func WithBaseline(catalog *catalogs.Catalog) Option {
 		return nil
 	}
 }
+
+// WithDiffOptions configures change detection against the baseline, such as
+// the tolerance rules from differ.WithRules.
+func WithDiffOptions(opts ...differ.Option) Option {
+	return func(r *options) error {
+		r.diffOptions = opts
+		return nil
+	}
+}
+
+// WithConflictResolver settles fields on which sources disagree and no field
+// authority covers a source with a value. Without it the strategy's fallback
+// pick is kept.
+func WithConflictResolver(resolver ConflictResolver) Option {
+	return func(r *options) error {
+		r.resolver = resolver
+		return nil
+	}
+}
+
+// WithModelMatching links provider models that MatchModels identifies as the
+// same underlying model to one shared definition after reconciliation.
+func WithModelMatching(enabled bool) Option {
+	return func(r *options) error {
+		r.matching = enabled
+		return nil
+	}
+}
@@ -33,6 +33,9 @@ type Reconciler struct {
 	tracking    bool
 	enhancers   *enhancer.Pipeline
 	baseline    *catalogs.Catalog // Baseline catalog for comparison
+	diffOptions []differ.Option
+	resolver    ConflictResolver
+	matching    bool
 }
 
 // New creates a new Reconciler with options.
@@ -51,6 +54,9 @@ func New(opts ...Option) (*Reconciler, error) {
 		tracking:    options.tracking,
 		enhancers:   enhancer.NewPipeline(options.enhancers...),
 		baseline:    options.baseline,
+		diffOptions: options.diffOptions,
+		resolver:    options.resolver,
+		matching:    options.matching,
 	}
 
 	return r, nil
@@ -110,6 +116,9 @@ func (r *Reconciler) Sources(ctx context.Context, primary sources.ID, srcs []sou
 	if err != nil {
 		return nil, err
 	}
+	if err := rctx.merger.resolverErr; err != nil {
+		return nil, errors.WrapResource("resolve", "conflicts", "", err)
+	}
 
 	// Step 5: Build catalog with providers and models
 	catalog, err := r.catalog(providers, modelResults)
@@ -125,6 +134,17 @@ func (r *Reconciler) Sources(ctx context.Context, primary sources.ID, srcs []sou
 		// Non-fatal - continue with reconciliation
 	}
 
+	// Step 5.6: Link the same model served by several providers to one definition
+	if r.matching {
+		linked, err := LinkModels(catalog)
+		if err != nil {
+			return nil, err
+		}
+		rctx.logger.Info().
+			Int("linked_models", linked).
+			Msg("Linked models across providers")
+	}
+
 	// Step 6: Compute changeset if we have a base catalog
 	changeset := r.changeset(rctx, catalog)
 
@@ -157,6 +177,7 @@ func (r *Reconciler) initialize(ctx context.Context, primary sources.ID, srcs []
 
 	merger := r.createMerger()
 	merger.setObservations(srcs)
+	merger.resolver = r.resolver
 
 	// Create context
 	return &reconcileContext{
@@ -316,7 +337,7 @@ func (r *Reconciler) changeset(rctx *reconcileContext, catalog *catalogs.Builder
 		rctx.logger.Debug().Msg("No baseline provided, using first source catalog")
 	}
 
-	return differ.New().Catalogs(baseCatalog, catalog)
+	return differ.New(r.diffOptions...).Catalogs(baseCatalog, catalog)
 }
 
 // result creates the final result.
@@ -364,6 +385,8 @@ func (r *Reconciler) result(rctx *reconcileContext, catalog *catalogs.Builder, c
 
 	// Calculate statistics
 	result.Metadata.Stats = r.calcStats(catalog, modelResults)
+	result.Metadata.Stats.ConflictsResolved = rctx.merger.conflictsResolved
+	result.Metadata.Stats.ConflictsDetected = rctx.merger.conflictsDetected
 
 	// Finalize result
 	result.Finalize()
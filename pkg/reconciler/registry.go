@@ -0,0 +1,127 @@
+package reconciler
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/agentstation/starmap/pkg/authority"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// StrategyFactory builds a registered strategy. It receives the field
+// authorities so custom strategies can fall back to authority resolution for
+// fields they do not handle themselves.
+type StrategyFactory func(authorities authority.Authority) (Strategy, error)
+
+// DefaultSourceOrder is the precedence used by the registered source-order
+// strategy: live provider APIs, then the local catalog, then models.dev, then
+// a federated remote catalog.
+var DefaultSourceOrder = []sources.ID{
+	sources.ProvidersID,
+	sources.LocalCatalogID,
+	sources.ModelsDevGitID,
+	sources.ModelsDevHTTPID,
+	sources.RemoteCatalogID,
+}
+
+var strategyRegistry = struct {
+	mu        sync.RWMutex
+	factories map[StrategyType]StrategyFactory
+}{
+	factories: map[StrategyType]StrategyFactory{
+		StrategyTypeFieldAuthority: func(authorities authority.Authority) (Strategy, error) {
+			return NewAuthorityStrategy(authorities), nil
+		},
+		StrategyTypeSourceOrder: func(authority.Authority) (Strategy, error) {
+			return NewSourceOrderStrategy(DefaultSourceOrder), nil
+		},
+		StrategyTypeMajorityVote: func(authorities authority.Authority) (Strategy, error) {
+			return NewMajorityVoteStrategy(authorities), nil
+		},
+		StrategyTypeFreshestSource: func(authorities authority.Authority) (Strategy, error) {
+			return NewFreshestSourceStrategy(authorities), nil
+		},
+	},
+}
+
+// RegisterStrategy makes a strategy selectable by name, for example from the
+// reconciliation_strategy configuration key. Registering an existing name
+// replaces it and a nil factory removes it. It returns a restore function
+// intended for tests and temporary integrations.
+//
+//	reconciler.RegisterStrategy("median-price", func(authorities authority.Authority) (reconciler.Strategy, error) {
+//		return newMedianPriceStrategy(authorities), nil
+//	})
+func RegisterStrategy(name StrategyType, factory StrategyFactory) func() {
+	name = StrategyType(strings.TrimSpace(string(name)))
+	strategyRegistry.mu.Lock()
+	previous, existed := strategyRegistry.factories[name]
+	setStrategyFactory(name, factory)
+	strategyRegistry.mu.Unlock()
+
+	return func() {
+		strategyRegistry.mu.Lock()
+		if existed {
+			setStrategyFactory(name, previous)
+		} else {
+			setStrategyFactory(name, nil)
+		}
+		strategyRegistry.mu.Unlock()
+	}
+}
+
+func setStrategyFactory(name StrategyType, factory StrategyFactory) {
+	if factory == nil {
+		delete(strategyRegistry.factories, name)
+		return
+	}
+	strategyRegistry.factories[name] = factory
+}
+
+// NewStrategy builds the strategy registered under name.
+func NewStrategy(name StrategyType, authorities authority.Authority) (Strategy, error) {
+	strategyRegistry.mu.RLock()
+	factory, ok := strategyRegistry.factories[StrategyType(strings.TrimSpace(string(name)))]
+	strategyRegistry.mu.RUnlock()
+	if !ok {
+		return nil, &errors.ValidationError{
+			Field:   "strategy",
+			Value:   name,
+			Message: "is not registered; available: " + strings.Join(strategyNames(), ", "),
+		}
+	}
+	if authorities == nil {
+		authorities = authority.New()
+	}
+	strategy, err := factory(authorities)
+	if err != nil {
+		return nil, errors.WrapResource("create", "reconciliation strategy", string(name), err)
+	}
+	if strategy == nil {
+		return nil, &errors.ValidationError{Field: "strategy", Value: name, Message: "factory returned nil"}
+	}
+	return strategy, nil
+}
+
+// Strategies returns the registered strategy names in sorted order.
+func Strategies() []StrategyType {
+	strategyRegistry.mu.RLock()
+	defer strategyRegistry.mu.RUnlock()
+	names := make([]StrategyType, 0, len(strategyRegistry.factories))
+	for name := range strategyRegistry.factories {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+func strategyNames() []string {
+	names := Strategies()
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = string(name)
+	}
+	return result
+}
@@ -0,0 +1,110 @@
+package reconciler_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/authority"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/reconciler"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// longestNameStrategy prefers the longest model name and defers every other
+// field to field authorities.
+type longestNameStrategy struct {
+	*reconciler.AuthorityStrategy
+}
+
+func (s longestNameStrategy) Type() reconciler.StrategyType { return "longest-name" }
+
+func (s longestNameStrategy) ResolveConflict(field string, values map[sources.ID]any) (any, sources.ID, string) {
+	return s.ResolveResourceConflict(sources.ResourceTypeModel, field, values)
+}
+
+func (s longestNameStrategy) ResolveResourceConflict(resourceType sources.ResourceType, field string, values map[sources.ID]any) (any, sources.ID, string) {
+	if resourceType != sources.ResourceTypeModel || field != "Name" {
+		return s.AuthorityStrategy.ResolveResourceConflict(resourceType, field, values)
+	}
+	var best string
+	var bestSource sources.ID
+	for source, value := range values {
+		name, _ := value.(string)
+		if len(name) > len(best) || (len(name) == len(best) && source < bestSource) {
+			best, bestSource = name, source
+		}
+	}
+	return best, bestSource, "selected longest name"
+}
+
+func TestRegisterStrategySelectsCustomConflictResolution(t *testing.T) {
+	restore := reconciler.RegisterStrategy("longest-name", func(authorities authority.Authority) (reconciler.Strategy, error) {
+		return longestNameStrategy{reconciler.NewAuthorityStrategy(authorities)}, nil
+	})
+
+	primary := catalogs.NewEmpty()
+	if err := addTestModels(primary, "test-provider", []*catalogs.Model{createTestModel("gpt-4", "GPT-4", 8192)}); err != nil {
+		t.Fatalf("add primary models: %v", err)
+	}
+	secondary := catalogs.NewEmpty()
+	if err := addTestModels(secondary, "test-provider", []*catalogs.Model{createTestModel("gpt-4", "GPT-4 Turbo Preview", 8192)}); err != nil {
+		t.Fatalf("add secondary models: %v", err)
+	}
+	srcs := reconciler.ConvertCatalogsMapToSources(map[sources.ID]*catalogs.Builder{"source1": primary, "source2": secondary})
+
+	resolveName := func(name reconciler.StrategyType) string {
+		t.Helper()
+		strategy, err := reconciler.NewStrategy(name, nil)
+		if err != nil {
+			t.Fatalf("NewStrategy(%q): %v", name, err)
+		}
+		reconcile, err := reconciler.New(reconciler.WithStrategy(strategy))
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		result, err := reconcile.Sources(context.Background(), "source1", srcs)
+		if err != nil {
+			t.Fatalf("Sources: %v", err)
+		}
+		model, err := result.Catalog.FindModel("gpt-4")
+		if err != nil {
+			t.Fatalf("FindModel: %v", err)
+		}
+		return model.Name
+	}
+
+	if got := resolveName("longest-name"); got != "GPT-4 Turbo Preview" {
+		t.Fatalf("longest-name selected %q", got)
+	}
+	if got := resolveName(reconciler.StrategyTypeFieldAuthority); got != "GPT-4" {
+		t.Fatalf("field-authority selected %q", got)
+	}
+
+	restore()
+	if _, err := reconciler.NewStrategy("longest-name", nil); err == nil {
+		t.Fatal("restore did not unregister longest-name")
+	}
+}
+
+func TestNewStrategyBuiltinsAndUnknownName(t *testing.T) {
+	for _, name := range []reconciler.StrategyType{
+		reconciler.StrategyTypeFieldAuthority,
+		reconciler.StrategyTypeSourceOrder,
+		reconciler.StrategyTypeMajorityVote,
+		reconciler.StrategyTypeFreshestSource,
+	} {
+		strategy, err := reconciler.NewStrategy(name, nil)
+		if err != nil {
+			t.Fatalf("NewStrategy(%q): %v", name, err)
+		}
+		if strategy.Type() != name {
+			t.Fatalf("NewStrategy(%q).Type() = %q", name, strategy.Type())
+		}
+	}
+
+	_, err := reconciler.NewStrategy("median-price", nil)
+	if err == nil || !strings.Contains(err.Error(), string(reconciler.StrategyTypeSourceOrder)) {
+		t.Fatalf("unknown strategy error = %v, want available names", err)
+	}
+}
@@ -60,7 +60,8 @@ type ResultMetadata struct {
 type ResultStatistics struct {
 	ModelsProcessed    int
 	ProvidersProcessed int
-	ConflictsResolved  int
+	ConflictsResolved  int // Conflicts settled by a ConflictResolver
+	ConflictsDetected  int // Fields whose sources provided distinct values
 	ResourcesSkipped   int
 	TotalTimeMs        int64
 }
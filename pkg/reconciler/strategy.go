@@ -2,8 +2,10 @@ package reconciler
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/agentstation/starmap/pkg/authority"
 	"github.com/agentstation/starmap/pkg/differ"
@@ -37,9 +39,14 @@ const (
 	StrategyTypeFieldAuthority StrategyType = "field-authority"
 	// StrategyTypeSourceOrder uses source ordering to resolve conflicts.
 	StrategyTypeSourceOrder StrategyType = "source-order"
+	// StrategyTypeMajorityVote resolves scalar fields by majority vote.
+	StrategyTypeMajorityVote StrategyType = "majority-vote"
+	// StrategyTypeFreshestSource prefers the most recently observed source.
+	StrategyTypeFreshestSource StrategyType = "freshest-source"
 )
 
-// Strategy defines how reconciliation should be performed.
+// Strategy defines how reconciliation should be performed. Custom strategies
+// are made selectable by name with RegisterStrategy.
 type Strategy interface {
 	// Type returns the strategy type
 	Type() StrategyType
@@ -60,10 +67,23 @@ type Strategy interface {
 	ApplyStrategy() differ.ApplyStrategy
 }
 
-type resourceConflictResolver interface {
+// ResourceConflictResolver is implemented by strategies that resolve a field
+// differently depending on whether it belongs to a model, provider, or author.
+// When a strategy implements it, the merger calls ResolveResourceConflict
+// instead of ResolveConflict.
+type ResourceConflictResolver interface {
 	ResolveResourceConflict(resourceType sources.ResourceType, field string, values map[sources.ID]any) (any, sources.ID, string)
 }
 
+// ObservedConflictResolver is implemented by strategies that weigh values by
+// when each source was observed. The merger consults it first, including for
+// provider offering pricing, and passes the observation time of every source
+// that has one. It returns ok false when observation times do not decide the
+// conflict; the merger then resolves the field as usual.
+type ObservedConflictResolver interface {
+	ResolveObservedConflict(resourceType sources.ResourceType, field string, values map[sources.ID]any, observedAt map[sources.ID]time.Time) (value any, source sources.ID, reason string, ok bool)
+}
+
 // baseStrategy provides common strategy functionality.
 type baseStrategy struct {
 	typ            StrategyType
@@ -184,16 +204,7 @@ func (s *AuthorityStrategy) ResolveResourceConflict(resourceType sources.Resourc
 }
 
 func (s *AuthorityStrategy) authoritiesFor(resourceType sources.ResourceType) []authority.Field {
-	switch resourceType {
-	case sources.ResourceTypeModel:
-		return s.authorities.ModelFields()
-	case sources.ResourceTypeProvider:
-		return s.authorities.ProviderFields()
-	case sources.ResourceTypeAuthor:
-		return s.authorities.AuthorFields()
-	default:
-		return nil
-	}
+	return authorityFields(s.authorities, resourceType)
 }
 
 // SourceOrderStrategy resolves conflicts using a fixed source precedence order.
@@ -258,6 +269,133 @@ func sortedValueSources(values map[sources.ID]any) []sources.ID {
 	for source := range values {
 		ids = append(ids, source)
 	}
-	sort.Slice(ids, func(i, j int) bool { return string(ids[i]) < string(ids[j]) })
+	sort.Slice(ids, func(i, j int) bool {
+		// A federated remote catalog only fills fields no other source provides.
+		if (ids[i] == sources.RemoteCatalogID) != (ids[j] == sources.RemoteCatalogID) {
+			return ids[j] == sources.RemoteCatalogID
+		}
+		return string(ids[i]) < string(ids[j])
+	})
 	return ids
 }
+
+// MajorityVoteStrategy resolves scalar fields by majority vote when at least
+// three sources provide a value: a value held by more than half of those
+// sources wins, and field authorities pick among the sources that agree.
+// Fields without a majority, and non-scalar fields, use field authorities.
+type MajorityVoteStrategy struct {
+	*AuthorityStrategy
+}
+
+// MinMajorityVoters is the fewest sources with a value that trigger a vote.
+const MinMajorityVoters = 3
+
+// NewMajorityVoteStrategy creates a majority vote strategy that falls back to
+// authorities.
+func NewMajorityVoteStrategy(authorities authority.Authority) *MajorityVoteStrategy {
+	strategy := &MajorityVoteStrategy{AuthorityStrategy: NewAuthorityStrategy(authorities)}
+	strategy.typ = StrategyTypeMajorityVote
+	strategy.description = fmt.Sprintf("Resolves scalar fields by majority vote across %d or more sources, falling back to field authority priorities", MinMajorityVoters)
+	return strategy
+}
+
+// ResolveConflict resolves a model field by majority vote.
+func (s *MajorityVoteStrategy) ResolveConflict(field string, values map[sources.ID]any) (any, sources.ID, string) {
+	return s.ResolveResourceConflict(sources.ResourceTypeModel, field, values)
+}
+
+// ResolveResourceConflict resolves a field by majority vote, or by authorities
+// when no value holds a majority.
+func (s *MajorityVoteStrategy) ResolveResourceConflict(resourceType sources.ResourceType, field string, values map[sources.ID]any) (any, sources.ID, string) {
+	votes := make(map[string][]sources.ID)
+	voters := 0
+	for source, value := range values {
+		key, ok := scalarVoteKey(value)
+		if !ok {
+			return s.AuthorityStrategy.ResolveResourceConflict(resourceType, field, values)
+		}
+		if key == "" {
+			continue
+		}
+		votes[key] = append(votes[key], source)
+		voters++
+	}
+	if voters < MinMajorityVoters {
+		return s.AuthorityStrategy.ResolveResourceConflict(resourceType, field, values)
+	}
+	for _, agreeing := range votes {
+		if 2*len(agreeing) <= voters {
+			continue
+		}
+		winners := make(map[sources.ID]any, len(agreeing))
+		for _, source := range agreeing {
+			winners[source] = values[source]
+		}
+		value, source, _ := s.AuthorityStrategy.ResolveResourceConflict(resourceType, field, winners)
+		return value, source, fmt.Sprintf("selected by majority vote (%d of %d sources)", len(agreeing), voters)
+	}
+	return s.AuthorityStrategy.ResolveResourceConflict(resourceType, field, values)
+}
+
+// scalarVoteKey returns a comparison key for scalar values. Empty values
+// return "" and do not vote; non-scalar values return ok false.
+func scalarVoteKey(value any) (string, bool) {
+	if value == nil {
+		return "", true
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.String:
+		if reflect.ValueOf(value).String() == "" {
+			return "", true
+		}
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+	default:
+		return "", false
+	}
+	return fmt.Sprintf("%T:%v", value, value), true
+}
+
+// FreshestSourceStrategy prefers the value from the most recently observed
+// source, using each source's observation time. It also selects provider
+// offering pricing, where sources frequently disagree. When the latest
+// observation time is unknown or shared by several sources, field authorities
+// decide.
+type FreshestSourceStrategy struct {
+	*AuthorityStrategy
+}
+
+// NewFreshestSourceStrategy creates a freshest-source strategy that falls back
+// to authorities.
+func NewFreshestSourceStrategy(authorities authority.Authority) *FreshestSourceStrategy {
+	strategy := &FreshestSourceStrategy{AuthorityStrategy: NewAuthorityStrategy(authorities)}
+	strategy.typ = StrategyTypeFreshestSource
+	strategy.description = "Resolves conflicts using the most recently observed source, falling back to field authority priorities"
+	return strategy
+}
+
+// ResolveObservedConflict selects the non-empty value from the source with
+// the latest observation time.
+func (s *FreshestSourceStrategy) ResolveObservedConflict(_ sources.ResourceType, _ string, values map[sources.ID]any, observedAt map[sources.ID]time.Time) (any, sources.ID, string, bool) {
+	var freshest sources.ID
+	var latest time.Time
+	tied := false
+	for source, value := range values {
+		if value == nil || value == "" {
+			continue
+		}
+		at := observedAt[source]
+		switch {
+		case at.After(latest):
+			freshest, latest, tied = source, at, false
+		case !at.IsZero() && at.Equal(latest):
+			tied = true
+		}
+	}
+	if freshest == "" || tied {
+		return nil, "", "", false
+	}
+	return values[freshest], freshest, fmt.Sprintf("selected freshest source (observed %s)", latest.UTC().Format(time.RFC3339)), true
+}
@@ -0,0 +1,157 @@
+package reconciler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/authority"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/reconciler"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+func TestMajorityVoteStrategy(t *testing.T) {
+	strategy := reconciler.NewMajorityVoteStrategy(authority.New())
+
+	tests := []struct {
+		name       string
+		values     map[sources.ID]any
+		wantValue  any
+		wantSource sources.ID
+	}{
+		{
+			name: "majority outvotes authority",
+			values: map[sources.ID]any{
+				sources.ProvidersID:     "GPT 4o",
+				sources.ModelsDevGitID:  "GPT-4o",
+				sources.ModelsDevHTTPID: "GPT-4o",
+			},
+			wantValue: "GPT-4o",
+		},
+		{
+			name: "empty values do not vote",
+			values: map[sources.ID]any{
+				sources.ProvidersID:     "GPT-4o",
+				sources.ModelsDevGitID:  "",
+				sources.ModelsDevHTTPID: "GPT 4o",
+			},
+			wantValue:  "GPT-4o",
+			wantSource: sources.ProvidersID,
+		},
+		{
+			name: "no majority falls back to authority",
+			values: map[sources.ID]any{
+				sources.ProvidersID:     "a",
+				sources.ModelsDevGitID:  "b",
+				sources.ModelsDevHTTPID: "c",
+				sources.LocalCatalogID:  "d",
+			},
+			wantValue:  "a",
+			wantSource: sources.ProvidersID,
+		},
+		{
+			name: "non-scalar values fall back to authority",
+			values: map[sources.ID]any{
+				sources.ProvidersID:     []string{"a"},
+				sources.ModelsDevGitID:  []string{"b"},
+				sources.ModelsDevHTTPID: []string{"b"},
+			},
+			wantSource: sources.ProvidersID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, source, _ := strategy.ResolveResourceConflict(sources.ResourceTypeModel, "Name", tt.values)
+			if tt.wantValue != nil && value != tt.wantValue {
+				t.Fatalf("value = %v, want %v", value, tt.wantValue)
+			}
+			if tt.wantSource != "" && source != tt.wantSource {
+				t.Fatalf("source = %q, want %q", source, tt.wantSource)
+			}
+			if tt.values[source] != nil && tt.wantValue != nil && tt.values[source] != value {
+				t.Fatalf("source %q does not hold value %v", source, value)
+			}
+		})
+	}
+}
+
+func TestFreshestSourceStrategyResolveObservedConflict(t *testing.T) {
+	strategy := reconciler.NewFreshestSourceStrategy(authority.New())
+	observed := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	values := map[sources.ID]any{
+		sources.ProvidersID:     "old",
+		sources.ModelsDevHTTPID: "new",
+		sources.LocalCatalogID:  "",
+	}
+
+	value, source, _, ok := strategy.ResolveObservedConflict(sources.ResourceTypeModel, "Name", values, map[sources.ID]time.Time{
+		sources.ProvidersID:     observed,
+		sources.ModelsDevHTTPID: observed.Add(time.Hour),
+		sources.LocalCatalogID:  observed.Add(2 * time.Hour),
+	})
+	if !ok || value != "new" || source != sources.ModelsDevHTTPID {
+		t.Fatalf("freshest = (%v, %q, %v), want models.dev value", value, source, ok)
+	}
+
+	if _, _, _, ok := strategy.ResolveObservedConflict(sources.ResourceTypeModel, "Name", values, map[sources.ID]time.Time{
+		sources.ProvidersID:     observed,
+		sources.ModelsDevHTTPID: observed,
+	}); ok {
+		t.Fatal("tied observation times decided the conflict")
+	}
+	if _, _, _, ok := strategy.ResolveObservedConflict(sources.ResourceTypeModel, "Name", values, nil); ok {
+		t.Fatal("unknown observation times decided the conflict")
+	}
+}
+
+func TestFreshestSourceStrategySelectsFreshestPricing(t *testing.T) {
+	observedAt := time.Date(2026, time.October, 1, 12, 0, 0, 0, time.UTC)
+	observation := func(id sources.ID, at time.Time, inputPrice float64) sources.Observation {
+		t.Helper()
+		builder := catalogs.NewEmpty()
+		if err := addModelsToProvider(builder, "test-provider", []*catalogs.Model{{
+			ID: "model-1", Name: "Model", Pricing: &catalogs.ModelPricing{
+				Currency: catalogs.ModelPricingCurrencyUSD,
+				Tokens:   &catalogs.ModelTokenPricing{Input: &catalogs.ModelTokenCost{Per1M: inputPrice}},
+			},
+		}}); err != nil {
+			t.Fatalf("add %s model: %v", id, err)
+		}
+		observation := reconciler.NewMockSource(id, builder)
+		observation.ObservedAt = at
+		return observation
+	}
+	observations := []sources.Observation{
+		observation(sources.ProvidersID, observedAt, 1),
+		observation(sources.ModelsDevHTTPID, observedAt.Add(time.Hour), 2),
+	}
+
+	inputPrice := func(name reconciler.StrategyType) float64 {
+		t.Helper()
+		strategy, err := reconciler.NewStrategy(name, nil)
+		if err != nil {
+			t.Fatalf("NewStrategy(%q): %v", name, err)
+		}
+		reconcile, err := reconciler.New(reconciler.WithStrategy(strategy))
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		result, err := reconcile.Sources(context.Background(), sources.ProvidersID, observations)
+		if err != nil {
+			t.Fatalf("Sources: %v", err)
+		}
+		model, err := result.Catalog.FindModel("model-1")
+		if err != nil {
+			t.Fatalf("FindModel: %v", err)
+		}
+		return model.Pricing.Tokens.Input.Per1M
+	}
+
+	if got := inputPrice(reconciler.StrategyTypeFieldAuthority); got != 1 {
+		t.Fatalf("field-authority input price = %v, want provider price", got)
+	}
+	if got := inputPrice(reconciler.StrategyTypeFreshestSource); got != 2 {
+		t.Fatalf("freshest-source input price = %v, want freshest price", got)
+	}
+}
@@ -0,0 +1,147 @@
+package sourcepayload
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// KeyPaths returns the sorted set of JSON member paths observed in data.
+// Paths use the same notation as UnknownJSONFields: object members are joined
+// with "." and array elements are collapsed into "[]", so
+// {"data":[{"id":"a"}]} yields ["data", "data[].id"]. Values are never retained.
+func KeyPaths(data []byte) ([]string, error) {
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, errors.WrapParse("json", "key-path inspection", err)
+	}
+	seen := make(map[string]struct{})
+	collectKeyPaths(decoded, "", seen)
+	return sortedPaths(seen), nil
+}
+
+func collectKeyPaths(value any, prefix string, seen map[string]struct{}) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for name, member := range typed {
+			path := name
+			if prefix != "" {
+				path = prefix + "." + name
+			}
+			seen[path] = struct{}{}
+			collectKeyPaths(member, path, seen)
+		}
+	case []any:
+		for _, element := range typed {
+			collectKeyPaths(element, prefix+"[]", seen)
+		}
+	}
+}
+
+func sortedPaths(seen map[string]struct{}) []string {
+	paths := make([]string, 0, len(seen))
+	for path := range seen {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// ShapeDrift describes key paths that appeared or disappeared between two
+// observations of the same upstream response.
+type ShapeDrift struct {
+	Added   []string `json:"added,omitempty" yaml:"added,omitempty"`
+	Removed []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+}
+
+// HasDrift reports whether any key path was added or removed.
+func (d ShapeDrift) HasDrift() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0
+}
+
+// CompareKeyPaths returns the structural difference from previous to current.
+func CompareKeyPaths(previous, current []string) ShapeDrift {
+	before := make(map[string]struct{}, len(previous))
+	for _, path := range previous {
+		before[path] = struct{}{}
+	}
+	after := make(map[string]struct{}, len(current))
+	for _, path := range current {
+		after[path] = struct{}{}
+	}
+
+	var drift ShapeDrift
+	for path := range after {
+		if _, exists := before[path]; !exists {
+			drift.Added = append(drift.Added, path)
+		}
+	}
+	for path := range before {
+		if _, exists := after[path]; !exists {
+			drift.Removed = append(drift.Removed, path)
+		}
+	}
+	slices.Sort(drift.Added)
+	slices.Sort(drift.Removed)
+	return drift
+}
+
+// ShapeRecorder accumulates key paths across every response decoded while it
+// is attached to a request context. Paginated listings therefore produce the
+// union of all page shapes.
+//
+// Thread Safety: ShapeRecorder is safe for concurrent use.
+type ShapeRecorder struct {
+	mu    sync.Mutex
+	paths map[string]struct{}
+}
+
+// NewShapeRecorder returns an empty recorder.
+func NewShapeRecorder() *ShapeRecorder {
+	return &ShapeRecorder{paths: make(map[string]struct{})}
+}
+
+// Record adds the key paths of one JSON payload.
+func (r *ShapeRecorder) Record(data []byte) error {
+	paths, err := KeyPaths(data)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, path := range paths {
+		r.paths[path] = struct{}{}
+	}
+	return nil
+}
+
+// Paths returns the caller-owned sorted key paths recorded so far, or nil when
+// no payload has been recorded.
+func (r *ShapeRecorder) Paths() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.paths) == 0 {
+		return nil
+	}
+	return sortedPaths(r.paths)
+}
+
+type shapeRecorderKey struct{}
+
+// WithShapeRecorder attaches recorder to ctx so response decoders can report
+// the structure of the payloads they accept.
+func WithShapeRecorder(ctx context.Context, recorder *ShapeRecorder) context.Context {
+	return context.WithValue(ctx, shapeRecorderKey{}, recorder)
+}
+
+// ShapeRecorderFromContext returns the recorder attached to ctx, if any.
+func ShapeRecorderFromContext(ctx context.Context) *ShapeRecorder {
+	if ctx == nil {
+		return nil
+	}
+	recorder, _ := ctx.Value(shapeRecorderKey{}).(*ShapeRecorder)
+	return recorder
+}
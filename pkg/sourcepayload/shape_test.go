@@ -0,0 +1,62 @@
+package sourcepayload
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestKeyPathsCollapseArraysAndOmitValues(t *testing.T) {
+	data := []byte(`{"object":"list","data":[{"id":"a","pricing":{"input":"1"}},{"id":"b","created":1}]}`)
+	paths, err := KeyPaths(data)
+	if err != nil {
+		t.Fatalf("KeyPaths: %v", err)
+	}
+	want := []string{"data", "data[].created", "data[].id", "data[].pricing", "data[].pricing.input", "object"}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("KeyPaths = %v, want %v", paths, want)
+	}
+	if _, err := KeyPaths([]byte(`{`)); err == nil {
+		t.Fatal("KeyPaths accepted malformed JSON")
+	}
+}
+
+func TestCompareKeyPathsReportsAddedAndRemoved(t *testing.T) {
+	drift := CompareKeyPaths(
+		[]string{"data", "data[].id", "data[].owned_by"},
+		[]string{"data", "data[].id", "data[].context_window"},
+	)
+	if !drift.HasDrift() {
+		t.Fatal("expected drift")
+	}
+	if !slices.Equal(drift.Added, []string{"data[].context_window"}) || !slices.Equal(drift.Removed, []string{"data[].owned_by"}) {
+		t.Fatalf("drift = %#v", drift)
+	}
+	if CompareKeyPaths([]string{"a"}, []string{"a"}).HasDrift() {
+		t.Fatal("identical shapes reported drift")
+	}
+}
+
+func TestShapeRecorderUnionsPagesThroughContext(t *testing.T) {
+	recorder := NewShapeRecorder()
+	ctx := WithShapeRecorder(context.Background(), recorder)
+	if ShapeRecorderFromContext(context.Background()) != nil {
+		t.Fatal("unexpected recorder on bare context")
+	}
+	attached := ShapeRecorderFromContext(ctx)
+	if attached != recorder {
+		t.Fatal("recorder was not attached")
+	}
+	if recorder.Paths() != nil {
+		t.Fatal("empty recorder returned paths")
+	}
+	for _, page := range []string{`{"models":[{"name":"a"}],"nextPageToken":"x"}`, `{"models":[{"name":"b"}]}`} {
+		if err := attached.Record([]byte(page)); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	want := []string{"models", "models[].name", "nextPageToken"}
+	if got := recorder.Paths(); !slices.Equal(got, want) {
+		t.Fatalf("Paths = %v, want %v", got, want)
+	}
+}
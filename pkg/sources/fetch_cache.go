@@ -0,0 +1,123 @@
+package sources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+// FetchCache keeps the models fetched from provider APIs on disk, so commands
+// run within its time-to-live share one fetch per provider. Entries are keyed
+// by provider ID and a hash of the provider's API configuration and
+// credentials; credentials themselves are never written.
+type FetchCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// cachedFetch is one provider's cached models.
+type cachedFetch struct {
+	ProviderID catalogs.ProviderID `json:"provider_id"`
+	Key        string              `json:"key"`
+	FetchedAt  time.Time           `json:"fetched_at"`
+	Models     []catalogs.Model    `json:"models"`
+}
+
+// NewFetchCache returns a cache that stores fetches under dir and reuses them
+// for ttl.
+func NewFetchCache(dir string, ttl time.Duration) *FetchCache {
+	return &FetchCache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// FetchCacheKey returns the hash identifying the models a provider API
+// returns: it covers the catalog endpoint, authentication settings, and the
+// loaded API key and environment variable values, so a changed endpoint or
+// credential never reads another configuration's models.
+func FetchCacheKey(provider *catalogs.Provider) string {
+	apiKey, _ := provider.APIKeyValue()
+	envNames := slices.Sorted(maps.Keys(provider.EnvVarValues))
+	envValues := make([]string, 0, len(envNames)*2)
+	for _, name := range envNames {
+		envValues = append(envValues, name, provider.EnvVarValues[name])
+	}
+	data, _ := json.Marshal(struct {
+		ID        catalogs.ProviderID       `json:"id"`
+		Catalog   *catalogs.ProviderCatalog `json:"catalog"`
+		APIKey    *catalogs.ProviderAPIKey  `json:"api_key"`
+		Key       string                    `json:"key"`
+		EnvValues []string                  `json:"env_values"`
+	}{provider.ID, provider.Catalog, provider.APIKey, apiKey, envValues})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *FetchCache) providerDir(providerID catalogs.ProviderID) string {
+	return filepath.Join(c.dir, string(providerID))
+}
+
+func (c *FetchCache) path(providerID catalogs.ProviderID, key string) string {
+	return filepath.Join(c.providerDir(providerID), key+".json")
+}
+
+// Load returns the cached models for provider, or false when there are none
+// for its current configuration or they are older than the cache's TTL.
+func (c *FetchCache) Load(provider *catalogs.Provider) ([]catalogs.Model, bool, error) {
+	key := FetchCacheKey(provider)
+	path := c.path(provider.ID, key)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.WrapIO("read", path, err)
+	}
+	var fetch cachedFetch
+	if err := json.Unmarshal(data, &fetch); err != nil {
+		return nil, false, errors.WrapParse("json", path, err)
+	}
+	if fetch.ProviderID != provider.ID || fetch.Key != key || c.now().Sub(fetch.FetchedAt) > c.ttl {
+		return nil, false, nil
+	}
+	return fetch.Models, true, nil
+}
+
+// Store replaces the cached models for provider, removing entries kept for
+// its earlier configurations.
+func (c *FetchCache) Store(provider *catalogs.Provider, models []catalogs.Model) error {
+	dir := c.providerDir(provider.ID)
+	if err := os.RemoveAll(dir); err != nil {
+		return errors.WrapIO("remove", dir, err)
+	}
+	if err := os.MkdirAll(dir, constants.DirPermissions); err != nil {
+		return errors.WrapIO("create", dir, err)
+	}
+	key := FetchCacheKey(provider)
+	data, err := json.Marshal(cachedFetch{
+		ProviderID: provider.ID,
+		Key:        key,
+		FetchedAt:  c.now(),
+		Models:     models,
+	})
+	if err != nil {
+		return errors.WrapParse("json", "provider fetch cache", err)
+	}
+	path := c.path(provider.ID, key)
+	temporary := path + ".tmp"
+	if err := os.WriteFile(temporary, data, constants.FilePermissions); err != nil {
+		return errors.WrapIO("write", temporary, err)
+	}
+	if err := os.Rename(temporary, path); err != nil {
+		return errors.WrapIO("rename", path, err)
+	}
+	return nil
+}
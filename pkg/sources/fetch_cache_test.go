@@ -0,0 +1,66 @@
+package sources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+)
+
+func TestProviderFetcherFetchCacheSharesFetches(t *testing.T) {
+	provider := providerForFetcherTest("provider-a")
+	calls := 0
+	factory := WithProviderClientFactory(func(*catalogs.Provider) (ProviderClient, error) {
+		calls++
+		return providerFetcherTestClient{models: []catalogs.Model{{ID: "model-a", Name: "Model A"}}}, nil
+	})
+	cache := NewFetchCache(t.TempDir(), time.Hour)
+
+	// A fresh fetch, as by models list --fresh, fills the cache for a later sync.
+	fresh := NewProviderFetcher(newFetcherProviderSet(provider), factory, WithFetchCache(cache), WithFreshFetch())
+	if _, err := fresh.FetchModels(context.Background(), &provider); err != nil {
+		t.Fatalf("fresh FetchModels failed: %v", err)
+	}
+	cached := NewProviderFetcher(newFetcherProviderSet(provider), factory, WithFetchCache(cache))
+	models, err := cached.FetchModels(context.Background(), &provider)
+	if err != nil {
+		t.Fatalf("cached FetchModels failed: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("provider API called %d times, want 1", calls)
+	}
+	if len(models) != 1 || models[0].ID != "model-a" {
+		t.Errorf("cached models = %#v, want model-a", models)
+	}
+
+	// A changed endpoint is a different cache key.
+	moved := provider
+	moved.Catalog = &catalogs.ProviderCatalog{Endpoint: provider.Catalog.Endpoint}
+	moved.Catalog.Endpoint.URL = "https://other.test/models"
+	if _, err := cached.FetchModels(context.Background(), &moved); err != nil {
+		t.Fatalf("FetchModels for moved endpoint failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("provider API called %d times after endpoint change, want 2", calls)
+	}
+}
+
+func TestFetchCacheExpires(t *testing.T) {
+	provider := providerForFetcherTest("provider-a")
+	cache := NewFetchCache(t.TempDir(), time.Minute)
+	fetchedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return fetchedAt }
+	if err := cache.Store(&provider, []catalogs.Model{{ID: "model-a"}}); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	cache.now = func() time.Time { return fetchedAt.Add(30 * time.Second) }
+	if _, ok, err := cache.Load(&provider); err != nil || !ok {
+		t.Fatalf("Load within TTL = %v, %v; want a hit", ok, err)
+	}
+	cache.now = func() time.Time { return fetchedAt.Add(2 * time.Minute) }
+	if _, ok, err := cache.Load(&provider); err != nil || ok {
+		t.Fatalf("Load after TTL = %v, %v; want a miss", ok, err)
+	}
+}
@@ -79,6 +79,7 @@ const (
 	ObservationIssueCodeMissingCredentials = catalogmeta.ObservationIssueCodeMissingCredentials
 	ObservationIssueCodeConfiguration      = catalogmeta.ObservationIssueCodeConfiguration
 	ObservationIssueCodeFetchFailed        = catalogmeta.ObservationIssueCodeFetchFailed
+	ObservationIssueCodePartialListing     = catalogmeta.ObservationIssueCodePartialListing
 	ObservationIssueCodeStaleFallback      = catalogmeta.ObservationIssueCodeStaleFallback
 	ObservationIssueCodeBootstrapFallback  = catalogmeta.ObservationIssueCodeBootstrapFallback
 )
@@ -290,6 +291,7 @@ func validateObservationIssue(index int, issue ObservationIssue) error {
 		ObservationIssueCodeMissingCredentials,
 		ObservationIssueCodeConfiguration,
 		ObservationIssueCodeFetchFailed,
+		ObservationIssueCodePartialListing,
 		ObservationIssueCodeStaleFallback,
 		ObservationIssueCodeBootstrapFallback:
 	default:
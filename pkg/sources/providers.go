@@ -3,14 +3,18 @@ package sources
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/agentstation/starmap/internal/providers/clients"
+	"github.com/agentstation/starmap/internal/transport"
 	"github.com/agentstation/starmap/pkg/catalogs"
 	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/logging"
 )
 
 // ProviderClient fetches model information from a provider API.
@@ -20,6 +24,14 @@ type ProviderClient interface {
 	HasAPIKey() bool
 }
 
+// PricingFetcher is an optional interface for provider clients that can fetch
+// live prices, keyed by model ID. FetchModels uses it to price models the
+// listing returned without prices; those prices carry the providers source's
+// pricing authority, above models.dev and other third-party data.
+type PricingFetcher interface {
+	FetchPricing(ctx context.Context) (map[string]*catalogs.ModelPricing, error)
+}
+
 // ProviderClientFactory creates provider API clients.
 type ProviderClientFactory func(*catalogs.Provider) (ProviderClient, error)
 
@@ -54,6 +66,9 @@ type providerOptions struct {
 	timeout         time.Duration // Context timeout for operations
 	clientFactory   ProviderClientFactory
 	rawFetcher      ProviderRawFetcher
+	cache           *FetchCache          // Reuse fetched models across commands
+	httpCache       *transport.HTTPCache // Reuse unchanged provider API responses
+	fresh           bool                 // Bypass cached models, refreshing the cache
 }
 
 func (po *providerOptions) apply(opts ...ProviderOption) *providerOptions {
@@ -156,6 +171,7 @@ type FetchStats struct {
 	AuthMethod   string        // How authentication was applied (Header, Query, None)
 	AuthLocation string        // Where auth was placed (header name or query param name)
 	AuthScheme   string        // Authentication scheme for header auth (Bearer, Basic, Direct)
+	Cache        string        // HTTP cache result (hit, revalidated); empty when downloaded
 }
 
 // HumanSize returns the payload size in human-readable format.
@@ -309,8 +325,36 @@ func WithProviderRawFetcher(fetcher ProviderRawFetcher) ProviderOption {
 	}
 }
 
+// WithFetchCache reuses models fetched within the cache's TTL instead of
+// calling the provider API again, and caches every successful fetch.
+func WithFetchCache(cache *FetchCache) ProviderOption {
+	return func(o *providerOptions) {
+		o.cache = cache
+	}
+}
+
+// WithHTTPCache stores provider API responses under dir. Responses stored
+// within ttl are reused without a request; older ones are revalidated with
+// their ETag and Last-Modified, so unchanged payloads are not downloaded again.
+func WithHTTPCache(dir string, ttl time.Duration) ProviderOption {
+	return func(o *providerOptions) {
+		o.httpCache = transport.NewHTTPCache(dir, ttl)
+	}
+}
+
+// WithFreshFetch always calls the provider API, ignoring cached models.
+// The fetched models still refresh the cache configured with WithFetchCache,
+// and responses in the cache configured with WithHTTPCache are revalidated.
+func WithFreshFetch() ProviderOption {
+	return func(o *providerOptions) {
+		o.fresh = true
+	}
+}
+
 // FetchModels fetches available models from a single provider's API.
 // It handles credential loading, client creation, and API communication.
+// When the provider's listing stops before its last page, the models listed
+// so far are returned with an error wrapping *errors.PartialListError.
 //
 // Example:
 //
@@ -330,6 +374,13 @@ func (pf *ProviderFetcher) FetchModels(ctx context.Context, provider *catalogs.P
 	}
 	defer cancel()
 
+	if options.cache != nil && !options.fresh {
+		// An unreadable cache entry is treated as a miss.
+		if models, ok, err := options.cache.Load(provider); err == nil && ok {
+			return models, nil
+		}
+	}
+
 	// Get client from providers
 	if options.clientFactory == nil {
 		return nil, &errors.ConfigError{
@@ -346,15 +397,89 @@ func (pf *ProviderFetcher) FetchModels(ctx context.Context, provider *catalogs.P
 	// Fetch models from API
 	models, err := client.ListModels(ctx)
 	if err != nil {
-		return nil, &errors.SyncError{
+		// A partial listing returns its models with the error and is not cached
+		var partial *errors.PartialListError
+		if !stderrors.As(err, &partial) || len(models) == 0 {
+			models = nil
+		}
+		applyLivePricing(ctx, client, models)
+		return models, &errors.SyncError{
 			Provider: string(provider.ID),
 			Err:      err,
 		}
 	}
 
+	applyLivePricing(ctx, client, models)
+
+	if options.cache != nil {
+		// A cache that cannot be written does not fail the fetch.
+		_ = options.cache.Store(provider, models)
+	}
+
 	return models, nil
 }
 
+// FetchPricing fetches live prices, keyed by model ID, from a provider whose
+// client implements PricingFetcher. It returns an error wrapping
+// errors.ErrNotImplemented for other providers.
+func (pf *ProviderFetcher) FetchPricing(ctx context.Context, provider *catalogs.Provider, opts ...ProviderOption) (map[string]*catalogs.ModelPricing, error) {
+	options := pf.options.clone().apply(opts...)
+	ctx, cancel, err := prepareProviderOperation(ctx, provider, options)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	defer cancel()
+
+	if options.clientFactory == nil {
+		return nil, &errors.ConfigError{
+			Component: string(provider.ID),
+			Message:   "provider client factory is not configured",
+		}
+	}
+	client, err := options.clientFactory(provider)
+	if err != nil {
+		return nil, errors.WrapResource("get", "client", string(provider.ID), err)
+	}
+	fetcher, ok := pricingFetcher(client)
+	if !ok {
+		return nil, errors.WrapResource("fetch", "pricing", string(provider.ID), errors.ErrNotImplemented)
+	}
+	prices, err := fetcher.FetchPricing(ctx)
+	if err != nil {
+		return nil, &errors.SyncError{
+			Provider: string(provider.ID),
+			Err:      err,
+		}
+	}
+	return prices, nil
+}
+
+// pricingFetcher returns the PricingFetcher beneath any client middleware.
+func pricingFetcher(client ProviderClient) (PricingFetcher, bool) {
+	fetcher, ok := clients.Unwrap(client).(PricingFetcher)
+	return fetcher, ok
+}
+
+// applyLivePricing prices the models listed without prices from the client's
+// live pricing, when it has any. A failed pricing fetch leaves them unpriced.
+func applyLivePricing(ctx context.Context, client ProviderClient, models []catalogs.Model) {
+	fetcher, ok := pricingFetcher(client)
+	if !ok || !slices.ContainsFunc(models, func(model catalogs.Model) bool { return model.Pricing == nil }) {
+		return
+	}
+	prices, err := fetcher.FetchPricing(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Debug().Err(err).Msg("Live pricing unavailable; keeping listed models unpriced")
+		return
+	}
+	for i := range models {
+		if models[i].Pricing == nil {
+			models[i].Pricing = prices[models[i].ID]
+		}
+	}
+}
+
 // FetchRawResponse fetches the raw API response from a provider's endpoint.
 // This is useful for testing, debugging, or saving raw responses as testdata.
 //
@@ -406,6 +531,7 @@ func (pf *ProviderFetcher) FetchRawResponse(ctx context.Context, provider *catal
 		AuthMethod:   authMethod,
 		AuthLocation: authLocation,
 		AuthScheme:   authScheme,
+		Cache:        result.Response.Header.Get(transport.CacheStatusHeader),
 	}
 
 	return result.Data, stats, nil
@@ -426,6 +552,12 @@ func prepareProviderOperation(
 	if options.timeout > 0 {
 		ctx, cancel = context.WithTimeout(ctx, options.timeout)
 	}
+	if cache := options.httpCache; cache != nil {
+		if options.fresh {
+			cache = cache.Revalidating()
+		}
+		ctx = transport.WithHTTPCache(ctx, cache)
+	}
 	if options.loadCredentials {
 		provider.LoadAPIKey()
 		provider.LoadEnvVars()
@@ -243,3 +243,60 @@ func providerForFetcherTest(id catalogs.ProviderID) catalogs.Provider {
 		},
 	}
 }
+
+type pricingFetcherTestClient struct {
+	providerFetcherTestClient
+	prices map[string]*catalogs.ModelPricing
+	calls  *int
+}
+
+func (c pricingFetcherTestClient) FetchPricing(context.Context) (map[string]*catalogs.ModelPricing, error) {
+	*c.calls++
+	return c.prices, nil
+}
+
+func TestProviderFetcherFetchModelsPricesUnpricedModelsFromLivePricing(t *testing.T) {
+	provider := providerForFetcherTest("provider-a")
+	listed := &catalogs.ModelPricing{Currency: catalogs.ModelPricingCurrencyUSD}
+	live := &catalogs.ModelPricing{Currency: catalogs.ModelPricingCurrencyUSD, Tokens: &catalogs.ModelTokenPricing{Input: &catalogs.ModelTokenCost{Per1M: 1}}}
+	calls := 0
+	fetcher := NewProviderFetcher(newFetcherProviderSet(provider),
+		WithProviderClientFactory(func(*catalogs.Provider) (ProviderClient, error) {
+			return pricingFetcherTestClient{
+				providerFetcherTestClient: providerFetcherTestClient{models: []catalogs.Model{
+					{ID: "priced", Pricing: listed},
+					{ID: "unpriced"},
+				}},
+				prices: map[string]*catalogs.ModelPricing{"priced": live, "unpriced": live},
+				calls:  &calls,
+			}, nil
+		}),
+	)
+
+	models, err := fetcher.FetchModels(context.Background(), &provider)
+	if err != nil {
+		t.Fatalf("FetchModels failed: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("FetchPricing called %d times, want 1", calls)
+	}
+	if models[0].Pricing != listed {
+		t.Errorf("listed pricing replaced: %#v", models[0].Pricing)
+	}
+	if models[1].Pricing != live {
+		t.Errorf("unpriced model pricing = %#v, want the live pricing", models[1].Pricing)
+	}
+}
+
+func TestProviderFetcherFetchPricingRequiresPricingFetcher(t *testing.T) {
+	provider := providerForFetcherTest("provider-a")
+	fetcher := NewProviderFetcher(newFetcherProviderSet(provider),
+		WithProviderClientFactory(func(*catalogs.Provider) (ProviderClient, error) {
+			return providerFetcherTestClient{}, nil
+		}),
+	)
+
+	if _, err := fetcher.FetchPricing(context.Background(), &provider); !stderrors.Is(err, pkgerrors.ErrNotImplemented) {
+		t.Fatalf("FetchPricing error = %v, want ErrNotImplemented", err)
+	}
+}
@@ -108,6 +108,8 @@ const (
 	ModelsDevGitID  = catalogmeta.ModelsDevGitID
 	ModelsDevHTTPID = catalogmeta.ModelsDevHTTPID
 	LocalCatalogID  = catalogmeta.LocalCatalogID
+	RemoteCatalogID = catalogmeta.RemoteCatalogID
+	BenchmarksID    = catalogmeta.BenchmarksID
 )
 
 // IDs returns all available source identifiers.
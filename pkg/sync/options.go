@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
@@ -12,7 +13,9 @@ import (
 
 	"github.com/agentstation/starmap/pkg/catalogs"
 	"github.com/agentstation/starmap/pkg/constants"
+	"github.com/agentstation/starmap/pkg/differ"
 	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/reconciler"
 	"github.com/agentstation/starmap/pkg/sources"
 )
 
@@ -27,7 +30,8 @@ type Options struct {
 	ProviderID *catalogs.ProviderID // Filter for specific provider
 
 	// Output control (used AFTER merging)
-	OutputPath string // Where to save final catalog (empty means default location)
+	OutputPath  string // Where to save final catalog (empty means default location)
+	SandboxPath string // Write the merged catalog here instead of applying it (implies DryRun)
 
 	// Source behavior control
 	Fresh              bool   // Delete existing models and fetch fresh from APIs (destructive)
@@ -35,6 +39,24 @@ type Options struct {
 	Reformat           bool   // Reformat providers.yaml file even without changes
 	SourcesDir         string // Directory for external source data (models.dev cache/git)
 	ModelsDevGitCommit string // Exact models.dev commit required by Git verification
+	WatchPolicies      bool   // Hash provider privacy policy and terms of service pages and report changes
+	ScrapeDocs         bool   // Fill pricing and limits missing from provider APIs from provider documentation pages
+	ReviewNewModels    bool   // Hold newly discovered models in pending-review until approved
+	MatchModels        bool   // Link the same model served by several providers to one definition
+	BenchmarksURL      string // Benchmark dataset URL or file path; empty disables the benchmarks source
+
+	// Reconciliation
+	Strategy  string       // Registered reconciliation strategy name (empty means field-authority)
+	DiffRules differ.Rules // Tolerance and ignore rules for change detection
+
+	// ConflictResolver settles fields on which sources disagree and no field
+	// authority decides. It is nil unless the caller asks to resolve
+	// conflicts, which keeps the strategy's pick.
+	ConflictResolver reconciler.ConflictResolver
+
+	// Federation
+	RemoteCatalogURL    string // Versioned API root of a Starmap server to federate as a source
+	RemoteCatalogAPIKey string // Bearer token for the federated server
 
 	// Dependency control
 	AutoInstallDeps   bool // Automatically install missing dependencies without prompting
@@ -103,6 +125,10 @@ func (s *Options) Validate(providers catalogs.ProvidersReader) error {
 		}
 	}
 
+	if err := s.DiffRules.Validate(); err != nil {
+		return err
+	}
+
 	if s.AutoInstallDeps && s.SkipDepPrompts {
 		return &errors.ValidationError{
 			Field:   "DependencyPolicy",
@@ -146,6 +172,16 @@ func (s *Options) Validate(providers catalogs.ProvidersReader) error {
 			}
 		}
 	}
+	if err := s.validateRemoteCatalog(); err != nil {
+		return err
+	}
+	if s.BenchmarksURL == "" && slices.Contains(s.Sources, sources.BenchmarksID) {
+		return &errors.ValidationError{
+			Field:   "BenchmarksURL",
+			Value:   "",
+			Message: "is required when benchmarks is a selected source",
+		}
+	}
 	if slices.Contains(s.Sources, sources.ModelsDevHTTPID) && slices.Contains(s.Sources, sources.ModelsDevGitID) {
 		return &errors.ValidationError{
 			Field:   "Sources",
@@ -183,6 +219,46 @@ func (s *Options) Validate(providers catalogs.ProvidersReader) error {
 		}
 	}
 
+	if err := s.validateSandbox(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSandbox checks that a sandboxed sync is a dry run.
+func (s *Options) validateSandbox() error {
+	if s.SandboxPath != "" && !s.DryRun {
+		return &errors.ValidationError{
+			Field:   "SandboxPath",
+			Value:   s.SandboxPath,
+			Message: "a sandboxed sync must not apply changes",
+		}
+	}
+	return nil
+}
+
+// validateRemoteCatalog checks that a federated remote is configured with an
+// absolute HTTP(S) URL and that selecting the remote source names one.
+func (s *Options) validateRemoteCatalog() error {
+	if s.RemoteCatalogURL == "" {
+		if slices.Contains(s.Sources, sources.RemoteCatalogID) {
+			return &errors.ValidationError{
+				Field:   "RemoteCatalogURL",
+				Value:   "",
+				Message: "is required when remote_catalog is a selected source",
+			}
+		}
+		return nil
+	}
+	parsed, err := url.Parse(s.RemoteCatalogURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &errors.ValidationError{
+			Field:   "RemoteCatalogURL",
+			Value:   s.RemoteCatalogURL,
+			Message: "must be an absolute HTTP(S) versioned API URL",
+		}
+	}
 	return nil
 }
 
@@ -238,6 +314,15 @@ func WithOutputPath(path string) Option {
 	}
 }
 
+// WithSandbox writes the fully merged catalog to path instead of applying it,
+// leaving the catalog store and output path untouched. It implies dry run.
+func WithSandbox(path string) Option {
+	return func(opts *Options) {
+		opts.SandboxPath = path
+		opts.DryRun = true
+	}
+}
+
 // WithFresh configures whether to delete existing models and fetch fresh from APIs.
 func WithFresh(fresh bool) Option {
 	return func(opts *Options) {
@@ -281,6 +366,84 @@ func isExactGitCommit(commit string) bool {
 	return err == nil
 }
 
+// WithPolicyWatch configures whether to fetch provider privacy policy and
+// terms of service URLs and report content changes in the changeset.
+func WithPolicyWatch(watch bool) Option {
+	return func(opts *Options) {
+		opts.WatchPolicies = watch
+	}
+}
+
+// WithDocsScrape fills pricing and limits that provider APIs leave empty from
+// the providers' documentation pages, for providers with an extractor config.
+func WithDocsScrape(scrape bool) Option {
+	return func(opts *Options) {
+		opts.ScrapeDocs = scrape
+	}
+}
+
+// WithReviewNewModels holds models that the sync discovers for the first time
+// in the pending-review state, so they are not served by default until a
+// reviewer approves them.
+func WithReviewNewModels(review bool) Option {
+	return func(opts *Options) {
+		opts.ReviewNewModels = review
+	}
+}
+
+// WithModelMatching links provider models that are the same underlying model,
+// such as Llama 3.1 70B on Groq and Bedrock, to one shared definition. See
+// reconciler.MatchModels for the matching rules.
+func WithModelMatching(match bool) Option {
+	return func(opts *Options) {
+		opts.MatchModels = match
+	}
+}
+
+// WithBenchmarks ingests MMLU, Arena Elo, and HumanEval scores from the
+// benchmark dataset at location, an HTTP(S) URL or a file path. Scores are
+// attached to every provider model the dataset names by model or definition
+// ID.
+func WithBenchmarks(location string) Option {
+	return func(opts *Options) {
+		opts.BenchmarksURL = location
+	}
+}
+
+// WithStrategy selects the reconciliation strategy registered under name with
+// reconciler.RegisterStrategy. An empty name uses field-authority.
+func WithStrategy(name string) Option {
+	return func(opts *Options) {
+		opts.Strategy = name
+	}
+}
+
+// WithConflictResolver settles conflicts no field authority decides, for
+// example with reconciler.NewChoiceResolver or an interactive prompt.
+func WithConflictResolver(resolver reconciler.ConflictResolver) Option {
+	return func(opts *Options) {
+		opts.ConflictResolver = resolver
+	}
+}
+
+// WithDiffRules sets the tolerance and ignore rules that keep cosmetic
+// source variations out of the changeset.
+func WithDiffRules(rules differ.Rules) Option {
+	return func(opts *Options) {
+		opts.DiffRules = rules
+	}
+}
+
+// WithRemoteCatalog federates the Starmap server at baseURL as an additional
+// source. baseURL is the server's versioned API root. A non-empty apiKey is
+// sent as a bearer token.
+func WithRemoteCatalog(baseURL, apiKey string) Option {
+	return func(opts *Options) {
+		opts.RemoteCatalogURL = baseURL
+		opts.RemoteCatalogAPIKey = apiKey
+	}
+}
+
 // WithAutoInstallDeps configures whether to automatically install missing dependencies.
 func WithAutoInstallDeps(autoInstall bool) Option {
 	return func(opts *Options) {
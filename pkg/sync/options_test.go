@@ -64,6 +64,43 @@ func TestOptionsValidateRequiresPinnedModelsDevGitCommit(t *testing.T) {
 	}
 }
 
+func TestOptionsValidateRemoteCatalog(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{name: "federated remote", opts: []Option{WithSources(sources.LocalCatalogID, sources.RemoteCatalogID), WithRemoteCatalog("https://starmap.example.com/api/v1", "")}},
+		{name: "remote source without URL", opts: []Option{WithSources(sources.RemoteCatalogID)}, wantErr: true},
+		{name: "relative URL", opts: []Option{WithRemoteCatalog("starmap.example.com/api/v1", "")}, wantErr: true},
+		{name: "unsupported scheme", opts: []Option{WithRemoteCatalog("ftp://starmap.example.com/api/v1", "")}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Defaults().Apply(test.opts...).Validate(catalogs.NewProviders())
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Validate error = %v, wantErr %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithSandboxImpliesDryRun(t *testing.T) {
+	opts := Defaults().Apply(WithSandbox("sandbox"))
+	if !opts.DryRun || opts.SandboxPath != "sandbox" {
+		t.Fatalf("options = %+v, want dry-run sandbox", opts)
+	}
+	if err := opts.Validate(catalogs.NewProviders()); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	applying := Defaults().Apply(WithSandbox("sandbox"), WithDryRun(false))
+	var validationErr *errors.ValidationError
+	if err := applying.Validate(catalogs.NewProviders()); !stderrors.As(err, &validationErr) {
+		t.Fatalf("Validate = %v, want *errors.ValidationError for an applying sandbox", err)
+	}
+}
+
 func TestWithSourcesCopiesSelection(t *testing.T) {
 	selected := []sources.ID{sources.ProvidersID}
 	opts := Defaults().Apply(WithSources(selected...))
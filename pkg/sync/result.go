@@ -39,12 +39,16 @@ type Result struct {
 	TotalChanges     int                                     // Total number of changes across all providers
 	ProvidersChanged int                                     // Number of providers with changes
 	ProviderResults  map[catalogs.ProviderID]*ProviderResult // Results per provider
+	PolicyChanges    []differ.PolicyChange                   // Provider policy documents whose content changed (advisory)
+	PinnedChanges    []differ.PinnedChange                   // Incoming changes blocked by field pins (advisory)
+	Changeset        *differ.Changeset                       // Full changeset the result summarizes
 
 	// Operation metadata
-	DryRun    bool   // Whether this was a dry run
-	Fresh     bool   // Whether this was a fresh sync
-	OutputDir string // Where files were written (empty means default)
-	Sources   []sources.ID
+	DryRun     bool   // Whether this was a dry run
+	Fresh      bool   // Whether this was a fresh sync
+	OutputDir  string // Where files were written (empty means default)
+	SandboxDir string // Where a sandboxed sync wrote the merged catalog
+	Sources    []sources.ID
 	// SourceObservations contains caller-owned freshness/audit projections from
 	// every source used by this attempt, including no-change synchronizations.
 	SourceObservations []catalogs.SourceObservationLink
@@ -136,6 +140,9 @@ func ChangesetToResultWithProvenance(changeset *differ.Changeset, dryRun bool, o
 		OutputDir:       outputDir,
 		Sources:         append([]sources.ID(nil), activeSources...),
 		ProviderResults: make(map[catalogs.ProviderID]*ProviderResult),
+		PolicyChanges:   changeset.Policies,
+		PinnedChanges:   changeset.Pinned,
+		Changeset:       changeset,
 	}
 
 	// Group models by provider for the provider results
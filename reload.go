@@ -0,0 +1,46 @@
+package starmap
+
+import (
+	"context"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+	"github.com/agentstation/starmap/pkg/sources"
+)
+
+// ReloadFromPath loads the catalog files in dir, such as a checkout of the
+// embedded catalog being edited by hand, and publishes them as a new
+// generation attributed to the local catalog. Catalog hooks fire as for any
+// publication. When the files do not load, for example while an editor is
+// halfway through a save, the error is returned and the current generation
+// stays published.
+func (c *Client) ReloadFromPath(ctx context.Context, dir string) error {
+	if err := c.requireWritableCatalogStore(); err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	builder, err := catalogs.NewFromPath(dir)
+	if err != nil {
+		return errors.WrapResource("load", "catalog", dir, err)
+	}
+	published, err := snapshotBuilder(builder)
+	if err != nil {
+		return err
+	}
+
+	release, err := c.updates.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	observation, err := c.catalogObservation(sources.LocalCatalogID, published, sources.Revision{Kind: sources.RevisionKindContentDigest})
+	if err != nil {
+		return err
+	}
+	_, err = c.commitAndPublish(ctx, published, []sources.Observation{observation})
+	return err
+}
@@ -0,0 +1,69 @@
+package starmap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	"github.com/agentstation/starmap/pkg/save"
+)
+
+func TestReloadFromPathPublishesCatalogFiles(t *testing.T) {
+	dir := t.TempDir()
+	seed := catalogs.NewEmpty()
+	if err := seed.SetProvider(catalogs.Provider{
+		ID:     "local",
+		Name:   "Local",
+		Models: map[string]*catalogs.Model{"local-model": {ID: "local-model", Name: "Local Model"}},
+	}); err != nil {
+		t.Fatalf("SetProvider: %v", err)
+	}
+	if err := seed.Save(save.WithPath(dir)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	client, err := New(WithCatalogStore(catalogstore.NewMemory()))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	published := make(chan CatalogPublishedEvent, 1)
+	client.OnCatalogPublished(func(event CatalogPublishedEvent) error {
+		published <- event
+		return nil
+	})
+
+	ctx := context.Background()
+	if err := client.ReloadFromPath(ctx, dir); err != nil {
+		t.Fatalf("ReloadFromPath: %v", err)
+	}
+	select {
+	case event := <-published:
+		if event.GenerationID != client.CurrentGenerationID() {
+			t.Fatalf("published generation %q, current %q", event.GenerationID, client.CurrentGenerationID())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload did not fire the catalog published hook")
+	}
+	if _, err := client.Catalog().ProviderModel("local", "local-model"); err != nil {
+		t.Fatalf("reloaded model missing: %v", err)
+	}
+
+	// A half-written file fails to load and leaves the reloaded generation in place.
+	generation := client.CurrentGenerationID()
+	if err := os.WriteFile(filepath.Join(dir, "providers.yaml"), []byte("- id: local\n  name: [unterminated\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := client.ReloadFromPath(ctx, dir); err == nil {
+		t.Fatal("ReloadFromPath accepted malformed catalog files")
+	}
+	if client.CurrentGenerationID() != generation {
+		t.Fatal("failed reload replaced the published generation")
+	}
+	if _, err := client.Catalog().ProviderModel("local", "local-model"); err != nil {
+		t.Fatalf("model missing after failed reload: %v", err)
+	}
+}
@@ -135,3 +135,35 @@ func incompatibleRemoteGeneration(t *testing.T, generation catalogstore.Generati
 	}
 	return incompatible
 }
+
+func TestActivateGenerationPublishesOnceAndRejectsIncompatible(t *testing.T) {
+	generation := rootRemoteGeneration(t)
+	store := catalogstore.NewMemory()
+	client, err := New(WithCatalogStore(store))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := client.ActivateGeneration(context.Background(), incompatibleRemoteGeneration(t, generation)); err == nil {
+		t.Fatal("ActivateGeneration accepted an incompatible generation")
+	}
+	if _, err := store.Current(context.Background()); !pkgerrors.IsNotFound(err) {
+		t.Fatalf("incompatible generation reached durable store: %v", err)
+	}
+
+	if err := client.ActivateGeneration(context.Background(), generation); err != nil {
+		t.Fatalf("ActivateGeneration: %v", err)
+	}
+	if _, err := client.Catalog().Provider("remote-root"); err != nil {
+		t.Fatalf("activated catalog was not published: %v", err)
+	}
+	first := client.CurrentCatalogState()
+	if first.GenerationID != generation.Manifest.GenerationID {
+		t.Fatalf("generation = %q, want %q", first.GenerationID, generation.Manifest.GenerationID)
+	}
+	if err := client.ActivateGeneration(context.Background(), generation); err != nil {
+		t.Fatalf("ActivateGeneration retry: %v", err)
+	}
+	if state := client.CurrentCatalogState(); state.Sequence != first.Sequence {
+		t.Fatalf("retry republished generation: sequence %d, want %d", state.Sequence, first.Sequence)
+	}
+}
@@ -0,0 +1,49 @@
+package starmap
+
+import (
+	"context"
+	"strings"
+
+	"github.com/agentstation/utc"
+
+	"github.com/agentstation/starmap/pkg/catalogmeta"
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/errors"
+)
+
+const reviewSourceID = catalogmeta.SourceID("review")
+
+// ReviewModel records a reviewer's decision on every offering of modelID, or
+// only on providerID's offering when providerID is set, and publishes the
+// result as a new generation. decision must be approved or rejected; the
+// transition is appended to each offering's review audit log with reviewer
+// and note. It returns the resulting review of the last offering changed.
+func (c *Client) ReviewModel(ctx context.Context, modelID string, providerID catalogs.ProviderID, decision catalogs.ModelReviewState, reviewer, note string) (*catalogs.ModelReview, error) {
+	if decision != catalogs.ModelReviewApproved && decision != catalogs.ModelReviewRejected {
+		return nil, &errors.ValidationError{Field: "decision", Value: decision, Message: "must be approved or rejected"}
+	}
+	reviewer = strings.TrimSpace(reviewer)
+	if reviewer == "" {
+		return nil, &errors.ValidationError{Field: "reviewer", Message: "is required"}
+	}
+
+	entry := catalogs.ModelReviewEntry{
+		State:    decision,
+		Reviewer: reviewer,
+		At:       utc.Now(),
+		Note:     strings.TrimSpace(note),
+	}
+	var review *catalogs.ModelReview
+	err := c.editModel(ctx, reviewSourceID, modelID, providerID, func(model *catalogs.Model) bool {
+		if model.Review == nil {
+			model.Review = &catalogs.ModelReview{}
+		}
+		model.Review.Record(entry)
+		review = model.Review
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
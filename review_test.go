@@ -0,0 +1,75 @@
+package starmap
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/agentstation/starmap/pkg/catalogs"
+	"github.com/agentstation/starmap/pkg/catalogstore"
+	pkgerrors "github.com/agentstation/starmap/pkg/errors"
+)
+
+func TestReviewModelRecordsReviewerInAuditLog(t *testing.T) {
+	client, err := New(
+		WithCatalogStore(catalogstore.NewMemory()),
+		WithUpdateFunc(func(_ context.Context, candidate *catalogs.Builder) (*catalogs.Builder, error) {
+			held := &catalogs.ModelReview{}
+			held.Record(catalogs.ModelReviewEntry{State: catalogs.ModelReviewPending, Reviewer: catalogs.ModelReviewSystemReviewer})
+			if err := candidate.SetProvider(catalogs.Provider{
+				ID:     "held",
+				Name:   "Held",
+				Models: map[string]*catalogs.Model{"held-model": {ID: "held-model", Name: "Held", Review: held}},
+			}); err != nil {
+				return nil, err
+			}
+			return candidate, nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+	if err := client.Update(ctx); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	review, err := client.ReviewModel(ctx, "held-model", "held", catalogs.ModelReviewApproved, " alice ", "passed evals")
+	if err != nil {
+		t.Fatalf("ReviewModel: %v", err)
+	}
+	if review.State != catalogs.ModelReviewApproved || len(review.Log) != 2 {
+		t.Fatalf("review = %#v", review)
+	}
+	if last := review.Log[1]; last.Reviewer != "alice" || last.Note != "passed evals" || last.At.IsZero() {
+		t.Fatalf("audit entry = %#v", last)
+	}
+
+	model, err := client.Catalog().ProviderModel("held", "held-model")
+	if err != nil {
+		t.Fatalf("ProviderModel: %v", err)
+	}
+	if !model.ReviewCleared() || model.Review.Log[0].State != catalogs.ModelReviewPending {
+		t.Fatalf("published review = %#v", model.Review)
+	}
+}
+
+func TestReviewModelRejectsInvalidDecisions(t *testing.T) {
+	client, err := New(WithCatalogStore(catalogstore.NewMemory()))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := context.Background()
+
+	var validation *pkgerrors.ValidationError
+	if _, err := client.ReviewModel(ctx, "any-model", "", catalogs.ModelReviewPending, "alice", ""); !stderrors.As(err, &validation) {
+		t.Fatalf("pending decision error = %v, want ValidationError", err)
+	}
+	if _, err := client.ReviewModel(ctx, "any-model", "", catalogs.ModelReviewApproved, " ", ""); !stderrors.As(err, &validation) {
+		t.Fatalf("missing reviewer error = %v, want ValidationError", err)
+	}
+	var notFound *pkgerrors.NotFoundError
+	if _, err := client.ReviewModel(ctx, "missing-model", "", catalogs.ModelReviewRejected, "alice", ""); !stderrors.As(err, &notFound) {
+		t.Fatalf("missing model error = %v, want NotFoundError", err)
+	}
+}
@@ -91,6 +91,9 @@ run go build -o "$TMPDIR/starmap" ./cmd/starmap
 run "$TMPDIR/starmap" version
 run env CATALOG_PATH="$VERIFY_CATALOG_DATABASE_PATH" CATALOG_EXPORT_PATH="$VERIFY_CATALOG_PATH" \
 	"$TMPDIR/starmap" validate catalog
+run "$TMPDIR/starmap" contribute check --catalog-dir "$VERIFY_CATALOG_PATH" --output table
+run "$TMPDIR/starmap" fmt --check "$VERIFY_CATALOG_PATH"
+run "$TMPDIR/starmap" docs verify --output table
 printf '\n==> isolated credential-free provider listing\n'
 (
 	cd "$TMPDIR"
@@ -98,6 +101,7 @@ printf '\n==> isolated credential-free provider listing\n'
 	-u ALIBABA_MODEL_STUDIO_API_KEY \
 	-u ANTHROPIC_API_KEY \
 	-u CEREBRAS_API_KEY \
+	-u COHERE_API_KEY \
 	-u DASHSCOPE_API_KEY \
 	-u DEEPINFRA_TOKEN \
 	-u DEEPSEEK_API_KEY \
@@ -106,6 +110,9 @@ printf '\n==> isolated credential-free provider listing\n'
 	-u GROQ_API_KEY \
 	-u MOONSHOT_API_KEY \
 	-u OPENAI_API_KEY \
+	-u PERPLEXITY_API_KEY \
+	-u REPLICATE_API_TOKEN \
+	-u TOGETHER_API_KEY \
 	CATALOG_PATH="$VERIFY_CATALOG_DATABASE_PATH" \
 	CATALOG_EXPORT_PATH="$VERIFY_CATALOG_PATH" \
 	"$TMPDIR/starmap" providers
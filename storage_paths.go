@@ -39,6 +39,36 @@ func validateCatalogPathSeparation(store any, exportPath string) error {
 	return nil
 }
 
+// validateSandboxPath checks that a sync sandbox overlaps neither the
+// editable catalog export nor the durable catalog database it previews.
+func validateSandboxPath(store any, exportPath, sandboxPath string) error {
+	resolvedSandbox, err := resolvedFilesystemPath(sandboxPath)
+	if err != nil {
+		return err
+	}
+	roots := []string{exportPath}
+	if filesystemStore, ok := store.(filesystemCatalogStore); ok {
+		roots = append(roots, filesystemStore.Root())
+	}
+	for _, root := range roots {
+		if strings.TrimSpace(root) == "" {
+			continue
+		}
+		resolvedRoot, err := resolvedFilesystemPath(root)
+		if err != nil {
+			return err
+		}
+		if pathsContainEachOther(resolvedRoot, resolvedSandbox) {
+			return &errors.ValidationError{
+				Field:   "sandbox",
+				Value:   sandboxPath,
+				Message: fmt.Sprintf("overlaps catalog directory %q; choose a separate sandbox directory", resolvedRoot),
+			}
+		}
+	}
+	return nil
+}
+
 func resolvedFilesystemPath(path string) (string, error) {
 	absolute, err := filepath.Abs(path)
 	if err != nil {
@@ -119,3 +119,29 @@ func assertCatalogLayoutError(t *testing.T, err error) {
 		t.Fatalf("component = %q", configError.Component)
 	}
 }
+
+func TestValidateSandboxPathRejectsCatalogOverlap(t *testing.T) {
+	root := t.TempDir()
+	store, err := catalogstore.NewFilesystem(filepath.Join(root, "catalog"))
+	if err != nil {
+		t.Fatalf("NewFilesystem: %v", err)
+	}
+	export := filepath.Join(root, "exports", "catalog")
+
+	for name, sandbox := range map[string]string{
+		"export":          export,
+		"inside export":   filepath.Join(export, "sandbox"),
+		"database":        filepath.Join(root, "catalog"),
+		"parent of roots": root,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var validationErr *starmaperrors.ValidationError
+			if err := validateSandboxPath(store, export, sandbox); !stderrors.As(err, &validationErr) {
+				t.Fatalf("validateSandboxPath(%s) = %v, want *errors.ValidationError", sandbox, err)
+			}
+		})
+	}
+	if err := validateSandboxPath(store, export, filepath.Join(root, "sandbox")); err != nil {
+		t.Fatalf("separate sandbox rejected: %v", err)
+	}
+}
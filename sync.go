@@ -16,6 +16,9 @@ import (
 
 // Sync synchronizes the catalog with provider APIs using staged source execution.
 func (c *Client) Sync(ctx context.Context, opts ...sync.Option) (*sync.Result, error) {
+	if err := c.requireOnline(); err != nil {
+		return nil, err
+	}
 	options := sync.Defaults().Apply(opts...)
 	outputPath := options.OutputPath
 	if c.options != nil && outputPath == "" && c.options.catalogExportPath != "" && !c.options.embeddedCatalogEnabled {
@@ -26,6 +29,15 @@ func (c *Client) Sync(ctx context.Context, opts ...sync.Option) (*sync.Result, e
 			return nil, err
 		}
 	}
+	if options.SandboxPath != "" {
+		var store any
+		if c.options != nil {
+			store = c.options.catalogStore
+		}
+		if err := validateSandboxPath(store, outputPath, options.SandboxPath); err != nil {
+			return nil, err
+		}
+	}
 	if !options.DryRun {
 		if err := c.requireWritableCatalogStore(); err != nil {
 			return nil, err
@@ -38,7 +50,17 @@ func (c *Client) Sync(ctx context.Context, opts ...sync.Option) (*sync.Result, e
 	}
 	defer release()
 
-	effective := append([]sync.Option(nil), opts...)
+	var effective []sync.Option
+	if c.options != nil && c.options.reviewNewModels {
+		effective = append(effective, sync.WithReviewNewModels(true))
+	}
+	if c.options != nil && c.options.reconciliationStrategy != "" {
+		effective = append(effective, sync.WithStrategy(c.options.reconciliationStrategy))
+	}
+	if c.options != nil && !c.options.diffRules.IsZero() {
+		effective = append(effective, sync.WithDiffRules(c.options.diffRules))
+	}
+	effective = append(effective, opts...)
 	if options.OutputPath == "" && c.options.catalogExportPath != "" && !c.options.embeddedCatalogEnabled {
 		effective = append(effective, sync.WithOutputPath(c.options.catalogExportPath))
 	}
@@ -16,6 +16,9 @@ import (
 
 // Update manually triggers a catalog update.
 func (c *Client) Update(ctx context.Context) error {
+	if err := c.requireOnline(); err != nil {
+		return err
+	}
 	if err := c.requireWritableCatalogStore(); err != nil {
 		return err
 	}